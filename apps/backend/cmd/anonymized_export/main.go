@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	tenant := flags.String("tenant", uuid.Nil.String(), "Tenant ID to operate on")
+	output := flags.String("output", "", "File to write the export to (default: stdout)")
+	keyFile := flags.String("key-file", "", "File holding the export key")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		log.Fatalf("Invalid tenant ID: %v", err)
+	}
+
+	// Sealed findings need the master key to be read
+	if os.Getenv("ENCRYPTION_KEY") != "" {
+		enc, err := encryption.NewEncryptionService()
+		if err != nil {
+			log.Fatalf("Failed to initialize encryption: %v", err)
+		}
+		persistence.ConfigureFindingEncryption(enc)
+	}
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	exportService := service.NewAnonymizedExportService(persistence.NewPostgresRepository(db))
+
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+
+	switch command {
+	case "export":
+		key := loadKey(*keyFile)
+
+		var w io.Writer = os.Stdout
+		if *output != "" {
+			f, err := os.Create(*output)
+			if err != nil {
+				log.Fatalf("Failed to create output file: %v", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		log.Printf("Exporting anonymized findings of tenant %s...", tenantID)
+		result, err := exportService.Export(ctx, w, key)
+		if err != nil {
+			if *output != "" {
+				os.Remove(*output)
+			}
+			log.Fatalf("Export failed: %v", err)
+		}
+		log.Printf("Exported %d findings; %d original values verified absent", result.Findings, result.ValuesChecked)
+		printJSON(os.Stderr, result)
+
+	case "verify":
+		if flags.NArg() < 1 {
+			log.Fatal("verify requires an export file")
+		}
+		f, err := os.Open(flags.Arg(0))
+		if err != nil {
+			log.Fatalf("Failed to open export: %v", err)
+		}
+		defer f.Close()
+
+		verification, err := exportService.Verify(ctx, f)
+		if err != nil {
+			log.Fatalf("Verification failed: %v", err)
+		}
+		printJSON(os.Stdout, verification)
+		if !verification.Passed {
+			log.Fatalf("Export holds original values on %d lines", len(verification.LeakingLines))
+		}
+		log.Printf("No original values found in %d lines", verification.Lines)
+
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+// loadKey reads the export key, or creates a one-off key whose exports cannot be
+// compared with any other
+func loadKey(keyFile string) []byte {
+	if keyFile == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			log.Fatalf("Failed to create export key: %v", err)
+		}
+		log.Println("No --key-file given; using a one-off key")
+		return key
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		log.Fatalf("Failed to read export key: %v", err)
+	}
+	key := []byte(strings.TrimSpace(string(data)))
+	if len(key) < 16 {
+		log.Fatal("Export key must be at least 16 bytes")
+	}
+	return key
+}
+
+func printJSON(w io.Writer, v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Fprintln(w, string(out))
+}
+
+func printUsage() {
+	fmt.Println("Usage: anonymized_export [command] [flags]")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	fmt.Println("  export                  - Write findings and classifications as anonymized JSON lines")
+	fmt.Println("  verify FILE             - Check an export for original values; exits non-zero on a leak")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --tenant ID             - Tenant to operate on (default: default tenant)")
+	fmt.Println("  --output FILE           - Export file (default: stdout)")
+	fmt.Println("  --key-file FILE         - Export key; exports with the same key can be compared (default: one-off key)")
+	fmt.Println("")
+	fmt.Println("Environment variables:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME - Postgres connection")
+	fmt.Println("  ENCRYPTION_KEY            - Master key, needed when findings are encrypted at rest")
+}
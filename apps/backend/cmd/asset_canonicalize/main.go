@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	lineageService "github.com/arc-platform/backend/modules/lineage/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/audit"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/canonical"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	flags := flag.NewFlagSet("asset_canonicalize", flag.ExitOnError)
+	flags.Usage = printUsage
+	tenant := flags.String("tenant", uuid.Nil.String(), "Tenant ID to operate on")
+	dryRun := flags.Bool("dry-run", false, "List merges and re-keys without applying them")
+	skipLineage := flags.Bool("skip-lineage", false, "Do not update Neo4j")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		log.Fatalf("Invalid tenant ID: %v", err)
+	}
+
+	cfg := config.LoadConfig()
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	repo := persistence.NewPostgresRepository(db)
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+
+	var lineageSync interfaces.LineageSync = &interfaces.NoOpLineageSync{}
+	if !*skipLineage && !*dryRun {
+		neo4jRepo, err := persistence.NewNeo4jRepository(
+			getEnv("NEO4J_URI", "bolt://127.0.0.1:7687"),
+			getEnv("NEO4J_USERNAME", "neo4j"),
+			getEnv("NEO4J_PASSWORD", "password123"),
+		)
+		if err != nil {
+			log.Printf("⚠️  Neo4j unavailable, the lineage graph will not be updated: %v", err)
+		} else {
+			defer neo4jRepo.Close(context.Background())
+			lineageSync = lineageService.NewSemanticLineageService(neo4jRepo, repo, service.NewFindingsService(repo))
+		}
+	}
+
+	policies := canonical.NewPolicies(cfg.AssetPaths.CaseSensitiveSources, cfg.AssetPaths.KeepTrailingSlash)
+	mergeService := service.NewAssetMergeService(repo, lineageSync, audit.NewPostgresAuditLogger(repo))
+	canonicalizeService := service.NewAssetCanonicalizeService(repo, mergeService, policies)
+
+	log.Printf("Canonicalizing asset paths (dry run: %v)...", *dryRun)
+	result, err := canonicalizeService.Canonicalize(ctx, *dryRun)
+	if err != nil {
+		log.Fatalf("Canonicalization failed: %v", err)
+	}
+	printJSON(result)
+	log.Printf("Scanned %d assets: %d duplicate groups merged, %d assets re-keyed, %d failures",
+		result.Scanned, len(result.Merges), len(result.Rekeys), len(result.Failures))
+	if len(result.Failures) > 0 {
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+func printUsage() {
+	fmt.Println("Usage: asset_canonicalize [flags]")
+	fmt.Println("")
+	fmt.Println("Recomputes asset stable IDs from canonical paths. Assets created before")
+	fmt.Println("canonicalization that are variants of one resource (\"/data//users.csv\" and")
+	fmt.Println("\"/data/users.csv\", a database host with and without its default port) are")
+	fmt.Println("merged into the oldest of them; other assets whose ID changes are re-keyed.")
+	fmt.Println("Run it once after upgrading, and again after changing the path policies.")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --tenant ID             - Tenant to operate on (default: default tenant)")
+	fmt.Println("  --dry-run               - List merges and re-keys without applying them")
+	fmt.Println("  --skip-lineage          - Leave the Neo4j graph untouched")
+	fmt.Println("")
+	fmt.Println("Environment variables:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME - Postgres connection")
+	fmt.Println("  NEO4J_URI, NEO4J_USERNAME, NEO4J_PASSWORD       - Neo4j connection")
+	fmt.Println("  ASSET_PATH_CASE_SENSITIVE_SOURCES, ASSET_PATH_KEEP_TRAILING_SLASH - Path policies")
+}
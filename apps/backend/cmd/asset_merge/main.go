@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	lineageService "github.com/arc-platform/backend/modules/lineage/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/audit"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	flags := flag.NewFlagSet("asset_merge", flag.ExitOnError)
+	flags.Usage = printUsage
+	tenant := flags.String("tenant", uuid.Nil.String(), "Tenant ID to operate on")
+	source := flags.String("source", "", "Duplicate asset to merge away")
+	target := flags.String("target", "", "Asset that survives the merge")
+	skipLineage := flags.Bool("skip-lineage", false, "Do not update Neo4j")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	if *source == "" || *target == "" {
+		printUsage()
+		os.Exit(1)
+	}
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		log.Fatalf("Invalid tenant ID: %v", err)
+	}
+	sourceID, err := uuid.Parse(*source)
+	if err != nil {
+		log.Fatalf("Invalid source asset ID: %v", err)
+	}
+	targetID, err := uuid.Parse(*target)
+	if err != nil {
+		log.Fatalf("Invalid target asset ID: %v", err)
+	}
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	repo := persistence.NewPostgresRepository(db)
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+
+	var lineageSync interfaces.LineageSync = &interfaces.NoOpLineageSync{}
+	if !*skipLineage {
+		neo4jRepo, err := persistence.NewNeo4jRepository(
+			getEnv("NEO4J_URI", "bolt://127.0.0.1:7687"),
+			getEnv("NEO4J_USERNAME", "neo4j"),
+			getEnv("NEO4J_PASSWORD", "password123"),
+		)
+		if err != nil {
+			log.Printf("⚠️  Neo4j unavailable, the lineage graph will not be updated: %v", err)
+		} else {
+			defer neo4jRepo.Close(context.Background())
+			lineageSync = lineageService.NewSemanticLineageService(neo4jRepo, repo, service.NewFindingsService(repo))
+		}
+	}
+
+	mergeService := service.NewAssetMergeService(repo, lineageSync, audit.NewPostgresAuditLogger(repo))
+
+	log.Printf("Merging asset %s into %s...", sourceID, targetID)
+	result, err := mergeService.MergeAssets(ctx, sourceID, targetID, "cli")
+	if err != nil {
+		log.Fatalf("Merge failed: %v", err)
+	}
+	printJSON(result)
+	log.Printf("Moved %d findings (%d duplicates dropped) and %d relationships",
+		len(result.MovedFindingIDs), len(result.DroppedFindings), len(result.MovedRelationships))
+	if result.LineageError != "" {
+		log.Printf("⚠️  Lineage graph not fully updated: %s", result.LineageError)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+func printUsage() {
+	fmt.Println("Usage: asset_merge --source ID --target ID [flags]")
+	fmt.Println("")
+	fmt.Println("Merges a duplicate asset into the surviving asset: findings, relationships,")
+	fmt.Println("masking audit entries and remediation history move to the target, the source")
+	fmt.Println("is deleted and the merge is recorded in the audit log with undo metadata.")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --source ID             - Duplicate asset to merge away")
+	fmt.Println("  --target ID             - Asset that survives the merge")
+	fmt.Println("  --tenant ID             - Tenant to operate on (default: default tenant)")
+	fmt.Println("  --skip-lineage          - Leave the Neo4j graph untouched")
+	fmt.Println("")
+	fmt.Println("Environment variables:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME - Postgres connection")
+	fmt.Println("  NEO4J_URI, NEO4J_USERNAME, NEO4J_PASSWORD       - Neo4j connection")
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	lineageService "github.com/arc-platform/backend/modules/lineage/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+// Exit codes, in line with the findings integrity audit
+const (
+	exitClean = 0
+	exitError = 1
+	exitDrift = 2
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(exitError)
+	}
+
+	switch os.Args[1] {
+	case "consistency":
+		os.Exit(runConsistency(os.Args[2:]))
+	default:
+		fmt.Printf("Unknown command: %s\n", os.Args[1])
+		printUsage()
+		os.Exit(exitError)
+	}
+}
+
+// runConsistency compares the Neo4j lineage graph against PostgreSQL and returns the exit code
+func runConsistency(args []string) int {
+	flags := flag.NewFlagSet("consistency", flag.ExitOnError)
+	flags.Usage = printUsage
+	tenant := flags.String("tenant", "", "Only check this tenant")
+	repair := flags.Bool("repair", false, "Queue drifted assets for lineage sync")
+	format := flags.String("format", "text", "Report format: text or json")
+	if err := flags.Parse(args); err != nil {
+		log.Printf("Invalid arguments: %v", err)
+		return exitError
+	}
+	if *format != "text" && *format != "json" {
+		log.Printf("Invalid format %q: use text or json", *format)
+		return exitError
+	}
+
+	// Orphan detection is graph-wide, so it only runs when every tenant is checked
+	opts := lineageService.ConsistencyOptions{Repair: *repair, Orphans: *tenant == ""}
+	if *tenant != "" {
+		tenantID, err := uuid.Parse(*tenant)
+		if err != nil {
+			log.Printf("Invalid tenant ID: %v", err)
+			return exitError
+		}
+		opts.TenantIDs = []uuid.UUID{tenantID}
+	}
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		log.Printf("Failed to connect to database: %v", err)
+		return exitError
+	}
+	defer db.Close()
+
+	neo4jRepo, err := persistence.NewNeo4jRepository(
+		getEnv("NEO4J_URI", "bolt://127.0.0.1:7687"),
+		getEnv("NEO4J_USERNAME", "neo4j"),
+		getEnv("NEO4J_PASSWORD", "password123"),
+	)
+	if err != nil {
+		log.Printf("Failed to connect to Neo4j: %v", err)
+		return exitError
+	}
+	defer neo4jRepo.Close(context.Background())
+
+	checker := lineageService.NewConsistencyChecker(neo4jRepo, persistence.NewPostgresRepository(db))
+	report, err := checker.Check(context.Background(), opts)
+	if err != nil {
+		log.Printf("Consistency check failed: %v", err)
+		return exitError
+	}
+
+	if *format == "json" {
+		printJSON(report)
+	} else {
+		printReport(report)
+	}
+
+	switch {
+	case len(report.Errors) > 0:
+		return exitError
+	case report.HasDrift():
+		return exitDrift
+	default:
+		return exitClean
+	}
+}
+
+func printReport(report *lineageService.ConsistencyReport) {
+	fmt.Println("=== ARC-HAWK LINEAGE CONSISTENCY REPORT ===")
+	fmt.Printf("Generated: %s\n\n", report.GeneratedAt.Format("2006-01-02 15:04:05 MST"))
+
+	for _, tenant := range report.Tenants {
+		fmt.Printf("Tenant %s\n", tenant.TenantID)
+		fmt.Printf("  Assets:         %d in PostgreSQL, %d in Neo4j\n", tenant.PostgresAssets, tenant.GraphAssets)
+		fmt.Printf("  PII categories: %d in PostgreSQL, %d in Neo4j\n", tenant.PostgresPIICategories, tenant.GraphPIICategories)
+		fmt.Printf("  Drifted assets: %d\n", len(tenant.Drifted))
+		for _, drift := range tenant.Drifted {
+			fmt.Printf("    - %s (%s): %s", drift.AssetID, drift.AssetName, strings.Join(drift.Kinds, ", "))
+			if drift.Requeued {
+				fmt.Print(" [requeued]")
+			}
+			fmt.Println()
+			for _, property := range drift.Properties {
+				fmt.Printf("        %s: postgres=%v neo4j=%v\n", property.Property, property.Postgres, property.Neo4j)
+			}
+			if len(drift.MissingPIITypes) > 0 {
+				fmt.Printf("        missing exposures: %s\n", strings.Join(drift.MissingPIITypes, ", "))
+			}
+			if len(drift.StalePIITypes) > 0 {
+				fmt.Printf("        stale exposures: %s\n", strings.Join(drift.StalePIITypes, ", "))
+			}
+		}
+		fmt.Println()
+	}
+
+	if len(report.OrphanAssetNodes) > 0 {
+		fmt.Printf("Orphan asset nodes (no live PostgreSQL asset): %d\n", len(report.OrphanAssetNodes))
+		for _, id := range report.OrphanAssetNodes {
+			fmt.Printf("  - %s\n", id)
+		}
+		fmt.Println()
+	}
+	for _, msg := range report.Errors {
+		fmt.Printf("❌ %s\n", msg)
+	}
+
+	fmt.Printf("Total drifted assets: %d, orphan nodes: %d", report.DriftedAssets, len(report.OrphanAssetNodes))
+	if report.Repair {
+		fmt.Printf(", requeued for sync: %d", report.Requeued)
+	}
+	fmt.Println()
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+func printUsage() {
+	fmt.Println("Usage: audit <command> [flags]")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	fmt.Println("  consistency   - Compare the Neo4j lineage graph against PostgreSQL and report drift")
+	fmt.Println("")
+	fmt.Println("Consistency flags:")
+	fmt.Println("  --tenant ID             - Only check this tenant (default: every tenant, plus orphan nodes)")
+	fmt.Println("  --repair                - Queue drifted assets in the lineage sync outbox")
+	fmt.Println("  --format text|json      - Report format (default: text)")
+	fmt.Println("")
+	fmt.Println("Exit codes: 0 in sync, 1 error, 2 drift found")
+	fmt.Println("")
+	fmt.Println("Environment variables:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME - Postgres connection")
+	fmt.Println("  NEO4J_URI, NEO4J_USERNAME, NEO4J_PASSWORD       - Neo4j connection")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/archive"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	tenant := flags.String("tenant", uuid.Nil.String(), "Tenant ID to operate on")
+	olderThanDays := flags.Int("older-than-days", 0, "Minimum scan run age in days (default: FINDING_ARCHIVE_OLDER_THAN_DAYS)")
+	includeOpen := flags.Bool("include-open", false, "Also archive findings that are not resolved or false positives")
+	limit := flags.Int("limit", 0, "Maximum number of scan runs to archive")
+	dryRun := flags.Bool("dry-run", false, "List eligible scan runs without archiving")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		log.Fatalf("Invalid tenant ID: %v", err)
+	}
+
+	cfg := config.LoadConfig()
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	store, err := archive.NewObjectStore(cfg.Archive)
+	if err != nil {
+		log.Fatalf("Failed to initialize archive store: %v", err)
+	}
+
+	archiveService := service.NewFindingArchiveService(persistence.NewPostgresRepository(db), store, cfg.Archive.Prefix,
+		service.ArchiveOptions{OlderThanDays: cfg.Archive.OlderThanDays, ClosedOnly: cfg.Archive.ClosedOnly}, nil)
+
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+
+	switch command {
+	case "archive":
+		opts := archiveService.DefaultOptions()
+		if *olderThanDays > 0 {
+			opts.OlderThanDays = *olderThanDays
+		}
+		if *includeOpen {
+			opts.ClosedOnly = false
+		}
+		opts.Limit = *limit
+		opts.DryRun = *dryRun
+
+		log.Printf("Archiving findings older than %d days (closed only: %v, dry run: %v)...", opts.OlderThanDays, opts.ClosedOnly, opts.DryRun)
+		result, err := archiveService.ArchiveFindings(ctx, opts, "cli")
+		if err != nil {
+			log.Fatalf("Archive failed: %v", err)
+		}
+		printJSON(result)
+		log.Printf("Archived %d findings from %d scan runs", result.ArchivedFindings, len(result.Archives))
+
+	case "restore":
+		if flags.NArg() < 1 {
+			fmt.Println("restore requires a scan run ID")
+			printUsage()
+			os.Exit(1)
+		}
+		scanRunID, err := uuid.Parse(flags.Arg(0))
+		if err != nil {
+			log.Fatalf("Invalid scan run ID: %v", err)
+		}
+
+		log.Printf("Restoring archived findings for scan run %s...", scanRunID)
+		restored, err := archiveService.RestoreScanRun(ctx, scanRunID)
+		if err != nil {
+			log.Fatalf("Restore failed: %v", err)
+		}
+		printJSON(restored)
+		log.Println("Restore completed successfully!")
+
+	case "list":
+		archives, err := archiveService.ListArchives(ctx, nil, "", 500, 0)
+		if err != nil {
+			log.Fatalf("Failed to list archives: %v", err)
+		}
+		printJSON(archives)
+
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+func printUsage() {
+	fmt.Println("Usage: finding_archive [command] [flags]")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	fmt.Println("  archive                 - Export old findings to object storage and delete them from Postgres")
+	fmt.Println("  restore <scan_run_id>   - Rehydrate the archived findings of a scan run")
+	fmt.Println("  list                    - List finding archives")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --tenant ID             - Tenant to operate on (default: default tenant)")
+	fmt.Println("  --older-than-days N     - Minimum scan run age (archive only)")
+	fmt.Println("  --include-open          - Archive open findings too (archive only)")
+	fmt.Println("  --limit N               - Maximum scan runs per run (archive only)")
+	fmt.Println("  --dry-run               - Show eligible scan runs without archiving")
+	fmt.Println("")
+	fmt.Println("Environment variables:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME - Postgres connection")
+	fmt.Println("  FINDING_ARCHIVE_PROVIDER  - s3, gcs or local (default: s3)")
+	fmt.Println("  FINDING_ARCHIVE_BUCKET    - Bucket holding archives")
+	fmt.Println("  FINDING_ARCHIVE_REGION    - Bucket region (default: us-east-1)")
+	fmt.Println("  FINDING_ARCHIVE_ENDPOINT  - Optional S3-compatible endpoint")
+	fmt.Println("  FINDING_ARCHIVE_LOCAL_PATH - Archive root directory for the local provider")
+}
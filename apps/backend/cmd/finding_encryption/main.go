@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	tenant := flags.String("tenant", uuid.Nil.String(), "Tenant ID to operate on")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	tenantID, err := uuid.Parse(*tenant)
+	if err != nil {
+		log.Fatalf("Invalid tenant ID: %v", err)
+	}
+
+	enc, err := encryption.NewEncryptionService()
+	if err != nil {
+		log.Fatalf("Finding encryption requires a master key: %v", err)
+	}
+	persistence.ConfigureFindingEncryption(enc)
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// No queue: rotation migrates findings inline
+	encryptService := service.NewFindingEncryptionService(persistence.NewPostgresRepository(db), nil, nil)
+
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+
+	switch command {
+	case "status":
+		status, err := encryptService.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to get status: %v", err)
+		}
+		printJSON(status)
+
+	case "rotate":
+		log.Printf("Creating a data key for tenant %s and migrating its findings...", tenantID)
+		key, _, err := encryptService.RotateKey(ctx, "cli")
+		if key != nil {
+			printJSON(key)
+		}
+		if err != nil {
+			log.Fatalf("Rotation failed: %v", err)
+		}
+		log.Printf("Findings now sealed under key version %d", key.Version)
+
+	case "migrate":
+		log.Printf("Migrating findings of tenant %s to its active data key...", tenantID)
+		migrated, err := encryptService.Migrate(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed after %d findings: %v", migrated, err)
+		}
+		log.Printf("Migrated %d findings", migrated)
+
+	default:
+		fmt.Printf("Unknown command: %s\n", command)
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode output: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+func printUsage() {
+	fmt.Println("Usage: finding_encryption [command] [flags]")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	fmt.Println("  status                  - Show data keys and findings per key version")
+	fmt.Println("  rotate                  - Create a data key (enabling encryption on first use) and migrate findings to it")
+	fmt.Println("  migrate                 - Seal plaintext findings and findings under retired keys with the active key")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --tenant ID             - Tenant to operate on (default: default tenant)")
+	fmt.Println("")
+	fmt.Println("Environment variables:")
+	fmt.Println("  DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME - Postgres connection")
+	fmt.Println("  ENCRYPTION_KEY            - 32-byte master key wrapping tenant data keys")
+}
@@ -11,25 +11,39 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/arc-platform/backend/modules/alerting"
 	"github.com/arc-platform/backend/modules/analytics"
 	"github.com/arc-platform/backend/modules/assets"
 	"github.com/arc-platform/backend/modules/auth"
-	"github.com/arc-platform/backend/modules/auth/service"
+	authmiddleware "github.com/arc-platform/backend/modules/auth/middleware"
 	"github.com/arc-platform/backend/modules/compliance"
 	"github.com/arc-platform/backend/modules/connections"
+	"github.com/arc-platform/backend/modules/consent"
+	"github.com/arc-platform/backend/modules/discovery"
+	"github.com/arc-platform/backend/modules/events"
 	"github.com/arc-platform/backend/modules/fplearning"
 	"github.com/arc-platform/backend/modules/lineage"
 	"github.com/arc-platform/backend/modules/masking"
 	"github.com/arc-platform/backend/modules/remediation"
+	"github.com/arc-platform/backend/modules/reporting"
 	"github.com/arc-platform/backend/modules/scanning"
 	"github.com/arc-platform/backend/modules/scanning/worker"
+	"github.com/arc-platform/backend/modules/search"
+	"github.com/arc-platform/backend/modules/secrets"
 	"github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/shared/config"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/audit"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/circuitbreaker"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/eventbus"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/kafka"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/arc-platform/backend/modules/shared/middleware"
+	"github.com/arc-platform/backend/modules/sharing"
+	"github.com/arc-platform/backend/modules/ticketing"
 	"github.com/arc-platform/backend/modules/websocket"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -37,6 +51,7 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
@@ -68,6 +83,35 @@ func main() {
 
 	log.Println("✅ Database connection established")
 
+	// Context deadlines, statement timeouts and slow-query thresholds per query class
+	persistence.ConfigureQueryGuards(persistence.QueryGuardOptions{
+		Interactive: persistence.QueryBudget{
+			Timeout:       time.Duration(cfg.Query.InteractiveTimeoutSeconds) * time.Second,
+			SlowThreshold: time.Duration(cfg.Query.InteractiveSlowMs) * time.Millisecond,
+		},
+		Report: persistence.QueryBudget{
+			Timeout:       time.Duration(cfg.Query.ReportTimeoutSeconds) * time.Second,
+			SlowThreshold: time.Duration(cfg.Query.ReportSlowMs) * time.Millisecond,
+		},
+		Batch: persistence.QueryBudget{
+			Timeout:       time.Duration(cfg.Query.BatchTimeoutSeconds) * time.Second,
+			SlowThreshold: time.Duration(cfg.Query.BatchSlowMs) * time.Millisecond,
+		},
+		PageSize: cfg.Query.PageSize,
+	})
+
+	// Large finding context and enrichment payloads are kept out of the findings table
+	persistence.ConfigureFindingPayloads(persistence.FindingPayloadOptions{
+		InlineLimit: cfg.Payload.InlineLimitBytes,
+	})
+
+	// Tenant data keys sealing finding values are wrapped with the master key
+	if enc, err := encryption.NewEncryptionService(); err != nil {
+		log.Printf("⚠️  Finding encryption unavailable: %v", err)
+	} else {
+		persistence.ConfigureFindingEncryption(enc)
+	}
+
 	// Run database migrations
 	migrationURL := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		os.Getenv("DB_USER"),
@@ -100,15 +144,34 @@ func main() {
 	neo4jUsername := getEnv("NEO4J_USERNAME", "neo4j")
 	neo4jPassword := getEnv("NEO4J_PASSWORD", "password123")
 
-	log.Printf("🔗 Connecting to Neo4j at %s...", neo4jURI)
+	if persistence.IsRoutingURI(neo4jURI) {
+		log.Printf("🔗 Connecting to Neo4j cluster at %s (reads routed to followers)...", neo4jURI)
+	} else {
+		log.Printf("🔗 Connecting to Neo4j at %s...", neo4jURI)
+	}
 
-	neo4jRepo, err := persistence.NewNeo4jRepository(neo4jURI, neo4jUsername, neo4jPassword)
+	neo4jRepo, err := persistence.NewNeo4jRepositoryWithOptions(neo4jURI, neo4jUsername, neo4jPassword, persistence.Neo4jOptions{
+		Database:                     cfg.Neo4j.Database,
+		MaxConnectionPoolSize:        cfg.Neo4j.MaxConnectionPoolSize,
+		ConnectionAcquisitionTimeout: time.Duration(cfg.Neo4j.ConnectionAcquisitionTimeoutSeconds) * time.Second,
+		MaxConnectionLifetime:        time.Duration(cfg.Neo4j.MaxConnectionLifetimeMinutes) * time.Minute,
+		FetchSize:                    cfg.Neo4j.FetchSize,
+		CausalConsistency:            cfg.Neo4j.CausalConsistency,
+		SkipSchemaBootstrap:          !cfg.Neo4j.SchemaBootstrap,
+	})
 	if err != nil {
 		log.Fatalf("❌ FATAL: Neo4j connection failed: %v", err)
 	}
 
 	log.Printf("✅ Neo4j connection established")
 
+	// Fail fast while Neo4j is unhealthy; lineage syncs are deferred to the outbox meanwhile
+	neo4jRepo.SetCircuitBreaker(
+		persistence.NewNeo4jCircuitBreaker(cfg.Neo4jBreaker.FailureThreshold,
+			time.Duration(cfg.Neo4jBreaker.CooldownSeconds)*time.Second),
+		time.Duration(cfg.Neo4jBreaker.OperationTimeoutSeconds)*time.Second,
+	)
+
 	// Initialize Module Registry
 	log.Println("\n📦 Initializing Modules...")
 	log.Println(strings.Repeat("=", 70))
@@ -120,13 +183,62 @@ func main() {
 	auditRepo := persistence.NewPostgresRepository(db)
 	auditLogger := audit.NewPostgresAuditLogger(auditRepo)
 
+	// Initialize live event bus (Shared Infrastructure)
+	// With Postgres pub/sub every replica streams events published by any replica
+	eventCtx, cancelEvents := context.WithCancel(context.Background())
+	defer cancelEvents()
+
+	var eventBus *eventbus.Bus
+	if cfg.Events.PubSub == "postgres" {
+		eventBus = eventbus.NewBus(db, cfg.Events.BufferSize)
+		if err := eventBus.Listen(eventCtx, dbConfig.DSN()); err != nil {
+			log.Printf("⚠️  Event bus listener unavailable, live events stay on this replica: %v", err)
+		}
+	} else {
+		eventBus = eventbus.NewBus(nil, cfg.Events.BufferSize)
+	}
+
+	// Initialize Kafka producer for downstream consumers (optional)
+	var integrationEvents interfaces.EventPublisher = &interfaces.NoOpEventPublisher{}
+	var kafkaProducer *kafka.Producer
+	if len(cfg.Kafka.Brokers) > 0 {
+		kafkaProducer, err = kafka.NewProducer(cfg.Kafka)
+		if err != nil {
+			log.Fatalf("Invalid Kafka configuration: %v", err)
+		}
+		integrationEvents = kafkaProducer
+		log.Printf("📤 Publishing integration events to Kafka topic %s", cfg.Kafka.Topic)
+	}
+
+	// Retried ingestion and remediation requests replay their first response
+	var idempotency *middleware.Idempotency
+	if cfg.Idempotency.Enabled {
+		idempotency = middleware.NewIdempotency(persistence.NewPostgresRepository(db), time.Duration(cfg.Idempotency.WindowHours)*time.Hour)
+		go idempotency.StartCleanup(eventCtx, time.Duration(cfg.Idempotency.CleanupIntervalMinutes)*time.Minute)
+		log.Printf("🔁 Idempotency keys enabled (%dh window)", cfg.Idempotency.WindowHours)
+	}
+
+	// Reads of finding PII are recorded with the user and stated purpose
+	var accessAudit *middleware.AccessAudit
+	if cfg.AccessAudit.Enabled {
+		accessAudit = middleware.NewAccessAudit(auditLogger, cfg.AccessAudit.RequirePurpose)
+	}
+
+	// Background jobs persist in Postgres; any replica may run a job another enqueued
+	jobQueue := jobs.NewQueue(persistence.NewPostgresRepository(db), cfg.Jobs)
+
 	// Prepare base module dependencies (without interfaces)
 	baseDeps := &interfaces.ModuleDependencies{
-		DB:          db,
-		Neo4jRepo:   neo4jRepo,
-		Config:      cfg,
-		Registry:    registry,
-		AuditLogger: auditLogger,
+		DB:                db,
+		Neo4jRepo:         neo4jRepo,
+		Config:            cfg,
+		Registry:          registry,
+		AuditLogger:       auditLogger,
+		EventPublisher:    eventBus,
+		IntegrationEvents: integrationEvents,
+		Idempotency:       idempotency,
+		AccessAudit:       accessAudit,
+		Jobs:              jobQueue,
 	}
 
 	// Phase 1: Initialize Assets Module first (no dependencies)
@@ -167,16 +279,27 @@ func main() {
 	websocketModule := websocket.NewWebSocketModule()
 	baseDeps.WebSocketService = websocketModule.GetWebSocketService()
 
+	authModule := auth.NewAuthModule()
+
 	remainingModules := []interfaces.Module{
 		scanning.NewScanningModule(),       // Scanning & Classification
-		auth.NewAuthModule(),               // Authentication
+		authModule,                         // Authentication
 		compliance.NewComplianceModule(),   // Compliance Posture
+		consent.NewConsentModule(),         // Consent Registry
+		alerting.NewAlertingModule(),       // Alert Rules & Email Digests
 		masking.NewMaskingModule(),         // Data Masking
 		analytics.NewAnalyticsModule(),     // Analytics & Heatmaps
+		reporting.NewReportingModule(),     // Custom Reports & Scheduled Delivery
+		sharing.NewSharingModule(),         // Dashboard Share Links
 		connections.NewConnectionsModule(), // Connections & Orchestration
+		discovery.NewDiscoveryModule(),     // Schema Discovery & Coverage
 		remediation.NewRemediationModule(), // Remediation
+		ticketing.NewTicketingModule(),     // Jira & ServiceNow Tickets
+		secrets.NewSecretsModule(),         // Secret Verification
+		search.NewSearchModule(),           // Findings Full-Text Search
 		fplearning.NewFPlearningModule(),   // Fingerprint Learning
 		websocketModule,                    // Real-time WebSocket Communication
+		events.NewEventsModule(eventBus),   // Live Event Stream (SSE)
 	}
 
 	for _, module := range remainingModules {
@@ -192,6 +315,11 @@ func main() {
 	log.Println("\n✅ All modules initialized successfully")
 	log.Println(strings.Repeat("=", 70))
 
+	// Every module has registered its job handlers by now
+	if cfg.Jobs.Enabled {
+		go jobQueue.Start(eventCtx)
+	}
+
 	// Optional: Initialize Temporal Worker
 	var temporalWorker *worker.TemporalWorker
 	if getEnv("TEMPORAL_ENABLED", "false") == "true" {
@@ -220,13 +348,19 @@ func main() {
 	// Setup HTTP server
 	router := gin.Default()
 
+	// Client IPs (share link allowlists, rate limits, audit) come from forwarding
+	// headers only when a trusted proxy sent them
+	if err := router.SetTrustedProxies(cfg.Proxy.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// CORS middleware
 	allowedOrigins := getEnv("ALLOWED_ORIGINS", "http://localhost:3000")
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{allowedOrigins},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.IdempotencyKeyHeader, middleware.AccessPurposeHeader},
+		ExposeHeaders:    []string{"Content-Length", middleware.IdempotentReplayedHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -245,8 +379,13 @@ func main() {
 	router.Use(middleware.SecurityHeaders())
 	log.Println("🔒 Security Headers enabled (HSTS, CSP, X-Frame-Options)")
 
-	// Initialize JWT service
-	jwtService := service.NewJWTService()
+	// Request validation failures are rendered in the standard error envelope
+	router.Use(middleware.ErrorEnvelope())
+
+	// Tokens are validated by the auth module's session service so revoked
+	// sessions and denylisted tokens are rejected on every route
+	sessionService := authModule.GetSessionService()
+	jwtService := sessionService.JWTService()
 
 	// Auth middleware with enforcement
 	// Define paths that allow anonymous access
@@ -255,18 +394,41 @@ func main() {
 		"/api/v1/auth/register": true,
 		"/api/v1/auth/refresh":  true,
 		"/api/v1/health":        true,
+		"/api/v1/openapi.json":  true,
+		// OIDC callback; the browser arrives from the IdP without a token
+		"/api/v1/auth/sso/callback": true,
 	}
 
 	authMiddleware := func(c *gin.Context) {
 		path := c.Request.URL.Path
 
 		// Check if this is a public path
-		if publicPaths[path] {
+		// Tracker webhooks authenticate with the integration's webhook secret instead,
+		// and share links with their token
+		if publicPaths[path] || strings.HasPrefix(path, "/api/v1/auth/sso/login/") ||
+			strings.HasPrefix(path, "/api/v1/ticketing/webhooks/") || strings.HasPrefix(path, "/api/v1/public/shares/") {
 			c.Next()
 			return
 		}
 
 		authHeader := c.GetHeader("Authorization")
+
+		// EventSource cannot send headers, so the event stream also accepts a
+		// single-use ?ticket= from POST /api/v1/auth/stream-ticket. Access tokens
+		// are never read from the URL, where logs and browser history keep them.
+		if authHeader == "" && path == "/api/v1/events" && c.Query("ticket") != "" {
+			claims, err := sessionService.RedeemStreamTicket(c.Request.Context(), c.Query("ticket"))
+			if err != nil {
+				log.Printf("WARN: stream ticket rejected for %s: %v", c.ClientIP(), err)
+				c.JSON(401, gin.H{"error": "Invalid stream ticket"})
+				c.Abort()
+				return
+			}
+			authmiddleware.SetRequestUser(c, claims)
+			c.Next()
+			return
+		}
+
 		if authHeader == "" {
 			// Check if AUTH_REQUIRED is enabled (default: false for backward compatibility)
 			if getEnv("AUTH_REQUIRED", "false") == "true" {
@@ -293,16 +455,34 @@ func main() {
 			c.Abort()
 			return
 		}
+		if err := sessionService.ValidateAccess(c.Request.Context(), claims); err != nil {
+			c.JSON(401, gin.H{"error": "Invalid token", "details": err.Error()})
+			c.Abort()
+			return
+		}
 
-		// Set user context for downstream handlers
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
-		c.Set("tenant_id", claims.TenantID)
-		c.Set("authenticated", true)
+		authmiddleware.SetRequestUser(c, claims)
 		c.Next()
 	}
 
+	// Prometheus metrics, including ingestion backpressure. An internal listener serves
+	// them without a token; on the API port they need METRICS_TOKEN.
+	var metricsSrv *http.Server
+	switch {
+	case cfg.Metrics.ListenAddr != "":
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		metricsSrv = &http.Server{
+			Addr:              cfg.Metrics.ListenAddr,
+			Handler:           metricsMux,
+			ReadHeaderTimeout: 5 * time.Second,
+		}
+	case cfg.Metrics.Token != "":
+		router.GET("/metrics", middleware.RequireBearerToken(cfg.Metrics.Token), gin.WrapH(promhttp.Handler()))
+	default:
+		log.Println("INFO: /metrics disabled; set METRICS_LISTEN_ADDR or METRICS_TOKEN to serve it")
+	}
+
 	// Health check with detailed status
 	router.GET("/health", func(c *gin.Context) {
 		// Check database connectivity
@@ -322,13 +502,18 @@ func main() {
 			status = "unhealthy"
 		}
 
+		breaker := neo4jRepo.CircuitBreaker().Snapshot()
+		if status == "healthy" && breaker.State != circuitbreaker.StateClosed {
+			status = "degraded"
+		}
+
 		c.JSON(200, gin.H{
 			"status":           status,
 			"service":          "arc-platform-backend",
 			"architecture":     "modular-monolith",
 			"modules":          len(registry.GetAll()),
 			"database":         gin.H{"healthy": dbHealthy},
-			"neo4j":            gin.H{"healthy": neo4jHealthy},
+			"neo4j":            gin.H{"healthy": neo4jHealthy, "circuit_breaker": breaker.State},
 			"temporal_enabled": false,
 		})
 	})
@@ -344,8 +529,38 @@ func main() {
 
 	// Register health components endpoint
 	healthHandler := api.NewHealthHandler(db, neo4jRepo)
+	if kafkaProducer != nil {
+		healthHandler.SetKafkaProducer(kafkaProducer)
+	}
 	apiV1.GET("/health/components", healthHandler.GetComponentsHealth)
 
+	// Error codes returned in the standard error envelope
+	apiV1.GET("/errors", api.GetErrorCatalogue)
+
+	// OpenAPI document, generated once every route is registered
+	openAPIHandler := api.NewOpenAPIHandler(publicPaths)
+	apiV1.GET("/openapi.json", openAPIHandler.GetSpec)
+
+	// Background job administration
+	jobsHandler := api.NewJobsHandler(jobQueue)
+	adminJobs := apiV1.Group("/admin/jobs", authmiddleware.NewAuthMiddleware(persistence.NewPostgresRepository(db)).RequireRole("admin"))
+	{
+		adminJobs.GET("", jobsHandler.ListJobs)
+		adminJobs.GET("/:id", jobsHandler.GetJob)
+		adminJobs.POST("/:id/retry", jobsHandler.RetryJob)
+		adminJobs.POST("/:id/cancel", jobsHandler.CancelJob)
+	}
+
+	operations := api.OpenAPIOperations()
+	for _, module := range registry.GetAll() {
+		if documented, ok := module.(api.OperationDocumenter); ok {
+			operations = append(operations, documented.OpenAPIOperations()...)
+		}
+	}
+	for _, stale := range openAPIHandler.Build(router.Routes(), operations) {
+		log.Printf("WARN: OpenAPI operation %s matches no route", stale)
+	}
+
 	log.Println("\n✅ All routes registered")
 	log.Println(strings.Repeat("=", 70))
 
@@ -372,6 +587,15 @@ func main() {
 		}
 	}()
 
+	if metricsSrv != nil {
+		go func() {
+			log.Printf("📈 Metrics listening on %s/metrics", metricsSrv.Addr)
+			if err := metricsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to start metrics server: %v", err)
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -390,9 +614,19 @@ func main() {
 		log.Printf("Error during module shutdown: %v", err)
 	}
 
+	// Flush integration events still buffered for Kafka
+	if kafkaProducer != nil {
+		kafkaProducer.Close()
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if metricsSrv != nil {
+		if err := metricsSrv.Shutdown(ctx); err != nil {
+			log.Printf("Metrics server forced to shutdown: %v", err)
+		}
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/arc-platform/backend/pkg/client"
+	"github.com/joho/godotenv"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+)
+
+// pollInterval is how often -wait checks the lineage graph for progress
+const pollInterval = 5 * time.Second
+
+// sync_tool triggers a lineage sync on a running backend through its API, for
+// manual synchronization after restores or Neo4j maintenance
+func main() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("sync_tool", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: sync_tool [flags]")
+		fmt.Fprintln(flags.Output(), "Authenticates with -token (ARC_API_TOKEN) or with -email, -tenant and ARC_API_PASSWORD.")
+		flags.PrintDefaults()
+	}
+	baseURL := flags.String("url", getEnv("ARC_API_URL", "http://localhost:8080"), "Backend base URL (ARC_API_URL)")
+	token := flags.String("token", os.Getenv("ARC_API_TOKEN"), "Access token (ARC_API_TOKEN)")
+	email := flags.String("email", os.Getenv("ARC_API_EMAIL"), "Log in as this user when no token is given (ARC_API_EMAIL)")
+	tenant := flags.String("tenant", os.Getenv("ARC_API_TENANT_ID"), "Tenant ID to log in to (ARC_API_TENANT_ID)")
+	wait := flags.Duration("wait", 0, "Wait up to this long for the graph to stop changing, e.g. 2m")
+	if err := flags.Parse(args); err != nil {
+		log.Printf("Invalid arguments: %v", err)
+		return exitError
+	}
+
+	ctx := context.Background()
+	api := client.New(*baseURL, client.WithToken(*token))
+	if *token == "" {
+		if *email == "" || *tenant == "" {
+			log.Println("Give -token, or -email and -tenant with ARC_API_PASSWORD set")
+			return exitError
+		}
+		if _, err := api.Login(ctx, *email, os.Getenv("ARC_API_PASSWORD"), *tenant); err != nil {
+			log.Printf("Login failed: %v", err)
+			return exitError
+		}
+	}
+
+	before, err := api.LineageStats(ctx)
+	if err != nil {
+		log.Printf("Failed to read lineage stats: %v", err)
+		return exitError
+	}
+	printStats("Before", before)
+
+	result, err := api.SyncLineage(ctx)
+	if err != nil {
+		log.Printf("Failed to start lineage sync: %v", err)
+		return exitError
+	}
+	fmt.Println(result.Message)
+
+	if *wait <= 0 {
+		return exitOK
+	}
+	after, settled := waitForStableGraph(ctx, api, *wait)
+	if after != nil {
+		printStats("After", after)
+	}
+	if !settled {
+		log.Printf("Graph was still changing after %s; the sync continues on the server", *wait)
+	}
+	return exitOK
+}
+
+// waitForStableGraph polls the lineage stats until two reads in a row match,
+// which is as close as the API gets to reporting that the sync finished
+func waitForStableGraph(ctx context.Context, api *client.Client, timeout time.Duration) (*client.LineageStats, bool) {
+	deadline := time.Now().Add(timeout)
+	var last *client.LineageStats
+	for time.Now().Before(deadline) {
+		time.Sleep(pollInterval)
+		stats, err := api.LineageStats(ctx)
+		if err != nil {
+			log.Printf("WARN: Failed to read lineage stats: %v", err)
+			continue
+		}
+		if last != nil && *stats == *last {
+			return stats, true
+		}
+		last = stats
+	}
+	return last, false
+}
+
+func printStats(label string, stats *client.LineageStats) {
+	fmt.Printf("%s: %d systems, %d assets, %d PII categories, %d edges\n",
+		label, stats.TotalSystems, stats.TotalAssets, stats.TotalPIICategories, stats.TotalEdges)
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	assetservice "github.com/arc-platform/backend/modules/assets/service"
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	authservice "github.com/arc-platform/backend/modules/auth/service"
+	connservice "github.com/arc-platform/backend/modules/connections/service"
+	lineageservice "github.com/arc-platform/backend/modules/lineage/service"
+	scanservice "github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/audit"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/database"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// demoNamespace keeps demo tenant IDs stable across runs
+var demoNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://arc-hawk.local/demo"))
+
+// demoTenants are the organisations a demo environment is populated with, in order
+var demoTenants = []struct {
+	Name        string
+	Slug        string
+	Description string
+}{
+	{"Acme Retail", "acme-retail", "E-commerce platform with customer and payment data"},
+	{"Globex Finance", "globex-finance", "Lending business holding KYC and bank account records"},
+	{"Initech Health", "initech-health", "Clinic network with patient contact details"},
+	{"Umbrella Logistics", "umbrella-logistics", "Courier service storing delivery addresses and phone numbers"},
+}
+
+// demoRoles are the users created in every demo tenant
+var demoRoles = []authentity.UserRole{
+	authentity.RoleAdmin,
+	authentity.RoleOperator,
+	authentity.RoleAuditor,
+	authentity.RoleViewer,
+}
+
+// DemoLoader builds a complete demo environment by driving the same services the API uses
+type DemoLoader struct {
+	generator *TestDataGenerator
+	password  string
+
+	db          *sql.DB
+	repo        *persistence.PostgresRepository
+	neo4jRepo   *persistence.Neo4jRepository
+	users       *authservice.UserService
+	connections *connservice.ConnectionService
+	ingestion   *scanservice.IngestionService
+	lineage     *lineageservice.SemanticLineageService
+}
+
+// NewDemoLoader connects to PostgreSQL and, when syncLineage is set, to Neo4j
+func NewDemoLoader(generator *TestDataGenerator, password string, syncLineage bool) (*DemoLoader, error) {
+	cfg := config.LoadConfig()
+
+	db, err := database.Connect(database.NewConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	repo := persistence.NewPostgresRepository(db)
+	auditLogger := audit.NewPostgresAuditLogger(repo)
+
+	l := &DemoLoader{
+		generator: generator,
+		password:  password,
+		db:        db,
+		repo:      repo,
+		users:     authservice.NewUserService(repo),
+	}
+
+	if enc, err := encryption.NewEncryptionService(); err != nil {
+		log.Printf("⚠️  Encryption unavailable, demo connections will be skipped: %v", err)
+	} else {
+		l.connections = connservice.NewConnectionService(repo, enc)
+	}
+
+	if syncLineage {
+		neo4jRepo, err := persistence.NewNeo4jRepository(
+			getEnv("NEO4J_URI", "bolt://127.0.0.1:7687"),
+			getEnv("NEO4J_USERNAME", "neo4j"),
+			getEnv("NEO4J_PASSWORD", "password123"),
+		)
+		if err != nil {
+			log.Printf("⚠️  Neo4j unavailable, lineage sync will be skipped: %v", err)
+		} else {
+			l.neo4jRepo = neo4jRepo
+			l.lineage = lineageservice.NewSemanticLineageService(neo4jRepo, repo, assetservice.NewFindingsService(repo))
+		}
+	}
+
+	// Lineage is synced once per tenant after ingestion rather than per asset
+	assetManager := assetservice.NewAssetService(repo, &interfaces.NoOpLineageSync{}, auditLogger)
+	l.ingestion = scanservice.NewIngestionService(
+		repo,
+		scanservice.NewClassificationService(repo, cfg),
+		scanservice.NewEnrichmentService(repo, nil),
+		assetManager,
+	)
+
+	return l, nil
+}
+
+// Close releases database connections
+func (l *DemoLoader) Close() {
+	if l.neo4jRepo != nil {
+		l.neo4jRepo.Close(context.Background())
+	}
+	l.db.Close()
+}
+
+// Load creates the demo tenants with their users, connections and ingested findings,
+// then syncs lineage. Re-running with the same seed reuses existing tenants, users and connections.
+func (l *DemoLoader) Load(ctx context.Context, numTenants, numAssets, findingsPerAsset int) ([]TestFinding, error) {
+	if numTenants < 1 || numTenants > len(demoTenants) {
+		return nil, fmt.Errorf("tenants must be between 1 and %d", len(demoTenants))
+	}
+
+	var all []TestFinding
+	for _, spec := range demoTenants[:numTenants] {
+		fmt.Printf("\n🏢 Loading tenant: %s\n", spec.Name)
+
+		tenant, err := l.ensureTenant(ctx, spec.Name, spec.Slug, spec.Description)
+		if err != nil {
+			return nil, err
+		}
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenant.ID)
+
+		admin, err := l.ensureUsers(tenantCtx, tenant)
+		if err != nil {
+			return nil, err
+		}
+		tenantCtx = context.WithValue(tenantCtx, "user_id", admin.ID)
+
+		if err := l.ensureConnections(tenantCtx, tenant, admin.Email); err != nil {
+			return nil, err
+		}
+
+		findings := l.generator.generateFindings(tenant.Slug+".example.com", numAssets, findingsPerAsset)
+		for i := range findings {
+			findings[i].Tenant = tenant.Slug
+		}
+
+		input := scanservice.VerifiedScanInput{
+			ScanID:   fmt.Sprintf("demo-%s-%d", tenant.Slug, time.Now().Unix()),
+			Findings: l.toVerifiedFindings(findings),
+			Metadata: map[string]interface{}{"demo": true},
+		}
+		if err := l.ingestion.IngestSDKVerified(tenantCtx, input); err != nil {
+			return nil, fmt.Errorf("failed to ingest findings for %s: %w", tenant.Slug, err)
+		}
+		fmt.Printf("   ✅ Ingested %d findings\n", len(findings))
+
+		if l.lineage != nil {
+			if err := l.lineage.SyncLineage(tenantCtx); err != nil {
+				log.Printf("⚠️  Lineage sync failed for %s: %v", tenant.Slug, err)
+			} else {
+				fmt.Printf("   ✅ Lineage synced\n")
+			}
+		}
+
+		all = append(all, findings...)
+	}
+
+	fmt.Printf("\n🔑 Demo users (password: %s):\n", l.password)
+	for _, spec := range demoTenants[:numTenants] {
+		for _, role := range demoRoles {
+			fmt.Printf("   - %s (tenant: %s)\n", demoEmail(role, spec.Slug), spec.Slug)
+		}
+	}
+
+	return all, nil
+}
+
+// ensureTenant returns the tenant with slug, creating it if needed
+func (l *DemoLoader) ensureTenant(ctx context.Context, name, slug, description string) (*authentity.Tenant, error) {
+	if tenant, err := l.repo.GetTenantBySlug(ctx, slug); err == nil {
+		fmt.Printf("   - Tenant exists: %s (%s)\n", slug, tenant.ID)
+		return tenant, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to look up tenant %s: %w", slug, err)
+	}
+
+	now := time.Now()
+	tenant := &authentity.Tenant{
+		ID:          uuid.NewSHA1(demoNamespace, []byte(slug)),
+		Name:        name,
+		Slug:        slug,
+		Description: description,
+		IsActive:    true,
+		Settings:    `{"demo": true}`,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := l.repo.CreateTenant(ctx, tenant); err != nil {
+		return nil, fmt.Errorf("failed to create tenant %s: %w", slug, err)
+	}
+	fmt.Printf("   - Created tenant: %s (%s)\n", slug, tenant.ID)
+	return tenant, nil
+}
+
+// ensureUsers creates one user per demo role and returns the tenant admin
+func (l *DemoLoader) ensureUsers(ctx context.Context, tenant *authentity.Tenant) (*authentity.User, error) {
+	var admin *authentity.User
+	for _, role := range demoRoles {
+		email := demoEmail(role, tenant.Slug)
+		firstName := strings.ToUpper(string(role[:1])) + string(role[1:])
+
+		user, err := l.users.CreateUser(ctx, tenant.ID, email, l.password, firstName, "Demo", role)
+		if errors.Is(err, authservice.ErrEmailExists) {
+			user, err = l.repo.GetUserByEmail(ctx, email)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user %s: %w", email, err)
+		}
+		if role == authentity.RoleAdmin {
+			admin = user
+		}
+	}
+	fmt.Printf("   - Users ready: %d\n", len(demoRoles))
+	return admin, nil
+}
+
+// ensureConnections registers a database and a filesystem source for the tenant
+func (l *DemoLoader) ensureConnections(ctx context.Context, tenant *authentity.Tenant, createdBy string) error {
+	if l.connections == nil {
+		return nil
+	}
+
+	sources := []struct {
+		sourceType string
+		config     map[string]interface{}
+	}{
+		{"postgresql", map[string]interface{}{
+			"host":     "prod-db-01." + tenant.Slug + ".example.com",
+			"port":     5432,
+			"database": strings.ReplaceAll(tenant.Slug, "-", "_"),
+			"user":     "arc_scanner",
+			"password": "demo-not-a-secret",
+		}},
+		{"filesystem", map[string]interface{}{
+			"path": "/data/exports",
+		}},
+	}
+
+	for _, source := range sources {
+		profile := fmt.Sprintf("demo-%s-%s", tenant.Slug, source.sourceType)
+		if _, err := l.connections.GetConnectionByProfile(ctx, source.sourceType, profile); err == nil {
+			continue
+		}
+		if _, err := l.connections.AddConnection(ctx, source.sourceType, profile, source.config, createdBy); err != nil {
+			return fmt.Errorf("failed to create connection %s: %w", profile, err)
+		}
+	}
+	fmt.Printf("   - Connections ready: %d\n", len(sources))
+	return nil
+}
+
+// toVerifiedFindings shapes generated findings like scanner SDK output. Values are
+// synthetic and only their hashes are sent, as the real scanner does.
+func (l *DemoLoader) toVerifiedFindings(findings []TestFinding) []scanservice.VerifiedFinding {
+	verified := make([]scanservice.VerifiedFinding, 0, len(findings))
+	for _, f := range findings {
+		source := scanservice.SourceLocation{
+			Path:       f.AssetPath,
+			DataSource: f.DataSource,
+			Host:       f.Host,
+		}
+		if f.DataSource == "filesystem" {
+			source.Line = 1 + l.generator.rand.Intn(5000)
+		} else {
+			source.Table = f.Table
+			source.Column = f.PatternName
+		}
+
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%d", f.AssetPath, f.PIIType, l.generator.rand.Int63())))
+		verified = append(verified, scanservice.VerifiedFinding{
+			PIIType:          f.PIIType,
+			ValueHash:        hex.EncodeToString(hash[:]),
+			Source:           source,
+			ValidatorsPassed: []string{"format", "checksum"},
+			ValidationMethod: "demo",
+			MLConfidence:     f.ConfidenceScore,
+			MLEntityType:     f.PIIType,
+			ContextExcerpt:   fmt.Sprintf("%s: [REDACTED]", f.PatternName),
+			ContextKeywords:  []string{f.PatternName},
+			PatternName:      f.PatternName,
+			DetectedAt:       time.Now().UTC().Format(time.RFC3339),
+			SDKVersion:       "demo",
+		})
+	}
+	return verified
+}
+
+// demoEmail is the login of a demo tenant's user with the given role
+func demoEmail(role authentity.UserRole, slug string) string {
+	return fmt.Sprintf("%s@%s.demo", role, slug)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
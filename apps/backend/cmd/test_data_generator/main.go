@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
 	"time"
 
+	"github.com/arc-platform/backend/pkg/dataset"
+	"github.com/arc-platform/backend/pkg/synthetic"
 	"github.com/google/uuid"
+	"github.com/joho/godotenv"
 )
 
 // TestDataGenerator generates realistic test data for lineage testing
@@ -28,11 +32,14 @@ type PIIType struct {
 
 // TestFinding represents a test finding
 type TestFinding struct {
+	Tenant          string `json:",omitempty"`
 	AssetID         uuid.UUID
 	AssetName       string
 	AssetPath       string
 	Host            string
 	Environment     string
+	DataSource      string
+	Table           string
 	PIIType         string
 	PatternName     string
 	Matches         []string
@@ -92,27 +99,70 @@ var piiTypes = []PIIType{
 		BaseRisk:        "High",
 		SamplePatterns:  []string{"driving_license", "dl_number"},
 	},
+	{
+		Name:            "IN_VOTER_ID",
+		DPDPACategory:   "Sensitive Personal Data",
+		RequiresConsent: true,
+		BaseRisk:        "High",
+		SamplePatterns:  []string{"voter_id", "epic_number"},
+	},
+	{
+		Name:            "IN_BANK_ACCOUNT",
+		DPDPACategory:   "Sensitive Personal Data",
+		RequiresConsent: true,
+		BaseRisk:        "Critical",
+		SamplePatterns:  []string{"bank_account", "account_number"},
+	},
+	{
+		Name:            "IN_UPI",
+		DPDPACategory:   "Personal Data",
+		RequiresConsent: true,
+		BaseRisk:        "High",
+		SamplePatterns:  []string{"upi_id", "vpa"},
+	},
+	{
+		Name:            "IN_IFSC",
+		DPDPACategory:   "Personal Data",
+		RequiresConsent: false,
+		BaseRisk:        "Medium",
+		SamplePatterns:  []string{"ifsc_code", "branch_ifsc"},
+	},
 }
 
-func NewTestDataGenerator() *TestDataGenerator {
+// NewTestDataGenerator creates a generator; the same seed always yields the same dataset
+func NewTestDataGenerator(seed int64) *TestDataGenerator {
 	return &TestDataGenerator{
-		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand: rand.New(rand.NewSource(seed)),
 	}
 }
 
 // GenerateTestFindings generates realistic test findings
 func (g *TestDataGenerator) GenerateTestFindings(numAssets, findingsPerAsset int) []TestFinding {
+	return g.generateFindings("example.com", numAssets, findingsPerAsset)
+}
+
+// generateFindings generates findings for assets on hosts under domain.
+// Asset identity is derived from the host, so each tenant needs its own domain.
+func (g *TestDataGenerator) generateFindings(domain string, numAssets, findingsPerAsset int) []TestFinding {
 	findings := []TestFinding{}
 
-	hosts := []string{"prod-db-01.example.com", "staging-db-01.example.com", "analytics-db.example.com"}
+	hosts := []string{"prod-db-01", "staging-db-01", "analytics-db"}
 	environments := []string{"Production", "Staging", "Development"}
 
 	for i := 0; i < numAssets; i++ {
-		assetID := uuid.New()
-		host := hosts[g.rand.Intn(len(hosts))]
+		assetID := uuid.Must(uuid.NewRandomFromReader(g.rand))
+		host := fmt.Sprintf("%s.%s", hosts[g.rand.Intn(len(hosts))], domain)
 		env := environments[g.rand.Intn(len(environments))]
+
+		// Every third asset is a file export rather than a database table
+		dataSource := "postgresql"
 		assetName := fmt.Sprintf("users_table_%d", i+1)
 		assetPath := fmt.Sprintf("postgresql://%s > public.%s", host, assetName)
+		if i%3 == 2 {
+			dataSource = "filesystem"
+			assetName = fmt.Sprintf("customer_export_%d.csv", i+1)
+			assetPath = fmt.Sprintf("/data/exports/%s", assetName)
+		}
 
 		// Generate findings for this asset
 		for j := 0; j < findingsPerAsset; j++ {
@@ -122,11 +172,11 @@ func (g *TestDataGenerator) GenerateTestFindings(numAssets, findingsPerAsset int
 			// Generate confidence score (biased towards higher values)
 			confidence := 0.45 + g.rand.Float64()*0.50 // 0.45 to 0.95
 
-			// Generate matches
+			// Generate format-preserving synthetic matches
 			numMatches := 1 + g.rand.Intn(10)
 			matches := make([]string, numMatches)
 			for k := 0; k < numMatches; k++ {
-				matches[k] = fmt.Sprintf("match_%d", k+1)
+				matches[k] = synthetic.Generate(piiType.Name, g.rand)
 			}
 
 			finding := TestFinding{
@@ -135,6 +185,8 @@ func (g *TestDataGenerator) GenerateTestFindings(numAssets, findingsPerAsset int
 				AssetPath:       assetPath,
 				Host:            host,
 				Environment:     env,
+				DataSource:      dataSource,
+				Table:           assetName,
 				PIIType:         piiType.Name,
 				PatternName:     pattern,
 				Matches:         matches,
@@ -157,6 +209,9 @@ func (g *TestDataGenerator) ExportToJSON(findings []TestFinding, filename string
 	if err != nil {
 		return err
 	}
+	if err := dataset.Validate(dataset.KindTestFindings, data); err != nil {
+		return err
+	}
 
 	return os.WriteFile(filename, data, 0644)
 }
@@ -181,33 +236,71 @@ func (g *TestDataGenerator) PrintSummary(findings []TestFinding) {
 }
 
 func main() {
-	ctx := context.Background()
-	_ = ctx // For future use
+	if len(os.Args) > 1 && os.Args[1] == "validate-dataset" {
+		os.Exit(runValidateDataset(os.Args[2:]))
+	}
+
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
 
-	generator := NewTestDataGenerator()
+	seed := flag.Int64("seed", 0, "Random seed for a reproducible dataset (default: time-based)")
+	numAssets := flag.Int("assets", 10, "Assets to generate (per tenant with --load)")
+	findingsPerAsset := flag.Int("findings-per-asset", 0, "Findings per asset (default: random 5-14)")
+	output := flag.String("output", "test_findings.json", "File the generated findings are written to")
+	load := flag.Bool("load", false, "Load a demo environment: tenants, users, connections, ingested findings and lineage")
+	numTenants := flag.Int("tenants", 2, "Demo tenants to create with --load")
+	password := flag.String("password", "ArcHawkDemo2024!", "Password for the demo users created with --load")
+	skipLineage := flag.Bool("skip-lineage", false, "Do not sync lineage to Neo4j after loading")
+	flag.Parse()
 
-	// Generate test data: 10 assets, 5-15 findings per asset
-	numAssets := 10
-	findingsPerAsset := 5 + rand.Intn(10)
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	generator := NewTestDataGenerator(*seed)
 
-	fmt.Printf("🔧 Generating test data...\n")
-	fmt.Printf("   - Assets: %d\n", numAssets)
-	fmt.Printf("   - Findings per asset: ~%d\n", findingsPerAsset)
+	if *findingsPerAsset < 1 {
+		*findingsPerAsset = 5 + generator.rand.Intn(10)
+	}
 
-	findings := generator.GenerateTestFindings(numAssets, findingsPerAsset)
+	fmt.Printf("🔧 Generating test data (seed: %d)...\n", *seed)
+	fmt.Printf("   - Assets: %d\n", *numAssets)
+	fmt.Printf("   - Findings per asset: ~%d\n", *findingsPerAsset)
+
+	var findings []TestFinding
+	if *load {
+		loader, err := NewDemoLoader(generator, *password, !*skipLineage)
+		if err != nil {
+			log.Fatalf("Failed to initialize demo loader: %v", err)
+		}
+		defer loader.Close()
+
+		findings, err = loader.Load(context.Background(), *numTenants, *numAssets, *findingsPerAsset)
+		if err != nil {
+			log.Fatalf("Failed to load demo environment: %v", err)
+		}
+	} else {
+		findings = generator.GenerateTestFindings(*numAssets, *findingsPerAsset)
+	}
 
 	generator.PrintSummary(findings)
 
 	// Export to JSON
-	filename := "test_findings.json"
-	if err := generator.ExportToJSON(findings, filename); err != nil {
+	if err := generator.ExportToJSON(findings, *output); err != nil {
 		log.Fatalf("Failed to export test data: %v", err)
 	}
 
-	fmt.Printf("\n✅ Test data exported to: %s\n", filename)
+	fmt.Printf("\n✅ Test data exported to: %s\n", *output)
+	fmt.Printf("🔁 Re-run with --seed %d to reproduce this dataset\n", *seed)
+	if *load {
+		return
+	}
+
 	fmt.Printf("\n💡 Next Steps:\n")
-	fmt.Printf("   1. Ingest this data using the scanner or ingestion API\n")
-	fmt.Printf("   2. Trigger lineage sync: POST /api/v1/lineage/sync\n")
-	fmt.Printf("   3. Verify lineage graph: GET /api/v1/lineage\n")
-	fmt.Printf("   4. Check frontend visualization at /lineage\n")
+	fmt.Printf("   1. Load a full demo environment instead: --load --seed %d\n", *seed)
+	fmt.Printf("   2. Or ingest this data using the scanner or ingestion API\n")
+	fmt.Printf("   3. Trigger lineage sync: POST /api/v1/lineage/sync\n")
+	fmt.Printf("   4. Verify lineage graph: GET /api/v1/lineage\n")
+	fmt.Printf("   5. Check frontend visualization at /lineage\n")
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arc-platform/backend/pkg/dataset"
+)
+
+// runValidateDataset implements the validate-dataset subcommand: it checks dataset
+// files, or every .json file in the given directories, against their schemas and
+// returns the process exit code
+func runValidateDataset(args []string) int {
+	fs := flag.NewFlagSet("validate-dataset", flag.ExitOnError)
+	kind := fs.String("kind", "auto", "Dataset kind: auto, "+joinKinds())
+	printSchema := fs.Bool("print-schema", false, "Print the JSON Schema of --kind and exit")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: test_data_generator validate-dataset [--kind KIND] PATH...\n\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *printSchema {
+		schema, err := dataset.Schema(dataset.Kind(*kind))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			return 2
+		}
+		os.Stdout.Write(schema)
+		return 0
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	files, err := datasetFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 2
+	}
+
+	invalid := 0
+	for _, file := range files {
+		if err := validateDatasetFile(file, dataset.Kind(*kind)); err != nil {
+			invalid++
+			var ve *dataset.ValidationError
+			if errors.As(err, &ve) {
+				for _, p := range ve.Problems {
+					fmt.Printf("%s:%s\n", file, p)
+				}
+			} else {
+				fmt.Printf("%s: %v\n", file, err)
+			}
+		}
+	}
+
+	if invalid > 0 {
+		fmt.Printf("\n❌ %d of %d dataset files are invalid\n", invalid, len(files))
+		return 1
+	}
+	fmt.Printf("✅ %d dataset files are valid\n", len(files))
+	return 0
+}
+
+func validateDatasetFile(file string, kind dataset.Kind) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if kind == "auto" {
+		if kind, err = dataset.DetectKind(data); err != nil {
+			// Report what is wrong with the file rather than that its kind is unknown
+			if verr := dataset.Validate(dataset.KindGroundTruth, data); verr != nil {
+				var ve *dataset.ValidationError
+				if errors.As(verr, &ve) && ve.Problems[0].Pointer == "" {
+					return verr
+				}
+			}
+			return fmt.Errorf("%v; pass --kind", err)
+		}
+	}
+	return dataset.Validate(kind, data)
+}
+
+// datasetFiles expands directories to the .json files directly inside them
+func datasetFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no dataset files found")
+	}
+	return files, nil
+}
+
+func joinKinds() string {
+	kinds := make([]string, len(dataset.Kinds))
+	for i, k := range dataset.Kinds {
+		kinds[i] = string(k)
+	}
+	return strings.Join(kinds, ", ")
+}
@@ -0,0 +1,7 @@
+-- Rollback migration for finding staleness tracking
+
+DROP INDEX IF EXISTS idx_findings_asset_scan_run;
+DROP INDEX IF EXISTS idx_findings_stale_at;
+
+ALTER TABLE findings DROP COLUMN IF EXISTS stale_scan_window;
+ALTER TABLE findings DROP COLUMN IF EXISTS stale_at;
@@ -0,0 +1,11 @@
+-- Migration: 000012_add_finding_staleness
+-- Description: Track findings that were not re-observed by recent scans of their asset
+
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS stale_at TIMESTAMP;
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS stale_scan_window INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_findings_stale_at ON findings(stale_at);
+CREATE INDEX IF NOT EXISTS idx_findings_asset_scan_run ON findings(asset_id, scan_run_id);
+
+COMMENT ON COLUMN findings.stale_at IS 'Timestamp when the finding was flagged as not re-observed in recent scans';
+COMMENT ON COLUMN findings.stale_scan_window IS 'Number of subsequent asset scans used when the finding was flagged stale';
@@ -0,0 +1,5 @@
+-- Rollback migration for dashboard summary views
+
+DROP TABLE IF EXISTS summary_view_refreshes;
+DROP INDEX IF EXISTS idx_dashboard_finding_summary_key;
+DROP MATERIALIZED VIEW IF EXISTS dashboard_finding_summary;
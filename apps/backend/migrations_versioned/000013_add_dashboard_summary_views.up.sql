@@ -0,0 +1,46 @@
+-- Migration: 000013_add_dashboard_summary_views
+-- Description: Pre-aggregated finding counts for dashboard summary endpoints
+
+-- Ensure findings.environment exists (previously added outside the versioned migrations)
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS environment VARCHAR(50) NOT NULL DEFAULT 'PROD';
+
+-- ============================================================================
+-- Finding summary by tenant / severity / classification / environment
+-- ============================================================================
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS dashboard_finding_summary AS
+SELECT
+    COALESCE(f.tenant_id, '00000000-0000-0000-0000-000000000000'::uuid) AS tenant_id,
+    f.severity,
+    COALESCE(c.classification_type, 'Unclassified') AS classification_type,
+    COALESCE(NULLIF(f.environment, ''), 'UNKNOWN') AS environment,
+    COUNT(DISTINCT f.id) AS finding_count,
+    COUNT(DISTINCT f.asset_id) AS asset_count,
+    MAX(f.created_at) AS last_finding_at
+FROM findings f
+LEFT JOIN classifications c ON c.finding_id = f.id
+WHERE f.deleted_at IS NULL
+  AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+GROUP BY 1, 2, 3, 4;
+
+-- Unique index required for REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dashboard_finding_summary_key
+ON dashboard_finding_summary(tenant_id, severity, classification_type, environment);
+
+-- ============================================================================
+-- Refresh bookkeeping (drives the staleness indicator)
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS summary_view_refreshes (
+    view_name VARCHAR(100) PRIMARY KEY,
+    refreshed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    refresh_duration_ms INTEGER DEFAULT 0,
+    last_error TEXT
+);
+
+INSERT INTO summary_view_refreshes (view_name, refreshed_at)
+VALUES ('dashboard_finding_summary', CURRENT_TIMESTAMP)
+ON CONFLICT (view_name) DO NOTHING;
+
+COMMENT ON MATERIALIZED VIEW dashboard_finding_summary IS 'Finding counts per tenant, severity, classification and environment; refreshed after ingestion';
+COMMENT ON TABLE summary_view_refreshes IS 'Last refresh time of each summary materialized view';
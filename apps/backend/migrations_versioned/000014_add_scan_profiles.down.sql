@@ -0,0 +1,4 @@
+-- Rollback migration for scan profiles
+
+DROP TRIGGER IF EXISTS trigger_scan_profiles_updated_at ON scan_profiles;
+DROP TABLE IF EXISTS scan_profiles CASCADE;
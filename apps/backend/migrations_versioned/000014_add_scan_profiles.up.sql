@@ -0,0 +1,34 @@
+-- Migration: 000014_add_scan_profiles
+-- Description: Connection-scoped scan profiles served to the scanner SDK
+
+CREATE TABLE IF NOT EXISTS scan_profiles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID,
+    connection_id UUID NOT NULL REFERENCES connections(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    include_globs TEXT[] NOT NULL DEFAULT '{}',
+    exclude_globs TEXT[] NOT NULL DEFAULT '{}',
+    table_allow_list TEXT[] NOT NULL DEFAULT '{}',
+    table_deny_list TEXT[] NOT NULL DEFAULT '{}',
+    sample_size INTEGER NOT NULL DEFAULT 100,
+    pii_types TEXT[] NOT NULL DEFAULT '{}',
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    CONSTRAINT unique_scan_profile_per_connection UNIQUE (connection_id, name),
+    CONSTRAINT scan_profiles_sample_size_positive CHECK (sample_size > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scan_profiles_connection ON scan_profiles(connection_id);
+CREATE INDEX IF NOT EXISTS idx_scan_profiles_tenant ON scan_profiles(tenant_id);
+
+-- At most one default profile per connection
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scan_profiles_default
+ON scan_profiles(connection_id) WHERE is_default;
+
+CREATE TRIGGER trigger_scan_profiles_updated_at
+    BEFORE UPDATE ON scan_profiles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_connections_updated_at();
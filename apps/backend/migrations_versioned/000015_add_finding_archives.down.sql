@@ -0,0 +1,3 @@
+-- Rollback migration for finding archives
+
+DROP TABLE IF EXISTS finding_archives CASCADE;
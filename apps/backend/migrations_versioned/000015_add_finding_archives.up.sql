@@ -0,0 +1,28 @@
+-- Migration: 000015_add_finding_archives
+-- Description: Manifest of scan run findings exported to object storage
+
+CREATE TABLE IF NOT EXISTS finding_archives (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID,
+    scan_run_id UUID NOT NULL REFERENCES scan_runs(id) ON DELETE CASCADE,
+    storage_provider VARCHAR(20) NOT NULL,
+    bucket VARCHAR(255) NOT NULL,
+    object_key TEXT NOT NULL,
+    manifest_key TEXT NOT NULL,
+    format VARCHAR(20) NOT NULL DEFAULT 'jsonl.gz',
+    finding_count INTEGER NOT NULL DEFAULT 0,
+    classification_count INTEGER NOT NULL DEFAULT 0,
+    review_state_count INTEGER NOT NULL DEFAULT 0,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    checksum_sha256 VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'archived',
+    archived_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    archived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    restored_at TIMESTAMP,
+    CONSTRAINT finding_archives_status_check CHECK (status IN ('archived', 'restored'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_finding_archives_scan_run ON finding_archives(scan_run_id);
+CREATE INDEX IF NOT EXISTS idx_finding_archives_tenant ON finding_archives(tenant_id, archived_at DESC);
+
+COMMENT ON TABLE finding_archives IS 'Findings exported to object storage and removed from Postgres, restorable per scan run';
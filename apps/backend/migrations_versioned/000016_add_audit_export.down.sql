@@ -0,0 +1,12 @@
+-- Rollback migration for audit export
+
+DROP TABLE IF EXISTS audit_export_cursors;
+DROP INDEX IF EXISTS idx_audit_created_id;
+
+ALTER TABLE audit_logs ALTER COLUMN resource_id TYPE UUID
+    USING CASE WHEN resource_id ~* '^[0-9a-f]{8}-([0-9a-f]{4}-){3}[0-9a-f]{12}$' THEN resource_id::uuid END;
+ALTER TABLE audit_logs ALTER COLUMN event_type DROP DEFAULT;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS result;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,24 @@
+-- Migration: 000016_add_audit_export
+-- Description: Align audit_logs with the application audit writers and track SIEM export progress
+
+-- Columns written by the application audit loggers
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS tenant_id UUID;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45);
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS user_agent TEXT;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS result VARCHAR(20);
+ALTER TABLE audit_logs ALTER COLUMN event_type SET DEFAULT 'application';
+ALTER TABLE audit_logs ALTER COLUMN resource_id TYPE VARCHAR(255) USING resource_id::text;
+
+CREATE INDEX IF NOT EXISTS idx_audit_created_id ON audit_logs(created_at, id);
+
+-- Last audit event delivered to each export sink
+CREATE TABLE IF NOT EXISTS audit_export_cursors (
+    sink_name VARCHAR(100) PRIMARY KEY,
+    last_created_at TIMESTAMP NOT NULL,
+    last_event_id UUID NOT NULL,
+    exported_count BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE audit_export_cursors IS 'Per-sink high-water mark of audit events forwarded to SIEM collectors';
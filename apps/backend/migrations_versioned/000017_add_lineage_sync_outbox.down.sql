@@ -0,0 +1,3 @@
+-- Rollback migration for lineage sync outbox
+
+DROP TABLE IF EXISTS lineage_sync_outbox;
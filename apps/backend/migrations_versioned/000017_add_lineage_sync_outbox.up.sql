@@ -0,0 +1,19 @@
+-- Migration: 000017_add_lineage_sync_outbox
+-- Description: Defer lineage syncs while the Neo4j circuit breaker is open
+
+CREATE TABLE IF NOT EXISTS lineage_sync_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    reason TEXT,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_lineage_sync_outbox_asset UNIQUE (asset_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_lineage_sync_outbox_created ON lineage_sync_outbox(created_at);
+
+COMMENT ON TABLE lineage_sync_outbox IS 'Assets whose Neo4j lineage sync was deferred while the graph database was unavailable';
+COMMENT ON COLUMN lineage_sync_outbox.requested_at IS 'Latest deferral; a row re-requested during a drain is kept for the next pass';
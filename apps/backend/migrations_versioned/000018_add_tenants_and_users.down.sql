@@ -0,0 +1,4 @@
+-- Rollback migration for tenants and users
+
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS tenants;
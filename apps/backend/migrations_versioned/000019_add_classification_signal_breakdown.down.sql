@@ -0,0 +1,3 @@
+-- Rollback migration for classification signal breakdown
+
+ALTER TABLE classifications DROP COLUMN IF EXISTS signal_breakdown;
@@ -0,0 +1,6 @@
+-- Migration: 000019_add_classification_signal_breakdown
+-- Description: Persist the per-signal scores behind each classification for explainability
+
+ALTER TABLE classifications ADD COLUMN IF NOT EXISTS signal_breakdown JSONB;
+
+COMMENT ON COLUMN classifications.signal_breakdown IS 'Per-signal raw score, weight and explanation recorded when the finding was classified';
@@ -0,0 +1,8 @@
+-- Rollback migration for consent registry
+
+DROP VIEW IF EXISTS consent_basis_gaps;
+DROP VIEW IF EXISTS consent_policy_validity;
+DROP TRIGGER IF EXISTS update_consent_policies_updated_at ON consent_policies;
+DROP TABLE IF EXISTS pii_consent_mappings;
+DROP TABLE IF EXISTS consent_artifacts;
+DROP TABLE IF EXISTS consent_policies;
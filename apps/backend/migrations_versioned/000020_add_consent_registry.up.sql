@@ -0,0 +1,93 @@
+-- Migration: 000020_add_consent_registry
+-- Description: Register consent policies and artifacts and map PII categories to their consent basis
+
+CREATE TABLE IF NOT EXISTS consent_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    principal_category VARCHAR(100) NOT NULL,   -- 'customer', 'employee', 'vendor', ...
+    purpose VARCHAR(255) NOT NULL,
+    lawful_basis VARCHAR(50) NOT NULL,          -- 'explicit', 'legitimate_interest', 'contractual', 'legal_obligation'
+    description TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_consent_policies_category_purpose UNIQUE (tenant_id, principal_category, purpose)
+);
+
+CREATE TABLE IF NOT EXISTS consent_artifacts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    policy_id UUID NOT NULL REFERENCES consent_policies(id) ON DELETE CASCADE,
+    artifact_type VARCHAR(50) NOT NULL,         -- 'consent_form', 'privacy_notice', 'contract', 'dpa', ...
+    reference TEXT NOT NULL,                    -- Document URI or identifier in the consent manager
+    version VARCHAR(50) NOT NULL DEFAULT '1',
+    collected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    withdrawn_at TIMESTAMP,
+    withdrawal_reason TEXT,
+    metadata JSONB,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_consent_artifacts_policy ON consent_artifacts(policy_id);
+
+CREATE TABLE IF NOT EXISTS pii_consent_mappings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    pii_type VARCHAR(100) NOT NULL,
+    policy_id UUID NOT NULL REFERENCES consent_policies(id) ON DELETE CASCADE,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_pii_consent_mappings UNIQUE (tenant_id, pii_type, policy_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pii_consent_mappings_policy ON pii_consent_mappings(policy_id);
+
+-- A policy is a valid consent basis while it is active and, for explicit consent,
+-- backed by at least one artifact that has neither expired nor been withdrawn
+CREATE OR REPLACE VIEW consent_policy_validity AS
+SELECT p.id AS policy_id,
+       p.tenant_id,
+       p.is_active AND (
+           p.lawful_basis <> 'explicit' OR EXISTS (
+               SELECT 1 FROM consent_artifacts ca
+               WHERE ca.policy_id = p.id
+                 AND ca.withdrawn_at IS NULL
+                 AND (ca.expires_at IS NULL OR ca.expires_at > NOW())
+           )
+       ) AS is_valid
+FROM consent_policies p;
+
+-- Stores holding consent-requiring PII without a valid mapped consent basis
+CREATE OR REPLACE VIEW consent_basis_gaps AS
+SELECT f.tenant_id,
+       f.asset_id,
+       a.name AS asset_name,
+       a.path AS asset_path,
+       a.data_source,
+       COALESCE(NULLIF(c.sub_category, ''), f.pattern_name) AS pii_type,
+       COUNT(DISTINCT f.id) AS finding_count,
+       MAX(f.created_at) AS last_seen_at
+FROM findings f
+JOIN classifications c ON c.finding_id = f.id
+JOIN assets a ON a.id = f.asset_id
+WHERE c.requires_consent = TRUE
+  AND f.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM pii_consent_mappings m
+      JOIN consent_policy_validity v ON v.policy_id = m.policy_id
+      WHERE m.tenant_id = f.tenant_id
+        AND m.pii_type = COALESCE(NULLIF(c.sub_category, ''), f.pattern_name)
+        AND v.is_valid
+  )
+GROUP BY f.tenant_id, f.asset_id, a.name, a.path, a.data_source, COALESCE(NULLIF(c.sub_category, ''), f.pattern_name);
+
+CREATE TRIGGER update_consent_policies_updated_at BEFORE UPDATE ON consent_policies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE consent_policies IS 'Consent basis per data principal category and processing purpose';
+COMMENT ON TABLE consent_artifacts IS 'Evidence backing a consent policy, such as signed forms or published notices';
+COMMENT ON TABLE pii_consent_mappings IS 'Links PII categories to the consent policies that cover them';
+COMMENT ON VIEW consent_basis_gaps IS 'Assets holding consent-requiring PII with no valid mapped consent basis';
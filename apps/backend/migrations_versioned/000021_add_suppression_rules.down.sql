@@ -0,0 +1,4 @@
+-- Rollback migration for suppression rules
+
+DROP TRIGGER IF EXISTS update_suppression_rules_updated_at ON suppression_rules;
+DROP TABLE IF EXISTS suppression_rules CASCADE;
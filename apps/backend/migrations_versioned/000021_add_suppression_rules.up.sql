@@ -0,0 +1,29 @@
+-- Migration: 000021_add_suppression_rules
+-- Description: Admin-defined rules that suppress a pattern's findings under matching paths or hosts at ingestion
+
+CREATE TABLE IF NOT EXISTS suppression_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    pattern_name VARCHAR(100) NOT NULL,         -- e.g. 'EMAIL_ADDRESS'
+    path_glob TEXT NOT NULL DEFAULT '',         -- e.g. '/var/log/**'; empty matches any path
+    host_glob TEXT NOT NULL DEFAULT '',         -- e.g. 'staging-*'; empty matches any host
+    reason TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    expires_at TIMESTAMP,
+    hit_count BIGINT NOT NULL DEFAULT 0,
+    last_matched_at TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_suppression_rules_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_suppression_rules_scoped CHECK (path_glob <> '' OR host_glob <> '')
+);
+
+CREATE INDEX IF NOT EXISTS idx_suppression_rules_active ON suppression_rules(tenant_id) WHERE is_active;
+
+CREATE TRIGGER update_suppression_rules_updated_at BEFORE UPDATE ON suppression_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE suppression_rules IS 'Pattern-level suppressions scoped by path or host glob, applied before findings are persisted';
+COMMENT ON COLUMN suppression_rules.hit_count IS 'Findings suppressed by this rule across all ingestions';
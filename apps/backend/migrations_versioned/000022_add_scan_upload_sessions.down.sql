@@ -0,0 +1,5 @@
+-- Rollback migration for scan upload sessions
+
+DROP TRIGGER IF EXISTS update_scan_upload_sessions_updated_at ON scan_upload_sessions;
+DROP TABLE IF EXISTS scan_upload_chunks CASCADE;
+DROP TABLE IF EXISTS scan_upload_sessions CASCADE;
@@ -0,0 +1,39 @@
+-- Migration: 000022_add_scan_upload_sessions
+-- Description: Resumable chunked scan uploads; chunks are kept in Postgres until the session is finalized
+
+CREATE TABLE IF NOT EXISTS scan_upload_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    scan_id VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',  -- 'open', 'finalizing', 'completed'
+    expected_chunks INTEGER,                     -- Optional; finalize requires exactly this many chunks when set
+    scan_run_id UUID REFERENCES scan_runs(id) ON DELETE SET NULL,
+    last_error TEXT,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    CONSTRAINT chk_scan_upload_sessions_status CHECK (status IN ('open', 'finalizing', 'completed')),
+    CONSTRAINT chk_scan_upload_sessions_expected_chunks CHECK (expected_chunks IS NULL OR expected_chunks > 0)
+);
+
+CREATE TABLE IF NOT EXISTS scan_upload_chunks (
+    session_id UUID NOT NULL REFERENCES scan_upload_sessions(id) ON DELETE CASCADE,
+    chunk_number INTEGER NOT NULL,
+    findings JSONB NOT NULL,
+    finding_count INTEGER NOT NULL,
+    checksum VARCHAR(64) NOT NULL,               -- SHA-256 of the stored findings
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (session_id, chunk_number),
+    CONSTRAINT chk_scan_upload_chunks_number CHECK (chunk_number > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scan_upload_sessions_tenant ON scan_upload_sessions(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scan_upload_sessions_expiry ON scan_upload_sessions(expires_at) WHERE status <> 'completed';
+
+CREATE TRIGGER update_scan_upload_sessions_updated_at BEFORE UPDATE ON scan_upload_sessions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE scan_upload_sessions IS 'Resumable scan uploads; scanners PUT numbered chunks and finalize to ingest them as one scan';
+COMMENT ON TABLE scan_upload_chunks IS 'Findings of one uploaded chunk, removed once the session completes';
@@ -0,0 +1,3 @@
+-- Rollback migration for finding observations
+
+DROP TABLE IF EXISTS finding_observations CASCADE;
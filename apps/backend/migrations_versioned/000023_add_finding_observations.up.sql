@@ -0,0 +1,32 @@
+-- Migration: 000023_add_finding_observations
+-- Description: Links findings to every scan run that observed them so exposure age can be measured
+
+-- An exposure is identified across scans by (asset_id, pattern_name, value_hash); each scan
+-- creates a new finding row for it and records one observation here. Observations outlive
+-- their finding row (archiving, merge de-duplication) so exposure history is kept.
+CREATE TABLE IF NOT EXISTS finding_observations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID,
+    finding_id UUID REFERENCES findings(id) ON DELETE SET NULL,
+    scan_run_id UUID NOT NULL REFERENCES scan_runs(id) ON DELETE CASCADE,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    pattern_name VARCHAR(255) NOT NULL,
+    value_hash VARCHAR(64) NOT NULL,             -- SHA-256 of the normalized first match
+    observed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_finding_observations_finding_scan UNIQUE (finding_id, scan_run_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_finding_observations_exposure
+    ON finding_observations(asset_id, pattern_name, value_hash, observed_at);
+CREATE INDEX IF NOT EXISTS idx_finding_observations_tenant ON finding_observations(tenant_id, pattern_name);
+CREATE INDEX IF NOT EXISTS idx_finding_observations_scan_run ON finding_observations(scan_run_id);
+
+-- Backfill one observation per existing finding, hashed the same way as ingestion
+INSERT INTO finding_observations (tenant_id, finding_id, scan_run_id, asset_id, pattern_name, value_hash, observed_at)
+SELECT f.tenant_id, f.id, f.scan_run_id, f.asset_id, f.pattern_name,
+    encode(sha256(convert_to(lower(replace(replace(COALESCE(f.matches[1], ''), ' ', ''), '-', '')), 'UTF8')), 'hex'),
+    f.created_at
+FROM findings f
+ON CONFLICT (finding_id, scan_run_id) DO NOTHING;
+
+COMMENT ON TABLE finding_observations IS 'One row per scan run that observed a finding; answers how long an exposure has existed';
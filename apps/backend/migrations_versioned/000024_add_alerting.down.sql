@@ -0,0 +1,7 @@
+-- Rollback migration for alerting
+
+DROP TRIGGER IF EXISTS update_alert_digests_updated_at ON alert_digests;
+DROP TRIGGER IF EXISTS update_alert_rules_updated_at ON alert_rules;
+DROP TABLE IF EXISTS alert_deliveries CASCADE;
+DROP TABLE IF EXISTS alert_digests CASCADE;
+DROP TABLE IF EXISTS alert_rules CASCADE;
@@ -0,0 +1,68 @@
+-- Migration: 000024_add_alerting
+-- Description: Alert rules emailed on matching new findings, scheduled digests and their delivery log
+
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    -- Finding filters; an empty array matches any value
+    severities TEXT[] NOT NULL DEFAULT '{}',
+    classification_types TEXT[] NOT NULL DEFAULT '{}',
+    environments TEXT[] NOT NULL DEFAULT '{}',
+    pattern_names TEXT[] NOT NULL DEFAULT '{}',
+    recipients TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    evaluated_until TIMESTAMP NOT NULL,          -- Findings created up to here have been evaluated
+    last_triggered_at TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_alert_rules_tenant_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_alert_rules_recipients CHECK (cardinality(recipients) > 0)
+);
+
+CREATE TABLE IF NOT EXISTS alert_digests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    frequency VARCHAR(10) NOT NULL,              -- 'daily', 'weekly'
+    recipients TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP NOT NULL,
+    last_sent_at TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_alert_digests_tenant_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_alert_digests_frequency CHECK (frequency IN ('daily', 'weekly')),
+    CONSTRAINT chk_alert_digests_recipients CHECK (cardinality(recipients) > 0)
+);
+
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    rule_id UUID REFERENCES alert_rules(id) ON DELETE SET NULL,
+    digest_id UUID REFERENCES alert_digests(id) ON DELETE SET NULL,
+    kind VARCHAR(10) NOT NULL,                   -- 'alert', 'digest'
+    recipients TEXT[] NOT NULL,
+    subject TEXT NOT NULL,
+    finding_count INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(10) NOT NULL,                 -- 'sent', 'failed', 'skipped'
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_alert_deliveries_kind CHECK (kind IN ('alert', 'digest')),
+    CONSTRAINT chk_alert_deliveries_status CHECK (status IN ('sent', 'failed', 'skipped'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_active ON alert_rules(evaluated_until) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_alert_digests_due ON alert_digests(next_run_at) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_alert_deliveries_tenant ON alert_deliveries(tenant_id, created_at DESC);
+
+CREATE TRIGGER update_alert_rules_updated_at BEFORE UPDATE ON alert_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_alert_digests_updated_at BEFORE UPDATE ON alert_digests
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE alert_rules IS 'User-defined filters emailed immediately when new findings match';
+COMMENT ON TABLE alert_digests IS 'Daily or weekly email summaries of new findings and remediation progress';
+COMMENT ON TABLE alert_deliveries IS 'Every alert and digest email attempted, with its outcome';
@@ -0,0 +1,14 @@
+-- Rollback migration for soft-delete trash
+
+DROP INDEX IF EXISTS idx_scan_runs_deleted_at;
+DROP INDEX IF EXISTS idx_scan_runs_trash_batch;
+DROP INDEX IF EXISTS idx_assets_trash_batch;
+DROP INDEX IF EXISTS idx_findings_trash_batch;
+
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS trash_batch_id;
+ALTER TABLE assets DROP COLUMN IF EXISTS trash_batch_id;
+ALTER TABLE findings DROP COLUMN IF EXISTS trash_batch_id;
+
+DROP TABLE IF EXISTS trash_batches CASCADE;
+
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,34 @@
+-- Migration: 000025_add_soft_delete_trash
+-- Description: Soft-delete scan runs alongside assets and findings, grouped into restorable trash batches
+
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS trash_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    scope VARCHAR(20) NOT NULL,                  -- 'scan_data', 'asset', 'scan_run'
+    resource_id UUID,                            -- Trashed asset or scan run; NULL for scan_data
+    asset_count INTEGER NOT NULL DEFAULT 0,
+    finding_count INTEGER NOT NULL DEFAULT 0,
+    scan_run_count INTEGER NOT NULL DEFAULT 0,
+    retained_count INTEGER NOT NULL DEFAULT 0,   -- Rows kept at purge because remediation or archive records reference them
+    deleted_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    restored_by VARCHAR(255),
+    restored_at TIMESTAMP,
+    purged_at TIMESTAMP,
+    CONSTRAINT chk_trash_batches_scope CHECK (scope IN ('scan_data', 'asset', 'scan_run'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_trash_batches_tenant ON trash_batches(tenant_id, deleted_at DESC);
+CREATE INDEX IF NOT EXISTS idx_trash_batches_pending ON trash_batches(deleted_at)
+    WHERE restored_at IS NULL AND purged_at IS NULL;
+
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS trash_batch_id UUID REFERENCES trash_batches(id) ON DELETE SET NULL;
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS trash_batch_id UUID REFERENCES trash_batches(id) ON DELETE SET NULL;
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS trash_batch_id UUID REFERENCES trash_batches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_findings_trash_batch ON findings(trash_batch_id) WHERE trash_batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_assets_trash_batch ON assets(trash_batch_id) WHERE trash_batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_scan_runs_trash_batch ON scan_runs(trash_batch_id) WHERE trash_batch_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_scan_runs_deleted_at ON scan_runs(deleted_at) WHERE deleted_at IS NOT NULL;
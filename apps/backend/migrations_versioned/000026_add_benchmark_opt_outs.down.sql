@@ -0,0 +1,3 @@
+-- Rollback migration for benchmark opt-outs
+
+DROP TABLE IF EXISTS benchmark_opt_outs CASCADE;
@@ -0,0 +1,8 @@
+-- Migration: 000026_add_benchmark_opt_outs
+-- Description: Tenants that opted out of contributing to cross-tenant benchmark baselines
+
+CREATE TABLE IF NOT EXISTS benchmark_opt_outs (
+    tenant_id UUID PRIMARY KEY,
+    opted_out_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    opted_out_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
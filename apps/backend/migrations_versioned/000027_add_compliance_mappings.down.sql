@@ -0,0 +1,3 @@
+-- Rollback migration for compliance category mappings
+
+DROP TABLE IF EXISTS compliance_mappings CASCADE;
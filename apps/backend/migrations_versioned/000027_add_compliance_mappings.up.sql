@@ -0,0 +1,15 @@
+-- Migration: 000027_add_compliance_mappings
+-- Description: Versioned per-tenant mappings from classifications and PII types to compliance framework categories
+
+CREATE TABLE IF NOT EXISTS compliance_mappings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    framework VARCHAR(20) NOT NULL CHECK (framework IN ('DPDPA', 'GDPR', 'CCPA')),
+    version INTEGER NOT NULL CHECK (version > 0),
+    classification_categories JSONB NOT NULL DEFAULT '{}',
+    pii_type_categories JSONB NOT NULL DEFAULT '{}',
+    comment TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant_id, framework, version)
+);
@@ -0,0 +1,6 @@
+-- Rollback migration for finding evidence
+
+DROP TRIGGER IF EXISTS queue_finding_evidence_object_deletion ON finding_evidence;
+DROP FUNCTION IF EXISTS queue_evidence_object_deletion();
+DROP TABLE IF EXISTS evidence_object_deletions CASCADE;
+DROP TABLE IF EXISTS finding_evidence CASCADE;
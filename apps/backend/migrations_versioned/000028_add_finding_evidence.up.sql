@@ -0,0 +1,52 @@
+-- Migration: 000028_add_finding_evidence
+-- Description: Evidence artifacts attached to findings, kept in object storage, and a queue of objects to remove once their evidence rows are deleted
+
+CREATE TABLE IF NOT EXISTS finding_evidence (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    finding_id UUID NOT NULL REFERENCES findings(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,                   -- 'screenshot', 'masked_excerpt', 'validation_proof', 'other'
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum_sha256 VARCHAR(64) NOT NULL,
+    storage_provider VARCHAR(10) NOT NULL,
+    bucket VARCHAR(255) NOT NULL DEFAULT '',
+    object_key TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    uploaded_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_finding_evidence_kind CHECK (kind IN ('screenshot', 'masked_excerpt', 'validation_proof', 'other')),
+    CONSTRAINT chk_finding_evidence_size CHECK (size_bytes > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_finding_evidence_finding ON finding_evidence(finding_id, created_at);
+
+-- Evidence rows disappear with their findings however those are purged (trash,
+-- archiving, asset merges), so the trigger below queues the object for removal
+CREATE TABLE IF NOT EXISTS evidence_object_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    storage_provider VARCHAR(10) NOT NULL,
+    bucket VARCHAR(255) NOT NULL DEFAULT '',
+    object_key TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    queued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_object_deletions_store ON evidence_object_deletions(storage_provider, bucket, attempts, id);
+
+CREATE OR REPLACE FUNCTION queue_evidence_object_deletion()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO evidence_object_deletions (storage_provider, bucket, object_key)
+    VALUES (OLD.storage_provider, OLD.bucket, OLD.object_key);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER queue_finding_evidence_object_deletion AFTER DELETE ON finding_evidence
+    FOR EACH ROW EXECUTE FUNCTION queue_evidence_object_deletion();
+
+COMMENT ON TABLE finding_evidence IS 'Screenshots, masked excerpts and validation proofs attached to findings';
+COMMENT ON TABLE evidence_object_deletions IS 'Object storage keys of deleted evidence, removed by the evidence cleanup worker';
@@ -0,0 +1,3 @@
+-- Rollback migration for finding match locations
+
+ALTER TABLE findings DROP COLUMN IF EXISTS match_locations;
@@ -0,0 +1,6 @@
+-- Migration: 000029_add_finding_match_locations
+-- Description: Per-match source locations on findings (line/column for files, table/field/primary key for databases) used by targeted remediation
+
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS match_locations JSONB;
+
+COMMENT ON COLUMN findings.match_locations IS 'Array of {match_index, line, column} or {match_index, table, field, pk_column, pk} entries, one per located match';
@@ -0,0 +1,3 @@
+-- Rollback migration for risk score history
+
+DROP TABLE IF EXISTS risk_score_history CASCADE;
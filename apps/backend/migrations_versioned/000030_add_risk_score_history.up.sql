@@ -0,0 +1,23 @@
+-- Migration: 000030_add_risk_score_history
+-- Description: History of asset risk score changes for trend charts and regression alerts
+
+CREATE TABLE IF NOT EXISTS risk_score_history (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    asset_id UUID NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL,
+    previous_score INTEGER,
+    cause VARCHAR(50) NOT NULL,                  -- 'baseline', 'scan_ingestion', 'asset_merge', 'recalculation'
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_score_history_asset ON risk_score_history(asset_id, computed_at);
+CREATE INDEX IF NOT EXISTS idx_risk_score_history_tenant ON risk_score_history(tenant_id, computed_at);
+
+-- Seed every asset with its current score so trends start from a known point
+INSERT INTO risk_score_history (tenant_id, asset_id, score, cause, computed_at)
+SELECT tenant_id, id, risk_score, 'baseline', updated_at
+FROM assets
+WHERE risk_score IS NOT NULL AND tenant_id IS NOT NULL;
+
+COMMENT ON TABLE risk_score_history IS 'One row per asset risk score change; written alongside the assets.risk_score update';
@@ -0,0 +1,4 @@
+-- Rollback migration for false-positive rule suggestions
+
+DROP TABLE IF EXISTS fp_learning CASCADE;
+DROP TABLE IF EXISTS fp_rule_suggestions CASCADE;
@@ -0,0 +1,56 @@
+-- Migration: 000031_add_fp_rule_suggestions
+-- Description: Suppression and FP-learning rules suggested by clustering false-positive feedback, pending admin approval
+
+CREATE TABLE IF NOT EXISTS fp_rule_suggestions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,                   -- 'suppression', 'fp_learning'
+    fingerprint TEXT NOT NULL,                   -- Identifies the cluster across analysis runs
+    pattern_name VARCHAR(255) NOT NULL,
+    path_prefix TEXT NOT NULL DEFAULT '',
+    token_shape VARCHAR(255) NOT NULL DEFAULT '',
+    false_positives INTEGER NOT NULL,
+    confirmed INTEGER NOT NULL DEFAULT 0,
+    asset_count INTEGER NOT NULL DEFAULT 0,
+    sample_finding_ids UUID[] NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    applied_rule_ids UUID[] NOT NULL DEFAULT '{}',
+    reviewed_by VARCHAR(255),
+    reviewed_at TIMESTAMP,
+    review_note TEXT NOT NULL DEFAULT '',
+    generated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_fp_rule_suggestions_kind CHECK (kind IN ('suppression', 'fp_learning')),
+    CONSTRAINT chk_fp_rule_suggestions_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT uq_fp_rule_suggestions_fingerprint UNIQUE (tenant_id, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fp_rule_suggestions_status ON fp_rule_suggestions(tenant_id, status, false_positives DESC);
+
+-- Learned false positives written by the FP learning service; approving an
+-- fp_learning suggestion adds one row per sampled finding
+CREATE TABLE IF NOT EXISTS fp_learning (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    asset_id UUID NOT NULL,
+    pattern_name VARCHAR(100) NOT NULL,
+    pii_type VARCHAR(50) NOT NULL DEFAULT '',
+    field_name VARCHAR(255) NOT NULL DEFAULT '',
+    field_path VARCHAR(500) NOT NULL DEFAULT '',
+    matched_value VARCHAR(500) NOT NULL DEFAULT '',
+    learning_type VARCHAR(50) NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    previous_value VARCHAR(500) NOT NULL DEFAULT '',
+    justification TEXT NOT NULL DEFAULT '',
+    source_finding_id UUID,
+    scan_run_id UUID,
+    expires_at TIMESTAMP,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_fp_learning_lookup ON fp_learning(tenant_id, pattern_name, is_active);
+
+COMMENT ON TABLE fp_rule_suggestions IS 'Rules proposed by the false-positive clustering job; applied only once an admin approves them';
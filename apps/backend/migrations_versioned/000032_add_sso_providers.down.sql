@@ -0,0 +1,3 @@
+-- Rollback migration for SSO providers
+
+DROP TABLE IF EXISTS sso_providers CASCADE;
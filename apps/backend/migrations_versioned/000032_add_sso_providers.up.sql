@@ -0,0 +1,24 @@
+-- Migration: 000032_add_sso_providers
+-- Description: Per-tenant OIDC single sign-on provider configuration
+
+CREATE TABLE IF NOT EXISTS sso_providers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL UNIQUE REFERENCES tenants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    issuer_url TEXT NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret_encrypted BYTEA,
+    redirect_url TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT ARRAY['openid', 'email', 'profile'],
+    role_claim VARCHAR(255) NOT NULL DEFAULT 'groups',
+    role_mapping JSONB NOT NULL DEFAULT '{}',
+    default_role VARCHAR(50) NOT NULL DEFAULT 'viewer',
+    allowed_domains TEXT[] NOT NULL DEFAULT '{}',
+    jit_provisioning BOOLEAN NOT NULL DEFAULT TRUE,
+    enforce_sso BOOLEAN NOT NULL DEFAULT FALSE,
+    break_glass_emails TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT sso_providers_default_role_check CHECK (default_role IN ('admin', 'auditor', 'operator', 'viewer'))
+);
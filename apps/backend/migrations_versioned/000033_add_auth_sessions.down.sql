@@ -0,0 +1,4 @@
+-- Rollback migration for auth sessions and revoked tokens
+
+DROP TABLE IF EXISTS revoked_tokens CASCADE;
+DROP TABLE IF EXISTS auth_sessions CASCADE;
@@ -0,0 +1,3 @@
+-- Rollback migration for discovered files
+
+DROP TABLE IF EXISTS discovered_files CASCADE;
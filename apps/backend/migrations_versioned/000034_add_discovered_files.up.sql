@@ -0,0 +1,15 @@
+-- Migration: 000034_add_discovered_files
+-- Description: Files and objects enumerated by discovery on S3 and filesystem connections, for scan coverage reporting
+
+CREATE TABLE IF NOT EXISTS discovered_files (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    connection_id UUID NOT NULL REFERENCES connections(id) ON DELETE CASCADE,
+    path TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    modified_at TIMESTAMPTZ,
+    discovered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_discovered_file UNIQUE (tenant_id, connection_id, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_discovered_files_connection ON discovered_files(tenant_id, connection_id);
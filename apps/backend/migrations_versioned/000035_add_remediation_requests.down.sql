@@ -0,0 +1,3 @@
+-- Rollback migration for remediation requests
+
+DROP TABLE IF EXISTS remediation_requests CASCADE;
@@ -0,0 +1,23 @@
+-- Migration: 000035_add_remediation_requests
+-- Description: Remediation requests awaiting a second approver (four-eyes principle)
+
+CREATE TABLE IF NOT EXISTS remediation_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID,
+    finding_ids TEXT[] NOT NULL,
+    action_type VARCHAR(100) NOT NULL,
+    impact INTEGER NOT NULL DEFAULT 0,
+    justification TEXT NOT NULL DEFAULT '',
+    status VARCHAR(30) NOT NULL DEFAULT 'pending_approval', -- 'pending_approval', 'approved', 'rejected', 'expired', 'executed', 'failed'
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    decided_by VARCHAR(255),
+    decided_at TIMESTAMP,
+    decision_comment TEXT NOT NULL DEFAULT '',
+    action_ids TEXT[] NOT NULL DEFAULT '{}',
+    errors TEXT[] NOT NULL DEFAULT '{}',
+    executed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_remediation_requests_status ON remediation_requests(tenant_id, status, requested_at DESC);
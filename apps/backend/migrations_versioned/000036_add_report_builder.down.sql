@@ -0,0 +1,5 @@
+-- Rollback migration for the report builder
+
+DROP TABLE IF EXISTS report_runs CASCADE;
+DROP TABLE IF EXISTS report_schedules CASCADE;
+DROP TABLE IF EXISTS report_templates CASCADE;
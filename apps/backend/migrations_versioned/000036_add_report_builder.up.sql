@@ -0,0 +1,64 @@
+-- Migration: 000036_add_report_builder
+-- Description: Custom findings report templates, their delivery schedules and the run log
+
+CREATE TABLE IF NOT EXISTS report_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    filters JSONB NOT NULL DEFAULT '{}',
+    group_by TEXT[] NOT NULL DEFAULT '{}',
+    columns TEXT[] NOT NULL DEFAULT '{}',
+    chart JSONB,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_report_templates_tenant_name UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS report_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    template_id UUID NOT NULL REFERENCES report_templates(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    frequency VARCHAR(10) NOT NULL,              -- 'daily', 'weekly', 'monthly'
+    format VARCHAR(10) NOT NULL,                 -- 'csv', 'json', 'pdf'
+    targets JSONB NOT NULL,                      -- [{type: email|webhook, recipients, url, secret}]
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_run_at TIMESTAMP NOT NULL,
+    last_run_at TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_report_schedules_tenant_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_report_schedules_frequency CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    CONSTRAINT chk_report_schedules_format CHECK (format IN ('csv', 'json', 'pdf'))
+);
+
+CREATE TABLE IF NOT EXISTS report_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    schedule_id UUID REFERENCES report_schedules(id) ON DELETE SET NULL,
+    template_id UUID REFERENCES report_templates(id) ON DELETE SET NULL,
+    trigger VARCHAR(10) NOT NULL,                -- 'schedule', 'manual'
+    format VARCHAR(10) NOT NULL,
+    row_count INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(10) NOT NULL,                 -- 'delivered', 'partial', 'failed', 'skipped'
+    error TEXT,
+    deliveries JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_report_runs_trigger CHECK (trigger IN ('schedule', 'manual')),
+    CONSTRAINT chk_report_runs_status CHECK (status IN ('delivered', 'partial', 'failed', 'skipped'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_schedules_due ON report_schedules(next_run_at) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_report_runs_tenant ON report_runs(tenant_id, created_at DESC);
+
+CREATE TRIGGER update_report_templates_updated_at BEFORE UPDATE ON report_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+CREATE TRIGGER update_report_schedules_updated_at BEFORE UPDATE ON report_schedules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE report_templates IS 'User-defined findings reports: filters, groupings, columns and chart';
+COMMENT ON TABLE report_schedules IS 'Recurring generation of a report template delivered to email or webhook targets';
+COMMENT ON TABLE report_runs IS 'Every scheduled or manually triggered report run, with per-target outcomes';
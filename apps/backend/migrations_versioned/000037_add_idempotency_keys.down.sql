@@ -0,0 +1,3 @@
+-- Rollback migration for idempotency keys
+
+DROP TABLE IF EXISTS idempotency_keys CASCADE;
@@ -0,0 +1,17 @@
+-- Migration: 000037_add_idempotency_keys
+-- Description: Responses of ingestion and remediation requests keyed by their Idempotency-Key header
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    tenant_id UUID NOT NULL,
+    endpoint VARCHAR(255) NOT NULL,              -- Route the key was sent to, e.g. 'POST /api/v1/remediation/execute'
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64),                    -- SHA-256 of the first request; NULL while it is processed
+    status_code INTEGER,                         -- NULL while the first request is processed
+    content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (tenant_id, endpoint, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,3 @@
+-- Rollback migration for the value occurrence index
+
+DROP INDEX IF EXISTS idx_finding_observations_value;
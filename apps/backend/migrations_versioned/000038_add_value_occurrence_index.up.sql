@@ -0,0 +1,5 @@
+-- Migration: 000038_add_value_occurrence_index
+-- Description: Finds every asset a normalized PII value was observed in
+
+CREATE INDEX IF NOT EXISTS idx_finding_observations_value
+    ON finding_observations(tenant_id, value_hash, asset_id);
@@ -0,0 +1,3 @@
+-- Rollback migration for classification shadow results
+
+DROP TABLE IF EXISTS classification_shadow_results;
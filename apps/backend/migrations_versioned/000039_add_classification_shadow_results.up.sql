@@ -0,0 +1,27 @@
+-- Migration: 000039_add_classification_shadow_results
+-- Description: Outcomes of a candidate classifier version run alongside the primary one
+
+CREATE TABLE IF NOT EXISTS classification_shadow_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,
+    scan_run_id UUID,
+    finding_id UUID REFERENCES findings(id) ON DELETE SET NULL, -- NULL when the primary engine discarded the finding
+    pattern_name VARCHAR(255) NOT NULL,
+    primary_version VARCHAR(100) NOT NULL,
+    primary_classification VARCHAR(100) NOT NULL,
+    primary_sub_category VARCHAR(100),
+    primary_confidence_level VARCHAR(50),
+    primary_score DECIMAL(5,2) NOT NULL,
+    primary_kept BOOLEAN NOT NULL,
+    shadow_version VARCHAR(100) NOT NULL,
+    shadow_classification VARCHAR(100) NOT NULL,
+    shadow_sub_category VARCHAR(100),
+    shadow_confidence_level VARCHAR(50),
+    shadow_score DECIMAL(5,2) NOT NULL,
+    shadow_kept BOOLEAN NOT NULL,
+    divergences TEXT[] NOT NULL DEFAULT '{}', -- Outcome fields that differ; empty when the engines agree
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_classification_shadow_version
+    ON classification_shadow_results(tenant_id, shadow_version, created_at);
@@ -0,0 +1,3 @@
+-- Rollback migration for the audit_logs action index
+
+DROP INDEX IF EXISTS idx_audit_tenant_action_created;
@@ -0,0 +1,5 @@
+-- Migration: 000040_add_audit_logs_action_index
+-- Description: Per-tenant lookups of one audit action over time, used by the PII access report
+
+CREATE INDEX IF NOT EXISTS idx_audit_tenant_action_created
+    ON audit_logs(tenant_id, action, created_at);
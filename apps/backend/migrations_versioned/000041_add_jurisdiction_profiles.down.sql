@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS tenant_jurisdictions;
+DROP TABLE IF EXISTS jurisdiction_profiles;
+
+DELETE FROM compliance_mappings WHERE framework = 'PDPA';
+ALTER TABLE compliance_mappings DROP CONSTRAINT IF EXISTS compliance_mappings_framework_check;
+ALTER TABLE compliance_mappings ADD CONSTRAINT compliance_mappings_framework_check
+    CHECK (framework IN ('DPDPA', 'GDPR', 'CCPA'));
@@ -0,0 +1,67 @@
+-- Migration: 000041_add_jurisdiction_profiles
+-- Description: Jurisdiction PII scope profiles (allowed PII types, validators, framework categories) selectable per tenant
+
+CREATE TABLE IF NOT EXISTS jurisdiction_profiles (
+    code VARCHAR(16) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    framework VARCHAR(20) NOT NULL,
+    pii_types JSONB NOT NULL DEFAULT '{}',
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS tenant_jurisdictions (
+    tenant_id UUID PRIMARY KEY,
+    profile_code VARCHAR(16) NOT NULL REFERENCES jurisdiction_profiles(code),
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE compliance_mappings DROP CONSTRAINT IF EXISTS compliance_mappings_framework_check;
+ALTER TABLE compliance_mappings ADD CONSTRAINT compliance_mappings_framework_check
+    CHECK (framework IN ('DPDPA', 'GDPR', 'CCPA', 'PDPA'));
+
+INSERT INTO jurisdiction_profiles (code, name, framework, pii_types) VALUES
+('IN', 'India', 'DPDPA', '{
+    "IN_AADHAAR":         {"description": "Aadhaar (UID)", "validator": "aadhaar", "category": "Sensitive Personal Data", "requires_consent": true},
+    "IN_PAN":             {"description": "Permanent Account Number", "category": "Financial Identifier", "requires_consent": true},
+    "IN_PASSPORT":        {"description": "Indian Passport Number", "category": "Government Identifier", "requires_consent": true},
+    "CREDIT_CARD":        {"description": "Credit/Debit Card", "category": "Financial Data", "requires_consent": true},
+    "IN_UPI":             {"description": "UPI ID", "category": "Financial Identifier"},
+    "IN_IFSC":            {"description": "IFSC Code", "category": "Financial Identifier"},
+    "IN_BANK_ACCOUNT":    {"description": "Bank Account Number", "category": "Financial Data"},
+    "IN_PHONE":           {"description": "Indian Phone (10 digit)", "category": "Contact Information"},
+    "EMAIL_ADDRESS":      {"description": "Email", "category": "Contact Information"},
+    "IN_VOTER_ID":        {"description": "Voter ID (EPIC)", "category": "Government Identifier"},
+    "IN_DRIVING_LICENSE": {"description": "Driving License (India)", "category": "Government Identifier", "requires_consent": true}
+}'),
+('EU', 'European Union', 'GDPR', '{
+    "EMAIL_ADDRESS":  {"description": "Email", "validator": "email", "category": "Contact Data"},
+    "PHONE_NUMBER":   {"description": "International Phone", "validator": "phone", "category": "Contact Data"},
+    "CREDIT_CARD":    {"description": "Credit/Debit Card", "validator": "luhn", "category": "Financial Data", "requires_consent": true},
+    "IBAN_CODE":      {"description": "IBAN", "category": "Financial Data", "requires_consent": true},
+    "EU_PASSPORT":    {"description": "Passport Number", "category": "Identification Data", "requires_consent": true},
+    "EU_NATIONAL_ID": {"description": "National Identity Number", "category": "Identification Data", "requires_consent": true},
+    "IP_ADDRESS":     {"description": "IP Address", "category": "Online Identifier"},
+    "UK_NHS":         {"description": "NHS Number", "category": "Special Category Data", "requires_consent": true}
+}'),
+('US', 'United States', 'CCPA', '{
+    "US_SSN":            {"description": "Social Security Number", "validator": "ssn", "category": "Sensitive Personal Information", "requires_consent": true},
+    "US_ITIN":           {"description": "Individual Taxpayer Identification Number", "category": "Sensitive Personal Information", "requires_consent": true},
+    "US_PASSPORT":       {"description": "US Passport Number", "category": "Sensitive Personal Information", "requires_consent": true},
+    "US_DRIVER_LICENSE": {"description": "Driver License", "category": "Sensitive Personal Information", "requires_consent": true},
+    "US_BANK_NUMBER":    {"description": "Bank Account Number", "category": "Sensitive Personal Information", "requires_consent": true},
+    "CREDIT_CARD":       {"description": "Credit/Debit Card", "validator": "luhn", "category": "Sensitive Personal Information", "requires_consent": true},
+    "EMAIL_ADDRESS":     {"description": "Email", "validator": "email", "category": "Identifiers"},
+    "PHONE_NUMBER":      {"description": "US Phone (10 digit)", "validator": "us_phone", "category": "Identifiers"}
+}'),
+('SEA', 'Southeast Asia', 'PDPA', '{
+    "SG_NRIC_FIN":   {"description": "Singapore NRIC/FIN", "category": "National Identifier", "requires_consent": true},
+    "MY_NRIC":       {"description": "Malaysia MyKad Number", "category": "National Identifier", "requires_consent": true},
+    "TH_TNIN":       {"description": "Thai National ID Number", "category": "National Identifier", "requires_consent": true},
+    "ID_NIK":        {"description": "Indonesia NIK", "category": "National Identifier", "requires_consent": true},
+    "CREDIT_CARD":   {"description": "Credit/Debit Card", "validator": "luhn", "category": "Financial Data", "requires_consent": true},
+    "EMAIL_ADDRESS": {"description": "Email", "validator": "email", "category": "Contact Information"},
+    "PHONE_NUMBER":  {"description": "International Phone", "validator": "phone", "category": "Contact Information"}
+}')
+ON CONFLICT (code) DO NOTHING;
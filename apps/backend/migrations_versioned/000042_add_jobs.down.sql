@@ -0,0 +1,3 @@
+-- Rollback migration for background jobs
+
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,3 @@
+-- Rollback migration for review state versions
+
+ALTER TABLE review_states DROP COLUMN IF EXISTS version;
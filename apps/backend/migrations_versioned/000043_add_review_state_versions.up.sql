@@ -0,0 +1,6 @@
+-- Migration: 000043_add_review_state_versions
+-- Description: Version counter on review states so concurrent reviews of a finding conflict instead of overwriting
+
+ALTER TABLE review_states ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN review_states.version IS 'Incremented on every update; reviewers send the version they saw (If-Match) and stale updates are rejected with 409';
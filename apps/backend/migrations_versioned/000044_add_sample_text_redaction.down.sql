@@ -0,0 +1,6 @@
+-- Rollback migration for sample text redaction; masked samples are not restored
+
+DROP TABLE IF EXISTS tenant_sample_text_policies;
+DROP INDEX IF EXISTS idx_findings_sample_text_unredacted;
+ALTER TABLE findings DROP COLUMN IF EXISTS sample_text_redacted;
+ALTER TABLE findings DROP COLUMN IF EXISTS sample_text_sha256;
@@ -0,0 +1,25 @@
+-- Migration: 000044_add_sample_text_redaction
+-- Description: Store finding sample text masked with a SHA-256 of the raw excerpt; tenants may opt into full storage
+
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS sample_text_sha256 CHAR(64);
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS sample_text_redacted BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Existing samples keep a hash of what the scanner saw; the sample_text.redact job masks them afterwards
+UPDATE findings
+SET sample_text_sha256 = encode(sha256(convert_to(sample_text, 'UTF8')), 'hex')
+WHERE sample_text IS NOT NULL AND sample_text <> '' AND sample_text_sha256 IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_findings_sample_text_unredacted
+    ON findings(tenant_id)
+    WHERE sample_text_redacted = FALSE AND sample_text <> '';
+
+CREATE TABLE IF NOT EXISTS tenant_sample_text_policies (
+    tenant_id UUID PRIMARY KEY,
+    store_full BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON COLUMN findings.sample_text_sha256 IS 'SHA-256 of the sample text as scanned, before redaction';
+COMMENT ON COLUMN findings.sample_text_redacted IS 'The redaction policy has been applied to sample_text';
+COMMENT ON TABLE tenant_sample_text_policies IS 'Tenants that opted into storing full sample text instead of the SAMPLE_TEXT_POLICY default';
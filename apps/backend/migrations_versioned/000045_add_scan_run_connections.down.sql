@@ -0,0 +1,4 @@
+-- Rollback migration for scan run connections
+
+DROP INDEX IF EXISTS idx_scan_runs_connection;
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS connection_id;
@@ -0,0 +1,15 @@
+-- Migration: 000045_add_scan_run_connections
+-- Description: Link scan runs to the connection they scanned, for per-connection scan history and coverage
+
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS connection_id UUID REFERENCES connections(id) ON DELETE SET NULL;
+
+-- Existing scan runs are linked by profile name where exactly one connection has it
+UPDATE scan_runs s
+SET connection_id = c.id
+FROM connections c
+WHERE s.connection_id IS NULL
+  AND s.profile_name = c.profile_name
+  AND (SELECT COUNT(*) FROM connections c2 WHERE c2.profile_name = c.profile_name) = 1;
+
+CREATE INDEX IF NOT EXISTS idx_scan_runs_connection
+    ON scan_runs(connection_id, scan_started_at DESC) WHERE connection_id IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Rollback migration for policy rules
+
+DROP TABLE IF EXISTS policy_violations CASCADE;
+DROP TABLE IF EXISTS policy_rules CASCADE;
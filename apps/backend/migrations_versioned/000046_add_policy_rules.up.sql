@@ -0,0 +1,43 @@
+-- Migration: 000046_add_policy_rules
+-- Description: Tenant policy-as-code compliance rules and the findings that violate them
+
+CREATE TABLE IF NOT EXISTS policy_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    expression TEXT NOT NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'high',
+    webhook_url TEXT,
+    webhook_secret_encrypted BYTEA,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_evaluation_at TIMESTAMP NOT NULL,
+    last_evaluated_at TIMESTAMP,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_policy_rules_tenant_name UNIQUE (tenant_id, name),
+    CONSTRAINT chk_policy_rules_severity CHECK (severity IN ('critical', 'high', 'medium', 'low'))
+);
+
+CREATE TABLE IF NOT EXISTS policy_violations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    rule_id UUID NOT NULL REFERENCES policy_rules(id) ON DELETE CASCADE,
+    finding_id UUID NOT NULL REFERENCES findings(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'open',  -- 'open', 'resolved'
+    first_detected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_evaluated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+    CONSTRAINT uq_policy_violations_rule_finding UNIQUE (rule_id, finding_id),
+    CONSTRAINT chk_policy_violations_status CHECK (status IN ('open', 'resolved'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_policy_rules_due ON policy_rules(next_evaluation_at) WHERE is_active;
+CREATE INDEX IF NOT EXISTS idx_policy_violations_tenant ON policy_violations(tenant_id, status, first_detected_at DESC);
+
+CREATE TRIGGER update_policy_rules_updated_at BEFORE UPDATE ON policy_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE policy_rules IS 'Tenant compliance rules written as expressions over finding attributes, evaluated nightly';
+COMMENT ON TABLE policy_violations IS 'Findings matching a policy rule; resolved when a later evaluation no longer matches them';
@@ -0,0 +1,4 @@
+-- Rollback migration for review priorities
+
+DROP TABLE IF EXISTS finding_review_priorities CASCADE;
+DROP TABLE IF EXISTS review_priority_policies CASCADE;
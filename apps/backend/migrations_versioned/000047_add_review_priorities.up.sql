@@ -0,0 +1,31 @@
+-- Migration: 000047_add_review_priorities
+-- Description: Tenant weighting of the review queue and the priority score of each pending finding
+
+CREATE TABLE IF NOT EXISTS review_priority_policies (
+    tenant_id UUID PRIMARY KEY,
+    risk_weight DOUBLE PRECISION NOT NULL,
+    pii_weight DOUBLE PRECISION NOT NULL,
+    environment_weight DOUBLE PRECISION NOT NULL,
+    exposure_weight DOUBLE PRECISION NOT NULL,
+    pii_criticality JSONB NOT NULL DEFAULT '{}',   -- PII type -> 0-100, overriding the built-in criticality
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS finding_review_priorities (
+    finding_id UUID PRIMARY KEY REFERENCES findings(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    risk_score DOUBLE PRECISION NOT NULL,
+    pii_score DOUBLE PRECISION NOT NULL,
+    environment_score DOUBLE PRECISION NOT NULL,
+    exposure_score DOUBLE PRECISION NOT NULL,
+    pii_type VARCHAR(100) NOT NULL,
+    sharing_assets INT NOT NULL DEFAULT 0,
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_finding_review_priorities_queue ON finding_review_priorities(tenant_id, score DESC);
+
+COMMENT ON TABLE review_priority_policies IS 'Tenant weights for ordering the review queue; findings scored before updated_at are rescored';
+COMMENT ON TABLE finding_review_priorities IS 'Review priority of findings awaiting review, 0-100; components are kept to explain the order';
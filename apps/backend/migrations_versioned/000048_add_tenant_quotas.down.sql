@@ -0,0 +1,4 @@
+-- Rollback migration for tenant quotas
+
+DROP INDEX IF EXISTS idx_scan_runs_tenant_created;
+DROP TABLE IF EXISTS tenant_quotas CASCADE;
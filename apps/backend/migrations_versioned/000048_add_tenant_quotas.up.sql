@@ -0,0 +1,16 @@
+-- Migration: 000048_add_tenant_quotas
+-- Description: Per-tenant overrides of the ingestion quotas configured by QUOTA_* settings
+
+CREATE TABLE IF NOT EXISTS tenant_quotas (
+    tenant_id UUID PRIMARY KEY,
+    max_findings INT CHECK (max_findings >= 0),                  -- NULL keeps QUOTA_MAX_FINDINGS; 0 is unlimited
+    max_scan_runs_per_day INT CHECK (max_scan_runs_per_day >= 0), -- NULL keeps QUOTA_MAX_SCAN_RUNS_PER_DAY
+    overage_behavior VARCHAR(20) CHECK (overage_behavior IN ('reject', 'downsample')),
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Daily scan run counts are read on every ingestion
+CREATE INDEX IF NOT EXISTS idx_scan_runs_tenant_created ON scan_runs(tenant_id, created_at);
+
+COMMENT ON TABLE tenant_quotas IS 'Tenant overrides of the default ingestion quotas; NULL columns fall back to the configured defaults';
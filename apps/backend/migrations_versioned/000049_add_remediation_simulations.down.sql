@@ -0,0 +1,4 @@
+-- Rollback migration for remediation simulations
+
+DROP INDEX IF EXISTS idx_remediation_simulations_finding;
+DROP TABLE IF EXISTS remediation_simulations CASCADE;
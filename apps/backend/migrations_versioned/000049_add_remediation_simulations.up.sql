@@ -0,0 +1,16 @@
+-- Migration: 000049_add_remediation_simulations
+-- Description: Dry runs of remediation actions, kept so approvers can review the would-be changes
+
+CREATE TABLE IF NOT EXISTS remediation_simulations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID,
+    finding_id UUID NOT NULL REFERENCES findings(id) ON DELETE CASCADE,
+    action_type VARCHAR(100) NOT NULL,
+    status VARCHAR(30) NOT NULL, -- 'simulated', 'failed'
+    changes JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    simulated_by VARCHAR(255) NOT NULL,
+    simulated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_remediation_simulations_finding ON remediation_simulations(finding_id, simulated_at DESC);
@@ -0,0 +1,7 @@
+-- Rollback migration for finding encryption. Decrypt findings first: values
+-- sealed under a tenant data key are unreadable once the keys are dropped.
+
+DROP INDEX IF EXISTS idx_findings_encryption_key_version;
+ALTER TABLE findings DROP COLUMN IF EXISTS encryption_key_version;
+DROP INDEX IF EXISTS idx_tenant_data_keys_active;
+DROP TABLE IF EXISTS tenant_data_keys CASCADE;
@@ -0,0 +1,22 @@
+-- Migration: 000050_add_finding_encryption
+-- Description: Per-tenant data keys encrypting finding matches and sample text at rest
+
+CREATE TABLE IF NOT EXISTS tenant_data_keys (
+    tenant_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    wrapped_key BYTEA NOT NULL, -- AES-256 key encrypted with ENCRYPTION_KEY
+    status VARCHAR(20) NOT NULL DEFAULT 'active', -- 'active', 'retired'
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    retired_at TIMESTAMP,
+    PRIMARY KEY (tenant_id, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_data_keys_active
+    ON tenant_data_keys(tenant_id) WHERE status = 'active';
+
+-- NULL while a finding's values are stored in plaintext
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS encryption_key_version INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_findings_encryption_key_version
+    ON findings(tenant_id, encryption_key_version);
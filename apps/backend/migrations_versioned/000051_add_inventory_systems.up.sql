@@ -0,0 +1,22 @@
+-- Hosts and managed databases imported from AWS and Azure inventories. Assets
+-- whose host matches one of a system's hosts are linked to it in lineage and
+-- take its environment and owner tags.
+CREATE TABLE IF NOT EXISTS inventory_systems (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,
+    resource_id TEXT NOT NULL,
+    resource_type VARCHAR(100) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    account VARCHAR(255),
+    region VARCHAR(100),
+    hosts TEXT[] NOT NULL DEFAULT '{}',
+    environment VARCHAR(100),
+    owner VARCHAR(255),
+    tags JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (tenant_id, provider, resource_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_systems_hosts ON inventory_systems USING GIN (hosts);
@@ -0,0 +1,11 @@
+-- Rollback migration for finding payloads. Offloaded payloads are moved back
+-- into the findings table before the side table is dropped.
+
+UPDATE findings f SET
+    context = p.context,
+    enrichment_signals = p.enrichment_signals
+FROM finding_payloads p
+WHERE p.finding_id = f.id AND f.payload_offloaded;
+
+ALTER TABLE findings DROP COLUMN IF EXISTS payload_offloaded;
+DROP TABLE IF EXISTS finding_payloads CASCADE;
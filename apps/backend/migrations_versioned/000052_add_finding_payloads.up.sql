@@ -0,0 +1,34 @@
+-- Migration: 000052_add_finding_payloads
+-- Description: Side table for large finding context and enrichment signal payloads
+
+CREATE TABLE IF NOT EXISTS finding_payloads (
+    finding_id UUID PRIMARY KEY REFERENCES findings(id) ON DELETE CASCADE,
+    context JSONB,
+    enrichment_signals JSONB,
+    size_bytes INTEGER NOT NULL, -- Serialized size of both payloads
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- When true, findings.context holds only the short top-level values of the
+-- context and enrichment_signals is NULL; the full payloads are in finding_payloads
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS payload_offloaded BOOLEAN NOT NULL DEFAULT false;
+
+-- Relocate existing payloads larger than the default inline limit (2048 bytes)
+INSERT INTO finding_payloads (finding_id, context, enrichment_signals, size_bytes)
+SELECT id, context, enrichment_signals,
+    COALESCE(octet_length(context::text), 0) + COALESCE(octet_length(enrichment_signals::text), 0)
+FROM findings
+WHERE NOT payload_offloaded
+  AND COALESCE(octet_length(context::text), 0) + COALESCE(octet_length(enrichment_signals::text), 0) > 2048
+ON CONFLICT (finding_id) DO NOTHING;
+
+UPDATE findings f SET
+    context = CASE WHEN jsonb_typeof(f.context) = 'object' THEN (
+        SELECT COALESCE(jsonb_object_agg(key, value), '{}'::jsonb)
+        FROM jsonb_each(f.context)
+        WHERE jsonb_typeof(value) IN ('string', 'number', 'boolean') AND octet_length(value::text) <= 256
+    ) END,
+    enrichment_signals = NULL,
+    payload_offloaded = true
+FROM finding_payloads p
+WHERE p.finding_id = f.id AND NOT f.payload_offloaded;
@@ -0,0 +1,8 @@
+-- Rollback migration for organizational units
+
+ALTER TABLE users DROP COLUMN IF EXISTS org_unit_id;
+ALTER TABLE connections DROP COLUMN IF EXISTS org_unit_id;
+ALTER TABLE assets DROP COLUMN IF EXISTS org_unit_source;
+ALTER TABLE assets DROP COLUMN IF EXISTS org_unit_id;
+
+DROP TABLE IF EXISTS org_units CASCADE;
@@ -0,0 +1,32 @@
+-- Migration: 000053_add_org_units
+-- Description: Organizational units (departments and their teams) that own assets, connections and users
+
+CREATE TABLE IF NOT EXISTS org_units (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    parent_id UUID REFERENCES org_units(id) ON DELETE RESTRICT,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('department', 'team')),
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    -- Departments are top level; teams sit directly under a department
+    CONSTRAINT chk_org_units_parent CHECK ((kind = 'department') = (parent_id IS NULL))
+);
+
+-- Names are unique among siblings; NULL parents would not collide in a plain unique constraint
+CREATE UNIQUE INDEX IF NOT EXISTS uq_org_units_sibling_name
+    ON org_units(tenant_id, COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), LOWER(name));
+CREATE INDEX IF NOT EXISTS idx_org_units_parent ON org_units(parent_id) WHERE parent_id IS NOT NULL;
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS org_unit_id UUID REFERENCES org_units(id) ON DELETE SET NULL;
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS org_unit_source VARCHAR(20);
+ALTER TABLE connections ADD COLUMN IF NOT EXISTS org_unit_id UUID REFERENCES org_units(id) ON DELETE SET NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_unit_id UUID REFERENCES org_units(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_assets_org_unit ON assets(org_unit_id) WHERE org_unit_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_connections_org_unit ON connections(org_unit_id) WHERE org_unit_id IS NOT NULL;
+
+COMMENT ON TABLE org_units IS 'Departments and teams that own assets; analysts assigned to one only see its findings';
+COMMENT ON COLUMN assets.org_unit_source IS 'manual when assigned directly, connection when inherited from the connection that scanned it';
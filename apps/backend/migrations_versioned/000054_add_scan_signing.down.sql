@@ -0,0 +1,10 @@
+-- Rollback migration for scan signing
+
+ALTER TABLE scan_upload_sessions DROP COLUMN IF EXISTS unsigned_chunks;
+ALTER TABLE scan_upload_sessions DROP COLUMN IF EXISTS signer_key_id;
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS signer_name;
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS signer_key_id;
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS signature_status;
+
+DROP TABLE IF EXISTS tenant_scan_signing_policies;
+DROP TABLE IF EXISTS scanner_signing_keys;
@@ -0,0 +1,37 @@
+-- Migration: 000054_add_scan_signing
+-- Description: Scanner signing keys, per-tenant strict mode and the signer of each scan run
+
+CREATE TABLE IF NOT EXISTS scanner_signing_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    key_id VARCHAR(100) NOT NULL,
+    scanner_name VARCHAR(255) NOT NULL,
+    algorithm VARCHAR(20) NOT NULL DEFAULT 'ed25519' CHECK (algorithm IN ('ed25519')),
+    public_key BYTEA NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP,
+    revoked_by VARCHAR(255),
+    UNIQUE (tenant_id, key_id)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_scan_signing_policies (
+    tenant_id UUID PRIMARY KEY,
+    require_signature BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS signature_status VARCHAR(20) NOT NULL DEFAULT 'unsigned'
+    CHECK (signature_status IN ('unsigned', 'verified'));
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS signer_key_id UUID REFERENCES scanner_signing_keys(id) ON DELETE SET NULL;
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS signer_name VARCHAR(255);
+
+-- Chunks of one upload session are all signed with the same key, or none are
+ALTER TABLE scan_upload_sessions ADD COLUMN IF NOT EXISTS signer_key_id UUID REFERENCES scanner_signing_keys(id) ON DELETE SET NULL;
+ALTER TABLE scan_upload_sessions ADD COLUMN IF NOT EXISTS unsigned_chunks BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON TABLE scanner_signing_keys IS 'Ed25519 public keys scanners sign ingestion payloads with, named by the key_id sent in X-Scan-Key-Id';
+COMMENT ON TABLE tenant_scan_signing_policies IS 'Tenants in strict mode, whose unsigned scan payloads are rejected';
+COMMENT ON COLUMN scan_runs.signer_name IS 'Scanner name of the signing key when the payload was verified; kept if the key is deleted';
@@ -0,0 +1,3 @@
+-- Rollback migration for finding comments
+
+DROP TABLE IF EXISTS finding_comments CASCADE;
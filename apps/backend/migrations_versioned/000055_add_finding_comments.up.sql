@@ -0,0 +1,22 @@
+-- Migration: 000055_add_finding_comments
+-- Description: Reviewer discussion threads on findings, with the users each comment mentions
+
+CREATE TABLE IF NOT EXISTS finding_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    finding_id UUID NOT NULL REFERENCES findings(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES finding_comments(id) ON DELETE CASCADE, -- The thread's first comment; NULL for that comment itself
+    author_id UUID,                                                   -- NULL for comments made with an API key
+    author VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    mentions TEXT[] NOT NULL DEFAULT '{}',                            -- Emails of the tenant users the body mentions
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at TIMESTAMP,
+    deleted_at TIMESTAMP,                                             -- Deleted comments keep their place in the thread with an empty body
+    CONSTRAINT chk_finding_comment_body CHECK (deleted_at IS NOT NULL OR length(body) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_finding_comments_finding ON finding_comments(tenant_id, finding_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_finding_comments_parent ON finding_comments(parent_id) WHERE parent_id IS NOT NULL;
+
+COMMENT ON TABLE finding_comments IS 'Threaded reviewer comments on findings';
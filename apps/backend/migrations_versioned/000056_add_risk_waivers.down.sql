@@ -0,0 +1,26 @@
+-- Rollback migration for risk waivers
+
+DROP MATERIALIZED VIEW IF EXISTS dashboard_finding_summary;
+
+CREATE MATERIALIZED VIEW dashboard_finding_summary AS
+SELECT
+    COALESCE(f.tenant_id, '00000000-0000-0000-0000-000000000000'::uuid) AS tenant_id,
+    f.severity,
+    COALESCE(c.classification_type, 'Unclassified') AS classification_type,
+    COALESCE(NULLIF(f.environment, ''), 'UNKNOWN') AS environment,
+    COUNT(DISTINCT f.id) AS finding_count,
+    COUNT(DISTINCT f.asset_id) AS asset_count,
+    MAX(f.created_at) AS last_finding_at
+FROM findings f
+LEFT JOIN classifications c ON c.finding_id = f.id
+WHERE f.deleted_at IS NULL
+  AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dashboard_finding_summary_key
+ON dashboard_finding_summary(tenant_id, severity, classification_type, environment);
+
+COMMENT ON MATERIALIZED VIEW dashboard_finding_summary IS 'Finding counts per tenant, severity, classification and environment; refreshed after ingestion';
+
+DROP TABLE IF EXISTS finding_waivers;
+DROP TABLE IF EXISTS risk_waivers;
@@ -0,0 +1,60 @@
+-- Migration: 000056_add_risk_waivers
+-- Description: Time-boxed acceptance of risk for single findings or for a pattern under path and host globs.
+-- Waived findings are kept but left out of risk rollups until their waiver expires or is revoked.
+
+CREATE TABLE IF NOT EXISTS risk_waivers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    finding_id UUID REFERENCES findings(id) ON DELETE CASCADE, -- Set for a single-finding waiver
+    pattern_name VARCHAR(100) NOT NULL DEFAULT '',              -- Set for a scope waiver, with optional globs
+    path_glob TEXT NOT NULL DEFAULT '',
+    host_glob TEXT NOT NULL DEFAULT '',
+    justification TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',               -- 'active', 'expired', 'revoked'
+    expires_at TIMESTAMP NOT NULL,
+    expiry_warned_at TIMESTAMP,                                  -- When admins were alerted that it is about to expire
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_by VARCHAR(255),
+    revoked_at TIMESTAMP,
+    CONSTRAINT chk_risk_waiver_status CHECK (status IN ('active', 'expired', 'revoked')),
+    CONSTRAINT chk_risk_waiver_scope CHECK (finding_id IS NOT NULL OR pattern_name <> '')
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_waivers_tenant ON risk_waivers(tenant_id, status, expires_at);
+
+-- The waiver currently covering each waived finding
+CREATE TABLE IF NOT EXISTS finding_waivers (
+    finding_id UUID PRIMARY KEY REFERENCES findings(id) ON DELETE CASCADE,
+    waiver_id UUID NOT NULL REFERENCES risk_waivers(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    waived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_finding_waivers_waiver ON finding_waivers(waiver_id);
+
+-- Waived findings leave the dashboard summary
+DROP MATERIALIZED VIEW IF EXISTS dashboard_finding_summary;
+
+CREATE MATERIALIZED VIEW dashboard_finding_summary AS
+SELECT
+    COALESCE(f.tenant_id, '00000000-0000-0000-0000-000000000000'::uuid) AS tenant_id,
+    f.severity,
+    COALESCE(c.classification_type, 'Unclassified') AS classification_type,
+    COALESCE(NULLIF(f.environment, ''), 'UNKNOWN') AS environment,
+    COUNT(DISTINCT f.id) AS finding_count,
+    COUNT(DISTINCT f.asset_id) AS asset_count,
+    MAX(f.created_at) AS last_finding_at
+FROM findings f
+LEFT JOIN classifications c ON c.finding_id = f.id
+WHERE f.deleted_at IS NULL
+  AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+  AND NOT EXISTS (SELECT 1 FROM finding_waivers w WHERE w.finding_id = f.id)
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dashboard_finding_summary_key
+ON dashboard_finding_summary(tenant_id, severity, classification_type, environment);
+
+COMMENT ON TABLE risk_waivers IS 'Accepted risk for a finding or a pattern scope, until an expiry date';
+COMMENT ON TABLE finding_waivers IS 'Findings currently covered by an active risk waiver';
+COMMENT ON MATERIALIZED VIEW dashboard_finding_summary IS 'Finding counts per tenant, severity, classification and environment, without waived findings; refreshed after ingestion';
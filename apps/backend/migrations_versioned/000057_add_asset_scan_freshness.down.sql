@@ -0,0 +1,9 @@
+-- Rollback migration for asset scan freshness
+
+DROP INDEX IF EXISTS idx_assets_scan_freshness;
+UPDATE assets SET risk_score = GREATEST(risk_score - freshness_penalty, 0) WHERE freshness_penalty > 0;
+ALTER TABLE assets DROP CONSTRAINT IF EXISTS chk_assets_scan_frequency;
+ALTER TABLE assets DROP COLUMN IF EXISTS freshness_penalty;
+ALTER TABLE assets DROP COLUMN IF EXISTS scan_overdue_since;
+ALTER TABLE assets DROP COLUMN IF EXISTS expected_scan_frequency_hours;
+ALTER TABLE assets DROP COLUMN IF EXISTS last_scanned_at;
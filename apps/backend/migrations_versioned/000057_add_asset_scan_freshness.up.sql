@@ -0,0 +1,26 @@
+-- Migration: 000057_add_asset_scan_freshness
+-- Description: When each asset was last scanned, how often it should be, and the risk
+-- points it carries while a scan is overdue
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS last_scanned_at TIMESTAMP;
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS expected_scan_frequency_hours INTEGER;   -- NULL uses SCAN_FRESHNESS_DEFAULT_HOURS
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS scan_overdue_since TIMESTAMP;            -- Set by the SLA job, cleared by the next scan
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS freshness_penalty INTEGER NOT NULL DEFAULT 0; -- Included in risk_score while overdue
+
+ALTER TABLE assets ADD CONSTRAINT chk_assets_scan_frequency
+    CHECK (expected_scan_frequency_hours IS NULL OR expected_scan_frequency_hours > 0);
+
+-- Assets were last scanned by the latest run that produced one of their findings
+UPDATE assets a SET last_scanned_at = s.last_scanned_at
+FROM (
+    SELECT f.asset_id, MAX(COALESCE(r.scan_completed_at, r.scan_started_at)) AS last_scanned_at
+    FROM findings f
+    JOIN scan_runs r ON r.id = f.scan_run_id
+    GROUP BY f.asset_id
+) s
+WHERE s.asset_id = a.id AND a.last_scanned_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_assets_scan_freshness ON assets(tenant_id, last_scanned_at) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN assets.last_scanned_at IS 'When findings from a scan of the asset were last ingested';
+COMMENT ON COLUMN assets.freshness_penalty IS 'Risk points added to risk_score while the asset is overdue for scanning';
@@ -0,0 +1,5 @@
+-- Rollback migration for analytics sync watermarks
+
+DROP INDEX IF EXISTS idx_classifications_updated_id;
+DROP INDEX IF EXISTS idx_findings_updated_id;
+DROP TABLE IF EXISTS analytics_sync_watermarks;
@@ -0,0 +1,20 @@
+-- Migration: 000058_add_analytics_sync_watermarks
+-- Description: How far findings and classifications have been mirrored into each analytics sink
+
+CREATE TABLE IF NOT EXISTS analytics_sync_watermarks (
+    sink VARCHAR(50) NOT NULL,                                                 -- e.g. 'clickhouse'
+    stream VARCHAR(50) NOT NULL,                                               -- 'findings' or 'classifications'
+    synced_until TIMESTAMP NOT NULL DEFAULT 'epoch',                          -- updated_at of the last mirrored row
+    last_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',     -- Breaks ties between rows updated at the same time
+    rows_synced BIGINT NOT NULL DEFAULT 0,
+    last_run_at TIMESTAMP,
+    last_error TEXT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (sink, stream)
+);
+
+-- Rows are read in (updated_at, id) order from the watermark
+CREATE INDEX IF NOT EXISTS idx_findings_updated_id ON findings(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_classifications_updated_id ON classifications(updated_at, id);
+
+COMMENT ON TABLE analytics_sync_watermarks IS 'Keyset position of the analytics sink sync per mirrored table';
@@ -0,0 +1,5 @@
+-- Rollback migration for review decision propagation
+
+DROP INDEX IF EXISTS idx_finding_observations_identical;
+ALTER TABLE review_states DROP COLUMN IF EXISTS propagated_from;
+DROP TABLE IF EXISTS review_propagation_settings CASCADE;
@@ -0,0 +1,18 @@
+-- Migration: 000059_add_review_propagation
+-- Description: Tenant opt-in to propagating review decisions to identical findings, and the source of each propagated review
+
+CREATE TABLE IF NOT EXISTS review_propagation_settings (
+    tenant_id UUID PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE review_states ADD COLUMN IF NOT EXISTS propagated_from UUID; -- No foreign key, so archived review states restore cleanly
+
+-- Identical findings are looked up by the value hash of their observation
+CREATE INDEX IF NOT EXISTS idx_finding_observations_identical
+    ON finding_observations(tenant_id, pattern_name, value_hash);
+
+COMMENT ON TABLE review_propagation_settings IS 'Tenants that let a review decision be copied to other findings of the same pattern and value hash';
+COMMENT ON COLUMN review_states.propagated_from IS 'Finding whose review decision this state was copied from; NULL for decisions made by a reviewer';
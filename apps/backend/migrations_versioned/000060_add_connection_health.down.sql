@@ -0,0 +1,5 @@
+-- Rollback migration for connection health
+
+ALTER TABLE connections DROP COLUMN IF EXISTS last_healthy_at;
+ALTER TABLE connections DROP COLUMN IF EXISTS failing_since;
+ALTER TABLE connections DROP COLUMN IF EXISTS consecutive_failures;
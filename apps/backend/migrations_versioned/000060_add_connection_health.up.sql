@@ -0,0 +1,12 @@
+-- Migration: 000060_add_connection_health
+-- Description: Health history of stored connections, kept up to date by the scheduled credential check
+
+ALTER TABLE connections ADD COLUMN IF NOT EXISTS consecutive_failures INT NOT NULL DEFAULT 0;
+ALTER TABLE connections ADD COLUMN IF NOT EXISTS failing_since TIMESTAMP;
+ALTER TABLE connections ADD COLUMN IF NOT EXISTS last_healthy_at TIMESTAMP;
+
+UPDATE connections SET last_healthy_at = last_validated_at WHERE validation_status = 'valid';
+UPDATE connections SET failing_since = last_validated_at, consecutive_failures = 1 WHERE validation_status = 'invalid';
+
+COMMENT ON COLUMN connections.consecutive_failures IS 'Connection tests failed in a row; reset by the next successful test';
+COMMENT ON COLUMN connections.failing_since IS 'First failed test of the current failure streak; NULL while the connection is healthy';
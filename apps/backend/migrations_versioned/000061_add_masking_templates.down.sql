@@ -0,0 +1,3 @@
+-- Rollback migration for masking templates
+
+DROP TABLE IF EXISTS masking_templates CASCADE;
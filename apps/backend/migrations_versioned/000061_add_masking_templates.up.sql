@@ -0,0 +1,20 @@
+-- Migration: 000061_add_masking_templates
+-- Description: Tenant masking templates per PII type, overriding the built-in templates
+
+CREATE TABLE IF NOT EXISTS masking_templates (
+    tenant_id UUID NOT NULL,
+    pii_type VARCHAR(100) NOT NULL,
+    style VARCHAR(20) NOT NULL,
+    keep_first INT NOT NULL DEFAULT 0,
+    keep_last INT NOT NULL DEFAULT 0,
+    format VARCHAR(100) NOT NULL DEFAULT '',
+    mask_char VARCHAR(8) NOT NULL DEFAULT '',
+    replacement VARCHAR(100) NOT NULL DEFAULT '',
+    updated_by VARCHAR(255) NOT NULL DEFAULT 'system',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, pii_type)
+);
+
+COMMENT ON TABLE masking_templates IS 'How a tenant masks values of a PII type in stored samples, API responses and remediation; types without a row use the built-in template';
+COMMENT ON COLUMN masking_templates.style IS 'partial, email, format or redact';
+COMMENT ON COLUMN masking_templates.format IS 'format style only: X is masked, # is kept, other characters are written as is';
@@ -0,0 +1,3 @@
+-- Rollback migration for tenant exports
+
+DROP TABLE IF EXISTS tenant_exports CASCADE;
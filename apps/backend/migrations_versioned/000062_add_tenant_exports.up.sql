@@ -0,0 +1,26 @@
+-- Migration: 000062_add_tenant_exports
+-- Description: Full tenant data export bundles for offboarding and regulator requests
+
+CREATE TABLE IF NOT EXISTS tenant_exports (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reason TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    job_id UUID,
+    storage_provider VARCHAR(20) NOT NULL DEFAULT '',
+    bucket VARCHAR(255) NOT NULL DEFAULT '',
+    object_key TEXT NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    checksum_sha256 VARCHAR(64) NOT NULL DEFAULT '',
+    manifest JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_exports_tenant ON tenant_exports(tenant_id, requested_at DESC);
+
+COMMENT ON TABLE tenant_exports IS 'Bundles of a tenant''s data built by the tenant_export.build job';
+COMMENT ON COLUMN tenant_exports.status IS 'pending, running, completed or failed';
+COMMENT ON COLUMN tenant_exports.checksum_sha256 IS 'SHA-256 of the whole bundle; the manifest inside it holds the checksum of each file';
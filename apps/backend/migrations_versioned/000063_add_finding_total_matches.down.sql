@@ -0,0 +1,3 @@
+-- Rollback migration for finding match totals
+
+ALTER TABLE findings DROP COLUMN IF EXISTS total_matches;
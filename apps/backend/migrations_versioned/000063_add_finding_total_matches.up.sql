@@ -0,0 +1,7 @@
+-- Migration: 000063_add_finding_total_matches
+-- Description: Matches reported per finding, when more than INGESTION_MAX_MATCHES_PER_FINDING were and only the first were stored
+
+-- NULL for findings stored before the cap, whose matches were all stored
+ALTER TABLE findings ADD COLUMN IF NOT EXISTS total_matches INT;
+
+COMMENT ON COLUMN findings.total_matches IS 'Matches reported by the scanner; matches holds at most the ingestion cap';
@@ -0,0 +1,4 @@
+-- Rollback migration for remediation batches
+
+DROP TABLE IF EXISTS remediation_batch_items;
+DROP TABLE IF EXISTS remediation_batches;
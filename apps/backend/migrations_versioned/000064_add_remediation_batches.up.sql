@@ -0,0 +1,37 @@
+-- Migration: 000064_add_remediation_batches
+-- Description: Bulk remediation batches, throttled per connection and run by the job queue
+
+CREATE TABLE IF NOT EXISTS remediation_batches (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    action_type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    requested_by VARCHAR(255) NOT NULL,
+    total_findings INT NOT NULL DEFAULT 0,
+    job_id UUID,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_remediation_batches_tenant ON remediation_batches(tenant_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS remediation_batch_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    batch_id UUID NOT NULL REFERENCES remediation_batches(id) ON DELETE CASCADE,
+    finding_id UUID NOT NULL,
+    connection TEXT NOT NULL DEFAULT '',
+    source_type VARCHAR(50) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    action_id UUID,
+    error TEXT NOT NULL DEFAULT '',
+    finished_at TIMESTAMP,
+    UNIQUE (batch_id, finding_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_remediation_batch_items_pending ON remediation_batch_items(batch_id, connection) WHERE status = 'pending';
+
+COMMENT ON TABLE remediation_batches IS 'Bulk remediations run by the remediation.batch job';
+COMMENT ON COLUMN remediation_batches.status IS 'pending, running, paused, completed or failed';
+COMMENT ON COLUMN remediation_batch_items.connection IS 'Source system of the finding''s asset; findings are throttled per connection';
@@ -0,0 +1,3 @@
+-- Rollback migration for compliance score snapshots
+
+DROP TABLE IF EXISTS compliance_score_snapshots;
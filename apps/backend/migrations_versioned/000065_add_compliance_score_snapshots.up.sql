@@ -0,0 +1,13 @@
+-- Migration: 000065_add_compliance_score_snapshots
+-- Description: Daily tenant compliance posture scores with their per-component breakdown
+
+CREATE TABLE IF NOT EXISTS compliance_score_snapshots (
+    tenant_id UUID NOT NULL,
+    snapshot_date DATE NOT NULL,
+    score NUMERIC(5,2) NOT NULL,                 -- 0-100, higher is better
+    components JSONB NOT NULL DEFAULT '[]',      -- Score, weight and inputs of each component
+    computed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, snapshot_date)
+);
+
+COMMENT ON TABLE compliance_score_snapshots IS 'One compliance posture score per tenant and day, refreshed until the day ends';
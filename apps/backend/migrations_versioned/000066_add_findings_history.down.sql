@@ -0,0 +1,10 @@
+-- Rollback migration for findings history
+
+DROP TRIGGER IF EXISTS version_review_states_update ON review_states;
+DROP TRIGGER IF EXISTS version_review_states_insert_delete ON review_states;
+DROP TRIGGER IF EXISTS version_findings_update ON findings;
+DROP TRIGGER IF EXISTS version_findings_insert_delete ON findings;
+DROP FUNCTION IF EXISTS version_review_state();
+DROP FUNCTION IF EXISTS version_finding();
+DROP TABLE IF EXISTS review_states_history;
+DROP TABLE IF EXISTS findings_history;
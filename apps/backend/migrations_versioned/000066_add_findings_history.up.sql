@@ -0,0 +1,87 @@
+-- Migration: 000066_add_findings_history
+-- Description: System-versioned history of findings and their review states for as-of reads
+
+-- Each row is one version of a finding, valid from valid_from until valid_to
+-- (open while current). The row is kept as JSONB so columns added to findings
+-- later are versioned without touching the trigger.
+CREATE TABLE IF NOT EXISTS findings_history (
+    history_id BIGSERIAL PRIMARY KEY,
+    finding_id UUID NOT NULL,
+    tenant_id UUID,
+    asset_id UUID NOT NULL,
+    row_data JSONB NOT NULL,
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS review_states_history (
+    history_id BIGSERIAL PRIMARY KEY,
+    finding_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    reviewed_by VARCHAR(255),
+    valid_from TIMESTAMP NOT NULL,
+    valid_to TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_findings_history_finding ON findings_history(finding_id, valid_from);
+CREATE INDEX IF NOT EXISTS idx_findings_history_asset ON findings_history(tenant_id, asset_id, valid_from);
+CREATE INDEX IF NOT EXISTS idx_findings_history_tenant ON findings_history(tenant_id, valid_from);
+CREATE INDEX IF NOT EXISTS idx_findings_history_open ON findings_history(finding_id) WHERE valid_to IS NULL;
+CREATE INDEX IF NOT EXISTS idx_review_states_history_finding ON review_states_history(finding_id, valid_from);
+
+-- Closes the current version of a finding and, unless it was deleted, opens the next one
+CREATE OR REPLACE FUNCTION version_finding() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE findings_history SET valid_to = NOW()
+        WHERE finding_id = OLD.id AND valid_to IS NULL;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        INSERT INTO findings_history (finding_id, tenant_id, asset_id, row_data, valid_from)
+        VALUES (NEW.id, NEW.tenant_id, NEW.asset_id, to_jsonb(NEW), NOW());
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- A finding's latest review state is its status, so a newly inserted review state
+-- also closes the version of the one before it
+CREATE OR REPLACE FUNCTION version_review_state() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE review_states_history SET valid_to = NOW()
+        WHERE finding_id = OLD.finding_id AND valid_to IS NULL;
+        RETURN NULL;
+    END IF;
+    UPDATE review_states_history SET valid_to = NOW()
+    WHERE finding_id = NEW.finding_id AND valid_to IS NULL;
+    INSERT INTO review_states_history (finding_id, status, reviewed_by, valid_from)
+    VALUES (NEW.finding_id, NEW.status, NEW.reviewed_by, NOW());
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER version_findings_insert_delete AFTER INSERT OR DELETE ON findings
+    FOR EACH ROW EXECUTE FUNCTION version_finding();
+-- Updates that only touch updated_at (re-saves of an unchanged finding) add no version
+CREATE TRIGGER version_findings_update AFTER UPDATE ON findings
+    FOR EACH ROW WHEN ((to_jsonb(OLD) - 'updated_at') IS DISTINCT FROM (to_jsonb(NEW) - 'updated_at'))
+    EXECUTE FUNCTION version_finding();
+
+CREATE TRIGGER version_review_states_insert_delete AFTER INSERT OR DELETE ON review_states
+    FOR EACH ROW EXECUTE FUNCTION version_review_state();
+CREATE TRIGGER version_review_states_update AFTER UPDATE ON review_states
+    FOR EACH ROW WHEN (OLD.status IS DISTINCT FROM NEW.status) EXECUTE FUNCTION version_review_state();
+
+-- Existing findings start their history at their last update
+INSERT INTO findings_history (finding_id, tenant_id, asset_id, row_data, valid_from)
+SELECT f.id, f.tenant_id, f.asset_id, to_jsonb(f), COALESCE(f.updated_at, f.created_at, NOW())
+FROM findings f;
+
+INSERT INTO review_states_history (finding_id, status, reviewed_by, valid_from)
+SELECT DISTINCT ON (rs.finding_id) rs.finding_id, rs.status, rs.reviewed_by, COALESCE(rs.updated_at, rs.created_at, NOW())
+FROM review_states rs
+ORDER BY rs.finding_id, rs.created_at DESC;
+
+COMMENT ON TABLE findings_history IS 'Versions of each finding, maintained by trigger; answers what findings looked like at a point in time';
+COMMENT ON TABLE review_states_history IS 'Versions of each finding''s review status, maintained by trigger';
@@ -0,0 +1,7 @@
+-- Rollback migration for scan anomalies
+
+DROP INDEX IF EXISTS idx_scan_runs_anomaly_unchecked;
+ALTER TABLE scan_runs DROP COLUMN IF EXISTS anomaly_checked_at;
+DROP TABLE IF EXISTS scan_anomalies;
+DROP TABLE IF EXISTS scan_anomaly_suppressions;
+DROP TABLE IF EXISTS scan_anomaly_settings;
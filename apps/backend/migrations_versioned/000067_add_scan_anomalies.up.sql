@@ -0,0 +1,66 @@
+-- Migration: 000067_add_scan_anomalies
+-- Description: Volume spikes in scan results against each asset's and connection's baseline, with sensitivity settings and suppression windows
+
+-- Per-tenant sensitivity; tenants without a row use the SCAN_ANOMALY_* defaults
+CREATE TABLE IF NOT EXISTS scan_anomaly_settings (
+    tenant_id UUID PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    spike_factor NUMERIC(8,2) NOT NULL,           -- Matches over the baseline median that make a spike
+    min_matches INT NOT NULL,                     -- Spikes below this many matches are ignored
+    baseline_runs INT NOT NULL,                   -- Previous scan runs the baseline is taken over
+    pii_spike_factors JSONB NOT NULL DEFAULT '{}', -- PII type -> spike factor overriding spike_factor
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Windows in which expected spikes (a migration, a backfill) are recorded but not alerted
+CREATE TABLE IF NOT EXISTS scan_anomaly_suppressions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    asset_id UUID REFERENCES assets(id) ON DELETE CASCADE,              -- NULL: any asset
+    connection_id UUID REFERENCES connections(id) ON DELETE CASCADE,    -- NULL: any connection
+    pii_type VARCHAR(255),                                              -- NULL: any PII type
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_scan_anomaly_suppressions_window CHECK (ends_at > starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS scan_anomalies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    scan_run_id UUID NOT NULL REFERENCES scan_runs(id) ON DELETE CASCADE,
+    scope VARCHAR(20) NOT NULL,                   -- 'asset' or 'connection'
+    asset_id UUID REFERENCES assets(id) ON DELETE CASCADE,
+    connection_id UUID REFERENCES connections(id) ON DELETE SET NULL,
+    pii_type VARCHAR(255) NOT NULL,
+    observed_matches INT NOT NULL,
+    baseline_matches NUMERIC(12,2) NOT NULL,      -- Median over the baseline runs
+    baseline_runs INT NOT NULL,
+    ratio NUMERIC(12,2) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',   -- 'open', 'acknowledged', 'suppressed'
+    suppression_id UUID REFERENCES scan_anomaly_suppressions(id) ON DELETE SET NULL,
+    detected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    acknowledged_by VARCHAR(255),
+    acknowledged_at TIMESTAMP,
+    CONSTRAINT chk_scan_anomalies_scope CHECK (scope IN ('asset', 'connection')),
+    CONSTRAINT chk_scan_anomalies_status CHECK (status IN ('open', 'acknowledged', 'suppressed'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_scan_anomalies_subject
+    ON scan_anomalies(scan_run_id, scope, COALESCE(asset_id, connection_id), pii_type);
+CREATE INDEX IF NOT EXISTS idx_scan_anomalies_tenant ON scan_anomalies(tenant_id, status, detected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scan_anomaly_suppressions_tenant ON scan_anomaly_suppressions(tenant_id, ends_at);
+
+-- Completed runs the detection job has not looked at yet
+ALTER TABLE scan_runs ADD COLUMN IF NOT EXISTS anomaly_checked_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_scan_runs_anomaly_unchecked ON scan_runs(tenant_id, scan_completed_at)
+    WHERE anomaly_checked_at IS NULL AND status = 'completed';
+
+-- Runs ingested before detection existed are not alerted retroactively
+UPDATE scan_runs SET anomaly_checked_at = NOW() WHERE anomaly_checked_at IS NULL;
+
+COMMENT ON TABLE scan_anomalies IS 'PII match volume spikes of a scan run against the asset''s or connection''s previous runs';
+COMMENT ON TABLE scan_anomaly_suppressions IS 'Windows in which matching anomalies are recorded as suppressed instead of alerted';
@@ -0,0 +1,3 @@
+-- Rollback migration for the dropped findings ledger
+
+DROP TABLE IF EXISTS dropped_findings;
@@ -0,0 +1,18 @@
+-- Migration: 000068_add_dropped_findings
+-- Description: Ledger of findings the ingestion filter dropped (or would drop, in audit mode), per scan run, reason and pattern
+
+CREATE TABLE IF NOT EXISTS dropped_findings (
+    tenant_id UUID NOT NULL,
+    scan_run_id UUID NOT NULL REFERENCES scan_runs(id) ON DELETE CASCADE,
+    reason VARCHAR(50) NOT NULL,                  -- 'non_pii' or 'low_confidence'
+    pattern_name VARCHAR(255) NOT NULL,
+    enforced BOOLEAN NOT NULL,                    -- FALSE: counted in audit mode, the findings were stored
+    finding_count INT NOT NULL,
+    max_confidence NUMERIC(5,4) NOT NULL,         -- Highest classification score among the findings
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (scan_run_id, reason, pattern_name, enforced)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dropped_findings_tenant ON dropped_findings(tenant_id, recorded_at DESC);
+
+COMMENT ON TABLE dropped_findings IS 'Findings filtered at ingestion as Non-PII or low confidence, counted per scan run so the filter can be audited';
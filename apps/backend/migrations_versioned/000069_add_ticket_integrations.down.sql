@@ -0,0 +1,4 @@
+-- Rollback migration for issue tracker integrations
+
+DROP TABLE IF EXISTS ticket_links;
+DROP TABLE IF EXISTS ticket_integrations;
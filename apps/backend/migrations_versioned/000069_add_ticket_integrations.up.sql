@@ -0,0 +1,46 @@
+-- Migration: 000069_add_ticket_integrations
+-- Description: Jira / ServiceNow integrations per tenant and the tickets linked to findings and remediation requests
+
+CREATE TABLE IF NOT EXISTS ticket_integrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    provider VARCHAR(20) NOT NULL,                  -- 'jira' or 'servicenow'
+    name VARCHAR(255) NOT NULL,
+    base_url TEXT NOT NULL,
+    username VARCHAR(255) NOT NULL DEFAULT '',
+    api_token_encrypted BYTEA,
+    webhook_secret_encrypted BYTEA,
+    queue_mapping JSONB NOT NULL DEFAULT '{}',      -- Default and per-severity project / queue
+    resolved_statuses TEXT[] NOT NULL DEFAULT '{}', -- Tracker statuses that count as resolved
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS ticket_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    integration_id UUID NOT NULL REFERENCES ticket_integrations(id) ON DELETE CASCADE,
+    finding_id UUID REFERENCES findings(id) ON DELETE CASCADE,
+    remediation_request_id UUID REFERENCES remediation_requests(id) ON DELETE CASCADE,
+    ticket_key VARCHAR(100) NOT NULL,               -- Jira issue key or ServiceNow number
+    external_id VARCHAR(100) NOT NULL,              -- Jira issue key or ServiceNow sys_id
+    ticket_type VARCHAR(100) NOT NULL DEFAULT '',   -- Jira issue type or ServiceNow table
+    url TEXT NOT NULL DEFAULT '',
+    tracker_status VARCHAR(100) NOT NULL DEFAULT '',
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    last_synced_status VARCHAR(50) NOT NULL DEFAULT '', -- ARC status last pushed to (or received from) the tracker
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((finding_id IS NULL) <> (remediation_request_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_links_finding ON ticket_links(integration_id, finding_id, ticket_key) WHERE finding_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_links_request ON ticket_links(integration_id, remediation_request_id, ticket_key) WHERE remediation_request_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_ticket_links_ticket ON ticket_links(integration_id, external_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_links_open ON ticket_links(tenant_id) WHERE NOT resolved;
+
+COMMENT ON TABLE ticket_links IS 'Issue tracker tickets linked to findings and remediation requests; status is synced both ways';
@@ -0,0 +1,3 @@
+-- Rollback migration for dashboard share links
+
+DROP TABLE IF EXISTS share_links;
@@ -0,0 +1,24 @@
+-- Migration: 000070_add_share_links
+-- Description: Read-only share links exposing selected dashboard widgets without authentication
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    widgets TEXT[] NOT NULL,                    -- 'pii_summary', 'compliance_score', 'risk_trend', 'pii_heatmap'
+    environment VARCHAR(20) NOT NULL DEFAULT '', -- Narrows the PII summary; empty for all environments
+    days INT NOT NULL DEFAULT 30,               -- Window of the risk trend and score history
+    ip_allowlist TEXT[] NOT NULL DEFAULT '{}',  -- IPs and CIDRs allowed to open the link; empty allows any
+    token_hash VARCHAR(64) NOT NULL UNIQUE,     -- SHA-256 of the link token; the token itself is not stored
+    expires_at TIMESTAMP NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP,
+    revoked_by VARCHAR(255),
+    last_accessed_at TIMESTAMP,
+    access_count INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_tenant ON share_links(tenant_id, created_at DESC);
+
+COMMENT ON TABLE share_links IS 'Expiring read-only links to aggregate dashboard widgets; never include sample values';
@@ -0,0 +1,4 @@
+-- Rollback migration for secret verification
+
+DROP TABLE IF EXISTS secret_verifications;
+DROP TABLE IF EXISTS secret_verification_settings;
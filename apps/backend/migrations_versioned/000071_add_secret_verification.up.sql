@@ -0,0 +1,29 @@
+-- Migration: 000071_add_secret_verification
+-- Description: Opt-in liveness checks of credentials found by Secrets findings, marking them active or stale
+
+-- Tenants without a row, or with enabled = FALSE, are never probed
+CREATE TABLE IF NOT EXISTS secret_verification_settings (
+    tenant_id UUID PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    providers TEXT[] NOT NULL DEFAULT '{}', -- 'aws', 'github', 'slack'; empty for all supported providers
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Latest verification of each Secrets finding
+CREATE TABLE IF NOT EXISTS secret_verifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL,
+    finding_id UUID NOT NULL UNIQUE REFERENCES findings(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,           -- Probe used; empty when no verifiable credential was found
+    status VARCHAR(20) NOT NULL,             -- 'ACTIVE_SECRET', 'STALE', 'UNVERIFIED'
+    identity TEXT NOT NULL DEFAULT '',       -- Who the credential authenticates as, e.g. an IAM ARN
+    detail TEXT NOT NULL DEFAULT '',         -- Why the credential could not be verified
+    original_severity VARCHAR(20) NOT NULL,  -- Severity before verification adjusted it
+    checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_secret_verifications_status CHECK (status IN ('ACTIVE_SECRET', 'STALE', 'UNVERIFIED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_verifications_tenant ON secret_verifications(tenant_id, status);
+
+COMMENT ON TABLE secret_verifications IS 'Credential liveness probes; the credential itself is never stored here';
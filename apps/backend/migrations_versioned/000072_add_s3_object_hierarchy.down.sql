@@ -0,0 +1,6 @@
+-- Rollback migration for S3 object hierarchy
+
+DROP INDEX IF EXISTS idx_assets_s3_objects;
+
+UPDATE assets SET asset_type = 'file'
+WHERE data_source = 's3' AND asset_type = 's3_object';
@@ -0,0 +1,11 @@
+-- Migration: 000072_add_s3_object_hierarchy
+-- Description: S3 objects as assets of their bucket, browsed and rolled up by the prefixes of their key
+
+-- S3 assets are now typed as objects; their bucket is the host and their key the path
+UPDATE assets SET asset_type = 's3_object'
+WHERE data_source = 's3' AND asset_type = 'file';
+
+-- Bucket listings and prefix rollups match keys by prefix within a bucket
+CREATE INDEX IF NOT EXISTS idx_assets_s3_objects
+    ON assets (tenant_id, host, path text_pattern_ops)
+    WHERE data_source = 's3' AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+-- Rollback migration for erasure requests
+
+DROP TABLE IF EXISTS erasure_certificates;
+DROP TABLE IF EXISTS erasure_targets;
+DROP TABLE IF EXISTS erasure_requests;
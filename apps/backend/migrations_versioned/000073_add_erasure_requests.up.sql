@@ -0,0 +1,55 @@
+-- Migration: 000073_add_erasure_requests
+-- Description: Data principal erasure requests, their per-finding targets and signed erasure certificates
+
+-- The value to erase is identified only by its normalized SHA-256 hash, as in finding_observations
+CREATE TABLE IF NOT EXISTS erasure_requests (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+    value_hash CHAR(64) NOT NULL,
+    requester_reference TEXT NOT NULL,       -- The data principal's request, e.g. a ticket number
+    action_type VARCHAR(20) NOT NULL,        -- 'DELETE' or 'MASK'
+    justification TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending_approval',
+    requested_by VARCHAR(255) NOT NULL,
+    requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_by VARCHAR(255) NOT NULL DEFAULT '',
+    decided_at TIMESTAMP,
+    decision_comment TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP,
+    CONSTRAINT chk_erasure_requests_status CHECK (status IN ('pending_approval', 'rejected', 'executing', 'completed', 'partial', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasure_requests_tenant ON erasure_requests(tenant_id, requested_at DESC);
+
+CREATE TABLE IF NOT EXISTS erasure_targets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    request_id UUID NOT NULL REFERENCES erasure_requests(id) ON DELETE CASCADE,
+    finding_id UUID NOT NULL,
+    asset_id UUID NOT NULL,
+    asset_name TEXT NOT NULL DEFAULT '',
+    asset_path TEXT NOT NULL DEFAULT '',
+    system TEXT NOT NULL DEFAULT '',
+    source_type VARCHAR(50) NOT NULL DEFAULT '',
+    matches INT NOT NULL DEFAULT 0,
+    mode VARCHAR(20) NOT NULL,               -- 'automated' or 'manual'
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    action_id UUID,
+    error TEXT NOT NULL DEFAULT '',
+    executed_at TIMESTAMP,
+    UNIQUE (request_id, finding_id)
+);
+
+-- Document is TEXT rather than JSONB so the signed bytes are kept exactly
+CREATE TABLE IF NOT EXISTS erasure_certificates (
+    request_id UUID PRIMARY KEY REFERENCES erasure_requests(id) ON DELETE CASCADE,
+    document TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    algorithm VARCHAR(20) NOT NULL,
+    key_fingerprint VARCHAR(64) NOT NULL,
+    issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE erasure_requests IS 'Four-eyes erasure of one value across every finding holding it; the raw value is never stored';
+COMMENT ON COLUMN erasure_targets.mode IS 'automated when the source connector can erase single matches, manual otherwise';
+COMMENT ON TABLE erasure_certificates IS 'Ed25519-signed records of executed erasure requests, issued to the requester';
@@ -0,0 +1,35 @@
+-- Migration: 000074_dedupe_dashboard_finding_summary
+-- Description: Count each finding once in the dashboard summary, under its latest classification
+
+-- Joining every classification row put a finding with several into several
+-- classification cells, inflating the totals summed over them
+DROP MATERIALIZED VIEW IF EXISTS dashboard_finding_summary;
+
+CREATE MATERIALIZED VIEW dashboard_finding_summary AS
+SELECT
+    COALESCE(f.tenant_id, '00000000-0000-0000-0000-000000000000'::uuid) AS tenant_id,
+    f.severity,
+    COALESCE(c.classification_type, 'Unclassified') AS classification_type,
+    COALESCE(NULLIF(f.environment, ''), 'UNKNOWN') AS environment,
+    COUNT(DISTINCT f.id) AS finding_count,
+    COUNT(DISTINCT f.asset_id) AS asset_count,
+    MAX(f.created_at) AS last_finding_at
+FROM findings f
+LEFT JOIN LATERAL (
+    SELECT classification_type FROM classifications
+    WHERE finding_id = f.id
+    ORDER BY created_at DESC, id DESC
+    LIMIT 1
+) c ON TRUE
+WHERE f.deleted_at IS NULL
+  AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+  AND NOT EXISTS (SELECT 1 FROM finding_waivers w WHERE w.finding_id = f.id)
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dashboard_finding_summary_key
+ON dashboard_finding_summary(tenant_id, severity, classification_type, environment);
+
+COMMENT ON MATERIALIZED VIEW dashboard_finding_summary IS 'Finding counts per tenant, severity, latest classification and environment, without waived findings; refreshed after ingestion';
+
+-- The staleness check reads the tenant's latest finding on every summary request
+CREATE INDEX IF NOT EXISTS idx_findings_tenant_created ON findings(tenant_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Rollback migration for stream tickets
+
+DROP TABLE IF EXISTS stream_tickets;
@@ -0,0 +1,18 @@
+-- Migration: 000075_add_stream_tickets
+-- Description: Short-lived single-use tickets that authenticate the live event stream
+
+-- EventSource cannot send an Authorization header, so clients exchange their
+-- access token for a ticket and pass it in the stream URL instead of the token
+CREATE TABLE IF NOT EXISTS stream_tickets (
+    ticket_hash VARCHAR(64) PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES auth_sessions(id) ON DELETE CASCADE,
+    token_id VARCHAR(64) NOT NULL,
+    user_id UUID NOT NULL,
+    tenant_id UUID NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    role VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stream_tickets_expires ON stream_tickets(expires_at);
@@ -0,0 +1,249 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/alerting/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AlertingHandler handles alert rule, digest and delivery requests
+type AlertingHandler struct {
+	service *service.AlertingService
+}
+
+// NewAlertingHandler creates a new alerting handler
+func NewAlertingHandler(service *service.AlertingService) *AlertingHandler {
+	return &AlertingHandler{service: service}
+}
+
+// CreateRule handles POST /api/v1/alerts/rules
+func (h *AlertingHandler) CreateRule(c *gin.Context) {
+	var input service.AlertRuleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	rule, err := h.service.CreateRule(sharedapi.RequestContext(c), input, actor(c))
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule})
+}
+
+// ListRules handles GET /api/v1/alerts/rules
+func (h *AlertingHandler) ListRules(c *gin.Context) {
+	rules, err := h.service.ListRules(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list alert rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules, "total": len(rules)})
+}
+
+// GetRule handles GET /api/v1/alerts/rules/:id
+func (h *AlertingHandler) GetRule(c *gin.Context) {
+	id, ok := parseID(c, "alert rule")
+	if !ok {
+		return
+	}
+
+	rule, err := h.service.GetRule(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// UpdateRule handles PUT /api/v1/alerts/rules/:id
+func (h *AlertingHandler) UpdateRule(c *gin.Context) {
+	id, ok := parseID(c, "alert rule")
+	if !ok {
+		return
+	}
+
+	var input service.AlertRuleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	rule, err := h.service.UpdateRule(sharedapi.RequestContext(c), id, input)
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// DeleteRule handles DELETE /api/v1/alerts/rules/:id
+func (h *AlertingHandler) DeleteRule(c *gin.Context) {
+	id, ok := parseID(c, "alert rule")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRule(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alert rule deleted"})
+}
+
+// CreateDigest handles POST /api/v1/alerts/digests
+func (h *AlertingHandler) CreateDigest(c *gin.Context) {
+	var input service.AlertDigestInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	digest, err := h.service.CreateDigest(sharedapi.RequestContext(c), input, actor(c))
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": digest})
+}
+
+// ListDigests handles GET /api/v1/alerts/digests
+func (h *AlertingHandler) ListDigests(c *gin.Context) {
+	digests, err := h.service.ListDigests(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list alert digests",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": digests, "total": len(digests)})
+}
+
+// GetDigest handles GET /api/v1/alerts/digests/:id
+func (h *AlertingHandler) GetDigest(c *gin.Context) {
+	id, ok := parseID(c, "alert digest")
+	if !ok {
+		return
+	}
+
+	digest, err := h.service.GetDigest(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": digest})
+}
+
+// UpdateDigest handles PUT /api/v1/alerts/digests/:id
+func (h *AlertingHandler) UpdateDigest(c *gin.Context) {
+	id, ok := parseID(c, "alert digest")
+	if !ok {
+		return
+	}
+
+	var input service.AlertDigestInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	digest, err := h.service.UpdateDigest(sharedapi.RequestContext(c), id, input)
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": digest})
+}
+
+// DeleteDigest handles DELETE /api/v1/alerts/digests/:id
+func (h *AlertingHandler) DeleteDigest(c *gin.Context) {
+	id, ok := parseID(c, "alert digest")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteDigest(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alert digest deleted"})
+}
+
+// PreviewDigest handles GET /api/v1/alerts/digests/:id/preview
+func (h *AlertingHandler) PreviewDigest(c *gin.Context) {
+	id, ok := parseID(c, "alert digest")
+	if !ok {
+		return
+	}
+
+	preview, err := h.service.PreviewDigest(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForAlertingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": preview})
+}
+
+// ListDeliveries handles GET /api/v1/alerts/deliveries
+func (h *AlertingHandler) ListDeliveries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	deliveries, err := h.service.ListDeliveries(sharedapi.RequestContext(c), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list alert deliveries",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": deliveries, "total": len(deliveries)})
+}
+
+func actor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func parseID(c *gin.Context, resource string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + resource + " ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForAlertingError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"), strings.Contains(msg, "must not"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
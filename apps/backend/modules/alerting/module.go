@@ -0,0 +1,91 @@
+package alerting
+
+import (
+	"context"
+	"log"
+
+	"github.com/arc-platform/backend/modules/alerting/api"
+	"github.com/arc-platform/backend/modules/alerting/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// AlertingModule emails alert rule matches as they are ingested and sends
+// scheduled digests of new findings and remediation progress
+type AlertingModule struct {
+	alertingService *service.AlertingService
+	alertingHandler *api.AlertingHandler
+
+	deps         *interfaces.ModuleDependencies
+	cancelWorker context.CancelFunc
+}
+
+// NewAlertingModule creates a new alerting module
+func NewAlertingModule() *AlertingModule {
+	return &AlertingModule{}
+}
+
+// Name returns the module name
+func (m *AlertingModule) Name() string {
+	return "alerting"
+}
+
+// Initialize sets up the module and starts the alerting worker when enabled
+func (m *AlertingModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("🔔 Initializing Alerting Module...")
+
+	var cfg config.AlertingConfig
+	if deps.Config != nil {
+		cfg = deps.Config.Alerting
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.alertingService = service.NewAlertingService(repo, mailer.New(cfg), cfg, deps.AuditLogger)
+	m.alertingHandler = api.NewAlertingHandler(m.alertingService)
+
+	if cfg.Enabled {
+		if cfg.SMTPHost == "" {
+			log.Printf("WARN: Alerting enabled but SMTP_HOST is not set; deliveries will be recorded as skipped")
+		}
+
+		var workerCtx context.Context
+		workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+		go m.alertingService.StartWorker(workerCtx, cfg.IntervalSeconds)
+	}
+
+	log.Println("✅ Alerting Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *AlertingModule) RegisterRoutes(router *gin.RouterGroup) {
+	alerts := router.Group("/alerts")
+	{
+		alerts.GET("/rules", m.alertingHandler.ListRules)
+		alerts.POST("/rules", m.alertingHandler.CreateRule)
+		alerts.GET("/rules/:id", m.alertingHandler.GetRule)
+		alerts.PUT("/rules/:id", m.alertingHandler.UpdateRule)
+		alerts.DELETE("/rules/:id", m.alertingHandler.DeleteRule)
+		alerts.GET("/digests", m.alertingHandler.ListDigests)
+		alerts.POST("/digests", m.alertingHandler.CreateDigest)
+		alerts.GET("/digests/:id", m.alertingHandler.GetDigest)
+		alerts.PUT("/digests/:id", m.alertingHandler.UpdateDigest)
+		alerts.DELETE("/digests/:id", m.alertingHandler.DeleteDigest)
+		alerts.GET("/digests/:id/preview", m.alertingHandler.PreviewDigest)
+		alerts.GET("/deliveries", m.alertingHandler.ListDeliveries)
+	}
+	log.Printf("🔔 Alerting routes registered")
+}
+
+// Shutdown stops the alerting worker
+func (m *AlertingModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Alerting Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+)
+
+// validSeverities are the finding severities a rule may filter on
+var validSeverities = map[string]bool{"critical": true, "high": true, "medium": true, "low": true}
+
+// nextDigestRun returns the first scheduled digest time after the given time.
+// Daily digests run at hour UTC; weekly digests run at hour UTC on Mondays.
+func nextDigestRun(frequency string, after time.Time, hour int) time.Time {
+	after = after.UTC()
+	run := time.Date(after.Year(), after.Month(), after.Day(), hour, 0, 0, 0, time.UTC)
+
+	step := 24 * time.Hour
+	if frequency == entity.DigestWeekly {
+		daysSinceMonday := (int(run.Weekday()) + 6) % 7
+		run = run.AddDate(0, 0, -daysSinceMonday)
+		step = 7 * 24 * time.Hour
+	}
+
+	for !run.After(after) {
+		run = run.Add(step)
+	}
+	return run
+}
+
+// digestPeriod is the span a digest covers when it has not been sent before
+func digestPeriod(frequency string) time.Duration {
+	if frequency == entity.DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// normalizeRecipients validates email addresses and drops duplicates
+func normalizeRecipients(recipients []string) ([]string, error) {
+	seen := map[string]bool{}
+	var result []string
+	for _, r := range recipients {
+		addr, err := mail.ParseAddress(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q", r)
+		}
+		key := strings.ToLower(addr.Address)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, addr.Address)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("recipients must not be empty")
+	}
+	return result, nil
+}
+
+// normalizeFilter trims filter values and drops blanks and case-insensitive duplicates
+func normalizeFilter(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+// renderAlertEmail lists the findings that triggered a rule
+func renderAlertEmail(rule *entity.AlertRule, matches []entity.AlertFinding, total int, dashboardURL string) mailer.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Alert rule %q matched %d new finding(s).\n\n", rule.Name, total)
+
+	for _, m := range matches {
+		asset := m.AssetName
+		if asset == "" {
+			asset = m.AssetID.String()
+		}
+		fmt.Fprintf(&b, "- [%s] %s on %s", strings.ToUpper(orDash(m.Severity)), m.PatternName, asset)
+
+		var details []string
+		if m.ClassificationType != "" {
+			details = append(details, m.ClassificationType)
+		}
+		if m.Environment != "" {
+			details = append(details, m.Environment)
+		}
+		if len(details) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+		}
+		fmt.Fprintf(&b, " at %s\n", m.CreatedAt.UTC().Format(time.RFC3339))
+	}
+	if more := total - len(matches); more > 0 {
+		fmt.Fprintf(&b, "...and %d more\n", more)
+	}
+
+	writeFooter(&b, dashboardURL)
+	return mailer.Message{
+		To:      rule.Recipients,
+		Subject: fmt.Sprintf("[ARC-Hawk] %s: %d new finding(s)", rule.Name, total),
+		Body:    b.String(),
+	}
+}
+
+// renderDigestEmail summarizes a digest period
+func renderDigestEmail(digest *entity.AlertDigest, s *entity.AlertDigestSummary, dashboardURL string) mailer.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s digest for %s to %s (UTC)\n\n", titleCase(digest.Frequency),
+		s.PeriodStart.UTC().Format("2006-01-02 15:04"), s.PeriodEnd.UTC().Format("2006-01-02 15:04"))
+
+	fmt.Fprintf(&b, "New findings: %d\n", s.NewFindings)
+	writeCounts(&b, "By severity", s.NewBySeverity)
+	writeCounts(&b, "By classification", s.NewByClassification)
+	if len(s.TopAssets) > 0 {
+		b.WriteString("Top assets:\n")
+		for _, a := range s.TopAssets {
+			fmt.Fprintf(&b, "  %s: %d\n", orDash(a.AssetName), a.Count)
+		}
+	}
+
+	b.WriteString("\nRemediation progress\n")
+	fmt.Fprintf(&b, "Findings remediated: %d\n", s.FindingsRemediated)
+	writeCounts(&b, "Remediation actions", s.RemediationsByStatus)
+	writeCounts(&b, "Reviews", s.ReviewsByStatus)
+	fmt.Fprintf(&b, "Open critical findings: %d\n", s.OpenCriticalFindings)
+
+	writeFooter(&b, dashboardURL)
+	return mailer.Message{
+		To:      digest.Recipients,
+		Subject: fmt.Sprintf("[ARC-Hawk] %s: %d new finding(s), %d remediated", digest.Name, s.NewFindings, s.FindingsRemediated),
+		Body:    b.String(),
+	}
+}
+
+// writeCounts writes a labelled breakdown, largest first
+func writeCounts(b *strings.Builder, label string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Fprintf(b, "%s:\n", label)
+	for _, k := range keys {
+		fmt.Fprintf(b, "  %s: %d\n", k, counts[k])
+	}
+}
+
+func writeFooter(b *strings.Builder, dashboardURL string) {
+	if dashboardURL != "" {
+		fmt.Fprintf(b, "\nOpen the dashboard: %s\n", dashboardURL)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
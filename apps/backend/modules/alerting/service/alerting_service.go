@@ -0,0 +1,470 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	// alertMatchLimit caps the findings listed in a single alert email
+	alertMatchLimit = 20
+
+	// alertLag keeps rule evaluation behind NOW so findings from ingestion
+	// transactions that commit slightly late are not skipped by the cursor
+	alertLag = 5 * time.Second
+)
+
+// AlertingService manages alert rules and digest subscriptions and emails them
+type AlertingService struct {
+	repo         *persistence.PostgresRepository
+	mailer       mailer.Mailer // nil when SMTP is not configured
+	auditLogger  interfaces.AuditLogger
+	digestHour   int
+	dashboardURL string
+}
+
+// NewAlertingService creates a new alerting service. A nil mailer logs
+// deliveries as skipped instead of sending them.
+func NewAlertingService(repo *persistence.PostgresRepository, m mailer.Mailer, cfg config.AlertingConfig, auditLogger interfaces.AuditLogger) *AlertingService {
+	hour := cfg.DigestHourUTC
+	if hour < 0 || hour > 23 {
+		hour = 8
+	}
+	return &AlertingService{
+		repo:         repo,
+		mailer:       m,
+		auditLogger:  auditLogger,
+		digestHour:   hour,
+		dashboardURL: cfg.DashboardURL,
+	}
+}
+
+// AlertRuleInput is the payload for creating or replacing an alert rule
+type AlertRuleInput struct {
+	Name                string   `json:"name"`
+	Severities          []string `json:"severities"`
+	ClassificationTypes []string `json:"classification_types"`
+	Environments        []string `json:"environments"`
+	PatternNames        []string `json:"pattern_names"`
+	Recipients          []string `json:"recipients"`
+	IsActive            *bool    `json:"is_active,omitempty"` // Defaults to true
+}
+
+// AlertDigestInput is the payload for creating or replacing a digest subscription
+type AlertDigestInput struct {
+	Name       string   `json:"name"`
+	Frequency  string   `json:"frequency"`
+	Recipients []string `json:"recipients"`
+	IsActive   *bool    `json:"is_active,omitempty"` // Defaults to true
+}
+
+// DigestPreview is a digest rendered for the current period without sending it
+type DigestPreview struct {
+	Summary *entity.AlertDigestSummary `json:"summary"`
+	Subject string                     `json:"subject"`
+	Body    string                     `json:"body"`
+}
+
+// CreateRule validates and stores a new alert rule. Only findings created after
+// the rule are alerted.
+func (s *AlertingService) CreateRule(ctx context.Context, input AlertRuleInput, createdBy string) (*entity.AlertRule, error) {
+	rule := &entity.AlertRule{
+		ID:             uuid.New(),
+		IsActive:       true,
+		EvaluatedUntil: cursorTime(time.Now()),
+		CreatedBy:      createdBy,
+	}
+	if err := applyRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateAlertRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "ALERT_RULE_CREATED", "alert_rule", rule.ID, map[string]interface{}{
+		"name":       rule.Name,
+		"recipients": len(rule.Recipients),
+	})
+	return rule, nil
+}
+
+// GetRule returns an alert rule
+func (s *AlertingService) GetRule(ctx context.Context, id uuid.UUID) (*entity.AlertRule, error) {
+	return s.repo.GetAlertRule(ctx, id)
+}
+
+// ListRules returns the tenant's alert rules
+func (s *AlertingService) ListRules(ctx context.Context) ([]*entity.AlertRule, error) {
+	rules, err := s.repo.ListAlertRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []*entity.AlertRule{}
+	}
+	return rules, nil
+}
+
+// UpdateRule replaces an alert rule's name, filters, recipients and active flag
+func (s *AlertingService) UpdateRule(ctx context.Context, id uuid.UUID, input AlertRuleInput) (*entity.AlertRule, error) {
+	rule := &entity.AlertRule{ID: id, IsActive: true}
+	if err := applyRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAlertRule(ctx, rule, cursorTime(time.Now())); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "ALERT_RULE_UPDATED", "alert_rule", rule.ID, map[string]interface{}{
+		"name":      rule.Name,
+		"is_active": rule.IsActive,
+	})
+	return rule, nil
+}
+
+// DeleteRule removes an alert rule; its delivery history is kept
+func (s *AlertingService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteAlertRule(ctx, id); err != nil {
+		return err
+	}
+	s.audit(ctx, "ALERT_RULE_DELETED", "alert_rule", id, nil)
+	return nil
+}
+
+// CreateDigest validates and stores a new digest subscription
+func (s *AlertingService) CreateDigest(ctx context.Context, input AlertDigestInput, createdBy string) (*entity.AlertDigest, error) {
+	digest := &entity.AlertDigest{
+		ID:        uuid.New(),
+		IsActive:  true,
+		CreatedBy: createdBy,
+	}
+	if err := applyDigestInput(digest, input); err != nil {
+		return nil, err
+	}
+	digest.NextRunAt = nextDigestRun(digest.Frequency, time.Now(), s.digestHour)
+
+	if err := s.repo.CreateAlertDigest(ctx, digest); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "ALERT_DIGEST_CREATED", "alert_digest", digest.ID, map[string]interface{}{
+		"name":      digest.Name,
+		"frequency": digest.Frequency,
+	})
+	return digest, nil
+}
+
+// GetDigest returns a digest subscription
+func (s *AlertingService) GetDigest(ctx context.Context, id uuid.UUID) (*entity.AlertDigest, error) {
+	return s.repo.GetAlertDigest(ctx, id)
+}
+
+// ListDigests returns the tenant's digest subscriptions
+func (s *AlertingService) ListDigests(ctx context.Context) ([]*entity.AlertDigest, error) {
+	digests, err := s.repo.ListAlertDigests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if digests == nil {
+		digests = []*entity.AlertDigest{}
+	}
+	return digests, nil
+}
+
+// UpdateDigest replaces a digest subscription. The next run is rescheduled for the
+// (possibly changed) frequency.
+func (s *AlertingService) UpdateDigest(ctx context.Context, id uuid.UUID, input AlertDigestInput) (*entity.AlertDigest, error) {
+	digest := &entity.AlertDigest{ID: id, IsActive: true}
+	if err := applyDigestInput(digest, input); err != nil {
+		return nil, err
+	}
+	digest.NextRunAt = nextDigestRun(digest.Frequency, time.Now(), s.digestHour)
+
+	if err := s.repo.UpdateAlertDigest(ctx, digest); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "ALERT_DIGEST_UPDATED", "alert_digest", digest.ID, map[string]interface{}{
+		"name":      digest.Name,
+		"frequency": digest.Frequency,
+		"is_active": digest.IsActive,
+	})
+	return digest, nil
+}
+
+// DeleteDigest removes a digest subscription; its delivery history is kept
+func (s *AlertingService) DeleteDigest(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteAlertDigest(ctx, id); err != nil {
+		return err
+	}
+	s.audit(ctx, "ALERT_DIGEST_DELETED", "alert_digest", id, nil)
+	return nil
+}
+
+// PreviewDigest renders what the digest would contain if it were sent now
+func (s *AlertingService) PreviewDigest(ctx context.Context, id uuid.UUID) (*DigestPreview, error) {
+	digest, err := s.repo.GetAlertDigest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	summary, err := s.repo.GetAlertDigestSummary(ctx, digestPeriodStart(digest, now), now)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := renderDigestEmail(digest, summary, s.dashboardURL)
+	return &DigestPreview{Summary: summary, Subject: msg.Subject, Body: msg.Body}, nil
+}
+
+// ListDeliveries returns the tenant's recent alert and digest emails
+func (s *AlertingService) ListDeliveries(ctx context.Context, limit, offset int) ([]*entity.AlertDelivery, error) {
+	if limit < 1 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	deliveries, err := s.repo.ListAlertDeliveries(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []*entity.AlertDelivery{}
+	}
+	return deliveries, nil
+}
+
+// StartWorker periodically evaluates alert rules and sends due digests for every tenant
+func (s *AlertingService) StartWorker(ctx context.Context, intervalSeconds int) {
+	if intervalSeconds < 1 {
+		intervalSeconds = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Printf("🔔 Starting alerting worker (interval: %ds, email: %t)", intervalSeconds, s.mailer != nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Alerting worker stopped")
+			return
+		case <-ticker.C:
+			s.EvaluateRules(ctx)
+			s.SendDueDigests(ctx)
+		}
+	}
+}
+
+// EvaluateRules emails every active rule's new matching findings since its last evaluation
+func (s *AlertingService) EvaluateRules(ctx context.Context) {
+	rules, err := s.repo.ListActiveAlertRules(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing alert rules: %v", err)
+		return
+	}
+
+	until := cursorTime(time.Now().Add(-alertLag))
+	for _, rule := range rules {
+		if !until.After(rule.EvaluatedUntil) {
+			continue
+		}
+		tenantCtx := context.WithValue(ctx, "tenant_id", rule.TenantID)
+		if err := s.evaluateRule(tenantCtx, rule, until); err != nil {
+			log.Printf("❌ Alert rule %s (%s) failed: %v", rule.ID, rule.Name, err)
+		}
+	}
+}
+
+// evaluateRule alerts the findings created in (rule.EvaluatedUntil, until]. The window is
+// claimed before sending so replicas never alert it twice; a failed send releases it for retry.
+func (s *AlertingService) evaluateRule(ctx context.Context, rule *entity.AlertRule, until time.Time) error {
+	from := rule.EvaluatedUntil
+	matches, total, err := s.repo.ListAlertRuleMatches(ctx, rule, from, until, alertMatchLimit)
+	if err != nil {
+		return err
+	}
+
+	claimed, err := s.repo.AdvanceAlertRule(ctx, rule.ID, from, until, total > 0)
+	if err != nil || !claimed || total == 0 {
+		return err
+	}
+
+	msg := renderAlertEmail(rule, matches, total, s.dashboardURL)
+	delivery := &entity.AlertDelivery{
+		RuleID:       &rule.ID,
+		Kind:         entity.AlertDeliveryKindAlert,
+		FindingCount: total,
+	}
+
+	if err := s.deliver(ctx, rule.TenantID, delivery, msg); err != nil {
+		if _, releaseErr := s.repo.AdvanceAlertRule(ctx, rule.ID, until, from, false); releaseErr != nil {
+			log.Printf("WARNING: %v", releaseErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// SendDueDigests sends every digest whose scheduled time has passed
+func (s *AlertingService) SendDueDigests(ctx context.Context) {
+	now := time.Now().UTC()
+	digests, err := s.repo.ListDueAlertDigests(ctx, now)
+	if err != nil {
+		log.Printf("❌ Error listing due digests: %v", err)
+		return
+	}
+
+	for _, digest := range digests {
+		tenantCtx := context.WithValue(ctx, "tenant_id", digest.TenantID)
+		if err := s.sendDigest(tenantCtx, digest, now); err != nil {
+			log.Printf("❌ Digest %s (%s) failed: %v", digest.ID, digest.Name, err)
+		}
+	}
+}
+
+// sendDigest claims a due digest run and sends it. A failed send is not retried;
+// the next run covers the missed period because last_sent_at does not move.
+func (s *AlertingService) sendDigest(ctx context.Context, digest *entity.AlertDigest, now time.Time) error {
+	periodEnd := digest.NextRunAt
+	claimed, err := s.repo.ClaimAlertDigest(ctx, digest.ID, digest.NextRunAt, nextDigestRun(digest.Frequency, now, s.digestHour))
+	if err != nil || !claimed {
+		return err
+	}
+
+	summary, err := s.repo.GetAlertDigestSummary(ctx, digestPeriodStart(digest, periodEnd), periodEnd)
+	if err != nil {
+		return err
+	}
+
+	msg := renderDigestEmail(digest, summary, s.dashboardURL)
+	delivery := &entity.AlertDelivery{
+		DigestID:     &digest.ID,
+		Kind:         entity.AlertDeliveryKindDigest,
+		FindingCount: summary.NewFindings,
+	}
+	if err := s.deliver(ctx, digest.TenantID, delivery, msg); err != nil {
+		return err
+	}
+
+	return s.repo.MarkAlertDigestSent(ctx, digest.ID, periodEnd)
+}
+
+// deliver sends msg and records the attempt. Without a mailer the delivery is
+// recorded as skipped and treated as done.
+func (s *AlertingService) deliver(ctx context.Context, tenantID uuid.UUID, delivery *entity.AlertDelivery, msg mailer.Message) error {
+	delivery.ID = uuid.New()
+	delivery.TenantID = tenantID
+	delivery.Recipients = msg.To
+	delivery.Subject = msg.Subject
+
+	var sendErr error
+	switch {
+	case s.mailer == nil:
+		delivery.Status = entity.AlertDeliverySkipped
+		delivery.Error = "email is not configured"
+		log.Printf("🔕 %s (email not configured)", msg.Subject)
+	default:
+		sendErr = s.mailer.Send(ctx, msg)
+		delivery.Status = entity.AlertDeliverySent
+		if sendErr != nil {
+			delivery.Status = entity.AlertDeliveryFailed
+			delivery.Error = sendErr.Error()
+		}
+	}
+
+	if err := s.repo.RecordAlertDelivery(ctx, delivery); err != nil {
+		log.Printf("WARNING: %v", err)
+	}
+	return sendErr
+}
+
+func (s *AlertingService) audit(ctx context.Context, action, resourceType string, id uuid.UUID, metadata map[string]interface{}) {
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, resourceType, id.String(), metadata)
+	}
+}
+
+// applyRuleInput validates input onto rule
+func applyRuleInput(rule *entity.AlertRule, input AlertRuleInput) error {
+	rule.Name = strings.TrimSpace(input.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+
+	rule.Severities = normalizeFilter(input.Severities)
+	for _, sev := range rule.Severities {
+		if !validSeverities[strings.ToLower(sev)] {
+			return fmt.Errorf("invalid severity %q: must be Critical, High, Medium or Low", sev)
+		}
+	}
+	rule.ClassificationTypes = normalizeFilter(input.ClassificationTypes)
+	rule.Environments = normalizeFilter(input.Environments)
+	rule.PatternNames = normalizeFilter(input.PatternNames)
+
+	recipients, err := normalizeRecipients(input.Recipients)
+	if err != nil {
+		return err
+	}
+	rule.Recipients = recipients
+
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+// applyDigestInput validates input onto digest
+func applyDigestInput(digest *entity.AlertDigest, input AlertDigestInput) error {
+	digest.Name = strings.TrimSpace(input.Name)
+	if digest.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+
+	digest.Frequency = strings.ToLower(strings.TrimSpace(input.Frequency))
+	if digest.Frequency != entity.DigestDaily && digest.Frequency != entity.DigestWeekly {
+		return fmt.Errorf("invalid frequency %q: must be daily or weekly", input.Frequency)
+	}
+
+	recipients, err := normalizeRecipients(input.Recipients)
+	if err != nil {
+		return err
+	}
+	digest.Recipients = recipients
+
+	if input.IsActive != nil {
+		digest.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+// digestPeriodStart is where a digest ending at end starts: the end of the
+// last sent period, or one period back for a first digest
+func digestPeriodStart(digest *entity.AlertDigest, end time.Time) time.Time {
+	if digest.LastSentAt != nil && digest.LastSentAt.Before(end) {
+		return *digest.LastSentAt
+	}
+	return end.Add(-digestPeriod(digest.Frequency))
+}
+
+// cursorTime normalizes a time to what a TIMESTAMP column stores, so cursors
+// written by the worker compare equal when read back
+func cursorTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
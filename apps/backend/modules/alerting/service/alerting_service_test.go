@@ -0,0 +1,118 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestNextDigestRun(t *testing.T) {
+	// 2026-03-04 is a Wednesday
+	wed := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency string
+		after     time.Time
+		want      time.Time
+	}{
+		{"daily before the hour", entity.DigestDaily, time.Date(2026, 3, 4, 7, 0, 0, 0, time.UTC), time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)},
+		{"daily after the hour", entity.DigestDaily, wed, time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)},
+		{"daily exactly at the hour", entity.DigestDaily, time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC), time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)},
+		{"weekly mid-week", entity.DigestWeekly, wed, time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"weekly on Monday morning", entity.DigestWeekly, time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"weekly on Sunday", entity.DigestWeekly, time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := nextDigestRun(tt.frequency, tt.after, 8); !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestApplyRuleInput(t *testing.T) {
+	rule := &entity.AlertRule{IsActive: true}
+	err := applyRuleInput(rule, AlertRuleInput{
+		Name:                " Critical SPD in Production ",
+		Severities:          []string{"Critical", "critical", " "},
+		ClassificationTypes: []string{"Sensitive Personal Data"},
+		Environments:        []string{"PROD"},
+		Recipients:          []string{"DPO <dpo@example.com>", "dpo@example.com", "sec@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rule.Name != "Critical SPD in Production" {
+		t.Errorf("expected a trimmed name, got %q", rule.Name)
+	}
+	if len(rule.Severities) != 1 {
+		t.Errorf("expected duplicate and blank severities dropped, got %v", rule.Severities)
+	}
+	if len(rule.Recipients) != 2 || rule.Recipients[0] != "dpo@example.com" {
+		t.Errorf("expected two bare, de-duplicated recipients, got %v", rule.Recipients)
+	}
+	if rule.PatternNames == nil {
+		t.Error("expected an empty pattern filter rather than nil")
+	}
+
+	invalid := []AlertRuleInput{
+		{Recipients: []string{"dpo@example.com"}},
+		{Name: "x", Recipients: []string{"not an address"}},
+		{Name: "x"},
+		{Name: "x", Severities: []string{"urgent"}, Recipients: []string{"dpo@example.com"}},
+	}
+	for i, input := range invalid {
+		if err := applyRuleInput(&entity.AlertRule{}, input); err == nil {
+			t.Errorf("case %d: expected a validation error", i)
+		}
+	}
+}
+
+func TestDigestPeriodStart(t *testing.T) {
+	end := time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)
+
+	first := &entity.AlertDigest{Frequency: entity.DigestWeekly}
+	if got := digestPeriodStart(first, end); !got.Equal(end.AddDate(0, 0, -7)) {
+		t.Errorf("expected a first weekly digest to cover one week, got %s", got)
+	}
+
+	// A failed send leaves last_sent_at behind, so the next digest covers the gap
+	lastSent := end.AddDate(0, 0, -3)
+	resumed := &entity.AlertDigest{Frequency: entity.DigestDaily, LastSentAt: &lastSent}
+	if got := digestPeriodStart(resumed, end); !got.Equal(lastSent) {
+		t.Errorf("expected the period to start at the last sent digest, got %s", got)
+	}
+}
+
+func TestRenderAlertEmail(t *testing.T) {
+	rule := &entity.AlertRule{Name: "Critical SPD", Recipients: []string{"dpo@example.com"}}
+	matches := []entity.AlertFinding{{
+		FindingID:          uuid.New(),
+		AssetName:          "customers.csv",
+		PatternName:        "IN_AADHAAR",
+		Severity:           "Critical",
+		ClassificationType: "Sensitive Personal Data",
+		Environment:        "PROD",
+		CreatedAt:          time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+	}}
+
+	msg := renderAlertEmail(rule, matches, 3, "https://hawk.example.com")
+
+	if msg.Subject != "[ARC-Hawk] Critical SPD: 3 new finding(s)" {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	for _, want := range []string{
+		"- [CRITICAL] IN_AADHAAR on customers.csv (Sensitive Personal Data, PROD)",
+		"...and 2 more",
+		"https://hawk.example.com",
+	} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("expected body to contain %q, got:\n%s", want, msg.Body)
+		}
+	}
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/arc-platform/backend/modules/analytics/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,11 +23,25 @@ func NewAnalyticsHandler(service *service.AnalyticsService) *AnalyticsHandler {
 	}
 }
 
-// GetPIIHeatmap returns the PII distribution heatmap
-// GET /api/v1/analytics/heatmap
+// GetPIIHeatmap returns finding counts and the highest risk per PII type for each
+// combination of the grouped dimensions (environment, data_source, host)
+// GET /api/v1/analytics/heatmap?group_by=environment,data_source
 func (h *AnalyticsHandler) GetPIIHeatmap(c *gin.Context) {
-	heatmap, err := h.service.GetPIIHeatmap(c.Request.Context())
+	var dimensions []string
+	if groupBy := c.Query("group_by"); groupBy != "" {
+		for _, dim := range strings.Split(groupBy, ",") {
+			dimensions = append(dimensions, strings.TrimSpace(dim))
+		}
+	}
+
+	heatmap, err := h.service.GetPIIHeatmap(sharedapi.RequestContext(c), dimensions)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidHeatmapDimension) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
 		})
@@ -54,3 +71,59 @@ func (h *AnalyticsHandler) GetRiskTrend(c *gin.Context) {
 
 	c.JSON(http.StatusOK, trend)
 }
+
+// GetPatternNoise returns each pattern's finding volume, confirmed and false positive
+// feedback and average confidence over time, with noisy patterns to tune first
+// GET /api/v1/analytics/patterns?days=90&interval=week&min_reviewed=5
+func (h *AnalyticsHandler) GetPatternNoise(c *gin.Context) {
+	opts := service.PatternNoiseOptions{Interval: c.Query("interval")}
+	for param, dest := range map[string]*int{"days": &opts.Days, "min_reviewed": &opts.MinReviewed} {
+		if v := c.Query(param); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be a positive integer"})
+				return
+			}
+			*dest = n
+		}
+	}
+
+	report, err := h.service.GetPatternNoise(sharedapi.RequestContext(c), opts)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetLanguageBreakdown returns the tenant's finding counts per language detected
+// in their sample text, with how many fall outside the supported languages
+// GET /api/v1/analytics/languages?days=90
+func (h *AnalyticsHandler) GetLanguageBreakdown(c *gin.Context) {
+	days := 0
+	if v := c.Query("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive integer"})
+			return
+		}
+		days = n
+	}
+
+	breakdown, err := h.service.GetLanguageBreakdown(sharedapi.RequestContext(c), days)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/analytics/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsSinkHandler handles analytics sink sync endpoints
+type AnalyticsSinkHandler struct {
+	service *service.AnalyticsSyncService
+}
+
+// NewAnalyticsSinkHandler creates a new analytics sink handler
+func NewAnalyticsSinkHandler(service *service.AnalyticsSyncService) *AnalyticsSinkHandler {
+	return &AnalyticsSinkHandler{service: service}
+}
+
+// GetStatus returns how far each stream has been mirrored into the sink
+// GET /api/v1/analytics/sink
+func (h *AnalyticsSinkHandler) GetStatus(c *gin.Context) {
+	status, err := h.service.Status(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": status})
+}
+
+// Sync mirrors rows changed since the last run without waiting for the worker
+// POST /api/v1/analytics/sink/sync
+func (h *AnalyticsSinkHandler) Sync(c *gin.Context) {
+	result, err := h.service.Sync(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error(), "data": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// Rebuild empties the sink so the next sync mirrors everything again
+// POST /api/v1/analytics/sink/rebuild
+func (h *AnalyticsSinkHandler) Rebuild(c *gin.Context) {
+	if err := h.service.Rebuild(sharedapi.RequestContext(c)); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Analytics sink reset; the next sync mirrors all rows"})
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/analytics/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// BenchmarkHandler handles cross-tenant benchmarking endpoints
+type BenchmarkHandler struct {
+	service *service.BenchmarkService
+}
+
+// NewBenchmarkHandler creates a new benchmark handler
+func NewBenchmarkHandler(service *service.BenchmarkService) *BenchmarkHandler {
+	return &BenchmarkHandler{service: service}
+}
+
+// GetBenchmark compares the tenant's exposure metrics with anonymized baselines
+// GET /api/v1/analytics/benchmark
+func (h *BenchmarkHandler) GetBenchmark(c *gin.Context) {
+	report, err := h.service.GetBenchmark(sharedapi.RequestContext(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "opted out") {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// GetParticipation reports whether the tenant contributes to benchmark baselines
+// GET /api/v1/analytics/benchmark/participation
+func (h *BenchmarkHandler) GetParticipation(c *gin.Context) {
+	participation, err := h.service.GetParticipation(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": participation})
+}
+
+// SetParticipation opts the tenant out of, or back into, benchmarking
+// PUT /api/v1/analytics/benchmark/participation
+func (h *BenchmarkHandler) SetParticipation(c *gin.Context) {
+	var input struct {
+		OptedOut *bool `json:"opted_out" binding:"required"`
+	}
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	updatedBy := c.GetString("user_email")
+	if updatedBy == "" {
+		updatedBy = "system"
+	}
+
+	participation, err := h.service.SetParticipation(sharedapi.RequestContext(c), *input.OptedOut, updatedBy)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": participation})
+}
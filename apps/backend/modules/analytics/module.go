@@ -1,10 +1,13 @@
 package analytics
 
 import (
+	"context"
 	"log"
 
 	"github.com/arc-platform/backend/modules/analytics/api"
 	"github.com/arc-platform/backend/modules/analytics/service"
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/clickhouse"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
@@ -13,7 +16,13 @@ import (
 type AnalyticsModule struct {
 	analyticsService *service.AnalyticsService
 	analyticsHandler *api.AnalyticsHandler
+	benchmarkHandler *api.BenchmarkHandler
+	sinkHandler      *api.AnalyticsSinkHandler // nil unless an analytics sink is configured
+	authMiddleware   *middleware.AuthMiddleware
 	deps             *interfaces.ModuleDependencies
+
+	workerCtx    context.Context
+	cancelWorker context.CancelFunc
 }
 
 func (m *AnalyticsModule) Name() string {
@@ -27,7 +36,22 @@ func (m *AnalyticsModule) Initialize(deps *interfaces.ModuleDependencies) error
 	repo := persistence.NewPostgresRepository(deps.DB)
 
 	m.analyticsService = service.NewAnalyticsService(repo)
+	if deps.Config != nil {
+		m.analyticsService.SetSupportedLanguages(deps.Config.Ingestion.SupportedLanguages)
+	}
 	m.analyticsHandler = api.NewAnalyticsHandler(m.analyticsService)
+	m.benchmarkHandler = api.NewBenchmarkHandler(service.NewBenchmarkService(repo, deps.AuditLogger))
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	if deps.Config != nil && deps.Config.AnalyticsSink.ClickHouseURL != "" {
+		cfg := deps.Config.AnalyticsSink
+		sink := service.NewClickHouseSink(clickhouse.New(cfg.ClickHouseURL, cfg.ClickHouseUser, cfg.ClickHousePassword), cfg.ClickHouseDatabase)
+		syncService := service.NewAnalyticsSyncService(repo, sink, cfg.BatchSize, cfg.SettleSeconds, cfg.ReadFromSink)
+		m.analyticsService.SetSink(sink, cfg.ReadFromSink)
+		m.sinkHandler = api.NewAnalyticsSinkHandler(syncService)
+		go syncService.StartSyncWorker(m.workerContext(), cfg.SyncIntervalMinutes)
+		log.Printf("📊 Analytics sink: ClickHouse (read path: %t)", cfg.ReadFromSink)
+	}
 
 	log.Printf("✅ Analytics Module initialized")
 	return nil
@@ -38,15 +62,42 @@ func (m *AnalyticsModule) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		analytics.GET("/heatmap", m.analyticsHandler.GetPIIHeatmap)
 		analytics.GET("/trends", m.analyticsHandler.GetRiskTrend)
+		analytics.GET("/patterns", m.analyticsHandler.GetPatternNoise)
+		analytics.GET("/languages", m.analyticsHandler.GetLanguageBreakdown)
+
+		// Cross-tenant comparisons are admin-only and expose aggregates only
+		benchmark := analytics.Group("/benchmark", m.authMiddleware.RequireRole("admin"))
+		benchmark.GET("", m.benchmarkHandler.GetBenchmark)
+		benchmark.GET("/participation", m.benchmarkHandler.GetParticipation)
+		benchmark.PUT("/participation", m.benchmarkHandler.SetParticipation)
+
+		// Sink sync state is shared by every tenant, so it is admin-only too
+		if m.sinkHandler != nil {
+			sink := analytics.Group("/sink", m.authMiddleware.RequireRole("admin"))
+			sink.GET("", m.sinkHandler.GetStatus)
+			sink.POST("/sync", m.sinkHandler.Sync)
+			sink.POST("/rebuild", m.sinkHandler.Rebuild)
+		}
 	}
 	log.Printf("📊 Analytics routes registered")
 }
 
 func (m *AnalyticsModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Analytics Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
 	return nil
 }
 
+// workerContext returns the context shared by background workers, cancelled on shutdown
+func (m *AnalyticsModule) workerContext() context.Context {
+	if m.workerCtx == nil {
+		m.workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+	}
+	return m.workerCtx
+}
+
 func NewAnalyticsModule() *AnalyticsModule {
 	return &AnalyticsModule{}
 }
@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
@@ -12,28 +16,49 @@ import (
 
 // AnalyticsService provides PII heatmap and trend analytics
 type AnalyticsService struct {
-	pgRepo *persistence.PostgresRepository
+	pgRepo       *persistence.PostgresRepository
+	sink         AnalyticsSink
+	readFromSink bool
+
+	// Languages ingestion treats as in scope; English when unset
+	supportedLanguages []string
 }
 
-// PIIHeatmap represents PII distribution across asset types and PII types
+// HeatmapDimensions are the asset dimensions heatmap rows can be grouped on
+var HeatmapDimensions = []string{"environment", "data_source", "host"}
+
+// DefaultHeatmapDimensions group heatmap rows by environment and data source
+var DefaultHeatmapDimensions = []string{"environment", "data_source"}
+
+// ErrInvalidHeatmapDimension is returned for a group_by value outside HeatmapDimensions
+var ErrInvalidHeatmapDimension = errors.New("invalid heatmap dimension")
+
+// PIIHeatmap is a matrix of PII finding counts: one row per combination of the
+// grouped dimensions, one column per PII type
 type PIIHeatmap struct {
-	Rows    []HeatmapRow `json:"rows"`
-	Columns []string     `json:"columns"` // 11 PII types
+	Dimensions    []string     `json:"dimensions"`
+	Columns       []string     `json:"columns"` // PII types, most findings first
+	Rows          []HeatmapRow `json:"rows"`    // Most findings first
+	TotalFindings int          `json:"total_findings"`
 }
 
-// HeatmapRow represents a row in the heatmap (asset type)
+// HeatmapRow is one combination of the grouped dimensions
 type HeatmapRow struct {
-	AssetType string        `json:"asset_type"`
-	Cells     []HeatmapCell `json:"cells"`
-	Total     int           `json:"total"`
+	Label       string        `json:"label"` // Grouped dimension values joined with " / "
+	Environment string        `json:"environment,omitempty"`
+	DataSource  string        `json:"data_source,omitempty"`
+	Host        string        `json:"host,omitempty"`
+	Cells       []HeatmapCell `json:"cells"` // One per column
+	Total       int           `json:"total"`
+	MaxRisk     string        `json:"max_risk"`
 }
 
 // HeatmapCell represents a cell in the heatmap
 type HeatmapCell struct {
 	PIIType      string `json:"pii_type"`
 	FindingCount int    `json:"finding_count"`
-	RiskLevel    string `json:"risk_level"` // critical, high, medium, low
-	Intensity    int    `json:"intensity"`  // 0-100 for color intensity
+	RiskLevel    string `json:"risk_level"` // Critical, High, Medium, Low
+	Intensity    int    `json:"intensity"`  // 0-100 relative to the largest cell
 }
 
 // RiskTrend represents risk trends over time
@@ -61,118 +86,183 @@ func NewAnalyticsService(pgRepo *persistence.PostgresRepository) *AnalyticsServi
 	}
 }
 
-// GetPIIHeatmap returns the PII distribution heatmap
-func (s *AnalyticsService) GetPIIHeatmap(ctx context.Context) (*PIIHeatmap, error) {
-	// Define 11 locked PII types
-	piiTypes := []string{
-		"IN_AADHAAR", "IN_PAN", "IN_PASSPORT", "CREDIT_CARD",
-		"IN_UPI", "IN_IFSC", "IN_BANK_ACCOUNT",
-		"IN_PHONE", "EMAIL_ADDRESS",
-		"IN_VOTER_ID", "IN_DRIVING_LICENSE",
-	}
-
-	// Define asset types
-	assetTypes := []string{"file", "database"}
+// SetSink attaches the analytics sink; with readFromSink, trends are read from it
+func (s *AnalyticsService) SetSink(sink AnalyticsSink, readFromSink bool) {
+	s.sink = sink
+	s.readFromSink = readFromSink
+}
 
-	heatmap := &PIIHeatmap{
-		Rows:    []HeatmapRow{},
-		Columns: piiTypes,
+// GetPIIHeatmap returns the PII distribution heatmap grouped by the given
+// dimensions, DefaultHeatmapDimensions when none are given
+func (s *AnalyticsService) GetPIIHeatmap(ctx context.Context, dimensions []string) (*PIIHeatmap, error) {
+	if len(dimensions) == 0 {
+		dimensions = DefaultHeatmapDimensions
+	}
+	seen := make(map[string]bool, len(dimensions))
+	for _, dim := range dimensions {
+		if !containsString(HeatmapDimensions, dim) || seen[dim] {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHeatmapDimension, dim)
+		}
+		seen[dim] = true
 	}
 
-	// Get all findings
-	findings, err := s.pgRepo.ListFindings(ctx, repository.FindingFilters{}, 10000, 0)
+	cells, err := s.pgRepo.GetPIIHeatmapCells(ctx, dimensions)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list findings: %w", err)
+		return nil, err
 	}
+	return buildPIIHeatmap(dimensions, cells), nil
+}
 
-	// Build heatmap data
-	for _, assetType := range assetTypes {
-		row := HeatmapRow{
-			AssetType: assetType,
-			Cells:     []HeatmapCell{},
-			Total:     0,
-		}
-
-		// Count findings for each PII type
-		piiCounts := make(map[string]int)
-		piiRisks := make(map[string]string)
-		maxCount := 0
-
-		for _, finding := range findings {
-			// Get asset
-			asset, err := s.pgRepo.GetAssetByID(ctx, finding.AssetID)
-			if err != nil || asset.AssetType != assetType {
-				continue
-			}
-
-			// Get classification
-			classifications, err := s.pgRepo.GetClassificationsByFindingID(ctx, finding.ID)
-			if err != nil || len(classifications) == 0 {
-				continue
-			}
-
-			piiType := classifications[0].SubCategory
-			if piiType == "" {
-				continue
-			}
-
-			piiCounts[piiType]++
-			row.Total++
-
-			if piiCounts[piiType] > maxCount {
-				maxCount = piiCounts[piiType]
-			}
+// buildPIIHeatmap arranges grouped counts into the heatmap matrix
+func buildPIIHeatmap(dimensions []string, cells []entity.PIIHeatmapCell) *PIIHeatmap {
+	heatmap := &PIIHeatmap{
+		Dimensions: dimensions,
+		Columns:    []string{},
+		Rows:       []HeatmapRow{},
+	}
 
-			// Track highest risk level
-			if finding.Severity == "Critical" {
-				piiRisks[piiType] = "Critical"
-			} else if finding.Severity == "High" && piiRisks[piiType] != "Critical" {
-				piiRisks[piiType] = "High"
-			} else if finding.Severity == "Medium" && piiRisks[piiType] != "Critical" && piiRisks[piiType] != "High" {
-				piiRisks[piiType] = "Medium"
-			} else if piiRisks[piiType] == "" {
-				piiRisks[piiType] = "Low"
-			}
+	// Columns and rows are ordered by their finding totals
+	columnTotals := make(map[string]int)
+	rowIndex := make(map[string]int)
+	rowCells := make(map[string]map[string]entity.PIIHeatmapCell)
+	maxCount := 0
+	for _, cell := range cells {
+		if _, ok := columnTotals[cell.PIIType]; !ok {
+			heatmap.Columns = append(heatmap.Columns, cell.PIIType)
+		}
+		columnTotals[cell.PIIType] += cell.FindingCount
+
+		key := cell.Environment + "\x00" + cell.DataSource + "\x00" + cell.Host
+		i, ok := rowIndex[key]
+		if !ok {
+			i = len(heatmap.Rows)
+			rowIndex[key] = i
+			rowCells[key] = make(map[string]entity.PIIHeatmapCell)
+			heatmap.Rows = append(heatmap.Rows, HeatmapRow{
+				Label:       heatmapRowLabel(dimensions, cell),
+				Environment: cell.Environment,
+				DataSource:  cell.DataSource,
+				Host:        cell.Host,
+				MaxRisk:     "Low",
+			})
 		}
+		row := &heatmap.Rows[i]
+		row.Total += cell.FindingCount
+		if riskRank(riskLevel(cell.MaxSeverity)) > riskRank(row.MaxRisk) {
+			row.MaxRisk = riskLevel(cell.MaxSeverity)
+		}
+		rowCells[key][cell.PIIType] = cell
 
-		// Create cells for each PII type
-		for _, piiType := range piiTypes {
-			count := piiCounts[piiType]
-			risk := piiRisks[piiType]
-			if risk == "" {
-				risk = "Low"
-			}
+		heatmap.TotalFindings += cell.FindingCount
+		if cell.FindingCount > maxCount {
+			maxCount = cell.FindingCount
+		}
+	}
 
+	sort.SliceStable(heatmap.Columns, func(i, j int) bool {
+		return columnTotals[heatmap.Columns[i]] > columnTotals[heatmap.Columns[j]]
+	})
+	sort.SliceStable(heatmap.Rows, func(i, j int) bool {
+		if heatmap.Rows[i].Total != heatmap.Rows[j].Total {
+			return heatmap.Rows[i].Total > heatmap.Rows[j].Total
+		}
+		return heatmap.Rows[i].Label < heatmap.Rows[j].Label
+	})
+
+	for i := range heatmap.Rows {
+		row := &heatmap.Rows[i]
+		key := row.Environment + "\x00" + row.DataSource + "\x00" + row.Host
+		row.Cells = make([]HeatmapCell, 0, len(heatmap.Columns))
+		for _, piiType := range heatmap.Columns {
+			cell := rowCells[key][piiType]
 			intensity := 0
 			if maxCount > 0 {
-				intensity = (count * 100) / maxCount
+				intensity = (cell.FindingCount * 100) / maxCount
 			}
-
 			row.Cells = append(row.Cells, HeatmapCell{
 				PIIType:      piiType,
-				FindingCount: count,
-				RiskLevel:    risk,
+				FindingCount: cell.FindingCount,
+				RiskLevel:    riskLevel(cell.MaxSeverity),
 				Intensity:    intensity,
 			})
 		}
+	}
+
+	return heatmap
+}
 
-		heatmap.Rows = append(heatmap.Rows, row)
+// heatmapRowLabel joins the grouped dimension values of a cell
+func heatmapRowLabel(dimensions []string, cell entity.PIIHeatmapCell) string {
+	parts := make([]string, 0, len(dimensions))
+	for _, dim := range dimensions {
+		var value string
+		switch dim {
+		case "environment":
+			value = cell.Environment
+		case "data_source":
+			value = cell.DataSource
+		case "host":
+			value = cell.Host
+		}
+		if value == "" {
+			value = "unknown"
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// riskLevel normalizes a stored severity to Critical, High, Medium or Low
+func riskLevel(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return "Critical"
+	case "HIGH":
+		return "High"
+	case "MEDIUM":
+		return "Medium"
+	default:
+		return "Low"
 	}
+}
 
-	return heatmap, nil
+func riskRank(level string) int {
+	switch level {
+	case "Critical":
+		return 3
+	case "High":
+		return 2
+	case "Medium":
+		return 1
+	default:
+		return 0
+	}
 }
 
-// GetRiskTrend returns risk trends over time
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRiskTrend returns risk trends over time. With the analytics sink read path
+// switched on the timeline is counted in the sink; if the sink fails the trend
+// falls back to PostgreSQL.
 func (s *AnalyticsService) GetRiskTrend(ctx context.Context, days int) (*RiskTrend, error) {
 	if days <= 0 {
 		days = 30 // Default to 30 days
 	}
+	now := time.Now()
 
-	trend := &RiskTrend{
-		Timeline:         []TimelinePoint{},
-		RiskDistribution: make(map[string]int),
-		NewlyExposed:     0,
-		Resolved:         0,
+	if s.sink != nil && s.readFromSink {
+		counts, err := s.sinkSeverityCounts(ctx, days, now)
+		if err == nil {
+			return buildRiskTrend(days, now, counts), nil
+		}
+		log.Printf("WARN: analytics sink %s unavailable, reading trends from PostgreSQL: %v", s.sink.Name(), err)
 	}
 
 	// Get all findings
@@ -182,43 +272,71 @@ func (s *AnalyticsService) GetRiskTrend(ctx context.Context, days int) (*RiskTre
 	}
 
 	// Group findings by date
-	findingsByDate := make(map[string][]*entity.Finding)
+	counts := make(map[string]map[string]int)
 	for _, finding := range findings {
-		date := finding.CreatedAt.Format("2006-01-02")
-		findingsByDate[date] = append(findingsByDate[date], finding)
+		addSeverityCount(counts, finding.CreatedAt.Format("2006-01-02"), finding.Severity, 1)
+	}
+	return buildRiskTrend(days, now, counts), nil
+}
+
+// sinkSeverityCounts reads the tenant's findings per day and severity from the sink
+func (s *AnalyticsService) sinkSeverityCounts(ctx context.Context, days int, now time.Time) (map[string]map[string]int, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -(days - 1))
+	rows, err := s.sink.SeverityTimeline(ctx, tenantID, since)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]map[string]int)
+	for _, row := range rows {
+		addSeverityCount(counts, row.Day, row.Severity, row.Findings)
+	}
+	return counts, nil
+}
+
+func addSeverityCount(counts map[string]map[string]int, date, severity string, n int) {
+	if counts[date] == nil {
+		counts[date] = make(map[string]int)
+	}
+	counts[date][severity] += n
+}
+
+// buildRiskTrend lays finding counts per date and severity out over the last
+// days days ending with now
+func buildRiskTrend(days int, now time.Time, counts map[string]map[string]int) *RiskTrend {
+	trend := &RiskTrend{
+		Timeline:         []TimelinePoint{},
+		RiskDistribution: make(map[string]int),
+		NewlyExposed:     0,
+		Resolved:         0,
 	}
 
 	// Build timeline for last N days
-	now := time.Now()
 	for i := days - 1; i >= 0; i-- {
 		date := now.AddDate(0, 0, -i).Format("2006-01-02")
 
-		point := TimelinePoint{
-			Date:        date,
-			TotalPII:    0,
-			CriticalPII: 0,
-			HighPII:     0,
-			MediumPII:   0,
-			LowPII:      0,
-		}
+		point := TimelinePoint{Date: date}
 
 		// Count findings for this date
-		for _, finding := range findingsByDate[date] {
-			point.TotalPII++
+		for severity, n := range counts[date] {
+			point.TotalPII += n
 
-			switch finding.Severity {
+			switch severity {
 			case "Critical":
-				point.CriticalPII++
-				trend.RiskDistribution["Critical"]++
+				point.CriticalPII += n
+				trend.RiskDistribution["Critical"] += n
 			case "High":
-				point.HighPII++
-				trend.RiskDistribution["High"]++
+				point.HighPII += n
+				trend.RiskDistribution["High"] += n
 			case "Medium":
-				point.MediumPII++
-				trend.RiskDistribution["Medium"]++
+				point.MediumPII += n
+				trend.RiskDistribution["Medium"] += n
 			default:
-				point.LowPII++
-				trend.RiskDistribution["Low"]++
+				point.LowPII += n
+				trend.RiskDistribution["Low"] += n
 			}
 		}
 
@@ -238,5 +356,5 @@ func (s *AnalyticsService) GetRiskTrend(ctx context.Context, days int) (*RiskTre
 		}
 	}
 
-	return trend, nil
+	return trend
 }
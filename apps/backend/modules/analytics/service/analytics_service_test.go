@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestBuildPIIHeatmap(t *testing.T) {
+	cells := []entity.PIIHeatmapCell{
+		{Environment: "prod", DataSource: "postgres", PIIType: "EMAIL_ADDRESS", FindingCount: 8, MaxSeverity: "HIGH"},
+		{Environment: "prod", DataSource: "postgres", PIIType: "IN_PAN", FindingCount: 2, MaxSeverity: "Critical"},
+		{Environment: "", DataSource: "s3", PIIType: "EMAIL_ADDRESS", FindingCount: 4, MaxSeverity: "medium"},
+		{Environment: "", DataSource: "s3", PIIType: "IN_AADHAAR", FindingCount: 5, MaxSeverity: ""},
+	}
+
+	heatmap := buildPIIHeatmap(DefaultHeatmapDimensions, cells)
+
+	if heatmap.TotalFindings != 19 {
+		t.Errorf("expected 19 findings, got %d", heatmap.TotalFindings)
+	}
+	wantColumns := []string{"EMAIL_ADDRESS", "IN_AADHAAR", "IN_PAN"}
+	if len(heatmap.Columns) != len(wantColumns) {
+		t.Fatalf("columns = %v, want %v", heatmap.Columns, wantColumns)
+	}
+	for i, col := range wantColumns {
+		if heatmap.Columns[i] != col {
+			t.Fatalf("columns = %v, want %v", heatmap.Columns, wantColumns)
+		}
+	}
+
+	if len(heatmap.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %+v", heatmap.Rows)
+	}
+	prod, s3 := heatmap.Rows[0], heatmap.Rows[1]
+	if prod.Label != "prod / postgres" || prod.Total != 10 || prod.MaxRisk != "Critical" {
+		t.Errorf("unexpected first row: %+v", prod)
+	}
+	if s3.Label != "unknown / s3" || s3.Total != 9 || s3.MaxRisk != "Medium" {
+		t.Errorf("unexpected second row: %+v", s3)
+	}
+
+	// Every row has a cell per column; intensity is relative to the largest cell
+	if len(prod.Cells) != 3 || prod.Cells[0].Intensity != 100 || prod.Cells[0].RiskLevel != "High" {
+		t.Errorf("unexpected prod cells: %+v", prod.Cells)
+	}
+	if prod.Cells[1].FindingCount != 0 || prod.Cells[1].Intensity != 0 {
+		t.Errorf("expected an empty IN_AADHAAR cell for prod, got %+v", prod.Cells[1])
+	}
+	if s3.Cells[1].FindingCount != 5 || s3.Cells[1].Intensity != 62 || s3.Cells[1].RiskLevel != "Low" {
+		t.Errorf("unexpected s3 IN_AADHAAR cell: %+v", s3.Cells[1])
+	}
+}
+
+func TestGetPIIHeatmapRejectsUnknownDimension(t *testing.T) {
+	s := NewAnalyticsService(nil)
+	for _, dims := range [][]string{{"asset_type"}, {"host", "host"}} {
+		if _, err := s.GetPIIHeatmap(context.Background(), dims); !errors.Is(err, ErrInvalidHeatmapDimension) {
+			t.Errorf("%v: expected ErrInvalidHeatmapDimension, got %v", dims, err)
+		}
+	}
+}
+
+func TestBuildRiskTrend(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	counts := map[string]map[string]int{
+		"2026-03-08": {"Critical": 2, "Low": 1},
+		"2026-03-09": {"High": 3},
+		"2026-03-10": {"Medium": 1, "": 1},
+		"2026-03-01": {"Critical": 9}, // outside the window
+	}
+
+	trend := buildRiskTrend(3, now, counts)
+
+	if len(trend.Timeline) != 3 || trend.Timeline[0].Date != "2026-03-08" || trend.Timeline[2].Date != "2026-03-10" {
+		t.Fatalf("unexpected timeline %+v", trend.Timeline)
+	}
+	if p := trend.Timeline[0]; p.TotalPII != 3 || p.CriticalPII != 2 || p.LowPII != 1 {
+		t.Errorf("unexpected first point %+v", p)
+	}
+	if p := trend.Timeline[2]; p.TotalPII != 2 || p.MediumPII != 1 || p.LowPII != 1 {
+		t.Errorf("unknown severities should count as low, got %+v", p)
+	}
+	if trend.RiskDistribution["Critical"] != 2 || trend.RiskDistribution["High"] != 3 {
+		t.Errorf("unexpected distribution %v", trend.RiskDistribution)
+	}
+	if trend.Resolved != 1 || trend.NewlyExposed != 0 {
+		t.Errorf("expected 1 resolved, got resolved=%d newly_exposed=%d", trend.Resolved, trend.NewlyExposed)
+	}
+}
+
+type fakeAnalyticsSink struct {
+	AnalyticsSink
+	tenantID uuid.UUID
+	since    time.Time
+	rows     []SeverityDayCount
+}
+
+func (f *fakeAnalyticsSink) Name() string { return "fake" }
+
+func (f *fakeAnalyticsSink) SeverityTimeline(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]SeverityDayCount, error) {
+	f.tenantID, f.since = tenantID, since
+	return f.rows, nil
+}
+
+func TestGetRiskTrendReadsFromSink(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	sink := &fakeAnalyticsSink{rows: []SeverityDayCount{
+		{Day: today, Severity: "Critical", Findings: 1200000},
+		{Day: today, Severity: "High", Findings: 5},
+	}}
+	// No repository: the trend must come from the sink alone
+	svc := &AnalyticsService{}
+	svc.SetSink(sink, true)
+
+	tenantID := uuid.New()
+	trend, err := svc.GetRiskTrend(context.WithValue(context.Background(), "tenant_id", tenantID), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sink.tenantID != tenantID {
+		t.Errorf("expected the sink to be queried for tenant %s, got %s", tenantID, sink.tenantID)
+	}
+	if want := time.Now().AddDate(0, 0, -6).Format("2006-01-02"); sink.since.Format("2006-01-02") != want {
+		t.Errorf("expected the window to start on %s, got %s", want, sink.since)
+	}
+	last := trend.Timeline[len(trend.Timeline)-1]
+	if last.CriticalPII != 1200000 || last.TotalPII != 1200005 {
+		t.Errorf("unexpected point %+v", last)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/clickhouse"
+	"github.com/google/uuid"
+)
+
+// AnalyticsSink is a columnar store findings and classifications are mirrored
+// into, so trends over millions of findings do not load PostgreSQL
+type AnalyticsSink interface {
+	Name() string
+	EnsureSchema(ctx context.Context) error
+	WriteFindings(ctx context.Context, findings []*entity.AnalyticsFinding) error
+	WriteClassifications(ctx context.Context, classifications []*entity.AnalyticsClassification) error
+	// Reset drops everything mirrored so far
+	Reset(ctx context.Context) error
+	// SeverityTimeline counts a tenant's live findings per creation day and severity
+	SeverityTimeline(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]SeverityDayCount, error)
+}
+
+// SeverityDayCount is the number of findings of one severity created on one day
+type SeverityDayCount struct {
+	Day      string `json:"day"` // YYYY-MM-DD
+	Severity string `json:"severity"`
+	Findings int    `json:"findings"`
+}
+
+// ClickHouseSink mirrors rows into ReplacingMergeTree tables keyed by tenant and
+// ID, so rows sent again after a change or a retried batch replace the old copy
+type ClickHouseSink struct {
+	client   *clickhouse.Client
+	database string
+}
+
+// NewClickHouseSink creates a sink writing to the given ClickHouse database
+func NewClickHouseSink(client *clickhouse.Client, database string) *ClickHouseSink {
+	return &ClickHouseSink{client: client, database: database}
+}
+
+// Name identifies the sink in watermarks
+func (s *ClickHouseSink) Name() string {
+	return "clickhouse"
+}
+
+func (s *ClickHouseSink) table(name string) string {
+	return "`" + s.database + "`.`" + name + "`"
+}
+
+// EnsureSchema creates the database and tables when they do not exist
+func (s *ClickHouseSink) EnsureSchema(ctx context.Context) error {
+	statements := []string{
+		"CREATE DATABASE IF NOT EXISTS `" + s.database + "`",
+		`CREATE TABLE IF NOT EXISTS ` + s.table(entity.AnalyticsStreamFindings) + ` (
+			id UUID,
+			tenant_id UUID,
+			asset_id UUID,
+			scan_run_id UUID,
+			pattern_name LowCardinality(String),
+			severity LowCardinality(String),
+			confidence_score Nullable(Float64),
+			environment LowCardinality(String),
+			data_source LowCardinality(String),
+			host String,
+			created_at DateTime64(3),
+			updated_at DateTime64(3),
+			deleted Bool
+		) ENGINE = ReplacingMergeTree(updated_at)
+		PARTITION BY toYYYYMM(created_at)
+		ORDER BY (tenant_id, id)`,
+		`CREATE TABLE IF NOT EXISTS ` + s.table(entity.AnalyticsStreamClassifications) + ` (
+			id UUID,
+			tenant_id UUID,
+			finding_id UUID,
+			classification_type LowCardinality(String),
+			sub_category LowCardinality(String),
+			confidence_score Float64,
+			dpdpa_category LowCardinality(String),
+			requires_consent Bool,
+			created_at DateTime64(3),
+			updated_at DateTime64(3)
+		) ENGINE = ReplacingMergeTree(updated_at)
+		PARTITION BY toYYYYMM(created_at)
+		ORDER BY (tenant_id, id)`,
+	}
+	for _, statement := range statements {
+		if err := s.client.Exec(ctx, statement, nil); err != nil {
+			return fmt.Errorf("failed to create analytics schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// WriteFindings inserts mirrored findings
+func (s *ClickHouseSink) WriteFindings(ctx context.Context, findings []*entity.AnalyticsFinding) error {
+	rows := make([]interface{}, len(findings))
+	for i, f := range findings {
+		rows[i] = f
+	}
+	return s.client.InsertJSONEachRow(ctx, s.table(entity.AnalyticsStreamFindings), rows)
+}
+
+// WriteClassifications inserts mirrored classifications
+func (s *ClickHouseSink) WriteClassifications(ctx context.Context, classifications []*entity.AnalyticsClassification) error {
+	rows := make([]interface{}, len(classifications))
+	for i, c := range classifications {
+		rows[i] = c
+	}
+	return s.client.InsertJSONEachRow(ctx, s.table(entity.AnalyticsStreamClassifications), rows)
+}
+
+// Reset empties the mirrored tables
+func (s *ClickHouseSink) Reset(ctx context.Context) error {
+	for _, stream := range []string{entity.AnalyticsStreamFindings, entity.AnalyticsStreamClassifications} {
+		if err := s.client.Exec(ctx, "TRUNCATE TABLE IF EXISTS "+s.table(stream), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SeverityTimeline counts a tenant's live findings per day and severity. FINAL
+// collapses rows mirrored more than once to their latest copy.
+func (s *ClickHouseSink) SeverityTimeline(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]SeverityDayCount, error) {
+	query := `
+		SELECT toString(toDate(created_at)) AS day, severity, count() AS findings
+		FROM ` + s.table(entity.AnalyticsStreamFindings) + ` FINAL
+		WHERE tenant_id = {tenant:UUID} AND created_at >= {since:DateTime64(3)} AND NOT deleted
+		GROUP BY day, severity
+		ORDER BY day, severity`
+	counts := []SeverityDayCount{}
+	err := s.client.Query(ctx, query, map[string]string{
+		"tenant": tenantID.String(),
+		"since":  since.UTC().Format("2006-01-02 15:04:05.000"),
+	}, &counts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query analytics sink: %w", err)
+	}
+	return counts, nil
+}
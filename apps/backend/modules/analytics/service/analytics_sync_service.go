@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAnalyticsSyncMinutes = 5
+	defaultAnalyticsBatchSize   = 5000
+)
+
+// AnalyticsSyncStatus is where each stream stands in the analytics sink
+type AnalyticsSyncStatus struct {
+	Sink         string                       `json:"sink"`
+	ReadFromSink bool                         `json:"read_from_sink"`
+	Watermarks   []*entity.AnalyticsWatermark `json:"watermarks"`
+}
+
+// AnalyticsSyncResult counts the rows one sync run mirrored per stream
+type AnalyticsSyncResult struct {
+	Rows       map[string]int `json:"rows"`
+	SyncedTo   time.Time      `json:"synced_to"`
+	DurationMs int64          `json:"duration_ms"`
+}
+
+// AnalyticsSyncService mirrors findings and classifications of every tenant into
+// the analytics sink. Each stream resumes from its watermark and stops a settle
+// window short of now, so rows of transactions still in flight are not skipped.
+type AnalyticsSyncService struct {
+	repo         *persistence.PostgresRepository
+	sink         AnalyticsSink
+	batchSize    int
+	settle       time.Duration
+	readFromSink bool
+
+	mu          sync.Mutex // one sync run at a time
+	schemaReady bool
+}
+
+// NewAnalyticsSyncService creates a new analytics sync service
+func NewAnalyticsSyncService(repo *persistence.PostgresRepository, sink AnalyticsSink, batchSize, settleSeconds int, readFromSink bool) *AnalyticsSyncService {
+	if batchSize < 1 {
+		batchSize = defaultAnalyticsBatchSize
+	}
+	if settleSeconds < 0 {
+		settleSeconds = 0
+	}
+	return &AnalyticsSyncService{
+		repo:         repo,
+		sink:         sink,
+		batchSize:    batchSize,
+		settle:       time.Duration(settleSeconds) * time.Second,
+		readFromSink: readFromSink,
+	}
+}
+
+// Status returns the sink's watermarks
+func (s *AnalyticsSyncService) Status(ctx context.Context) (*AnalyticsSyncStatus, error) {
+	watermarks, err := s.repo.ListAnalyticsWatermarks(ctx, s.sink.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &AnalyticsSyncStatus{Sink: s.sink.Name(), ReadFromSink: s.readFromSink, Watermarks: watermarks}, nil
+}
+
+// Sync mirrors every row changed since the last run
+func (s *AnalyticsSyncService) Sync(ctx context.Context) (*AnalyticsSyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := time.Now()
+	if !s.schemaReady {
+		if err := s.sink.EnsureSchema(ctx); err != nil {
+			return nil, err
+		}
+		s.schemaReady = true
+	}
+
+	until := started.Add(-s.settle)
+	result := &AnalyticsSyncResult{Rows: make(map[string]int), SyncedTo: until}
+
+	findings := func(w *entity.AnalyticsWatermark) (int, time.Time, uuid.UUID, error) {
+		rows, err := s.repo.ListFindingsForAnalytics(ctx, w.SyncedUntil, w.LastID, until, s.batchSize)
+		if err != nil || len(rows) == 0 {
+			return 0, time.Time{}, uuid.Nil, err
+		}
+		if err := s.sink.WriteFindings(ctx, rows); err != nil {
+			return 0, time.Time{}, uuid.Nil, err
+		}
+		last := rows[len(rows)-1]
+		return len(rows), last.UpdatedAt, last.ID, nil
+	}
+	classifications := func(w *entity.AnalyticsWatermark) (int, time.Time, uuid.UUID, error) {
+		rows, err := s.repo.ListClassificationsForAnalytics(ctx, w.SyncedUntil, w.LastID, until, s.batchSize)
+		if err != nil || len(rows) == 0 {
+			return 0, time.Time{}, uuid.Nil, err
+		}
+		if err := s.sink.WriteClassifications(ctx, rows); err != nil {
+			return 0, time.Time{}, uuid.Nil, err
+		}
+		last := rows[len(rows)-1]
+		return len(rows), last.UpdatedAt, last.ID, nil
+	}
+
+	for _, stream := range []struct {
+		name string
+		next func(*entity.AnalyticsWatermark) (int, time.Time, uuid.UUID, error)
+	}{
+		{entity.AnalyticsStreamFindings, findings},
+		{entity.AnalyticsStreamClassifications, classifications},
+	} {
+		n, err := s.syncStream(ctx, stream.name, started, stream.next)
+		result.Rows[stream.name] = n
+		if err != nil {
+			if recordErr := s.repo.RecordAnalyticsSyncError(ctx, s.sink.Name(), stream.name, err.Error(), started); recordErr != nil {
+				log.Printf("WARN: Failed to record analytics sync error: %v", recordErr)
+			}
+			return result, fmt.Errorf("failed to sync %s: %w", stream.name, err)
+		}
+	}
+
+	result.DurationMs = time.Since(started).Milliseconds()
+	return result, nil
+}
+
+// syncStream mirrors one stream batch by batch, advancing its watermark after
+// each batch so a failed run resumes where it stopped
+func (s *AnalyticsSyncService) syncStream(ctx context.Context, stream string, runAt time.Time, next func(*entity.AnalyticsWatermark) (int, time.Time, uuid.UUID, error)) (int, error) {
+	w, err := s.repo.GetAnalyticsWatermark(ctx, s.sink.Name(), stream)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		n, lastAt, lastID, err := next(w)
+		if err != nil {
+			return total, err
+		}
+		if n > 0 {
+			w.SyncedUntil, w.LastID = lastAt, lastID
+		}
+		if n > 0 || total == 0 {
+			if err := s.repo.SaveAnalyticsWatermark(ctx, w, n, runAt); err != nil {
+				return total, err
+			}
+		}
+		total += n
+		if n < s.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// Rebuild empties the sink and its watermarks, so the next sync mirrors
+// everything again and drops rows purged from PostgreSQL
+func (s *AnalyticsSyncService) Rebuild(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.sink.EnsureSchema(ctx); err != nil {
+		return err
+	}
+	s.schemaReady = true
+	if err := s.sink.Reset(ctx); err != nil {
+		return fmt.Errorf("failed to reset analytics sink: %w", err)
+	}
+	return s.repo.ResetAnalyticsWatermarks(ctx, s.sink.Name())
+}
+
+// StartSyncWorker mirrors new rows into the sink periodically
+func (s *AnalyticsSyncService) StartSyncWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = defaultAnalyticsSyncMinutes
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🕰️  Starting analytics sync worker (sink: %s, interval: %d minutes)", s.sink.Name(), intervalMinutes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Analytics sync worker stopped")
+			return
+		case <-ticker.C:
+			result, err := s.Sync(ctx)
+			if err != nil {
+				log.Printf("❌ Error syncing analytics sink %s: %v", s.sink.Name(), err)
+				continue
+			}
+			if synced := result.Rows[entity.AnalyticsStreamFindings] + result.Rows[entity.AnalyticsStreamClassifications]; synced > 0 {
+				log.Printf("✅ Mirrored %d rows into analytics sink %s", synced, s.sink.Name())
+			}
+		}
+	}
+}
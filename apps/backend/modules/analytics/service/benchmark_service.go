@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+)
+
+const (
+	// minBenchmarkCohort is the fewest participating tenants behind any baseline figure,
+	// so no single tenant's data can be inferred from a comparison
+	minBenchmarkCohort = 5
+
+	// benchmarkTopTypes is the number of PII types listed per ranking
+	benchmarkTopTypes = 5
+)
+
+// Benchmark metric names
+const (
+	MetricFindingsPerAsset      = "findings_per_asset"
+	MetricRemediatedWithin30Pct = "remediated_within_30_days_pct"
+)
+
+// BenchmarkService compares a tenant's PII exposure with anonymized baselines
+// aggregated across all participating tenants
+type BenchmarkService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+}
+
+// BenchmarkBaseline is the spread of a metric across the cohort
+type BenchmarkBaseline struct {
+	P25    float64 `json:"p25"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+}
+
+// BenchmarkMetric compares one tenant metric with its cohort baseline
+type BenchmarkMetric struct {
+	Name       string            `json:"name"`
+	Tenant     *float64          `json:"tenant"` // Nil when the tenant has no data for the metric yet
+	Baseline   BenchmarkBaseline `json:"baseline"`
+	Percentile *int              `json:"percentile,omitempty"` // Share of the cohort with a lower value
+	CohortSize int               `json:"cohort_size"`
+}
+
+// PIITypeShare is a PII type's share of findings for the tenant and across the cohort
+type PIITypeShare struct {
+	PatternName     string   `json:"pattern_name"`
+	TenantPercent   float64  `json:"tenant_percent"`
+	BaselinePercent *float64 `json:"baseline_percent,omitempty"` // Nil when too few tenants hold the type
+}
+
+// BenchmarkReport is the tenant's comparison against anonymized cross-tenant baselines
+type BenchmarkReport struct {
+	GeneratedAt         time.Time         `json:"generated_at"`
+	Available           bool              `json:"available"`
+	Reason              string            `json:"reason,omitempty"`
+	CohortSize          int               `json:"cohort_size"`
+	Metrics             []BenchmarkMetric `json:"metrics"`
+	TenantTopPIITypes   []PIITypeShare    `json:"tenant_top_pii_types"`
+	BaselineTopPIITypes []PIITypeShare    `json:"baseline_top_pii_types"`
+}
+
+// NewBenchmarkService creates a new benchmark service
+func NewBenchmarkService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *BenchmarkService {
+	return &BenchmarkService{
+		repo:        repo,
+		auditLogger: auditLogger,
+	}
+}
+
+// GetBenchmark compares the tenant with the cohort of participating tenants. Tenants
+// that opted out neither contribute to nor see baselines.
+func (s *BenchmarkService) GetBenchmark(ctx context.Context) (*BenchmarkReport, error) {
+	participation, err := s.repo.GetBenchmarkParticipation(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if participation.OptedOut {
+		return nil, fmt.Errorf("tenant has opted out of benchmarking")
+	}
+
+	cohort, err := s.repo.ListTenantBenchmarkMetrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	own := &entity.TenantBenchmarkMetrics{TenantID: participation.TenantID, PatternCounts: map[string]int{}}
+	for _, m := range cohort {
+		if m.TenantID == participation.TenantID {
+			own = m
+			break
+		}
+	}
+
+	return buildBenchmarkReport(own, cohort, time.Now()), nil
+}
+
+// GetParticipation reports whether the tenant contributes to benchmark baselines
+func (s *BenchmarkService) GetParticipation(ctx context.Context) (*entity.BenchmarkParticipation, error) {
+	return s.repo.GetBenchmarkParticipation(ctx)
+}
+
+// SetParticipation opts the tenant out of, or back into, benchmarking
+func (s *BenchmarkService) SetParticipation(ctx context.Context, optOut bool, updatedBy string) (*entity.BenchmarkParticipation, error) {
+	if err := s.repo.SetBenchmarkOptOut(ctx, optOut, updatedBy); err != nil {
+		return nil, err
+	}
+
+	participation, err := s.repo.GetBenchmarkParticipation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "BENCHMARK_PARTICIPATION_UPDATED", "tenant", participation.TenantID.String(), map[string]interface{}{
+			"opted_out": optOut,
+		})
+	}
+	return participation, nil
+}
+
+// buildBenchmarkReport aggregates the cohort into baselines and places own within them.
+// Only aggregates are returned; figures backed by fewer than minBenchmarkCohort tenants are withheld.
+func buildBenchmarkReport(own *entity.TenantBenchmarkMetrics, cohort []*entity.TenantBenchmarkMetrics, now time.Time) *BenchmarkReport {
+	report := &BenchmarkReport{
+		GeneratedAt:         now,
+		CohortSize:          len(cohort),
+		Metrics:             []BenchmarkMetric{},
+		TenantTopPIITypes:   []PIITypeShare{},
+		BaselineTopPIITypes: []PIITypeShare{},
+	}
+	if len(cohort) < minBenchmarkCohort {
+		report.Reason = fmt.Sprintf("baselines need at least %d participating tenants", minBenchmarkCohort)
+		return report
+	}
+	report.Available = true
+
+	var perAsset, remediated []float64
+	for _, m := range cohort {
+		if v, ok := findingsPerAsset(m); ok {
+			perAsset = append(perAsset, v)
+		}
+		if v, ok := remediatedWithin30Pct(m); ok {
+			remediated = append(remediated, v)
+		}
+	}
+
+	ownPerAsset, hasPerAsset := findingsPerAsset(own)
+	ownRemediated, hasRemediated := remediatedWithin30Pct(own)
+	for _, metric := range []struct {
+		name   string
+		values []float64
+		own    float64
+		hasOwn bool
+	}{
+		{MetricFindingsPerAsset, perAsset, ownPerAsset, hasPerAsset},
+		{MetricRemediatedWithin30Pct, remediated, ownRemediated, hasRemediated},
+	} {
+		if len(metric.values) < minBenchmarkCohort {
+			continue
+		}
+		report.Metrics = append(report.Metrics, compareMetric(metric.name, metric.values, metric.own, metric.hasOwn))
+	}
+
+	// A type's baseline share averages over the whole cohort, counting 0 for tenants without it
+	baselineShares := make(map[string]float64)
+	holders := make(map[string]int)
+	for _, m := range cohort {
+		for pattern, share := range patternShares(m) {
+			baselineShares[pattern] += share / float64(len(cohort))
+			holders[pattern]++
+		}
+	}
+	baselinePercent := func(pattern string) *float64 {
+		if holders[pattern] < minBenchmarkCohort {
+			return nil
+		}
+		v := round2(baselineShares[pattern])
+		return &v
+	}
+
+	ownShares := patternShares(own)
+	for _, pattern := range topPatterns(ownShares, benchmarkTopTypes) {
+		report.TenantTopPIITypes = append(report.TenantTopPIITypes, PIITypeShare{
+			PatternName:     pattern,
+			TenantPercent:   round2(ownShares[pattern]),
+			BaselinePercent: baselinePercent(pattern),
+		})
+	}
+
+	eligible := make(map[string]float64)
+	for pattern, share := range baselineShares {
+		if holders[pattern] >= minBenchmarkCohort {
+			eligible[pattern] = share
+		}
+	}
+	for _, pattern := range topPatterns(eligible, benchmarkTopTypes) {
+		report.BaselineTopPIITypes = append(report.BaselineTopPIITypes, PIITypeShare{
+			PatternName:     pattern,
+			TenantPercent:   round2(ownShares[pattern]),
+			BaselinePercent: baselinePercent(pattern),
+		})
+	}
+
+	return report
+}
+
+func compareMetric(name string, values []float64, own float64, hasOwn bool) BenchmarkMetric {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	metric := BenchmarkMetric{
+		Name: name,
+		Baseline: BenchmarkBaseline{
+			P25:    round2(quantile(sorted, 0.25)),
+			Median: round2(quantile(sorted, 0.5)),
+			P75:    round2(quantile(sorted, 0.75)),
+		},
+		CohortSize: len(sorted),
+	}
+	if hasOwn {
+		v := round2(own)
+		metric.Tenant = &v
+
+		below := sort.SearchFloat64s(sorted, own)
+		percentile := int(math.Round(float64(below) * 100 / float64(len(sorted))))
+		metric.Percentile = &percentile
+	}
+	return metric
+}
+
+func findingsPerAsset(m *entity.TenantBenchmarkMetrics) (float64, bool) {
+	if m.AssetCount == 0 {
+		return 0, false
+	}
+	return float64(m.FindingCount) / float64(m.AssetCount), true
+}
+
+func remediatedWithin30Pct(m *entity.TenantBenchmarkMetrics) (float64, bool) {
+	if m.EligibleFindings == 0 {
+		return 0, false
+	}
+	return float64(m.RemediatedWithin30Days) * 100 / float64(m.EligibleFindings), true
+}
+
+// patternShares returns each PII type's percentage of the tenant's findings
+func patternShares(m *entity.TenantBenchmarkMetrics) map[string]float64 {
+	total := 0
+	for _, count := range m.PatternCounts {
+		total += count
+	}
+
+	shares := make(map[string]float64, len(m.PatternCounts))
+	if total == 0 {
+		return shares
+	}
+	for pattern, count := range m.PatternCounts {
+		shares[pattern] = float64(count) * 100 / float64(total)
+	}
+	return shares
+}
+
+// topPatterns returns up to n patterns by descending share, ties broken by name
+func topPatterns(shares map[string]float64, n int) []string {
+	patterns := make([]string, 0, len(shares))
+	for pattern := range shares {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if shares[patterns[i]] != shares[patterns[j]] {
+			return shares[patterns[i]] > shares[patterns[j]]
+		}
+		return patterns[i] < patterns[j]
+	})
+	if len(patterns) > n {
+		patterns = patterns[:n]
+	}
+	return patterns
+}
+
+// quantile interpolates the q-th quantile of sorted values
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
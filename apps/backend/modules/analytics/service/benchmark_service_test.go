@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func benchmarkTenant(assets, findings, eligible, remediated int, patterns map[string]int) *entity.TenantBenchmarkMetrics {
+	return &entity.TenantBenchmarkMetrics{
+		TenantID:               uuid.New(),
+		AssetCount:             assets,
+		FindingCount:           findings,
+		EligibleFindings:       eligible,
+		RemediatedWithin30Days: remediated,
+		PatternCounts:          patterns,
+	}
+}
+
+func TestBuildBenchmarkReportWithholdsSmallCohorts(t *testing.T) {
+	own := benchmarkTenant(2, 10, 10, 5, map[string]int{"EMAIL_ADDRESS": 10})
+	cohort := []*entity.TenantBenchmarkMetrics{own, benchmarkTenant(1, 4, 0, 0, map[string]int{"IN_PAN": 4})}
+
+	report := buildBenchmarkReport(own, cohort, time.Now())
+	if report.Available || len(report.Metrics) != 0 || len(report.BaselineTopPIITypes) != 0 {
+		t.Errorf("expected no baselines for a cohort of %d tenants, got %+v", len(cohort), report)
+	}
+}
+
+func TestBuildBenchmarkReport(t *testing.T) {
+	own := benchmarkTenant(10, 40, 20, 10, map[string]int{"IN_AADHAAR": 30, "RARE_TYPE": 10})
+	cohort := []*entity.TenantBenchmarkMetrics{
+		own,
+		benchmarkTenant(10, 10, 10, 10, map[string]int{"IN_AADHAAR": 5, "EMAIL_ADDRESS": 5}),
+		benchmarkTenant(10, 20, 10, 2, map[string]int{"IN_AADHAAR": 10, "EMAIL_ADDRESS": 10}),
+		benchmarkTenant(10, 30, 0, 0, map[string]int{"IN_AADHAAR": 15, "EMAIL_ADDRESS": 15}),
+		benchmarkTenant(10, 50, 10, 8, map[string]int{"IN_AADHAAR": 25, "EMAIL_ADDRESS": 25}),
+	}
+
+	report := buildBenchmarkReport(own, cohort, time.Now())
+	if !report.Available || report.CohortSize != 5 {
+		t.Fatalf("expected an available report over 5 tenants, got %+v", report)
+	}
+
+	// Only 4 tenants have findings old enough to measure remediation, so that baseline is withheld
+	if len(report.Metrics) != 1 || report.Metrics[0].Name != MetricFindingsPerAsset {
+		t.Fatalf("expected only the findings-per-asset metric, got %+v", report.Metrics)
+	}
+	perAsset := report.Metrics[0]
+	if perAsset.Baseline.Median != 3 || *perAsset.Tenant != 4 || *perAsset.Percentile != 60 {
+		t.Errorf("unexpected findings-per-asset comparison: %+v", perAsset)
+	}
+
+	if len(report.TenantTopPIITypes) != 2 {
+		t.Fatalf("expected the tenant's two PII types, got %+v", report.TenantTopPIITypes)
+	}
+	if got := report.TenantTopPIITypes[0]; got.PatternName != "IN_AADHAAR" || got.TenantPercent != 75 || got.BaselinePercent == nil {
+		t.Errorf("unexpected top tenant PII type: %+v", got)
+	}
+	if got := report.TenantTopPIITypes[1]; got.PatternName != "RARE_TYPE" || got.BaselinePercent != nil {
+		t.Errorf("expected no baseline for a PII type held by one tenant, got %+v", got)
+	}
+	for _, share := range report.BaselineTopPIITypes {
+		if share.PatternName != "IN_AADHAAR" {
+			t.Errorf("expected only PII types held by enough tenants in the baseline ranking, got %s", share.PatternName)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/pkg/langdetect"
+)
+
+// DefaultLanguageBreakdownDays is how far back the language breakdown looks without ?days
+const DefaultLanguageBreakdownDays = 90
+
+// LanguageBreakdown counts a tenant's findings per language detected in their
+// sample text, showing how much content falls outside the supported languages
+type LanguageBreakdown struct {
+	Since       time.Time                     `json:"since"`
+	Supported   []string                      `json:"supported"`
+	Findings    int                           `json:"findings"`
+	Unsupported int                           `json:"unsupported"` // Findings in languages outside Supported
+	Languages   []entity.LanguageFindingCount `json:"languages"`   // Most findings first
+}
+
+// SetSupportedLanguages sets the languages ingestion treats as in scope
+func (s *AnalyticsService) SetSupportedLanguages(languages []string) {
+	s.supportedLanguages = nil
+	for _, language := range languages {
+		if language = strings.ToLower(strings.TrimSpace(language)); language != "" {
+			s.supportedLanguages = append(s.supportedLanguages, language)
+		}
+	}
+}
+
+// GetLanguageBreakdown counts the tenant's findings created in the last days days
+// (default 90, at most 365) per detected language
+func (s *AnalyticsService) GetLanguageBreakdown(ctx context.Context, days int) (*LanguageBreakdown, error) {
+	if days <= 0 {
+		days = DefaultLanguageBreakdownDays
+	}
+	if days > 365 {
+		return nil, fmt.Errorf("invalid days %d: must be at most 365", days)
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(days - 1))
+	counts, err := s.pgRepo.CountFindingsByLanguage(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	return buildLanguageBreakdown(counts, since, s.supported()), nil
+}
+
+func (s *AnalyticsService) supported() []string {
+	if len(s.supportedLanguages) == 0 {
+		return []string{"en"}
+	}
+	return s.supportedLanguages
+}
+
+// buildLanguageBreakdown marks each language supported or not and totals them.
+// Undetermined content is in scope, as it is at ingestion.
+func buildLanguageBreakdown(counts []entity.LanguageFindingCount, since time.Time, supported []string) *LanguageBreakdown {
+	breakdown := &LanguageBreakdown{Since: since, Supported: supported, Languages: counts}
+	for i := range counts {
+		c := &counts[i]
+		c.Supported = c.Language == langdetect.Undetermined || containsString(supported, c.Language)
+		breakdown.Findings += c.Findings
+		if !c.Supported {
+			breakdown.Unsupported += c.Findings
+		}
+	}
+	return breakdown
+}
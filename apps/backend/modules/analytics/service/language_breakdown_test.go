@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestBuildLanguageBreakdown(t *testing.T) {
+	counts := []entity.LanguageFindingCount{
+		{Language: "en", Findings: 40},
+		{Language: "und", Findings: 12},
+		{Language: "hi", Findings: 7, LanguageReview: 7},
+		{Language: "fr", Findings: 3},
+	}
+
+	b := buildLanguageBreakdown(counts, time.Now(), []string{"en", "fr"})
+	if b.Findings != 62 || b.Unsupported != 7 {
+		t.Errorf("expected 62 findings with 7 unsupported, got %d and %d", b.Findings, b.Unsupported)
+	}
+	for _, c := range b.Languages {
+		if want := c.Language != "hi"; c.Supported != want {
+			t.Errorf("expected %s supported=%t, got %t", c.Language, want, c.Supported)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// Pattern noise defaults
+const (
+	DefaultPatternNoiseDays        = 90
+	DefaultPatternNoiseMinReviewed = 5
+	// noisyFPRate is the false positive share of reviewed findings at which a
+	// pattern is recommended for tuning
+	noisyFPRate = 0.5
+	// disableFPRate is the share at which disabling the pattern is recommended
+	disableFPRate = 0.9
+	// thresholdGap is how much higher confirmed findings must score on average than
+	// false positives, relatively, for a confidence threshold to separate them
+	thresholdGap = 1.1
+)
+
+// Recommended tuning actions for noisy patterns
+const (
+	PatternActionRaiseThreshold = "raise_threshold"
+	PatternActionDisable        = "disable_pattern"
+	PatternActionAddFPRules     = "add_fp_rules"
+)
+
+// PatternNoiseOptions select the window of a pattern noise report
+type PatternNoiseOptions struct {
+	Days        int    // Findings created in the last Days days; default 90
+	Interval    string // "day" or "week" timeline periods; default week
+	MinReviewed int    // Reviewed findings a pattern needs before it can be called noisy; default 5
+}
+
+// PatternNoiseReport shows each pattern's volume, feedback verdicts and confidence
+// over time, with the noisiest patterns first in line for tuning
+type PatternNoiseReport struct {
+	Since         time.Time      `json:"since"`
+	Interval      string         `json:"interval"`
+	MinReviewed   int            `json:"min_reviewed"`
+	Patterns      []PatternStats `json:"patterns"`       // Most findings first
+	NoisyPatterns []NoisyPattern `json:"noisy_patterns"` // Worst first
+}
+
+// PatternStats totals one pattern's findings over the report window
+type PatternStats struct {
+	PatternName    string          `json:"pattern_name"`
+	Findings       int             `json:"findings"`
+	Confirmed      int             `json:"confirmed"`
+	FalsePositives int             `json:"false_positives"`
+	FPRate         *float64        `json:"fp_rate"` // Of reviewed findings; null when none were reviewed
+	AvgConfidence  *float64        `json:"avg_confidence"`
+	Timeline       []PatternPeriod `json:"timeline"`
+
+	confirmedConfidence *float64
+	fpConfidence        *float64
+}
+
+// PatternPeriod is one pattern's findings created in one day or week
+type PatternPeriod struct {
+	Period         string   `json:"period"` // Date the day or week starts
+	Findings       int      `json:"findings"`
+	Confirmed      int      `json:"confirmed"`
+	FalsePositives int      `json:"false_positives"`
+	FPRate         *float64 `json:"fp_rate"`
+	AvgConfidence  *float64 `json:"avg_confidence"`
+}
+
+// NoisyPattern recommends tuning a pattern whose reviewed findings are mostly
+// false positives. NoiseScore estimates its false positives over the window.
+type NoisyPattern struct {
+	PatternName        string   `json:"pattern_name"`
+	Findings           int      `json:"findings"`
+	FalsePositives     int      `json:"false_positives"`
+	FPRate             float64  `json:"fp_rate"`
+	NoiseScore         float64  `json:"noise_score"`
+	Action             string   `json:"action"`
+	SuggestedThreshold *float64 `json:"suggested_threshold,omitempty"`
+	Recommendation     string   `json:"recommendation"`
+}
+
+// GetPatternNoise reports the tenant's per-pattern hit rates and false positive
+// ratios from feedback, and which patterns are noisy enough to tune
+func (s *AnalyticsService) GetPatternNoise(ctx context.Context, opts PatternNoiseOptions) (*PatternNoiseReport, error) {
+	if opts.Days <= 0 {
+		opts.Days = DefaultPatternNoiseDays
+	}
+	if opts.Days > 365 {
+		return nil, fmt.Errorf("invalid days %d: must be at most 365", opts.Days)
+	}
+	if opts.Interval == "" {
+		opts.Interval = "week"
+	}
+	if opts.Interval != "day" && opts.Interval != "week" {
+		return nil, fmt.Errorf("invalid interval %q: must be day or week", opts.Interval)
+	}
+	if opts.MinReviewed <= 0 {
+		opts.MinReviewed = DefaultPatternNoiseMinReviewed
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(opts.Days - 1))
+	activity, err := s.pgRepo.GetPatternActivity(ctx, since, opts.Interval)
+	if err != nil {
+		return nil, err
+	}
+	return buildPatternNoiseReport(activity, since, opts), nil
+}
+
+// buildPatternNoiseReport totals activity per pattern and picks out the noisy ones
+func buildPatternNoiseReport(activity []entity.PatternActivity, since time.Time, opts PatternNoiseOptions) *PatternNoiseReport {
+	report := &PatternNoiseReport{
+		Since:         since,
+		Interval:      opts.Interval,
+		MinReviewed:   opts.MinReviewed,
+		Patterns:      []PatternStats{},
+		NoisyPatterns: []NoisyPattern{},
+	}
+
+	byPattern := make(map[string][]entity.PatternActivity)
+	var names []string
+	for _, a := range activity {
+		if _, ok := byPattern[a.PatternName]; !ok {
+			names = append(names, a.PatternName)
+		}
+		byPattern[a.PatternName] = append(byPattern[a.PatternName], a)
+	}
+
+	for _, name := range names {
+		periods := byPattern[name]
+		sort.Slice(periods, func(i, j int) bool { return periods[i].Period.Before(periods[j].Period) })
+
+		stats := PatternStats{PatternName: name, Timeline: make([]PatternPeriod, 0, len(periods))}
+		var total entity.PatternActivity
+		for _, a := range periods {
+			stats.Timeline = append(stats.Timeline, PatternPeriod{
+				Period:         a.Period.Format("2006-01-02"),
+				Findings:       a.Findings,
+				Confirmed:      a.Confirmed,
+				FalsePositives: a.FalsePositives,
+				FPRate:         fpRate(a.Confirmed, a.FalsePositives),
+				AvgConfidence:  average(a.ConfidenceSum, a.ConfidenceCount),
+			})
+			total.Findings += a.Findings
+			total.Confirmed += a.Confirmed
+			total.FalsePositives += a.FalsePositives
+			total.ConfidenceSum += a.ConfidenceSum
+			total.ConfidenceCount += a.ConfidenceCount
+			total.ConfirmedConfidenceSum += a.ConfirmedConfidenceSum
+			total.ConfirmedConfidenceCount += a.ConfirmedConfidenceCount
+			total.FPConfidenceSum += a.FPConfidenceSum
+			total.FPConfidenceCount += a.FPConfidenceCount
+		}
+		stats.Findings = total.Findings
+		stats.Confirmed = total.Confirmed
+		stats.FalsePositives = total.FalsePositives
+		stats.FPRate = fpRate(total.Confirmed, total.FalsePositives)
+		stats.AvgConfidence = average(total.ConfidenceSum, total.ConfidenceCount)
+		stats.confirmedConfidence = average(total.ConfirmedConfidenceSum, total.ConfirmedConfidenceCount)
+		stats.fpConfidence = average(total.FPConfidenceSum, total.FPConfidenceCount)
+		report.Patterns = append(report.Patterns, stats)
+
+		if noisy, ok := noisyPattern(stats, opts.MinReviewed); ok {
+			report.NoisyPatterns = append(report.NoisyPatterns, noisy)
+		}
+	}
+
+	sort.SliceStable(report.Patterns, func(i, j int) bool {
+		if report.Patterns[i].Findings != report.Patterns[j].Findings {
+			return report.Patterns[i].Findings > report.Patterns[j].Findings
+		}
+		return report.Patterns[i].PatternName < report.Patterns[j].PatternName
+	})
+	sort.SliceStable(report.NoisyPatterns, func(i, j int) bool {
+		if report.NoisyPatterns[i].NoiseScore != report.NoisyPatterns[j].NoiseScore {
+			return report.NoisyPatterns[i].NoiseScore > report.NoisyPatterns[j].NoiseScore
+		}
+		return report.NoisyPatterns[i].PatternName < report.NoisyPatterns[j].PatternName
+	})
+	return report
+}
+
+// noisyPattern recommends tuning a pattern with enough reviewed findings that are
+// mostly false positives. When false positives score clearly lower than confirmed findings
+// a confidence threshold between the two separates them; failing that, a pattern
+// that is nearly always wrong is better disabled, and otherwise its recurring false
+// positives can be excluded with FP rules.
+func noisyPattern(stats PatternStats, minReviewed int) (NoisyPattern, bool) {
+	if stats.Confirmed+stats.FalsePositives < minReviewed || stats.FPRate == nil || *stats.FPRate < noisyFPRate {
+		return NoisyPattern{}, false
+	}
+
+	rate := *stats.FPRate
+	noisy := NoisyPattern{
+		PatternName:    stats.PatternName,
+		Findings:       stats.Findings,
+		FalsePositives: stats.FalsePositives,
+		FPRate:         rate,
+		NoiseScore:     round2(rate * float64(stats.Findings)),
+	}
+	switch {
+	case stats.confirmedConfidence != nil && stats.fpConfidence != nil && *stats.confirmedConfidence >= *stats.fpConfidence*thresholdGap:
+		threshold := round2((*stats.confirmedConfidence + *stats.fpConfidence) / 2)
+		noisy.Action = PatternActionRaiseThreshold
+		noisy.SuggestedThreshold = &threshold
+		noisy.Recommendation = fmt.Sprintf(
+			"False positives average %.2f confidence against %.2f for confirmed findings; preview a threshold near %.2f with POST /api/v1/admin/threshold-simulation",
+			*stats.fpConfidence, *stats.confirmedConfidence, threshold)
+	case rate >= disableFPRate:
+		noisy.Action = PatternActionDisable
+		noisy.Recommendation = fmt.Sprintf(
+			"%.0f%% of reviewed findings are false positives; consider disabling the pattern or limiting it to the data sources where it is confirmed", rate*100)
+	default:
+		noisy.Action = PatternActionAddFPRules
+		noisy.Recommendation = "Exclude the pattern's recurring false positives with the rules suggested at GET /api/v1/admin/fp-suggestions"
+	}
+	return noisy, true
+}
+
+// fpRate is the false positive share of reviewed findings, nil when none were reviewed
+func fpRate(confirmed, falsePositives int) *float64 {
+	if confirmed+falsePositives == 0 {
+		return nil
+	}
+	rate := round2(float64(falsePositives) / float64(confirmed+falsePositives))
+	return &rate
+}
+
+func average(sum float64, count int) *float64 {
+	if count == 0 {
+		return nil
+	}
+	avg := round2(sum / float64(count))
+	return &avg
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestBuildPatternNoiseReport(t *testing.T) {
+	week1 := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	week2 := week1.AddDate(0, 0, 7)
+	activity := []entity.PatternActivity{
+		// Mostly false positives that score lower than confirmed findings
+		{PatternName: "IN_PHONE", Period: week2, Findings: 30, Confirmed: 1, FalsePositives: 5,
+			ConfidenceSum: 21, ConfidenceCount: 30, ConfirmedConfidenceSum: 0.9, ConfirmedConfidenceCount: 1, FPConfidenceSum: 2.5, FPConfidenceCount: 5},
+		{PatternName: "IN_PHONE", Period: week1, Findings: 10, Confirmed: 1, FalsePositives: 3,
+			ConfidenceSum: 7, ConfidenceCount: 10, ConfirmedConfidenceSum: 0.9, ConfirmedConfidenceCount: 1, FPConfidenceSum: 1.5, FPConfidenceCount: 3},
+		// Nearly always wrong, and confidence does not tell the verdicts apart
+		{PatternName: "BANK_ACCOUNT", Period: week1, Findings: 12, Confirmed: 0, FalsePositives: 6,
+			FPConfidenceSum: 4.8, FPConfidenceCount: 6},
+		// Accurate
+		{PatternName: "EMAIL_ADDRESS", Period: week1, Findings: 50, Confirmed: 9, FalsePositives: 1},
+		// Noisy but too few reviews to call
+		{PatternName: "IN_PAN", Period: week2, Findings: 4, Confirmed: 0, FalsePositives: 2},
+	}
+
+	report := buildPatternNoiseReport(activity, week1, PatternNoiseOptions{Interval: "week", MinReviewed: 5})
+
+	if len(report.Patterns) != 4 || report.Patterns[0].PatternName != "EMAIL_ADDRESS" || report.Patterns[1].PatternName != "IN_PHONE" {
+		t.Fatalf("expected patterns by volume, got %+v", report.Patterns)
+	}
+	phone := report.Patterns[1]
+	if phone.Findings != 40 || phone.FalsePositives != 8 || *phone.FPRate != 0.8 || *phone.AvgConfidence != 0.7 {
+		t.Errorf("unexpected IN_PHONE totals: %+v", phone)
+	}
+	if len(phone.Timeline) != 2 || phone.Timeline[0].Period != "2026-03-02" || *phone.Timeline[1].FPRate != 0.83 {
+		t.Errorf("unexpected IN_PHONE timeline: %+v", phone.Timeline)
+	}
+	if report.Patterns[3].PatternName != "IN_PAN" || report.Patterns[3].AvgConfidence != nil {
+		t.Errorf("expected IN_PAN last without a confidence, got %+v", report.Patterns[3])
+	}
+
+	if len(report.NoisyPatterns) != 2 {
+		t.Fatalf("expected IN_PHONE and BANK_ACCOUNT to be noisy, got %+v", report.NoisyPatterns)
+	}
+	worst, next := report.NoisyPatterns[0], report.NoisyPatterns[1]
+	if worst.PatternName != "IN_PHONE" || worst.NoiseScore != 32 || worst.Action != PatternActionRaiseThreshold ||
+		worst.SuggestedThreshold == nil || *worst.SuggestedThreshold != 0.7 {
+		t.Errorf("unexpected worst pattern: %+v", worst)
+	}
+	if next.PatternName != "BANK_ACCOUNT" || next.Action != PatternActionDisable || next.SuggestedThreshold != nil {
+		t.Errorf("unexpected second pattern: %+v", next)
+	}
+}
@@ -1,8 +1,12 @@
 package api
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/arc-platform/backend/modules/assets/service"
 	"github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -18,6 +22,7 @@ func NewAssetHandler(service *service.AssetService) *AssetHandler {
 }
 
 // GetAsset handles GET /api/v1/assets/:id
+// Query: as_of (RFC3339 or YYYY-MM-DD) returns the finding count and risk score the asset had then
 func (h *AssetHandler) GetAsset(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -26,18 +31,45 @@ func (h *AssetHandler) GetAsset(c *gin.Context) {
 		return
 	}
 
-	asset, err := h.service.GetAsset(c.Request.Context(), id)
-	if err != nil {
+	var asset *entity.Asset
+	if v := c.Query("as_of"); v != "" {
+		asOf, parseErr := parseHistoryTime(v, true)
+		if parseErr != nil {
+			api.BadRequest(c, "as_of must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		asset, err = h.service.GetAssetAsOf(api.RequestContext(c), id, asOf)
+	} else {
+		asset, err = h.service.GetAsset(api.RequestContext(c), id)
+	}
+	if errors.Is(err, service.ErrAssetNotFound) {
 		api.NotFound(c, "Asset not found")
 		return
 	}
+	if err != nil {
+		api.InternalServerError(c, "Failed to get asset")
+		return
+	}
 
 	api.Success(c, asset)
 }
 
 // ListAssets handles GET /api/v1/assets
+// Query: bucket lists the S3 objects in a bucket, and prefix those whose key starts with it
 func (h *AssetHandler) ListAssets(c *gin.Context) {
-	assets, err := h.service.ListAssets(c.Request.Context(), 100, 0)
+	bucket, prefix := c.Query("bucket"), c.Query("prefix")
+	if prefix != "" && bucket == "" {
+		api.BadRequest(c, "prefix requires bucket")
+		return
+	}
+
+	var assets []*entity.Asset
+	var err error
+	if bucket != "" {
+		assets, err = h.service.ListS3Objects(api.RequestContext(c), bucket, prefix, 100, 0)
+	} else {
+		assets, err = h.service.ListAssets(api.RequestContext(c), 100, 0)
+	}
 	if err != nil {
 		api.InternalServerError(c, "Failed to list assets")
 		return
@@ -45,3 +77,42 @@ func (h *AssetHandler) ListAssets(c *gin.Context) {
 
 	api.Success(c, assets)
 }
+
+// ListS3Buckets handles GET /api/v1/assets/s3/buckets
+func (h *AssetHandler) ListS3Buckets(c *gin.Context) {
+	buckets, err := h.service.ListS3Buckets(api.RequestContext(c))
+	if err != nil {
+		api.InternalServerError(c, "Failed to list S3 buckets")
+		return
+	}
+
+	api.Success(c, buckets)
+}
+
+// BrowseS3Prefix handles GET /api/v1/assets/s3/browse
+// Query: bucket (required), prefix (empty for the bucket root), limit and offset of the objects
+func (h *AssetHandler) BrowseS3Prefix(c *gin.Context) {
+	bucket := c.Query("bucket")
+	if bucket == "" {
+		api.BadRequest(c, "bucket is required")
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 1 || limit > 1000 {
+		api.BadRequest(c, "limit must be between 1 and 1000")
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		api.BadRequest(c, "offset must be a non-negative integer")
+		return
+	}
+
+	listing, err := h.service.BrowseS3Prefix(api.RequestContext(c), bucket, c.Query("prefix"), limit, offset)
+	if err != nil {
+		api.InternalServerError(c, "Failed to browse S3 prefix")
+		return
+	}
+
+	api.Success(c, listing)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestListS3BucketsUsesTenantFromGinKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tenantID := uuid.New()
+	mock.ExpectQuery(`SELECT a.host,`).WithArgs(tenantID).WillReturnRows(sqlmock.NewRows([]string{
+		"host", "objects", "findings", "critical", "high", "medium", "low", "max_risk_score", "exposed_objects",
+	}).AddRow("reports", 3, 5, 1, 2, 1, 1, 80.0, 2))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("tenant_id", tenantID) })
+	handler := NewAssetHandler(service.NewAssetService(persistence.NewPostgresRepository(db), nil, nil))
+	router.GET("/assets/s3/buckets", handler.ListS3Buckets)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets/s3/buckets", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func getAssetAsOf(t *testing.T, expect func(mock sqlmock.Sqlmock, tenantID, assetID uuid.UUID), asOf string) int {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tenantID, assetID := uuid.New(), uuid.New()
+	expect(mock, tenantID, assetID)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("tenant_id", tenantID) })
+	handler := NewAssetHandler(service.NewAssetService(persistence.NewPostgresRepository(db), nil, nil))
+	router.GET("/assets/:id", handler.GetAsset)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets/"+assetID.String()+"?as_of="+asOf, nil))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	return w.Code
+}
+
+func TestGetAssetAsOfBeforeCreation(t *testing.T) {
+	code := getAssetAsOf(t, func(mock sqlmock.Sqlmock, tenantID, assetID uuid.UUID) {
+		created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`FROM assets WHERE id = \$1 AND tenant_id = \$2`).WithArgs(assetID, tenantID).WillReturnRows(sqlmock.NewRows([]string{
+			"id", "tenant_id", "stable_id", "asset_type", "name", "path", "data_source", "host",
+			"environment", "owner", "source_system", "file_metadata", "risk_score", "total_findings", "org_unit_id", "created_at", "updated_at",
+		}).AddRow(assetID, tenantID, "stable", "file", "report.csv", "/data/report.csv", "fs", "host1",
+			"prod", "", "scanner", nil, 40, 2, nil, created, created))
+	}, "2026-01-01")
+	if code != http.StatusNotFound {
+		t.Errorf("expected 404 for an asset created after as_of, got %d", code)
+	}
+}
+
+func TestGetAssetAsOfRepositoryFailure(t *testing.T) {
+	code := getAssetAsOf(t, func(mock sqlmock.Sqlmock, tenantID, assetID uuid.UUID) {
+		mock.ExpectQuery(`FROM assets WHERE id = \$1 AND tenant_id = \$2`).WithArgs(assetID, tenantID).WillReturnError(errors.New("connection reset"))
+	}, "2026-01-01")
+	if code != http.StatusInternalServerError {
+		t.Errorf("expected 500 for a repository failure, got %d", code)
+	}
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AssetMergeHandler handles duplicate asset merge requests
+type AssetMergeHandler struct {
+	service *service.AssetMergeService
+}
+
+// NewAssetMergeHandler creates a new asset merge handler
+func NewAssetMergeHandler(service *service.AssetMergeService) *AssetMergeHandler {
+	return &AssetMergeHandler{service: service}
+}
+
+// MergeAssetsRequest names the asset to merge away and the asset that survives
+type MergeAssetsRequest struct {
+	SourceAssetID string `json:"source_asset_id" binding:"required"`
+	TargetAssetID string `json:"target_asset_id" binding:"required"`
+}
+
+// MergeAssets handles POST /api/v1/assets/merge
+func (h *AssetMergeHandler) MergeAssets(c *gin.Context) {
+	var req MergeAssetsRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	sourceID, err := uuid.Parse(req.SourceAssetID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid source_asset_id"})
+		return
+	}
+	targetID, err := uuid.Parse(req.TargetAssetID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target_asset_id"})
+		return
+	}
+	if sourceID == targetID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source_asset_id and target_asset_id must differ"})
+		return
+	}
+
+	result, err := h.service.MergeAssets(sharedapi.RequestContext(c), sourceID, targetID, requestActor(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "asset not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to merge assets",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
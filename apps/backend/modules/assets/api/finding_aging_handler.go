@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FindingAgingHandler handles finding aging and stale-detection requests
+type FindingAgingHandler struct {
+	service *service.FindingAgingService
+}
+
+// NewFindingAgingHandler creates a new finding aging handler
+func NewFindingAgingHandler(service *service.FindingAgingService) *FindingAgingHandler {
+	return &FindingAgingHandler{service: service}
+}
+
+// GetAgingReport handles GET /api/v1/findings/aging
+func (h *FindingAgingHandler) GetAgingReport(c *gin.Context) {
+	report, err := h.service.GetAgingReport(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build aging report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// ListStaleFindings handles GET /api/v1/findings/stale
+func (h *FindingAgingHandler) ListStaleFindings(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	findings, err := h.service.ListStaleFindings(sharedapi.RequestContext(c), c.Query("review_status"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list stale findings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  findings,
+		"total": len(findings),
+	})
+}
+
+// DetectStaleFindings handles POST /api/v1/findings/stale/detect
+func (h *FindingAgingHandler) DetectStaleFindings(c *gin.Context) {
+	var opts service.StaleDetectionOptions
+	if c.Request.ContentLength > 0 {
+		if !sharedapi.BindJSON(c, &opts) {
+			return
+		}
+	}
+
+	if opts.Action != "" && opts.Action != entity.StaleActionAutoClose && opts.Action != entity.StaleActionQueue {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "action must be auto_close or queue"})
+		return
+	}
+
+	result, err := h.service.DetectStaleFindings(sharedapi.RequestContext(c), opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to detect stale findings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// GetFindingExposure handles GET /api/v1/findings/:id/exposure
+func (h *FindingAgingHandler) GetFindingExposure(c *gin.Context) {
+	findingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid finding ID"})
+		return
+	}
+
+	exposure, err := h.service.GetFindingExposure(sharedapi.RequestContext(c), findingID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to get finding exposure",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": exposure})
+}
+
+// GetExposureAgeReport handles GET /api/v1/findings/exposure-age?pattern_name=
+func (h *FindingAgingHandler) GetExposureAgeReport(c *gin.Context) {
+	report, err := h.service.GetExposureAgeReport(sharedapi.RequestContext(c), c.Query("pattern_name"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build exposure age report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
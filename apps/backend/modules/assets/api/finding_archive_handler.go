@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FindingArchiveHandler handles finding archive and restore requests
+type FindingArchiveHandler struct {
+	service *service.FindingArchiveService
+}
+
+// NewFindingArchiveHandler creates a new finding archive handler
+func NewFindingArchiveHandler(service *service.FindingArchiveService) *FindingArchiveHandler {
+	return &FindingArchiveHandler{service: service}
+}
+
+// ListArchives handles GET /api/v1/findings/archives
+func (h *FindingArchiveHandler) ListArchives(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	var scanRunID *uuid.UUID
+	if raw := c.Query("scan_run_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan_run_id"})
+			return
+		}
+		scanRunID = &id
+	}
+
+	archives, err := h.service.ListArchives(sharedapi.RequestContext(c), scanRunID, c.Query("status"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list finding archives",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  archives,
+		"total": len(archives),
+	})
+}
+
+// ArchiveFindings handles POST /api/v1/findings/archives
+func (h *FindingArchiveHandler) ArchiveFindings(c *gin.Context) {
+	opts := h.service.DefaultOptions()
+	if c.Request.ContentLength > 0 {
+		if !sharedapi.BindJSON(c, &opts) {
+			return
+		}
+	}
+
+	result, err := h.service.ArchiveFindings(sharedapi.RequestContext(c), opts, requestActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to archive findings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// RestoreScanRun handles POST /api/v1/findings/archives/scan-runs/:scanRunId/restore
+func (h *FindingArchiveHandler) RestoreScanRun(c *gin.Context) {
+	scanRunID, err := uuid.Parse(c.Param("scanRunId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan run ID"})
+		return
+	}
+
+	restored, err := h.service.RestoreScanRun(sharedapi.RequestContext(c), scanRunID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrNoArchivedFindings) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":    "Failed to restore scan run",
+			"details":  err.Error(),
+			"restored": restored,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": restored})
+}
+
+func requestActor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
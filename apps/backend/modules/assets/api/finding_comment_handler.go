@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FindingCommentHandler handles reviewer discussions on findings
+type FindingCommentHandler struct {
+	service *service.FindingCommentService
+}
+
+// NewFindingCommentHandler creates a new finding comment handler
+func NewFindingCommentHandler(service *service.FindingCommentService) *FindingCommentHandler {
+	return &FindingCommentHandler{service: service}
+}
+
+// EditCommentRequest replaces a comment's body
+type EditCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// ListComments handles GET /api/v1/findings/:id/comments
+func (h *FindingCommentHandler) ListComments(c *gin.Context) {
+	findingID, ok := parseFindingID(c)
+	if !ok {
+		return
+	}
+
+	threads, err := h.service.List(sharedapi.RequestContext(c), findingID)
+	if err != nil {
+		c.JSON(statusForCommentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": threads, "total": len(threads)})
+}
+
+// AddComment handles POST /api/v1/findings/:id/comments
+// Set "parent_id" to reply in a thread; "@user@example.com" in the body mentions a user
+func (h *FindingCommentHandler) AddComment(c *gin.Context) {
+	findingID, ok := parseFindingID(c)
+	if !ok {
+		return
+	}
+	var req service.NewComment
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	comment, err := h.service.Add(sharedapi.RequestContext(c), findingID, req, commentAuthor(c))
+	if err != nil {
+		c.JSON(statusForCommentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": comment})
+}
+
+// EditComment handles PUT /api/v1/findings/:id/comments/:commentId
+func (h *FindingCommentHandler) EditComment(c *gin.Context) {
+	findingID, commentID, ok := parseCommentIDs(c)
+	if !ok {
+		return
+	}
+	var req EditCommentRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	comment, err := h.service.Edit(sharedapi.RequestContext(c), findingID, commentID, req.Body, commentAuthor(c))
+	if err != nil {
+		c.JSON(statusForCommentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comment})
+}
+
+// DeleteComment handles DELETE /api/v1/findings/:id/comments/:commentId
+func (h *FindingCommentHandler) DeleteComment(c *gin.Context) {
+	findingID, commentID, ok := parseCommentIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(sharedapi.RequestContext(c), findingID, commentID, commentAuthor(c)); err != nil {
+		c.JSON(statusForCommentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// commentAuthor identifies the caller from their token, never from the body
+func commentAuthor(c *gin.Context) service.CommentAuthor {
+	author := service.CommentAuthor{Email: requestActor(c)}
+	if userID, exists := c.Get("user_id"); exists {
+		if id, err := uuid.Parse(fmt.Sprint(userID)); err == nil {
+			author.ID = &id
+		}
+	}
+	role, _ := c.Get("user_role")
+	author.IsAdmin = fmt.Sprint(role) == "admin"
+	return author
+}
+
+func parseCommentIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	findingID, ok := parseFindingID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return findingID, commentID, true
+}
+
+func statusForCommentError(err error) int {
+	msg := err.Error()
+	switch {
+	case errors.Is(err, service.ErrCommentForbidden):
+		return http.StatusForbidden
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
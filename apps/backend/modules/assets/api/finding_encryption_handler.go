@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+)
+
+// FindingEncryptionHandler handles per-tenant encryption of finding values
+type FindingEncryptionHandler struct {
+	service *service.FindingEncryptionService
+}
+
+// NewFindingEncryptionHandler creates a new finding encryption handler
+func NewFindingEncryptionHandler(service *service.FindingEncryptionService) *FindingEncryptionHandler {
+	return &FindingEncryptionHandler{service: service}
+}
+
+// GetStatus handles GET /api/v1/findings/encryption
+func (h *FindingEncryptionHandler) GetStatus(c *gin.Context) {
+	status, err := h.service.Status(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get finding encryption status", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": status})
+}
+
+// RotateKey handles POST /api/v1/findings/encryption/keys
+// The first key enables encryption; each later one rotates it
+func (h *FindingEncryptionHandler) RotateKey(c *gin.Context) {
+	key, queued, err := h.service.RotateKey(sharedapi.RequestContext(c), c.GetString("user_email"))
+	if errors.Is(err, persistence.ErrFindingEncryptionUnavailable) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Finding encryption is not configured", "details": err.Error()})
+		return
+	}
+	if err != nil && key == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create data key", "details": err.Error()})
+		return
+	}
+	if err != nil {
+		// The key is in place and new findings use it; stored findings migrate on the next rotation or CLI run
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Data key created but migrating findings failed", "details": err.Error()})
+		return
+	}
+	if queued {
+		c.JSON(http.StatusAccepted, gin.H{"data": key, "message": "Finding migration queued"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": key, "message": "Findings migrated"})
+}
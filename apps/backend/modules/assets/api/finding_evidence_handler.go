@@ -0,0 +1,202 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverheadBytes allows for form fields and part headers around the file
+const multipartOverheadBytes = 64 << 10
+
+// FindingEvidenceHandler handles evidence attached to findings
+type FindingEvidenceHandler struct {
+	service *service.FindingEvidenceService
+}
+
+// NewFindingEvidenceHandler creates a new finding evidence handler
+func NewFindingEvidenceHandler(service *service.FindingEvidenceService) *FindingEvidenceHandler {
+	return &FindingEvidenceHandler{service: service}
+}
+
+// AttachEvidence handles POST /api/v1/findings/:id/evidence
+// Expects a multipart form with a "file" part, a "kind" and an optional "description"
+func (h *FindingEvidenceHandler) AttachEvidence(c *gin.Context) {
+	findingID, ok := parseFindingID(c)
+	if !ok {
+		return
+	}
+
+	maxBytes := h.service.MaxSizeBytes()
+	if c.Request.ContentLength > maxBytes+multipartOverheadBytes {
+		evidenceTooLarge(c, maxBytes)
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+multipartOverheadBytes)
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			evidenceTooLarge(c, maxBytes)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "A multipart \"file\" part is required",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxBytes {
+		evidenceTooLarge(c, maxBytes)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read evidence file", "details": err.Error()})
+		return
+	}
+
+	evidence, err := h.service.Attach(sharedapi.RequestContext(c), findingID, service.EvidenceUpload{
+		Kind:        c.PostForm("kind"),
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		Description: c.PostForm("description"),
+		Body:        body,
+	}, requestActor(c))
+	if err != nil {
+		c.JSON(statusForEvidenceError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": evidence})
+}
+
+// ListEvidence handles GET /api/v1/findings/:id/evidence
+func (h *FindingEvidenceHandler) ListEvidence(c *gin.Context) {
+	findingID, ok := parseFindingID(c)
+	if !ok {
+		return
+	}
+
+	evidence, err := h.service.List(sharedapi.RequestContext(c), findingID)
+	if err != nil {
+		c.JSON(statusForEvidenceError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": evidence, "total": len(evidence)})
+}
+
+// GetDownloadURL handles GET /api/v1/findings/:id/evidence/:evidenceId/url
+// Stores that cannot sign URLs (the local provider) point at the authenticated content endpoint
+func (h *FindingEvidenceHandler) GetDownloadURL(c *gin.Context) {
+	findingID, evidenceID, ok := parseEvidenceIDs(c)
+	if !ok {
+		return
+	}
+
+	url, err := h.service.DownloadURL(sharedapi.RequestContext(c), findingID, evidenceID)
+	if service.IsPresignUnsupported(err) {
+		c.JSON(http.StatusOK, gin.H{"data": gin.H{
+			"url":    fmt.Sprintf("/api/v1/findings/%s/evidence/%s/content", findingID, evidenceID),
+			"signed": false,
+		}})
+		return
+	}
+	if err != nil {
+		c.JSON(statusForEvidenceError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"url":        url.URL,
+		"expires_at": url.ExpiresAt,
+		"signed":     true,
+	}})
+}
+
+// DownloadContent handles GET /api/v1/findings/:id/evidence/:evidenceId/content
+func (h *FindingEvidenceHandler) DownloadContent(c *gin.Context) {
+	findingID, evidenceID, ok := parseEvidenceIDs(c)
+	if !ok {
+		return
+	}
+
+	evidence, body, err := h.service.Download(sharedapi.RequestContext(c), findingID, evidenceID)
+	if err != nil {
+		c.JSON(statusForEvidenceError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// Always download rather than render, so uploaded content never runs in the app's origin
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", evidence.FileName))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Data(http.StatusOK, evidence.ContentType, body)
+}
+
+// DeleteEvidence handles DELETE /api/v1/findings/:id/evidence/:evidenceId
+func (h *FindingEvidenceHandler) DeleteEvidence(c *gin.Context) {
+	findingID, evidenceID, ok := parseEvidenceIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(sharedapi.RequestContext(c), findingID, evidenceID); err != nil {
+		c.JSON(statusForEvidenceError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Evidence deleted"})
+}
+
+func parseFindingID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid finding ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func parseEvidenceIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	findingID, ok := parseFindingID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	evidenceID, err := uuid.Parse(c.Param("evidenceId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid evidence ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return findingID, evidenceID, true
+}
+
+func evidenceTooLarge(c *gin.Context, maxBytes int64) {
+	sharedapi.Fail(c, sharedapi.CodePayloadTooLarge, "Evidence file too large", gin.H{"max_bytes": maxBytes})
+}
+
+func statusForEvidenceError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "exceeds"):
+		return http.StatusRequestEntityTooLarge
+	case strings.Contains(msg, "unsupported"):
+		return http.StatusUnsupportedMediaType
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,18 +1,22 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 // FindingsHandler handles findings requests
 type FindingsHandler struct {
-	service *service.FindingsService
+	service     *service.FindingsService
+	propagation *service.ReviewPropagationService
 }
 
 // NewFindingsHandler creates a new findings handler
@@ -20,6 +24,11 @@ func NewFindingsHandler(service *service.FindingsService) *FindingsHandler {
 	return &FindingsHandler{service: service}
 }
 
+// SetReviewPropagation lets feedback ask for its decision to be copied to identical findings
+func (h *FindingsHandler) SetReviewPropagation(propagation *service.ReviewPropagationService) {
+	h.propagation = propagation
+}
+
 // GetFindings handles GET /api/v1/findings
 func (h *FindingsHandler) GetFindings(c *gin.Context) {
 	// Parse query parameters
@@ -27,6 +36,8 @@ func (h *FindingsHandler) GetFindings(c *gin.Context) {
 		Severity:    c.Query("severity"),
 		PatternName: c.Query("pattern_name"),
 		DataSource:  c.Query("data_source"),
+		Bucket:      c.Query("bucket"),
+		Prefix:      c.Query("prefix"),
 		SortBy:      c.DefaultQuery("sort_by", "created_at"),
 		SortOrder:   c.DefaultQuery("sort_order", "desc"),
 	}
@@ -72,6 +83,40 @@ func (h *FindingsHandler) GetFindings(c *gin.Context) {
 		query.AssetID = &assetID
 	}
 
+	// Parse org_unit_id if provided
+	if orgUnitIDStr := c.Query("org_unit_id"); orgUnitIDStr != "" {
+		orgUnitID, err := uuid.Parse(orgUnitIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid org_unit_id format",
+				"details": err.Error(),
+			})
+			return
+		}
+		query.OrgUnitID = &orgUnitID
+	}
+
+	if query.Prefix != "" && query.Bucket == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid prefix filter",
+			"details": "prefix requires bucket",
+		})
+		return
+	}
+
+	// Parse as_of if provided; findings are then read as they were at that time
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		asOf, err := parseHistoryTime(asOfStr, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid as_of format",
+				"details": "use an RFC 3339 timestamp or a YYYY-MM-DD date",
+			})
+			return
+		}
+		query.AsOf = &asOf
+	}
+
 	// Get findings
 	response, err := h.service.GetFindings(c.Request.Context(), query)
 	if err != nil {
@@ -82,12 +127,18 @@ func (h *FindingsHandler) GetFindings(c *gin.Context) {
 		return
 	}
 
+	for _, f := range response.Findings {
+		middleware.RecordAccessedFindings(c, f.ID)
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"data": response,
 	})
 }
 
-// SubmitFeedback handles POST /api/v1/findings/:id/feedback
+// SubmitFeedback handles POST /api/v1/findings/:id/feedback. The review state
+// version the reviewer saw is required, as If-Match or the version field (0 for an
+// unreviewed finding); a stale version returns 409 with the current review state.
 func (h *FindingsHandler) SubmitFeedback(c *gin.Context) {
 	findingIDStr := c.Param("id")
 	findingID, err := uuid.Parse(findingIDStr)
@@ -101,12 +152,28 @@ func (h *FindingsHandler) SubmitFeedback(c *gin.Context) {
 		OriginalClassification string `json:"original_classification"`
 		ProposedClassification string `json:"proposed_classification"`
 		Comments               string `json:"comments"`
+		Version                *int   `json:"version" binding:"omitempty,min=0"`
+		Propagate              bool   `json:"propagate"` // Copy the decision to identical findings
+	}
+
+	if !sharedapi.BindJSON(c, &request) {
+		return
 	}
 
-	if err := c.ShouldBindJSON(&request); err != nil {
+	version, ok, err := sharedapi.IfMatchVersion(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !ok {
+		if request.Version == nil {
+			c.JSON(http.StatusPreconditionRequired, gin.H{
+				"error": "The review version the update is based on is required as an If-Match header or version field",
+			})
+			return
+		}
+		version = *request.Version
+	}
 
 	// Create domain entity
 	feedback := &entity.FindingFeedback{
@@ -118,10 +185,28 @@ func (h *FindingsHandler) SubmitFeedback(c *gin.Context) {
 		Comments:               request.Comments,
 	}
 
-	if err := h.service.SubmitFeedback(c.Request.Context(), feedback); err != nil {
+	state, err := h.service.SubmitFeedback(c.Request.Context(), feedback, version)
+	if err != nil {
+		var conflict *service.ReviewConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "conflict": conflict})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"status": "success"})
+	response := gin.H{"status": "success", "data": state}
+	if request.Propagate && h.propagation != nil {
+		// The decision is saved either way; a failed propagation is reported beside it
+		result, err := h.propagation.Propagate(sharedapi.RequestContext(c), findingID, requestActor(c))
+		if err != nil {
+			response["propagation_error"] = err.Error()
+		} else {
+			response["propagation"] = result
+		}
+	}
+
+	sharedapi.SetVersionETag(c, state.Version)
+	c.JSON(http.StatusCreated, response)
 }
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// OpenAPIOperations describes the asset, finding and org unit routes for the
+// OpenAPI document
+func OpenAPIOperations() []sharedapi.Operation {
+	return []sharedapi.Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/assets",
+			Summary: "List assets",
+			Query: []sharedapi.Parameter{
+				{Name: "bucket", Description: "S3 bucket; lists its objects"},
+				{Name: "prefix", Description: "With bucket, objects whose key starts with it"},
+			},
+			Response: sharedapi.Envelope([]entity.Asset{}),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/assets/s3/buckets",
+			Summary:  "Roll up S3 objects and findings per bucket",
+			Response: sharedapi.Envelope([]entity.S3PrefixRollup{}),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/assets/s3/browse",
+			Summary: "List one level of an S3 bucket: the prefixes under a prefix, rolled up, and its objects",
+			Query: []sharedapi.Parameter{
+				{Name: "bucket", Required: true},
+				{Name: "prefix", Description: "Default the bucket root"},
+				{Name: "limit", Type: "integer", Description: "Objects, 1 to 1000, default 100"},
+				{Name: "offset", Type: "integer", Description: "Default 0"},
+			},
+			Response: sharedapi.Envelope(entity.S3PrefixListing{}),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/assets/:id",
+			Summary:  "Get an asset",
+			Response: sharedapi.Envelope(entity.Asset{}),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/findings",
+			Summary: "List findings, filtered and paginated",
+			Query: []sharedapi.Parameter{
+				{Name: "severity", Description: "Critical, High, Medium or Low"},
+				{Name: "pattern_name"},
+				{Name: "data_source"},
+				{Name: "scan_run_id", Description: "UUID"},
+				{Name: "asset_id", Description: "UUID"},
+				{Name: "org_unit_id", Description: "UUID; assets of a department include its teams'"},
+				{Name: "bucket", Description: "S3 bucket"},
+				{Name: "prefix", Description: "With bucket, objects whose key starts with it"},
+				{Name: "sort_by", Description: "Default created_at"},
+				{Name: "sort_order", Description: "asc or desc, default desc"},
+				{Name: "page", Type: "integer", Description: "Default 1"},
+				{Name: "page_size", Type: "integer", Description: "1 to 100, default 20"},
+			},
+			Response: sharedapi.Data(service.FindingsResponse{}),
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/org-units",
+			Summary:  "List departments, each followed by its teams",
+			Response: sharedapi.Data([]entity.OrgUnit{}),
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/org-units",
+			Summary:  "Create a department, or a team under a department",
+			Request:  CreateOrgUnitRequest{},
+			Status:   http.StatusCreated,
+			Response: sharedapi.Data(entity.OrgUnit{}),
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/org-units/assign",
+			Summary:  "Move assets, connections and users into an org unit",
+			Request:  AssignOrgUnitRequest{},
+			Response: sharedapi.Data(entity.OrgUnitAssignmentResult{}),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/org-units/rollup",
+			Summary: "Roll up asset risk and finding severities per department or team",
+			Query: []sharedapi.Parameter{
+				{Name: "kind", Description: "department (default) or team"},
+				{Name: "high_risk_score", Type: "integer", Description: "1 to 100, default 70"},
+			},
+			Response: sharedapi.Data([]entity.OrgUnitRollup{}),
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OrgUnitHandler handles department and team management and risk rollups
+type OrgUnitHandler struct {
+	service *service.OrgUnitService
+}
+
+// NewOrgUnitHandler creates a new org unit handler
+func NewOrgUnitHandler(service *service.OrgUnitService) *OrgUnitHandler {
+	return &OrgUnitHandler{service: service}
+}
+
+// CreateOrgUnitRequest describes a new department, or a team with its department as parent
+type CreateOrgUnitRequest struct {
+	Kind        string     `json:"kind" binding:"required"`
+	Name        string     `json:"name" binding:"required"`
+	Description string     `json:"description"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+}
+
+// UpdateOrgUnitRequest renames a unit or changes its description
+type UpdateOrgUnitRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// AssignOrgUnitRequest moves assets, connections and users into a unit; a null
+// org_unit_id takes them out of any unit
+type AssignOrgUnitRequest struct {
+	OrgUnitID     *uuid.UUID  `json:"org_unit_id"`
+	AssetIDs      []uuid.UUID `json:"asset_ids"`
+	ConnectionIDs []uuid.UUID `json:"connection_ids"`
+	UserIDs       []uuid.UUID `json:"user_ids"`
+}
+
+// ListOrgUnits handles GET /api/v1/org-units
+func (h *OrgUnitHandler) ListOrgUnits(c *gin.Context) {
+	units, err := h.service.ListOrgUnits(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list org units",
+			"details": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": units})
+}
+
+// GetOrgUnit handles GET /api/v1/org-units/:id
+func (h *OrgUnitHandler) GetOrgUnit(c *gin.Context) {
+	id, ok := orgUnitID(c)
+	if !ok {
+		return
+	}
+	unit, err := h.service.GetOrgUnit(sharedapi.RequestContext(c), id)
+	if err != nil {
+		orgUnitError(c, "Failed to get org unit", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": unit})
+}
+
+// CreateOrgUnit handles POST /api/v1/org-units
+func (h *OrgUnitHandler) CreateOrgUnit(c *gin.Context) {
+	var req CreateOrgUnitRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	unit, err := h.service.CreateOrgUnit(sharedapi.RequestContext(c), &entity.OrgUnit{
+		Kind:        req.Kind,
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+		CreatedBy:   requestActor(c),
+	})
+	if err != nil {
+		orgUnitError(c, "Failed to create org unit", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"data": unit})
+}
+
+// UpdateOrgUnit handles PUT /api/v1/org-units/:id
+func (h *OrgUnitHandler) UpdateOrgUnit(c *gin.Context) {
+	id, ok := orgUnitID(c)
+	if !ok {
+		return
+	}
+	var req UpdateOrgUnitRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	unit, err := h.service.UpdateOrgUnit(sharedapi.RequestContext(c), id, req.Name, req.Description, requestActor(c))
+	if err != nil {
+		orgUnitError(c, "Failed to update org unit", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": unit})
+}
+
+// DeleteOrgUnit handles DELETE /api/v1/org-units/:id
+func (h *OrgUnitHandler) DeleteOrgUnit(c *gin.Context) {
+	id, ok := orgUnitID(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteOrgUnit(sharedapi.RequestContext(c), id, requestActor(c)); err != nil {
+		orgUnitError(c, "Failed to delete org unit", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"id": id, "deleted": true}})
+}
+
+// Assign handles POST /api/v1/org-units/assign
+func (h *OrgUnitHandler) Assign(c *gin.Context) {
+	var req AssignOrgUnitRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.service.Assign(sharedapi.RequestContext(c), &entity.OrgUnitAssignment{
+		OrgUnitID:     req.OrgUnitID,
+		AssetIDs:      req.AssetIDs,
+		ConnectionIDs: req.ConnectionIDs,
+		UserIDs:       req.UserIDs,
+	}, requestActor(c))
+	if err != nil {
+		orgUnitError(c, "Failed to assign org unit", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// GetRollup handles GET /api/v1/org-units/rollup?kind=department|team&high_risk_score=70
+func (h *OrgUnitHandler) GetRollup(c *gin.Context) {
+	threshold := 0
+	if raw := c.Query("high_risk_score"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "high_risk_score must be between 1 and 100"})
+			return
+		}
+		threshold = n
+	}
+
+	rollup, err := h.service.Rollup(sharedapi.RequestContext(c), c.Query("kind"), threshold)
+	if err != nil {
+		orgUnitError(c, "Failed to build org unit rollup", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": rollup})
+}
+
+func orgUnitID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid org unit ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// orgUnitError maps org unit errors to their HTTP status
+func orgUnitError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrInvalidOrgUnit):
+		status = http.StatusBadRequest
+	case errors.Is(err, persistence.ErrOrgUnitNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, persistence.ErrOrgUnitExists), errors.Is(err, persistence.ErrOrgUnitHasTeams):
+		status = http.StatusConflict
+	}
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
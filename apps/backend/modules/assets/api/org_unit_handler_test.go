@@ -0,0 +1,95 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/assets/service"
+	authmiddleware "github.com/arc-platform/backend/modules/auth/middleware"
+	authservice "github.com/arc-platform/backend/modules/auth/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var rollupColumns = []string{"id", "parent_id", "kind", "name", "assets", "high_risk_assets", "max_risk_score",
+	"avg_risk_score", "total_findings", "critical", "high", "medium", "low", "waived"}
+
+// rollupAs requests the org unit rollup as the caller of claims, through the keys
+// the API's auth middleware sets and the org unit scope of the route
+func rollupAs(t *testing.T, mock sqlmock.Sqlmock, repo *persistence.PostgresRepository, claims *authservice.JWTClaims) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { authmiddleware.SetRequestUser(c, claims) })
+	handler := NewOrgUnitHandler(service.NewOrgUnitService(repo, nil))
+	router.GET("/org-units/rollup", authmiddleware.NewAuthMiddleware(repo).ScopeToOrgUnit(), handler.GetRollup)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/org-units/rollup", nil))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	return w
+}
+
+func TestRollupIsScopedToAnalystOrgUnit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := persistence.NewPostgresRepository(db)
+
+	tenantID, userID, orgUnitID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(`FROM users WHERE id = \$1`).WithArgs(userID).WillReturnRows(sqlmock.NewRows([]string{
+		"id", "email", "password_hash", "first_name", "last_name", "role", "tenant_id", "is_active", "org_unit_id",
+		"last_login_at", "created_at", "updated_at",
+	}).AddRow(userID, "analyst@example.com", "", "", "", "viewer", tenantID, true, orgUnitID, nil, now, now))
+	mock.ExpectQuery(`WITH units AS`).WithArgs(tenantID, "department", sqlmock.AnyArg(), orgUnitID.String()).
+		WillReturnRows(sqlmock.NewRows(rollupColumns))
+
+	w := rollupAs(t, mock, repo, &authservice.JWTClaims{UserID: userID.String(), Role: "viewer", TenantID: tenantID.String()})
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRollupIsNotScopedForAdmins(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := persistence.NewPostgresRepository(db)
+
+	// Admins see every unit without their user being loaded
+	tenantID := uuid.New()
+	mock.ExpectQuery(`WITH units AS`).WillReturnRows(sqlmock.NewRows(rollupColumns))
+
+	w := rollupAs(t, mock, repo, &authservice.JWTClaims{UserID: uuid.NewString(), Role: "admin", TenantID: tenantID.String()})
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestRollupRejectsUnknownUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := persistence.NewPostgresRepository(db)
+
+	userID := uuid.New()
+	mock.ExpectQuery(`FROM users WHERE id = \$1`).WithArgs(userID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w := rollupAs(t, mock, repo, &authservice.JWTClaims{UserID: userID.String(), Role: "viewer", TenantID: uuid.NewString()})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a token of a deleted user, got %d", w.Code)
+	}
+}
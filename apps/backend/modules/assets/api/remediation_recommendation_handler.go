@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RemediationRecommendationHandler serves per-asset remediation recommendations
+type RemediationRecommendationHandler struct {
+	service *service.RemediationRecommendationService
+}
+
+// NewRemediationRecommendationHandler creates a new recommendation handler
+func NewRemediationRecommendationHandler(service *service.RemediationRecommendationService) *RemediationRecommendationHandler {
+	return &RemediationRecommendationHandler{service: service}
+}
+
+// GetRecommendations handles GET /api/v1/assets/:id/recommendations
+func (h *RemediationRecommendationHandler) GetRecommendations(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.BadRequest(c, "Invalid asset ID")
+		return
+	}
+
+	recommendations, err := h.service.GetRecommendations(api.RequestContext(c), id)
+	if err != nil {
+		if errors.Is(err, service.ErrAssetNotFound) {
+			api.NotFound(c, "Asset not found")
+			return
+		}
+		api.InternalServerError(c, "Failed to build remediation recommendations")
+		return
+	}
+
+	api.Success(c, recommendations)
+}
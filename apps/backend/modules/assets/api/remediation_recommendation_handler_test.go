@@ -0,0 +1,41 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestGetRecommendationsUnknownAsset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// The tenant reaches the repository from the gin keys the auth middleware sets
+	tenantID, assetID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`FROM assets WHERE id = \$1 AND tenant_id = \$2`).WithArgs(assetID, tenantID).WillReturnError(sql.ErrNoRows)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("tenant_id", tenantID) })
+	handler := NewRemediationRecommendationHandler(service.NewRemediationRecommendationService(persistence.NewPostgresRepository(db)))
+	router.GET("/assets/:id/recommendations", handler.GetRecommendations)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets/"+assetID.String()+"/recommendations", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown asset, got %d: %s", w.Code, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
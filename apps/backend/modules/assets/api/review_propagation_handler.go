@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReviewPropagationHandler handles copying review decisions to identical findings
+type ReviewPropagationHandler struct {
+	service *service.ReviewPropagationService
+}
+
+// NewReviewPropagationHandler creates a new review propagation handler
+func NewReviewPropagationHandler(service *service.ReviewPropagationService) *ReviewPropagationHandler {
+	return &ReviewPropagationHandler{service: service}
+}
+
+// GetSettings handles GET /api/v1/findings/review-propagation
+func (h *ReviewPropagationHandler) GetSettings(c *gin.Context) {
+	settings, err := h.service.GetSettings(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// UpdateSettings handles PUT /api/v1/findings/review-propagation
+func (h *ReviewPropagationHandler) UpdateSettings(c *gin.Context) {
+	var input struct {
+		Enabled *bool `json:"enabled" binding:"required"`
+	}
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	settings, err := h.service.SetEnabled(sharedapi.RequestContext(c), *input.Enabled, requestActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// Preview handles GET /api/v1/findings/:id/propagation-preview?decision=false_positive
+func (h *ReviewPropagationHandler) Preview(c *gin.Context) {
+	findingID, ok := parsePropagationFindingID(c)
+	if !ok {
+		return
+	}
+
+	preview, err := h.service.Preview(sharedapi.RequestContext(c), findingID, c.Query("decision"))
+	if err != nil {
+		c.JSON(statusForPropagationError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": preview})
+}
+
+// Propagate handles POST /api/v1/findings/:id/propagate
+// Copies the finding's current decision to its unreviewed identical findings
+func (h *ReviewPropagationHandler) Propagate(c *gin.Context) {
+	findingID, ok := parsePropagationFindingID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Propagate(sharedapi.RequestContext(c), findingID, requestActor(c))
+	if err != nil {
+		c.JSON(statusForPropagationError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func parsePropagationFindingID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid finding ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForPropagationError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "not enabled"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
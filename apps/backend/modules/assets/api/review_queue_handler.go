@@ -0,0 +1,118 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewQueueHandler handles the prioritized review queue and the policy ordering it
+type ReviewQueueHandler struct {
+	service *service.ReviewPriorityService
+}
+
+// NewReviewQueueHandler creates a new review queue handler
+func NewReviewQueueHandler(service *service.ReviewPriorityService) *ReviewQueueHandler {
+	return &ReviewQueueHandler{service: service}
+}
+
+// GetQueue handles GET /api/v1/findings/review-queue
+// Query: pii_type, queue (main, or language for findings held for content outside
+// the supported languages), limit (default 50, at most 500), offset
+func (h *ReviewQueueHandler) GetQueue(c *gin.Context) {
+	filter := entity.ReviewQueueFilter{
+		PIIType: c.Query("pii_type"),
+		Limit:   50,
+	}
+	switch c.Query("queue") {
+	case "", "main":
+	case "language":
+		filter.LanguageReview = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "queue must be main or language"})
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		filter.Offset = offset
+	}
+
+	queue, err := h.service.GetQueue(sharedapi.RequestContext(c), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get review queue",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":            queue.Items,
+		"total":           queue.Total,
+		"scoring_pending": queue.ScoringPending,
+	})
+}
+
+// GetPolicy handles GET /api/v1/findings/review-queue/policy
+func (h *ReviewQueueHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.service.GetPolicy(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get review priority policy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// UpdatePolicy handles PUT /api/v1/findings/review-queue/policy
+// Replaces the tenant's weights and PII criticality and rescores pending findings
+func (h *ReviewQueueHandler) UpdatePolicy(c *gin.Context) {
+	var input service.ReviewPriorityPolicyInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	policy, err := h.service.UpdatePolicy(sharedapi.RequestContext(c), input, requestActor(c))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if msg := err.Error(); strings.Contains(msg, "must be") || strings.Contains(msg, "invalid") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// Recompute handles POST /api/v1/findings/review-queue/recompute
+func (h *ReviewQueueHandler) Recompute(c *gin.Context) {
+	queued, err := h.service.Recompute(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to recompute review priorities", "details": err.Error()})
+		return
+	}
+	if queued {
+		c.JSON(http.StatusAccepted, gin.H{"message": "Review priority recompute queued"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Review priorities recomputed"})
+}
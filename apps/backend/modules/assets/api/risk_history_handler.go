@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RiskHistoryHandler handles asset risk trend requests
+type RiskHistoryHandler struct {
+	service *service.RiskHistoryService
+}
+
+// NewRiskHistoryHandler creates a new risk history handler
+func NewRiskHistoryHandler(service *service.RiskHistoryService) *RiskHistoryHandler {
+	return &RiskHistoryHandler{service: service}
+}
+
+// GetRiskHistory handles GET /api/v1/assets/:id/risk-history
+// Query: since, until (RFC3339 or YYYY-MM-DD; default the last 90 days), limit (default 500)
+func (h *RiskHistoryHandler) GetRiskHistory(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	var query service.RiskHistoryQuery
+	if v := c.Query("since"); v != "" {
+		if query.Since, err = parseHistoryTime(v, false); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be RFC3339 or YYYY-MM-DD"})
+			return
+		}
+	}
+	if v := c.Query("until"); v != "" {
+		if query.Until, err = parseHistoryTime(v, true); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be RFC3339 or YYYY-MM-DD"})
+			return
+		}
+	}
+	query.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	history, err := h.service.GetRiskHistory(sharedapi.RequestContext(c), assetID, query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			status = http.StatusNotFound
+		case strings.Contains(err.Error(), "invalid window"):
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": history})
+}
+
+// parseHistoryTime accepts RFC3339 or a date; a date as upper bound covers the whole day
+func parseHistoryTime(value string, endOfDay bool) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return day.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return day, nil
+}
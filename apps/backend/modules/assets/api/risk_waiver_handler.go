@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RiskWaiverHandler handles time-boxed acceptance of risk
+type RiskWaiverHandler struct {
+	service *service.RiskWaiverService
+}
+
+// NewRiskWaiverHandler creates a new risk waiver handler
+func NewRiskWaiverHandler(service *service.RiskWaiverService) *RiskWaiverHandler {
+	return &RiskWaiverHandler{service: service}
+}
+
+// ListWaivers handles GET /api/v1/waivers?status=active|expired|revoked
+func (h *RiskWaiverHandler) ListWaivers(c *gin.Context) {
+	waivers, err := h.service.List(sharedapi.RequestContext(c), c.Query("status"))
+	if err != nil {
+		c.JSON(statusForWaiverError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": waivers, "total": len(waivers)})
+}
+
+// GetWaiver handles GET /api/v1/waivers/:id
+func (h *RiskWaiverHandler) GetWaiver(c *gin.Context) {
+	id, ok := parseWaiverID(c)
+	if !ok {
+		return
+	}
+
+	waiver, err := h.service.Get(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForWaiverError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": waiver})
+}
+
+// CreateWaiver handles POST /api/v1/waivers
+func (h *RiskWaiverHandler) CreateWaiver(c *gin.Context) {
+	var req service.RiskWaiverInput
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	waiver, err := h.service.Create(sharedapi.RequestContext(c), req, requestActor(c))
+	if err != nil {
+		c.JSON(statusForWaiverError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": waiver})
+}
+
+// RevokeWaiver handles POST /api/v1/waivers/:id/revoke
+func (h *RiskWaiverHandler) RevokeWaiver(c *gin.Context) {
+	id, ok := parseWaiverID(c)
+	if !ok {
+		return
+	}
+
+	waiver, err := h.service.Revoke(sharedapi.RequestContext(c), id, requestActor(c))
+	if err != nil {
+		c.JSON(statusForWaiverError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": waiver})
+}
+
+// SweepWaivers handles POST /api/v1/waivers/sweep, running the tenant's expiry sweep now
+func (h *RiskWaiverHandler) SweepWaivers(c *gin.Context) {
+	result, err := h.service.Sweep(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(statusForWaiverError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func parseWaiverID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid waiver ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForWaiverError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScanFreshnessHandler handles asset scan freshness and its SLA
+type ScanFreshnessHandler struct {
+	service *service.ScanFreshnessService
+}
+
+// NewScanFreshnessHandler creates a new scan freshness handler
+func NewScanFreshnessHandler(service *service.ScanFreshnessService) *ScanFreshnessHandler {
+	return &ScanFreshnessHandler{service: service}
+}
+
+// ScanFrequencyRequest sets an asset's scan frequency; null restores the default
+type ScanFrequencyRequest struct {
+	ExpectedFrequencyHours *int `json:"expected_frequency_hours"`
+}
+
+// ListStaleAssets handles GET /api/v1/assets/stale?limit=200
+func (h *ScanFreshnessHandler) ListStaleAssets(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	assets, err := h.service.ListStale(sharedapi.RequestContext(c), limit)
+	if err != nil {
+		c.JSON(statusForFreshnessError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": assets, "total": len(assets)})
+}
+
+// GetFreshness handles GET /api/v1/assets/:id/freshness
+func (h *ScanFreshnessHandler) GetFreshness(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	freshness, err := h.service.Get(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForFreshnessError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": freshness})
+}
+
+// SetScanFrequency handles PUT /api/v1/assets/:id/scan-frequency
+func (h *ScanFreshnessHandler) SetScanFrequency(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+	var req ScanFrequencyRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	freshness, err := h.service.SetFrequency(sharedapi.RequestContext(c), id, req.ExpectedFrequencyHours, requestActor(c))
+	if err != nil {
+		c.JSON(statusForFreshnessError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": freshness})
+}
+
+// EvaluateFreshness handles POST /api/v1/assets/stale/evaluate, running the tenant's SLA check now
+func (h *ScanFreshnessHandler) EvaluateFreshness(c *gin.Context) {
+	result, err := h.service.Evaluate(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(statusForFreshnessError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func statusForFreshnessError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/assets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ValueOccurrenceHandler handles lookups of a PII value across assets
+type ValueOccurrenceHandler struct {
+	service *service.ValueOccurrenceService
+}
+
+// NewValueOccurrenceHandler creates a new value occurrence handler
+func NewValueOccurrenceHandler(service *service.ValueOccurrenceService) *ValueOccurrenceHandler {
+	return &ValueOccurrenceHandler{service: service}
+}
+
+// GetValueOccurrences handles GET /api/v1/values/:hash/occurrences
+func (h *ValueOccurrenceHandler) GetValueOccurrences(c *gin.Context) {
+	occurrences, err := h.service.GetValueOccurrences(sharedapi.RequestContext(c), c.Param("hash"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidValueHash):
+			status = http.StatusBadRequest
+		case strings.Contains(err.Error(), "not found"):
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to get value occurrences",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": occurrences})
+}
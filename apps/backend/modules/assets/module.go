@@ -1,25 +1,63 @@
 package assets
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"github.com/arc-platform/backend/modules/assets/api"
 	"github.com/arc-platform/backend/modules/assets/service"
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	maskingservice "github.com/arc-platform/backend/modules/masking/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/archive"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/canonical"
 	"github.com/gin-gonic/gin"
 )
 
 type AssetsModule struct {
-	assetService    *service.AssetService
-	findingsService *service.FindingsService
-	datasetService  *service.DatasetService
+	assetService     *service.AssetService
+	findingsService  *service.FindingsService
+	datasetService   *service.DatasetService
+	agingService     *service.FindingAgingService
+	archiveService   *service.FindingArchiveService // nil when no archive store is configured
+	recommendations  *service.RemediationRecommendationService
+	mergeService     *service.AssetMergeService
+	evidenceService  *service.FindingEvidenceService // nil when no evidence store is configured
+	priorityService  *service.ReviewPriorityService
+	encryptService   *service.FindingEncryptionService
+	orgUnitService   *service.OrgUnitService
+	commentService   *service.FindingCommentService
+	waiverService    *service.RiskWaiverService
+	freshnessService *service.ScanFreshnessService
+	propagation      *service.ReviewPropagationService
 
-	assetHandler    *api.AssetHandler
-	findingsHandler *api.FindingsHandler
-	datasetHandler  *api.DatasetHandler
+	assetHandler       *api.AssetHandler
+	findingsHandler    *api.FindingsHandler
+	datasetHandler     *api.DatasetHandler
+	agingHandler       *api.FindingAgingHandler
+	archiveHandler     *api.FindingArchiveHandler
+	recommendHandler   *api.RemediationRecommendationHandler
+	mergeHandler       *api.AssetMergeHandler
+	evidenceHandler    *api.FindingEvidenceHandler
+	riskHistoryHandler *api.RiskHistoryHandler
+	occurrenceHandler  *api.ValueOccurrenceHandler
+	reviewQueueHandler *api.ReviewQueueHandler
+	encryptionHandler  *api.FindingEncryptionHandler
+	orgUnitHandler     *api.OrgUnitHandler
+	commentHandler     *api.FindingCommentHandler
+	waiverHandler      *api.RiskWaiverHandler
+	freshnessHandler   *api.ScanFreshnessHandler
+	propagationHandler *api.ReviewPropagationHandler
 
-	deps *interfaces.ModuleDependencies
+	authMiddleware *middleware.AuthMiddleware
+
+	deps         *interfaces.ModuleDependencies
+	workerCtx    context.Context
+	cancelWorker context.CancelFunc
 }
 
 func (m *AssetsModule) Name() string {
@@ -55,12 +93,142 @@ func (m *AssetsModule) Initialize(deps *interfaces.ModuleDependencies) error {
 	}
 
 	m.assetService = service.NewAssetService(repo, lineageSync, auditLogger)
+	if deps.Config != nil {
+		m.assetService.SetPathPolicies(canonical.NewPolicies(deps.Config.AssetPaths.CaseSensitiveSources, deps.Config.AssetPaths.KeepTrailingSlash))
+	}
 	m.findingsService = service.NewFindingsService(repo)
+	m.findingsService.SetIntegrationEvents(deps.IntegrationEvents)
+	m.findingsService.SetMaskingTemplates(maskingservice.NewMaskingTemplateService(repo, auditLogger))
+	if deps.Config != nil {
+		m.findingsService.SetSampleTextPolicy(deps.Config.SampleText.Policy)
+	}
 	m.datasetService = service.NewDatasetService(repo)
+	m.agingService = service.NewFindingAgingService(repo, auditLogger)
+	m.recommendations = service.NewRemediationRecommendationService(repo)
+	m.mergeService = service.NewAssetMergeService(repo, lineageSync, auditLogger)
+
+	// Pending findings are ordered for review; a policy change rescores them in the background
+	m.priorityService = service.NewReviewPriorityService(repo, auditLogger, deps.Jobs)
+	if deps.Jobs != nil {
+		m.priorityService.RegisterJobs(deps.Jobs)
+	}
+
+	// Finding values are sealed under per-tenant data keys once a tenant creates one
+	m.encryptService = service.NewFindingEncryptionService(repo, auditLogger, deps.Jobs)
+	if deps.Jobs != nil {
+		m.encryptService.RegisterJobs(deps.Jobs)
+	}
+
+	// Departments and teams own assets; analysts in one only see its findings
+	m.orgUnitService = service.NewOrgUnitService(repo, auditLogger)
+
+	// Reviewers discuss findings in comment threads; mentioned users are notified
+	m.commentService = service.NewFindingCommentService(repo, auditLogger)
+	if deps.Config != nil {
+		m.commentService.SetMentionNotifier(service.NewMentionNotifier(
+			deps.EventPublisher, mailer.New(deps.Config.Alerting), deps.Config.Alerting.DashboardURL))
+	}
+
+	// Accepted risk is time-boxed; waived findings leave risk rollups until the waiver lapses
+	var waiverOpts service.RiskWaiverOptions
+	if deps.Config != nil {
+		waiverOpts = service.RiskWaiverOptions{
+			MaxDays:     deps.Config.Waivers.MaxDays,
+			WarningDays: deps.Config.Waivers.WarningDays,
+		}
+	}
+	m.waiverService = service.NewRiskWaiverService(repo, auditLogger, waiverOpts)
+	if deps.Config != nil {
+		m.waiverService.SetAlerts(deps.EventPublisher, mailer.New(deps.Config.Alerting), deps.Config.Alerting.DashboardURL)
+	}
+
+	// Assets are held to a scan frequency; overdue ones carry extra risk until rescanned
+	freshnessHours := 0
+	if deps.Config != nil {
+		freshnessHours = deps.Config.Freshness.DefaultFrequencyHours
+	}
+	m.freshnessService = service.NewScanFreshnessService(repo, auditLogger, freshnessHours)
+	m.freshnessService.SetEventPublisher(deps.EventPublisher)
+	m.propagation = service.NewReviewPropagationService(repo, auditLogger)
 
 	m.assetHandler = api.NewAssetHandler(m.assetService)
 	m.findingsHandler = api.NewFindingsHandler(m.findingsService)
+	m.findingsHandler.SetReviewPropagation(m.propagation)
 	m.datasetHandler = api.NewDatasetHandler(m.datasetService)
+	m.agingHandler = api.NewFindingAgingHandler(m.agingService)
+	m.recommendHandler = api.NewRemediationRecommendationHandler(m.recommendations)
+	m.mergeHandler = api.NewAssetMergeHandler(m.mergeService)
+	m.riskHistoryHandler = api.NewRiskHistoryHandler(service.NewRiskHistoryService(repo))
+	m.occurrenceHandler = api.NewValueOccurrenceHandler(service.NewValueOccurrenceService(repo))
+	m.reviewQueueHandler = api.NewReviewQueueHandler(m.priorityService)
+	m.encryptionHandler = api.NewFindingEncryptionHandler(m.encryptService)
+	m.orgUnitHandler = api.NewOrgUnitHandler(m.orgUnitService)
+	m.commentHandler = api.NewFindingCommentHandler(m.commentService)
+	m.waiverHandler = api.NewRiskWaiverHandler(m.waiverService)
+	m.freshnessHandler = api.NewScanFreshnessHandler(m.freshnessService)
+	m.propagationHandler = api.NewReviewPropagationHandler(m.propagation)
+
+	// Initialize Auth Middleware for admin-only routes
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	// Finding archiving requires an object store; skip it when none is configured
+	if deps.Config != nil && (deps.Config.Archive.Bucket != "" || deps.Config.Archive.LocalPath != "") {
+		archiveCfg := deps.Config.Archive
+		store, err := archive.NewObjectStore(archiveCfg)
+		if err != nil {
+			log.Printf("⚠️  Finding archive store unavailable: %v", err)
+		} else {
+			m.archiveService = service.NewFindingArchiveService(repo, store, archiveCfg.Prefix, service.ArchiveOptions{
+				OlderThanDays: archiveCfg.OlderThanDays,
+				ClosedOnly:    archiveCfg.ClosedOnly,
+			}, auditLogger)
+			m.archiveHandler = api.NewFindingArchiveHandler(m.archiveService)
+		}
+	}
+
+	// Evidence attachments require an object store; skip them when none is configured
+	if deps.Config != nil && (deps.Config.Evidence.Bucket != "" || deps.Config.Evidence.LocalPath != "") {
+		evidenceCfg := deps.Config.Evidence
+		store, err := archive.NewObjectStore(evidenceCfg.StoreConfig())
+		if err != nil {
+			log.Printf("⚠️  Finding evidence store unavailable: %v", err)
+		} else {
+			m.evidenceService = service.NewFindingEvidenceService(repo, store, evidenceCfg.Prefix, service.EvidenceLimits{
+				MaxSizeBytes: int64(evidenceCfg.MaxSizeKB) << 10,
+				AllowedTypes: evidenceCfg.AllowedTypes,
+				URLExpiry:    time.Duration(evidenceCfg.URLExpiryMinutes) * time.Minute,
+			}, auditLogger)
+			m.evidenceHandler = api.NewFindingEvidenceHandler(m.evidenceService)
+			go m.evidenceService.StartCleanupWorker(m.workerContext(), evidenceCfg.CleanupIntervalMinutes)
+		}
+	}
+
+	// Start stale finding detection in the background if enabled
+	if deps.Config != nil && deps.Config.FindingAging.Enabled {
+		go m.agingService.StartStaleDetectionWorker(m.workerContext(), deps.Config.FindingAging.IntervalMinutes, service.StaleDetectionOptions{
+			ScanWindow: deps.Config.FindingAging.ScanWindow,
+			Action:     deps.Config.FindingAging.Action,
+		})
+	}
+
+	// Expire waivers, alert on upcoming expiries and waive new findings in waived scopes
+	sweepMinutes := 0
+	if deps.Config != nil {
+		sweepMinutes = deps.Config.Waivers.SweepIntervalMinutes
+	}
+	go m.waiverService.StartSweepWorker(m.workerContext(), sweepMinutes)
+
+	// Flag assets overdue for a scan and keep their freshness penalty current
+	freshnessMinutes := 0
+	if deps.Config != nil {
+		freshnessMinutes = deps.Config.Freshness.IntervalMinutes
+	}
+	go m.freshnessService.StartSLAWorker(m.workerContext(), freshnessMinutes)
+
+	// Start finding archiving in the background if enabled
+	if m.archiveService != nil && deps.Config.Archive.Enabled {
+		go m.archiveService.StartArchiveWorker(m.workerContext(), deps.Config.Archive.IntervalMinutes)
+	}
 
 	log.Printf("✅ Assets Module initialized")
 	return nil
@@ -68,18 +236,88 @@ func (m *AssetsModule) Initialize(deps *interfaces.ModuleDependencies) error {
 
 func (m *AssetsModule) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/assets", m.assetHandler.ListAssets)
+	router.POST("/assets/merge", m.authMiddleware.RequireRole("admin"), m.mergeHandler.MergeAssets)
+	router.GET("/assets/s3/buckets", m.authMiddleware.ScopeToOrgUnit(), m.assetHandler.ListS3Buckets)
+	router.GET("/assets/s3/browse", m.authMiddleware.ScopeToOrgUnit(), m.assetHandler.BrowseS3Prefix)
+	router.GET("/assets/stale", m.freshnessHandler.ListStaleAssets)
+	router.POST("/assets/stale/evaluate", m.authMiddleware.RequireRole("admin"), m.freshnessHandler.EvaluateFreshness)
 	router.GET("/assets/:id", m.assetHandler.GetAsset)
-	router.GET("/findings", m.findingsHandler.GetFindings)
+	router.GET("/assets/:id/freshness", m.freshnessHandler.GetFreshness)
+	router.PUT("/assets/:id/scan-frequency", m.authMiddleware.RequireRole("admin"), m.freshnessHandler.SetScanFrequency)
+	router.GET("/assets/:id/recommendations", m.recommendHandler.GetRecommendations)
+	router.GET("/assets/:id/risk-history", m.riskHistoryHandler.GetRiskHistory)
+	router.GET("/org-units", m.orgUnitHandler.ListOrgUnits)
+	router.POST("/org-units", m.authMiddleware.RequireRole("admin"), m.orgUnitHandler.CreateOrgUnit)
+	router.POST("/org-units/assign", m.authMiddleware.RequireRole("admin"), m.orgUnitHandler.Assign)
+	router.GET("/org-units/rollup", m.authMiddleware.ScopeToOrgUnit(), m.orgUnitHandler.GetRollup)
+	router.GET("/org-units/:id", m.orgUnitHandler.GetOrgUnit)
+	router.PUT("/org-units/:id", m.authMiddleware.RequireRole("admin"), m.orgUnitHandler.UpdateOrgUnit)
+	router.DELETE("/org-units/:id", m.authMiddleware.RequireRole("admin"), m.orgUnitHandler.DeleteOrgUnit)
+	// Finding lists include PII sample values; reads are audited and limited to the user's org unit
+	router.GET("/findings", m.authMiddleware.ScopeToOrgUnit(), m.deps.AccessAudit.Middleware(), m.findingsHandler.GetFindings)
 	router.POST("/findings/:id/feedback", m.findingsHandler.SubmitFeedback)
+	router.GET("/findings/:id/propagation-preview", m.propagationHandler.Preview)
+	router.POST("/findings/:id/propagate", m.propagationHandler.Propagate)
+	router.GET("/findings/review-propagation", m.propagationHandler.GetSettings)
+	router.PUT("/findings/review-propagation", m.authMiddleware.RequireRole("admin"), m.propagationHandler.UpdateSettings)
+	router.GET("/findings/review-queue", m.authMiddleware.ScopeToOrgUnit(), m.reviewQueueHandler.GetQueue)
+	router.GET("/findings/review-queue/policy", m.reviewQueueHandler.GetPolicy)
+	router.PUT("/findings/review-queue/policy", m.authMiddleware.RequireRole("admin"), m.reviewQueueHandler.UpdatePolicy)
+	router.POST("/findings/review-queue/recompute", m.authMiddleware.RequireRole("admin"), m.reviewQueueHandler.Recompute)
+	router.GET("/findings/encryption", m.authMiddleware.RequireRole("admin"), m.encryptionHandler.GetStatus)
+	router.POST("/findings/encryption/keys", m.authMiddleware.RequireRole("admin"), m.encryptionHandler.RotateKey)
+	router.GET("/findings/aging", m.agingHandler.GetAgingReport)
+	router.GET("/findings/stale", m.agingHandler.ListStaleFindings)
+	router.POST("/findings/stale/detect", m.authMiddleware.RequireRole("admin"), m.agingHandler.DetectStaleFindings)
+	router.GET("/findings/exposure-age", m.agingHandler.GetExposureAgeReport)
+	router.GET("/findings/:id/exposure", m.agingHandler.GetFindingExposure)
+	router.GET("/findings/:id/comments", m.commentHandler.ListComments)
+	router.POST("/findings/:id/comments", m.commentHandler.AddComment)
+	router.PUT("/findings/:id/comments/:commentId", m.commentHandler.EditComment)
+	router.DELETE("/findings/:id/comments/:commentId", m.commentHandler.DeleteComment)
+	if m.archiveHandler != nil {
+		router.GET("/findings/archives", m.archiveHandler.ListArchives)
+		router.POST("/findings/archives", m.authMiddleware.RequireRole("admin"), m.archiveHandler.ArchiveFindings)
+		router.POST("/findings/archives/scan-runs/:scanRunId/restore", m.authMiddleware.RequireRole("admin"), m.archiveHandler.RestoreScanRun)
+	}
+	if m.evidenceHandler != nil {
+		router.GET("/findings/:id/evidence", m.evidenceHandler.ListEvidence)
+		router.POST("/findings/:id/evidence", m.evidenceHandler.AttachEvidence)
+		router.GET("/findings/:id/evidence/:evidenceId/url", m.evidenceHandler.GetDownloadURL)
+		router.GET("/findings/:id/evidence/:evidenceId/content", m.evidenceHandler.DownloadContent)
+		router.DELETE("/findings/:id/evidence/:evidenceId", m.evidenceHandler.DeleteEvidence)
+	}
+	router.GET("/waivers", m.waiverHandler.ListWaivers)
+	router.POST("/waivers", m.authMiddleware.RequireRole("admin"), m.waiverHandler.CreateWaiver)
+	router.POST("/waivers/sweep", m.authMiddleware.RequireRole("admin"), m.waiverHandler.SweepWaivers)
+	router.GET("/waivers/:id", m.waiverHandler.GetWaiver)
+	router.POST("/waivers/:id/revoke", m.authMiddleware.RequireRole("admin"), m.waiverHandler.RevokeWaiver)
+	router.GET("/values/:hash/occurrences", m.occurrenceHandler.GetValueOccurrences)
 	router.GET("/dataset/golden", m.datasetHandler.GetGoldenDataset)
 	log.Printf("📦 Assets routes registered")
 }
 
+// OpenAPIOperations describes the module's routes for the OpenAPI document
+func (m *AssetsModule) OpenAPIOperations() []sharedapi.Operation {
+	return api.OpenAPIOperations()
+}
+
 func (m *AssetsModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Assets Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
 	return nil
 }
 
+// workerContext returns the context shared by background workers, cancelled on shutdown
+func (m *AssetsModule) workerContext() context.Context {
+	if m.workerCtx == nil {
+		m.workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+	}
+	return m.workerCtx
+}
+
 // GetAssetService returns the asset service for inter-module use
 func (m *AssetsModule) GetAssetService() *service.AssetService {
 	return m.assetService
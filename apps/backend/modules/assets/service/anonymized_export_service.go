@@ -0,0 +1,360 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/pkg/normalization"
+	"github.com/arc-platform/backend/pkg/synthetic"
+	"github.com/google/uuid"
+)
+
+// minLeakLength is the shortest normalized original value the leak check looks
+// for; shorter ones occur by chance in synthetic data
+const minLeakLength = 6
+
+// AnonymizedFinding is one line of an anonymized dataset export. IDs are
+// pseudonyms stable within the export key, so findings still join to their
+// assets and scan runs, and values are format-preserving synthetic stand-ins.
+type AnonymizedFinding struct {
+	FindingID       uuid.UUID                 `json:"finding_id"`
+	ScanRunID       uuid.UUID                 `json:"scan_run_id"`
+	AssetID         uuid.UUID                 `json:"asset_id"`
+	AssetName       string                    `json:"asset_name"`
+	AssetPath       string                    `json:"asset_path"`
+	Host            string                    `json:"host"`
+	DataSource      string                    `json:"data_source"`
+	Environment     string                    `json:"environment"`
+	PatternName     string                    `json:"pattern_name"`
+	Matches         []string                  `json:"matches"`
+	SampleText      string                    `json:"sample_text"`
+	Severity        string                    `json:"severity"`
+	ConfidenceScore *float64                  `json:"confidence_score,omitempty"`
+	Classification  *AnonymizedClassification `json:"classification,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+}
+
+// texts returns the fields derived from original values, which the leak check
+// covers; timestamps, scores and categorical fields are left out as they would
+// match numeric values by chance
+func (f *AnonymizedFinding) texts() []string {
+	texts := append([]string{f.FindingID.String(), f.ScanRunID.String(), f.AssetID.String(),
+		f.AssetName, f.AssetPath, f.Host, f.SampleText}, f.Matches...)
+	if f.Classification != nil {
+		texts = append(texts, f.Classification.Justification)
+	}
+	return texts
+}
+
+// AnonymizedClassification is the latest classification of an exported finding
+type AnonymizedClassification struct {
+	Type            string  `json:"type"`
+	SubCategory     string  `json:"sub_category,omitempty"`
+	ConfidenceScore float64 `json:"confidence_score"`
+	DPDPACategory   string  `json:"dpdpa_category,omitempty"`
+	RequiresConsent bool    `json:"requires_consent"`
+	Justification   string  `json:"justification,omitempty"`
+}
+
+// AnonymizedExportResult summarizes an export and its leak verification
+type AnonymizedExportResult struct {
+	Findings       int    `json:"findings"`
+	Assets         int    `json:"assets"`
+	ScanRuns       int    `json:"scan_runs"`
+	SamplesDropped int    `json:"samples_dropped"` // Free text still holding an original value after replacement
+	ValuesChecked  int    `json:"values_checked"`  // Distinct original values verified absent from the export
+	KeyFingerprint string `json:"key_fingerprint"` // Exports with the same fingerprint share replacements
+}
+
+// ExportVerification is the result of checking an export file for original values
+type ExportVerification struct {
+	Lines         int      `json:"lines"`
+	ValuesChecked int      `json:"values_checked"`
+	LeakingLines  []int    `json:"leaking_lines,omitempty"`
+	Passed        bool     `json:"passed"`
+	Leaks         []string `json:"-"` // The leaked values; never printed
+}
+
+// AnonymizedExportService exports a tenant's findings and classifications as an
+// anonymized dataset for ML experiments. Every line is checked against the
+// tenant's original values before it is written.
+type AnonymizedExportService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewAnonymizedExportService creates a new anonymized export service
+func NewAnonymizedExportService(repo *persistence.PostgresRepository) *AnonymizedExportService {
+	return &AnonymizedExportService{repo: repo}
+}
+
+// Export writes the tenant's findings as anonymized JSON lines to w. Values equal
+// in the source stay equal in the export, and exports made with the same key
+// use the same replacements so they can be compared. It fails without writing
+// the line if an original value would leak.
+func (s *AnonymizedExportService) Export(ctx context.Context, w io.Writer, key []byte) (*AnonymizedExportResult, error) {
+	detector, err := s.originalValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	anon := newFindingAnonymizer(key)
+	result := &AnonymizedExportResult{ValuesChecked: detector.size(), KeyFingerprint: keyFingerprint(key)}
+	assets, scanRuns := make(map[uuid.UUID]bool), make(map[uuid.UUID]bool)
+	enc := json.NewEncoder(w)
+
+	err = s.repo.IterateFindingsForExport(ctx, func(rows []*entity.FindingExportRow) error {
+		for _, row := range rows {
+			record := anon.finding(row)
+			if detector.leaks(record.texts()...) != nil {
+				// Reformatted matches in free text escape replacement; drop the free text
+				record.SampleText = ""
+				if record.Classification != nil {
+					record.Classification.Justification = ""
+				}
+				result.SamplesDropped++
+				if detector.leaks(record.texts()...) != nil {
+					return fmt.Errorf("anonymized finding %s still holds an original value; export aborted", record.FindingID)
+				}
+			}
+			if err := enc.Encode(record); err != nil {
+				return err
+			}
+			result.Findings++
+			assets[row.AssetID], scanRuns[row.ScanRunID] = true, true
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Assets, result.ScanRuns = len(assets), len(scanRuns)
+	return result, nil
+}
+
+// Verify checks every line of an export for the tenant's original values
+func (s *AnonymizedExportService) Verify(ctx context.Context, r io.Reader) (*ExportVerification, error) {
+	detector, err := s.originalValues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &ExportVerification{ValuesChecked: detector.size()}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		verification.Lines++
+		var record AnonymizedFinding
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d is not an anonymized finding: %w", verification.Lines, err)
+		}
+		if leaks := detector.leaks(record.texts()...); leaks != nil {
+			verification.LeakingLines = append(verification.LeakingLines, verification.Lines)
+			verification.Leaks = append(verification.Leaks, leaks...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read export: %w", err)
+	}
+	verification.Passed = len(verification.LeakingLines) == 0
+	return verification, nil
+}
+
+// originalValues collects the tenant's values an export must not contain: match
+// values, IDs, hosts, asset names and paths
+func (s *AnonymizedExportService) originalValues(ctx context.Context) (*leakDetector, error) {
+	detector := newLeakDetector()
+	err := s.repo.IterateFindingsForExport(ctx, func(rows []*entity.FindingExportRow) error {
+		for _, row := range rows {
+			for _, match := range row.Matches {
+				detector.add(match)
+			}
+			detector.add(row.FindingID.String())
+			detector.add(row.ScanRunID.String())
+			detector.add(row.AssetID.String())
+			detector.add(row.Host)
+			detector.add(row.AssetName)
+			detector.add(row.AssetPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read original values: %w", err)
+	}
+	return detector, nil
+}
+
+// findingAnonymizer turns export rows into anonymized findings
+type findingAnonymizer struct {
+	values    *synthetic.Anonymizer
+	namespace uuid.UUID
+}
+
+func newFindingAnonymizer(key []byte) *findingAnonymizer {
+	return &findingAnonymizer{
+		values:    synthetic.NewAnonymizer(key),
+		namespace: uuid.NewSHA1(uuid.Nil, key),
+	}
+}
+
+func (a *findingAnonymizer) finding(row *entity.FindingExportRow) *AnonymizedFinding {
+	piiType := row.ClassificationType
+	if piiType == "" {
+		piiType = row.PatternName
+	}
+
+	// Free text is rewritten with the replacements of every value known to be in it
+	replacements := make([]string, 0, 2*len(row.Matches)+4)
+	matches := make([]string, len(row.Matches))
+	for i, match := range row.Matches {
+		matches[i] = a.values.Replace(piiType, match)
+		replacements = append(replacements, match, matches[i])
+	}
+	host := a.values.Replace("HOST", row.Host)
+	name := a.anonymizeName(row.AssetName)
+	replacements = append(replacements, row.Host, host, row.AssetName, name)
+	text := replacer(replacements)
+
+	record := &AnonymizedFinding{
+		FindingID:       a.pseudonym(row.FindingID),
+		ScanRunID:       a.pseudonym(row.ScanRunID),
+		AssetID:         a.pseudonym(row.AssetID),
+		AssetName:       name,
+		AssetPath:       a.anonymizePath(row.AssetPath, text),
+		Host:            host,
+		DataSource:      row.DataSource,
+		Environment:     row.Environment,
+		PatternName:     row.PatternName,
+		Matches:         matches,
+		SampleText:      text.Replace(row.SampleText),
+		Severity:        row.Severity,
+		ConfidenceScore: row.ConfidenceScore,
+		CreatedAt:       row.CreatedAt,
+	}
+	if row.ClassificationType != "" {
+		record.Classification = &AnonymizedClassification{
+			Type:            row.ClassificationType,
+			SubCategory:     row.SubCategory,
+			ConfidenceScore: row.ClassificationScore,
+			DPDPACategory:   row.DPDPACategory,
+			RequiresConsent: row.RequiresConsent,
+			Justification:   text.Replace(row.Justification),
+		}
+	}
+	return record
+}
+
+// anonymizeName replaces an asset name or path, keeping a file extension so the
+// file type stays visible
+func (a *findingAnonymizer) anonymizeName(name string) string {
+	ext := path.Ext(name)
+	if len(ext) < 2 || len(ext) > 5 {
+		ext = ""
+	}
+	return a.values.Replace("ASSET", strings.TrimSuffix(name, ext)) + ext
+}
+
+// anonymizePath replaces every directory of a path and rewrites its last element,
+// which holds the asset name and for databases the host, with the replacements
+// used elsewhere in the finding. Schemes such as "postgresql:" are kept.
+func (a *findingAnonymizer) anonymizePath(p string, text *strings.Replacer) string {
+	i := strings.LastIndex(p, "/")
+	if i < 0 {
+		if replaced := text.Replace(p); replaced != p {
+			return replaced
+		}
+		return a.anonymizeName(p)
+	}
+	dirs := strings.Split(p[:i], "/")
+	for j, dir := range dirs {
+		if dir != "" && !strings.HasSuffix(dir, ":") {
+			dirs[j] = a.values.Replace("PATH", dir)
+		}
+	}
+	return strings.Join(dirs, "/") + "/" + text.Replace(p[i+1:])
+}
+
+// pseudonym maps an ID to a stable ID within the export key
+func (a *findingAnonymizer) pseudonym(id uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(a.namespace, id[:])
+}
+
+// replacer replaces old and new pairs in text, longest first so a value is not
+// partly replaced through a shorter one it contains
+func replacer(pairs []string) *strings.Replacer {
+	var args []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i] != "" {
+			args = append(args, pairs[i], pairs[i+1])
+		}
+	}
+	for i := 0; i < len(args); i += 2 {
+		for j := i + 2; j < len(args); j += 2 {
+			if len(args[j]) > len(args[i]) {
+				args[i], args[i+1], args[j], args[j+1] = args[j], args[j+1], args[i], args[i+1]
+			}
+		}
+	}
+	return strings.NewReplacer(args...)
+}
+
+func keyFingerprint(key []byte) string {
+	sum := sha256.Sum256(append([]byte("anonymized-export:"), key...))
+	return hex.EncodeToString(sum[:8])
+}
+
+// leakDetector finds original values in text regardless of formatting: both are
+// compared in normalized form, so "4111 1111" is found in "x41111111x"
+type leakDetector struct {
+	byLength map[int]map[string]bool
+	count    int
+}
+
+func newLeakDetector() *leakDetector {
+	return &leakDetector{byLength: make(map[int]map[string]bool)}
+}
+
+func (d *leakDetector) add(value string) {
+	canonical := normalization.NormalizeForDedup(value)
+	n := len([]rune(canonical))
+	if n < minLeakLength {
+		return
+	}
+	if d.byLength[n] == nil {
+		d.byLength[n] = make(map[string]bool)
+	}
+	if !d.byLength[n][canonical] {
+		d.byLength[n][canonical] = true
+		d.count++
+	}
+}
+
+func (d *leakDetector) size() int {
+	return d.count
+}
+
+// leaks returns the original values found in texts, or nil
+func (d *leakDetector) leaks(texts ...string) []string {
+	var found []string
+	for _, text := range texts {
+		canonical := []rune(normalization.NormalizeForDedup(text))
+		for n, values := range d.byLength {
+			for i := 0; i+n <= len(canonical); i++ {
+				if window := string(canonical[i : i+n]); values[window] {
+					found = append(found, window)
+				}
+			}
+		}
+	}
+	return found
+}
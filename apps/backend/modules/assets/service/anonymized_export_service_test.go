@@ -0,0 +1,83 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func exportRow() *entity.FindingExportRow {
+	return &entity.FindingExportRow{
+		FindingID:          uuid.New(),
+		ScanRunID:          uuid.New(),
+		AssetID:            uuid.New(),
+		AssetName:          "customers.csv",
+		AssetPath:          "/home/asha/exports/customers.csv",
+		Host:               "files-01.example.com",
+		PatternName:        "Credit Card",
+		Matches:            []string{"4111 1111 1111 1111"},
+		SampleText:         "card 4111 1111 1111 1111 found in customers.csv on files-01.example.com",
+		ClassificationType: "CREDIT_CARD",
+		Justification:      "Luhn-valid 4111 1111 1111 1111",
+	}
+}
+
+func TestFindingAnonymizerKeepsReferencesAndReplacesValues(t *testing.T) {
+	row := exportRow()
+	anon := newFindingAnonymizer([]byte("export-key"))
+	record := anon.finding(row)
+
+	if record.FindingID == row.FindingID || record.AssetID == row.AssetID {
+		t.Fatalf("expected IDs to be pseudonymized")
+	}
+	if again := anon.finding(row); again.AssetID != record.AssetID || again.Matches[0] != record.Matches[0] {
+		t.Errorf("expected the same row to anonymize the same way")
+	}
+	if other := newFindingAnonymizer([]byte("other-key")).finding(row); other.AssetID == record.AssetID {
+		t.Errorf("expected pseudonyms to depend on the key")
+	}
+
+	if !strings.HasSuffix(record.AssetName, ".csv") || record.AssetName == row.AssetName {
+		t.Errorf("expected a replaced name keeping its extension, got %q", record.AssetName)
+	}
+	if !strings.HasSuffix(record.AssetPath, "/"+record.AssetName) || strings.Contains(record.AssetPath, "asha") {
+		t.Errorf("expected every path element replaced, got %q", record.AssetPath)
+	}
+	want := "card " + record.Matches[0] + " found in " + record.AssetName + " on " + record.Host
+	if record.SampleText != want {
+		t.Errorf("expected the sample rewritten with the replacements, got %q", record.SampleText)
+	}
+
+	detector := newLeakDetector()
+	for _, value := range append(row.Matches, row.AssetName, row.AssetPath, row.Host, row.FindingID.String()) {
+		detector.add(value)
+	}
+	if leaks := detector.leaks(record.texts()...); leaks != nil {
+		t.Errorf("expected no original values in the record, got %d", len(leaks))
+	}
+}
+
+func TestLeakDetectorIgnoresFormatting(t *testing.T) {
+	detector := newLeakDetector()
+	detector.add("4111-1111-1111-1111")
+	detector.add("12345") // Too short to check
+
+	if detector.leaks("paid with 4111111111111111 today") == nil {
+		t.Errorf("expected a reformatted value to be found")
+	}
+	if detector.leaks("order 12345") != nil {
+		t.Errorf("expected short values to be ignored")
+	}
+	if detector.size() != 1 {
+		t.Errorf("expected one value checked, got %d", detector.size())
+	}
+}
+
+func TestReplacerPrefersLongerValues(t *testing.T) {
+	r := replacer([]string{"db", "x", "db-01.example.com", "host-a", "", "ignored"})
+	if got := r.Replace("connect to db-01.example.com via db"); got != "connect to host-a via x" {
+		t.Errorf("expected the longer value replaced whole, got %q", got)
+	}
+}
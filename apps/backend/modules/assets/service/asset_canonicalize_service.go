@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/pkg/canonical"
+	"github.com/google/uuid"
+)
+
+// CanonicalMerge is a group of assets whose paths canonicalize to one identity;
+// the oldest survives and the others are merged into it
+type CanonicalMerge struct {
+	StableID  string      `json:"stable_id"`
+	TargetID  uuid.UUID   `json:"target_id"`
+	SourceIDs []uuid.UUID `json:"source_ids"`
+	Paths     []string    `json:"paths"`
+}
+
+// CanonicalRekey is an asset whose stable ID changes without a duplicate
+type CanonicalRekey struct {
+	AssetID  uuid.UUID `json:"asset_id"`
+	Path     string    `json:"path"`
+	OldID    string    `json:"old_stable_id"`
+	StableID string    `json:"stable_id"`
+}
+
+// CanonicalizeResult reports what a canonicalization run did, or would do on a dry run
+type CanonicalizeResult struct {
+	DryRun   bool              `json:"dry_run"`
+	Scanned  int               `json:"scanned"`
+	Merges   []*CanonicalMerge `json:"merges"`
+	Rekeys   []*CanonicalRekey `json:"rekeys"`
+	Failures []string          `json:"failures,omitempty"`
+}
+
+// AssetCanonicalizeService brings assets created before path canonicalization
+// in line with it: duplicates are merged and the rest re-keyed
+type AssetCanonicalizeService struct {
+	repo     *persistence.PostgresRepository
+	merger   *AssetMergeService
+	policies canonical.Policies
+}
+
+// NewAssetCanonicalizeService creates a new asset canonicalization service
+func NewAssetCanonicalizeService(repo *persistence.PostgresRepository, merger *AssetMergeService, policies canonical.Policies) *AssetCanonicalizeService {
+	return &AssetCanonicalizeService{repo: repo, merger: merger, policies: policies}
+}
+
+// Canonicalize recomputes the stable ID of each of the tenant's live assets.
+// Assets sharing one are merged into the oldest, which then takes the new ID;
+// a failed merge or re-key is reported and the run goes on.
+func (s *AssetCanonicalizeService) Canonicalize(ctx context.Context, dryRun bool) (*CanonicalizeResult, error) {
+	var assets []*entity.Asset
+	if err := s.repo.IterateAssets(ctx, func(page []*entity.Asset) error {
+		assets = append(assets, page...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	merges, rekeys := planCanonicalization(assets, s.policies)
+	result := &CanonicalizeResult{DryRun: dryRun, Scanned: len(assets), Merges: merges, Rekeys: rekeys}
+	if dryRun {
+		return result, nil
+	}
+
+	for _, merge := range merges {
+		for _, sourceID := range merge.SourceIDs {
+			if _, err := s.merger.MergeAssets(ctx, sourceID, merge.TargetID, "canonicalize"); err != nil {
+				log.Printf("❌ Failed to merge asset %s into %s: %v", sourceID, merge.TargetID, err)
+				result.Failures = append(result.Failures, "merge "+sourceID.String()+": "+err.Error())
+			}
+		}
+	}
+	for _, rekey := range rekeys {
+		if err := s.repo.UpdateAssetStableID(ctx, rekey.AssetID, rekey.StableID); err != nil {
+			log.Printf("❌ Failed to re-key asset %s: %v", rekey.AssetID, err)
+			result.Failures = append(result.Failures, "rekey "+rekey.AssetID.String()+": "+err.Error())
+		}
+	}
+	return result, nil
+}
+
+// planCanonicalization groups assets by canonical stable ID. Groups of several
+// become merges into their oldest asset; every survivor whose ID changes is
+// re-keyed, after the merges so duplicates no longer hold the new ID.
+func planCanonicalization(assets []*entity.Asset, policies canonical.Policies) ([]*CanonicalMerge, []*CanonicalRekey) {
+	groups := make(map[string][]*entity.Asset)
+	var order []string
+	for _, asset := range assets {
+		id := canonical.StableID(asset.DataSource, asset.Host, asset.Path, policies)
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+		}
+		groups[id] = append(groups[id], asset)
+	}
+
+	merges := []*CanonicalMerge{}
+	rekeys := []*CanonicalRekey{}
+	for _, stableID := range order {
+		group := groups[stableID]
+		sort.Slice(group, func(i, j int) bool {
+			if !group[i].CreatedAt.Equal(group[j].CreatedAt) {
+				return group[i].CreatedAt.Before(group[j].CreatedAt)
+			}
+			return group[i].ID.String() < group[j].ID.String()
+		})
+
+		target := group[0]
+		if len(group) > 1 {
+			merge := &CanonicalMerge{StableID: stableID, TargetID: target.ID, Paths: []string{target.Path}}
+			for _, source := range group[1:] {
+				merge.SourceIDs = append(merge.SourceIDs, source.ID)
+				merge.Paths = append(merge.Paths, source.Path)
+			}
+			merges = append(merges, merge)
+		}
+		if target.StableID != stableID {
+			rekeys = append(rekeys, &CanonicalRekey{AssetID: target.ID, Path: target.Path, OldID: target.StableID, StableID: stableID})
+		}
+	}
+	return merges, rekeys
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/pkg/canonical"
+	"github.com/google/uuid"
+)
+
+func TestPlanCanonicalization(t *testing.T) {
+	policies := canonical.NewPolicies(nil, false)
+	now := time.Now()
+	asset := func(path string, age time.Duration) *entity.Asset {
+		a := &entity.Asset{ID: uuid.New(), DataSource: "fs", Path: path, CreatedAt: now.Add(-age)}
+		a.StableID = canonical.StableID(a.DataSource, a.Host, a.Path, policies)
+		return a
+	}
+
+	clean := asset("/data/users.csv", time.Hour)
+	doubled := asset("/data//users.csv", 2*time.Hour)
+	trailing := asset("/data/exports/", time.Hour)
+	untouched := asset("/data/orders.csv", time.Hour)
+	// Stored before canonicalization: the raw path was hashed
+	doubled.StableID, trailing.StableID = "legacy-1", "legacy-2"
+
+	merges, rekeys := planCanonicalization([]*entity.Asset{clean, doubled, trailing, untouched}, policies)
+
+	if len(merges) != 1 || merges[0].TargetID != doubled.ID || len(merges[0].SourceIDs) != 1 || merges[0].SourceIDs[0] != clean.ID {
+		t.Fatalf("expected the newer duplicate to merge into the oldest, got %+v", merges)
+	}
+	if len(rekeys) != 2 {
+		t.Fatalf("expected the surviving duplicate and the trailing slash path to be re-keyed, got %+v", rekeys)
+	}
+	for _, rekey := range rekeys {
+		switch rekey.AssetID {
+		case doubled.ID:
+			if rekey.StableID != clean.StableID {
+				t.Errorf("expected the survivor to take the canonical ID, got %s", rekey.StableID)
+			}
+		case trailing.ID:
+			if rekey.StableID != canonical.StableID("fs", "", "/data/exports", policies) {
+				t.Errorf("unexpected ID for %s: %s", trailing.Path, rekey.StableID)
+			}
+		default:
+			t.Errorf("unexpected re-key of %s", rekey.Path)
+		}
+	}
+}
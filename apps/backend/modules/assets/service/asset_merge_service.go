@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// AssetMergeService folds duplicate assets (case or path variants of the same
+// resource) into a single surviving asset
+type AssetMergeService struct {
+	repo        *persistence.PostgresRepository
+	lineageSync interfaces.LineageSync
+	auditLogger interfaces.AuditLogger
+}
+
+// NewAssetMergeService creates a new asset merge service
+func NewAssetMergeService(repo *persistence.PostgresRepository, lineageSync interfaces.LineageSync, auditLogger interfaces.AuditLogger) *AssetMergeService {
+	if lineageSync == nil {
+		lineageSync = &interfaces.NoOpLineageSync{}
+	}
+	return &AssetMergeService{
+		repo:        repo,
+		lineageSync: lineageSync,
+		auditLogger: auditLogger,
+	}
+}
+
+// MergeAssets moves everything attached to the source asset onto the target asset and
+// deletes the source. Postgres is the source of truth: once the merge is committed a
+// lineage failure is reported in the result rather than returned as an error.
+func (s *AssetMergeService) MergeAssets(ctx context.Context, sourceID, targetID uuid.UUID, mergedBy string) (*entity.AssetMergeResult, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("source and target asset must differ")
+	}
+
+	result, err := s.repo.MergeAssets(ctx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.lineageSync.MergeAssetNodes(ctx, sourceID, targetID); err != nil {
+		log.Printf("⚠️  Lineage update after merging asset %s into %s failed: %v", sourceID, targetID, err)
+		result.LineageError = err.Error()
+	} else {
+		result.LineageSynced = s.lineageSync.IsAvailable()
+	}
+
+	if s.auditLogger != nil {
+		// The full result is the undo record: the source asset snapshot plus every row moved or dropped
+		if err := s.auditLogger.Record(ctx, "ASSET_MERGED", "asset", targetID.String(), map[string]interface{}{
+			"merged_by":        mergedBy,
+			"source_asset_id":  sourceID.String(),
+			"moved_findings":   len(result.MovedFindingIDs),
+			"dropped_findings": len(result.DroppedFindings),
+			"undo":             result,
+		}); err != nil {
+			log.Printf("❌ Failed to record undo metadata for asset merge %s -> %s: %v", sourceID, targetID, err)
+		}
+	}
+
+	log.Printf("🔀 Merged asset %s into %s: %d findings moved, %d duplicates dropped, %d relationships moved",
+		sourceID, targetID, len(result.MovedFindingIDs), len(result.DroppedFindings), len(result.MovedRelationships))
+
+	return result, nil
+}
@@ -2,24 +2,30 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/scanperf"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/canonical"
 	"github.com/google/uuid"
 )
 
+// ErrAssetNotFound is returned for an asset ID with no live asset in the tenant
+var ErrAssetNotFound = persistence.ErrAssetNotFound
+
 // AssetService handles asset retrieval and management
 // This is the SINGLE SOURCE OF TRUTH for asset lifecycle
 type AssetService struct {
-	repo        *persistence.PostgresRepository
-	lineageSync interfaces.LineageSync
-	auditLogger interfaces.AuditLogger
+	repo         *persistence.PostgresRepository
+	lineageSync  interfaces.LineageSync
+	auditLogger  interfaces.AuditLogger
+	pathPolicies canonical.Policies
 }
 
 // NewAssetService creates a new asset service
@@ -28,12 +34,23 @@ func NewAssetService(repo *persistence.PostgresRepository, lineageSync interface
 		lineageSync = &interfaces.NoOpLineageSync{}
 	}
 	return &AssetService{
-		repo:        repo,
-		lineageSync: lineageSync,
-		auditLogger: auditLogger,
+		repo:         repo,
+		lineageSync:  lineageSync,
+		auditLogger:  auditLogger,
+		pathPolicies: canonical.NewPolicies(nil, false),
 	}
 }
 
+// SetPathPolicies sets how asset paths are canonicalized into stable IDs
+func (s *AssetService) SetPathPolicies(policies canonical.Policies) {
+	s.pathPolicies = policies
+}
+
+// PathPolicies returns how asset paths are canonicalized into stable IDs
+func (s *AssetService) PathPolicies() canonical.Policies {
+	return s.pathPolicies
+}
+
 // CreateOrUpdateAsset creates a new asset or updates existing one
 // This is the SINGLE SOURCE OF TRUTH for asset creation
 // Returns: assetID, isNew, error
@@ -63,6 +80,15 @@ func (s *AssetService) CreateOrUpdateAsset(ctx context.Context, asset *entity.As
 
 		log.Printf("📦 Asset already exists: %s (ID: %s)", asset.Name, assetID)
 
+		// A trashed asset that is scanned again comes back; its old findings stay in the trash
+		restored, err := s.repo.UntrashAsset(ctx, assetID)
+		if err != nil {
+			return uuid.Nil, false, err
+		}
+		if restored {
+			log.Printf("♻️  Restored trashed asset on rescan: %s (ID: %s)", asset.Name, assetID)
+		}
+
 		// Audit Log for Update (Implicit)
 		if s.auditLogger != nil {
 			_ = s.auditLogger.Record(ctx, "ASSET_ACCESSED", "asset", assetID.String(), map[string]interface{}{
@@ -97,7 +123,10 @@ func (s *AssetService) CreateOrUpdateAsset(ctx context.Context, asset *entity.As
 
 	// Trigger lineage sync (async, non-blocking)
 	if s.lineageSync.IsAvailable() {
+		// Counted in the profile of the scan run being ingested, if any
+		synced := scanperf.FromContext(ctx).TrackSync()
 		go func() {
+			defer synced()
 			// Use background context to avoid cancellation
 			if err := s.lineageSync.SyncAssetToNeo4j(context.Background(), assetID); err != nil {
 				// Log error but don't fail asset creation
@@ -111,22 +140,11 @@ func (s *AssetService) CreateOrUpdateAsset(ctx context.Context, asset *entity.As
 	return assetID, isNew, nil
 }
 
-// generateStableID creates a stable identifier from asset properties
+// generateStableID creates a stable identifier from the asset's canonical
+// identity, so variants such as "/data//users.csv" and "/data/users.csv" or a
+// database host with and without its default port are the same asset
 func (s *AssetService) generateStableID(asset *entity.Asset) string {
-	var identifier string
-
-	if asset.DataSource == "postgresql" || asset.DataSource == "mysql" {
-		// For databases: use data source + host + path (table name)
-		identifier = fmt.Sprintf("%s::%s::%s", asset.DataSource, asset.Host, asset.Path)
-	} else {
-		// For filesystem: use file path
-		identifier = asset.Path
-	}
-
-	// Normalize to lowercase to prevent duplicates on case-insensitive systems
-	normalizedPath := strings.ToLower(identifier)
-	hash := sha256.Sum256([]byte(normalizedPath))
-	return hex.EncodeToString(hash[:])
+	return canonical.StableID(asset.DataSource, asset.Host, asset.Path, s.pathPolicies)
 }
 
 // GetAsset retrieves an asset by ID with full context
@@ -138,6 +156,34 @@ func (s *AssetService) GetAsset(ctx context.Context, id uuid.UUID) (*entity.Asse
 	return asset, nil
 }
 
+// GetAssetAsOf retrieves an asset with its finding count and risk score as they
+// were at asOf. The risk score stays the current one when no change was recorded
+// before asOf; assets created after asOf are not found.
+func (s *AssetService) GetAssetAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (*entity.Asset, error) {
+	asset, err := s.GetAsset(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if asset.CreatedAt.After(asOf) {
+		return nil, fmt.Errorf("%w at %s", ErrAssetNotFound, asOf.Format(time.RFC3339))
+	}
+
+	total, err := s.repo.CountFindings(ctx, repository.FindingFilters{AssetID: &id, AsOf: &asOf})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count findings: %w", err)
+	}
+	asset.TotalFindings = total
+
+	points, err := s.repo.ListRiskScoreHistory(ctx, id, time.Time{}, asOf, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) > 0 {
+		asset.RiskScore = points[0].Score
+	}
+	return asset, nil
+}
+
 // GetAssetByStableID retrieves asset by stable identifier
 func (s *AssetService) GetAssetByStableID(ctx context.Context, stableID string) (*entity.Asset, error) {
 	return s.repo.GetAssetByStableID(ctx, stableID)
@@ -145,10 +191,49 @@ func (s *AssetService) GetAssetByStableID(ctx context.Context, stableID string)
 
 // UpdateAssetStats updates finding count and risk score
 func (s *AssetService) UpdateAssetStats(ctx context.Context, assetID uuid.UUID, riskScore, findingCount int) error {
-	return s.repo.UpdateAssetStats(ctx, assetID, riskScore, findingCount)
+	return s.repo.UpdateAssetStats(ctx, assetID, riskScore, findingCount, entity.RiskCauseRecalculation)
 }
 
 // ListAssets returns a list of assets
 func (s *AssetService) ListAssets(ctx context.Context, limit, offset int) ([]*entity.Asset, error) {
 	return s.repo.ListAssets(ctx, limit, offset)
 }
+
+// ListS3Buckets rolls up the S3 objects and findings of each bucket
+func (s *AssetService) ListS3Buckets(ctx context.Context) ([]entity.S3PrefixRollup, error) {
+	return s.repo.ListS3Buckets(ctx)
+}
+
+// ListS3Objects returns the objects in a bucket whose key starts with prefix
+func (s *AssetService) ListS3Objects(ctx context.Context, bucket, prefix string, limit, offset int) ([]*entity.Asset, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("bucket must be set")
+	}
+	return s.repo.ListS3Objects(ctx, bucket, prefix, true, limit, offset)
+}
+
+// BrowseS3Prefix returns one level of a bucket: the total under prefix, the
+// prefixes directly under it with their rollups, and the objects stored at it.
+// A prefix not ending in "/" is taken as a folder name and gets one.
+func (s *AssetService) BrowseS3Prefix(ctx context.Context, bucket, prefix string, limit, offset int) (*entity.S3PrefixListing, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("bucket must be set")
+	}
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	total, err := s.repo.GetS3PrefixRollup(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	prefixes, err := s.repo.ListS3ChildPrefixes(ctx, bucket, prefix)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.repo.ListS3Objects(ctx, bucket, prefix, false, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.S3PrefixListing{Bucket: bucket, Prefix: prefix, Total: *total, Prefixes: prefixes, Objects: objects}, nil
+}
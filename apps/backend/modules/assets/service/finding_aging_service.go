@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultStaleScanWindow = 3
+	staleDetectionBatch    = 500
+)
+
+// defaultAgingBuckets are the age ranges reported for open findings
+var defaultAgingBuckets = []entity.FindingAgingBucket{
+	{Label: "0-7 days", MinDays: 0, MaxDays: 7},
+	{Label: "8-30 days", MinDays: 7, MaxDays: 30},
+	{Label: "31-90 days", MinDays: 30, MaxDays: 90},
+	{Label: "90+ days", MinDays: 90},
+}
+
+// defaultExposureBuckets are the ranges reported for how long exposures have been observed
+var defaultExposureBuckets = []entity.FindingAgingBucket{
+	{Label: "0-7 days", MinDays: 0, MaxDays: 7},
+	{Label: "8-30 days", MinDays: 7, MaxDays: 30},
+	{Label: "31-90 days", MinDays: 30, MaxDays: 90},
+	{Label: "91-180 days", MinDays: 90, MaxDays: 180},
+	{Label: "180+ days", MinDays: 180},
+}
+
+// FindingAgingService detects findings that are no longer observed by recent scans
+type FindingAgingService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+}
+
+// NewFindingAgingService creates a new finding aging service
+func NewFindingAgingService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *FindingAgingService {
+	return &FindingAgingService{
+		repo:        repo,
+		auditLogger: auditLogger,
+	}
+}
+
+// StaleDetectionOptions controls a stale detection run
+type StaleDetectionOptions struct {
+	ScanWindow int    `json:"scan_window"`
+	Action     string `json:"action"`  // auto_close or queue
+	DryRun     bool   `json:"dry_run"` // Report candidates without flagging them
+}
+
+// StaleDetectionResult summarizes a stale detection run
+type StaleDetectionResult struct {
+	ScanWindow int                    `json:"scan_window"`
+	Action     string                 `json:"action"`
+	DryRun     bool                   `json:"dry_run"`
+	Candidates int                    `json:"candidates"`
+	Flagged    int                    `json:"flagged"`
+	Findings   []*entity.StaleFinding `json:"findings"`
+}
+
+// FindingAgingReport summarizes open finding age and stale exposure
+type FindingAgingReport struct {
+	Buckets     []entity.FindingAgingBucket `json:"buckets"`
+	OpenTotal   int                         `json:"open_total"`
+	StaleTotal  int                         `json:"stale_total"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+}
+
+// ExposureAgeReport buckets exposures by how long they have been observed
+type ExposureAgeReport struct {
+	PatternName string                      `json:"pattern_name,omitempty"`
+	Buckets     []entity.FindingAgingBucket `json:"buckets"`
+	Total       int                         `json:"total"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+}
+
+// DetectStaleFindings flags findings not re-observed in the last N scans of the same asset
+func (s *FindingAgingService) DetectStaleFindings(ctx context.Context, opts StaleDetectionOptions) (*StaleDetectionResult, error) {
+	if opts.ScanWindow < 1 {
+		opts.ScanWindow = defaultStaleScanWindow
+	}
+	if opts.Action == "" {
+		opts.Action = entity.StaleActionQueue
+	}
+	if opts.Action != entity.StaleActionAutoClose && opts.Action != entity.StaleActionQueue {
+		return nil, fmt.Errorf("invalid stale action: %s (must be %s or %s)", opts.Action, entity.StaleActionAutoClose, entity.StaleActionQueue)
+	}
+
+	candidates, err := s.repo.ListStaleFindingCandidates(ctx, opts.ScanWindow, staleDetectionBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale candidates: %w", err)
+	}
+
+	result := &StaleDetectionResult{
+		ScanWindow: opts.ScanWindow,
+		Action:     opts.Action,
+		DryRun:     opts.DryRun,
+		Candidates: len(candidates),
+		Findings:   candidates,
+	}
+
+	if opts.DryRun || len(candidates) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uuid.UUID, len(candidates))
+	for i, c := range candidates {
+		ids[i] = c.FindingID
+	}
+
+	flagged, err := s.repo.MarkFindingsStale(ctx, ids, opts.ScanWindow)
+	if err != nil {
+		return nil, err
+	}
+	result.Flagged = flagged
+
+	reviewStatus := entity.ReviewStatusResolvedCandidate
+	if opts.Action == entity.StaleActionAutoClose {
+		reviewStatus = entity.ReviewStatusResolved
+	}
+
+	comment := fmt.Sprintf("Not re-observed in the last %d scans of the asset", opts.ScanWindow)
+	for _, c := range candidates {
+		if err := s.setReviewStatus(ctx, c.FindingID, reviewStatus, comment); err != nil {
+			log.Printf("⚠️  Failed to update review state for stale finding %s: %v", c.FindingID, err)
+			continue
+		}
+		c.ReviewStatus = reviewStatus
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "FINDINGS_MARKED_STALE", "finding", "", map[string]interface{}{
+			"scan_window": opts.ScanWindow,
+			"action":      opts.Action,
+			"flagged":     flagged,
+		})
+	}
+
+	return result, nil
+}
+
+// setReviewStatus updates or creates the review state for a finding. A reviewer
+// saving the same finding meanwhile wins; the update is retried on their version.
+func (s *FindingAgingService) setReviewStatus(ctx context.Context, findingID uuid.UUID, status, comment string) error {
+	for attempt := 1; ; attempt++ {
+		err := s.saveReviewStatus(ctx, findingID, status, comment)
+		if !errors.Is(err, persistence.ErrReviewStateConflict) || attempt == 3 {
+			return err
+		}
+	}
+}
+
+func (s *FindingAgingService) saveReviewStatus(ctx context.Context, findingID uuid.UUID, status, comment string) error {
+	now := time.Now()
+
+	existing, err := s.repo.GetReviewStateByFindingID(ctx, findingID)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		existing.Status = status
+		existing.ReviewedBy = "system"
+		existing.ReviewedAt = &now
+		existing.Comments = comment
+		return s.repo.UpdateReviewState(ctx, existing)
+	}
+
+	return s.repo.CreateFirstReviewState(ctx, &entity.ReviewState{
+		ID:         uuid.New(),
+		FindingID:  findingID,
+		Status:     status,
+		ReviewedBy: "system",
+		ReviewedAt: &now,
+		Comments:   comment,
+	})
+}
+
+// ListStaleFindings returns flagged findings, optionally filtered by review status
+func (s *FindingAgingService) ListStaleFindings(ctx context.Context, reviewStatus string, limit, offset int) ([]*entity.StaleFinding, error) {
+	if limit < 1 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListStaleFindings(ctx, reviewStatus, limit, offset)
+}
+
+// GetAgingReport returns open findings bucketed by age plus the stale total
+func (s *FindingAgingService) GetAgingReport(ctx context.Context) (*FindingAgingReport, error) {
+	buckets, err := s.repo.CountFindingsByAge(ctx, defaultAgingBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	staleTotal, err := s.repo.CountStaleFindings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count stale findings: %w", err)
+	}
+
+	return &FindingAgingReport{
+		Buckets:     buckets,
+		OpenTotal:   sumBuckets(buckets),
+		StaleTotal:  staleTotal,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// GetFindingExposure returns every scan run that observed the exposure behind a finding,
+// including scans whose finding rows have since been archived
+func (s *FindingAgingService) GetFindingExposure(ctx context.Context, findingID uuid.UUID) (*entity.FindingExposure, error) {
+	return s.repo.GetFindingExposure(ctx, findingID)
+}
+
+// GetExposureAgeReport buckets exposures by days between first and last observation,
+// optionally for a single pattern
+func (s *FindingAgingService) GetExposureAgeReport(ctx context.Context, patternName string) (*ExposureAgeReport, error) {
+	buckets, err := s.repo.CountExposuresByAge(ctx, patternName, defaultExposureBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExposureAgeReport{
+		PatternName: patternName,
+		Buckets:     buckets,
+		Total:       sumBuckets(buckets),
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// StartStaleDetectionWorker periodically runs stale detection for every tenant
+func (s *FindingAgingService) StartStaleDetectionWorker(ctx context.Context, intervalMinutes int, opts StaleDetectionOptions) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🕰️  Starting stale finding detection worker (interval: %d minutes, window: %d scans, action: %s)",
+		intervalMinutes, opts.ScanWindow, opts.Action)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Stale finding detection worker stopped")
+			return
+		case <-ticker.C:
+			s.detectForAllTenants(ctx, opts)
+		}
+	}
+}
+
+// detectForAllTenants runs stale detection once per tenant that owns findings
+func (s *FindingAgingService) detectForAllTenants(ctx context.Context, opts StaleDetectionOptions) {
+	tenantIDs, err := s.repo.ListFindingTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for stale detection: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		result, err := s.DetectStaleFindings(tenantCtx, opts)
+		if err != nil {
+			log.Printf("❌ Stale detection failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if result.Flagged > 0 {
+			log.Printf("✅ Flagged %d stale finding(s) for tenant %s", result.Flagged, tenantID)
+		}
+	}
+}
+
+// sumBuckets totals the counts of a bucketed report
+func sumBuckets(buckets []entity.FindingAgingBucket) int {
+	total := 0
+	for _, b := range buckets {
+		total += b.Count
+	}
+	return total
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+var staleCandidateColumns = []string{"id", "asset_id", "asset_name", "scan_run_id", "pattern_name", "severity", "created_at"}
+
+func newFindingAgingTest(t *testing.T) (*FindingAgingService, sqlmock.Sqlmock, context.Context, uuid.UUID) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	return NewFindingAgingService(persistence.NewPostgresRepository(db), nil), mock, ctx, tenantID
+}
+
+func TestDetectStaleFindingsScanWindow(t *testing.T) {
+	cases := []struct {
+		name       string
+		scanWindow int
+		want       int
+	}{
+		{name: "defaults to the last three scans", scanWindow: 0, want: defaultStaleScanWindow},
+		{name: "uses the requested window", scanWindow: 5, want: 5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, mock, ctx, tenantID := newFindingAgingTest(t)
+			// Findings ranked beyond the window with no newer matching observation are candidates
+			mock.ExpectQuery(`s\.scan_rank > \$2\s+AND NOT EXISTS`).
+				WithArgs(tenantID, tc.want, staleDetectionBatch).
+				WillReturnRows(sqlmock.NewRows(staleCandidateColumns))
+
+			result, err := svc.DetectStaleFindings(ctx, StaleDetectionOptions{ScanWindow: tc.scanWindow})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.ScanWindow != tc.want || result.Action != entity.StaleActionQueue || result.Flagged != 0 {
+				t.Errorf("unexpected result %+v", result)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDetectStaleFindingsRejectsUnknownAction(t *testing.T) {
+	svc, mock, ctx, _ := newFindingAgingTest(t)
+	if _, err := svc.DetectStaleFindings(ctx, StaleDetectionOptions{Action: "delete"}); err == nil {
+		t.Error("expected an unknown action to be rejected")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDetectStaleFindingsDryRunFlagsNothing(t *testing.T) {
+	svc, mock, ctx, tenantID := newFindingAgingTest(t)
+	mock.ExpectQuery(`FROM findings f`).WithArgs(tenantID, defaultStaleScanWindow, staleDetectionBatch).
+		WillReturnRows(sqlmock.NewRows(staleCandidateColumns).
+			AddRow(uuid.New(), uuid.New(), "orders.csv", uuid.New(), "EMAIL", "High", time.Now()))
+
+	result, err := svc.DetectStaleFindings(ctx, StaleDetectionOptions{Action: entity.StaleActionAutoClose, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Candidates != 1 || result.Flagged != 0 || result.Findings[0].ReviewStatus != "pending" {
+		t.Errorf("expected one unflagged candidate, got %+v", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDetectStaleFindingsReviewStatus(t *testing.T) {
+	cases := []struct {
+		action string
+		want   string
+	}{
+		{action: entity.StaleActionAutoClose, want: entity.ReviewStatusResolved},
+		{action: entity.StaleActionQueue, want: entity.ReviewStatusResolvedCandidate},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.action, func(t *testing.T) {
+			svc, mock, ctx, tenantID := newFindingAgingTest(t)
+			unreviewed, reviewed := uuid.New(), uuid.New()
+			reviewID := uuid.New()
+
+			mock.ExpectQuery(`FROM findings f`).WithArgs(tenantID, defaultStaleScanWindow, staleDetectionBatch).
+				WillReturnRows(sqlmock.NewRows(staleCandidateColumns).
+					AddRow(unreviewed, uuid.New(), "orders.csv", uuid.New(), "EMAIL", "High", time.Now()).
+					AddRow(reviewed, uuid.New(), "users.csv", uuid.New(), "PAN", "Critical", time.Now()))
+			mock.ExpectExec(`UPDATE findings\s+SET stale_at = NOW\(\)`).
+				WithArgs(defaultStaleScanWindow, sqlmock.AnyArg(), tenantID).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			// A finding without a review gets its first one
+			mock.ExpectQuery(`FROM review_states`).WithArgs(unreviewed).WillReturnError(sql.ErrNoRows)
+			mock.ExpectQuery(`INSERT INTO review_states`).
+				WithArgs(sqlmock.AnyArg(), unreviewed, tc.want, "system", sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"version", "created_at", "updated_at"}).AddRow(1, time.Now(), time.Now()))
+
+			// A reviewed finding has its review moved on
+			mock.ExpectQuery(`FROM review_states`).WithArgs(reviewed).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "finding_id", "status", "reviewed_by", "reviewed_at", "comments", "version", "propagated_from", "created_at", "updated_at"}).
+					AddRow(reviewID, reviewed, "confirmed", "analyst", time.Now(), "", 2, nil, time.Now(), time.Now()))
+			mock.ExpectQuery(`UPDATE review_states`).
+				WithArgs(tc.want, "system", sqlmock.AnyArg(), sqlmock.AnyArg(), reviewID, 2).
+				WillReturnRows(sqlmock.NewRows([]string{"version", "updated_at"}).AddRow(3, time.Now()))
+
+			result, err := svc.DetectStaleFindings(ctx, StaleDetectionOptions{Action: tc.action})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Flagged != 2 {
+				t.Errorf("expected 2 flagged findings, got %d", result.Flagged)
+			}
+			for _, f := range result.Findings {
+				if f.ReviewStatus != tc.want {
+					t.Errorf("expected finding %s to be %s, got %s", f.FindingID, tc.want, f.ReviewStatus)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/archive"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultArchiveOlderThanDays = 365
+	defaultArchiveRunLimit      = 20
+	maxArchiveRunLimit          = 200
+)
+
+// Table names recorded on each archived row
+const (
+	archiveTableFindings        = "findings"
+	archiveTableClassifications = "classifications"
+	archiveTableReviewStates    = "review_states"
+)
+
+// ErrNoArchivedFindings is returned when restoring a scan run without outstanding archives
+var ErrNoArchivedFindings = errors.New("no archived findings")
+
+// FindingArchiveService moves findings of old scan runs to object storage and back
+type FindingArchiveService struct {
+	repo        *persistence.PostgresRepository
+	store       archive.ObjectStore
+	prefix      string
+	defaults    ArchiveOptions
+	auditLogger interfaces.AuditLogger
+}
+
+// NewFindingArchiveService creates a new finding archive service
+func NewFindingArchiveService(repo *persistence.PostgresRepository, store archive.ObjectStore, prefix string, defaults ArchiveOptions, auditLogger interfaces.AuditLogger) *FindingArchiveService {
+	if defaults.OlderThanDays < 1 {
+		defaults.OlderThanDays = defaultArchiveOlderThanDays
+	}
+	return &FindingArchiveService{
+		repo:        repo,
+		store:       store,
+		prefix:      prefix,
+		defaults:    defaults,
+		auditLogger: auditLogger,
+	}
+}
+
+// ArchiveOptions controls an archive run
+type ArchiveOptions struct {
+	OlderThanDays int  `json:"older_than_days"` // Minimum scan run age
+	ClosedOnly    bool `json:"closed_only"`     // Only archive resolved or false-positive findings
+	Limit         int  `json:"limit"`           // Maximum scan runs archived in one run
+	DryRun        bool `json:"dry_run"`         // Report eligible scan runs without archiving
+}
+
+// ArchiveResult summarizes an archive run
+type ArchiveResult struct {
+	OlderThanDays    int                         `json:"older_than_days"`
+	ClosedOnly       bool                        `json:"closed_only"`
+	DryRun           bool                        `json:"dry_run"`
+	Eligible         []*entity.ArchivableScanRun `json:"eligible"`
+	Archives         []*entity.FindingArchive    `json:"archives"`
+	ArchivedFindings int                         `json:"archived_findings"`
+	Errors           []string                    `json:"errors,omitempty"`
+}
+
+// archiveRecord is one line of an archive object
+type archiveRecord struct {
+	Table string          `json:"table"`
+	Row   json.RawMessage `json:"row"`
+}
+
+// DefaultOptions returns the configured archive options
+func (s *FindingArchiveService) DefaultOptions() ArchiveOptions {
+	return s.defaults
+}
+
+// ArchiveFindings archives eligible findings of the oldest scan runs, one archive per scan run
+func (s *FindingArchiveService) ArchiveFindings(ctx context.Context, opts ArchiveOptions, archivedBy string) (*ArchiveResult, error) {
+	if opts.OlderThanDays < 1 {
+		opts.OlderThanDays = s.defaults.OlderThanDays
+	}
+	if opts.Limit < 1 || opts.Limit > maxArchiveRunLimit {
+		opts.Limit = defaultArchiveRunLimit
+	}
+
+	runs, err := s.repo.ListArchivableScanRuns(ctx, opts.OlderThanDays, opts.ClosedOnly, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ArchiveResult{
+		OlderThanDays: opts.OlderThanDays,
+		ClosedOnly:    opts.ClosedOnly,
+		DryRun:        opts.DryRun,
+		Eligible:      runs,
+		Archives:      []*entity.FindingArchive{},
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	for _, run := range runs {
+		archived, err := s.ArchiveScanRun(ctx, run.ScanRunID, opts, archivedBy)
+		if err != nil {
+			log.Printf("❌ Failed to archive scan run %s: %v", run.ScanRunID, err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", run.ScanRunID, err))
+			continue
+		}
+		if archived == nil {
+			continue
+		}
+		result.Archives = append(result.Archives, archived)
+		result.ArchivedFindings += archived.FindingCount
+	}
+
+	return result, nil
+}
+
+// ArchiveScanRun exports a scan run's eligible findings to object storage with a manifest and
+// deletes them from Postgres. Returns nil when the scan run has nothing to archive.
+func (s *FindingArchiveService) ArchiveScanRun(ctx context.Context, scanRunID uuid.UUID, opts ArchiveOptions, archivedBy string) (*entity.FindingArchive, error) {
+	if opts.OlderThanDays < 1 {
+		opts.OlderThanDays = s.defaults.OlderThanDays
+	}
+
+	rows, err := s.repo.LoadScanRunArchiveRows(ctx, scanRunID, opts.OlderThanDays, opts.ClosedOnly)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows.Findings) == 0 {
+		return nil, nil
+	}
+
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := encodeArchive(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode archive: %w", err)
+	}
+	checksum := sha256.Sum256(body)
+
+	archiveID := uuid.New()
+	baseKey := path.Join(s.prefix, tenantID.String(), scanRunID.String(), archiveID.String())
+
+	record := &entity.FindingArchive{
+		ID:                  archiveID,
+		TenantID:            tenantID,
+		ScanRunID:           scanRunID,
+		StorageProvider:     s.store.Provider(),
+		Bucket:              s.store.Bucket(),
+		ObjectKey:           baseKey + "." + entity.ArchiveFormatJSONLGzip,
+		ManifestKey:         baseKey + ".manifest.json",
+		Format:              entity.ArchiveFormatJSONLGzip,
+		FindingCount:        len(rows.Findings),
+		ClassificationCount: len(rows.Classifications),
+		ReviewStateCount:    len(rows.ReviewStates),
+		SizeBytes:           int64(len(body)),
+		ChecksumSHA256:      hex.EncodeToString(checksum[:]),
+		Status:              entity.ArchiveStatusArchived,
+		ArchivedBy:          archivedBy,
+	}
+
+	manifest, err := json.MarshalIndent(entity.ArchiveManifest{
+		ArchiveID:           record.ID,
+		TenantID:            record.TenantID,
+		ScanRunID:           record.ScanRunID,
+		ScanRun:             rows.ScanRun,
+		ObjectKey:           record.ObjectKey,
+		Format:              record.Format,
+		FindingCount:        record.FindingCount,
+		ClassificationCount: record.ClassificationCount,
+		ReviewStateCount:    record.ReviewStateCount,
+		RelatedCounts:       relatedCounts(rows),
+		SizeBytes:           record.SizeBytes,
+		ChecksumSHA256:      record.ChecksumSHA256,
+		ArchivedAt:          time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	// Upload before deleting so Postgres rows are only removed once the archive is durable
+	if err := s.store.Put(ctx, record.ObjectKey, body, "application/gzip"); err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(ctx, record.ManifestKey, manifest, "application/json"); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CompleteFindingArchive(ctx, record, rows.FindingIDs); err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "FINDINGS_ARCHIVED", "scan_run", scanRunID.String(), map[string]interface{}{
+			"archive_id":    record.ID.String(),
+			"object_key":    record.ObjectKey,
+			"finding_count": record.FindingCount,
+		})
+	}
+
+	return record, nil
+}
+
+// RestoreScanRun rehydrates every outstanding archive of a scan run
+func (s *FindingArchiveService) RestoreScanRun(ctx context.Context, scanRunID uuid.UUID) ([]*entity.FindingArchive, error) {
+	archives, err := s.repo.ListFindingArchives(ctx, &scanRunID, entity.ArchiveStatusArchived, maxArchiveRunLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(archives) == 0 {
+		return nil, fmt.Errorf("%w found for scan run %s", ErrNoArchivedFindings, scanRunID)
+	}
+
+	restored := make([]*entity.FindingArchive, 0, len(archives))
+	for _, a := range archives {
+		record, err := s.RestoreArchive(ctx, a.ID)
+		if err != nil {
+			return restored, fmt.Errorf("failed to restore archive %s: %w", a.ID, err)
+		}
+		restored = append(restored, record)
+	}
+
+	return restored, nil
+}
+
+// RestoreArchive downloads an archive, verifies its checksum and reinserts its rows
+func (s *FindingArchiveService) RestoreArchive(ctx context.Context, archiveID uuid.UUID) (*entity.FindingArchive, error) {
+	record, err := s.repo.GetFindingArchive(ctx, archiveID)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status != entity.ArchiveStatusArchived {
+		return nil, fmt.Errorf("finding archive %s is already %s", archiveID, record.Status)
+	}
+	if record.StorageProvider != s.store.Provider() || record.Bucket != s.store.Bucket() {
+		return nil, fmt.Errorf("finding archive %s is stored in %s://%s, which is not the configured archive store",
+			archiveID, record.StorageProvider, record.Bucket)
+	}
+
+	body, err := s.store.Get(ctx, record.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(body)
+	if hex.EncodeToString(checksum[:]) != record.ChecksumSHA256 {
+		return nil, fmt.Errorf("checksum mismatch for %s", record.ObjectKey)
+	}
+
+	rows, err := decodeArchive(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode archive: %w", err)
+	}
+	if len(rows.Findings) != record.FindingCount {
+		return nil, fmt.Errorf("archive holds %d findings, manifest expects %d", len(rows.Findings), record.FindingCount)
+	}
+
+	if err := s.repo.RestoreFindingArchive(ctx, record.ID, rows); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record.Status = entity.ArchiveStatusRestored
+	record.RestoredAt = &now
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "FINDINGS_RESTORED", "scan_run", record.ScanRunID.String(), map[string]interface{}{
+			"archive_id":    record.ID.String(),
+			"finding_count": record.FindingCount,
+		})
+	}
+
+	return record, nil
+}
+
+// ListArchives lists finding archives, optionally filtered by scan run and status
+func (s *FindingArchiveService) ListArchives(ctx context.Context, scanRunID *uuid.UUID, status string, limit, offset int) ([]*entity.FindingArchive, error) {
+	if limit < 1 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListFindingArchives(ctx, scanRunID, status, limit, offset)
+}
+
+// StartArchiveWorker periodically archives old findings for every tenant
+func (s *FindingArchiveService) StartArchiveWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 1440
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🗄️  Starting finding archive worker (interval: %d minutes, older than: %d days, closed only: %v, store: %s://%s)",
+		intervalMinutes, s.defaults.OlderThanDays, s.defaults.ClosedOnly, s.store.Provider(), s.store.Bucket())
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Finding archive worker stopped")
+			return
+		case <-ticker.C:
+			s.archiveForAllTenants(ctx)
+		}
+	}
+}
+
+// archiveForAllTenants runs the archiver once per tenant that owns findings
+func (s *FindingArchiveService) archiveForAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListFindingTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for finding archiving: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		result, err := s.ArchiveFindings(tenantCtx, s.defaults, "system")
+		if err != nil {
+			log.Printf("❌ Finding archiving failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if result.ArchivedFindings > 0 {
+			log.Printf("✅ Archived %d finding(s) from %d scan run(s) for tenant %s",
+				result.ArchivedFindings, len(result.Archives), tenantID)
+		}
+	}
+}
+
+// encodeArchive writes archived rows as gzip-compressed JSON Lines
+func encodeArchive(rows *entity.ArchivedScanRunRows) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+
+	type tableRows struct {
+		name string
+		rows []json.RawMessage
+	}
+	tables := []tableRows{
+		{archiveTableFindings, rows.Findings},
+		{archiveTableClassifications, rows.Classifications},
+		{archiveTableReviewStates, rows.ReviewStates},
+	}
+	for _, name := range persistence.ArchivedFindingTables {
+		tables = append(tables, tableRows{name, rows.Related[name]})
+	}
+
+	for _, table := range tables {
+		for _, row := range table.rows {
+			if err := enc.Encode(archiveRecord{Table: table.name, Row: row}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeArchive reads gzip-compressed JSON Lines back into archived rows
+func decodeArchive(body []byte) (*entity.ArchivedScanRunRows, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	rows := &entity.ArchivedScanRunRows{}
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var record archiveRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, err
+		}
+
+		switch record.Table {
+		case archiveTableFindings:
+			rows.Findings = append(rows.Findings, record.Row)
+		case archiveTableClassifications:
+			rows.Classifications = append(rows.Classifications, record.Row)
+		case archiveTableReviewStates:
+			rows.ReviewStates = append(rows.ReviewStates, record.Row)
+		default:
+			if !isArchivedFindingTable(record.Table) {
+				return nil, fmt.Errorf("unknown archive table: %s", record.Table)
+			}
+			if rows.Related == nil {
+				rows.Related = make(map[string][]json.RawMessage)
+			}
+			rows.Related[record.Table] = append(rows.Related[record.Table], record.Row)
+		}
+	}
+
+	return rows, scanner.Err()
+}
+
+// isArchivedFindingTable reports whether archives carry rows of the table
+func isArchivedFindingTable(table string) bool {
+	for _, name := range persistence.ArchivedFindingTables {
+		if name == table {
+			return true
+		}
+	}
+	return false
+}
+
+// relatedCounts counts the archived rows of each table referencing the findings
+func relatedCounts(rows *entity.ArchivedScanRunRows) map[string]int {
+	if len(rows.Related) == 0 {
+		return nil
+	}
+	counts := make(map[string]int, len(rows.Related))
+	for table, related := range rows.Related {
+		counts[table] = len(related)
+	}
+	return counts
+}
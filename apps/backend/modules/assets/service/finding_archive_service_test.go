@@ -0,0 +1,90 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestArchiveEncodeDecodeRoundTrip(t *testing.T) {
+	rows := &entity.ArchivedScanRunRows{
+		Findings: []json.RawMessage{
+			json.RawMessage(`{"id":"f1","matches":["a@b.com"],"severity":"HIGH"}`),
+			json.RawMessage(`{"id":"f2","matches":null,"severity":"LOW"}`),
+		},
+		Classifications: []json.RawMessage{json.RawMessage(`{"id":"c1","finding_id":"f1"}`)},
+		ReviewStates:    []json.RawMessage{json.RawMessage(`{"id":"r1","finding_id":"f1","status":"resolved"}`)},
+	}
+
+	body, err := encodeArchive(rows)
+	if err != nil {
+		t.Fatalf("encodeArchive: %v", err)
+	}
+
+	decoded, err := decodeArchive(body)
+	if err != nil {
+		t.Fatalf("decodeArchive: %v", err)
+	}
+
+	if len(decoded.Findings) != 2 || len(decoded.Classifications) != 1 || len(decoded.ReviewStates) != 1 {
+		t.Fatalf("unexpected row counts: %d findings, %d classifications, %d review states",
+			len(decoded.Findings), len(decoded.Classifications), len(decoded.ReviewStates))
+	}
+	if string(decoded.Findings[0]) != string(rows.Findings[0]) {
+		t.Errorf("finding row changed: got %s, want %s", decoded.Findings[0], rows.Findings[0])
+	}
+	if string(decoded.ReviewStates[0]) != string(rows.ReviewStates[0]) {
+		t.Errorf("review state row changed: got %s, want %s", decoded.ReviewStates[0], rows.ReviewStates[0])
+	}
+}
+
+func TestArchiveKeepsRowsReferencingFindings(t *testing.T) {
+	rows := &entity.ArchivedScanRunRows{
+		Findings: []json.RawMessage{json.RawMessage(`{"id":"f1"}`)},
+		Related: map[string][]json.RawMessage{
+			"finding_comments": {json.RawMessage(`{"id":"m1","finding_id":"f1"}`), json.RawMessage(`{"id":"m2","finding_id":"f1"}`)},
+			"ticket_links":     {json.RawMessage(`{"id":"t1","finding_id":"f1"}`)},
+		},
+	}
+
+	body, err := encodeArchive(rows)
+	if err != nil {
+		t.Fatalf("encodeArchive: %v", err)
+	}
+	decoded, err := decodeArchive(body)
+	if err != nil {
+		t.Fatalf("decodeArchive: %v", err)
+	}
+	if len(decoded.Related["finding_comments"]) != 2 || len(decoded.Related["ticket_links"]) != 1 {
+		t.Errorf("related rows lost in the round trip: %v", decoded.Related)
+	}
+	if counts := relatedCounts(decoded); counts["finding_comments"] != 2 || counts["ticket_links"] != 1 {
+		t.Errorf("unexpected related counts %v", counts)
+	}
+}
+
+func TestDecodeArchiveRejectsUnknownTable(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write([]byte(`{"table":"users","row":{"id":"u1"}}` + "\n"))
+	gz.Close()
+
+	if _, err := decodeArchive(buf.Bytes()); err == nil {
+		t.Error("expected rows of a table archives do not carry to be rejected")
+	}
+}
+
+func TestDecodeArchiveRejectsTruncatedBody(t *testing.T) {
+	rows := &entity.ArchivedScanRunRows{Findings: []json.RawMessage{json.RawMessage(`{"id":"f1"}`)}}
+	body, err := encodeArchive(rows)
+	if err != nil {
+		t.Fatalf("encodeArchive: %v", err)
+	}
+
+	if _, err := decodeArchive(body[:len(body)-4]); err == nil {
+		t.Error("expected truncated archive to fail decoding")
+	}
+}
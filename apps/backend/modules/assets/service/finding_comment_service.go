@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentLength   = 10000
+	maxCommentMentions = 20
+)
+
+// ErrCommentForbidden is returned when someone other than the author changes a comment
+var ErrCommentForbidden = errors.New("only the author may change this comment")
+
+// mentionPattern matches "@" followed by an email address, e.g. "@asha.rao@example.in"
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.+-])@([A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// CommentAuthor is the user writing, editing or deleting a comment
+type CommentAuthor struct {
+	ID      *uuid.UUID // Nil for API key callers
+	Email   string
+	IsAdmin bool // Admins may delete anyone's comment
+}
+
+// NewComment is a comment submitted on a finding
+type NewComment struct {
+	Body     string     `json:"body" binding:"required"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"` // Reply to this comment's thread
+}
+
+// FindingCommentService keeps reviewer discussions on findings and tells mentioned users
+type FindingCommentService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+	notifier    *MentionNotifier // nil when notifications are not wired
+}
+
+// NewFindingCommentService creates a new finding comment service
+func NewFindingCommentService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *FindingCommentService {
+	return &FindingCommentService{repo: repo, auditLogger: auditLogger}
+}
+
+// SetMentionNotifier enables notifications for users mentioned in comments
+func (s *FindingCommentService) SetMentionNotifier(notifier *MentionNotifier) {
+	s.notifier = notifier
+}
+
+// List returns a finding's comments as threads, oldest first
+func (s *FindingCommentService) List(ctx context.Context, findingID uuid.UUID) ([]*entity.FindingComment, error) {
+	if _, err := s.repo.GetFindingByID(ctx, findingID); err != nil {
+		return nil, err
+	}
+	comments, err := s.repo.ListFindingComments(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCommentThreads(comments), nil
+}
+
+// Add comments on a finding, or replies in a thread, and notifies the users it mentions
+func (s *FindingCommentService) Add(ctx context.Context, findingID uuid.UUID, input NewComment, author CommentAuthor) (*entity.FindingComment, error) {
+	body, err := validateCommentBody(input.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the finding first so comments are only added within the tenant
+	finding, err := s.repo.GetFindingByID(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entity.FindingComment{
+		ID:        uuid.New(),
+		FindingID: finding.ID,
+		AuthorID:  author.ID,
+		Author:    author.Email,
+		Body:      body,
+	}
+	if input.ParentID != nil {
+		parent, err := s.repo.GetFindingComment(ctx, finding.ID, *input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		// Threads are one level deep; a reply to a reply joins the same thread
+		comment.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		}
+	}
+
+	mentioned, err := s.resolveMentions(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentionEmails(mentioned)
+
+	if err := s.repo.CreateFindingComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, "FINDING_COMMENT_ADDED", comment, author)
+	s.notify(ctx, finding, comment, mentioned)
+	return comment, nil
+}
+
+// Edit replaces the body of the author's own comment. Only users mentioned for the
+// first time are notified.
+func (s *FindingCommentService) Edit(ctx context.Context, findingID, commentID uuid.UUID, body string, author CommentAuthor) (*entity.FindingComment, error) {
+	body, err := validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	finding, err := s.repo.GetFindingByID(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := s.repo.GetFindingComment(ctx, finding.ID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted() {
+		return nil, fmt.Errorf("finding comment not found")
+	}
+	if !isCommentAuthor(comment, author) {
+		return nil, ErrCommentForbidden
+	}
+
+	mentioned, err := s.resolveMentions(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[string]bool, len(comment.Mentions))
+	for _, email := range comment.Mentions {
+		previous[email] = true
+	}
+	var added []*authentity.User
+	for _, u := range mentioned {
+		if !previous[strings.ToLower(u.Email)] {
+			added = append(added, u)
+		}
+	}
+
+	comment.Body = body
+	comment.Mentions = mentionEmails(mentioned)
+	if err := s.repo.UpdateFindingComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, "FINDING_COMMENT_EDITED", comment, author)
+	s.notify(ctx, finding, comment, added)
+	return comment, nil
+}
+
+// Delete removes a comment's text. Authors may delete their own comments and admins any.
+func (s *FindingCommentService) Delete(ctx context.Context, findingID, commentID uuid.UUID, author CommentAuthor) error {
+	comment, err := s.repo.GetFindingComment(ctx, findingID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted() {
+		return fmt.Errorf("finding comment not found")
+	}
+	if !author.IsAdmin && !isCommentAuthor(comment, author) {
+		return ErrCommentForbidden
+	}
+	if err := s.repo.DeleteFindingComment(ctx, findingID, commentID); err != nil {
+		return err
+	}
+	s.audit(ctx, "FINDING_COMMENT_DELETED", comment, author)
+	return nil
+}
+
+// resolveMentions returns the active tenant users mentioned in body; unknown addresses
+// are left as plain text
+func (s *FindingCommentService) resolveMentions(ctx context.Context, body string) ([]*authentity.User, error) {
+	emails := parseMentions(body)
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.repo.GetUsersByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+	byEmail := make(map[string]*authentity.User, len(users))
+	for _, u := range users {
+		if u.IsActive {
+			byEmail[strings.ToLower(u.Email)] = u
+		}
+	}
+
+	var mentioned []*authentity.User
+	for _, email := range emails {
+		if u, ok := byEmail[email]; ok {
+			mentioned = append(mentioned, u)
+		}
+	}
+	return mentioned, nil
+}
+
+func (s *FindingCommentService) notify(ctx context.Context, finding *entity.Finding, comment *entity.FindingComment, mentioned []*authentity.User) {
+	if s.notifier == nil {
+		return
+	}
+	// Authors are not told about mentioning themselves
+	var recipients []*authentity.User
+	for _, u := range mentioned {
+		if !strings.EqualFold(u.Email, comment.Author) {
+			recipients = append(recipients, u)
+		}
+	}
+	if len(recipients) > 0 {
+		s.notifier.Mentioned(ctx, finding, comment, recipients)
+	}
+}
+
+// audit records comment activity without the comment text, which may quote PII
+func (s *FindingCommentService) audit(ctx context.Context, action string, comment *entity.FindingComment, author CommentAuthor) {
+	if s.auditLogger == nil {
+		return
+	}
+	metadata := map[string]interface{}{
+		"comment_id": comment.ID,
+		"author":     comment.Author,
+		"actor":      author.Email,
+		"mentions":   len(comment.Mentions),
+	}
+	if comment.ParentID != nil {
+		metadata["parent_id"] = *comment.ParentID
+	}
+	if err := s.auditLogger.Record(ctx, action, "finding", comment.FindingID.String(), metadata); err != nil {
+		log.Printf("WARNING: Failed to audit %s for finding %s: %v", action, comment.FindingID, err)
+	}
+}
+
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", fmt.Errorf("invalid comment: body is empty")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return "", fmt.Errorf("invalid comment: body exceeds %d characters", maxCommentLength)
+	}
+	return body, nil
+}
+
+// parseMentions returns the lower-cased, distinct email addresses mentioned in body,
+// in the order they first appear, up to maxCommentMentions
+func parseMentions(body string) []string {
+	var emails []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+		if len(emails) == maxCommentMentions {
+			break
+		}
+	}
+	return emails
+}
+
+func mentionEmails(users []*authentity.User) []string {
+	emails := make([]string, 0, len(users))
+	for _, u := range users {
+		emails = append(emails, strings.ToLower(u.Email))
+	}
+	return emails
+}
+
+// isCommentAuthor matches by user ID, or by email for comments made without one
+func isCommentAuthor(comment *entity.FindingComment, author CommentAuthor) bool {
+	if comment.AuthorID != nil && author.ID != nil {
+		return *comment.AuthorID == *author.ID
+	}
+	return comment.AuthorID == nil && author.ID == nil && strings.EqualFold(comment.Author, author.Email)
+}
+
+// buildCommentThreads nests replies under the comment that started their thread.
+// Deleted comments are dropped unless they started a thread that still has replies.
+func buildCommentThreads(comments []*entity.FindingComment) []*entity.FindingComment {
+	roots := make(map[uuid.UUID]*entity.FindingComment)
+	var ordered []*entity.FindingComment
+	for _, c := range comments {
+		if c.ParentID == nil {
+			roots[c.ID] = c
+			ordered = append(ordered, c)
+		}
+	}
+	for _, c := range comments {
+		if c.ParentID == nil || c.Deleted() {
+			continue
+		}
+		if root, ok := roots[*c.ParentID]; ok {
+			root.Replies = append(root.Replies, c)
+		}
+	}
+
+	threads := make([]*entity.FindingComment, 0, len(ordered))
+	for _, root := range ordered {
+		if root.Deleted() && len(root.Replies) == 0 {
+			continue
+		}
+		threads = append(threads, root)
+	}
+	return threads
+}
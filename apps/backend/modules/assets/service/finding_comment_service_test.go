@@ -0,0 +1,108 @@
+package service
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"none", "Looks like a test fixture", nil},
+		{"one", "@asha.rao@example.in can you confirm?", []string{"asha.rao@example.in"}},
+		{"trailing punctuation", "Checked with @Ravi@Example.com.", []string{"ravi@example.com"}},
+		{"deduplicated in order", "@b@example.com then @a@example.com and @B@example.com", []string{"b@example.com", "a@example.com"}},
+		{"plain email is not a mention", "mail ops@example.com about it", nil},
+		{"handle without email", "thanks @ravi", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseMentions(tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMentions(%q) = %v, want %v", tt.body, got, tt.want)
+			}
+		})
+	}
+
+	var many strings.Builder
+	for i := 0; i < maxCommentMentions+5; i++ {
+		many.WriteString("@user" + string(rune('a'+i)) + "@example.com ")
+	}
+	if got := len(parseMentions(many.String())); got != maxCommentMentions {
+		t.Errorf("expected mentions to stop at %d, got %d", maxCommentMentions, got)
+	}
+}
+
+func TestValidateCommentBody(t *testing.T) {
+	if body, err := validateCommentBody("  looks fine \n"); err != nil || body != "looks fine" {
+		t.Errorf("validateCommentBody = %q, %v", body, err)
+	}
+	if _, err := validateCommentBody(" \n "); err == nil {
+		t.Error("expected an empty comment to be refused")
+	}
+	if _, err := validateCommentBody(strings.Repeat("é", maxCommentLength+1)); err == nil {
+		t.Error("expected an overlong comment to be refused")
+	}
+}
+
+func TestBuildCommentThreads(t *testing.T) {
+	now := time.Now()
+	comment := func(parent *entity.FindingComment, deleted bool) *entity.FindingComment {
+		c := &entity.FindingComment{ID: uuid.New()}
+		if parent != nil {
+			c.ParentID = &parent.ID
+		}
+		if deleted {
+			c.DeletedAt = &now
+		}
+		return c
+	}
+
+	first := comment(nil, false)
+	deletedWithReplies := comment(nil, true)
+	deletedAlone := comment(nil, true)
+	replyA := comment(first, false)
+	replyB := comment(deletedWithReplies, false)
+	deletedReply := comment(first, true)
+	replyC := comment(first, false)
+
+	threads := buildCommentThreads([]*entity.FindingComment{
+		first, deletedWithReplies, deletedAlone, replyA, replyB, deletedReply, replyC,
+	})
+	if len(threads) != 2 || threads[0] != first || threads[1] != deletedWithReplies {
+		t.Fatalf("unexpected threads %+v", threads)
+	}
+	if !reflect.DeepEqual(first.Replies, []*entity.FindingComment{replyA, replyC}) {
+		t.Errorf("expected live replies in order, got %+v", first.Replies)
+	}
+	if len(deletedWithReplies.Replies) != 1 || deletedWithReplies.Replies[0] != replyB {
+		t.Errorf("expected the deleted comment to keep its reply, got %+v", deletedWithReplies.Replies)
+	}
+}
+
+func TestIsCommentAuthor(t *testing.T) {
+	userID, otherID := uuid.New(), uuid.New()
+	byUser := &entity.FindingComment{AuthorID: &userID, Author: "asha@example.in"}
+	byKey := &entity.FindingComment{Author: "ci-bot"}
+
+	if !isCommentAuthor(byUser, CommentAuthor{ID: &userID, Email: "asha@example.in"}) {
+		t.Error("expected the author to match by ID")
+	}
+	if isCommentAuthor(byUser, CommentAuthor{ID: &otherID, Email: "asha@example.in"}) {
+		t.Error("expected another user with the same email not to match")
+	}
+	if isCommentAuthor(byUser, CommentAuthor{Email: "asha@example.in"}) {
+		t.Error("expected an API key caller not to match a user's comment")
+	}
+	if !isCommentAuthor(byKey, CommentAuthor{Email: "CI-Bot"}) {
+		t.Error("expected API key comments to match by name")
+	}
+}
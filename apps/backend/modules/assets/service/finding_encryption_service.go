@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+)
+
+const (
+	// FindingEncryptionMigrateJobType seals a tenant's stored finding values under its active data key
+	FindingEncryptionMigrateJobType = "finding_encryption.migrate"
+
+	// findingEncryptionBatch is how many findings are re-encrypted per transaction
+	findingEncryptionBatch = 500
+)
+
+// FindingEncryptionStatus reports a tenant's data keys and how its findings are stored
+type FindingEncryptionStatus struct {
+	Enabled       bool                            `json:"enabled"`
+	ActiveVersion int                             `json:"active_version,omitempty"`
+	Keys          []entity.TenantDataKey          `json:"keys"`
+	Findings      []entity.FindingKeyVersionCount `json:"findings"`
+	// Pending counts findings in plaintext or under a retired key, awaiting migration
+	Pending int `json:"pending"`
+}
+
+// FindingEncryptionService manages per-tenant encryption of finding matches and
+// sample text. A tenant's first data key turns encryption on for new findings;
+// each later key rotates it. Either way the findings already stored are sealed
+// under the new key by a background migration, and retired keys are kept so
+// archives and not yet migrated findings stay readable.
+type FindingEncryptionService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+	jobs        *jobs.Queue // Nil when the job queue is disabled
+}
+
+// NewFindingEncryptionService creates a new finding encryption service
+func NewFindingEncryptionService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger, queue *jobs.Queue) *FindingEncryptionService {
+	return &FindingEncryptionService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		jobs:        queue,
+	}
+}
+
+// RegisterJobs registers the migration job with the queue
+func (s *FindingEncryptionService) RegisterJobs(queue *jobs.Queue) {
+	queue.Register(FindingEncryptionMigrateJobType, s.migrateJob, jobs.HandlerOptions{Timeout: 2 * time.Hour})
+}
+
+// Status returns the data keys of the tenant in ctx and its findings per key version
+func (s *FindingEncryptionService) Status(ctx context.Context) (*FindingEncryptionStatus, error) {
+	keys, err := s.repo.ListTenantDataKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountFindingsByKeyVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &FindingEncryptionStatus{Keys: keys, Findings: counts}
+	for _, key := range keys {
+		if key.Status == entity.DataKeyActive {
+			status.Enabled = true
+			status.ActiveVersion = key.Version
+		}
+	}
+	if status.Enabled {
+		for _, count := range counts {
+			if count.KeyVersion == nil || *count.KeyVersion != status.ActiveVersion {
+				status.Pending += count.Findings
+			}
+		}
+	}
+	return status, nil
+}
+
+// RotateKey creates a new data key for the tenant in ctx, enabling encryption on
+// the first call, and migrates stored findings to it. The migration runs as a
+// job when the queue is enabled and reports whether it was queued.
+func (s *FindingEncryptionService) RotateKey(ctx context.Context, actor string) (*entity.TenantDataKey, bool, error) {
+	key, err := s.repo.CreateTenantDataKey(ctx, actor)
+	if err != nil {
+		return nil, false, err
+	}
+
+	action := "FINDING_ENCRYPTION_KEY_ROTATED"
+	if key.Version == 1 {
+		action = "FINDING_ENCRYPTION_ENABLED"
+	}
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, "tenant", key.TenantID.String(), map[string]interface{}{
+			"key_version": key.Version,
+		})
+	}
+
+	if s.jobs != nil {
+		_, err := s.jobs.Enqueue(ctx, FindingEncryptionMigrateJobType, map[string]interface{}{"key_version": key.Version}, jobs.EnqueueOptions{})
+		if err == nil {
+			return key, true, nil
+		}
+		log.Printf("WARN: Failed to queue finding encryption migration: %v", err)
+	}
+	_, err = s.Migrate(ctx)
+	return key, false, err
+}
+
+// Migrate seals every finding of the tenant in ctx that is in plaintext or under
+// a retired key under the active key, in batches, and returns how many it rewrote
+func (s *FindingEncryptionService) Migrate(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		n, err := s.repo.ReencryptFindings(ctx, findingEncryptionBatch)
+		total += n
+		if err != nil || n == 0 {
+			return total, err
+		}
+	}
+}
+
+// migrateJob migrates the job tenant's findings
+func (s *FindingEncryptionService) migrateJob(ctx context.Context, job *entity.Job) error {
+	if job.TenantID == nil {
+		return nil
+	}
+	migrated, err := s.Migrate(ctx)
+	if migrated > 0 {
+		log.Printf("🔐 Re-encrypted %d findings for tenant %s", migrated, *job.TenantID)
+	}
+	return err
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/archive"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultEvidenceMaxSizeBytes = 5 << 20
+	defaultEvidenceURLExpiry    = 15 * time.Minute
+	maxEvidenceFileNameLength   = 255
+	evidenceCleanupBatchSize    = 100
+)
+
+// defaultEvidenceTypes are accepted when no content types are configured
+var defaultEvidenceTypes = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain", "application/json",
+}
+
+// EvidenceLimits bounds what may be attached to a finding
+type EvidenceLimits struct {
+	MaxSizeBytes int64
+	AllowedTypes []string
+	URLExpiry    time.Duration // Lifetime of signed download URLs
+}
+
+// EvidenceUpload is an artifact submitted for a finding
+type EvidenceUpload struct {
+	Kind        string
+	FileName    string
+	ContentType string
+	Description string
+	Body        []byte
+}
+
+// EvidenceURL is a time-limited download link for an evidence object
+type EvidenceURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FindingEvidenceService attaches evidence artifacts to findings and keeps them in object storage
+type FindingEvidenceService struct {
+	repo        *persistence.PostgresRepository
+	store       archive.ObjectStore
+	prefix      string
+	limits      EvidenceLimits
+	auditLogger interfaces.AuditLogger
+}
+
+// NewFindingEvidenceService creates a new finding evidence service
+func NewFindingEvidenceService(repo *persistence.PostgresRepository, store archive.ObjectStore, prefix string, limits EvidenceLimits, auditLogger interfaces.AuditLogger) *FindingEvidenceService {
+	if limits.MaxSizeBytes <= 0 {
+		limits.MaxSizeBytes = defaultEvidenceMaxSizeBytes
+	}
+	if len(limits.AllowedTypes) == 0 {
+		limits.AllowedTypes = defaultEvidenceTypes
+	}
+	if limits.URLExpiry <= 0 {
+		limits.URLExpiry = defaultEvidenceURLExpiry
+	}
+	return &FindingEvidenceService{
+		repo:        repo,
+		store:       store,
+		prefix:      prefix,
+		limits:      limits,
+		auditLogger: auditLogger,
+	}
+}
+
+// MaxSizeBytes returns the largest attachment accepted
+func (s *FindingEvidenceService) MaxSizeBytes() int64 {
+	return s.limits.MaxSizeBytes
+}
+
+// Attach validates an upload, stores it and records it against the finding
+func (s *FindingEvidenceService) Attach(ctx context.Context, findingID uuid.UUID, upload EvidenceUpload, uploadedBy string) (*entity.FindingEvidence, error) {
+	if !entity.IsEvidenceKind(upload.Kind) {
+		return nil, fmt.Errorf("invalid evidence kind: %s", upload.Kind)
+	}
+	if len(upload.Body) == 0 {
+		return nil, fmt.Errorf("invalid evidence: file is empty")
+	}
+	if int64(len(upload.Body)) > s.limits.MaxSizeBytes {
+		return nil, fmt.Errorf("evidence exceeds the maximum size of %d bytes", s.limits.MaxSizeBytes)
+	}
+	contentType, err := evidenceContentType(upload.ContentType, upload.Body, s.limits.AllowedTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the finding first so evidence is only attached within the tenant
+	finding, err := s.repo.GetFindingByID(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(upload.Body)
+	evidence := &entity.FindingEvidence{
+		ID:              uuid.New(),
+		FindingID:       finding.ID,
+		Kind:            upload.Kind,
+		FileName:        sanitizeEvidenceFileName(upload.FileName),
+		ContentType:     contentType,
+		SizeBytes:       int64(len(upload.Body)),
+		ChecksumSHA256:  hex.EncodeToString(checksum[:]),
+		StorageProvider: s.store.Provider(),
+		Bucket:          s.store.Bucket(),
+		Description:     strings.TrimSpace(upload.Description),
+		UploadedBy:      uploadedBy,
+	}
+	// The key never contains the client's file name
+	evidence.ObjectKey = path.Join(s.prefix, finding.TenantID.String(), finding.ID.String(), evidence.ID.String())
+
+	if err := s.store.Put(ctx, evidence.ObjectKey, upload.Body, contentType); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateFindingEvidence(ctx, evidence); err != nil {
+		if delErr := s.store.Delete(ctx, evidence.ObjectKey); delErr != nil {
+			log.Printf("⚠️  Failed to remove orphaned evidence object %s: %v", evidence.ObjectKey, delErr)
+		}
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "FINDING_EVIDENCE_ATTACHED", "finding", findingID.String(), map[string]interface{}{
+			"evidence_id":  evidence.ID.String(),
+			"kind":         evidence.Kind,
+			"content_type": evidence.ContentType,
+			"size_bytes":   evidence.SizeBytes,
+		})
+	}
+
+	return evidence, nil
+}
+
+// List returns the evidence attached to a finding
+func (s *FindingEvidenceService) List(ctx context.Context, findingID uuid.UUID) ([]*entity.FindingEvidence, error) {
+	return s.repo.ListFindingEvidence(ctx, findingID)
+}
+
+// Download returns an evidence item together with its content
+func (s *FindingEvidenceService) Download(ctx context.Context, findingID, id uuid.UUID) (*entity.FindingEvidence, []byte, error) {
+	evidence, err := s.repo.GetFindingEvidence(ctx, findingID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.store.Get(ctx, evidence.ObjectKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return evidence, body, nil
+}
+
+// DownloadURL returns a signed download URL for an evidence item. Returns
+// archive.ErrPresignUnsupported when the store cannot sign URLs.
+func (s *FindingEvidenceService) DownloadURL(ctx context.Context, findingID, id uuid.UUID) (*EvidenceURL, error) {
+	evidence, err := s.repo.GetFindingEvidence(ctx, findingID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.limits.URLExpiry)
+	url, err := s.store.PresignGet(ctx, evidence.ObjectKey, evidence.FileName, s.limits.URLExpiry)
+	if err != nil {
+		return nil, err
+	}
+	return &EvidenceURL{URL: url, ExpiresAt: expiresAt}, nil
+}
+
+// Delete removes an evidence item; its object is cleaned up by the cleanup worker
+func (s *FindingEvidenceService) Delete(ctx context.Context, findingID, id uuid.UUID) error {
+	if err := s.repo.DeleteFindingEvidence(ctx, findingID, id); err != nil {
+		return err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "FINDING_EVIDENCE_DELETED", "finding", findingID.String(), map[string]interface{}{
+			"evidence_id": id.String(),
+		})
+	}
+	return nil
+}
+
+// StartCleanupWorker periodically removes the objects of deleted evidence from storage
+func (s *FindingEvidenceService) StartCleanupWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 10
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("📎 Starting evidence cleanup worker (interval: %dm)", intervalMinutes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Evidence cleanup worker stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.CleanupDeletedObjects(ctx); err != nil {
+				log.Printf("❌ Evidence cleanup failed: %v", err)
+			}
+		}
+	}
+}
+
+// CleanupDeletedObjects removes objects whose evidence rows were deleted, directly or
+// because their finding was purged, and returns how many were removed
+func (s *FindingEvidenceService) CleanupDeletedObjects(ctx context.Context) (int, error) {
+	deletions, err := s.repo.ListEvidenceObjectDeletions(ctx, s.store.Provider(), s.store.Bucket(), evidenceCleanupBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, deletion := range deletions {
+		if err := s.store.Delete(ctx, deletion.ObjectKey); err != nil {
+			log.Printf("❌ Failed to remove evidence object %s: %v", deletion.ObjectKey, err)
+			if err := s.repo.FailEvidenceObjectDeletion(ctx, deletion.ID, err.Error()); err != nil {
+				return removed, err
+			}
+			continue
+		}
+		if err := s.repo.CompleteEvidenceObjectDeletion(ctx, deletion.ID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		log.Printf("📎 Removed %d evidence objects of deleted evidence", removed)
+	}
+	return removed, nil
+}
+
+// evidenceContentType checks the declared content type against the allowed types and
+// the uploaded bytes. Without a usable declaration the sniffed type is used.
+func evidenceContentType(declared string, body []byte, allowed []string) (string, error) {
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(body))
+
+	mediaType, _, err := mime.ParseMediaType(declared)
+	if err != nil || mediaType == "application/octet-stream" {
+		mediaType = sniffed
+	}
+
+	if !containsFold(allowed, mediaType) {
+		return "", fmt.Errorf("unsupported evidence content type: %s", mediaType)
+	}
+
+	// Text formats all sniff as text/plain; binary formats must sniff as themselves
+	if isTextEvidenceType(mediaType) {
+		if sniffed != "text/plain" {
+			return "", fmt.Errorf("invalid evidence: content does not match %s", mediaType)
+		}
+	} else if sniffed != mediaType {
+		return "", fmt.Errorf("invalid evidence: content does not match %s", mediaType)
+	}
+	return mediaType, nil
+}
+
+func isTextEvidenceType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") || mediaType == "application/json"
+}
+
+// sanitizeEvidenceFileName keeps the base name of an uploaded file without control characters
+func sanitizeEvidenceFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "evidence"
+	}
+	for len(name) > maxEvidenceFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPresignUnsupported reports whether err means the store cannot sign download URLs
+func IsPresignUnsupported(err error) bool {
+	return errors.Is(err, archive.ErrPresignUnsupported)
+}
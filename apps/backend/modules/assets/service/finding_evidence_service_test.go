@@ -0,0 +1,63 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestEvidenceContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared string
+		body     []byte
+		want     string
+		wantErr  string
+	}{
+		{"declared png", "image/png", pngHeader, "image/png", ""},
+		{"sniffed when undeclared", "", pngHeader, "image/png", ""},
+		{"sniffed when octet-stream", "application/octet-stream", pngHeader, "image/png", ""},
+		{"json is text", "application/json; charset=utf-8", []byte(`{"match":"****@example.com"}`), "application/json", ""},
+		{"masked excerpt", "text/plain", []byte("Email: j***@example.com"), "text/plain", ""},
+		{"disallowed type", "text/html", []byte("<html></html>"), "", "unsupported"},
+		{"png claiming to be pdf", "application/pdf", pngHeader, "", "does not match"},
+		{"binary claiming to be text", "text/plain", pngHeader, "", "does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evidenceContentType(tt.declared, tt.body, defaultEvidenceTypes)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("content type = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeEvidenceFileName(t *testing.T) {
+	tests := map[string]string{
+		"screenshot.png":          "screenshot.png",
+		"../../etc/passwd":        "passwd",
+		`C:\Users\scan\proof.pdf`: "proof.pdf",
+		"bad\"name\r\n.txt":       "badname.txt",
+		"":                        "evidence",
+		"   ":                     "evidence",
+		strings.Repeat("é", 200):  strings.Repeat("é", 127),
+	}
+
+	for input, want := range tests {
+		if got := sanitizeEvidenceFileName(input); got != want {
+			t.Errorf("sanitizeEvidenceFileName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
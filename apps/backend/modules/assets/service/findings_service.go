@@ -2,23 +2,94 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	maskingservice "github.com/arc-platform/backend/modules/masking/service"
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/redaction"
 	"github.com/google/uuid"
 )
 
+// ErrReviewConflict is matched by a ReviewConflictError
+var ErrReviewConflict = errors.New("finding review was updated by someone else")
+
+// ReviewConflictError reports a review submitted against a version of the finding's
+// review state that is no longer current, with the current state so the UI can merge
+type ReviewConflictError struct {
+	FindingID       uuid.UUID           `json:"finding_id"`
+	ExpectedVersion int                 `json:"expected_version"`
+	CurrentVersion  int                 `json:"current_version"`
+	Current         *entity.ReviewState `json:"current,omitempty"` // Nil when the finding has not been reviewed
+}
+
+func (e *ReviewConflictError) Error() string {
+	return fmt.Sprintf("%s: expected version %d, current version %d", ErrReviewConflict, e.ExpectedVersion, e.CurrentVersion)
+}
+
+// Is lets errors.Is match ErrReviewConflict
+func (e *ReviewConflictError) Is(target error) bool {
+	return target == ErrReviewConflict
+}
+
 // FindingsService handles findings queries
 type FindingsService struct {
-	repo *persistence.PostgresRepository
+	repo             *persistence.PostgresRepository
+	events           interfaces.EventPublisher
+	sampleTextPolicy string // SAMPLE_TEXT_POLICY; applied to samples stored before redaction
+	templates        *maskingservice.MaskingTemplateService
 }
 
 // NewFindingsService creates a new findings service
 func NewFindingsService(repo *persistence.PostgresRepository) *FindingsService {
-	return &FindingsService{repo: repo}
+	return &FindingsService{repo: repo, events: &interfaces.NoOpEventPublisher{}, sampleTextPolicy: redaction.PolicyMask}
+}
+
+// SetSampleTextPolicy sets the policy returned samples honor unless the tenant
+// stores full sample text
+func (s *FindingsService) SetSampleTextPolicy(policy string) {
+	if redaction.IsPolicy(policy) {
+		s.sampleTextPolicy = policy
+	}
+}
+
+// SetMaskingTemplates masks returned samples with the tenant's masking templates;
+// the built-in templates apply otherwise
+func (s *FindingsService) SetMaskingTemplates(templates *maskingservice.MaskingTemplateService) {
+	s.templates = templates
+}
+
+// redactSamples applies the tenant's sample text policy to findings whose samples
+// were stored before redaction, so responses never carry more than is kept at rest
+func (s *FindingsService) redactSamples(ctx context.Context, findings []*entity.Finding) error {
+	if s.sampleTextPolicy == redaction.PolicyFull {
+		return nil
+	}
+	tenantPolicy, err := s.repo.GetTenantSampleTextPolicy(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sample text policy: %w", err)
+	}
+	if tenantPolicy != nil && tenantPolicy.StoreFull {
+		return nil
+	}
+	templates := s.templates.Templates(ctx)
+	for _, finding := range findings {
+		if !finding.SampleTextRedacted {
+			finding.SampleText = templates.Apply(s.sampleTextPolicy, finding.PatternName, finding.SampleText, finding.Matches).Text
+		}
+	}
+	return nil
+}
+
+// SetIntegrationEvents enables classification.updated events for downstream consumers
+func (s *FindingsService) SetIntegrationEvents(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
 }
 
 // FindingsQuery represents query parameters
@@ -28,10 +99,14 @@ type FindingsQuery struct {
 	Severity    string
 	PatternName string
 	DataSource  string
+	OrgUnitID   *uuid.UUID
+	Bucket      string // S3 bucket; Prefix narrows it to keys under a prefix
+	Prefix      string
 	Page        int
 	PageSize    int
 	SortBy      string
 	SortOrder   string
+	AsOf        *time.Time // Read the findings and review statuses as they were at this time
 }
 
 // FindingsResponse represents paginated findings response
@@ -53,6 +128,9 @@ type FindingWithDetails struct {
 	SourceSystem    string                   `json:"source_system"`
 	Classifications []*entity.Classification `json:"classifications"`
 	ReviewStatus    string                   `json:"review_status"`
+	ReviewVersion   int                      `json:"review_version"` // Send as If-Match when submitting feedback
+	CommentCount    int                      `json:"comment_count"`
+	WaiverID        *uuid.UUID               `json:"waiver_id,omitempty"` // Set while an active risk waiver covers the finding
 }
 
 // GetFindings retrieves paginated and filtered findings
@@ -74,6 +152,10 @@ func (s *FindingsService) GetFindings(ctx context.Context, query FindingsQuery)
 		Severity:    query.Severity,
 		PatternName: query.PatternName,
 		DataSource:  query.DataSource,
+		OrgUnitID:   query.OrgUnitID,
+		Bucket:      query.Bucket,
+		Prefix:      query.Prefix,
+		AsOf:        query.AsOf,
 	}
 
 	// Get findings
@@ -81,6 +163,9 @@ func (s *FindingsService) GetFindings(ctx context.Context, query FindingsQuery)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list findings: %w", err)
 	}
+	if err := s.redactSamples(ctx, findings); err != nil {
+		return nil, err
+	}
 
 	// Get total count
 	total, err := s.repo.CountFindings(ctx, filters)
@@ -88,11 +173,35 @@ func (s *FindingsService) GetFindings(ctx context.Context, query FindingsQuery)
 		return nil, fmt.Errorf("failed to count findings: %w", err)
 	}
 
+	// Count the page's comments and look up its waivers in one query each
+	findingIDs := make([]uuid.UUID, len(findings))
+	for i, finding := range findings {
+		findingIDs[i] = finding.ID
+	}
+	commentCounts, err := s.repo.CountFindingComments(ctx, findingIDs)
+	if err != nil {
+		return nil, err
+	}
+	waivers, err := s.repo.GetFindingWaiverIDs(ctx, findingIDs)
+	if err != nil {
+		return nil, err
+	}
+	var pastStatuses map[uuid.UUID]string
+	if query.AsOf != nil {
+		pastStatuses, err = s.repo.GetReviewStatusesAsOf(ctx, findingIDs, *query.AsOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Enrich findings with details
 	enrichedFindings := make([]*FindingWithDetails, 0, len(findings))
 	for _, finding := range findings {
-		// Get asset details
+		// Get asset details; past findings may be on assets deleted since
 		asset, err := s.repo.GetAssetByID(ctx, finding.AssetID)
+		if err != nil && query.AsOf != nil {
+			asset, err = &entity.Asset{}, nil
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to get asset: %w", err)
 		}
@@ -106,11 +215,19 @@ func (s *FindingsService) GetFindings(ctx context.Context, query FindingsQuery)
 		// Get review status
 		reviewState, err := s.repo.GetReviewStateByFindingID(ctx, finding.ID)
 		reviewStatus := "pending"
+		reviewVersion := 0
 		if err == nil && reviewState != nil {
 			reviewStatus = reviewState.Status
+			reviewVersion = reviewState.Version
+		}
+		if query.AsOf != nil {
+			reviewStatus = "pending"
+			if status, ok := pastStatuses[finding.ID]; ok {
+				reviewStatus = status
+			}
 		}
 
-		enrichedFindings = append(enrichedFindings, &FindingWithDetails{
+		detail := &FindingWithDetails{
 			Finding:         finding,
 			AssetName:       asset.Name,
 			AssetPath:       asset.Path,
@@ -119,7 +236,13 @@ func (s *FindingsService) GetFindings(ctx context.Context, query FindingsQuery)
 			SourceSystem:    asset.SourceSystem,
 			Classifications: classifications,
 			ReviewStatus:    reviewStatus,
-		})
+			ReviewVersion:   reviewVersion,
+			CommentCount:    commentCounts[finding.ID],
+		}
+		if waiverID, ok := waivers[finding.ID]; ok {
+			detail.WaiverID = &waiverID
+		}
+		enrichedFindings = append(enrichedFindings, detail)
 	}
 
 	totalPages := (total + query.PageSize - 1) / query.PageSize
@@ -133,12 +256,15 @@ func (s *FindingsService) GetFindings(ctx context.Context, query FindingsQuery)
 	}, nil
 }
 
-// SubmitFeedback records user feedback for a finding
-func (s *FindingsService) SubmitFeedback(ctx context.Context, feedback *entity.FindingFeedback) error {
+// SubmitFeedback records user feedback for a finding and updates its review state.
+// expectedVersion is the review state version the reviewer saw, 0 when the finding
+// had not been reviewed; a stale version returns a *ReviewConflictError and nothing
+// is saved.
+func (s *FindingsService) SubmitFeedback(ctx context.Context, feedback *entity.FindingFeedback, expectedVersion int) (*entity.ReviewState, error) {
 	// 1. Verify finding exists
 	_, err := s.repo.GetFindingByID(ctx, feedback.FindingID)
 	if err != nil {
-		return fmt.Errorf("finding not found: %w", err)
+		return nil, fmt.Errorf("finding not found: %w", err)
 	}
 
 	// 2. Set defaults
@@ -156,12 +282,7 @@ func (s *FindingsService) SubmitFeedback(ctx context.Context, feedback *entity.F
 		feedback.OriginalClassification = "Unknown"
 	}
 
-	// 3. Save feedback
-	if err := s.repo.CreateFeedback(ctx, feedback); err != nil {
-		return fmt.Errorf("failed to save feedback: %w", err)
-	}
-
-	// 4. IMMEDIATE ACTION: Update Review State based on feedback
+	// 3. Update Review State based on feedback, unless another reviewer got there first
 	// If User says "False Positive", we should mark it as such.
 	reviewStatus := "pending"
 	switch feedback.FeedbackType {
@@ -171,47 +292,95 @@ func (s *FindingsService) SubmitFeedback(ctx context.Context, feedback *entity.F
 		reviewStatus = "confirmed"
 	}
 
-	// Update or Create Review State
 	existingState, err := s.repo.GetReviewStateByFindingID(ctx, feedback.FindingID)
 	if err != nil {
-		return fmt.Errorf("failed to check review state: %w", err)
+		return nil, fmt.Errorf("failed to check review state: %w", err)
+	}
+	if err := checkReviewVersion(feedback.FindingID, existingState, expectedVersion); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
+	state := existingState
+	if state == nil {
+		state = &entity.ReviewState{ID: uuid.New(), FindingID: feedback.FindingID}
+	}
+	state.Status = reviewStatus
+	state.ReviewedBy = feedback.UserID
+	state.ReviewedAt = &now
+	state.Comments = feedback.Comments
+
 	if existingState != nil {
-		// Update existing
-		existingState.Status = reviewStatus
-		existingState.ReviewedBy = feedback.UserID
-		existingState.ReviewedAt = &now
-		existingState.Comments = feedback.Comments
-		if err := s.repo.UpdateReviewState(ctx, existingState); err != nil {
-			return fmt.Errorf("failed to update review state: %w", err)
-		}
+		err = s.repo.UpdateReviewState(ctx, state)
 	} else {
-		// Create new
-		newState := &entity.ReviewState{
-			ID:         uuid.New(),
-			FindingID:  feedback.FindingID,
-			Status:     reviewStatus,
-			ReviewedBy: feedback.UserID,
-			ReviewedAt: &now,
-			Comments:   feedback.Comments,
-		}
-		if err := s.repo.CreateReviewState(ctx, newState); err != nil {
-			return fmt.Errorf("failed to create review state: %w", err)
+		err = s.repo.CreateFirstReviewState(ctx, state)
+	}
+	if errors.Is(err, persistence.ErrReviewStateConflict) {
+		// Lost the race between the version check and the write
+		current, getErr := s.repo.GetReviewStateByFindingID(ctx, feedback.FindingID)
+		if getErr != nil {
+			return nil, fmt.Errorf("failed to check review state: %w", getErr)
 		}
+		return nil, newReviewConflict(feedback.FindingID, current, expectedVersion)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save review state: %w", err)
+	}
+
+	// 4. Save feedback
+	if err := s.repo.CreateFeedback(ctx, feedback); err != nil {
+		return nil, fmt.Errorf("failed to save feedback: %w", err)
+	}
+
+	s.events.Publish(ctx, interfaces.EventClassificationUpdated, map[string]interface{}{
+		"finding_id":              feedback.FindingID,
+		"feedback_id":             feedback.ID,
+		"feedback_type":           feedback.FeedbackType,
+		"original_classification": feedback.OriginalClassification,
+		"proposed_classification": feedback.ProposedClassification,
+		"review_status":           reviewStatus,
+		"review_version":          state.Version,
+		"reviewed_by":             feedback.UserID,
+		"reviewed_at":             now,
+	})
+
+	return state, nil
+}
 
+// checkReviewVersion rejects a review based on a version other than the current one
+func checkReviewVersion(findingID uuid.UUID, current *entity.ReviewState, expectedVersion int) error {
+	currentVersion := 0
+	if current != nil {
+		currentVersion = current.Version
+	}
+	if currentVersion != expectedVersion {
+		return newReviewConflict(findingID, current, expectedVersion)
+	}
 	return nil
 }
 
+func newReviewConflict(findingID uuid.UUID, current *entity.ReviewState, expectedVersion int) *ReviewConflictError {
+	conflict := &ReviewConflictError{FindingID: findingID, ExpectedVersion: expectedVersion, Current: current}
+	if current != nil {
+		conflict.CurrentVersion = current.Version
+	}
+	return conflict
+}
+
 // GetFindingsByAsset retrieves all findings for a specific asset
 // Implements FindingsProvider interface
 func (s *FindingsService) GetFindingsByAsset(ctx context.Context, assetID uuid.UUID, limit, offset int) ([]*entity.Finding, error) {
 	filters := repository.FindingFilters{
 		AssetID: &assetID,
 	}
-	return s.repo.ListFindings(ctx, filters, limit, offset)
+	findings, err := s.repo.ListFindings(ctx, filters, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redactSamples(ctx, findings); err != nil {
+		return nil, err
+	}
+	return findings, nil
 }
 
 // GetClassificationsByFinding retrieves classifications for a finding
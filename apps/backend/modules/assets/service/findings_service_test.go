@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestCheckReviewVersion(t *testing.T) {
+	findingID := uuid.New()
+
+	// An unreviewed finding is at version 0
+	if err := checkReviewVersion(findingID, nil, 0); err != nil {
+		t.Errorf("first review of an unreviewed finding should pass, got %v", err)
+	}
+	current := &entity.ReviewState{FindingID: findingID, Status: "confirmed", Version: 2}
+	if err := checkReviewVersion(findingID, current, 2); err != nil {
+		t.Errorf("review at the current version should pass, got %v", err)
+	}
+
+	// A reviewer who saw version 1 lost to one who saved version 2
+	err := checkReviewVersion(findingID, current, 1)
+	if !errors.Is(err, ErrReviewConflict) {
+		t.Fatalf("expected ErrReviewConflict, got %v", err)
+	}
+	var conflict *ReviewConflictError
+	if !errors.As(err, &conflict) || conflict.ExpectedVersion != 1 || conflict.CurrentVersion != 2 || conflict.Current.Status != "confirmed" {
+		t.Errorf("unexpected conflict metadata: %+v", conflict)
+	}
+
+	// Reviewing a finding someone else reviewed first also conflicts
+	if err := checkReviewVersion(findingID, current, 0); !errors.Is(err, ErrReviewConflict) {
+		t.Errorf("expected ErrReviewConflict for a stale first review, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+)
+
+// mentionEmailTimeout bounds email delivery, which runs after the request returns
+const mentionEmailTimeout = 30 * time.Second
+
+// MentionNotifier tells users they were mentioned in a finding comment. Live events
+// always go out; email only when SMTP is configured.
+type MentionNotifier struct {
+	events       interfaces.EventPublisher
+	mailer       mailer.Mailer // nil when SMTP is not configured
+	dashboardURL string
+}
+
+// NewMentionNotifier creates a mention notifier. events and m may be nil.
+func NewMentionNotifier(events interfaces.EventPublisher, m mailer.Mailer, dashboardURL string) *MentionNotifier {
+	if events == nil {
+		events = &interfaces.NoOpEventPublisher{}
+	}
+	return &MentionNotifier{events: events, mailer: m, dashboardURL: dashboardURL}
+}
+
+// Mentioned notifies the given users about a comment that mentions them. Neither the
+// event nor the email carries the comment text, which the recipients may not be
+// allowed to see; they follow the link and sign in instead.
+func (n *MentionNotifier) Mentioned(ctx context.Context, finding *entity.Finding, comment *entity.FindingComment, users []*authentity.User) {
+	to := make([]string, 0, len(users))
+	for _, u := range users {
+		to = append(to, u.Email)
+	}
+	n.events.Publish(ctx, interfaces.EventFindingCommentMention, map[string]interface{}{
+		"finding_id": finding.ID,
+		"comment_id": comment.ID,
+		"author":     comment.Author,
+		"mentioned":  to,
+	})
+
+	if n.mailer == nil {
+		return
+	}
+	msg := mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("[ARC-Hawk] %s mentioned you on a %s finding", comment.Author, finding.PatternName),
+		Body:    n.mentionBody(finding, comment),
+	}
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), mentionEmailTimeout)
+		defer cancel()
+		if err := n.mailer.Send(sendCtx, msg); err != nil {
+			log.Printf("WARNING: Failed to send comment mention email: %v", err)
+		}
+	}()
+}
+
+func (n *MentionNotifier) mentionBody(finding *entity.Finding, comment *entity.FindingComment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s mentioned you in a comment on a finding.\n\n", comment.Author)
+	fmt.Fprintf(&b, "Finding:  %s\n", finding.ID)
+	fmt.Fprintf(&b, "PII type: %s\n", finding.PatternName)
+	fmt.Fprintf(&b, "Severity: %s\n", finding.Severity)
+	if n.dashboardURL != "" {
+		fmt.Fprintf(&b, "\nRead it at %s/findings/%s\n", strings.TrimRight(n.dashboardURL, "/"), finding.ID)
+	}
+	return b.String()
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidOrgUnit reports a unit or assignment request that cannot be stored
+var ErrInvalidOrgUnit = errors.New("invalid org unit")
+
+// DefaultRollupHighRiskScore is the asset risk score counted as high risk in
+// rollups unless the caller picks another threshold
+const DefaultRollupHighRiskScore = 70
+
+// maxOrgUnitAssignment bounds the IDs one assignment request may move
+const maxOrgUnitAssignment = 1000
+
+// OrgUnitService manages departments and teams, what they own and the risk
+// rolled up to them
+type OrgUnitService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+}
+
+// NewOrgUnitService creates a new org unit service
+func NewOrgUnitService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *OrgUnitService {
+	return &OrgUnitService{repo: repo, auditLogger: auditLogger}
+}
+
+// ListOrgUnits returns the tenant's departments, each followed by its teams
+func (s *OrgUnitService) ListOrgUnits(ctx context.Context) ([]*entity.OrgUnit, error) {
+	return s.repo.ListOrgUnits(ctx)
+}
+
+// GetOrgUnit returns one unit
+func (s *OrgUnitService) GetOrgUnit(ctx context.Context, id uuid.UUID) (*entity.OrgUnit, error) {
+	return s.repo.GetOrgUnit(ctx, id)
+}
+
+// CreateOrgUnit stores a department, or a team under an existing department
+func (s *OrgUnitService) CreateOrgUnit(ctx context.Context, unit *entity.OrgUnit) (*entity.OrgUnit, error) {
+	unit.Name = strings.TrimSpace(unit.Name)
+	if err := validateOrgUnit(unit); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateOrgUnit(ctx, unit); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "ORG_UNIT_CREATED", unit.ID, map[string]interface{}{
+		"created_by": unit.CreatedBy,
+		"kind":       unit.Kind,
+		"name":       unit.Name,
+		"parent_id":  unit.ParentID,
+	})
+	return unit, nil
+}
+
+// UpdateOrgUnit renames a unit or changes its description; its kind and parent
+// stay as created
+func (s *OrgUnitService) UpdateOrgUnit(ctx context.Context, id uuid.UUID, name, description, updatedBy string) (*entity.OrgUnit, error) {
+	unit, err := s.repo.GetOrgUnit(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	previous := unit.Name
+	unit.Name, unit.Description = strings.TrimSpace(name), description
+	if err := validateOrgUnit(unit); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateOrgUnit(ctx, unit); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "ORG_UNIT_UPDATED", unit.ID, map[string]interface{}{
+		"updated_by":    updatedBy,
+		"name":          unit.Name,
+		"previous_name": previous,
+	})
+	return unit, nil
+}
+
+// DeleteOrgUnit removes a unit, leaving what it owned in no unit. Departments
+// must have no teams left.
+func (s *OrgUnitService) DeleteOrgUnit(ctx context.Context, id uuid.UUID, deletedBy string) error {
+	unit, err := s.repo.GetOrgUnit(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteOrgUnit(ctx, id); err != nil {
+		return err
+	}
+
+	s.record(ctx, "ORG_UNIT_DELETED", id, map[string]interface{}{
+		"deleted_by":  deletedBy,
+		"kind":        unit.Kind,
+		"name":        unit.Name,
+		"assets":      unit.Assets,
+		"connections": unit.Connections,
+		"users":       unit.Users,
+	})
+	return nil
+}
+
+// Assign moves assets, connections and users into a unit, or out of any unit
+// when the assignment names none. Assets scanned through an assigned connection
+// follow it unless they were assigned directly.
+func (s *OrgUnitService) Assign(ctx context.Context, assignment *entity.OrgUnitAssignment, assignedBy string) (*entity.OrgUnitAssignmentResult, error) {
+	if err := validateOrgUnitAssignment(assignment); err != nil {
+		return nil, err
+	}
+	result, err := s.repo.AssignOrgUnit(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	target := "none"
+	if assignment.OrgUnitID != nil {
+		target = assignment.OrgUnitID.String()
+	}
+	s.record(ctx, "ORG_UNIT_ASSIGNED", uuid.Nil, map[string]interface{}{
+		"assigned_by":      assignedBy,
+		"org_unit_id":      target,
+		"asset_ids":        assignment.AssetIDs,
+		"connection_ids":   assignment.ConnectionIDs,
+		"user_ids":         assignment.UserIDs,
+		"inherited_assets": result.InheritedAssets,
+	})
+	return result, nil
+}
+
+// Rollup aggregates asset risk and finding severities per department, or per
+// team with kind team. Assets at or above highRiskScore count as high risk.
+func (s *OrgUnitService) Rollup(ctx context.Context, kind string, highRiskScore int) ([]*entity.OrgUnitRollup, error) {
+	if kind == "" {
+		kind = entity.OrgUnitDepartment
+	}
+	if kind != entity.OrgUnitDepartment && kind != entity.OrgUnitTeam {
+		return nil, fmt.Errorf("%w: kind must be %s or %s", ErrInvalidOrgUnit, entity.OrgUnitDepartment, entity.OrgUnitTeam)
+	}
+	if highRiskScore <= 0 {
+		highRiskScore = DefaultRollupHighRiskScore
+	}
+	return s.repo.GetOrgUnitRollup(ctx, kind, highRiskScore)
+}
+
+// validateOrgUnit checks a unit's name and its place in the hierarchy
+func validateOrgUnit(unit *entity.OrgUnit) error {
+	switch {
+	case unit.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidOrgUnit)
+	case len(unit.Name) > 255:
+		return fmt.Errorf("%w: name is longer than 255 characters", ErrInvalidOrgUnit)
+	case unit.Kind == entity.OrgUnitDepartment && unit.ParentID != nil:
+		return fmt.Errorf("%w: departments are top level and take no parent", ErrInvalidOrgUnit)
+	case unit.Kind == entity.OrgUnitTeam && unit.ParentID == nil:
+		return fmt.Errorf("%w: a team needs the department it belongs to as parent", ErrInvalidOrgUnit)
+	case unit.Kind != entity.OrgUnitDepartment && unit.Kind != entity.OrgUnitTeam:
+		return fmt.Errorf("%w: kind must be %s or %s", ErrInvalidOrgUnit, entity.OrgUnitDepartment, entity.OrgUnitTeam)
+	}
+	return nil
+}
+
+// validateOrgUnitAssignment requires something to move and bounds the request
+func validateOrgUnitAssignment(assignment *entity.OrgUnitAssignment) error {
+	total := len(assignment.AssetIDs) + len(assignment.ConnectionIDs) + len(assignment.UserIDs)
+	if total == 0 {
+		return fmt.Errorf("%w: name at least one asset, connection or user", ErrInvalidOrgUnit)
+	}
+	if total > maxOrgUnitAssignment {
+		return fmt.Errorf("%w: at most %d IDs can be assigned at once", ErrInvalidOrgUnit, maxOrgUnitAssignment)
+	}
+	return nil
+}
+
+func (s *OrgUnitService) record(ctx context.Context, action string, id uuid.UUID, details map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	resourceType, resourceID := "org_unit", id.String()
+	if id == uuid.Nil {
+		tenantID, _ := persistence.GetTenantID(ctx)
+		resourceType, resourceID = "tenant", tenantID.String()
+	}
+	if err := s.auditLogger.Record(ctx, action, resourceType, resourceID, details); err != nil {
+		log.Printf("WARN: Failed to record %s audit event: %v", action, err)
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestValidateOrgUnit(t *testing.T) {
+	department := uuid.New()
+	cases := []struct {
+		name  string
+		unit  entity.OrgUnit
+		valid bool
+	}{
+		{"department", entity.OrgUnit{Kind: entity.OrgUnitDepartment, Name: "Finance"}, true},
+		{"team under a department", entity.OrgUnit{Kind: entity.OrgUnitTeam, Name: "Payroll", ParentID: &department}, true},
+		{"team without a department", entity.OrgUnit{Kind: entity.OrgUnitTeam, Name: "Payroll"}, false},
+		{"department with a parent", entity.OrgUnit{Kind: entity.OrgUnitDepartment, Name: "Finance", ParentID: &department}, false},
+		{"unknown kind", entity.OrgUnit{Kind: "division", Name: "Finance"}, false},
+		{"no name", entity.OrgUnit{Kind: entity.OrgUnitDepartment}, false},
+	}
+	for _, tc := range cases {
+		err := validateOrgUnit(&tc.unit)
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+		if !tc.valid && !errors.Is(err, ErrInvalidOrgUnit) {
+			t.Errorf("%s: expected ErrInvalidOrgUnit, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestValidateOrgUnitAssignment(t *testing.T) {
+	if err := validateOrgUnitAssignment(&entity.OrgUnitAssignment{}); !errors.Is(err, ErrInvalidOrgUnit) {
+		t.Errorf("expected an empty assignment to be refused, got %v", err)
+	}
+
+	// Clearing the unit is a valid assignment
+	if err := validateOrgUnitAssignment(&entity.OrgUnitAssignment{UserIDs: []uuid.UUID{uuid.New()}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tooMany := &entity.OrgUnitAssignment{AssetIDs: make([]uuid.UUID, maxOrgUnitAssignment), ConnectionIDs: []uuid.UUID{uuid.New()}}
+	if err := validateOrgUnitAssignment(tooMany); !errors.Is(err, ErrInvalidOrgUnit) {
+		t.Errorf("expected an oversized assignment to be refused, got %v", err)
+	}
+}
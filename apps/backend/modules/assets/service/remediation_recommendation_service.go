@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// Remediation actions understood by the remediation module
+const (
+	RecommendMask    = "MASK"
+	RecommendEncrypt = "ENCRYPT"
+	RecommendDelete  = "DELETE"
+)
+
+// staleDumpAge is how long a dump must go unmodified and unread before deletion is suggested
+const staleDumpAge = 180 * 24 * time.Hour
+
+// scannerTimeLayout is the format the scanner uses for file_metadata timestamps
+const scannerTimeLayout = "2006-01-02 15:04:05"
+
+// criticalPIITypes are identifiers whose exposure warrants the strongest remediation
+var criticalPIITypes = map[string]bool{
+	"IN_AADHAAR":      true,
+	"IN_PAN":          true,
+	"IN_PASSPORT":     true,
+	"IN_BANK_ACCOUNT": true,
+	"CREDIT_CARD":     true,
+}
+
+var (
+	structuredAssetTypes  = map[string]bool{"table": true, "column": true, "collection": true, "database": true}
+	structuredDataSources = map[string]bool{"postgresql": true, "postgres": true, "mysql": true, "mongodb": true}
+	dumpExtensions        = map[string]bool{".sql": true, ".dump": true, ".bak": true, ".csv": true, ".tsv": true, ".gz": true, ".zip": true, ".tar": true, ".tgz": true}
+	dumpNameHints         = []string{"dump", "backup", "export", "snapshot"}
+)
+
+// RemediationRecommendationService suggests remediation actions per asset
+type RemediationRecommendationService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewRemediationRecommendationService creates a new recommendation service
+func NewRemediationRecommendationService(repo *persistence.PostgresRepository) *RemediationRecommendationService {
+	return &RemediationRecommendationService{repo: repo}
+}
+
+// GetRecommendations builds the remediation recommendations for an asset
+func (s *RemediationRecommendationService) GetRecommendations(ctx context.Context, assetID uuid.UUID) (*entity.AssetRemediationRecommendations, error) {
+	asset, err := s.repo.GetAssetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.repo.GetAssetPIISummary(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	assetOutcomes, err := s.repo.GetRemediationOutcomeStats(ctx, &assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantOutcomes, err := s.repo.GetRemediationOutcomeStats(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AssetRemediationRecommendations{
+		AssetID:         asset.ID.String(),
+		AssetName:       asset.Name,
+		Environment:     asset.Environment,
+		PIISummary:      summary,
+		Recommendations: recommendRemediations(asset, summary, outcomesByAction(assetOutcomes), outcomesByAction(tenantOutcomes), time.Now()),
+	}, nil
+}
+
+func outcomesByAction(stats []entity.RemediationOutcomeStats) map[string]entity.RemediationOutcomeStats {
+	byAction := make(map[string]entity.RemediationOutcomeStats, len(stats))
+	for _, s := range stats {
+		byAction[strings.ToUpper(s.ActionType)] = s
+	}
+	return byAction
+}
+
+// assetProfile captures the asset characteristics the rules look at
+type assetProfile struct {
+	structured    bool
+	dump          bool
+	production    bool
+	worldReadable bool
+	idleFor       time.Duration // Time since last modification or access; zero when unknown
+	column        string
+}
+
+func profileAsset(asset *entity.Asset, now time.Time) assetProfile {
+	p := assetProfile{
+		structured: structuredAssetTypes[strings.ToLower(asset.AssetType)] || structuredDataSources[strings.ToLower(asset.DataSource)],
+		production: isProductionEnvironment(asset.Environment),
+	}
+
+	if !p.structured {
+		name := strings.ToLower(asset.Name)
+		if name == "" {
+			name = strings.ToLower(filepath.Base(asset.Path))
+		}
+		p.dump = dumpExtensions[filepath.Ext(name)]
+		for _, hint := range dumpNameHints {
+			if strings.Contains(name, hint) {
+				p.dump = true
+			}
+		}
+	}
+
+	meta := asset.FileMetadata
+	if perms, ok := meta["permissions_octal"].(string); ok && perms != "" {
+		// The last octal digit holds the "other" permission bits; 4 is read
+		if other, err := strconv.Atoi(perms[len(perms)-1:]); err == nil && other&4 != 0 {
+			p.worldReadable = true
+		}
+	}
+
+	var lastTouched time.Time
+	for _, key := range []string{"modified_time", "accessed_time"} {
+		if raw, ok := meta[key].(string); ok {
+			if t, err := time.ParseInLocation(scannerTimeLayout, raw, time.UTC); err == nil && t.After(lastTouched) {
+				lastTouched = t
+			}
+		}
+	}
+	if !lastTouched.IsZero() && now.After(lastTouched) {
+		p.idleFor = now.Sub(lastTouched)
+	}
+
+	if column, ok := meta["column_name"].(string); ok {
+		p.column = column
+	}
+
+	return p
+}
+
+func isProductionEnvironment(env string) bool {
+	switch strings.ToLower(env) {
+	case "production", "prod":
+		return true
+	}
+	return false
+}
+
+// recommendRemediations applies the recommendation rules to an asset's open PII.
+// Each asset gets at most one recommendation per action type, ordered by score.
+func recommendRemediations(asset *entity.Asset, summary []entity.AssetPIISummary, assetOutcomes, tenantOutcomes map[string]entity.RemediationOutcomeStats, now time.Time) []entity.RemediationRecommendation {
+	var piiTypes, criticalTypes []string
+	pending := 0
+	for _, s := range summary {
+		open := s.FindingCount - s.RemediatedCount
+		if open <= 0 {
+			continue
+		}
+		pending += open
+		piiTypes = append(piiTypes, s.PIIType)
+		if criticalPIITypes[strings.ToUpper(s.PIIType)] {
+			criticalTypes = append(criticalTypes, s.PIIType)
+		}
+	}
+	if pending == 0 {
+		return []entity.RemediationRecommendation{}
+	}
+
+	profile := profileAsset(asset, now)
+	var recs []entity.RemediationRecommendation
+
+	newRec := func(action, title string, base int, reason string) entity.RemediationRecommendation {
+		score := base
+		reasons := []string{reason}
+
+		if len(criticalTypes) > 0 {
+			score += 20
+			reasons = append(reasons, fmt.Sprintf("It holds critical identifiers (%s).", strings.Join(criticalTypes, ", ")))
+		}
+		if profile.production {
+			score += 10
+			reasons = append(reasons, "The asset is in a production environment.")
+		}
+		if pending >= 10 {
+			score += 5
+		}
+
+		score, historyReasons := applyOutcomeHistory(action, score, assetOutcomes[action], tenantOutcomes[action])
+		reasons = append(reasons, historyReasons...)
+		score = clampScore(score)
+
+		return entity.RemediationRecommendation{
+			ActionType:   action,
+			Title:        title,
+			Score:        score,
+			Priority:     priorityForScore(score),
+			PIITypes:     piiTypes,
+			FindingCount: pending,
+			Reasoning:    strings.Join(reasons, " "),
+		}
+	}
+
+	switch {
+	case profile.structured:
+		target := "the affected columns"
+		if profile.column != "" {
+			target = "column " + profile.column
+		}
+		recs = append(recs, newRec(RecommendMask, "Mask "+target, 50,
+			fmt.Sprintf("%d open finding(s) of %s sit in structured data, where masking %s removes the values without breaking the schema.",
+				pending, strings.Join(piiTypes, ", "), target)))
+
+	case profile.dump && profile.idleFor >= staleDumpAge:
+		recs = append(recs, newRec(RecommendDelete, "Delete stale dump", 60,
+			fmt.Sprintf("The file looks like a dump or backup and has not been modified or read for %d days; it holds %d open finding(s) of %s that no live system depends on.",
+				int(profile.idleFor.Hours()/24), pending, strings.Join(piiTypes, ", "))))
+		// Keep a non-destructive alternative for dumps that must be retained
+		recs = append(recs, newRec(RecommendEncrypt, "Encrypt file", 35,
+			"If the dump must be retained, encrypting it protects the data at rest instead of deleting it."))
+
+	default:
+		reason := fmt.Sprintf("%d open finding(s) of %s are stored in a file; encrypting it protects the values at rest.",
+			pending, strings.Join(piiTypes, ", "))
+		if profile.dump {
+			reason = fmt.Sprintf("The file looks like a dump or backup but is still in use, so it is encrypted rather than deleted; it holds %d open finding(s) of %s.",
+				pending, strings.Join(piiTypes, ", "))
+		}
+		base := 45
+		if profile.worldReadable {
+			base += 15
+			reason += " Its permissions make it readable by any local user."
+		}
+		recs = append(recs, newRec(RecommendEncrypt, "Encrypt file", base, reason))
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].Score > recs[j].Score })
+	return recs
+}
+
+// applyOutcomeHistory adjusts a score using past remediation outcomes of the same action
+func applyOutcomeHistory(action string, score int, onAsset, tenantWide entity.RemediationOutcomeStats) (int, []string) {
+	var reasons []string
+
+	if onAsset.RolledBack > 0 {
+		score -= 15
+		reasons = append(reasons, fmt.Sprintf("A previous %s on this asset was rolled back %d time(s); confirm with the owner before retrying.",
+			action, onAsset.RolledBack))
+	}
+	if onAsset.Failed > 0 {
+		score -= 10
+		reasons = append(reasons, fmt.Sprintf("%d earlier %s attempt(s) on this asset failed.", onAsset.Failed, action))
+	}
+
+	if attempts := tenantWide.Attempts(); attempts >= 3 {
+		rate := tenantWide.SuccessRate()
+		switch {
+		case rate >= 0.8:
+			score += 5
+			reasons = append(reasons, fmt.Sprintf("%s has succeeded in %d of %d past attempts.", action, tenantWide.Completed, attempts))
+		case rate < 0.5:
+			score -= 10
+			reasons = append(reasons, fmt.Sprintf("%s has only succeeded in %d of %d past attempts.", action, tenantWide.Completed, attempts))
+		}
+	}
+
+	return score, reasons
+}
+
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+func priorityForScore(score int) string {
+	switch {
+	case score >= 80:
+		return "critical"
+	case score >= 60:
+		return "high"
+	case score >= 40:
+		return "medium"
+	default:
+		return "low"
+	}
+}
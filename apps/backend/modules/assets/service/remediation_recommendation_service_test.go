@@ -0,0 +1,73 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestRecommendRemediations(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	aadhaar := []entity.AssetPIISummary{{PIIType: "IN_AADHAAR", FindingCount: 4, MaxSeverity: "CRITICAL"}}
+	email := []entity.AssetPIISummary{{PIIType: "EMAIL_ADDRESS", FindingCount: 2, MaxSeverity: "LOW"}}
+	none := map[string]entity.RemediationOutcomeStats{}
+
+	t.Run("structured data is masked", func(t *testing.T) {
+		asset := &entity.Asset{AssetType: "table", DataSource: "postgresql", Environment: "Production",
+			FileMetadata: map[string]interface{}{"column_name": "aadhaar_no"}}
+		recs := recommendRemediations(asset, aadhaar, none, none, now)
+		if len(recs) != 1 || recs[0].ActionType != RecommendMask {
+			t.Fatalf("expected a single MASK recommendation, got %+v", recs)
+		}
+		if recs[0].Title != "Mask column aadhaar_no" || recs[0].Priority != "critical" {
+			t.Errorf("unexpected recommendation: %+v", recs[0])
+		}
+	})
+
+	t.Run("stale dump is deleted with encryption as fallback", func(t *testing.T) {
+		asset := &entity.Asset{AssetType: "file", Name: "customers_backup.sql", Environment: "Development",
+			FileMetadata: map[string]interface{}{"modified_time": "2025-01-01 10:00:00", "accessed_time": "2025-02-01 10:00:00"}}
+		recs := recommendRemediations(asset, email, none, none, now)
+		if len(recs) != 2 || recs[0].ActionType != RecommendDelete || recs[1].ActionType != RecommendEncrypt {
+			t.Fatalf("expected DELETE then ENCRYPT, got %+v", recs)
+		}
+		if !strings.Contains(recs[0].Reasoning, "484 days") {
+			t.Errorf("reasoning should mention idle days: %q", recs[0].Reasoning)
+		}
+	})
+
+	t.Run("recent dump is encrypted, world readable raises score", func(t *testing.T) {
+		meta := map[string]interface{}{"modified_time": "2026-05-20 10:00:00"}
+		asset := &entity.Asset{AssetType: "file", Name: "export.csv", FileMetadata: meta}
+		private := recommendRemediations(asset, email, none, none, now)
+
+		meta["permissions_octal"] = "644"
+		public := recommendRemediations(asset, email, none, none, now)
+
+		if len(public) != 1 || public[0].ActionType != RecommendEncrypt {
+			t.Fatalf("expected a single ENCRYPT recommendation, got %+v", public)
+		}
+		if public[0].Score <= private[0].Score {
+			t.Errorf("world-readable file should score higher: %d <= %d", public[0].Score, private[0].Score)
+		}
+	})
+
+	t.Run("rolled back history lowers score", func(t *testing.T) {
+		asset := &entity.Asset{AssetType: "table", DataSource: "mysql"}
+		base := recommendRemediations(asset, email, none, none, now)
+		history := map[string]entity.RemediationOutcomeStats{RecommendMask: {ActionType: RecommendMask, RolledBack: 1}}
+		recs := recommendRemediations(asset, email, history, none, now)
+		if recs[0].Score >= base[0].Score || !strings.Contains(recs[0].Reasoning, "rolled back") {
+			t.Errorf("expected rollback penalty, got %+v", recs[0])
+		}
+	})
+
+	t.Run("fully remediated asset has no recommendations", func(t *testing.T) {
+		done := []entity.AssetPIISummary{{PIIType: "IN_PAN", FindingCount: 3, RemediatedCount: 3}}
+		if recs := recommendRemediations(&entity.Asset{AssetType: "file"}, done, none, none, now); len(recs) != 0 {
+			t.Errorf("expected no recommendations, got %+v", recs)
+		}
+	})
+}
@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+)
+
+const (
+	// ReviewPriorityRecomputeJobType rescores a tenant's pending findings after its policy changed
+	ReviewPriorityRecomputeJobType = "review_priority.recompute"
+
+	// reviewPriorityBatch is how many findings are scored per transaction
+	reviewPriorityBatch = 500
+
+	// reviewQueueInlineBatches bounds the scoring done while serving the queue;
+	// anything left is handed to a recompute job
+	reviewQueueInlineBatches = 4
+
+	// exposurePointsPerAsset is what each other asset holding the value adds to the exposure score
+	exposurePointsPerAsset = 20
+)
+
+// DefaultReviewPriorityPolicy applies to tenants that have not set their own
+var DefaultReviewPriorityPolicy = entity.ReviewPriorityPolicy{
+	RiskWeight:        0.35,
+	PIIWeight:         0.30,
+	EnvironmentWeight: 0.15,
+	ExposureWeight:    0.20,
+	PIICriticality:    map[string]float64{},
+}
+
+// ReviewPriorityPolicyInput replaces a tenant's review priority policy
+type ReviewPriorityPolicyInput struct {
+	RiskWeight        float64            `json:"risk_weight"`
+	PIIWeight         float64            `json:"pii_weight"`
+	EnvironmentWeight float64            `json:"environment_weight"`
+	ExposureWeight    float64            `json:"exposure_weight"`
+	PIICriticality    map[string]float64 `json:"pii_criticality"`
+}
+
+// ReviewQueue is a page of the review queue
+type ReviewQueue struct {
+	Items []*entity.ReviewQueueItem `json:"items"`
+	Total int                       `json:"total"`
+	// ScoringPending is set while findings are still being scored; they join the queue once scored
+	ScoringPending bool `json:"scoring_pending"`
+}
+
+// ReviewPriorityService orders findings awaiting review by how urgently they need
+// a reviewer: their risk, how critical the PII type is, whether they sit in
+// production and how many other assets hold the same value. Findings are scored
+// when the queue is read and rescored in the background when the policy changes.
+type ReviewPriorityService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+	jobs        *jobs.Queue // Nil when the job queue is disabled
+}
+
+// NewReviewPriorityService creates a new review priority service
+func NewReviewPriorityService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger, queue *jobs.Queue) *ReviewPriorityService {
+	return &ReviewPriorityService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		jobs:        queue,
+	}
+}
+
+// RegisterJobs registers the recompute job with the queue
+func (s *ReviewPriorityService) RegisterJobs(queue *jobs.Queue) {
+	queue.Register(ReviewPriorityRecomputeJobType, s.recomputeJob, jobs.HandlerOptions{Timeout: 30 * time.Minute})
+}
+
+// GetPolicy returns the review priority policy of the tenant in ctx
+func (s *ReviewPriorityService) GetPolicy(ctx context.Context) (*entity.ReviewPriorityPolicy, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := s.repo.GetReviewPriorityPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		defaults := DefaultReviewPriorityPolicy
+		defaults.TenantID = tenantID
+		defaults.PIICriticality = map[string]float64{}
+		return &defaults, nil
+	}
+	return policy, nil
+}
+
+// UpdatePolicy replaces the review priority policy of the tenant in ctx and
+// queues rescoring of its pending findings
+func (s *ReviewPriorityService) UpdatePolicy(ctx context.Context, input ReviewPriorityPolicyInput, updatedBy string) (*entity.ReviewPriorityPolicy, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := newReviewPriorityPolicy(input)
+	if err != nil {
+		return nil, err
+	}
+	policy.UpdatedBy = updatedBy
+	if err := s.repo.SetReviewPriorityPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "REVIEW_PRIORITY_POLICY_CHANGED", "tenant", tenantID.String(), map[string]interface{}{
+			"risk_weight":        policy.RiskWeight,
+			"pii_weight":         policy.PIIWeight,
+			"environment_weight": policy.EnvironmentWeight,
+			"exposure_weight":    policy.ExposureWeight,
+			"pii_criticality":    policy.PIICriticality,
+		})
+	}
+
+	if _, err := s.Recompute(ctx); err != nil {
+		log.Printf("WARN: Failed to queue review priority recompute: %v", err)
+	}
+	return s.GetPolicy(ctx)
+}
+
+// Recompute rescores the pending findings of the tenant in ctx that are unscored
+// or were scored under an earlier policy. It runs as a job when the queue is
+// enabled and reports whether it was queued.
+func (s *ReviewPriorityService) Recompute(ctx context.Context) (bool, error) {
+	if s.jobs != nil {
+		_, err := s.jobs.Enqueue(ctx, ReviewPriorityRecomputeJobType, map[string]interface{}{}, jobs.EnqueueOptions{})
+		return err == nil, err
+	}
+	_, _, err := s.score(ctx, 0)
+	return false, err
+}
+
+// GetQueue returns pending findings, highest priority first. Findings not yet
+// scored under the current policy are scored first, up to a bound; the rest are
+// left to a recompute job and reported as ScoringPending.
+func (s *ReviewPriorityService) GetQueue(ctx context.Context, filter entity.ReviewQueueFilter) (*ReviewQueue, error) {
+	_, remaining, err := s.score(ctx, reviewQueueInlineBatches)
+	if err != nil {
+		return nil, err
+	}
+	if remaining {
+		if _, err := s.Recompute(ctx); err != nil {
+			log.Printf("WARN: Failed to queue review priority recompute: %v", err)
+		}
+	}
+
+	items, total, err := s.repo.ListReviewQueue(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewQueue{Items: items, Total: total, ScoringPending: remaining}, nil
+}
+
+// score scores the tenant's unscored and stale pending findings in batches, at
+// most maxBatches of them (zero for no limit). It reports how many were scored
+// and whether any are left.
+func (s *ReviewPriorityService) score(ctx context.Context, maxBatches int) (int, bool, error) {
+	policy, err := s.GetPolicy(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	scored := 0
+	for batch := 0; maxBatches == 0 || batch < maxBatches; batch++ {
+		facts, err := s.repo.ListUnscoredReviewFacts(ctx, policy.UpdatedAt, reviewPriorityBatch)
+		if err != nil {
+			return scored, false, err
+		}
+		if len(facts) == 0 {
+			return scored, false, nil
+		}
+		priorities := make([]*entity.FindingReviewPriority, len(facts))
+		for i, f := range facts {
+			priorities[i] = scoreReviewPriority(f, policy)
+		}
+		if err := s.repo.SaveReviewPriorities(ctx, priorities); err != nil {
+			return scored, false, err
+		}
+		scored += len(facts)
+		if len(facts) < reviewPriorityBatch {
+			return scored, false, nil
+		}
+	}
+	return scored, true, nil
+}
+
+// recomputeJob rescores the job tenant's pending findings
+func (s *ReviewPriorityService) recomputeJob(ctx context.Context, job *entity.Job) error {
+	if job.TenantID == nil {
+		return nil
+	}
+	scored, _, err := s.score(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if scored > 0 {
+		log.Printf("📋 Scored review priority of %d findings for tenant %s", scored, *job.TenantID)
+	}
+	return nil
+}
+
+// newReviewPriorityPolicy validates a policy input
+func newReviewPriorityPolicy(input ReviewPriorityPolicyInput) (*entity.ReviewPriorityPolicy, error) {
+	weights := map[string]float64{
+		"risk_weight":        input.RiskWeight,
+		"pii_weight":         input.PIIWeight,
+		"environment_weight": input.EnvironmentWeight,
+		"exposure_weight":    input.ExposureWeight,
+	}
+	sum := 0.0
+	for name, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("%s must be a non-negative number", name)
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("at least one weight must be positive")
+	}
+
+	criticality := make(map[string]float64, len(input.PIICriticality))
+	for piiType, score := range input.PIICriticality {
+		piiType = strings.ToUpper(strings.TrimSpace(piiType))
+		if piiType == "" {
+			return nil, fmt.Errorf("invalid PII type in pii_criticality")
+		}
+		if score < 0 || score > 100 {
+			return nil, fmt.Errorf("pii_criticality of %s must be between 0 and 100", piiType)
+		}
+		criticality[piiType] = score
+	}
+
+	return &entity.ReviewPriorityPolicy{
+		RiskWeight:        input.RiskWeight,
+		PIIWeight:         input.PIIWeight,
+		EnvironmentWeight: input.EnvironmentWeight,
+		ExposureWeight:    input.ExposureWeight,
+		PIICriticality:    criticality,
+	}, nil
+}
+
+// scoreReviewPriority scores a pending finding under a policy. Each factor is
+// scored 0-100 and the priority is their weighted mean, rounded to one decimal.
+func scoreReviewPriority(f *entity.ReviewPriorityFacts, policy *entity.ReviewPriorityPolicy) *entity.FindingReviewPriority {
+	p := &entity.FindingReviewPriority{
+		FindingID:        f.FindingID,
+		TenantID:         f.TenantID,
+		RiskScore:        severityRiskScore(f.Severity),
+		PIIScore:         piiCriticalityScore(f.PIIType, f.ClassificationType, policy.PIICriticality),
+		EnvironmentScore: environmentReviewScore(f.Environment),
+		ExposureScore:    math.Min(float64(f.SharingAssets*exposurePointsPerAsset), 100),
+		PIIType:          f.PIIType,
+		SharingAssets:    f.SharingAssets,
+	}
+
+	weights := policy.RiskWeight + policy.PIIWeight + policy.EnvironmentWeight + policy.ExposureWeight
+	if weights > 0 {
+		score := (p.RiskScore*policy.RiskWeight +
+			p.PIIScore*policy.PIIWeight +
+			p.EnvironmentScore*policy.EnvironmentWeight +
+			p.ExposureScore*policy.ExposureWeight) / weights
+		p.Score = math.Round(score*10) / 10
+	}
+	return p
+}
+
+// severityRiskScore converts a finding severity to a 0-100 risk score. The
+// scanner reports its most severe findings as "Highest".
+func severityRiskScore(severity string) float64 {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL", "HIGHEST":
+		return 95
+	case "HIGH":
+		return 80
+	case "MEDIUM":
+		return 60
+	case "LOW":
+		return 30
+	default:
+		return 10
+	}
+}
+
+// piiCriticalityScore rates how damaging exposure of a PII type is, 0-100. The
+// tenant's overrides win over national identifiers and payment data, which win
+// over the classification type.
+func piiCriticalityScore(piiType, classificationType string, overrides map[string]float64) float64 {
+	if score, ok := overrides[strings.ToUpper(piiType)]; ok {
+		return score
+	}
+	if criticalPIITypes[strings.ToUpper(piiType)] {
+		return 100
+	}
+	switch classificationType {
+	case "Sensitive Personal Data":
+		return 90
+	case "Secrets":
+		return 85
+	case "Personal Data":
+		return 50
+	default:
+		return 20
+	}
+}
+
+// environmentReviewScore rates production data above everything else; the 30 for
+// other environments matches the risk score's non-production multiplier. As in
+// risk scoring, an unknown environment is treated as production.
+func environmentReviewScore(environment string) float64 {
+	env := strings.ToUpper(strings.TrimSpace(environment))
+	if env == "" || strings.HasPrefix(env, "PROD") {
+		return 100
+	}
+	return 30
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestScoreReviewPriorityOrdersFindings(t *testing.T) {
+	policy := DefaultReviewPriorityPolicy
+
+	aadhaarProd := scoreReviewPriority(&entity.ReviewPriorityFacts{
+		PIIType: "IN_AADHAAR", ClassificationType: "Sensitive Personal Data", Severity: "Highest", Environment: "Production", SharingAssets: 3,
+	}, &policy)
+	aadhaarTest := scoreReviewPriority(&entity.ReviewPriorityFacts{
+		PIIType: "IN_AADHAAR", ClassificationType: "Sensitive Personal Data", Severity: "Highest", Environment: "TEST",
+	}, &policy)
+	emailProd := scoreReviewPriority(&entity.ReviewPriorityFacts{
+		PIIType: "EMAIL_ADDRESS", ClassificationType: "Personal Data", Severity: "Low", Environment: "PROD",
+	}, &policy)
+
+	if aadhaarProd.ExposureScore != 60 || aadhaarProd.PIIScore != 100 || aadhaarProd.EnvironmentScore != 100 {
+		t.Errorf("unexpected components: %+v", aadhaarProd)
+	}
+	// 95*0.35 + 100*0.30 + 100*0.15 + 60*0.20
+	if aadhaarProd.Score != 90.3 {
+		t.Errorf("expected 90.3, got %v", aadhaarProd.Score)
+	}
+	if !(aadhaarProd.Score > aadhaarTest.Score && aadhaarTest.Score > emailProd.Score) {
+		t.Errorf("unexpected order: %v, %v, %v", aadhaarProd.Score, aadhaarTest.Score, emailProd.Score)
+	}
+}
+
+func TestScoreReviewPriorityAppliesPolicy(t *testing.T) {
+	facts := &entity.ReviewPriorityFacts{PIIType: "email_address", ClassificationType: "Personal Data", Severity: "Low", SharingAssets: 9}
+
+	exposureOnly := &entity.ReviewPriorityPolicy{ExposureWeight: 1}
+	if p := scoreReviewPriority(facts, exposureOnly); p.Score != 100 {
+		t.Errorf("exposure is capped at 100, got %v", p.Score)
+	}
+
+	overridden := &entity.ReviewPriorityPolicy{PIIWeight: 1, PIICriticality: map[string]float64{"EMAIL_ADDRESS": 75}}
+	if p := scoreReviewPriority(facts, overridden); p.PIIScore != 75 || p.Score != 75 {
+		t.Errorf("expected the override to set the PII score, got %+v", p)
+	}
+
+	// An unknown environment counts as production
+	if p := scoreReviewPriority(facts, &entity.ReviewPriorityPolicy{EnvironmentWeight: 1}); p.Score != 100 {
+		t.Errorf("expected 100 for an unknown environment, got %v", p.Score)
+	}
+}
+
+func TestNewReviewPriorityPolicy(t *testing.T) {
+	policy, err := newReviewPriorityPolicy(ReviewPriorityPolicyInput{
+		RiskWeight:     2,
+		PIIWeight:      1,
+		PIICriticality: map[string]float64{" in_voter_id ": 95},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policy.PIICriticality["IN_VOTER_ID"] != 95 {
+		t.Errorf("expected the PII type to be normalized, got %v", policy.PIICriticality)
+	}
+
+	for _, tc := range []struct {
+		input ReviewPriorityPolicyInput
+		want  string
+	}{
+		{ReviewPriorityPolicyInput{}, "at least one weight must be positive"},
+		{ReviewPriorityPolicyInput{RiskWeight: 1, ExposureWeight: -1}, "exposure_weight must be a non-negative number"},
+		{ReviewPriorityPolicyInput{RiskWeight: 1, PIICriticality: map[string]float64{"CREDIT_CARD": 120}}, "between 0 and 100"},
+		{ReviewPriorityPolicyInput{RiskWeight: 1, PIICriticality: map[string]float64{" ": 50}}, "invalid PII type"},
+	} {
+		_, err := newReviewPriorityPolicy(tc.input)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: expected an error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// propagatableDecisions are the review decisions that may be copied to identical findings
+var propagatableDecisions = map[string]bool{"false_positive": true, "confirmed": true}
+
+// ReviewPropagationPreview lists the findings a decision would be copied to
+type ReviewPropagationPreview struct {
+	SourceFindingID uuid.UUID                  `json:"source_finding_id"`
+	Decision        string                     `json:"decision"`
+	Enabled         bool                       `json:"enabled"`
+	Affected        []*entity.IdenticalFinding `json:"affected"` // Unreviewed or pending, would take the decision
+	Skipped         []*entity.IdenticalFinding `json:"skipped"`  // Already decided by a reviewer, left alone
+}
+
+// ReviewPropagationResult is what one propagation changed
+type ReviewPropagationResult struct {
+	SourceFindingID uuid.UUID   `json:"source_finding_id"`
+	Decision        string      `json:"decision"`
+	Propagated      []uuid.UUID `json:"propagated"`
+	Skipped         int         `json:"skipped"`
+}
+
+// ReviewPropagationService copies a reviewer's decision on one finding to the
+// tenant's other findings of the same pattern and normalized value hash, so the
+// same false positive need not be rejected on every asset it appears in. Only
+// unreviewed or pending findings inherit a decision, and only for tenants that
+// enabled propagation.
+type ReviewPropagationService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+}
+
+// NewReviewPropagationService creates a new review propagation service
+func NewReviewPropagationService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *ReviewPropagationService {
+	return &ReviewPropagationService{repo: repo, auditLogger: auditLogger}
+}
+
+// GetSettings returns whether the tenant propagates review decisions
+func (s *ReviewPropagationService) GetSettings(ctx context.Context) (*entity.ReviewPropagationSettings, error) {
+	return s.repo.GetReviewPropagationSettings(ctx)
+}
+
+// SetEnabled turns review propagation on or off for the tenant
+func (s *ReviewPropagationService) SetEnabled(ctx context.Context, enabled bool, actor string) (*entity.ReviewPropagationSettings, error) {
+	if err := s.repo.SetReviewPropagationEnabled(ctx, enabled, actor); err != nil {
+		return nil, err
+	}
+	if s.auditLogger != nil {
+		tenantID, _ := persistence.GetTenantID(ctx)
+		if err := s.auditLogger.Record(ctx, "REVIEW_PROPAGATION_SETTINGS_CHANGED", "tenant", tenantID.String(), map[string]interface{}{
+			"enabled":    enabled,
+			"updated_by": actor,
+		}); err != nil {
+			log.Printf("WARNING: Failed to audit review propagation settings: %v", err)
+		}
+	}
+	return s.repo.GetReviewPropagationSettings(ctx)
+}
+
+// Preview lists the findings a decision on findingID would be copied to. decision
+// defaults to the finding's current review decision, or false_positive when it has
+// none yet, so reviewers can see the impact before deciding.
+func (s *ReviewPropagationService) Preview(ctx context.Context, findingID uuid.UUID, decision string) (*ReviewPropagationPreview, error) {
+	if _, err := s.repo.GetFindingByID(ctx, findingID); err != nil {
+		return nil, fmt.Errorf("finding not found: %w", err)
+	}
+	if decision == "" {
+		state, err := s.repo.GetReviewStateByFindingID(ctx, findingID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get review state: %w", err)
+		}
+		decision = "false_positive"
+		if state != nil && propagatableDecisions[state.Status] {
+			decision = state.Status
+		}
+	}
+	if !propagatableDecisions[decision] {
+		return nil, fmt.Errorf("invalid decision %q: only false_positive and confirmed can be propagated", decision)
+	}
+
+	settings, err := s.repo.GetReviewPropagationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identical, err := s.repo.ListIdenticalFindings(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, skipped := splitPropagationTargets(identical)
+	return &ReviewPropagationPreview{
+		SourceFindingID: findingID,
+		Decision:        decision,
+		Enabled:         settings.Enabled,
+		Affected:        affected,
+		Skipped:         skipped,
+	}, nil
+}
+
+// Propagate copies the current review decision of findingID to its unreviewed and
+// pending identical findings. Each change is audited against the finding it was
+// made to. No feedback is recorded for them, so one reviewer decision is counted
+// once by false-positive learning.
+func (s *ReviewPropagationService) Propagate(ctx context.Context, findingID uuid.UUID, actor string) (*ReviewPropagationResult, error) {
+	settings, err := s.repo.GetReviewPropagationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return nil, fmt.Errorf("review propagation is not enabled for this tenant")
+	}
+
+	if _, err := s.repo.GetFindingByID(ctx, findingID); err != nil {
+		return nil, fmt.Errorf("finding not found: %w", err)
+	}
+	state, err := s.repo.GetReviewStateByFindingID(ctx, findingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review state: %w", err)
+	}
+	if state == nil || !propagatableDecisions[state.Status] {
+		return nil, fmt.Errorf("invalid propagation: finding %s has no false_positive or confirmed decision to propagate", findingID)
+	}
+
+	identical, err := s.repo.ListIdenticalFindings(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	affected, skipped := splitPropagationTargets(identical)
+	targets := make([]uuid.UUID, len(affected))
+	previous := make(map[uuid.UUID]string, len(affected))
+	for i, f := range affected {
+		targets[i] = f.FindingID
+		previous[f.FindingID] = f.ReviewStatus
+	}
+
+	comments := fmt.Sprintf("Propagated from finding %s", findingID)
+	propagated, err := s.repo.PropagateReviewDecision(ctx, findingID, state.Status, actor, comments, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		for _, id := range propagated {
+			if err := s.auditLogger.Record(ctx, "REVIEW_DECISION_PROPAGATED", "finding", id.String(), map[string]interface{}{
+				"source_finding_id": findingID,
+				"status":            state.Status,
+				"previous_status":   previous[id],
+				"source_reviewer":   state.ReviewedBy,
+				"propagated_by":     actor,
+			}); err != nil {
+				log.Printf("WARNING: Failed to audit propagated review of finding %s: %v", id, err)
+			}
+		}
+	}
+
+	return &ReviewPropagationResult{
+		SourceFindingID: findingID,
+		Decision:        state.Status,
+		Propagated:      propagated,
+		// Targets decided by a reviewer between the listing and the update count as skipped
+		Skipped: len(skipped) + len(targets) - len(propagated),
+	}, nil
+}
+
+// splitPropagationTargets separates findings that may inherit a decision, those
+// never reviewed or still pending, from those a reviewer already decided
+func splitPropagationTargets(findings []*entity.IdenticalFinding) (affected, skipped []*entity.IdenticalFinding) {
+	affected, skipped = []*entity.IdenticalFinding{}, []*entity.IdenticalFinding{}
+	for _, f := range findings {
+		if f.ReviewStatus == "" || f.ReviewStatus == "pending" {
+			affected = append(affected, f)
+		} else {
+			skipped = append(skipped, f)
+		}
+	}
+	return affected, skipped
+}
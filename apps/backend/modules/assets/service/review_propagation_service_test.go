@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestSplitPropagationTargets(t *testing.T) {
+	unreviewed := &entity.IdenticalFinding{FindingID: uuid.New()}
+	pending := &entity.IdenticalFinding{FindingID: uuid.New(), ReviewStatus: "pending"}
+	confirmed := &entity.IdenticalFinding{FindingID: uuid.New(), ReviewStatus: "confirmed"}
+	rejected := &entity.IdenticalFinding{FindingID: uuid.New(), ReviewStatus: "false_positive"}
+
+	affected, skipped := splitPropagationTargets([]*entity.IdenticalFinding{unreviewed, confirmed, pending, rejected})
+
+	if len(affected) != 2 || affected[0] != unreviewed || affected[1] != pending {
+		t.Errorf("expected the unreviewed and pending findings to be affected, got %+v", affected)
+	}
+	if len(skipped) != 2 || skipped[0] != confirmed || skipped[1] != rejected {
+		t.Errorf("expected reviewer decisions to be skipped, got %+v", skipped)
+	}
+
+	affected, skipped = splitPropagationTargets(nil)
+	if affected == nil || skipped == nil {
+		t.Error("expected empty, non-nil lists so they encode as []")
+	}
+}
+
+func TestPropagatableDecisions(t *testing.T) {
+	for status, want := range map[string]bool{
+		"false_positive": true,
+		"confirmed":      true,
+		"pending":        false,
+		"resolved":       false,
+	} {
+		if propagatableDecisions[status] != want {
+			t.Errorf("propagatable(%q) = %v, want %v", status, !want, want)
+		}
+	}
+}
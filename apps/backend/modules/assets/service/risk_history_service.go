@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// Risk history query bounds
+const (
+	defaultRiskHistoryWindow = 90 * 24 * time.Hour
+	defaultRiskHistoryLimit  = 500
+	maxRiskHistoryLimit      = 5000
+)
+
+// RiskHistoryService serves the recorded risk score changes of assets
+type RiskHistoryService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewRiskHistoryService creates a new risk history service
+func NewRiskHistoryService(repo *persistence.PostgresRepository) *RiskHistoryService {
+	return &RiskHistoryService{repo: repo}
+}
+
+// RiskHistoryQuery selects the window of a risk history; zero values use the defaults
+type RiskHistoryQuery struct {
+	Since time.Time
+	Until time.Time
+	Limit int
+}
+
+// RiskHistory is an asset's risk score time series over a window
+type RiskHistory struct {
+	AssetID      uuid.UUID                `json:"asset_id"`
+	CurrentScore int                      `json:"current_score"`
+	Since        time.Time                `json:"since"`
+	Until        time.Time                `json:"until"`
+	Points       []*entity.RiskScorePoint `json:"points"`
+	Summary      RiskHistorySummary       `json:"summary"`
+}
+
+// RiskHistorySummary condenses the window for alerting. Regressed is set when the
+// score ended the window higher than it started.
+type RiskHistorySummary struct {
+	Changes    int  `json:"changes"`
+	StartScore int  `json:"start_score"`
+	EndScore   int  `json:"end_score"`
+	MinScore   int  `json:"min_score"`
+	MaxScore   int  `json:"max_score"`
+	NetChange  int  `json:"net_change"`
+	Increases  int  `json:"increases"`
+	Decreases  int  `json:"decreases"`
+	Regressed  bool `json:"regressed"`
+}
+
+// GetRiskHistory returns the asset's risk score changes within the query window
+func (s *RiskHistoryService) GetRiskHistory(ctx context.Context, assetID uuid.UUID, query RiskHistoryQuery) (*RiskHistory, error) {
+	if query.Until.IsZero() {
+		query.Until = time.Now().UTC()
+	}
+	if query.Since.IsZero() {
+		query.Since = query.Until.Add(-defaultRiskHistoryWindow)
+	}
+	if query.Since.After(query.Until) {
+		return nil, fmt.Errorf("invalid window: since must be before until")
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultRiskHistoryLimit
+	}
+	if query.Limit > maxRiskHistoryLimit {
+		query.Limit = maxRiskHistoryLimit
+	}
+
+	asset, err := s.repo.GetAssetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := s.repo.ListRiskScoreHistory(ctx, assetID, query.Since, query.Until, query.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RiskHistory{
+		AssetID:      assetID,
+		CurrentScore: asset.RiskScore,
+		Since:        query.Since,
+		Until:        query.Until,
+		Points:       points,
+		Summary:      summarizeRiskHistory(points, asset.RiskScore),
+	}, nil
+}
+
+// summarizeRiskHistory condenses points ordered oldest first. Without any change in
+// the window the score held at current throughout.
+func summarizeRiskHistory(points []*entity.RiskScorePoint, current int) RiskHistorySummary {
+	summary := RiskHistorySummary{StartScore: current, EndScore: current, MinScore: current, MaxScore: current}
+	if len(points) == 0 {
+		return summary
+	}
+
+	first := points[0]
+	summary.StartScore = first.Score
+	if first.PreviousScore != nil {
+		summary.StartScore = *first.PreviousScore
+	}
+	summary.EndScore = points[len(points)-1].Score
+	summary.MinScore, summary.MaxScore = summary.StartScore, summary.StartScore
+
+	for _, p := range points {
+		summary.Changes++
+		if p.Score < summary.MinScore {
+			summary.MinScore = p.Score
+		}
+		if p.Score > summary.MaxScore {
+			summary.MaxScore = p.Score
+		}
+		switch {
+		case p.Delta > 0:
+			summary.Increases++
+		case p.Delta < 0:
+			summary.Decreases++
+		}
+	}
+	summary.NetChange = summary.EndScore - summary.StartScore
+	summary.Regressed = summary.NetChange > 0
+	return summary
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestSummarizeRiskHistory(t *testing.T) {
+	prev := func(v int) *int { return &v }
+	points := []*entity.RiskScorePoint{
+		{Score: 75, PreviousScore: prev(40), Delta: 35, Cause: entity.RiskCauseScanIngestion},
+		{Score: 95, PreviousScore: prev(75), Delta: 20, Cause: entity.RiskCauseScanIngestion},
+		{Score: 10, PreviousScore: prev(95), Delta: -85, Cause: entity.RiskCauseScanIngestion},
+		{Score: 60, PreviousScore: prev(10), Delta: 50, Cause: entity.RiskCauseAssetMerge},
+	}
+
+	got := summarizeRiskHistory(points, 60)
+	want := RiskHistorySummary{
+		Changes: 4, StartScore: 40, EndScore: 60, MinScore: 10, MaxScore: 95,
+		NetChange: 20, Increases: 3, Decreases: 1, Regressed: true,
+	}
+	if got != want {
+		t.Errorf("summary = %+v, want %+v", got, want)
+	}
+
+	// A baseline row has no previous score; the window starts at its score
+	baseline := []*entity.RiskScorePoint{{Score: 85, Cause: entity.RiskCauseBaseline}, {Score: 40, PreviousScore: prev(85), Delta: -45}}
+	if got := summarizeRiskHistory(baseline, 40); got.StartScore != 85 || got.NetChange != -45 || got.Regressed {
+		t.Errorf("unexpected summary from baseline: %+v", got)
+	}
+
+	if got := summarizeRiskHistory(nil, 75); got.StartScore != 75 || got.EndScore != 75 || got.Changes != 0 || got.Regressed {
+		t.Errorf("unexpected summary without changes: %+v", got)
+	}
+}
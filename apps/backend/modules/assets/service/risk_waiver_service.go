@@ -0,0 +1,435 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/pathglob"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultWaiverMaxDays      = 365
+	defaultWaiverWarningDays  = 7
+	minWaiverJustification    = 20
+	waiverAlertTimeout        = 30 * time.Second
+	defaultWaiverSweepMinutes = 60
+)
+
+// RiskWaiverOptions bounds waivers and when their expiry is alerted
+type RiskWaiverOptions struct {
+	MaxDays     int // Longest a waiver may run
+	WarningDays int // Admins are alerted this many days before expiry
+}
+
+// RiskWaiverInput is the payload for accepting risk. Set finding_id to waive one
+// finding, or pattern_name with path_glob and/or host_glob to waive a scope.
+type RiskWaiverInput struct {
+	FindingID     *uuid.UUID `json:"finding_id,omitempty"`
+	PatternName   string     `json:"pattern_name,omitempty"`
+	PathGlob      string     `json:"path_glob,omitempty"`
+	HostGlob      string     `json:"host_glob,omitempty"`
+	Justification string     `json:"justification" binding:"required"`
+	ExpiresAt     time.Time  `json:"expires_at" binding:"required"`
+}
+
+// WaiverSweepResult summarizes one waiver sweep of a tenant
+type WaiverSweepResult struct {
+	Expired int `json:"expired"`
+	Warned  int `json:"warned"`
+	Waived  int `json:"waived"` // New findings put under a scope waiver
+}
+
+// RiskWaiverService manages time-boxed acceptance of risk. Waived findings are kept
+// and listed but left out of risk rollups until their waiver expires or is revoked.
+type RiskWaiverService struct {
+	repo         *persistence.PostgresRepository
+	auditLogger  interfaces.AuditLogger
+	opts         RiskWaiverOptions
+	events       interfaces.EventPublisher
+	mailer       mailer.Mailer // nil when SMTP is not configured
+	dashboardURL string
+	now          func() time.Time
+}
+
+// NewRiskWaiverService creates a new risk waiver service
+func NewRiskWaiverService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger, opts RiskWaiverOptions) *RiskWaiverService {
+	if opts.MaxDays <= 0 {
+		opts.MaxDays = defaultWaiverMaxDays
+	}
+	if opts.WarningDays <= 0 {
+		opts.WarningDays = defaultWaiverWarningDays
+	}
+	return &RiskWaiverService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		opts:        opts,
+		events:      &interfaces.NoOpEventPublisher{},
+		now:         time.Now,
+	}
+}
+
+// SetAlerts enables expiry alerts: live events always, email when m is not nil
+func (s *RiskWaiverService) SetAlerts(events interfaces.EventPublisher, m mailer.Mailer, dashboardURL string) {
+	if events != nil {
+		s.events = events
+	}
+	s.mailer = m
+	s.dashboardURL = dashboardURL
+}
+
+// Create validates a waiver, stores it and waives the findings it covers now
+func (s *RiskWaiverService) Create(ctx context.Context, input RiskWaiverInput, createdBy string) (*entity.RiskWaiver, error) {
+	waiver := &entity.RiskWaiver{
+		ID:            uuid.New(),
+		FindingID:     input.FindingID,
+		PatternName:   strings.ToUpper(strings.TrimSpace(input.PatternName)),
+		PathGlob:      strings.TrimSpace(input.PathGlob),
+		HostGlob:      strings.ToLower(strings.TrimSpace(input.HostGlob)),
+		Justification: strings.TrimSpace(input.Justification),
+		Status:        entity.RiskWaiverActive,
+		ExpiresAt:     input.ExpiresAt.UTC(),
+		CreatedBy:     createdBy,
+	}
+	if err := validateRiskWaiver(waiver, s.now(), s.opts.MaxDays); err != nil {
+		return nil, err
+	}
+
+	// Resolve the finding first so only the tenant's own findings are waived
+	if waiver.FindingID != nil {
+		if _, err := s.repo.GetFindingByID(ctx, *waiver.FindingID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.CreateRiskWaiver(ctx, waiver); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if waiver.FindingID != nil {
+		// A waiver for one finding takes it over from any scope waiver
+		waiver.WaivedFindings, err = s.repo.WaiveFindings(ctx, waiver.ID, []uuid.UUID{*waiver.FindingID}, true)
+	} else {
+		waiver.WaivedFindings, err = s.applyScope(ctx, waiver)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "RISK_WAIVER_CREATED", waiver, map[string]interface{}{
+		"finding_id":      waiver.FindingID,
+		"pattern_name":    waiver.PatternName,
+		"path_glob":       waiver.PathGlob,
+		"host_glob":       waiver.HostGlob,
+		"justification":   waiver.Justification,
+		"expires_at":      waiver.ExpiresAt,
+		"waived_findings": waiver.WaivedFindings,
+		"actor":           createdBy,
+	})
+	return waiver, nil
+}
+
+// Get returns a waiver
+func (s *RiskWaiverService) Get(ctx context.Context, id uuid.UUID) (*entity.RiskWaiver, error) {
+	return s.repo.GetRiskWaiver(ctx, id)
+}
+
+// List returns the tenant's waivers with the given status, or all of them
+func (s *RiskWaiverService) List(ctx context.Context, status string) ([]*entity.RiskWaiver, error) {
+	switch status {
+	case "", entity.RiskWaiverActive, entity.RiskWaiverExpired, entity.RiskWaiverRevoked:
+	default:
+		return nil, fmt.Errorf("invalid status: %s", status)
+	}
+	return s.repo.ListRiskWaivers(ctx, status)
+}
+
+// Revoke ends a waiver before it expires. Its findings count toward risk again
+// unless another active waiver covers them.
+func (s *RiskWaiverService) Revoke(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.RiskWaiver, error) {
+	if err := s.repo.RevokeRiskWaiver(ctx, id, revokedBy); err != nil {
+		return nil, err
+	}
+	if _, err := s.applyActive(ctx); err != nil {
+		log.Printf("WARNING: Failed to reapply risk waivers after revoking %s: %v", id, err)
+	}
+	waiver, err := s.repo.GetRiskWaiver(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.audit(ctx, "RISK_WAIVER_REVOKED", waiver, map[string]interface{}{"actor": revokedBy})
+	return waiver, nil
+}
+
+// Sweep expires the tenant's lapsed waivers, alerts admins about waivers that are
+// about to expire and puts new findings under the scope waivers that cover them
+func (s *RiskWaiverService) Sweep(ctx context.Context) (*WaiverSweepResult, error) {
+	now := s.now()
+	result := &WaiverSweepResult{}
+
+	expired, err := s.repo.ExpireRiskWaivers(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, waiver := range expired {
+		s.audit(ctx, "RISK_WAIVER_EXPIRED", waiver, map[string]interface{}{"expires_at": waiver.ExpiresAt})
+		s.alert(ctx, interfaces.EventRiskWaiverExpired, waiver)
+	}
+	result.Expired = len(expired)
+
+	expiring, err := s.repo.ListExpiringRiskWaivers(ctx, now.AddDate(0, 0, s.opts.WarningDays))
+	if err != nil {
+		return nil, err
+	}
+	for _, waiver := range expiring {
+		if err := s.repo.MarkRiskWaiverWarned(ctx, waiver.ID); err != nil {
+			return nil, err
+		}
+		s.alert(ctx, interfaces.EventRiskWaiverExpiring, waiver)
+		result.Warned++
+	}
+
+	// Findings released by an expired waiver may fall under another one
+	if result.Waived, err = s.applyActive(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StartSweepWorker periodically sweeps the waivers of every tenant that has active ones
+func (s *RiskWaiverService) StartSweepWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = defaultWaiverSweepMinutes
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🕰️  Starting risk waiver sweep worker (interval: %d minutes, warning: %d days)", intervalMinutes, s.opts.WarningDays)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Risk waiver sweep worker stopped")
+			return
+		case <-ticker.C:
+			s.sweepAllTenants(ctx)
+		}
+	}
+}
+
+func (s *RiskWaiverService) sweepAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListRiskWaiverTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for the risk waiver sweep: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		result, err := s.Sweep(tenantCtx)
+		if err != nil {
+			log.Printf("❌ Risk waiver sweep failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if result.Expired > 0 || result.Warned > 0 || result.Waived > 0 {
+			log.Printf("✅ Risk waivers for tenant %s: %d expired, %d expiring alerted, %d finding(s) waived",
+				tenantID, result.Expired, result.Warned, result.Waived)
+		}
+	}
+}
+
+// applyActive puts unwaived findings under the active scope waivers covering them
+func (s *RiskWaiverService) applyActive(ctx context.Context) (int, error) {
+	waivers, err := s.repo.ListRiskWaivers(ctx, entity.RiskWaiverActive)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, waiver := range waivers {
+		if waiver.FindingID != nil {
+			continue
+		}
+		n, err := s.applyScope(ctx, waiver)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// applyScope waives the unwaived findings inside a scope waiver's pattern and globs
+func (s *RiskWaiverService) applyScope(ctx context.Context, waiver *entity.RiskWaiver) (int, error) {
+	scope, err := compileWaiverScope(waiver)
+	if err != nil {
+		return 0, err
+	}
+	candidates, err := s.repo.ListWaiverCandidates(ctx, waiver.PatternName)
+	if err != nil {
+		return 0, err
+	}
+	var ids []uuid.UUID
+	for _, c := range candidates {
+		if scope.covers(c.Path, c.Host) {
+			ids = append(ids, c.FindingID)
+		}
+	}
+	return s.repo.WaiveFindings(ctx, waiver.ID, ids, false)
+}
+
+// alert tells the tenant's admins and the waiver's author that it expired or is about to
+func (s *RiskWaiverService) alert(ctx context.Context, eventType string, waiver *entity.RiskWaiver) {
+	s.events.Publish(ctx, eventType, map[string]interface{}{
+		"waiver_id":       waiver.ID,
+		"finding_id":      waiver.FindingID,
+		"pattern_name":    waiver.PatternName,
+		"expires_at":      waiver.ExpiresAt,
+		"waived_findings": waiver.WaivedFindings,
+		"status":          waiver.Status,
+	})
+
+	if s.mailer == nil {
+		return
+	}
+	to, err := s.alertRecipients(ctx, waiver)
+	if err != nil {
+		log.Printf("WARNING: Failed to load recipients for risk waiver %s: %v", waiver.ID, err)
+		return
+	}
+	if len(to) == 0 {
+		return
+	}
+	msg := renderWaiverAlert(waiver, to, s.dashboardURL)
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), waiverAlertTimeout)
+		defer cancel()
+		if err := s.mailer.Send(sendCtx, msg); err != nil {
+			log.Printf("WARNING: Failed to send risk waiver alert: %v", err)
+		}
+	}()
+}
+
+func (s *RiskWaiverService) alertRecipients(ctx context.Context, waiver *entity.RiskWaiver) ([]string, error) {
+	users, err := s.repo.GetUsersByTenant(ctx, waiver.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	var to []string
+	for _, u := range users {
+		if u.IsActive && (u.Role == authentity.RoleAdmin || strings.EqualFold(u.Email, waiver.CreatedBy)) {
+			to = append(to, u.Email)
+		}
+	}
+	return to, nil
+}
+
+func (s *RiskWaiverService) audit(ctx context.Context, action string, waiver *entity.RiskWaiver, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, "risk_waiver", waiver.ID.String(), metadata); err != nil {
+		log.Printf("WARNING: Failed to audit %s for risk waiver %s: %v", action, waiver.ID, err)
+	}
+}
+
+// validateRiskWaiver checks a waiver is justified, time-boxed and scoped to one
+// finding or a pattern narrowed by a glob
+func validateRiskWaiver(waiver *entity.RiskWaiver, now time.Time, maxDays int) error {
+	if len(waiver.Justification) < minWaiverJustification {
+		return fmt.Errorf("invalid waiver: justification must be at least %d characters", minWaiverJustification)
+	}
+	if !waiver.ExpiresAt.After(now) {
+		return fmt.Errorf("invalid waiver: expires_at must be in the future")
+	}
+	if waiver.ExpiresAt.After(now.AddDate(0, 0, maxDays)) {
+		return fmt.Errorf("invalid waiver: expires_at may be at most %d days away", maxDays)
+	}
+
+	if waiver.FindingID != nil {
+		if waiver.PatternName != "" || waiver.PathGlob != "" || waiver.HostGlob != "" {
+			return fmt.Errorf("invalid waiver: set either finding_id or a pattern scope, not both")
+		}
+		return nil
+	}
+	if waiver.PatternName == "" {
+		return fmt.Errorf("invalid waiver: finding_id or pattern_name must be set")
+	}
+	// A scope covering every path on every host would waive the whole pattern
+	if waiver.PathGlob == "" && waiver.HostGlob == "" {
+		return fmt.Errorf("invalid waiver: at least one of path_glob or host_glob must be set")
+	}
+	_, err := compileWaiverScope(waiver)
+	return err
+}
+
+// waiverScope is a scope waiver with its globs compiled for matching
+type waiverScope struct {
+	path *regexp.Regexp // nil matches any path
+	host *regexp.Regexp // nil matches any host
+}
+
+func compileWaiverScope(waiver *entity.RiskWaiver) (*waiverScope, error) {
+	scope := &waiverScope{}
+	var err error
+	if waiver.PathGlob != "" {
+		if scope.path, err = pathglob.Compile(waiver.PathGlob); err != nil {
+			return nil, fmt.Errorf("invalid waiver: path_glob contains invalid pattern %q: %w", waiver.PathGlob, err)
+		}
+	}
+	if waiver.HostGlob != "" {
+		if scope.host, err = pathglob.Compile(strings.ToLower(waiver.HostGlob)); err != nil {
+			return nil, fmt.Errorf("invalid waiver: host_glob contains invalid pattern %q: %w", waiver.HostGlob, err)
+		}
+	}
+	return scope, nil
+}
+
+func (w *waiverScope) covers(path, host string) bool {
+	if w.path != nil && !w.path.MatchString(path) {
+		return false
+	}
+	return w.host == nil || w.host.MatchString(strings.ToLower(host))
+}
+
+func renderWaiverAlert(waiver *entity.RiskWaiver, to []string, dashboardURL string) mailer.Message {
+	subject := fmt.Sprintf("[ARC-Hawk] Risk waiver expires %s", waiver.ExpiresAt.UTC().Format("2 Jan 2006"))
+	lead := "A risk waiver is about to expire. Its findings will count toward risk again unless it is renewed."
+	if waiver.Status == entity.RiskWaiverExpired {
+		subject = "[ARC-Hawk] Risk waiver expired"
+		lead = "A risk waiver has expired. Its findings count toward risk again and are back on the dashboards."
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", lead)
+	fmt.Fprintf(&b, "Waiver:        %s\n", waiver.ID)
+	if waiver.FindingID != nil {
+		fmt.Fprintf(&b, "Finding:       %s\n", waiver.FindingID)
+	} else {
+		fmt.Fprintf(&b, "Scope:         %s", waiver.PatternName)
+		if waiver.PathGlob != "" {
+			fmt.Fprintf(&b, " under %s", waiver.PathGlob)
+		}
+		if waiver.HostGlob != "" {
+			fmt.Fprintf(&b, " on %s", waiver.HostGlob)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "Findings:      %d\n", waiver.WaivedFindings)
+	fmt.Fprintf(&b, "Expires:       %s\n", waiver.ExpiresAt.UTC().Format(time.RFC1123))
+	fmt.Fprintf(&b, "Accepted by:   %s\n", waiver.CreatedBy)
+	fmt.Fprintf(&b, "Justification: %s\n", waiver.Justification)
+	if dashboardURL != "" {
+		fmt.Fprintf(&b, "\nReview it at %s/waivers\n", strings.TrimRight(dashboardURL, "/"))
+	}
+	return mailer.Message{To: to, Subject: subject, Body: b.String()}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestValidateRiskWaiver(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	findingID := uuid.New()
+	justified := "Test fixtures reviewed by the DPO; masked before release"
+
+	tests := []struct {
+		name    string
+		waiver  entity.RiskWaiver
+		wantErr string
+	}{
+		{"single finding", entity.RiskWaiver{FindingID: &findingID, Justification: justified, ExpiresAt: now.AddDate(0, 0, 14)}, ""},
+		{"scope", entity.RiskWaiver{PatternName: "IN_PAN", PathGlob: "/data/fixtures/**", Justification: justified, ExpiresAt: now.AddDate(0, 0, 14)}, ""},
+		{"short justification", entity.RiskWaiver{FindingID: &findingID, Justification: "ok", ExpiresAt: now.AddDate(0, 0, 14)}, "justification"},
+		{"already expired", entity.RiskWaiver{FindingID: &findingID, Justification: justified, ExpiresAt: now}, "future"},
+		{"too long", entity.RiskWaiver{FindingID: &findingID, Justification: justified, ExpiresAt: now.AddDate(0, 0, 31)}, "at most 30 days"},
+		{"finding and scope", entity.RiskWaiver{FindingID: &findingID, PatternName: "IN_PAN", Justification: justified, ExpiresAt: now.AddDate(0, 0, 1)}, "not both"},
+		{"no target", entity.RiskWaiver{Justification: justified, ExpiresAt: now.AddDate(0, 0, 1)}, "must be set"},
+		{"whole pattern", entity.RiskWaiver{PatternName: "IN_PAN", Justification: justified, ExpiresAt: now.AddDate(0, 0, 1)}, "path_glob or host_glob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRiskWaiver(&tt.waiver, now, 30)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWaiverScopeCovers(t *testing.T) {
+	scope, err := compileWaiverScope(&entity.RiskWaiver{PatternName: "IN_PAN", PathGlob: "/data/fixtures/**", HostGlob: "QA-*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path, host string
+		want       bool
+	}{
+		{"/data/fixtures/customers.csv", "qa-fs01", true},
+		{"/data/fixtures/nested/pan.txt", "QA-FS02", true},
+		{"/data/prod/customers.csv", "qa-fs01", false},
+		{"/data/fixtures/customers.csv", "prod-fs01", false},
+	}
+	for _, tt := range tests {
+		if got := scope.covers(tt.path, tt.host); got != tt.want {
+			t.Errorf("covers(%q, %q) = %v, want %v", tt.path, tt.host, got, tt.want)
+		}
+	}
+
+	anyHost, err := compileWaiverScope(&entity.RiskWaiver{PatternName: "IN_PAN", PathGlob: "/tmp/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !anyHost.covers("/tmp/export.csv", "anything") {
+		t.Error("expected an empty host glob to match any host")
+	}
+}
+
+func TestRenderWaiverAlert(t *testing.T) {
+	waiver := &entity.RiskWaiver{
+		ID:             uuid.New(),
+		PatternName:    "IN_PAN",
+		PathGlob:       "/data/fixtures/**",
+		Justification:  "Synthetic PANs used by the test suite",
+		Status:         entity.RiskWaiverActive,
+		ExpiresAt:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		WaivedFindings: 12,
+		CreatedBy:      "dpo@example.in",
+	}
+
+	msg := renderWaiverAlert(waiver, []string{"admin@example.in"}, "https://arc.example.in/")
+	if !strings.Contains(msg.Subject, "expires 1 Apr 2026") {
+		t.Errorf("unexpected subject %q", msg.Subject)
+	}
+	for _, want := range []string{"about to expire", "IN_PAN under /data/fixtures/**", "Findings:      12", "https://arc.example.in/waivers"} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("expected %q in the body:\n%s", want, msg.Body)
+		}
+	}
+
+	waiver.Status = entity.RiskWaiverExpired
+	if msg := renderWaiverAlert(waiver, []string{"admin@example.in"}, ""); msg.Subject != "[ARC-Hawk] Risk waiver expired" || !strings.Contains(msg.Body, "back on the dashboards") {
+		t.Errorf("unexpected expired alert %q:\n%s", msg.Subject, msg.Body)
+	}
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultScanFrequencyHours = 168 // One week
+	maxScanFrequencyHours     = 24 * 366
+	defaultFreshnessMinutes   = 60
+	defaultStaleAssetLimit    = 200
+	maxStaleAssetLimit        = 1000
+
+	// Risk points an overdue asset carries until its next scan: what was last
+	// seen in it may no longer be what it holds
+	freshnessPenaltyOverdue = 5
+	freshnessPenaltySevere  = 10 // Overdue by a whole frequency or more
+)
+
+// FreshnessEvaluation summarizes one SLA evaluation of a tenant's assets
+type FreshnessEvaluation struct {
+	Evaluated    int `json:"evaluated"`
+	NewlyOverdue int `json:"newly_overdue"`
+	Rescored     int `json:"rescored"` // Assets whose freshness penalty changed
+	Cleared      int `json:"cleared"`  // Assets no longer overdue without a new scan
+}
+
+// ScanFreshnessService holds assets to a scan frequency. Assets overdue for a
+// scan are flagged and carry a freshness penalty in their risk score until
+// ingestion records a new scan of them.
+type ScanFreshnessService struct {
+	repo         *persistence.PostgresRepository
+	auditLogger  interfaces.AuditLogger
+	defaultHours int
+	events       interfaces.EventPublisher
+	now          func() time.Time
+}
+
+// NewScanFreshnessService creates a new scan freshness service. Assets without
+// their own frequency must be scanned every defaultHours.
+func NewScanFreshnessService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger, defaultHours int) *ScanFreshnessService {
+	if defaultHours <= 0 {
+		defaultHours = defaultScanFrequencyHours
+	}
+	return &ScanFreshnessService{
+		repo:         repo,
+		auditLogger:  auditLogger,
+		defaultHours: defaultHours,
+		events:       &interfaces.NoOpEventPublisher{},
+		now:          time.Now,
+	}
+}
+
+// SetEventPublisher enables live events for assets that become overdue
+func (s *ScanFreshnessService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// ListStale returns the assets overdue for a scan, longest overdue first
+func (s *ScanFreshnessService) ListStale(ctx context.Context, limit int) ([]*entity.AssetFreshness, error) {
+	if limit <= 0 {
+		limit = defaultStaleAssetLimit
+	}
+	if limit > maxStaleAssetLimit {
+		limit = maxStaleAssetLimit
+	}
+	now := s.now()
+	assets, err := s.repo.ListOverdueAssets(ctx, s.defaultHours, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range assets {
+		evaluateFreshness(f, s.defaultHours, now)
+	}
+	return assets, nil
+}
+
+// Get returns the scan freshness of one asset
+func (s *ScanFreshnessService) Get(ctx context.Context, assetID uuid.UUID) (*entity.AssetFreshness, error) {
+	f, err := s.repo.GetAssetFreshness(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	evaluateFreshness(f, s.defaultHours, s.now())
+	return f, nil
+}
+
+// SetFrequency sets how often an asset must be scanned, or restores the default
+// when hours is nil, and re-evaluates the asset against it straight away
+func (s *ScanFreshnessService) SetFrequency(ctx context.Context, assetID uuid.UUID, hours *int, actor string) (*entity.AssetFreshness, error) {
+	if hours != nil && (*hours < 1 || *hours > maxScanFrequencyHours) {
+		return nil, fmt.Errorf("invalid scan frequency: must be between 1 and %d hours", maxScanFrequencyHours)
+	}
+	if err := s.repo.SetAssetScanFrequency(ctx, assetID, hours); err != nil {
+		return nil, err
+	}
+
+	f, err := s.Get(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.apply(ctx, f); err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		if err := s.auditLogger.Record(ctx, "ASSET_SCAN_FREQUENCY_SET", "asset", assetID.String(), map[string]interface{}{
+			"expected_frequency_hours": hours,
+			"actor":                    actor,
+		}); err != nil {
+			log.Printf("WARN: Failed to audit scan frequency change: %v", err)
+		}
+	}
+	return s.Get(ctx, assetID)
+}
+
+// Evaluate flags the tenant's assets that are overdue for a scan and brings their
+// freshness penalty up to date
+func (s *ScanFreshnessService) Evaluate(ctx context.Context) (*FreshnessEvaluation, error) {
+	now := s.now()
+	candidates, err := s.repo.ListFreshnessCandidates(ctx, s.defaultHours, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FreshnessEvaluation{}
+	for _, f := range candidates {
+		evaluateFreshness(f, s.defaultHours, now)
+		change, err := s.apply(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		result.Evaluated++
+		switch change {
+		case freshnessOverdue:
+			result.NewlyOverdue++
+			result.Rescored++
+		case freshnessRescored:
+			result.Rescored++
+		case freshnessCleared:
+			result.Cleared++
+		}
+	}
+	return result, nil
+}
+
+// StartSLAWorker periodically evaluates the scan freshness of every tenant's assets
+func (s *ScanFreshnessService) StartSLAWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = defaultFreshnessMinutes
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🕰️  Starting scan freshness SLA worker (interval: %d minutes, default frequency: %d hours)", intervalMinutes, s.defaultHours)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Scan freshness SLA worker stopped")
+			return
+		case <-ticker.C:
+			s.evaluateAllTenants(ctx)
+		}
+	}
+}
+
+func (s *ScanFreshnessService) evaluateAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListAssetTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for the scan freshness SLA: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		result, err := s.Evaluate(tenantCtx)
+		if err != nil {
+			log.Printf("❌ Scan freshness evaluation failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if result.NewlyOverdue > 0 || result.Rescored > 0 || result.Cleared > 0 {
+			log.Printf("✅ Scan freshness for tenant %s: %d newly overdue, %d rescored, %d cleared",
+				tenantID, result.NewlyOverdue, result.Rescored, result.Cleared)
+		}
+	}
+}
+
+type freshnessChange int
+
+const (
+	freshnessUnchanged freshnessChange = iota
+	freshnessOverdue                   // The asset became overdue
+	freshnessRescored                  // The asset's penalty changed while it stayed overdue
+	freshnessCleared                   // The asset stopped being overdue without a scan
+)
+
+// apply stores an evaluated asset's penalty and overdue flag when they changed
+func (s *ScanFreshnessService) apply(ctx context.Context, f *entity.AssetFreshness) (freshnessChange, error) {
+	penalty := freshnessPenalty(f)
+	flagged := f.OverdueSince != nil
+	if penalty == f.FreshnessPenalty && flagged == f.Overdue {
+		return freshnessUnchanged, nil
+	}
+
+	var overdueSince *time.Time
+	if f.Overdue {
+		overdueSince = &f.DueAt
+	}
+	if err := s.repo.SetFreshnessPenalty(ctx, f.AssetID, penalty, overdueSince); err != nil {
+		return freshnessUnchanged, err
+	}
+	f.RiskScore = clampScore(f.RiskScore - f.FreshnessPenalty + penalty)
+	f.FreshnessPenalty = penalty
+
+	switch {
+	case f.Overdue && !flagged:
+		f.OverdueSince = overdueSince
+		s.events.Publish(ctx, interfaces.EventAssetScanOverdue, f)
+		if s.auditLogger != nil {
+			if err := s.auditLogger.Record(ctx, "ASSET_SCAN_OVERDUE", "asset", f.AssetID.String(), map[string]interface{}{
+				"due_at":                   f.DueAt,
+				"expected_frequency_hours": f.ExpectedFrequencyHours,
+				"freshness_penalty":        penalty,
+			}); err != nil {
+				log.Printf("WARN: Failed to audit overdue asset: %v", err)
+			}
+		}
+		return freshnessOverdue, nil
+	case !f.Overdue:
+		f.OverdueSince = nil
+		return freshnessCleared, nil
+	default:
+		return freshnessRescored, nil
+	}
+}
+
+// evaluateFreshness fills in an asset's frequency, due date and how overdue it is.
+// Assets never scanned are due one frequency after they were discovered.
+func evaluateFreshness(f *entity.AssetFreshness, defaultHours int, now time.Time) {
+	if !f.CustomFrequency {
+		f.ExpectedFrequencyHours = defaultHours
+	}
+	baseline := f.DiscoveredAt
+	f.NeverScanned = f.LastScannedAt == nil
+	if !f.NeverScanned {
+		baseline = *f.LastScannedAt
+	}
+	f.DueAt = baseline.Add(time.Duration(f.ExpectedFrequencyHours) * time.Hour)
+	f.Overdue = now.After(f.DueAt)
+	f.OverdueHours = 0
+	if f.Overdue {
+		f.OverdueHours = int(now.Sub(f.DueAt) / time.Hour)
+	}
+}
+
+// freshnessPenalty is the risk an evaluated asset carries for being overdue: a
+// flat penalty, doubled once it has gone a whole extra frequency without a scan
+func freshnessPenalty(f *entity.AssetFreshness) int {
+	switch {
+	case !f.Overdue:
+		return 0
+	case f.OverdueHours >= f.ExpectedFrequencyHours:
+		return freshnessPenaltySevere
+	default:
+		return freshnessPenaltyOverdue
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestEvaluateFreshness(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	scanned := func(ago time.Duration) *time.Time {
+		at := now.Add(-ago)
+		return &at
+	}
+
+	cases := []struct {
+		name         string
+		asset        entity.AssetFreshness
+		wantDue      time.Time
+		wantOverdue  int // Hours; -1 when not overdue
+		wantPenalty  int
+		neverScanned bool
+	}{
+		{
+			name:        "scanned within the default frequency",
+			asset:       entity.AssetFreshness{LastScannedAt: scanned(24 * time.Hour)},
+			wantDue:     now.Add(6 * 24 * time.Hour),
+			wantOverdue: -1,
+		},
+		{
+			name:        "overdue on the default frequency",
+			asset:       entity.AssetFreshness{LastScannedAt: scanned(170 * time.Hour)},
+			wantDue:     now.Add(-2 * time.Hour),
+			wantOverdue: 2,
+			wantPenalty: freshnessPenaltyOverdue,
+		},
+		{
+			name:        "a custom frequency overrides the default",
+			asset:       entity.AssetFreshness{LastScannedAt: scanned(30 * time.Hour), ExpectedFrequencyHours: 24, CustomFrequency: true},
+			wantDue:     now.Add(-6 * time.Hour),
+			wantOverdue: 6,
+			wantPenalty: freshnessPenaltyOverdue,
+		},
+		{
+			name:        "a whole frequency overdue doubles the penalty",
+			asset:       entity.AssetFreshness{LastScannedAt: scanned(48 * time.Hour), ExpectedFrequencyHours: 24, CustomFrequency: true},
+			wantDue:     now.Add(-24 * time.Hour),
+			wantOverdue: 24,
+			wantPenalty: freshnessPenaltySevere,
+		},
+		{
+			name:         "never scanned assets count from discovery",
+			asset:        entity.AssetFreshness{DiscoveredAt: now.Add(-200 * time.Hour)},
+			wantDue:      now.Add(-32 * time.Hour),
+			wantOverdue:  32,
+			wantPenalty:  freshnessPenaltyOverdue,
+			neverScanned: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := tc.asset
+			evaluateFreshness(&f, 168, now)
+			if !f.DueAt.Equal(tc.wantDue) {
+				t.Errorf("due at %v, want %v", f.DueAt, tc.wantDue)
+			}
+			if f.Overdue != (tc.wantOverdue >= 0) {
+				t.Errorf("overdue = %v, want %v", f.Overdue, tc.wantOverdue >= 0)
+			}
+			if tc.wantOverdue >= 0 && f.OverdueHours != tc.wantOverdue {
+				t.Errorf("overdue by %d hours, want %d", f.OverdueHours, tc.wantOverdue)
+			}
+			if f.NeverScanned != tc.neverScanned {
+				t.Errorf("never scanned = %v, want %v", f.NeverScanned, tc.neverScanned)
+			}
+			if got := freshnessPenalty(&f); got != tc.wantPenalty {
+				t.Errorf("penalty %d, want %d", got, tc.wantPenalty)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+// ErrInvalidValueHash is returned for a value hash that is not hex SHA-256
+var ErrInvalidValueHash = errors.New("value hash must be 64 hexadecimal characters")
+
+var valueHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ValueOccurrenceService links findings holding the same normalized value across assets
+type ValueOccurrenceService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewValueOccurrenceService creates a new value occurrence service
+func NewValueOccurrenceService(repo *persistence.PostgresRepository) *ValueOccurrenceService {
+	return &ValueOccurrenceService{repo: repo}
+}
+
+// GetValueOccurrences returns every asset a value hash was observed in
+func (s *ValueOccurrenceService) GetValueOccurrences(ctx context.Context, valueHash string) (*entity.ValueOccurrences, error) {
+	valueHash = strings.ToLower(valueHash)
+	if !valueHashPattern.MatchString(valueHash) {
+		return nil, ErrInvalidValueHash
+	}
+
+	occurrences, err := s.repo.ListValueOccurrences(ctx, valueHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(occurrences) == 0 {
+		return nil, fmt.Errorf("value %s not found", valueHash)
+	}
+
+	return buildValueOccurrences(valueHash, occurrences), nil
+}
+
+// buildValueOccurrences counts the assets and systems holding a value and describes its spread
+func buildValueOccurrences(valueHash string, occurrences []entity.ValueOccurrence) *entity.ValueOccurrences {
+	systems := make(map[string]bool)
+	patterns := make(map[string]bool)
+	for _, o := range occurrences {
+		systems[o.System] = true
+		for _, p := range o.PatternNames {
+			patterns[p] = true
+		}
+	}
+
+	patternNames := make([]string, 0, len(patterns))
+	for p := range patterns {
+		patternNames = append(patternNames, p)
+	}
+	sort.Strings(patternNames)
+
+	label := "This value"
+	if len(patternNames) == 1 {
+		label = "This " + patternNames[0] + " value"
+	}
+
+	return &entity.ValueOccurrences{
+		ValueHash:    valueHash,
+		PatternNames: patternNames,
+		AssetCount:   len(occurrences),
+		SystemCount:  len(systems),
+		Summary: fmt.Sprintf("%s appears in %s across %s", label,
+			pluralize(len(occurrences), "asset"), pluralize(len(systems), "system")),
+		Occurrences: occurrences,
+	}
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
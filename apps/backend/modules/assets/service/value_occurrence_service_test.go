@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestBuildValueOccurrences(t *testing.T) {
+	occurrences := []entity.ValueOccurrence{
+		{AssetName: "customers", System: "postgresql", PatternNames: []string{"Aadhaar"}},
+		{AssetName: "exports/customers.csv", System: "s3", PatternNames: []string{"Aadhaar"}},
+		{AssetName: "kyc", System: "postgresql", PatternNames: []string{"Aadhaar"}},
+	}
+
+	got := buildValueOccurrences("abc", occurrences)
+	if got.AssetCount != 3 || got.SystemCount != 2 {
+		t.Errorf("counts = %d assets, %d systems; want 3, 2", got.AssetCount, got.SystemCount)
+	}
+	if want := "This Aadhaar value appears in 3 assets across 2 systems"; got.Summary != want {
+		t.Errorf("summary = %q, want %q", got.Summary, want)
+	}
+
+	// A value matched by several patterns is not named after any one of them
+	single := buildValueOccurrences("abc", []entity.ValueOccurrence{
+		{System: "s3", PatternNames: []string{"PAN", "Passport"}},
+	})
+	if want := "This value appears in 1 asset across 1 system"; single.Summary != want {
+		t.Errorf("summary = %q, want %q", single.Summary, want)
+	}
+	if len(single.PatternNames) != 2 {
+		t.Errorf("pattern names = %v", single.PatternNames)
+	}
+}
+
+func TestGetValueOccurrencesRejectsInvalidHash(t *testing.T) {
+	s := NewValueOccurrenceService(nil)
+	for _, hash := range []string{"", "abc", strings.Repeat("g", 64)} {
+		if _, err := s.GetValueOccurrences(context.Background(), hash); !errors.Is(err, ErrInvalidValueHash) {
+			t.Errorf("hash %q: expected ErrInvalidValueHash, got %v", hash, err)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/arc-platform/backend/modules/auth/entity"
 	"github.com/arc-platform/backend/modules/auth/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -13,14 +14,14 @@ import (
 
 type AuthHandler struct {
 	userService *service.UserService
-	jwtService  *service.JWTService
+	sessions    *service.SessionService
 	repo        *persistence.PostgresRepository
 }
 
-func NewAuthHandler(repo *persistence.PostgresRepository) *AuthHandler {
+func NewAuthHandler(repo *persistence.PostgresRepository, sessions *service.SessionService) *AuthHandler {
 	return &AuthHandler{
 		userService: service.NewUserService(repo),
-		jwtService:  service.NewJWTService(),
+		sessions:    sessions,
 		repo:        repo,
 	}
 }
@@ -71,11 +72,7 @@ type ErrorResponse struct {
 
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -87,20 +84,35 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, accessToken, refreshToken, err := h.userService.Authenticate(c.Request.Context(), req.Email, req.Password, req.TenantID)
+	user, err := h.userService.Authenticate(c.Request.Context(), req.Email, req.Password, req.TenantID)
 	if err != nil {
 		status := http.StatusUnauthorized
 		message := "Invalid credentials"
+		errorCode := "authentication_error"
 		if err == service.ErrUserInactive {
 			message = "User account is inactive"
 		}
+		if err == service.ErrSSORequired {
+			status = http.StatusForbidden
+			errorCode = "sso_required"
+			message = "Single sign-on is required for this organization"
+		}
 		c.JSON(status, ErrorResponse{
-			Error:   "authentication_error",
+			Error:   errorCode,
 			Message: message,
 		})
 		return
 	}
 
+	accessToken, refreshToken, err := h.sessions.Issue(c.Request.Context(), user, sessionMeta(c, service.AuthMethodPassword))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "token_error",
+			Message: "Failed to generate tokens",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, LoginResponse{
 		User:         user,
 		AccessToken:  accessToken,
@@ -112,11 +124,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -153,7 +161,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.jwtService.GenerateToken(user, uuid.New())
+	accessToken, refreshToken, err := h.sessions.Issue(c.Request.Context(), user, sessionMeta(c, service.AuthMethodPassword))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "token_error",
@@ -171,49 +179,43 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 }
 
+// Refresh rotates the refresh token: the presented token stops working and a
+// second use of it revokes the whole session
 func (h *AuthHandler) Refresh(c *gin.Context) {
 	var req RefreshRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: err.Error(),
-		})
-		return
-	}
-
-	claims, err := h.jwtService.ValidateRefreshToken(req.RefreshToken)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "invalid_token",
-			Message: "Invalid or expired refresh token",
-		})
-		return
-	}
-
-	userID, err := uuid.Parse(claims.UserID)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "invalid_token",
-			Message: "Invalid user ID in token",
-		})
-		return
-	}
-
-	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
-	if err != nil || !user.IsActive {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{
-			Error:   "user_not_found",
-			Message: "User not found or inactive",
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.jwtService.GenerateToken(user, uuid.New())
+	_, accessToken, refreshToken, err := h.sessions.Refresh(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "token_error",
-			Message: "Failed to generate tokens",
-		})
+		switch err {
+		case service.ErrRefreshTokenReused:
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "token_reused",
+				Message: "Refresh token was already used; please log in again",
+			})
+		case service.ErrSessionRevoked:
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "session_revoked",
+				Message: "Session has been revoked or has expired",
+			})
+		case service.ErrUserNotFound, service.ErrUserInactive:
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "user_not_found",
+				Message: "User not found or inactive",
+			})
+		case service.ErrInvalidToken, service.ErrTokenExpired, service.ErrInvalidClaims:
+			c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Error:   "invalid_token",
+				Message: "Invalid or expired refresh token",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error:   "token_error",
+				Message: "Failed to refresh tokens",
+			})
+		}
 		return
 	}
 
@@ -258,15 +260,13 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	}
 
 	var req ChangePasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
-	err := h.userService.ChangePassword(c.Request.Context(), userID.(uuid.UUID), req.CurrentPassword, req.NewPassword)
+	// Other sessions are signed out; the one changing the password stays
+	keepSessionID, _ := uuid.Parse(c.GetString("session_id"))
+	err := h.userService.ChangePassword(c.Request.Context(), userID.(uuid.UUID), keepSessionID, req.CurrentPassword, req.NewPassword)
 	if err != nil {
 		status := http.StatusInternalServerError
 		message := "Failed to change password"
@@ -362,11 +362,7 @@ func (h *AuthHandler) UpdateSettings(c *gin.Context) {
 	// We bind raw body mostly because we want to store it as is, or validation?
 	// The struct uses `map[string]interface{}` which is good for flexible JSON
 	var req SettingsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation_error",
-			Message: err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
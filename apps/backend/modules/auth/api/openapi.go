@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/auth/entity"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+)
+
+// OpenAPIOperations describes the auth routes for the OpenAPI document
+func OpenAPIOperations() []sharedapi.Operation {
+	return []sharedapi.Operation{
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/auth/login",
+			Summary:  "Log in with email and password",
+			Request:  LoginRequest{},
+			Response: LoginResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/auth/refresh",
+			Summary:  "Exchange a refresh token for a new access token",
+			Request:  RefreshRequest{},
+			Response: RefreshResponse{},
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/auth/stream-ticket",
+			Summary:  "Get a single-use ticket for the live event stream",
+			Response: StreamTicketResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/auth/profile",
+			Summary:  "Get the authenticated user",
+			Response: entity.User{},
+		},
+	}
+}
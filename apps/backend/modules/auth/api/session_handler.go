@@ -0,0 +1,290 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/arc-platform/backend/modules/auth/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SessionHandler lets users manage their own sessions and admins revoke
+// sessions and tokens across their tenant
+type SessionHandler struct {
+	sessions *service.SessionService
+}
+
+func NewSessionHandler(sessions *service.SessionService) *SessionHandler {
+	return &SessionHandler{sessions: sessions}
+}
+
+type RevokeSessionsRequest struct {
+	Reason string `json:"reason" binding:"max=100"`
+}
+
+type RevokeTokenRequest struct {
+	Token  string `json:"token" binding:"required"`
+	Reason string `json:"reason" binding:"max=100"`
+}
+
+// Logout handles POST /api/v1/auth/logout
+func (h *SessionHandler) Logout(c *gin.Context) {
+	claims, ok := tokenClaims(c)
+	if !ok {
+		return
+	}
+
+	if err := h.sessions.Logout(sharedapi.RequestContext(c), claims); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to end session",
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// StreamTicketResponse carries a single-use ticket for the live event stream
+type StreamTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IssueStreamTicket handles POST /api/v1/auth/stream-ticket
+// The ticket opens GET /api/v1/events?ticket= once, within service.StreamTicketTTL.
+func (h *SessionHandler) IssueStreamTicket(c *gin.Context) {
+	claims, ok := tokenClaims(c)
+	if !ok {
+		return
+	}
+
+	ticket, expiresAt, err := h.sessions.IssueStreamTicket(sharedapi.RequestContext(c), claims)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to issue stream ticket",
+		})
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, StreamTicketResponse{Ticket: ticket, ExpiresAt: expiresAt})
+}
+
+// ListMySessions handles GET /api/v1/auth/sessions
+func (h *SessionHandler) ListMySessions(c *gin.Context) {
+	userID, tenantID, ok := requestUser(c)
+	if !ok {
+		return
+	}
+	h.listSessions(c, tenantID, userID)
+}
+
+// RevokeMySession handles DELETE /api/v1/auth/sessions/:id
+func (h *SessionHandler) RevokeMySession(c *gin.Context) {
+	userID, _, ok := requestUser(c)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid session ID",
+		})
+		return
+	}
+
+	if err := h.sessions.RevokeSession(sharedapi.RequestContext(c), userID, sessionID, service.RevokeReasonUser); err != nil {
+		h.sessionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// ListUserSessions handles GET /api/v1/auth/admin/users/:id/sessions
+func (h *SessionHandler) ListUserSessions(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+	userID, ok := parseUserParam(c)
+	if !ok {
+		return
+	}
+	h.listSessions(c, tenantID, userID)
+}
+
+// RevokeUserSessions handles POST /api/v1/auth/admin/users/:id/sessions/revoke
+func (h *SessionHandler) RevokeUserSessions(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+	userID, ok := parseUserParam(c)
+	if !ok {
+		return
+	}
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.sessions.RevokeUserSessions(sharedapi.RequestContext(c), tenantID, userID, uuid.Nil, reason)
+	if err != nil {
+		h.sessionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"user_id": userID, "revoked": revoked}})
+}
+
+// RevokeTenantSessions handles POST /api/v1/auth/admin/sessions/revoke. Every
+// session in the tenant ends, including the caller's.
+func (h *SessionHandler) RevokeTenantSessions(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+	reason, ok := bindReason(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.sessions.RevokeTenantSessions(sharedapi.RequestContext(c), tenantID, reason)
+	if err != nil {
+		h.sessionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"tenant_id": tenantID, "revoked": revoked}})
+}
+
+// RevokeToken handles POST /api/v1/auth/admin/tokens/revoke, denylisting a single
+// access token (for example one leaked into logs) without ending its session
+func (h *SessionHandler) RevokeToken(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+	var req RevokeTokenRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = service.RevokeReasonAdmin
+	}
+
+	claims, err := h.sessions.RevokeAccessToken(sharedapi.RequestContext(c), tenantID, req.Token, reason)
+	if err != nil {
+		h.sessionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"jti":        claims.ID,
+		"user_id":    claims.UserID,
+		"session_id": claims.SessionID,
+		"expires_at": claims.ExpiresAt,
+	}})
+}
+
+func (h *SessionHandler) listSessions(c *gin.Context, tenantID, userID uuid.UUID) {
+	sessions, err := h.sessions.ListSessions(sharedapi.RequestContext(c), tenantID, userID)
+	if err != nil {
+		h.sessionError(c, err)
+		return
+	}
+
+	current := c.GetString("session_id")
+	data := make([]gin.H, 0, len(sessions))
+	for _, s := range sessions {
+		data = append(data, gin.H{
+			"id":           s.ID,
+			"auth_method":  s.AuthMethod,
+			"ip_address":   s.IPAddress,
+			"user_agent":   s.UserAgent,
+			"created_at":   s.CreatedAt,
+			"last_used_at": s.LastUsedAt,
+			"expires_at":   s.ExpiresAt,
+			"current":      s.ID.String() == current,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data, "total": len(data)})
+}
+
+func (h *SessionHandler) sessionError(c *gin.Context, err error) {
+	switch err {
+	case service.ErrUserNotFound, service.ErrSessionNotFound:
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "not_found", Message: err.Error()})
+	case service.ErrTokenTenantMismatch:
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "forbidden", Message: err.Error()})
+	case service.ErrInvalidToken, service.ErrTokenExpired, service.ErrInvalidClaims:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid_token", Message: "Token is invalid or already expired"})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal_error", Message: err.Error()})
+	}
+}
+
+func bindReason(c *gin.Context) (string, bool) {
+	var req RevokeSessionsRequest
+	if c.Request.ContentLength > 0 {
+		if !sharedapi.BindJSON(c, &req) {
+			return "", false
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = service.RevokeReasonAdmin
+	}
+	return req.Reason, true
+}
+
+func parseUserParam(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid user ID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func requestUser(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, ok := c.Get("user_id")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, _ := userID.(uuid.UUID)
+	return id, tenantID, true
+}
+
+func tokenClaims(c *gin.Context) (*service.JWTClaims, bool) {
+	value, _ := c.Get("token_claims")
+	claims, ok := value.(*service.JWTClaims)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+		})
+		return nil, false
+	}
+	return claims, true
+}
+
+// sessionMeta records where a session was opened from
+func sessionMeta(c *gin.Context, method string) service.SessionMeta {
+	return service.SessionMeta{
+		AuthMethod: method,
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+}
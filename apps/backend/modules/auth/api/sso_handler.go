@@ -0,0 +1,203 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/auth/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	ssoStateCookie     = "arc_sso_state"
+	ssoStateCookiePath = "/api/v1/auth/sso"
+)
+
+// SSOHandler serves the OIDC login flow and the admin provider configuration
+type SSOHandler struct {
+	ssoService         *service.SSOService
+	sessions           *service.SessionService
+	successRedirectURL string
+}
+
+func NewSSOHandler(ssoService *service.SSOService, sessions *service.SessionService, successRedirectURL string) *SSOHandler {
+	return &SSOHandler{
+		ssoService:         ssoService,
+		sessions:           sessions,
+		successRedirectURL: successRedirectURL,
+	}
+}
+
+// Login handles GET /api/v1/auth/sso/login/:tenant. It redirects to the
+// tenant's identity provider, or returns the URL with ?mode=json for SPAs.
+func (h *SSOHandler) Login(c *gin.Context) {
+	authURL, stateToken, err := h.ssoService.BeginLogin(c.Request.Context(), c.Param("tenant"))
+	if err != nil {
+		h.ssoError(c, err)
+		return
+	}
+
+	// Lax so the cookie survives the top-level redirect back from the IdP
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(ssoStateCookie, stateToken, int(h.ssoService.StateTTL().Seconds()), ssoStateCookiePath, "", isSecureRequest(c), true)
+
+	if c.Query("mode") == "json" {
+		c.JSON(http.StatusOK, gin.H{"data": gin.H{"authorization_url": authURL}})
+		return
+	}
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// Callback handles GET /api/v1/auth/sso/callback, the redirect URL registered with the IdP
+func (h *SSOHandler) Callback(c *gin.Context) {
+	// The state cookie is single use whatever the outcome
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(ssoStateCookie, "", -1, ssoStateCookiePath, "", isSecureRequest(c), true)
+
+	if idpError := c.Query("error"); idpError != "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "sso_error",
+			Message: strings.TrimSpace(idpError + " " + c.Query("error_description")),
+		})
+		return
+	}
+
+	stateToken, _ := c.Cookie(ssoStateCookie)
+	user, err := h.ssoService.CompleteLogin(c.Request.Context(), stateToken, c.Query("state"), c.Query("code"))
+	if err != nil {
+		h.ssoError(c, err)
+		return
+	}
+
+	accessToken, refreshToken, err := h.sessions.Issue(c.Request.Context(), user, sessionMeta(c, service.AuthMethodSSO))
+	if err != nil {
+		h.ssoError(c, err)
+		return
+	}
+
+	if h.successRedirectURL != "" {
+		// Tokens travel in the fragment so they never reach server logs or Referer headers
+		fragment := url.Values{}
+		fragment.Set("access_token", accessToken)
+		fragment.Set("refresh_token", refreshToken)
+		fragment.Set("expires_in", strconv.Itoa(86400))
+		fragment.Set("token_type", "Bearer")
+		c.Redirect(http.StatusFound, h.successRedirectURL+"#"+fragment.Encode())
+		return
+	}
+
+	c.JSON(http.StatusOK, LoginResponse{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    86400,
+		TokenType:    "Bearer",
+	})
+}
+
+// GetProvider handles GET /api/v1/auth/sso/provider
+func (h *SSOHandler) GetProvider(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+
+	provider, err := h.ssoService.GetProvider(c.Request.Context(), tenantID)
+	if err != nil {
+		h.ssoError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": provider})
+}
+
+// SaveProvider handles PUT /api/v1/auth/sso/provider
+func (h *SSOHandler) SaveProvider(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+
+	var req service.SSOProviderInput
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	provider, err := h.ssoService.SaveProvider(sharedapi.RequestContext(c), tenantID, req)
+	if err != nil {
+		h.ssoError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": provider})
+}
+
+// DeleteProvider handles DELETE /api/v1/auth/sso/provider
+func (h *SSOHandler) DeleteProvider(c *gin.Context) {
+	tenantID, ok := requestTenantID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.ssoService.DeleteProvider(sharedapi.RequestContext(c), tenantID); err != nil {
+		h.ssoError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "SSO provider deleted"})
+}
+
+func (h *SSOHandler) ssoError(c *gin.Context, err error) {
+	status, code := http.StatusBadGateway, "sso_error"
+	switch {
+	case errors.Is(err, service.ErrSSONotConfigured):
+		status, code = http.StatusNotFound, "sso_not_configured"
+	case errors.Is(err, service.ErrSSOStateInvalid):
+		status, code = http.StatusBadRequest, "invalid_state"
+	case errors.Is(err, service.ErrSSOEmailMissing), errors.Is(err, service.ErrSSOEmailUnverified),
+		errors.Is(err, service.ErrIDTokenInvalid):
+		status, code = http.StatusUnauthorized, "authentication_error"
+	case errors.Is(err, service.ErrSSODomainNotAllowed), errors.Is(err, service.ErrSSOUserNotProvisioned),
+		errors.Is(err, service.ErrSSOTenantMismatch), errors.Is(err, service.ErrUserInactive):
+		status, code = http.StatusForbidden, "access_denied"
+	case errors.Is(err, service.ErrSSOSecretUnavailable):
+		status, code = http.StatusServiceUnavailable, "encryption_unavailable"
+	case strings.HasPrefix(err.Error(), "invalid "):
+		status, code = http.StatusBadRequest, "validation_error"
+	case strings.Contains(err.Error(), "failed to"):
+		status, code = http.StatusInternalServerError, "internal_error"
+	}
+	if status >= http.StatusInternalServerError {
+		log.Printf("ERROR: SSO request failed: %v", err)
+	}
+	c.JSON(status, ErrorResponse{Error: code, Message: err.Error()})
+}
+
+// requestTenantID reads the tenant set by the auth middleware, which stores it as a string
+func requestTenantID(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get("tenant_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Tenant not found",
+		})
+		return uuid.Nil, false
+	}
+	tenantID, err := uuid.Parse(fmt.Sprint(value))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "Invalid tenant",
+		})
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
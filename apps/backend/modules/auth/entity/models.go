@@ -9,10 +9,11 @@ import (
 type UserRole string
 
 const (
-	RoleAdmin    UserRole = "admin"
-	RoleAuditor  UserRole = "auditor"
-	RoleOperator UserRole = "operator"
-	RoleViewer   UserRole = "viewer"
+	RoleAdmin      UserRole = "admin"
+	RoleAuditor    UserRole = "auditor"
+	RoleOperator   UserRole = "operator"
+	RoleRemediator UserRole = "remediator"
+	RoleViewer     UserRole = "viewer"
 )
 
 type Permission string
@@ -45,6 +46,10 @@ var RolePermissions = map[UserRole][]Permission{
 		PermissionSourceManage, PermissionSourceRead,
 		PermissionReport,
 	},
+	RoleRemediator: {
+		PermissionScanRead, PermissionSourceRead, PermissionReport,
+		PermissionRemediate, PermissionRemediateApprove,
+	},
 	RoleViewer: {
 		PermissionScanRead, PermissionSourceRead, PermissionReport,
 	},
@@ -59,6 +64,7 @@ type User struct {
 	Role         UserRole   `json:"role" gorm:"size:50;default:viewer"`
 	TenantID     uuid.UUID  `json:"tenant_id" gorm:"type:uuid;index"`
 	IsActive     bool       `json:"is_active" gorm:"default:true"`
+	OrgUnitID    *uuid.UUID `json:"org_unit_id,omitempty" gorm:"type:uuid"` // Limits finding reads unless the user is an admin or auditor
 	LastLoginAt  *time.Time `json:"last_login_at,omitempty"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
@@ -89,12 +95,29 @@ type AuditLog struct {
 }
 
 type LoginSession struct {
-	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;index"`
-	TenantID  uuid.UUID `json:"tenant_id" gorm:"type:uuid;index"`
-	TokenHash string    `json:"-" gorm:"size:64;uniqueIndex"`
-	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
-	IPAddress string    `json:"ip_address" gorm:"size:45"`
-	UserAgent string    `json:"user_agent" gorm:"size:500"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	TenantID      uuid.UUID  `json:"tenant_id" gorm:"type:uuid;index"`
+	TokenHash     string     `json:"-" gorm:"size:64;uniqueIndex"` // SHA-256 of the current refresh token
+	AuthMethod    string     `json:"auth_method" gorm:"size:20"`   // "password" or "sso"
+	ExpiresAt     time.Time  `json:"expires_at" gorm:"index"`
+	IPAddress     string     `json:"ip_address" gorm:"size:45"`
+	UserAgent     string     `json:"user_agent" gorm:"size:500"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	RevokedReason string     `json:"revoked_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// StreamTicket is a single-use credential for the live event stream, bound to
+// the access token it was issued for
+type StreamTicket struct {
+	TicketHash string    `json:"-"` // SHA-256 of the ticket
+	SessionID  uuid.UUID `json:"session_id"`
+	TokenID    string    `json:"token_id"` // jti of the access token
+	UserID     uuid.UUID `json:"user_id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSOProvider is a tenant's OIDC identity provider. A tenant has at most one.
+type SSOProvider struct {
+	ID                    uuid.UUID         `json:"id"`
+	TenantID              uuid.UUID         `json:"tenant_id"`
+	Name                  string            `json:"name"`
+	IssuerURL             string            `json:"issuer_url"`
+	ClientID              string            `json:"client_id"`
+	ClientSecret          string            `json:"-"` // Decrypted in memory only
+	ClientSecretEncrypted []byte            `json:"-"`
+	HasClientSecret       bool              `json:"has_client_secret"`
+	RedirectURL           string            `json:"redirect_url"`
+	Scopes                []string          `json:"scopes"`
+	RoleClaim             string            `json:"role_claim"`   // Claim holding IdP groups or roles; dotted paths reach nested claims
+	RoleMapping           map[string]string `json:"role_mapping"` // IdP group or role -> ARC-Hawk role
+	DefaultRole           UserRole          `json:"default_role"`
+	AllowedDomains        []string          `json:"allowed_domains"`
+	JITProvisioning       bool              `json:"jit_provisioning"`
+	EnforceSSO            bool              `json:"enforce_sso"`        // Disable local password login for the tenant
+	BreakGlassEmails      []string          `json:"break_glass_emails"` // Admins still allowed to log in locally while SSO is enforced
+	Enabled               bool              `json:"enabled"`
+	CreatedAt             time.Time         `json:"created_at"`
+	UpdatedAt             time.Time         `json:"updated_at"`
+}
@@ -14,6 +14,7 @@ import (
 
 type AuthMiddleware struct {
 	jwtService    *service.JWTService
+	sessions      *service.SessionService
 	userService   *service.UserService
 	postgresRepo  *persistence.PostgresRepository
 	skipAuthPaths map[string]bool
@@ -42,6 +43,13 @@ func NewAuthMiddleware(repo *persistence.PostgresRepository) *AuthMiddleware {
 	}
 }
 
+// SetSessionService makes Authenticate reject tokens whose session or token was
+// revoked, and validate tokens with the session service's signer
+func (m *AuthMiddleware) SetSessionService(sessions *service.SessionService) {
+	m.sessions = sessions
+	m.jwtService = sessions.JWTService()
+}
+
 func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		path := c.Request.URL.Path
@@ -85,6 +93,21 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 			return
 		}
 
+		if m.sessions != nil {
+			if err := m.sessions.ValidateAccess(c.Request.Context(), claims); err != nil {
+				message := "Session has been revoked"
+				if err != service.ErrSessionRevoked && err != service.ErrInvalidToken {
+					message = "Failed to verify session"
+				}
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":   "unauthorized",
+					"message": message,
+				})
+				c.Abort()
+				return
+			}
+		}
+
 		userID, err := uuid.Parse(claims.UserID)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -126,6 +149,8 @@ func (m *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		c.Set("user_role", claims.Role)
 		c.Set("tenant_id", claims.TenantID)
 		c.Set("user", user)
+		c.Set("session_id", claims.SessionID)
+		c.Set("token_claims", claims)
 
 		c.Next()
 	}
@@ -234,6 +259,56 @@ func (m *AuthMiddleware) RequireRole(roles ...string) gin.HandlerFunc {
 	}
 }
 
+// SetRequestUser stores the caller of a validated access token in the gin keys
+// handlers and the role and org unit checks read
+func SetRequestUser(c *gin.Context, claims *service.JWTClaims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_role", claims.Role)
+	c.Set("tenant_id", claims.TenantID)
+	c.Set("authenticated", true)
+}
+
+// ScopeToOrgUnit limits the finding reads of the route to the user's
+// organizational unit. Admins, auditors, anonymous callers and users in no unit
+// see every finding.
+func (m *AuthMiddleware) ScopeToOrgUnit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("user_role")
+		rawUserID := c.GetString("user_id")
+		if rawUserID == "" || role == string(entity.RoleAdmin) || role == string(entity.RoleAuditor) {
+			c.Next()
+			return
+		}
+
+		userID, err := uuid.Parse(rawUserID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "Invalid user ID in token",
+			})
+			c.Abort()
+			return
+		}
+		user, err := m.userService.GetUserByID(c.Request.Context(), userID)
+		if err != nil || !user.IsActive {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not found or inactive",
+			})
+			c.Abort()
+			return
+		}
+		if user.OrgUnitID == nil || user.Role == entity.RoleAdmin || user.Role == entity.RoleAuditor {
+			c.Next()
+			return
+		}
+
+		c.Request = c.Request.WithContext(persistence.WithOrgUnitScope(c.Request.Context(), *user.OrgUnitID))
+		c.Next()
+	}
+}
+
 type UserContext struct {
 	UserID    uuid.UUID
 	Email     string
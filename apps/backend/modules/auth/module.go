@@ -1,19 +1,27 @@
 package auth
 
 import (
+	"context"
 	"log"
 
 	"github.com/arc-platform/backend/modules/auth/api"
 	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/auth/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
 )
 
 type AuthModule struct {
-	handler    *api.AuthHandler
-	middleware *middleware.AuthMiddleware
-	pgRepo     *persistence.PostgresRepository
+	handler        *api.AuthHandler
+	ssoHandler     *api.SSOHandler
+	sessionHandler *api.SessionHandler
+	middleware     *middleware.AuthMiddleware
+	sessions       *service.SessionService
+	pgRepo         *persistence.PostgresRepository
+	cancelWorker   context.CancelFunc
 }
 
 func NewAuthModule() *AuthModule {
@@ -28,8 +36,29 @@ func (m *AuthModule) Initialize(deps *interfaces.ModuleDependencies) error {
 	log.Printf("📡 Initializing Auth Module...")
 
 	m.pgRepo = persistence.NewPostgresRepository(deps.DB)
-	m.handler = api.NewAuthHandler(m.pgRepo)
+
+	// One signer for issuing and validating, so tokens survive across handlers
+	// even when JWT_SECRET is auto-generated in development
+	jwtService := service.NewJWTService()
+	m.sessions = service.NewSessionService(m.pgRepo, jwtService, deps.AuditLogger, deps.Config.Sessions)
+
+	m.handler = api.NewAuthHandler(m.pgRepo, m.sessions)
+	m.sessionHandler = api.NewSessionHandler(m.sessions)
 	m.middleware = middleware.NewAuthMiddleware(m.pgRepo)
+	m.middleware.SetSessionService(m.sessions)
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	m.cancelWorker = cancel
+	go m.sessions.StartCleanupWorker(workerCtx, deps.Config.Sessions.CleanupIntervalMinutes)
+
+	// SSO client secrets are encrypted at rest; without a key only public OIDC clients can be configured
+	encryptionService, err := encryption.NewEncryptionService()
+	if err != nil {
+		log.Printf("WARN: SSO client secrets unavailable: %v", err)
+		encryptionService = nil
+	}
+	ssoService := service.NewSSOService(m.pgRepo, jwtService, encryptionService, deps.AuditLogger, deps.Config.SSO)
+	m.ssoHandler = api.NewSSOHandler(ssoService, m.sessions, deps.Config.SSO.SuccessRedirectURL)
 
 	log.Printf("✅ Auth Module initialized")
 	return nil
@@ -42,6 +71,10 @@ func (m *AuthModule) RegisterRoutes(router *gin.RouterGroup) {
 		auth.POST("/register", m.handler.Register)
 		auth.POST("/refresh", m.handler.Refresh)
 
+		// OIDC single sign-on
+		auth.GET("/sso/login/:tenant", m.ssoHandler.Login)
+		auth.GET("/sso/callback", m.ssoHandler.Callback)
+
 		protected := auth.Group("")
 		protected.Use(m.middleware.Authenticate())
 		{
@@ -49,15 +82,48 @@ func (m *AuthModule) RegisterRoutes(router *gin.RouterGroup) {
 			protected.POST("/change-password", m.handler.ChangePassword)
 			protected.GET("/users", m.handler.ListUsers)
 
+			// Sessions
+			protected.POST("/logout", m.sessionHandler.Logout)
+			protected.GET("/sessions", m.sessionHandler.ListMySessions)
+			protected.DELETE("/sessions/:id", m.sessionHandler.RevokeMySession)
+			protected.POST("/stream-ticket", m.sessionHandler.IssueStreamTicket)
+
 			// Settings
 			protected.GET("/settings", m.handler.GetSettings)
 			protected.PUT("/settings", m.handler.UpdateSettings)
+
+			// SSO provider configuration
+			ssoAdmin := protected.Group("/sso/provider")
+			ssoAdmin.Use(m.middleware.RequireRole("admin"))
+			{
+				ssoAdmin.GET("", m.ssoHandler.GetProvider)
+				ssoAdmin.PUT("", m.ssoHandler.SaveProvider)
+				ssoAdmin.DELETE("", m.ssoHandler.DeleteProvider)
+			}
+
+			// Session and token revocation
+			admin := protected.Group("/admin")
+			admin.Use(m.middleware.RequireRole("admin"))
+			{
+				admin.GET("/users/:id/sessions", m.sessionHandler.ListUserSessions)
+				admin.POST("/users/:id/sessions/revoke", m.sessionHandler.RevokeUserSessions)
+				admin.POST("/sessions/revoke", m.sessionHandler.RevokeTenantSessions)
+				admin.POST("/tokens/revoke", m.sessionHandler.RevokeToken)
+			}
 		}
 	}
 }
 
+// OpenAPIOperations describes the module's routes for the OpenAPI document
+func (m *AuthModule) OpenAPIOperations() []sharedapi.Operation {
+	return api.OpenAPIOperations()
+}
+
 func (m *AuthModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Auth Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
 	return nil
 }
 
@@ -65,6 +131,11 @@ func (m *AuthModule) GetMiddleware() *middleware.AuthMiddleware {
 	return m.middleware
 }
 
+// GetSessionService returns the session service that issues and validates tokens
+func (m *AuthModule) GetSessionService() *service.SessionService {
+	return m.sessions
+}
+
 func (m *AuthModule) GetRepository() *persistence.PostgresRepository {
 	return m.pgRepo
 }
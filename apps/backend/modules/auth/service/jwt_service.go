@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"os"
 	"time"
@@ -77,6 +78,7 @@ func (s *JWTService) GenerateToken(user *entity.User, sessionID uuid.UUID) (stri
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "arc-hawk",
 			Subject:   user.ID.String(),
+			ID:        uuid.NewString(),
 		},
 	}
 
@@ -96,6 +98,8 @@ func (s *JWTService) GenerateToken(user *entity.User, sessionID uuid.UUID) (stri
 			ExpiresAt: jwt.NewNumericDate(refreshExpiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    "arc-hawk-refresh",
+			Subject:   user.ID.String(),
+			ID:        uuid.NewString(),
 		},
 	}
 
@@ -151,9 +155,19 @@ func (s *JWTService) ValidateRefreshToken(refreshTokenString string) (*JWTClaims
 		return nil, ErrInvalidClaims
 	}
 
+	// An access token must not be usable as a refresh token
+	if claims.Issuer != "arc-hawk-refresh" {
+		return nil, ErrInvalidToken
+	}
+
 	return claims, nil
 }
 
+// RefreshExpiry is the lifetime of a refresh token, and so of an idle session
+func (s *JWTService) RefreshExpiry() time.Duration {
+	return s.refreshExpiry
+}
+
 func GenerateSecureToken(length int) (string, error) {
 	bytes := make([]byte, length)
 	if _, err := rand.Read(bytes); err != nil {
@@ -164,7 +178,7 @@ func GenerateSecureToken(length int) (string, error) {
 
 func HashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
-	return string(hash[:])
+	return hex.EncodeToString(hash[:])
 }
 
 func (s *JWTService) GenerateResetToken(userID uuid.UUID) (string, time.Time, error) {
@@ -208,3 +222,47 @@ func (s *JWTService) InvalidateToken(tokenString string) error {
 	_, err := s.ValidateToken(tokenString)
 	return err
 }
+
+// SSOStateClaims carry an OIDC login between the redirect to the identity
+// provider and its callback. They are signed with a key derived from the JWT
+// secret so a state token can never pass as an access token.
+type SSOStateClaims struct {
+	ProviderID string `json:"provider_id"`
+	State      string `json:"state"`
+	Nonce      string `json:"nonce"`
+	Verifier   string `json:"verifier"`
+	jwt.RegisteredClaims
+}
+
+func (s *JWTService) ssoStateKey() []byte {
+	key := sha256.Sum256(append([]byte("arc-hawk-sso-state:"), s.secretKey...))
+	return key[:]
+}
+
+func (s *JWTService) GenerateSSOState(claims SSOStateClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Issuer:    "arc-hawk-sso-state",
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.ssoStateKey())
+}
+
+func (s *JWTService) ValidateSSOState(tokenString string) (*SSOStateClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &SSOStateClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return s.ssoStateKey(), nil
+	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithIssuer("arc-hawk-sso-state"), jwt.WithExpirationRequired())
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrTokenExpired
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*SSOStateClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidClaims
+	}
+	return claims, nil
+}
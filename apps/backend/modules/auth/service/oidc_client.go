@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	oidcDiscoveryTTL = time.Hour
+	// A token signed with an unknown key triggers a JWKS refresh at most this often
+	oidcJWKSMinRefresh = time.Minute
+	oidcMaxResponse    = 1 << 20
+)
+
+var ErrIDTokenInvalid = errors.New("invalid ID token")
+
+// oidcMetadata is the subset of the provider's discovery document the code flow needs
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcIssuerState struct {
+	metadata    oidcMetadata
+	fetchedAt   time.Time
+	keys        map[string]interface{} // kid -> *rsa.PublicKey or *ecdsa.PublicKey
+	keysFetched time.Time
+}
+
+// OIDCTokenResponse is the token endpoint's answer to an authorization code exchange
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// OIDCClient runs the authorization code flow against OpenID Connect providers.
+// Discovery documents and signing keys are cached per issuer.
+type OIDCClient struct {
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu      sync.Mutex
+	issuers map[string]*oidcIssuerState
+}
+
+func NewOIDCClient(timeout time.Duration) *OIDCClient {
+	return &OIDCClient{
+		httpClient: &http.Client{Timeout: timeout},
+		now:        time.Now,
+		issuers:    make(map[string]*oidcIssuerState),
+	}
+}
+
+// AuthCodeURL builds the authorization request with PKCE (S256) for the provider
+func (c *OIDCClient) AuthCodeURL(ctx context.Context, p *entity.SSOProvider, state, nonce, verifier string) (string, error) {
+	md, err := c.metadata(ctx, p.IssuerURL)
+	if err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.ClientID)
+	q.Set("redirect_uri", p.RedirectURL)
+	q.Set("scope", strings.Join(p.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(md.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return md.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange trades an authorization code for tokens. Confidential clients
+// authenticate with client_secret_basic; public clients send only their ID.
+func (c *OIDCClient) Exchange(ctx context.Context, p *entity.SSOProvider, code, verifier string) (*OIDCTokenResponse, error) {
+	md, err := c.metadata(ctx, p.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("code_verifier", verifier)
+	if p.ClientSecret == "" {
+		form.Set("client_id", p.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var idpErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &idpErr)
+		if idpErr.Error != "" {
+			return nil, fmt.Errorf("token endpoint returned %s: %s %s", resp.Status, idpErr.Error, idpErr.Description)
+		}
+		return nil, fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var tokens OIDCTokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("token response has no id_token")
+	}
+	return &tokens, nil
+}
+
+// VerifyIDToken checks the ID token's signature against the provider's JWKS
+// and validates issuer, audience, expiry and nonce. It returns the token's claims.
+func (c *OIDCClient) VerifyIDToken(ctx context.Context, p *entity.SSOProvider, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	md, err := c.metadata(ctx, p.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(p.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+		jwt.WithTimeFunc(c.now),
+	)
+
+	claims := jwt.MapClaims{}
+	_, err = parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.signingKey(ctx, p.IssuerURL, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIDTokenInvalid, err)
+	}
+
+	if got, _ := claims["nonce"].(string); got == "" || got != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrIDTokenInvalid)
+	}
+	// With several audiences the token must name us as the authorized party
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != p.ClientID {
+			return nil, fmt.Errorf("%w: authorized party mismatch", ErrIDTokenInvalid)
+		}
+	}
+	return claims, nil
+}
+
+func (c *OIDCClient) metadata(ctx context.Context, issuer string) (oidcMetadata, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	c.mu.Lock()
+	st := c.issuers[issuer]
+	if st != nil && c.now().Sub(st.fetchedAt) < oidcDiscoveryTTL {
+		md := st.metadata
+		c.mu.Unlock()
+		return md, nil
+	}
+	c.mu.Unlock()
+
+	var md oidcMetadata
+	if err := c.getJSON(ctx, issuer+"/.well-known/openid-configuration", &md); err != nil {
+		return oidcMetadata{}, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if strings.TrimSuffix(md.Issuer, "/") != issuer {
+		return oidcMetadata{}, fmt.Errorf("OIDC discovery failed: issuer %q does not match configured %q", md.Issuer, issuer)
+	}
+	if md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "" {
+		return oidcMetadata{}, fmt.Errorf("OIDC discovery failed: document lacks authorization, token or JWKS endpoint")
+	}
+
+	c.mu.Lock()
+	if st = c.issuers[issuer]; st == nil {
+		st = &oidcIssuerState{}
+		c.issuers[issuer] = st
+	}
+	st.metadata = md
+	st.fetchedAt = c.now()
+	c.mu.Unlock()
+	return md, nil
+}
+
+func (c *OIDCClient) signingKey(ctx context.Context, issuer, kid string) (interface{}, error) {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	c.mu.Lock()
+	st := c.issuers[issuer]
+	if st == nil {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("unknown issuer")
+	}
+	key, found := lookupKey(st.keys, kid)
+	stale := c.now().Sub(st.keysFetched) >= oidcJWKSMinRefresh
+	jwksURI := st.metadata.JWKSURI
+	c.mu.Unlock()
+
+	if found {
+		return key, nil
+	}
+	if !stale {
+		return nil, fmt.Errorf("no signing key %q", kid)
+	}
+
+	// Unknown kid: the provider may have rotated its keys
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+
+	c.mu.Lock()
+	st.keys = keys
+	st.keysFetched = c.now()
+	c.mu.Unlock()
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("no signing key %q", kid)
+}
+
+// lookupKey finds a key by kid. Tokens without a kid are accepted only when the set has a single key.
+func lookupKey(keys map[string]interface{}, kid string) (interface{}, bool) {
+	if key, ok := keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func (c *OIDCClient) getJSON(ctx context.Context, rawURL string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", rawURL, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, oidcMaxResponse)).Decode(dest)
+}
+
+// jsonWebKey is an RSA or EC public key from a JWKS document (RFC 7517)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("invalid EC key")
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionRevoked      = errors.New("session has been revoked or has expired")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used; the session has been revoked")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrTokenTenantMismatch = errors.New("token belongs to a different tenant")
+)
+
+// Session revocation reasons
+const (
+	RevokeReasonLogout          = "logout"
+	RevokeReasonUser            = "revoked_by_user"
+	RevokeReasonAdmin           = "revoked_by_admin"
+	RevokeReasonTenant          = "tenant_revocation"
+	RevokeReasonRefreshReuse    = "refresh_token_reuse"
+	RevokeReasonPasswordChanged = "password_changed"
+	RevokeReasonUserDeactivated = "user_deactivated"
+)
+
+// Session authentication methods
+const (
+	AuthMethodPassword = "password"
+	AuthMethodSSO      = "sso"
+)
+
+// SessionMeta describes the client a session was opened from
+type SessionMeta struct {
+	AuthMethod string
+	IPAddress  string
+	UserAgent  string
+}
+
+// SessionService issues tokens bound to server-side sessions, rotates refresh
+// tokens, and revokes sessions or individual access tokens. Every authenticated
+// request is checked against it so revocation takes effect immediately.
+type SessionService struct {
+	repo        *persistence.PostgresRepository
+	jwtService  *JWTService
+	auditLogger interfaces.AuditLogger
+	cacheTTL    time.Duration
+	retention   time.Duration
+
+	mu    sync.Mutex
+	valid map[string]time.Time // session|jti -> checked live until
+}
+
+func NewSessionService(repo *persistence.PostgresRepository, jwtService *JWTService, auditLogger interfaces.AuditLogger, cfg config.SessionConfig) *SessionService {
+	retention := time.Duration(cfg.RetentionDays) * 24 * time.Hour
+	if retention <= 0 {
+		retention = 30 * 24 * time.Hour
+	}
+	return &SessionService{
+		repo:        repo,
+		jwtService:  jwtService,
+		auditLogger: auditLogger,
+		cacheTTL:    time.Duration(cfg.ValidationCacheSeconds) * time.Second,
+		retention:   retention,
+		valid:       make(map[string]time.Time),
+	}
+}
+
+// JWTService returns the signer whose tokens this service validates
+func (s *SessionService) JWTService() *JWTService {
+	return s.jwtService
+}
+
+// Issue opens a session for the user and returns its access and refresh tokens
+func (s *SessionService) Issue(ctx context.Context, user *entity.User, meta SessionMeta) (string, string, error) {
+	sessionID := uuid.New()
+	accessToken, refreshToken, err := s.jwtService.GenerateToken(user, sessionID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	method := meta.AuthMethod
+	if method == "" {
+		method = AuthMethodPassword
+	}
+	session := &entity.LoginSession{
+		ID:         sessionID,
+		UserID:     user.ID,
+		TenantID:   user.TenantID,
+		TokenHash:  HashToken(refreshToken),
+		AuthMethod: method,
+		ExpiresAt:  time.Now().Add(s.jwtService.RefreshExpiry()),
+		IPAddress:  truncate(meta.IPAddress, 45),
+		UserAgent:  truncate(meta.UserAgent, 500),
+	}
+	if err := s.repo.CreateAuthSession(ctx, session); err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// Refresh rotates the session's refresh token. Presenting a refresh token that was
+// already rotated means it was copied, so the whole session is revoked.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*entity.User, string, string, error) {
+	claims, err := s.jwtService.ValidateRefreshToken(refreshToken)
+	if err != nil {
+		return nil, "", "", err
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return nil, "", "", ErrInvalidToken
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, "", "", ErrInvalidToken
+	}
+
+	session, err := s.repo.GetAuthSession(ctx, sessionID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, "", "", ErrSessionRevoked
+		}
+		return nil, "", "", err
+	}
+	if session.UserID != userID || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, "", "", ErrSessionRevoked
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, "", "", ErrUserNotFound
+	}
+	if !user.IsActive {
+		_ = s.repo.RevokeAuthSession(ctx, sessionID, RevokeReasonUserDeactivated)
+		return nil, "", "", ErrUserInactive
+	}
+
+	accessToken, newRefreshToken, err := s.jwtService.GenerateToken(user, sessionID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	rotated, err := s.repo.RotateAuthSessionToken(ctx, sessionID, HashToken(refreshToken), HashToken(newRefreshToken),
+		time.Now().Add(s.jwtService.RefreshExpiry()))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if !rotated {
+		if err := s.repo.RevokeAuthSession(ctx, sessionID, RevokeReasonRefreshReuse); err != nil {
+			log.Printf("ERROR: failed to revoke session %s after refresh token reuse: %v", sessionID, err)
+		}
+		s.forget(sessionID)
+		log.Printf("WARN: refresh token reuse detected for user %s, session %s revoked", user.Email, sessionID)
+		s.audit(ctx, user.TenantID, user.ID, "SESSION_REVOKED", sessionID.String(), map[string]interface{}{
+			"reason": RevokeReasonRefreshReuse,
+		})
+		return nil, "", "", ErrRefreshTokenReused
+	}
+	return user, accessToken, newRefreshToken, nil
+}
+
+// ValidateAccess checks that an access token's session is live and the token itself
+// has not been revoked. Tokens issued without a session are rejected.
+func (s *SessionService) ValidateAccess(ctx context.Context, claims *JWTClaims) error {
+	if claims.Issuer != "arc-hawk" || claims.ID == "" {
+		return ErrInvalidToken
+	}
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return ErrSessionRevoked
+	}
+
+	key := claims.SessionID + "|" + claims.ID
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		until, ok := s.valid[key]
+		s.mu.Unlock()
+		if ok && time.Now().Before(until) {
+			return nil
+		}
+	}
+
+	active, err := s.repo.IsAuthSessionActive(ctx, sessionID, claims.ID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return ErrSessionRevoked
+	}
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		s.valid[key] = time.Now().Add(s.cacheTTL)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// Logout ends the session behind an access token and denylists the token itself
+func (s *SessionService) Logout(ctx context.Context, claims *JWTClaims) error {
+	if err := s.revokeToken(ctx, claims, RevokeReasonLogout); err != nil {
+		return err
+	}
+	if sessionID, err := uuid.Parse(claims.SessionID); err == nil {
+		if err := s.repo.RevokeAuthSession(ctx, sessionID, RevokeReasonLogout); err != nil {
+			return err
+		}
+		s.forget(sessionID)
+	}
+	return nil
+}
+
+// RevokeAccessToken denylists a single access token until it expires. The token
+// must be signed by us and belong to the given tenant.
+func (s *SessionService) RevokeAccessToken(ctx context.Context, tenantID uuid.UUID, token, reason string) (*JWTClaims, error) {
+	claims, err := s.jwtService.ValidateToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TenantID != tenantID.String() {
+		return nil, ErrTokenTenantMismatch
+	}
+	if claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+	if err := s.revokeToken(ctx, claims, reason); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, tenantID, uuid.Nil, "TOKEN_REVOKED", claims.ID, map[string]interface{}{
+		"user_id":    claims.UserID,
+		"session_id": claims.SessionID,
+		"reason":     reason,
+	})
+	return claims, nil
+}
+
+// ListSessions returns the user's live sessions; the user must belong to the tenant
+func (s *SessionService) ListSessions(ctx context.Context, tenantID, userID uuid.UUID) ([]*entity.LoginSession, error) {
+	if err := s.checkUserTenant(ctx, tenantID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListActiveAuthSessions(ctx, userID)
+}
+
+// RevokeSession revokes one of the user's sessions
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID, reason string) error {
+	session, err := s.repo.GetAuthSession(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if err := s.repo.RevokeAuthSession(ctx, sessionID, reason); err != nil {
+		return err
+	}
+	s.forget(sessionID)
+	s.audit(ctx, session.TenantID, userID, "SESSION_REVOKED", sessionID.String(), map[string]interface{}{"reason": reason})
+	return nil
+}
+
+// RevokeUserSessions revokes all of a user's sessions, optionally keeping one (e.g. the caller's)
+func (s *SessionService) RevokeUserSessions(ctx context.Context, tenantID, userID, keepSessionID uuid.UUID, reason string) (int64, error) {
+	if err := s.checkUserTenant(ctx, tenantID, userID); err != nil {
+		return 0, err
+	}
+	n, err := s.repo.RevokeUserAuthSessions(ctx, userID, keepSessionID, reason)
+	if err != nil {
+		return 0, err
+	}
+	s.forgetAll()
+	s.audit(ctx, tenantID, userID, "USER_SESSIONS_REVOKED", userID.String(), map[string]interface{}{
+		"reason":  reason,
+		"revoked": n,
+	})
+	return n, nil
+}
+
+// RevokeTenantSessions revokes every session in the tenant, the caller's included
+func (s *SessionService) RevokeTenantSessions(ctx context.Context, tenantID uuid.UUID, reason string) (int64, error) {
+	n, err := s.repo.RevokeTenantAuthSessions(ctx, tenantID, reason)
+	if err != nil {
+		return 0, err
+	}
+	s.forgetAll()
+	s.audit(ctx, tenantID, uuid.Nil, "TENANT_SESSIONS_REVOKED", tenantID.String(), map[string]interface{}{
+		"reason":  reason,
+		"revoked": n,
+	})
+	return n, nil
+}
+
+// StartCleanupWorker periodically drops denylist entries for expired tokens and old sessions
+func (s *SessionService) StartCleanupWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🔐 Starting session cleanup worker (interval: %d minutes)", intervalMinutes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Session cleanup worker stopped")
+			return
+		case <-ticker.C:
+			n, err := s.repo.PurgeExpiredAuthRecords(ctx, s.retention)
+			if err != nil {
+				log.Printf("❌ Error purging expired sessions: %v", err)
+				continue
+			}
+			if n > 0 {
+				log.Printf("🔐 Purged %d expired session and token records", n)
+			}
+			s.pruneCache()
+		}
+	}
+}
+
+func (s *SessionService) revokeToken(ctx context.Context, claims *JWTClaims, reason string) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	sessionID, _ := uuid.Parse(claims.SessionID)
+	userID, _ := uuid.Parse(claims.UserID)
+	tenantID, _ := uuid.Parse(claims.TenantID)
+	if err := s.repo.DenylistToken(ctx, claims.ID, sessionID, userID, tenantID, reason, claims.ExpiresAt.Time); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.valid, claims.SessionID+"|"+claims.ID)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *SessionService) checkUserTenant(ctx context.Context, tenantID, userID uuid.UUID) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil || user.TenantID != tenantID {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// forget drops cached checks for one session
+func (s *SessionService) forget(sessionID uuid.UUID) {
+	prefix := sessionID.String() + "|"
+	s.mu.Lock()
+	for key := range s.valid {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.valid, key)
+		}
+	}
+	s.mu.Unlock()
+}
+
+// forgetAll drops every cached check after a bulk revocation
+func (s *SessionService) forgetAll() {
+	s.mu.Lock()
+	s.valid = make(map[string]time.Time)
+	s.mu.Unlock()
+}
+
+func (s *SessionService) pruneCache() {
+	now := time.Now()
+	s.mu.Lock()
+	for key, until := range s.valid {
+		if now.After(until) {
+			delete(s.valid, key)
+		}
+	}
+	s.mu.Unlock()
+}
+
+func (s *SessionService) audit(ctx context.Context, tenantID, userID uuid.UUID, action, resourceID string, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if ctx.Value("tenant_id") == nil {
+		ctx = context.WithValue(ctx, "tenant_id", tenantID)
+	}
+	if ctx.Value("user_id") == nil && userID != uuid.Nil {
+		ctx = context.WithValue(ctx, "user_id", userID)
+	}
+	_ = s.auditLogger.Record(ctx, action, "session", resourceID, metadata)
+}
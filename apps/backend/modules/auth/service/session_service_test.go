@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/google/uuid"
+)
+
+func TestTokensCarryIDsAndAreNotInterchangeable(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-test-secret-test-secret")
+	jwtService := NewJWTService()
+	user := &entity.User{ID: uuid.New(), TenantID: uuid.New(), Email: "a@example.com", Role: entity.RoleAdmin}
+	sessionID := uuid.New()
+
+	access, refresh, err := jwtService.GenerateToken(user, sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessClaims, err := jwtService.ValidateToken(access)
+	if err != nil {
+		t.Fatalf("access token rejected: %v", err)
+	}
+	refreshClaims, err := jwtService.ValidateRefreshToken(refresh)
+	if err != nil {
+		t.Fatalf("refresh token rejected: %v", err)
+	}
+	if accessClaims.ID == "" || refreshClaims.ID == "" || accessClaims.ID == refreshClaims.ID {
+		t.Errorf("tokens need distinct IDs, got %q and %q", accessClaims.ID, refreshClaims.ID)
+	}
+	if accessClaims.SessionID != sessionID.String() || refreshClaims.SessionID != sessionID.String() {
+		t.Error("tokens must carry the session ID")
+	}
+
+	if _, err := jwtService.ValidateRefreshToken(access); err == nil {
+		t.Error("access token accepted as a refresh token")
+	}
+
+	// Session checks run before any lookup for tokens that cannot belong to a session
+	sessions := NewSessionService(nil, jwtService, nil, config.SessionConfig{})
+	if err := sessions.ValidateAccess(context.Background(), refreshClaims); err != ErrInvalidToken {
+		t.Errorf("refresh token used as access token: got %v, want ErrInvalidToken", err)
+	}
+	legacy := *accessClaims
+	legacy.ID = ""
+	if err := sessions.ValidateAccess(context.Background(), &legacy); err != ErrInvalidToken {
+		t.Errorf("token without jti: got %v, want ErrInvalidToken", err)
+	}
+	legacy = *accessClaims
+	legacy.SessionID = "not-a-session"
+	if err := sessions.ValidateAccess(context.Background(), &legacy); err != ErrSessionRevoked {
+		t.Errorf("token without session: got %v, want ErrSessionRevoked", err)
+	}
+}
+
+func TestHashTokenIsHex(t *testing.T) {
+	if h := HashToken("token"); len(h) != 64 {
+		t.Errorf("HashToken length = %d, want 64 hex characters", len(h))
+	}
+}
+
+func TestSSOStateCannotPassAsAccessToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret-test-secret-test-secret")
+	jwtService := NewJWTService()
+
+	state, err := jwtService.GenerateSSOState(SSOStateClaims{State: "s", Nonce: "n", Verifier: "v"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwtService.ValidateToken(state); err == nil {
+		t.Error("SSO state accepted as an access token")
+	}
+	claims, err := jwtService.ValidateSSOState(state)
+	if err != nil || claims.Nonce != "n" {
+		t.Errorf("ValidateSSOState = %+v, %v", claims, err)
+	}
+}
@@ -0,0 +1,573 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrSSONotConfigured      = errors.New("single sign-on is not configured for this tenant")
+	ErrSSORequired           = errors.New("single sign-on is required for this tenant")
+	ErrSSOStateInvalid       = errors.New("SSO login state is missing, expired or does not match")
+	ErrSSOEmailMissing       = errors.New("identity provider did not return a verified email")
+	ErrSSOEmailUnverified    = errors.New("identity provider did not assert email_verified, which linking to an existing account requires")
+	ErrSSODomainNotAllowed   = errors.New("email domain is not allowed for this tenant")
+	ErrSSOUserNotProvisioned = errors.New("user is not provisioned and JIT provisioning is disabled")
+	ErrSSOTenantMismatch     = errors.New("user belongs to a different tenant")
+	ErrSSOSecretUnavailable  = errors.New("client secret cannot be stored: ENCRYPTION_KEY is not configured")
+)
+
+// rolePrecedence orders roles when IdP claims map to more than one
+var rolePrecedence = map[entity.UserRole]int{
+	entity.RoleViewer:     1,
+	entity.RoleAuditor:    2,
+	entity.RoleOperator:   3,
+	entity.RoleRemediator: 4,
+	entity.RoleAdmin:      5,
+}
+
+// SSOProviderInput is an admin's provider configuration. An empty ClientSecret
+// keeps the stored secret.
+type SSOProviderInput struct {
+	Name             string            `json:"name" binding:"max=255"`
+	IssuerURL        string            `json:"issuer_url" binding:"required,url"`
+	ClientID         string            `json:"client_id" binding:"required,max=255"`
+	ClientSecret     string            `json:"client_secret"`
+	RedirectURL      string            `json:"redirect_url" binding:"required,url"`
+	Scopes           []string          `json:"scopes"`
+	RoleClaim        string            `json:"role_claim" binding:"max=255"`
+	RoleMapping      map[string]string `json:"role_mapping"`
+	DefaultRole      string            `json:"default_role"`
+	AllowedDomains   []string          `json:"allowed_domains"`
+	JITProvisioning  *bool             `json:"jit_provisioning"`
+	EnforceSSO       bool              `json:"enforce_sso"`
+	BreakGlassEmails []string          `json:"break_glass_emails"`
+	Enabled          *bool             `json:"enabled"`
+}
+
+// SSOService configures per-tenant OIDC providers and runs the login flow:
+// redirect to the IdP, code exchange, ID token verification and JIT provisioning.
+type SSOService struct {
+	repo        *persistence.PostgresRepository
+	oidc        *OIDCClient
+	jwtService  *JWTService
+	encryption  *encryption.EncryptionService // nil when ENCRYPTION_KEY is unset; only public clients work then
+	auditLogger interfaces.AuditLogger
+	stateTTL    time.Duration
+}
+
+func NewSSOService(repo *persistence.PostgresRepository, jwtService *JWTService, enc *encryption.EncryptionService, auditLogger interfaces.AuditLogger, cfg config.SSOConfig) *SSOService {
+	ttl := time.Duration(cfg.StateTTLMinutes) * time.Minute
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	timeout := time.Duration(cfg.HTTPTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &SSOService{
+		repo:        repo,
+		oidc:        NewOIDCClient(timeout),
+		jwtService:  jwtService,
+		encryption:  enc,
+		auditLogger: auditLogger,
+		stateTTL:    ttl,
+	}
+}
+
+// StateTTL is how long a started login stays valid
+func (s *SSOService) StateTTL() time.Duration {
+	return s.stateTTL
+}
+
+// GetProvider returns the tenant's provider configuration
+func (s *SSOService) GetProvider(ctx context.Context, tenantID uuid.UUID) (*entity.SSOProvider, error) {
+	p, err := s.repo.GetSSOProviderByTenant(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrSSOProviderNotFound) {
+			return nil, ErrSSONotConfigured
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// SaveProvider creates or replaces the tenant's provider configuration
+func (s *SSOService) SaveProvider(ctx context.Context, tenantID uuid.UUID, in SSOProviderInput) (*entity.SSOProvider, error) {
+	p, err := buildProvider(tenantID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.ClientSecret != "" {
+		if s.encryption == nil {
+			return nil, ErrSSOSecretUnavailable
+		}
+		p.ClientSecretEncrypted, err = s.encryption.Encrypt(in.ClientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt client secret: %w", err)
+		}
+	}
+
+	if err := s.repo.UpsertSSOProvider(ctx, p); err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "SSO_PROVIDER_SAVED", "sso_provider", p.ID.String(), map[string]interface{}{
+			"issuer_url":  p.IssuerURL,
+			"client_id":   p.ClientID,
+			"enabled":     p.Enabled,
+			"enforce_sso": p.EnforceSSO,
+		})
+	}
+	return p, nil
+}
+
+// DeleteProvider removes the tenant's provider, which re-enables local login for everyone
+func (s *SSOService) DeleteProvider(ctx context.Context, tenantID uuid.UUID) error {
+	if err := s.repo.DeleteSSOProvider(ctx, tenantID); err != nil {
+		if errors.Is(err, persistence.ErrSSOProviderNotFound) {
+			return ErrSSONotConfigured
+		}
+		return err
+	}
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "SSO_PROVIDER_DELETED", "sso_provider", tenantID.String(), nil)
+	}
+	return nil
+}
+
+// BeginLogin starts an authorization code flow for the tenant, identified by ID or slug.
+// It returns the IdP URL to redirect to and the signed state to keep in a cookie until the callback.
+func (s *SSOService) BeginLogin(ctx context.Context, tenantRef string) (string, string, error) {
+	tenant, err := s.resolveTenant(ctx, tenantRef)
+	if err != nil {
+		return "", "", err
+	}
+	p, err := s.enabledProvider(ctx, tenant.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := GenerateSecureToken(24)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := GenerateSecureToken(24)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := GenerateSecureToken(48)
+	if err != nil {
+		return "", "", err
+	}
+	// PKCE verifiers are limited to unreserved characters; base64url padding is not one of them
+	verifier = strings.TrimRight(verifier, "=")
+
+	authURL, err := s.oidc.AuthCodeURL(ctx, p, state, nonce, verifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	stateToken, err := s.jwtService.GenerateSSOState(SSOStateClaims{
+		ProviderID: p.ID.String(),
+		State:      state,
+		Nonce:      nonce,
+		Verifier:   verifier,
+	}, s.stateTTL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign login state: %w", err)
+	}
+	return authURL, stateToken, nil
+}
+
+// CompleteLogin handles the IdP callback: it checks the state against the cookie,
+// exchanges the code, verifies the ID token and provisions the user.
+func (s *SSOService) CompleteLogin(ctx context.Context, stateToken, state, code string) (*entity.User, error) {
+	claims, err := s.jwtService.ValidateSSOState(stateToken)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(claims.State), []byte(state)) != 1 {
+		return nil, ErrSSOStateInvalid
+	}
+	if code == "" {
+		return nil, fmt.Errorf("callback has no authorization code")
+	}
+
+	providerID, err := uuid.Parse(claims.ProviderID)
+	if err != nil {
+		return nil, ErrSSOStateInvalid
+	}
+	p, err := s.repo.GetSSOProviderByID(ctx, providerID)
+	if err != nil || !p.Enabled {
+		return nil, ErrSSONotConfigured
+	}
+	if err := s.decryptSecret(p); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.oidc.Exchange(ctx, p, code, claims.Verifier)
+	if err != nil {
+		return nil, err
+	}
+	idClaims, err := s.oidc.VerifyIDToken(ctx, p, tokens.IDToken, claims.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.provisionUser(ctx, p, idClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		auditCtx := context.WithValue(context.WithValue(ctx, "tenant_id", user.TenantID), "user_id", user.ID)
+		_ = s.auditLogger.Record(auditCtx, "SSO_LOGIN", "user", user.ID.String(), map[string]interface{}{
+			"issuer": p.IssuerURL,
+			"role":   user.Role,
+		})
+	}
+	return user, nil
+}
+
+// checkLocalLogin rejects password logins for tenants that enforce SSO, except
+// for break-glass admins. With no break-glass list every tenant admin qualifies.
+func checkLocalLogin(ctx context.Context, repo *persistence.PostgresRepository, user *entity.User) error {
+	p, err := repo.GetSSOProviderByTenant(ctx, user.TenantID)
+	if err != nil {
+		if errors.Is(err, persistence.ErrSSOProviderNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to load SSO provider: %w", err)
+	}
+	if !p.Enabled || !p.EnforceSSO {
+		return nil
+	}
+	if !isBreakGlass(p, user) {
+		return ErrSSORequired
+	}
+	log.Printf("WARN: break-glass local login for %s in tenant %s while SSO is enforced", user.Email, user.TenantID)
+	return nil
+}
+
+func isBreakGlass(p *entity.SSOProvider, user *entity.User) bool {
+	if user.Role != entity.RoleAdmin {
+		return false
+	}
+	if len(p.BreakGlassEmails) == 0 {
+		return true
+	}
+	for _, email := range p.BreakGlassEmails {
+		if strings.EqualFold(email, user.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SSOService) resolveTenant(ctx context.Context, ref string) (*entity.Tenant, error) {
+	var tenant *entity.Tenant
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		tenant, err = s.repo.GetTenantByID(ctx, id)
+	} else {
+		tenant, err = s.repo.GetTenantBySlug(ctx, strings.ToLower(ref))
+	}
+	if err != nil || !tenant.IsActive {
+		return nil, ErrSSONotConfigured
+	}
+	return tenant, nil
+}
+
+func (s *SSOService) enabledProvider(ctx context.Context, tenantID uuid.UUID) (*entity.SSOProvider, error) {
+	p, err := s.GetProvider(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Enabled {
+		return nil, ErrSSONotConfigured
+	}
+	return p, nil
+}
+
+func (s *SSOService) decryptSecret(p *entity.SSOProvider) error {
+	if len(p.ClientSecretEncrypted) == 0 {
+		return nil
+	}
+	if s.encryption == nil {
+		return ErrSSOSecretUnavailable
+	}
+	if err := s.encryption.Decrypt(p.ClientSecretEncrypted, &p.ClientSecret); err != nil {
+		return fmt.Errorf("failed to decrypt client secret: %w", err)
+	}
+	return nil
+}
+
+// provisionUser finds or creates the local user for a verified identity and
+// applies the role mapped from IdP claims
+func (s *SSOService) provisionUser(ctx context.Context, p *entity.SSOProvider, claims jwt.MapClaims) (*entity.User, error) {
+	email := strings.ToLower(strings.TrimSpace(stringClaim(claims, "email")))
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, ErrSSOEmailMissing
+	}
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return nil, ErrSSOEmailMissing
+	}
+	if !domainAllowed(p.AllowedDomains, email) {
+		return nil, ErrSSODomainNotAllowed
+	}
+
+	role, mapped := mapRole(p, claims)
+	firstName, lastName := nameFromClaims(claims)
+	now := time.Now()
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err == nil && user != nil {
+		// An unverified address could claim someone else's local account
+		if verified, _ := claims["email_verified"].(bool); !verified {
+			return nil, ErrSSOEmailUnverified
+		}
+		if user.TenantID != p.TenantID {
+			return nil, ErrSSOTenantMismatch
+		}
+		if !user.IsActive {
+			return nil, ErrUserInactive
+		}
+		// The IdP is the source of truth for roles once a mapping is configured
+		if mapped {
+			user.Role = role
+		}
+		if user.FirstName == "" && user.LastName == "" {
+			user.FirstName, user.LastName = firstName, lastName
+		}
+		user.LastLoginAt = &now
+		if err := s.repo.UpdateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+		return user, nil
+	}
+
+	if !p.JITProvisioning {
+		return nil, ErrSSOUserNotProvisioned
+	}
+
+	// SSO users get an unusable random password; they can only sign in through the IdP
+	random, err := GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(random), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user = &entity.User{
+		ID:           uuid.New(),
+		Email:        email,
+		PasswordHash: string(hash),
+		FirstName:    firstName,
+		LastName:     lastName,
+		Role:         role,
+		TenantID:     p.TenantID,
+		IsActive:     true,
+		LastLoginAt:  &now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+	if err := s.repo.UpdateUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to record login: %w", err)
+	}
+	log.Printf("INFO: provisioned SSO user %s in tenant %s as %s", email, p.TenantID, role)
+	return user, nil
+}
+
+// mapRole maps the provider's role claim to the highest-ranked ARC-Hawk role.
+// It reports false when nothing matched and the default role was used.
+func mapRole(p *entity.SSOProvider, claims jwt.MapClaims) (entity.UserRole, bool) {
+	best := entity.UserRole("")
+	for _, value := range claimValues(claims, p.RoleClaim) {
+		for idpRole, role := range p.RoleMapping {
+			if !strings.EqualFold(idpRole, value) {
+				continue
+			}
+			r := entity.UserRole(role)
+			if rolePrecedence[r] > rolePrecedence[best] {
+				best = r
+			}
+		}
+	}
+	if best != "" {
+		return best, true
+	}
+	if p.DefaultRole != "" {
+		return p.DefaultRole, false
+	}
+	return entity.RoleViewer, false
+}
+
+// claimValues reads a string or string-list claim; dotted paths such as
+// "realm_access.roles" reach into nested objects
+func claimValues(claims jwt.MapClaims, path string) []string {
+	if path == "" {
+		return nil
+	}
+	var current interface{} = map[string]interface{}(claims)
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = obj[part]
+	}
+
+	switch v := current.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+func nameFromClaims(claims jwt.MapClaims) (string, string) {
+	first, last := stringClaim(claims, "given_name"), stringClaim(claims, "family_name")
+	if first == "" && last == "" {
+		if parts := strings.Fields(stringClaim(claims, "name")); len(parts) > 0 {
+			first, last = parts[0], strings.Join(parts[1:], " ")
+		}
+	}
+	return truncate(first, 100), truncate(last, 100)
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+func domainAllowed(domains []string, email string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	for _, d := range domains {
+		if strings.EqualFold(strings.TrimPrefix(d, "@"), domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildProvider(tenantID uuid.UUID, in SSOProviderInput) (*entity.SSOProvider, error) {
+	issuer := strings.TrimSuffix(strings.TrimSpace(in.IssuerURL), "/")
+	if u, err := url.Parse(issuer); err != nil || u.Host == "" || (u.Scheme != "https" && u.Hostname() != "localhost") {
+		return nil, fmt.Errorf("invalid issuer_url: must be an https URL")
+	}
+	if u, err := url.Parse(in.RedirectURL); err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("invalid redirect_url")
+	}
+
+	scopes := in.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	hasOpenID := false
+	for _, scope := range scopes {
+		if scope == "openid" {
+			hasOpenID = true
+		}
+	}
+	if !hasOpenID {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	defaultRole := entity.RoleViewer
+	if in.DefaultRole != "" {
+		defaultRole = entity.UserRole(strings.ToLower(in.DefaultRole))
+		if _, ok := rolePrecedence[defaultRole]; !ok {
+			return nil, fmt.Errorf("invalid default_role %q", in.DefaultRole)
+		}
+	}
+
+	mapping := make(map[string]string, len(in.RoleMapping))
+	for idpRole, role := range in.RoleMapping {
+		r := strings.ToLower(role)
+		if _, ok := rolePrecedence[entity.UserRole(r)]; !ok {
+			return nil, fmt.Errorf("invalid role %q in role_mapping", role)
+		}
+		mapping[idpRole] = r
+	}
+
+	roleClaim := strings.TrimSpace(in.RoleClaim)
+	if roleClaim == "" {
+		roleClaim = "groups"
+	}
+
+	breakGlass := make([]string, 0, len(in.BreakGlassEmails))
+	for _, email := range in.BreakGlassEmails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			breakGlass = append(breakGlass, email)
+		}
+	}
+	domains := make([]string, 0, len(in.AllowedDomains))
+	for _, d := range in.AllowedDomains {
+		if d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@")); d != "" {
+			domains = append(domains, d)
+		}
+	}
+
+	jit, enabled := true, true
+	if in.JITProvisioning != nil {
+		jit = *in.JITProvisioning
+	}
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+
+	return &entity.SSOProvider{
+		ID:               uuid.New(),
+		TenantID:         tenantID,
+		Name:             in.Name,
+		IssuerURL:        issuer,
+		ClientID:         in.ClientID,
+		RedirectURL:      in.RedirectURL,
+		Scopes:           scopes,
+		RoleClaim:        roleClaim,
+		RoleMapping:      mapping,
+		DefaultRole:      defaultRole,
+		AllowedDomains:   domains,
+		JITProvisioning:  jit,
+		EnforceSSO:       in.EnforceSSO,
+		BreakGlassEmails: breakGlass,
+		Enabled:          enabled,
+	}, nil
+}
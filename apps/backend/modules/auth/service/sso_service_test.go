@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestMapRole(t *testing.T) {
+	p := &entity.SSOProvider{
+		RoleClaim:   "realm_access.roles",
+		RoleMapping: map[string]string{"arc-admins": "admin", "Arc-Operators": "operator", "auditors": "auditor"},
+		DefaultRole: entity.RoleViewer,
+	}
+	claims := func(roles ...interface{}) jwt.MapClaims {
+		return jwt.MapClaims{"realm_access": map[string]interface{}{"roles": roles}}
+	}
+
+	cases := []struct {
+		name   string
+		claims jwt.MapClaims
+		want   entity.UserRole
+		mapped bool
+	}{
+		{"highest mapped role wins", claims("auditors", "arc-operators", "unrelated"), entity.RoleOperator, true},
+		{"admin", claims("arc-admins", "auditors"), entity.RoleAdmin, true},
+		{"no match falls back to default", claims("unrelated"), entity.RoleViewer, false},
+		{"missing claim", jwt.MapClaims{}, entity.RoleViewer, false},
+	}
+	for _, tc := range cases {
+		got, mapped := mapRole(p, tc.claims)
+		if got != tc.want || mapped != tc.mapped {
+			t.Errorf("%s: mapRole = (%q, %v), want (%q, %v)", tc.name, got, mapped, tc.want, tc.mapped)
+		}
+	}
+
+	p.RoleClaim = "role"
+	if got, _ := mapRole(p, jwt.MapClaims{"role": "ARC-ADMINS"}); got != entity.RoleAdmin {
+		t.Errorf("string claim: mapRole = %q, want admin", got)
+	}
+}
+
+func TestIsBreakGlass(t *testing.T) {
+	admin := &entity.User{Email: "Root@Example.com", Role: entity.RoleAdmin}
+	operator := &entity.User{Email: "ops@example.com", Role: entity.RoleOperator}
+
+	open := &entity.SSOProvider{}
+	if !isBreakGlass(open, admin) || isBreakGlass(open, operator) {
+		t.Error("without a break-glass list every admin, and only admins, may log in locally")
+	}
+
+	listed := &entity.SSOProvider{BreakGlassEmails: []string{"root@example.com"}}
+	if !isBreakGlass(listed, admin) {
+		t.Error("listed admin should be break-glass")
+	}
+	if isBreakGlass(listed, &entity.User{Email: "other@example.com", Role: entity.RoleAdmin}) {
+		t.Error("unlisted admin should not be break-glass")
+	}
+}
+
+func TestBuildProviderValidation(t *testing.T) {
+	in := SSOProviderInput{
+		IssuerURL:   "https://idp.example.com/",
+		ClientID:    "arc-hawk",
+		RedirectURL: "https://arc.example.com/api/v1/auth/sso/callback",
+		Scopes:      []string{"email"},
+		RoleMapping: map[string]string{"admins": "Admin"},
+	}
+	p, err := buildProvider(uuid.New(), in)
+	if err != nil {
+		t.Fatalf("buildProvider: %v", err)
+	}
+	if p.IssuerURL != "https://idp.example.com" || p.Scopes[0] != "openid" || p.RoleMapping["admins"] != "admin" ||
+		p.RoleClaim != "groups" || !p.JITProvisioning || !p.Enabled {
+		t.Errorf("unexpected provider: %+v", p)
+	}
+
+	in.RoleMapping = map[string]string{"admins": "superuser"}
+	if _, err := buildProvider(p.TenantID, in); err == nil {
+		t.Error("expected an error for an unknown mapped role")
+	}
+	in.RoleMapping = nil
+	in.IssuerURL = "http://idp.example.com"
+	if _, err := buildProvider(p.TenantID, in); err == nil {
+		t.Error("expected an error for a plain-http issuer")
+	}
+}
+
+// fakeIdP serves discovery and JWKS for an RSA key and signs ID tokens with it
+type fakeIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &fakeIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *fakeIdP) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "k1"
+	raw, err := token.SignedString(idp.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	idp := newFakeIdP(t)
+	client := NewOIDCClient(5 * time.Second)
+	p := &entity.SSOProvider{IssuerURL: idp.server.URL, ClientID: "arc-hawk", RedirectURL: "https://arc.example.com/cb", Scopes: []string{"openid"}}
+	ctx := context.Background()
+
+	valid := func() jwt.MapClaims {
+		now := time.Now()
+		return jwt.MapClaims{
+			"iss":   idp.server.URL,
+			"aud":   "arc-hawk",
+			"sub":   "user-1",
+			"email": "jane@example.com",
+			"nonce": "n-123",
+			"iat":   now.Unix(),
+			"exp":   now.Add(5 * time.Minute).Unix(),
+		}
+	}
+
+	claims, err := client.VerifyIDToken(ctx, p, idp.sign(t, valid()), "n-123")
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if claims["email"] != "jane@example.com" {
+		t.Errorf("unexpected claims: %v", claims)
+	}
+
+	tamper := map[string]func(jwt.MapClaims){
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "someone-else" },
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"wrong nonce":    func(c jwt.MapClaims) { c["nonce"] = "replayed" },
+		"foreign azp":    func(c jwt.MapClaims) { c["aud"] = []string{"arc-hawk", "other"}; c["azp"] = "other" },
+	}
+	for name, mutate := range tamper {
+		c := valid()
+		mutate(c)
+		if _, err := client.VerifyIDToken(ctx, p, idp.sign(t, c), "n-123"); err == nil {
+			t.Errorf("%s: token accepted", name)
+		}
+	}
+
+	// A token signed by another key must not verify even with a known kid
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, valid())
+	forged.Header["kid"] = "k1"
+	raw, _ := forged.SignedString(other)
+	if _, err := client.VerifyIDToken(ctx, p, raw, "n-123"); err == nil {
+		t.Error("forged token accepted")
+	}
+
+	// HMAC tokens keyed with public material are rejected outright
+	hmacToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, valid()).SignedString([]byte("secret"))
+	if _, err := client.VerifyIDToken(ctx, p, hmacToken, "n-123"); err == nil {
+		t.Error("HS256 token accepted")
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp := newFakeIdP(t)
+	client := NewOIDCClient(5 * time.Second)
+	p := &entity.SSOProvider{IssuerURL: idp.server.URL, ClientID: "arc-hawk", RedirectURL: "https://arc.example.com/cb", Scopes: []string{"openid", "email"}}
+
+	authURL, err := client.AuthCodeURL(context.Background(), p, "st", "no", "verifier")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	for _, want := range []string{"/authorize?", "client_id=arc-hawk", "state=st", "nonce=no", "code_challenge_method=S256", "scope=openid+email"} {
+		if !strings.Contains(authURL, want) {
+			t.Errorf("authorization URL %q lacks %q", authURL, want)
+		}
+	}
+}
+
+func newSSOServiceTest(t *testing.T) (*SSOService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewSSOService(persistence.NewPostgresRepository(db), nil, nil, nil, config.SSOConfig{}), mock
+}
+
+func TestGetProviderNotConfigured(t *testing.T) {
+	svc, mock := newSSOServiceTest(t)
+	tenantID := uuid.New()
+	mock.ExpectQuery(`FROM sso_providers WHERE tenant_id = \$1`).WithArgs(tenantID).WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec(`DELETE FROM sso_providers`).WithArgs(tenantID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if _, err := svc.GetProvider(context.Background(), tenantID); !errors.Is(err, ErrSSONotConfigured) {
+		t.Errorf("expected ErrSSONotConfigured for a missing provider, got %v", err)
+	}
+	if err := svc.DeleteProvider(context.Background(), tenantID); !errors.Is(err, ErrSSONotConfigured) {
+		t.Errorf("expected ErrSSONotConfigured when deleting a missing provider, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProvisionUserRequiresVerifiedEmailToLink(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims jwt.MapClaims
+	}{
+		{name: "email_verified absent", claims: jwt.MapClaims{"email": "ana@example.com"}},
+		{name: "email_verified not a bool", claims: jwt.MapClaims{"email": "ana@example.com", "email_verified": "true"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc, mock := newSSOServiceTest(t)
+			p := &entity.SSOProvider{TenantID: uuid.New(), JITProvisioning: true}
+			now := time.Now()
+			mock.ExpectQuery(`FROM users WHERE email = \$1`).WithArgs("ana@example.com").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password_hash", "first_name", "last_name", "role", "tenant_id", "is_active", "org_unit_id", "last_login_at", "created_at", "updated_at"}).
+					AddRow(uuid.New(), "ana@example.com", "hash", "Ana", "", "admin", p.TenantID, true, nil, nil, now, now))
+
+			if _, err := svc.provisionUser(context.Background(), p, tc.claims); !errors.Is(err, ErrSSOEmailUnverified) {
+				t.Errorf("expected ErrSSOEmailUnverified, got %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
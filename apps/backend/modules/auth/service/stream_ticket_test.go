@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func TestStreamTicketIsSingleUse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	sessions := NewSessionService(persistence.NewPostgresRepository(db), nil, nil, config.SessionConfig{})
+
+	sessionID, userID, tenantID := uuid.New(), uuid.New(), uuid.New()
+	claims := &JWTClaims{
+		UserID: userID.String(), Email: "asha@example.in", Role: "admin", TenantID: tenantID.String(),
+		SessionID: sessionID.String(), RegisteredClaims: jwt.RegisteredClaims{Issuer: "arc-hawk", ID: "jti-1"},
+	}
+
+	mock.ExpectExec(`INSERT INTO stream_tickets`).
+		WithArgs(sqlmock.AnyArg(), sessionID, "jti-1", userID, tenantID, "asha@example.in", "admin", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	ticket, expiresAt, err := sessions.IssueStreamTicket(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("IssueStreamTicket: %v", err)
+	}
+	if ticket == "" || time.Until(expiresAt) > StreamTicketTTL {
+		t.Fatalf("unexpected ticket %q expiring at %s", ticket, expiresAt)
+	}
+
+	// Only the hash is stored and looked up
+	columns := []string{"session_id", "token_id", "user_id", "tenant_id", "email", "role", "expires_at", "live"}
+	mock.ExpectQuery(`DELETE FROM stream_tickets WHERE ticket_hash = \$1`).WithArgs(HashToken(ticket)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(sessionID, "jti-1", userID, tenantID, "asha@example.in", "admin", expiresAt, true))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs(sessionID, "jti-1").
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(true))
+	redeemed, err := sessions.RedeemStreamTicket(context.Background(), ticket)
+	if err != nil {
+		t.Fatalf("RedeemStreamTicket: %v", err)
+	}
+	if redeemed.UserID != claims.UserID || redeemed.TenantID != claims.TenantID || redeemed.Role != "admin" {
+		t.Errorf("unexpected claims: %+v", redeemed)
+	}
+
+	mock.ExpectQuery(`DELETE FROM stream_tickets`).WithArgs(HashToken(ticket)).WillReturnError(sql.ErrNoRows)
+	if _, err := sessions.RedeemStreamTicket(context.Background(), ticket); err != ErrStreamTicketInvalid {
+		t.Errorf("second redemption: got %v, want ErrStreamTicketInvalid", err)
+	}
+
+	// Tickets are void once their session is revoked
+	mock.ExpectQuery(`DELETE FROM stream_tickets`).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(sessionID, "jti-1", userID, tenantID, "asha@example.in", "admin", expiresAt, true))
+	mock.ExpectQuery(`SELECT EXISTS`).WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+	if _, err := sessions.RedeemStreamTicket(context.Background(), "other-ticket"); err != ErrSessionRevoked {
+		t.Errorf("ticket of a revoked session: got %v, want ErrSessionRevoked", err)
+	}
+
+	mock.ExpectQuery(`DELETE FROM stream_tickets`).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(sessionID, "jti-1", userID, tenantID, "asha@example.in", "admin", expiresAt, false))
+	if _, err := sessions.RedeemStreamTicket(context.Background(), "expired-ticket"); err != ErrStreamTicketInvalid {
+		t.Errorf("expired ticket: got %v, want ErrStreamTicketInvalid", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
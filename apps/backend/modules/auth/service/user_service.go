@@ -22,14 +22,12 @@ var (
 )
 
 type UserService struct {
-	repo       *persistence.PostgresRepository
-	jwtService *JWTService
+	repo *persistence.PostgresRepository
 }
 
 func NewUserService(repo *persistence.PostgresRepository) *UserService {
 	return &UserService{
-		repo:       repo,
-		jwtService: NewJWTService(),
+		repo: repo,
 	}
 }
 
@@ -64,35 +62,34 @@ func (s *UserService) CreateUser(ctx context.Context, tenantID uuid.UUID, email,
 	return user, nil
 }
 
-func (s *UserService) Authenticate(ctx context.Context, email, password, tenantIDStr string) (*entity.User, string, string, error) {
+// Authenticate checks a local password login. Tokens are issued by the SessionService.
+func (s *UserService) Authenticate(ctx context.Context, email, password, tenantIDStr string) (*entity.User, error) {
 	user, err := s.repo.GetUserByEmail(ctx, email)
 	if err != nil {
-		return nil, "", "", ErrUserNotFound
+		return nil, ErrUserNotFound
 	}
 
 	if !user.IsActive {
-		return nil, "", "", ErrUserInactive
+		return nil, ErrUserInactive
 	}
 
 	if user.TenantID.String() != tenantIDStr {
-		return nil, "", "", ErrUserNotFound
+		return nil, ErrUserNotFound
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return nil, "", "", ErrInvalidPassword
+		return nil, ErrInvalidPassword
 	}
 
-	sessionID := uuid.New()
-	token, refreshToken, err := s.jwtService.GenerateToken(user, sessionID)
-	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to generate token: %w", err)
+	if err := checkLocalLogin(ctx, s.repo, user); err != nil {
+		return nil, err
 	}
 
 	now := time.Now()
 	user.LastLoginAt = &now
 	s.repo.UpdateUser(ctx, user)
 
-	return user, token, refreshToken, nil
+	return user, nil
 }
 
 func (s *UserService) GetUserByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
@@ -108,7 +105,8 @@ func (s *UserService) UpdateUser(ctx context.Context, user *entity.User) error {
 	return s.repo.UpdateUser(ctx, user)
 }
 
-func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+// ChangePassword sets a new password and signs the user out everywhere except the given session
+func (s *UserService) ChangePassword(ctx context.Context, userID, keepSessionID uuid.UUID, currentPassword, newPassword string) error {
 	user, err := s.GetUserByID(ctx, userID)
 	if err != nil {
 		return err
@@ -124,7 +122,11 @@ func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, curr
 	}
 
 	user.PasswordHash = string(hashedPassword)
-	return s.UpdateUser(ctx, user)
+	if err := s.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	_, err = s.repo.RevokeUserAuthSessions(ctx, userID, keepSessionID, RevokeReasonPasswordChanged)
+	return err
 }
 
 func (s *UserService) ResetPassword(ctx context.Context, userID uuid.UUID, newPassword string) error {
@@ -139,7 +141,11 @@ func (s *UserService) ResetPassword(ctx context.Context, userID uuid.UUID, newPa
 	}
 
 	user.PasswordHash = string(hashedPassword)
-	return s.UpdateUser(ctx, user)
+	if err := s.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	_, err = s.repo.RevokeUserAuthSessions(ctx, userID, uuid.Nil, RevokeReasonPasswordChanged)
+	return err
 }
 
 func (s *UserService) DeactivateUser(ctx context.Context, userID uuid.UUID) error {
@@ -149,7 +155,11 @@ func (s *UserService) DeactivateUser(ctx context.Context, userID uuid.UUID) erro
 	}
 
 	user.IsActive = false
-	return s.UpdateUser(ctx, user)
+	if err := s.UpdateUser(ctx, user); err != nil {
+		return err
+	}
+	_, err = s.repo.RevokeUserAuthSessions(ctx, userID, uuid.Nil, RevokeReasonUserDeactivated)
+	return err
 }
 
 func (s *UserService) GetUsersByTenant(ctx context.Context, tenantID uuid.UUID) ([]*entity.User, error) {
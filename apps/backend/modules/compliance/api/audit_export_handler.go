@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditExportHandler handles SIEM audit export API endpoints
+type AuditExportHandler struct {
+	service *service.AuditExportService
+}
+
+// NewAuditExportHandler creates a new audit export handler
+func NewAuditExportHandler(service *service.AuditExportService) *AuditExportHandler {
+	return &AuditExportHandler{service: service}
+}
+
+// GetExportStatus returns the delivery position of each export sink
+// GET /api/v1/audit/export/status
+func (h *AuditExportHandler) GetExportStatus(c *gin.Context) {
+	statuses, err := h.service.Status(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sinks":   statuses,
+		"actions": h.service.Actions(),
+	})
+}
+
+// ReplayAuditEvents re-sends audit events in a time range to the export sinks
+// POST /api/v1/audit/export/replay
+func (h *AuditExportHandler) ReplayAuditEvents(c *gin.Context) {
+	var req struct {
+		Start   time.Time `json:"start" binding:"required"`
+		End     time.Time `json:"end"`
+		Sink    string    `json:"sink"`
+		Actions []string  `json:"actions"`
+	}
+
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+	if req.End.IsZero() {
+		req.End = time.Now()
+	}
+	if !req.End.After(req.Start) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end must be after start"})
+		return
+	}
+
+	results, err := h.service.Replay(sharedapi.RequestContext(c), service.AuditReplayRequest{
+		Sink:    req.Sink,
+		Since:   req.Start,
+		Until:   req.End,
+		Actions: req.Actions,
+	})
+	if errors.Is(err, service.ErrUnknownAuditSink) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error(), "results": results})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"results": results,
+	})
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ComplianceScoreHandler serves the tenant's compliance posture score
+type ComplianceScoreHandler struct {
+	service *service.ComplianceScoreService
+}
+
+// NewComplianceScoreHandler creates a new compliance score handler
+func NewComplianceScoreHandler(service *service.ComplianceScoreService) *ComplianceScoreHandler {
+	return &ComplianceScoreHandler{service: service}
+}
+
+// GetScore handles GET /api/v1/compliance/score
+// Query: days (trend history; default 90, at most 365)
+func (h *ComplianceScoreHandler) GetScore(c *gin.Context) {
+	days := 0
+	if v := c.Query("days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 365"})
+			return
+		}
+		days = parsed
+	}
+
+	score, err := h.service.Score(sharedapi.RequestContext(c), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to compute compliance score",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": score})
+}
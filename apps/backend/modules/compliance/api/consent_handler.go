@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,8 +23,7 @@ func NewConsentHandler(service *service.ConsentService) *ConsentHandler {
 // POST /api/v1/consent/records
 func (h *ConsentHandler) RecordConsent(c *gin.Context) {
 	var req service.ConsentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -79,8 +79,7 @@ func (h *ConsentHandler) WithdrawConsent(c *gin.Context) {
 	}
 
 	var req service.ConsentWithdrawalRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
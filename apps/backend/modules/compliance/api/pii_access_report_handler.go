@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// PIIAccessReportHandler serves the report of who viewed finding PII
+type PIIAccessReportHandler struct {
+	service *service.PIIAccessReportService
+}
+
+// NewPIIAccessReportHandler creates a new PII access report handler
+func NewPIIAccessReportHandler(service *service.PIIAccessReportService) *PIIAccessReportHandler {
+	return &PIIAccessReportHandler{service: service}
+}
+
+// GetReport handles GET /api/v1/audit/pii-access/report
+// Query: days (default ACCESS_AUDIT_REPORT_WINDOW_DAYS, at most 365), limit (top viewers; default 20)
+func (h *PIIAccessReportHandler) GetReport(c *gin.Context) {
+	days := 0
+	if v := c.Query("days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 365"})
+			return
+		}
+		days = parsed
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	report, err := h.service.Report(sharedapi.RequestContext(c), days, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build PII access report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
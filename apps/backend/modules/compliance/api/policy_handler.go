@@ -0,0 +1,191 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PolicyHandler handles policy rule management and policy violation requests
+type PolicyHandler struct {
+	service *service.PolicyService
+}
+
+// NewPolicyHandler creates a new policy handler
+func NewPolicyHandler(service *service.PolicyService) *PolicyHandler {
+	return &PolicyHandler{service: service}
+}
+
+// GetVariables handles GET /api/v1/policy/variables, the finding attributes rules can reference
+func (h *PolicyHandler) GetVariables(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": service.PolicyVariables})
+}
+
+// CreateRule handles POST /api/v1/policy/rules
+func (h *PolicyHandler) CreateRule(c *gin.Context) {
+	var input service.PolicyRuleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	actor := c.GetString("user_email")
+	if actor == "" {
+		actor = "system"
+	}
+	rule, err := h.service.CreateRule(sharedapi.RequestContext(c), input, actor)
+	if err != nil {
+		c.JSON(statusForPolicyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule})
+}
+
+// ListRules handles GET /api/v1/policy/rules
+func (h *PolicyHandler) ListRules(c *gin.Context) {
+	rules, err := h.service.ListRules(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list policy rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules, "total": len(rules)})
+}
+
+// GetRule handles GET /api/v1/policy/rules/:id
+func (h *PolicyHandler) GetRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy rule ID"})
+		return
+	}
+
+	rule, err := h.service.GetRule(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForPolicyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// UpdateRule handles PUT /api/v1/policy/rules/:id
+func (h *PolicyHandler) UpdateRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy rule ID"})
+		return
+	}
+
+	var input service.PolicyRuleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	rule, err := h.service.UpdateRule(sharedapi.RequestContext(c), id, input)
+	if err != nil {
+		c.JSON(statusForPolicyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// DeleteRule handles DELETE /api/v1/policy/rules/:id
+func (h *PolicyHandler) DeleteRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy rule ID"})
+		return
+	}
+
+	if err := h.service.DeleteRule(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForPolicyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Policy rule deleted"})
+}
+
+// Evaluate handles POST /api/v1/policy/evaluate, evaluating the tenant's active rules now
+func (h *PolicyHandler) Evaluate(c *gin.Context) {
+	results, err := h.service.EvaluateNow(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to evaluate policy rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// ListViolations handles GET /api/v1/policy/violations
+// Query: rule_id, status (open or resolved), severity, limit (default 50, at most 500), offset
+func (h *PolicyHandler) ListViolations(c *gin.Context) {
+	filter := entity.PolicyViolationFilter{
+		Status:   c.Query("status"),
+		Severity: c.Query("severity"),
+		Limit:    50,
+	}
+	if v := c.Query("rule_id"); v != "" {
+		ruleID, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy rule ID"})
+			return
+		}
+		filter.RuleID = &ruleID
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		filter.Offset = offset
+	}
+
+	violations, total, err := h.service.ListViolations(sharedapi.RequestContext(c), filter)
+	if err != nil {
+		c.JSON(statusForPolicyError(err), gin.H{"error": "Failed to list policy violations", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": violations, "total": total})
+}
+
+func statusForPolicyError(err error) int {
+	if errors.Is(err, service.ErrPolicySecretUnavailable) {
+		return http.StatusBadRequest
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"), strings.Contains(msg, "must not"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -29,8 +30,7 @@ func (h *RetentionHandler) SetRetentionPolicy(c *gin.Context) {
 		PolicyBasis string `json:"policy_basis"`
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
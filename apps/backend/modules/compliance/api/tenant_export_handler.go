@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/compliance/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TenantExportHandler handles requests for and downloads of tenant data bundles
+type TenantExportHandler struct {
+	service *service.TenantExportService
+}
+
+// NewTenantExportHandler creates a new tenant export handler
+func NewTenantExportHandler(service *service.TenantExportService) *TenantExportHandler {
+	return &TenantExportHandler{service: service}
+}
+
+// RequestExport handles POST /api/v1/compliance/exports
+// Body: {"reason": "..."}; the bundle is built in the background
+func (h *TenantExportHandler) RequestExport(c *gin.Context) {
+	var input struct {
+		Reason string `json:"reason"`
+	}
+	if !sharedapi.BindOptionalJSON(c, &input) {
+		return
+	}
+
+	actor := c.GetString("user_email")
+	if actor == "" {
+		actor = "system"
+	}
+	export, err := h.service.Request(sharedapi.RequestContext(c), strings.TrimSpace(input.Reason), actor)
+	if err != nil {
+		c.JSON(statusForTenantExportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"data": export})
+}
+
+// ListExports handles GET /api/v1/compliance/exports
+func (h *TenantExportHandler) ListExports(c *gin.Context) {
+	exports, err := h.service.List(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list tenant exports",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": exports})
+}
+
+// GetExport handles GET /api/v1/compliance/exports/:id
+func (h *TenantExportHandler) GetExport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenant export ID"})
+		return
+	}
+
+	export, err := h.service.Get(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForTenantExportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": export})
+}
+
+// DownloadExport handles GET /api/v1/compliance/exports/:id/download
+func (h *TenantExportHandler) DownloadExport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenant export ID"})
+		return
+	}
+
+	actor := c.GetString("user_email")
+	if actor == "" {
+		actor = "system"
+	}
+	export, body, err := h.service.Download(sharedapi.RequestContext(c), id, actor)
+	if err != nil {
+		c.JSON(statusForTenantExportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "tenant-export-"+export.ID.String()+".tar.gz"))
+	c.Header("X-Checksum-SHA256", export.ChecksumSHA256)
+	c.Data(http.StatusOK, "application/gzip", body)
+}
+
+func statusForTenantExportError(err error) int {
+	if errors.Is(err, service.ErrTenantExportInProgress) {
+		return http.StatusConflict
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "not ready"):
+		return http.StatusConflict
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
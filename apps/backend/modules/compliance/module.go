@@ -1,27 +1,49 @@
 package compliance
 
 import (
+	"context"
 	"log"
 
+	"github.com/arc-platform/backend/modules/auth/middleware"
 	"github.com/arc-platform/backend/modules/compliance/api"
 	"github.com/arc-platform/backend/modules/compliance/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/archive"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/auditexport"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
 )
 
 type ComplianceModule struct {
-	complianceService *service.ComplianceService
-	consentService    *service.ConsentService
-	retentionService  *service.RetentionService
-	auditService      *service.AuditService
-
-	complianceHandler *api.ComplianceHandler
-	consentHandler    *api.ConsentHandler
-	retentionHandler  *api.RetentionHandler
-	auditHandler      *api.AuditHandler
-
-	deps *interfaces.ModuleDependencies
+	complianceService   *service.ComplianceService
+	consentService      *service.ConsentService
+	retentionService    *service.RetentionService
+	auditService        *service.AuditService
+	exportService       *service.AuditExportService // nil when audit export is disabled
+	accessService       *service.PIIAccessReportService
+	policyService       *service.PolicyService
+	tenantExportService *service.TenantExportService // nil without an export store or job queue
+	scoreService        *service.ComplianceScoreService
+
+	complianceHandler   *api.ComplianceHandler
+	consentHandler      *api.ConsentHandler
+	retentionHandler    *api.RetentionHandler
+	auditHandler        *api.AuditHandler
+	exportHandler       *api.AuditExportHandler
+	accessHandler       *api.PIIAccessReportHandler
+	policyHandler       *api.PolicyHandler
+	tenantExportHandler *api.TenantExportHandler
+	scoreHandler        *api.ComplianceScoreHandler
+
+	// The PII access report and policy rule changes are admin-only
+	authMiddleware *middleware.AuthMiddleware
+
+	deps            *interfaces.ModuleDependencies
+	cancelWorker    context.CancelFunc
+	cancelEvaluator context.CancelFunc
+	cancelSnapshots context.CancelFunc
 }
 
 func (m *ComplianceModule) Name() string {
@@ -45,8 +67,83 @@ func (m *ComplianceModule) Initialize(deps *interfaces.ModuleDependencies) error
 	m.consentHandler = api.NewConsentHandler(m.consentService)
 	m.retentionHandler = api.NewRetentionHandler(m.retentionService)
 	m.auditHandler = api.NewAuditHandler(m.auditService)
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	// Reads of finding PII are recorded by the access audit middleware
+	accessOpts := service.PIIAccessReportOptions{}
+	if deps.Config != nil {
+		accessCfg := deps.Config.AccessAudit
+		accessOpts = service.PIIAccessReportOptions{
+			WindowDays: accessCfg.ReportWindowDays,
+			Factor:     accessCfg.AnomalyFactor,
+			MinViews:   accessCfg.AnomalyMinViews,
+		}
+	}
+	m.accessService = service.NewPIIAccessReportService(repo, accessOpts)
+	m.accessHandler = api.NewPIIAccessReportHandler(m.accessService)
+
+	// Policy rules are evaluated nightly; their webhook secrets are encrypted at rest
+	var policyCfg config.PolicyConfig
+	if deps.Config != nil {
+		policyCfg = deps.Config.Policy
+	}
+	encryptionService, err := encryption.NewEncryptionService()
+	if err != nil {
+		log.Printf("WARN: Policy webhook secrets unavailable: %v", err)
+		encryptionService = nil
+	}
+	m.policyService = service.NewPolicyService(repo, encryptionService, deps.AuditLogger, policyCfg)
+	m.policyService.SetEventPublisher(deps.EventPublisher)
+	m.policyHandler = api.NewPolicyHandler(m.policyService)
+	if policyCfg.EvaluatorEnabled {
+		var evaluatorCtx context.Context
+		evaluatorCtx, m.cancelEvaluator = context.WithCancel(context.Background())
+		go m.policyService.StartEvaluator(evaluatorCtx, policyCfg.IntervalSeconds)
+	}
+
+	// The posture score is snapshotted daily for its trend
+	var scoreCfg config.ComplianceScoreConfig
+	if deps.Config != nil {
+		scoreCfg = deps.Config.PostureScore
+	}
+	m.scoreService = service.NewComplianceScoreService(repo, scoreCfg)
+	m.scoreHandler = api.NewComplianceScoreHandler(m.scoreService)
+	var snapshotCtx context.Context
+	snapshotCtx, m.cancelSnapshots = context.WithCancel(context.Background())
+	go m.scoreService.StartSnapshotWorker(snapshotCtx, scoreCfg.SnapshotIntervalMinutes)
+
+	// SIEM export streams audit events to syslog and/or an HTTPS collector
+	if deps.Config != nil && deps.Config.AuditExport.Enabled {
+		exportCfg := deps.Config.AuditExport
+		sinks, err := auditexport.NewSinks(exportCfg)
+		if err != nil {
+			log.Printf("WARN: Audit export disabled: %v", err)
+		} else if len(sinks) == 0 {
+			log.Printf("WARN: Audit export enabled but no syslog address or HTTP endpoint is configured")
+		} else {
+			m.exportService = service.NewAuditExportService(repo, sinks, exportCfg.Actions, exportCfg.BatchSize, deps.AuditLogger)
+			m.exportHandler = api.NewAuditExportHandler(m.exportService)
+
+			var workerCtx context.Context
+			workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+			go m.exportService.StartExportWorker(workerCtx, exportCfg.IntervalSeconds)
+		}
+	}
 
-	log.Printf("✅ Compliance Module initialized (4 services)")
+	// Tenant data bundles are built by the job queue and kept in their own object store
+	if deps.Config != nil && deps.Jobs != nil && (deps.Config.TenantExport.Bucket != "" || deps.Config.TenantExport.LocalPath != "") {
+		exportCfg := deps.Config.TenantExport
+		store, err := archive.NewObjectStore(exportCfg.StoreConfig())
+		if err != nil {
+			log.Printf("⚠️  Tenant export store unavailable: %v", err)
+		} else {
+			m.tenantExportService = service.NewTenantExportService(repo, store, exportCfg.Prefix, deps.Jobs, deps.AuditLogger)
+			m.tenantExportService.RegisterJobs(deps.Jobs)
+			m.tenantExportHandler = api.NewTenantExportHandler(m.tenantExportService)
+		}
+	}
+
+	log.Printf("✅ Compliance Module initialized (5 services)")
 	return nil
 }
 
@@ -56,6 +153,16 @@ func (m *ComplianceModule) RegisterRoutes(router *gin.RouterGroup) {
 		compliance.GET("/overview", m.complianceHandler.GetComplianceOverview)
 		compliance.GET("/violations", m.complianceHandler.GetConsentViolations)
 		compliance.GET("/critical", m.complianceHandler.GetCriticalAssets)
+		compliance.GET("/score", m.scoreHandler.GetScore)
+
+		// Full tenant data bundles for offboarding and regulator requests
+		if m.tenantExportHandler != nil {
+			exports := compliance.Group("/exports", m.authMiddleware.RequireRole("admin"))
+			exports.POST("", m.tenantExportHandler.RequestExport)
+			exports.GET("", m.tenantExportHandler.ListExports)
+			exports.GET("/:id", m.tenantExportHandler.GetExport)
+			exports.GET("/:id/download", m.tenantExportHandler.DownloadExport)
+		}
 	}
 
 	// Consent management routes
@@ -84,13 +191,44 @@ func (m *ComplianceModule) RegisterRoutes(router *gin.RouterGroup) {
 		audit.GET("/user/:userId", m.auditHandler.GetUserActivity)
 		audit.GET("/resource/:resourceType/:resourceId", m.auditHandler.GetResourceHistory)
 		audit.GET("/recent", m.auditHandler.GetRecentActivity)
+
+		// Top viewers of finding PII and anomalous access volumes
+		audit.GET("/pii-access/report", m.authMiddleware.RequireRole("admin"), m.accessHandler.GetReport)
+
+		// Export status and replay span every tenant's audit events
+		if m.exportHandler != nil {
+			audit.GET("/export/status", m.authMiddleware.RequireRole("admin"), m.exportHandler.GetExportStatus)
+			audit.POST("/export/replay", m.authMiddleware.RequireRole("admin"), m.exportHandler.ReplayAuditEvents)
+		}
+	}
+
+	// Policy-as-code compliance rules and their violations
+	policy := router.Group("/policy")
+	{
+		policy.GET("/variables", m.policyHandler.GetVariables)
+		policy.GET("/rules", m.policyHandler.ListRules)
+		policy.GET("/rules/:id", m.policyHandler.GetRule)
+		policy.POST("/rules", m.authMiddleware.RequireRole("admin"), m.policyHandler.CreateRule)
+		policy.PUT("/rules/:id", m.authMiddleware.RequireRole("admin"), m.policyHandler.UpdateRule)
+		policy.DELETE("/rules/:id", m.authMiddleware.RequireRole("admin"), m.policyHandler.DeleteRule)
+		policy.POST("/evaluate", m.authMiddleware.RequireRole("admin"), m.policyHandler.Evaluate)
+		policy.GET("/violations", m.policyHandler.ListViolations)
 	}
 
-	log.Printf("⚖️  Compliance routes registered (17 endpoints)")
+	log.Printf("⚖️  Compliance routes registered (27 endpoints)")
 }
 
 func (m *ComplianceModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Compliance Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
+	if m.cancelEvaluator != nil {
+		m.cancelEvaluator()
+	}
+	if m.cancelSnapshots != nil {
+		m.cancelSnapshots()
+	}
 	return nil
 }
 
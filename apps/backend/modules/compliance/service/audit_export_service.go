@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/auditexport"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+)
+
+// auditExportLag keeps the live export behind NOW so rows from transactions
+// that commit slightly out of created_at order are not skipped by the cursor
+const auditExportLag = 5 * time.Second
+
+// maxReplayEvents bounds a single replay request
+const maxReplayEvents = 100000
+
+// ErrUnknownAuditSink is returned when a replay names a sink that is not configured
+var ErrUnknownAuditSink = errors.New("unknown audit export sink")
+
+// AuditExportService streams audit events to SIEM sinks
+type AuditExportService struct {
+	repo        *persistence.PostgresRepository
+	sinks       []auditexport.Sink
+	actions     []string
+	batchSize   int
+	auditLogger interfaces.AuditLogger
+}
+
+// AuditExportStatus reports the delivery position of one sink
+type AuditExportStatus struct {
+	Sink          string     `json:"sink"`
+	LastCreatedAt *time.Time `json:"last_created_at,omitempty"`
+	ExportedCount int64      `json:"exported_count"`
+	LastError     string     `json:"last_error,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// AuditReplayRequest selects the events to re-send to a sink
+type AuditReplayRequest struct {
+	Sink    string // Empty replays to every sink
+	Since   time.Time
+	Until   time.Time
+	Actions []string // Empty falls back to the configured action filter
+}
+
+// AuditReplayResult summarises a replay per sink
+type AuditReplayResult struct {
+	Sink      string `json:"sink"`
+	Delivered int    `json:"delivered"`
+	Truncated bool   `json:"truncated"`
+}
+
+// NewAuditExportService creates a new audit export service
+func NewAuditExportService(repo *persistence.PostgresRepository, sinks []auditexport.Sink, actions []string, batchSize int, auditLogger interfaces.AuditLogger) *AuditExportService {
+	if batchSize < 1 {
+		batchSize = 500
+	}
+	return &AuditExportService{
+		repo:        repo,
+		sinks:       sinks,
+		actions:     actions,
+		batchSize:   batchSize,
+		auditLogger: auditLogger,
+	}
+}
+
+// StartExportWorker polls for new audit events and forwards them to every sink
+func (s *AuditExportService) StartExportWorker(ctx context.Context, intervalSeconds int) {
+	if intervalSeconds < 1 {
+		intervalSeconds = 10
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Printf("📤 Starting audit export worker (interval: %ds, sinks: %d, actions: %v)", intervalSeconds, len(s.sinks), s.actions)
+
+	for {
+		select {
+		case <-ctx.Done():
+			for _, sink := range s.sinks {
+				sink.Close()
+			}
+			log.Println("🛑 Audit export worker stopped")
+			return
+		case <-ticker.C:
+			for _, sink := range s.sinks {
+				if err := s.ExportPending(ctx, sink); err != nil {
+					log.Printf("❌ Audit export to %s failed: %v", sink.Name(), err)
+					if recErr := s.repo.RecordAuditExportError(ctx, sink.Name(), err); recErr != nil {
+						log.Printf("WARN: Failed to record audit export error: %v", recErr)
+					}
+				}
+			}
+		}
+	}
+}
+
+// ExportPending delivers every event after the sink's cursor, advancing the
+// cursor after each successfully delivered batch
+func (s *AuditExportService) ExportPending(ctx context.Context, sink auditexport.Sink) error {
+	cursor, err := s.repo.GetAuditExportCursor(ctx, sink.Name())
+	if err != nil {
+		return fmt.Errorf("failed to load export cursor: %w", err)
+	}
+
+	until := time.Now().Add(-auditExportLag)
+	filters := repository.AuditEventFilters{Until: &until, Actions: s.actions}
+	if cursor != nil {
+		filters.AfterCreatedAt = &cursor.LastCreatedAt
+		filters.AfterID = cursor.LastEventID
+	}
+
+	for {
+		events, err := s.repo.ListAuditEvents(ctx, filters, s.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		if err := sink.Send(ctx, events); err != nil {
+			return err
+		}
+
+		last := events[len(events)-1]
+		if err := s.repo.AdvanceAuditExportCursor(ctx, sink.Name(), last, len(events)); err != nil {
+			return err
+		}
+
+		if len(events) < s.batchSize {
+			return nil
+		}
+		filters.AfterCreatedAt = &last.CreatedAt
+		filters.AfterID = last.ID
+	}
+}
+
+// Replay re-sends a time range of audit events without moving the live cursor.
+// Collectors are expected to de-duplicate on the event id.
+func (s *AuditExportService) Replay(ctx context.Context, req AuditReplayRequest) ([]AuditReplayResult, error) {
+	if !req.Until.After(req.Since) {
+		return nil, fmt.Errorf("end must be after start")
+	}
+
+	sinks := s.sinks
+	if req.Sink != "" {
+		sinks = nil
+		for _, sink := range s.sinks {
+			if sink.Name() == req.Sink {
+				sinks = append(sinks, sink)
+			}
+		}
+		if len(sinks) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownAuditSink, req.Sink)
+		}
+	}
+
+	actions := req.Actions
+	if len(actions) == 0 {
+		actions = s.actions
+	}
+
+	var results []AuditReplayResult
+	for _, sink := range sinks {
+		result := AuditReplayResult{Sink: sink.Name()}
+		filters := repository.AuditEventFilters{Since: &req.Since, Until: &req.Until, Actions: actions}
+
+		for {
+			events, err := s.repo.ListAuditEvents(ctx, filters, s.batchSize)
+			if err != nil {
+				return results, err
+			}
+			if len(events) == 0 {
+				break
+			}
+			if err := sink.Send(ctx, events); err != nil {
+				return append(results, result), fmt.Errorf("replay to %s failed after %d events: %w", sink.Name(), result.Delivered, err)
+			}
+
+			result.Delivered += len(events)
+			if len(events) < s.batchSize {
+				break
+			}
+			if result.Delivered >= maxReplayEvents {
+				result.Truncated = true
+				break
+			}
+
+			last := events[len(events)-1]
+			filters.AfterCreatedAt = &last.CreatedAt
+			filters.AfterID = last.ID
+		}
+		results = append(results, result)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "AUDIT_EXPORT_REPLAYED", "audit_log", "", map[string]interface{}{
+			"start":   req.Since,
+			"end":     req.Until,
+			"sink":    req.Sink,
+			"actions": actions,
+			"results": results,
+		})
+	}
+
+	return results, nil
+}
+
+// Status returns the delivery position of every configured sink
+func (s *AuditExportService) Status(ctx context.Context) ([]AuditExportStatus, error) {
+	cursors, err := s.repo.ListAuditExportCursors(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bySink := make(map[string]*entity.AuditExportCursor, len(cursors))
+	for _, cursor := range cursors {
+		bySink[cursor.SinkName] = cursor
+	}
+
+	statuses := make([]AuditExportStatus, 0, len(s.sinks))
+	for _, sink := range s.sinks {
+		status := AuditExportStatus{Sink: sink.Name()}
+		if cursor, ok := bySink[sink.Name()]; ok {
+			status.LastCreatedAt = &cursor.LastCreatedAt
+			status.ExportedCount = cursor.ExportedCount
+			status.LastError = cursor.LastError
+			status.UpdatedAt = &cursor.UpdatedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Actions returns the configured action filter
+func (s *AuditExportService) Actions() []string {
+	return s.actions
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+const (
+	defaultScoreHistoryDays = 90
+	maxScoreHistoryDays     = 365
+)
+
+// ComplianceScore is a tenant's compliance posture now, with its daily history
+type ComplianceScore struct {
+	Score      float64                            `json:"score"`
+	Grade      string                             `json:"grade"`
+	Components []*entity.ComplianceScoreComponent `json:"components"`
+	ComputedAt time.Time                          `json:"computed_at"`
+	Change7d   *float64                           `json:"change_7d"`  // Against the snapshot 7 days ago, if any
+	Change30d  *float64                           `json:"change_30d"` // Against the snapshot 30 days ago, if any
+	History    []*entity.ComplianceScoreSnapshot  `json:"history"`
+}
+
+// ComplianceScoreService combines PII exposure, remediation velocity, review
+// backlog and policy violations into one posture score per tenant, and keeps
+// a snapshot of it per day for trends
+type ComplianceScoreService struct {
+	repo *persistence.PostgresRepository
+	cfg  config.ComplianceScoreConfig
+}
+
+// NewComplianceScoreService creates a new compliance score service
+func NewComplianceScoreService(repo *persistence.PostgresRepository, cfg config.ComplianceScoreConfig) *ComplianceScoreService {
+	if cfg.WeightExposure+cfg.WeightVelocity+cfg.WeightBacklog+cfg.WeightPolicy <= 0 {
+		cfg.WeightExposure, cfg.WeightVelocity, cfg.WeightBacklog, cfg.WeightPolicy = 0.35, 0.25, 0.2, 0.2
+	}
+	if cfg.VelocityWindowDays < 1 {
+		cfg.VelocityWindowDays = 30
+	}
+	if cfg.ReviewSLADays < 1 {
+		cfg.ReviewSLADays = 7
+	}
+	return &ComplianceScoreService{repo: repo, cfg: cfg}
+}
+
+// Score computes the tenant's current score and returns it with the last days of snapshots
+func (s *ComplianceScoreService) Score(ctx context.Context, days int) (*ComplianceScore, error) {
+	if days < 1 {
+		days = defaultScoreHistoryDays
+	}
+	if days > maxScoreHistoryDays {
+		days = maxScoreHistoryDays
+	}
+
+	current, err := s.compute(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.repo.ListComplianceScoreSnapshots(ctx, current.ComputedAt.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	score := &ComplianceScore{
+		Score:      current.Score,
+		Grade:      complianceGrade(current.Score),
+		Components: current.Components,
+		ComputedAt: current.ComputedAt,
+		History:    history,
+	}
+	score.Change7d = scoreChange(history, current, 7)
+	score.Change30d = scoreChange(history, current, 30)
+	return score, nil
+}
+
+// Snapshot computes the tenant's score and stores it as today's snapshot
+func (s *ComplianceScoreService) Snapshot(ctx context.Context) (*entity.ComplianceScoreSnapshot, error) {
+	snapshot, err := s.compute(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveComplianceScoreSnapshot(ctx, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *ComplianceScoreService) compute(ctx context.Context, now time.Time) (*entity.ComplianceScoreSnapshot, error) {
+	inputs, err := s.repo.GetComplianceScoreInputs(ctx,
+		now.AddDate(0, 0, -s.cfg.ReviewSLADays), now.AddDate(0, 0, -s.cfg.VelocityWindowDays))
+	if err != nil {
+		return nil, err
+	}
+	score, components := computeComplianceScore(inputs, s.cfg)
+	return &entity.ComplianceScoreSnapshot{
+		Date:       now.Format("2006-01-02"),
+		Score:      score,
+		Components: components,
+		ComputedAt: now,
+	}, nil
+}
+
+// computeComplianceScore scores each component from 0 to 100 and averages them by weight:
+//   - PII exposure: share of assets without open critical or high findings, masked assets excepted
+//   - Remediation velocity: findings closed per finding found in the window, capped at 100
+//   - Review backlog: share of open findings not pending review past the SLA
+//   - Policy violations: share of active policy rules without open violations
+//
+// A component with nothing to measure scores 100.
+func computeComplianceScore(in *entity.ComplianceScoreInputs, cfg config.ComplianceScoreConfig) (float64, []*entity.ComplianceScoreComponent) {
+	components := []*entity.ComplianceScoreComponent{
+		{
+			Name:   entity.ComplianceComponentExposure,
+			Score:  shareWithout(in.ExposedAssets, in.TotalAssets),
+			Weight: cfg.WeightExposure,
+			Details: map[string]int{
+				"total_assets":   in.TotalAssets,
+				"exposed_assets": in.ExposedAssets,
+			},
+		},
+		{
+			Name:   entity.ComplianceComponentVelocity,
+			Score:  velocityScore(in.ClosedFindings, in.NewFindings),
+			Weight: cfg.WeightVelocity,
+			Details: map[string]int{
+				"window_days":     cfg.VelocityWindowDays,
+				"new_findings":    in.NewFindings,
+				"closed_findings": in.ClosedFindings,
+			},
+		},
+		{
+			Name:   entity.ComplianceComponentBacklog,
+			Score:  shareWithout(in.OverdueReviews, in.OpenFindings),
+			Weight: cfg.WeightBacklog,
+			Details: map[string]int{
+				"sla_days":        cfg.ReviewSLADays,
+				"open_findings":   in.OpenFindings,
+				"overdue_reviews": in.OverdueReviews,
+			},
+		},
+		{
+			Name:   entity.ComplianceComponentPolicy,
+			Score:  shareWithout(in.ViolatedRules, in.ActiveRules),
+			Weight: cfg.WeightPolicy,
+			Details: map[string]int{
+				"active_rules":    in.ActiveRules,
+				"violated_rules":  in.ViolatedRules,
+				"open_violations": in.OpenViolations,
+			},
+		},
+	}
+
+	var totalWeight, weighted float64
+	for _, c := range components {
+		if c.Weight < 0 {
+			c.Weight = 0
+		}
+		totalWeight += c.Weight
+	}
+	for _, c := range components {
+		c.Weight = round2(c.Weight / totalWeight)
+		weighted += c.Score * c.Weight
+	}
+	return round2(weighted), components
+}
+
+// shareWithout is the percentage of total not among bad
+func shareWithout(bad, total int) float64 {
+	if total <= 0 {
+		return 100
+	}
+	return round2(100 * (1 - math.Min(float64(bad), float64(total))/float64(total)))
+}
+
+func velocityScore(closed, found int) float64 {
+	if found <= 0 {
+		return 100
+	}
+	return round2(math.Min(100, 100*float64(closed)/float64(found)))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// complianceGrade maps a score to a letter grade for dashboards
+func complianceGrade(score float64) string {
+	switch {
+	case score >= 90:
+		return "A"
+	case score >= 80:
+		return "B"
+	case score >= 70:
+		return "C"
+	case score >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// scoreChange is the difference between the current score and the snapshot of
+// the given number of days earlier, or nil without one
+func scoreChange(history []*entity.ComplianceScoreSnapshot, current *entity.ComplianceScoreSnapshot, days int) *float64 {
+	day := current.ComputedAt.AddDate(0, 0, -days).Format("2006-01-02")
+	for _, snapshot := range history {
+		if snapshot.Date == day {
+			change := round2(current.Score - snapshot.Score)
+			return &change
+		}
+	}
+	return nil
+}
+
+// StartSnapshotWorker refreshes every tenant's snapshot for the day periodically,
+// so each day keeps the last score computed on it
+func (s *ComplianceScoreService) StartSnapshotWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🕰️  Starting compliance score snapshot worker (interval: %d minutes)", intervalMinutes)
+
+	s.snapshotAllTenants(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Compliance score snapshot worker stopped")
+			return
+		case <-ticker.C:
+			s.snapshotAllTenants(ctx)
+		}
+	}
+}
+
+// snapshotAllTenants stores today's score of every tenant that owns findings
+func (s *ComplianceScoreService) snapshotAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListFindingTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for compliance score snapshots: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		if _, err := s.Snapshot(tenantCtx); err != nil {
+			log.Printf("❌ Compliance score snapshot failed for tenant %s: %v", tenantID, err)
+		}
+	}
+}
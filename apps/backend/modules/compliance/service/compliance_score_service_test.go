@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestComputeComplianceScore(t *testing.T) {
+	cfg := config.ComplianceScoreConfig{WeightExposure: 2, WeightVelocity: 1, WeightBacklog: 1, WeightPolicy: 0, VelocityWindowDays: 30, ReviewSLADays: 7}
+	in := &entity.ComplianceScoreInputs{
+		TotalAssets:    10,
+		ExposedAssets:  4, // exposure 60
+		NewFindings:    50,
+		ClosedFindings: 80, // velocity capped at 100
+		OpenFindings:   20,
+		OverdueReviews: 5, // backlog 75
+		ActiveRules:    4,
+		ViolatedRules:  4, // policy 0, but weighted 0
+	}
+
+	score, components := computeComplianceScore(in, cfg)
+
+	// (60*0.5 + 100*0.25 + 75*0.25) with weights normalized to sum to 1
+	if score != 73.75 {
+		t.Errorf("expected a score of 73.75, got %v", score)
+	}
+	want := map[string]float64{
+		entity.ComplianceComponentExposure: 60,
+		entity.ComplianceComponentVelocity: 100,
+		entity.ComplianceComponentBacklog:  75,
+		entity.ComplianceComponentPolicy:   0,
+	}
+	for _, c := range components {
+		if c.Score != want[c.Name] {
+			t.Errorf("%s scored %v, want %v", c.Name, c.Score, want[c.Name])
+		}
+	}
+	if components[0].Weight != 0.5 || components[3].Weight != 0 {
+		t.Errorf("expected normalized weights, got %v and %v", components[0].Weight, components[3].Weight)
+	}
+}
+
+func TestComputeComplianceScoreWithNothingToMeasure(t *testing.T) {
+	score, _ := computeComplianceScore(&entity.ComplianceScoreInputs{}, config.ComplianceScoreConfig{WeightExposure: 1, WeightVelocity: 1, WeightBacklog: 1, WeightPolicy: 1})
+	if score != 100 {
+		t.Errorf("expected an empty tenant to score 100, got %v", score)
+	}
+}
+
+func TestScoreChange(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	current := &entity.ComplianceScoreSnapshot{Score: 82.5, ComputedAt: now}
+	history := []*entity.ComplianceScoreSnapshot{
+		{Date: "2026-03-01", Score: 70},
+		{Date: "2026-03-24", Score: 80},
+	}
+
+	if change := scoreChange(history, current, 7); change == nil || *change != 2.5 {
+		t.Errorf("expected a 7 day change of 2.5, got %v", change)
+	}
+	if change := scoreChange(history, current, 30); change == nil || *change != 12.5 {
+		t.Errorf("expected a 30 day change of 12.5, got %v", change)
+	}
+	if change := scoreChange(history, current, 1); change != nil {
+		t.Errorf("expected no change without a snapshot, got %v", *change)
+	}
+	if complianceGrade(82.5) != "B" || complianceGrade(59.99) != "F" {
+		t.Error("unexpected grades")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/google/uuid"
@@ -64,7 +65,11 @@ func NewComplianceService(pgRepo *persistence.PostgresRepository, neo4jRepo *per
 // GetComplianceOverview returns the DPDPA compliance dashboard
 func (s *ComplianceService) GetComplianceOverview(ctx context.Context) (*ComplianceOverview, error) {
 	// Get all assets
-	assets, err := s.pgRepo.ListAssets(ctx, 10000, 0)
+	var assets []*entity.Asset
+	err := s.pgRepo.IterateAssets(ctx, func(page []*entity.Asset) error {
+		assets = append(assets, page...)
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list assets: %w", err)
 	}
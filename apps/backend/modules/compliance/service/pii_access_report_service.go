@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+// PIIAccessAnomaly is a day on which a user viewed far more findings than usual
+type PIIAccessAnomaly struct {
+	UserID        string    `json:"user_id"`
+	UserEmail     string    `json:"user_email,omitempty"`
+	Day           time.Time `json:"day"`
+	FindingViews  int       `json:"finding_views"`
+	BaselineViews float64   `json:"baseline_daily_views"` // The user's average over the other days in the window
+}
+
+// PIIAccessReport lists who read finding PII in a window and which access volumes stand out
+type PIIAccessReport struct {
+	Since      time.Time          `json:"since"`
+	WindowDays int                `json:"window_days"`
+	TopViewers []entity.PIIViewer `json:"top_viewers"`
+	Anomalies  []PIIAccessAnomaly `json:"anomalies"`
+	Factor     float64            `json:"anomaly_factor"`
+	MinViews   int                `json:"anomaly_min_views"`
+}
+
+// PIIAccessReportOptions tunes anomaly detection in the PII access report
+type PIIAccessReportOptions struct {
+	WindowDays int     // Default look-back when a report does not name one
+	Factor     float64 // A day above this multiple of the user's baseline is anomalous
+	MinViews   int     // Days with fewer finding views are never anomalous
+}
+
+// PIIAccessReportService reports on the FINDINGS_VIEWED entries the access audit
+// middleware writes to audit_logs
+type PIIAccessReportService struct {
+	repo *persistence.PostgresRepository
+	opts PIIAccessReportOptions
+}
+
+// NewPIIAccessReportService creates a new PII access report service
+func NewPIIAccessReportService(repo *persistence.PostgresRepository, opts PIIAccessReportOptions) *PIIAccessReportService {
+	if opts.WindowDays < 2 {
+		opts.WindowDays = 30
+	}
+	if opts.Factor <= 1 {
+		opts.Factor = 5
+	}
+	if opts.MinViews < 1 {
+		opts.MinViews = 200
+	}
+	return &PIIAccessReportService{repo: repo, opts: opts}
+}
+
+// Report builds the access report for the tenant in ctx over the last windowDays
+// (the configured default when zero), listing up to limit top viewers
+func (s *PIIAccessReportService) Report(ctx context.Context, windowDays, limit int) (*PIIAccessReport, error) {
+	if windowDays < 1 {
+		windowDays = s.opts.WindowDays
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(windowDays - 1))
+
+	viewers, err := s.repo.GetTopPIIViewers(ctx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.repo.GetDailyPIIAccess(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PIIAccessReport{
+		Since:      since,
+		WindowDays: windowDays,
+		TopViewers: viewers,
+		Anomalies:  detectAccessAnomalies(days, windowDays, s.opts.Factor, s.opts.MinViews),
+		Factor:     s.opts.Factor,
+		MinViews:   s.opts.MinViews,
+	}, nil
+}
+
+// detectAccessAnomalies flags user-days with at least minViews finding views and
+// more than factor times the user's average over the window's other days, where
+// days without reads count as zero. Most views first.
+func detectAccessAnomalies(days []entity.PIIAccessDay, windowDays int, factor float64, minViews int) []PIIAccessAnomaly {
+	totals := make(map[string]int)
+	for _, d := range days {
+		totals[d.UserID] += d.FindingViews
+	}
+
+	anomalies := []PIIAccessAnomaly{}
+	for _, d := range days {
+		if d.FindingViews < minViews {
+			continue
+		}
+		baseline := 0.0
+		if windowDays > 1 {
+			baseline = float64(totals[d.UserID]-d.FindingViews) / float64(windowDays-1)
+		}
+		if float64(d.FindingViews) <= factor*baseline {
+			continue
+		}
+		anomalies = append(anomalies, PIIAccessAnomaly{
+			UserID:        d.UserID,
+			UserEmail:     d.UserEmail,
+			Day:           d.Day,
+			FindingViews:  d.FindingViews,
+			BaselineViews: math.Round(baseline*100) / 100,
+		})
+	}
+
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return anomalies[i].FindingViews > anomalies[j].FindingViews
+	})
+	return anomalies
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestDetectAccessAnomalies(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2026, 10, n, 0, 0, 0, 0, time.UTC) }
+	days := []entity.PIIAccessDay{
+		// A steady reader: 100 views on each of ten days
+		{UserID: "steady", Day: day(1), FindingViews: 100},
+		{UserID: "steady", Day: day(2), FindingViews: 100},
+		{UserID: "steady", Day: day(3), FindingViews: 100},
+		{UserID: "steady", Day: day(4), FindingViews: 100},
+		{UserID: "steady", Day: day(5), FindingViews: 100},
+		{UserID: "steady", Day: day(6), FindingViews: 100},
+		{UserID: "steady", Day: day(7), FindingViews: 100},
+		{UserID: "steady", Day: day(8), FindingViews: 100},
+		{UserID: "steady", Day: day(9), FindingViews: 100},
+		{UserID: "steady", Day: day(10), FindingViews: 300},
+		// A spike from a user who rarely reads findings
+		{UserID: "spike", Day: day(3), FindingViews: 20},
+		{UserID: "spike", Day: day(9), FindingViews: 900},
+		// Far above baseline but under the minimum volume
+		{UserID: "quiet", Day: day(5), FindingViews: 40},
+	}
+
+	anomalies := detectAccessAnomalies(days, 10, 5, 200)
+	if len(anomalies) != 1 {
+		t.Fatalf("expected 1 anomaly, got %+v", anomalies)
+	}
+	got := anomalies[0]
+	if got.UserID != "spike" || got.FindingViews != 900 || !got.Day.Equal(day(9)) {
+		t.Errorf("unexpected anomaly: %+v", got)
+	}
+	if got.BaselineViews != 2.22 {
+		t.Errorf("expected a baseline of 2.22 views/day, got %v", got.BaselineViews)
+	}
+}
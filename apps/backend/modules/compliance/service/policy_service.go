@@ -0,0 +1,555 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/policyexpr"
+	"github.com/google/uuid"
+)
+
+const (
+	maxPolicyExpressionLength = 2000
+	policyFactsBatchSize      = 1000
+	maxPolicyWebhookFindings  = 100 // Finding IDs listed in one webhook alert
+)
+
+// Headers sent with every policy webhook. The signature is an HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the rule's secret, as for report webhooks.
+const (
+	policyRuleHeader       = "X-ARC-Policy-Rule"
+	policyTimestampHeader  = "X-ARC-Timestamp"
+	policySignatureHeader  = "X-ARC-Signature"
+	policyWebhookEventName = "policy.violations"
+)
+
+// ErrPolicySecretUnavailable is returned when a webhook secret is supplied but cannot be encrypted
+var ErrPolicySecretUnavailable = errors.New("webhook secret cannot be stored: ENCRYPTION_KEY is not configured")
+
+var policySeverities = map[string]bool{"critical": true, "high": true, "medium": true, "low": true}
+
+// PolicyVariable is a finding attribute policy expressions can reference
+type PolicyVariable struct {
+	Name        string          `json:"name"`
+	Type        policyexpr.Type `json:"type"`
+	Description string          `json:"description"`
+}
+
+// PolicyVariables are the variables of policy expressions, one finding at a time
+var PolicyVariables = []PolicyVariable{
+	{"pii_type", policyexpr.String, "PII type, e.g. CREDIT_CARD or IN_AADHAAR: the classification sub-category, else the pattern name"},
+	{"pattern_name", policyexpr.String, "Name of the pattern that matched"},
+	{"classification_type", policyexpr.String, "Classification of the finding, e.g. Sensitive Personal Data"},
+	{"severity", policyexpr.String, "Finding severity, lowercased: critical, high, medium or low"},
+	{"confidence", policyexpr.Number, "Classification confidence between 0 and 1"},
+	{"environment", policyexpr.String, "Environment of the finding as recorded by the scanner, e.g. Production"},
+	{"data_source", policyexpr.String, "Data source of the asset, e.g. postgresql or fs"},
+	{"asset_name", policyexpr.String, "Name of the asset"},
+	{"asset_path", policyexpr.String, "Path of the asset"},
+	{"review_status", policyexpr.String, "Review status; open when never reviewed"},
+	{"age_days", policyexpr.Number, "Whole days since the finding was first recorded"},
+	{"remediations", policyexpr.StringList, "Action types of completed, active remediations, e.g. ENCRYPT or MASK"},
+	{"remediated", policyexpr.Bool, "Whether any remediation is completed and active"},
+}
+
+var policyDecls = func() map[string]policyexpr.Type {
+	decls := make(map[string]policyexpr.Type, len(PolicyVariables))
+	for _, v := range PolicyVariables {
+		decls[v.Name] = v.Type
+	}
+	return decls
+}()
+
+// PolicyRuleInput is the payload for creating or replacing a policy rule
+type PolicyRuleInput struct {
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	Expression    string `json:"expression"`
+	Severity      string `json:"severity"` // Defaults to high
+	WebhookURL    string `json:"webhook_url"`
+	WebhookSecret string `json:"webhook_secret"`      // Kept on update when omitted and the URL is unchanged
+	IsActive      *bool  `json:"is_active,omitempty"` // Defaults to true
+}
+
+// PolicyEvaluation is the outcome of evaluating one rule
+type PolicyEvaluation struct {
+	RuleID        uuid.UUID `json:"rule_id"`
+	RuleName      string    `json:"rule_name"`
+	Violations    int       `json:"violations"`
+	NewViolations int       `json:"new_violations"`
+	Resolved      int       `json:"resolved"`
+	Webhook       string    `json:"webhook,omitempty"` // "delivered" or "failed" when new violations were posted
+	Error         string    `json:"error,omitempty"`
+}
+
+// PolicyService manages tenant policy rules and evaluates them against findings
+type PolicyService struct {
+	repo           *persistence.PostgresRepository
+	encryption     *encryption.EncryptionService // nil when ENCRYPTION_KEY is unset; webhooks are then unsigned
+	auditLogger    interfaces.AuditLogger
+	events         interfaces.EventPublisher
+	client         *http.Client
+	evaluationHour int
+}
+
+// NewPolicyService creates a new policy service
+func NewPolicyService(repo *persistence.PostgresRepository, enc *encryption.EncryptionService, auditLogger interfaces.AuditLogger, cfg config.PolicyConfig) *PolicyService {
+	hour := cfg.EvaluationHourUTC
+	if hour < 0 || hour > 23 {
+		hour = 2
+	}
+	timeout := time.Duration(cfg.WebhookTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &PolicyService{
+		repo:           repo,
+		encryption:     enc,
+		auditLogger:    auditLogger,
+		events:         &interfaces.NoOpEventPublisher{},
+		client:         &http.Client{Timeout: timeout},
+		evaluationHour: hour,
+	}
+}
+
+// SetEventPublisher enables policy_violations_found events
+func (s *PolicyService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// CreateRule validates and stores a new policy rule, first evaluated at the next nightly run
+func (s *PolicyService) CreateRule(ctx context.Context, input PolicyRuleInput, createdBy string) (*entity.PolicyRule, error) {
+	rule := &entity.PolicyRule{
+		ID:               uuid.New(),
+		IsActive:         true,
+		NextEvaluationAt: nextPolicyEvaluation(time.Now(), s.evaluationHour),
+		CreatedBy:        createdBy,
+	}
+	if err := s.applyRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreatePolicyRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "POLICY_RULE_CREATED", rule)
+	return rule, nil
+}
+
+// GetRule returns a policy rule
+func (s *PolicyService) GetRule(ctx context.Context, id uuid.UUID) (*entity.PolicyRule, error) {
+	return s.repo.GetPolicyRule(ctx, id)
+}
+
+// ListRules returns the tenant's policy rules
+func (s *PolicyService) ListRules(ctx context.Context) ([]*entity.PolicyRule, error) {
+	return s.repo.ListPolicyRules(ctx)
+}
+
+// UpdateRule replaces a policy rule. Its violations are kept until the next evaluation.
+func (s *PolicyService) UpdateRule(ctx context.Context, id uuid.UUID, input PolicyRuleInput) (*entity.PolicyRule, error) {
+	rule, err := s.repo.GetPolicyRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	wasActive := rule.IsActive
+	if err := s.applyRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+	if rule.IsActive && !wasActive {
+		rule.NextEvaluationAt = nextPolicyEvaluation(time.Now(), s.evaluationHour)
+	}
+
+	if err := s.repo.UpdatePolicyRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "POLICY_RULE_UPDATED", rule)
+	return s.repo.GetPolicyRule(ctx, id)
+}
+
+// DeleteRule deletes a policy rule and its violations
+func (s *PolicyService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	rule, err := s.repo.GetPolicyRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeletePolicyRule(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, "POLICY_RULE_DELETED", rule)
+	return nil
+}
+
+// ListViolations returns the tenant's policy violations matching the filter, with their total
+func (s *PolicyService) ListViolations(ctx context.Context, filter entity.PolicyViolationFilter) ([]*entity.PolicyViolation, int, error) {
+	filter.Status = strings.ToLower(strings.TrimSpace(filter.Status))
+	if filter.Status != "" && filter.Status != entity.PolicyViolationOpen && filter.Status != entity.PolicyViolationResolved {
+		return nil, 0, fmt.Errorf("invalid status %q: must be open or resolved", filter.Status)
+	}
+	filter.Severity = strings.ToLower(strings.TrimSpace(filter.Severity))
+	if filter.Severity != "" && !policySeverities[filter.Severity] {
+		return nil, 0, fmt.Errorf("invalid severity %q: must be critical, high, medium or low", filter.Severity)
+	}
+	return s.repo.ListPolicyViolations(ctx, filter)
+}
+
+// EvaluateNow evaluates every active rule of the tenant immediately, outside the nightly run
+func (s *PolicyService) EvaluateNow(ctx context.Context) ([]*PolicyEvaluation, error) {
+	rules, err := s.repo.ListPolicyRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := rules[:0]
+	for _, rule := range rules {
+		if rule.IsActive {
+			active = append(active, rule)
+		}
+	}
+	return s.evaluate(ctx, active)
+}
+
+// StartEvaluator periodically evaluates the due policy rules of every tenant until ctx is cancelled
+func (s *PolicyService) StartEvaluator(ctx context.Context, intervalSeconds int) {
+	if intervalSeconds < 1 {
+		intervalSeconds = 300
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Printf("📜 Starting policy evaluator (interval: %ds, nightly at %02d:00 UTC)", intervalSeconds, s.evaluationHour)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Policy evaluator stopped")
+			return
+		case <-ticker.C:
+			s.RunDueRules(ctx)
+		}
+	}
+}
+
+// RunDueRules claims and evaluates every rule whose nightly evaluation has passed,
+// a tenant's rules in one pass over its findings. Rules are claimed first so
+// replicas never evaluate, and alert on, the same run twice.
+func (s *PolicyService) RunDueRules(ctx context.Context) {
+	now := time.Now().UTC()
+	rules, err := s.repo.ListDuePolicyRules(ctx, now)
+	if err != nil {
+		log.Printf("❌ Error listing due policy rules: %v", err)
+		return
+	}
+
+	next := nextPolicyEvaluation(now, s.evaluationHour)
+	byTenant := make(map[uuid.UUID][]*entity.PolicyRule)
+	var tenants []uuid.UUID
+	for _, rule := range rules {
+		claimed, err := s.repo.ClaimPolicyRule(ctx, rule.ID, rule.NextEvaluationAt, next)
+		if err != nil {
+			log.Printf("❌ Policy rule %s (%s) failed: %v", rule.ID, rule.Name, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if _, ok := byTenant[rule.TenantID]; !ok {
+			tenants = append(tenants, rule.TenantID)
+		}
+		byTenant[rule.TenantID] = append(byTenant[rule.TenantID], rule)
+	}
+
+	for _, tenantID := range tenants {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		results, err := s.evaluate(tenantCtx, byTenant[tenantID])
+		if err != nil {
+			log.Printf("❌ Policy evaluation for tenant %s failed: %v", tenantID, err)
+			continue
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				log.Printf("❌ Policy rule %s (%s) failed: %s", result.RuleID, result.RuleName, result.Error)
+			} else if result.NewViolations > 0 {
+				log.Printf("📜 Policy rule %s (%s): %d new violations", result.RuleID, result.RuleName, result.NewViolations)
+			}
+		}
+	}
+}
+
+// evaluate runs the rules of the context's tenant over all of its findings, records
+// their violations and alerts on new ones
+func (s *PolicyService) evaluate(ctx context.Context, rules []*entity.PolicyRule) ([]*PolicyEvaluation, error) {
+	results := make([]*PolicyEvaluation, len(rules))
+	programs := make([]*policyexpr.Program, len(rules))
+	violating := make([][]uuid.UUID, len(rules))
+	for i, rule := range rules {
+		results[i] = &PolicyEvaluation{RuleID: rule.ID, RuleName: rule.Name}
+		program, err := policyexpr.Compile(rule.Expression, policyDecls)
+		if err != nil {
+			results[i].Error = fmt.Sprintf("invalid expression: %v", err)
+			continue
+		}
+		programs[i] = program
+	}
+
+	now := time.Now().UTC()
+	afterID := uuid.Nil
+	for {
+		facts, err := s.repo.ListPolicyFindingFacts(ctx, afterID, policyFactsBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range facts {
+			vars := policyFindingVars(f, now)
+			for i, program := range programs {
+				if program == nil {
+					continue
+				}
+				matched, err := program.Eval(vars)
+				if err != nil {
+					results[i].Error = err.Error()
+					programs[i] = nil
+					continue
+				}
+				if matched {
+					violating[i] = append(violating[i], f.FindingID)
+				}
+			}
+		}
+		if len(facts) < policyFactsBatchSize {
+			break
+		}
+		afterID = facts[len(facts)-1].FindingID
+	}
+
+	// Stamped to the microsecond so it compares equal to the stored timestamps
+	evaluatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	for i, rule := range rules {
+		result := results[i]
+		if programs[i] == nil {
+			continue
+		}
+		opened, resolved, err := s.repo.RecordPolicyEvaluation(ctx, rule, violating[i], evaluatedAt)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.Violations = len(violating[i])
+		result.NewViolations = len(opened)
+		result.Resolved = resolved
+		if len(opened) > 0 {
+			result.Webhook = s.alert(ctx, rule, result, opened, evaluatedAt)
+		}
+	}
+	return results, nil
+}
+
+// alert publishes a policy_violations_found event and posts new violations to the
+// rule's webhook, if it has one. It returns the webhook outcome.
+func (s *PolicyService) alert(ctx context.Context, rule *entity.PolicyRule, result *PolicyEvaluation, opened []uuid.UUID, evaluatedAt time.Time) string {
+	findingIDs := opened
+	if len(findingIDs) > maxPolicyWebhookFindings {
+		findingIDs = findingIDs[:maxPolicyWebhookFindings]
+	}
+	payload := map[string]interface{}{
+		"event": policyWebhookEventName,
+		"rule": map[string]interface{}{
+			"id":         rule.ID,
+			"name":       rule.Name,
+			"severity":   rule.Severity,
+			"expression": rule.Expression,
+		},
+		"evaluated_at":   evaluatedAt,
+		"violations":     result.Violations,
+		"new_violations": result.NewViolations,
+		"finding_ids":    findingIDs, // The first maxPolicyWebhookFindings new violations
+		"truncated":      len(opened) > len(findingIDs),
+	}
+	s.events.Publish(ctx, interfaces.EventPolicyViolationsFound, payload)
+
+	if rule.WebhookURL == "" {
+		return ""
+	}
+	if err := s.postWebhook(ctx, rule, payload); err != nil {
+		log.Printf("WARN: Policy webhook for rule %s (%s) failed: %v", rule.ID, rule.Name, err)
+		return "failed"
+	}
+	return "delivered"
+}
+
+func (s *PolicyService) postWebhook(ctx context.Context, rule *entity.PolicyRule, payload map[string]interface{}) error {
+	var secret string
+	if len(rule.WebhookSecretEncrypted) > 0 {
+		if s.encryption == nil {
+			return ErrPolicySecretUnavailable
+		}
+		if err := s.encryption.Decrypt(rule.WebhookSecretEncrypted, &secret); err != nil {
+			return fmt.Errorf("failed to decrypt webhook secret: %w", err)
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rule.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(policyRuleHeader, rule.ID.String())
+	req.Header.Set(policyTimestampHeader, timestamp)
+	if secret != "" {
+		req.Header.Set(policySignatureHeader, "sha256="+signPolicyWebhook(secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post policy alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+func (s *PolicyService) applyRuleInput(rule *entity.PolicyRule, input PolicyRuleInput) error {
+	rule.Name = strings.TrimSpace(input.Name)
+	if rule.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	rule.Description = strings.TrimSpace(input.Description)
+
+	expression := strings.TrimSpace(input.Expression)
+	if expression == "" {
+		return fmt.Errorf("expression must not be empty")
+	}
+	if len(expression) > maxPolicyExpressionLength {
+		return fmt.Errorf("expression must not be longer than %d characters", maxPolicyExpressionLength)
+	}
+	if _, err := policyexpr.Compile(expression, policyDecls); err != nil {
+		return fmt.Errorf("invalid expression: %w", err)
+	}
+	rule.Expression = expression
+
+	rule.Severity = strings.ToLower(strings.TrimSpace(input.Severity))
+	if rule.Severity == "" {
+		rule.Severity = "high"
+	}
+	if !policySeverities[rule.Severity] {
+		return fmt.Errorf("invalid severity %q: must be critical, high, medium or low", input.Severity)
+	}
+
+	previousURL := rule.WebhookURL
+	rule.WebhookURL = ""
+	if raw := strings.TrimSpace(input.WebhookURL); raw != "" {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid webhook url %q: must be an absolute http(s) URL", input.WebhookURL)
+		}
+		rule.WebhookURL = u.String()
+	}
+
+	switch {
+	case rule.WebhookURL == "":
+		rule.WebhookSecretEncrypted = nil
+	case input.WebhookSecret != "":
+		if s.encryption == nil {
+			return ErrPolicySecretUnavailable
+		}
+		encrypted, err := s.encryption.Encrypt(input.WebhookSecret)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+		}
+		rule.WebhookSecretEncrypted = encrypted
+	case rule.WebhookURL != previousURL:
+		rule.WebhookSecretEncrypted = nil
+	}
+	rule.WebhookSecret = ""
+	rule.HasWebhookSecret = len(rule.WebhookSecretEncrypted) > 0
+
+	if input.IsActive != nil {
+		rule.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+func (s *PolicyService) audit(ctx context.Context, action string, rule *entity.PolicyRule) {
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, "policy_rule", rule.ID.String(), map[string]interface{}{
+			"name":       rule.Name,
+			"expression": rule.Expression,
+			"severity":   rule.Severity,
+			"is_active":  rule.IsActive,
+		})
+	}
+}
+
+// policyFindingVars returns the expression variables of a finding
+func policyFindingVars(f *entity.PolicyFindingFacts, now time.Time) map[string]policyexpr.Value {
+	ageDays := 0
+	if age := now.Sub(f.CreatedAt); age > 0 {
+		ageDays = int(age.Hours() / 24)
+	}
+
+	return map[string]policyexpr.Value{
+		"pii_type":            f.PIIType,
+		"pattern_name":        f.PatternName,
+		"classification_type": f.ClassificationType,
+		"severity":            strings.ToLower(f.Severity),
+		"confidence":          f.Confidence,
+		"environment":         f.Environment,
+		"data_source":         f.DataSource,
+		"asset_name":          f.AssetName,
+		"asset_path":          f.AssetPath,
+		"review_status":       f.ReviewStatus,
+		"age_days":            ageDays,
+		"remediations":        f.Remediations,
+		"remediated":          len(f.Remediations) > 0,
+	}
+}
+
+// nextPolicyEvaluation returns the first nightly evaluation time after the given time
+func nextPolicyEvaluation(after time.Time, hour int) time.Time {
+	after = after.UTC()
+	run := time.Date(after.Year(), after.Month(), after.Day(), hour, 0, 0, 0, time.UTC)
+	for !run.After(after) {
+		run = run.AddDate(0, 0, 1)
+	}
+	return run
+}
+
+// signPolicyWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func signPolicyWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/pkg/policyexpr"
+	"github.com/google/uuid"
+)
+
+func TestPolicyRuleMatchesFindings(t *testing.T) {
+	// No CREDIT_CARD outside production unless encrypted within 7 days
+	program, err := policyexpr.Compile(
+		`pii_type == "CREDIT_CARD" && lower(environment) != "production" && !("ENCRYPT" in remediations) && age_days > 7`,
+		policyDecls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 5, 20, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		fact entity.PolicyFindingFacts
+		want bool
+	}{
+		{"stale card in staging", entity.PolicyFindingFacts{PIIType: "CREDIT_CARD", Environment: "Staging", CreatedAt: now.AddDate(0, 0, -10)}, true},
+		{"within grace period", entity.PolicyFindingFacts{PIIType: "CREDIT_CARD", Environment: "Staging", CreatedAt: now.AddDate(0, 0, -3)}, false},
+		{"encrypted", entity.PolicyFindingFacts{PIIType: "CREDIT_CARD", Environment: "Staging", Remediations: []string{"ENCRYPT"}, CreatedAt: now.AddDate(0, 0, -10)}, false},
+		{"masked only", entity.PolicyFindingFacts{PIIType: "CREDIT_CARD", Environment: "DEV", Remediations: []string{"MASK"}, CreatedAt: now.AddDate(0, 0, -10)}, true},
+		{"production", entity.PolicyFindingFacts{PIIType: "CREDIT_CARD", Environment: "Production", CreatedAt: now.AddDate(0, 0, -10)}, false},
+		{"other PII", entity.PolicyFindingFacts{PIIType: "IN_PAN", Environment: "Staging", CreatedAt: now.AddDate(0, 0, -10)}, false},
+	}
+
+	for _, tt := range tests {
+		got, err := program.Eval(policyFindingVars(&tt.fact, now))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPolicyFindingVarsCoverEveryVariable(t *testing.T) {
+	vars := policyFindingVars(&entity.PolicyFindingFacts{}, time.Now())
+	for _, v := range PolicyVariables {
+		if _, ok := vars[v.Name]; !ok {
+			t.Errorf("variable %q has no value", v.Name)
+		}
+	}
+	if len(vars) != len(PolicyVariables) {
+		t.Errorf("expected %d variables, got %d", len(PolicyVariables), len(vars))
+	}
+}
+
+func TestApplyPolicyRuleInput(t *testing.T) {
+	s := NewPolicyService(nil, nil, nil, config.PolicyConfig{})
+
+	rule := &entity.PolicyRule{ID: uuid.New()}
+	err := s.applyRuleInput(rule, PolicyRuleInput{
+		Name:       " Cards outside prod ",
+		Expression: `pii_type == "CREDIT_CARD" && environment != "Production"`,
+		WebhookURL: "https://hooks.example.com/policy",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.Name != "Cards outside prod" || rule.Severity != "high" || rule.WebhookURL != "https://hooks.example.com/policy" {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+
+	for _, tc := range []struct {
+		input PolicyRuleInput
+		want  string
+	}{
+		{PolicyRuleInput{Name: "r", Expression: `pii == "PAN"`}, "invalid expression"},
+		{PolicyRuleInput{Name: "r", Expression: ""}, "expression must not be empty"},
+		{PolicyRuleInput{Name: "r", Expression: `remediated`, Severity: "urgent"}, "invalid severity"},
+		{PolicyRuleInput{Name: "r", Expression: `remediated`, WebhookURL: "ftp://example.com"}, "invalid webhook url"},
+		{PolicyRuleInput{Name: "r", Expression: `remediated`, WebhookURL: "https://example.com", WebhookSecret: "s3cret"}, "ENCRYPTION_KEY"},
+	} {
+		err := s.applyRuleInput(&entity.PolicyRule{}, tc.input)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: expected an error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+}
+
+func TestNextPolicyEvaluation(t *testing.T) {
+	tests := []struct {
+		after time.Time
+		want  time.Time
+	}{
+		{time.Date(2026, 5, 20, 1, 30, 0, 0, time.UTC), time.Date(2026, 5, 20, 2, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 5, 20, 2, 0, 0, 0, time.UTC), time.Date(2026, 5, 21, 2, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 5, 31, 23, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 2, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := nextPolicyEvaluation(tt.after, 2); !got.Equal(tt.want) {
+			t.Errorf("nextPolicyEvaluation(%s) = %s, want %s", tt.after, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"path"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/archive"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// TenantExportJobType builds a requested tenant export bundle
+const TenantExportJobType = "tenant_export.build"
+
+const (
+	maxTenantExportList = 100
+	// tenantExportManifest and tenantExportChecksums are the bundle's own files,
+	// ahead of one JSON Lines file per table
+	tenantExportManifest  = "manifest.json"
+	tenantExportChecksums = "SHA256SUMS"
+)
+
+// ErrTenantExportInProgress is returned when the tenant already has an export being built
+var ErrTenantExportInProgress = errors.New("a tenant export is already in progress")
+
+// TenantExportService bundles all of a tenant's data (assets, finding metadata,
+// classifications, review states, remediation history and audit logs) into a
+// gzip-compressed tar with a manifest and checksums, for offboarding and regulator
+// requests. Bundles are built by the job queue and kept in object storage.
+type TenantExportService struct {
+	repo        *persistence.PostgresRepository
+	store       archive.ObjectStore
+	prefix      string
+	jobs        *jobs.Queue
+	auditLogger interfaces.AuditLogger
+}
+
+// NewTenantExportService creates a tenant export service
+func NewTenantExportService(repo *persistence.PostgresRepository, store archive.ObjectStore, prefix string, queue *jobs.Queue, auditLogger interfaces.AuditLogger) *TenantExportService {
+	return &TenantExportService{
+		repo:        repo,
+		store:       store,
+		prefix:      prefix,
+		jobs:        queue,
+		auditLogger: auditLogger,
+	}
+}
+
+// RegisterJobs registers the bundle job with the queue
+func (s *TenantExportService) RegisterJobs(queue *jobs.Queue) {
+	queue.Register(TenantExportJobType, s.buildJob, jobs.HandlerOptions{MaxAttempts: 3, Timeout: 2 * time.Hour})
+}
+
+// Request records an export of the tenant in ctx and queues building its bundle
+func (s *TenantExportService) Request(ctx context.Context, reason, requestedBy string) (*entity.TenantExport, error) {
+	latest, err := s.repo.ListTenantExports(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(latest) > 0 && (latest[0].Status == entity.TenantExportPending || latest[0].Status == entity.TenantExportRunning) {
+		return nil, fmt.Errorf("%w: %s", ErrTenantExportInProgress, latest[0].ID)
+	}
+
+	export := &entity.TenantExport{Reason: reason, RequestedBy: requestedBy}
+	if err := s.repo.CreateTenantExport(ctx, export); err != nil {
+		return nil, err
+	}
+	job, err := s.jobs.Enqueue(ctx, TenantExportJobType, map[string]interface{}{"export_id": export.ID.String()}, jobs.EnqueueOptions{})
+	if err != nil {
+		_ = s.repo.FailTenantExport(ctx, export.ID, err.Error())
+		return nil, fmt.Errorf("failed to queue tenant export: %w", err)
+	}
+	if err := s.repo.SetTenantExportJob(ctx, export.ID, job.ID); err != nil {
+		return nil, err
+	}
+	export.JobID = &job.ID
+
+	s.audit(ctx, "TENANT_EXPORT_REQUESTED", export, map[string]interface{}{
+		"reason":       reason,
+		"requested_by": requestedBy,
+		"job_id":       job.ID.String(),
+	})
+	return export, nil
+}
+
+// List returns the tenant's exports, newest first
+func (s *TenantExportService) List(ctx context.Context) ([]*entity.TenantExport, error) {
+	return s.repo.ListTenantExports(ctx, maxTenantExportList)
+}
+
+// Get returns one of the tenant's exports
+func (s *TenantExportService) Get(ctx context.Context, id uuid.UUID) (*entity.TenantExport, error) {
+	return s.repo.GetTenantExport(ctx, id)
+}
+
+// Download returns a completed export's bundle after checking it against the
+// checksum recorded when it was built
+func (s *TenantExportService) Download(ctx context.Context, id uuid.UUID, downloadedBy string) (*entity.TenantExport, []byte, error) {
+	export, err := s.repo.GetTenantExport(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != entity.TenantExportCompleted {
+		return nil, nil, fmt.Errorf("tenant export %s is %s, not ready to download", id, export.Status)
+	}
+	if export.StorageProvider != s.store.Provider() || export.Bucket != s.store.Bucket() {
+		return nil, nil, fmt.Errorf("tenant export %s is stored in %s://%s, which is not the configured export store",
+			id, export.StorageProvider, export.Bucket)
+	}
+
+	body, err := s.store.Get(ctx, export.ObjectKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	checksum := sha256.Sum256(body)
+	if hex.EncodeToString(checksum[:]) != export.ChecksumSHA256 {
+		return nil, nil, fmt.Errorf("checksum mismatch for %s", export.ObjectKey)
+	}
+
+	s.audit(ctx, "TENANT_EXPORT_DOWNLOADED", export, map[string]interface{}{
+		"downloaded_by": downloadedBy,
+		"size_bytes":    export.SizeBytes,
+	})
+	return export, body, nil
+}
+
+// buildJob builds the bundle of the export named in the job's payload. The export
+// is marked failed once the job runs out of attempts.
+func (s *TenantExportService) buildJob(ctx context.Context, job *entity.Job) error {
+	id, err := uuid.Parse(fmt.Sprint(job.Payload["export_id"]))
+	if err != nil {
+		return fmt.Errorf("invalid export_id in job payload: %w", err)
+	}
+	export, err := s.repo.GetTenantExport(ctx, id)
+	if err != nil {
+		return err
+	}
+	if export.Status == entity.TenantExportCompleted {
+		return nil
+	}
+	if err := s.repo.StartTenantExport(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.build(ctx, export); err != nil {
+		if job.Attempts >= job.MaxAttempts {
+			failCtx := context.WithoutCancel(ctx)
+			if ferr := s.repo.FailTenantExport(failCtx, id, err.Error()); ferr != nil {
+				log.Printf("WARNING: Failed to record tenant export %s failure: %v", id, ferr)
+			}
+			s.audit(failCtx, "TENANT_EXPORT_FAILED", export, map[string]interface{}{"error": err.Error()})
+		}
+		return err
+	}
+
+	log.Printf("📦 Built tenant export %s (%d bytes)", id, export.SizeBytes)
+	s.audit(ctx, "TENANT_EXPORT_COMPLETED", export, map[string]interface{}{
+		"object_key":      export.ObjectKey,
+		"size_bytes":      export.SizeBytes,
+		"checksum_sha256": export.ChecksumSHA256,
+	})
+	return nil
+}
+
+// build reads every exported table of the tenant, uploads the bundle and records it
+func (s *TenantExportService) build(ctx context.Context, export *entity.TenantExport) error {
+	files := make([]tenantExportFile, 0, len(persistence.TenantExportTables))
+	for _, table := range persistence.TenantExportTables {
+		file := tenantExportFile{TenantExportFile: entity.TenantExportFile{Name: table + ".jsonl", Table: table}}
+		var buf bytes.Buffer
+		err := s.repo.IterateTenantExportRows(ctx, table, func(rows []json.RawMessage) error {
+			for _, row := range rows {
+				buf.Write(row)
+				buf.WriteByte('\n')
+			}
+			file.Rows += len(rows)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		file.data = buf.Bytes()
+		files = append(files, file)
+	}
+
+	manifest := &entity.TenantExportManifest{
+		ExportID:      export.ID,
+		TenantID:      export.TenantID,
+		FormatVersion: entity.TenantExportFormatVersion,
+		GeneratedAt:   time.Now().UTC(),
+	}
+	body, err := encodeTenantExportBundle(manifest, files)
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+	checksum := sha256.Sum256(body)
+
+	export.StorageProvider = s.store.Provider()
+	export.Bucket = s.store.Bucket()
+	export.ObjectKey = path.Join(s.prefix, export.TenantID.String(), export.ID.String()+".tar.gz")
+	export.SizeBytes = int64(len(body))
+	export.ChecksumSHA256 = hex.EncodeToString(checksum[:])
+	export.Manifest = manifest
+
+	if err := s.store.Put(ctx, export.ObjectKey, body, "application/gzip"); err != nil {
+		return err
+	}
+	return s.repo.CompleteTenantExport(ctx, export)
+}
+
+func (s *TenantExportService) audit(ctx context.Context, action string, export *entity.TenantExport, details map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, "tenant_export", export.ID.String(), details); err != nil {
+		log.Printf("WARNING: Failed to audit tenant export %s: %v", export.ID, err)
+	}
+}
+
+// tenantExportFile is a table's JSON Lines file with its contents
+type tenantExportFile struct {
+	entity.TenantExportFile
+	data []byte
+}
+
+// encodeTenantExportBundle writes a gzip-compressed tar holding manifest.json, a
+// SHA256SUMS file that `sha256sum -c` verifies, and the table files. The file
+// entries of the manifest are filled in from the files.
+func encodeTenantExportBundle(manifest *entity.TenantExportManifest, files []tenantExportFile) ([]byte, error) {
+	var sums bytes.Buffer
+	manifest.Files = make([]entity.TenantExportFile, 0, len(files))
+	for _, f := range files {
+		sum := sha256.Sum256(f.data)
+		f.SHA256 = hex.EncodeToString(sum[:])
+		f.SizeBytes = int64(len(f.data))
+		manifest.Files = append(manifest.Files, f.TenantExportFile)
+		fmt.Fprintf(&sums, "%s  %s\n", f.SHA256, f.Name)
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	write := func(name string, data []byte) error {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: manifest.GeneratedAt}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := write(tenantExportManifest, manifestJSON); err != nil {
+		return nil, err
+	}
+	if err := write(tenantExportChecksums, sums.Bytes()); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if err := write(f.Name, f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestEncodeTenantExportBundle(t *testing.T) {
+	manifest := &entity.TenantExportManifest{
+		ExportID:      uuid.New(),
+		TenantID:      uuid.New(),
+		FormatVersion: entity.TenantExportFormatVersion,
+		GeneratedAt:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	files := []tenantExportFile{
+		{TenantExportFile: entity.TenantExportFile{Name: "assets.jsonl", Table: "assets", Rows: 2},
+			data: []byte("{\"id\":\"a1\"}\n{\"id\":\"a2\"}\n")},
+		{TenantExportFile: entity.TenantExportFile{Name: "audit_logs.jsonl", Table: "audit_logs"}},
+	}
+
+	body, err := encodeTenantExportBundle(manifest, files)
+	if err != nil {
+		t.Fatalf("encodeTenantExportBundle: %v", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	contents := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(tr)
+		names = append(names, header.Name)
+		contents[header.Name] = data
+	}
+
+	if strings.Join(names, ",") != "manifest.json,SHA256SUMS,assets.jsonl,audit_logs.jsonl" {
+		t.Fatalf("unexpected bundle layout: %v", names)
+	}
+
+	var decoded entity.TenantExportManifest
+	if err := json.Unmarshal(contents["manifest.json"], &decoded); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if decoded.ExportID != manifest.ExportID || len(decoded.Files) != 2 {
+		t.Fatalf("unexpected manifest: %+v", decoded)
+	}
+	for _, f := range decoded.Files {
+		sum := sha256.Sum256(contents[f.Name])
+		if f.SHA256 != hex.EncodeToString(sum[:]) || f.SizeBytes != int64(len(contents[f.Name])) {
+			t.Errorf("manifest entry %s does not match its file: %+v", f.Name, f)
+		}
+		if !strings.Contains(string(contents["SHA256SUMS"]), f.SHA256+"  "+f.Name+"\n") {
+			t.Errorf("SHA256SUMS is missing %s", f.Name)
+		}
+	}
+	if decoded.Files[0].Rows != 2 {
+		t.Errorf("expected the row count to be kept, got %d", decoded.Files[0].Rows)
+	}
+}
@@ -1,9 +1,12 @@
 package api
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/arc-platform/backend/modules/connections/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -26,7 +29,7 @@ func NewConnectionHandler(s *service.ConnectionService, syncService *service.Con
 
 // AddConnectionRequest represents the request body for adding a connection
 type AddConnectionRequest struct {
-	SourceType  string                 `json:"source_type" binding:"required,oneof=postgresql mysql mongodb s3 filesystem redis slack"`
+	SourceType  string                 `json:"source_type" binding:"required,oneof=postgresql mysql mongodb s3 filesystem sftp smb redis slack"`
 	ProfileName string                 `json:"profile_name" binding:"required,min=1,max=50,alphanum"`
 	Config      map[string]interface{} `json:"config" binding:"required"`
 }
@@ -34,8 +37,7 @@ type AddConnectionRequest struct {
 // AddConnection handles POST /api/v1/connections
 func (h *ConnectionHandler) AddConnection(c *gin.Context) {
 	var req AddConnectionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -43,6 +45,10 @@ func (h *ConnectionHandler) AddConnection(c *gin.Context) {
 	createdBy := "system"
 
 	conn, err := h.service.AddConnection(c.Request.Context(), req.SourceType, req.ProfileName, req.Config, createdBy)
+	if errors.Is(err, service.ErrInvalidConnectionConfig) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add connection: " + err.Error()})
 		return
@@ -63,6 +69,11 @@ func (h *ConnectionHandler) AddConnection(c *gin.Context) {
 	}()
 }
 
+// GetSchemas handles GET /api/v1/connections/schemas with the config fields of each source type
+func (h *ConnectionHandler) GetSchemas(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"schemas": service.ConnectionSchemas()})
+}
+
 // GetConnections handles GET /api/v1/connections
 func (h *ConnectionHandler) GetConnections(c *gin.Context) {
 	connections, err := h.service.GetConnections(c.Request.Context())
@@ -106,19 +117,20 @@ func (h *ConnectionHandler) DeleteConnection(c *gin.Context) {
 
 // TestConnectionRequest represents the request body for testing a connection
 type TestConnectionRequest struct {
-	SourceType string                 `json:"source_type" binding:"required,oneof=postgresql mysql mongodb s3 filesystem redis slack"`
+	SourceType string                 `json:"source_type" binding:"required,oneof=postgresql mysql mongodb s3 filesystem sftp smb redis slack"`
 	Config     map[string]interface{} `json:"config" binding:"required"`
+	// Probe opts in to reading a small sample to estimate whether the source holds PII
+	Probe bool `json:"probe"`
 }
 
 // TestConnection handles POST /api/v1/connections/test
 func (h *ConnectionHandler) TestConnection(c *gin.Context) {
 	var req TestConnectionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
-	result, err := h.testConnectionSvc.TestConnectionByConfig(c.Request.Context(), req.SourceType, req.Config)
+	result, err := h.testConnectionSvc.TestConnectionByConfig(c.Request.Context(), req.SourceType, req.Config, req.Probe)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to test connection: " + err.Error()})
 		return
@@ -128,10 +140,20 @@ func (h *ConnectionHandler) TestConnection(c *gin.Context) {
 }
 
 // TestConnectionByID handles POST /api/v1/connections/:id/test
+// Query: probe=true also samples a few rows to estimate whether the source holds PII
 func (h *ConnectionHandler) TestConnectionByID(c *gin.Context) {
 	id := c.Param("id")
 
-	result, err := h.testConnectionSvc.TestConnection(c.Request.Context(), id)
+	probe := false
+	if v := c.Query("probe"); v != "" {
+		var err error
+		if probe, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "probe must be true or false"})
+			return
+		}
+	}
+
+	result, err := h.testConnectionSvc.TestConnection(c.Request.Context(), id, probe)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/connections/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ConnectionHealthHandler handles the health of stored connection credentials
+type ConnectionHealthHandler struct {
+	service *service.ConnectionHealthService
+}
+
+// NewConnectionHealthHandler creates a new connection health handler
+func NewConnectionHealthHandler(s *service.ConnectionHealthService) *ConnectionHealthHandler {
+	return &ConnectionHealthHandler{service: s}
+}
+
+// GetFleetHealth handles GET /api/v1/connections/health
+func (h *ConnectionHealthHandler) GetFleetHealth(c *gin.Context) {
+	fleet, err := h.service.FleetHealth(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get connection health", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": fleet})
+}
+
+// RunCheck handles POST /api/v1/connections/health/check
+// Tests every connection now instead of waiting for the scheduled check
+func (h *ConnectionHealthHandler) RunCheck(c *gin.Context) {
+	run, err := h.service.CheckAll(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check connection health", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": run})
+}
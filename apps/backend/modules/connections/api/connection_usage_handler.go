@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/connections/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ConnectionUsageHandler handles per-connection scan history, finding trends and coverage
+type ConnectionUsageHandler struct {
+	service *service.ConnectionUsageService
+}
+
+// NewConnectionUsageHandler creates a new connection usage handler
+func NewConnectionUsageHandler(s *service.ConnectionUsageService) *ConnectionUsageHandler {
+	return &ConnectionUsageHandler{service: s}
+}
+
+// GetScanHistory handles GET /api/v1/connections/:id/scans
+func (h *ConnectionUsageHandler) GetScanHistory(c *gin.Context) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+	limit, offset := 50, 0
+	if v := c.Query("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+	}
+	if v := c.Query("offset"); v != "" {
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+	}
+
+	scanRuns, total, err := h.service.ScanHistory(sharedapi.RequestContext(c), connectionID, limit, offset)
+	if err != nil {
+		c.JSON(statusForUsageError(err), gin.H{"error": "Failed to get scan history", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": scanRuns, "total": total})
+}
+
+// GetFindingTrend handles GET /api/v1/connections/:id/findings-trend
+func (h *ConnectionUsageHandler) GetFindingTrend(c *gin.Context) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+	days, ok := parseDays(c)
+	if !ok {
+		return
+	}
+
+	trend, err := h.service.FindingTrend(sharedapi.RequestContext(c), connectionID, days)
+	if err != nil {
+		c.JSON(statusForUsageError(err), gin.H{"error": "Failed to get finding trend", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trend})
+}
+
+// ListStaleConnections handles GET /api/v1/connections/stale
+// Lists connections never scanned, or not scanned in the last ?days= (default 30)
+func (h *ConnectionUsageHandler) ListStaleConnections(c *gin.Context) {
+	days, ok := parseDays(c)
+	if !ok {
+		return
+	}
+	if days == 0 {
+		days = h.service.StaleAfterDays()
+	}
+
+	connections, err := h.service.StaleConnections(sharedapi.RequestContext(c), days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list stale connections", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": connections, "total": len(connections), "days": days})
+}
+
+// parseDays reads the optional ?days= window; zero when absent
+func parseDays(c *gin.Context) (int, bool) {
+	v := c.Query("days")
+	if v == "" {
+		return 0, true
+	}
+	days, err := strconv.Atoi(v)
+	if err != nil || days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "days must be between 1 and 365"})
+		return 0, false
+	}
+	return days, true
+}
+
+func statusForUsageError(err error) int {
+	if errors.Is(err, service.ErrConnectionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
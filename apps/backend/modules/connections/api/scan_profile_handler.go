@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/connections/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScanProfileHandler handles connection scan profile requests
+type ScanProfileHandler struct {
+	service *service.ScanProfileService
+}
+
+// NewScanProfileHandler creates a new scan profile handler
+func NewScanProfileHandler(s *service.ScanProfileService) *ScanProfileHandler {
+	return &ScanProfileHandler{service: s}
+}
+
+// CreateProfile handles POST /api/v1/connections/:id/scan-profiles
+func (h *ScanProfileHandler) CreateProfile(c *gin.Context) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+
+	var input service.ScanProfileInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	createdBy := c.GetString("user_email")
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	profile, err := h.service.CreateProfile(sharedapi.RequestContext(c), connectionID, input, createdBy)
+	if err != nil {
+		c.JSON(statusForProfileError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"profile": profile})
+}
+
+// ListProfiles handles GET /api/v1/connections/:id/scan-profiles
+func (h *ScanProfileHandler) ListProfiles(c *gin.Context) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+
+	profiles, err := h.service.ListProfiles(sharedapi.RequestContext(c), connectionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list scan profiles: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profiles": profiles})
+}
+
+// GetProfile handles GET /api/v1/connections/:id/scan-profiles/:profileId
+func (h *ScanProfileHandler) GetProfile(c *gin.Context) {
+	connectionID, profileID, ok := parseProfileIDs(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.GetProfile(sharedapi.RequestContext(c), connectionID, profileID)
+	if err != nil {
+		c.JSON(statusForProfileError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+// UpdateProfile handles PUT /api/v1/connections/:id/scan-profiles/:profileId
+func (h *ScanProfileHandler) UpdateProfile(c *gin.Context) {
+	connectionID, profileID, ok := parseProfileIDs(c)
+	if !ok {
+		return
+	}
+
+	var input service.ScanProfileInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	profile, err := h.service.UpdateProfile(sharedapi.RequestContext(c), connectionID, profileID, input)
+	if err != nil {
+		c.JSON(statusForProfileError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+// DeleteProfile handles DELETE /api/v1/connections/:id/scan-profiles/:profileId
+func (h *ScanProfileHandler) DeleteProfile(c *gin.Context) {
+	connectionID, profileID, ok := parseProfileIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProfile(sharedapi.RequestContext(c), connectionID, profileID); err != nil {
+		c.JSON(statusForProfileError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Scan profile deleted successfully",
+	})
+}
+
+// GetEffectiveConfig handles GET /api/v1/connections/:id/scan-config
+// Used by the scanner SDK to fetch its configuration instead of local YAML
+func (h *ScanProfileHandler) GetEffectiveConfig(c *gin.Context) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+
+	config, err := h.service.GetEffectiveConfig(sharedapi.RequestContext(c), connectionID, c.Query("profile"))
+	if err != nil {
+		c.JSON(statusForProfileError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+func parseProfileIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	profileID, err := uuid.Parse(c.Param("profileId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan profile ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return connectionID, profileID, true
+}
+
+func statusForProfileError(err error) int {
+	switch {
+	case errors.Is(err, service.ErrConnectionNotFound), errors.Is(err, service.ErrScanProfileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidScanProfile):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrScanProfileExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
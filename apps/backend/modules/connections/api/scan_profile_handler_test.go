@@ -0,0 +1,61 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/connections/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestStatusForProfileError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrConnectionNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: nightly", service.ErrScanProfileNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: sample_size must be between 1 and 100000", service.ErrInvalidScanProfile), http.StatusBadRequest},
+		{service.ErrScanProfileExists, http.StatusConflict},
+		// Messages that merely read like a known error are not mapped
+		{errors.New("failed to update scan profile: row not found"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := statusForProfileError(tt.err); got != tt.want {
+			t.Errorf("statusForProfileError(%q) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestGetEffectiveConfigUnknownConnection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	connectionID := uuid.New()
+	mock.ExpectQuery(`FROM connections WHERE id = \$1`).WithArgs(connectionID).WillReturnError(sql.ErrNoRows)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewScanProfileHandler(service.NewScanProfileService(persistence.NewPostgresRepository(db)))
+	router.GET("/connections/:id/scan-config", handler.GetEffectiveConfig)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/connections/"+connectionID.String()+"/scan-config", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown connection, got %d: %s", w.Code, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
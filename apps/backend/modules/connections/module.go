@@ -1,11 +1,13 @@
 package connections
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/arc-platform/backend/modules/connections/api"
 	"github.com/arc-platform/backend/modules/connections/service"
+	"github.com/arc-platform/backend/modules/shared/config"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
@@ -17,12 +19,20 @@ type ConnectionsModule struct {
 	connectionSyncService    *service.ConnectionSyncService
 	testConnectionService    *service.TestConnectionService
 	scanOrchestrationService *service.ScanOrchestrationService
+	scanProfileService       *service.ScanProfileService
+	connectionUsageService   *service.ConnectionUsageService
+	connectionHealthService  *service.ConnectionHealthService
 
 	connectionHandler        *api.ConnectionHandler
 	connectionSyncHandler    *api.ConnectionSyncHandler
 	scanOrchestrationHandler *api.ScanOrchestrationHandler
+	scanProfileHandler       *api.ScanProfileHandler
+	connectionUsageHandler   *api.ConnectionUsageHandler
+	connectionHealthHandler  *api.ConnectionHealthHandler
 
-	deps *interfaces.ModuleDependencies
+	deps         *interfaces.ModuleDependencies
+	workerCtx    context.Context
+	cancelWorker context.CancelFunc
 }
 
 func (m *ConnectionsModule) Name() string {
@@ -55,10 +65,38 @@ func (m *ConnectionsModule) Initialize(deps *interfaces.ModuleDependencies) erro
 	// Initialize scan orchestration service
 	m.scanOrchestrationService = service.NewScanOrchestrationService(pgRepo)
 
+	// Initialize scan profile service
+	m.scanProfileService = service.NewScanProfileService(pgRepo)
+
+	// Scan history, finding trends and alerts for connections that go unscanned
+	var coverageCfg config.ConnectionCoverageConfig
+	if deps.Config != nil {
+		coverageCfg = deps.Config.Coverage
+	}
+	m.connectionUsageService = service.NewConnectionUsageService(pgRepo, coverageCfg.StaleAfterDays)
+	m.connectionUsageService.SetEventPublisher(deps.EventPublisher)
+	if coverageCfg.CheckIntervalHours > 0 {
+		go m.connectionUsageService.StartCoverageWorker(m.workerContext(), coverageCfg.CheckIntervalHours)
+	}
+
+	// Scheduled re-test of stored credentials, alerting when healthy connections start failing
+	var healthCfg config.ConnectionHealthConfig
+	if deps.Config != nil {
+		healthCfg = deps.Config.Health
+	}
+	m.connectionHealthService = service.NewConnectionHealthService(pgRepo, m.testConnectionService, healthCfg.Concurrency)
+	m.connectionHealthService.SetEventPublisher(deps.EventPublisher)
+	if healthCfg.IntervalMinutes > 0 {
+		go m.connectionHealthService.StartHealthWorker(m.workerContext(), healthCfg.IntervalMinutes)
+	}
+
 	// Initialize handlers
 	m.connectionHandler = api.NewConnectionHandler(m.connectionService, m.connectionSyncService, m.testConnectionService)
 	m.connectionSyncHandler = api.NewConnectionSyncHandler(m.connectionSyncService)
 	m.scanOrchestrationHandler = api.NewScanOrchestrationHandler(m.scanOrchestrationService)
+	m.scanProfileHandler = api.NewScanProfileHandler(m.scanProfileService)
+	m.connectionUsageHandler = api.NewConnectionUsageHandler(m.connectionUsageService)
+	m.connectionHealthHandler = api.NewConnectionHealthHandler(m.connectionHealthService)
 
 	log.Println("✅ Connections Module initialized")
 	return nil
@@ -67,6 +105,7 @@ func (m *ConnectionsModule) Initialize(deps *interfaces.ModuleDependencies) erro
 func (m *ConnectionsModule) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/connections", m.connectionHandler.AddConnection)
 	router.GET("/connections", m.connectionHandler.GetConnections)
+	router.GET("/connections/schemas", m.connectionHandler.GetSchemas)
 	router.POST("/connections/test", m.connectionHandler.TestConnection)
 	router.POST("/connections/:id/test", m.connectionHandler.TestConnectionByID)
 
@@ -74,6 +113,23 @@ func (m *ConnectionsModule) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/connections/sync", m.connectionSyncHandler.SyncToScanner)
 	router.GET("/connections/sync/validate", m.connectionSyncHandler.ValidateSync)
 
+	// Scan profile routes
+	router.GET("/connections/:id/scan-config", m.scanProfileHandler.GetEffectiveConfig)
+	router.POST("/connections/:id/scan-profiles", m.scanProfileHandler.CreateProfile)
+	router.GET("/connections/:id/scan-profiles", m.scanProfileHandler.ListProfiles)
+	router.GET("/connections/:id/scan-profiles/:profileId", m.scanProfileHandler.GetProfile)
+	router.PUT("/connections/:id/scan-profiles/:profileId", m.scanProfileHandler.UpdateProfile)
+	router.DELETE("/connections/:id/scan-profiles/:profileId", m.scanProfileHandler.DeleteProfile)
+
+	// Connection usage routes
+	router.GET("/connections/stale", m.connectionUsageHandler.ListStaleConnections)
+	router.GET("/connections/:id/scans", m.connectionUsageHandler.GetScanHistory)
+	router.GET("/connections/:id/findings-trend", m.connectionUsageHandler.GetFindingTrend)
+
+	// Connection health routes
+	router.GET("/connections/health", m.connectionHealthHandler.GetFleetHealth)
+	router.POST("/connections/health/check", m.connectionHealthHandler.RunCheck)
+
 	scans := router.Group("/scans")
 	{
 		scans.POST("/scan-all", m.scanOrchestrationHandler.ScanAllAssets)
@@ -86,9 +142,20 @@ func (m *ConnectionsModule) RegisterRoutes(router *gin.RouterGroup) {
 
 func (m *ConnectionsModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Connections Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
 	return nil
 }
 
+// workerContext returns the context shared by background workers, cancelled on shutdown
+func (m *ConnectionsModule) workerContext() context.Context {
+	if m.workerCtx == nil {
+		m.workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+	}
+	return m.workerCtx
+}
+
 func NewConnectionsModule() *ConnectionsModule {
 	return &ConnectionsModule{}
 }
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultHealthConcurrency = 4
+	// connectionHealthTimeout bounds one connection's test, whatever its source type
+	connectionHealthTimeout = 30 * time.Second
+)
+
+// Health transitions that raise an alert
+const (
+	healthFailing  = "failing"
+	healthRestored = "restored"
+)
+
+// ConnectionHealthRun summarizes one pass over every stored connection
+type ConnectionHealthRun struct {
+	Checked    int         `json:"checked"`
+	Healthy    int         `json:"healthy"`
+	Failing    int         `json:"failing"`
+	NewFailing []uuid.UUID `json:"new_failing"` // Were valid before this run
+	Restored   []uuid.UUID `json:"restored"`    // Were invalid before this run
+	DurationMs int64       `json:"duration_ms"`
+}
+
+// ConnectionFleetHealth is the health of all stored connections
+type ConnectionFleetHealth struct {
+	Total        int                        `json:"total"`
+	ByStatus     map[string]int             `json:"by_status"`      // valid, invalid, pending
+	BySourceType map[string]map[string]int  `json:"by_source_type"` // source type -> status -> count
+	Failing      []*entity.ConnectionHealth `json:"failing"`        // Longest failing first
+	Connections  []*entity.ConnectionHealth `json:"connections"`
+	LastRun      *ConnectionHealthRun       `json:"last_run,omitempty"`
+	LastRunAt    *time.Time                 `json:"last_run_at,omitempty"`
+}
+
+// ConnectionHealthService re-tests every stored connection on a schedule so
+// expired passwords and rotated keys surface before the next scan fails. It
+// keeps validation_status current and raises an alert when a connection that
+// was healthy starts failing, and again when it recovers.
+type ConnectionHealthService struct {
+	pgRepo      *persistence.PostgresRepository
+	tester      *TestConnectionService
+	events      interfaces.EventPublisher
+	concurrency int
+
+	mu        sync.Mutex // one run at a time
+	lastRun   *ConnectionHealthRun
+	lastRunAt *time.Time
+}
+
+// NewConnectionHealthService creates a connection health service testing up to
+// concurrency connections at once
+func NewConnectionHealthService(pgRepo *persistence.PostgresRepository, tester *TestConnectionService, concurrency int) *ConnectionHealthService {
+	if concurrency < 1 {
+		concurrency = defaultHealthConcurrency
+	}
+	return &ConnectionHealthService{
+		pgRepo:      pgRepo,
+		tester:      tester,
+		events:      &interfaces.NoOpEventPublisher{},
+		concurrency: concurrency,
+	}
+}
+
+// SetEventPublisher enables connection_health_failing and connection_health_restored events
+func (s *ConnectionHealthService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// StartHealthWorker tests every connection on each interval until ctx is cancelled
+func (s *ConnectionHealthService) StartHealthWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🔌 Starting connection health worker (interval: %d minutes, concurrency: %d)", intervalMinutes, s.concurrency)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Connection health worker stopped")
+			return
+		case <-ticker.C:
+			run, err := s.CheckAll(ctx)
+			if err != nil {
+				log.Printf("❌ Connection health check failed: %v", err)
+				continue
+			}
+			if run.Failing > 0 {
+				log.Printf("WARN: %d of %d connections failing their test (%d newly)", run.Failing, run.Checked, len(run.NewFailing))
+			}
+		}
+	}
+}
+
+// CheckAll tests every stored connection and records the results
+func (s *ConnectionHealthService) CheckAll(ctx context.Context) (*ConnectionHealthRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := time.Now()
+	connections, err := s.pgRepo.ListConnections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &ConnectionHealthRun{NewFailing: []uuid.UUID{}, Restored: []uuid.UUID{}}
+	var runMu sync.Mutex
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+	for _, conn := range connections {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(conn *entity.Connection) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			validationError, ok := s.test(ctx, conn)
+			if !ok {
+				return
+			}
+			previous, err := s.pgRepo.RecordConnectionHealth(ctx, conn.ID, validationError, time.Now())
+			if err != nil {
+				log.Printf("WARN: Failed to record health of connection %s: %v", conn.ID, err)
+				return
+			}
+
+			transition := healthTransition(previous, validationError == nil)
+			s.alert(ctx, transition, conn, validationError)
+
+			runMu.Lock()
+			defer runMu.Unlock()
+			run.Checked++
+			if validationError == nil {
+				run.Healthy++
+			} else {
+				run.Failing++
+			}
+			switch transition {
+			case healthFailing:
+				run.NewFailing = append(run.NewFailing, conn.ID)
+			case healthRestored:
+				run.Restored = append(run.Restored, conn.ID)
+			}
+		}(conn)
+	}
+	wg.Wait()
+
+	run.DurationMs = time.Since(started).Milliseconds()
+	s.lastRun, s.lastRunAt = run, &started
+	return run, nil
+}
+
+// test runs a connection's test, returning the error to store or nil when it
+// passed. ok is false when there is nothing to record, e.g. the connection was
+// deleted during the run.
+func (s *ConnectionHealthService) test(ctx context.Context, conn *entity.Connection) (validationError *string, ok bool) {
+	testCtx, cancel := context.WithTimeout(ctx, connectionHealthTimeout)
+	defer cancel()
+
+	result, err := s.tester.TestConnection(testCtx, conn.ID.String(), false)
+	if ctx.Err() != nil {
+		// Shutting down: the result says nothing about the connection
+		return nil, false
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false
+		}
+		// Configs that no longer decrypt, e.g. after an encryption key rotation, fail here
+		msg := err.Error()
+		return &msg, true
+	}
+	if result.Success {
+		return nil, true
+	}
+	msg := result.Message
+	if result.ErrorDetails != "" {
+		msg += ": " + result.ErrorDetails
+	}
+	return &msg, true
+}
+
+// healthTransition returns the alert a test result raises given the connection's
+// previous status: only connections known to work can start failing
+func healthTransition(previous string, healthy bool) string {
+	switch {
+	case previous == "valid" && !healthy:
+		return healthFailing
+	case previous == "invalid" && healthy:
+		return healthRestored
+	default:
+		return ""
+	}
+}
+
+func (s *ConnectionHealthService) alert(ctx context.Context, transition string, conn *entity.Connection, validationError *string) {
+	data := map[string]interface{}{
+		"id":           conn.ID,
+		"profile_name": conn.ProfileName,
+		"source_type":  conn.SourceType,
+	}
+	switch transition {
+	case healthFailing:
+		data["error"] = *validationError
+		log.Printf("WARN: Connection %s (%s) started failing: %s", conn.ProfileName, conn.SourceType, *validationError)
+		s.events.Publish(ctx, interfaces.EventConnectionHealthFailing, data)
+	case healthRestored:
+		log.Printf("✅ Connection %s (%s) is healthy again", conn.ProfileName, conn.SourceType)
+		s.events.Publish(ctx, interfaces.EventConnectionHealthRestored, data)
+	}
+}
+
+// FleetHealth returns the health of every stored connection with totals per
+// status and source type, and the last scheduled or manual run
+func (s *ConnectionHealthService) FleetHealth(ctx context.Context) (*ConnectionFleetHealth, error) {
+	health, err := s.pgRepo.ListConnectionHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fleet := summarizeFleetHealth(health)
+	s.mu.Lock()
+	fleet.LastRun, fleet.LastRunAt = s.lastRun, s.lastRunAt
+	s.mu.Unlock()
+	return fleet, nil
+}
+
+func summarizeFleetHealth(health []*entity.ConnectionHealth) *ConnectionFleetHealth {
+	fleet := &ConnectionFleetHealth{
+		Total:        len(health),
+		ByStatus:     map[string]int{"valid": 0, "invalid": 0, "pending": 0},
+		BySourceType: make(map[string]map[string]int),
+		Failing:      []*entity.ConnectionHealth{},
+		Connections:  health,
+	}
+	for _, h := range health {
+		fleet.ByStatus[h.ValidationStatus]++
+		if fleet.BySourceType[h.SourceType] == nil {
+			fleet.BySourceType[h.SourceType] = make(map[string]int)
+		}
+		fleet.BySourceType[h.SourceType][h.ValidationStatus]++
+		if h.ValidationStatus == "invalid" {
+			fleet.Failing = append(fleet.Failing, h)
+		}
+	}
+	sort.SliceStable(fleet.Failing, func(i, j int) bool {
+		a, b := fleet.Failing[i].FailingSince, fleet.Failing[j].FailingSince
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+	return fleet
+}
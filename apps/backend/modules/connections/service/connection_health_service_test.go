@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestHealthTransition(t *testing.T) {
+	tests := []struct {
+		previous string
+		healthy  bool
+		want     string
+	}{
+		{"valid", false, healthFailing},
+		{"valid", true, ""},
+		{"invalid", true, healthRestored},
+		{"invalid", false, ""},
+		// Connections that never passed a test do not raise alerts
+		{"pending", false, ""},
+		{"pending", true, ""},
+	}
+	for _, tt := range tests {
+		if got := healthTransition(tt.previous, tt.healthy); got != tt.want {
+			t.Errorf("healthTransition(%q, %v) = %q, want %q", tt.previous, tt.healthy, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeFleetHealth(t *testing.T) {
+	now := time.Now()
+	older, newer := now.Add(-72*time.Hour), now.Add(-time.Hour)
+	health := []*entity.ConnectionHealth{
+		{ConnectionID: uuid.New(), SourceType: "postgresql", ValidationStatus: "valid"},
+		{ConnectionID: uuid.New(), SourceType: "postgresql", ValidationStatus: "invalid", FailingSince: &newer},
+		{ConnectionID: uuid.New(), SourceType: "s3", ValidationStatus: "invalid"},
+		{ConnectionID: uuid.New(), SourceType: "s3", ValidationStatus: "invalid", FailingSince: &older},
+		{ConnectionID: uuid.New(), SourceType: "mysql", ValidationStatus: "pending"},
+	}
+
+	fleet := summarizeFleetHealth(health)
+
+	if fleet.Total != 5 || fleet.ByStatus["valid"] != 1 || fleet.ByStatus["invalid"] != 3 || fleet.ByStatus["pending"] != 1 {
+		t.Errorf("unexpected totals %d %v", fleet.Total, fleet.ByStatus)
+	}
+	if fleet.BySourceType["s3"]["invalid"] != 2 || fleet.BySourceType["postgresql"]["valid"] != 1 {
+		t.Errorf("unexpected source type totals %v", fleet.BySourceType)
+	}
+	if len(fleet.Failing) != 3 {
+		t.Fatalf("expected 3 failing connections, got %d", len(fleet.Failing))
+	}
+	if fleet.Failing[0] != health[3] || fleet.Failing[1] != health[1] || fleet.Failing[2] != health[2] {
+		t.Error("expected failing connections longest failing first, unknown start last")
+	}
+}
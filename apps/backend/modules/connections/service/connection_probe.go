@@ -0,0 +1,365 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/pkg/validation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// A probe reads at most probeMaxTables tables, probeMaxRows rows each and
+// probeMaxColumns string columns per row; values are never stored
+const (
+	probeMaxTables     = 3
+	probeMaxRows       = 10
+	probeMaxColumns    = 5
+	probeMaxValueBytes = 1024
+	probeTimeout       = 10 * time.Second
+)
+
+// Probe likelihoods
+const (
+	ProbeLikelihoodHigh   = "high"   // Sensitive personal data or secrets, or PII in half the columns sampled
+	ProbeLikelihoodMedium = "medium" // Some PII
+	ProbeLikelihoodLow    = "low"    // No PII in the sample
+)
+
+// ConnectionProbeResult estimates whether a source holds PII from a small sample
+// read during a connection test. Only counts are reported; sampled values are
+// discarded once classified.
+type ConnectionProbeResult struct {
+	Supported      bool             `json:"supported"`
+	Message        string           `json:"message"`
+	TablesSampled  int              `json:"tables_sampled"`
+	ColumnsSampled int              `json:"columns_sampled"`
+	ValuesSampled  int              `json:"values_sampled"`
+	PIIProbability float64          `json:"pii_probability"` // Share of sampled columns holding PII
+	Likelihood     string           `json:"likelihood,omitempty"`
+	Detections     []ProbeDetection `json:"detections"`
+}
+
+// ProbeDetection counts the validated values of one PII type found in a sampled column
+type ProbeDetection struct {
+	Table          string  `json:"table"`
+	Column         string  `json:"column"`
+	PIIType        string  `json:"pii_type"`
+	Classification string  `json:"classification"`
+	Confidence     float64 `json:"confidence"`
+	Matches        int     `json:"matches"`
+}
+
+// probeDetector finds candidate values of one PII type; candidates must pass the
+// named validator before they count
+type probeDetector struct {
+	piiType   string
+	pattern   *regexp.Regexp
+	validator string
+}
+
+var probeDetectors = []probeDetector{
+	{"EMAIL_ADDRESS", regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`), "email"},
+	{"IN_PAN", regexp.MustCompile(`\b[A-Z]{5}[0-9]{4}[A-Z]\b`), "pan"},
+	{"IN_AADHAAR", regexp.MustCompile(`\b[2-9][0-9]{3}[ -]?[0-9]{4}[ -]?[0-9]{4}\b`), "aadhaar"},
+	{"CREDIT_CARD", regexp.MustCompile(`\b[0-9]{4}[ -]?[0-9]{4}[ -]?[0-9]{4}[ -]?[0-9]{4}\b`), "luhn"},
+	{"IN_PHONE", regexp.MustCompile(`\b[6-9][0-9]{9}\b`), "in_phone"},
+}
+
+// probeColumn is a string column chosen for sampling
+type probeColumn struct {
+	table  string
+	column string
+}
+
+// piiProbe accumulates the detections of one probe
+type piiProbe struct {
+	classify   func(piiType, table, column, value string) (string, float64)
+	columns    map[probeColumn]bool
+	tables     map[string]bool
+	values     int
+	detections map[probeColumn]map[string]*ProbeDetection
+}
+
+func (s *TestConnectionService) newPIIProbe() *piiProbe {
+	return &piiProbe{
+		classify: func(piiType, table, column, value string) (string, float64) {
+			result := s.classifier.Classify(piiType, table, value, map[string]interface{}{"column_name": column})
+			return result.ClassificationType, result.ConfidenceScore
+		},
+		columns:    make(map[probeColumn]bool),
+		tables:     make(map[string]bool),
+		detections: make(map[probeColumn]map[string]*ProbeDetection),
+	}
+}
+
+// observe runs one sampled value through detection and classification
+func (p *piiProbe) observe(table, column, value string) {
+	col := probeColumn{table: table, column: column}
+	p.tables[table] = true
+	p.columns[col] = true
+	if value == "" {
+		return
+	}
+	p.values++
+	if len(value) > probeMaxValueBytes {
+		value = value[:probeMaxValueBytes]
+	}
+
+	for _, d := range probeDetectors {
+		validate, _ := validation.LookupValidator(d.validator)
+		for _, candidate := range d.pattern.FindAllString(value, -1) {
+			if validate != nil {
+				if ok, _ := validate(candidate); !ok {
+					continue
+				}
+			}
+			classification, confidence := p.classify(d.piiType, table, column, candidate)
+			if classification == "" || classification == "Non-PII" {
+				continue
+			}
+			if p.detections[col] == nil {
+				p.detections[col] = make(map[string]*ProbeDetection)
+			}
+			det := p.detections[col][d.piiType]
+			if det == nil {
+				det = &ProbeDetection{Table: table, Column: column, PIIType: d.piiType, Classification: classification}
+				p.detections[col][d.piiType] = det
+			}
+			det.Matches++
+			det.Confidence = math.Max(det.Confidence, confidence)
+		}
+	}
+}
+
+// result summarizes the probe
+func (p *piiProbe) result() *ConnectionProbeResult {
+	res := &ConnectionProbeResult{
+		Supported:      true,
+		TablesSampled:  len(p.tables),
+		ColumnsSampled: len(p.columns),
+		ValuesSampled:  p.values,
+		Likelihood:     ProbeLikelihoodLow,
+		Detections:     []ProbeDetection{},
+	}
+
+	sensitive := false
+	for _, byType := range p.detections {
+		for _, det := range byType {
+			res.Detections = append(res.Detections, *det)
+			if det.Classification == "Sensitive Personal Data" || det.Classification == "Secrets" {
+				sensitive = true
+			}
+		}
+	}
+	sort.Slice(res.Detections, func(i, j int) bool {
+		a, b := res.Detections[i], res.Detections[j]
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.PIIType < b.PIIType
+	})
+
+	if len(p.columns) > 0 {
+		res.PIIProbability = math.Round(float64(len(p.detections))/float64(len(p.columns))*100) / 100
+	}
+	switch {
+	case sensitive || res.PIIProbability >= 0.5:
+		res.Likelihood = ProbeLikelihoodHigh
+	case len(p.detections) > 0:
+		res.Likelihood = ProbeLikelihoodMedium
+	}
+
+	if len(p.columns) == 0 {
+		res.Message = "No string columns found to sample"
+	} else {
+		res.Message = fmt.Sprintf("Sampled %d values from %d columns in %d tables", res.ValuesSampled, res.ColumnsSampled, res.TablesSampled)
+	}
+	return res
+}
+
+// probeSQL samples string columns of a PostgreSQL or MySQL database in a read-only transaction
+func (s *TestConnectionService) probeSQL(ctx context.Context, db *sql.DB, sourceType string) *ConnectionProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return probeFailed(sourceType, err)
+	}
+	defer tx.Rollback()
+
+	var columnsQuery string
+	quote := quotePostgresIdent
+	if sourceType == "mysql" {
+		quote = quoteMySQLIdent
+		columnsQuery = `
+			SELECT table_schema, table_name, column_name FROM information_schema.columns
+			WHERE table_schema = DATABASE()
+			  AND data_type IN ('char', 'varchar', 'tinytext', 'text', 'mediumtext', 'longtext')
+			ORDER BY table_name, ordinal_position
+			LIMIT 500`
+	} else {
+		columnsQuery = `
+			SELECT c.table_schema, c.table_name, c.column_name FROM information_schema.columns c
+			JOIN information_schema.tables t
+			  ON t.table_schema = c.table_schema AND t.table_name = c.table_name AND t.table_type = 'BASE TABLE'
+			WHERE c.table_schema NOT IN ('pg_catalog', 'information_schema')
+			  AND c.data_type IN ('character varying', 'character', 'text')
+			ORDER BY c.table_schema, c.table_name, c.ordinal_position
+			LIMIT 500`
+	}
+
+	rows, err := tx.QueryContext(ctx, columnsQuery)
+	if err != nil {
+		return probeFailed(sourceType, err)
+	}
+	type table struct {
+		schema, name string
+		columns      []string
+	}
+	var tables []*table
+	for rows.Next() {
+		var schema, name, column string
+		if err := rows.Scan(&schema, &name, &column); err != nil {
+			rows.Close()
+			return probeFailed(sourceType, err)
+		}
+		if n := len(tables); n == 0 || tables[n-1].schema != schema || tables[n-1].name != name {
+			if n == probeMaxTables {
+				break
+			}
+			tables = append(tables, &table{schema: schema, name: name})
+		}
+		if t := tables[len(tables)-1]; len(t.columns) < probeMaxColumns {
+			t.columns = append(t.columns, column)
+		}
+	}
+	rows.Close()
+
+	probe := s.newPIIProbe()
+	for _, t := range tables {
+		quoted := make([]string, len(t.columns))
+		for i, c := range t.columns {
+			quoted[i] = quote(c)
+		}
+		query := fmt.Sprintf("SELECT %s FROM %s.%s LIMIT %d",
+			strings.Join(quoted, ", "), quote(t.schema), quote(t.name), probeMaxRows)
+		if err := sampleRows(ctx, tx, query, t.schema+"."+t.name, t.columns, probe); err != nil {
+			return probeFailed(sourceType, err)
+		}
+	}
+	return probe.result()
+}
+
+// sampleRows feeds the string values of a sample query to the probe
+func sampleRows(ctx context.Context, tx *sql.Tx, query, table string, columns []string, probe *piiProbe) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		for i, v := range values {
+			probe.observe(table, columns[i], v.String)
+		}
+	}
+	return rows.Err()
+}
+
+// probeMongo samples top-level string fields of a MongoDB database's collections
+func (s *TestConnectionService) probeMongo(ctx context.Context, client *mongo.Client, dbname string) *ConnectionProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	if dbname == "" {
+		return &ConnectionProbeResult{Supported: true, Message: "Set a database to sample", Detections: []ProbeDetection{}}
+	}
+	db := client.Database(dbname)
+	names, err := db.ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return probeFailed("mongodb", err)
+	}
+	sort.Strings(names)
+	if len(names) > probeMaxTables {
+		names = names[:probeMaxTables]
+	}
+
+	probe := s.newPIIProbe()
+	for _, name := range names {
+		cursor, err := db.Collection(name).Find(ctx, bson.D{}, options.Find().SetLimit(probeMaxRows))
+		if err != nil {
+			return probeFailed("mongodb", err)
+		}
+		var fields []string
+		for cursor.Next(ctx) {
+			var doc bson.D
+			if err := cursor.Decode(&doc); err != nil {
+				cursor.Close(ctx)
+				return probeFailed("mongodb", err)
+			}
+			for _, elem := range doc {
+				value, ok := elem.Value.(string)
+				if !ok || elem.Key == "_id" {
+					continue
+				}
+				if !containsString(fields, elem.Key) {
+					if len(fields) == probeMaxColumns {
+						continue
+					}
+					fields = append(fields, elem.Key)
+				}
+				probe.observe(name, elem.Key, value)
+			}
+		}
+		cursor.Close(ctx)
+	}
+	return probe.result()
+}
+
+// probeFailed reports a probe that could not read its sample. The connection test
+// itself succeeded, so the details are only logged.
+func probeFailed(sourceType string, err error) *ConnectionProbeResult {
+	fmt.Printf("[SECURITY] %s sampling probe failed - %v\n", sourceType, err)
+	return &ConnectionProbeResult{
+		Supported:  true,
+		Message:    "Unable to read a sample. Please verify the user can read the source's tables.",
+		Detections: []ProbeDetection{},
+	}
+}
+
+func quotePostgresIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
+
+func quoteMySQLIdent(ident string) string {
+	return "`" + strings.ReplaceAll(ident, "`", "``") + "`"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	scanningservice "github.com/arc-platform/backend/modules/scanning/service"
+)
+
+func TestPIIProbe(t *testing.T) {
+	s := &TestConnectionService{classifier: scanningservice.NewClassificationService(nil, nil)}
+
+	t.Run("PII in sampled columns", func(t *testing.T) {
+		probe := s.newPIIProbe()
+		probe.observe("public.customers", "email", "asha@example.com")
+		probe.observe("public.customers", "email", "ravi@example.org")
+		probe.observe("public.customers", "notes", "paid with 4111 1111 1111 1111")
+		probe.observe("public.customers", "pan", "ABCPE1234F")
+		probe.observe("public.customers", "city", "Pune")
+
+		res := probe.result()
+		if res.ColumnsSampled != 4 || res.ValuesSampled != 5 || res.TablesSampled != 1 {
+			t.Fatalf("unexpected sample counts: %+v", res)
+		}
+		if res.Likelihood != ProbeLikelihoodHigh || res.PIIProbability != 0.75 {
+			t.Errorf("expected high likelihood with probability 0.75, got %s %v", res.Likelihood, res.PIIProbability)
+		}
+
+		found := map[string]ProbeDetection{}
+		for _, d := range res.Detections {
+			found[d.Column+"/"+d.PIIType] = d
+		}
+		if d := found["email/EMAIL_ADDRESS"]; d.Matches != 2 || d.Classification != "Personal Data" {
+			t.Errorf("unexpected email detection: %+v", d)
+		}
+		if d := found["notes/CREDIT_CARD"]; d.Matches != 1 || d.Classification != "Sensitive Personal Data" {
+			t.Errorf("unexpected card detection: %+v", d)
+		}
+		if _, ok := found["pan/IN_PAN"]; !ok {
+			t.Errorf("expected a PAN detection, got %+v", res.Detections)
+		}
+	})
+
+	t.Run("candidates failing validation are ignored", func(t *testing.T) {
+		probe := s.newPIIProbe()
+		probe.observe("orders", "ref", "4111 1111 1111 1112")
+		probe.observe("orders", "code", "ABCXE1234F")
+
+		res := probe.result()
+		if len(res.Detections) != 0 || res.Likelihood != ProbeLikelihoodLow || res.PIIProbability != 0 {
+			t.Errorf("expected no detections, got %+v", res)
+		}
+	})
+
+	t.Run("no string columns", func(t *testing.T) {
+		res := s.newPIIProbe().result()
+		if res.Message != "No string columns found to sample" || res.Likelihood != ProbeLikelihoodLow {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	})
+}
+
+func TestQuoteIdentifiers(t *testing.T) {
+	if got := quotePostgresIdent(`we"ird`); got != `"we""ird"` {
+		t.Errorf("quotePostgresIdent: got %s", got)
+	}
+	if got := quoteMySQLIdent("we`ird"); got != "`we``ird`" {
+		t.Errorf("quoteMySQLIdent: got %s", got)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConnectionConfig is returned for a config that does not match its source type's schema
+var ErrInvalidConnectionConfig = errors.New("invalid connection config")
+
+// ConfigField is one setting of a connection config
+type ConfigField struct {
+	Name        string      `json:"name"`
+	Type        string      `json:"type"` // "string", "integer", "boolean" or "text" (multi-line, e.g. a key)
+	Required    bool        `json:"required"`
+	Secret      bool        `json:"secret,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Description string      `json:"description"`
+}
+
+// ConnectionSchema describes the config a source type takes
+type ConnectionSchema struct {
+	SourceType string        `json:"source_type"`
+	Fields     []ConfigField `json:"fields"`
+	// OneOf lists groups of fields of which at least one must be set, e.g. a password or a key
+	OneOf [][]string `json:"one_of,omitempty"`
+}
+
+// connectionSchemas are the source types connections can be added for
+var connectionSchemas = []ConnectionSchema{
+	{SourceType: "postgresql", Fields: []ConfigField{
+		{Name: "host", Type: "string", Required: true, Description: "Server hostname or IP"},
+		{Name: "port", Type: "integer", Default: 5432, Description: "Server port"},
+		{Name: "user", Type: "string", Required: true, Description: "Login user"},
+		{Name: "password", Type: "string", Secret: true, Description: "Login password"},
+		{Name: "database", Type: "string", Description: "Database to scan"},
+		{Name: "sslmode", Type: "string", Default: "prefer", Description: "libpq SSL mode"},
+	}},
+	{SourceType: "mysql", Fields: []ConfigField{
+		{Name: "host", Type: "string", Required: true, Description: "Server hostname or IP"},
+		{Name: "port", Type: "integer", Default: 3306, Description: "Server port"},
+		{Name: "user", Type: "string", Required: true, Description: "Login user"},
+		{Name: "password", Type: "string", Secret: true, Description: "Login password"},
+		{Name: "database", Type: "string", Description: "Database to scan"},
+	}},
+	{SourceType: "mongodb", Fields: []ConfigField{
+		{Name: "host", Type: "string", Required: true, Description: "Server hostname or IP"},
+		{Name: "port", Type: "integer", Default: 27017, Description: "Server port"},
+		{Name: "user", Type: "string", Description: "Login user"},
+		{Name: "password", Type: "string", Secret: true, Description: "Login password"},
+		{Name: "database", Type: "string", Description: "Database to scan"},
+		{Name: "auth_source", Type: "string", Default: "admin", Description: "Database the user is defined in"},
+	}},
+	{SourceType: "s3", Fields: []ConfigField{
+		{Name: "bucket", Type: "string", Required: true, Description: "Bucket to scan"},
+		{Name: "region", Type: "string", Description: "AWS region"},
+		{Name: "access_key", Type: "string", Required: true, Description: "Access key ID"},
+		{Name: "secret_key", Type: "string", Required: true, Secret: true, Description: "Secret access key"},
+		{Name: "endpoint", Type: "string", Description: "host:port of an S3-compatible endpoint"},
+	}},
+	{SourceType: "filesystem", Fields: []ConfigField{
+		{Name: "path", Type: "string", Required: true, Description: "Directory to scan"},
+	}},
+	{SourceType: "sftp", Fields: []ConfigField{
+		{Name: "host", Type: "string", Required: true, Description: "Server hostname or IP"},
+		{Name: "port", Type: "integer", Default: 22, Description: "SSH port"},
+		{Name: "user", Type: "string", Required: true, Description: "Login user"},
+		{Name: "password", Type: "string", Secret: true, Description: "Login password"},
+		{Name: "private_key", Type: "text", Secret: true, Description: "PEM or OpenSSH private key"},
+		{Name: "passphrase", Type: "string", Secret: true, Description: "Passphrase of an encrypted private key"},
+		{Name: "host_key_fingerprint", Type: "string", Required: true, Description: "Pinned SHA256 host key fingerprint, as ssh-keygen -lf prints it; servers presenting another key are refused"},
+		{Name: "path", Type: "string", Default: ".", Description: "Directory to scan; relative paths start at the user's home"},
+	}, OneOf: [][]string{{"password", "private_key"}}},
+	{SourceType: "smb", Fields: []ConfigField{
+		{Name: "host", Type: "string", Required: true, Description: "File server hostname or IP"},
+		{Name: "port", Type: "integer", Default: 445, Description: "SMB port"},
+		{Name: "share", Type: "string", Required: true, Description: "Share name"},
+		{Name: "domain", Type: "string", Description: "Windows domain of the user"},
+		{Name: "user", Type: "string", Required: true, Description: "Login user"},
+		{Name: "password", Type: "string", Required: true, Secret: true, Description: "Login password"},
+		{Name: "path", Type: "string", Description: "Directory within the share to scan; the share's root when empty"},
+	}},
+	{SourceType: "redis", Fields: []ConfigField{
+		{Name: "host", Type: "string", Required: true, Description: "Server hostname or IP"},
+		{Name: "port", Type: "integer", Default: 6379, Description: "Server port"},
+		{Name: "db", Type: "integer", Default: 0, Description: "Database number"},
+		{Name: "password", Type: "string", Secret: true, Description: "AUTH password"},
+	}},
+	{SourceType: "slack", Fields: []ConfigField{
+		{Name: "bot_token", Type: "string", Required: true, Secret: true, Description: "Bot token (xoxb-...)"},
+	}},
+}
+
+// ConnectionSchemas returns the config schema of every supported source type
+func ConnectionSchemas() []ConnectionSchema {
+	return connectionSchemas
+}
+
+// ConnectionSchemaFor returns the config schema of a source type, or nil when it is not supported
+func ConnectionSchemaFor(sourceType string) *ConnectionSchema {
+	for i := range connectionSchemas {
+		if connectionSchemas[i].SourceType == sourceType {
+			return &connectionSchemas[i]
+		}
+	}
+	return nil
+}
+
+// ValidateConnectionConfig checks a config against its source type's schema:
+// required fields are set and fields have their type. Fields the schema does
+// not list are passed on to the scanner unchecked.
+func ValidateConnectionConfig(sourceType string, config map[string]interface{}) error {
+	schema := ConnectionSchemaFor(sourceType)
+	if schema == nil {
+		return fmt.Errorf("%w: unsupported source type %s", ErrInvalidConnectionConfig, sourceType)
+	}
+
+	var problems []string
+	for _, field := range schema.Fields {
+		value, set := config[field.Name]
+		if !set || value == nil || value == "" {
+			if field.Required {
+				problems = append(problems, field.Name+" is required")
+			}
+			continue
+		}
+		if !hasConfigType(value, field.Type) {
+			problems = append(problems, fmt.Sprintf("%s must be of type %s", field.Name, field.Type))
+		}
+	}
+	for _, group := range schema.OneOf {
+		found := false
+		for _, name := range group {
+			if value, set := config[name]; set && value != nil && value != "" {
+				found = true
+			}
+		}
+		if !found {
+			problems = append(problems, "one of "+strings.Join(group, ", ")+" is required")
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidConnectionConfig, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// hasConfigType reports whether a JSON-decoded value has a schema type. Integers
+// may also be given as numeric strings, as forms often send them.
+func hasConfigType(value interface{}, fieldType string) bool {
+	switch fieldType {
+	case "integer":
+		switch v := value.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return v == math.Trunc(v)
+		case string:
+			_, err := strconv.Atoi(v)
+			return err == nil
+		}
+		return false
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	default:
+		_, ok := value.(string)
+		return ok
+	}
+}
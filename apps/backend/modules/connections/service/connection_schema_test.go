@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateConnectionConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		sourceType string
+		config     map[string]interface{}
+		problem    string // Empty when the config is valid
+	}{
+		{"sftp with password", "sftp", map[string]interface{}{"host": "files.example.com", "user": "scanner", "password": "pw", "port": float64(2222), "host_key_fingerprint": "SHA256:abc"}, ""},
+		{"sftp with key and port string", "sftp", map[string]interface{}{"host": "files.example.com", "user": "scanner", "private_key": "-----BEGIN...", "port": "22", "host_key_fingerprint": "SHA256:abc"}, ""},
+		{"sftp without credentials", "sftp", map[string]interface{}{"host": "files.example.com", "user": "scanner", "host_key_fingerprint": "SHA256:abc"}, "one of password, private_key is required"},
+		{"sftp without host key", "sftp", map[string]interface{}{"host": "files.example.com", "user": "scanner", "password": "pw"}, "host_key_fingerprint is required"},
+		{"sftp with a fractional port", "sftp", map[string]interface{}{"host": "h", "user": "u", "password": "p", "port": 22.5, "host_key_fingerprint": "SHA256:abc"}, "port must be of type integer"},
+		{"smb", "smb", map[string]interface{}{"host": "fileserver", "share": "hr", "user": "scanner", "password": "pw", "domain": "CORP"}, ""},
+		{"smb without share", "smb", map[string]interface{}{"host": "fileserver", "user": "scanner", "password": "pw"}, "share is required"},
+		{"unknown fields pass", "filesystem", map[string]interface{}{"path": "/data", "exclude": []interface{}{"*.tmp"}}, ""},
+		{"unsupported type", "ftp", map[string]interface{}{}, "unsupported source type ftp"},
+	}
+	for _, tt := range tests {
+		err := ValidateConnectionConfig(tt.sourceType, tt.config)
+		if tt.problem == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidConnectionConfig) || !strings.Contains(err.Error(), tt.problem) {
+			t.Errorf("%s: expected %q, got %v", tt.name, tt.problem, err)
+		}
+	}
+}
+
+func TestConnectionSchemasCoverTestedSourceTypes(t *testing.T) {
+	for _, sourceType := range []string{"postgresql", "mysql", "mongodb", "s3", "filesystem", "sftp", "smb", "redis", "slack"} {
+		if ConnectionSchemaFor(sourceType) == nil {
+			t.Errorf("no schema for %s", sourceType)
+		}
+	}
+}
@@ -24,8 +24,13 @@ func NewConnectionService(pgRepo *persistence.PostgresRepository, enc *encryptio
 	}
 }
 
-// AddConnection creates a new connection with encrypted credentials
+// AddConnection creates a new connection with encrypted credentials. The config
+// must match the source type's schema.
 func (s *ConnectionService) AddConnection(ctx context.Context, sourceType, profileName string, config map[string]interface{}, createdBy string) (*entity.Connection, error) {
+	if err := ValidateConnectionConfig(sourceType, config); err != nil {
+		return nil, err
+	}
+
 	// 1. Encrypt config
 	configEncrypted, err := s.encryption.Encrypt(config)
 	if err != nil {
@@ -3,13 +3,18 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
+	scanningservice "github.com/arc-platform/backend/modules/scanning/service"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/sftp"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/smb"
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"go.mongodb.org/mongo-driver/bson"
@@ -20,12 +25,14 @@ import (
 type TestConnectionService struct {
 	pgRepo     *persistence.PostgresRepository
 	encryption *encryption.EncryptionService
+	classifier *scanningservice.ClassificationService // Classifies values read by sampling probes
 }
 
 func NewTestConnectionService(pgRepo *persistence.PostgresRepository, enc *encryption.EncryptionService) *TestConnectionService {
 	return &TestConnectionService{
 		pgRepo:     pgRepo,
 		encryption: enc,
+		classifier: scanningservice.NewClassificationService(pgRepo, nil),
 	}
 }
 
@@ -37,9 +44,17 @@ type ConnectionTestResult struct {
 	ErrorDetails  string `json:"error_details,omitempty"`
 	ServerVersion string `json:"server_version,omitempty"`
 	DatabaseInfo  string `json:"database_info,omitempty"`
+
+	// HostKeyFingerprint is the SFTP server's host key, to pin with host_key_fingerprint
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+
+	// Probe is set when sampling was requested and the connection succeeded
+	Probe *ConnectionProbeResult `json:"probe,omitempty"`
 }
 
-func (s *TestConnectionService) TestConnection(ctx context.Context, connID string) (*ConnectionTestResult, error) {
+// TestConnection tests a saved connection. With probe set, a successful test also
+// samples a few rows and estimates whether the source holds PII.
+func (s *TestConnectionService) TestConnection(ctx context.Context, connID string, probe bool) (*ConnectionTestResult, error) {
 	connUUID, err := uuid.Parse(connID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid connection ID: %w", err)
@@ -55,48 +70,47 @@ func (s *TestConnectionService) TestConnection(ctx context.Context, connID strin
 		return nil, fmt.Errorf("failed to decrypt config: %w", err)
 	}
 
-	startTime := time.Now()
-	var result *ConnectionTestResult
-
-	switch conn.SourceType {
-	case "postgresql":
-		result, err = s.testPostgreSQL(ctx, config)
-	case "mysql":
-		result, err = s.testMySQL(ctx, config)
-	case "mongodb":
-		result, err = s.testMongoDB(ctx, config)
-	case "s3":
-		result, err = s.testS3(ctx, config)
-	case "filesystem":
-		result, err = s.testFilesystem(ctx, config)
-	case "redis":
-		result, err = s.testRedis(ctx, config)
-	case "slack":
-		result, err = s.testSlack(ctx, config)
-	default:
-		return nil, fmt.Errorf("unsupported source type: %s", conn.SourceType)
-	}
+	return s.test(ctx, conn.SourceType, config, probe)
+}
 
-	result.ResponseTime = time.Since(startTime).Milliseconds()
-	return result, nil
+// TestConnectionByConfig tests an unsaved connection configuration, sampling it as
+// TestConnection does when probe is set
+func (s *TestConnectionService) TestConnectionByConfig(ctx context.Context, sourceType string, config map[string]interface{}, probe bool) (*ConnectionTestResult, error) {
+	return s.test(ctx, sourceType, config, probe)
 }
 
-func (s *TestConnectionService) TestConnectionByConfig(ctx context.Context, sourceType string, config map[string]interface{}) (*ConnectionTestResult, error) {
+// test checks config against its source type's schema and tests the connection it describes
+func (s *TestConnectionService) test(ctx context.Context, sourceType string, config map[string]interface{}, probe bool) (*ConnectionTestResult, error) {
+	if ConnectionSchemaFor(sourceType) == nil {
+		return nil, fmt.Errorf("unsupported source type: %s", sourceType)
+	}
+	if err := ValidateConnectionConfig(sourceType, config); err != nil {
+		return &ConnectionTestResult{
+			SourceType:   sourceType,
+			Message:      "Invalid connection configuration",
+			ErrorDetails: strings.TrimPrefix(err.Error(), ErrInvalidConnectionConfig.Error()+": "),
+		}, nil
+	}
+
 	startTime := time.Now()
 	var result *ConnectionTestResult
 	var err error
 
 	switch sourceType {
 	case "postgresql":
-		result, err = s.testPostgreSQL(ctx, config)
+		result, err = s.testPostgreSQL(ctx, config, probe)
 	case "mysql":
-		result, err = s.testMySQL(ctx, config)
+		result, err = s.testMySQL(ctx, config, probe)
 	case "mongodb":
-		result, err = s.testMongoDB(ctx, config)
+		result, err = s.testMongoDB(ctx, config, probe)
 	case "s3":
 		result, err = s.testS3(ctx, config)
 	case "filesystem":
 		result, err = s.testFilesystem(ctx, config)
+	case "sftp":
+		result, err = s.testSFTP(ctx, config)
+	case "smb":
+		result, err = s.testSMB(ctx, config)
 	case "redis":
 		result, err = s.testRedis(ctx, config)
 	case "slack":
@@ -106,10 +120,21 @@ func (s *TestConnectionService) TestConnectionByConfig(ctx context.Context, sour
 	}
 
 	result.ResponseTime = time.Since(startTime).Milliseconds()
+	markProbeUnsupported(result, probe)
 	return result, err
 }
 
-func (s *TestConnectionService) testPostgreSQL(ctx context.Context, config map[string]interface{}) (*ConnectionTestResult, error) {
+// markProbeUnsupported tells callers who asked for sampling that the source type cannot be sampled
+func markProbeUnsupported(result *ConnectionTestResult, probe bool) {
+	if probe && result.Success && result.Probe == nil {
+		result.Probe = &ConnectionProbeResult{
+			Message:    fmt.Sprintf("Sampling is not supported for %s sources", result.SourceType),
+			Detections: []ProbeDetection{},
+		}
+	}
+}
+
+func (s *TestConnectionService) testPostgreSQL(ctx context.Context, config map[string]interface{}, probe bool) (*ConnectionTestResult, error) {
 	result := &ConnectionTestResult{SourceType: "postgresql"}
 
 	host := getString(config, "host")
@@ -163,10 +188,13 @@ func (s *TestConnectionService) testPostgreSQL(ctx context.Context, config map[s
 	result.Success = true
 	result.Message = "Connection successful"
 	result.DatabaseInfo = fmt.Sprintf("Database: %s", dbname)
+	if probe {
+		result.Probe = s.probeSQL(ctx, db, result.SourceType)
+	}
 	return result, nil
 }
 
-func (s *TestConnectionService) testMySQL(ctx context.Context, config map[string]interface{}) (*ConnectionTestResult, error) {
+func (s *TestConnectionService) testMySQL(ctx context.Context, config map[string]interface{}, probe bool) (*ConnectionTestResult, error) {
 	result := &ConnectionTestResult{SourceType: "mysql"}
 
 	host := getString(config, "host")
@@ -212,10 +240,13 @@ func (s *TestConnectionService) testMySQL(ctx context.Context, config map[string
 	result.Success = true
 	result.Message = "Connection successful"
 	result.DatabaseInfo = fmt.Sprintf("Database: %s", dbname)
+	if probe {
+		result.Probe = s.probeSQL(ctx, db, result.SourceType)
+	}
 	return result, nil
 }
 
-func (s *TestConnectionService) testMongoDB(ctx context.Context, config map[string]interface{}) (*ConnectionTestResult, error) {
+func (s *TestConnectionService) testMongoDB(ctx context.Context, config map[string]interface{}, probe bool) (*ConnectionTestResult, error) {
 	result := &ConnectionTestResult{SourceType: "mongodb"}
 
 	host := getString(config, "host")
@@ -272,6 +303,9 @@ func (s *TestConnectionService) testMongoDB(ctx context.Context, config map[stri
 	result.Success = true
 	result.Message = "Connection successful"
 	result.DatabaseInfo = fmt.Sprintf("Database: %s", dbname)
+	if probe {
+		result.Probe = s.probeMongo(ctx, client, dbname)
+	}
 	return result, nil
 }
 
@@ -334,6 +368,122 @@ func (s *TestConnectionService) testFilesystem(ctx context.Context, config map[s
 	return result, nil
 }
 
+// testSFTP logs in to the SFTP server and lists the configured directory
+func (s *TestConnectionService) testSFTP(ctx context.Context, config map[string]interface{}) (*ConnectionTestResult, error) {
+	result := &ConnectionTestResult{SourceType: "sftp"}
+
+	host := getString(config, "host")
+	port := getInt(config, "port", 22)
+	dir := getString(config, "path")
+	if dir == "" {
+		dir = "."
+	}
+
+	client, err := sftp.Dial(ctx, sftp.Config{
+		Host:               host,
+		Port:               port,
+		User:               getString(config, "user"),
+		Password:           getString(config, "password"),
+		PrivateKey:         getString(config, "private_key"),
+		Passphrase:         getString(config, "passphrase"),
+		HostKeyFingerprint: getString(config, "host_key_fingerprint"),
+		Timeout:            10 * time.Second,
+	})
+	if err != nil {
+		result.Success = false
+		result.Message = "Failed to connect to SFTP server"
+		result.ErrorDetails = "Unable to log in. Please verify your credentials, host key fingerprint, hostname, and network access."
+		// Log detailed error server-side only
+		fmt.Printf("[SECURITY] SFTP connection failed for %s:%d - %v\n", host, port, err)
+		return result, nil
+	}
+	defer client.Close()
+	result.ServerVersion = client.ServerVersion()
+	result.HostKeyFingerprint = client.Fingerprint()
+
+	entries, err := client.ReadDir(dir)
+	if err != nil {
+		result.Success = false
+		result.Message = "Failed to list SFTP directory"
+		result.ErrorDetails = directoryErrorDetails(dir, errors.Is(err, sftp.ErrNotFound), errors.Is(err, sftp.ErrPermissionDenied))
+		fmt.Printf("[SECURITY] SFTP listing failed for %s:%d %s - %v\n", host, port, dir, err)
+		return result, nil
+	}
+
+	result.Success = true
+	result.Message = "Connection successful"
+	result.DatabaseInfo = fmt.Sprintf("Path: %s (%d entries)", dir, len(entries))
+	return result, nil
+}
+
+// testSMB logs in to the file server, connects to the share and lists the configured directory
+func (s *TestConnectionService) testSMB(ctx context.Context, config map[string]interface{}) (*ConnectionTestResult, error) {
+	result := &ConnectionTestResult{SourceType: "smb"}
+
+	host := getString(config, "host")
+	port := getInt(config, "port", 445)
+	share := getString(config, "share")
+	dir := getString(config, "path")
+
+	client, err := smb.Dial(ctx, smb.Config{
+		Host:     host,
+		Port:     port,
+		Share:    share,
+		Domain:   getString(config, "domain"),
+		User:     getString(config, "user"),
+		Password: getString(config, "password"),
+		Timeout:  10 * time.Second,
+	})
+	if err != nil {
+		result.Success = false
+		switch {
+		case smb.IsLogonFailure(err):
+			result.Message = "SMB authentication failed"
+			result.ErrorDetails = "The server rejected the login. Please verify the domain, user, and password."
+		case smb.IsNotFound(err):
+			result.Message = "SMB share not found"
+			result.ErrorDetails = fmt.Sprintf("The server has no share named %s.", share)
+		case errors.Is(err, smb.ErrDialectUnsupported):
+			result.Message = "SMB dialect not supported"
+			result.ErrorDetails = "The server only accepts SMB 3; connection tests support SMB 2.0.2 and 2.1."
+		default:
+			result.Message = "Failed to connect to SMB server"
+			result.ErrorDetails = "Unable to establish connection. Please verify hostname, port, and network access."
+		}
+		// Log detailed error server-side only
+		fmt.Printf("[SECURITY] SMB connection failed for %s:%d/%s - %v\n", host, port, share, err)
+		return result, nil
+	}
+	defer client.Close()
+	result.ServerVersion = "SMB " + client.Dialect()
+
+	entries, err := client.ReadDir(dir)
+	if err != nil {
+		result.Success = false
+		result.Message = "Failed to list SMB directory"
+		result.ErrorDetails = directoryErrorDetails(dir, smb.IsNotFound(err), false)
+		fmt.Printf("[SECURITY] SMB listing failed for %s:%d/%s %s - %v\n", host, port, share, dir, err)
+		return result, nil
+	}
+
+	result.Success = true
+	result.Message = "Connection successful"
+	result.DatabaseInfo = fmt.Sprintf("Share: %s, Path: /%s (%d entries)", share, strings.Trim(dir, `/\`), len(entries))
+	return result, nil
+}
+
+// directoryErrorDetails explains why a file server directory could not be listed
+func directoryErrorDetails(dir string, notFound, denied bool) string {
+	switch {
+	case notFound:
+		return fmt.Sprintf("Directory %s does not exist.", dir)
+	case denied:
+		return fmt.Sprintf("The user may not read directory %s.", dir)
+	default:
+		return fmt.Sprintf("Unable to list directory %s. Please verify the path and the user's permissions.", dir)
+	}
+}
+
 func (s *TestConnectionService) testRedis(ctx context.Context, config map[string]interface{}) (*ConnectionTestResult, error) {
 	result := &ConnectionTestResult{SourceType: "redis"}
 
@@ -403,6 +553,10 @@ func getInt(config map[string]interface{}, key string, defaultVal int) int {
 			return int(v)
 		case float64:
 			return int(v)
+		case string:
+			if n, err := strconv.Atoi(v); err == nil {
+				return n
+			}
 		}
 	}
 	return defaultVal
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTrendDays = 30
+	maxTrendDays     = 365
+)
+
+// ErrConnectionNotFound is returned for a connection ID with no stored connection
+var ErrConnectionNotFound = errors.New("connection not found")
+
+// ConnectionFindingTrend is a connection's scans and findings per day
+type ConnectionFindingTrend struct {
+	ConnectionID uuid.UUID                     `json:"connection_id"`
+	ProfileName  string                        `json:"profile_name"`
+	Days         int                           `json:"days"`
+	Points       []entity.ConnectionTrendPoint `json:"points"`
+}
+
+// ConnectionUsageService reports the scans performed against each connection and
+// alerts on connections that have gone unscanned
+type ConnectionUsageService struct {
+	pgRepo         *persistence.PostgresRepository
+	events         interfaces.EventPublisher
+	staleAfterDays int
+}
+
+// NewConnectionUsageService creates a connection usage service. Connections without a
+// scan for staleAfterDays are reported by the coverage check.
+func NewConnectionUsageService(pgRepo *persistence.PostgresRepository, staleAfterDays int) *ConnectionUsageService {
+	if staleAfterDays < 1 {
+		staleAfterDays = 30
+	}
+	return &ConnectionUsageService{
+		pgRepo:         pgRepo,
+		events:         &interfaces.NoOpEventPublisher{},
+		staleAfterDays: staleAfterDays,
+	}
+}
+
+// SetEventPublisher enables connection_coverage_stale events
+func (s *ConnectionUsageService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// StaleAfterDays returns the default window of the coverage check
+func (s *ConnectionUsageService) StaleAfterDays() int {
+	return s.staleAfterDays
+}
+
+// ScanHistory returns a connection's scan runs, newest first, with their total
+func (s *ConnectionUsageService) ScanHistory(ctx context.Context, connectionID uuid.UUID, limit, offset int) ([]*entity.ScanRun, int, error) {
+	if _, err := s.getConnection(ctx, connectionID); err != nil {
+		return nil, 0, err
+	}
+	return s.pgRepo.ListConnectionScanRuns(ctx, connectionID, limit, offset)
+}
+
+// FindingTrend returns a connection's scans and findings for each of the last days,
+// including days without scans
+func (s *ConnectionUsageService) FindingTrend(ctx context.Context, connectionID uuid.UUID, days int) (*ConnectionFindingTrend, error) {
+	conn, err := s.getConnection(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if days < 1 {
+		days = defaultTrendDays
+	}
+	if days > maxTrendDays {
+		days = maxTrendDays
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+	points, err := s.pgRepo.ListConnectionFindingTrend(ctx, connectionID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConnectionFindingTrend{
+		ConnectionID: conn.ID,
+		ProfileName:  conn.ProfileName,
+		Days:         days,
+		Points:       fillTrendDays(points, since, days),
+	}, nil
+}
+
+// StaleConnections returns connections not scanned in the last days, or never,
+// least recently scanned first. Zero days uses the configured window.
+func (s *ConnectionUsageService) StaleConnections(ctx context.Context, days int) ([]*entity.Connection, error) {
+	if days < 1 {
+		days = s.staleAfterDays
+	}
+	return s.pgRepo.ListConnectionsNotScannedSince(ctx, time.Now().AddDate(0, 0, -days))
+}
+
+// StartCoverageWorker checks for stale connections on every interval until ctx is cancelled
+func (s *ConnectionUsageService) StartCoverageWorker(ctx context.Context, intervalHours int) {
+	if intervalHours < 1 {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalHours) * time.Hour)
+	defer ticker.Stop()
+
+	log.Printf("🔌 Starting connection coverage worker (interval: %dh, stale after: %dd)", intervalHours, s.staleAfterDays)
+
+	for {
+		if err := s.CheckCoverage(ctx); err != nil {
+			log.Printf("❌ Connection coverage check failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Connection coverage worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckCoverage publishes a connection_coverage_stale event listing the connections
+// not scanned within the configured window, if any
+func (s *ConnectionUsageService) CheckCoverage(ctx context.Context) error {
+	stale, err := s.StaleConnections(ctx, s.staleAfterDays)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	connections := make([]map[string]interface{}, 0, len(stale))
+	for _, conn := range stale {
+		connections = append(connections, map[string]interface{}{
+			"id":              conn.ID,
+			"profile_name":    conn.ProfileName,
+			"source_type":     conn.SourceType,
+			"last_scanned_at": conn.LastScannedAt,
+		})
+	}
+	log.Printf("WARN: %d connections not scanned in %d days", len(stale), s.staleAfterDays)
+	s.events.Publish(ctx, interfaces.EventConnectionCoverageStale, map[string]interface{}{
+		"stale_after_days": s.staleAfterDays,
+		"count":            len(stale),
+		"connections":      connections,
+	})
+	return nil
+}
+
+func (s *ConnectionUsageService) getConnection(ctx context.Context, connectionID uuid.UUID) (*entity.Connection, error) {
+	return getConnection(ctx, s.pgRepo, connectionID)
+}
+
+// getConnection loads a connection, reporting a missing one as ErrConnectionNotFound
+func getConnection(ctx context.Context, repo *persistence.PostgresRepository, connectionID uuid.UUID) (*entity.Connection, error) {
+	conn, err := repo.GetConnection(ctx, connectionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrConnectionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	return conn, nil
+}
+
+// fillTrendDays returns one point per day from since, with zeros for days without scans
+func fillTrendDays(points []entity.ConnectionTrendPoint, since time.Time, days int) []entity.ConnectionTrendPoint {
+	byDay := make(map[time.Time]entity.ConnectionTrendPoint, len(points))
+	for _, p := range points {
+		byDay[p.Day] = p
+	}
+
+	filled := make([]entity.ConnectionTrendPoint, days)
+	for i := range filled {
+		day := since.AddDate(0, 0, i)
+		if p, ok := byDay[day]; ok {
+			filled[i] = p
+		} else {
+			filled[i] = entity.ConnectionTrendPoint{Day: day}
+		}
+	}
+	return filled
+}
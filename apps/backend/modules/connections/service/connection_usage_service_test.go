@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestFillTrendDays(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	points := []entity.ConnectionTrendPoint{
+		{Day: since, Scans: 1, Findings: 12, Critical: 2},
+		{Day: since.AddDate(0, 0, 2), Scans: 2, Findings: 5, High: 5},
+	}
+
+	filled := fillTrendDays(points, since, 4)
+	if len(filled) != 4 {
+		t.Fatalf("expected 4 days, got %d", len(filled))
+	}
+	for i, p := range filled {
+		if want := since.AddDate(0, 0, i); !p.Day.Equal(want) {
+			t.Errorf("day %d: expected %s, got %s", i, want, p.Day)
+		}
+	}
+	if filled[0].Findings != 12 || filled[2].Scans != 2 || filled[2].High != 5 {
+		t.Errorf("scanned days lost their counts: %+v", filled)
+	}
+	if filled[1].Scans != 0 || filled[3].Findings != 0 {
+		t.Errorf("days without scans should be zero: %+v", filled)
+	}
+}
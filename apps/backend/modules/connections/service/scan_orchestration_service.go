@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/google/uuid"
 )
@@ -63,7 +64,11 @@ func (s *ScanOrchestrationService) ScanAllAssets(ctx context.Context) (*ScanAllS
 	// This legacy "Scan All" flow will be replaced by Temporal workflows in Phase 3.
 
 	// Get all assets from database
-	assets, err := s.pgRepo.ListAssets(ctx, 10000, 0)
+	var assets []*entity.Asset
+	err := s.pgRepo.IterateAssets(ctx, func(page []*entity.Asset) error {
+		assets = append(assets, page...)
+		return nil
+	})
 	if err != nil {
 		s.mu.Unlock()
 		return nil, fmt.Errorf("failed to list assets: %w", err)
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/pkg/pathglob"
+)
+
+// ShouldScanPath applies a profile's include/exclude globs to a file path.
+// Excludes win over includes; an empty include list includes everything.
+func ShouldScanPath(profile *entity.ScanProfile, path string) bool {
+	path = strings.ReplaceAll(path, "\\", "/")
+
+	for _, glob := range profile.ExcludeGlobs {
+		if pathglob.Match(glob, path) {
+			return false
+		}
+	}
+
+	if len(profile.IncludeGlobs) == 0 {
+		return true
+	}
+	for _, glob := range profile.IncludeGlobs {
+		if pathglob.Match(glob, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldScanTable applies a profile's table allow/deny lists.
+// Entries may be "table" or "schema.table" and support "*" wildcards.
+func ShouldScanTable(profile *entity.ScanProfile, table string) bool {
+	table = strings.ToLower(table)
+
+	for _, pattern := range profile.TableDenyList {
+		if matchTable(pattern, table) {
+			return false
+		}
+	}
+
+	if len(profile.TableAllowList) == 0 {
+		return true
+	}
+	for _, pattern := range profile.TableAllowList {
+		if matchTable(pattern, table) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTable(pattern, table string) bool {
+	pattern = strings.ToLower(pattern)
+	if pathglob.Match(pattern, table) {
+		return true
+	}
+	// Unqualified patterns also match schema-qualified tables
+	if !strings.Contains(pattern, ".") {
+		if idx := strings.LastIndex(table, "."); idx >= 0 {
+			return pathglob.Match(pattern, table[idx+1:])
+		}
+	}
+	return false
+}
+
+// validateGlobs checks that every glob compiles
+func validateGlobs(field string, globs []string) error {
+	for _, glob := range globs {
+		if strings.TrimSpace(glob) == "" {
+			return fmt.Errorf("%s contains an empty pattern", field)
+		}
+		if _, err := pathglob.Compile(glob); err != nil {
+			return fmt.Errorf("%s contains invalid pattern %q: %w", field, glob, err)
+		}
+	}
+	return nil
+}
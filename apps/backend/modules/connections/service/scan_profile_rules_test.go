@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestShouldScanPath(t *testing.T) {
+	profile := &entity.ScanProfile{
+		IncludeGlobs: []string{"/data/**/*.csv", "/exports/*.json"},
+		ExcludeGlobs: []string{"**/tmp/**"},
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/data/customers.csv", true},
+		{"/data/2024/q1/customers.csv", true},
+		{"/data/tmp/customers.csv", false},
+		{"/exports/users.json", true},
+		{"/exports/nested/users.json", false},
+		{"/data/customers.txt", false},
+		{"C:\\data\\customers.csv", false},
+	}
+
+	for _, tt := range tests {
+		if got := ShouldScanPath(profile, tt.path); got != tt.want {
+			t.Errorf("ShouldScanPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestShouldScanPath_NoIncludes(t *testing.T) {
+	profile := &entity.ScanProfile{ExcludeGlobs: []string{"*.log"}}
+
+	if !ShouldScanPath(profile, "report.csv") {
+		t.Error("expected path to be included when no include globs are set")
+	}
+	if ShouldScanPath(profile, "app.log") {
+		t.Error("expected excluded path to be skipped")
+	}
+}
+
+func TestShouldScanTable(t *testing.T) {
+	profile := &entity.ScanProfile{
+		TableAllowList: []string{"public.*", "users"},
+		TableDenyList:  []string{"*_audit"},
+	}
+
+	tests := []struct {
+		table string
+		want  bool
+	}{
+		{"public.customers", true},
+		{"public.login_audit", false},
+		{"crm.users", true},
+		{"crm.orders", false},
+		{"Public.Customers", true},
+	}
+
+	for _, tt := range tests {
+		if got := ShouldScanTable(profile, tt.table); got != tt.want {
+			t.Errorf("ShouldScanTable(%q) = %v, want %v", tt.table, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	scanningservice "github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSampleSize = 100
+	maxSampleSize     = 100000
+)
+
+var (
+	// ErrScanProfileNotFound is returned for a profile that does not exist or belongs to another connection
+	ErrScanProfileNotFound = persistence.ErrScanProfileNotFound
+	// ErrScanProfileExists is returned when the connection already has a profile with the name
+	ErrScanProfileExists = persistence.ErrScanProfileExists
+	// ErrInvalidScanProfile wraps the reason profile input was rejected
+	ErrInvalidScanProfile = errors.New("invalid scan profile")
+)
+
+// ScanProfileService manages connection-scoped scan profiles
+type ScanProfileService struct {
+	pgRepo        *persistence.PostgresRepository
+	jurisdictions *scanningservice.JurisdictionService
+}
+
+// NewScanProfileService creates a new scan profile service
+func NewScanProfileService(pgRepo *persistence.PostgresRepository) *ScanProfileService {
+	return &ScanProfileService{
+		pgRepo:        pgRepo,
+		jurisdictions: scanningservice.NewJurisdictionService(pgRepo, nil),
+	}
+}
+
+// ScanProfileInput represents the editable fields of a scan profile
+type ScanProfileInput struct {
+	Name           string   `json:"name" binding:"required,min=1,max=100"`
+	Description    string   `json:"description"`
+	IncludeGlobs   []string `json:"include_globs"`
+	ExcludeGlobs   []string `json:"exclude_globs"`
+	TableAllowList []string `json:"table_allow_list"`
+	TableDenyList  []string `json:"table_deny_list"`
+	SampleSize     int      `json:"sample_size"`
+	PIITypes       []string `json:"pii_types"`
+	IsDefault      bool     `json:"is_default"`
+}
+
+// EffectiveScanConfig is the configuration the scanner SDK applies to a connection
+type EffectiveScanConfig struct {
+	ConnectionID   uuid.UUID  `json:"connection_id"`
+	SourceType     string     `json:"source_type"`
+	ConnectionName string     `json:"connection_name"`
+	ProfileID      *uuid.UUID `json:"profile_id,omitempty"`
+	ProfileName    string     `json:"profile_name"`
+	IncludeGlobs   []string   `json:"include_globs"`
+	ExcludeGlobs   []string   `json:"exclude_globs"`
+	TableAllowList []string   `json:"table_allow_list"`
+	TableDenyList  []string   `json:"table_deny_list"`
+	SampleSize     int        `json:"sample_size"`
+	PIITypes       []string   `json:"pii_types"`
+}
+
+// CreateProfile attaches a new scan profile to a connection
+func (s *ScanProfileService) CreateProfile(ctx context.Context, connectionID uuid.UUID, input ScanProfileInput, createdBy string) (*entity.ScanProfile, error) {
+	if _, err := getConnection(ctx, s.pgRepo, connectionID); err != nil {
+		return nil, err
+	}
+
+	profile := &entity.ScanProfile{
+		ID:           uuid.New(),
+		ConnectionID: connectionID,
+		CreatedBy:    createdBy,
+	}
+	if err := applyProfileInput(profile, input, s.jurisdictions.Resolve(ctx)); err != nil {
+		return nil, err
+	}
+
+	if err := s.pgRepo.CreateScanProfile(ctx, profile); err != nil {
+		if errors.Is(err, ErrScanProfileExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create scan profile: %w", err)
+	}
+	return profile, nil
+}
+
+// UpdateProfile replaces the rules of a connection's scan profile
+func (s *ScanProfileService) UpdateProfile(ctx context.Context, connectionID, profileID uuid.UUID, input ScanProfileInput) (*entity.ScanProfile, error) {
+	profile, err := s.getConnectionProfile(ctx, connectionID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyProfileInput(profile, input, s.jurisdictions.Resolve(ctx)); err != nil {
+		return nil, err
+	}
+
+	if err := s.pgRepo.UpdateScanProfile(ctx, profile); err != nil {
+		if errors.Is(err, ErrScanProfileNotFound) || errors.Is(err, ErrScanProfileExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update scan profile: %w", err)
+	}
+	return profile, nil
+}
+
+// GetProfile retrieves a connection's scan profile
+func (s *ScanProfileService) GetProfile(ctx context.Context, connectionID, profileID uuid.UUID) (*entity.ScanProfile, error) {
+	return s.getConnectionProfile(ctx, connectionID, profileID)
+}
+
+// ListProfiles lists the scan profiles of a connection
+func (s *ScanProfileService) ListProfiles(ctx context.Context, connectionID uuid.UUID) ([]*entity.ScanProfile, error) {
+	return s.pgRepo.ListScanProfilesByConnection(ctx, connectionID)
+}
+
+// DeleteProfile removes a connection's scan profile
+func (s *ScanProfileService) DeleteProfile(ctx context.Context, connectionID, profileID uuid.UUID) error {
+	if _, err := s.getConnectionProfile(ctx, connectionID, profileID); err != nil {
+		return err
+	}
+	return s.pgRepo.DeleteScanProfile(ctx, profileID)
+}
+
+// GetEffectiveConfig resolves the configuration the scanner should use for a connection.
+// The named profile is used when given, otherwise the connection's default profile;
+// without any profile the scanner gets permissive defaults.
+func (s *ScanProfileService) GetEffectiveConfig(ctx context.Context, connectionID uuid.UUID, profileName string) (*EffectiveScanConfig, error) {
+	conn, err := getConnection(ctx, s.pgRepo, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile *entity.ScanProfile
+	if profileName != "" {
+		profile, err = s.pgRepo.GetScanProfileByName(ctx, connectionID, profileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load scan profile: %w", err)
+		}
+		if profile == nil {
+			return nil, fmt.Errorf("%w: %s", ErrScanProfileNotFound, profileName)
+		}
+	} else {
+		profile, err = s.pgRepo.GetDefaultScanProfile(ctx, connectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load default scan profile: %w", err)
+		}
+	}
+
+	config := &EffectiveScanConfig{
+		ConnectionID:   conn.ID,
+		SourceType:     conn.SourceType,
+		ConnectionName: conn.ProfileName,
+		ProfileName:    "default",
+		IncludeGlobs:   []string{},
+		ExcludeGlobs:   []string{},
+		TableAllowList: []string{},
+		TableDenyList:  []string{},
+		SampleSize:     defaultSampleSize,
+		PIITypes:       s.jurisdictions.Resolve(ctx).SortedPIITypes(),
+	}
+
+	if profile != nil {
+		config.ProfileID = &profile.ID
+		config.ProfileName = profile.Name
+		config.IncludeGlobs = nonNil(profile.IncludeGlobs)
+		config.ExcludeGlobs = nonNil(profile.ExcludeGlobs)
+		config.TableAllowList = nonNil(profile.TableAllowList)
+		config.TableDenyList = nonNil(profile.TableDenyList)
+		config.SampleSize = profile.SampleSize
+		if len(profile.PIITypes) > 0 {
+			config.PIITypes = profile.PIITypes
+		}
+	}
+
+	return config, nil
+}
+
+func (s *ScanProfileService) getConnectionProfile(ctx context.Context, connectionID, profileID uuid.UUID) (*entity.ScanProfile, error) {
+	profile, err := s.pgRepo.GetScanProfile(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.ConnectionID != connectionID {
+		return nil, ErrScanProfileNotFound
+	}
+	return profile, nil
+}
+
+// applyProfileInput validates input and copies it onto the profile. Rejected
+// input is reported as ErrInvalidScanProfile.
+func applyProfileInput(profile *entity.ScanProfile, input ScanProfileInput, jurisdiction *entity.JurisdictionProfile) error {
+	if err := validateGlobs("include_globs", input.IncludeGlobs); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScanProfile, err)
+	}
+	if err := validateGlobs("exclude_globs", input.ExcludeGlobs); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScanProfile, err)
+	}
+
+	sampleSize := input.SampleSize
+	if sampleSize == 0 {
+		sampleSize = defaultSampleSize
+	}
+	if sampleSize < 1 || sampleSize > maxSampleSize {
+		return fmt.Errorf("%w: sample_size must be between 1 and %d", ErrInvalidScanProfile, maxSampleSize)
+	}
+
+	piiTypes := make([]string, 0, len(input.PIITypes))
+	for _, piiType := range input.PIITypes {
+		normalized := strings.ToUpper(strings.TrimSpace(piiType))
+		if !jurisdiction.Allows(normalized) {
+			return fmt.Errorf("%w: pii type %q is not in scope", ErrInvalidScanProfile, piiType)
+		}
+		piiTypes = append(piiTypes, normalized)
+	}
+
+	profile.Name = strings.TrimSpace(input.Name)
+	profile.Description = input.Description
+	profile.IncludeGlobs = nonNil(input.IncludeGlobs)
+	profile.ExcludeGlobs = nonNil(input.ExcludeGlobs)
+	profile.TableAllowList = nonNil(input.TableAllowList)
+	profile.TableDenyList = nonNil(input.TableDenyList)
+	profile.SampleSize = sampleSize
+	profile.PIITypes = piiTypes
+	profile.IsDefault = input.IsDefault
+	return nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestApplyProfileInputRejectsInvalidInput(t *testing.T) {
+	jurisdiction := entity.DefaultJurisdictionProfile()
+	for name, input := range map[string]ScanProfileInput{
+		"empty glob":        {Name: "nightly", IncludeGlobs: []string{" "}},
+		"sample size":       {Name: "nightly", SampleSize: maxSampleSize + 1},
+		"out of scope type": {Name: "nightly", PIITypes: []string{"US_SSN"}},
+	} {
+		if err := applyProfileInput(&entity.ScanProfile{}, input, jurisdiction); !errors.Is(err, ErrInvalidScanProfile) {
+			t.Errorf("%s: expected ErrInvalidScanProfile, got %v", name, err)
+		}
+	}
+
+	profile := &entity.ScanProfile{}
+	if err := applyProfileInput(profile, ScanProfileInput{Name: " nightly ", PIITypes: []string{"in_pan"}}, jurisdiction); err != nil {
+		t.Fatalf("applyProfileInput: %v", err)
+	}
+	if profile.Name != "nightly" || profile.SampleSize != defaultSampleSize || profile.PIITypes[0] != "IN_PAN" {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+}
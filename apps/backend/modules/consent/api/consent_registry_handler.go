@@ -0,0 +1,181 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/consent/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ConsentRegistryHandler handles consent policy, artifact and PII mapping requests
+type ConsentRegistryHandler struct {
+	service *service.ConsentRegistryService
+}
+
+// NewConsentRegistryHandler creates a new consent registry handler
+func NewConsentRegistryHandler(service *service.ConsentRegistryService) *ConsentRegistryHandler {
+	return &ConsentRegistryHandler{service: service}
+}
+
+// CreatePolicy handles POST /api/v1/consent/policies
+func (h *ConsentRegistryHandler) CreatePolicy(c *gin.Context) {
+	var input service.ConsentPolicyInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	policy, err := h.service.CreatePolicy(sharedapi.RequestContext(c), input, actor(c))
+	if err != nil {
+		c.JSON(statusForConsentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": policy})
+}
+
+// ListPolicies handles GET /api/v1/consent/policies
+func (h *ConsentRegistryHandler) ListPolicies(c *gin.Context) {
+	policies, err := h.service.ListPolicies(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list consent policies",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policies, "total": len(policies)})
+}
+
+// GetPolicy handles GET /api/v1/consent/policies/:id
+func (h *ConsentRegistryHandler) GetPolicy(c *gin.Context) {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy ID"})
+		return
+	}
+
+	policy, err := h.service.GetPolicy(sharedapi.RequestContext(c), policyID)
+	if err != nil {
+		c.JSON(statusForConsentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// AddArtifact handles POST /api/v1/consent/policies/:id/artifacts
+func (h *ConsentRegistryHandler) AddArtifact(c *gin.Context) {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid policy ID"})
+		return
+	}
+
+	var input service.ConsentArtifactInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	artifact, err := h.service.AddArtifact(sharedapi.RequestContext(c), policyID, input, actor(c))
+	if err != nil {
+		c.JSON(statusForConsentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": artifact})
+}
+
+// WithdrawArtifact handles POST /api/v1/consent/artifacts/:id/withdraw
+func (h *ConsentRegistryHandler) WithdrawArtifact(c *gin.Context) {
+	artifactID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid artifact ID"})
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	// The reason is optional, so an empty body is fine
+	_ = c.ShouldBindJSON(&req)
+
+	artifact, err := h.service.WithdrawArtifact(sharedapi.RequestContext(c), artifactID, req.Reason)
+	if err != nil {
+		c.JSON(statusForConsentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": artifact})
+}
+
+// ListPIIMappings handles GET /api/v1/consent/pii-mappings
+func (h *ConsentRegistryHandler) ListPIIMappings(c *gin.Context) {
+	mappings, err := h.service.ListPIIMappings(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list PII consent mappings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mappings})
+}
+
+// SetPIIMappings handles PUT /api/v1/consent/pii-mappings/:piiType
+func (h *ConsentRegistryHandler) SetPIIMappings(c *gin.Context) {
+	var req struct {
+		PolicyIDs []uuid.UUID `json:"policy_ids"`
+	}
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	mapping, err := h.service.SetPIIMappings(sharedapi.RequestContext(c), c.Param("piiType"), req.PolicyIDs, actor(c))
+	if err != nil {
+		c.JSON(statusForConsentError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mapping})
+}
+
+// GetConsentGaps handles GET /api/v1/compliance/consent-gaps
+func (h *ConsentRegistryHandler) GetConsentGaps(c *gin.Context) {
+	report, err := h.service.GetConsentGaps(sharedapi.RequestContext(c), c.Query("pii_type"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build consent gap report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+func actor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func statusForConsentError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"),
+		strings.Contains(msg, "not in scope"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
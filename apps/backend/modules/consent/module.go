@@ -0,0 +1,62 @@
+package consent
+
+import (
+	"log"
+
+	"github.com/arc-platform/backend/modules/consent/api"
+	"github.com/arc-platform/backend/modules/consent/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// ConsentModule keeps the registry of consent policies and artifacts and maps
+// PII categories onto them
+type ConsentModule struct {
+	registryService *service.ConsentRegistryService
+	registryHandler *api.ConsentRegistryHandler
+
+	deps *interfaces.ModuleDependencies
+}
+
+// NewConsentModule creates a new consent module
+func NewConsentModule() *ConsentModule {
+	return &ConsentModule{}
+}
+
+// Name returns the module name
+func (m *ConsentModule) Name() string {
+	return "consent"
+}
+
+// Initialize sets up the module
+func (m *ConsentModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("📜 Initializing Consent Module...")
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.registryService = service.NewConsentRegistryService(repo, deps.AuditLogger)
+	m.registryHandler = api.NewConsentRegistryHandler(m.registryService)
+
+	log.Println("✅ Consent Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *ConsentModule) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/consent/policies", m.registryHandler.CreatePolicy)
+	router.GET("/consent/policies", m.registryHandler.ListPolicies)
+	router.GET("/consent/policies/:id", m.registryHandler.GetPolicy)
+	router.POST("/consent/policies/:id/artifacts", m.registryHandler.AddArtifact)
+	router.POST("/consent/artifacts/:id/withdraw", m.registryHandler.WithdrawArtifact)
+	router.GET("/consent/pii-mappings", m.registryHandler.ListPIIMappings)
+	router.PUT("/consent/pii-mappings/:piiType", m.registryHandler.SetPIIMappings)
+	router.GET("/compliance/consent-gaps", m.registryHandler.GetConsentGaps)
+	log.Printf("📜 Consent routes registered")
+}
+
+// Shutdown cleans up resources
+func (m *ConsentModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Consent Module...")
+	return nil
+}
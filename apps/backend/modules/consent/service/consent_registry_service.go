@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	scanningservice "github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// ConsentRegistryService manages consent policies, the artifacts backing them and
+// the mapping of PII categories onto them
+type ConsentRegistryService struct {
+	repo          *persistence.PostgresRepository
+	auditLogger   interfaces.AuditLogger
+	jurisdictions *scanningservice.JurisdictionService
+}
+
+// NewConsentRegistryService creates a new consent registry service
+func NewConsentRegistryService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *ConsentRegistryService {
+	return &ConsentRegistryService{
+		repo:          repo,
+		auditLogger:   auditLogger,
+		jurisdictions: scanningservice.NewJurisdictionService(repo, nil),
+	}
+}
+
+// ConsentPolicyInput is the payload for registering a consent policy
+type ConsentPolicyInput struct {
+	PrincipalCategory string `json:"principal_category" binding:"required"`
+	Purpose           string `json:"purpose" binding:"required"`
+	LawfulBasis       string `json:"lawful_basis" binding:"required"`
+	Description       string `json:"description"`
+}
+
+// ConsentArtifactInput is the payload for attaching an artifact to a consent policy
+type ConsentArtifactInput struct {
+	ArtifactType string                 `json:"artifact_type" binding:"required"`
+	Reference    string                 `json:"reference" binding:"required"`
+	Version      string                 `json:"version"`
+	CollectedAt  *time.Time             `json:"collected_at,omitempty"`
+	ExpiresAt    *time.Time             `json:"expires_at,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// ConsentGapReport lists PII stores lacking a valid mapped consent basis
+type ConsentGapReport struct {
+	Gaps             []*entity.ConsentBasisGap `json:"gaps"`
+	TotalAssets      int                       `json:"total_assets"`
+	TotalFindings    int                       `json:"total_findings"`
+	UnmappedPIITypes []string                  `json:"unmapped_pii_types"`
+}
+
+// CreatePolicy registers a consent policy for a data principal category and purpose
+func (s *ConsentRegistryService) CreatePolicy(ctx context.Context, input ConsentPolicyInput, createdBy string) (*entity.ConsentPolicy, error) {
+	policy := &entity.ConsentPolicy{
+		ID:                uuid.New(),
+		PrincipalCategory: strings.ToLower(strings.TrimSpace(input.PrincipalCategory)),
+		Purpose:           strings.TrimSpace(input.Purpose),
+		LawfulBasis:       strings.ToLower(strings.TrimSpace(input.LawfulBasis)),
+		Description:       input.Description,
+		IsActive:          true,
+		PIITypes:          []string{},
+		CreatedBy:         createdBy,
+	}
+
+	if policy.PrincipalCategory == "" || policy.Purpose == "" {
+		return nil, fmt.Errorf("principal_category and purpose must be set")
+	}
+	if !isLawfulBasis(policy.LawfulBasis) {
+		return nil, fmt.Errorf("invalid lawful_basis %q: must be one of %s", input.LawfulBasis, strings.Join(entity.LawfulBases, ", "))
+	}
+
+	if err := s.repo.CreateConsentPolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+	// Explicit consent only becomes a valid basis once an artifact is attached
+	policy.IsValid = policy.LawfulBasis != entity.LawfulBasisExplicit
+
+	s.record(ctx, "CONSENT_POLICY_CREATED", "consent_policy", policy.ID.String(), map[string]interface{}{
+		"principal_category": policy.PrincipalCategory,
+		"purpose":            policy.Purpose,
+		"lawful_basis":       policy.LawfulBasis,
+	})
+
+	return policy, nil
+}
+
+// GetPolicy returns a consent policy with its artifacts and mapped PII types
+func (s *ConsentRegistryService) GetPolicy(ctx context.Context, id uuid.UUID) (*entity.ConsentPolicy, error) {
+	return s.repo.GetConsentPolicy(ctx, id)
+}
+
+// ListPolicies returns all consent policies of the tenant
+func (s *ConsentRegistryService) ListPolicies(ctx context.Context) ([]*entity.ConsentPolicy, error) {
+	return s.repo.ListConsentPolicies(ctx)
+}
+
+// AddArtifact attaches consent evidence to a policy
+func (s *ConsentRegistryService) AddArtifact(ctx context.Context, policyID uuid.UUID, input ConsentArtifactInput, createdBy string) (*entity.ConsentArtifact, error) {
+	artifact := &entity.ConsentArtifact{
+		ID:           uuid.New(),
+		PolicyID:     policyID,
+		ArtifactType: strings.ToLower(strings.TrimSpace(input.ArtifactType)),
+		Reference:    strings.TrimSpace(input.Reference),
+		Version:      strings.TrimSpace(input.Version),
+		CollectedAt:  time.Now().UTC(),
+		ExpiresAt:    input.ExpiresAt,
+		Metadata:     input.Metadata,
+		CreatedBy:    createdBy,
+	}
+	if input.CollectedAt != nil {
+		artifact.CollectedAt = *input.CollectedAt
+	}
+	if artifact.Version == "" {
+		artifact.Version = "1"
+	}
+
+	if artifact.ArtifactType == "" || artifact.Reference == "" {
+		return nil, fmt.Errorf("artifact_type and reference must be set")
+	}
+	if artifact.ExpiresAt != nil && !artifact.ExpiresAt.After(artifact.CollectedAt) {
+		return nil, fmt.Errorf("expires_at must be after collected_at")
+	}
+
+	if err := s.repo.CreateConsentArtifact(ctx, artifact); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "CONSENT_ARTIFACT_ADDED", "consent_policy", policyID.String(), map[string]interface{}{
+		"artifact_id":   artifact.ID.String(),
+		"artifact_type": artifact.ArtifactType,
+		"reference":     artifact.Reference,
+		"version":       artifact.Version,
+	})
+
+	return artifact, nil
+}
+
+// WithdrawArtifact withdraws consent evidence so it no longer backs its policy
+func (s *ConsentRegistryService) WithdrawArtifact(ctx context.Context, artifactID uuid.UUID, reason string) (*entity.ConsentArtifact, error) {
+	artifact, err := s.repo.WithdrawConsentArtifact(ctx, artifactID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "CONSENT_ARTIFACT_WITHDRAWN", "consent_policy", artifact.PolicyID.String(), map[string]interface{}{
+		"artifact_id": artifactID.String(),
+		"reason":      reason,
+	})
+
+	return artifact, nil
+}
+
+// SetPIIMappings links a PII category to the consent policies that cover it,
+// replacing any previous links
+func (s *ConsentRegistryService) SetPIIMappings(ctx context.Context, piiType string, policyIDs []uuid.UUID, createdBy string) (*entity.PIIConsentMapping, error) {
+	piiType = strings.ToUpper(strings.TrimSpace(piiType))
+	if !s.jurisdictions.IsAllowedPIIType(ctx, piiType) {
+		return nil, fmt.Errorf("pii type %s is not in scope", piiType)
+	}
+
+	policyIDs = uniqueIDs(policyIDs)
+	if err := s.repo.SetPIIConsentMappings(ctx, piiType, policyIDs, createdBy); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "PII_CONSENT_MAPPED", "pii_type", piiType, map[string]interface{}{
+		"policy_ids": policyIDs,
+	})
+
+	mappings, err := s.repo.ListPIIConsentMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		if mapping.PIIType == piiType {
+			return mapping, nil
+		}
+	}
+	return &entity.PIIConsentMapping{PIIType: piiType, PolicyIDs: []uuid.UUID{}}, nil
+}
+
+// ListPIIMappings returns the consent policies mapped to each PII category
+func (s *ConsentRegistryService) ListPIIMappings(ctx context.Context) ([]*entity.PIIConsentMapping, error) {
+	return s.repo.ListPIIConsentMappings(ctx)
+}
+
+// GetConsentGaps reports PII stores holding consent-requiring PII without a valid
+// mapped consent basis
+func (s *ConsentRegistryService) GetConsentGaps(ctx context.Context, piiType string) (*ConsentGapReport, error) {
+	gaps, err := s.repo.ListConsentBasisGaps(ctx, strings.ToUpper(strings.TrimSpace(piiType)))
+	if err != nil {
+		return nil, err
+	}
+	return summarizeGaps(gaps), nil
+}
+
+// summarizeGaps totals a gap list by asset, finding and unmapped PII type
+func summarizeGaps(gaps []*entity.ConsentBasisGap) *ConsentGapReport {
+	report := &ConsentGapReport{Gaps: gaps, UnmappedPIITypes: []string{}}
+
+	assets := make(map[uuid.UUID]bool)
+	unmapped := make(map[string]bool)
+	for _, gap := range gaps {
+		assets[gap.AssetID] = true
+		report.TotalFindings += gap.FindingCount
+		if gap.Reason == "UNMAPPED" && !unmapped[gap.PIIType] {
+			unmapped[gap.PIIType] = true
+			report.UnmappedPIITypes = append(report.UnmappedPIITypes, gap.PIIType)
+		}
+	}
+	report.TotalAssets = len(assets)
+
+	return report
+}
+
+func (s *ConsentRegistryService) record(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) {
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, resourceType, resourceID, metadata)
+	}
+}
+
+func isLawfulBasis(basis string) bool {
+	for _, b := range entity.LawfulBases {
+		if basis == b {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
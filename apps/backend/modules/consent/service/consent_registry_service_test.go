@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestSummarizeGaps(t *testing.T) {
+	assetA, assetB := uuid.New(), uuid.New()
+	gaps := []*entity.ConsentBasisGap{
+		{AssetID: assetA, PIIType: "IN_AADHAAR", FindingCount: 4, Reason: "UNMAPPED"},
+		{AssetID: assetA, PIIType: "IN_PAN", FindingCount: 2, Reason: "NO_VALID_BASIS"},
+		{AssetID: assetB, PIIType: "IN_AADHAAR", FindingCount: 1, Reason: "UNMAPPED"},
+	}
+
+	report := summarizeGaps(gaps)
+
+	if report.TotalAssets != 2 {
+		t.Errorf("expected 2 assets, got %d", report.TotalAssets)
+	}
+	if report.TotalFindings != 7 {
+		t.Errorf("expected 7 findings, got %d", report.TotalFindings)
+	}
+	if len(report.UnmappedPIITypes) != 1 || report.UnmappedPIITypes[0] != "IN_AADHAAR" {
+		t.Errorf("expected only IN_AADHAAR to be unmapped, got %v", report.UnmappedPIITypes)
+	}
+
+	empty := summarizeGaps(nil)
+	if empty.UnmappedPIITypes == nil || empty.TotalAssets != 0 {
+		t.Errorf("expected an empty report with a non-nil type list, got %+v", empty)
+	}
+}
+
+func TestArtifactIsLive(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	cases := []struct {
+		name     string
+		artifact entity.ConsentArtifact
+		want     bool
+	}{
+		{"no expiry", entity.ConsentArtifact{}, true},
+		{"expires later", entity.ConsentArtifact{ExpiresAt: &future}, true},
+		{"expired", entity.ConsentArtifact{ExpiresAt: &past}, false},
+		{"withdrawn", entity.ConsentArtifact{ExpiresAt: &future, WithdrawnAt: &past}, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.artifact.IsLive(now); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
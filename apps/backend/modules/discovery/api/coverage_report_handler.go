@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/discovery/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxCoverageGapLimit bounds the gaps listed in one report
+const maxCoverageGapLimit = 1000
+
+// CoverageReportHandler serves the per-connection scan coverage report
+type CoverageReportHandler struct {
+	service *service.CoverageReportService
+}
+
+// NewCoverageReportHandler creates a new coverage report handler
+func NewCoverageReportHandler(service *service.CoverageReportService) *CoverageReportHandler {
+	return &CoverageReportHandler{service: service}
+}
+
+// GetCoverageReport handles GET /api/v1/reports/coverage. With format=csv it exports
+// the per-connection rows, or the high-risk gaps with section=gaps.
+func (h *CoverageReportHandler) GetCoverageReport(c *gin.Context) {
+	opts := service.CoverageReportOptions{
+		ConnectionID: c.Query("connection_id"),
+		MinRisk:      service.DefaultHighRiskThreshold,
+		GapLimit:     100,
+	}
+	if opts.ConnectionID != "" {
+		if _, err := uuid.Parse(opts.ConnectionID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection_id"})
+			return
+		}
+	}
+	if v := c.Query("min_risk"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 100 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_risk must be between 0 and 100"})
+			return
+		}
+		opts.MinRisk = n
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxCoverageGapLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", maxCoverageGapLimit)})
+			return
+		}
+		opts.GapLimit = n
+	}
+
+	format := c.DefaultQuery("format", "json")
+	section := c.DefaultQuery("section", "connections")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+	if section != "connections" && section != "gaps" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "section must be connections or gaps"})
+		return
+	}
+	// An export of gaps should not be cut at the JSON page size
+	if format == "csv" && section == "gaps" && c.Query("limit") == "" {
+		opts.GapLimit = 0
+	}
+
+	report, err := h.service.GenerateReport(sharedapi.RequestContext(c), opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build coverage report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, gin.H{"data": report})
+		return
+	}
+
+	filename := fmt.Sprintf("coverage-%s-%s.csv", section, report.GeneratedAt.Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	if section == "gaps" {
+		writeGapRows(w, report)
+	} else {
+		writeConnectionRows(w, report)
+	}
+	w.Flush()
+}
+
+func writeConnectionRows(w *csv.Writer, report *service.ScanCoverageReport) {
+	_ = w.Write([]string{
+		"connection_id", "profile_name", "source_type", "last_scan_at",
+		"tables_discovered", "tables_scanned", "tables_with_findings",
+		"files_enumerated", "files_scanned", "files_with_findings",
+		"never_scanned", "high_risk_gaps", "coverage_percent",
+	})
+	for _, c := range report.Connections {
+		lastScan := ""
+		if c.LastScanAt != nil {
+			lastScan = c.LastScanAt.UTC().Format(time.RFC3339)
+		}
+		_ = w.Write([]string{
+			c.ConnectionID.String(), c.ProfileName, c.SourceType, lastScan,
+			strconv.Itoa(c.TablesDiscovered), strconv.Itoa(c.TablesScanned), strconv.Itoa(c.TablesWithFindings),
+			strconv.Itoa(c.FilesEnumerated), strconv.Itoa(c.FilesScanned), strconv.Itoa(c.FilesWithFindings),
+			strconv.Itoa(c.NeverScanned), strconv.Itoa(c.HighRiskGaps),
+			strconv.FormatFloat(c.CoveragePercent, 'f', 1, 64),
+		})
+	}
+}
+
+func writeGapRows(w *csv.Writer, report *service.ScanCoverageReport) {
+	_ = w.Write([]string{
+		"connection_id", "profile_name", "source_type", "kind", "location",
+		"row_count", "size_bytes", "risk_score", "risk_reasons",
+	})
+	for _, g := range report.HighRiskGaps {
+		_ = w.Write([]string{
+			g.ConnectionID, g.ProfileName, g.SourceType, g.Kind, g.Location,
+			strconv.FormatInt(g.RowCount, 10), strconv.FormatInt(g.SizeBytes, 10),
+			strconv.Itoa(g.RiskScore), strings.Join(g.RiskReasons, "; "),
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/discovery/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DiscoveryHandler handles schema discovery and coverage requests
+type DiscoveryHandler struct {
+	service *service.DiscoveryService
+}
+
+// NewDiscoveryHandler creates a new discovery handler
+func NewDiscoveryHandler(service *service.DiscoveryService) *DiscoveryHandler {
+	return &DiscoveryHandler{service: service}
+}
+
+// DiscoverConnection handles POST /api/v1/discovery/connections/:id
+func (h *DiscoveryHandler) DiscoverConnection(c *gin.Context) {
+	connectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection ID"})
+		return
+	}
+
+	result, err := h.service.DiscoverConnection(sharedapi.RequestContext(c), connectionID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case strings.HasPrefix(err.Error(), "connection not found"):
+			status = http.StatusNotFound
+		case strings.HasPrefix(err.Error(), "schema discovery is not supported"):
+			status = http.StatusBadRequest
+		case strings.HasPrefix(err.Error(), "schema discovery failed"):
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, gin.H{
+			"error":   "Failed to discover connection schema",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// GetCoverage handles GET /api/v1/coverage
+func (h *DiscoveryHandler) GetCoverage(c *gin.Context) {
+	connectionID := c.Query("connection_id")
+	if connectionID != "" {
+		if _, err := uuid.Parse(connectionID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid connection_id"})
+			return
+		}
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", entity.CoverageScanned, entity.CoverageNoFindings, entity.CoverageNeverScanned:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	report, err := h.service.GetCoverage(sharedapi.RequestContext(c), connectionID, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to build coverage report",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
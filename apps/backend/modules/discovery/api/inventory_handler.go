@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/discovery/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// InventoryHandler handles cloud inventory imports
+type InventoryHandler struct {
+	service *service.InventoryService
+}
+
+// NewInventoryHandler creates a new inventory handler
+func NewInventoryHandler(service *service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{service: service}
+}
+
+// ListSystems handles GET /api/v1/discovery/inventory
+// Query: provider (aws or azure)
+func (h *InventoryHandler) ListSystems(c *gin.Context) {
+	systems, err := h.service.ListSystems(sharedapi.RequestContext(c), c.Query("provider"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list inventory systems", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": systems, "providers": h.service.Providers()})
+}
+
+// ImportInventory handles POST /api/v1/discovery/inventory/:provider/import
+func (h *InventoryHandler) ImportInventory(c *gin.Context) {
+	result, err := h.service.ImportInventory(sharedapi.RequestContext(c), c.Param("provider"), c.GetString("user_email"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrUnknownInventoryProvider):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrInventoryProviderNotConfigured):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrInventoryListFailed):
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, gin.H{"error": "Failed to import inventory", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
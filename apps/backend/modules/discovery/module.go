@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/discovery/api"
+	"github.com/arc-platform/backend/modules/discovery/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// DiscoveryModule crawls database schemas and file stores of stored connections,
+// imports cloud inventories and reports scan coverage
+type DiscoveryModule struct {
+	discoveryService *service.DiscoveryService
+	discoveryHandler *api.DiscoveryHandler
+	reportHandler    *api.CoverageReportHandler
+	inventoryHandler *api.InventoryHandler
+
+	authMiddleware *middleware.AuthMiddleware
+
+	deps *interfaces.ModuleDependencies
+}
+
+// NewDiscoveryModule creates a new discovery module
+func NewDiscoveryModule() *DiscoveryModule {
+	return &DiscoveryModule{}
+}
+
+// Name returns the module name
+func (m *DiscoveryModule) Name() string {
+	return "discovery"
+}
+
+// Initialize sets up the module
+func (m *DiscoveryModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("🔎 Initializing Discovery Module...")
+
+	if deps.AssetManager == nil {
+		return fmt.Errorf("discovery module requires an AssetManager")
+	}
+
+	// Stored connection configs are encrypted
+	encryptionService, err := encryption.NewEncryptionService()
+	if err != nil {
+		return fmt.Errorf("failed to initialize encryption service: %w", err)
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.discoveryService = service.NewDiscoveryService(repo, encryptionService, deps.AssetManager, deps.AuditLogger)
+	m.discoveryHandler = api.NewDiscoveryHandler(m.discoveryService)
+	m.reportHandler = api.NewCoverageReportHandler(service.NewCoverageReportService(repo))
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	// Cloud inventories map asset hosts to the instances and databases behind them
+	var inventoryCfg config.InventoryConfig
+	if deps.Config != nil {
+		inventoryCfg = deps.Config.Inventory
+	}
+	m.inventoryHandler = api.NewInventoryHandler(service.NewInventoryService(repo, inventoryCfg, deps.LineageSync, deps.AuditLogger))
+
+	log.Println("✅ Discovery Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *DiscoveryModule) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/discovery/connections/:id", m.discoveryHandler.DiscoverConnection)
+	router.GET("/discovery/inventory", m.inventoryHandler.ListSystems)
+	router.POST("/discovery/inventory/:provider/import", m.authMiddleware.RequireRole("admin"), m.inventoryHandler.ImportInventory)
+	router.GET("/coverage", m.discoveryHandler.GetCoverage)
+	router.GET("/reports/coverage", m.reportHandler.GetCoverageReport)
+	log.Printf("🔎 Discovery routes registered")
+}
+
+// Shutdown cleans up resources
+func (m *DiscoveryModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Discovery Module...")
+	return nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+// DefaultHighRiskThreshold is the risk score from which a never-scanned location
+// is reported as a high-risk gap
+const DefaultHighRiskThreshold = 50
+
+// Risk heuristics for never-scanned locations. They only look at names, sizes and
+// column lists that discovery already holds; no data is read.
+var (
+	sensitiveColumnTokens = tokenSet("email", "mail", "phone", "mobile", "msisdn", "ssn", "aadhaar", "aadhar",
+		"pan", "passport", "dob", "birth", "birthdate", "address", "card", "iban", "ifsc", "account",
+		"salary", "password", "tax", "voter", "license", "upi")
+	sensitiveNameTokens = tokenSet("customer", "customers", "user", "users", "employee", "employees", "patient",
+		"patients", "payment", "payments", "kyc", "member", "members", "account", "accounts", "payroll",
+		"hr", "billing", "pii", "personal", "backup", "backups", "dump", "export", "exports", "statement",
+		"statements", "invoice", "invoices", "aadhaar", "passport")
+	dataFileExtensions = tokenSet(".csv", ".tsv", ".xls", ".xlsx", ".json", ".jsonl", ".parquet", ".avro",
+		".sql", ".db", ".sqlite", ".bak", ".dump", ".pdf", ".doc", ".docx", ".txt", ".log")
+)
+
+// CoverageReportService compares discovered tables and files with scan results per connection
+type CoverageReportService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewCoverageReportService creates a new coverage report service
+func NewCoverageReportService(repo *persistence.PostgresRepository) *CoverageReportService {
+	return &CoverageReportService{repo: repo}
+}
+
+// CoverageReportOptions narrows a coverage report
+type CoverageReportOptions struct {
+	ConnectionID string // Empty for every connection
+	MinRisk      int    // Risk score from which a never-scanned location is a high-risk gap
+	GapLimit     int    // Maximum number of high-risk gaps listed; 0 lists all
+}
+
+// ScanCoverageReport is the per-connection coverage report
+type ScanCoverageReport struct {
+	GeneratedAt  time.Time                    `json:"generated_at"`
+	Connections  []*entity.ConnectionCoverage `json:"connections"`
+	HighRiskGaps []*entity.CoverageGap        `json:"high_risk_gaps"`
+	TotalGaps    int                          `json:"total_high_risk_gaps"`
+}
+
+// GenerateReport computes coverage for each connection and ranks its never-scanned
+// locations by risk. Connections that were never discovered are listed with zero
+// counts so missing discovery is visible too.
+func (s *CoverageReportService) GenerateReport(ctx context.Context, opts CoverageReportOptions) (*ScanCoverageReport, error) {
+	connections, err := s.repo.ListConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections: %w", err)
+	}
+	tables, err := s.repo.ListTableCoverage(ctx, opts.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	files, err := s.repo.ListFileCoverage(ctx, opts.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ScanCoverageReport{
+		GeneratedAt:  time.Now().UTC(),
+		Connections:  []*entity.ConnectionCoverage{},
+		HighRiskGaps: []*entity.CoverageGap{},
+	}
+
+	byID := make(map[string]*entity.ConnectionCoverage)
+	for _, conn := range connections {
+		if opts.ConnectionID != "" && conn.ID.String() != opts.ConnectionID {
+			continue
+		}
+		coverage := &entity.ConnectionCoverage{
+			ConnectionID: conn.ID,
+			ProfileName:  conn.ProfileName,
+			SourceType:   conn.SourceType,
+		}
+		byID[conn.ID.String()] = coverage
+		report.Connections = append(report.Connections, coverage)
+	}
+
+	var gaps []*entity.CoverageGap
+	for _, table := range tables {
+		coverage := byID[table.ConnectionID]
+		if coverage == nil {
+			continue
+		}
+		table.Status = coverageStatus(table)
+		coverage.TablesDiscovered++
+		coverage.LastScanAt = laterOf(coverage.LastScanAt, table.LastScanAt)
+		switch table.Status {
+		case entity.CoverageScanned:
+			coverage.TablesScanned++
+			coverage.TablesWithFindings++
+		case entity.CoverageNoFindings:
+			coverage.TablesScanned++
+		default:
+			coverage.NeverScanned++
+			if gap := tableGap(coverage, table); gap.RiskScore >= opts.MinRisk {
+				coverage.HighRiskGaps++
+				gaps = append(gaps, gap)
+			}
+		}
+	}
+
+	for _, file := range files {
+		coverage := byID[file.ConnectionID]
+		if coverage == nil {
+			continue
+		}
+		file.Status = locationCoverageStatus(file.FindingCount, file.LastScanAt)
+		coverage.FilesEnumerated++
+		coverage.LastScanAt = laterOf(coverage.LastScanAt, file.LastScanAt)
+		switch file.Status {
+		case entity.CoverageScanned:
+			coverage.FilesScanned++
+			coverage.FilesWithFindings++
+		case entity.CoverageNoFindings:
+			coverage.FilesScanned++
+		default:
+			coverage.NeverScanned++
+			if gap := fileGap(coverage, file); gap.RiskScore >= opts.MinRisk {
+				coverage.HighRiskGaps++
+				gaps = append(gaps, gap)
+			}
+		}
+	}
+
+	for _, coverage := range report.Connections {
+		coverage.CoveragePercent = coveragePercent(coverage)
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool {
+		if gaps[i].RiskScore != gaps[j].RiskScore {
+			return gaps[i].RiskScore > gaps[j].RiskScore
+		}
+		if gaps[i].ProfileName != gaps[j].ProfileName {
+			return gaps[i].ProfileName < gaps[j].ProfileName
+		}
+		return gaps[i].Location < gaps[j].Location
+	})
+	report.TotalGaps = len(gaps)
+	if opts.GapLimit > 0 && len(gaps) > opts.GapLimit {
+		gaps = gaps[:opts.GapLimit]
+	}
+	report.HighRiskGaps = append(report.HighRiskGaps, gaps...)
+
+	return report, nil
+}
+
+// coveragePercent is the share of discovered tables and files that scans have
+// reached, to one decimal place
+func coveragePercent(c *entity.ConnectionCoverage) float64 {
+	discovered := c.TablesDiscovered + c.FilesEnumerated
+	if discovered == 0 {
+		return 0
+	}
+	scanned := c.TablesScanned + c.FilesScanned
+	return math.Round(float64(scanned)/float64(discovered)*1000) / 10
+}
+
+// tableGap scores a never-scanned table on its sensitive-looking columns, its
+// name and its estimated size
+func tableGap(c *entity.ConnectionCoverage, table *entity.TableCoverage) *entity.CoverageGap {
+	gap := newGap(c, entity.AssetTypeTable, table.Path)
+	gap.RowCount = table.RowCount
+
+	var sensitive []string
+	for _, column := range table.ColumnNames {
+		if hasToken(column, sensitiveColumnTokens) {
+			sensitive = append(sensitive, column)
+		}
+	}
+	if len(sensitive) > 0 {
+		gap.RiskScore += min(15*len(sensitive), 45)
+		gap.RiskReasons = append(gap.RiskReasons, "sensitive columns: "+strings.Join(sensitive, ", "))
+	}
+	if hasToken(table.Path, sensitiveNameTokens) {
+		gap.RiskScore += 20
+		gap.RiskReasons = append(gap.RiskReasons, "sensitive table name")
+	}
+	switch {
+	case table.RowCount >= 1_000_000:
+		gap.RiskScore += 25
+		gap.RiskReasons = append(gap.RiskReasons, "over 1M rows")
+	case table.RowCount >= 100_000:
+		gap.RiskScore += 15
+		gap.RiskReasons = append(gap.RiskReasons, "over 100k rows")
+	}
+
+	gap.RiskScore = min(gap.RiskScore, 100)
+	return gap
+}
+
+// fileGap scores a never-scanned file on its type, its path and its size
+func fileGap(c *entity.ConnectionCoverage, file *entity.FileCoverage) *entity.CoverageGap {
+	gap := newGap(c, entity.AssetTypeFile, file.Path)
+	gap.SizeBytes = file.SizeBytes
+
+	if ext := strings.ToLower(path.Ext(file.Path)); dataFileExtensions[ext] {
+		gap.RiskScore += 25
+		gap.RiskReasons = append(gap.RiskReasons, "data-bearing file type "+ext)
+	}
+	if hasToken(file.Path, sensitiveNameTokens) {
+		gap.RiskScore += 30
+		gap.RiskReasons = append(gap.RiskReasons, "sensitive path")
+	}
+	if hasToken(path.Base(file.Path), sensitiveColumnTokens) {
+		gap.RiskScore += 15
+		gap.RiskReasons = append(gap.RiskReasons, "personal data in file name")
+	}
+	switch {
+	case file.SizeBytes >= 100<<20:
+		gap.RiskScore += 20
+		gap.RiskReasons = append(gap.RiskReasons, "over 100 MB")
+	case file.SizeBytes >= 10<<20:
+		gap.RiskScore += 10
+		gap.RiskReasons = append(gap.RiskReasons, "over 10 MB")
+	}
+
+	gap.RiskScore = min(gap.RiskScore, 100)
+	return gap
+}
+
+func newGap(c *entity.ConnectionCoverage, kind, location string) *entity.CoverageGap {
+	return &entity.CoverageGap{
+		ConnectionID: c.ConnectionID.String(),
+		ProfileName:  c.ProfileName,
+		SourceType:   c.SourceType,
+		Kind:         kind,
+		Location:     location,
+		RiskReasons:  []string{},
+	}
+}
+
+// hasToken reports whether any word of name, split on anything but letters and
+// digits, is in the set
+func hasToken(name string, tokens map[string]bool) bool {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	for _, word := range words {
+		if tokens[word] {
+			return true
+		}
+	}
+	return false
+}
+
+func tokenSet(tokens ...string) map[string]bool {
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[t] = true
+	}
+	return set
+}
+
+func laterOf(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// discoveryTimeout bounds a single crawl of a source database
+const discoveryTimeout = 2 * time.Minute
+
+// DiscoveryService crawls database schemas of stored connections into assets and
+// reports which discovered tables scans have not covered
+type DiscoveryService struct {
+	repo         *persistence.PostgresRepository
+	encryption   *encryption.EncryptionService
+	assetManager interfaces.AssetManager
+	auditLogger  interfaces.AuditLogger
+}
+
+// NewDiscoveryService creates a new discovery service
+func NewDiscoveryService(repo *persistence.PostgresRepository, enc *encryption.EncryptionService, assetManager interfaces.AssetManager, auditLogger interfaces.AuditLogger) *DiscoveryService {
+	return &DiscoveryService{
+		repo:         repo,
+		encryption:   enc,
+		assetManager: assetManager,
+		auditLogger:  auditLogger,
+	}
+}
+
+// CoverageReport lists discovered tables with their scan coverage
+type CoverageReport struct {
+	Summary entity.CoverageSummary  `json:"summary"`
+	Tables  []*entity.TableCoverage `json:"tables"`
+}
+
+// DiscoverConnection enumerates the schemas, tables and columns behind a stored
+// PostgreSQL or MySQL connection and records every table as an asset. For S3 and
+// filesystem connections it enumerates files into the connection's file inventory.
+func (s *DiscoveryService) DiscoverConnection(ctx context.Context, connectionID uuid.UUID) (*entity.DiscoveryResult, error) {
+	conn, err := s.repo.GetConnection(ctx, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("connection not found: %w", err)
+	}
+
+	var config map[string]interface{}
+	if err := s.encryption.Decrypt(conn.ConfigEncrypted, &config); err != nil {
+		return nil, fmt.Errorf("failed to decrypt config: %w", err)
+	}
+
+	crawlCtx, cancel := context.WithTimeout(ctx, discoveryTimeout)
+	defer cancel()
+
+	var tables []entity.DiscoveredTable
+	var files []entity.DiscoveredFile
+	enumeratesFiles, truncated := false, false
+	switch conn.SourceType {
+	case "postgresql":
+		tables, err = crawlPostgreSQL(crawlCtx, config)
+	case "mysql":
+		tables, err = crawlMySQL(crawlCtx, config)
+	case "s3":
+		enumeratesFiles = true
+		files, truncated, err = crawlS3(crawlCtx, config)
+	case "filesystem", "fs":
+		enumeratesFiles = true
+		files, truncated, err = crawlFilesystem(crawlCtx, config)
+	default:
+		return nil, fmt.Errorf("schema discovery is not supported for source type: %s", conn.SourceType)
+	}
+	if err != nil {
+		// Keep driver details (which may echo the DSN) server-side
+		log.Printf("[SECURITY] Schema discovery failed for connection %s: %v", connectionID, err)
+		return nil, fmt.Errorf("schema discovery failed for connection %s", conn.ProfileName)
+	}
+
+	result := &entity.DiscoveryResult{
+		ConnectionID: conn.ID,
+		ProfileName:  conn.ProfileName,
+		SourceType:   conn.SourceType,
+		Schemas:      []string{},
+		Files:        len(files),
+		Truncated:    truncated,
+		DiscoveredAt: time.Now().UTC(),
+	}
+
+	if enumeratesFiles {
+		if err := s.repo.ReplaceDiscoveredFiles(ctx, conn.ID, files, result.DiscoveredAt); err != nil {
+			return nil, err
+		}
+	}
+
+	host := configString(config, "host")
+	environment := configString(config, "environment")
+	if environment == "" {
+		environment = "production"
+	}
+
+	schemas := make(map[string]bool)
+	for _, table := range tables {
+		asset := &entity.Asset{
+			AssetType:    entity.AssetTypeTable,
+			Name:         table.Name,
+			Path:         tablePath(table),
+			DataSource:   conn.SourceType,
+			Host:         host,
+			Environment:  environment,
+			SourceSystem: fmt.Sprintf("%s://%s", conn.SourceType, host),
+			FileMetadata: discoveryMetadata(conn, table, result.DiscoveredAt),
+		}
+
+		assetID, isNew, err := s.assetManager.CreateOrUpdateAsset(ctx, asset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record table %s: %w", asset.Path, err)
+		}
+		if isNew {
+			result.NewAssets++
+		} else if err := s.repo.MergeAssetMetadata(ctx, assetID, asset.FileMetadata); err != nil {
+			return nil, fmt.Errorf("failed to update table %s: %w", asset.Path, err)
+		}
+
+		schemas[table.Schema] = true
+		result.Tables++
+		result.Columns += len(table.Columns)
+	}
+
+	for schema := range schemas {
+		result.Schemas = append(result.Schemas, schema)
+	}
+	sort.Strings(result.Schemas)
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "SCHEMA_DISCOVERED", "connection", connectionID.String(), map[string]interface{}{
+			"profile_name": conn.ProfileName,
+			"tables":       result.Tables,
+			"columns":      result.Columns,
+			"new_assets":   result.NewAssets,
+			"files":        result.Files,
+			"truncated":    result.Truncated,
+		})
+	}
+
+	if enumeratesFiles {
+		log.Printf("🔎 Enumerated %d files (truncated: %t) in connection %s", result.Files, result.Truncated, conn.ProfileName)
+	} else {
+		log.Printf("🔎 Discovered %d tables (%d columns, %d new assets) in connection %s",
+			result.Tables, result.Columns, result.NewAssets, conn.ProfileName)
+	}
+
+	return result, nil
+}
+
+// GetCoverage reports the scan coverage of discovered tables, optionally limited to
+// one connection and one coverage status
+func (s *DiscoveryService) GetCoverage(ctx context.Context, connectionID, status string) (*CoverageReport, error) {
+	tables, err := s.repo.ListTableCoverage(ctx, connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CoverageReport{Tables: []*entity.TableCoverage{}}
+	for _, table := range tables {
+		table.Status = coverageStatus(table)
+		report.Summary.DiscoveredTables++
+		switch table.Status {
+		case entity.CoverageScanned:
+			report.Summary.Scanned++
+		case entity.CoverageNoFindings:
+			report.Summary.NoFindings++
+		default:
+			report.Summary.NeverScanned++
+		}
+
+		if status == "" || status == table.Status {
+			report.Tables = append(report.Tables, table)
+		}
+	}
+
+	return report, nil
+}
+
+// coverageStatus classifies a discovered table. The scanner only reports tables in
+// which it found something, so a table of a scanned connection without findings is
+// either clean or excluded by the scan profile; neither can be told apart here.
+func coverageStatus(table *entity.TableCoverage) string {
+	return locationCoverageStatus(table.FindingCount, table.LastScanAt)
+}
+
+// locationCoverageStatus classifies any discovered location, table or file, from
+// its finding count and the last completed scan of its connection
+func locationCoverageStatus(findingCount int, lastScanAt *time.Time) string {
+	switch {
+	case findingCount > 0:
+		return entity.CoverageScanned
+	case lastScanAt != nil:
+		return entity.CoverageNoFindings
+	default:
+		return entity.CoverageNeverScanned
+	}
+}
+
+// discoveryMetadata is the file metadata stored on a discovered table's asset
+func discoveryMetadata(conn *entity.Connection, table entity.DiscoveredTable, discoveredAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"connection_id":       conn.ID.String(),
+		"profile_name":        conn.ProfileName,
+		"database":            table.Database,
+		"schema":              table.Schema,
+		"table":               table.Name,
+		"row_count":           table.RowCount,
+		"row_count_estimated": true,
+		"columns":             table.Columns,
+		"discovered_at":       discoveredAt.Format(time.RFC3339),
+	}
+}
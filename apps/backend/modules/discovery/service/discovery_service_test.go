@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestAttachColumns(t *testing.T) {
+	tables := []entity.DiscoveredTable{
+		{Schema: "public", Name: "customers"},
+		{Schema: "billing", Name: "customers"},
+		{Schema: "public", Name: "empty"},
+	}
+	columns := []catalogColumn{
+		{schema: "public", table: "customers", column: entity.DiscoveredColumn{Name: "id", Position: 1}},
+		{schema: "public", table: "customers", column: entity.DiscoveredColumn{Name: "email", Position: 2}},
+		{schema: "billing", table: "customers", column: entity.DiscoveredColumn{Name: "pan", Position: 1}},
+		{schema: "public", table: "active_customers", column: entity.DiscoveredColumn{Name: "id", Position: 1}},
+	}
+
+	tables = attachColumns(tables, columns)
+
+	if len(tables[0].Columns) != 2 || tables[0].Columns[1].Name != "email" {
+		t.Errorf("expected public.customers to keep both columns in order, got %+v", tables[0].Columns)
+	}
+	if len(tables[1].Columns) != 1 || tables[1].Columns[0].Name != "pan" {
+		t.Errorf("expected billing.customers columns to stay separate, got %+v", tables[1].Columns)
+	}
+	if tables[2].Columns == nil || len(tables[2].Columns) != 0 {
+		t.Errorf("expected an empty, non-nil column list, got %#v", tables[2].Columns)
+	}
+	if tablePath(tables[1]) != "billing.customers" {
+		t.Errorf("unexpected table path %s", tablePath(tables[1]))
+	}
+}
+
+func TestCoverageStatus(t *testing.T) {
+	scannedAt := time.Now()
+
+	cases := []struct {
+		name  string
+		table entity.TableCoverage
+		want  string
+	}{
+		{"findings recorded", entity.TableCoverage{FindingCount: 3, LastScanAt: &scannedAt}, entity.CoverageScanned},
+		{"findings without a completed run", entity.TableCoverage{FindingCount: 1}, entity.CoverageScanned},
+		{"connection scanned, table clean", entity.TableCoverage{LastScanAt: &scannedAt}, entity.CoverageNoFindings},
+		{"connection never scanned", entity.TableCoverage{}, entity.CoverageNeverScanned},
+	}
+
+	for _, tc := range cases {
+		if got := coverageStatus(&tc.table); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCoverageGapRisk(t *testing.T) {
+	conn := &entity.ConnectionCoverage{ProfileName: "prod"}
+
+	customers := tableGap(conn, &entity.TableCoverage{
+		Path:        "public.customers",
+		RowCount:    2_000_000,
+		ColumnNames: []string{"id", "email_address", "phone", "company"},
+	})
+	if customers.RiskScore != 75 || len(customers.RiskReasons) != 3 {
+		t.Errorf("expected customers table to score 75 with three reasons, got %d %v", customers.RiskScore, customers.RiskReasons)
+	}
+
+	lookup := tableGap(conn, &entity.TableCoverage{Path: "public.countries", ColumnNames: []string{"code", "company"}})
+	if lookup.RiskScore != 0 {
+		t.Errorf("expected lookup table to score 0, got %d %v", lookup.RiskScore, lookup.RiskReasons)
+	}
+
+	export := fileGap(conn, &entity.FileCoverage{Path: "exports/2024/customer_pan.csv", SizeBytes: 20 << 20})
+	if export.RiskScore != 80 {
+		t.Errorf("expected customer export to score 80, got %d %v", export.RiskScore, export.RiskReasons)
+	}
+
+	image := fileGap(conn, &entity.FileCoverage{Path: "static/logo.png", SizeBytes: 1024})
+	if image.RiskScore != 0 {
+		t.Errorf("expected image to score 0, got %d %v", image.RiskScore, image.RiskReasons)
+	}
+}
+
+func TestCoveragePercent(t *testing.T) {
+	c := &entity.ConnectionCoverage{TablesDiscovered: 2, TablesScanned: 1, FilesEnumerated: 1}
+	if got := coveragePercent(c); got != 33.3 {
+		t.Errorf("expected 33.3, got %v", got)
+	}
+	if got := coveragePercent(&entity.ConnectionCoverage{}); got != 0 {
+		t.Errorf("expected 0 for an undiscovered connection, got %v", got)
+	}
+}
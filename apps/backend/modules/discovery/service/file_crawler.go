@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// maxDiscoveredFiles caps one enumeration so a huge bucket or tree cannot
+// exhaust memory or the discovery timeout; the result is marked truncated
+const maxDiscoveredFiles = 50000
+
+// crawlS3 lists the objects of the connection's bucket, under its prefix when set.
+// Only object metadata is read.
+func crawlS3(ctx context.Context, config map[string]interface{}) ([]entity.DiscoveredFile, bool, error) {
+	bucket := configString(config, "bucket")
+	if bucket == "" {
+		return nil, false, fmt.Errorf("bucket not found in config")
+	}
+
+	awsConfig := &aws.Config{Region: aws.String(configString(config, "region"))}
+	if accessKey := configString(config, "access_key"); accessKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(accessKey, configString(config, "secret_key"), "")
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(bucket)}
+	if prefix := configString(config, "prefix"); prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
+	var files []entity.DiscoveredFile
+	truncated := false
+	err = s3.New(sess).ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			// Zero-byte keys ending in a slash are folder placeholders
+			if key == "" || key[len(key)-1] == '/' {
+				continue
+			}
+			if len(files) >= maxDiscoveredFiles {
+				truncated = true
+				return false
+			}
+			files = append(files, entity.DiscoveredFile{
+				Path:       key,
+				SizeBytes:  aws.Int64Value(obj.Size),
+				ModifiedAt: obj.LastModified,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list bucket: %w", err)
+	}
+
+	return files, truncated, nil
+}
+
+// crawlFilesystem walks the connection's base path and lists regular files.
+// Unreadable directories are skipped rather than failing the crawl.
+func crawlFilesystem(ctx context.Context, config map[string]interface{}) ([]entity.DiscoveredFile, bool, error) {
+	root := configString(config, "path")
+	if root == "" {
+		root = configString(config, "base_path")
+	}
+	if root == "" {
+		return nil, false, fmt.Errorf("path not found in config")
+	}
+
+	var files []entity.DiscoveredFile
+	truncated := false
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() && path != root {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if len(files) >= maxDiscoveredFiles {
+			truncated = true
+			return fs.SkipAll
+		}
+
+		file := entity.DiscoveredFile{Path: path}
+		if info, err := d.Info(); err == nil {
+			modifiedAt := info.ModTime().UTC()
+			file.SizeBytes = info.Size()
+			file.ModifiedAt = &modifiedAt
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+
+	return files, truncated, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/rds"
+)
+
+// awsInventory lists EC2 instances and RDS databases in each configured region
+type awsInventory struct {
+	regions     []string
+	credentials *credentials.Credentials // Nil uses the default credential chain
+	tags        inventoryTagKeys
+}
+
+func (a *awsInventory) ListSystems(ctx context.Context) ([]entity.InventorySystem, error) {
+	var systems []entity.InventorySystem
+	for _, region := range a.regions {
+		sess, err := session.NewSession(&aws.Config{Region: aws.String(region), Credentials: a.credentials})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create AWS session: %w", err)
+		}
+
+		err = ec2.New(sess).DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, reservation := range page.Reservations {
+				for _, instance := range reservation.Instances {
+					if instance.State != nil && aws.StringValue(instance.State.Name) == ec2.InstanceStateNameTerminated {
+						continue
+					}
+					systems = append(systems, a.ec2System(region, aws.StringValue(reservation.OwnerId), instance))
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list EC2 instances in %s: %w", region, err)
+		}
+
+		err = rds.New(sess).DescribeDBInstancesPagesWithContext(ctx, &rds.DescribeDBInstancesInput{}, func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
+			for _, db := range page.DBInstances {
+				systems = append(systems, a.rdsSystem(region, db))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list RDS instances in %s: %w", region, err)
+		}
+	}
+	return systems, nil
+}
+
+func (a *awsInventory) ec2System(region, account string, instance *ec2.Instance) entity.InventorySystem {
+	tags := make(map[string]string, len(instance.Tags))
+	for _, tag := range instance.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	id := aws.StringValue(instance.InstanceId)
+	name := tags["Name"]
+	if name == "" {
+		name = id
+	}
+
+	system := entity.InventorySystem{
+		Provider:     entity.InventoryProviderAWS,
+		ResourceID:   id,
+		ResourceType: "ec2_instance",
+		Name:         name,
+		Account:      account,
+		Region:       region,
+		Hosts: inventoryHosts(id, tags["Name"],
+			aws.StringValue(instance.PrivateDnsName), aws.StringValue(instance.PrivateIpAddress),
+			aws.StringValue(instance.PublicDnsName), aws.StringValue(instance.PublicIpAddress)),
+		Tags: tags,
+	}
+	a.tags.apply(&system)
+	return system
+}
+
+func (a *awsInventory) rdsSystem(region string, db *rds.DBInstance) entity.InventorySystem {
+	tags := make(map[string]string, len(db.TagList))
+	for _, tag := range db.TagList {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	id := aws.StringValue(db.DBInstanceIdentifier)
+	account := ""
+	if parsed, err := arn.Parse(aws.StringValue(db.DBInstanceArn)); err == nil {
+		account = parsed.AccountID
+	}
+	endpoint := ""
+	if db.Endpoint != nil {
+		endpoint = aws.StringValue(db.Endpoint.Address)
+	}
+
+	system := entity.InventorySystem{
+		Provider:     entity.InventoryProviderAWS,
+		ResourceID:   id,
+		ResourceType: "rds_" + strings.ToLower(aws.StringValue(db.Engine)),
+		Name:         id,
+		Account:      account,
+		Region:       region,
+		Hosts:        inventoryHosts(id, endpoint),
+		Tags:         tags,
+	}
+	a.tags.apply(&system)
+	return system
+}
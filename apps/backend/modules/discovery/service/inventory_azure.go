@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+const (
+	azureLoginURL      = "https://login.microsoftonline.com"
+	azureManagementURL = "https://management.azure.com"
+
+	// azureInventoryQuery selects virtual machines and managed SQL, PostgreSQL and
+	// MySQL servers with the names assets may report as their host
+	azureInventoryQuery = `Resources
+| where type in~ ('microsoft.compute/virtualmachines', 'microsoft.sql/servers',
+	'microsoft.dbforpostgresql/servers', 'microsoft.dbforpostgresql/flexibleservers',
+	'microsoft.dbformysql/servers', 'microsoft.dbformysql/flexibleservers')
+| project id, name, type, location, subscriptionId, tags,
+	computerName = tostring(properties.osProfile.computerName),
+	fqdn = tostring(properties.fullyQualifiedDomainName)`
+
+	// azureMaxResponse bounds a token or Resource Graph response
+	azureMaxResponse = 16 << 20
+)
+
+// azureInventory queries Azure Resource Graph with a service principal
+type azureInventory struct {
+	tenantID      string
+	clientID      string
+	clientSecret  string
+	subscriptions []string
+	tags          inventoryTagKeys
+
+	loginURL      string
+	managementURL string
+	client        *http.Client
+}
+
+type azureResource struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	Type           string            `json:"type"`
+	Location       string            `json:"location"`
+	SubscriptionID string            `json:"subscriptionId"`
+	Tags           map[string]string `json:"tags"`
+	ComputerName   string            `json:"computerName"`
+	FQDN           string            `json:"fqdn"`
+}
+
+func (a *azureInventory) ListSystems(ctx context.Context) ([]entity.InventorySystem, error) {
+	token, err := a.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var systems []entity.InventorySystem
+	skipToken := ""
+	for {
+		options := map[string]interface{}{"resultFormat": "objectArray"}
+		if skipToken != "" {
+			options["$skipToken"] = skipToken
+		}
+		body, _ := json.Marshal(map[string]interface{}{
+			"subscriptions": a.subscriptions,
+			"query":         azureInventoryQuery,
+			"options":       options,
+		})
+
+		var page struct {
+			Data      []azureResource `json:"data"`
+			SkipToken string          `json:"$skipToken"`
+		}
+		endpoint := a.managementURL + "/providers/Microsoft.ResourceGraph/resources?api-version=2021-03-01"
+		if err := a.post(ctx, endpoint, "application/json", bytes.NewReader(body), token, &page); err != nil {
+			return nil, fmt.Errorf("resource graph query failed: %w", err)
+		}
+		for _, resource := range page.Data {
+			systems = append(systems, a.system(resource))
+		}
+		if page.SkipToken == "" {
+			return systems, nil
+		}
+		skipToken = page.SkipToken
+	}
+}
+
+func (a *azureInventory) system(resource azureResource) entity.InventorySystem {
+	if resource.Tags == nil {
+		resource.Tags = map[string]string{}
+	}
+	system := entity.InventorySystem{
+		Provider:     entity.InventoryProviderAzure,
+		ResourceID:   resource.ID,
+		ResourceType: strings.ToLower(resource.Type),
+		Name:         resource.Name,
+		Account:      resource.SubscriptionID,
+		Region:       resource.Location,
+		Hosts:        inventoryHosts(resource.Name, resource.ComputerName, resource.FQDN),
+		Tags:         resource.Tags,
+	}
+	a.tags.apply(&system)
+	return system
+}
+
+// token obtains an Azure Resource Manager token with the client credentials grant
+func (a *azureInventory) token(ctx context.Context) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", a.clientID)
+	form.Set("client_secret", a.clientSecret)
+	form.Set("scope", a.managementURL+"/.default")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+	}
+	endpoint := a.loginURL + "/" + url.PathEscape(a.tenantID) + "/oauth2/v2.0/token"
+	if err := a.post(ctx, endpoint, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()), "", &resp); err != nil {
+		return "", fmt.Errorf("azure token request failed: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf("azure token response has no access token")
+	}
+	return resp.AccessToken, nil
+}
+
+func (a *azureInventory) post(ctx context.Context, endpoint, contentType string, body io.Reader, token string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, azureMaxResponse))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var azureErr struct {
+			Error interface{} `json:"error"`
+		}
+		if json.Unmarshal(data, &azureErr) == nil && azureErr.Error != nil {
+			return fmt.Errorf("status %d: %v", resp.StatusCode, azureErr.Error)
+		}
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return json.Unmarshal(data, dest)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+)
+
+// inventoryTimeout bounds listing one provider's inventory
+const inventoryTimeout = 5 * time.Minute
+
+var (
+	ErrUnknownInventoryProvider       = errors.New("unknown inventory provider")
+	ErrInventoryProviderNotConfigured = errors.New("inventory provider is not configured")
+	ErrInventoryListFailed            = errors.New("failed to list inventory")
+)
+
+// InventorySource lists the hosts and managed databases of a cloud account
+type InventorySource interface {
+	ListSystems(ctx context.Context) ([]entity.InventorySystem, error)
+}
+
+// InventoryService imports cloud inventories as source systems. Assets on an
+// imported host take its environment and owner tags, and lineage places them
+// under the instance or database rather than a System node per hostname.
+type InventoryService struct {
+	repo        *persistence.PostgresRepository
+	sources     map[string]InventorySource
+	lineageSync interfaces.LineageSync
+	auditLogger interfaces.AuditLogger
+}
+
+// NewInventoryService creates an inventory service for the providers configured in cfg
+func NewInventoryService(repo *persistence.PostgresRepository, cfg config.InventoryConfig, lineageSync interfaces.LineageSync, auditLogger interfaces.AuditLogger) *InventoryService {
+	tags := inventoryTagKeys{environment: cfg.EnvironmentTags, owner: cfg.OwnerTags}
+	sources := make(map[string]InventorySource)
+	if len(cfg.AWSRegions) > 0 {
+		aws := &awsInventory{regions: cfg.AWSRegions, tags: tags}
+		if cfg.AWSAccessKey != "" {
+			aws.credentials = credentials.NewStaticCredentials(cfg.AWSAccessKey, cfg.AWSSecretKey, "")
+		}
+		sources[entity.InventoryProviderAWS] = aws
+	}
+	if cfg.AzureClientID != "" && len(cfg.AzureSubscriptions) > 0 {
+		sources[entity.InventoryProviderAzure] = &azureInventory{
+			tenantID:      cfg.AzureTenantID,
+			clientID:      cfg.AzureClientID,
+			clientSecret:  cfg.AzureClientSecret,
+			subscriptions: cfg.AzureSubscriptions,
+			tags:          tags,
+			loginURL:      azureLoginURL,
+			managementURL: azureManagementURL,
+			client:        &http.Client{Timeout: time.Minute},
+		}
+	}
+	return &InventoryService{
+		repo:        repo,
+		sources:     sources,
+		lineageSync: lineageSync,
+		auditLogger: auditLogger,
+	}
+}
+
+// Providers returns the configured inventory providers
+func (s *InventoryService) Providers() []string {
+	providers := make([]string, 0, len(s.sources))
+	for provider := range s.sources {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// ListSystems returns the tenant's imported systems, optionally of one provider
+func (s *InventoryService) ListSystems(ctx context.Context, provider string) ([]entity.InventorySystem, error) {
+	return s.repo.ListInventorySystems(ctx, provider)
+}
+
+// ImportInventory replaces the tenant's systems from a provider's inventory,
+// enriches the assets on their hosts and relinks those assets in lineage
+func (s *InventoryService) ImportInventory(ctx context.Context, provider string, actor string) (*entity.InventoryImportResult, error) {
+	source, ok := s.sources[provider]
+	if !ok {
+		if provider != entity.InventoryProviderAWS && provider != entity.InventoryProviderAzure {
+			return nil, ErrUnknownInventoryProvider
+		}
+		return nil, ErrInventoryProviderNotConfigured
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, inventoryTimeout)
+	systems, err := source.ListSystems(listCtx)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %v", ErrInventoryListFailed, provider, err)
+	}
+
+	result := &entity.InventoryImportResult{Provider: provider, Systems: len(systems)}
+	if result.Removed, err = s.repo.ReplaceInventorySystems(ctx, provider, systems); err != nil {
+		return nil, err
+	}
+	matched, enriched, err := s.repo.ApplyInventoryToAssets(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	result.AssetsMatched, result.AssetsEnriched = len(matched), enriched
+
+	if s.lineageSync != nil && s.lineageSync.IsAvailable() {
+		for _, assetID := range matched {
+			if err := s.lineageSync.SyncAssetToNeo4j(ctx, assetID); err != nil {
+				log.Printf("WARN: Failed to relink asset %s to its inventory system: %v", assetID, err)
+				continue
+			}
+			result.LineageSynced++
+		}
+	}
+
+	if s.auditLogger != nil {
+		tenantID, _ := persistence.GetTenantID(ctx)
+		_ = s.auditLogger.Record(ctx, "INVENTORY_IMPORTED", "tenant", tenantID.String(), map[string]interface{}{
+			"provider":        provider,
+			"systems":         result.Systems,
+			"removed":         result.Removed,
+			"assets_enriched": result.AssetsEnriched,
+			"actor":           actor,
+		})
+	}
+	return result, nil
+}
+
+// inventoryTagKeys are the tag keys read as a system's environment and owner
+type inventoryTagKeys struct {
+	environment []string
+	owner       []string
+}
+
+func (k inventoryTagKeys) apply(system *entity.InventorySystem) {
+	system.Environment = normalizeInventoryEnvironment(lookupTag(system.Tags, k.environment))
+	system.Owner = lookupTag(system.Tags, k.owner)
+}
+
+// lookupTag returns the value of the first of keys that is tagged, ignoring case
+func lookupTag(tags map[string]string, keys []string) string {
+	for _, key := range keys {
+		for tag, value := range tags {
+			if strings.EqualFold(tag, key) && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeInventoryEnvironment maps common tag spellings onto the environment
+// names ingestion uses; anything else is kept as tagged
+func normalizeInventoryEnvironment(env string) string {
+	switch strings.ToLower(env) {
+	case "prod", "prd", "production":
+		return "Production"
+	case "dev", "development":
+		return "Development"
+	case "stage", "stg", "staging":
+		return "Staging"
+	}
+	return env
+}
+
+// inventoryHosts collects the distinct non-empty names an asset may report as its host
+func inventoryHosts(names ...string) []string {
+	seen := make(map[string]bool, len(names))
+	hosts := make([]string, 0, len(names))
+	for _, name := range names {
+		host := strings.ToLower(strings.TrimSpace(name))
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
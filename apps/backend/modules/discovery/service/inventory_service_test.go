@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAzureInventoryListsAllPages(t *testing.T) {
+	var queries []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tenant-1/oauth2/v2.0/token":
+			if err := r.ParseForm(); err != nil || r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"access_token": "token-1"})
+		case "/providers/Microsoft.ResourceGraph/resources":
+			if r.Header.Get("Authorization") != "Bearer token-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			queries = append(queries, body)
+
+			if options, _ := body["options"].(map[string]interface{}); options["$skipToken"] == nil {
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"$skipToken": "page-2",
+					"data": []map[string]interface{}{{
+						"id":   "/subscriptions/sub-1/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/vm-app",
+						"name": "vm-app", "type": "Microsoft.Compute/virtualMachines", "location": "centralindia",
+						"subscriptionId": "sub-1", "computerName": "APP-01",
+						"tags": map[string]string{"ENV": "prd", "Owner": "payments@example.com"},
+					}},
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{
+					"id":   "/subscriptions/sub-1/resourceGroups/rg/providers/Microsoft.Sql/servers/sql-core",
+					"name": "sql-core", "type": "Microsoft.Sql/servers", "location": "centralindia",
+					"subscriptionId": "sub-1", "fqdn": "sql-core.database.windows.net",
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	inventory := &azureInventory{
+		tenantID:      "tenant-1",
+		clientID:      "client-1",
+		clientSecret:  "secret",
+		subscriptions: []string{"sub-1"},
+		tags:          inventoryTagKeys{environment: []string{"Environment", "env"}, owner: []string{"Owner"}},
+		loginURL:      server.URL,
+		managementURL: server.URL,
+		client:        server.Client(),
+	}
+
+	systems, err := inventory.ListSystems(context.Background())
+	if err != nil {
+		t.Fatalf("ListSystems: %v", err)
+	}
+	if len(queries) != 2 || len(systems) != 2 {
+		t.Fatalf("expected both pages to be read, got %d queries and %d systems", len(queries), len(systems))
+	}
+
+	vm := systems[0]
+	if vm.Environment != "Production" || vm.Owner != "payments@example.com" {
+		t.Errorf("expected tags mapped to environment and owner, got %q and %q", vm.Environment, vm.Owner)
+	}
+	if len(vm.Hosts) != 2 || vm.Hosts[1] != "app-01" {
+		t.Errorf("expected the VM and computer names as hosts, got %v", vm.Hosts)
+	}
+	if vm.ResourceType != "microsoft.compute/virtualmachines" || vm.Account != "sub-1" {
+		t.Errorf("unexpected VM system: %+v", vm)
+	}
+
+	sql := systems[1]
+	if sql.Environment != "" || sql.Tags == nil {
+		t.Errorf("expected an untagged server to have no environment and empty tags, got %+v", sql)
+	}
+	if len(sql.Hosts) != 2 || sql.Hosts[1] != "sql-core.database.windows.net" {
+		t.Errorf("expected the server FQDN as a host, got %v", sql.Hosts)
+	}
+}
+
+func TestImportInventoryRejectsUnconfiguredProviders(t *testing.T) {
+	s := &InventoryService{sources: map[string]InventorySource{}}
+
+	if _, err := s.ImportInventory(context.Background(), "azure", "admin"); err != ErrInventoryProviderNotConfigured {
+		t.Errorf("expected ErrInventoryProviderNotConfigured, got %v", err)
+	}
+	if _, err := s.ImportInventory(context.Background(), "gcp", "admin"); err != ErrUnknownInventoryProvider {
+		t.Errorf("expected ErrUnknownInventoryProvider, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+)
+
+// crawlerConnectTimeoutSeconds bounds how long a crawler waits for the source database
+const crawlerConnectTimeoutSeconds = 10
+
+// Catalog queries only read system catalogs; table data is never touched and row
+// counts are the planner's statistics rather than COUNT(*) results.
+const (
+	postgresTablesQuery = `
+		SELECT n.nspname, c.relname, GREATEST(c.reltuples, 0)::bigint
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p')
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		  AND n.nspname NOT LIKE 'pg_toast%'
+		  AND n.nspname NOT LIKE 'pg_temp%'
+		ORDER BY n.nspname, c.relname`
+
+	postgresColumnsQuery = `
+		SELECT table_schema, table_name, column_name, data_type, is_nullable = 'YES', ordinal_position
+		FROM information_schema.columns
+		WHERE table_schema NOT IN ('pg_catalog', 'information_schema')
+		  AND table_schema NOT LIKE 'pg_toast%'
+		  AND table_schema NOT LIKE 'pg_temp%'
+		ORDER BY table_schema, table_name, ordinal_position`
+
+	mysqlSystemSchemas = `('mysql', 'information_schema', 'performance_schema', 'sys')`
+)
+
+// catalogColumn is a column row read from information_schema
+type catalogColumn struct {
+	schema string
+	table  string
+	column entity.DiscoveredColumn
+}
+
+// crawlPostgreSQL enumerates the user tables of a PostgreSQL database
+func crawlPostgreSQL(ctx context.Context, config map[string]interface{}) ([]entity.DiscoveredTable, error) {
+	database := configString(config, "database")
+	sslmode := configString(config, "sslmode")
+	if sslmode == "" {
+		sslmode = "prefer"
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s connect_timeout=%d",
+		configString(config, "host"), configInt(config, "port", 5432), configString(config, "user"),
+		configString(config, "password"), database, sslmode, crawlerConnectTimeoutSeconds)
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+	}
+	defer db.Close()
+
+	return crawlCatalog(ctx, db, database, postgresTablesQuery, postgresColumnsQuery)
+}
+
+// crawlMySQL enumerates the base tables of a MySQL database, or of every
+// non-system database when the connection does not name one
+func crawlMySQL(ctx context.Context, config map[string]interface{}) ([]entity.DiscoveredTable, error) {
+	database := configString(config, "database")
+
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&timeout=%ds",
+		configString(config, "user"), configString(config, "password"), configString(config, "host"),
+		configInt(config, "port", 3306), database, crawlerConnectTimeoutSeconds)
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open MySQL connection: %w", err)
+	}
+	defer db.Close()
+
+	schemaFilter := "table_schema NOT IN " + mysqlSystemSchemas
+	if database != "" {
+		schemaFilter = "table_schema = DATABASE()"
+	}
+
+	tablesQuery := `
+		SELECT table_schema, table_name, COALESCE(table_rows, 0)
+		FROM information_schema.tables
+		WHERE table_type = 'BASE TABLE' AND ` + schemaFilter + `
+		ORDER BY table_schema, table_name`
+
+	columnsQuery := `
+		SELECT table_schema, table_name, column_name, column_type, is_nullable = 'YES', ordinal_position
+		FROM information_schema.columns
+		WHERE ` + schemaFilter + `
+		ORDER BY table_schema, table_name, ordinal_position`
+
+	tables, err := crawlCatalog(ctx, db, database, tablesQuery, columnsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// MySQL has no schemas inside a database; the schema is the database itself
+	for i := range tables {
+		tables[i].Database = tables[i].Schema
+	}
+	return tables, nil
+}
+
+// crawlCatalog runs a tables query and a columns query and joins their rows
+func crawlCatalog(ctx context.Context, db *sql.DB, database, tablesQuery, columnsQuery string) ([]entity.DiscoveredTable, error) {
+	rows, err := db.QueryContext(ctx, tablesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	var tables []entity.DiscoveredTable
+	for rows.Next() {
+		table := entity.DiscoveredTable{Database: database}
+		if err := rows.Scan(&table.Schema, &table.Name, &table.RowCount); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.QueryContext(ctx, columnsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []catalogColumn
+	for rows.Next() {
+		var c catalogColumn
+		if err := rows.Scan(&c.schema, &c.table, &c.column.Name, &c.column.DataType, &c.column.Nullable, &c.column.Position); err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachColumns(tables, columns), nil
+}
+
+// attachColumns assigns catalog columns to their tables. Columns of relations that
+// are not listed as tables (views, for example) are ignored.
+func attachColumns(tables []entity.DiscoveredTable, columns []catalogColumn) []entity.DiscoveredTable {
+	index := make(map[string]int, len(tables))
+	for i, t := range tables {
+		index[t.Schema+"."+t.Name] = i
+	}
+
+	for _, c := range columns {
+		if i, ok := index[c.schema+"."+c.table]; ok {
+			tables[i].Columns = append(tables[i].Columns, c.column)
+		}
+	}
+
+	for i := range tables {
+		if tables[i].Columns == nil {
+			tables[i].Columns = []entity.DiscoveredColumn{}
+		}
+	}
+	return tables
+}
+
+// tablePath is the asset path of a discovered table, matching the "schema.table"
+// paths the scanner reports for database findings
+func tablePath(table entity.DiscoveredTable) string {
+	return table.Schema + "." + table.Name
+}
+
+func configString(config map[string]interface{}, key string) string {
+	if val, ok := config[key].(string); ok {
+		return val
+	}
+	return ""
+}
+
+func configInt(config map[string]interface{}, key string, defaultVal int) int {
+	switch v := config[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return defaultVal
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/eventbus"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EventStreamHandler streams live events to clients as server-sent events
+type EventStreamHandler struct {
+	bus       *eventbus.Bus
+	heartbeat time.Duration
+	done      chan struct{}
+}
+
+// NewEventStreamHandler creates a new event stream handler
+func NewEventStreamHandler(bus *eventbus.Bus, heartbeat time.Duration) *EventStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &EventStreamHandler{bus: bus, heartbeat: heartbeat, done: make(chan struct{})}
+}
+
+// Close ends every open stream so server shutdown is not held up by them
+func (h *EventStreamHandler) Close() {
+	close(h.done)
+}
+
+// Stream handles GET /api/v1/events
+// Optional query: types=scan_progress,critical_finding to filter event types.
+func (h *EventStreamHandler) Stream(c *gin.Context) {
+	ctx := sharedapi.RequestContext(c)
+	tenantID, err := persistence.GetTenantID(ctx)
+	if err != nil {
+		tenantID = uuid.Nil
+	}
+
+	var types []string
+	for _, t := range strings.Split(c.Query("types"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+
+	// Streams outlive the server-wide write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	sub := h.bus.Subscribe(tenantID, types)
+	defer sub.Close()
+
+	fmt.Fprint(c.Writer, "retry: 5000\n: connected\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-h.done:
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if err := writeEvent(c.Writer, event); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeEvent renders an event in the text/event-stream wire format
+func writeEvent(w io.Writer, event eventbus.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/events/api"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/eventbus"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// EventsModule exposes the live event stream (server-sent events)
+type EventsModule struct {
+	bus     *eventbus.Bus
+	handler *api.EventStreamHandler
+}
+
+// NewEventsModule creates the events module around a shared event bus
+func NewEventsModule(bus *eventbus.Bus) *EventsModule {
+	return &EventsModule{bus: bus}
+}
+
+// Name returns the module name
+func (m *EventsModule) Name() string {
+	return "events"
+}
+
+// Initialize sets up the stream handler
+func (m *EventsModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	heartbeat := 15 * time.Second
+	if deps.Config != nil && deps.Config.Events.HeartbeatSeconds > 0 {
+		heartbeat = time.Duration(deps.Config.Events.HeartbeatSeconds) * time.Second
+	}
+
+	m.handler = api.NewEventStreamHandler(m.bus, heartbeat)
+	log.Println("✅ Events module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the event stream route
+func (m *EventsModule) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/events", m.handler.Stream)
+}
+
+// Shutdown closes open event streams
+func (m *EventsModule) Shutdown() error {
+	if m.handler != nil {
+		m.handler.Close()
+	}
+	return nil
+}
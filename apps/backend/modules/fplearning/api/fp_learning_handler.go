@@ -6,6 +6,7 @@ import (
 
 	"github.com/arc-platform/backend/modules/fplearning/entity"
 	"github.com/arc-platform/backend/modules/fplearning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -43,8 +44,7 @@ func (h *FPLearningHandler) MarkFalsePositive(c *gin.Context) {
 	tenantID, _ := uuid.Parse(tenantIDStr.(string))
 
 	var req CreateFPLearningRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -86,8 +86,7 @@ func (h *FPLearningHandler) MarkConfirmed(c *gin.Context) {
 	tenantID, _ := uuid.Parse(tenantIDStr.(string))
 
 	var req CreateFPLearningRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -208,8 +207,7 @@ func (h *FPLearningHandler) CheckFalsePositive(c *gin.Context) {
 	tenantID, _ := uuid.Parse(tenantIDStr.(string))
 
 	var req entity.FPMatchRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
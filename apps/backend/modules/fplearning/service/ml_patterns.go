@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/arc-platform/backend/pkg/validation"
 )
 
 // SimilarityConfig defines thresholds for pattern matching
@@ -148,8 +150,8 @@ func generateAadhaarPattern(aadhaar string) string {
 		}
 	}
 
-	if len(digits) == 12 {
-		// Return first 4 digits pattern for grouping
+	if validation.ValidateAadhaar(digits) {
+		// Return last 4 digits pattern for grouping
 		return "aadhaar:****-****-" + digits[8:]
 	}
 	return "aadhaar:invalid"
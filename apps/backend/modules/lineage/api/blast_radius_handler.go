@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/lineage/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// BlastRadiusHandler serves the blast radius of a compromised system
+type BlastRadiusHandler struct {
+	service *service.BlastRadiusService
+}
+
+// NewBlastRadiusHandler creates a new blast radius handler
+func NewBlastRadiusHandler(service *service.BlastRadiusService) *BlastRadiusHandler {
+	return &BlastRadiusHandler{service: service}
+}
+
+// GetBlastRadius handles GET /api/v1/lineage/blast-radius?system_id=X. With format=csv
+// it exports the affected assets, or the PII categories or downstream flows with
+// section=categories or section=downstream.
+func (h *BlastRadiusHandler) GetBlastRadius(c *gin.Context) {
+	systemID := strings.TrimSpace(c.Query("system_id"))
+	if systemID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "system_id is required"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	section := c.DefaultQuery("section", "assets")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+	if section != "assets" && section != "categories" && section != "downstream" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "section must be assets, categories or downstream"})
+		return
+	}
+
+	report, err := h.service.GetBlastRadius(sharedapi.RequestContext(c), systemID)
+	if err != nil {
+		if errors.Is(err, service.ErrSystemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "System not found", "details": systemID})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to compute blast radius",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, gin.H{"data": report})
+		return
+	}
+
+	filename := fmt.Sprintf("blast-radius-%s-%s-%s.csv", report.Host, section, report.GeneratedAt.Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	switch section {
+	case "categories":
+		writeBlastRadiusCategoryRows(w, report)
+	case "downstream":
+		writeBlastRadiusDownstreamRows(w, report)
+	default:
+		writeBlastRadiusAssetRows(w, report)
+	}
+	w.Flush()
+}
+
+func writeBlastRadiusAssetRows(w *csv.Writer, report *service.BlastRadiusReport) {
+	_ = w.Write([]string{"system_id", "asset_id", "name", "path", "environment", "risk_score", "pii_types", "findings"})
+	for _, a := range report.Assets {
+		_ = w.Write([]string{
+			report.SystemID, a.AssetID.String(), a.Name, a.Path, a.Environment,
+			strconv.Itoa(a.RiskScore), strings.Join(a.PIITypes, "; "), strconv.FormatInt(a.Findings, 10),
+		})
+	}
+}
+
+func writeBlastRadiusCategoryRows(w *csv.Writer, report *service.BlastRadiusReport) {
+	_ = w.Write([]string{"system_id", "pii_type", "dpdpa_category", "risk_level", "assets", "findings"})
+	for _, cat := range report.Categories {
+		_ = w.Write([]string{
+			report.SystemID, cat.PIIType, cat.DPDPACategory, cat.RiskLevel,
+			strconv.Itoa(cat.Assets), strconv.FormatInt(cat.Findings, 10),
+		})
+	}
+}
+
+func writeBlastRadiusDownstreamRows(w *csv.Writer, report *service.BlastRadiusReport) {
+	_ = w.Write([]string{"system_id", "asset_id", "asset_name", "asset_path", "host", "system", "environment", "risk_score", "pattern_names", "shared_values"})
+	for _, f := range report.Downstream {
+		_ = w.Write([]string{
+			report.SystemID, f.AssetID.String(), f.AssetName, f.AssetPath, f.Host, f.System, f.Environment,
+			strconv.Itoa(f.RiskScore), strings.Join(f.PatternNames, "; "), strconv.Itoa(f.SharedValues),
+		})
+	}
+}
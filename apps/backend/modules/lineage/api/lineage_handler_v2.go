@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/lineage/service"
@@ -89,6 +90,69 @@ func (h *LineageHandlerV2) GetLineage(c *gin.Context) {
 	})
 }
 
+// ExportLineage handles GET /api/v1/lineage/export?format=graphml|cytoscape
+// Serializes the semantic graph, narrowed by the same system and risk filters as
+// GET /api/v1/lineage, for external graph tools. The body is streamed as it is written.
+func (h *LineageHandlerV2) ExportLineage(c *gin.Context) {
+	format := c.DefaultQuery("format", service.ExportFormatGraphML)
+	contentType, extension, err := service.ExportContentType(format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	graph, err := h.semanticLineageService.GetSemanticGraph(c.Request.Context(), service.SemanticGraphFilters{
+		SystemID:  c.Query("system"),
+		RiskLevel: c.Query("risk"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve lineage",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="lineage.%s"`, extension))
+	c.Status(http.StatusOK)
+	if err := service.WriteGraph(flushWriter{c.Writer}, format, graph); err != nil {
+		// Headers are sent; the client sees a truncated document
+		log.Printf("WARN: Lineage export failed after %d bytes: %v", c.Writer.Size(), err)
+	}
+}
+
+// flushWriter pushes every write to the client so large exports stream
+type flushWriter struct {
+	w gin.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.w.Flush()
+	return n, err
+}
+
+// LineageStats counts the nodes and edges of the semantic graph
+type LineageStats struct {
+	TotalSystems       int `json:"total_systems"`
+	TotalAssets        int `json:"total_assets"`
+	TotalPIICategories int `json:"total_pii_categories"`
+	TotalEdges         int `json:"total_edges"`
+}
+
+// LineageStatsResponse is the body of GET /api/v1/lineage/stats
+type LineageStatsResponse struct {
+	Status string       `json:"status"`
+	Stats  LineageStats `json:"stats"`
+}
+
+// SyncLineageResponse is the body of POST /api/v1/lineage/sync
+type SyncLineageResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
 // GetLineageStats handles GET /api/v1/lineage/stats
 // Returns aggregated statistics from the graph
 func (h *LineageHandlerV2) GetLineageStats(c *gin.Context) {
@@ -104,19 +168,23 @@ func (h *LineageHandlerV2) GetLineageStats(c *gin.Context) {
 	}
 
 	// Calculate stats from graph
-	stats := map[string]interface{}{
-		"total_systems":        countNodesByType(graph.Nodes, "system"),
-		"total_assets":         countNodesByType(graph.Nodes, "asset"),
-		"total_pii_categories": countNodesByType(graph.Nodes, "pii_category"),
-		"total_edges":          len(graph.Edges),
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"stats":  stats,
+	c.JSON(http.StatusOK, LineageStatsResponse{
+		Status: "success",
+		Stats: LineageStats{
+			TotalSystems:       countNodesByType(graph.Nodes, "system"),
+			TotalAssets:        countNodesByType(graph.Nodes, "asset"),
+			TotalPIICategories: countNodesByType(graph.Nodes, "pii_category"),
+			TotalEdges:         len(graph.Edges),
+		},
 	})
 }
 
+// GetWriteQueue handles GET /api/v1/lineage/write-queue
+// Returns the in-flight and queued Neo4j writes of each priority lane
+func (h *LineageHandlerV2) GetWriteQueue(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.semanticLineageService.WriteQueueStats()})
+}
+
 // Helper function to count nodes by type
 func countNodesByType(nodes []service.SemanticNode, nodeType string) int {
 	count := 0
@@ -140,8 +208,8 @@ func (h *LineageHandlerV2) SyncLineage(c *gin.Context) {
 		}
 	}()
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"message": "Lineage synchronization started in background",
+	c.JSON(http.StatusOK, SyncLineageResponse{
+		Status:  "success",
+		Message: "Lineage synchronization started in background",
 	})
 }
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+)
+
+// OpenAPIOperations describes the lineage routes for the OpenAPI document
+func OpenAPIOperations() []sharedapi.Operation {
+	return []sharedapi.Operation{
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/lineage/sync",
+			Summary:     "Start a full lineage sync from PostgreSQL to Neo4j",
+			Description: "Returns once the sync has started; it runs in the background",
+			Response:    SyncLineageResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/lineage/stats",
+			Summary:  "Count the systems, assets, PII categories and edges of the lineage graph",
+			Response: LineageStatsResponse{},
+		},
+	}
+}
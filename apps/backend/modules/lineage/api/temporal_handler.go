@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/lineage/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TemporalHandler handles point-in-time lineage requests
+type TemporalHandler struct {
+	temporalService *service.TemporalLineageService
+}
+
+// NewTemporalHandler creates a new temporal lineage handler
+func NewTemporalHandler(temporalService *service.TemporalLineageService) *TemporalHandler {
+	return &TemporalHandler{
+		temporalService: temporalService,
+	}
+}
+
+// GetGraphAsOf handles GET /api/v1/lineage/as-of?at=2026-01-31
+// Accepts an RFC 3339 timestamp, or a date meaning the end of that day (UTC)
+func (h *TemporalHandler) GetGraphAsOf(c *gin.Context) {
+	at, err := parseAsOf(c.Query("at"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid 'at' parameter",
+			"details": "use an RFC 3339 timestamp or a YYYY-MM-DD date",
+		})
+		return
+	}
+
+	graph, err := h.temporalService.GetGraphAsOf(sharedapi.RequestContext(c), at, c.Query("system"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to retrieve point-in-time lineage",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": graph,
+		"meta": gin.H{
+			"as_of":      at,
+			"node_count": len(graph.Nodes),
+			"edge_count": len(graph.Edges),
+		},
+	})
+}
+
+// GetAssetPIIHistory handles GET /api/v1/lineage/assets/:id/history
+func (h *TemporalHandler) GetAssetPIIHistory(c *gin.Context) {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid asset ID"})
+		return
+	}
+
+	history, err := h.temporalService.GetAssetPIIHistory(sharedapi.RequestContext(c), assetID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "not found") {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": history})
+}
+
+func parseAsOf(value string) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
@@ -20,9 +20,9 @@ func MigrateToTemporalGraph(ctx context.Context, driver neo4j.Driver) error {
 	log.Println("Step 1: Adding temporal properties to ASSET_CONTAINS_PII edges...")
 	_, err := session.Run(`
 		MATCH (a:Asset)-[r:ASSET_CONTAINS_PII]->(p:PII_Category)
-		WHERE r.since IS NULL
-		SET r.since = datetime(),
-			r.until = null,
+		WHERE r.valid_from IS NULL
+		SET r.valid_from = datetime(),
+			r.valid_to = null,
 			r.first_scan_id = 'migration',
 			r.last_scan_id = 'migration'
 		RETURN count(r) as updated_count
@@ -50,28 +50,44 @@ func MigrateToTemporalGraph(ctx context.Context, driver neo4j.Driver) error {
 		log.Printf("Renamed %v edges from ASSET_CONTAINS_PII to EXPOSES\n", count)
 	}
 
-	// Step 3: Create indexes for temporal queries
-	log.Println("Step 3: Creating indexes for temporal queries...")
+	// Step 3: Move EXPOSES edges written with since/until onto valid_from/valid_to
+	log.Println("Step 3: Renaming since/until to valid_from/valid_to on EXPOSES edges...")
 	_, err = session.Run(`
-		CREATE INDEX exposes_since IF NOT EXISTS
+		MATCH (a:Asset)-[r:EXPOSES]->(p:PII_Category)
+		WHERE r.valid_from IS NULL
+		SET r.valid_from = coalesce(r.since, r.first_detected, datetime()),
+			r.valid_to = r.until
+		REMOVE r.since, r.until
+		RETURN count(r) as renamed_count
+	`, nil)
+	if err != nil {
+		return fmt.Errorf("failed to rename temporal properties: %w", err)
+	}
+	_, _ = session.Run(`DROP INDEX exposes_since IF EXISTS`, nil)
+	_, _ = session.Run(`DROP INDEX exposes_until IF EXISTS`, nil)
+
+	// Step 4: Create indexes for temporal queries
+	log.Println("Step 4: Creating indexes for temporal queries...")
+	_, err = session.Run(`
+		CREATE INDEX exposes_valid_from IF NOT EXISTS
 		FOR ()-[r:EXPOSES]-()
-		ON (r.since)
+		ON (r.valid_from)
 	`, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create since index: %w", err)
+		return fmt.Errorf("failed to create valid_from index: %w", err)
 	}
 
 	_, err = session.Run(`
-		CREATE INDEX exposes_until IF NOT EXISTS
+		CREATE INDEX exposes_valid_to IF NOT EXISTS
 		FOR ()-[r:EXPOSES]-()
-		ON (r.until)
+		ON (r.valid_to)
 	`, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create until index: %w", err)
+		return fmt.Errorf("failed to create valid_to index: %w", err)
 	}
 
-	// Step 4: Add temporal properties to System and Asset nodes
-	log.Println("Step 4: Adding created_at to System and Asset nodes...")
+	// Step 5: Add temporal properties to System and Asset nodes
+	log.Println("Step 5: Adding created_at to System and Asset nodes...")
 	_, err = session.Run(`
 		MATCH (s:System)
 		WHERE s.created_at IS NULL
@@ -115,14 +131,14 @@ func RollbackTemporalGraph(ctx context.Context, driver neo4j.Driver) error {
 	}
 
 	// Drop indexes
-	_, err = session.Run(`DROP INDEX exposes_since IF EXISTS`, nil)
+	_, err = session.Run(`DROP INDEX exposes_valid_from IF EXISTS`, nil)
 	if err != nil {
-		log.Printf("Warning: failed to drop exposes_since index: %v\n", err)
+		log.Printf("Warning: failed to drop exposes_valid_from index: %v\n", err)
 	}
 
-	_, err = session.Run(`DROP INDEX exposes_until IF EXISTS`, nil)
+	_, err = session.Run(`DROP INDEX exposes_valid_to IF EXISTS`, nil)
 	if err != nil {
-		log.Printf("Warning: failed to drop exposes_until index: %v\n", err)
+		log.Printf("Warning: failed to drop exposes_valid_to index: %v\n", err)
 	}
 
 	log.Println("Temporal graph migration rolled back successfully!")
@@ -1,11 +1,13 @@
 package lineage
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/arc-platform/backend/modules/lineage/api"
 	"github.com/arc-platform/backend/modules/lineage/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
@@ -13,9 +15,15 @@ import (
 
 type LineageModule struct {
 	semanticLineageService *service.SemanticLineageService
+	temporalService        *service.TemporalLineageService
+	blastRadiusService     *service.BlastRadiusService
 
-	graphHandler   *api.GraphHandler
-	lineageHandler *api.LineageHandlerV2
+	graphHandler       *api.GraphHandler
+	lineageHandler     *api.LineageHandlerV2
+	temporalHandler    *api.TemporalHandler
+	blastRadiusHandler *api.BlastRadiusHandler
+
+	cancelOutbox context.CancelFunc
 
 	deps *interfaces.ModuleDependencies
 }
@@ -43,9 +51,28 @@ func (m *LineageModule) Initialize(deps *interfaces.ModuleDependencies) error {
 		repo,
 		findingsProvider,
 	)
+	m.semanticLineageService.SetEventPublisher(deps.EventPublisher)
+	if deps.Config != nil {
+		m.semanticLineageService.SetWriteQueue(service.NewWriteQueue(deps.Config.Neo4jWrites))
+	}
+	m.temporalService = service.NewTemporalLineageService(deps.Neo4jRepo, repo)
+	m.blastRadiusService = service.NewBlastRadiusService(deps.Neo4jRepo, repo)
 
 	m.graphHandler = api.NewGraphHandler(m.semanticLineageService)
 	m.lineageHandler = api.NewLineageHandlerV2(m.semanticLineageService)
+	m.temporalHandler = api.NewTemporalHandler(m.temporalService)
+	m.blastRadiusHandler = api.NewBlastRadiusHandler(m.blastRadiusService)
+
+	// Replay lineage syncs deferred while the Neo4j circuit breaker was open
+	if deps.Neo4jRepo != nil {
+		interval := 0
+		if deps.Config != nil {
+			interval = deps.Config.Neo4jBreaker.OutboxIntervalSeconds
+		}
+		var outboxCtx context.Context
+		outboxCtx, m.cancelOutbox = context.WithCancel(context.Background())
+		go m.semanticLineageService.StartOutboxWorker(outboxCtx, interval)
+	}
 
 	log.Printf("✅ Lineage Module initialized")
 	return nil
@@ -54,7 +81,16 @@ func (m *LineageModule) Initialize(deps *interfaces.ModuleDependencies) error {
 func (m *LineageModule) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/lineage", m.lineageHandler.GetLineage)
 	router.GET("/lineage/stats", m.lineageHandler.GetLineageStats)
+	router.GET("/lineage/export", m.lineageHandler.ExportLineage)
 	router.POST("/lineage/sync", m.lineageHandler.SyncLineage)
+	router.GET("/lineage/write-queue", m.lineageHandler.GetWriteQueue)
+
+	// Point-in-time lineage from exposure windows on EXPOSES edges
+	router.GET("/lineage/as-of", m.temporalHandler.GetGraphAsOf)
+	router.GET("/lineage/assets/:id/history", m.temporalHandler.GetAssetPIIHistory)
+
+	// Incident response scoping of a compromised system
+	router.GET("/lineage/blast-radius", m.blastRadiusHandler.GetBlastRadius)
 
 	graph := router.Group("/graph")
 	{
@@ -64,8 +100,16 @@ func (m *LineageModule) RegisterRoutes(router *gin.RouterGroup) {
 	log.Printf("🔗 Lineage routes registered")
 }
 
+// OpenAPIOperations describes the module's routes for the OpenAPI document
+func (m *LineageModule) OpenAPIOperations() []sharedapi.Operation {
+	return api.OpenAPIOperations()
+}
+
 func (m *LineageModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Lineage Module...")
+	if m.cancelOutbox != nil {
+		m.cancelOutbox()
+	}
 	return nil
 }
 
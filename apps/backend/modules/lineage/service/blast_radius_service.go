@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// BlastRadiusHighRisk is the asset risk score from which an asset counts as high risk
+const BlastRadiusHighRisk = 70
+
+// ErrSystemNotFound is returned when a system owns no assets of the tenant
+var ErrSystemNotFound = errors.New("system not found")
+
+// BlastRadiusSummary counts what is reachable from a system
+type BlastRadiusSummary struct {
+	Assets            int   `json:"assets"`
+	PIICategories     int   `json:"pii_categories"`
+	Findings          int64 `json:"findings"`
+	DownstreamAssets  int   `json:"downstream_assets"`
+	DownstreamSystems int   `json:"downstream_systems"`
+}
+
+// BlastRadiusRisk rolls up the risk scores of the system's assets
+type BlastRadiusRisk struct {
+	MaxRiskScore     int            `json:"max_risk_score"`
+	AverageRiskScore float64        `json:"average_risk_score"`
+	HighRiskAssets   int            `json:"high_risk_assets"`
+	RiskLevels       map[string]int `json:"risk_levels"` // PII categories per risk level
+}
+
+// BlastRadiusCategory is a PII category exposed by the system
+type BlastRadiusCategory struct {
+	PIIType       string `json:"pii_type"`
+	DPDPACategory string `json:"dpdpa_category,omitempty"`
+	RiskLevel     string `json:"risk_level,omitempty"`
+	Assets        int    `json:"assets"`
+	Findings      int64  `json:"findings"`
+}
+
+// BlastRadiusAsset is an asset owned by the system with the PII it exposes
+type BlastRadiusAsset struct {
+	AssetID     uuid.UUID `json:"asset_id"`
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`
+	Environment string    `json:"environment,omitempty"`
+	RiskScore   int       `json:"risk_score"`
+	PIITypes    []string  `json:"pii_types"`
+	Findings    int64     `json:"findings"`
+}
+
+// BlastRadiusReport is everything an incident responder needs to scope a compromised system
+type BlastRadiusReport struct {
+	SystemID    string                `json:"system_id"`
+	Host        string                `json:"host"`
+	GeneratedAt time.Time             `json:"generated_at"`
+	Summary     BlastRadiusSummary    `json:"summary"`
+	Risk        BlastRadiusRisk       `json:"risk"`
+	Categories  []BlastRadiusCategory `json:"categories"`
+	Assets      []BlastRadiusAsset    `json:"assets"`
+	Downstream  []entity.ValueFlow    `json:"downstream"`
+}
+
+// BlastRadiusService scopes the exposure of a compromised system. Assets and PII
+// categories come from the lineage graph; the graph has no edges between assets, so
+// downstream flows are the other assets holding the same PII values.
+type BlastRadiusService struct {
+	neo4jRepo *persistence.Neo4jRepository
+	pgRepo    *persistence.PostgresRepository
+}
+
+// NewBlastRadiusService creates a new blast radius service
+func NewBlastRadiusService(neo4jRepo *persistence.Neo4jRepository, pgRepo *persistence.PostgresRepository) *BlastRadiusService {
+	return &BlastRadiusService{
+		neo4jRepo: neo4jRepo,
+		pgRepo:    pgRepo,
+	}
+}
+
+// GetBlastRadius returns the blast radius of a system, given by graph ID or host
+func (s *BlastRadiusService) GetBlastRadius(ctx context.Context, systemID string) (*BlastRadiusReport, error) {
+	if s.neo4jRepo == nil {
+		return nil, fmt.Errorf("neo4j repository not configured - blast radius unavailable")
+	}
+
+	exposures, err := s.neo4jRepo.GetSystemExposures(ctx, systemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to traverse system graph in neo4j: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(exposures))
+	seen := make(map[uuid.UUID]bool, len(exposures))
+	for _, e := range exposures {
+		if id, err := uuid.Parse(e.AssetID); err == nil && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, ErrSystemNotFound
+	}
+
+	// Graph nodes are not tenant-scoped; only assets PostgreSQL resolves for the tenant count
+	scores, err := s.pgRepo.GetAssetRiskScores(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) == 0 {
+		return nil, ErrSystemNotFound
+	}
+
+	owned := make([]uuid.UUID, 0, len(scores))
+	for id := range scores {
+		owned = append(owned, id)
+	}
+	flows, err := s.pgRepo.ListValueFlows(ctx, owned)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildBlastRadiusReport(exposures, scores, flows)
+	report.GeneratedAt = time.Now().UTC()
+	return report, nil
+}
+
+// buildBlastRadiusReport rolls exposures of the tenant's assets, with their risk
+// scores, and the downstream flows up into a report
+func buildBlastRadiusReport(exposures []persistence.SystemExposure, scores map[uuid.UUID]int, flows []entity.ValueFlow) *BlastRadiusReport {
+	report := &BlastRadiusReport{
+		Risk:       BlastRadiusRisk{RiskLevels: map[string]int{}},
+		Categories: []BlastRadiusCategory{},
+		Assets:     []BlastRadiusAsset{},
+		Downstream: flows,
+	}
+	if report.Downstream == nil {
+		report.Downstream = []entity.ValueFlow{}
+	}
+
+	assets := make(map[uuid.UUID]*BlastRadiusAsset)
+	categories := make(map[string]*BlastRadiusCategory)
+	for _, e := range exposures {
+		id, err := uuid.Parse(e.AssetID)
+		if err != nil {
+			continue
+		}
+		score, ok := scores[id]
+		if !ok {
+			continue
+		}
+		if report.SystemID == "" {
+			report.SystemID, report.Host = e.SystemID, e.SystemHost
+		}
+
+		asset, ok := assets[id]
+		if !ok {
+			asset = &BlastRadiusAsset{AssetID: id, Name: e.AssetName, Path: e.AssetPath, Environment: e.Environment, RiskScore: score, PIITypes: []string{}}
+			assets[id] = asset
+		}
+		if e.PIIType == "" {
+			continue
+		}
+		asset.PIITypes = append(asset.PIITypes, e.PIIType)
+		asset.Findings += e.FindingCount
+
+		category, ok := categories[e.PIIType]
+		if !ok {
+			category = &BlastRadiusCategory{PIIType: e.PIIType, DPDPACategory: e.DPDPACategory, RiskLevel: e.RiskLevel}
+			categories[e.PIIType] = category
+		}
+		category.Assets++
+		category.Findings += e.FindingCount
+	}
+
+	totalRisk := 0
+	for _, asset := range assets {
+		sort.Strings(asset.PIITypes)
+		report.Assets = append(report.Assets, *asset)
+		report.Summary.Findings += asset.Findings
+		totalRisk += asset.RiskScore
+		if asset.RiskScore > report.Risk.MaxRiskScore {
+			report.Risk.MaxRiskScore = asset.RiskScore
+		}
+		if asset.RiskScore >= BlastRadiusHighRisk {
+			report.Risk.HighRiskAssets++
+		}
+	}
+	for _, category := range categories {
+		report.Categories = append(report.Categories, *category)
+		if category.RiskLevel != "" {
+			report.Risk.RiskLevels[category.RiskLevel]++
+		}
+	}
+
+	sort.Slice(report.Assets, func(i, j int) bool {
+		if report.Assets[i].RiskScore != report.Assets[j].RiskScore {
+			return report.Assets[i].RiskScore > report.Assets[j].RiskScore
+		}
+		return report.Assets[i].Name < report.Assets[j].Name
+	})
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if report.Categories[i].Assets != report.Categories[j].Assets {
+			return report.Categories[i].Assets > report.Categories[j].Assets
+		}
+		if report.Categories[i].Findings != report.Categories[j].Findings {
+			return report.Categories[i].Findings > report.Categories[j].Findings
+		}
+		return report.Categories[i].PIIType < report.Categories[j].PIIType
+	})
+
+	report.Summary.Assets = len(report.Assets)
+	report.Summary.PIICategories = len(report.Categories)
+	if len(report.Assets) > 0 {
+		report.Risk.AverageRiskScore = math.Round(float64(totalRisk)/float64(len(report.Assets))*10) / 10
+	}
+
+	// Graph systems are keyed by host; flows from assets without one fall back to their source system
+	systems := make(map[string]bool)
+	for _, flow := range report.Downstream {
+		system := flow.Host
+		if system == "" {
+			system = flow.System
+		}
+		if system != "" && system != report.Host {
+			systems[system] = true
+		}
+	}
+	report.Summary.DownstreamAssets = len(report.Downstream)
+	report.Summary.DownstreamSystems = len(systems)
+	return report
+}
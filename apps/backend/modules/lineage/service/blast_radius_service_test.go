@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+func TestBuildBlastRadiusReport(t *testing.T) {
+	users, payments, clean, foreign := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	exposure := func(id uuid.UUID, name, piiType, risk string, findings int64) persistence.SystemExposure {
+		return persistence.SystemExposure{
+			SystemID: "system-db01", SystemHost: "db01", AssetID: id.String(), AssetName: name,
+			PIIType: piiType, RiskLevel: risk, FindingCount: findings,
+		}
+	}
+	exposures := []persistence.SystemExposure{
+		exposure(users, "users", "EMAIL_ADDRESS", "Medium", 10),
+		exposure(users, "users", "IN_AADHAAR", "Critical", 4),
+		exposure(payments, "payments", "IN_AADHAAR", "Critical", 6),
+		exposure(clean, "logs", "", "", 0),
+		// An asset of another tenant sharing the graph node is not reported
+		exposure(foreign, "other", "IN_PAN", "High", 50),
+	}
+	scores := map[uuid.UUID]int{users: 60, payments: 90, clean: 0}
+	flows := []entity.ValueFlow{
+		{AssetID: uuid.New(), Host: "crm01", SharedValues: 3},
+		{AssetID: uuid.New(), Host: "crm01", SharedValues: 2},
+		{AssetID: uuid.New(), System: "s3", SharedValues: 1},
+	}
+
+	report := buildBlastRadiusReport(exposures, scores, flows)
+
+	if report.SystemID != "system-db01" || report.Host != "db01" {
+		t.Errorf("unexpected system: %s %s", report.SystemID, report.Host)
+	}
+	want := BlastRadiusSummary{Assets: 3, PIICategories: 2, Findings: 20, DownstreamAssets: 3, DownstreamSystems: 2}
+	if report.Summary != want {
+		t.Errorf("unexpected summary: %+v", report.Summary)
+	}
+	if report.Risk.MaxRiskScore != 90 || report.Risk.AverageRiskScore != 50 || report.Risk.HighRiskAssets != 1 {
+		t.Errorf("unexpected risk rollup: %+v", report.Risk)
+	}
+	if report.Risk.RiskLevels["Critical"] != 1 || report.Risk.RiskLevels["Medium"] != 1 {
+		t.Errorf("unexpected risk levels: %v", report.Risk.RiskLevels)
+	}
+
+	if report.Assets[0].AssetID != payments || len(report.Assets[1].PIITypes) != 2 || len(report.Assets[2].PIITypes) != 0 {
+		t.Errorf("assets should be ordered by risk: %+v", report.Assets)
+	}
+	if aadhaar := report.Categories[0]; aadhaar.PIIType != "IN_AADHAAR" || aadhaar.Assets != 2 || aadhaar.Findings != 10 {
+		t.Errorf("unexpected top category: %+v", aadhaar)
+	}
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// Kinds of drift between an asset in PostgreSQL and its lineage in Neo4j
+const (
+	DriftMissingNode      = "missing_node"      // No Asset node in the graph
+	DriftPropertyMismatch = "property_mismatch" // Node properties differ from the asset row
+	DriftMissingExposure  = "missing_exposure"  // A detected PII type has no open EXPOSES edge
+	DriftStaleExposure    = "stale_exposure"    // An open EXPOSES edge for a PII type no longer detected
+)
+
+// consistencyBatchSize bounds the asset IDs sent to Neo4j in one query
+const consistencyBatchSize = 500
+
+// PropertyDrift is one node property that differs from PostgreSQL
+type PropertyDrift struct {
+	Property string      `json:"property"`
+	Postgres interface{} `json:"postgres"`
+	Neo4j    interface{} `json:"neo4j"`
+}
+
+// AssetDrift describes how one asset's lineage differs from PostgreSQL
+type AssetDrift struct {
+	AssetID         uuid.UUID       `json:"asset_id"`
+	AssetName       string          `json:"asset_name"`
+	Kinds           []string        `json:"kinds"`
+	Properties      []PropertyDrift `json:"properties,omitempty"`
+	MissingPIITypes []string        `json:"missing_pii_types,omitempty"`
+	StalePIITypes   []string        `json:"stale_pii_types,omitempty"`
+	Requeued        bool            `json:"requeued"`
+}
+
+// TenantConsistencyReport compares one tenant's assets and PII categories across both stores
+type TenantConsistencyReport struct {
+	TenantID              uuid.UUID    `json:"tenant_id"`
+	PostgresAssets        int          `json:"postgres_assets"`
+	GraphAssets           int          `json:"graph_assets"`
+	PostgresPIICategories int          `json:"postgres_pii_categories"`
+	GraphPIICategories    int          `json:"graph_pii_categories"`
+	Drifted               []AssetDrift `json:"drifted"`
+	Requeued              int          `json:"requeued"`
+}
+
+// ConsistencyReport is the drift report of a consistency check
+type ConsistencyReport struct {
+	GeneratedAt      time.Time                  `json:"generated_at"`
+	Repair           bool                       `json:"repair"`
+	Tenants          []*TenantConsistencyReport `json:"tenants"`
+	DriftedAssets    int                        `json:"drifted_assets"`
+	Requeued         int                        `json:"requeued"`
+	OrphanAssetNodes []string                   `json:"orphan_asset_nodes"` // Graph assets with no live PostgreSQL row
+	Errors           []string                   `json:"errors,omitempty"`
+}
+
+// HasDrift reports whether the check found anything out of sync
+func (r *ConsistencyReport) HasDrift() bool {
+	return r.DriftedAssets > 0 || len(r.OrphanAssetNodes) > 0
+}
+
+// ConsistencyOptions selects what a consistency check covers
+type ConsistencyOptions struct {
+	TenantIDs []uuid.UUID // Empty checks every tenant with assets
+	Repair    bool        // Queue drifted assets in the lineage sync outbox
+	Orphans   bool        // Also look for graph assets missing from PostgreSQL (graph-wide)
+}
+
+// ConsistencyChecker compares the Neo4j lineage graph against the authoritative PostgreSQL data
+type ConsistencyChecker struct {
+	neo4jRepo *persistence.Neo4jRepository
+	pgRepo    *persistence.PostgresRepository
+}
+
+// NewConsistencyChecker creates a new consistency checker
+func NewConsistencyChecker(neo4jRepo *persistence.Neo4jRepository, pgRepo *persistence.PostgresRepository) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		neo4jRepo: neo4jRepo,
+		pgRepo:    pgRepo,
+	}
+}
+
+// Check compares each selected tenant and, with Repair, re-queues drifted assets for sync.
+// A tenant that cannot be checked is reported in Errors and the rest still run.
+func (c *ConsistencyChecker) Check(ctx context.Context, opts ConsistencyOptions) (*ConsistencyReport, error) {
+	tenantIDs := opts.TenantIDs
+	if len(tenantIDs) == 0 {
+		var err error
+		if tenantIDs, err = c.pgRepo.ListAssetTenantIDs(ctx); err != nil {
+			return nil, fmt.Errorf("failed to list tenants: %w", err)
+		}
+	}
+
+	report := &ConsistencyReport{
+		GeneratedAt:      time.Now(),
+		Repair:           opts.Repair,
+		Tenants:          []*TenantConsistencyReport{},
+		OrphanAssetNodes: []string{},
+	}
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		tenantReport, err := c.CheckTenant(tenantCtx, opts.Repair)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("tenant %s: %v", tenantID, err))
+			continue
+		}
+		report.Tenants = append(report.Tenants, tenantReport)
+		report.DriftedAssets += len(tenantReport.Drifted)
+		report.Requeued += tenantReport.Requeued
+	}
+
+	if opts.Orphans {
+		orphans, err := c.orphanAssetNodes(ctx)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("orphan check: %v", err))
+		} else {
+			report.OrphanAssetNodes = orphans
+		}
+	}
+
+	return report, nil
+}
+
+// CheckTenant compares the assets of the context's tenant
+func (c *ConsistencyChecker) CheckTenant(ctx context.Context, repair bool) (*TenantConsistencyReport, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := c.pgRepo.ListAssetLineageStates(ctx, MinLineageConfidence)
+	if err != nil {
+		return nil, err
+	}
+
+	actual := make(map[string]*entity.AssetLineageState, len(expected))
+	for start := 0; start < len(expected); start += consistencyBatchSize {
+		end := start + consistencyBatchSize
+		if end > len(expected) {
+			end = len(expected)
+		}
+		ids := make([]string, 0, end-start)
+		for _, state := range expected[start:end] {
+			ids = append(ids, state.AssetID.String())
+		}
+
+		states, err := c.neo4jRepo.GetAssetLineageStates(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read lineage graph: %w", err)
+		}
+		for id, state := range states {
+			actual[id] = state
+		}
+	}
+
+	report := &TenantConsistencyReport{
+		TenantID:       tenantID,
+		PostgresAssets: len(expected),
+		GraphAssets:    len(actual),
+		Drifted:        []AssetDrift{},
+	}
+	pgCategories := map[string]bool{}
+	graphCategories := map[string]bool{}
+	for _, state := range expected {
+		for _, piiType := range state.PIITypes {
+			pgCategories[piiType] = true
+		}
+		graphState := actual[state.AssetID.String()]
+		if graphState != nil {
+			for _, piiType := range graphState.PIITypes {
+				graphCategories[piiType] = true
+			}
+		}
+
+		drift := diffAssetLineage(state, graphState)
+		if drift == nil {
+			continue
+		}
+		if repair {
+			reason := "consistency check: " + strings.Join(drift.Kinds, ", ")
+			if err := c.pgRepo.EnqueueLineageSync(ctx, state.AssetID, reason); err != nil {
+				return nil, err
+			}
+			drift.Requeued = true
+			report.Requeued++
+		}
+		report.Drifted = append(report.Drifted, *drift)
+	}
+	report.PostgresPIICategories = len(pgCategories)
+	report.GraphPIICategories = len(graphCategories)
+
+	return report, nil
+}
+
+// orphanAssetNodes returns graph assets whose PostgreSQL asset is deleted or missing.
+// Re-syncing cannot remove them, so they are only reported.
+func (c *ConsistencyChecker) orphanAssetNodes(ctx context.Context) ([]string, error) {
+	ids, err := c.neo4jRepo.ListAssetNodeIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list graph assets: %w", err)
+	}
+
+	orphans := []string{}
+	for start := 0; start < len(ids); start += consistencyBatchSize {
+		end := start + consistencyBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		live, err := c.pgRepo.FilterLiveAssetIDs(ctx, ids[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids[start:end] {
+			if !live[id] {
+				orphans = append(orphans, id)
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// diffAssetLineage compares an asset row against its graph node; actual is nil when the
+// node is missing. Returns nil when they agree.
+func diffAssetLineage(expected, actual *entity.AssetLineageState) *AssetDrift {
+	drift := &AssetDrift{AssetID: expected.AssetID, AssetName: expected.Name}
+
+	if actual == nil {
+		drift.Kinds = []string{DriftMissingNode}
+		drift.MissingPIITypes = expected.PIITypes
+		return drift
+	}
+
+	properties := []PropertyDrift{
+		{"name", expected.Name, actual.Name},
+		{"host", expected.Host, actual.Host},
+		{"environment", expected.Environment, actual.Environment},
+		{"risk_score", expected.RiskScore, actual.RiskScore},
+		{"total_findings", expected.TotalFindings, actual.TotalFindings},
+	}
+	for _, property := range properties {
+		if property.Postgres != property.Neo4j {
+			drift.Properties = append(drift.Properties, property)
+		}
+	}
+	if len(drift.Properties) > 0 {
+		drift.Kinds = append(drift.Kinds, DriftPropertyMismatch)
+	}
+
+	drift.MissingPIITypes = stringsMissing(expected.PIITypes, actual.PIITypes)
+	if len(drift.MissingPIITypes) > 0 {
+		drift.Kinds = append(drift.Kinds, DriftMissingExposure)
+	}
+	drift.StalePIITypes = stringsMissing(actual.PIITypes, expected.PIITypes)
+	if len(drift.StalePIITypes) > 0 {
+		drift.Kinds = append(drift.Kinds, DriftStaleExposure)
+	}
+
+	if len(drift.Kinds) == 0 {
+		return nil
+	}
+	return drift
+}
+
+// stringsMissing returns the values of want that are not in have, sorted
+func stringsMissing(want, have []string) []string {
+	present := make(map[string]bool, len(have))
+	for _, v := range have {
+		present[v] = true
+	}
+	var missing []string
+	for _, v := range want {
+		if !present[v] {
+			missing = append(missing, v)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func lineageState(piiTypes ...string) *entity.AssetLineageState {
+	return &entity.AssetLineageState{
+		AssetID:       uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Name:          "customers.csv",
+		Host:          "fileserver-01",
+		Environment:   "production",
+		RiskScore:     80,
+		TotalFindings: 12,
+		PIITypes:      piiTypes,
+	}
+}
+
+func TestDiffAssetLineageInSync(t *testing.T) {
+	if drift := diffAssetLineage(lineageState("EMAIL_ADDRESS", "IN_PAN"), lineageState("EMAIL_ADDRESS", "IN_PAN")); drift != nil {
+		t.Fatalf("expected no drift, got %+v", drift)
+	}
+}
+
+func TestDiffAssetLineageMissingNode(t *testing.T) {
+	drift := diffAssetLineage(lineageState("IN_PAN"), nil)
+	if drift == nil || !reflect.DeepEqual(drift.Kinds, []string{DriftMissingNode}) {
+		t.Fatalf("expected missing node drift, got %+v", drift)
+	}
+	if !reflect.DeepEqual(drift.MissingPIITypes, []string{"IN_PAN"}) {
+		t.Errorf("missing PII types = %v, want [IN_PAN]", drift.MissingPIITypes)
+	}
+}
+
+func TestDiffAssetLineageReportsEveryKind(t *testing.T) {
+	actual := lineageState("CREDIT_CARD", "EMAIL_ADDRESS")
+	actual.Host = "fileserver-02"
+	actual.TotalFindings = 9
+
+	drift := diffAssetLineage(lineageState("EMAIL_ADDRESS", "IN_AADHAAR", "IN_PAN"), actual)
+	if drift == nil {
+		t.Fatal("expected drift")
+	}
+
+	wantKinds := []string{DriftPropertyMismatch, DriftMissingExposure, DriftStaleExposure}
+	if !reflect.DeepEqual(drift.Kinds, wantKinds) {
+		t.Errorf("kinds = %v, want %v", drift.Kinds, wantKinds)
+	}
+	wantProperties := []PropertyDrift{
+		{"host", "fileserver-01", "fileserver-02"},
+		{"total_findings", 12, 9},
+	}
+	if !reflect.DeepEqual(drift.Properties, wantProperties) {
+		t.Errorf("properties = %+v, want %+v", drift.Properties, wantProperties)
+	}
+	if !reflect.DeepEqual(drift.MissingPIITypes, []string{"IN_AADHAAR", "IN_PAN"}) {
+		t.Errorf("missing PII types = %v", drift.MissingPIITypes)
+	}
+	if !reflect.DeepEqual(drift.StalePIITypes, []string{"CREDIT_CARD"}) {
+		t.Errorf("stale PII types = %v", drift.StalePIITypes)
+	}
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Graph export formats
+const (
+	ExportFormatGraphML   = "graphml"
+	ExportFormatCytoscape = "cytoscape"
+)
+
+// exportFlushEvery is how many elements are written between flushes, so large
+// graphs reach the client while they are still being serialized
+const exportFlushEvery = 500
+
+// ExportContentType returns the media type and file extension of an export format
+func ExportContentType(format string) (contentType, extension string, err error) {
+	switch format {
+	case ExportFormatGraphML:
+		return "application/graphml+xml", "graphml", nil
+	case ExportFormatCytoscape:
+		return "application/json", "cyjs", nil
+	default:
+		return "", "", fmt.Errorf("invalid export format %q: use %s or %s", format, ExportFormatGraphML, ExportFormatCytoscape)
+	}
+}
+
+// WriteGraph serializes a semantic graph in the given format
+func WriteGraph(w io.Writer, format string, graph *SemanticGraph) error {
+	switch format {
+	case ExportFormatGraphML:
+		return WriteGraphML(w, graph)
+	case ExportFormatCytoscape:
+		return WriteCytoscapeJSON(w, graph)
+	default:
+		_, _, err := ExportContentType(format)
+		return err
+	}
+}
+
+// graphMLKey declares one node or edge attribute of a GraphML document
+type graphMLKey struct {
+	id, domain, name, attrType string
+}
+
+// WriteGraphML serializes a semantic graph as GraphML. Node and edge types and
+// labels become attributes, as does every metadata key; nested metadata values
+// are written as JSON strings.
+func WriteGraphML(w io.Writer, graph *SemanticGraph) error {
+	nodeKeys := collectGraphMLKeys("node", nodeMetadata(graph.Nodes), "type", "label")
+	edgeKeys := collectGraphMLKeys("edge", edgeMetadata(graph.Edges), "type")
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	root := xml.StartElement{Name: xml.Name{Local: "graphml"}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: "xmlns"}, Value: "http://graphml.graphdrawing.org/xmlns"},
+	}}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+
+	fixed := []graphMLKey{
+		{id: "n_type", domain: "node", name: "type", attrType: "string"},
+		{id: "n_label", domain: "node", name: "label", attrType: "string"},
+		{id: "e_type", domain: "edge", name: "type", attrType: "string"},
+	}
+	for _, key := range append(append(fixed, nodeKeys...), edgeKeys...) {
+		if err := enc.EncodeElement(struct{}{}, xml.StartElement{Name: xml.Name{Local: "key"}, Attr: []xml.Attr{
+			{Name: xml.Name{Local: "id"}, Value: key.id},
+			{Name: xml.Name{Local: "for"}, Value: key.domain},
+			{Name: xml.Name{Local: "attr.name"}, Value: key.name},
+			{Name: xml.Name{Local: "attr.type"}, Value: key.attrType},
+		}}); err != nil {
+			return err
+		}
+	}
+
+	graphEl := xml.StartElement{Name: xml.Name{Local: "graph"}, Attr: []xml.Attr{
+		{Name: xml.Name{Local: "id"}, Value: "lineage"},
+		{Name: xml.Name{Local: "edgedefault"}, Value: "directed"},
+	}}
+	if err := enc.EncodeToken(graphEl); err != nil {
+		return err
+	}
+
+	written := 0
+	for _, node := range graph.Nodes {
+		data := []graphMLData{{Key: "n_type", Value: node.Type}, {Key: "n_label", Value: node.Label}}
+		data = append(data, graphMLMetadata("n_", nodeKeys, node.Metadata)...)
+		if err := enc.EncodeElement(graphMLElement{ID: node.ID, Data: data}, xml.StartElement{Name: xml.Name{Local: "node"}}); err != nil {
+			return err
+		}
+		if written++; written%exportFlushEvery == 0 {
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, edge := range graph.Edges {
+		data := []graphMLData{{Key: "e_type", Value: edge.Type}}
+		data = append(data, graphMLMetadata("e_", edgeKeys, edge.Metadata)...)
+		el := graphMLElement{ID: edge.ID, Source: edge.Source, Target: edge.Target, Data: data}
+		if err := enc.EncodeElement(el, xml.StartElement{Name: xml.Name{Local: "edge"}}); err != nil {
+			return err
+		}
+		if written++; written%exportFlushEvery == 0 {
+			if err := enc.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := enc.EncodeToken(graphEl.End()); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+type graphMLElement struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr,omitempty"`
+	Target string        `xml:"target,attr,omitempty"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func nodeMetadata(nodes []SemanticNode) []map[string]interface{} {
+	metadata := make([]map[string]interface{}, len(nodes))
+	for i, node := range nodes {
+		metadata[i] = node.Metadata
+	}
+	return metadata
+}
+
+func edgeMetadata(edges []SemanticEdge) []map[string]interface{} {
+	metadata := make([]map[string]interface{}, len(edges))
+	for i, edge := range edges {
+		metadata[i] = edge.Metadata
+	}
+	return metadata
+}
+
+// collectGraphMLKeys declares a key per metadata name other than the reserved
+// ones, typed by its values; names holding values of different types are
+// declared as strings
+func collectGraphMLKeys(domain string, metadata []map[string]interface{}, reserved ...string) []graphMLKey {
+	skip := make(map[string]bool, len(reserved))
+	for _, name := range reserved {
+		skip[name] = true
+	}
+	types := make(map[string]string)
+	for _, m := range metadata {
+		for name, value := range m {
+			if skip[name] {
+				continue
+			}
+			if value == nil {
+				if _, ok := types[name]; !ok {
+					types[name] = ""
+				}
+				continue
+			}
+			t := graphMLType(value)
+			if seen, ok := types[name]; ok && seen != "" && seen != t {
+				t = "string"
+			}
+			types[name] = t
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]graphMLKey, 0, len(names))
+	for _, name := range names {
+		attrType := types[name]
+		if attrType == "" {
+			attrType = "string"
+		}
+		keys = append(keys, graphMLKey{id: domain[:1] + "_" + name, domain: domain, name: name, attrType: attrType})
+	}
+	return keys
+}
+
+func graphMLType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case int, int32, int64:
+		return "long"
+	case float32, float64:
+		return "double"
+	default:
+		return "string"
+	}
+}
+
+// graphMLMetadata writes an element's metadata as data of the declared keys, in key order
+func graphMLMetadata(prefix string, keys []graphMLKey, metadata map[string]interface{}) []graphMLData {
+	data := make([]graphMLData, 0, len(metadata))
+	for _, key := range keys {
+		value, ok := metadata[key.name]
+		if !ok || value == nil {
+			continue
+		}
+		data = append(data, graphMLData{Key: prefix + key.name, Value: graphMLValue(value)})
+	}
+	return data
+}
+
+func graphMLValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int, int32, int64, float32, float64:
+		return fmt.Sprint(v)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// WriteCytoscapeJSON serializes a semantic graph in the Cytoscape.js elements
+// format. Metadata keys sit beside id, label, type, source and target in each
+// element's data, which they never override.
+func WriteCytoscapeJSON(w io.Writer, graph *SemanticGraph) error {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(`{"format_version":"1.0","generated_by":"arc-hawk","target_cytoscapejs_version":"~3","data":{"name":"lineage"},"elements":{"nodes":[`); err != nil {
+		return err
+	}
+
+	written := 0
+	writeElement := func(first bool, data map[string]interface{}) error {
+		encoded, err := json.Marshal(map[string]interface{}{"data": data})
+		if err != nil {
+			return err
+		}
+		if !first {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		if _, err := bw.Write(encoded); err != nil {
+			return err
+		}
+		if written++; written%exportFlushEvery == 0 {
+			return bw.Flush()
+		}
+		return nil
+	}
+
+	for i, node := range graph.Nodes {
+		data := cytoscapeData(node.Metadata)
+		data["id"], data["label"], data["type"] = node.ID, node.Label, node.Type
+		if err := writeElement(i == 0, data); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.WriteString(`],"edges":[`); err != nil {
+		return err
+	}
+	for i, edge := range graph.Edges {
+		data := cytoscapeData(edge.Metadata)
+		data["id"], data["source"], data["target"], data["type"] = edge.ID, edge.Source, edge.Target, edge.Type
+		if err := writeElement(i == 0, data); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.WriteString("]}}\n"); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func cytoscapeData(metadata map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{}, len(metadata)+4)
+	for k, v := range metadata {
+		data[k] = v
+	}
+	return data
+}
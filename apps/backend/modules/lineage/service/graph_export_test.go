@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func exportGraph(assets int) *SemanticGraph {
+	graph := &SemanticGraph{Nodes: []SemanticNode{
+		{ID: "system-fs01", Type: "system", Label: "fs01 <prod>", Metadata: map[string]interface{}{"host": "fs01"}},
+		{ID: "pii-IN_PAN", Type: "pii_category", Label: "IN_PAN", Metadata: map[string]interface{}{
+			"pii_type": "IN_PAN", "finding_count": int64(4), "avg_confidence": 0.9, "type": "ignored",
+		}},
+	}}
+	for i := 0; i < assets; i++ {
+		id := fmt.Sprintf("asset-%d", i)
+		graph.Nodes = append(graph.Nodes, SemanticNode{ID: id, Type: "asset", Label: "/data/file.csv", Metadata: map[string]interface{}{
+			"risk_score": int64(80), "tags": []string{"pii", "prod"},
+		}})
+		graph.Edges = append(graph.Edges,
+			SemanticEdge{ID: "owns-" + id, Source: "system-fs01", Target: id, Type: "SYSTEM_OWNS_ASSET"},
+			SemanticEdge{ID: "exposes-" + id, Source: id, Target: "pii-IN_PAN", Type: "EXPOSES", Metadata: map[string]interface{}{"count": int64(2)}},
+		)
+	}
+	return graph
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraph(&buf, ExportFormatGraphML, exportGraph(exportFlushEvery)); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Keys []struct {
+			ID   string `xml:"id,attr"`
+			For  string `xml:"for,attr"`
+			Name string `xml:"attr.name,attr"`
+			Type string `xml:"attr.type,attr"`
+		} `xml:"key"`
+		Graph struct {
+			Nodes []struct {
+				ID   string `xml:"id,attr"`
+				Data []struct {
+					Key   string `xml:"key,attr"`
+					Value string `xml:",chardata"`
+				} `xml:"data"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("export is not valid XML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != exportFlushEvery+2 || len(doc.Graph.Edges) != 2*exportFlushEvery {
+		t.Fatalf("expected every node and edge, got %d nodes and %d edges", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+
+	types := make(map[string]string)
+	for _, key := range doc.Keys {
+		types[key.ID] = key.Type
+	}
+	for id, want := range map[string]string{"n_type": "string", "n_finding_count": "long", "n_avg_confidence": "double", "n_tags": "string", "e_count": "long"} {
+		if types[id] != want {
+			t.Errorf("expected key %s of type %s, got %q", id, want, types[id])
+		}
+	}
+	if strings.Count(buf.String(), `id="n_type"`) != 1 {
+		t.Error("expected metadata named type not to be declared again")
+	}
+	if label := doc.Graph.Nodes[0].Data[1]; label.Key != "n_label" || label.Value != "fs01 <prod>" {
+		t.Errorf("expected the label to survive escaping, got %+v", label)
+	}
+}
+
+func TestWriteCytoscapeJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGraph(&buf, ExportFormatCytoscape, exportGraph(3)); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Elements struct {
+			Nodes []struct{ Data map[string]interface{} } `json:"nodes"`
+			Edges []struct{ Data map[string]interface{} } `json:"edges"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("export is not valid JSON: %v", err)
+	}
+	if len(doc.Elements.Nodes) != 5 || len(doc.Elements.Edges) != 6 {
+		t.Fatalf("expected 5 nodes and 6 edges, got %d and %d", len(doc.Elements.Nodes), len(doc.Elements.Edges))
+	}
+	if data := doc.Elements.Nodes[1].Data; data["type"] != "pii_category" || data["pii_type"] != "IN_PAN" {
+		t.Errorf("expected metadata beside the node type, which it must not override: %v", data)
+	}
+	if data := doc.Elements.Edges[1].Data; data["source"] != "asset-0" || data["target"] != "pii-IN_PAN" || data["count"] != float64(2) {
+		t.Errorf("unexpected edge data %v", data)
+	}
+
+	empty := &bytes.Buffer{}
+	if err := WriteCytoscapeJSON(empty, &SemanticGraph{}); err != nil || !json.Valid(empty.Bytes()) {
+		t.Errorf("expected an empty graph to export as valid JSON, got %q (%v)", empty.String(), err)
+	}
+	if err := WriteGraph(empty, "dot", &SemanticGraph{}); err == nil {
+		t.Error("expected an unknown format to be refused")
+	}
+}
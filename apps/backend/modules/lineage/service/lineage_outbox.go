@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/circuitbreaker"
+)
+
+// outboxBatchSize bounds how many deferred syncs are replayed per pass
+const outboxBatchSize = 100
+
+// OutboxDrainResult summarises one pass over the lineage sync outbox
+type OutboxDrainResult struct {
+	Synced  int `json:"synced"`
+	Failed  int `json:"failed"`
+	Pending int `json:"pending"`
+}
+
+// StartOutboxWorker periodically replays lineage syncs deferred while Neo4j was unavailable
+func (s *SemanticLineageService) StartOutboxWorker(ctx context.Context, intervalSeconds int) {
+	if intervalSeconds < 1 {
+		intervalSeconds = 30
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Printf("📮 Starting lineage outbox worker (interval: %ds)", intervalSeconds)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Lineage outbox worker stopped")
+			return
+		case <-ticker.C:
+			result, err := s.DrainOutbox(ctx)
+			if err != nil {
+				log.Printf("❌ Lineage outbox drain failed: %v", err)
+				continue
+			}
+			if result.Synced > 0 || result.Failed > 0 {
+				log.Printf("📮 Lineage outbox: %d synced, %d failed, %d still pending",
+					result.Synced, result.Failed, result.Pending)
+			}
+		}
+	}
+}
+
+// DrainOutbox replays one batch of deferred syncs. It stops early when the
+// Neo4j circuit breaker is not letting calls through or the context ends.
+func (s *SemanticLineageService) DrainOutbox(ctx context.Context) (*OutboxDrainResult, error) {
+	result := &OutboxDrainResult{}
+	if s.neo4jRepo == nil || !s.neo4jRepo.IsAvailable() {
+		return result, nil
+	}
+
+	entries, err := s.pgRepo.ListPendingLineageSyncs(ctx, outboxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		tenantCtx := context.WithValue(ctx, "tenant_id", entry.TenantID)
+
+		// Replays take the lowest lane, behind real-time syncs and resyncs
+		err := s.writes.Do(tenantCtx, LaneReconcile, func(ctx context.Context) error {
+			return s.syncAsset(ctx, entry.AssetID)
+		})
+		if err != nil {
+			if errors.Is(err, circuitbreaker.ErrOpen) || ctx.Err() != nil {
+				// Neo4j went away again, or the worker is stopping; leave the rest queued untouched
+				break
+			}
+			result.Failed++
+			if recordErr := s.pgRepo.RecordLineageSyncFailure(ctx, entry.ID, err.Error()); recordErr != nil {
+				log.Printf("WARN: Failed to record lineage outbox failure for asset %s: %v", entry.AssetID, recordErr)
+			}
+			continue
+		}
+
+		result.Synced++
+		if err := s.pgRepo.CompleteLineageSync(ctx, entry.ID, entry.RequestedAt); err != nil {
+			log.Printf("WARN: Failed to remove synced asset %s from lineage outbox: %v", entry.AssetID, err)
+		}
+	}
+
+	pending, _, err := s.pgRepo.GetLineageOutboxStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Pending = pending
+	return result, nil
+}
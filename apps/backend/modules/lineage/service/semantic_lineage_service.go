@@ -2,19 +2,30 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/circuitbreaker"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/canonical"
 	"github.com/google/uuid"
 )
 
+// MinLineageConfidence is the lowest classification confidence synced to the lineage graph
+const MinLineageConfidence = 0.45
+
 // SemanticLineageService builds aggregated semantic lineage graphs
 // Implements LineageSync interface
 type SemanticLineageService struct {
 	neo4jRepo        *persistence.Neo4jRepository
 	pgRepo           *persistence.PostgresRepository
 	findingsProvider interfaces.FindingsProvider
+	events           interfaces.EventPublisher
+	writes           *WriteQueue
 }
 
 // NewSemanticLineageService creates a new semantic lineage service
@@ -27,6 +38,27 @@ func NewSemanticLineageService(
 		neo4jRepo:        neo4jRepo,
 		pgRepo:           pgRepo,
 		findingsProvider: findingsProvider,
+		events:           &interfaces.NoOpEventPublisher{},
+		writes:           DefaultWriteQueue(),
+	}
+}
+
+// SetWriteQueue replaces the default limits on concurrent Neo4j writes
+func (s *SemanticLineageService) SetWriteQueue(writes *WriteQueue) {
+	if writes != nil {
+		s.writes = writes
+	}
+}
+
+// WriteQueueStats reports the load of the Neo4j write queue per lane
+func (s *SemanticLineageService) WriteQueueStats() WriteQueueStats {
+	return s.writes.Stats()
+}
+
+// SetEventPublisher enables live sync completion events
+func (s *SemanticLineageService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
 	}
 }
 
@@ -66,9 +98,79 @@ type SemanticGraph struct {
 }
 
 // SyncAssetToNeo4j syncs an asset and its findings to Neo4j (3-level hierarchy - Frozen Semantic Contract)
+// While the Neo4j circuit breaker is open, or too many real-time syncs are waiting for the
+// write queue, the sync is deferred to the outbox instead of failing.
+// Implements LineageSync interface
+func (s *SemanticLineageService) SyncAssetToNeo4j(ctx context.Context, assetID uuid.UUID) error {
+	_, err := s.syncOrDefer(ctx, assetID, LaneRealtime)
+	return err
+}
+
+// MergeAssetNodes drops the merged-away asset's node and resyncs the surviving asset.
+// The surviving asset's sync is deferred like any other while Neo4j is unavailable;
+// the stale source node is left for the caller to report since the outbox only replays existing assets.
+// Implements LineageSync interface
+func (s *SemanticLineageService) MergeAssetNodes(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	if s.neo4jRepo != nil {
+		err := s.writes.Do(ctx, LaneRealtime, func(ctx context.Context) error {
+			return s.neo4jRepo.DeleteAssetNode(ctx, sourceID.String())
+		})
+		if err != nil {
+			if deferErr := s.deferSync(ctx, targetID, "asset merge: "+err.Error()); deferErr != nil {
+				return deferErr
+			}
+			return fmt.Errorf("failed to delete merged asset node %s: %w", sourceID, err)
+		}
+	}
+	return s.SyncAssetToNeo4j(ctx, targetID)
+}
+
+// RemoveAssetNode detaches and deletes a deleted asset's node. Unlike syncs it is not
+// deferred while Neo4j is unavailable, since the outbox only replays existing assets.
+// Implements LineageSync interface
+func (s *SemanticLineageService) RemoveAssetNode(ctx context.Context, assetID uuid.UUID) error {
+	if s.neo4jRepo == nil {
+		return nil
+	}
+	err := s.writes.Do(ctx, LaneRealtime, func(ctx context.Context) error {
+		return s.neo4jRepo.DeleteAssetNode(ctx, assetID.String())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete asset node %s: %w", assetID, err)
+	}
+	return nil
+}
+
+// syncOrDefer syncs an asset through the write queue lane, or queues it in the outbox
+// when Neo4j is unavailable or the lane is full. deferred reports whether the sync
+// was queued rather than performed.
+func (s *SemanticLineageService) syncOrDefer(ctx context.Context, assetID uuid.UUID, lane WriteLane) (deferred bool, err error) {
+	if s.neo4jRepo != nil && !s.neo4jRepo.IsAvailable() {
+		return true, s.deferSync(ctx, assetID, "neo4j circuit breaker open")
+	}
+
+	err = s.writes.Do(ctx, lane, func(ctx context.Context) error {
+		return s.syncAsset(ctx, assetID)
+	})
+	if err != nil && (errors.Is(err, circuitbreaker.ErrOpen) || errors.Is(err, ErrWriteQueueFull)) {
+		return true, s.deferSync(ctx, assetID, err.Error())
+	}
+	return false, err
+}
+
+// deferSync queues an asset for the outbox worker to sync once Neo4j recovers
+func (s *SemanticLineageService) deferSync(ctx context.Context, assetID uuid.UUID, reason string) error {
+	if err := s.pgRepo.EnqueueLineageSync(ctx, assetID, reason); err != nil {
+		return fmt.Errorf("neo4j unavailable and failed to defer lineage sync: %w", err)
+	}
+	fmt.Printf("⏸️  [SYNC] Neo4j unavailable - deferred lineage sync for asset: %s\n", assetID)
+	return nil
+}
+
+// syncAsset writes an asset's lineage to Neo4j
 // Creates: System → Asset → PII_Category (specific PII types like IN_AADHAAR, CREDIT_CARD)
 // NO DataCategory abstraction layer - direct mapping to PII types
-func (s *SemanticLineageService) SyncAssetToNeo4j(ctx context.Context, assetID uuid.UUID) error {
+func (s *SemanticLineageService) syncAsset(ctx context.Context, assetID uuid.UUID) error {
 	fmt.Printf("🔄 [SYNC] Starting SyncAssetToNeo4j for asset: %s\n", assetID)
 
 	// Skip if Neo4j is not available
@@ -86,14 +188,33 @@ func (s *SemanticLineageService) SyncAssetToNeo4j(ctx context.Context, assetID u
 	fmt.Printf("✅ [SYNC] Retrieved asset from PostgreSQL: %s (Host: %s, Path: %s)\n",
 		asset.Name, asset.Host, asset.Path)
 
-	// 1. Create/Update System node
+	// 1. Create/Update System node; hosts found in an imported cloud inventory
+	// belong to the instance or database there rather than a node per hostname
 	systemID := fmt.Sprintf("system-%s", asset.Host)
+	systemLabel := asset.Host
 	systemMetadata := map[string]interface{}{
 		"host":          asset.Host,
 		"source_system": asset.SourceSystem,
 		"environment":   asset.Environment,
 	}
-	if err := s.neo4jRepo.CreateSystemNode(ctx, systemID, asset.Host, systemMetadata); err != nil {
+	inventorySystem, err := s.pgRepo.GetInventorySystemForHost(ctx, asset.TenantID, asset.Host)
+	if err != nil {
+		fmt.Printf("⚠️  [SYNC] Failed to look up inventory system for host %s: %v\n", asset.Host, err)
+	}
+	if inventorySystem != nil {
+		systemID = inventorySystem.SystemID()
+		systemLabel = inventorySystem.Name
+		systemMetadata["inventory"] = map[string]interface{}{
+			"provider":      inventorySystem.Provider,
+			"resource_id":   inventorySystem.ResourceID,
+			"resource_type": inventorySystem.ResourceType,
+			"account":       inventorySystem.Account,
+			"region":        inventorySystem.Region,
+			"environment":   inventorySystem.Environment,
+			"owner":         inventorySystem.Owner,
+		}
+	}
+	if err := s.neo4jRepo.CreateSystemNode(ctx, systemID, systemLabel, systemMetadata); err != nil {
 		fmt.Printf("❌ [SYNC] Failed to create System node: %s - %v\n", systemID, err)
 		return fmt.Errorf("failed to create system node: %w", err)
 	}
@@ -113,6 +234,25 @@ func (s *SemanticLineageService) SyncAssetToNeo4j(ctx context.Context, assetID u
 		return fmt.Errorf("failed to create system-asset relationship: %w", err)
 	}
 	fmt.Printf("✅ [SYNC] Created SYSTEM_OWNS_ASSET: %s → %s\n", systemID, asset.ID)
+	if err := s.neo4jRepo.RemoveOtherSystemOwners(ctx, asset.ID.String(), systemID); err != nil {
+		return fmt.Errorf("failed to detach asset from previous system: %w", err)
+	}
+
+	// Link the asset to the department or team owning it, and a team to its department
+	unit, department, err := s.assetOrgUnits(ctx, asset)
+	if err != nil {
+		return fmt.Errorf("failed to load asset org unit: %w", err)
+	}
+	if err := s.neo4jRepo.SetAssetOrgUnit(ctx, asset.ID.String(), unit, department); err != nil {
+		return fmt.Errorf("failed to link asset to its org unit: %w", err)
+	}
+
+	// Link S3 objects to the prefixes of their key, under their bucket's System node
+	if canonical.DataSource(asset.DataSource) == "s3" && asset.Host != "" {
+		if err := s.neo4jRepo.SetAssetS3Prefixes(ctx, asset.ID.String(), systemID, asset.Host, canonical.S3Prefixes(asset.Path)); err != nil {
+			return fmt.Errorf("failed to link S3 object to its prefixes: %w", err)
+		}
+	}
 
 	// 4. Get findings for this asset using FindingsProvider
 	findings, err := s.findingsProvider.GetFindingsByAsset(ctx, assetID, 1000, 0)
@@ -140,7 +280,7 @@ func (s *SemanticLineageService) SyncAssetToNeo4j(ctx context.Context, assetID u
 		classification := classifications[0]
 
 		// Filter low-confidence findings
-		if classification.ConfidenceScore < 0.45 {
+		if classification.ConfidenceScore < MinLineageConfidence {
 			lowConfidenceCount++
 			continue
 		}
@@ -229,16 +369,48 @@ func (s *SemanticLineageService) SyncAssetToNeo4j(ctx context.Context, assetID u
 		piiNodesCreated++
 	}
 
+	// 7. Close exposure windows of PII types no longer detected in the asset
+	activePIITypes := make([]string, 0, len(piiCategoryMap))
+	for piiType := range piiCategoryMap {
+		activePIITypes = append(activePIITypes, piiType)
+	}
+	closedCount, err := s.neo4jRepo.CloseStaleExposures(ctx, asset.ID.String(), activePIITypes, time.Now())
+	if err != nil {
+		fmt.Printf("❌ [SYNC] Failed to close stale exposures for asset %s: %v\n", asset.ID, err)
+		return fmt.Errorf("failed to close stale exposures: %w", err)
+	}
+
 	fmt.Printf("🎉 [SYNC] Successfully synced asset %s to Neo4j:\n", assetID)
 	fmt.Printf("   - System node: %s\n", systemID)
 	fmt.Printf("   - Asset node: %s\n", asset.ID)
 	fmt.Printf("   - PII_Category nodes: %d\n", piiNodesCreated)
 	fmt.Printf("   - Total relationships: %d (1 SYSTEM_OWNS_ASSET + %d EXPOSES)\n",
 		1+piiNodesCreated, piiNodesCreated)
+	fmt.Printf("   - Closed exposure windows: %d\n", closedCount)
 
 	return nil
 }
 
+// assetOrgUnits returns the unit owning an asset and, when that unit is a team,
+// its department; both are nil for an asset in no unit
+func (s *SemanticLineageService) assetOrgUnits(ctx context.Context, asset *entity.Asset) (unit, department *entity.OrgUnit, err error) {
+	if asset.OrgUnitID == nil {
+		return nil, nil, nil
+	}
+	unit, err = s.pgRepo.GetOrgUnit(ctx, *asset.OrgUnitID)
+	if errors.Is(err, persistence.ErrOrgUnitNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil || unit.ParentID == nil {
+		return unit, nil, err
+	}
+	department, err = s.pgRepo.GetOrgUnit(ctx, *unit.ParentID)
+	if errors.Is(err, persistence.ErrOrgUnitNotFound) {
+		return unit, nil, nil
+	}
+	return unit, department, err
+}
+
 // getRiskLevelForPIIType determines risk level based on specific PII type and confidence
 // Frozen Semantic Contract: Risk is based on the PII type itself, not abstracted classification
 func getRiskLevelForPIIType(piiType string, avgConfidence float64) string {
@@ -360,28 +532,62 @@ func (s *SemanticLineageService) SyncLineage(ctx context.Context) error {
 		return fmt.Errorf("neo4j repository not configured")
 	}
 
-	// 1. Get all assets
-	// Use a large limit for now, or implement pagination
-	assets, err := s.pgRepo.ListAssets(ctx, 10000, 0)
-	if err != nil {
-		fmt.Printf("❌ [FULL-SYNC] Failed to list assets: %v\n", err)
-		return fmt.Errorf("failed to list assets: %w", err)
-	}
-	fmt.Printf("📊 [FULL-SYNC] Found %d assets to synchronize\n", len(assets))
-
+	// Assets are read a page at a time, so the sync never holds one long query over the whole table.
+	// Each page is written by as many workers as the resync lane allows; real-time syncs
+	// take precedence for the queue's remaining capacity.
+	totalCount := 0
 	successCount := 0
 	errorCount := 0
-
-	for i, asset := range assets {
-		fmt.Printf("🔄 [FULL-SYNC] Syncing asset %d/%d: %s\n", i+1, len(assets), asset.Name)
-		if err := s.SyncAssetToNeo4j(ctx, asset.ID); err != nil {
-			fmt.Printf("❌ [FULL-SYNC] Error syncing asset %s: %v\n", asset.Name, err)
-			errorCount++
-		} else {
-			successCount++
+	deferredCount := 0
+	var mu sync.Mutex
+
+	err := s.pgRepo.IterateAssets(ctx, func(assets []*entity.Asset) error {
+		fmt.Printf("📊 [FULL-SYNC] Synchronizing %d more assets\n", len(assets))
+		pending := make(chan *entity.Asset)
+		var wg sync.WaitGroup
+		for i := 0; i < s.writes.Concurrency(LaneResync); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for asset := range pending {
+					deferred, err := s.syncOrDefer(ctx, asset.ID, LaneResync)
+					mu.Lock()
+					switch {
+					case err != nil:
+						fmt.Printf("❌ [FULL-SYNC] Error syncing asset %s: %v\n", asset.Name, err)
+						errorCount++
+					case deferred:
+						deferredCount++
+					default:
+						successCount++
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		for _, asset := range assets {
+			totalCount++
+			fmt.Printf("🔄 [FULL-SYNC] Syncing asset %d: %s\n", totalCount, asset.Name)
+			pending <- asset
 		}
+		close(pending)
+		wg.Wait()
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("❌ [FULL-SYNC] Failed to list assets after %d: %v\n", totalCount, err)
+		return fmt.Errorf("failed to list assets: %w", err)
 	}
 
-	fmt.Printf("🎉 [FULL-SYNC] Sync completed: %d assets synced, %d failed\n", successCount, errorCount)
+	fmt.Printf("🎉 [FULL-SYNC] Sync completed: %d assets synced, %d deferred, %d failed\n",
+		successCount, deferredCount, errorCount)
+
+	s.events.Publish(ctx, interfaces.EventSyncCompleted, map[string]interface{}{
+		"sync":            "lineage",
+		"total_assets":    totalCount,
+		"synced_assets":   successCount,
+		"deferred_assets": deferredCount,
+		"failed_assets":   errorCount,
+	})
 	return nil
 }
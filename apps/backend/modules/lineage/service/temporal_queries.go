@@ -3,247 +3,137 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
 )
 
-// PIIExposure represents a PII exposure at a specific point in time
-type PIIExposure struct {
-	PIIType      string
-	ExposedSince time.Time
-	ExposedUntil *time.Time // nil if still exposed
-	FirstScanID  string
-	LastScanID   string
+// Exposure timeline event types
+const (
+	EventExposureStarted = "EXPOSURE_STARTED"
+	EventExposureEnded   = "EXPOSURE_ENDED"
+)
+
+// ExposureEvent is the start or end of an exposure window
+type ExposureEvent struct {
+	EventTime time.Time `json:"event_time"`
+	EventType string    `json:"event_type"`
+	PIIType   string    `json:"pii_type"`
 }
 
-// ExposureWindow represents a complete exposure history for a PII type
-type ExposureWindow struct {
-	PIIType     string
-	Since       time.Time
-	Until       *time.Time
-	FirstScanID string
-	LastScanID  string
-	IsActive    bool
+// AssetPIIHistory is the PII category history of one asset
+type AssetPIIHistory struct {
+	AssetID        uuid.UUID                    `json:"asset_id"`
+	AssetName      string                       `json:"asset_name"`
+	ActivePIITypes []string                     `json:"active_pii_types"`
+	Windows        []persistence.ExposureWindow `json:"windows"`
+	Timeline       []ExposureEvent              `json:"timeline"`
 }
 
-// TemporalLineageService provides temporal-aware lineage queries
+// TemporalLineageService answers point-in-time lineage queries from the
+// valid_from/valid_to exposure windows written on EXPOSES edges during sync
 type TemporalLineageService struct {
-	neo4j neo4j.Driver
+	neo4jRepo *persistence.Neo4jRepository
+	pgRepo    *persistence.PostgresRepository
 }
 
 // NewTemporalLineageService creates a new temporal lineage service
-func NewTemporalLineageService(driver neo4j.Driver) *TemporalLineageService {
+func NewTemporalLineageService(neo4jRepo *persistence.Neo4jRepository, pgRepo *persistence.PostgresRepository) *TemporalLineageService {
 	return &TemporalLineageService{
-		neo4j: driver,
+		neo4jRepo: neo4jRepo,
+		pgRepo:    pgRepo,
 	}
 }
 
-// GetExposureAtTime returns all PII exposed at a specific point in time
-func (s *TemporalLineageService) GetExposureAtTime(ctx context.Context, assetID string, atTime time.Time) ([]PIIExposure, error) {
-	session := s.neo4j.NewSession(neo4j.SessionConfig{})
-	defer session.Close()
-
-	result, err := session.Run(`
-		MATCH (a:Asset {stable_id: $assetID})-[e:EXPOSES]->(p:PII_Category)
-		WHERE e.since <= $atTime 
-		  AND (e.until IS NULL OR e.until > $atTime)
-		RETURN p.pii_type as pii_type, 
-		       e.since as exposed_since,
-		       e.until as exposed_until,
-		       e.first_scan_id as first_scan_id,
-		       e.last_scan_id as last_scan_id
-	`, map[string]interface{}{
-		"assetID": assetID,
-		"atTime":  atTime,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query exposure at time: %w", err)
-	}
-
-	var exposures []PIIExposure
-	for result.Next() {
-		record := result.Record()
-
-		var exposedUntil *time.Time
-		if untilVal, ok := record.Get("exposed_until"); ok && untilVal != nil {
-			if t, ok := untilVal.(time.Time); ok {
-				exposedUntil = &t
-			}
-		}
-
-		exposures = append(exposures, PIIExposure{
-			PIIType:      record.Values[0].(string),
-			ExposedSince: record.Values[1].(time.Time),
-			ExposedUntil: exposedUntil,
-			FirstScanID:  record.Values[3].(string),
-			LastScanID:   record.Values[4].(string),
-		})
+// GetGraphAsOf returns the System → Asset → PII_Category graph as it stood at a point in time
+func (s *TemporalLineageService) GetGraphAsOf(ctx context.Context, at time.Time, systemFilter string) (*SemanticGraph, error) {
+	if s.neo4jRepo == nil {
+		return nil, fmt.Errorf("neo4j repository not configured - temporal lineage unavailable")
 	}
 
-	return exposures, nil
-}
-
-// GetExposureHistory returns complete exposure history for an asset
-func (s *TemporalLineageService) GetExposureHistory(ctx context.Context, assetID string) ([]ExposureWindow, error) {
-	session := s.neo4j.NewSession(neo4j.SessionConfig{})
-	defer session.Close()
-
-	result, err := session.Run(`
-		MATCH (a:Asset {stable_id: $assetID})-[e:EXPOSES]->(p:PII_Category)
-		RETURN p.pii_type as pii_type,
-		       e.since as since,
-		       e.until as until,
-		       e.first_scan_id as first_scan,
-		       e.last_scan_id as last_scan
-		ORDER BY e.since DESC
-	`, map[string]interface{}{
-		"assetID": assetID,
-	})
+	nodes, edges, err := s.neo4jRepo.GetSemanticGraphAt(ctx, at, systemFilter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query exposure history: %w", err)
+		return nil, fmt.Errorf("failed to get point-in-time graph from neo4j: %w", err)
 	}
 
-	var windows []ExposureWindow
-	for result.Next() {
-		record := result.Record()
-
-		var until *time.Time
-		if untilVal, ok := record.Get("until"); ok && untilVal != nil {
-			if t, ok := untilVal.(time.Time); ok {
-				until = &t
-			}
-		}
-
-		windows = append(windows, ExposureWindow{
-			PIIType:     record.Values[0].(string),
-			Since:       record.Values[1].(time.Time),
-			Until:       until,
-			FirstScanID: record.Values[3].(string),
-			LastScanID:  record.Values[4].(string),
-			IsActive:    until == nil,
+	graph := &SemanticGraph{
+		Nodes: make([]SemanticNode, 0, len(nodes)),
+		Edges: make([]SemanticEdge, 0, len(edges)),
+	}
+	for _, node := range nodes {
+		graph.Nodes = append(graph.Nodes, SemanticNode{
+			ID:       node.ID,
+			Type:     node.Type,
+			Label:    node.Label,
+			Metadata: node.Metadata,
 		})
 	}
-
-	return windows, nil
+	for _, edge := range edges {
+		graph.Edges = append(graph.Edges, SemanticEdge{
+			ID:       edge.ID,
+			Source:   edge.Source,
+			Target:   edge.Target,
+			Type:     edge.Type,
+			Metadata: edge.Metadata,
+		})
+	}
+	return graph, nil
 }
 
-// GetActiveExposures returns all currently active PII exposures for an asset
-func (s *TemporalLineageService) GetActiveExposures(ctx context.Context, assetID string) ([]PIIExposure, error) {
-	session := s.neo4j.NewSession(neo4j.SessionConfig{})
-	defer session.Close()
-
-	result, err := session.Run(`
-		MATCH (a:Asset {stable_id: $assetID})-[e:EXPOSES]->(p:PII_Category)
-		WHERE e.until IS NULL
-		RETURN p.pii_type as pii_type,
-		       e.since as exposed_since,
-		       e.until as exposed_until,
-		       e.first_scan_id as first_scan_id,
-		       e.last_scan_id as last_scan_id
-	`, map[string]interface{}{
-		"assetID": assetID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query active exposures: %w", err)
+// GetAssetPIIHistory returns every exposure window of a tenant asset with a timeline of changes
+func (s *TemporalLineageService) GetAssetPIIHistory(ctx context.Context, assetID uuid.UUID) (*AssetPIIHistory, error) {
+	if s.neo4jRepo == nil {
+		return nil, fmt.Errorf("neo4j repository not configured - temporal lineage unavailable")
 	}
 
-	var exposures []PIIExposure
-	for result.Next() {
-		record := result.Record()
-		exposures = append(exposures, PIIExposure{
-			PIIType:      record.Values[0].(string),
-			ExposedSince: record.Values[1].(time.Time),
-			ExposedUntil: nil, // Active exposures have no end time
-			FirstScanID:  record.Values[3].(string),
-			LastScanID:   record.Values[4].(string),
-		})
+	// Resolve through PostgreSQL first so the asset is checked against the tenant
+	asset, err := s.pgRepo.GetAssetByID(ctx, assetID)
+	if err != nil {
+		return nil, err
 	}
 
-	return exposures, nil
-}
-
-// GetExposureDuration calculates how long a PII type was exposed
-func (s *TemporalLineageService) GetExposureDuration(ctx context.Context, assetID string, piiType string) (time.Duration, error) {
-	session := s.neo4j.NewSession(neo4j.SessionConfig{})
-	defer session.Close()
-
-	result, err := session.Run(`
-		MATCH (a:Asset {stable_id: $assetID})-[e:EXPOSES]->(p:PII_Category {pii_type: $piiType})
-		RETURN e.since as since, e.until as until
-	`, map[string]interface{}{
-		"assetID": assetID,
-		"piiType": piiType,
-	})
+	windows, err := s.neo4jRepo.GetAssetExposureHistory(ctx, asset.ID.String())
 	if err != nil {
-		return 0, fmt.Errorf("failed to query exposure duration: %w", err)
+		return nil, fmt.Errorf("failed to get exposure history from neo4j: %w", err)
 	}
 
-	if !result.Next() {
-		return 0, fmt.Errorf("no exposure found for PII type %s", piiType)
+	history := &AssetPIIHistory{
+		AssetID:        asset.ID,
+		AssetName:      asset.Name,
+		ActivePIITypes: []string{},
+		Windows:        windows,
+		Timeline:       exposureTimeline(windows),
 	}
-
-	record := result.Record()
-	since := record.Values[0].(time.Time)
-
-	var until time.Time
-	if untilVal := record.Values[1]; untilVal != nil {
-		until = untilVal.(time.Time)
-	} else {
-		until = time.Now() // Still exposed, use current time
+	for _, window := range windows {
+		if window.ValidTo == nil {
+			history.ActivePIITypes = append(history.ActivePIITypes, window.PIIType)
+		}
 	}
-
-	return until.Sub(since), nil
+	sort.Strings(history.ActivePIITypes)
+	return history, nil
 }
 
-// WasCompliantAt checks if an asset was compliant at a specific time
-// An asset is compliant if it had no active PII exposures at that time
-func (s *TemporalLineageService) WasCompliantAt(ctx context.Context, assetID string, atTime time.Time) (bool, error) {
-	exposures, err := s.GetExposureAtTime(ctx, assetID, atTime)
-	if err != nil {
-		return false, err
+// exposureTimeline flattens exposure windows into start and end events, oldest first
+func exposureTimeline(windows []persistence.ExposureWindow) []ExposureEvent {
+	events := make([]ExposureEvent, 0, len(windows)*2)
+	for _, window := range windows {
+		events = append(events, ExposureEvent{EventTime: window.ValidFrom, EventType: EventExposureStarted, PIIType: window.PIIType})
+		if window.ValidTo != nil {
+			events = append(events, ExposureEvent{EventTime: *window.ValidTo, EventType: EventExposureEnded, PIIType: window.PIIType})
+		}
 	}
 
-	// Asset is compliant if it has no exposures
-	return len(exposures) == 0, nil
-}
-
-// GetComplianceTimeline returns a timeline of compliance status changes
-func (s *TemporalLineageService) GetComplianceTimeline(ctx context.Context, assetID string) ([]ComplianceEvent, error) {
-	session := s.neo4j.NewSession(neo4j.SessionConfig{})
-	defer session.Close()
-
-	result, err := session.Run(`
-		MATCH (a:Asset {stable_id: $assetID})-[e:EXPOSES]->(p:PII_Category)
-		RETURN e.since as event_time, 'EXPOSURE_STARTED' as event_type, p.pii_type as pii_type
-		UNION
-		MATCH (a:Asset {stable_id: $assetID})-[e:EXPOSES]->(p:PII_Category)
-		WHERE e.until IS NOT NULL
-		RETURN e.until as event_time, 'EXPOSURE_ENDED' as event_type, p.pii_type as pii_type
-		ORDER BY event_time ASC
-	`, map[string]interface{}{
-		"assetID": assetID,
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].EventTime.Equal(events[j].EventTime) {
+			return events[i].EventTime.Before(events[j].EventTime)
+		}
+		// An exposure ending at the instant another starts is listed first
+		if events[i].EventType != events[j].EventType {
+			return events[i].EventType == EventExposureEnded
+		}
+		return events[i].PIIType < events[j].PIIType
 	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to query compliance timeline: %w", err)
-	}
-
-	var events []ComplianceEvent
-	for result.Next() {
-		record := result.Record()
-		events = append(events, ComplianceEvent{
-			EventTime: record.Values[0].(time.Time),
-			EventType: record.Values[1].(string),
-			PIIType:   record.Values[2].(string),
-		})
-	}
-
-	return events, nil
-}
-
-// ComplianceEvent represents a change in compliance status
-type ComplianceEvent struct {
-	EventTime time.Time
-	EventType string // 'EXPOSURE_STARTED' or 'EXPOSURE_ENDED'
-	PIIType   string
+	return events
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+func TestExposureTimeline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	ended := day(5)
+
+	windows := []persistence.ExposureWindow{
+		{PIIType: "IN_PAN", ValidFrom: day(5)},
+		{PIIType: "EMAIL_ADDRESS", ValidFrom: day(1), ValidTo: &ended},
+	}
+
+	events := exposureTimeline(windows)
+
+	want := []ExposureEvent{
+		{EventTime: day(1), EventType: EventExposureStarted, PIIType: "EMAIL_ADDRESS"},
+		{EventTime: day(5), EventType: EventExposureEnded, PIIType: "EMAIL_ADDRESS"},
+		{EventTime: day(5), EventType: EventExposureStarted, PIIType: "IN_PAN"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i := range want {
+		if !events[i].EventTime.Equal(want[i].EventTime) || events[i].EventType != want[i].EventType || events[i].PIIType != want[i].PIIType {
+			t.Errorf("event %d: got %+v, want %+v", i, events[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+)
+
+// WriteLane is the priority class of a Neo4j write
+type WriteLane int
+
+// Lanes in priority order: a free slot goes to the highest lane with writes waiting
+const (
+	LaneRealtime  WriteLane = iota // Syncs of assets just ingested or changed
+	LaneResync                     // Full resyncs of every asset
+	LaneReconcile                  // Outbox replays and consistency repairs
+	writeLaneCount
+)
+
+// ErrWriteQueueFull is returned when too many real-time writes are already waiting.
+// Callers defer the write to the outbox instead of blocking ingestion.
+var ErrWriteQueueFull = errors.New("neo4j write queue is full")
+
+func (l WriteLane) String() string {
+	switch l {
+	case LaneRealtime:
+		return "realtime"
+	case LaneResync:
+		return "resync"
+	case LaneReconcile:
+		return "reconcile"
+	default:
+		return "unknown"
+	}
+}
+
+// WriteLaneStats reports one lane of the write queue
+type WriteLaneStats struct {
+	Lane        string `json:"lane"`
+	Concurrency int    `json:"concurrency"`
+	InFlight    int    `json:"in_flight"`
+	Queued      int    `json:"queued"`
+	Completed   int64  `json:"completed"`
+	Rejected    int64  `json:"rejected"`
+}
+
+// WriteQueueStats reports the write queue
+type WriteQueueStats struct {
+	MaxConcurrency int              `json:"max_concurrency"`
+	InFlight       int              `json:"in_flight"`
+	Lanes          []WriteLaneStats `json:"lanes"`
+}
+
+type writeLane struct {
+	limit     int
+	inFlight  int
+	waiting   []chan struct{}
+	completed int64
+	rejected  int64
+}
+
+// WriteQueue bounds concurrent Neo4j writes overall and per lane, and hands freed
+// slots to higher lanes first, so bulk resyncs never hold up real-time syncs for
+// longer than one write. Callers block in Do until their write has run, which
+// slows a resync down to the capacity real-time syncs leave over.
+type WriteQueue struct {
+	mu          sync.Mutex
+	limit       int
+	inFlight    int
+	lanes       [writeLaneCount]*writeLane
+	maxRealtime int // Real-time writes allowed to wait; unbounded when 0
+}
+
+// NewWriteQueue creates a write queue from the configured concurrency limits
+func NewWriteQueue(cfg config.Neo4jWriteQueueConfig) *WriteQueue {
+	q := &WriteQueue{limit: atLeastOne(cfg.MaxConcurrency), maxRealtime: cfg.RealtimeMaxQueued}
+	for lane, limit := range [writeLaneCount]int{cfg.RealtimeConcurrency, cfg.ResyncConcurrency, cfg.ReconcileConcurrency} {
+		limit = atLeastOne(limit)
+		if limit > q.limit {
+			limit = q.limit
+		}
+		q.lanes[lane] = &writeLane{limit: limit}
+	}
+	return q
+}
+
+// DefaultWriteQueue leaves two slots of four to real-time syncs while a resync runs
+func DefaultWriteQueue() *WriteQueue {
+	return NewWriteQueue(config.Neo4jWriteQueueConfig{
+		MaxConcurrency:       4,
+		RealtimeConcurrency:  4,
+		ResyncConcurrency:    2,
+		ReconcileConcurrency: 1,
+		RealtimeMaxQueued:    100,
+	})
+}
+
+// Concurrency returns how many writes of a lane may run at once
+func (q *WriteQueue) Concurrency(lane WriteLane) int {
+	return q.lanes[lane].limit
+}
+
+// Do runs write once the lane has a free slot. It returns ctx's error if the
+// context ends while waiting, and ErrWriteQueueFull for a real-time write when
+// the lane's wait list is full.
+func (q *WriteQueue) Do(ctx context.Context, lane WriteLane, write func(context.Context) error) error {
+	if err := q.acquire(ctx, lane); err != nil {
+		return err
+	}
+	defer q.release(lane)
+	return write(ctx)
+}
+
+func (q *WriteQueue) acquire(ctx context.Context, lane WriteLane) error {
+	q.mu.Lock()
+	l := q.lanes[lane]
+	if q.canRun(lane) && len(l.waiting) == 0 {
+		q.start(lane)
+		q.mu.Unlock()
+		return nil
+	}
+	if lane == LaneRealtime && q.maxRealtime > 0 && len(l.waiting) >= q.maxRealtime {
+		l.rejected++
+		q.mu.Unlock()
+		return ErrWriteQueueFull
+	}
+	granted := make(chan struct{})
+	l.waiting = append(l.waiting, granted)
+	q.mu.Unlock()
+
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		for i, w := range l.waiting {
+			if w == granted {
+				l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+				return ctx.Err()
+			}
+		}
+		// Granted as the context ended: hand the slot on
+		q.finish(lane)
+		return ctx.Err()
+	}
+}
+
+func (q *WriteQueue) release(lane WriteLane) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.lanes[lane].completed++
+	q.finish(lane)
+}
+
+// finish frees a slot of lane and grants free slots to waiting writes by priority
+func (q *WriteQueue) finish(lane WriteLane) {
+	q.lanes[lane].inFlight--
+	q.inFlight--
+	for next := LaneRealtime; next < writeLaneCount; next++ {
+		l := q.lanes[next]
+		for len(l.waiting) > 0 && q.canRun(next) {
+			granted := l.waiting[0]
+			l.waiting = l.waiting[1:]
+			q.start(next)
+			close(granted)
+		}
+	}
+}
+
+func (q *WriteQueue) canRun(lane WriteLane) bool {
+	return q.inFlight < q.limit && q.lanes[lane].inFlight < q.lanes[lane].limit
+}
+
+func (q *WriteQueue) start(lane WriteLane) {
+	q.lanes[lane].inFlight++
+	q.inFlight++
+}
+
+// Stats reports the queue's current load and totals per lane
+func (q *WriteQueue) Stats() WriteQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := WriteQueueStats{MaxConcurrency: q.limit, InFlight: q.inFlight, Lanes: make([]WriteLaneStats, 0, writeLaneCount)}
+	for lane := LaneRealtime; lane < writeLaneCount; lane++ {
+		l := q.lanes[lane]
+		stats.Lanes = append(stats.Lanes, WriteLaneStats{
+			Lane:        lane.String(),
+			Concurrency: l.limit,
+			InFlight:    l.inFlight,
+			Queued:      len(l.waiting),
+			Completed:   l.completed,
+			Rejected:    l.rejected,
+		})
+	}
+	return stats
+}
+
+func atLeastOne(n int) int {
+	if n < 1 {
+		return 1
+	}
+	return n
+}
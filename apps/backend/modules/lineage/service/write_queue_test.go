@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+)
+
+// holdSlots starts n writes in lane that run until release is closed
+func holdSlots(t *testing.T, q *WriteQueue, lane WriteLane, n int, release chan struct{}, wg *sync.WaitGroup) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = q.Do(context.Background(), lane, func(context.Context) error {
+				<-release
+				return nil
+			})
+		}()
+	}
+	waitFor(t, func() bool { return q.Stats().Lanes[lane].InFlight == n })
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWriteQueueLaneLimits(t *testing.T) {
+	q := NewWriteQueue(config.Neo4jWriteQueueConfig{MaxConcurrency: 3, RealtimeConcurrency: 3, ResyncConcurrency: 1, ReconcileConcurrency: 1})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+
+	// The resync lane holds one slot; further resyncs wait while real-time writes use the rest
+	holdSlots(t, q, LaneResync, 1, release, &wg)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = q.Do(context.Background(), LaneResync, func(context.Context) error { return nil })
+	}()
+	waitFor(t, func() bool { return q.Stats().Lanes[LaneResync].Queued == 1 })
+	holdSlots(t, q, LaneRealtime, 2, release, &wg)
+
+	if stats := q.Stats(); stats.InFlight != 3 || stats.Lanes[LaneResync].InFlight != 1 {
+		t.Errorf("unexpected load %+v", stats)
+	}
+	close(release)
+	wg.Wait()
+	if stats := q.Stats(); stats.InFlight != 0 || stats.Lanes[LaneResync].Completed != 2 || stats.Lanes[LaneRealtime].Completed != 2 {
+		t.Errorf("expected every write to complete, got %+v", stats)
+	}
+}
+
+func TestWriteQueueGrantsHigherLanesFirst(t *testing.T) {
+	q := NewWriteQueue(config.Neo4jWriteQueueConfig{MaxConcurrency: 1, RealtimeConcurrency: 1, ResyncConcurrency: 1, ReconcileConcurrency: 1})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	holdSlots(t, q, LaneReconcile, 1, release, &wg)
+
+	var mu sync.Mutex
+	order := []WriteLane{}
+	queue := func(lane WriteLane) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = q.Do(context.Background(), lane, func(context.Context) error {
+				mu.Lock()
+				order = append(order, lane)
+				mu.Unlock()
+				return nil
+			})
+		}()
+		waitFor(t, func() bool { return q.Stats().Lanes[lane].Queued > 0 })
+	}
+	queue(LaneReconcile)
+	queue(LaneResync)
+	queue(LaneRealtime)
+
+	close(release)
+	wg.Wait()
+	if len(order) != 3 || order[0] != LaneRealtime || order[1] != LaneResync || order[2] != LaneReconcile {
+		t.Errorf("expected writes in priority order, got %v", order)
+	}
+}
+
+func TestWriteQueueRejectsRealtimeOverflow(t *testing.T) {
+	q := NewWriteQueue(config.Neo4jWriteQueueConfig{MaxConcurrency: 1, RealtimeConcurrency: 1, ResyncConcurrency: 1, ReconcileConcurrency: 1, RealtimeMaxQueued: 1})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	holdSlots(t, q, LaneRealtime, 1, release, &wg)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = q.Do(context.Background(), LaneRealtime, func(context.Context) error { return nil })
+	}()
+	waitFor(t, func() bool { return q.Stats().Lanes[LaneRealtime].Queued == 1 })
+
+	err := q.Do(context.Background(), LaneRealtime, func(context.Context) error { return nil })
+	if !errors.Is(err, ErrWriteQueueFull) {
+		t.Errorf("expected ErrWriteQueueFull, got %v", err)
+	}
+	if q.Stats().Lanes[LaneRealtime].Rejected != 1 {
+		t.Error("expected the rejected write to be counted")
+	}
+	close(release)
+	wg.Wait()
+}
+
+func TestWriteQueueCancelledWhileWaiting(t *testing.T) {
+	q := NewWriteQueue(config.Neo4jWriteQueueConfig{MaxConcurrency: 1, RealtimeConcurrency: 1, ResyncConcurrency: 1, ReconcileConcurrency: 1})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	holdSlots(t, q, LaneResync, 1, release, &wg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- q.Do(ctx, LaneResync, func(context.Context) error {
+			t.Error("cancelled write must not run")
+			return nil
+		})
+	}()
+	waitFor(t, func() bool { return q.Stats().Lanes[LaneResync].Queued == 1 })
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+	wg.Wait()
+	if stats := q.Stats(); stats.InFlight != 0 || stats.Lanes[LaneResync].Queued != 0 {
+		t.Errorf("expected an idle queue, got %+v", stats)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/masking/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -30,11 +31,7 @@ type MaskAssetRequest struct {
 // MaskAsset handles POST /api/v1/masking/mask-asset
 func (h *MaskingHandler) MaskAsset(c *gin.Context) {
 	var req MaskAssetRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request format",
-			"details": err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
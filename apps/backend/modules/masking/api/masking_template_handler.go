@@ -0,0 +1,105 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/masking/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/pkg/redaction"
+	"github.com/gin-gonic/gin"
+)
+
+// MaskingTemplateHandler manages the tenant's masking templates
+type MaskingTemplateHandler struct {
+	service *service.MaskingTemplateService
+}
+
+// NewMaskingTemplateHandler creates a masking template handler
+func NewMaskingTemplateHandler(templateService *service.MaskingTemplateService) *MaskingTemplateHandler {
+	return &MaskingTemplateHandler{service: templateService}
+}
+
+// PreviewTemplateRequest is a value to mask with the tenant's template for its
+// PII type, or with a draft template
+type PreviewTemplateRequest struct {
+	PIIType  string              `json:"pii_type"`
+	Value    string              `json:"value" binding:"required"`
+	Template *redaction.Template `json:"template,omitempty"`
+}
+
+// ListTemplates handles GET /api/v1/masking/templates
+func (h *MaskingTemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.service.List(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(statusForTemplateError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": templates})
+}
+
+// SetTemplate handles PUT /api/v1/masking/templates/:pii_type
+func (h *MaskingTemplateHandler) SetTemplate(c *gin.Context) {
+	var template redaction.Template
+	if !sharedapi.BindJSON(c, &template) {
+		return
+	}
+
+	view, err := h.service.Set(sharedapi.RequestContext(c), c.Param("pii_type"), template, templateActor(c))
+	if err != nil {
+		c.JSON(statusForTemplateError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": view})
+}
+
+// ResetTemplate handles DELETE /api/v1/masking/templates/:pii_type
+// Drops the tenant's template so the built-in one applies again
+func (h *MaskingTemplateHandler) ResetTemplate(c *gin.Context) {
+	if err := h.service.Reset(sharedapi.RequestContext(c), c.Param("pii_type"), templateActor(c)); err != nil {
+		c.JSON(statusForTemplateError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewTemplate handles POST /api/v1/masking/templates/preview
+func (h *MaskingTemplateHandler) PreviewTemplate(c *gin.Context) {
+	var req PreviewTemplateRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	masked, err := h.service.Preview(sharedapi.RequestContext(c), req.PIIType, req.Value, req.Template)
+	if err != nil {
+		c.JSON(statusForTemplateError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"pii_type": redaction.CanonicalPIIType(req.PIIType),
+		"masked":   masked,
+	}})
+}
+
+func templateActor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func statusForTemplateError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
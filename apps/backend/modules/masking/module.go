@@ -3,6 +3,7 @@ package masking
 import (
 	"log"
 
+	"github.com/arc-platform/backend/modules/auth/middleware"
 	"github.com/arc-platform/backend/modules/masking/api"
 	"github.com/arc-platform/backend/modules/masking/service"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
@@ -11,9 +12,11 @@ import (
 )
 
 type MaskingModule struct {
-	maskingService *service.MaskingService
-	maskingHandler *api.MaskingHandler
-	deps           *interfaces.ModuleDependencies
+	maskingService  *service.MaskingService
+	maskingHandler  *api.MaskingHandler
+	templateHandler *api.MaskingTemplateHandler
+	authMiddleware  *middleware.AuthMiddleware
+	deps            *interfaces.ModuleDependencies
 }
 
 func (m *MaskingModule) Name() string {
@@ -27,8 +30,12 @@ func (m *MaskingModule) Initialize(deps *interfaces.ModuleDependencies) error {
 	repo := persistence.NewPostgresRepository(deps.DB)
 	maskingAuditRepo := persistence.NewMaskingAuditRepository(deps.DB)
 
+	templateService := service.NewMaskingTemplateService(repo, deps.AuditLogger)
 	m.maskingService = service.NewMaskingService(repo, repo, maskingAuditRepo)
+	m.maskingService.SetMaskingTemplates(templateService)
 	m.maskingHandler = api.NewMaskingHandler(m.maskingService)
+	m.templateHandler = api.NewMaskingTemplateHandler(templateService)
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
 
 	log.Printf("✅ Masking Module initialized")
 	return nil
@@ -40,6 +47,12 @@ func (m *MaskingModule) RegisterRoutes(router *gin.RouterGroup) {
 		masking.POST("/mask-asset", m.maskingHandler.MaskAsset)
 		masking.GET("/status/:assetId", m.maskingHandler.GetMaskingStatus)
 		masking.GET("/audit/:assetId", m.maskingHandler.GetMaskingAuditLog)
+
+		// Per-PII-type templates shared by sample redaction and remediation
+		masking.GET("/templates", m.templateHandler.ListTemplates)
+		masking.POST("/templates/preview", m.templateHandler.PreviewTemplate)
+		masking.PUT("/templates/:pii_type", m.authMiddleware.RequireRole("admin"), m.templateHandler.SetTemplate)
+		masking.DELETE("/templates/:pii_type", m.authMiddleware.RequireRole("admin"), m.templateHandler.ResetTemplate)
 	}
 	log.Printf("🔒 Masking routes registered")
 }
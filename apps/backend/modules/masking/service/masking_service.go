@@ -10,6 +10,7 @@ import (
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/pkg/redaction"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +19,7 @@ type MaskingService struct {
 	assetRepo        *persistence.PostgresRepository
 	findingRepo      *persistence.PostgresRepository
 	maskingAuditRepo *persistence.MaskingAuditRepository
+	templates        *MaskingTemplateService
 }
 
 // NewMaskingService creates a new masking service
@@ -33,6 +35,12 @@ func NewMaskingService(
 	}
 }
 
+// SetMaskingTemplates makes the PARTIAL strategy mask with the tenant's masking
+// templates; the built-in templates apply otherwise
+func (s *MaskingService) SetMaskingTemplates(templates *MaskingTemplateService) {
+	s.templates = templates
+}
+
 // MaskingStrategy defines the masking approach
 type MaskingStrategy string
 
@@ -71,12 +79,13 @@ func (s *MaskingService) MaskAsset(ctx context.Context, assetID uuid.UUID, strat
 	}
 
 	// Apply masking to each finding
+	templates := s.templates.Templates(ctx)
 	maskedData := make(map[uuid.UUID]string)
 	for _, finding := range findings {
 		if len(finding.Matches) > 0 {
 			// Mask the first match (representative value)
 			originalValue := finding.Matches[0]
-			maskedValue := s.applyMaskingStrategy(originalValue, finding.PatternName, strategy)
+			maskedValue := s.applyMaskingStrategy(templates, originalValue, finding.PatternName, strategy)
 			maskedData[finding.ID] = maskedValue
 		}
 	}
@@ -113,13 +122,13 @@ func (s *MaskingService) MaskAsset(ctx context.Context, assetID uuid.UUID, strat
 }
 
 // applyMaskingStrategy applies the specified masking strategy to a value
-func (s *MaskingService) applyMaskingStrategy(value, piiType string, strategy MaskingStrategy) string {
+func (s *MaskingService) applyMaskingStrategy(templates redaction.Templates, value, piiType string, strategy MaskingStrategy) string {
 	switch strategy {
 	case MaskingStrategyRedact:
 		return "[REDACTED]"
 
 	case MaskingStrategyPartial:
-		return s.applyPartialMasking(value, piiType)
+		return templates.Mask(piiType, value)
 
 	case MaskingStrategyTokenize:
 		return s.applyTokenization(value)
@@ -129,59 +138,6 @@ func (s *MaskingService) applyMaskingStrategy(value, piiType string, strategy Ma
 	}
 }
 
-// applyPartialMasking masks the middle portion of a value, keeping first and last characters
-func (s *MaskingService) applyPartialMasking(value, piiType string) string {
-	// Remove whitespace and special characters for processing
-	cleaned := strings.ReplaceAll(value, " ", "")
-	cleaned = strings.ReplaceAll(cleaned, "-", "")
-
-	length := len(cleaned)
-
-	// For very short values, just redact
-	if length <= 4 {
-		return "[REDACTED]"
-	}
-
-	// Different strategies based on PII type
-	switch {
-	case strings.Contains(strings.ToUpper(piiType), "AADHAAR"):
-		// Aadhaar: Show last 4 digits (e.g., XXXX-XXXX-1234)
-		if length >= 12 {
-			return "XXXX-XXXX-" + cleaned[length-4:]
-		}
-		return "XXXX-XXXX-" + cleaned[length-4:]
-
-	case strings.Contains(strings.ToUpper(piiType), "PAN"):
-		// PAN: Show first 3 and last 4 (e.g., ABC****1234)
-		if length >= 10 {
-			return cleaned[:3] + "****" + cleaned[length-4:]
-		}
-		return cleaned[:2] + "****" + cleaned[length-2:]
-
-	case strings.Contains(strings.ToUpper(piiType), "PHONE"):
-		// Phone: Show last 4 digits (e.g., ******1234)
-		if length >= 10 {
-			return "******" + cleaned[length-4:]
-		}
-		return "****" + cleaned[length-4:]
-
-	case strings.Contains(strings.ToUpper(piiType), "EMAIL"):
-		// Email: Show first 2 chars and domain (e.g., ab****@example.com)
-		parts := strings.Split(value, "@")
-		if len(parts) == 2 && len(parts[0]) > 2 {
-			return parts[0][:2] + "****@" + parts[1]
-		}
-		return "****@" + parts[len(parts)-1]
-
-	default:
-		// Generic: Show first 2 and last 4
-		if length > 6 {
-			return cleaned[:2] + strings.Repeat("X", length-6) + cleaned[length-4:]
-		}
-		return cleaned[:1] + strings.Repeat("X", length-2) + cleaned[length-1:]
-	}
-}
-
 // applyTokenization generates a consistent token for a value
 func (s *MaskingService) applyTokenization(value string) string {
 	// Generate SHA-256 hash
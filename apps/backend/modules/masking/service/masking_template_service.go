@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/redaction"
+	"github.com/google/uuid"
+)
+
+// maskingTemplateCacheTTL bounds how long a changed template takes to reach
+// every module and replica
+const maskingTemplateCacheTTL = time.Minute
+
+// MaskingTemplateView is the template applied to one PII type
+type MaskingTemplateView struct {
+	PIIType string `json:"pii_type"`
+	redaction.Template
+	Source    string     `json:"source"` // "default" or "tenant"
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// MaskingTemplateService resolves how each PII type is masked for a tenant: the
+// built-in templates with the tenant's overrides. The same templates mask stored
+// samples, findings responses, remediation previews and values masked in place
+// by remediation connectors.
+type MaskingTemplateService struct {
+	repo        repository.MaskingTemplateRepository
+	auditLogger interfaces.AuditLogger
+
+	mu      sync.RWMutex
+	tenants map[uuid.UUID]cachedMaskingTemplates
+}
+
+type cachedMaskingTemplates struct {
+	templates redaction.Templates
+	loadedAt  time.Time
+}
+
+// NewMaskingTemplateService creates a masking template service
+func NewMaskingTemplateService(repo repository.MaskingTemplateRepository, auditLogger interfaces.AuditLogger) *MaskingTemplateService {
+	return &MaskingTemplateService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		tenants:     make(map[uuid.UUID]cachedMaskingTemplates),
+	}
+}
+
+// Templates returns the templates of the tenant in ctx. They are cached briefly,
+// and the built-in templates are used if the tenant's cannot be loaded.
+func (s *MaskingTemplateService) Templates(ctx context.Context) redaction.Templates {
+	if s == nil {
+		return redaction.DefaultTemplates()
+	}
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return redaction.DefaultTemplates()
+	}
+
+	s.mu.RLock()
+	cached, ok := s.tenants[tenantID]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < maskingTemplateCacheTTL {
+		return cached.templates
+	}
+
+	stored, err := s.repo.ListMaskingTemplates(ctx)
+	if err != nil {
+		log.Printf("WARN: Failed to load masking templates; using the built-in templates: %v", err)
+		return redaction.DefaultTemplates()
+	}
+	overrides := make(redaction.Templates, len(stored))
+	for _, t := range stored {
+		overrides[t.PIIType] = templateOf(t)
+	}
+	templates := redaction.DefaultTemplates().With(overrides)
+
+	s.mu.Lock()
+	s.tenants[tenantID] = cachedMaskingTemplates{templates: templates, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return templates
+}
+
+// List returns the template of every PII type that has one, built-in or the tenant's
+func (s *MaskingTemplateService) List(ctx context.Context) ([]*MaskingTemplateView, error) {
+	stored, err := s.repo.ListMaskingTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make(map[string]*MaskingTemplateView)
+	for piiType, t := range redaction.DefaultTemplates() {
+		views[piiType] = &MaskingTemplateView{PIIType: piiType, Template: t, Source: "default"}
+	}
+	for _, t := range stored {
+		updatedAt := t.UpdatedAt
+		views[t.PIIType] = &MaskingTemplateView{
+			PIIType:   t.PIIType,
+			Template:  templateOf(t),
+			Source:    "tenant",
+			UpdatedBy: t.UpdatedBy,
+			UpdatedAt: &updatedAt,
+		}
+	}
+
+	list := make([]*MaskingTemplateView, 0, len(views))
+	for _, v := range views {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].PIIType < list[j].PIIType })
+	return list, nil
+}
+
+// Set replaces the tenant's template for a PII type
+func (s *MaskingTemplateService) Set(ctx context.Context, piiType string, template redaction.Template, actor string) (*MaskingTemplateView, error) {
+	piiType = redaction.CanonicalPIIType(piiType)
+	if piiType == "" {
+		return nil, fmt.Errorf("invalid PII type: must not be empty")
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	stored := &entity.MaskingTemplate{
+		PIIType:     piiType,
+		Style:       template.Style,
+		KeepFirst:   template.KeepFirst,
+		KeepLast:    template.KeepLast,
+		Format:      template.Format,
+		MaskChar:    template.MaskChar,
+		Replacement: template.Replacement,
+		UpdatedBy:   actor,
+	}
+	if err := s.repo.SaveMaskingTemplate(ctx, stored); err != nil {
+		return nil, err
+	}
+	s.forget(ctx)
+	s.audit(ctx, "MASKING_TEMPLATE_CHANGED", piiType, map[string]interface{}{
+		"pii_type":   piiType,
+		"template":   template,
+		"updated_by": actor,
+	})
+
+	return &MaskingTemplateView{
+		PIIType:   piiType,
+		Template:  templateOf(stored),
+		Source:    "tenant",
+		UpdatedBy: actor,
+		UpdatedAt: &stored.UpdatedAt,
+	}, nil
+}
+
+// Reset removes the tenant's template for a PII type, so the built-in one applies again
+func (s *MaskingTemplateService) Reset(ctx context.Context, piiType, actor string) error {
+	piiType = redaction.CanonicalPIIType(piiType)
+	deleted, err := s.repo.DeleteMaskingTemplate(ctx, piiType)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("masking template for %s not found", piiType)
+	}
+	s.forget(ctx)
+	s.audit(ctx, "MASKING_TEMPLATE_RESET", piiType, map[string]interface{}{
+		"pii_type": piiType,
+		"reset_by": actor,
+	})
+	return nil
+}
+
+// Preview masks a value of piiType with the tenant's templates, or with template
+// when one is given, without storing anything
+func (s *MaskingTemplateService) Preview(ctx context.Context, piiType, value string, template *redaction.Template) (string, error) {
+	if template != nil {
+		if err := template.Validate(); err != nil {
+			return "", err
+		}
+		return template.Mask(value), nil
+	}
+	return s.Templates(ctx).Mask(piiType, value), nil
+}
+
+// forget drops the cached templates of the tenant in ctx; other instances pick
+// the change up within maskingTemplateCacheTTL
+func (s *MaskingTemplateService) forget(ctx context.Context) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	delete(s.tenants, tenantID)
+	s.mu.Unlock()
+}
+
+func (s *MaskingTemplateService) audit(ctx context.Context, action, piiType string, details map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, "masking_template", piiType, details); err != nil {
+		log.Printf("WARNING: Failed to audit masking template change: %v", err)
+	}
+}
+
+func templateOf(t *entity.MaskingTemplate) redaction.Template {
+	return redaction.Template{
+		Style:       t.Style,
+		KeepFirst:   t.KeepFirst,
+		KeepLast:    t.KeepLast,
+		Format:      t.Format,
+		MaskChar:    t.MaskChar,
+		Replacement: t.Replacement,
+	}
+}
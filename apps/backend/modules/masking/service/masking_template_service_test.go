@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/arc-platform/backend/pkg/redaction"
+	"github.com/google/uuid"
+)
+
+type recordingAuditLogger struct {
+	actions []string
+}
+
+func (l *recordingAuditLogger) Record(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) error {
+	l.actions = append(l.actions, action)
+	return nil
+}
+
+func TestMaskingTemplateOverrides(t *testing.T) {
+	audit := &recordingAuditLogger{}
+	s := NewMaskingTemplateService(memory.NewRepository(), audit)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	if got := s.Templates(ctx).Mask("CREDIT_CARD", "4111111111111234"); got != "XXXXXXXXXXXX1234" {
+		t.Fatalf("expected the built-in card template, got %q", got)
+	}
+
+	// Pattern names resolve to the PII type they mask as
+	view, err := s.Set(ctx, "credit card", redaction.Template{Style: redaction.StylePartial, KeepFirst: 6, KeepLast: 4}, "asha@example.com")
+	if err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if view.PIIType != "CREDIT_CARD" || view.Source != "tenant" {
+		t.Fatalf("unexpected view: %+v", view)
+	}
+	if got := s.Templates(ctx).Mask("CREDIT_CARD", "4111111111111234"); got != "411111XXXXXX1234" {
+		t.Fatalf("expected the tenant's card template right after setting it, got %q", got)
+	}
+
+	list, err := s.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := make(map[string]string)
+	for _, v := range list {
+		sources[v.PIIType] = v.Source
+	}
+	if sources["CREDIT_CARD"] != "tenant" || sources["IN_AADHAAR"] != "default" {
+		t.Errorf("unexpected template sources: %v", sources)
+	}
+
+	if err := s.Reset(ctx, "CREDIT_CARD", "asha@example.com"); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if got := s.Templates(ctx).Mask("CREDIT_CARD", "4111111111111234"); got != "XXXXXXXXXXXX1234" {
+		t.Fatalf("expected the built-in card template after a reset, got %q", got)
+	}
+	if err := s.Reset(ctx, "CREDIT_CARD", "asha@example.com"); err == nil {
+		t.Error("expected resetting a type without a tenant template to fail")
+	}
+
+	if len(audit.actions) != 2 || audit.actions[0] != "MASKING_TEMPLATE_CHANGED" || audit.actions[1] != "MASKING_TEMPLATE_RESET" {
+		t.Errorf("unexpected audit events: %v", audit.actions)
+	}
+}
+
+func TestMaskingTemplateSetRejectsInvalid(t *testing.T) {
+	s := NewMaskingTemplateService(memory.NewRepository(), nil)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	if _, err := s.Set(ctx, "IN_AADHAAR", redaction.Template{Style: redaction.StyleFormat, Format: "####"}, "asha"); err == nil {
+		t.Error("expected a format without masked slots to be rejected")
+	}
+	if _, err := s.Set(ctx, " ", redaction.Template{Style: redaction.StyleRedact}, "asha"); err == nil {
+		t.Error("expected an empty PII type to be rejected")
+	}
+
+	masked, err := s.Preview(ctx, "IN_PAN", "ABCDE1234F", &redaction.Template{Style: redaction.StyleRedact, Replacement: "<pan>"})
+	if err != nil || masked != "<pan>" {
+		t.Errorf("expected the draft template to be previewed, got %q %v", masked, err)
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/remediation/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalHandler serves the four-eyes approval of remediation requests
+type ApprovalHandler struct {
+	service *service.RemediationService
+}
+
+// NewApprovalHandler creates a new approval handler
+func NewApprovalHandler(svc *service.RemediationService) *ApprovalHandler {
+	return &ApprovalHandler{service: svc}
+}
+
+// DecisionRequest is the body of an approve or reject call
+type DecisionRequest struct {
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+// ListApprovals handles GET /api/v1/remediation/approvals
+func (h *ApprovalHandler) ListApprovals(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", service.RequestPendingApproval, service.RequestApproved, service.RequestRejected,
+		service.RequestExpired, service.RequestExecuted, service.RequestFailed:
+	default:
+		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, "Invalid status", status))
+		return
+	}
+
+	requests, total, err := h.service.ListApprovalRequests(sharedapi.RequestContext(c), status, limit, offset)
+	if err != nil {
+		h.approvalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":   requests,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetApproval handles GET /api/v1/remediation/approvals/:id. The latest dry run
+// of each finding is included so the approver can see what the request would change.
+func (h *ApprovalHandler) GetApproval(c *gin.Context) {
+	ctx := sharedapi.RequestContext(c)
+	req, err := h.service.GetApprovalRequest(ctx, c.Param("id"))
+	if err != nil {
+		h.approvalError(c, err)
+		return
+	}
+	simulations, err := h.service.LatestSimulations(ctx, req.FindingIDs, req.ActionType)
+	if err != nil {
+		h.approvalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": req, "simulations": simulations})
+}
+
+// Approve handles POST /api/v1/remediation/approvals/:id/approve and executes the request
+func (h *ApprovalHandler) Approve(c *gin.Context) {
+	approverID, comment, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.service.ApproveRequest(sharedapi.RequestContext(c), c.Param("id"), approverID, comment)
+	if err != nil {
+		h.approvalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": req})
+}
+
+// Reject handles POST /api/v1/remediation/approvals/:id/reject
+func (h *ApprovalHandler) Reject(c *gin.Context) {
+	approverID, comment, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.service.RejectRequest(sharedapi.RequestContext(c), c.Param("id"), approverID, comment)
+	if err != nil {
+		h.approvalError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": req})
+}
+
+// bindDecision identifies the approver from their token, never from the body, and
+// checks that their role may approve remediation
+func (h *ApprovalHandler) bindDecision(c *gin.Context) (string, string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, interfaces.NewErrorResponse(interfaces.ErrCodeUnauthorized, "Approving remediation requires an authenticated user", ""))
+		return "", "", false
+	}
+	role, _ := c.Get("user_role")
+	if !service.CanApprove(fmt.Sprint(role)) {
+		c.JSON(http.StatusForbidden, interfaces.NewErrorResponse(interfaces.ErrCodeForbidden, "Insufficient permissions", "remediation:approve is required"))
+		return "", "", false
+	}
+
+	var req DecisionRequest
+	if c.Request.ContentLength > 0 && !sharedapi.BindJSON(c, &req) {
+		return "", "", false
+	}
+	return fmt.Sprint(userID), req.Comment, true
+}
+
+func (h *ApprovalHandler) approvalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrRequestNotFound):
+		c.JSON(http.StatusNotFound, interfaces.NewErrorResponse(interfaces.ErrCodeNotFound, err.Error(), ""))
+	case errors.Is(err, service.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, interfaces.NewErrorResponse(interfaces.ErrCodeForbidden, err.Error(), ""))
+	case errors.Is(err, service.ErrRequestNotPending):
+		c.JSON(http.StatusConflict, interfaces.NewErrorResponse(interfaces.ErrCodeConflict, err.Error(), ""))
+	default:
+		c.JSON(http.StatusInternalServerError, interfaces.NewErrorResponse(interfaces.ErrCodeInternalServer, "Failed to process remediation request", err.Error()))
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/remediation/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BatchHandler serves throttled bulk remediation batches
+type BatchHandler struct {
+	service *service.RemediationBatchService
+}
+
+// NewBatchHandler creates a new remediation batch handler
+func NewBatchHandler(svc *service.RemediationBatchService) *BatchHandler {
+	return &BatchHandler{service: svc}
+}
+
+// CreateBatchRequest is the body of a remediation batch request
+type CreateBatchRequest struct {
+	FindingIDs []string `json:"finding_ids" binding:"required,min=1"`
+	ActionType string   `json:"action_type" binding:"required,oneof=MASK DELETE ENCRYPT"`
+}
+
+// CreateBatch handles POST /api/v1/remediation/batches. The batch runs in the
+// background; its progress is read with GetBatch.
+func (h *BatchHandler) CreateBatch(c *gin.Context) {
+	var req CreateBatchRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	batch, err := h.service.Create(sharedapi.RequestContext(c), req.FindingIDs, req.ActionType, batchActor(c))
+	if err != nil {
+		h.batchError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"data": batch})
+}
+
+// ListBatches handles GET /api/v1/remediation/batches
+func (h *BatchHandler) ListBatches(c *gin.Context) {
+	batches, err := h.service.List(sharedapi.RequestContext(c))
+	if err != nil {
+		h.batchError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": batches})
+}
+
+// GetLimits handles GET /api/v1/remediation/batches/limits
+func (h *BatchHandler) GetLimits(c *gin.Context) {
+	limits := h.service.Limits()
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"default":    limits.Default,
+		"connectors": limits.Connectors,
+	}})
+}
+
+// GetBatch handles GET /api/v1/remediation/batches/:id with progress per connection
+func (h *BatchHandler) GetBatch(c *gin.Context) {
+	id, ok := batchID(c)
+	if !ok {
+		return
+	}
+	batch, err := h.service.Get(sharedapi.RequestContext(c), id)
+	if err != nil {
+		h.batchError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+// PauseBatch handles POST /api/v1/remediation/batches/:id/pause
+func (h *BatchHandler) PauseBatch(c *gin.Context) {
+	id, ok := batchID(c)
+	if !ok {
+		return
+	}
+	batch, err := h.service.Pause(sharedapi.RequestContext(c), id, batchActor(c))
+	if err != nil {
+		h.batchError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+// ResumeBatch handles POST /api/v1/remediation/batches/:id/resume
+func (h *BatchHandler) ResumeBatch(c *gin.Context) {
+	id, ok := batchID(c)
+	if !ok {
+		return
+	}
+	batch, err := h.service.Resume(sharedapi.RequestContext(c), id, batchActor(c))
+	if err != nil {
+		h.batchError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+func batchID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, "Invalid batch ID", c.Param("id")))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// batchActor is the authenticated user, or "system" when AUTH_REQUIRED is off
+func batchActor(c *gin.Context) string {
+	if userID, exists := c.Get("user_id"); exists {
+		return fmt.Sprint(userID)
+	}
+	return "system"
+}
+
+func (h *BatchHandler) batchError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrBatchNotFound):
+		c.JSON(http.StatusNotFound, interfaces.NewErrorResponse(interfaces.ErrCodeNotFound, err.Error(), ""))
+	case errors.Is(err, service.ErrInvalidBatch):
+		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, err.Error(), ""))
+	case errors.Is(err, service.ErrBatchState), errors.Is(err, service.ErrBatchRequiresApproval):
+		c.JSON(http.StatusConflict, interfaces.NewErrorResponse(interfaces.ErrCodeConflict, err.Error(), ""))
+	default:
+		c.JSON(http.StatusInternalServerError, interfaces.NewErrorResponse(interfaces.ErrCodeInternalServer, "Failed to process remediation batch", err.Error()))
+	}
+}
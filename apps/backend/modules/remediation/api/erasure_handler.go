@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/remediation/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ErasureHandler serves data principal erasure requests and their certificates
+type ErasureHandler struct {
+	service *service.ErasureService
+}
+
+// NewErasureHandler creates a new erasure handler
+func NewErasureHandler(svc *service.ErasureService) *ErasureHandler {
+	return &ErasureHandler{service: svc}
+}
+
+// CreateErasureRequest is the body of POST /remediation/dsr/erasures. Value is
+// hashed on arrival and never stored or logged.
+type CreateErasureRequest struct {
+	ValueHash          string `json:"value_hash"`
+	Value              string `json:"value"`
+	RequesterReference string `json:"requester_reference" binding:"required,max=255"`
+	ActionType         string `json:"action_type"`
+	Justification      string `json:"justification" binding:"max=1000"`
+}
+
+// VerifyCertificateRequest is the body of POST /remediation/dsr/erasures/certificates/verify
+type VerifyCertificateRequest struct {
+	Document  json.RawMessage `json:"document" binding:"required"`
+	Signature string          `json:"signature" binding:"required"`
+}
+
+// CreateErasure handles POST /api/v1/remediation/dsr/erasures. The plan is held
+// until a second user approves it.
+func (h *ErasureHandler) CreateErasure(c *gin.Context) {
+	var body CreateErasureRequest
+	if !sharedapi.BindJSON(c, &body) {
+		return
+	}
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, interfaces.NewErrorResponse(interfaces.ErrCodeUnauthorized, "Requesting an erasure requires an authenticated user", ""))
+		return
+	}
+
+	req, err := h.service.Create(sharedapi.RequestContext(c), service.ErasureInput{
+		ValueHash:          body.ValueHash,
+		Value:              body.Value,
+		RequesterReference: body.RequesterReference,
+		ActionType:         body.ActionType,
+		Justification:      body.Justification,
+	}, fmt.Sprint(userID))
+	if err != nil {
+		h.erasureError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"data": req})
+}
+
+// ListErasures handles GET /api/v1/remediation/dsr/erasures
+func (h *ErasureHandler) ListErasures(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", entity.ErasurePendingApproval, entity.ErasureRejected, entity.ErasureExecuting,
+		entity.ErasureCompleted, entity.ErasurePartial, entity.ErasureFailed:
+	default:
+		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, "Invalid status", status))
+		return
+	}
+
+	requests, total, err := h.service.List(sharedapi.RequestContext(c), status, limit, offset)
+	if err != nil {
+		h.erasureError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":   requests,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetErasure handles GET /api/v1/remediation/dsr/erasures/:id
+func (h *ErasureHandler) GetErasure(c *gin.Context) {
+	id, ok := h.requestID(c)
+	if !ok {
+		return
+	}
+	req, err := h.service.Get(sharedapi.RequestContext(c), id)
+	if err != nil {
+		h.erasureError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": req})
+}
+
+// Approve handles POST /api/v1/remediation/dsr/erasures/:id/approve and executes the request
+func (h *ErasureHandler) Approve(c *gin.Context) {
+	id, ok := h.requestID(c)
+	if !ok {
+		return
+	}
+	approverID, comment, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.service.Approve(sharedapi.RequestContext(c), id, approverID, comment)
+	if err != nil {
+		h.erasureError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": req})
+}
+
+// Reject handles POST /api/v1/remediation/dsr/erasures/:id/reject
+func (h *ErasureHandler) Reject(c *gin.Context) {
+	id, ok := h.requestID(c)
+	if !ok {
+		return
+	}
+	approverID, comment, ok := h.bindDecision(c)
+	if !ok {
+		return
+	}
+
+	req, err := h.service.Reject(sharedapi.RequestContext(c), id, approverID, comment)
+	if err != nil {
+		h.erasureError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": req})
+}
+
+// GetCertificate handles GET /api/v1/remediation/dsr/erasures/:id/certificate
+func (h *ErasureHandler) GetCertificate(c *gin.Context) {
+	id, ok := h.requestID(c)
+	if !ok {
+		return
+	}
+	cert, err := h.service.Certificate(sharedapi.RequestContext(c), id)
+	if err != nil {
+		h.erasureError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": cert})
+}
+
+// VerifyCertificate handles POST /api/v1/remediation/dsr/erasures/certificates/verify
+func (h *ErasureHandler) VerifyCertificate(c *gin.Context) {
+	var body VerifyCertificateRequest
+	if !sharedapi.BindJSON(c, &body) {
+		return
+	}
+	key := h.service.SigningKey()
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{
+		"valid":           h.service.VerifyCertificate(body.Document, body.Signature),
+		"key_fingerprint": key.Fingerprint,
+	}})
+}
+
+// GetSigningKey handles GET /api/v1/remediation/dsr/erasures/signing-key, so
+// requesters can verify certificates on their own
+func (h *ErasureHandler) GetSigningKey(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.service.SigningKey()})
+}
+
+func (h *ErasureHandler) requestID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, "Invalid erasure request ID", c.Param("id")))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// bindDecision identifies the approver from their token, never from the body, and
+// checks that their role may approve remediation
+func (h *ErasureHandler) bindDecision(c *gin.Context) (string, string, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, interfaces.NewErrorResponse(interfaces.ErrCodeUnauthorized, "Approving an erasure requires an authenticated user", ""))
+		return "", "", false
+	}
+	role, _ := c.Get("user_role")
+	if !service.CanApprove(fmt.Sprint(role)) {
+		c.JSON(http.StatusForbidden, interfaces.NewErrorResponse(interfaces.ErrCodeForbidden, "Insufficient permissions", "remediation:approve is required"))
+		return "", "", false
+	}
+
+	var req DecisionRequest
+	if c.Request.ContentLength > 0 && !sharedapi.BindJSON(c, &req) {
+		return "", "", false
+	}
+	return fmt.Sprint(userID), req.Comment, true
+}
+
+func (h *ErasureHandler) erasureError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidErasure):
+		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, err.Error(), ""))
+	case errors.Is(err, service.ErrErasureNotFound), errors.Is(err, service.ErrErasureValueNotFound),
+		errors.Is(err, service.ErrCertificateNotIssued):
+		c.JSON(http.StatusNotFound, interfaces.NewErrorResponse(interfaces.ErrCodeNotFound, err.Error(), ""))
+	case errors.Is(err, service.ErrErasureSelfApproval):
+		c.JSON(http.StatusForbidden, interfaces.NewErrorResponse(interfaces.ErrCodeForbidden, err.Error(), ""))
+	case errors.Is(err, service.ErrErasureNotPending):
+		c.JSON(http.StatusConflict, interfaces.NewErrorResponse(interfaces.ErrCodeConflict, err.Error(), ""))
+	default:
+		c.JSON(http.StatusInternalServerError, interfaces.NewErrorResponse(interfaces.ErrCodeInternalServer, "Failed to process erasure request", err.Error()))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/remediation/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -66,8 +67,7 @@ type ApprovalRequest struct {
 // PreviewRemediation generates a preview of remediation impact
 func (h *RemediationConfirmationHandler) PreviewRemediation(c *gin.Context) {
 	var req PreviewRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -84,8 +84,7 @@ func (h *RemediationConfirmationHandler) PreviewRemediation(c *gin.Context) {
 // ApproveRemediation approves and executes remediation
 func (h *RemediationConfirmationHandler) ApproveRemediation(c *gin.Context) {
 	var req ApprovalRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
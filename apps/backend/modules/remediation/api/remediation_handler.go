@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/remediation/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
 )
@@ -26,6 +28,10 @@ type ExecuteRemediationRequest struct {
 	FindingIDs []string `json:"finding_ids" binding:"required"`
 	ActionType string   `json:"action_type" binding:"required,oneof=MASK DELETE ENCRYPT"`
 	UserID     string   `json:"user_id" binding:"required"`
+	// Justification is shown to approvers when the request needs a second approver
+	Justification string `json:"justification" binding:"max=2000"`
+	// DryRun simulates the remediation against the source without changing it
+	DryRun bool `json:"dry_run"`
 }
 
 // ExecuteRemediationResponse represents a remediation execution response
@@ -36,11 +42,38 @@ type ExecuteRemediationResponse struct {
 	Errors    []string `json:"errors,omitempty"`
 }
 
-// ExecuteRemediation executes remediation for multiple findings
+// ExecuteRemediation executes remediation for multiple findings. Requests the
+// approval policy gates are held as pending_approval and answered with 202. A dry
+// run (dry_run in the body or query) changes nothing, so it skips the approval
+// gate and returns the stored simulations instead.
 func (h *RemediationHandler) ExecuteRemediation(c *gin.Context) {
 	var req ExecuteRemediationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, "Invalid request format", err.Error()))
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	// The authenticated user is the requester; user_id in the body only names
+	// anonymous callers when AUTH_REQUIRED is off
+	if userID, exists := c.Get("user_id"); exists {
+		req.UserID = fmt.Sprint(userID)
+	}
+
+	if req.DryRun || c.Query("dry_run") == "true" {
+		h.simulateRemediation(c, req)
+		return
+	}
+
+	if h.service.RequiresApproval(req.ActionType, req.FindingIDs) {
+		pending, err := h.service.SubmitForApproval(sharedapi.RequestContext(c), req.FindingIDs, req.ActionType, req.UserID, req.Justification)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, interfaces.NewErrorResponse(interfaces.ErrCodeInternalServer, "Failed to submit remediation for approval", err.Error()))
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{
+			"status":  service.RequestPendingApproval,
+			"message": "Remediation requires approval by a second user with the remediation:approve permission",
+			"data":    pending,
+		})
 		return
 	}
 
@@ -68,6 +101,43 @@ func (h *RemediationHandler) ExecuteRemediation(c *gin.Context) {
 	})
 }
 
+// simulateRemediation dry-runs the request for each finding. A simulation that
+// finds the real run would fail is still stored and returned; only findings that
+// could not be simulated at all are reported as errors.
+func (h *RemediationHandler) simulateRemediation(c *gin.Context, req ExecuteRemediationRequest) {
+	ctx := sharedapi.RequestContext(c)
+	simulations := make([]*service.RemediationSimulation, 0, len(req.FindingIDs))
+	var errors []string
+	for _, findingID := range req.FindingIDs {
+		sim, err := h.service.SimulateRemediation(ctx, findingID, req.ActionType, req.UserID)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Finding %s: %s", findingID, err.Error()))
+			continue
+		}
+		simulations = append(simulations, sim)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run": true,
+		"data":    simulations,
+		"errors":  errors,
+	})
+}
+
+// GetSimulation handles GET /api/v1/remediation/simulations/:id
+func (h *RemediationHandler) GetSimulation(c *gin.Context) {
+	sim, err := h.service.GetSimulation(sharedapi.RequestContext(c), c.Param("id"))
+	if errors.Is(err, service.ErrSimulationNotFound) {
+		c.JSON(http.StatusNotFound, interfaces.NewErrorResponse(interfaces.ErrCodeNotFound, err.Error(), ""))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, interfaces.NewErrorResponse(interfaces.ErrCodeInternalServer, "Failed to retrieve remediation simulation", err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": sim})
+}
+
 // RollbackRemediation rolls back a remediation action
 func (h *RemediationHandler) RollbackRemediation(c *gin.Context) {
 	actionID := c.Param("actionId")
@@ -83,14 +153,16 @@ func (h *RemediationHandler) RollbackRemediation(c *gin.Context) {
 	})
 }
 
+// GeneratePreviewRequest is the body of a remediation preview request
+type GeneratePreviewRequest struct {
+	FindingIDs []string `json:"finding_ids" binding:"required,min=1"`
+	ActionType string   `json:"action_type" binding:"required"`
+}
+
 // GeneratePreview generates a remediation preview
 func (h *RemediationHandler) GeneratePreview(c *gin.Context) {
-	var req struct {
-		FindingIDs []string `json:"finding_ids"`
-		ActionType string   `json:"action_type"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, interfaces.NewErrorResponse(interfaces.ErrCodeBadRequest, "Invalid request format", err.Error()))
+	var req GeneratePreviewRequest
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -43,7 +43,7 @@ func (f *ConnectorFactory) NewConnector(sourceType string) (SourceConnector, err
 		return &S3Connector{}, nil
 	case "mongodb":
 		return &MongoDBConnector{}, nil
-	case "filesystem":
+	case "filesystem", "fs":
 		return &FilesystemConnector{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", sourceType)
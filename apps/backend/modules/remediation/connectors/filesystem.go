@@ -164,3 +164,135 @@ func (c *FilesystemConnector) maskPIIInContent(content string, fieldName string)
 	re := regexp.MustCompile(pattern)
 	return re.ReplaceAllString(content, "***REDACTED***")
 }
+
+// MaskMatch overwrites the matched value at its line and column with its masked
+// form of the same length, so the offsets of other matches in the file stay valid
+func (c *FilesystemConnector) MaskMatch(ctx context.Context, target MatchTarget) (string, error) {
+	return target.Value, c.rewriteFile(target.Location, func(content string) (string, error) {
+		return replaceAtPosition(content, target.Line, target.Column, target.Value, maskedValue(target, target.Value))
+	})
+}
+
+// DeleteMatch blanks the line holding the match and returns the line for rollback.
+// The line break is kept so later line numbers still address the same lines.
+func (c *FilesystemConnector) DeleteMatch(ctx context.Context, target MatchTarget) (string, error) {
+	var original string
+	err := c.rewriteFile(target.Location, func(content string) (string, error) {
+		var err error
+		content, original, err = replaceLine(content, target.Line, target.Column, target.Value, "")
+		return content, err
+	})
+	return original, err
+}
+
+// RestoreMatch puts a masked value or a blanked line back in place
+func (c *FilesystemConnector) RestoreMatch(ctx context.Context, target MatchTarget, actionType string, snapshot string) error {
+	return c.rewriteFile(target.Location, func(content string) (string, error) {
+		switch actionType {
+		case "MASK":
+			return replaceAtPosition(content, target.Line, target.Column, maskedValue(target, snapshot), snapshot)
+		case "DELETE":
+			restored, _, err := replaceLine(content, target.Line, 0, "", snapshot)
+			return restored, err
+		default:
+			return "", fmt.Errorf("unsupported action type: %s", actionType)
+		}
+	})
+}
+
+// SimulateMatch checks the file still holds the match and returns the line as it
+// is and as MaskMatch or DeleteMatch would leave it
+func (c *FilesystemConnector) SimulateMatch(ctx context.Context, target MatchTarget, actionType string) (*SimulatedChange, error) {
+	content, err := ioutil.ReadFile(filepath.Join(c.basePath, target.Location))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	change := &SimulatedChange{Target: target}
+	switch actionType {
+	case "MASK":
+		updated, err := replaceAtPosition(string(content), target.Line, target.Column, target.Value, maskedValue(target, target.Value))
+		if err != nil {
+			return nil, err
+		}
+		change.Before = strings.Split(string(content), "\n")[target.Line-1]
+		change.After = strings.Split(updated, "\n")[target.Line-1]
+	case "DELETE":
+		if _, change.Before, err = replaceLine(string(content), target.Line, target.Column, target.Value, ""); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported action type: %s", actionType)
+	}
+	return change, nil
+}
+
+// rewriteFile applies edit to the file at location, keeping its permissions
+func (c *FilesystemConnector) rewriteFile(location string, edit func(string) (string, error)) error {
+	filePath := filepath.Join(c.basePath, location)
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	updated, err := edit(string(content))
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filePath, []byte(updated), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// maskedValue is what replaces value in a file: the target's masked form when it
+// keeps the value's length, asterisks otherwise
+func maskedValue(target MatchTarget, value string) string {
+	length := len([]rune(value))
+	if target.Masked != "" && len([]rune(target.Masked)) == length {
+		return target.Masked
+	}
+	return strings.Repeat("*", length)
+}
+
+// replaceAtPosition swaps expected for replacement at a 1-based line and column
+// (in characters), failing if the file no longer holds expected there
+func replaceAtPosition(content string, line, column int, expected, replacement string) (string, error) {
+	lines := strings.Split(content, "\n")
+	if line < 1 || line > len(lines) {
+		return "", fmt.Errorf("line %d is out of range", line)
+	}
+	text := []rune(lines[line-1])
+	want := []rune(expected)
+	start := column - 1
+	if column < 1 || start+len(want) > len(text) || string(text[start:start+len(want)]) != expected {
+		return "", fmt.Errorf("content at line %d, column %d no longer matches the finding", line, column)
+	}
+
+	lines[line-1] = string(text[:start]) + replacement + string(text[start+len(want):])
+	return strings.Join(lines, "\n"), nil
+}
+
+// replaceLine swaps a 1-based line for replacement and returns the old line. When
+// expected is set the line must still hold it at column.
+func replaceLine(content string, line, column int, expected, replacement string) (string, string, error) {
+	lines := strings.Split(content, "\n")
+	if line < 1 || line > len(lines) {
+		return "", "", fmt.Errorf("line %d is out of range", line)
+	}
+	original := lines[line-1]
+	if expected != "" {
+		if _, err := replaceAtPosition(original, 1, column, expected, expected); err != nil {
+			return "", "", fmt.Errorf("content at line %d, column %d no longer matches the finding", line, column)
+		}
+	}
+
+	lines[line-1] = replacement
+	return strings.Join(lines, "\n"), original, nil
+}
@@ -0,0 +1,109 @@
+package connectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilesystemMaskMatchTargetsExactPosition(t *testing.T) {
+	dir := t.TempDir()
+	content := "name,email\nasha,asha@example.com\nravi,asha@example.com\n"
+	if err := os.WriteFile(filepath.Join(dir, "users.csv"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &FilesystemConnector{}
+	if err := c.Connect(context.Background(), map[string]interface{}{"base_path": dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the occurrence on line 3 is masked, the identical value on line 2 is left alone
+	target := MatchTarget{Location: "users.csv", Value: "asha@example.com", Line: 3, Column: 6}
+	snapshot, err := c.MaskMatch(context.Background(), target)
+	if err != nil {
+		t.Fatalf("MaskMatch: %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "users.csv"))
+	if want := "name,email\nasha,asha@example.com\nravi,****************\n"; string(got) != want {
+		t.Fatalf("masked content = %q, want %q", got, want)
+	}
+
+	// A stale location must not touch the file
+	if _, err := c.MaskMatch(context.Background(), target); err == nil {
+		t.Fatal("expected masking an already masked position to fail")
+	}
+
+	if err := c.RestoreMatch(context.Background(), target, "MASK", snapshot); err != nil {
+		t.Fatalf("RestoreMatch: %v", err)
+	}
+	got, _ = os.ReadFile(filepath.Join(dir, "users.csv"))
+	if string(got) != content {
+		t.Fatalf("restored content = %q, want %q", got, content)
+	}
+}
+
+func TestFilesystemMaskMatchUsesTemplateMask(t *testing.T) {
+	dir := t.TempDir()
+	content := "card=4111 1111 1111 1234\n"
+	if err := os.WriteFile(filepath.Join(dir, "orders.txt"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &FilesystemConnector{basePath: dir}
+	target := MatchTarget{Location: "orders.txt", Value: "4111 1111 1111 1234", Masked: "XXXX XXXX XXXX 1234", Line: 1, Column: 6}
+	snapshot, err := c.MaskMatch(context.Background(), target)
+	if err != nil {
+		t.Fatalf("MaskMatch: %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "orders.txt"))
+	if want := "card=XXXX XXXX XXXX 1234\n"; string(got) != want {
+		t.Fatalf("masked content = %q, want %q", got, want)
+	}
+
+	if err := c.RestoreMatch(context.Background(), target, "MASK", snapshot); err != nil {
+		t.Fatalf("RestoreMatch: %v", err)
+	}
+	got, _ = os.ReadFile(filepath.Join(dir, "orders.txt"))
+	if string(got) != content {
+		t.Fatalf("restored content = %q, want %q", got, content)
+	}
+
+	// A masked form of another length would shift later columns, so asterisks are written
+	target.Masked = "[REDACTED]"
+	if _, err := c.MaskMatch(context.Background(), target); err != nil {
+		t.Fatalf("MaskMatch: %v", err)
+	}
+	got, _ = os.ReadFile(filepath.Join(dir, "orders.txt"))
+	if want := "card=*******************\n"; string(got) != want {
+		t.Fatalf("masked content = %q, want %q", got, want)
+	}
+}
+
+func TestFilesystemDeleteMatchKeepsLineNumbers(t *testing.T) {
+	dir := t.TempDir()
+	content := "a\nsecret=XYZ\nc\n"
+	if err := os.WriteFile(filepath.Join(dir, "app.env"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &FilesystemConnector{basePath: dir}
+	target := MatchTarget{Location: "app.env", Value: "XYZ", Line: 2, Column: 8}
+	snapshot, err := c.DeleteMatch(context.Background(), target)
+	if err != nil {
+		t.Fatalf("DeleteMatch: %v", err)
+	}
+	got, _ := os.ReadFile(filepath.Join(dir, "app.env"))
+	if want := "a\n\nc\n"; string(got) != want {
+		t.Fatalf("content after delete = %q, want %q", got, want)
+	}
+
+	if err := c.RestoreMatch(context.Background(), target, "DELETE", snapshot); err != nil {
+		t.Fatalf("RestoreMatch: %v", err)
+	}
+	got, _ = os.ReadFile(filepath.Join(dir, "app.env"))
+	if string(got) != content {
+		t.Fatalf("restored content = %q, want %q", got, content)
+	}
+}
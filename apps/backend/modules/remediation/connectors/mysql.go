@@ -103,3 +103,35 @@ func (c *MySQLConnector) RestoreValue(ctx context.Context, location string, fiel
 	}
 	return nil
 }
+
+// MaskMatch redacts the field of the single row the match was found in
+func (c *MySQLConnector) MaskMatch(ctx context.Context, target MatchTarget) (string, error) {
+	if err := requireRecordTarget(target); err != nil {
+		return "", err
+	}
+	return mysqlDialect.maskRecord(ctx, c.db, target)
+}
+
+// DeleteMatch deletes the single row the match was found in
+func (c *MySQLConnector) DeleteMatch(ctx context.Context, target MatchTarget) (string, error) {
+	if err := requireRecordTarget(target); err != nil {
+		return "", err
+	}
+	return mysqlDialect.deleteRecord(ctx, c.db, target)
+}
+
+// RestoreMatch writes back the masked value or re-inserts the deleted row
+func (c *MySQLConnector) RestoreMatch(ctx context.Context, target MatchTarget, actionType string, snapshot string) error {
+	if err := requireRecordTarget(target); err != nil {
+		return err
+	}
+	return mysqlDialect.restoreRecord(ctx, c.db, target, actionType, snapshot)
+}
+
+// SimulateMatch reads the row a MaskMatch or DeleteMatch would change
+func (c *MySQLConnector) SimulateMatch(ctx context.Context, target MatchTarget, actionType string) (*SimulatedChange, error) {
+	if err := requireRecordTarget(target); err != nil {
+		return nil, err
+	}
+	return mysqlDialect.simulateRecord(ctx, c.db, target, actionType)
+}
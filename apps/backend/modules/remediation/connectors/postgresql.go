@@ -106,3 +106,35 @@ func (c *PostgreSQLConnector) RestoreValue(ctx context.Context, location string,
 	}
 	return nil
 }
+
+// MaskMatch redacts the field of the single row the match was found in
+func (c *PostgreSQLConnector) MaskMatch(ctx context.Context, target MatchTarget) (string, error) {
+	if err := requireRecordTarget(target); err != nil {
+		return "", err
+	}
+	return postgresDialect.maskRecord(ctx, c.db, target)
+}
+
+// DeleteMatch deletes the single row the match was found in
+func (c *PostgreSQLConnector) DeleteMatch(ctx context.Context, target MatchTarget) (string, error) {
+	if err := requireRecordTarget(target); err != nil {
+		return "", err
+	}
+	return postgresDialect.deleteRecord(ctx, c.db, target)
+}
+
+// RestoreMatch writes back the masked value or re-inserts the deleted row
+func (c *PostgreSQLConnector) RestoreMatch(ctx context.Context, target MatchTarget, actionType string, snapshot string) error {
+	if err := requireRecordTarget(target); err != nil {
+		return err
+	}
+	return postgresDialect.restoreRecord(ctx, c.db, target, actionType, snapshot)
+}
+
+// SimulateMatch reads the row a MaskMatch or DeleteMatch would change
+func (c *PostgreSQLConnector) SimulateMatch(ctx context.Context, target MatchTarget, actionType string) (*SimulatedChange, error) {
+	if err := requireRecordTarget(target); err != nil {
+		return nil, err
+	}
+	return postgresDialect.simulateRecord(ctx, c.db, target, actionType)
+}
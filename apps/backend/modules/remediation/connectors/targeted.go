@@ -0,0 +1,252 @@
+package connectors
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MatchTarget addresses a single matched value inside an asset. File targets use
+// Line and Column, database targets use Table, Field and the row's primary key.
+type MatchTarget struct {
+	Location         string `json:"location"`         // Asset path
+	Value            string `json:"-"`                // Matched value, verified before a file is changed
+	Masked           string `json:"masked,omitempty"` // What a MASK writes in place of Value
+	Line             int    `json:"line,omitempty"`
+	Column           int    `json:"column,omitempty"`
+	Table            string `json:"table,omitempty"`
+	Field            string `json:"field,omitempty"`
+	PrimaryKeyColumn string `json:"pk_column,omitempty"`
+	PrimaryKey       string `json:"pk,omitempty"`
+}
+
+// Key identifies the record a target lives in; targets sharing a key are removed
+// by a single DELETE
+func (t MatchTarget) Key() string {
+	if t.Table != "" {
+		return fmt.Sprintf("%s|%s=%s", t.Table, t.PrimaryKeyColumn, t.PrimaryKey)
+	}
+	return fmt.Sprintf("%s:%d", t.Location, t.Line)
+}
+
+// TargetedConnector is implemented by connectors that can remediate one located
+// match instead of the whole asset. Each call returns a snapshot that RestoreMatch
+// uses to undo it.
+type TargetedConnector interface {
+	// MaskMatch redacts exactly the matched value
+	MaskMatch(ctx context.Context, target MatchTarget) (string, error)
+
+	// DeleteMatch removes the record (database row or file line) holding the match
+	DeleteMatch(ctx context.Context, target MatchTarget) (string, error)
+
+	// RestoreMatch undoes a MaskMatch or DeleteMatch from its snapshot
+	RestoreMatch(ctx context.Context, target MatchTarget, actionType string, snapshot string) error
+}
+
+// SimulatedChange is what a remediation would change at one target. Approximate
+// is set when the connector could only confirm the record and After is the
+// action's nominal effect rather than the exact value it would write.
+type SimulatedChange struct {
+	Target      MatchTarget `json:"target"`
+	Before      string      `json:"before"`
+	After       string      `json:"after"`
+	Approximate bool        `json:"approximate,omitempty"`
+}
+
+// SimulatingConnector is implemented by targeted connectors that can check a
+// target is still in place and report the change MaskMatch or DeleteMatch would
+// make to it, without writing anything
+type SimulatingConnector interface {
+	SimulateMatch(ctx context.Context, target MatchTarget, actionType string) (*SimulatedChange, error)
+}
+
+// sqlDialect captures the identifier quoting and placeholder syntax of a database
+type sqlDialect struct {
+	quote       string
+	placeholder func(n int) string
+}
+
+var (
+	postgresDialect = sqlDialect{quote: `"`, placeholder: func(n int) string { return fmt.Sprintf("$%d", n) }}
+	mysqlDialect    = sqlDialect{quote: "`", placeholder: func(int) string { return "?" }}
+)
+
+// ident quotes a possibly schema-qualified identifier
+func (d sqlDialect) ident(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = d.quote + strings.ReplaceAll(part, d.quote, d.quote+d.quote) + d.quote
+	}
+	return strings.Join(parts, ".")
+}
+
+func (d sqlDialect) maskRecord(ctx context.Context, db *sql.DB, t MatchTarget) (string, error) {
+	original, err := d.readField(ctx, db, t)
+	if err != nil {
+		return "", err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+		d.ident(t.Table), d.ident(t.Field), d.placeholder(1), d.ident(t.PrimaryKeyColumn), d.placeholder(2))
+	if _, err := db.ExecContext(ctx, query, maskedField(original, t), t.PrimaryKey); err != nil {
+		return "", fmt.Errorf("failed to mask PII: %w", err)
+	}
+
+	return original, nil
+}
+
+// maskedField is what a masked field holds: the field with the matched value
+// masked in place when it holds the value, or the masked value alone otherwise
+func maskedField(original string, t MatchTarget) string {
+	if t.Masked == "" {
+		return "REDACTED"
+	}
+	if t.Value != "" && strings.Contains(original, t.Value) {
+		return strings.ReplaceAll(original, t.Value, t.Masked)
+	}
+	return t.Masked
+}
+
+// readField returns the target field of the target's row
+func (d sqlDialect) readField(ctx context.Context, db *sql.DB, t MatchTarget) (string, error) {
+	var value sql.NullString
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s",
+		d.ident(t.Field), d.ident(t.Table), d.ident(t.PrimaryKeyColumn), d.placeholder(1))
+	if err := db.QueryRowContext(ctx, query, t.PrimaryKey).Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("record %s=%s not found in %s", t.PrimaryKeyColumn, t.PrimaryKey, t.Table)
+		}
+		return "", fmt.Errorf("failed to read value: %w", err)
+	}
+	return value.String, nil
+}
+
+// deleteRecord snapshots the whole row as a JSON object of column values before
+// deleting it, so the row can be re-inserted on rollback
+func (d sqlDialect) deleteRecord(ctx context.Context, db *sql.DB, t MatchTarget) (string, error) {
+	snapshot, err := d.readRecord(ctx, db, t)
+	if err != nil {
+		return "", err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = %s",
+		d.ident(t.Table), d.ident(t.PrimaryKeyColumn), d.placeholder(1))
+	if _, err := db.ExecContext(ctx, query, t.PrimaryKey); err != nil {
+		return "", fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// readRecord returns the target's row as a JSON object of column values
+func (d sqlDialect) readRecord(ctx context.Context, db *sql.DB, t MatchTarget) (string, error) {
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s = %s",
+		d.ident(t.Table), d.ident(t.PrimaryKeyColumn), d.placeholder(1))
+	rows, err := db.QueryContext(ctx, query, t.PrimaryKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to read record: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", fmt.Errorf("failed to read record: %w", err)
+		}
+		return "", fmt.Errorf("record %s=%s not found in %s", t.PrimaryKeyColumn, t.PrimaryKey, t.Table)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", fmt.Errorf("failed to read record: %w", err)
+	}
+	record := make(map[string]*string, len(columns))
+	for i, column := range columns {
+		if values[i] != nil {
+			value := string(values[i])
+			record[column] = &value
+		} else {
+			record[column] = nil
+		}
+	}
+
+	snapshot, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	return string(snapshot), nil
+}
+
+// simulateRecord reads what maskRecord or deleteRecord would change. A masked
+// field is reported as maskRecord would write it; a deleted row is reported as
+// its column values before and nothing after.
+func (d sqlDialect) simulateRecord(ctx context.Context, db *sql.DB, t MatchTarget, actionType string) (*SimulatedChange, error) {
+	switch actionType {
+	case "MASK":
+		before, err := d.readField(ctx, db, t)
+		if err != nil {
+			return nil, err
+		}
+		return &SimulatedChange{Target: t, Before: before, After: maskedField(before, t)}, nil
+	case "DELETE":
+		before, err := d.readRecord(ctx, db, t)
+		if err != nil {
+			return nil, err
+		}
+		return &SimulatedChange{Target: t, Before: before}, nil
+	default:
+		return nil, fmt.Errorf("unsupported action type: %s", actionType)
+	}
+}
+
+func (d sqlDialect) restoreRecord(ctx context.Context, db *sql.DB, t MatchTarget, actionType string, snapshot string) error {
+	switch actionType {
+	case "MASK":
+		query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+			d.ident(t.Table), d.ident(t.Field), d.placeholder(1), d.ident(t.PrimaryKeyColumn), d.placeholder(2))
+		if _, err := db.ExecContext(ctx, query, snapshot, t.PrimaryKey); err != nil {
+			return fmt.Errorf("failed to restore value: %w", err)
+		}
+		return nil
+	case "DELETE":
+		var record map[string]*string
+		if err := json.Unmarshal([]byte(snapshot), &record); err != nil {
+			return fmt.Errorf("invalid record snapshot: %w", err)
+		}
+		columns := make([]string, 0, len(record))
+		placeholders := make([]string, 0, len(record))
+		args := make([]interface{}, 0, len(record))
+		for column, value := range record {
+			columns = append(columns, d.ident(column))
+			placeholders = append(placeholders, d.placeholder(len(args)+1))
+			if value != nil {
+				args = append(args, *value)
+			} else {
+				args = append(args, nil)
+			}
+		}
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			d.ident(t.Table), strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to restore record: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported action type: %s", actionType)
+	}
+}
+
+func requireRecordTarget(t MatchTarget) error {
+	if t.Table == "" || t.Field == "" || t.PrimaryKeyColumn == "" || t.PrimaryKey == "" {
+		return fmt.Errorf("match target has no record location")
+	}
+	return nil
+}
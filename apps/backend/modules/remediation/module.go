@@ -5,8 +5,11 @@ import (
 	"log"
 
 	"github.com/arc-platform/backend/modules/auth/middleware"
+	maskingservice "github.com/arc-platform/backend/modules/masking/service"
 	"github.com/arc-platform/backend/modules/remediation/api"
 	"github.com/arc-platform/backend/modules/remediation/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
@@ -18,6 +21,9 @@ type RemediationModule struct {
 	lineageSync    interfaces.LineageSync
 	service        *service.RemediationService
 	authMiddleware *middleware.AuthMiddleware
+	idempotent     gin.HandlerFunc   // Replays retried POSTs carrying an Idempotency-Key
+	batchHandler   *api.BatchHandler // nil without the job queue
+	erasureHandler *api.ErasureHandler
 }
 
 // NewRemediationModule creates a new remediation module
@@ -33,6 +39,7 @@ func (m *RemediationModule) Name() string {
 // Initialize sets up the module
 func (m *RemediationModule) Initialize(deps *interfaces.ModuleDependencies) error {
 	m.db = deps.DB
+	m.idempotent = deps.Idempotency.Middleware()
 
 	// Get LineageSync from dependencies
 	if deps.LineageSync != nil {
@@ -42,11 +49,44 @@ func (m *RemediationModule) Initialize(deps *interfaces.ModuleDependencies) erro
 		log.Printf("⚠️  LineageSync not available - using NoOp implementation")
 	}
 
+	repo := persistence.NewPostgresRepository(m.db)
+
 	// Initialize service with LineageSync instead of Neo4j driver
-	m.service = service.NewRemediationService(m.db, m.lineageSync)
+	m.service = service.NewRemediationService(repo, m.lineageSync)
+	m.service.SetIntegrationEvents(deps.IntegrationEvents)
+	m.service.SetMaskingTemplates(maskingservice.NewMaskingTemplateService(repo, deps.AuditLogger))
+
+	// Destructive actions above the impact threshold wait for a second approver
+	if deps.Config != nil {
+		m.service.SetApprovalPolicy(deps.Config.Approvals)
+		m.service.SetApprovalNotifier(service.NewApprovalNotifier(
+			deps.EventPublisher, mailer.New(deps.Config.Alerting), repo, deps.Config.Alerting.DashboardURL))
+	}
+
+	// Bulk remediation runs in the job queue, throttled per connection
+	if deps.Config != nil && deps.Jobs != nil {
+		limits, err := service.NewBatchLimits(deps.Config.Batches)
+		if err != nil {
+			log.Printf("⚠️  Remediation batches unavailable: %v", err)
+		} else {
+			batches := service.NewRemediationBatchService(repo, m.service, deps.Jobs, limits, deps.Config.Batches.MaxFindings)
+			batches.RegisterJobs(deps.Jobs)
+			m.batchHandler = api.NewBatchHandler(batches)
+		}
+	}
+
+	// Data principal erasures take a second approver and end with a signed certificate
+	var erasureConfig config.ErasureConfig
+	if deps.Config != nil {
+		erasureConfig = deps.Config.Erasure
+	}
+	if erasures, err := service.NewErasureService(repo, m.service, erasureConfig); err != nil {
+		log.Printf("⚠️  Erasure requests unavailable: %v", err)
+	} else {
+		m.erasureHandler = api.NewErasureHandler(erasures)
+	}
 
 	// Initialize Auth Middleware for permission checks
-	repo := persistence.NewPostgresRepository(m.db)
 	m.authMiddleware = middleware.NewAuthMiddleware(repo)
 
 	log.Println("✅ Remediation module initialized")
@@ -57,19 +97,49 @@ func (m *RemediationModule) Initialize(deps *interfaces.ModuleDependencies) erro
 func (m *RemediationModule) RegisterRoutes(router *gin.RouterGroup) {
 	handler := api.NewRemediationHandler(m.service)
 	historyHandler := api.NewRemediationHistoryHandler(m.service)
+	approvalHandler := api.NewApprovalHandler(m.service)
 
 	// Create remediation group
 	g := router.Group("/remediation")
 	{
-		g.POST("/preview", handler.GeneratePreview)
+		g.POST("/preview", m.idempotent, handler.GeneratePreview)
 		// Enforce "remediation:execute" permission for execution
-		g.POST("/execute", m.authMiddleware.RequirePermission("remediation:execute"), handler.ExecuteRemediation)
+		g.POST("/execute", m.authMiddleware.RequirePermission("remediation:execute"), m.idempotent, handler.ExecuteRemediation)
 
 		// Specific routes MUST come before dynamic /:id route
 		g.GET("/history", historyHandler.GetHistory)
 		g.GET("/history/:assetId", handler.GetRemediationHistory)
 		g.GET("/actions/:findingId", handler.GetRemediationActions)
-		g.POST("/rollback/:id", handler.RollbackRemediation)
+		g.POST("/rollback/:id", m.idempotent, handler.RollbackRemediation)
+		g.GET("/simulations/:id", handler.GetSimulation)
+
+		if m.batchHandler != nil {
+			execute := m.authMiddleware.RequireRole("admin", "remediator")
+			g.POST("/batches", execute, m.idempotent, m.batchHandler.CreateBatch)
+			g.GET("/batches", m.batchHandler.ListBatches)
+			g.GET("/batches/limits", m.batchHandler.GetLimits)
+			g.GET("/batches/:id", m.batchHandler.GetBatch)
+			g.POST("/batches/:id/pause", execute, m.batchHandler.PauseBatch)
+			g.POST("/batches/:id/resume", execute, m.idempotent, m.batchHandler.ResumeBatch)
+		}
+
+		// Four-eyes approval; the approver's role is checked in the handler
+		g.GET("/approvals", approvalHandler.ListApprovals)
+		g.GET("/approvals/:id", approvalHandler.GetApproval)
+		g.POST("/approvals/:id/approve", m.idempotent, approvalHandler.Approve)
+		g.POST("/approvals/:id/reject", m.idempotent, approvalHandler.Reject)
+
+		// Data principal erasure; like approvals, the approver's role is checked in the handler
+		if m.erasureHandler != nil {
+			g.POST("/dsr/erasures", m.authMiddleware.RequireRole("admin", "remediator"), m.idempotent, m.erasureHandler.CreateErasure)
+			g.GET("/dsr/erasures", m.erasureHandler.ListErasures)
+			g.GET("/dsr/erasures/signing-key", m.erasureHandler.GetSigningKey)
+			g.POST("/dsr/erasures/certificates/verify", m.erasureHandler.VerifyCertificate)
+			g.GET("/dsr/erasures/:id", m.erasureHandler.GetErasure)
+			g.GET("/dsr/erasures/:id/certificate", m.erasureHandler.GetCertificate)
+			g.POST("/dsr/erasures/:id/approve", m.idempotent, m.erasureHandler.Approve)
+			g.POST("/dsr/erasures/:id/reject", m.idempotent, m.erasureHandler.Reject)
+		}
 
 		// Dynamic route last
 		g.GET("/:id", handler.GetRemediationAction)
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// notificationTimeout bounds email delivery, which runs after the request returns
+const notificationTimeout = 30 * time.Second
+
+// ApprovalNotifier tells approvers about pending remediation requests and requesters
+// about decisions. Live events always go out; email only when SMTP is configured.
+type ApprovalNotifier struct {
+	events       interfaces.EventPublisher
+	mailer       mailer.Mailer // nil when SMTP is not configured
+	users        *persistence.PostgresRepository
+	dashboardURL string
+}
+
+// NewApprovalNotifier creates an approval notifier. events and m may be nil.
+func NewApprovalNotifier(events interfaces.EventPublisher, m mailer.Mailer, users *persistence.PostgresRepository, dashboardURL string) *ApprovalNotifier {
+	if events == nil {
+		events = &interfaces.NoOpEventPublisher{}
+	}
+	return &ApprovalNotifier{events: events, mailer: m, users: users, dashboardURL: dashboardURL}
+}
+
+// RequestSubmitted notifies every other user of the tenant who may approve remediation
+func (n *ApprovalNotifier) RequestSubmitted(ctx context.Context, req *RemediationApprovalRequest) {
+	n.events.Publish(ctx, interfaces.EventRemediationApprovalRequested, approvalEventData(req))
+
+	tenantID, err := persistence.GetTenantID(ctx)
+	if n.mailer == nil || err != nil || tenantID == uuid.Nil {
+		return
+	}
+	users, err := n.users.GetUsersByTenant(ctx, tenantID)
+	if err != nil {
+		log.Printf("WARNING: Failed to load approvers for remediation request %s: %v", req.ID, err)
+		return
+	}
+
+	var to []string
+	for _, u := range users {
+		if u.IsActive && u.ID.String() != req.RequestedBy && canApprove(u.Role) {
+			to = append(to, u.Email)
+		}
+	}
+	if len(to) == 0 {
+		log.Printf("WARNING: Remediation request %s has no one to approve it", req.ID)
+		return
+	}
+
+	n.send(ctx, mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("[ARC-Hawk] Approval needed: %s remediation of %d finding(s)", req.ActionType, req.Impact),
+		Body:    n.submittedBody(req),
+	})
+}
+
+// RequestDecided notifies the requester that their request was approved or rejected
+func (n *ApprovalNotifier) RequestDecided(ctx context.Context, req *RemediationApprovalRequest) {
+	n.events.Publish(ctx, interfaces.EventRemediationApprovalDecided, approvalEventData(req))
+
+	requesterID, err := uuid.Parse(req.RequestedBy)
+	if n.mailer == nil || err != nil {
+		return
+	}
+	requester, err := n.users.GetUserByID(ctx, requesterID)
+	if err != nil {
+		return
+	}
+
+	n.send(ctx, mailer.Message{
+		To:      []string{requester.Email},
+		Subject: fmt.Sprintf("[ARC-Hawk] Remediation request %s: %s", shortID(req.ID), req.Status),
+		Body:    n.decidedBody(req),
+	})
+}
+
+// send delivers in the background so the API call does not wait on SMTP
+func (n *ApprovalNotifier) send(ctx context.Context, msg mailer.Message) {
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notificationTimeout)
+		defer cancel()
+		if err := n.mailer.Send(sendCtx, msg); err != nil {
+			log.Printf("WARNING: Failed to send remediation approval email: %v", err)
+		}
+	}()
+}
+
+func (n *ApprovalNotifier) submittedBody(req *RemediationApprovalRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "A %s remediation needs a second approver.\n\n", req.ActionType)
+	fmt.Fprintf(&b, "Request:       %s\n", req.ID)
+	fmt.Fprintf(&b, "Requested by:  %s\n", req.RequestedBy)
+	fmt.Fprintf(&b, "Findings:      %d\n", req.Impact)
+	fmt.Fprintf(&b, "Expires:       %s\n", req.ExpiresAt.UTC().Format(time.RFC1123))
+	if req.Justification != "" {
+		fmt.Fprintf(&b, "Justification: %s\n", req.Justification)
+	}
+	n.writeLink(&b)
+	return b.String()
+}
+
+func (n *ApprovalNotifier) decidedBody(req *RemediationApprovalRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your %s remediation request %s is now %s.\n\n", req.ActionType, req.ID, req.Status)
+	fmt.Fprintf(&b, "Decided by: %s\n", req.DecidedBy)
+	if req.DecisionComment != "" {
+		fmt.Fprintf(&b, "Comment:    %s\n", req.DecisionComment)
+	}
+	if req.Status == RequestExecuted || req.Status == RequestFailed {
+		fmt.Fprintf(&b, "Remediated: %d of %d finding(s)\n", len(req.ActionIDs), req.Impact)
+	}
+	n.writeLink(&b)
+	return b.String()
+}
+
+func (n *ApprovalNotifier) writeLink(b *strings.Builder) {
+	if n.dashboardURL != "" {
+		fmt.Fprintf(b, "\nReview it at %s\n", strings.TrimRight(n.dashboardURL, "/")+"/remediation")
+	}
+}
+
+// approvalEventData is the live event payload; finding values are never included
+func approvalEventData(req *RemediationApprovalRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"request_id":   req.ID,
+		"action_type":  req.ActionType,
+		"impact":       req.Impact,
+		"status":       req.Status,
+		"requested_by": req.RequestedBy,
+		"decided_by":   req.DecidedBy,
+	}
+}
+
+// canApprove reports whether a role carries the remediation:approve permission
+func canApprove(role authentity.UserRole) bool {
+	for _, p := range authentity.RolePermissions[role] {
+		if p == authentity.PermissionRemediateApprove {
+			return true
+		}
+	}
+	return false
+}
+
+// CanApprove reports whether the named role may approve remediation requests
+func CanApprove(role string) bool {
+	return canApprove(authentity.UserRole(role))
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// Remediation request statuses
+const (
+	RequestPendingApproval = entity.RemediationRequestPendingApproval
+	RequestApproved        = entity.RemediationRequestApproved
+	RequestRejected        = entity.RemediationRequestRejected
+	RequestExpired         = entity.RemediationRequestExpired
+	RequestExecuted        = entity.RemediationRequestExecuted
+	RequestFailed          = entity.RemediationRequestFailed
+)
+
+// defaultApprovalExpiry applies when no expiry is configured
+const defaultApprovalExpiry = 72 * time.Hour
+
+var (
+	ErrRequestNotFound   = errors.New("remediation request not found")
+	ErrRequestNotPending = errors.New("remediation request is not pending approval")
+	ErrSelfApproval      = errors.New("a remediation request must be decided by someone other than its requester")
+)
+
+// RemediationApprovalRequest is a remediation held back until a second user approves it
+type RemediationApprovalRequest = entity.RemediationApprovalRequest
+
+// SetApprovalPolicy enables the four-eyes workflow for the configured action types
+func (s *RemediationService) SetApprovalPolicy(cfg config.RemediationApprovalConfig) {
+	if len(cfg.Actions) == 0 {
+		cfg.Actions = []string{"DELETE"}
+	}
+	s.approvals = cfg
+}
+
+// SetApprovalNotifier sends approval notifications through n
+func (s *RemediationService) SetApprovalNotifier(n *ApprovalNotifier) {
+	s.notifier = n
+}
+
+// RequiresApproval reports whether a remediation must wait for a second approver.
+// The impact of a request is the number of distinct findings it touches.
+func (s *RemediationService) RequiresApproval(actionType string, findingIDs []string) bool {
+	if !s.approvals.Enabled {
+		return false
+	}
+	gated := false
+	for _, action := range s.approvals.Actions {
+		if strings.EqualFold(action, actionType) {
+			gated = true
+			break
+		}
+	}
+	return gated && requestImpact(findingIDs) > s.approvals.ImpactThreshold
+}
+
+// SubmitForApproval records a remediation request and notifies approvers. Nothing
+// is executed until another user approves it.
+func (s *RemediationService) SubmitForApproval(ctx context.Context, findingIDs []string, actionType, requestedBy, justification string) (*RemediationApprovalRequest, error) {
+	for _, id := range findingIDs {
+		if _, err := s.getFinding(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to get finding %s: %w", id, err)
+		}
+	}
+
+	expiry := time.Duration(s.approvals.ExpiryHours) * time.Hour
+	if expiry <= 0 {
+		expiry = defaultApprovalExpiry
+	}
+
+	req := &RemediationApprovalRequest{
+		FindingIDs:    findingIDs,
+		ActionType:    actionType,
+		Impact:        requestImpact(findingIDs),
+		Justification: justification,
+		RequestedBy:   requestedBy,
+		ExpiresAt:     time.Now().Add(expiry),
+	}
+	if err := s.repo.CreateRemediationRequest(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to create remediation request: %w", err)
+	}
+
+	s.recordAuditLog(ctx, "REMEDIATION_APPROVAL_REQUESTED", requestedBy, "remediation_request", req.ID, map[string]interface{}{
+		"action_type":   req.ActionType,
+		"finding_ids":   req.FindingIDs,
+		"impact":        req.Impact,
+		"justification": req.Justification,
+	})
+	if s.notifier != nil {
+		s.notifier.RequestSubmitted(ctx, req)
+	}
+
+	return req, nil
+}
+
+// ListApprovalRequests returns remediation requests, newest first, optionally
+// filtered by status
+func (s *RemediationService) ListApprovalRequests(ctx context.Context, status string, limit, offset int) ([]*RemediationApprovalRequest, int, error) {
+	s.expireStaleRequests(ctx)
+
+	return s.repo.ListRemediationRequests(ctx, status, limit, offset)
+}
+
+// GetApprovalRequest retrieves one remediation request
+func (s *RemediationService) GetApprovalRequest(ctx context.Context, id string) (*RemediationApprovalRequest, error) {
+	s.expireStaleRequests(ctx)
+
+	req, err := s.repo.GetRemediationRequest(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remediation request: %w", err)
+	}
+	if req == nil {
+		return nil, ErrRequestNotFound
+	}
+	return req, nil
+}
+
+// ApproveRequest approves a pending request on behalf of a second user and executes
+// it. Findings are remediated one by one, as with direct execution; failures are
+// recorded on the request rather than aborting the rest.
+func (s *RemediationService) ApproveRequest(ctx context.Context, id, approverID, comment string) (*RemediationApprovalRequest, error) {
+	req, err := s.decide(ctx, id, approverID, comment, RequestApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAuditLog(ctx, "REMEDIATION_APPROVED", approverID, "remediation_request", req.ID, map[string]interface{}{
+		"action_type":  req.ActionType,
+		"finding_ids":  req.FindingIDs,
+		"requested_by": req.RequestedBy,
+		"comment":      comment,
+	})
+
+	var actionIDs, failures []string
+	for _, findingID := range req.FindingIDs {
+		actionID, err := s.ExecuteRemediation(ctx, findingID, req.ActionType, req.RequestedBy)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("Finding %s: %s", findingID, err.Error()))
+			continue
+		}
+		actionIDs = append(actionIDs, actionID)
+	}
+
+	status := RequestExecuted
+	if len(actionIDs) == 0 {
+		status = RequestFailed
+	}
+	if req, err = s.repo.CompleteRemediationRequest(ctx, req.ID, status, actionIDs, failures); err != nil {
+		return nil, fmt.Errorf("failed to record remediation result: %w", err)
+	}
+
+	if s.notifier != nil {
+		s.notifier.RequestDecided(ctx, req)
+	}
+	return req, nil
+}
+
+// RejectRequest rejects a pending request. Like approval, it takes a second user.
+func (s *RemediationService) RejectRequest(ctx context.Context, id, approverID, comment string) (*RemediationApprovalRequest, error) {
+	req, err := s.decide(ctx, id, approverID, comment, RequestRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordAuditLog(ctx, "REMEDIATION_REJECTED", approverID, "remediation_request", req.ID, map[string]interface{}{
+		"action_type":  req.ActionType,
+		"finding_ids":  req.FindingIDs,
+		"requested_by": req.RequestedBy,
+		"comment":      comment,
+	})
+	if s.notifier != nil {
+		s.notifier.RequestDecided(ctx, req)
+	}
+	return req, nil
+}
+
+// decide moves a pending request to status. The update is conditional, so two
+// approvers racing on the same request cannot both execute it.
+func (s *RemediationService) decide(ctx context.Context, id, approverID, comment, status string) (*RemediationApprovalRequest, error) {
+	req, err := s.GetApprovalRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.RequestedBy == approverID {
+		return nil, ErrSelfApproval
+	}
+	if req.Status != RequestPendingApproval {
+		return nil, ErrRequestNotPending
+	}
+
+	decided, err := s.repo.DecideRemediationRequest(ctx, req.ID, status, approverID, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record decision: %w", err)
+	}
+	if decided == nil {
+		return nil, ErrRequestNotPending
+	}
+	return decided, nil
+}
+
+// expireStaleRequests lapses pending requests past their expiry. It runs before
+// requests are read, so no separate worker is needed.
+func (s *RemediationService) expireStaleRequests(ctx context.Context) {
+	expired, err := s.repo.ExpireRemediationRequests(ctx)
+	if err != nil {
+		log.Printf("WARNING: Failed to expire remediation requests: %v", err)
+		return
+	}
+
+	for _, req := range expired {
+		s.recordAuditLog(ctx, "REMEDIATION_APPROVAL_EXPIRED", "system", "remediation_request", req.ID, map[string]interface{}{
+			"requested_by": req.RequestedBy,
+		})
+	}
+}
+
+// requestImpact counts the distinct findings a request touches
+func requestImpact(findingIDs []string) int {
+	seen := make(map[string]bool, len(findingIDs))
+	for _, id := range findingIDs {
+		seen[id] = true
+	}
+	return len(seen)
+}
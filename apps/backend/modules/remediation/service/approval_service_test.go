@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+)
+
+func TestRequiresApproval(t *testing.T) {
+	s := NewRemediationService(nil, nil)
+	if s.RequiresApproval("DELETE", []string{"f1"}) {
+		t.Error("approval must not be required before a policy is set")
+	}
+
+	s.SetApprovalPolicy(config.RemediationApprovalConfig{Enabled: true, ImpactThreshold: 2})
+	cases := []struct {
+		action   string
+		findings []string
+		want     bool
+	}{
+		{"DELETE", []string{"f1", "f2", "f3"}, true},
+		{"delete", []string{"f1", "f2", "f3"}, true},
+		{"DELETE", []string{"f1", "f2"}, false},
+		{"DELETE", []string{"f1", "f1", "f1"}, false}, // duplicates count once
+		{"MASK", []string{"f1", "f2", "f3"}, false},
+	}
+	for _, tc := range cases {
+		if got := s.RequiresApproval(tc.action, tc.findings); got != tc.want {
+			t.Errorf("RequiresApproval(%s, %v) = %t, want %t", tc.action, tc.findings, got, tc.want)
+		}
+	}
+
+	s.SetApprovalPolicy(config.RemediationApprovalConfig{Enabled: false})
+	if s.RequiresApproval("DELETE", []string{"f1", "f2", "f3"}) {
+		t.Error("a disabled policy must not gate requests")
+	}
+}
+
+func TestCanApprove(t *testing.T) {
+	for role, want := range map[string]bool{
+		"admin": true, "remediator": true, "operator": false, "auditor": false, "viewer": false, "": false,
+	} {
+		if got := CanApprove(role); got != want {
+			t.Errorf("CanApprove(%q) = %t, want %t", role, got, want)
+		}
+	}
+}
+
+func TestApprovalWorkflow(t *testing.T) {
+	repo, path, findingID := newFilesystemFixture(t)
+	s := NewRemediationService(repo, nil)
+	s.SetApprovalPolicy(config.RemediationApprovalConfig{Enabled: true, Actions: []string{"MASK"}})
+	ctx := context.Background()
+
+	req, err := s.SubmitForApproval(ctx, []string{findingID}, "MASK", "asha", "quarterly clean-up")
+	if err != nil {
+		t.Fatalf("SubmitForApproval: %v", err)
+	}
+	if req.Status != RequestPendingApproval || req.Impact != 1 {
+		t.Fatalf("unexpected request: %+v", req)
+	}
+	if got, _ := os.ReadFile(path); string(got) != usersCSV {
+		t.Fatal("nothing may be remediated before approval")
+	}
+
+	if _, err := s.ApproveRequest(ctx, req.ID, "asha", ""); !errors.Is(err, ErrSelfApproval) {
+		t.Fatalf("expected self-approval to be refused, got %v", err)
+	}
+
+	approved, err := s.ApproveRequest(ctx, req.ID, "ravi", "ok")
+	if err != nil {
+		t.Fatalf("ApproveRequest: %v", err)
+	}
+	if approved.Status != RequestExecuted || approved.DecidedBy != "ravi" || len(approved.ActionIDs) != 1 {
+		t.Fatalf("unexpected approved request: %+v", approved)
+	}
+	if got, _ := os.ReadFile(path); string(got) == usersCSV {
+		t.Fatal("expected the approved remediation to run")
+	}
+
+	if _, err := s.RejectRequest(ctx, req.ID, "ravi", ""); !errors.Is(err, ErrRequestNotPending) {
+		t.Fatalf("expected a decided request to stay decided, got %v", err)
+	}
+}
+
+func TestApprovalRequestExpires(t *testing.T) {
+	repo, _, findingID := newFilesystemFixture(t)
+	s := NewRemediationService(repo, nil)
+	s.SetApprovalPolicy(config.RemediationApprovalConfig{Enabled: true, ExpiryHours: 1})
+	ctx := context.Background()
+
+	req, err := s.SubmitForApproval(ctx, []string{findingID}, "DELETE", "asha", "")
+	if err != nil {
+		t.Fatalf("SubmitForApproval: %v", err)
+	}
+
+	repo.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	expired, err := s.GetApprovalRequest(ctx, req.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired.Status != RequestExpired {
+		t.Fatalf("expected the request to expire, got %s", expired.Status)
+	}
+	if _, err := s.ApproveRequest(ctx, req.ID, "ravi", ""); !errors.Is(err, ErrRequestNotPending) {
+		t.Fatalf("expected an expired request not to be approvable, got %v", err)
+	}
+
+	logs := repo.AuditLogs()
+	if last := logs[len(logs)-1]; last.EventType != "REMEDIATION_APPROVAL_EXPIRED" || last.ResourceID != req.ID {
+		t.Errorf("unexpected last audit event: %+v", last)
+	}
+}
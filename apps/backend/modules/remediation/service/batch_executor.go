@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ConnectorLimit throttles batch remediation on each connection of a connector type
+type ConnectorLimit struct {
+	Concurrency   int     `json:"concurrency"`     // Findings remediated at once per connection
+	RatePerSecond float64 `json:"rate_per_second"` // Findings started per second per connection; 0 is unlimited
+}
+
+// BatchLimits are the throttling limits of each connector type
+type BatchLimits struct {
+	Default    ConnectorLimit
+	Connectors map[string]ConnectorLimit
+}
+
+// NewBatchLimits reads the limits in cfg. ConnectorLimits entries are
+// "type=concurrency:rate"; the rate may be left out to keep the default one.
+func NewBatchLimits(cfg config.RemediationBatchConfig) (BatchLimits, error) {
+	limits := BatchLimits{
+		Default:    ConnectorLimit{Concurrency: cfg.Concurrency, RatePerSecond: cfg.RatePerSecond},
+		Connectors: make(map[string]ConnectorLimit),
+	}
+	if limits.Default.Concurrency < 1 {
+		limits.Default.Concurrency = 1
+	}
+	for _, entry := range cfg.ConnectorLimits {
+		sourceType, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(sourceType) == "" {
+			return limits, fmt.Errorf("invalid connector limit %q: expected type=concurrency:rate", entry)
+		}
+		limit := limits.Default
+		concurrency, rate, hasRate := strings.Cut(value, ":")
+		n, err := strconv.Atoi(strings.TrimSpace(concurrency))
+		if err != nil || n < 1 {
+			return limits, fmt.Errorf("invalid connector limit %q: concurrency must be a positive integer", entry)
+		}
+		limit.Concurrency = n
+		if hasRate {
+			r, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+			if err != nil || r < 0 {
+				return limits, fmt.Errorf("invalid connector limit %q: rate must be a number of at least 0", entry)
+			}
+			limit.RatePerSecond = r
+		}
+		limits.Connectors[normalizeSourceType(sourceType)] = limit
+	}
+	return limits, nil
+}
+
+// For returns the limit of a connector type
+func (l BatchLimits) For(sourceType string) ConnectorLimit {
+	if limit, ok := l.Connectors[normalizeSourceType(sourceType)]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+// normalizeSourceType maps the data source names assets are stored with to the
+// connector types limits are configured for
+func normalizeSourceType(sourceType string) string {
+	switch t := strings.ToLower(strings.TrimSpace(sourceType)); t {
+	case "fs":
+		return "filesystem"
+	case "postgres":
+		return "postgresql"
+	default:
+		return t
+	}
+}
+
+// throttle spaces the starts of operations at least interval apart
+type throttle struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newThrottle(ratePerSecond float64) *throttle {
+	if ratePerSecond <= 0 {
+		return &throttle{}
+	}
+	return &throttle{interval: time.Duration(float64(time.Second) / ratePerSecond)}
+}
+
+// wait blocks until the caller's turn, or until ctx is done
+func (t *throttle) wait(ctx context.Context) error {
+	if t.interval == 0 {
+		return ctx.Err()
+	}
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(t.interval)
+	t.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(start))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// runBatchItems remediates items with execute, each connection in parallel within
+// the limit of its connector type. No further items are started once stopped
+// reports true or ctx is done; items already started finish. It returns how many
+// items were run.
+func runBatchItems(ctx context.Context, items []*entity.RemediationBatchItem, limits BatchLimits,
+	stopped func() bool, execute func(ctx context.Context, item *entity.RemediationBatchItem)) int {
+	byConnection := make(map[string][]*entity.RemediationBatchItem)
+	var connections []string
+	for _, item := range items {
+		if _, ok := byConnection[item.Connection]; !ok {
+			connections = append(connections, item.Connection)
+		}
+		byConnection[item.Connection] = append(byConnection[item.Connection], item)
+	}
+
+	var mu sync.Mutex
+	run := 0
+	var wg sync.WaitGroup
+	for _, connection := range connections {
+		queue := byConnection[connection]
+		limit := limits.For(queue[0].SourceType)
+		pace := newThrottle(limit.RatePerSecond)
+
+		var taken int
+		var queueMu sync.Mutex
+		take := func() *entity.RemediationBatchItem {
+			queueMu.Lock()
+			defer queueMu.Unlock()
+			if taken == len(queue) || stopped() {
+				return nil
+			}
+			taken++
+			return queue[taken-1]
+		}
+
+		for i := 0; i < limit.Concurrency && i < len(queue); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					item := take()
+					if item == nil || pace.wait(ctx) != nil {
+						return
+					}
+					execute(ctx, item)
+					mu.Lock()
+					run++
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	return run
+}
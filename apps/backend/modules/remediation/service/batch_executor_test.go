@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestNewBatchLimits(t *testing.T) {
+	limits, err := NewBatchLimits(config.RemediationBatchConfig{
+		Concurrency:     2,
+		RatePerSecond:   5,
+		ConnectorLimits: []string{"filesystem=8:0", "postgres=1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := limits.For("fs"); got != (ConnectorLimit{Concurrency: 8}) {
+		t.Errorf("fs limit = %+v", got)
+	}
+	// A limit without a rate keeps the default rate
+	if got := limits.For("postgresql"); got != (ConnectorLimit{Concurrency: 1, RatePerSecond: 5}) {
+		t.Errorf("postgresql limit = %+v", got)
+	}
+	if got := limits.For("mysql"); got != (ConnectorLimit{Concurrency: 2, RatePerSecond: 5}) {
+		t.Errorf("mysql limit = %+v", got)
+	}
+
+	for _, entry := range []string{"mysql", "mysql=0:1", "mysql=2:-1", "=2:1"} {
+		if _, err := NewBatchLimits(config.RemediationBatchConfig{ConnectorLimits: []string{entry}}); err == nil {
+			t.Errorf("expected %q to be rejected", entry)
+		}
+	}
+}
+
+func batchItems(connection, sourceType string, n int) []*entity.RemediationBatchItem {
+	items := make([]*entity.RemediationBatchItem, n)
+	for i := range items {
+		items[i] = &entity.RemediationBatchItem{ID: uuid.New(), Connection: connection, SourceType: sourceType}
+	}
+	return items
+}
+
+func TestRunBatchItemsLimitsConcurrencyPerConnection(t *testing.T) {
+	items := append(batchItems("prod-db", "postgresql", 6), batchItems("share", "filesystem", 6)...)
+	limits := BatchLimits{
+		Default:    ConnectorLimit{Concurrency: 1},
+		Connectors: map[string]ConnectorLimit{"filesystem": {Concurrency: 3}},
+	}
+
+	var mu sync.Mutex
+	inFlight := make(map[string]int)
+	peak := make(map[string]int)
+	run := runBatchItems(context.Background(), items, limits, func() bool { return false }, func(ctx context.Context, item *entity.RemediationBatchItem) {
+		mu.Lock()
+		inFlight[item.Connection]++
+		if inFlight[item.Connection] > peak[item.Connection] {
+			peak[item.Connection] = inFlight[item.Connection]
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight[item.Connection]--
+		mu.Unlock()
+	})
+
+	if run != len(items) {
+		t.Errorf("expected %d items to run, got %d", len(items), run)
+	}
+	if peak["prod-db"] != 1 {
+		t.Errorf("expected one finding at a time on prod-db, got %d", peak["prod-db"])
+	}
+	if peak["share"] < 2 || peak["share"] > 3 {
+		t.Errorf("expected up to 3 findings at a time on share, got %d", peak["share"])
+	}
+}
+
+func TestRunBatchItemsRateLimit(t *testing.T) {
+	items := batchItems("prod-db", "postgresql", 4)
+	limits := BatchLimits{Default: ConnectorLimit{Concurrency: 4, RatePerSecond: 100}}
+
+	start := time.Now()
+	runBatchItems(context.Background(), items, limits, func() bool { return false }, func(context.Context, *entity.RemediationBatchItem) {})
+
+	// Four starts 10ms apart take at least 30ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected starts to be spaced by the rate limit, took %v", elapsed)
+	}
+}
+
+func TestRunBatchItemsStopsWhenPaused(t *testing.T) {
+	items := batchItems("prod-db", "postgresql", 10)
+	limits := BatchLimits{Default: ConnectorLimit{Concurrency: 1}}
+
+	var started atomic.Int32
+	run := runBatchItems(context.Background(), items, limits, func() bool { return started.Load() >= 3 }, func(context.Context, *entity.RemediationBatchItem) {
+		started.Add(1)
+	})
+
+	if run != 3 {
+		t.Errorf("expected the batch to stop after 3 findings, ran %d", run)
+	}
+}
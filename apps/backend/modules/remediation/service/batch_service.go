@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// RemediationBatchJobType runs the pending findings of a remediation batch
+const RemediationBatchJobType = "remediation.batch"
+
+const (
+	maxRemediationBatchList = 100
+	// batchStatusCheckInterval is how often a running batch re-reads its status to
+	// notice it was paused
+	batchStatusCheckInterval = time.Second
+)
+
+var (
+	ErrBatchNotFound         = errors.New("remediation batch not found")
+	ErrBatchState            = errors.New("remediation batch cannot be changed in its current state")
+	ErrBatchRequiresApproval = errors.New("remediation batch requires approval; submit it through /remediation/execute")
+	ErrInvalidBatch          = errors.New("invalid remediation batch")
+)
+
+// RemediationBatchService runs bulk remediation through the job queue without
+// saturating production sources: findings are grouped by connection, and each
+// connection is throttled by the concurrency and rate limits of its connector type.
+// Batches can be paused between findings and resumed where they stopped.
+type RemediationBatchService struct {
+	repo        *persistence.PostgresRepository
+	remediation *RemediationService
+	jobs        *jobs.Queue
+	limits      BatchLimits
+	maxFindings int
+}
+
+// NewRemediationBatchService creates a remediation batch service
+func NewRemediationBatchService(repo *persistence.PostgresRepository, remediation *RemediationService, queue *jobs.Queue, limits BatchLimits, maxFindings int) *RemediationBatchService {
+	return &RemediationBatchService{
+		repo:        repo,
+		remediation: remediation,
+		jobs:        queue,
+		limits:      limits,
+		maxFindings: maxFindings,
+	}
+}
+
+// RegisterJobs registers the batch job with the queue. Attempts after the first
+// continue with the findings not yet run.
+func (s *RemediationBatchService) RegisterJobs(queue *jobs.Queue) {
+	queue.Register(RemediationBatchJobType, s.runJob, jobs.HandlerOptions{MaxAttempts: 3, Timeout: 12 * time.Hour})
+}
+
+// Limits returns the throttling limits batches run with
+func (s *RemediationBatchService) Limits() BatchLimits {
+	return s.limits
+}
+
+// Create records a batch of the tenant in ctx and queues running it. Batches the
+// approval policy gates are refused; they go through the four-eyes workflow instead.
+func (s *RemediationBatchService) Create(ctx context.Context, findingIDs []string, actionType, requestedBy string) (*entity.RemediationBatch, error) {
+	switch actionType {
+	case "MASK", "DELETE", "ENCRYPT":
+	default:
+		return nil, fmt.Errorf("%w: unsupported action type %q", ErrInvalidBatch, actionType)
+	}
+
+	ids := make([]uuid.UUID, 0, len(findingIDs))
+	seen := make(map[uuid.UUID]bool, len(findingIDs))
+	for _, raw := range findingIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: finding ID %q is not a UUID", ErrInvalidBatch, raw)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: no findings", ErrInvalidBatch)
+	}
+	if s.maxFindings > 0 && len(ids) > s.maxFindings {
+		return nil, fmt.Errorf("%w: %d findings exceed the limit of %d", ErrInvalidBatch, len(ids), s.maxFindings)
+	}
+	if s.remediation.RequiresApproval(actionType, findingIDs) {
+		return nil, ErrBatchRequiresApproval
+	}
+
+	batch := &entity.RemediationBatch{ActionType: actionType, RequestedBy: requestedBy}
+	if err := s.repo.CreateRemediationBatch(ctx, batch, ids); err != nil {
+		if errors.Is(err, persistence.ErrRemediationBatchFindings) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBatch, err)
+		}
+		return nil, err
+	}
+	if err := s.enqueue(ctx, batch); err != nil {
+		if _, ferr := s.repo.TransitionRemediationBatch(ctx, batch.ID, entity.RemediationBatchFailed, err.Error(), entity.RemediationBatchPending); ferr != nil {
+			log.Printf("WARNING: Failed to record remediation batch %s failure: %v", batch.ID, ferr)
+		}
+		return nil, err
+	}
+
+	s.remediation.recordAuditLog(ctx, "REMEDIATION_BATCH_CREATED", requestedBy, "remediation_batch", batch.ID.String(), map[string]interface{}{
+		"action_type":    actionType,
+		"total_findings": batch.TotalFindings,
+	})
+	return s.Get(ctx, batch.ID)
+}
+
+// List returns the tenant's batches, newest first
+func (s *RemediationBatchService) List(ctx context.Context) ([]*entity.RemediationBatch, error) {
+	return s.repo.ListRemediationBatches(ctx, maxRemediationBatchList)
+}
+
+// Get returns one of the tenant's batches with its progress per connection
+func (s *RemediationBatchService) Get(ctx context.Context, id uuid.UUID) (*entity.RemediationBatch, error) {
+	batch, err := s.repo.GetRemediationBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, ErrBatchNotFound
+	}
+	return batch, nil
+}
+
+// Pause stops a pending or running batch after the findings already in flight
+func (s *RemediationBatchService) Pause(ctx context.Context, id uuid.UUID, actor string) (*entity.RemediationBatch, error) {
+	if err := s.transition(ctx, id, entity.RemediationBatchPaused, entity.RemediationBatchPending, entity.RemediationBatchRunning); err != nil {
+		return nil, err
+	}
+	s.remediation.recordAuditLog(ctx, "REMEDIATION_BATCH_PAUSED", actor, "remediation_batch", id.String(), nil)
+	return s.Get(ctx, id)
+}
+
+// Resume queues a paused batch to continue with the findings it has not run
+func (s *RemediationBatchService) Resume(ctx context.Context, id uuid.UUID, actor string) (*entity.RemediationBatch, error) {
+	if err := s.transition(ctx, id, entity.RemediationBatchPending, entity.RemediationBatchPaused); err != nil {
+		return nil, err
+	}
+	batch, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enqueue(ctx, batch); err != nil {
+		// Left paused so it can be resumed again
+		if _, perr := s.repo.TransitionRemediationBatch(ctx, id, entity.RemediationBatchPaused, "", entity.RemediationBatchPending); perr != nil {
+			log.Printf("WARNING: Failed to re-pause remediation batch %s: %v", id, perr)
+		}
+		return nil, err
+	}
+	s.remediation.recordAuditLog(ctx, "REMEDIATION_BATCH_RESUMED", actor, "remediation_batch", id.String(), nil)
+	return s.Get(ctx, id)
+}
+
+func (s *RemediationBatchService) transition(ctx context.Context, id uuid.UUID, status string, from ...string) error {
+	ok, err := s.repo.TransitionRemediationBatch(ctx, id, status, "", from...)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	current, err := s.repo.GetRemediationBatchStatus(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return ErrBatchNotFound
+	}
+	return fmt.Errorf("%w: it is %s", ErrBatchState, current)
+}
+
+func (s *RemediationBatchService) enqueue(ctx context.Context, batch *entity.RemediationBatch) error {
+	job, err := s.jobs.Enqueue(ctx, RemediationBatchJobType, map[string]interface{}{"batch_id": batch.ID.String()}, jobs.EnqueueOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to queue remediation batch: %w", err)
+	}
+	if err := s.repo.SetRemediationBatchJob(ctx, batch.ID, job.ID); err != nil {
+		return err
+	}
+	batch.JobID = &job.ID
+	return nil
+}
+
+// runJob runs the pending findings of the batch named in the job's payload. A
+// finding that cannot be remediated is recorded on its item and does not fail the
+// batch; the batch fails only if it cannot be run once the job is out of attempts.
+func (s *RemediationBatchService) runJob(ctx context.Context, job *entity.Job) error {
+	id, err := uuid.Parse(fmt.Sprint(job.Payload["batch_id"]))
+	if err != nil {
+		return fmt.Errorf("invalid batch_id in job payload: %w", err)
+	}
+	batch, err := s.repo.GetRemediationBatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	if batch == nil {
+		return nil
+	}
+
+	// A retried attempt finds the batch still running
+	started, err := s.repo.TransitionRemediationBatch(ctx, id, entity.RemediationBatchRunning, "",
+		entity.RemediationBatchPending, entity.RemediationBatchRunning)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return nil // Paused, or already finished
+	}
+
+	if err := s.run(ctx, batch); err != nil {
+		if job.Attempts >= job.MaxAttempts {
+			failCtx := context.WithoutCancel(ctx)
+			if _, ferr := s.repo.TransitionRemediationBatch(failCtx, id, entity.RemediationBatchFailed, err.Error(), entity.RemediationBatchRunning); ferr != nil {
+				log.Printf("WARNING: Failed to record remediation batch %s failure: %v", id, ferr)
+			}
+			s.remediation.recordAuditLog(failCtx, "REMEDIATION_BATCH_FAILED", batch.RequestedBy, "remediation_batch", id.String(), map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return err
+	}
+	return nil
+}
+
+// run remediates the batch's pending findings, then completes the batch unless it
+// was paused meanwhile
+func (s *RemediationBatchService) run(ctx context.Context, batch *entity.RemediationBatch) error {
+	items, err := s.repo.ListPendingRemediationBatchItems(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+
+	status := &batchStatusWatch{repo: s.repo, id: batch.ID}
+	run := runBatchItems(ctx, items, s.limits, func() bool { return status.stopped(ctx) }, func(ctx context.Context, item *entity.RemediationBatchItem) {
+		s.runItem(ctx, batch, item)
+	})
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if run < len(items) && status.stopped(ctx) {
+		log.Printf("⏸️  Remediation batch %s stopped with %d of %d findings left", batch.ID, len(items)-run, len(items))
+		return nil
+	}
+
+	completed, err := s.repo.TransitionRemediationBatch(ctx, batch.ID, entity.RemediationBatchCompleted, "", entity.RemediationBatchRunning)
+	if err != nil {
+		return err
+	}
+	if completed {
+		done, err := s.Get(ctx, batch.ID)
+		if err != nil {
+			return err
+		}
+		succeeded, failed := 0, 0
+		for _, c := range done.Connections {
+			succeeded += c.Completed
+			failed += c.Failed
+		}
+		log.Printf("✅ Remediation batch %s completed: %d remediated, %d failed", batch.ID, succeeded, failed)
+		s.remediation.recordAuditLog(ctx, "REMEDIATION_BATCH_COMPLETED", batch.RequestedBy, "remediation_batch", batch.ID.String(), map[string]interface{}{
+			"action_type": batch.ActionType,
+			"succeeded":   succeeded,
+			"failed":      failed,
+		})
+	}
+	return nil
+}
+
+// runItem remediates one finding and records the outcome on its item
+func (s *RemediationBatchService) runItem(ctx context.Context, batch *entity.RemediationBatch, item *entity.RemediationBatchItem) {
+	actionID, err := s.remediation.ExecuteRemediation(ctx, item.FindingID.String(), batch.ActionType, batch.RequestedBy)
+	item.ActionID = actionID
+	item.Status = entity.RemediationBatchItemCompleted
+	if err != nil {
+		item.Status = entity.RemediationBatchItemFailed
+		item.Error = err.Error()
+	}
+	if err := s.repo.FinishRemediationBatchItem(context.WithoutCancel(ctx), item); err != nil {
+		log.Printf("WARNING: Failed to record remediation batch item %s: %v", item.ID, err)
+	}
+}
+
+// batchStatusWatch reports whether a running batch has been paused, reading its
+// status at most once per batchStatusCheckInterval
+type batchStatusWatch struct {
+	repo *persistence.PostgresRepository
+	id   uuid.UUID
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	stop      bool
+}
+
+func (w *batchStatusWatch) stopped(ctx context.Context) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.stop || time.Since(w.checkedAt) < batchStatusCheckInterval {
+		return w.stop
+	}
+	w.checkedAt = time.Now()
+	status, err := w.repo.GetRemediationBatchStatus(ctx, w.id)
+	if err != nil {
+		log.Printf("WARNING: Failed to read remediation batch %s status: %v", w.id, err)
+		return false
+	}
+	w.stop = status != entity.RemediationBatchRunning
+	return w.stop
+}
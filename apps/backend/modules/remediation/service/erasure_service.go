@@ -0,0 +1,569 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/remediation/connectors"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/pkg/scansign"
+	"github.com/google/uuid"
+)
+
+// ErasureCertificateContext prefixes every signed certificate digest, so the key
+// cannot be used to pass off a signature over anything else
+const ErasureCertificateContext = "arc-hawk-erasure-certificate-v1"
+
+// ErasureCertificateAlgorithm is how certificates are signed
+const ErasureCertificateAlgorithm = "Ed25519"
+
+const maxErasureRequestList = 500
+
+var (
+	ErrErasureNotFound      = errors.New("erasure request not found")
+	ErrErasureNotPending    = errors.New("erasure request is not pending approval")
+	ErrErasureSelfApproval  = errors.New("an erasure request must be decided by someone other than its requester")
+	ErrErasureValueNotFound = errors.New("the value was not found in any open finding")
+	ErrCertificateNotIssued = errors.New("no erasure certificate was issued for this request")
+	ErrInvalidErasure       = errors.New("invalid erasure request")
+	ErrErasureKeyUnset      = errors.New("ERASURE_CERTIFICATE_SIGNING_KEY must be set outside development")
+	ErrInvalidErasureKey    = errors.New("erasure signing key must be a base64 Ed25519 seed or private key, or a PEM PKCS#8 private key")
+	ErrNoValueMatches       = errors.New("the finding has no located match of the value")
+	ErrConnectorNotTargeted = errors.New("the source connector cannot erase single values")
+)
+
+var erasureValueHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ErasureInput is a data principal's erasure request. The value is given either as
+// its hash or in the clear; a clear value is hashed and never stored.
+type ErasureInput struct {
+	ValueHash          string
+	Value              string
+	RequesterReference string
+	ActionType         string // DELETE or MASK
+	Justification      string
+}
+
+// ErasureCertificateDocument is what an erasure certificate attests: which
+// findings held the value and what became of each. It is signed as marshaled.
+type ErasureCertificateDocument struct {
+	Version            int                        `json:"version"`
+	RequestID          uuid.UUID                  `json:"request_id"`
+	TenantID           uuid.UUID                  `json:"tenant_id"`
+	RequesterReference string                     `json:"requester_reference"`
+	ValueHash          string                     `json:"value_hash"`
+	ActionType         string                     `json:"action_type"`
+	Outcome            string                     `json:"outcome"`
+	RequestedBy        string                     `json:"requested_by"`
+	RequestedAt        time.Time                  `json:"requested_at"`
+	ApprovedBy         string                     `json:"approved_by"`
+	ApprovedAt         *time.Time                 `json:"approved_at"`
+	Erased             int                        `json:"erased"`
+	Failed             int                        `json:"failed"`
+	ManualRequired     int                        `json:"manual_required"`
+	Targets            []ErasureCertificateTarget `json:"targets"`
+	IssuedAt           time.Time                  `json:"issued_at"`
+}
+
+// ErasureCertificateTarget is one finding in a certificate
+type ErasureCertificateTarget struct {
+	FindingID  uuid.UUID  `json:"finding_id"`
+	AssetName  string     `json:"asset_name"`
+	AssetPath  string     `json:"asset_path"`
+	System     string     `json:"system"`
+	Status     string     `json:"status"`
+	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+}
+
+// ErasureSigningKey is the public half of the certificate signing key
+type ErasureSigningKey struct {
+	Algorithm   string `json:"algorithm"`
+	PublicKey   string `json:"public_key"` // Base64 raw Ed25519 key
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ErasureService erases one data principal's value everywhere it was found: it
+// plans the erasure per finding, holds it for a second approver, erases each
+// finding's matches of the value through its connector, and issues the requester a
+// signed certificate of the outcome.
+type ErasureService struct {
+	repo        *persistence.PostgresRepository
+	remediation *RemediationService
+	key         ed25519.PrivateKey
+}
+
+// NewErasureService creates an erasure service signing with the configured key.
+// Without one it fails with ErrErasureKeyUnset, unless cfg allows a key generated
+// at startup, whose certificates cannot be verified after a restart.
+func NewErasureService(repo *persistence.PostgresRepository, remediation *RemediationService, cfg config.ErasureConfig) (*ErasureService, error) {
+	var key ed25519.PrivateKey
+	if strings.TrimSpace(cfg.SigningKey) == "" {
+		if !cfg.AllowGeneratedKey {
+			return nil, ErrErasureKeyUnset
+		}
+		_, generated, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate erasure signing key: %w", err)
+		}
+		log.Printf("WARNING: ERASURE_CERTIFICATE_SIGNING_KEY is not set; erasure certificates are signed with a key generated at startup")
+		key = generated
+	} else {
+		parsed, err := parseErasureSigningKey(cfg.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+		key = parsed
+	}
+	return &ErasureService{repo: repo, remediation: remediation, key: key}, nil
+}
+
+// SigningKey returns the public key certificates are verified with
+func (s *ErasureService) SigningKey() ErasureSigningKey {
+	public := s.key.Public().(ed25519.PublicKey)
+	return ErasureSigningKey{
+		Algorithm:   ErasureCertificateAlgorithm,
+		PublicKey:   base64.StdEncoding.EncodeToString(public),
+		Fingerprint: scansign.Fingerprint(public),
+	}
+}
+
+// Create plans the erasure of a value across the findings of the tenant in ctx
+// holding it and records the plan for approval. Nothing is erased yet.
+func (s *ErasureService) Create(ctx context.Context, input ErasureInput, requestedBy string) (*entity.ErasureRequest, error) {
+	actionType := strings.ToUpper(strings.TrimSpace(input.ActionType))
+	if actionType == "" {
+		actionType = "DELETE"
+	}
+	if actionType != "DELETE" && actionType != "MASK" {
+		return nil, fmt.Errorf("%w: action type must be DELETE or MASK", ErrInvalidErasure)
+	}
+	reference := strings.TrimSpace(input.RequesterReference)
+	if reference == "" {
+		return nil, fmt.Errorf("%w: requester_reference is required", ErrInvalidErasure)
+	}
+
+	hash := strings.ToLower(strings.TrimSpace(input.ValueHash))
+	switch {
+	case hash != "" && input.Value != "":
+		return nil, fmt.Errorf("%w: give either value or value_hash, not both", ErrInvalidErasure)
+	case input.Value != "":
+		hash = erasureValueHash(input.Value)
+	case !erasureValueHashPattern.MatchString(hash):
+		return nil, fmt.Errorf("%w: value_hash must be 64 hexadecimal characters", ErrInvalidErasure)
+	}
+
+	findingIDs, err := s.repo.ListValueFindingIDs(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(findingIDs) == 0 {
+		return nil, ErrErasureValueNotFound
+	}
+
+	req := &entity.ErasureRequest{
+		ValueHash:          hash,
+		RequesterReference: reference,
+		ActionType:         actionType,
+		Justification:      strings.TrimSpace(input.Justification),
+		RequestedBy:        requestedBy,
+	}
+	for _, findingID := range findingIDs {
+		target, err := s.remediation.planErasureTarget(ctx, findingID.String(), hash, actionType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan erasure of finding %s: %w", findingID, err)
+		}
+		req.Targets = append(req.Targets, target)
+	}
+	if err := s.repo.CreateErasureRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	s.remediation.recordAuditLog(ctx, "ERASURE_REQUESTED", requestedBy, "erasure_request", req.ID.String(), map[string]interface{}{
+		"value_hash":          req.ValueHash,
+		"requester_reference": req.RequesterReference,
+		"action_type":         req.ActionType,
+		"targets":             len(req.Targets),
+		"manual_targets":      countErasureTargets(req.Targets, entity.ErasureTargetManualRequired),
+	})
+	return req, nil
+}
+
+// Get returns one erasure request with its targets
+func (s *ErasureService) Get(ctx context.Context, id uuid.UUID) (*entity.ErasureRequest, error) {
+	req, err := s.repo.GetErasureRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, ErrErasureNotFound
+	}
+	return req, nil
+}
+
+// List returns erasure requests, newest first, optionally filtered by status
+func (s *ErasureService) List(ctx context.Context, status string, limit, offset int) ([]*entity.ErasureRequest, int, error) {
+	if limit < 1 || limit > maxErasureRequestList {
+		limit = 50
+	}
+	return s.repo.ListErasureRequests(ctx, status, limit, offset)
+}
+
+// Approve approves a pending request on behalf of a second user and executes it.
+// Each automated target is erased on its own, so one failing source does not stop
+// the rest; manual targets are left to their owners. Unless nothing could be
+// erased, the requester's certificate is issued.
+func (s *ErasureService) Approve(ctx context.Context, id uuid.UUID, approverID, comment string) (*entity.ErasureRequest, error) {
+	req, err := s.decide(ctx, id, approverID, comment, entity.ErasureExecuting)
+	if err != nil {
+		return nil, err
+	}
+	s.remediation.recordAuditLog(ctx, "ERASURE_APPROVED", approverID, "erasure_request", req.ID.String(), map[string]interface{}{
+		"value_hash":   req.ValueHash,
+		"action_type":  req.ActionType,
+		"requested_by": req.RequestedBy,
+		"comment":      comment,
+	})
+
+	for _, target := range req.Targets {
+		if target.Mode != entity.ErasureModeAutomated {
+			continue
+		}
+		actionID, err := s.remediation.ExecuteValueRemediation(ctx, target.FindingID.String(), req.ValueHash, req.ActionType, req.RequestedBy)
+		now := time.Now()
+		target.ExecutedAt = &now
+		if err != nil {
+			target.Status = entity.ErasureTargetFailed
+			target.Error = err.Error()
+		} else {
+			target.Status = entity.ErasureTargetErased
+			target.ActionID = actionID
+		}
+		if err := s.repo.FinishErasureTarget(ctx, req.ID, target); err != nil {
+			return nil, err
+		}
+	}
+
+	outcome := erasureOutcome(req.Targets)
+	if err := s.repo.CompleteErasureRequest(ctx, req.ID, outcome); err != nil {
+		return nil, err
+	}
+	if req, err = s.Get(ctx, req.ID); err != nil {
+		return nil, err
+	}
+
+	if outcome != entity.ErasureFailed {
+		if err := s.issueCertificate(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	s.remediation.recordAuditLog(ctx, "ERASURE_COMPLETED", approverID, "erasure_request", req.ID.String(), map[string]interface{}{
+		"value_hash":      req.ValueHash,
+		"outcome":         outcome,
+		"erased":          countErasureTargets(req.Targets, entity.ErasureTargetErased),
+		"failed":          countErasureTargets(req.Targets, entity.ErasureTargetFailed),
+		"manual_required": countErasureTargets(req.Targets, entity.ErasureTargetManualRequired),
+	})
+	return req, nil
+}
+
+// Reject rejects a pending request. Like approval, it takes a second user.
+func (s *ErasureService) Reject(ctx context.Context, id uuid.UUID, approverID, comment string) (*entity.ErasureRequest, error) {
+	req, err := s.decide(ctx, id, approverID, comment, entity.ErasureRejected)
+	if err != nil {
+		return nil, err
+	}
+	s.remediation.recordAuditLog(ctx, "ERASURE_REJECTED", approverID, "erasure_request", req.ID.String(), map[string]interface{}{
+		"value_hash":   req.ValueHash,
+		"requested_by": req.RequestedBy,
+		"comment":      comment,
+	})
+	return req, nil
+}
+
+// Certificate returns the signed certificate of an executed request
+func (s *ErasureService) Certificate(ctx context.Context, id uuid.UUID) (*entity.ErasureCertificate, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	cert, err := s.repo.GetErasureCertificate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, ErrCertificateNotIssued
+	}
+	return cert, nil
+}
+
+// VerifyCertificate reports whether signature is this service's signature over
+// document. Insignificant whitespace in the document is ignored.
+func (s *ErasureService) VerifyCertificate(document []byte, signature string) bool {
+	return verifyErasureCertificate(s.key.Public().(ed25519.PublicKey), document, signature)
+}
+
+// decide moves a pending request to status. The update is conditional, so two
+// approvers racing on the same request cannot both execute it.
+func (s *ErasureService) decide(ctx context.Context, id uuid.UUID, approverID, comment, status string) (*entity.ErasureRequest, error) {
+	req, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.RequestedBy == approverID {
+		return nil, ErrErasureSelfApproval
+	}
+	if req.Status != entity.ErasurePendingApproval {
+		return nil, ErrErasureNotPending
+	}
+
+	decided, err := s.repo.DecideErasureRequest(ctx, id, status, approverID, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record decision: %w", err)
+	}
+	if decided == nil {
+		return nil, ErrErasureNotPending
+	}
+	return decided, nil
+}
+
+// issueCertificate signs and stores the certificate of an executed request
+func (s *ErasureService) issueCertificate(ctx context.Context, req *entity.ErasureRequest) error {
+	document, err := json.Marshal(erasureCertificateDocument(req, time.Now().UTC()))
+	if err != nil {
+		return fmt.Errorf("failed to encode erasure certificate: %w", err)
+	}
+	cert := &entity.ErasureCertificate{
+		RequestID:      req.ID,
+		Document:       document,
+		Signature:      signErasureCertificate(s.key, document),
+		Algorithm:      ErasureCertificateAlgorithm,
+		KeyFingerprint: s.SigningKey().Fingerprint,
+		IssuedAt:       time.Now(),
+	}
+	return s.repo.SaveErasureCertificate(ctx, cert)
+}
+
+// planErasureTarget decides how the matches of one value in a finding are erased:
+// automated when the finding's connector can erase single matches and the value is
+// located in the finding, manual otherwise
+func (s *RemediationService) planErasureTarget(ctx context.Context, findingID, valueHash, actionType string) (*entity.ErasureTarget, error) {
+	finding, err := s.getFinding(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &entity.ErasureTarget{
+		AssetName:  finding.AssetName,
+		AssetPath:  finding.AssetPath,
+		System:     finding.SourceSystem,
+		SourceType: finding.SourceType,
+		Mode:       entity.ErasureModeAutomated,
+		Status:     entity.ErasureTargetPlanned,
+	}
+	target.FindingID, _ = uuid.Parse(finding.ID)
+	target.AssetID, _ = uuid.Parse(finding.AssetID)
+	target.Matches = len(matchTargets(valueFinding(finding, valueHash), actionType, s.templates.Templates(ctx)))
+
+	connector, err := s.connectorFactory.NewConnector(finding.SourceType)
+	switch {
+	case err != nil:
+		target.Reason = err.Error()
+	case !isTargeted(connector):
+		target.Reason = fmt.Sprintf("the %s connector cannot erase single values", finding.SourceType)
+	case target.Matches == 0:
+		target.Reason = ErrNoValueMatches.Error()
+	}
+	if connector != nil {
+		connector.Close()
+	}
+	if target.Reason != "" {
+		target.Mode = entity.ErasureModeManual
+		target.Status = entity.ErasureTargetManualRequired
+	}
+	return target, nil
+}
+
+// ExecuteValueRemediation remediates only the matches of one value in a finding,
+// leaving its other matches alone. Unlike ExecuteRemediation, it never falls back
+// to asset-level actions, which would touch other data principals' values too.
+func (s *RemediationService) ExecuteValueRemediation(ctx context.Context, findingID, valueHash, actionType, userID string) (string, error) {
+	finding, err := s.getFinding(ctx, findingID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get finding: %w", err)
+	}
+	targets := matchTargets(valueFinding(finding, valueHash), actionType, s.templates.Templates(ctx))
+	if len(targets) == 0 {
+		return "", ErrNoValueMatches
+	}
+
+	config, err := s.getSourceConfig(ctx, finding.SourceSystem)
+	if err != nil {
+		return "", fmt.Errorf("failed to get source config: %w", err)
+	}
+	connector, err := s.connectorFactory.NewConnector(finding.SourceType)
+	if err != nil {
+		return "", fmt.Errorf("failed to create connector: %w", err)
+	}
+	defer connector.Close()
+
+	targeted, ok := connector.(connectors.TargetedConnector)
+	if !ok {
+		return "", ErrConnectorNotTargeted
+	}
+	if err := connector.Connect(ctx, config); err != nil {
+		return "", fmt.Errorf("failed to connect to source: %w", err)
+	}
+	return s.executeTargetedRemediation(ctx, targeted, finding, targets, actionType, userID)
+}
+
+func isTargeted(connector connectors.SourceConnector) bool {
+	_, ok := connector.(connectors.TargetedConnector)
+	return ok
+}
+
+// valueFinding narrows a finding's match locations to those of one value
+func valueFinding(finding *Finding, valueHash string) *Finding {
+	narrowed := *finding
+	narrowed.Locations = nil
+	for _, loc := range finding.Locations {
+		if loc.MatchIndex >= 0 && loc.MatchIndex < len(finding.Matches) && erasureValueHash(finding.Matches[loc.MatchIndex]) == valueHash {
+			narrowed.Locations = append(narrowed.Locations, loc)
+		}
+	}
+	return &narrowed
+}
+
+// erasureValueHash is the value hash findings' observations are recorded under:
+// the SHA-256 of the value with spaces and dashes removed, lowercased
+func erasureValueHash(value string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(value, " ", ""), "-", ""))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// erasureOutcome is the status of an executed request: completed when every
+// target was erased, failed when none was and some failed, partial otherwise
+func erasureOutcome(targets []*entity.ErasureTarget) string {
+	erased := countErasureTargets(targets, entity.ErasureTargetErased)
+	failed := countErasureTargets(targets, entity.ErasureTargetFailed)
+	switch {
+	case erased == len(targets):
+		return entity.ErasureCompleted
+	case erased == 0 && failed > 0:
+		return entity.ErasureFailed
+	default:
+		return entity.ErasurePartial
+	}
+}
+
+func countErasureTargets(targets []*entity.ErasureTarget, status string) int {
+	n := 0
+	for _, target := range targets {
+		if target.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func erasureCertificateDocument(req *entity.ErasureRequest, issuedAt time.Time) ErasureCertificateDocument {
+	doc := ErasureCertificateDocument{
+		Version:            1,
+		RequestID:          req.ID,
+		TenantID:           req.TenantID,
+		RequesterReference: req.RequesterReference,
+		ValueHash:          req.ValueHash,
+		ActionType:         req.ActionType,
+		Outcome:            erasureOutcome(req.Targets),
+		RequestedBy:        req.RequestedBy,
+		RequestedAt:        req.RequestedAt.UTC(),
+		ApprovedBy:         req.DecidedBy,
+		ApprovedAt:         req.DecidedAt,
+		Erased:             countErasureTargets(req.Targets, entity.ErasureTargetErased),
+		Failed:             countErasureTargets(req.Targets, entity.ErasureTargetFailed),
+		ManualRequired:     countErasureTargets(req.Targets, entity.ErasureTargetManualRequired),
+		Targets:            make([]ErasureCertificateTarget, 0, len(req.Targets)),
+		IssuedAt:           issuedAt,
+	}
+	for _, target := range req.Targets {
+		doc.Targets = append(doc.Targets, ErasureCertificateTarget{
+			FindingID:  target.FindingID,
+			AssetName:  target.AssetName,
+			AssetPath:  target.AssetPath,
+			System:     target.System,
+			Status:     target.Status,
+			ExecutedAt: target.ExecutedAt,
+		})
+	}
+	return doc
+}
+
+// erasureCertificateMessage is what is signed for a certificate document: the
+// context, a newline and the hex SHA-256 of the document
+func erasureCertificateMessage(document []byte) []byte {
+	digest := sha256.Sum256(document)
+	return []byte(ErasureCertificateContext + "\n" + hex.EncodeToString(digest[:]))
+}
+
+func signErasureCertificate(key ed25519.PrivateKey, document []byte) string {
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, erasureCertificateMessage(document)))
+}
+
+func verifyErasureCertificate(key ed25519.PublicKey, document []byte, signature string) bool {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, document); err == nil {
+		document = compact.Bytes()
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(key, erasureCertificateMessage(document), sig)
+}
+
+// parseErasureSigningKey reads a base64 Ed25519 seed or private key, or a PEM
+// encoded PKCS#8 private key
+func parseErasureSigningKey(encoded string) (ed25519.PrivateKey, error) {
+	encoded = strings.TrimSpace(encoded)
+	if block, _ := pem.Decode([]byte(encoded)); block != nil {
+		if block.Type != "PRIVATE KEY" {
+			return nil, ErrInvalidErasureKey
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidErasureKey, err)
+		}
+		key, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, ErrInvalidErasureKey
+		}
+		return key, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidErasureKey
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, ErrInvalidErasureKey
+	}
+}
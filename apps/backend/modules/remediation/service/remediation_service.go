@@ -2,57 +2,81 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
+	maskingservice "github.com/arc-platform/backend/modules/masking/service"
 	"github.com/arc-platform/backend/modules/remediation/connectors"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/redaction"
 	"github.com/google/uuid"
 )
 
 // RemediationService handles remediation operations
 type RemediationService struct {
-	db               *sql.DB
+	repo             repository.RemediationRepository
 	lineageSync      interfaces.LineageSync
 	connectorFactory *connectors.ConnectorFactory
+	events           interfaces.EventPublisher
+	approvals        config.RemediationApprovalConfig
+	notifier         *ApprovalNotifier
+	templates        *maskingservice.MaskingTemplateService
 }
 
 // NewRemediationService creates a new remediation service
-func NewRemediationService(db *sql.DB, lineageSync interfaces.LineageSync) *RemediationService {
+func NewRemediationService(repo repository.RemediationRepository, lineageSync interfaces.LineageSync) *RemediationService {
 	if lineageSync == nil {
 		lineageSync = &interfaces.NoOpLineageSync{}
 	}
 	return &RemediationService{
-		db:               db,
+		repo:             repo,
 		lineageSync:      lineageSync,
 		connectorFactory: &connectors.ConnectorFactory{},
+		events:           &interfaces.NoOpEventPublisher{},
 	}
 }
 
-// GetDB returns the database connection
-func (s *RemediationService) GetDB() *sql.DB {
-	return s.db
+// SetIntegrationEvents enables remediation.executed events for downstream consumers
+func (s *RemediationService) SetIntegrationEvents(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// SetMaskingTemplates masks matched values and previews with the tenant's masking
+// templates; the built-in templates apply otherwise
+func (s *RemediationService) SetMaskingTemplates(templates *maskingservice.MaskingTemplateService) {
+	s.templates = templates
+}
+
+// publishRemediationExecuted announces a completed remediation. Matched values
+// are never included.
+func (s *RemediationService) publishRemediationExecuted(ctx context.Context, actionID string, finding *Finding, actionType, userID string, targets int) {
+	s.events.Publish(ctx, interfaces.EventRemediationExecuted, map[string]interface{}{
+		"action_id":   actionID,
+		"finding_id":  finding.ID,
+		"asset_id":    finding.AssetID,
+		"action_type": actionType,
+		"pii_type":    finding.PIIType,
+		"executed_by": userID,
+		"targets":     targets,
+		"executed_at": time.Now(),
+	})
 }
 
 // Finding represents a PII finding
-type Finding struct {
-	ID           string
-	AssetID      string
-	SystemID     string
-	AssetName    string
-	Location     string // Asset path/location
-	AssetPath    string
-	SourceSystem string
-	SourceType   string
-	FieldName    string
-	PIIType      string
-	RecordID     string
-	SampleText   string
-	Context      string
+type Finding = entity.RemediationFinding
+
+// TargetSnapshot is what a targeted remediation replaced at one match location,
+// kept in the action's metadata for rollback
+type TargetSnapshot struct {
+	Target   connectors.MatchTarget `json:"target"`
+	Snapshot string                 `json:"snapshot"`
 }
 
 // RemediationRequest represents a remediation request
@@ -88,6 +112,14 @@ func (s *RemediationService) ExecuteRemediation(ctx context.Context, findingID s
 		return "", fmt.Errorf("failed to connect to source: %w", err)
 	}
 
+	// Findings with located matches are remediated match by match when the
+	// connector supports it; everything else falls back to asset-level actions
+	if targeted, ok := connector.(connectors.TargetedConnector); ok && (actionType == "MASK" || actionType == "DELETE") {
+		if targets := matchTargets(finding, actionType, s.templates.Templates(ctx)); len(targets) > 0 {
+			return s.executeTargetedRemediation(ctx, targeted, finding, targets, actionType, userID)
+		}
+	}
+
 	// 5. Get original value (for rollback)
 	originalValue, err := connector.GetOriginalValue(ctx, finding.AssetPath, finding.FieldName, finding.RecordID)
 	if err != nil {
@@ -144,6 +176,7 @@ func (s *RemediationService) ExecuteRemediation(ctx context.Context, findingID s
 		"action_type": actionType,
 		"asset_name":  finding.AssetName,
 	})
+	s.publishRemediationExecuted(ctx, actionID, finding, actionType, userID, 0)
 
 	return actionID, nil
 }
@@ -185,7 +218,15 @@ func (s *RemediationService) RollbackRemediation(ctx context.Context, actionID s
 	}
 
 	// 6. Restore original value
-	if err := connector.RestoreValue(ctx, finding.AssetPath, finding.FieldName, finding.RecordID, action.OriginalValue); err != nil {
+	if len(action.Targets) > 0 {
+		targeted, ok := connector.(connectors.TargetedConnector)
+		if !ok {
+			return fmt.Errorf("connector for %s cannot restore targeted remediation", finding.SourceType)
+		}
+		if err := restoreTargets(ctx, targeted, action.ActionType, action.Targets); err != nil {
+			return fmt.Errorf("failed to restore value: %w", err)
+		}
+	} else if err := connector.RestoreValue(ctx, finding.AssetPath, finding.FieldName, finding.RecordID, action.OriginalValue); err != nil {
 		return fmt.Errorf("failed to restore value: %w", err)
 	}
 
@@ -195,12 +236,7 @@ func (s *RemediationService) RollbackRemediation(ctx context.Context, actionID s
 	}
 
 	// 8. Set effective_until
-	_, err = s.db.ExecContext(ctx, `
-		UPDATE remediation_actions 
-		SET effective_until = NOW()
-		WHERE id = $1
-	`, actionID)
-	if err != nil {
+	if err := s.repo.EndRemediationAction(ctx, actionID); err != nil {
 		return fmt.Errorf("failed to set effective_until: %w", err)
 	}
 
@@ -212,6 +248,108 @@ func (s *RemediationService) RollbackRemediation(ctx context.Context, actionID s
 	return nil
 }
 
+// executeTargetedRemediation applies the action to each located match. If one
+// target fails, the targets already changed are restored before returning.
+func (s *RemediationService) executeTargetedRemediation(ctx context.Context, connector connectors.TargetedConnector, finding *Finding, targets []connectors.MatchTarget, actionType string, userID string) (string, error) {
+	actionID, err := s.createRemediationAction(ctx, finding.ID, actionType, userID, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create remediation action: %w", err)
+	}
+	if err := s.updateRemediationStatus(ctx, actionID, "IN_PROGRESS"); err != nil {
+		return "", fmt.Errorf("failed to update status: %w", err)
+	}
+
+	applied := make([]TargetSnapshot, 0, len(targets))
+	for _, target := range targets {
+		var snapshot string
+		if actionType == "DELETE" {
+			snapshot, err = connector.DeleteMatch(ctx, target)
+		} else {
+			snapshot, err = connector.MaskMatch(ctx, target)
+		}
+		if err != nil {
+			if restoreErr := restoreTargets(ctx, connector, actionType, applied); restoreErr != nil {
+				log.Printf("WARNING: Failed to undo partial remediation %s: %v", actionID, restoreErr)
+			}
+			s.updateRemediationStatus(ctx, actionID, "FAILED")
+			return "", fmt.Errorf("failed to execute remediation: %w", err)
+		}
+		applied = append(applied, TargetSnapshot{Target: target, Snapshot: snapshot})
+	}
+
+	metadataJSON, _ := json.Marshal(map[string]interface{}{"targets": applied})
+	if err := s.repo.UpdateRemediationActionMetadata(ctx, actionID, metadataJSON); err != nil {
+		return "", fmt.Errorf("failed to record remediation snapshots: %w", err)
+	}
+
+	if err := s.updateRemediationStatus(ctx, actionID, "COMPLETED"); err != nil {
+		return "", fmt.Errorf("failed to update status: %w", err)
+	}
+
+	if s.lineageSync.IsAvailable() {
+		if assetUUID, parseErr := uuid.Parse(finding.AssetID); parseErr == nil {
+			if err := s.lineageSync.SyncAssetToNeo4j(ctx, assetUUID); err != nil {
+				log.Printf("WARNING: Failed to sync asset to lineage after remediation: %v", err)
+			}
+		}
+	}
+
+	s.recordAuditLog(ctx, "REMEDIATION_EXECUTED", userID, "remediation_action", actionID, map[string]interface{}{
+		"finding_id":  finding.ID,
+		"action_type": actionType,
+		"asset_name":  finding.AssetName,
+		"targets":     len(applied),
+	})
+	s.publishRemediationExecuted(ctx, actionID, finding, actionType, userID, len(applied))
+
+	return actionID, nil
+}
+
+// restoreTargets undoes targeted remediation in reverse order, so repeated changes
+// to the same value unwind back to the original
+func restoreTargets(ctx context.Context, connector connectors.TargetedConnector, actionType string, applied []TargetSnapshot) error {
+	for i := len(applied) - 1; i >= 0; i-- {
+		if err := connector.RestoreMatch(ctx, applied[i].Target, actionType, applied[i].Snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchTargets turns a finding's match locations into connector targets, masking
+// each value with its PII type's template for a MASK. A DELETE needs each record
+// only once, however many matches it holds.
+func matchTargets(finding *Finding, actionType string, templates redaction.Templates) []connectors.MatchTarget {
+	var targets []connectors.MatchTarget
+	seen := make(map[string]bool)
+	for _, loc := range finding.Locations {
+		if loc.MatchIndex < 0 || loc.MatchIndex >= len(finding.Matches) {
+			continue
+		}
+		target := connectors.MatchTarget{
+			Location:         finding.AssetPath,
+			Value:            finding.Matches[loc.MatchIndex],
+			Line:             loc.Line,
+			Column:           loc.Column,
+			Table:            loc.Table,
+			Field:            loc.Field,
+			PrimaryKeyColumn: loc.PrimaryKeyColumn,
+			PrimaryKey:       loc.PrimaryKey,
+		}
+		if actionType == "MASK" {
+			target.Masked = templates.Mask(finding.PIIType, target.Value)
+		}
+		if actionType == "DELETE" {
+			if seen[target.Key()] {
+				continue
+			}
+			seen[target.Key()] = true
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
 // GenerateRemediationPreview generates a preview of remediation impact
 func (s *RemediationService) GenerateRemediationPreview(ctx context.Context, findingIDs []string, actionType string) (*RemediationPreview, error) {
 	// Get findings details
@@ -342,69 +480,32 @@ type RemediationResult struct {
 // Helper functions
 
 func (s *RemediationService) getFinding(ctx context.Context, findingID string) (*Finding, error) {
-	query := `
-		SELECT f.id, f.asset_id, a.name, a.path, sp.name as source_system, sp.source_type,
-		       f.field_name, f.pii_type, f.record_id, f.sample_text, f.context
-		FROM findings f
-		JOIN assets a ON f.asset_id = a.id
-		JOIN source_profiles sp ON a.source_profile_id = sp.id
-		WHERE f.id = $1
-	`
-
-	var finding Finding
-	err := s.db.QueryRowContext(ctx, query, findingID).Scan(
-		&finding.ID, &finding.AssetID, &finding.AssetName, &finding.AssetPath,
-		&finding.SourceSystem, &finding.SourceType, &finding.FieldName,
-		&finding.PIIType, &finding.RecordID, &finding.SampleText, &finding.Context,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &finding, nil
+	return s.repo.GetRemediationFinding(ctx, findingID)
 }
 
 func (s *RemediationService) getSourceConfig(ctx context.Context, sourceName string) (map[string]interface{}, error) {
-	var configJSON string
-	err := s.db.QueryRowContext(ctx, `
-		SELECT connection_config FROM source_profiles WHERE name = $1
-	`, sourceName).Scan(&configJSON)
-	if err != nil {
-		return nil, err
-	}
-
-	var config map[string]interface{}
-	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
-		return nil, err
-	}
-
-	return config, nil
+	return s.repo.GetRemediationSourceConfig(ctx, sourceName)
 }
 
 func (s *RemediationService) createRemediationAction(ctx context.Context, findingID string, actionType string, userID string, originalValue string) (string, error) {
-	actionID := uuid.New().String()
-
 	metadata := map[string]interface{}{
 		"original_value": originalValue,
 	}
 	metadataJSON, _ := json.Marshal(metadata)
 
-	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO remediation_actions 
-		(id, finding_id, action_type, executed_by, executed_at, effective_from, status, metadata)
-		VALUES ($1, $2, $3, $4, NOW(), NOW(), 'PENDING', $5)
-	`, actionID, findingID, actionType, userID, metadataJSON)
-
-	return actionID, err
+	action := &entity.RemediationAction{
+		ID:         uuid.New().String(),
+		FindingID:  findingID,
+		ActionType: actionType,
+		ExecutedBy: userID,
+		Metadata:   metadataJSON,
+	}
+	err := s.repo.CreateRemediationAction(ctx, action)
+	return action.ID, err
 }
 
 func (s *RemediationService) updateRemediationStatus(ctx context.Context, actionID string, status string) error {
-	_, err := s.db.ExecContext(ctx, `
-		UPDATE remediation_actions 
-		SET status = $1
-		WHERE id = $2
-	`, status, actionID)
-	return err
+	return s.repo.UpdateRemediationActionStatus(ctx, actionID, status)
 }
 
 type RemediationAction struct {
@@ -415,192 +516,85 @@ type RemediationAction struct {
 	ExecutedAt    time.Time
 	Status        string
 	OriginalValue string
+	Targets       []TargetSnapshot
+}
+
+// remediationActions converts stored actions; list queries carry no metadata
+func remediationActions(records []*entity.RemediationAction) []*RemediationAction {
+	var actions []*RemediationAction
+	for _, record := range records {
+		actions = append(actions, &RemediationAction{
+			ID:         record.ID,
+			FindingID:  record.FindingID,
+			ActionType: record.ActionType,
+			ExecutedBy: record.ExecutedBy,
+			ExecutedAt: record.ExecutedAt,
+			Status:     record.Status,
+		})
+	}
+	return actions
 }
 
 func (s *RemediationService) GetRemediationActions(ctx context.Context, findingID string) ([]*RemediationAction, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, finding_id, action_type, executed_by, executed_at, status
-		FROM remediation_actions
-		WHERE finding_id = $1
-		ORDER BY executed_at DESC
-	`, findingID)
+	records, err := s.repo.ListRemediationActionsByFinding(ctx, findingID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var actions []*RemediationAction
-	for rows.Next() {
-		var action RemediationAction
-		err := rows.Scan(&action.ID, &action.FindingID, &action.ActionType, &action.ExecutedBy, &action.ExecutedAt, &action.Status)
-		if err != nil {
-			return nil, err
-		}
-		actions = append(actions, &action)
-	}
-
-	return actions, nil
+	return remediationActions(records), nil
 }
 
 // GetAllRemediationActions retrieves all remediation actions with pagination and filtering
 func (s *RemediationService) GetAllRemediationActions(ctx context.Context, limit, offset int, actionFilter string) ([]*RemediationAction, int, error) {
-	// Build base query
-	query := `
-		SELECT id, finding_id, action_type, executed_by, executed_at, status
-		FROM remediation_actions
-		WHERE 1=1
-	`
-	countQuery := `SELECT COUNT(*) FROM remediation_actions WHERE 1=1`
-
-	args := []interface{}{}
-	argCount := 1
-
-	// Add filter
-	if actionFilter != "" && actionFilter != "ALL" {
-		filterClause := fmt.Sprintf(" AND action_type = $%d", argCount)
-		query += filterClause
-		countQuery += filterClause
-		args = append(args, actionFilter)
-		argCount++
-	}
-
-	// Add ordering and pagination
-	query += fmt.Sprintf(" ORDER BY executed_at DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
-	args = append(args, limit, offset)
-
-	// Execute count query
-	var total int
-	// For count we only need the filter args, not limit/offset
-	countArgs := args[:len(args)-2]
-	err := s.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+	records, total, err := s.repo.ListRemediationActions(ctx, actionFilter, limit, offset)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count remediation actions: %w", err)
-	}
-
-	// Execute data query
-	rows, err := s.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list remediation actions: %w", err)
-	}
-	defer rows.Close()
-
-	var actions []*RemediationAction
-	for rows.Next() {
-		var action RemediationAction
-		err := rows.Scan(
-			&action.ID, &action.FindingID, &action.ActionType,
-			&action.ExecutedBy, &action.ExecutedAt, &action.Status,
-		)
-		if err != nil {
-			return nil, 0, err
-		}
-
-		actions = append(actions, &action)
+		return nil, 0, err
 	}
-
-	return actions, total, nil
+	return remediationActions(records), total, nil
 }
 
 func (s *RemediationService) GetRemediationHistory(ctx context.Context, assetID string) ([]*RemediationAction, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT ra.id, ra.finding_id, ra.action_type, ra.executed_by, ra.executed_at, ra.status
-		FROM remediation_actions ra
-		JOIN findings f ON ra.finding_id = f.id::text
-		WHERE f.asset_id = $1
-		ORDER BY ra.executed_at DESC
-	`, assetID)
+	records, err := s.repo.ListRemediationActionsByAsset(ctx, assetID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var actions []*RemediationAction
-	for rows.Next() {
-		var action RemediationAction
-		err := rows.Scan(&action.ID, &action.FindingID, &action.ActionType, &action.ExecutedBy, &action.ExecutedAt, &action.Status)
-		if err != nil {
-			return nil, err
-		}
-		actions = append(actions, &action)
-	}
-
-	return actions, nil
+	return remediationActions(records), nil
 }
 
 func (s *RemediationService) GetPIIPreview(ctx context.Context, findingID string) (map[string]interface{}, error) {
-	var finding struct {
-		SampleText string
-		PIIType    string
-	}
-	err := s.db.QueryRowContext(ctx, `
-		SELECT sample_text, pii_type
-		FROM findings
-		WHERE id = $1
-	`, findingID).Scan(&finding.SampleText, &finding.PIIType)
+	sampleText, piiType, err := s.repo.GetFindingPreviewSample(ctx, findingID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Simple masking for preview
-	maskedText := s.maskText(finding.SampleText, finding.PIIType)
+	maskedText := s.templates.Templates(ctx).Mask(piiType, sampleText)
 
 	return map[string]interface{}{
 		"finding_id":    findingID,
-		"original_text": finding.SampleText,
+		"original_text": sampleText,
 		"masked_text":   maskedText,
-		"pii_type":      finding.PIIType,
+		"pii_type":      piiType,
 	}, nil
 }
 
-func (s *RemediationService) maskText(text, piiType string) string {
-	// Simple masking logic
-	switch piiType {
-	case "EMAIL":
-		return strings.ReplaceAll(text, "@", "[AT]")
-	case "PHONE":
-		return strings.Repeat("*", len(text))
-	case "CREDIT_CARD":
-		if len(text) > 4 {
-			return strings.Repeat("*", len(text)-4) + text[len(text)-4:]
-		}
-		return strings.Repeat("*", len(text))
-	default:
-		return strings.Repeat("*", len(text))
-	}
-}
-
 func (s *RemediationService) GetRemediationAction(ctx context.Context, actionID string) (*RemediationAction, error) {
-	var action RemediationAction
-	var metadataJSON string
-
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, finding_id, action_type, executed_by, executed_at, status, metadata
-		FROM remediation_actions
-		WHERE id = $1
-	`, actionID).Scan(
-		&action.ID, &action.FindingID, &action.ActionType,
-		&action.ExecutedBy, &action.ExecutedAt, &action.Status, &metadataJSON,
-	)
+	record, err := s.repo.GetRemediationAction(ctx, actionID)
 	if err != nil {
 		return nil, err
 	}
+	action := remediationActions([]*entity.RemediationAction{record})[0]
 
-	var metadata map[string]interface{}
-	if err := json.Unmarshal([]byte(metadataJSON), &metadata); err == nil {
-		if val, ok := metadata["original_value"].(string); ok {
-			action.OriginalValue = val
-		}
+	var metadata struct {
+		OriginalValue string           `json:"original_value"`
+		Targets       []TargetSnapshot `json:"targets"`
+	}
+	if err := json.Unmarshal(record.Metadata, &metadata); err == nil {
+		action.OriginalValue = metadata.OriginalValue
+		action.Targets = metadata.Targets
 	}
 
-	return &action, nil
+	return action, nil
 }
 
 func (s *RemediationService) recordAuditLog(ctx context.Context, eventType string, userID string, resourceType string, resourceID string, metadata map[string]interface{}) {
-	metadataJSON, _ := json.Marshal(metadata)
-
-	s.db.ExecContext(ctx, `
-		INSERT INTO audit_logs 
-		(event_type, event_time, user_id, resource_type, resource_id, action, metadata)
-		VALUES ($1, NOW(), $2, $3, $4, $5, $6)
-	`, eventType, userID, resourceType, resourceID, eventType, metadataJSON)
+	s.repo.RecordRemediationAuditLog(ctx, eventType, userID, resourceType, resourceID, metadata)
 }
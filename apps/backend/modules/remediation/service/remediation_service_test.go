@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+)
+
+const usersCSV = "name,email\nasha,asha@example.com\n"
+
+// newFilesystemFixture stores a finding on a CSV file in a temp directory, so
+// remediation runs against the filesystem connector without a database
+func newFilesystemFixture(t *testing.T) (*memory.Repository, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "users.csv"), []byte(usersCSV), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := memory.NewRepository()
+	repo.PutSourceConfig("fs://local", map[string]interface{}{"base_path": dir})
+	asset := &entity.Asset{ID: uuid.New(), Name: "users.csv", Path: "users.csv", DataSource: "fs", SourceSystem: "fs://local"}
+	repo.PutAsset(asset)
+
+	finding := &entity.Finding{
+		ID:          uuid.New(),
+		AssetID:     asset.ID,
+		PatternName: "EMAIL_ADDRESS",
+		Matches:     []string{"asha@example.com"},
+		SampleText:  "asha@example.com",
+		Locations:   []entity.MatchLocation{{MatchIndex: 0, Line: 2, Column: 6}},
+	}
+	repo.PutFinding(finding)
+
+	return repo, filepath.Join(dir, "users.csv"), finding.ID.String()
+}
+
+func TestExecuteAndRollbackRemediation(t *testing.T) {
+	repo, path, findingID := newFilesystemFixture(t)
+	s := NewRemediationService(repo, nil)
+	ctx := context.Background()
+
+	actionID, err := s.ExecuteRemediation(ctx, findingID, "MASK", "asha")
+	if err != nil {
+		t.Fatalf("ExecuteRemediation: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "name,email\nasha,XXXX@example.com\n" {
+		t.Fatalf("masked content = %q", got)
+	}
+
+	action, err := s.GetRemediationAction(ctx, actionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Status != "COMPLETED" || len(action.Targets) != 1 {
+		t.Fatalf("unexpected action: %+v", action)
+	}
+
+	if err := s.RollbackRemediation(ctx, actionID); err != nil {
+		t.Fatalf("RollbackRemediation: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != usersCSV {
+		t.Fatalf("restored content = %q", got)
+	}
+	if err := s.RollbackRemediation(ctx, actionID); err == nil {
+		t.Error("expected a second rollback to be refused")
+	}
+
+	actions, err := s.GetRemediationActions(ctx, findingID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 1 || actions[0].Status != "ROLLED_BACK" {
+		t.Errorf("unexpected actions: %+v", actions)
+	}
+
+	var events []string
+	for _, entry := range repo.AuditLogs() {
+		events = append(events, entry.EventType)
+	}
+	if len(events) != 2 || events[0] != "REMEDIATION_EXECUTED" || events[1] != "REMEDIATION_ROLLED_BACK" {
+		t.Errorf("unexpected audit events: %v", events)
+	}
+}
+
+func TestExecuteRemediationUnknownFinding(t *testing.T) {
+	s := NewRemediationService(memory.NewRepository(), nil)
+	if _, err := s.ExecuteRemediation(context.Background(), uuid.NewString(), "MASK", "asha"); err == nil {
+		t.Fatal("expected an unknown finding to fail")
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/remediation/connectors"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// Remediation simulation statuses
+const (
+	SimulationSucceeded = entity.RemediationSimulationSucceeded
+	SimulationFailed    = entity.RemediationSimulationFailed
+)
+
+var ErrSimulationNotFound = errors.New("remediation simulation not found")
+
+// RemediationSimulation is a stored dry run of a remediation
+type RemediationSimulation = entity.RemediationSimulation
+
+// SimulateRemediation dry-runs a remediation against the source: it connects,
+// checks every target the real run would touch is still there and computes each
+// before and after value, but writes nothing. The result is stored even when the
+// dry run fails, since that is what tells an approver the real run would fail too.
+func (s *RemediationService) SimulateRemediation(ctx context.Context, findingID string, actionType string, userID string) (*RemediationSimulation, error) {
+	switch actionType {
+	case "MASK", "DELETE", "ENCRYPT":
+	default:
+		return nil, fmt.Errorf("unsupported action type: %s", actionType)
+	}
+
+	finding, err := s.getFinding(ctx, findingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finding: %w", err)
+	}
+
+	sim := &RemediationSimulation{
+		FindingID:   findingID,
+		ActionType:  actionType,
+		Status:      SimulationSucceeded,
+		SimulatedBy: userID,
+	}
+	changes, err := s.simulateChanges(ctx, finding, actionType)
+	if err != nil {
+		sim.Status = SimulationFailed
+		sim.Error = err.Error()
+	}
+	if sim.Changes, err = json.Marshal(changes); err != nil {
+		return nil, fmt.Errorf("failed to encode simulated changes: %w", err)
+	}
+	if err := s.repo.CreateRemediationSimulation(ctx, sim); err != nil {
+		return nil, fmt.Errorf("failed to store remediation simulation: %w", err)
+	}
+
+	s.recordAuditLog(ctx, "REMEDIATION_SIMULATED", userID, "remediation_simulation", sim.ID, map[string]interface{}{
+		"finding_id":  findingID,
+		"action_type": actionType,
+		"asset_name":  finding.AssetName,
+		"status":      sim.Status,
+		"targets":     len(changes),
+	})
+
+	return sim, nil
+}
+
+// simulateChanges computes the changes ExecuteRemediation would make, taking the
+// same targeted or asset-level path. Changes found before a failing target are
+// returned with the error.
+func (s *RemediationService) simulateChanges(ctx context.Context, finding *Finding, actionType string) ([]connectors.SimulatedChange, error) {
+	changes := make([]connectors.SimulatedChange, 0)
+
+	config, err := s.getSourceConfig(ctx, finding.SourceSystem)
+	if err != nil {
+		return changes, fmt.Errorf("failed to get source config: %w", err)
+	}
+	connector, err := s.connectorFactory.NewConnector(finding.SourceType)
+	if err != nil {
+		return changes, fmt.Errorf("failed to create connector: %w", err)
+	}
+	defer connector.Close()
+
+	if err := connector.Connect(ctx, config); err != nil {
+		return changes, fmt.Errorf("failed to connect to source: %w", err)
+	}
+
+	if simulating, ok := connector.(connectors.SimulatingConnector); ok && (actionType == "MASK" || actionType == "DELETE") {
+		if targets := matchTargets(finding, actionType, s.templates.Templates(ctx)); len(targets) > 0 {
+			for _, target := range targets {
+				change, err := simulating.SimulateMatch(ctx, target, actionType)
+				if err != nil {
+					return changes, fmt.Errorf("target %s: %w", target.Key(), err)
+				}
+				changes = append(changes, *change)
+			}
+			return changes, nil
+		}
+	}
+
+	// Asset-level actions can only be checked by reading the original value; what
+	// the connector writes in its place is reported by its nominal effect
+	original, err := connector.GetOriginalValue(ctx, finding.AssetPath, finding.FieldName, finding.RecordID)
+	if err != nil {
+		return changes, fmt.Errorf("failed to get original value: %w", err)
+	}
+	return append(changes, connectors.SimulatedChange{
+		Target:      connectors.MatchTarget{Location: finding.AssetPath, Field: finding.FieldName, PrimaryKey: finding.RecordID},
+		Before:      original,
+		After:       s.generateSampleAfter(original, actionType),
+		Approximate: true,
+	}), nil
+}
+
+// GetSimulation retrieves one remediation simulation
+func (s *RemediationService) GetSimulation(ctx context.Context, id string) (*RemediationSimulation, error) {
+	sim, err := s.repo.GetRemediationSimulation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get remediation simulation: %w", err)
+	}
+	if sim == nil {
+		return nil, ErrSimulationNotFound
+	}
+	return sim, nil
+}
+
+// LatestSimulations returns the newest simulation of actionType for each finding
+// that has one, so an approver can see what a request would change
+func (s *RemediationService) LatestSimulations(ctx context.Context, findingIDs []string, actionType string) ([]*RemediationSimulation, error) {
+	latest := make([]*RemediationSimulation, 0, len(findingIDs))
+	for _, findingID := range findingIDs {
+		sims, err := s.repo.ListRemediationSimulationsByFinding(ctx, findingID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list remediation simulations: %w", err)
+		}
+		for _, sim := range sims {
+			if sim.ActionType == actionType {
+				latest = append(latest, sim)
+				break
+			}
+		}
+	}
+	return latest, nil
+}
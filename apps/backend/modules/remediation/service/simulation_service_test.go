@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/remediation/connectors"
+)
+
+func TestSimulateRemediationChangesNothing(t *testing.T) {
+	repo, path, findingID := newFilesystemFixture(t)
+	s := NewRemediationService(repo, nil)
+	ctx := context.Background()
+
+	sim, err := s.SimulateRemediation(ctx, findingID, "MASK", "asha")
+	if err != nil {
+		t.Fatalf("SimulateRemediation: %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != usersCSV {
+		t.Fatalf("a dry run changed the file: %q", got)
+	}
+	if sim.Status != SimulationSucceeded {
+		t.Fatalf("unexpected simulation: %+v", sim)
+	}
+
+	var changes []connectors.SimulatedChange
+	if err := json.Unmarshal(sim.Changes, &changes); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Before != "asha,asha@example.com" || changes[0].After != "asha,XXXX@example.com" {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+
+	stored, err := s.GetSimulation(ctx, sim.ID)
+	if err != nil || stored.FindingID != findingID {
+		t.Fatalf("expected the simulation stored, got %+v %v", stored, err)
+	}
+	if actions, _ := s.GetRemediationActions(ctx, findingID); len(actions) != 0 {
+		t.Errorf("a dry run must not record remediation actions: %+v", actions)
+	}
+	if logs := repo.AuditLogs(); len(logs) != 1 || logs[0].EventType != "REMEDIATION_SIMULATED" {
+		t.Errorf("unexpected audit events: %+v", logs)
+	}
+}
+
+func TestSimulateRemediationRecordsFailure(t *testing.T) {
+	repo, path, findingID := newFilesystemFixture(t)
+	s := NewRemediationService(repo, nil)
+	ctx := context.Background()
+
+	// The value moved since the scan, so the real run would refuse to touch the file
+	if err := os.WriteFile(path, []byte("name,email\nravi,ravi@example.com\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	sim, err := s.SimulateRemediation(ctx, findingID, "DELETE", "asha")
+	if err != nil {
+		t.Fatalf("SimulateRemediation: %v", err)
+	}
+	if sim.Status != SimulationFailed || sim.Error == "" {
+		t.Fatalf("expected a failed simulation, got %+v", sim)
+	}
+
+	latest, err := s.LatestSimulations(ctx, []string{findingID}, "DELETE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 1 || latest[0].ID != sim.ID {
+		t.Errorf("expected the failed simulation as the latest, got %+v", latest)
+	}
+	if latest, _ := s.LatestSimulations(ctx, []string{findingID}, "MASK"); len(latest) != 0 {
+		t.Errorf("simulations of other actions must not be returned, got %+v", latest)
+	}
+}
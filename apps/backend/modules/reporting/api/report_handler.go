@@ -0,0 +1,315 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/reporting/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReportHandler handles report template, rendering, schedule and run requests
+type ReportHandler struct {
+	service *service.ReportService
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(service *service.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GetFields handles GET /api/v1/reports/fields
+func (h *ReportHandler) GetFields(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"data": h.service.Fields()})
+}
+
+// CreateTemplate handles POST /api/v1/reports/templates
+func (h *ReportHandler) CreateTemplate(c *gin.Context) {
+	var input service.ReportTemplateInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	tmpl, err := h.service.CreateTemplate(sharedapi.RequestContext(c), input, actor(c))
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": tmpl})
+}
+
+// ListTemplates handles GET /api/v1/reports/templates
+func (h *ReportHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.service.ListTemplates(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list report templates",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": templates, "total": len(templates)})
+}
+
+// GetTemplate handles GET /api/v1/reports/templates/:id
+func (h *ReportHandler) GetTemplate(c *gin.Context) {
+	id, ok := parseID(c, "report template")
+	if !ok {
+		return
+	}
+
+	tmpl, err := h.service.GetTemplate(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tmpl})
+}
+
+// UpdateTemplate handles PUT /api/v1/reports/templates/:id
+func (h *ReportHandler) UpdateTemplate(c *gin.Context) {
+	id, ok := parseID(c, "report template")
+	if !ok {
+		return
+	}
+
+	var input service.ReportTemplateInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	tmpl, err := h.service.UpdateTemplate(sharedapi.RequestContext(c), id, input)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": tmpl})
+}
+
+// DeleteTemplate handles DELETE /api/v1/reports/templates/:id
+func (h *ReportHandler) DeleteTemplate(c *gin.Context) {
+	id, ok := parseID(c, "report template")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTemplate(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Report template deleted"})
+}
+
+// PreviewTemplate handles POST /api/v1/reports/templates/preview, evaluating an
+// unsaved template as JSON
+func (h *ReportHandler) PreviewTemplate(c *gin.Context) {
+	var input service.ReportTemplateInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	result, err := h.service.PreviewReport(sharedapi.RequestContext(c), input)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// RenderTemplate handles GET /api/v1/reports/templates/:id/render. format=json
+// (the default) returns the report inline; csv and pdf download it as a file.
+func (h *ReportHandler) RenderTemplate(c *gin.Context) {
+	id, ok := parseID(c, "report template")
+	if !ok {
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format == "json" {
+		result, err := h.service.GenerateReport(sharedapi.RequestContext(c), id)
+		if err != nil {
+			c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"data": result})
+		return
+	}
+
+	report, err := h.service.RenderReport(sharedapi.RequestContext(c), id, format)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", report.Filename))
+	c.Data(http.StatusOK, report.ContentType, report.Data)
+}
+
+// CreateSchedule handles POST /api/v1/reports/schedules
+func (h *ReportHandler) CreateSchedule(c *gin.Context) {
+	var input service.ReportScheduleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(sharedapi.RequestContext(c), input, actor(c))
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": schedule})
+}
+
+// ListSchedules handles GET /api/v1/reports/schedules
+func (h *ReportHandler) ListSchedules(c *gin.Context) {
+	schedules, err := h.service.ListSchedules(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list report schedules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schedules, "total": len(schedules)})
+}
+
+// GetSchedule handles GET /api/v1/reports/schedules/:id
+func (h *ReportHandler) GetSchedule(c *gin.Context) {
+	id, ok := parseID(c, "report schedule")
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schedule})
+}
+
+// UpdateSchedule handles PUT /api/v1/reports/schedules/:id
+func (h *ReportHandler) UpdateSchedule(c *gin.Context) {
+	id, ok := parseID(c, "report schedule")
+	if !ok {
+		return
+	}
+
+	var input service.ReportScheduleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(sharedapi.RequestContext(c), id, input)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schedule})
+}
+
+// DeleteSchedule handles DELETE /api/v1/reports/schedules/:id
+func (h *ReportHandler) DeleteSchedule(c *gin.Context) {
+	id, ok := parseID(c, "report schedule")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSchedule(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Report schedule deleted"})
+}
+
+// RunSchedule handles POST /api/v1/reports/schedules/:id/run, delivering the report now
+func (h *ReportHandler) RunSchedule(c *gin.Context) {
+	id, ok := parseID(c, "report schedule")
+	if !ok {
+		return
+	}
+
+	run, err := h.service.RunSchedule(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForReportError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": run})
+}
+
+// ListRuns handles GET /api/v1/reports/runs
+func (h *ReportHandler) ListRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	var scheduleID *uuid.UUID
+	if v := c.Query("schedule_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule_id"})
+			return
+		}
+		scheduleID = &id
+	}
+
+	runs, err := h.service.ListRuns(sharedapi.RequestContext(c), scheduleID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list report runs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": runs, "total": len(runs)})
+}
+
+func actor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func parseID(c *gin.Context, resource string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + resource + " ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForReportError(err error) int {
+	if errors.Is(err, service.ErrReportSecretUnavailable) {
+		return http.StatusBadRequest
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"), strings.Contains(msg, "must not"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,103 @@
+package reporting
+
+import (
+	"context"
+	"log"
+
+	"github.com/arc-platform/backend/modules/reporting/api"
+	"github.com/arc-platform/backend/modules/reporting/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// ReportingModule lets tenants build custom findings reports, render them as
+// CSV, JSON or PDF, and deliver them on a schedule to email or webhook targets
+type ReportingModule struct {
+	reportService *service.ReportService
+	reportHandler *api.ReportHandler
+
+	deps         *interfaces.ModuleDependencies
+	cancelWorker context.CancelFunc
+}
+
+// NewReportingModule creates a new reporting module
+func NewReportingModule() *ReportingModule {
+	return &ReportingModule{}
+}
+
+// Name returns the module name
+func (m *ReportingModule) Name() string {
+	return "reporting"
+}
+
+// Initialize sets up the module and starts the report scheduler when enabled
+func (m *ReportingModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("📑 Initializing Reporting Module...")
+
+	var cfg config.ReportingConfig
+	var alerting config.AlertingConfig
+	if deps.Config != nil {
+		cfg = deps.Config.Reporting
+		alerting = deps.Config.Alerting
+	}
+
+	// Webhook secrets are encrypted at rest; without a key only unsigned webhooks can be configured
+	encryptionService, err := encryption.NewEncryptionService()
+	if err != nil {
+		log.Printf("WARN: Report webhook secrets unavailable: %v", err)
+		encryptionService = nil
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.reportService = service.NewReportService(repo, mailer.New(alerting), encryptionService, deps.AuditLogger, cfg, alerting.DashboardURL)
+	m.reportHandler = api.NewReportHandler(m.reportService)
+
+	if cfg.SchedulerEnabled {
+		var workerCtx context.Context
+		workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+		go m.reportService.StartWorker(workerCtx, cfg.IntervalSeconds)
+	}
+
+	log.Println("✅ Reporting Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *ReportingModule) RegisterRoutes(router *gin.RouterGroup) {
+	reports := router.Group("/reports")
+	{
+		reports.GET("/fields", m.reportHandler.GetFields)
+
+		reports.GET("/templates", m.reportHandler.ListTemplates)
+		reports.POST("/templates", m.reportHandler.CreateTemplate)
+		reports.POST("/templates/preview", m.reportHandler.PreviewTemplate)
+		reports.GET("/templates/:id", m.reportHandler.GetTemplate)
+		reports.PUT("/templates/:id", m.reportHandler.UpdateTemplate)
+		reports.DELETE("/templates/:id", m.reportHandler.DeleteTemplate)
+		reports.GET("/templates/:id/render", m.reportHandler.RenderTemplate)
+
+		reports.GET("/schedules", m.reportHandler.ListSchedules)
+		reports.POST("/schedules", m.reportHandler.CreateSchedule)
+		reports.GET("/schedules/:id", m.reportHandler.GetSchedule)
+		reports.PUT("/schedules/:id", m.reportHandler.UpdateSchedule)
+		reports.DELETE("/schedules/:id", m.reportHandler.DeleteSchedule)
+		reports.POST("/schedules/:id/run", m.reportHandler.RunSchedule)
+
+		reports.GET("/runs", m.reportHandler.ListRuns)
+	}
+	log.Printf("📑 Reporting routes registered")
+}
+
+// Shutdown stops the report scheduler
+func (m *ReportingModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Reporting Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+)
+
+// Headers sent with every report webhook. The signature is an HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the target's secret, so receivers can reject
+// replays by checking the timestamp.
+const (
+	webhookRunHeader       = "X-ARC-Report-Run"
+	webhookTemplateHeader  = "X-ARC-Report-Template"
+	webhookTimestampHeader = "X-ARC-Timestamp"
+	webhookSignatureHeader = "X-ARC-Signature"
+)
+
+// deliver sends a rendered report to one target. Email targets are skipped when
+// SMTP is not configured.
+func (s *ReportService) deliver(ctx context.Context, schedule *entity.ReportSchedule, run *entity.ReportRun, result *ReportResult, report *RenderedReport, target entity.ReportTarget) entity.ReportDelivery {
+	delivery := entity.ReportDelivery{Type: target.Type}
+
+	var err error
+	switch target.Type {
+	case entity.ReportTargetEmail:
+		delivery.Destination = strings.Join(target.Recipients, ", ")
+		if s.mailer == nil {
+			delivery.Status = entity.ReportRunSkipped
+			delivery.Error = "email is not configured"
+			return delivery
+		}
+		err = s.mailer.Send(ctx, s.reportEmail(schedule, result, report, target.Recipients))
+
+	case entity.ReportTargetWebhook:
+		delivery.Destination = target.URL
+		if u, parseErr := url.Parse(target.URL); parseErr == nil {
+			delivery.Destination = u.Host // Query strings may carry credentials
+		}
+		err = s.postWebhook(ctx, run, result, report, target)
+
+	default:
+		err = fmt.Errorf("unknown target type %q", target.Type)
+	}
+
+	delivery.Status = entity.ReportRunDelivered
+	if err != nil {
+		delivery.Status = entity.ReportRunFailed
+		delivery.Error = err.Error()
+	}
+	return delivery
+}
+
+// reportEmail attaches the rendered report to a short summary
+func (s *ReportService) reportEmail(schedule *entity.ReportSchedule, result *ReportResult, report *RenderedReport, to []string) mailer.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your %s report %q is attached (%s).\n\n", schedule.Frequency, result.Name, strings.ToUpper(schedule.Format))
+	fmt.Fprintf(&b, "Generated: %s\n", result.GeneratedAt.UTC().Format(time.RFC1123))
+	fmt.Fprintf(&b, "Filters:   %s\n", describeFilters(result.Filters))
+	fmt.Fprintf(&b, "Rows:      %d\n", result.RowCount)
+	if result.Truncated {
+		b.WriteString("\nThe report was truncated; narrow its filters to see every row.\n")
+	}
+	if s.dashboardURL != "" {
+		fmt.Fprintf(&b, "\nManage report schedules at %s\n", strings.TrimRight(s.dashboardURL, "/")+"/reports")
+	}
+
+	return mailer.Message{
+		To:      to,
+		Subject: fmt.Sprintf("[ARC-Hawk] Report: %s", result.Name),
+		Body:    b.String(),
+		Attachments: []mailer.Attachment{
+			{Filename: report.Filename, ContentType: report.ContentType, Data: report.Data},
+		},
+	}
+}
+
+// postWebhook posts the rendered report as the request body
+func (s *ReportService) postWebhook(ctx context.Context, run *entity.ReportRun, result *ReportResult, report *RenderedReport, target entity.ReportTarget) error {
+	var secret string
+	if len(target.SecretEncrypted) > 0 {
+		if s.encryption == nil {
+			return ErrReportSecretUnavailable
+		}
+		if err := s.encryption.Decrypt(target.SecretEncrypted, &secret); err != nil {
+			return fmt.Errorf("failed to decrypt webhook secret: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(report.Data))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", report.ContentType)
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", report.Filename))
+	req.Header.Set(webhookRunHeader, run.ID.String())
+	req.Header.Set(webhookTemplateHeader, result.TemplateID.String())
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	if secret != "" {
+		req.Header.Set(webhookSignatureHeader, "sha256="+signWebhook(secret, timestamp, report.Data))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>"
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
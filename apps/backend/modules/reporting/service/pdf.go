@@ -0,0 +1,144 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 landscape in points, with the margin kept clear on every side
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 40.0
+)
+
+// pdfWriter lays out text, filled rectangles and pages for a single PDF 1.4
+// document using the built-in Helvetica fonts, so no font files are embedded.
+// Text outside Latin-1 is replaced with '?'.
+type pdfWriter struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64 // Top of the next line, measured from the bottom of the page
+}
+
+func newPDFWriter() *pdfWriter {
+	w := &pdfWriter{}
+	w.newPage()
+	return w
+}
+
+func (w *pdfWriter) newPage() {
+	w.page = &bytes.Buffer{}
+	w.pages = append(w.pages, w.page)
+	w.y = pdfPageHeight - pdfMargin
+}
+
+// ensure starts a new page when fewer than height points are left
+func (w *pdfWriter) ensure(height float64) bool {
+	if w.y-height < pdfMargin {
+		w.newPage()
+		return true
+	}
+	return false
+}
+
+// text draws s with its baseline at (x, y), cut to fit maxWidth when positive
+func (w *pdfWriter) text(x, y, size float64, bold bool, s string, maxWidth float64) {
+	if maxWidth > 0 {
+		s = fitText(s, size, maxWidth)
+	}
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(w.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(s))
+}
+
+// rect fills a rectangle with a grey level from 0 (black) to 1 (white)
+func (w *pdfWriter) rect(x, y, width, height, grey float64) {
+	fmt.Fprintf(w.page, "%.2f g %.2f %.2f %.2f %.2f re f 0 g\n", grey, x, y, width, height)
+}
+
+// line writes a line of text at the left margin and moves down
+func (w *pdfWriter) line(size float64, bold bool, s string) {
+	height := size * 1.4
+	w.ensure(height)
+	w.y -= height
+	w.text(pdfMargin, w.y, size, bold, s, pdfPageWidth-2*pdfMargin)
+}
+
+// space moves down without drawing
+func (w *pdfWriter) space(height float64) {
+	w.y -= height
+}
+
+// bytes assembles the document: catalog, page tree, the two fonts, then a
+// page object and content stream per page, followed by the xref table
+func (w *pdfWriter) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range w.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// fitText shortens s with an ellipsis so it fits width at the given font size.
+// Widths are estimated from Helvetica's average glyph width.
+func fitText(s string, size, width float64) string {
+	maxChars := int(width / (size * 0.52))
+	runes := []rune(s)
+	if len(runes) <= maxChars {
+		return s
+	}
+	if maxChars < 4 {
+		return string(runes[:max(maxChars, 0)])
+	}
+	return string(runes[:maxChars-3]) + "..."
+}
+
+// pdfEscape encodes s as the body of a PDF literal string in WinAnsi
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteByte(' ')
+		case r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff:
+			b.WriteByte('?')
+		default:
+			b.WriteByte(byte(r))
+		}
+	}
+	return b.String()
+}
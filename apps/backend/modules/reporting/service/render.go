@@ -0,0 +1,274 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// pdfChartBars caps the bars drawn in a PDF chart; the rest are summed as "Other"
+const pdfChartBars = 12
+
+// ReportResult is a template evaluated against the tenant's findings
+type ReportResult struct {
+	TemplateID  uuid.UUID            `json:"template_id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description,omitempty"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Filters     entity.ReportFilters `json:"filters"`
+	GroupBy     []string             `json:"group_by"`
+	Columns     []string             `json:"columns"`
+	Rows        [][]string           `json:"rows"`
+	RowCount    int                  `json:"row_count"`
+	Truncated   bool                 `json:"truncated"` // More rows matched than the configured maximum
+	Chart       *ReportChartData     `json:"chart,omitempty"`
+}
+
+// ReportChartData is a chart spec with its series: the finding count per value
+// of the chart field, summed over the other groupings
+type ReportChartData struct {
+	Type   string   `json:"type"`
+	Field  string   `json:"field"`
+	Labels []string `json:"labels"`
+	Values []int64  `json:"values"`
+}
+
+// RenderedReport is a report encoded in one of the output formats
+type RenderedReport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// buildChart sums the finding counts of the result's rows per value of the chart
+// field. Bar and pie series are ordered largest first, line series by label.
+func buildChart(spec *entity.ReportChart, columns []string, rows [][]string) *ReportChartData {
+	if spec == nil {
+		return nil
+	}
+	chart := &ReportChartData{Type: spec.Type, Field: spec.Field, Labels: []string{}, Values: []int64{}}
+
+	field, count := -1, -1
+	for i, c := range columns {
+		switch c {
+		case spec.Field:
+			field = i
+		case "finding_count":
+			count = i
+		}
+	}
+	if field < 0 || count < 0 {
+		return chart
+	}
+
+	totals := map[string]int64{}
+	for _, row := range rows {
+		n, _ := strconv.ParseInt(row[count], 10, 64)
+		if _, seen := totals[row[field]]; !seen {
+			chart.Labels = append(chart.Labels, row[field])
+		}
+		totals[row[field]] += n
+	}
+
+	if spec.Type == "line" {
+		sort.Strings(chart.Labels)
+	} else {
+		sort.SliceStable(chart.Labels, func(i, j int) bool {
+			return totals[chart.Labels[i]] > totals[chart.Labels[j]]
+		})
+	}
+	for _, label := range chart.Labels {
+		chart.Values = append(chart.Values, totals[label])
+	}
+	return chart
+}
+
+// renderReport encodes result as csv, json or pdf
+func renderReport(result *ReportResult, format string) (*RenderedReport, error) {
+	base := fmt.Sprintf("%s-%s", reportSlug(result.Name), result.GeneratedAt.Format("20060102-1504"))
+
+	switch format {
+	case entity.ReportFormatCSV:
+		return &RenderedReport{Filename: base + ".csv", ContentType: "text/csv; charset=utf-8", Data: renderCSV(result)}, nil
+	case entity.ReportFormatJSON:
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &RenderedReport{Filename: base + ".json", ContentType: "application/json", Data: data}, nil
+	case entity.ReportFormatPDF:
+		return &RenderedReport{Filename: base + ".pdf", ContentType: "application/pdf", Data: renderPDF(result)}, nil
+	default:
+		return nil, fmt.Errorf("invalid format %q: must be csv, json or pdf", format)
+	}
+}
+
+func renderCSV(result *ReportResult) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(result.Columns)
+	for _, row := range result.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = csvSafe(cell)
+		}
+		_ = w.Write(cells)
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+// csvSafe stops spreadsheet applications from evaluating scanned values such as
+// asset names as formulas when the report is opened
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// renderPDF lays the report out as a title block, the chart as horizontal bars
+// whatever its type, and the rows as a table whose header repeats on every page
+func renderPDF(result *ReportResult) []byte {
+	w := newPDFWriter()
+
+	w.line(16, true, result.Name)
+	if result.Description != "" {
+		w.line(10, false, result.Description)
+	}
+	w.line(9, false, "Generated "+result.GeneratedAt.UTC().Format(time.RFC1123))
+	w.line(9, false, "Filters: "+describeFilters(result.Filters))
+	rows := fmt.Sprintf("Rows: %d", result.RowCount)
+	if result.Truncated {
+		rows += " (truncated)"
+	}
+	w.line(9, false, rows)
+	w.space(10)
+
+	if result.Chart != nil && len(result.Chart.Labels) > 0 {
+		drawPDFChart(w, result.Chart)
+		w.space(14)
+	}
+
+	drawPDFTable(w, result.Columns, result.Rows)
+	return w.bytes()
+}
+
+func drawPDFChart(w *pdfWriter, chart *ReportChartData) {
+	labels, values := chart.Labels, chart.Values
+	if len(labels) > pdfChartBars {
+		var other int64
+		for _, v := range values[pdfChartBars-1:] {
+			other += v
+		}
+		labels = append(append([]string{}, labels[:pdfChartBars-1]...), "Other")
+		values = append(append([]int64{}, values[:pdfChartBars-1]...), other)
+	}
+
+	var peak int64
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	const rowHeight, labelWidth, barWidth = 14.0, 180.0, 420.0
+	w.ensure(rowHeight * float64(len(labels)+2))
+	w.line(11, true, "Findings by "+strings.ReplaceAll(chart.Field, "_", " "))
+	w.space(4)
+	for i, label := range labels {
+		w.y -= rowHeight
+		if label == "" {
+			label = "(none)"
+		}
+		w.text(pdfMargin, w.y+2, 8, false, label, labelWidth-10)
+		width := 0.0
+		if peak > 0 {
+			width = barWidth * float64(values[i]) / float64(peak)
+		}
+		w.rect(pdfMargin+labelWidth, w.y, max(width, 1), rowHeight-4, 0.45)
+		w.text(pdfMargin+labelWidth+width+6, w.y+2, 8, false, strconv.FormatInt(values[i], 10), 0)
+	}
+}
+
+func drawPDFTable(w *pdfWriter, columns []string, rows [][]string) {
+	if len(columns) == 0 {
+		return
+	}
+	const rowHeight = 13.0
+	colWidth := (pdfPageWidth - 2*pdfMargin) / float64(len(columns))
+
+	header := func() {
+		w.y -= rowHeight
+		w.rect(pdfMargin, w.y-3, pdfPageWidth-2*pdfMargin, rowHeight, 0.85)
+		for i, c := range columns {
+			w.text(pdfMargin+float64(i)*colWidth+2, w.y, 8, true, c, colWidth-4)
+		}
+	}
+
+	w.ensure(rowHeight * 2)
+	header()
+	if len(rows) == 0 {
+		w.line(9, false, "No findings match this report.")
+		return
+	}
+	for _, row := range rows {
+		if w.ensure(rowHeight) {
+			header()
+		}
+		w.y -= rowHeight
+		for i, cell := range row {
+			w.text(pdfMargin+float64(i)*colWidth+2, w.y, 8, false, cell, colWidth-4)
+		}
+	}
+}
+
+// describeFilters summarizes a template's filters for report headers and emails
+func describeFilters(f entity.ReportFilters) string {
+	var parts []string
+	add := func(name string, values []string) {
+		if len(values) > 0 {
+			parts = append(parts, name+" "+strings.Join(values, ", "))
+		}
+	}
+	add("severity", f.Severities)
+	add("classification", f.ClassificationTypes)
+	add("environment", f.Environments)
+	add("pattern", f.PatternNames)
+	add("data source", f.DataSources)
+	add("review status", f.ReviewStatuses)
+	if f.SinceDays > 0 {
+		parts = append(parts, fmt.Sprintf("created in the last %d days", f.SinceDays))
+	}
+	if len(parts) == 0 {
+		return "all findings"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// reportSlug turns a report name into a file name stem
+func reportSlug(name string) string {
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, name), "-")
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	if slug == "" {
+		return "report"
+	}
+	return slug
+}
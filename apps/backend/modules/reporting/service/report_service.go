@@ -0,0 +1,701 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/mailer"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	maxReportGroupings = 3
+	maxReportTargets   = 10
+	maxReportSinceDays = 3650
+)
+
+// ErrReportSecretUnavailable is returned when a webhook secret is supplied but cannot be encrypted
+var ErrReportSecretUnavailable = errors.New("webhook secret cannot be stored: ENCRYPTION_KEY is not configured")
+
+var (
+	validSeverities  = map[string]bool{"critical": true, "high": true, "medium": true, "low": true}
+	validChartTypes  = map[string]bool{"bar": true, "pie": true, "line": true}
+	validFrequencies = map[string]bool{entity.ReportDaily: true, entity.ReportWeekly: true, entity.ReportMonthly: true}
+	validFormats     = map[string]bool{entity.ReportFormatCSV: true, entity.ReportFormatJSON: true, entity.ReportFormatPDF: true}
+)
+
+// ReportService manages report templates and schedules, renders reports and delivers them
+type ReportService struct {
+	repo         *persistence.PostgresRepository
+	mailer       mailer.Mailer                 // nil when SMTP is not configured
+	encryption   *encryption.EncryptionService // nil when ENCRYPTION_KEY is unset; webhooks are then unsigned
+	auditLogger  interfaces.AuditLogger
+	client       *http.Client
+	maxRows      int
+	deliveryHour int
+	dashboardURL string
+}
+
+// NewReportService creates a new report service. A nil mailer records email
+// deliveries as skipped instead of sending them.
+func NewReportService(repo *persistence.PostgresRepository, m mailer.Mailer, enc *encryption.EncryptionService, auditLogger interfaces.AuditLogger, cfg config.ReportingConfig, dashboardURL string) *ReportService {
+	hour := cfg.DeliveryHourUTC
+	if hour < 0 || hour > 23 {
+		hour = 7
+	}
+	maxRows := cfg.MaxRows
+	if maxRows < 1 {
+		maxRows = 10000
+	}
+	timeout := time.Duration(cfg.WebhookTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &ReportService{
+		repo:         repo,
+		mailer:       m,
+		encryption:   enc,
+		auditLogger:  auditLogger,
+		client:       &http.Client{Timeout: timeout},
+		maxRows:      maxRows,
+		deliveryHour: hour,
+		dashboardURL: dashboardURL,
+	}
+}
+
+// ReportTemplateInput is the payload for creating, replacing or previewing a report template
+type ReportTemplateInput struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Filters     entity.ReportFilters `json:"filters"`
+	GroupBy     []string             `json:"group_by"`
+	Columns     []string             `json:"columns"`
+	Chart       *entity.ReportChart  `json:"chart,omitempty"`
+}
+
+// ReportScheduleInput is the payload for creating or replacing a report schedule
+type ReportScheduleInput struct {
+	Name       string                `json:"name"`
+	TemplateID string                `json:"template_id"`
+	Frequency  string                `json:"frequency"`
+	Format     string                `json:"format"`
+	Targets    []entity.ReportTarget `json:"targets"`
+	IsActive   *bool                 `json:"is_active,omitempty"` // Defaults to true
+}
+
+// ReportFieldCatalogue lists what a template may use, for report builder front ends
+type ReportFieldCatalogue struct {
+	Columns     []string `json:"columns"`
+	GroupFields []string `json:"group_fields"`
+	ChartTypes  []string `json:"chart_types"`
+	Formats     []string `json:"formats"`
+	Frequencies []string `json:"frequencies"`
+}
+
+// Fields returns the report field catalogue
+func (s *ReportService) Fields() *ReportFieldCatalogue {
+	return &ReportFieldCatalogue{
+		Columns:     entity.ReportColumns,
+		GroupFields: entity.ReportGroupFields,
+		ChartTypes:  []string{"bar", "pie", "line"},
+		Formats:     []string{entity.ReportFormatCSV, entity.ReportFormatJSON, entity.ReportFormatPDF},
+		Frequencies: []string{entity.ReportDaily, entity.ReportWeekly, entity.ReportMonthly},
+	}
+}
+
+// CreateTemplate validates and stores a new report template
+func (s *ReportService) CreateTemplate(ctx context.Context, input ReportTemplateInput, createdBy string) (*entity.ReportTemplate, error) {
+	tmpl := &entity.ReportTemplate{ID: uuid.New(), CreatedBy: createdBy}
+	if err := applyTemplateInput(tmpl, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateReportTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "REPORT_TEMPLATE_CREATED", "report_template", tmpl.ID, map[string]interface{}{
+		"name":     tmpl.Name,
+		"group_by": tmpl.GroupBy,
+	})
+	return tmpl, nil
+}
+
+// GetTemplate returns a report template
+func (s *ReportService) GetTemplate(ctx context.Context, id uuid.UUID) (*entity.ReportTemplate, error) {
+	return s.repo.GetReportTemplate(ctx, id)
+}
+
+// ListTemplates returns the tenant's report templates
+func (s *ReportService) ListTemplates(ctx context.Context) ([]*entity.ReportTemplate, error) {
+	templates, err := s.repo.ListReportTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if templates == nil {
+		templates = []*entity.ReportTemplate{}
+	}
+	return templates, nil
+}
+
+// UpdateTemplate replaces a report template's definition. Its schedules pick up
+// the change on their next run.
+func (s *ReportService) UpdateTemplate(ctx context.Context, id uuid.UUID, input ReportTemplateInput) (*entity.ReportTemplate, error) {
+	tmpl := &entity.ReportTemplate{ID: id}
+	if err := applyTemplateInput(tmpl, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReportTemplate(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "REPORT_TEMPLATE_UPDATED", "report_template", tmpl.ID, map[string]interface{}{
+		"name":     tmpl.Name,
+		"group_by": tmpl.GroupBy,
+	})
+	return tmpl, nil
+}
+
+// DeleteTemplate removes a report template together with its schedules
+func (s *ReportService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteReportTemplate(ctx, id); err != nil {
+		return err
+	}
+	s.audit(ctx, "REPORT_TEMPLATE_DELETED", "report_template", id, nil)
+	return nil
+}
+
+// GenerateReport evaluates a stored template
+func (s *ReportService) GenerateReport(ctx context.Context, id uuid.UUID) (*ReportResult, error) {
+	tmpl, err := s.repo.GetReportTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, tmpl)
+}
+
+// PreviewReport evaluates an unsaved template so a report can be tried out while it is built
+func (s *ReportService) PreviewReport(ctx context.Context, input ReportTemplateInput) (*ReportResult, error) {
+	tmpl := &entity.ReportTemplate{}
+	if strings.TrimSpace(input.Name) == "" {
+		input.Name = "Preview"
+	}
+	if err := applyTemplateInput(tmpl, input); err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, tmpl)
+}
+
+// RenderReport evaluates a stored template and encodes it as csv, json or pdf
+func (s *ReportService) RenderReport(ctx context.Context, id uuid.UUID, format string) (*RenderedReport, error) {
+	if !validFormats[format] {
+		return nil, fmt.Errorf("invalid format %q: must be csv, json or pdf", format)
+	}
+	result, err := s.GenerateReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "REPORT_RENDERED", "report_template", id, map[string]interface{}{
+		"format":    format,
+		"row_count": result.RowCount,
+	})
+	return renderReport(result, format)
+}
+
+func (s *ReportService) generate(ctx context.Context, tmpl *entity.ReportTemplate) (*ReportResult, error) {
+	columns, rows, truncated, err := s.repo.QueryReportRows(ctx, tmpl, s.maxRows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportResult{
+		TemplateID:  tmpl.ID,
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		GeneratedAt: time.Now().UTC(),
+		Filters:     tmpl.Filters,
+		GroupBy:     tmpl.GroupBy,
+		Columns:     columns,
+		Rows:        rows,
+		RowCount:    len(rows),
+		Truncated:   truncated,
+		Chart:       buildChart(tmpl.Chart, columns, rows),
+	}, nil
+}
+
+// CreateSchedule validates and stores a new report schedule
+func (s *ReportService) CreateSchedule(ctx context.Context, input ReportScheduleInput, createdBy string) (*entity.ReportSchedule, error) {
+	schedule := &entity.ReportSchedule{ID: uuid.New(), IsActive: true, CreatedBy: createdBy}
+	if err := s.applyScheduleInput(schedule, input, nil); err != nil {
+		return nil, err
+	}
+	schedule.NextRunAt = nextReportRun(schedule.Frequency, time.Now(), s.deliveryHour)
+
+	if err := s.repo.CreateReportSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "REPORT_SCHEDULE_CREATED", "report_schedule", schedule.ID, scheduleAuditMetadata(schedule))
+	return schedule, nil
+}
+
+// GetSchedule returns a report schedule
+func (s *ReportService) GetSchedule(ctx context.Context, id uuid.UUID) (*entity.ReportSchedule, error) {
+	return s.repo.GetReportSchedule(ctx, id)
+}
+
+// ListSchedules returns the tenant's report schedules
+func (s *ReportService) ListSchedules(ctx context.Context) ([]*entity.ReportSchedule, error) {
+	schedules, err := s.repo.ListReportSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schedules == nil {
+		schedules = []*entity.ReportSchedule{}
+	}
+	return schedules, nil
+}
+
+// UpdateSchedule replaces a report schedule and reschedules its next run. A webhook
+// target given without a secret keeps the secret it had for the same URL.
+func (s *ReportService) UpdateSchedule(ctx context.Context, id uuid.UUID, input ReportScheduleInput) (*entity.ReportSchedule, error) {
+	existing, err := s.repo.GetReportSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &entity.ReportSchedule{ID: id, IsActive: true}
+	if err := s.applyScheduleInput(schedule, input, existing.Targets); err != nil {
+		return nil, err
+	}
+	if schedule.TemplateID != existing.TemplateID {
+		return nil, fmt.Errorf("template_id must not change; create a new schedule instead")
+	}
+	schedule.NextRunAt = nextReportRun(schedule.Frequency, time.Now(), s.deliveryHour)
+
+	if err := s.repo.UpdateReportSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "REPORT_SCHEDULE_UPDATED", "report_schedule", schedule.ID, scheduleAuditMetadata(schedule))
+	return schedule, nil
+}
+
+// DeleteSchedule removes a report schedule; its run history is kept
+func (s *ReportService) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteReportSchedule(ctx, id); err != nil {
+		return err
+	}
+	s.audit(ctx, "REPORT_SCHEDULE_DELETED", "report_schedule", id, nil)
+	return nil
+}
+
+// RunSchedule generates and delivers a schedule now without moving its next run
+func (s *ReportService) RunSchedule(ctx context.Context, id uuid.UUID) (*entity.ReportRun, error) {
+	schedule, err := s.repo.GetReportSchedule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	run := s.runSchedule(ctx, schedule, entity.ReportTriggerManual)
+
+	s.audit(ctx, "REPORT_SCHEDULE_RUN", "report_schedule", id, map[string]interface{}{
+		"run_id": run.ID,
+		"status": run.Status,
+	})
+	return run, nil
+}
+
+// ListRuns returns the tenant's recent report runs, optionally for one schedule
+func (s *ReportService) ListRuns(ctx context.Context, scheduleID *uuid.UUID, limit, offset int) ([]*entity.ReportRun, error) {
+	if limit < 1 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	runs, err := s.repo.ListReportRuns(ctx, scheduleID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if runs == nil {
+		runs = []*entity.ReportRun{}
+	}
+	return runs, nil
+}
+
+// StartWorker periodically runs the due report schedules of every tenant
+func (s *ReportService) StartWorker(ctx context.Context, intervalSeconds int) {
+	if intervalSeconds < 1 {
+		intervalSeconds = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Printf("📑 Starting report scheduler (interval: %ds, email: %t)", intervalSeconds, s.mailer != nil)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Report scheduler stopped")
+			return
+		case <-ticker.C:
+			s.RunDueSchedules(ctx)
+		}
+	}
+}
+
+// RunDueSchedules claims and runs every schedule whose next run has passed. The run
+// is claimed first so replicas never deliver it twice; a failed run is not retried.
+func (s *ReportService) RunDueSchedules(ctx context.Context) {
+	now := time.Now().UTC()
+	schedules, err := s.repo.ListDueReportSchedules(ctx, now)
+	if err != nil {
+		log.Printf("❌ Error listing due report schedules: %v", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		tenantCtx := context.WithValue(ctx, "tenant_id", schedule.TenantID)
+		next := nextReportRun(schedule.Frequency, now, s.deliveryHour)
+		claimed, err := s.repo.ClaimReportSchedule(tenantCtx, schedule.ID, schedule.NextRunAt, next)
+		if err != nil {
+			log.Printf("❌ Report schedule %s (%s) failed: %v", schedule.ID, schedule.Name, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		run := s.runSchedule(tenantCtx, schedule, entity.ReportTriggerSchedule)
+		if run.Status == entity.ReportRunFailed || run.Status == entity.ReportRunPartial {
+			log.Printf("❌ Report schedule %s (%s) %s: %s", schedule.ID, schedule.Name, run.Status, run.Error)
+		}
+	}
+}
+
+// runSchedule renders the schedule's template, delivers it to every target and
+// records the outcome
+func (s *ReportService) runSchedule(ctx context.Context, schedule *entity.ReportSchedule, trigger string) *entity.ReportRun {
+	run := &entity.ReportRun{
+		ID:         uuid.New(),
+		TenantID:   schedule.TenantID,
+		ScheduleID: &schedule.ID,
+		TemplateID: &schedule.TemplateID,
+		Trigger:    trigger,
+		Format:     schedule.Format,
+		Deliveries: []entity.ReportDelivery{},
+	}
+
+	result, err := s.GenerateReport(ctx, schedule.TemplateID)
+	var report *RenderedReport
+	if err == nil {
+		run.RowCount = result.RowCount
+		report, err = renderReport(result, schedule.Format)
+	}
+
+	if err != nil {
+		run.Status = entity.ReportRunFailed
+		run.Error = err.Error()
+	} else {
+		for _, target := range schedule.Targets {
+			run.Deliveries = append(run.Deliveries, s.deliver(ctx, schedule, run, result, report, target))
+		}
+		run.Status, run.Error = runOutcome(run.Deliveries)
+	}
+
+	if err := s.repo.RecordReportRun(ctx, run); err != nil {
+		log.Printf("WARNING: %v", err)
+	}
+	return run
+}
+
+// runOutcome summarizes the deliveries of a run. Skipped targets count as
+// neither success nor failure.
+func runOutcome(deliveries []entity.ReportDelivery) (string, string) {
+	var delivered, failed int
+	var errs []string
+	for _, d := range deliveries {
+		switch d.Status {
+		case entity.ReportRunDelivered:
+			delivered++
+		case entity.ReportRunFailed:
+			failed++
+			errs = append(errs, d.Type+" "+d.Destination+": "+d.Error)
+		}
+	}
+
+	switch {
+	case failed > 0 && delivered > 0:
+		return entity.ReportRunPartial, strings.Join(errs, "; ")
+	case failed > 0:
+		return entity.ReportRunFailed, strings.Join(errs, "; ")
+	case delivered > 0:
+		return entity.ReportRunDelivered, ""
+	default:
+		return entity.ReportRunSkipped, ""
+	}
+}
+
+func (s *ReportService) audit(ctx context.Context, action, resourceType string, id uuid.UUID, metadata map[string]interface{}) {
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, resourceType, id.String(), metadata)
+	}
+}
+
+func scheduleAuditMetadata(schedule *entity.ReportSchedule) map[string]interface{} {
+	targets := make([]string, len(schedule.Targets))
+	for i, t := range schedule.Targets {
+		targets[i] = t.Type
+	}
+	return map[string]interface{}{
+		"name":        schedule.Name,
+		"template_id": schedule.TemplateID,
+		"frequency":   schedule.Frequency,
+		"format":      schedule.Format,
+		"targets":     targets,
+		"is_active":   schedule.IsActive,
+	}
+}
+
+// applyTemplateInput validates input onto tmpl. A template either groups findings
+// and counts them, or lists findings with the chosen columns.
+func applyTemplateInput(tmpl *entity.ReportTemplate, input ReportTemplateInput) error {
+	tmpl.Name = strings.TrimSpace(input.Name)
+	if tmpl.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+	tmpl.Description = strings.TrimSpace(input.Description)
+
+	f := input.Filters
+	if f.SinceDays < 0 || f.SinceDays > maxReportSinceDays {
+		return fmt.Errorf("since_days must be between 0 and %d", maxReportSinceDays)
+	}
+	tmpl.Filters = entity.ReportFilters{
+		Severities:          normalizeValues(f.Severities),
+		ClassificationTypes: normalizeValues(f.ClassificationTypes),
+		Environments:        normalizeValues(f.Environments),
+		PatternNames:        normalizeValues(f.PatternNames),
+		DataSources:         normalizeValues(f.DataSources),
+		ReviewStatuses:      normalizeValues(f.ReviewStatuses),
+		SinceDays:           f.SinceDays,
+	}
+	for _, sev := range tmpl.Filters.Severities {
+		if !validSeverities[strings.ToLower(sev)] {
+			return fmt.Errorf("invalid severity %q: must be Critical, High, Medium or Low", sev)
+		}
+	}
+
+	groupBy, err := normalizeFields(input.GroupBy, entity.ReportGroupFields, "group_by")
+	if err != nil {
+		return err
+	}
+	if len(groupBy) > maxReportGroupings {
+		return fmt.Errorf("group_by must not have more than %d fields", maxReportGroupings)
+	}
+	columns, err := normalizeFields(input.Columns, entity.ReportColumns, "column")
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(groupBy) > 0 && len(columns) > 0:
+		return fmt.Errorf("columns must not be set together with group_by; grouped reports show the group fields and their finding count")
+	case len(groupBy) == 0 && len(columns) == 0:
+		return fmt.Errorf("columns or group_by must be set")
+	}
+	tmpl.GroupBy = groupBy
+	tmpl.Columns = columns
+
+	tmpl.Chart = nil
+	if input.Chart != nil {
+		chart := &entity.ReportChart{
+			Type:  strings.ToLower(strings.TrimSpace(input.Chart.Type)),
+			Field: strings.ToLower(strings.TrimSpace(input.Chart.Field)),
+		}
+		if !validChartTypes[chart.Type] {
+			return fmt.Errorf("invalid chart type %q: must be bar, pie or line", input.Chart.Type)
+		}
+		if !slices.Contains(groupBy, chart.Field) {
+			return fmt.Errorf("invalid chart field %q: must be one of the group_by fields", input.Chart.Field)
+		}
+		tmpl.Chart = chart
+	}
+	return nil
+}
+
+// applyScheduleInput validates input onto schedule and encrypts new webhook
+// secrets. previous holds the targets being replaced on update.
+func (s *ReportService) applyScheduleInput(schedule *entity.ReportSchedule, input ReportScheduleInput, previous []entity.ReportTarget) error {
+	schedule.Name = strings.TrimSpace(input.Name)
+	if schedule.Name == "" {
+		return fmt.Errorf("name must not be empty")
+	}
+
+	templateID, err := uuid.Parse(strings.TrimSpace(input.TemplateID))
+	if err != nil {
+		return fmt.Errorf("invalid template_id %q", input.TemplateID)
+	}
+	schedule.TemplateID = templateID
+
+	schedule.Frequency = strings.ToLower(strings.TrimSpace(input.Frequency))
+	if !validFrequencies[schedule.Frequency] {
+		return fmt.Errorf("invalid frequency %q: must be daily, weekly or monthly", input.Frequency)
+	}
+	schedule.Format = strings.ToLower(strings.TrimSpace(input.Format))
+	if !validFormats[schedule.Format] {
+		return fmt.Errorf("invalid format %q: must be csv, json or pdf", input.Format)
+	}
+
+	if len(input.Targets) == 0 {
+		return fmt.Errorf("targets must not be empty")
+	}
+	if len(input.Targets) > maxReportTargets {
+		return fmt.Errorf("targets must not have more than %d entries", maxReportTargets)
+	}
+	schedule.Targets = make([]entity.ReportTarget, 0, len(input.Targets))
+	for _, t := range input.Targets {
+		target, err := s.normalizeTarget(t, previous)
+		if err != nil {
+			return err
+		}
+		schedule.Targets = append(schedule.Targets, target)
+	}
+
+	if input.IsActive != nil {
+		schedule.IsActive = *input.IsActive
+	}
+	return nil
+}
+
+func (s *ReportService) normalizeTarget(t entity.ReportTarget, previous []entity.ReportTarget) (entity.ReportTarget, error) {
+	switch strings.ToLower(strings.TrimSpace(t.Type)) {
+	case entity.ReportTargetEmail:
+		recipients, err := normalizeRecipients(t.Recipients)
+		if err != nil {
+			return entity.ReportTarget{}, err
+		}
+		return entity.ReportTarget{Type: entity.ReportTargetEmail, Recipients: recipients}, nil
+
+	case entity.ReportTargetWebhook:
+		u, err := url.Parse(strings.TrimSpace(t.URL))
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return entity.ReportTarget{}, fmt.Errorf("invalid webhook url %q: must be an absolute http(s) URL", t.URL)
+		}
+		target := entity.ReportTarget{Type: entity.ReportTargetWebhook, URL: u.String()}
+
+		switch {
+		case t.Secret != "":
+			if s.encryption == nil {
+				return entity.ReportTarget{}, ErrReportSecretUnavailable
+			}
+			target.SecretEncrypted, err = s.encryption.Encrypt(t.Secret)
+			if err != nil {
+				return entity.ReportTarget{}, fmt.Errorf("failed to encrypt webhook secret: %w", err)
+			}
+		default:
+			for _, p := range previous {
+				if p.Type == entity.ReportTargetWebhook && p.URL == target.URL {
+					target.SecretEncrypted = p.SecretEncrypted
+					break
+				}
+			}
+		}
+		target.HasSecret = len(target.SecretEncrypted) > 0
+		return target, nil
+
+	default:
+		return entity.ReportTarget{}, fmt.Errorf("invalid target type %q: must be email or webhook", t.Type)
+	}
+}
+
+// nextReportRun returns the first scheduled report time after the given time: at
+// hour UTC every day, on Mondays, or on the first of the month
+func nextReportRun(frequency string, after time.Time, hour int) time.Time {
+	after = after.UTC()
+	run := time.Date(after.Year(), after.Month(), after.Day(), hour, 0, 0, 0, time.UTC)
+
+	switch frequency {
+	case entity.ReportMonthly:
+		run = time.Date(after.Year(), after.Month(), 1, hour, 0, 0, 0, time.UTC)
+		for !run.After(after) {
+			run = run.AddDate(0, 1, 0)
+		}
+		return run
+	case entity.ReportWeekly:
+		daysSinceMonday := (int(run.Weekday()) + 6) % 7
+		run = run.AddDate(0, 0, -daysSinceMonday)
+		for !run.After(after) {
+			run = run.AddDate(0, 0, 7)
+		}
+		return run
+	default:
+		for !run.After(after) {
+			run = run.AddDate(0, 0, 1)
+		}
+		return run
+	}
+}
+
+// normalizeFields lowercases and de-duplicates field names and checks them against allowed
+func normalizeFields(fields, allowed []string, kind string) ([]string, error) {
+	result := []string{}
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if !slices.Contains(allowed, f) {
+			return nil, fmt.Errorf("invalid %s %q: must be one of %s", kind, f, strings.Join(allowed, ", "))
+		}
+		if !slices.Contains(result, f) {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+// normalizeValues trims filter values and drops empty ones
+func normalizeValues(values []string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// normalizeRecipients validates email addresses and drops duplicates
+func normalizeRecipients(recipients []string) ([]string, error) {
+	seen := map[string]bool{}
+	var result []string
+	for _, r := range recipients {
+		addr, err := mail.ParseAddress(strings.TrimSpace(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q", r)
+		}
+		key := strings.ToLower(addr.Address)
+		if !seen[key] {
+			seen[key] = true
+			result = append(result, addr.Address)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("recipients must not be empty")
+	}
+	return result, nil
+}
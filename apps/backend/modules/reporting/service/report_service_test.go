@@ -0,0 +1,241 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestNextReportRun(t *testing.T) {
+	// 2026-03-04 is a Wednesday
+	wed := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		frequency string
+		after     time.Time
+		want      time.Time
+	}{
+		{"daily before the hour", entity.ReportDaily, time.Date(2026, 3, 4, 6, 0, 0, 0, time.UTC), time.Date(2026, 3, 4, 7, 0, 0, 0, time.UTC)},
+		{"daily after the hour", entity.ReportDaily, wed, time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC)},
+		{"weekly mid-week", entity.ReportWeekly, wed, time.Date(2026, 3, 9, 7, 0, 0, 0, time.UTC)},
+		{"monthly mid-month", entity.ReportMonthly, wed, time.Date(2026, 4, 1, 7, 0, 0, 0, time.UTC)},
+		{"monthly on the first before the hour", entity.ReportMonthly, time.Date(2026, 3, 1, 5, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 7, 0, 0, 0, time.UTC)},
+		{"monthly across the year end", entity.ReportMonthly, time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 7, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := nextReportRun(tt.frequency, tt.after, 7); !got.Equal(tt.want) {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestApplyTemplateInput(t *testing.T) {
+	tmpl := &entity.ReportTemplate{}
+	err := applyTemplateInput(tmpl, ReportTemplateInput{
+		Name:    " Critical by source ",
+		Filters: entity.ReportFilters{Severities: []string{"Critical", " "}, SinceDays: 30},
+		GroupBy: []string{"Data_Source", "severity", "data_source"},
+		Chart:   &entity.ReportChart{Type: "BAR", Field: "data_source"},
+	})
+	if err != nil {
+		t.Fatalf("expected a valid template, got %v", err)
+	}
+	if tmpl.Name != "Critical by source" {
+		t.Errorf("expected a trimmed name, got %q", tmpl.Name)
+	}
+	if strings.Join(tmpl.GroupBy, ",") != "data_source,severity" {
+		t.Errorf("expected normalized, de-duplicated groupings, got %v", tmpl.GroupBy)
+	}
+	if len(tmpl.Filters.Severities) != 1 {
+		t.Errorf("expected blank filter values to be dropped, got %v", tmpl.Filters.Severities)
+	}
+	if tmpl.Chart == nil || tmpl.Chart.Type != "bar" {
+		t.Errorf("expected a normalized bar chart, got %+v", tmpl.Chart)
+	}
+
+	invalid := []struct {
+		name  string
+		input ReportTemplateInput
+	}{
+		{"no name", ReportTemplateInput{Columns: []string{"severity"}}},
+		{"no columns or groupings", ReportTemplateInput{Name: "r"}},
+		{"columns with groupings", ReportTemplateInput{Name: "r", Columns: []string{"severity"}, GroupBy: []string{"severity"}}},
+		{"unknown column", ReportTemplateInput{Name: "r", Columns: []string{"sample_text"}}},
+		{"column that cannot be grouped", ReportTemplateInput{Name: "r", GroupBy: []string{"finding_id"}}},
+		{"unknown severity", ReportTemplateInput{Name: "r", Columns: []string{"severity"}, Filters: entity.ReportFilters{Severities: []string{"urgent"}}}},
+		{"negative window", ReportTemplateInput{Name: "r", Columns: []string{"severity"}, Filters: entity.ReportFilters{SinceDays: -1}}},
+		{"chart without grouping", ReportTemplateInput{Name: "r", Columns: []string{"severity"}, Chart: &entity.ReportChart{Type: "bar", Field: "severity"}}},
+		{"chart on another field", ReportTemplateInput{Name: "r", GroupBy: []string{"severity"}, Chart: &entity.ReportChart{Type: "pie", Field: "environment"}}},
+		{"unknown chart type", ReportTemplateInput{Name: "r", GroupBy: []string{"severity"}, Chart: &entity.ReportChart{Type: "radar", Field: "severity"}}},
+	}
+	for _, tt := range invalid {
+		if err := applyTemplateInput(&entity.ReportTemplate{}, tt.input); err == nil {
+			t.Errorf("%s: expected a validation error", tt.name)
+		}
+	}
+}
+
+func TestApplyScheduleInputTargets(t *testing.T) {
+	s := &ReportService{}
+	input := ReportScheduleInput{
+		Name:       "Weekly",
+		TemplateID: "6f1c1c8e-8b8f-4c55-9d1f-0d6b9c7f2a10",
+		Frequency:  "Weekly",
+		Format:     "PDF",
+		Targets: []entity.ReportTarget{
+			{Type: "email", Recipients: []string{"DPO <dpo@example.com>", "dpo@example.com"}},
+			{Type: "webhook", URL: "https://hooks.example.com/arc"},
+		},
+	}
+
+	previous := []entity.ReportTarget{{Type: entity.ReportTargetWebhook, URL: "https://hooks.example.com/arc", SecretEncrypted: []byte("sealed")}}
+	schedule := &entity.ReportSchedule{}
+	if err := s.applyScheduleInput(schedule, input, previous); err != nil {
+		t.Fatalf("expected a valid schedule, got %v", err)
+	}
+	if schedule.Frequency != entity.ReportWeekly || schedule.Format != entity.ReportFormatPDF {
+		t.Errorf("expected normalized frequency and format, got %q and %q", schedule.Frequency, schedule.Format)
+	}
+	if got := schedule.Targets[0].Recipients; len(got) != 1 || got[0] != "dpo@example.com" {
+		t.Errorf("expected de-duplicated recipients, got %v", got)
+	}
+	if !schedule.Targets[1].HasSecret || string(schedule.Targets[1].SecretEncrypted) != "sealed" {
+		t.Error("expected a webhook updated without a secret to keep its previous secret")
+	}
+
+	// A new secret cannot be stored without an encryption key
+	input.Targets = []entity.ReportTarget{{Type: "webhook", URL: "https://hooks.example.com/arc", Secret: "s3cret"}}
+	if err := s.applyScheduleInput(&entity.ReportSchedule{}, input, nil); err != ErrReportSecretUnavailable {
+		t.Errorf("expected ErrReportSecretUnavailable, got %v", err)
+	}
+
+	for _, target := range []entity.ReportTarget{
+		{Type: "webhook", URL: "ftp://hooks.example.com"},
+		{Type: "webhook", URL: "/relative"},
+		{Type: "email"},
+		{Type: "slack"},
+	} {
+		input.Targets = []entity.ReportTarget{target}
+		if err := s.applyScheduleInput(&entity.ReportSchedule{}, input, nil); err == nil {
+			t.Errorf("expected target %+v to be rejected", target)
+		}
+	}
+}
+
+func TestBuildChart(t *testing.T) {
+	columns := []string{"data_source", "severity", "finding_count"}
+	rows := [][]string{
+		{"postgres", "Critical", "5"},
+		{"s3", "High", "9"},
+		{"postgres", "High", "7"},
+	}
+
+	chart := buildChart(&entity.ReportChart{Type: "bar", Field: "data_source"}, columns, rows)
+	if strings.Join(chart.Labels, ",") != "postgres,s3" || fmt.Sprint(chart.Values) != "[12 9]" {
+		t.Errorf("expected counts summed per source, largest first, got %v %v", chart.Labels, chart.Values)
+	}
+
+	chart = buildChart(&entity.ReportChart{Type: "line", Field: "severity"}, columns, rows)
+	if strings.Join(chart.Labels, ",") != "Critical,High" || fmt.Sprint(chart.Values) != "[5 16]" {
+		t.Errorf("expected line series ordered by label, got %v %v", chart.Labels, chart.Values)
+	}
+
+	if buildChart(nil, columns, rows) != nil {
+		t.Error("expected no chart without a chart spec")
+	}
+}
+
+func TestRenderCSVNeutralizesFormulas(t *testing.T) {
+	result := &ReportResult{
+		Columns: []string{"asset_name", "finding_count"},
+		Rows:    [][]string{{"=HYPERLINK(\"http://x\")", "3"}, {"customers", "1"}},
+	}
+	got := string(renderCSV(result))
+	want := "asset_name,finding_count\n\"'=HYPERLINK(\"\"http://x\"\")\",3\ncustomers,1\n"
+	if got != want {
+		t.Errorf("expected formula cells to be prefixed\nwant %q\ngot  %q", want, got)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	result := &ReportResult{
+		Name:        "Findings (all) by source",
+		GeneratedAt: time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC),
+		Columns:     []string{"data_source", "finding_count"},
+		Chart:       &ReportChartData{Type: "pie", Field: "data_source", Labels: []string{"postgres"}, Values: []int64{3}},
+	}
+	for i := 0; i < 120; i++ {
+		result.Rows = append(result.Rows, []string{fmt.Sprintf("source-%d ✓", i), "3"})
+	}
+	result.RowCount = len(result.Rows)
+
+	pdf := renderPDF(result)
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("expected a PDF header and trailer")
+	}
+	if !bytes.Contains(pdf, []byte(`(Findings \(all\) by source)`)) {
+		t.Error("expected parentheses in text to be escaped")
+	}
+	if !bytes.Contains(pdf, []byte("(source-0 ?)")) {
+		t.Error("expected characters outside WinAnsi to be replaced")
+	}
+
+	count := regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`).FindSubmatch(pdf)
+	if count == nil {
+		t.Fatal("expected a page tree")
+	}
+	if pages, _ := strconv.Atoi(string(count[1])); pages < 2 {
+		t.Errorf("expected 120 rows to span several pages, got %d", pages)
+	}
+
+	// Every xref entry must point at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[offset:], -1)
+	for i, entry := range entries {
+		at, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(pdf[at:], []byte(want)) {
+			t.Errorf("expected xref entry %d to point at %q", i+1, want)
+		}
+	}
+}
+
+func TestRunOutcome(t *testing.T) {
+	delivered := entity.ReportDelivery{Type: "email", Status: entity.ReportRunDelivered}
+	failed := entity.ReportDelivery{Type: "webhook", Destination: "hooks.example.com", Status: entity.ReportRunFailed, Error: "webhook returned 500"}
+	skipped := entity.ReportDelivery{Type: "email", Status: entity.ReportRunSkipped}
+
+	tests := []struct {
+		deliveries []entity.ReportDelivery
+		want       string
+	}{
+		{[]entity.ReportDelivery{delivered, skipped}, entity.ReportRunDelivered},
+		{[]entity.ReportDelivery{delivered, failed}, entity.ReportRunPartial},
+		{[]entity.ReportDelivery{failed, skipped}, entity.ReportRunFailed},
+		{[]entity.ReportDelivery{skipped}, entity.ReportRunSkipped},
+	}
+	for _, tt := range tests {
+		if got, _ := runOutcome(tt.deliveries); got != tt.want {
+			t.Errorf("expected %s for %+v, got %s", tt.want, tt.deliveries, got)
+		}
+	}
+
+	if _, msg := runOutcome([]entity.ReportDelivery{failed}); msg != "webhook hooks.example.com: webhook returned 500" {
+		t.Errorf("expected the failure to be described, got %q", msg)
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	want := "b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163"
+	if got := signWebhook("secret", "1700000000", []byte("{}")); got != want {
+		t.Errorf("expected HMAC-SHA256 of \"<timestamp>.<body>\", got %s", got)
+	}
+}
@@ -113,11 +113,11 @@ func (a *ScanActivities) CloseExposureWindow(ctx context.Context, findingID stri
 	session := a.neo4j.NewSession(ctx, neo4j.SessionConfig{})
 	defer session.Close(ctx)
 
-	// Update EXPOSES edge to set 'until' timestamp
+	// Update EXPOSES edge to set 'valid_to' timestamp
 	_, err := session.Run(ctx, `
 		MATCH (a:Asset)-[e:EXPOSES]->(p:PII_Category)
-		WHERE e.finding_id = $findingID AND e.until IS NULL
-		SET e.until = $closedAt
+		WHERE e.finding_id = $findingID AND e.valid_to IS NULL
+		SET e.valid_to = $closedAt
 	`, map[string]interface{}{
 		"findingID": findingID,
 		"closedAt":  closedAt,
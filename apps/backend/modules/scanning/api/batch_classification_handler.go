@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// BatchClassificationHandler exposes the classification engine to other systems
+type BatchClassificationHandler struct {
+	service *service.BatchClassificationService
+}
+
+// NewBatchClassificationHandler creates a new batch classification handler
+func NewBatchClassificationHandler(service *service.BatchClassificationService) *BatchClassificationHandler {
+	return &BatchClassificationHandler{service: service}
+}
+
+// ClassifyBatchRequest is the body of POST /api/v1/classify
+type ClassifyBatchRequest struct {
+	Items []service.ClassifyItem `json:"items" binding:"required"`
+}
+
+// ClassifyBatch handles POST /api/v1/classify. It returns a MultiSignalDecision
+// per item without storing findings or values.
+func (h *BatchClassificationHandler) ClassifyBatch(c *gin.Context) {
+	var req ClassifyBatchRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	results, err := h.service.Classify(sharedapi.RequestContext(c), req.Items)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": results, "total": len(results)})
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ClassificationExplanationHandler serves auditor-facing classification explanations
+type ClassificationExplanationHandler struct {
+	service *service.ClassificationExplanationService
+}
+
+// NewClassificationExplanationHandler creates a new explanation handler
+func NewClassificationExplanationHandler(service *service.ClassificationExplanationService) *ClassificationExplanationHandler {
+	return &ClassificationExplanationHandler{service: service}
+}
+
+// GetExplanation handles GET /api/v1/findings/:id/explanation
+// Use ?format=text for the plain-text rendering only.
+func (h *ClassificationExplanationHandler) GetExplanation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		api.BadRequest(c, "Invalid finding ID")
+		return
+	}
+
+	explanation, err := h.service.Explain(api.RequestContext(c), id)
+	if err != nil {
+		if err.Error() == "finding not found" {
+			api.NotFound(c, "Finding not found")
+			return
+		}
+		api.InternalServerError(c, "Failed to build classification explanation")
+		return
+	}
+	middleware.RecordAccessedFindings(c, id)
+
+	if c.Query("format") == "text" {
+		c.String(http.StatusOK, explanation.Text)
+		return
+	}
+
+	api.Success(c, explanation)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -48,8 +49,7 @@ type ClassificationRequest struct {
 // It returns the classification result for a given text without persisting it.
 func (h *ClassificationHandler) Predict(c *gin.Context) {
 	var req ClassificationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
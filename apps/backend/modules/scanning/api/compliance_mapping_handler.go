@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ComplianceMappingHandler handles the per-tenant mappings from classifications and
+// PII types to compliance framework categories
+type ComplianceMappingHandler struct {
+	service *service.ComplianceMappingService
+}
+
+// NewComplianceMappingHandler creates a new compliance mapping handler
+func NewComplianceMappingHandler(service *service.ComplianceMappingService) *ComplianceMappingHandler {
+	return &ComplianceMappingHandler{service: service}
+}
+
+// GetMapping handles GET /api/v1/classification/mappings/:framework
+func (h *ComplianceMappingHandler) GetMapping(c *gin.Context) {
+	mapping, err := h.service.GetCurrent(sharedapi.RequestContext(c), c.Param("framework"))
+	if err != nil {
+		c.JSON(statusForMappingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mapping})
+}
+
+// UpdateMapping handles PUT /api/v1/classification/mappings/:framework
+// Saves the payload as the tenant's next mapping version
+func (h *ComplianceMappingHandler) UpdateMapping(c *gin.Context) {
+	var input service.ComplianceMappingInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	mapping, err := h.service.Update(sharedapi.RequestContext(c), c.Param("framework"), input, mappingActor(c))
+	if err != nil {
+		c.JSON(statusForMappingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mapping})
+}
+
+// ListHistory handles GET /api/v1/classification/mappings/:framework/history
+func (h *ComplianceMappingHandler) ListHistory(c *gin.Context) {
+	mappings, err := h.service.ListHistory(sharedapi.RequestContext(c), c.Param("framework"))
+	if err != nil {
+		c.JSON(statusForMappingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mappings, "total": len(mappings)})
+}
+
+// GetVersion handles GET /api/v1/classification/mappings/:framework/versions/:version
+func (h *ComplianceMappingHandler) GetVersion(c *gin.Context) {
+	version, ok := parseMappingVersion(c)
+	if !ok {
+		return
+	}
+
+	mapping, err := h.service.GetVersion(sharedapi.RequestContext(c), c.Param("framework"), version)
+	if err != nil {
+		c.JSON(statusForMappingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mapping})
+}
+
+// RestoreVersion handles POST /api/v1/classification/mappings/:framework/versions/:version/restore
+// Version 0 restores the built-in default
+func (h *ComplianceMappingHandler) RestoreVersion(c *gin.Context) {
+	version, ok := parseMappingVersion(c)
+	if !ok {
+		return
+	}
+
+	mapping, err := h.service.RestoreVersion(sharedapi.RequestContext(c), c.Param("framework"), version, mappingActor(c))
+	if err != nil {
+		c.JSON(statusForMappingError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": mapping})
+}
+
+func mappingActor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func parseMappingVersion(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid mapping version"})
+		return 0, false
+	}
+	return version, true
+}
+
+func statusForMappingError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "concurrently"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
@@ -15,13 +17,15 @@ import (
 
 // DashboardHandler handles dashboard-specific endpoints
 type DashboardHandler struct {
-	pgRepo *persistence.PostgresRepository
+	pgRepo         *persistence.PostgresRepository
+	summaryService *service.DashboardSummaryService
 }
 
 // NewDashboardHandler creates a new dashboard handler
-func NewDashboardHandler(pgRepo *persistence.PostgresRepository) *DashboardHandler {
+func NewDashboardHandler(pgRepo *persistence.PostgresRepository, summaryService *service.DashboardSummaryService) *DashboardHandler {
 	return &DashboardHandler{
-		pgRepo: pgRepo,
+		pgRepo:         pgRepo,
+		summaryService: summaryService,
 	}
 }
 
@@ -131,3 +135,40 @@ func (h *DashboardHandler) GetDashboardMetrics(c *gin.Context) {
 		"data": metrics,
 	})
 }
+
+// GetDashboardSummary returns pre-aggregated finding counts with a staleness indicator
+// GET /api/v1/dashboard/summary
+func (h *DashboardHandler) GetDashboardSummary(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(sharedapi.RequestContext(c), 10*time.Second)
+	defer cancel()
+
+	summary, err := h.summaryService.GetSummary(ctx, c.Query("env"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to fetch dashboard summary",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": summary,
+	})
+}
+
+// RefreshDashboardSummary forces a synchronous refresh of the summary views
+// POST /api/v1/dashboard/summary/refresh
+func (h *DashboardHandler) RefreshDashboardSummary(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	if err := h.summaryService.RefreshNow(ctx); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to refresh dashboard summary",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "success"})
+}
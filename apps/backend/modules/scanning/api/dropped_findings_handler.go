@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultDroppedFindingsWindow is how far back the dropped findings summary looks without ?since
+const defaultDroppedFindingsWindow = 7 * 24 * time.Hour
+
+// DroppedFindingsHandler reports what the ingestion filter dropped
+type DroppedFindingsHandler struct {
+	service *service.DroppedFindingsService
+}
+
+// NewDroppedFindingsHandler creates a new dropped findings handler
+func NewDroppedFindingsHandler(service *service.DroppedFindingsService) *DroppedFindingsHandler {
+	return &DroppedFindingsHandler{service: service}
+}
+
+// GetScanDroppedFindings handles GET /api/v1/scans/:id/dropped-findings
+func (h *DroppedFindingsHandler) GetScanDroppedFindings(c *gin.Context) {
+	scanRunID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan ID"})
+		return
+	}
+
+	result, err := h.service.ForScanRun(sharedapi.RequestContext(c), scanRunID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get dropped findings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// GetDroppedFindingsSummary handles GET /api/v1/scans/dropped-findings
+// Query: since, until (RFC3339; default the last 7 days)
+func (h *DroppedFindingsHandler) GetDroppedFindingsSummary(c *gin.Context) {
+	until := time.Now()
+	since := until.Add(-defaultDroppedFindingsWindow)
+	for name, target := range map[string]*time.Time{"since": &since, "until": &until} {
+		if v := c.Query(name); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be RFC3339"})
+				return
+			}
+			*target = parsed
+		}
+	}
+	if !until.After(since) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "until must be after since"})
+		return
+	}
+
+	report, err := h.service.Summarize(sharedapi.RequestContext(c), since, until)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to summarize dropped findings",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
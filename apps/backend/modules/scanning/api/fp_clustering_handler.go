@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FPClusteringHandler handles false-positive clustering reports and rule suggestions
+type FPClusteringHandler struct {
+	service *service.FPClusteringService
+}
+
+// NewFPClusteringHandler creates a new false-positive clustering handler
+func NewFPClusteringHandler(service *service.FPClusteringService) *FPClusteringHandler {
+	return &FPClusteringHandler{service: service}
+}
+
+// reviewRequest is the optional body of an approve or reject call
+type reviewRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// Analyze handles POST /api/v1/admin/fp-suggestions/analyze
+func (h *FPClusteringHandler) Analyze(c *gin.Context) {
+	var opts service.FPClusteringOptions
+	if c.Request.ContentLength > 0 {
+		if !sharedapi.BindJSON(c, &opts) {
+			return
+		}
+	}
+
+	report, err := h.service.Analyze(sharedapi.RequestContext(c), opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to cluster false-positive feedback",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// ListSuggestions handles GET /api/v1/admin/fp-suggestions
+func (h *FPClusteringHandler) ListSuggestions(c *gin.Context) {
+	suggestions, err := h.service.ListSuggestions(sharedapi.RequestContext(c), c.Query("status"))
+	if err != nil {
+		c.JSON(statusForSuggestionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestions, "total": len(suggestions)})
+}
+
+// GetSuggestion handles GET /api/v1/admin/fp-suggestions/:id
+func (h *FPClusteringHandler) GetSuggestion(c *gin.Context) {
+	id, ok := parseSuggestionID(c)
+	if !ok {
+		return
+	}
+
+	suggestion, err := h.service.GetSuggestion(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForSuggestionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestion})
+}
+
+// ApproveSuggestion handles POST /api/v1/admin/fp-suggestions/:id/approve
+func (h *FPClusteringHandler) ApproveSuggestion(c *gin.Context) {
+	id, ok := parseSuggestionID(c)
+	if !ok {
+		return
+	}
+	var req reviewRequest
+	if c.Request.ContentLength > 0 {
+		if !sharedapi.BindJSON(c, &req) {
+			return
+		}
+	}
+
+	// Learned entries reference the approving user; the id is a UUID or its string form
+	var reviewerID uuid.UUID
+	if v, exists := c.Get("user_id"); exists {
+		reviewerID, _ = uuid.Parse(fmt.Sprint(v))
+	}
+
+	suggestion, err := h.service.Approve(sharedapi.RequestContext(c), id, reviewerName(c), reviewerID, req.Note)
+	if err != nil {
+		c.JSON(statusForSuggestionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestion})
+}
+
+// RejectSuggestion handles POST /api/v1/admin/fp-suggestions/:id/reject
+func (h *FPClusteringHandler) RejectSuggestion(c *gin.Context) {
+	id, ok := parseSuggestionID(c)
+	if !ok {
+		return
+	}
+	var req reviewRequest
+	if c.Request.ContentLength > 0 {
+		if !sharedapi.BindJSON(c, &req) {
+			return
+		}
+	}
+
+	suggestion, err := h.service.Reject(sharedapi.RequestContext(c), id, reviewerName(c), req.Note)
+	if err != nil {
+		c.JSON(statusForSuggestionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suggestion})
+}
+
+func reviewerName(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func parseSuggestionID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rule suggestion ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForSuggestionError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "already"):
+		return http.StatusConflict
+	default:
+		return statusForSuppressionError(err)
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/admission"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/scanperf"
+	"github.com/gin-gonic/gin"
+)
+
+// IngestionAdmissionHandler holds back ingestion requests while Postgres is slow
+type IngestionAdmissionHandler struct {
+	limiter *admission.Limiter // Nil when backpressure is disabled
+}
+
+func NewIngestionAdmissionHandler(limiter *admission.Limiter) *IngestionAdmissionHandler {
+	return &IngestionAdmissionHandler{limiter: limiter}
+}
+
+// Admit is route middleware that runs the ingestion once the limiter admits it.
+// Rejected requests get 503 with Retry-After; nothing from them is stored.
+func (h *IngestionAdmissionHandler) Admit(c *gin.Context) {
+	if h.limiter == nil {
+		c.Next()
+		return
+	}
+
+	queued := time.Now()
+	release, err := h.limiter.Acquire(c.Request.Context())
+	if err != nil {
+		var rejected *admission.RejectedError
+		if !errors.As(err, &rejected) {
+			// The client went away while queued
+			c.Abort()
+			return
+		}
+		retryAfter := int(rejected.RetryAfter.Seconds())
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error":               "Ingestion is temporarily throttled to protect the database; retry later",
+			"code":                "SERVICE_UNAVAILABLE",
+			"reason":              rejected.Reason,
+			"retry_after_seconds": retryAfter,
+		})
+		return
+	}
+	defer release()
+
+	// The wait is reported in the performance profile of the scan run ingested
+	c.Request = c.Request.WithContext(scanperf.WithQueueWait(c.Request.Context(), time.Since(queued)))
+	c.Next()
+}
+
+// GetStatus returns the ingestion limiter's current limit, queue and probe latency
+// GET /api/v1/scans/ingestion/admission
+func (h *IngestionAdmissionHandler) GetStatus(c *gin.Context) {
+	if h.limiter == nil {
+		c.JSON(http.StatusOK, gin.H{"data": gin.H{"enabled": false}})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"enabled": true, "limiter": h.limiter.Snapshot()}})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,11 +23,7 @@ func NewIngestionHandler(service *service.IngestionService) *IngestionHandler {
 func (h *IngestionHandler) IngestScan(c *gin.Context) {
 	var input service.HawkeyeScanInput
 
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &input) {
 		return
 	}
 
@@ -39,8 +36,11 @@ func (h *IngestionHandler) IngestScan(c *gin.Context) {
 	}
 
 	// Process ingestion
-	result, err := h.service.IngestScan(c.Request.Context(), &input)
+	result, err := h.service.IngestScan(sharedapi.RequestContext(c), &input)
 	if err != nil {
+		if writeQuotaError(c, err) || writeSchemaVersionError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to ingest scan",
 			"details": err.Error(),
@@ -88,20 +88,3 @@ func (h *IngestionHandler) GetLatestScan(c *gin.Context) {
 func (h *IngestionHandler) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct{}{})
 }
-
-// ClearScanData handles DELETE /api/v1/scans/clear
-// Clears all previous scan data for clean scan-replace workflow
-func (h *IngestionHandler) ClearScanData(c *gin.Context) {
-	err := h.service.ClearAllScanData(c.Request.Context())
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to clear scan data",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Previous scan data cleared successfully",
-	})
-}
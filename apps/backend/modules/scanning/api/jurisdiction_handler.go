@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// JurisdictionHandler handles the jurisdiction profiles that set a tenant's PII scope
+type JurisdictionHandler struct {
+	service *service.JurisdictionService
+}
+
+// NewJurisdictionHandler creates a new jurisdiction handler
+func NewJurisdictionHandler(service *service.JurisdictionService) *JurisdictionHandler {
+	return &JurisdictionHandler{service: service}
+}
+
+// ListProfiles handles GET /api/v1/classification/jurisdictions
+func (h *JurisdictionHandler) ListProfiles(c *gin.Context) {
+	profiles, err := h.service.ListProfiles(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list jurisdiction profiles", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profiles, "total": len(profiles)})
+}
+
+// GetProfile handles GET /api/v1/classification/jurisdictions/:code
+func (h *JurisdictionHandler) GetProfile(c *gin.Context) {
+	profile, err := h.service.GetProfile(sharedapi.RequestContext(c), c.Param("code"))
+	if err != nil {
+		c.JSON(statusForJurisdictionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profile})
+}
+
+// GetTenantProfile handles GET /api/v1/classification/jurisdiction
+func (h *JurisdictionHandler) GetTenantProfile(c *gin.Context) {
+	profile, err := h.service.GetTenantProfile(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(statusForJurisdictionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profile})
+}
+
+// SetTenantProfile handles PUT /api/v1/classification/jurisdiction
+// Selects the profile applied to the tenant's ingestion from the next scan on
+func (h *JurisdictionHandler) SetTenantProfile(c *gin.Context) {
+	var input struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	profile, err := h.service.SetTenantProfile(sharedapi.RequestContext(c), input.Code, mappingActor(c))
+	if err != nil {
+		c.JSON(statusForJurisdictionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": profile})
+}
+
+// Refresh handles POST /api/v1/classification/jurisdictions/refresh
+// Applies profiles edited in the database without waiting for the cache to expire
+func (h *JurisdictionHandler) Refresh(c *gin.Context) {
+	h.service.Refresh()
+	c.JSON(http.StatusOK, gin.H{"data": gin.H{"refreshed": true}})
+}
+
+func statusForJurisdictionError(err error) int {
+	if errors.Is(err, service.ErrJurisdictionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
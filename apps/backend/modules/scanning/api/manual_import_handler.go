@@ -0,0 +1,106 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxManualImportBytes bounds the uploaded CSV or XLSX file
+	maxManualImportBytes = 10 << 20
+	// manualImportFormOverhead allows for part headers around the file
+	manualImportFormOverhead = 64 << 10
+)
+
+// ManualImportHandler handles imports of findings tracked outside the scanners
+type ManualImportHandler struct {
+	service *service.IngestionService
+}
+
+// NewManualImportHandler creates a new manual import handler
+func NewManualImportHandler(service *service.IngestionService) *ManualImportHandler {
+	return &ManualImportHandler{service: service}
+}
+
+// ImportFindings handles POST /api/v1/findings/import?dry_run=true
+// The multipart "file" part is a CSV or XLSX sheet with asset_path, pii_type,
+// severity and note columns. A dry run validates and previews without storing.
+func (h *ManualImportHandler) ImportFindings(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "dry_run must be true or false"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxManualImportBytes+manualImportFormOverhead)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, gin.H{
+			"error":   "A multipart \"file\" part of at most 10MB is required",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	body, err := io.ReadAll(io.LimitReader(file, maxManualImportBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read import file", "details": err.Error()})
+		return
+	}
+	if len(body) > maxManualImportBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Import file must be at most 10MB"})
+		return
+	}
+
+	rows, err := service.ParseManualFindings(header.Filename, body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	importedBy := c.GetString("user_email")
+	if importedBy == "" {
+		importedBy = "system"
+	}
+
+	result, err := h.service.ImportManualFindings(sharedapi.RequestContext(c), header.Filename, rows, dryRun, importedBy)
+	if err != nil {
+		if writeQuotaError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, service.ErrManualImportInvalid):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":   "Import has invalid rows; nothing was imported",
+				"details": result.Errors,
+				"data":    result,
+			})
+		case errors.Is(err, service.ErrManualImportFormat):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to import findings",
+				"details": err.Error(),
+			})
+		}
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, gin.H{"data": result})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// QuotaHandler reports and sets per-tenant ingestion quotas
+type QuotaHandler struct {
+	service *service.QuotaService
+}
+
+func NewQuotaHandler(service *service.QuotaService) *QuotaHandler {
+	return &QuotaHandler{service: service}
+}
+
+// GetUsage returns the tenant's stored findings and scan runs against its quotas
+// GET /api/v1/usage
+func (h *QuotaHandler) GetUsage(c *gin.Context) {
+	usage, err := h.service.Usage(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get usage", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": usage})
+}
+
+// SetQuotas replaces the tenant's quota overrides; omitted fields use the defaults
+// PUT /api/v1/usage/quotas
+func (h *QuotaHandler) SetQuotas(c *gin.Context) {
+	var input service.TenantQuotaInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	usage, err := h.service.SetTenantQuota(sharedapi.RequestContext(c), input, c.GetString("user_email"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidQuota) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set quotas", "details": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": usage})
+}
+
+// writeQuotaError responds to a quota error, reporting whether err was one. Past
+// the findings quota the tenant gets 402 until findings are deleted or the quota is
+// raised; past the daily scan run quota it gets 429 with Retry-After.
+func writeQuotaError(c *gin.Context, err error) bool {
+	var quotaErr *service.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+
+	body := gin.H{
+		"error": "Quota exceeded; nothing from this request was stored",
+		"code":  sharedapi.CodeQuotaExceeded,
+		"quota": quotaErr.Quota,
+		"limit": quotaErr.Limit,
+		"used":  quotaErr.Used,
+	}
+	if quotaErr.Quota == service.QuotaScanRunsPerDay {
+		retryAfter := int(math.Ceil(quotaErr.RetryAfter.Seconds()))
+		body["code"] = sharedapi.CodeRateLimited
+		body["retry_after_seconds"] = retryAfter
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		c.JSON(http.StatusTooManyRequests, body)
+		return true
+	}
+
+	body["incoming"] = quotaErr.Incoming
+	c.JSON(http.StatusPaymentRequired, body)
+	return true
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestSetQuotasRejectsInvalidInput(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("tenant_id", uuid.New()) })
+	quotas := service.NewQuotaService(persistence.NewPostgresRepository(db), config.QuotaConfig{}, nil)
+	router.PUT("/usage/quotas", NewQuotaHandler(quotas).SetQuotas)
+
+	for _, body := range []string{`{"max_findings": -1}`, `{"overage_behavior": "drop"}`} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/usage/quotas", strings.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for %s, got %d: %s", body, w.Code, w.Body.String())
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
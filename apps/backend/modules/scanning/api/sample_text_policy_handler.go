@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// SampleTextPolicyHandler handles the tenant's choice of how finding sample text is stored
+type SampleTextPolicyHandler struct {
+	service *service.SampleTextPolicyService
+}
+
+// NewSampleTextPolicyHandler creates a new sample text policy handler
+func NewSampleTextPolicyHandler(service *service.SampleTextPolicyService) *SampleTextPolicyHandler {
+	return &SampleTextPolicyHandler{service: service}
+}
+
+// GetPolicy handles GET /api/v1/classification/sample-text-policy
+func (h *SampleTextPolicyHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.service.GetTenantPolicy(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get sample text policy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// SetPolicy handles PUT /api/v1/classification/sample-text-policy
+// Opts the tenant in to, or out of, storing full sample text from the next scan on
+func (h *SampleTextPolicyHandler) SetPolicy(c *gin.Context) {
+	var input struct {
+		StoreFull *bool `json:"store_full" binding:"required"`
+	}
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	policy, err := h.service.SetTenantStoreFull(sharedapi.RequestContext(c), *input.StoreFull, mappingActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set sample text policy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScanAnomalyHandler handles scan volume anomalies, their sensitivity settings and suppression windows
+type ScanAnomalyHandler struct {
+	service *service.ScanAnomalyService
+}
+
+// NewScanAnomalyHandler creates a new scan anomaly handler
+func NewScanAnomalyHandler(service *service.ScanAnomalyService) *ScanAnomalyHandler {
+	return &ScanAnomalyHandler{service: service}
+}
+
+// ListAnomalies handles GET /api/v1/scans/anomalies
+func (h *ScanAnomalyHandler) ListAnomalies(c *gin.Context) {
+	filter := entity.ScanAnomalyFilter{Status: c.Query("status")}
+	filter.Limit, _ = strconv.Atoi(c.DefaultQuery("limit", "50"))
+	filter.Offset, _ = strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if v := c.Query("scan_run_id"); v != "" {
+		scanRunID, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan_run_id format"})
+			return
+		}
+		filter.ScanRunID = &scanRunID
+	}
+
+	anomalies, total, err := h.service.ListAnomalies(sharedapi.RequestContext(c), filter)
+	if err != nil {
+		c.JSON(statusForScanAnomalyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": anomalies, "total": total})
+}
+
+// AcknowledgeAnomaly handles POST /api/v1/scans/anomalies/:id/acknowledge
+func (h *ScanAnomalyHandler) AcknowledgeAnomaly(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid anomaly ID"})
+		return
+	}
+
+	anomaly, err := h.service.Acknowledge(sharedapi.RequestContext(c), id, reviewerName(c))
+	if err != nil {
+		c.JSON(statusForScanAnomalyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": anomaly})
+}
+
+// Detect handles POST /api/v1/scans/anomalies/detect, checking the tenant's new scan runs now
+func (h *ScanAnomalyHandler) Detect(c *gin.Context) {
+	report, err := h.service.Detect(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to detect scan anomalies",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// GetSettings handles GET /api/v1/scans/anomalies/settings
+func (h *ScanAnomalyHandler) GetSettings(c *gin.Context) {
+	settings, err := h.service.GetSettings(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get scan anomaly settings", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// UpdateSettings handles PUT /api/v1/scans/anomalies/settings
+func (h *ScanAnomalyHandler) UpdateSettings(c *gin.Context) {
+	var input service.ScanAnomalySettingsInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(sharedapi.RequestContext(c), input, reviewerName(c))
+	if err != nil {
+		c.JSON(statusForScanAnomalyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// ListSuppressions handles GET /api/v1/scans/anomalies/suppressions
+func (h *ScanAnomalyHandler) ListSuppressions(c *gin.Context) {
+	suppressions, err := h.service.ListSuppressions(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list suppression windows", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": suppressions, "total": len(suppressions)})
+}
+
+// CreateSuppression handles POST /api/v1/scans/anomalies/suppressions
+func (h *ScanAnomalyHandler) CreateSuppression(c *gin.Context) {
+	var input service.ScanAnomalySuppressionInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	suppression, err := h.service.CreateSuppression(sharedapi.RequestContext(c), input, reviewerName(c))
+	if err != nil {
+		c.JSON(statusForScanAnomalyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": suppression})
+}
+
+// DeleteSuppression handles DELETE /api/v1/scans/anomalies/suppressions/:id
+func (h *ScanAnomalyHandler) DeleteSuppression(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid suppression window ID"})
+		return
+	}
+
+	if err := h.service.DeleteSuppression(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForScanAnomalyError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Suppression window deleted"})
+}
+
+func statusForScanAnomalyError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScanPerformanceHandler reports how long scan runs took to ingest
+type ScanPerformanceHandler struct {
+	service *service.ScanPerformanceService
+}
+
+// NewScanPerformanceHandler creates a new scan performance handler
+func NewScanPerformanceHandler(service *service.ScanPerformanceService) *ScanPerformanceHandler {
+	return &ScanPerformanceHandler{service: service}
+}
+
+// GetScanPerformance handles GET /api/v1/scans/:id/performance
+func (h *ScanPerformanceHandler) GetScanPerformance(c *gin.Context) {
+	scanRunID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scan ID"})
+		return
+	}
+
+	perf, err := h.service.ForScanRun(sharedapi.RequestContext(c), scanRunID)
+	if errors.Is(err, service.ErrScanPerformanceNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get scan performance",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": perf})
+}
+
+// GetPerformanceTrends handles GET /api/v1/scans/performance/trends
+// Query: days (default 30), interval (day or week; default day)
+func (h *ScanPerformanceHandler) GetPerformanceTrends(c *gin.Context) {
+	days := 0
+	if v := c.Query("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a positive integer"})
+			return
+		}
+		days = n
+	}
+
+	trends, err := h.service.Trends(sharedapi.RequestContext(c), days, c.Query("interval"))
+	if errors.Is(err, service.ErrInvalidPerformanceWindow) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get scan performance trends",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": trends})
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/pkg/scansign"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ScanSigningHandler handles scanner signing keys and the tenant's strict mode
+type ScanSigningHandler struct {
+	service *service.ScanSigningService
+}
+
+// NewScanSigningHandler creates a new scan signing handler
+func NewScanSigningHandler(service *service.ScanSigningService) *ScanSigningHandler {
+	return &ScanSigningHandler{service: service}
+}
+
+// ListKeys handles GET /api/v1/scans/signing-keys
+func (h *ScanSigningHandler) ListKeys(c *gin.Context) {
+	keys, err := h.service.ListKeys(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list signing keys", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": keys})
+}
+
+// RegisterKey handles POST /api/v1/scans/signing-keys
+func (h *ScanSigningHandler) RegisterKey(c *gin.Context) {
+	var input service.RegisterSigningKeyInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	key, err := h.service.RegisterKey(sharedapi.RequestContext(c), input, mappingActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSigningKeyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, scansign.ErrInvalidPublicKey), strings.HasPrefix(err.Error(), "invalid"),
+			strings.Contains(err.Error(), "must not be empty"):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register signing key", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": key})
+}
+
+// RevokeKey handles DELETE /api/v1/scans/signing-keys/:id
+// The key is kept, revoked, so scan runs it signed still name their signer
+func (h *ScanSigningHandler) RevokeKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid signing key ID"})
+		return
+	}
+
+	key, err := h.service.RevokeKey(sharedapi.RequestContext(c), id, mappingActor(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrSigningKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Active signing key not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke signing key", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": key})
+}
+
+// GetPolicy handles GET /api/v1/scans/signing-policy
+func (h *ScanSigningHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.service.GetPolicy(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get scan signing policy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
+
+// SetPolicy handles PUT /api/v1/scans/signing-policy
+// Strict mode rejects unsigned scan payloads of the tenant
+func (h *ScanSigningHandler) SetPolicy(c *gin.Context) {
+	var input struct {
+		RequireSignature *bool `json:"require_signature" binding:"required"`
+	}
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	policy, err := h.service.SetRequireSignature(sharedapi.RequestContext(c), *input.RequireSignature, mappingActor(c))
+	if err != nil {
+		if strings.Contains(err.Error(), "register a signing key") {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set scan signing policy", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": policy})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/arc-platform/backend/modules/websocket"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -107,6 +108,11 @@ func (h *ScanStatusHandler) ListScans(c *gin.Context) {
 	})
 }
 
+// CompleteScanRequest is the body of POST /api/v1/scans/:id/complete
+type CompleteScanRequest struct {
+	Status string `json:"status" binding:"required,oneof=completed failed"`
+}
+
 // CompleteScan handles POST /api/v1/scans/:id/complete
 // Updates scan status to completed (called by scanner service)
 func (h *ScanStatusHandler) CompleteScan(c *gin.Context) {
@@ -118,22 +124,8 @@ func (h *ScanStatusHandler) CompleteScan(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		Status string `json:"status" binding:"required"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid request body",
-		})
-		return
-	}
-
-	// Only allow specific status updates
-	if req.Status != "completed" && req.Status != "failed" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid status",
-		})
+	var req CompleteScanRequest
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
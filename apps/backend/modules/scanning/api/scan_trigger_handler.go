@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
@@ -65,12 +66,7 @@ func (h *ScanTriggerHandler) TriggerScan(c *gin.Context) {
 	}()
 
 	var req service.TriggerScanRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		scanTriggerFailureCounter.WithLabelValues("unknown", "validation_error").Inc()
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+	if !sharedapi.BindJSON(c, &req) {
 		return
 	}
 
@@ -96,6 +92,10 @@ func (h *ScanTriggerHandler) TriggerScan(c *gin.Context) {
 	ctx := c.Request.Context()
 	scanRun, err := h.scanService.CreateScanRun(ctx, &req, triggeredBy)
 	if err != nil {
+		if writeQuotaError(c, err) {
+			scanTriggerFailureCounter.WithLabelValues("unknown", "quota_exceeded").Inc()
+			return
+		}
 		scanTriggerFailureCounter.WithLabelValues("unknown", "creation_error").Inc()
 		log.Printf("ERROR: Failed to create scan run: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
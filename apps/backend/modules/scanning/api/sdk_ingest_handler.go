@@ -1,57 +1,219 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/pkg/scansign"
 	"github.com/gin-gonic/gin"
 )
 
 // SDKIngestHandler handles SDK-verified finding ingestion
 type SDKIngestHandler struct {
 	ingestionService *service.IngestionService
+	signing          *service.ScanSigningService
+	limits           ingestLimits
 }
 
-func NewSDKIngestHandler(ingestionService *service.IngestionService) *SDKIngestHandler {
+func NewSDKIngestHandler(ingestionService *service.IngestionService, signing *service.ScanSigningService, limits config.IngestionConfig) *SDKIngestHandler {
 	return &SDKIngestHandler{
 		ingestionService: ingestionService,
+		signing:          signing,
+		limits:           newIngestLimits(limits),
 	}
 }
 
 // IngestVerified handles POST /api/v1/scans/ingest-verified
+// The body is decoded as a stream, one finding at a time, within the configured
+// payload and finding limits. A signed body is verified before anything from it
+// is committed.
 func (h *SDKIngestHandler) IngestVerified(c *gin.Context) {
-	var input service.VerifiedScanInput
+	stream, ok := h.limits.decoder(c, h.signing)
+	if !ok {
+		return
+	}
 
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request body",
+	// Process findings
+	result, err := h.ingestionService.IngestSDKVerifiedStream(sharedapi.RequestContext(c), stream)
+	if err != nil {
+		if h.limits.writePayloadError(c, err) || writeQuotaError(c, err) || writeSignatureError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrNoFindings) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "No findings provided",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to ingest findings",
 			"details": err.Error(),
 		})
 		return
 	}
 
-	// Validate input
-	if len(input.Findings) == 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "No findings provided",
-		})
+	c.JSON(http.StatusOK, gin.H{
+		"status":           "success",
+		"findings_count":   result.Received,
+		"accepted_count":   result.Accepted,
+		"suppressed_count": result.Suppressed,
+		"scan_id":          result.ScanID,
+		"scan_run_id":      result.ScanRunID,
+		"message":          "SDK-verified findings ingested successfully",
+	})
+}
+
+// Capabilities handles GET /api/v1/scans/capabilities
+// Scanners read it to pick a schema version and stay within the limits before sending
+func (h *SDKIngestHandler) Capabilities(c *gin.Context) {
+	required, err := h.signing.RequiresSignature(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Process findings
-	ctx := c.Request.Context()
-	if err := h.ingestionService.IngestSDKVerified(ctx, input); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to ingest findings",
+	c.JSON(http.StatusOK, gin.H{"data": service.NewIngestionCapabilities(
+		service.IngestionLimits{
+			MaxPayloadBytes: h.limits.maxPayloadBytes,
+			MaxFindings:     h.limits.maxFindings,
+		},
+		service.SigningCapability{
+			Required:        required,
+			Algorithm:       "ed25519",
+			KeyIDHeader:     scansign.HeaderKeyID,
+			SignatureHeader: scansign.HeaderSignature,
+		},
+	)})
+}
+
+// writeSchemaVersionError responds to a payload in an unsupported schema version,
+// reporting whether err was one
+func writeSchemaVersionError(c *gin.Context, err error) bool {
+	var versionErr *service.UnsupportedSchemaVersionError
+	if !errors.As(err, &versionErr) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Unsupported scan payload schema version",
+		"code":    sharedapi.CodeUnsupportedSchema,
+		"details": err.Error(),
+		"schema_version": gin.H{
+			"min":     service.MinSchemaVersion,
+			"current": service.CurrentSchemaVersion,
+		},
+	})
+	return true
+}
+
+// ingestLimits bounds a single ingestion request body
+type ingestLimits struct {
+	maxPayloadBytes int64
+	maxFindings     int
+}
+
+func newIngestLimits(cfg config.IngestionConfig) ingestLimits {
+	return ingestLimits{
+		maxPayloadBytes: int64(cfg.MaxPayloadMB) << 20,
+		maxFindings:     cfg.MaxFindings,
+	}
+}
+
+// decoder streams the request body within the limits, checking its signature
+// headers with signing. It responds and returns false when the declared body size
+// is already over the limit or the payload is refused for its signature headers.
+func (l ingestLimits) decoder(c *gin.Context, signing *service.ScanSigningService) (service.VerifiedFindingStream, bool) {
+	// Reject declared oversize bodies before reading anything
+	if l.maxPayloadBytes > 0 && c.Request.ContentLength > l.maxPayloadBytes {
+		l.payloadTooLarge(c, "max_payload_bytes", l.maxPayloadBytes)
+		return nil, false
+	}
+
+	var body io.Reader = c.Request.Body
+	if l.maxPayloadBytes > 0 {
+		body = http.MaxBytesReader(c.Writer, c.Request.Body, l.maxPayloadBytes)
+	}
+	body, signature, err := signing.Begin(sharedapi.RequestContext(c), c.GetHeader(scansign.HeaderKeyID), c.GetHeader(scansign.HeaderSignature), body)
+	if err != nil {
+		if !writeSignatureError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "Failed to check the payload signature",
+				"details": err.Error(),
+			})
+		}
+		return nil, false
+	}
+	return signature.Stream(service.NewVerifiedScanDecoder(body, l.maxFindings)), true
+}
+
+// writeSignatureError responds to a refused payload signature, reporting whether
+// err was one. Nothing from the payload is stored.
+func writeSignatureError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrSignatureRequired):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Scan payload is not signed",
+			"code":    sharedapi.CodeSignatureRequired,
+			"details": fmt.Sprintf("Sign the body and send %s and %s", scansign.HeaderKeyID, scansign.HeaderSignature),
+		})
+	case errors.Is(err, service.ErrInvalidSignature):
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Scan payload signature rejected",
+			"code":    sharedapi.CodeInvalidSignature,
 			"details": err.Error(),
 		})
-		return
+	default:
+		return false
 	}
+	return true
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"status":         "success",
-		"findings_count": len(input.Findings),
-		"scan_id":        input.ScanID,
-		"message":        "SDK-verified findings ingested successfully",
+// writePayloadError responds to limit and decoding errors from a decoded body,
+// reporting whether err was one of them
+func (l ingestLimits) writePayloadError(c *gin.Context, err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	var limitErr *service.PayloadLimitError
+	var decodeErr *service.PayloadDecodeError
+	switch {
+	case writeSchemaVersionError(c, err):
+	case errors.As(err, &maxBytesErr):
+		l.payloadTooLarge(c, "max_payload_bytes", maxBytesErr.Limit)
+	case errors.As(err, &limitErr):
+		l.payloadTooLarge(c, limitErr.Limit, limitErr.Max)
+	case errors.As(err, &decodeErr):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// payloadTooLarge responds 413 with the exceeded limit and how to stay within it.
+// No findings from the rejected payload are stored.
+func (l ingestLimits) payloadTooLarge(c *gin.Context, limit string, max int64) {
+	chunk := l.maxFindings
+	if chunk <= 0 {
+		chunk = 1000
+	}
+
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error": "Scan payload too large",
+		"code":  "PAYLOAD_TOO_LARGE",
+		"limit": limit,
+		"max":   max,
+		"guidance": fmt.Sprintf(
+			"No findings from this payload were stored. Open an upload session with POST /api/v1/scans/uploads, "+
+				"PUT the findings in numbered chunks of at most %d, then POST /api/v1/scans/uploads/{id}/finalize.", chunk),
+		"limits": gin.H{
+			"max_payload_bytes": l.maxPayloadBytes,
+			"max_findings":      l.maxFindings,
+		},
 	})
 }
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultShadowComparisonWindow is how far back a comparison looks without ?since
+const defaultShadowComparisonWindow = 7 * 24 * time.Hour
+
+// ShadowClassificationHandler reports how a candidate classifier version compares to the primary one
+type ShadowClassificationHandler struct {
+	service *service.ShadowClassificationService
+}
+
+// NewShadowClassificationHandler creates a new shadow classification handler
+func NewShadowClassificationHandler(service *service.ShadowClassificationService) *ShadowClassificationHandler {
+	return &ShadowClassificationHandler{service: service}
+}
+
+// GetComparison handles GET /api/v1/classification/shadow/comparison
+// Query: version (default the active candidate, else the latest one with results),
+// since (RFC3339; default the last 7 days)
+func (h *ShadowClassificationHandler) GetComparison(c *gin.Context) {
+	since := time.Now().Add(-defaultShadowComparisonWindow)
+	if v := c.Query("since"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be RFC3339"})
+			return
+		}
+		since = parsed
+	}
+
+	comparison, err := h.service.Compare(sharedapi.RequestContext(c), c.Query("version"), since)
+	if err != nil {
+		if errors.Is(err, service.ErrNoShadowResults) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to compare shadow classifications",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": comparison})
+}
+
+// ListVersions handles GET /api/v1/classification/shadow/versions
+func (h *ShadowClassificationHandler) ListVersions(c *gin.Context) {
+	versions, err := h.service.ListVersions(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list shadow classifier versions",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": versions})
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SuppressionHandler handles suppression rule requests
+type SuppressionHandler struct {
+	service *service.SuppressionService
+}
+
+// NewSuppressionHandler creates a new suppression handler
+func NewSuppressionHandler(service *service.SuppressionService) *SuppressionHandler {
+	return &SuppressionHandler{service: service}
+}
+
+// CreateRule handles POST /api/v1/suppressions
+func (h *SuppressionHandler) CreateRule(c *gin.Context) {
+	var input service.SuppressionRuleInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	createdBy := c.GetString("user_email")
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	rule, err := h.service.CreateRule(sharedapi.RequestContext(c), input, createdBy)
+	if err != nil {
+		c.JSON(statusForSuppressionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": rule})
+}
+
+// ListRules handles GET /api/v1/suppressions
+func (h *SuppressionHandler) ListRules(c *gin.Context) {
+	rules, err := h.service.ListRules(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list suppression rules",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rules, "total": len(rules)})
+}
+
+// GetRule handles GET /api/v1/suppressions/:id
+func (h *SuppressionHandler) GetRule(c *gin.Context) {
+	id, ok := parseRuleID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.service.GetRule(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForSuppressionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// SetRuleActive handles PATCH /api/v1/suppressions/:id
+func (h *SuppressionHandler) SetRuleActive(c *gin.Context) {
+	id, ok := parseRuleID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		IsActive *bool `json:"is_active" binding:"required"`
+	}
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	rule, err := h.service.SetRuleActive(sharedapi.RequestContext(c), id, *req.IsActive)
+	if err != nil {
+		c.JSON(statusForSuppressionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": rule})
+}
+
+// DeleteRule handles DELETE /api/v1/suppressions/:id
+func (h *SuppressionHandler) DeleteRule(c *gin.Context) {
+	id, ok := parseRuleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRule(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForSuppressionError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Suppression rule deleted"})
+}
+
+func parseRuleID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid suppression rule ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForSuppressionError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already exists"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
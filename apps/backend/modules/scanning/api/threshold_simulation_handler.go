@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ThresholdSimulationHandler handles confidence threshold simulations
+type ThresholdSimulationHandler struct {
+	service *service.ThresholdSimulationService
+}
+
+// NewThresholdSimulationHandler creates a new threshold simulation handler
+func NewThresholdSimulationHandler(service *service.ThresholdSimulationService) *ThresholdSimulationHandler {
+	return &ThresholdSimulationHandler{service: service}
+}
+
+// Simulate handles POST /api/v1/admin/threshold-simulation
+func (h *ThresholdSimulationHandler) Simulate(c *gin.Context) {
+	var req service.ThresholdSimulationRequest
+	if !sharedapi.BindJSON(c, &req) {
+		return
+	}
+
+	simulation, err := h.service.Simulate(sharedapi.RequestContext(c), req.Thresholds)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "must") {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": simulation})
+}
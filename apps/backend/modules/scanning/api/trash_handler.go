@@ -0,0 +1,164 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TrashHandler handles soft deletes of scan data and restores from the trash
+type TrashHandler struct {
+	service *service.TrashService
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(service *service.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// ClearScanData handles DELETE /api/v1/scans/clear
+// Moves all previous scan data to the trash for a clean scan-replace workflow
+func (h *TrashHandler) ClearScanData(c *gin.Context) {
+	batch, err := h.service.TrashScanData(sharedapi.RequestContext(c), trashActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to clear scan data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Previous scan data moved to trash",
+		"data":    batch,
+	})
+}
+
+// DeleteScan handles DELETE /api/v1/scans/:id
+func (h *TrashHandler) DeleteScan(c *gin.Context) {
+	id, ok := parseTrashParam(c, "scan run")
+	if !ok {
+		return
+	}
+
+	batch, err := h.service.TrashScanRun(sharedapi.RequestContext(c), id, trashActor(c))
+	if err != nil {
+		c.JSON(statusForTrashError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Scan run moved to trash", "data": batch})
+}
+
+// DeleteAsset handles DELETE /api/v1/assets/:id
+// Query: force=true deletes the asset permanently instead of moving it to the trash
+func (h *TrashHandler) DeleteAsset(c *gin.Context) {
+	id, ok := parseTrashParam(c, "asset")
+	if !ok {
+		return
+	}
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "force must be true or false"})
+		return
+	}
+
+	deletion, err := h.service.DeleteAsset(sharedapi.RequestContext(c), id, trashActor(c), force)
+	if err != nil {
+		c.JSON(statusForTrashError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	message := "Asset moved to trash"
+	if deletion.Purged {
+		message = "Asset deleted permanently"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "data": deletion})
+}
+
+// ListTrash handles GET /api/v1/trash
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	includeClosed, _ := strconv.ParseBool(c.DefaultQuery("include_closed", "false"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	batches, err := h.service.ListBatches(sharedapi.RequestContext(c), includeClosed, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list trash",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": batches, "total": len(batches)})
+}
+
+// GetTrashBatch handles GET /api/v1/trash/:id
+func (h *TrashHandler) GetTrashBatch(c *gin.Context) {
+	id, ok := parseTrashParam(c, "trash batch")
+	if !ok {
+		return
+	}
+
+	batch, err := h.service.GetBatch(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForTrashError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+// RestoreTrashBatch handles POST /api/v1/trash/:id/restore
+func (h *TrashHandler) RestoreTrashBatch(c *gin.Context) {
+	id, ok := parseTrashParam(c, "trash batch")
+	if !ok {
+		return
+	}
+
+	batch, err := h.service.RestoreBatch(sharedapi.RequestContext(c), id, trashActor(c))
+	if err != nil {
+		c.JSON(statusForTrashError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Trash batch restored", "data": batch})
+}
+
+func trashActor(c *gin.Context) string {
+	if email := c.GetString("user_email"); email != "" {
+		return email
+	}
+	return "system"
+}
+
+func parseTrashParam(c *gin.Context, resource string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + resource + " ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForTrashError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already been"):
+		return http.StatusConflict
+	case strings.Contains(msg, "expired"):
+		return http.StatusGone
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/scanning/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// UploadSessionHandler handles resumable chunked scan uploads
+type UploadSessionHandler struct {
+	service *service.UploadSessionService
+	signing *service.ScanSigningService
+	limits  ingestLimits
+}
+
+// NewUploadSessionHandler creates a new upload session handler. Each chunk is held
+// to the same limits and signature checks as a single ingest-verified body.
+func NewUploadSessionHandler(service *service.UploadSessionService, signing *service.ScanSigningService, limits config.IngestionConfig) *UploadSessionHandler {
+	return &UploadSessionHandler{
+		service: service,
+		signing: signing,
+		limits:  newIngestLimits(limits),
+	}
+}
+
+// CreateSession handles POST /api/v1/scans/uploads
+func (h *UploadSessionHandler) CreateSession(c *gin.Context) {
+	var input service.CreateSessionInput
+	// All fields are optional, so an empty body is fine
+	if !sharedapi.BindOptionalJSON(c, &input) {
+		return
+	}
+
+	createdBy := c.GetString("user_email")
+	if createdBy == "" {
+		createdBy = "system"
+	}
+
+	session, err := h.service.CreateSession(sharedapi.RequestContext(c), input, createdBy)
+	if err != nil {
+		c.JSON(statusForUploadError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": session})
+}
+
+// GetSession handles GET /api/v1/scans/uploads/:id
+func (h *UploadSessionHandler) GetSession(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload session ID"})
+		return
+	}
+
+	session, err := h.service.GetSession(sharedapi.RequestContext(c), sessionID)
+	if err != nil {
+		c.JSON(statusForUploadError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": session})
+}
+
+// UploadChunk handles PUT /api/v1/scans/uploads/:id/chunks/:number
+// The body has the ingest-verified shape; only its findings are kept.
+func (h *UploadSessionHandler) UploadChunk(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload session ID"})
+		return
+	}
+	chunkNumber, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid chunk number"})
+		return
+	}
+
+	stream, ok := h.limits.decoder(c, h.signing)
+	if !ok {
+		return
+	}
+
+	chunk, err := h.service.UploadChunk(sharedapi.RequestContext(c), sessionID, chunkNumber, stream)
+	if err != nil {
+		if h.limits.writePayloadError(c, err) || writeSignatureError(c, err) {
+			return
+		}
+		c.JSON(statusForUploadError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": chunk})
+}
+
+// FinalizeSession handles POST /api/v1/scans/uploads/:id/finalize
+func (h *UploadSessionHandler) FinalizeSession(c *gin.Context) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid upload session ID"})
+		return
+	}
+
+	session, err := h.service.Finalize(sharedapi.RequestContext(c), sessionID)
+	if err != nil {
+		if writeQuotaError(c, err) || writeSignatureError(c, err) {
+			return
+		}
+		c.JSON(statusForUploadError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data":    session,
+		"message": "Ingestion started; poll the session until it is completed",
+	})
+}
+
+func statusForUploadError(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "expired"):
+		return http.StatusGone
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "must be"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "already"), strings.Contains(msg, "missing chunks"),
+		strings.Contains(msg, "no chunks"), strings.Contains(msg, "not accepting"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,11 +1,16 @@
 package scanning
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	maskingservice "github.com/arc-platform/backend/modules/masking/service"
 	"github.com/arc-platform/backend/modules/scanning/api"
 	"github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/admission"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/gin-gonic/gin"
@@ -17,8 +22,22 @@ type ScanningModule struct {
 	ingestionService             *service.IngestionService
 	classificationService        *service.ClassificationService
 	classificationSummaryService *service.ClassificationSummaryService
+	explanationService           *service.ClassificationExplanationService
 	enrichmentService            *service.EnrichmentService
 	scanService                  *service.ScanService
+	summaryService               *service.DashboardSummaryService
+	suppressionService           *service.SuppressionService
+	uploadSessionService         *service.UploadSessionService
+	trashService                 *service.TrashService
+	complianceMappingService     *service.ComplianceMappingService
+	jurisdictionService          *service.JurisdictionService
+	thresholdSimulationService   *service.ThresholdSimulationService
+	fpClusteringService          *service.FPClusteringService
+	shadowService                *service.ShadowClassificationService
+	sampleTextService            *service.SampleTextPolicyService
+	scanSigningService           *service.ScanSigningService
+	quotaService                 *service.QuotaService
+	scanAnomalyService           *service.ScanAnomalyService
 
 	// Handlers
 	ingestionHandler      *api.IngestionHandler
@@ -27,9 +46,32 @@ type ScanningModule struct {
 	scanTriggerHandler    *api.ScanTriggerHandler
 	scanStatusHandler     *api.ScanStatusHandler
 	dashboardHandler      *api.DashboardHandler
+	explanationHandler    *api.ClassificationExplanationHandler
+	suppressionHandler    *api.SuppressionHandler
+	uploadSessionHandler  *api.UploadSessionHandler
+	trashHandler          *api.TrashHandler
+	mappingHandler        *api.ComplianceMappingHandler
+	jurisdictionHandler   *api.JurisdictionHandler
+	thresholdHandler      *api.ThresholdSimulationHandler
+	fpClusteringHandler   *api.FPClusteringHandler
+	admissionHandler      *api.IngestionAdmissionHandler
+	manualImportHandler   *api.ManualImportHandler
+	shadowHandler         *api.ShadowClassificationHandler
+	sampleTextHandler     *api.SampleTextPolicyHandler
+	scanSigningHandler    *api.ScanSigningHandler
+	quotaHandler          *api.QuotaHandler
+	batchClassifyHandler  *api.BatchClassificationHandler
+	scanAnomalyHandler    *api.ScanAnomalyHandler
+	droppedHandler        *api.DroppedFindingsHandler
+	performanceHandler    *api.ScanPerformanceHandler
+
+	// Suppression rules, category mappings, jurisdiction selection, sample text storage, quotas, deletes, restores,
+	// threshold simulations, false-positive rule suggestions and anomaly sensitivity are admin-only
+	authMiddleware *middleware.AuthMiddleware
 
 	// Dependencies
-	deps *interfaces.ModuleDependencies
+	deps         *interfaces.ModuleDependencies
+	cancelWorker context.CancelFunc
 }
 
 // Name returns the module name
@@ -49,10 +91,29 @@ func (m *ScanningModule) Initialize(deps *interfaces.ModuleDependencies) error {
 	// Initialize services
 	m.enrichmentService = service.NewEnrichmentService(repo, nil)
 	m.classificationService = service.NewClassificationService(repo, deps.Config)
+	m.complianceMappingService = service.NewComplianceMappingService(repo, deps.AuditLogger)
+	m.classificationService.SetComplianceMappings(m.complianceMappingService)
+	m.jurisdictionService = service.NewJurisdictionService(repo, deps.AuditLogger)
+	m.classificationService.SetJurisdictions(m.jurisdictionService)
 	m.classificationSummaryService = service.NewClassificationSummaryService(repo)
+	m.explanationService = service.NewClassificationExplanationService(repo, m.classificationService)
+	m.suppressionService = service.NewSuppressionService(repo, deps.AuditLogger)
+	m.thresholdSimulationService = service.NewThresholdSimulationService(repo)
+	fpCfg := deps.Config.FPClustering
+	m.fpClusteringService = service.NewFPClusteringService(repo, m.suppressionService, deps.AuditLogger, service.FPClusteringOptions{
+		WindowDays:   fpCfg.WindowDays,
+		MinSupport:   fpCfg.MinSupport,
+		MinPrecision: fpCfg.MinPrecision,
+		PathDepth:    fpCfg.PathDepth,
+	})
+
+	// Per-tenant findings and daily scan run quotas; zero limits leave ingestion unbounded
+	m.quotaService = service.NewQuotaService(repo, deps.Config.Quota, deps.AuditLogger)
 
 	// Create scan service for scan orchestration
 	m.scanService = service.NewScanService(repo)
+	m.scanService.SetEventPublisher(deps.EventPublisher)
+	m.scanService.SetQuotas(m.quotaService)
 
 	// Get AssetManager from dependencies (injected by main.go)
 	var assetManager interfaces.AssetManager
@@ -71,16 +132,132 @@ func (m *ScanningModule) Initialize(deps *interfaces.ModuleDependencies) error {
 		assetManager,
 	)
 
+	// Dashboard summary views are refreshed after each ingestion
+	m.summaryService = service.NewDashboardSummaryService(repo)
+	m.ingestionService.SetSummaryService(m.summaryService)
+	m.ingestionService.SetEventPublisher(deps.EventPublisher)
+	m.ingestionService.SetIntegrationEvents(deps.IntegrationEvents)
+	m.ingestionService.SetPathPolicies(assetManager.PathPolicies())
+	m.ingestionService.SetWorkers(deps.Config.Ingestion.Workers)
+	m.ingestionService.SetMaxMatchesPerFinding(deps.Config.Ingestion.MaxMatchesPerFinding)
+	// Non-PII and low-confidence findings are counted in the dropped findings ledger, and dropped unless auditing
+	filter := service.NewIngestionFilter(deps.Config.Ingestion.FilterMode, deps.Config.Ingestion.FilterMinConfidence)
+	m.ingestionService.SetFilter(filter)
+	// Findings likely affecting many records are raised a severity tier per threshold reached
+	m.ingestionService.SetVolumeEscalation(service.NewVolumeEscalation(deps.Config.Ingestion.VolumeSeverityThresholds))
+	// Findings in languages outside the supported ones are tagged for separate review or downgraded
+	m.ingestionService.SetLanguagePolicy(service.NewLanguagePolicy(deps.Config.Ingestion.LanguageHandling,
+		deps.Config.Ingestion.SupportedLanguages, deps.Config.Ingestion.LanguageDowngradeFactor))
+	m.ingestionService.SetTransactionRetry(persistence.RetryOptions{
+		MaxAttempts: deps.Config.Ingestion.TxRetryMaxAttempts,
+		BaseDelay:   time.Duration(deps.Config.Ingestion.TxRetryBaseDelayMs) * time.Millisecond,
+		MaxDelay:    time.Duration(deps.Config.Ingestion.TxRetryMaxDelayMs) * time.Millisecond,
+	})
+	m.ingestionService.SetQuotas(m.quotaService)
+	// Assets found through a connection assigned to an org unit join that unit
+	m.ingestionService.SetOrgUnitInheritance(repo)
+
+	// A candidate classifier version, if configured, classifies the same findings for comparison
+	m.shadowService = service.NewShadowClassificationService(repo, m.classificationService, deps.Config.Shadow)
+	m.ingestionService.SetShadowClassifier(m.shadowService)
+
+	// Sample text is redacted before it is stored unless the tenant opted in to full storage
+	m.sampleTextService = service.NewSampleTextPolicyService(repo, deps.Config.SampleText.Policy, deps.AuditLogger, deps.Jobs)
+	m.sampleTextService.SetMaskingTemplates(maskingservice.NewMaskingTemplateService(repo, deps.AuditLogger))
+	m.ingestionService.SetSampleTextPolicy(m.sampleTextService)
+	if deps.Jobs != nil {
+		m.sampleTextService.RegisterJobs(deps.Jobs)
+	}
+
+	// Scanners sign their payloads with registered keys; strict tenants reject unsigned ones
+	m.scanSigningService = service.NewScanSigningService(repo, deps.AuditLogger)
+
+	// Chunked uploads are ingested through the same SDK-verified path
+	m.uploadSessionService = service.NewUploadSessionService(
+		repo,
+		m.ingestionService,
+		time.Duration(deps.Config.Ingestion.UploadSessionTTLHours)*time.Hour,
+	)
+	m.uploadSessionService.SetScanSigning(m.scanSigningService)
+
+	// Deleted scan data stays restorable for the retention window before it is purged
+	m.trashService = service.NewTrashService(repo, deps.Config.Trash, deps.AuditLogger)
+	m.trashService.SetLineageSync(deps.LineageSync)
+
+	// Completed scan runs are compared with their assets' and connection's previous runs for volume spikes
+	m.scanAnomalyService = service.NewScanAnomalyService(repo, deps.Config.ScanAnomaly, deps.AuditLogger)
+	m.scanAnomalyService.SetEventPublisher(deps.EventPublisher)
+
+	workerCtx, cancel := context.WithCancel(context.Background())
+	m.cancelWorker = cancel
+	go m.summaryService.StartRefreshWorker(workerCtx, 10*time.Minute)
+	go m.uploadSessionService.StartExpiryWorker(workerCtx, time.Hour)
+	m.shadowService.StartWorkers(workerCtx)
+	go func() {
+		// Samples stored before redaction are rewritten by a background job
+		if err := m.sampleTextService.EnqueueBackfill(workerCtx); err != nil {
+			log.Printf("WARN: Failed to queue sample text redaction backfill: %v", err)
+		}
+	}()
+	if deps.Config.Trash.PurgeEnabled {
+		go m.trashService.StartPurgeWorker(workerCtx, deps.Config.Trash.PurgeIntervalMinutes)
+	}
+	if fpCfg.Enabled {
+		go m.fpClusteringService.StartClusteringWorker(workerCtx, fpCfg.IntervalMinutes)
+	}
+	if anomalyCfg := deps.Config.ScanAnomaly; anomalyCfg.Enabled {
+		go m.scanAnomalyService.StartDetectionWorker(workerCtx, anomalyCfg.IntervalMinutes)
+	}
+
+	// Ingestion backs off while Postgres latency is high instead of piling on more load
+	var limiter *admission.Limiter
+	if ingestCfg := deps.Config.Ingestion; ingestCfg.AdmissionEnabled {
+		limiter = admission.New("ingestion", admission.Settings{
+			MinConcurrency: ingestCfg.AdmissionMinConcurrent,
+			MaxConcurrency: ingestCfg.AdmissionMaxConcurrent,
+			MaxQueue:       ingestCfg.AdmissionMaxQueue,
+			QueueTimeout:   time.Duration(ingestCfg.AdmissionQueueTimeoutSeconds) * time.Second,
+			LatencyTarget:  time.Duration(ingestCfg.DBLatencyTargetMs) * time.Millisecond,
+			LatencyShed:    time.Duration(ingestCfg.DBLatencyShedMs) * time.Millisecond,
+			RetryAfter:     time.Duration(ingestCfg.RetryAfterSeconds) * time.Second,
+		})
+		// The probe waits for a pooled connection like ingestion does, so pool exhaustion shows up as latency
+		go limiter.StartProbe(workerCtx, time.Duration(ingestCfg.DBProbeIntervalSeconds)*time.Second, func(ctx context.Context) error {
+			var one int
+			return deps.DB.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+		})
+	}
+
 	// Initialize handlers
 	m.ingestionHandler = api.NewIngestionHandler(m.ingestionService)
 	m.classificationHandler = api.NewClassificationHandler(
 		m.classificationService,
 		m.classificationSummaryService,
 	)
-	m.sdkIngestHandler = api.NewSDKIngestHandler(m.ingestionService)
+	m.sdkIngestHandler = api.NewSDKIngestHandler(m.ingestionService, m.scanSigningService, deps.Config.Ingestion)
+	m.uploadSessionHandler = api.NewUploadSessionHandler(m.uploadSessionService, m.scanSigningService, deps.Config.Ingestion)
 	m.scanTriggerHandler = api.NewScanTriggerHandler(m.scanService, deps.WebSocketService) // Wired real WebSocket service
 	m.scanStatusHandler = api.NewScanStatusHandler(m.scanService, deps.WebSocketService)
-	m.dashboardHandler = api.NewDashboardHandler(repo)
+	m.dashboardHandler = api.NewDashboardHandler(repo, m.summaryService)
+	m.explanationHandler = api.NewClassificationExplanationHandler(m.explanationService)
+	m.suppressionHandler = api.NewSuppressionHandler(m.suppressionService)
+	m.trashHandler = api.NewTrashHandler(m.trashService)
+	m.mappingHandler = api.NewComplianceMappingHandler(m.complianceMappingService)
+	m.jurisdictionHandler = api.NewJurisdictionHandler(m.jurisdictionService)
+	m.thresholdHandler = api.NewThresholdSimulationHandler(m.thresholdSimulationService)
+	m.fpClusteringHandler = api.NewFPClusteringHandler(m.fpClusteringService)
+	m.admissionHandler = api.NewIngestionAdmissionHandler(limiter)
+	m.manualImportHandler = api.NewManualImportHandler(m.ingestionService)
+	m.shadowHandler = api.NewShadowClassificationHandler(m.shadowService)
+	m.sampleTextHandler = api.NewSampleTextPolicyHandler(m.sampleTextService)
+	m.scanSigningHandler = api.NewScanSigningHandler(m.scanSigningService)
+	m.quotaHandler = api.NewQuotaHandler(m.quotaService)
+	m.batchClassifyHandler = api.NewBatchClassificationHandler(
+		service.NewBatchClassificationService(m.classificationService, m.enrichmentService))
+	m.scanAnomalyHandler = api.NewScanAnomalyHandler(m.scanAnomalyService)
+	m.droppedHandler = api.NewDroppedFindingsHandler(service.NewDroppedFindingsService(repo, filter))
+	m.performanceHandler = api.NewScanPerformanceHandler(service.NewScanPerformanceService(repo))
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
 
 	log.Printf("✅ Scanning & Classification Module initialized")
 	return nil
@@ -88,10 +265,48 @@ func (m *ScanningModule) Initialize(deps *interfaces.ModuleDependencies) error {
 
 // RegisterRoutes registers the module's HTTP routes
 func (m *ScanningModule) RegisterRoutes(router *gin.RouterGroup) {
+	// Retried ingestion requests with an Idempotency-Key replay their first response
+	idempotent := m.deps.Idempotency.Middleware()
+
 	scans := router.Group("/scans")
 	{
 		// SDK-verified ingestion (Intelligence-at-Edge)
-		scans.POST("/ingest-verified", m.sdkIngestHandler.IngestVerified)
+		scans.GET("/capabilities", m.sdkIngestHandler.Capabilities)
+		scans.POST("/ingest-verified", m.admissionHandler.Admit, idempotent, m.sdkIngestHandler.IngestVerified)
+
+		// Resumable chunked uploads for payloads over the ingest-verified limits
+		scans.POST("/uploads", m.uploadSessionHandler.CreateSession)
+		scans.GET("/uploads/:id", m.uploadSessionHandler.GetSession)
+		scans.PUT("/uploads/:id/chunks/:number", m.uploadSessionHandler.UploadChunk)
+		scans.POST("/uploads/:id/finalize", m.admissionHandler.Admit, idempotent, m.uploadSessionHandler.FinalizeSession)
+
+		// Keys scanners sign payloads with, and the tenant's strict mode
+		scans.GET("/signing-keys", m.scanSigningHandler.ListKeys)
+		scans.POST("/signing-keys", m.authMiddleware.RequireRole("admin"), m.scanSigningHandler.RegisterKey)
+		scans.DELETE("/signing-keys/:id", m.authMiddleware.RequireRole("admin"), m.scanSigningHandler.RevokeKey)
+		scans.GET("/signing-policy", m.scanSigningHandler.GetPolicy)
+		scans.PUT("/signing-policy", m.authMiddleware.RequireRole("admin"), m.scanSigningHandler.SetPolicy)
+
+		// PII volume spikes against each asset's and connection's previous runs
+		scans.GET("/anomalies", m.scanAnomalyHandler.ListAnomalies)
+		scans.POST("/anomalies/:id/acknowledge", m.scanAnomalyHandler.AcknowledgeAnomaly)
+		scans.POST("/anomalies/detect", m.authMiddleware.RequireRole("admin"), m.scanAnomalyHandler.Detect)
+		scans.GET("/anomalies/settings", m.scanAnomalyHandler.GetSettings)
+		scans.PUT("/anomalies/settings", m.authMiddleware.RequireRole("admin"), m.scanAnomalyHandler.UpdateSettings)
+		scans.GET("/anomalies/suppressions", m.scanAnomalyHandler.ListSuppressions)
+		scans.POST("/anomalies/suppressions", m.authMiddleware.RequireRole("admin"), m.scanAnomalyHandler.CreateSuppression)
+		scans.DELETE("/anomalies/suppressions/:id", m.authMiddleware.RequireRole("admin"), m.scanAnomalyHandler.DeleteSuppression)
+
+		// Findings the ingestion filter dropped as Non-PII or low confidence
+		scans.GET("/dropped-findings", m.droppedHandler.GetDroppedFindingsSummary)
+		scans.GET("/:id/dropped-findings", m.droppedHandler.GetScanDroppedFindings)
+
+		// Ingestion performance profiles, for capacity planning
+		scans.GET("/performance/trends", m.performanceHandler.GetPerformanceTrends)
+		scans.GET("/:id/performance", m.performanceHandler.GetScanPerformance)
+
+		// Ingestion backpressure state
+		scans.GET("/ingestion/admission", m.admissionHandler.GetStatus)
 
 		// Scan trigger
 		scans.POST("/trigger", m.scanTriggerHandler.TriggerScan)
@@ -101,21 +316,91 @@ func (m *ScanningModule) RegisterRoutes(router *gin.RouterGroup) {
 		scans.GET("/:id/status", m.scanStatusHandler.GetScanStatus)
 		scans.POST("/:id/complete", m.scanStatusHandler.CompleteScan)
 		scans.POST("/:id/cancel", m.scanStatusHandler.CancelScan)
+		scans.DELETE("/:id", m.authMiddleware.RequireRole("admin"), m.trashHandler.DeleteScan)
 
 		// Scan management
 		scans.GET("", m.scanStatusHandler.ListScans)
 		scans.GET("/latest", m.ingestionHandler.GetLatestScan)
-		scans.DELETE("/clear", m.ingestionHandler.ClearScanData)
+		scans.DELETE("/clear", m.trashHandler.ClearScanData)
+	}
+
+	// Soft-deleted scan data, restorable until purged
+	router.DELETE("/assets/:id", m.authMiddleware.RequireRole("admin"), m.trashHandler.DeleteAsset)
+	trash := router.Group("/trash")
+	{
+		trash.GET("", m.trashHandler.ListTrash)
+		trash.GET("/:id", m.trashHandler.GetTrashBatch)
+		trash.POST("/:id/restore", m.authMiddleware.RequireRole("admin"), m.trashHandler.RestoreTrashBatch)
 	}
 
+	// Classification of values sent by other systems (e.g. a DLP proxy); nothing is stored
+	router.POST("/classify", m.batchClassifyHandler.ClassifyBatch)
+
 	// Classification
 	classification := router.Group("/classification")
 	{
 		classification.GET("/summary", m.classificationHandler.GetClassificationSummary)
+
+		// Versioned per-tenant category mappings (DPDPA, GDPR, CCPA, PDPA)
+		classification.GET("/mappings/:framework", m.mappingHandler.GetMapping)
+		classification.PUT("/mappings/:framework", m.authMiddleware.RequireRole("admin"), m.mappingHandler.UpdateMapping)
+		classification.GET("/mappings/:framework/history", m.mappingHandler.ListHistory)
+		classification.GET("/mappings/:framework/versions/:version", m.mappingHandler.GetVersion)
+		classification.POST("/mappings/:framework/versions/:version/restore", m.authMiddleware.RequireRole("admin"), m.mappingHandler.RestoreVersion)
+
+		// Jurisdiction profiles selecting each tenant's PII scope (IN, EU, US, SEA)
+		classification.GET("/jurisdictions", m.jurisdictionHandler.ListProfiles)
+		classification.GET("/jurisdictions/:code", m.jurisdictionHandler.GetProfile)
+		classification.POST("/jurisdictions/refresh", m.authMiddleware.RequireRole("admin"), m.jurisdictionHandler.Refresh)
+		classification.GET("/jurisdiction", m.jurisdictionHandler.GetTenantProfile)
+		classification.PUT("/jurisdiction", m.authMiddleware.RequireRole("admin"), m.jurisdictionHandler.SetTenantProfile)
+
+		// Whether the tenant stores full finding sample text instead of the redacted default
+		classification.GET("/sample-text-policy", m.sampleTextHandler.GetPolicy)
+		classification.PUT("/sample-text-policy", m.authMiddleware.RequireRole("admin"), m.sampleTextHandler.SetPolicy)
+
+		// Divergence of a candidate classifier version from the primary one, before promotion
+		classification.GET("/shadow/comparison", m.authMiddleware.RequireRole("admin"), m.shadowHandler.GetComparison)
+		classification.GET("/shadow/versions", m.authMiddleware.RequireRole("admin"), m.shadowHandler.ListVersions)
 	}
 
+	// Findings tracked outside the scanners, imported from CSV/XLSX
+	router.POST("/findings/import", idempotent, m.manualImportHandler.ImportFindings)
+
+	// Classification explainability for auditors
+	router.GET("/findings/:id/explanation", m.deps.AccessAudit.Middleware(), m.explanationHandler.GetExplanation)
+
+	// Suppression rules applied at ingestion
+	suppressions := router.Group("/suppressions")
+	{
+		suppressions.GET("", m.suppressionHandler.ListRules)
+		suppressions.GET("/:id", m.suppressionHandler.GetRule)
+		suppressions.POST("", m.authMiddleware.RequireRole("admin"), m.suppressionHandler.CreateRule)
+		suppressions.PATCH("/:id", m.authMiddleware.RequireRole("admin"), m.suppressionHandler.SetRuleActive)
+		suppressions.DELETE("/:id", m.authMiddleware.RequireRole("admin"), m.suppressionHandler.DeleteRule)
+	}
+
+	// Read-only impact preview of a different ingestion confidence threshold
+	router.POST("/admin/threshold-simulation", m.authMiddleware.RequireRole("admin"), m.thresholdHandler.Simulate)
+
+	// Rules suggested from clustered false-positive feedback, applied only on approval
+	fpSuggestions := router.Group("/admin/fp-suggestions", m.authMiddleware.RequireRole("admin"))
+	{
+		fpSuggestions.GET("", m.fpClusteringHandler.ListSuggestions)
+		fpSuggestions.POST("/analyze", m.fpClusteringHandler.Analyze)
+		fpSuggestions.GET("/:id", m.fpClusteringHandler.GetSuggestion)
+		fpSuggestions.POST("/:id/approve", m.fpClusteringHandler.ApproveSuggestion)
+		fpSuggestions.POST("/:id/reject", m.fpClusteringHandler.RejectSuggestion)
+	}
+
+	// Usage against the tenant's ingestion quotas, for billing and operations
+	router.GET("/usage", m.quotaHandler.GetUsage)
+	router.PUT("/usage/quotas", m.authMiddleware.RequireRole("admin"), m.quotaHandler.SetQuotas)
+
 	// Dashboard
 	router.GET("/dashboard/metrics", m.dashboardHandler.GetDashboardMetrics)
+	router.GET("/dashboard/summary", m.dashboardHandler.GetDashboardSummary)
+	router.POST("/dashboard/summary/refresh", m.dashboardHandler.RefreshDashboardSummary)
 
 	log.Printf("📡 Scanning & Classification routes registered")
 }
@@ -123,7 +408,9 @@ func (m *ScanningModule) RegisterRoutes(router *gin.RouterGroup) {
 // Shutdown performs cleanup
 func (m *ScanningModule) Shutdown() error {
 	log.Printf("🔌 Shutting down Scanning & Classification Module...")
-	// Cleanup if needed
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
 	return nil
 }
 
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/pkg/normalization"
+)
+
+const (
+	maxClassifyBatchItems = 500
+	maxClassifyValueBytes = 4096
+)
+
+// ClassifyItem is one value to classify, as a DLP proxy or another internal
+// system would see it
+type ClassifyItem struct {
+	PatternName string `json:"pattern_name"`
+	Value       string `json:"value"`
+	ColumnName  string `json:"column_name,omitempty"`
+	Path        string `json:"path,omitempty"`
+}
+
+// ClassifyItemResult is the decision for one item of a batch, in request order.
+// Items that cannot be classified carry an error instead of a decision.
+type ClassifyItemResult struct {
+	Index       int                  `json:"index"`
+	PatternName string               `json:"pattern_name"`
+	Decision    *MultiSignalDecision `json:"decision,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+// BatchClassificationService runs values through the classification engine used at
+// ingestion without storing anything, so other systems can reuse its decisions
+type BatchClassificationService struct {
+	classifier *ClassificationService
+	enrichment *EnrichmentService
+}
+
+// NewBatchClassificationService creates a new batch classification service
+func NewBatchClassificationService(classifier *ClassificationService, enrichment *EnrichmentService) *BatchClassificationService {
+	return &BatchClassificationService{classifier: classifier, enrichment: enrichment}
+}
+
+// Classify returns a decision for each item. Values are normalized and enriched
+// as at ingestion; PII types outside the tenant's jurisdiction profile are refused
+// per item rather than failing the batch.
+func (s *BatchClassificationService) Classify(ctx context.Context, items []ClassifyItem) ([]ClassifyItemResult, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("invalid batch: no items to classify")
+	}
+	if len(items) > maxClassifyBatchItems {
+		return nil, fmt.Errorf("invalid batch: at most %d items may be classified at once", maxClassifyBatchItems)
+	}
+
+	jurisdiction := s.classifier.Jurisdiction(ctx)
+	results := make([]ClassifyItemResult, len(items))
+	for i, item := range items {
+		patternName := strings.ToUpper(strings.TrimSpace(item.PatternName))
+		results[i] = ClassifyItemResult{Index: i, PatternName: patternName}
+
+		switch {
+		case patternName == "":
+			results[i].Error = "pattern_name is required"
+			continue
+		case item.Value == "":
+			results[i].Error = "value is required"
+			continue
+		case len(item.Value) > maxClassifyValueBytes:
+			results[i].Error = fmt.Sprintf("value is longer than %d bytes", maxClassifyValueBytes)
+			continue
+		case !jurisdiction.Allows(patternName):
+			results[i].Error = fmt.Sprintf("PII type %s is not in the %s jurisdiction scope", patternName, jurisdiction.Code)
+			continue
+		}
+
+		value := normalization.Normalize(item.Value)
+		signals := s.enrichment.Enrich(ctx, EnrichmentContext{
+			FilePath:    item.Path,
+			MatchValue:  value,
+			PatternName: patternName,
+			AssetType:   "file",
+			ColumnName:  item.ColumnName,
+		})
+		decision, err := s.classifier.ClassifyMultiSignal(ctx, MultiSignalInput{
+			PatternName:       patternName,
+			FilePath:          item.Path,
+			MatchValue:        value,
+			ColumnName:        item.ColumnName,
+			EnrichmentScore:   s.enrichment.GetEnrichmentScore(signals),
+			EnrichmentSignals: signals,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to classify item %d: %w", i, err)
+		}
+		results[i].Decision = decision
+	}
+	return results, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+)
+
+func TestBatchClassify(t *testing.T) {
+	cfg := &config.Config{Classification: config.ClassificationConfig{
+		WeightRules: 0.40, WeightContext: 0.30, WeightEntropy: 0.10, Threshold: 0.60,
+	}}
+	s := NewBatchClassificationService(NewClassificationService(memory.NewRepository(), cfg), NewEnrichmentService(nil, nil))
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	results, err := s.Classify(ctx, []ClassifyItem{
+		{PatternName: "email_address", Value: "priya@example.in", ColumnName: "customer_email", Path: "crm.customers"},
+		{PatternName: "IN_AADHAAR", Value: "123456789012"},
+		{PatternName: "US_SSN", Value: "123-45-6789"},
+		{PatternName: "IN_PAN", Value: ""},
+	})
+	if err != nil {
+		t.Fatalf("Classify: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected a result per item, got %d", len(results))
+	}
+
+	if d := results[0].Decision; d == nil || d.Classification != "Personal Data" || results[0].PatternName != "EMAIL_ADDRESS" {
+		t.Errorf("expected the email to be classified as personal data, got %+v", results[0])
+	}
+	if d := results[1].Decision; d == nil || d.ConfidenceLevel != "DISCARD" {
+		t.Errorf("expected an invalid Aadhaar number to be discarded by the validation gate, got %+v", results[1])
+	}
+	if results[2].Decision != nil || !strings.Contains(results[2].Error, "jurisdiction") {
+		t.Errorf("expected a PII type outside the jurisdiction to be refused, got %+v", results[2])
+	}
+	if results[3].Decision != nil || results[3].Error == "" || results[3].Index != 3 {
+		t.Errorf("expected an empty value to be refused, got %+v", results[3])
+	}
+
+	if _, err := s.Classify(ctx, nil); err == nil {
+		t.Error("expected an empty batch to be refused")
+	}
+	if _, err := s.Classify(ctx, make([]ClassifyItem, maxClassifyBatchItems+1)); err == nil {
+		t.Error("expected an oversized batch to be refused")
+	}
+}
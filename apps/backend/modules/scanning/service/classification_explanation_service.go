@@ -0,0 +1,482 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	fpentity "github.com/arc-platform/backend/modules/fplearning/entity"
+	fpservice "github.com/arc-platform/backend/modules/fplearning/service"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// ExplanationSignal is one detection signal's contribution to a classification
+type ExplanationSignal struct {
+	Name string `json:"name"`
+	SignalScore
+}
+
+// ExplanationValidation describes who validated the match and how
+type ExplanationValidation struct {
+	HandledBy  string   `json:"handled_by"`
+	Validators []string `json:"validators,omitempty"`
+	Method     string   `json:"method,omitempty"`
+	Passed     bool     `json:"passed"`
+	Note       string   `json:"note,omitempty"`
+}
+
+// AppliedFPRule is an active FP-learning rule recorded for the finding's asset and pattern
+type AppliedFPRule struct {
+	ID            uuid.UUID `json:"id"`
+	LearningType  string    `json:"learning_type"`
+	FieldPath     string    `json:"field_path,omitempty"`
+	Justification string    `json:"justification,omitempty"`
+	Matched       bool      `json:"matched"`
+	MatchType     string    `json:"match_type"`
+	Similarity    float64   `json:"similarity"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// EnvironmentAdjustment is a contextual factor that raised or lowered the finding's weight
+type EnvironmentAdjustment struct {
+	Factor string `json:"factor"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+// RiskPolicyInputs are the values fed into the risk scoring policy
+type RiskPolicyInputs struct {
+	Classification string `json:"classification"`
+	ConfidenceTier string `json:"confidence_tier"`
+	Severity       string `json:"severity"`
+	RiskScoreBreakdown
+}
+
+// ClassificationExplanation is an auditor-facing account of why a finding was classified as it was
+type ClassificationExplanation struct {
+	FindingID              uuid.UUID               `json:"finding_id"`
+	AssetID                uuid.UUID               `json:"asset_id"`
+	AssetName              string                  `json:"asset_name"`
+	AssetPath              string                  `json:"asset_path"`
+	PatternName            string                  `json:"pattern_name"`
+	Classification         string                  `json:"classification"`
+	SubCategory            string                  `json:"sub_category"`
+	DPDPACategory          string                  `json:"dpdpa_category"`
+	RequiresConsent        bool                    `json:"requires_consent"`
+	ConfidenceScore        float64                 `json:"confidence_score"`
+	ConfidenceTier         string                  `json:"confidence_tier"`
+	Justification          string                  `json:"justification"`
+	EngineVersion          string                  `json:"engine_version"`
+	Signals                []ExplanationSignal     `json:"signals"`
+	Validation             *ExplanationValidation  `json:"validation,omitempty"`
+	FPLearningRules        []AppliedFPRule         `json:"fp_learning_rules"`
+	EnvironmentAdjustments []EnvironmentAdjustment `json:"environment_adjustments"`
+	RiskPolicy             RiskPolicyInputs        `json:"risk_policy"`
+	Text                   string                  `json:"text"`
+	GeneratedAt            time.Time               `json:"generated_at"`
+}
+
+// explanationSignals maps each signal to the breakdown keys it has been stored under.
+// The multi-signal engine uses short keys; SDK-verified findings use the *_signal form.
+var explanationSignals = []struct {
+	name string
+	keys []string
+}{
+	{"rule", []string{"rule", "rule_signal"}},
+	{"presidio", []string{"presidio", "presidio_signal"}},
+	{"context", []string{"context", "context_signal"}},
+	{"entropy", []string{"entropy", "entropy_signal"}},
+	{"validation", []string{"validation_signal"}},
+}
+
+// ClassificationExplanationService rebuilds the reasoning behind stored classifications
+type ClassificationExplanationService struct {
+	repo       *persistence.PostgresRepository
+	classifier *ClassificationService
+}
+
+// NewClassificationExplanationService creates a new explanation service
+func NewClassificationExplanationService(repo *persistence.PostgresRepository, classifier *ClassificationService) *ClassificationExplanationService {
+	return &ClassificationExplanationService{
+		repo:       repo,
+		classifier: classifier,
+	}
+}
+
+// Explain builds the explanation for a finding in the caller's tenant
+func (s *ClassificationExplanationService) Explain(ctx context.Context, findingID uuid.UUID) (*ClassificationExplanation, error) {
+	finding, err := s.repo.GetFindingByID(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.repo.GetAssetByID(ctx, finding.AssetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get asset: %w", err)
+	}
+
+	classifications, err := s.repo.GetClassificationsByFindingID(ctx, finding.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classifications: %w", err)
+	}
+	classification := &entity.Classification{}
+	if len(classifications) > 0 {
+		classification = classifications[0]
+	}
+
+	explanation := &ClassificationExplanation{
+		FindingID:       finding.ID,
+		AssetID:         asset.ID,
+		AssetName:       asset.Name,
+		AssetPath:       asset.Path,
+		PatternName:     finding.PatternName,
+		Classification:  classification.ClassificationType,
+		SubCategory:     classification.SubCategory,
+		DPDPACategory:   classification.DPDPACategory,
+		RequiresConsent: classification.RequiresConsent,
+		ConfidenceScore: classification.ConfidenceScore,
+		Justification:   classification.Justification,
+		EngineVersion:   classification.EngineVersion,
+		GeneratedAt:     time.Now(),
+	}
+
+	// Findings ingested before the breakdown was persisted on the classification
+	// carry it in the finding context instead
+	breakdown := classification.SignalBreakdown
+	if len(breakdown) == 0 {
+		breakdown = finding.Context
+	}
+	explanation.Signals = normalizeSignals(breakdown)
+	explanation.ConfidenceTier = s.confidenceTier(explanation.Signals)
+	explanation.Validation = buildValidation(breakdown, finding.EnrichmentSignals)
+
+	explanation.FPLearningRules, err = s.appliedFPRules(ctx, finding, classification.SubCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	fileData := assetFileData(asset)
+	explanation.EnvironmentAdjustments = environmentAdjustments(finding, asset, explanation.Signals)
+	explanation.RiskPolicy = RiskPolicyInputs{
+		Classification:     classification.ClassificationType,
+		ConfidenceTier:     explanation.ConfidenceTier,
+		Severity:           finding.Severity,
+		RiskScoreBreakdown: riskScoreBreakdown(classification.ClassificationType, explanation.ConfidenceTier, fileData),
+	}
+
+	explanation.Text = RenderExplanationText(explanation)
+	return explanation, nil
+}
+
+// confidenceTier re-derives the tier from the ML and context signals, as ingestion does
+func (s *ClassificationExplanationService) confidenceTier(signals []ExplanationSignal) string {
+	var presidio, contextSignal *ExplanationSignal
+	for i := range signals {
+		switch signals[i].Name {
+		case "presidio":
+			presidio = &signals[i]
+		case "context":
+			contextSignal = &signals[i]
+		}
+	}
+	if presidio == nil && contextSignal == nil {
+		return ""
+	}
+
+	var mlConfidence, contextScore float64
+	if presidio != nil {
+		mlConfidence = presidio.Confidence
+	}
+	if contextSignal != nil {
+		contextScore = contextSignal.RawScore
+	}
+	return s.classifier.assignConfidenceTier(mlConfidence, contextScore)
+}
+
+// appliedFPRules lists active FP-learning rules for the finding's asset and pattern,
+// marking the ones that match the finding under the FP-learning similarity rules
+func (s *ClassificationExplanationService) appliedFPRules(ctx context.Context, finding *entity.Finding, piiType string) ([]AppliedFPRule, error) {
+	active := true
+	learnings, err := s.repo.GetAllFPLearnings(ctx, fpentity.FPLearningFilter{
+		TenantID: finding.TenantID,
+		IsActive: &active,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FP learnings: %w", err)
+	}
+
+	fieldPath := findingFieldPath(finding)
+	matchedValue := ""
+	if len(finding.Matches) > 0 {
+		matchedValue = finding.Matches[0]
+	}
+
+	rules := []AppliedFPRule{}
+	for _, fp := range learnings {
+		if fp.AssetID != finding.AssetID {
+			continue
+		}
+		if fp.PatternName != finding.PatternName && (piiType == "" || fp.PIIType != piiType) {
+			continue
+		}
+
+		stored := &fpservice.StoredFPPattern{
+			FieldPath:    fp.FieldPath,
+			MatchedValue: fp.MatchedValue,
+			Pattern:      fpservice.GeneratePattern(fp.MatchedValue, fp.PIIType),
+			PIIType:      fp.PIIType,
+		}
+		match := fpservice.ComputeOverallMatch(stored, fieldPath, matchedValue, fp.PIIType, fpservice.DefaultSimilarityConfig)
+
+		rules = append(rules, AppliedFPRule{
+			ID:            fp.ID,
+			LearningType:  string(fp.LearningType),
+			FieldPath:     fp.FieldPath,
+			Justification: fp.Justification,
+			Matched:       match.IsMatch,
+			MatchType:     match.MatchType,
+			Similarity:    match.Similarity,
+			CreatedAt:     fp.CreatedAt,
+		})
+	}
+	return rules, nil
+}
+
+// normalizeSignals reads a stored signal breakdown in either key format
+func normalizeSignals(breakdown map[string]interface{}) []ExplanationSignal {
+	signals := []ExplanationSignal{}
+	for _, spec := range explanationSignals {
+		for _, key := range spec.keys {
+			raw, ok := breakdown[key].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			score := SignalScore{
+				WeightedScore: floatValue(raw["weighted_score"]),
+				Weight:        floatValue(raw["weight"]),
+				Confidence:    floatValue(raw["confidence"]),
+			}
+			score.Explanation, _ = raw["explanation"].(string)
+			if _, ok := raw["raw_score"]; ok {
+				score.RawScore = floatValue(raw["raw_score"])
+			} else {
+				score.RawScore = score.Confidence
+			}
+			signals = append(signals, ExplanationSignal{Name: spec.name, SignalScore: score})
+			break
+		}
+	}
+	return signals
+}
+
+// buildValidation combines the engine's validation record with the scanner's validators
+func buildValidation(breakdown, enrichment map[string]interface{}) *ExplanationValidation {
+	if v, ok := breakdown["validation"].(map[string]interface{}); ok {
+		validation := &ExplanationValidation{Passed: true}
+		validation.HandledBy, _ = v["handled_by"].(string)
+		validation.Note, _ = v["note"].(string)
+		if validator, ok := v["validator"].(string); ok && validator != "" {
+			validation.Validators = []string{validator}
+		}
+		if passed, ok := v["passed"].(bool); ok {
+			validation.Passed = passed
+		}
+		if reason, ok := v["reason"].(string); ok && reason != "" {
+			validation.Note = reason
+		}
+		return validation
+	}
+
+	validators, ok := enrichment["validators_passed"].([]interface{})
+	if !ok {
+		return nil
+	}
+	validation := &ExplanationValidation{HandledBy: "scanner_sdk", Passed: true}
+	for _, v := range validators {
+		if name, ok := v.(string); ok {
+			validation.Validators = append(validation.Validators, name)
+		}
+	}
+	validation.Method, _ = enrichment["validation_method"].(string)
+	return validation
+}
+
+// environmentAdjustments lists the contextual factors applied to the finding
+func environmentAdjustments(finding *entity.Finding, asset *entity.Asset, signals []ExplanationSignal) []EnvironmentAdjustment {
+	adjustments := []EnvironmentAdjustment{}
+
+	environment := asset.Environment
+	if environment == "" {
+		environment = "unspecified"
+	}
+	if isProductionEnvironment(assetFileData(asset)) {
+		adjustments = append(adjustments, EnvironmentAdjustment{
+			Factor: "asset_environment",
+			Value:  environment,
+			Effect: "Treated as production data; risk environment multiplier 1.0",
+		})
+	} else {
+		adjustments = append(adjustments, EnvironmentAdjustment{
+			Factor: "asset_environment",
+			Value:  environment,
+			Effect: "Treated as non-production data; risk environment multiplier 0.3",
+		})
+	}
+
+	if finding.Environment == "TEST" {
+		adjustments = append(adjustments, EnvironmentAdjustment{
+			Factor: "test_data",
+			Value:  finding.Environment,
+			Effect: "Flagged as a test artifact or synthetic sample at ingestion; review status set to ignored",
+		})
+	}
+
+	for _, signal := range signals {
+		if signal.Name != "rule" {
+			continue
+		}
+		if strings.Contains(signal.Explanation, "high-risk path context") {
+			adjustments = append(adjustments, EnvironmentAdjustment{
+				Factor: "path_context",
+				Value:  asset.Path,
+				Effect: "Rule score boosted 5% for a high-risk path (users, billing, auth, ...)",
+			})
+		}
+		if strings.Contains(signal.Explanation, "reduced: test data") {
+			adjustments = append(adjustments, EnvironmentAdjustment{
+				Factor: "path_test_penalty",
+				Value:  asset.Path,
+				Effect: "Rule score reduced 20% for a test or fixture path",
+			})
+		}
+	}
+
+	return adjustments
+}
+
+// RenderExplanationText formats an explanation as plain text for audit records
+func RenderExplanationText(e *ClassificationExplanation) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Classification explanation for finding %s\n", e.FindingID)
+	fmt.Fprintf(&b, "Pattern %q on asset %s (%s)\n", e.PatternName, e.AssetName, e.AssetPath)
+	fmt.Fprintf(&b, "Result: %s / %s, confidence %.2f", textOr(e.Classification, "unclassified"), textOr(e.SubCategory, "n/a"), e.ConfidenceScore)
+	if e.ConfidenceTier != "" {
+		fmt.Fprintf(&b, " (%s)", e.ConfidenceTier)
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "DPDPA category: %s; consent required: %s\n", textOr(e.DPDPACategory, "n/a"), yesNo(e.RequiresConsent))
+	fmt.Fprintf(&b, "Engine version: %s\n", textOr(e.EngineVersion, "not recorded"))
+
+	b.WriteString("\nSignals:\n")
+	if len(e.Signals) == 0 {
+		b.WriteString("  - not recorded for this finding\n")
+	}
+	for _, s := range e.Signals {
+		fmt.Fprintf(&b, "  - %s: score %.2f x weight %.2f = %.2f", s.Name, s.RawScore, s.Weight, s.WeightedScore)
+		if s.Explanation != "" {
+			fmt.Fprintf(&b, " (%s)", s.Explanation)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nValidation:\n")
+	if e.Validation == nil {
+		b.WriteString("  - not recorded for this finding\n")
+	} else {
+		fmt.Fprintf(&b, "  - handled by %s; passed: %s\n", textOr(e.Validation.HandledBy, "unknown"), yesNo(e.Validation.Passed))
+		if len(e.Validation.Validators) > 0 {
+			fmt.Fprintf(&b, "  - validators: %s", strings.Join(e.Validation.Validators, ", "))
+			if e.Validation.Method != "" {
+				fmt.Fprintf(&b, " (%s)", e.Validation.Method)
+			}
+			b.WriteString("\n")
+		}
+		if e.Validation.Note != "" {
+			fmt.Fprintf(&b, "  - note: %s\n", e.Validation.Note)
+		}
+	}
+
+	b.WriteString("\nFP-learning rules:\n")
+	if len(e.FPLearningRules) == 0 {
+		b.WriteString("  - none active for this asset and pattern\n")
+	}
+	for _, r := range e.FPLearningRules {
+		status := "does not match this finding"
+		if r.Matched {
+			status = fmt.Sprintf("matches this finding (%s, similarity %.2f)", r.MatchType, r.Similarity)
+		}
+		fmt.Fprintf(&b, "  - %s rule %s on %s: %s", r.LearningType, r.ID, textOr(r.FieldPath, "any field"), status)
+		if r.Justification != "" {
+			fmt.Fprintf(&b, "; justification: %s", r.Justification)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\nEnvironment adjustments:\n")
+	for _, a := range e.EnvironmentAdjustments {
+		fmt.Fprintf(&b, "  - %s = %s: %s\n", a.Factor, a.Value, a.Effect)
+	}
+
+	p := e.RiskPolicy
+	b.WriteString("\nRisk policy:\n")
+	fmt.Fprintf(&b, "  - classification weight %.0f x 0.6 + confidence multiplier %.2f x 20 + environment multiplier %.2f x 20 = %d/100\n",
+		p.ClassificationWeight, p.ConfidenceMultiplier, p.EnvironmentMultiplier, p.Score)
+	fmt.Fprintf(&b, "  - severity: %s\n", textOr(p.Severity, "n/a"))
+
+	if e.Justification != "" {
+		fmt.Fprintf(&b, "\nJustification: %s\n", e.Justification)
+	}
+
+	return b.String()
+}
+
+// assetFileData exposes the asset fields the environment checks look at
+func assetFileData(asset *entity.Asset) map[string]interface{} {
+	fileData := map[string]interface{}{}
+	if asset.Environment != "" {
+		fileData["environment"] = asset.Environment
+	}
+	if db, ok := asset.FileMetadata["database"].(string); ok {
+		fileData["database"] = db
+	}
+	return fileData
+}
+
+// findingFieldPath is the table.column or file location FP-learning rules are keyed on
+func findingFieldPath(finding *entity.Finding) string {
+	table, _ := finding.Context["table"].(string)
+	column, _ := finding.Context["column"].(string)
+	switch {
+	case table != "" && column != "":
+		return table + "." + column
+	case column != "":
+		return column
+	default:
+		return table
+	}
+}
+
+func floatValue(v interface{}) float64 {
+	if f, ok := v.(float64); ok {
+		return f
+	}
+	return 0
+}
+
+func textOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
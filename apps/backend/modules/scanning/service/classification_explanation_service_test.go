@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// roundTrip stores and reloads a breakdown the way the classifications table does
+func roundTrip(t *testing.T, breakdown map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	raw, err := json.Marshal(breakdown)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return decoded
+}
+
+func TestNormalizeSignals_EngineBreakdown(t *testing.T) {
+	breakdown := roundTrip(t, map[string]interface{}{
+		"rule":     SignalScore{RawScore: 0.99, WeightedScore: 0.297, Weight: 0.3, Confidence: 0.99, Explanation: "Rules: Aadhaar pattern detected (reduced: test data)"},
+		"presidio": SignalScore{Explanation: "Presidio handled by scanner SDK (Intelligence-at-Edge)"},
+		"context":  SignalScore{RawScore: 0.8, WeightedScore: 0.16, Weight: 0.2, Confidence: 0.8},
+		"validation": map[string]interface{}{
+			"handled_by":        "scanner_sdk",
+			"backend_validated": false,
+			"note":              "Intelligence-at-Edge - validation in scanner only",
+		},
+	})
+
+	signals := normalizeSignals(breakdown)
+	if len(signals) != 3 {
+		t.Fatalf("expected 3 signals, got %d: %+v", len(signals), signals)
+	}
+	if signals[0].Name != "rule" || signals[0].RawScore != 0.99 || signals[0].Weight != 0.3 {
+		t.Errorf("unexpected rule signal: %+v", signals[0])
+	}
+
+	validation := buildValidation(breakdown, nil)
+	if validation == nil || validation.HandledBy != "scanner_sdk" || !validation.Passed {
+		t.Errorf("unexpected validation: %+v", validation)
+	}
+
+	s := &ClassificationExplanationService{classifier: &ClassificationService{}}
+	if tier := s.confidenceTier(signals); tier != "HIGH_CONFIDENCE" {
+		t.Errorf("expected HIGH_CONFIDENCE from context 0.8, got %s", tier)
+	}
+
+	adjustments := environmentAdjustments(&entity.Finding{}, &entity.Asset{Environment: "dev", Path: "tests/users.csv"}, signals)
+	if len(adjustments) != 2 || adjustments[0].Factor != "asset_environment" || adjustments[1].Factor != "path_test_penalty" {
+		t.Errorf("unexpected adjustments: %+v", adjustments)
+	}
+}
+
+func TestNormalizeSignals_SDKBreakdown(t *testing.T) {
+	vf := &VerifiedFinding{
+		PIIType:          "IN_PAN",
+		ValidatorsPassed: []string{"format", "checksum"},
+		ValidationMethod: "regex+checksum",
+		MLConfidence:     0.9,
+		ContextKeywords:  []string{"pan"},
+		SDKVersion:       "1.0",
+	}
+	adapter := NewSDKAdapter()
+	classification := adapter.MapToClassification(vf, uuid.New())
+	finding := adapter.MapToFinding(vf, uuid.New(), uuid.New())
+
+	signals := normalizeSignals(roundTrip(t, classification.SignalBreakdown))
+	if len(signals) != 5 {
+		t.Fatalf("expected 5 signals, got %d", len(signals))
+	}
+	if signals[1].Name != "presidio" || signals[1].RawScore != 0.9 {
+		t.Errorf("expected the ML confidence to be used as the raw score, got %+v", signals[1])
+	}
+
+	var total float64
+	for _, s := range signals {
+		total += s.WeightedScore
+	}
+	if diff := total - classification.ConfidenceScore; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("weighted scores sum to %.4f, classification score is %.4f", total, classification.ConfidenceScore)
+	}
+
+	validation := buildValidation(nil, roundTrip(t, finding.EnrichmentSignals))
+	if validation == nil || len(validation.Validators) != 2 || validation.Method != "regex+checksum" {
+		t.Errorf("unexpected validation: %+v", validation)
+	}
+}
+
+func TestRenderExplanationText(t *testing.T) {
+	explanation := &ClassificationExplanation{
+		FindingID:      uuid.New(),
+		PatternName:    "aadhaar",
+		AssetName:      "users.csv",
+		AssetPath:      "/data/users.csv",
+		Classification: "Sensitive Personal Data",
+		ConfidenceTier: "CONFIRMED",
+		Signals: []ExplanationSignal{
+			{Name: "rule", SignalScore: SignalScore{RawScore: 0.99, Weight: 0.3, WeightedScore: 0.297, Explanation: "Rules: Aadhaar pattern detected"}},
+		},
+		FPLearningRules: []AppliedFPRule{
+			{ID: uuid.New(), LearningType: "false_positive", FieldPath: "users.id", Matched: true, MatchType: "pattern", Similarity: 0.95},
+		},
+		EnvironmentAdjustments: []EnvironmentAdjustment{
+			{Factor: "asset_environment", Value: "prod", Effect: "Treated as production data; risk environment multiplier 1.0"},
+		},
+		RiskPolicy: RiskPolicyInputs{
+			Severity:           "Critical",
+			RiskScoreBreakdown: riskScoreBreakdown("Sensitive Personal Data", "CONFIRMED", nil),
+		},
+	}
+
+	text := RenderExplanationText(explanation)
+	for _, want := range []string{
+		"Result: Sensitive Personal Data / n/a, confidence 0.00 (CONFIRMED)",
+		"rule: score 0.99 x weight 0.30 = 0.30 (Rules: Aadhaar pattern detected)",
+		"Validation:\n  - not recorded for this finding",
+		"false_positive rule",
+		"matches this finding (pattern, similarity 0.95)",
+		"= 100/100",
+		"severity: Critical",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected text to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestRiskScoreBreakdownMatchesRiskScore(t *testing.T) {
+	fileData := map[string]interface{}{"environment": "staging"}
+	breakdown := riskScoreBreakdown("Personal Data", "HIGH_CONFIDENCE", fileData)
+
+	if breakdown.Score != calculateComprehensiveRiskScore("Personal Data", "HIGH_CONFIDENCE", fileData) {
+		t.Errorf("breakdown score %d differs from the risk score", breakdown.Score)
+	}
+	if breakdown.Production || breakdown.EnvironmentMultiplier != 0.3 {
+		t.Errorf("expected staging to be treated as non-production: %+v", breakdown)
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"gopkg.in/yaml.v3"
+)
+
+// Run with -update to rewrite the golden files from the current classifier:
+//
+//	go test ./modules/scanning/service -run TestClassificationFixtures -update
+var updateGolden = flag.Bool("update", false, "rewrite the classification golden files")
+
+const classificationFixtureDir = "testdata/classification"
+
+// classificationFixture is one fixture file: cases classified with the same weights
+type classificationFixture struct {
+	Weights *fixtureWeights      `yaml:"weights"` // Defaults to defaultFixtureWeights
+	Cases   []classificationCase `yaml:"cases"`
+}
+
+type fixtureWeights struct {
+	Rules   float64 `yaml:"rules"`
+	Context float64 `yaml:"context"`
+	Entropy float64 `yaml:"entropy"`
+}
+
+type classificationCase struct {
+	Name   string                 `yaml:"name"`
+	Input  classificationCaseIn   `yaml:"input"`
+	Expect classificationCaseWant `yaml:"expect"`
+}
+
+type classificationCaseIn struct {
+	PatternName      string                 `yaml:"pattern_name"`
+	FilePath         string                 `yaml:"file_path"`
+	MatchValue       string                 `yaml:"match_value"`
+	ColumnName       string                 `yaml:"column_name"`
+	FileData         map[string]interface{} `yaml:"file_data"`
+	EnrichmentScore  float64                `yaml:"enrichment_score"`
+	Environment      string                 `yaml:"environment"`
+	AssetSemantics   float64                `yaml:"asset_semantics"`
+	Entropy          float64                `yaml:"entropy"`
+	CharsetDiversity float64                `yaml:"charset_diversity"`
+}
+
+// classificationCaseWant holds the decision fields a case pins down; fields left
+// out are not checked here, only through the golden file
+type classificationCaseWant struct {
+	Classification  string   `yaml:"classification"`
+	SubCategory     string   `yaml:"sub_category"`
+	ConfidenceLevel string   `yaml:"confidence_level"`
+	FinalScore      *float64 `yaml:"final_score"`
+	DPDPACategory   string   `yaml:"dpdpa_category"`
+	RequiresConsent *bool    `yaml:"requires_consent"`
+}
+
+var defaultFixtureWeights = fixtureWeights{Rules: 0.40, Context: 0.30, Entropy: 0.10}
+
+func (in classificationCaseIn) multiSignalInput() MultiSignalInput {
+	return MultiSignalInput{
+		PatternName:     in.PatternName,
+		FilePath:        in.FilePath,
+		MatchValue:      in.MatchValue,
+		ColumnName:      in.ColumnName,
+		FileData:        in.FileData,
+		EnrichmentScore: in.EnrichmentScore,
+		EnrichmentSignals: EnrichmentSignals{
+			Environment:      in.Environment,
+			AssetSemantics:   in.AssetSemantics,
+			Entropy:          in.Entropy,
+			CharsetDiversity: in.CharsetDiversity,
+		},
+	}
+}
+
+func (want classificationCaseWant) check(t *testing.T, got *MultiSignalDecision) {
+	t.Helper()
+	if want.Classification != "" && got.Classification != want.Classification {
+		t.Errorf("classification: expected %q, got %q", want.Classification, got.Classification)
+	}
+	if want.SubCategory != "" && got.SubCategory != want.SubCategory {
+		t.Errorf("sub_category: expected %q, got %q", want.SubCategory, got.SubCategory)
+	}
+	if want.ConfidenceLevel != "" && got.ConfidenceLevel != want.ConfidenceLevel {
+		t.Errorf("confidence_level: expected %q, got %q", want.ConfidenceLevel, got.ConfidenceLevel)
+	}
+	if want.FinalScore != nil && math.Abs(got.FinalScore-*want.FinalScore) > 1e-9 {
+		t.Errorf("final_score: expected %v, got %v", *want.FinalScore, got.FinalScore)
+	}
+	if want.DPDPACategory != "" && got.DPDPACategory != want.DPDPACategory {
+		t.Errorf("dpdpa_category: expected %q, got %q", want.DPDPACategory, got.DPDPACategory)
+	}
+	if want.RequiresConsent != nil && got.RequiresConsent != *want.RequiresConsent {
+		t.Errorf("requires_consent: expected %t, got %t", *want.RequiresConsent, got.RequiresConsent)
+	}
+}
+
+// TestClassificationFixtures classifies the cases of every fixture file, checks
+// the fields each case expects and compares the full decisions with the file's
+// golden output, so any change to ClassifyMultiSignal shows up as a fixture diff
+func TestClassificationFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join(classificationFixtureDir, "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("no fixtures in %s", classificationFixtureDir)
+	}
+
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".yaml"), func(t *testing.T) {
+			runClassificationFixture(t, path)
+		})
+	}
+}
+
+func runClassificationFixture(t *testing.T, path string) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture classificationFixture
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fixture); err != nil {
+		t.Fatalf("parse %s: %v", path, err)
+	}
+
+	weights := defaultFixtureWeights
+	if fixture.Weights != nil {
+		weights = *fixture.Weights
+	}
+	// A fixed version keeps CLASSIFIER_VERSION out of the golden output
+	classifier := NewClassificationService(memory.NewRepository(), &config.Config{}).withEngine("fixture", config.ClassificationConfig{
+		WeightRules: weights.Rules, WeightContext: weights.Context, WeightEntropy: weights.Entropy, Threshold: 0.60,
+	})
+
+	decisions := make(map[string]*MultiSignalDecision, len(fixture.Cases))
+	for _, c := range fixture.Cases {
+		if c.Name == "" || decisions[c.Name] != nil {
+			t.Fatalf("every case needs a unique name, got %q", c.Name)
+		}
+		decision, err := classifier.ClassifyMultiSignal(context.Background(), c.Input.multiSignalInput())
+		if err != nil {
+			t.Fatalf("%s: ClassifyMultiSignal: %v", c.Name, err)
+		}
+		decisions[c.Name] = decision
+		t.Run(c.Name, func(t *testing.T) { c.Expect.check(t, decision) })
+	}
+
+	got, err := json.MarshalIndent(decisions, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := strings.TrimSuffix(path, ".yaml") + ".golden.json"
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("decisions differ from %s in %s; if the change is intended, run with -update and review the diff",
+			golden, strings.Join(changedCases(t, want, decisions), ", "))
+	}
+}
+
+// changedCases names the cases whose decision differs from the golden output,
+// including cases added to or removed from the fixture since it was written
+func changedCases(t *testing.T, golden []byte, decisions map[string]*MultiSignalDecision) []string {
+	t.Helper()
+	var want map[string]json.RawMessage
+	if err := json.Unmarshal(golden, &want); err != nil {
+		t.Fatalf("parse golden output: %v", err)
+	}
+
+	var changed []string
+	for name, decision := range decisions {
+		got, err := json.Marshal(decision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, want[name]); err != nil || !bytes.Equal(compact.Bytes(), got) {
+			changed = append(changed, fmt.Sprintf("%q", name))
+		}
+	}
+	for name := range want {
+		if decisions[name] == nil {
+			changed = append(changed, fmt.Sprintf("%q (removed)", name))
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -8,13 +8,17 @@ import (
 	"strings"
 
 	"github.com/arc-platform/backend/modules/shared/config"
-	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/pkg/validation"
 )
 
 // ==================================================================================
 // LOCKED PII SCOPE - Intelligence-at-Edge Architecture
 // ==================================================================================
-// Only these 11 India PII types are in scope. All others MUST be rejected.
+// These 11 India PII types are the scope of the default India jurisdiction profile.
+// Ingestion applies the tenant's profile (JurisdictionService); all other types
+// MUST be rejected.
 // Language: English only
 // ==================================================================================
 var LOCKED_PII_TYPES = map[string]bool{
@@ -31,7 +35,7 @@ var LOCKED_PII_TYPES = map[string]bool{
 	"IN_DRIVING_LICENSE": true, // Driving License (India)
 }
 
-// IsLockedPIIType validates if a PII type is in the locked scope
+// IsLockedPIIType validates if a PII type is in the locked India scope
 func IsLockedPIIType(piiType string) bool {
 	normalized := strings.ToUpper(strings.TrimSpace(piiType))
 	return LOCKED_PII_TYPES[normalized]
@@ -39,13 +43,15 @@ func IsLockedPIIType(piiType string) bool {
 
 // ClassificationService handles PII classification with multi-signal intelligence
 type ClassificationService struct {
-	repo          *persistence.PostgresRepository
+	repo          repository.ClassificationRepository
 	config        *config.Config
 	engineVersion string
+	mappings      *ComplianceMappingService
+	jurisdictions *JurisdictionService
 }
 
 // NewClassificationService creates a new classification service
-func NewClassificationService(repo *persistence.PostgresRepository, cfg *config.Config) *ClassificationService {
+func NewClassificationService(repo repository.ClassificationRepository, cfg *config.Config) *ClassificationService {
 	// MEDIUM FIX #12: Load version from environment
 	version := os.Getenv("CLASSIFIER_VERSION")
 	if version == "" {
@@ -59,6 +65,47 @@ func NewClassificationService(repo *persistence.PostgresRepository, cfg *config.
 	}
 }
 
+// SetComplianceMappings resolves categories from the tenant's mapping instead of the built-in one
+func (s *ClassificationService) SetComplianceMappings(mappings *ComplianceMappingService) {
+	s.mappings = mappings
+}
+
+// SetJurisdictions applies each tenant's jurisdiction profile instead of the India one
+func (s *ClassificationService) SetJurisdictions(jurisdictions *JurisdictionService) {
+	s.jurisdictions = jurisdictions
+}
+
+// withEngine returns a copy of the service that reports version and scores with the
+// given weights, sharing the repository and compliance mappings
+func (s *ClassificationService) withEngine(version string, weights config.ClassificationConfig) *ClassificationService {
+	cfg := *s.config
+	cfg.Classification = weights
+	return &ClassificationService{
+		repo:          s.repo,
+		config:        &cfg,
+		engineVersion: version,
+		mappings:      s.mappings,
+		jurisdictions: s.jurisdictions,
+	}
+}
+
+// Jurisdiction returns the jurisdiction profile that applies to the tenant in ctx
+func (s *ClassificationService) Jurisdiction(ctx context.Context) *entity.JurisdictionProfile {
+	return s.jurisdictions.Resolve(ctx)
+}
+
+// CategoryMapping returns the category mapping that applies to the tenant in ctx: its
+// saved mapping for the framework of its jurisdiction, or else the profile's categories
+func (s *ClassificationService) CategoryMapping(ctx context.Context) *entity.ComplianceMapping {
+	profile := s.Jurisdiction(ctx)
+	if s.mappings != nil {
+		if mapping := s.mappings.Resolve(ctx, profile.Framework); !mapping.IsDefault() {
+			return mapping
+		}
+	}
+	return profile.ComplianceMapping()
+}
+
 // ClassificationResult is the legacy result format for backward compatibility
 type ClassificationResult struct {
 	ClassificationType string                 `json:"classification_type"`
@@ -142,16 +189,35 @@ func (s *ClassificationService) ClassifyMultiSignal(ctx context.Context, input M
 	}
 	decision.PresidioSignal = presidioSignal
 
-	// STAGE 3: VALIDATION GATE - REMOVED (Intelligence-at-Edge)
+	// STAGE 3: VALIDATION GATE (Intelligence-at-Edge)
 	// ========================================================
-	// Backend NO LONGER validates findings - this is now handled exclusively
-	// by the scanner SDK before findings are sent to backend.
+	// Validation is handled by the scanner SDK before findings are sent to backend.
 	// Scanner SDK performs:
 	//   - Presidio ML analysis (embedded)
 	//   - Mathematical validation (Luhn, Verhoeff, PAN format)
 	//   - Context extraction
-	// Backend receives ONLY verified findings with proof of validation.
+	// Exception: the backend re-checks unmasked values of PII types whose
+	// jurisdiction profile names a validator, and Aadhaar patterns (which match
+	// any 12-digit number) with Verhoeff + UIDAI rules, and discards failures.
 	// ========================================================
+	if validator, checked, valid, reason := validateMatch(s.Jurisdiction(ctx), input.PatternName, input.MatchValue); checked && !valid {
+		decision.Classification = "Non-PII"
+		decision.SubCategory = "Other"
+		decision.FinalScore = 0.0
+		decision.ConfidenceLevel = "DISCARD"
+		decision.Justification = "Rejected by validation gate: " + validator.subject + " " + strings.ToLower(reason)
+		decision.SignalBreakdown = map[string]interface{}{
+			"rule": ruleSignal,
+			"validation": map[string]interface{}{
+				"handled_by":        "backend",
+				"backend_validated": true,
+				"validator":         validator.name,
+				"passed":            false,
+				"reason":            reason,
+			},
+		}
+		return decision, nil
+	}
 
 	// STAGE 4: Enrichment (ONLY for validated findings)
 	contextSignal := s.classifyWithContext(input)
@@ -175,7 +241,8 @@ func (s *ClassificationService) ClassifyMultiSignal(ctx context.Context, input M
 	decision.SubCategory = s.extractSubCategory(decision.Classification)
 
 	// Set DPDPA metadata
-	s.setDPDPAMetadata(decision)
+	mapping := s.CategoryMapping(ctx)
+	setDPDPAMetadata(decision, mapping)
 
 	// Build comprehensive justification
 	decision.Justification = s.buildJustification(decision)
@@ -191,6 +258,7 @@ func (s *ClassificationService) ClassifyMultiSignal(ctx context.Context, input M
 			"backend_validated": false,
 			"note":              "Intelligence-at-Edge - validation in scanner only",
 		},
+		"category_mapping": categoryMappingSignal(mapping),
 	}
 
 	return decision, nil
@@ -242,21 +310,18 @@ func (s *ClassificationService) extractClassificationFromPattern(patternName str
 	return "Non-PII"
 }
 
-// setDPDPAMetadata assigns DPDPA compliance metadata
-func (s *ClassificationService) setDPDPAMetadata(decision *MultiSignalDecision) {
-	switch decision.Classification {
-	case "Sensitive Personal Data":
-		decision.DPDPACategory = "Sensitive Personal Data"
-		decision.RequiresConsent = true
-	case "Personal Data":
-		decision.DPDPACategory = "Personal Data"
-		decision.RequiresConsent = true
-	case "Secrets":
-		decision.DPDPACategory = "N/A"
-		decision.RequiresConsent = false
-	default:
-		decision.DPDPACategory = "N/A"
-		decision.RequiresConsent = false
+// setDPDPAMetadata assigns DPDPA compliance metadata from the tenant's category mapping
+func setDPDPAMetadata(decision *MultiSignalDecision, mapping *entity.ComplianceMapping) {
+	category := mapping.ForClassification(decision.Classification)
+	decision.DPDPACategory = category.Category
+	decision.RequiresConsent = category.RequiresConsent
+}
+
+// categoryMappingSignal records which mapping version assigned the category
+func categoryMappingSignal(mapping *entity.ComplianceMapping) map[string]interface{} {
+	return map[string]interface{}{
+		"framework": mapping.Framework,
+		"version":   mapping.Version,
 	}
 }
 
@@ -409,6 +474,46 @@ func (s *ClassificationService) buildJustification(decision *MultiSignalDecision
 	)
 }
 
+// matchValidator names the check a match value was rejected by
+type matchValidator struct {
+	name    string
+	subject string // What the justification says failed
+}
+
+// validateMatch validates a match value with the validator the jurisdiction profile
+// names for the pattern's PII type, or as Aadhaar for Aadhaar patterns without one.
+// checked is false when neither applies and for masked or missing values.
+func validateMatch(profile *entity.JurisdictionProfile, patternName, matchValue string) (validator matchValidator, checked bool, valid bool, reason string) {
+	if rule, ok := profile.Rule(patternName); ok && rule.Validator != "" && rule.Validator != "aadhaar" {
+		validate, ok := validation.LookupValidator(rule.Validator)
+		value := strings.TrimSpace(matchValue)
+		if !ok || value == "" || strings.ContainsAny(value, "*#") {
+			return matchValidator{}, false, false, ""
+		}
+		valid, reason = validate(value)
+		return matchValidator{name: rule.Validator, subject: strings.ToUpper(strings.TrimSpace(patternName))}, true, valid, reason
+	}
+
+	checked, valid, reason = validateAadhaarMatch(patternName, matchValue)
+	return matchValidator{name: "aadhaar_verhoeff", subject: "Aadhaar"}, checked, valid, reason
+}
+
+// validateAadhaarMatch validates the match value of an Aadhaar pattern.
+// checked is false for other patterns and for masked or missing values.
+func validateAadhaarMatch(patternName, matchValue string) (checked bool, valid bool, reason string) {
+	if !containsStrict(strings.ToLower(patternName), []string{"aadhaar", "uidai", "adhaar", "aadhar"}) {
+		return false, false, ""
+	}
+
+	value := strings.TrimSpace(matchValue)
+	if value == "" || strings.ContainsAny(value, "*xX#") {
+		return false, false, ""
+	}
+
+	valid, reason = validation.ValidateAadhaarWithDetails(value)
+	return true, valid, reason
+}
+
 // containsStrict checks word boundaries
 func containsStrict(text string, keywords []string) bool {
 	for _, kw := range keywords {
@@ -445,7 +550,7 @@ func (s *ClassificationService) Classify(patternName, filePath, sampleText strin
 	return s.classifyLegacy(patternName, filePath, sampleText, fileData)
 }
 
-func (s *ClassificationService) classifyLegacy(patternName, filePath, _ string, fileData map[string]interface{}) ClassificationResult {
+func (s *ClassificationService) classifyLegacy(patternName, filePath, sampleText string, fileData map[string]interface{}) ClassificationResult {
 	signals := map[string]interface{}{
 		"pattern_match": true,
 		"context_score": 0.0,
@@ -494,6 +599,18 @@ func (s *ClassificationService) classifyLegacy(patternName, filePath, _ string,
 		result.DPDPACategory = "Sensitive Personal Data"
 		result.ConfidenceScore = 0.99
 		result.Justification = "Pattern indicates Aadhaar"
+
+		// Same validation gate as the multi-signal engine (used by regression runs)
+		if checked, valid, reason := validateAadhaarMatch(patternName, sampleText); checked && !valid {
+			signals["validation_failed"] = reason
+			result.ClassificationType = "Non-PII"
+			result.SubCategory = "Other"
+			result.DPDPACategory = ""
+			result.ConfidenceScore = 0.0
+			result.Justification = "Pattern indicates Aadhaar but value failed validation: " + reason
+			result.Signals = signals
+			return result
+		}
 	} else if containsStrict(lowerPattern, []string{"phone", "mobile", "cellphone"}) || containsStrict(lowerCol, []string{"phone", "mobile"}) {
 		result.ClassificationType = "Personal Data"
 		result.SubCategory = "Phone Number"
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	// complianceMappingCacheTTL bounds how long other replicas keep classifying with a replaced mapping
+	complianceMappingCacheTTL = time.Minute
+
+	// maxCategoryMappings caps the entries of each mapping table
+	maxCategoryMappings = 200
+)
+
+// ComplianceMappingService manages the versioned per-tenant mappings that assign
+// framework categories and consent requirements to classified findings
+type ComplianceMappingService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+
+	mu    sync.RWMutex
+	cache map[string]cachedComplianceMapping
+}
+
+type cachedComplianceMapping struct {
+	mapping  *entity.ComplianceMapping
+	loadedAt time.Time
+}
+
+// ComplianceMappingInput is the payload for saving a new mapping version
+type ComplianceMappingInput struct {
+	ClassificationCategories map[string]entity.CategoryMapping `json:"classification_categories"`
+	PIITypeCategories        map[string]entity.CategoryMapping `json:"pii_type_categories"`
+	Comment                  string                            `json:"comment"`
+}
+
+// NewComplianceMappingService creates a new compliance mapping service
+func NewComplianceMappingService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *ComplianceMappingService {
+	return &ComplianceMappingService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		cache:       make(map[string]cachedComplianceMapping),
+	}
+}
+
+// GetCurrent retrieves the tenant's active mapping for a framework, or the built-in
+// default when the tenant has not saved one
+func (s *ComplianceMappingService) GetCurrent(ctx context.Context, framework string) (*entity.ComplianceMapping, error) {
+	framework, err := normalizeFramework(framework)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := s.repo.GetCurrentComplianceMapping(ctx, framework)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil {
+		return entity.DefaultComplianceMapping(framework), nil
+	}
+	return mapping, nil
+}
+
+// GetVersion retrieves one version of the tenant's mapping. Version 0 is the built-in default.
+func (s *ComplianceMappingService) GetVersion(ctx context.Context, framework string, version int) (*entity.ComplianceMapping, error) {
+	framework, err := normalizeFramework(framework)
+	if err != nil {
+		return nil, err
+	}
+	if version < 0 {
+		return nil, fmt.Errorf("invalid mapping version")
+	}
+	if version == 0 {
+		return entity.DefaultComplianceMapping(framework), nil
+	}
+	return s.repo.GetComplianceMappingVersion(ctx, framework, version)
+}
+
+// ListHistory lists every saved version of the tenant's mapping, newest first
+func (s *ComplianceMappingService) ListHistory(ctx context.Context, framework string) ([]*entity.ComplianceMapping, error) {
+	framework, err := normalizeFramework(framework)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.repo.ListComplianceMappings(ctx, framework)
+	if err != nil {
+		return nil, err
+	}
+	if mappings == nil {
+		mappings = []*entity.ComplianceMapping{}
+	}
+	return mappings, nil
+}
+
+// Update validates the input and saves it as the tenant's next mapping version. It
+// applies to findings classified from the next ingestion on.
+func (s *ComplianceMappingService) Update(ctx context.Context, framework string, input ComplianceMappingInput, createdBy string) (*entity.ComplianceMapping, error) {
+	framework, err := normalizeFramework(framework)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &entity.ComplianceMapping{
+		ID:        uuid.New(),
+		Framework: framework,
+		Comment:   strings.TrimSpace(input.Comment),
+		CreatedBy: createdBy,
+	}
+	if mapping.ClassificationCategories, err = normalizeCategoryMappings("classification", input.ClassificationCategories, false); err != nil {
+		return nil, err
+	}
+	if mapping.PIITypeCategories, err = normalizeCategoryMappings("PII type", input.PIITypeCategories, true); err != nil {
+		return nil, err
+	}
+	if len(mapping.ClassificationCategories) == 0 && len(mapping.PIITypeCategories) == 0 {
+		return nil, fmt.Errorf("invalid mapping: at least one category mapping is required")
+	}
+
+	return s.save(ctx, mapping, nil)
+}
+
+// RestoreVersion saves a copy of an earlier version, or of the built-in default for
+// version 0, as the tenant's next mapping version
+func (s *ComplianceMappingService) RestoreVersion(ctx context.Context, framework string, version int, createdBy string) (*entity.ComplianceMapping, error) {
+	previous, err := s.GetVersion(ctx, framework, version)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &entity.ComplianceMapping{
+		ID:                       uuid.New(),
+		Framework:                previous.Framework,
+		ClassificationCategories: previous.ClassificationCategories,
+		PIITypeCategories:        previous.PIITypeCategories,
+		Comment:                  fmt.Sprintf("Restored from version %d", version),
+		CreatedBy:                createdBy,
+	}
+	return s.save(ctx, mapping, map[string]interface{}{"restored_from": version})
+}
+
+// Resolve returns the mapping that classification applies for the tenant in ctx. It is
+// cached briefly and falls back to the built-in default if the mapping cannot be loaded.
+func (s *ComplianceMappingService) Resolve(ctx context.Context, framework string) *entity.ComplianceMapping {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return entity.DefaultComplianceMapping(framework)
+	}
+	key := complianceMappingCacheKey(tenantID, framework)
+
+	s.mu.RLock()
+	cached, ok := s.cache[key]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < complianceMappingCacheTTL {
+		return cached.mapping
+	}
+
+	mapping, err := s.GetCurrent(ctx, framework)
+	if err != nil {
+		return entity.DefaultComplianceMapping(framework)
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedComplianceMapping{mapping: mapping, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return mapping
+}
+
+func (s *ComplianceMappingService) save(ctx context.Context, mapping *entity.ComplianceMapping, extra map[string]interface{}) (*entity.ComplianceMapping, error) {
+	if err := s.repo.CreateComplianceMapping(ctx, mapping); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.cache, complianceMappingCacheKey(mapping.TenantID, mapping.Framework))
+	s.mu.Unlock()
+
+	if s.auditLogger != nil {
+		metadata := map[string]interface{}{
+			"framework":                 mapping.Framework,
+			"version":                   mapping.Version,
+			"classification_categories": len(mapping.ClassificationCategories),
+			"pii_type_categories":       len(mapping.PIITypeCategories),
+		}
+		for k, v := range extra {
+			metadata[k] = v
+		}
+		_ = s.auditLogger.Record(ctx, "COMPLIANCE_MAPPING_UPDATED", "compliance_mapping", mapping.ID.String(), metadata)
+	}
+	return mapping, nil
+}
+
+func complianceMappingCacheKey(tenantID uuid.UUID, framework string) string {
+	return tenantID.String() + "/" + framework
+}
+
+func normalizeFramework(framework string) (string, error) {
+	framework = strings.ToUpper(strings.TrimSpace(framework))
+	if !entity.IsComplianceFramework(framework) {
+		return "", fmt.Errorf("invalid framework %q: must be one of DPDPA, GDPR, CCPA, PDPA", framework)
+	}
+	return framework, nil
+}
+
+// normalizeCategoryMappings trims keys and categories, upper-casing keys that are PII types
+func normalizeCategoryMappings(kind string, in map[string]entity.CategoryMapping, upperKeys bool) (map[string]entity.CategoryMapping, error) {
+	if len(in) > maxCategoryMappings {
+		return nil, fmt.Errorf("invalid mapping: at most %d %s categories are allowed", maxCategoryMappings, kind)
+	}
+
+	out := make(map[string]entity.CategoryMapping, len(in))
+	for key, mapping := range in {
+		key = strings.TrimSpace(key)
+		if upperKeys {
+			key = strings.ToUpper(key)
+		}
+		mapping.Category = strings.TrimSpace(mapping.Category)
+
+		if key == "" {
+			return nil, fmt.Errorf("invalid mapping: %s must not be empty", kind)
+		}
+		if mapping.Category == "" {
+			return nil, fmt.Errorf("invalid mapping: category for %s %q must not be empty", kind, key)
+		}
+		if _, dup := out[key]; dup {
+			return nil, fmt.Errorf("invalid mapping: %s %q is mapped more than once", kind, key)
+		}
+		out[key] = mapping
+	}
+	return out, nil
+}
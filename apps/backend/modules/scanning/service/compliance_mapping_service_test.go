@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestDefaultDPDPAMappingMatchesBuiltInCategories(t *testing.T) {
+	mapping := entity.DefaultComplianceMapping(entity.ComplianceFrameworkDPDPA)
+
+	cases := []struct {
+		classification string
+		category       string
+		consent        bool
+	}{
+		{"Sensitive Personal Data", "Sensitive Personal Data", true},
+		{"Personal Data", "Personal Data", true},
+		{"Secrets", "N/A", false},
+		{"Non-PII", "N/A", false},
+	}
+	for _, tc := range cases {
+		decision := &MultiSignalDecision{Classification: tc.classification}
+		setDPDPAMetadata(decision, mapping)
+		if decision.DPDPACategory != tc.category || decision.RequiresConsent != tc.consent {
+			t.Errorf("%s: got %q/%v, want %q/%v", tc.classification, decision.DPDPACategory, decision.RequiresConsent, tc.category, tc.consent)
+		}
+	}
+
+	vf := &VerifiedFinding{PIIType: "IN_PAN", MLConfidence: 0.9}
+	classification := NewSDKAdapter().MapToClassification(vf, uuid.New())
+	if classification.DPDPACategory != "Financial Identifier" || !classification.RequiresConsent {
+		t.Errorf("IN_PAN: got %q/%v", classification.DPDPACategory, classification.RequiresConsent)
+	}
+	if got := mapping.ForPIIType("UNKNOWN_TYPE").Category; got != "General Personal Data" {
+		t.Errorf("unknown PII type: got %q", got)
+	}
+}
+
+func TestSDKAdapterUsesTenantMapping(t *testing.T) {
+	mapping := &entity.ComplianceMapping{
+		Framework: entity.ComplianceFrameworkDPDPA,
+		Version:   3,
+		PIITypeCategories: map[string]entity.CategoryMapping{
+			"EMAIL_ADDRESS": {Category: "Customer Contact", RequiresConsent: true},
+		},
+	}
+
+	vf := &VerifiedFinding{PIIType: "EMAIL_ADDRESS", MLConfidence: 0.9}
+	classification := NewSDKAdapterWithMapping(mapping).MapToClassification(vf, uuid.New())
+
+	if classification.DPDPACategory != "Customer Contact" || !classification.RequiresConsent {
+		t.Errorf("got %q/%v", classification.DPDPACategory, classification.RequiresConsent)
+	}
+	signal, _ := classification.SignalBreakdown["category_mapping"].(map[string]interface{})
+	if signal["version"] != 3 || signal["framework"] != entity.ComplianceFrameworkDPDPA {
+		t.Errorf("unexpected category_mapping signal: %v", signal)
+	}
+}
+
+func TestNormalizeCategoryMappings(t *testing.T) {
+	out, err := normalizeCategoryMappings("PII type", map[string]entity.CategoryMapping{
+		" in_pan ": {Category: " Financial Identifier ", RequiresConsent: true},
+	}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := out["IN_PAN"]; got.Category != "Financial Identifier" || !got.RequiresConsent {
+		t.Errorf("unexpected normalized mapping: %+v", out)
+	}
+
+	invalid := []map[string]entity.CategoryMapping{
+		{"": {Category: "Personal Data"}},
+		{"IN_PHONE": {Category: "  "}},
+		{"in_phone": {Category: "Contact"}, "IN_PHONE": {Category: "Contact"}},
+	}
+	for _, in := range invalid {
+		if _, err := normalizeCategoryMappings("PII type", in, true); err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("expected invalid mapping error for %v, got %v", in, err)
+		}
+	}
+}
+
+func TestComplianceMappingFramework(t *testing.T) {
+	if framework, err := normalizeFramework(" gdpr "); err != nil || framework != entity.ComplianceFrameworkGDPR {
+		t.Errorf("got %q, %v", framework, err)
+	}
+	if _, err := normalizeFramework("HIPAA"); err == nil {
+		t.Error("expected unsupported framework to be rejected")
+	}
+
+	svc := NewComplianceMappingService(nil, nil)
+	if _, err := svc.Update(context.Background(), entity.ComplianceFrameworkCCPA, ComplianceMappingInput{}, "admin"); err == nil {
+		t.Error("expected empty mapping to be rejected")
+	}
+	mapping, err := svc.GetVersion(context.Background(), "dpdpa", 0)
+	if err != nil || !mapping.IsDefault() || len(mapping.PIITypeCategories) != 11 {
+		t.Errorf("expected built-in default for version 0, got %+v, %v", mapping, err)
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+// summaryMaxAge is how old the summary view may get before it is reported stale
+// even when no new findings were ingested
+const summaryMaxAge = 15 * time.Minute
+
+// DashboardSummaryService serves dashboard counts from pre-aggregated views and
+// refreshes them after ingestion
+type DashboardSummaryService struct {
+	repo    *persistence.PostgresRepository
+	refresh chan struct{}
+}
+
+// NewDashboardSummaryService creates a new dashboard summary service
+func NewDashboardSummaryService(repo *persistence.PostgresRepository) *DashboardSummaryService {
+	return &DashboardSummaryService{
+		repo:    repo,
+		refresh: make(chan struct{}, 1),
+	}
+}
+
+// DashboardSummary represents the aggregated dashboard summary
+type DashboardSummary struct {
+	TotalFindings    int                         `json:"total_findings"`
+	BySeverity       map[string]int              `json:"by_severity"`
+	ByClassification map[string]int              `json:"by_classification"`
+	ByEnvironment    map[string]int              `json:"by_environment"`
+	Staleness        SummaryStaleness            `json:"staleness"`
+	Rows             []DashboardSummaryBreakdown `json:"breakdown"`
+}
+
+// DashboardSummaryBreakdown is a single severity/classification/environment cell
+type DashboardSummaryBreakdown struct {
+	Severity           string `json:"severity"`
+	ClassificationType string `json:"classification_type"`
+	Environment        string `json:"environment"`
+	FindingCount       int    `json:"finding_count"`
+	AssetCount         int    `json:"asset_count"`
+}
+
+// SummaryStaleness tells the client how current the pre-aggregated numbers are
+type SummaryStaleness struct {
+	RefreshedAt   time.Time  `json:"refreshed_at"`
+	AgeSeconds    int64      `json:"age_seconds"`
+	DataChangedAt *time.Time `json:"data_changed_at,omitempty"`
+	IsStale       bool       `json:"is_stale"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// GetSummary returns the dashboard summary for the tenant in ctx, optionally filtered by environment
+func (s *DashboardSummaryService) GetSummary(ctx context.Context, environment string) (*DashboardSummary, error) {
+	rows, err := s.repo.GetDashboardSummaryRows(ctx, environment)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.repo.GetSummaryRefreshState(ctx, persistence.DashboardSummaryView)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &DashboardSummary{
+		BySeverity:       make(map[string]int),
+		ByClassification: make(map[string]int),
+		ByEnvironment:    make(map[string]int),
+		Rows:             make([]DashboardSummaryBreakdown, 0, len(rows)),
+	}
+
+	for _, row := range rows {
+		summary.TotalFindings += row.FindingCount
+		summary.BySeverity[row.Severity] += row.FindingCount
+		summary.ByClassification[row.ClassificationType] += row.FindingCount
+		summary.ByEnvironment[row.Environment] += row.FindingCount
+		summary.Rows = append(summary.Rows, DashboardSummaryBreakdown{
+			Severity:           row.Severity,
+			ClassificationType: row.ClassificationType,
+			Environment:        row.Environment,
+			FindingCount:       row.FindingCount,
+			AssetCount:         row.AssetCount,
+		})
+	}
+
+	age := time.Since(state.RefreshedAt)
+	summary.Staleness = SummaryStaleness{
+		RefreshedAt:   state.RefreshedAt,
+		AgeSeconds:    int64(age.Seconds()),
+		DataChangedAt: state.DataChangedAt,
+		LastError:     state.LastError,
+		IsStale: age > summaryMaxAge ||
+			(state.DataChangedAt != nil && state.DataChangedAt.After(state.RefreshedAt)),
+	}
+
+	return summary, nil
+}
+
+// RequestRefresh schedules a summary refresh; concurrent requests are coalesced
+func (s *DashboardSummaryService) RequestRefresh() {
+	select {
+	case s.refresh <- struct{}{}:
+	default:
+		// A refresh is already pending
+	}
+}
+
+// RefreshNow refreshes the summary views synchronously
+func (s *DashboardSummaryService) RefreshNow(ctx context.Context) error {
+	duration, err := s.repo.RefreshDashboardSummary(ctx)
+	if err != nil {
+		return fmt.Errorf("summary refresh failed: %w", err)
+	}
+	log.Printf("📊 Dashboard summary refreshed in %v", duration)
+	return nil
+}
+
+// StartRefreshWorker processes refresh requests and refreshes periodically as a safety net
+func (s *DashboardSummaryService) StartRefreshWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("📊 Starting dashboard summary refresh worker (interval: %v)", interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Dashboard summary refresh worker stopped")
+			return
+		case <-s.refresh:
+			if err := s.RefreshNow(ctx); err != nil {
+				log.Printf("❌ %v", err)
+			}
+		case <-ticker.C:
+			if err := s.RefreshNow(ctx); err != nil {
+				log.Printf("❌ %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// ScanRunDroppedFindings is what the ingestion filter matched in one scan run
+type ScanRunDroppedFindings struct {
+	ScanRunID uuid.UUID                    `json:"scan_run_id"`
+	Filter    IngestionFilter              `json:"filter"` // The filter as configured now
+	Dropped   int                          `json:"dropped"`
+	Audited   int                          `json:"audited"` // Counted in audit mode; the findings were stored
+	Entries   []entity.DroppedFindingCount `json:"entries"`
+}
+
+// DroppedFindingsReport totals what the ingestion filter matched over a period
+type DroppedFindingsReport struct {
+	Filter  IngestionFilter                `json:"filter"`
+	Since   time.Time                      `json:"since"`
+	Until   time.Time                      `json:"until"`
+	Dropped int                            `json:"dropped"`
+	Audited int                            `json:"audited"`
+	Entries []entity.DroppedFindingSummary `json:"entries"`
+}
+
+// DroppedFindingsService reads the ledger of findings the ingestion filter dropped,
+// so what it removes can be checked before and after it is enforced
+type DroppedFindingsService struct {
+	repo   *persistence.PostgresRepository
+	filter IngestionFilter
+}
+
+// NewDroppedFindingsService creates a new dropped findings service
+func NewDroppedFindingsService(repo *persistence.PostgresRepository, filter IngestionFilter) *DroppedFindingsService {
+	return &DroppedFindingsService{repo: repo, filter: filter}
+}
+
+// ForScanRun returns the ledger entries of a scan run
+func (s *DroppedFindingsService) ForScanRun(ctx context.Context, scanRunID uuid.UUID) (*ScanRunDroppedFindings, error) {
+	entries, err := s.repo.ListDroppedFindings(ctx, scanRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScanRunDroppedFindings{ScanRunID: scanRunID, Filter: s.filter, Entries: entries}
+	for _, e := range entries {
+		if e.Enforced {
+			result.Dropped += e.Findings
+		} else {
+			result.Audited += e.Findings
+		}
+	}
+	return result, nil
+}
+
+// Summarize totals the tenant's ledger entries recorded in [since, until) by reason and pattern
+func (s *DroppedFindingsService) Summarize(ctx context.Context, since, until time.Time) (*DroppedFindingsReport, error) {
+	entries, err := s.repo.SummarizeDroppedFindings(ctx, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DroppedFindingsReport{Filter: s.filter, Since: since, Until: until, Entries: entries}
+	for _, e := range entries {
+		if e.Enforced {
+			report.Dropped += e.Findings
+		} else {
+			report.Audited += e.Findings
+		}
+	}
+	return report, nil
+}
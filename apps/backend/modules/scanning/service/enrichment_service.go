@@ -9,6 +9,7 @@ import (
 
 	"github.com/arc-platform/backend/modules/lineage/service"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/pkg/langdetect"
 )
 
 // EnrichmentService adds contextual intelligence to raw findings before classification
@@ -35,6 +36,9 @@ type EnrichmentSignals struct {
 	HistoricalCount  int     `json:"historical_count"`  // Times this pattern+value seen before
 	ValueHash        string  `json:"value_hash"`        // SHA256 hash of value for deduplication
 	EnrichmentFailed bool    `json:"enrichment_failed"` // Track if enrichment had errors
+
+	Language           string  `json:"language"`            // ISO 639-1 code of the sample text, or "und"
+	LanguageConfidence float64 `json:"language_confidence"` // Share of the text's evidence behind Language
 }
 
 // EnrichmentContext contains input data for enrichment
@@ -44,6 +48,7 @@ type EnrichmentContext struct {
 	PatternName string
 	AssetType   string
 	ColumnName  string // For database assets
+	SampleText  string // Text around the match; its language is detected
 }
 
 // Enrich performs contextual enrichment on a finding
@@ -75,6 +80,11 @@ func (s *EnrichmentService) Enrich(ctx context.Context, input EnrichmentContext)
 	// For now, return 0 - will implement after DB schema update
 	signals.HistoricalCount = 0
 
+	// 8. Language of the surrounding text
+	language := langdetect.Detect(input.SampleText)
+	signals.Language = language.Language
+	signals.LanguageConfidence = language.Confidence
+
 	return signals
 }
 
@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	fplearningentity "github.com/arc-platform/backend/modules/fplearning/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// maxSuggestionSamples bounds the findings kept as evidence on a suggestion; they
+// are also the values learned when an fp_learning suggestion is approved
+const maxSuggestionSamples = 20
+
+// FPClusteringOptions tunes how false-positive feedback is clustered into suggestions
+type FPClusteringOptions struct {
+	WindowDays   int     `json:"window_days"`   // Feedback older than this is ignored
+	MinSupport   int     `json:"min_support"`   // False positives a cluster needs before a rule is suggested
+	MinPrecision float64 `json:"min_precision"` // Share of false positives among all feedback in the cluster
+	PathDepth    int     `json:"path_depth"`    // Directory levels kept in a path prefix
+}
+
+func (o FPClusteringOptions) withDefaults() FPClusteringOptions {
+	if o.WindowDays < 1 {
+		o.WindowDays = 90
+	}
+	if o.MinSupport < 2 {
+		o.MinSupport = 5
+	}
+	if o.MinPrecision <= 0 || o.MinPrecision > 1 {
+		o.MinPrecision = 0.9
+	}
+	if o.PathDepth < 1 {
+		o.PathDepth = 3
+	}
+	return o
+}
+
+// FPClusteringReport summarizes one clustering run
+type FPClusteringReport struct {
+	FeedbackAnalyzed int                        `json:"feedback_analyzed"`
+	FalsePositives   int                        `json:"false_positives"`
+	Suggestions      []*entity.FPRuleSuggestion `json:"suggestions"`
+	NewSuggestions   int                        `json:"new_suggestions"`
+}
+
+// FPClusteringService clusters FALSE_POSITIVE feedback by pattern, path prefix and
+// token shape and proposes suppression or FP-learning rules. Nothing is applied
+// until an admin approves a suggestion.
+type FPClusteringService struct {
+	repo         *persistence.PostgresRepository
+	suppressions *SuppressionService
+	auditLogger  interfaces.AuditLogger
+	defaults     FPClusteringOptions
+}
+
+// NewFPClusteringService creates a new false-positive clustering service
+func NewFPClusteringService(repo *persistence.PostgresRepository, suppressions *SuppressionService, auditLogger interfaces.AuditLogger, defaults FPClusteringOptions) *FPClusteringService {
+	return &FPClusteringService{
+		repo:         repo,
+		suppressions: suppressions,
+		auditLogger:  auditLogger,
+		defaults:     defaults.withDefaults(),
+	}
+}
+
+// Analyze clusters the tenant's recent feedback and stores the resulting
+// suggestions. Zero options fall back to the configured defaults.
+func (s *FPClusteringService) Analyze(ctx context.Context, opts FPClusteringOptions) (*FPClusteringReport, error) {
+	if opts == (FPClusteringOptions{}) {
+		opts = s.defaults
+	}
+	opts = opts.withDefaults()
+
+	since := time.Now().AddDate(0, 0, -opts.WindowDays)
+	signals, err := s.repo.ListFeedbackSignals(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FPClusteringReport{
+		FeedbackAnalyzed: len(signals),
+		Suggestions:      clusterFeedback(signals, opts),
+	}
+	for _, signal := range signals {
+		if signal.FeedbackType == entity.FeedbackTypeFalsePositive {
+			report.FalsePositives++
+		}
+	}
+
+	for _, suggestion := range report.Suggestions {
+		created, err := s.repo.UpsertFPRuleSuggestion(ctx, suggestion)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			report.NewSuggestions++
+		}
+	}
+	return report, nil
+}
+
+// ListSuggestions returns stored suggestions, optionally filtered by status
+func (s *FPClusteringService) ListSuggestions(ctx context.Context, status string) ([]*entity.FPRuleSuggestion, error) {
+	switch status {
+	case "", entity.FPSuggestionPending, entity.FPSuggestionApproved, entity.FPSuggestionRejected:
+	default:
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+	return s.repo.ListFPRuleSuggestions(ctx, status)
+}
+
+// GetSuggestion retrieves a suggestion
+func (s *FPClusteringService) GetSuggestion(ctx context.Context, id uuid.UUID) (*entity.FPRuleSuggestion, error) {
+	return s.repo.GetFPRuleSuggestion(ctx, id)
+}
+
+// Approve applies a pending suggestion: a suppression suggestion becomes a suppression
+// rule for its path prefix, an fp_learning suggestion records each sampled finding's
+// value as a learned false positive. reviewerID is stored on learned entries.
+func (s *FPClusteringService) Approve(ctx context.Context, id uuid.UUID, reviewer string, reviewerID uuid.UUID, note string) (*entity.FPRuleSuggestion, error) {
+	suggestion, err := s.repo.GetFPRuleSuggestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.Status != entity.FPSuggestionPending {
+		return nil, fmt.Errorf("rule suggestion already %s", suggestion.Status)
+	}
+
+	var applied []uuid.UUID
+	switch suggestion.Kind {
+	case entity.FPSuggestionSuppression:
+		rule, err := s.suppressions.CreateRule(ctx, SuppressionRuleInput{
+			Name:        fmt.Sprintf("FP cluster: %s under %s", suggestion.PatternName, suggestion.PathPrefix),
+			PatternName: suggestion.PatternName,
+			PathGlob:    suggestion.PathPrefix + "/**",
+			Reason:      fmt.Sprintf("Approved from %d false-positive reports (suggestion %s)", suggestion.FalsePositives, suggestion.ID),
+		}, reviewer)
+		if err != nil {
+			return nil, err
+		}
+		applied = append(applied, rule.ID)
+	case entity.FPSuggestionFPLearning:
+		applied, err = s.learnSamples(ctx, suggestion, reviewerID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown suggestion kind %s", suggestion.Kind)
+	}
+
+	if err := s.repo.ReviewFPRuleSuggestion(ctx, id, entity.FPSuggestionApproved, reviewer, note, applied); err != nil {
+		return nil, err
+	}
+	s.record(ctx, "FP_SUGGESTION_APPROVED", suggestion, map[string]interface{}{"applied_rule_ids": applied})
+
+	return s.repo.GetFPRuleSuggestion(ctx, id)
+}
+
+// Reject closes a pending suggestion; the same cluster is not suggested again
+func (s *FPClusteringService) Reject(ctx context.Context, id uuid.UUID, reviewer, note string) (*entity.FPRuleSuggestion, error) {
+	suggestion, err := s.repo.GetFPRuleSuggestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReviewFPRuleSuggestion(ctx, id, entity.FPSuggestionRejected, reviewer, note, nil); err != nil {
+		return nil, err
+	}
+	s.record(ctx, "FP_SUGGESTION_REJECTED", suggestion, map[string]interface{}{"note": note})
+
+	return s.repo.GetFPRuleSuggestion(ctx, id)
+}
+
+// learnSamples stores one FP learning entry per sampled finding with a match
+func (s *FPClusteringService) learnSamples(ctx context.Context, suggestion *entity.FPRuleSuggestion, reviewerID uuid.UUID) ([]uuid.UUID, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	findings, err := s.repo.ListFindingSamples(ctx, suggestion.SampleFindingIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var learned []uuid.UUID
+	now := time.Now()
+	for _, f := range findings {
+		if len(f.Matches) == 0 {
+			continue
+		}
+		findingID, scanRunID := f.ID, f.ScanRunID
+		fp := &fplearningentity.FPLearning{
+			ID:              uuid.New(),
+			TenantID:        tenantID,
+			UserID:          reviewerID,
+			AssetID:         f.AssetID,
+			PatternName:     f.PatternName,
+			PIIType:         f.PatternName,
+			MatchedValue:    f.Matches[0],
+			LearningType:    fplearningentity.FPLearningTypeFalsePositive,
+			Version:         1,
+			Justification:   fmt.Sprintf("Approved FP cluster %s (token shape %s)", suggestion.ID, suggestion.TokenShape),
+			SourceFindingID: &findingID,
+			ScanRunID:       &scanRunID,
+			IsActive:        true,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if err := s.repo.CreateFPLearning(ctx, fp); err != nil {
+			return nil, fmt.Errorf("failed to create FP learning: %w", err)
+		}
+		learned = append(learned, fp.ID)
+	}
+	if len(learned) == 0 {
+		return nil, fmt.Errorf("rule suggestion has no sampled findings left to learn from")
+	}
+	return learned, nil
+}
+
+func (s *FPClusteringService) record(ctx context.Context, action string, suggestion *entity.FPRuleSuggestion, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	metadata["kind"] = suggestion.Kind
+	metadata["pattern_name"] = suggestion.PatternName
+	metadata["path_prefix"] = suggestion.PathPrefix
+	metadata["token_shape"] = suggestion.TokenShape
+	_ = s.auditLogger.Record(ctx, action, "fp_rule_suggestion", suggestion.ID.String(), metadata)
+}
+
+// StartClusteringWorker periodically clusters feedback for every tenant until ctx is cancelled
+func (s *FPClusteringService) StartClusteringWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 1440
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🧩 Starting false-positive clustering worker (interval: %d minutes)", intervalMinutes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 False-positive clustering worker stopped")
+			return
+		case <-ticker.C:
+			s.analyzeAllTenants(ctx)
+		}
+	}
+}
+
+// analyzeAllTenants runs clustering once per tenant that owns findings
+func (s *FPClusteringService) analyzeAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListFindingTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for false-positive clustering: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		report, err := s.Analyze(tenantCtx, FPClusteringOptions{})
+		if err != nil {
+			log.Printf("❌ False-positive clustering failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if report.NewSuggestions > 0 {
+			log.Printf("✅ Suggested %d new false-positive rule(s) for tenant %s", report.NewSuggestions, tenantID)
+		}
+	}
+}
+
+// ============================================================================
+// Clustering
+// ============================================================================
+
+// fpCluster tallies feedback sharing a pattern and either a path prefix or a token shape
+type fpCluster struct {
+	pattern   string
+	prefix    string
+	shape     string
+	fp        int
+	confirmed int
+	assets    map[uuid.UUID]bool
+	prefixes  map[string]bool
+	samples   []uuid.UUID
+	uncovered int // False positives not already covered by a suppression suggestion
+}
+
+func (c *fpCluster) add(signal entity.FeedbackSignal, prefix string) {
+	if signal.FeedbackType != entity.FeedbackTypeFalsePositive {
+		c.confirmed++
+		return
+	}
+	c.fp++
+	c.assets[signal.AssetID] = true
+	c.prefixes[prefix] = true
+	if len(c.samples) < maxSuggestionSamples {
+		c.samples = append(c.samples, signal.FindingID)
+	}
+}
+
+func (c *fpCluster) precision() float64 {
+	return float64(c.fp) / float64(c.fp+c.confirmed)
+}
+
+func (c *fpCluster) suggestion(kind string) *entity.FPRuleSuggestion {
+	return &entity.FPRuleSuggestion{
+		ID:               uuid.New(),
+		Kind:             kind,
+		Fingerprint:      strings.Join([]string{kind, c.pattern, c.prefix, c.shape}, "|"),
+		PatternName:      c.pattern,
+		PathPrefix:       c.prefix,
+		TokenShape:       c.shape,
+		FalsePositives:   c.fp,
+		Confirmed:        c.confirmed,
+		Precision:        c.precision(),
+		AssetCount:       len(c.assets),
+		SampleFindingIDs: c.samples,
+		Status:           entity.FPSuggestionPending,
+	}
+}
+
+// clusterFeedback turns feedback into rule suggestions. A pattern that keeps being
+// marked false positive under one path prefix yields a suppression suggestion for
+// that prefix. A token shape marked false positive under several prefixes that no
+// suppression suggestion covers yields an fp_learning suggestion, since the values
+// rather than the location are what the detector gets wrong. Clusters with too much
+// confirmed feedback are never suggested.
+func clusterFeedback(signals []entity.FeedbackSignal, opts FPClusteringOptions) []*entity.FPRuleSuggestion {
+	newCluster := func(pattern, prefix, shape string) *fpCluster {
+		return &fpCluster{pattern: pattern, prefix: prefix, shape: shape, assets: map[uuid.UUID]bool{}, prefixes: map[string]bool{}}
+	}
+
+	byPrefix := make(map[string]*fpCluster)
+	byShape := make(map[string]*fpCluster)
+	for _, signal := range signals {
+		pattern := strings.ToUpper(strings.TrimSpace(signal.PatternName))
+		prefix := pathPrefix(signal.Path, opts.PathDepth)
+		shape := tokenShape(signal.Sample)
+
+		if prefix != "" {
+			key := pattern + "|" + prefix
+			if byPrefix[key] == nil {
+				byPrefix[key] = newCluster(pattern, prefix, "")
+			}
+			byPrefix[key].add(signal, prefix)
+		}
+		if shape != "" {
+			key := pattern + "|" + shape
+			if byShape[key] == nil {
+				byShape[key] = newCluster(pattern, "", shape)
+			}
+			byShape[key].add(signal, prefix)
+		}
+	}
+
+	qualifies := func(c *fpCluster, support int) bool {
+		return support >= opts.MinSupport && c.precision() >= opts.MinPrecision
+	}
+
+	var suggestions []*entity.FPRuleSuggestion
+	suppressed := make(map[string]bool) // pattern|prefix
+	for key, c := range byPrefix {
+		if qualifies(c, c.fp) {
+			suggestions = append(suggestions, c.suggestion(entity.FPSuggestionSuppression))
+			suppressed[key] = true
+		}
+	}
+
+	for _, signal := range signals {
+		if signal.FeedbackType != entity.FeedbackTypeFalsePositive {
+			continue
+		}
+		pattern := strings.ToUpper(strings.TrimSpace(signal.PatternName))
+		shape := tokenShape(signal.Sample)
+		if shape == "" || suppressed[pattern+"|"+pathPrefix(signal.Path, opts.PathDepth)] {
+			continue
+		}
+		byShape[pattern+"|"+shape].uncovered++
+	}
+	for _, c := range byShape {
+		if len(c.prefixes) >= 2 && qualifies(c, c.uncovered) {
+			suggestions = append(suggestions, c.suggestion(entity.FPSuggestionFPLearning))
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].FalsePositives != suggestions[j].FalsePositives {
+			return suggestions[i].FalsePositives > suggestions[j].FalsePositives
+		}
+		return suggestions[i].Fingerprint < suggestions[j].Fingerprint
+	})
+	return suggestions
+}
+
+// pathPrefix returns the first depth directories of a file path, or "" when the
+// file sits at the root or the prefix could not be used as a literal glob
+func pathPrefix(path string, depth int) string {
+	path = strings.ReplaceAll(strings.TrimSpace(path), "\\", "/")
+	idx := strings.LastIndex(path, "/")
+	if idx <= 0 || strings.ContainsAny(path, "*?") {
+		return ""
+	}
+
+	dir := path[:idx]
+	var kept []string
+	for _, segment := range strings.Split(dir, "/") {
+		if segment == "" {
+			continue
+		}
+		kept = append(kept, segment)
+		if len(kept) == depth {
+			break
+		}
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+
+	prefix := strings.Join(kept, "/")
+	if strings.HasPrefix(dir, "/") {
+		prefix = "/" + prefix
+	}
+	return prefix
+}
+
+// tokenShape reduces a value to its character classes with run lengths, so
+// "ABCDE1234F" becomes "A{5}9{4}A{1}": letters as A/a, digits as 9, spaces as _
+// and other characters kept literally. Values of the same format share a shape.
+func tokenShape(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	class := func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r):
+			return 'A'
+		case unicode.IsLetter(r):
+			return 'a'
+		case unicode.IsDigit(r):
+			return '9'
+		case unicode.IsSpace(r):
+			return '_'
+		}
+		return r
+	}
+
+	var b strings.Builder
+	var current rune
+	run := 0
+	flush := func() {
+		if run > 0 {
+			fmt.Fprintf(&b, "%c{%d}", current, run)
+		}
+	}
+	for _, r := range value {
+		c := class(r)
+		if c == current {
+			run++
+			continue
+		}
+		flush()
+		current, run = c, 1
+	}
+	flush()
+	return b.String()
+}
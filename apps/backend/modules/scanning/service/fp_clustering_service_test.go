@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestTokenShape(t *testing.T) {
+	cases := map[string]string{
+		"ABCDE1234F":       "A{5}9{4}A{1}",
+		"order-00123":      "a{5}-{1}9{5}",
+		"ops@example.com":  "a{3}@{1}a{7}.{1}a{3}",
+		"  4111 1111 1111": "9{4}_{1}9{4}_{1}9{4}",
+		"":                 "",
+	}
+	for value, want := range cases {
+		if got := tokenShape(value); got != want {
+			t.Errorf("tokenShape(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestPathPrefix(t *testing.T) {
+	cases := []struct {
+		path  string
+		depth int
+		want  string
+	}{
+		{"/srv/app/fixtures/2024/users.csv", 3, "/srv/app/fixtures"},
+		{"/srv/app/users.csv", 3, "/srv/app"},
+		{`C:\data\exports\dump.sql`, 2, "C:/data"},
+		{"users.csv", 3, ""},
+		{"/users.csv", 3, ""},
+		{"/data/*/users.csv", 3, ""},
+	}
+	for _, tc := range cases {
+		if got := pathPrefix(tc.path, tc.depth); got != tc.want {
+			t.Errorf("pathPrefix(%q, %d) = %q, want %q", tc.path, tc.depth, got, tc.want)
+		}
+	}
+}
+
+func TestClusterFeedback(t *testing.T) {
+	opts := FPClusteringOptions{MinSupport: 3, MinPrecision: 0.75, PathDepth: 2}.withDefaults()
+	signal := func(feedback, pattern, path, sample string) entity.FeedbackSignal {
+		return entity.FeedbackSignal{FindingID: uuid.New(), AssetID: uuid.New(), PatternName: pattern, Path: path, FeedbackType: feedback, Sample: sample}
+	}
+	fp, ok := entity.FeedbackTypeFalsePositive, entity.FeedbackTypeConfirmed
+
+	signals := []entity.FeedbackSignal{
+		// Test fixtures keep tripping the PAN detector: suppress the directory
+		signal(fp, "IN_PAN", "/srv/fixtures/a.csv", "ABCDE1234F"),
+		signal(fp, "IN_PAN", "/srv/fixtures/b.csv", "PQRST9876Z"),
+		signal(fp, "in_pan", "/srv/fixtures/deep/c.csv", "LMNOP1111K"),
+		// Order numbers look like phone numbers everywhere: learn the shape
+		signal(fp, "PHONE", "/var/orders/1.log", "ORD-0012345678"),
+		signal(fp, "PHONE", "/var/billing/2.log", "ORD-0098765432"),
+		signal(fp, "PHONE", "/home/ops/3.log", "ORD-0011111111"),
+		// Confirmed reports keep a real leak location from being suppressed
+		signal(fp, "EMAIL", "/srv/crm/a.json", "x@y.com"),
+		signal(fp, "EMAIL", "/srv/crm/b.json", "x@y.com"),
+		signal(fp, "EMAIL", "/srv/crm/c.json", "x@y.com"),
+		signal(ok, "EMAIL", "/srv/crm/d.json", "real@customer.com"),
+		signal(ok, "EMAIL", "/srv/crm/e.json", "real@customer.com"),
+	}
+
+	suggestions := clusterFeedback(signals, opts)
+	if len(suggestions) != 2 {
+		for _, s := range suggestions {
+			t.Logf("suggestion: %+v", s)
+		}
+		t.Fatalf("got %d suggestions, want 2", len(suggestions))
+	}
+
+	byKind := map[string]*entity.FPRuleSuggestion{}
+	for _, s := range suggestions {
+		byKind[s.Kind] = s
+	}
+
+	suppression := byKind[entity.FPSuggestionSuppression]
+	if suppression == nil || suppression.PatternName != "IN_PAN" || suppression.PathPrefix != "/srv/fixtures" ||
+		suppression.FalsePositives != 3 || len(suppression.SampleFindingIDs) != 3 {
+		t.Errorf("unexpected suppression suggestion: %+v", suppression)
+	}
+
+	learning := byKind[entity.FPSuggestionFPLearning]
+	if learning == nil || learning.PatternName != "PHONE" || learning.TokenShape != "A{3}-{1}9{10}" || learning.AssetCount != 3 {
+		t.Errorf("unexpected fp_learning suggestion: %+v", learning)
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Ingestion payload schema versions. A payload without schema_version is
+// version 1, the format scanners sent before versions existed.
+//
+// Version 2 findings name source.data_source by its connection source type
+// ("filesystem", "postgresql") and send detected_at as RFC 3339 with a zone.
+// Version 1 findings are upgraded on ingestion: "fs" and "postgres" become
+// "filesystem" and "postgresql", zoneless timestamps are read as UTC, and a
+// top-level "verified_findings" array is read as "findings".
+const (
+	MinSchemaVersion     = 1
+	CurrentSchemaVersion = 2
+)
+
+// UnsupportedSchemaVersionError reports a payload in a schema version this
+// backend cannot read, either too old or newer than it knows
+type UnsupportedSchemaVersionError struct {
+	Version int
+}
+
+func (e *UnsupportedSchemaVersionError) Error() string {
+	return fmt.Sprintf("unsupported schema_version %d: this backend accepts %d to %d", e.Version, MinSchemaVersion, CurrentSchemaVersion)
+}
+
+// normalizeSchemaVersion returns the version a payload declared, or version 1 when
+// it declared none, failing for versions outside the supported range
+func normalizeSchemaVersion(version int) (int, error) {
+	if version == 0 {
+		return MinSchemaVersion, nil
+	}
+	if version < MinSchemaVersion || version > CurrentSchemaVersion {
+		return 0, &UnsupportedSchemaVersionError{Version: version}
+	}
+	return version, nil
+}
+
+// findingUpgrades maps a schema version to the step upgrading its findings to the next one
+var findingUpgrades = map[int]func(*VerifiedFinding){
+	1: upgradeFindingV1,
+}
+
+// upgradeFinding brings a finding sent in the given schema version up to the current one
+func upgradeFinding(version int, vf *VerifiedFinding) {
+	for v := version; v < CurrentSchemaVersion; v++ {
+		if upgrade, ok := findingUpgrades[v]; ok {
+			upgrade(vf)
+		}
+	}
+}
+
+// legacyDataSources are data_source spellings of unversioned scanners
+var legacyDataSources = map[string]string{
+	"fs":       "filesystem",
+	"postgres": "postgresql",
+}
+
+// legacyTimestampLayouts are detected_at formats of unversioned scanners, such as
+// Python's datetime.isoformat() on a naive datetime
+var legacyTimestampLayouts = []string{
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+func upgradeFindingV1(vf *VerifiedFinding) {
+	source := strings.ToLower(vf.Source.DataSource)
+	if canonical, ok := legacyDataSources[source]; ok {
+		vf.Source.DataSource = canonical
+	}
+
+	if vf.DetectedAt == "" {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339Nano, vf.DetectedAt); err == nil {
+		return
+	}
+	for _, layout := range legacyTimestampLayouts {
+		if t, err := time.ParseInLocation(layout, vf.DetectedAt, time.UTC); err == nil {
+			vf.DetectedAt = t.Format(time.RFC3339Nano)
+			return
+		}
+	}
+}
+
+// IngestionCapabilities tells scanners which payloads this backend accepts
+type IngestionCapabilities struct {
+	SchemaVersion  SchemaVersionRange `json:"schema_version"`
+	PayloadFields  []string           `json:"payload_fields"`
+	FindingFields  []string           `json:"finding_fields"`
+	SourceFields   []string           `json:"source_fields"`
+	Limits         IngestionLimits    `json:"limits"`
+	Signing        SigningCapability  `json:"signing"`
+	ChunkedUploads bool               `json:"chunked_uploads"`
+}
+
+// SchemaVersionRange is the span of payload schema versions accepted
+type SchemaVersionRange struct {
+	Current   int   `json:"current"`
+	Min       int   `json:"min"`
+	Supported []int `json:"supported"`
+	Default   int   `json:"default"` // Assumed when a payload has no schema_version
+}
+
+// IngestionLimits bounds one ingest-verified body or upload chunk; 0 is unlimited
+type IngestionLimits struct {
+	MaxPayloadBytes int64 `json:"max_payload_bytes"`
+	MaxFindings     int   `json:"max_findings"`
+}
+
+// SigningCapability describes how payloads are signed and whether the tenant requires it
+type SigningCapability struct {
+	Required        bool   `json:"required"`
+	Algorithm       string `json:"algorithm"`
+	KeyIDHeader     string `json:"key_id_header"`
+	SignatureHeader string `json:"signature_header"`
+}
+
+// NewIngestionCapabilities describes the payloads accepted within limits
+func NewIngestionCapabilities(limits IngestionLimits, signing SigningCapability) *IngestionCapabilities {
+	supported := make([]int, 0, CurrentSchemaVersion-MinSchemaVersion+1)
+	for v := MinSchemaVersion; v <= CurrentSchemaVersion; v++ {
+		supported = append(supported, v)
+	}
+
+	return &IngestionCapabilities{
+		SchemaVersion: SchemaVersionRange{
+			Current:   CurrentSchemaVersion,
+			Min:       MinSchemaVersion,
+			Supported: supported,
+			Default:   MinSchemaVersion,
+		},
+		PayloadFields:  jsonFieldNames(reflect.TypeOf(VerifiedScanInput{})),
+		FindingFields:  jsonFieldNames(reflect.TypeOf(VerifiedFinding{})),
+		SourceFields:   jsonFieldNames(reflect.TypeOf(SourceLocation{})),
+		Limits:         limits,
+		Signing:        signing,
+		ChunkedUploads: true,
+	}
+}
+
+// jsonFieldNames lists the JSON names of a struct's fields, so the capabilities
+// always match what the decoder reads
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
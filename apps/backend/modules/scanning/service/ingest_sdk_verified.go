@@ -2,76 +2,167 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
-	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/scanperf"
+	"github.com/arc-platform/backend/pkg/langdetect"
 	"github.com/google/uuid"
 )
 
 // VerifiedScanInput represents batch of SDK-validated findings
 type VerifiedScanInput struct {
-	ScanID   string                 `json:"scan_id"`
-	Findings []VerifiedFinding      `json:"findings"`
-	Metadata map[string]interface{} `json:"metadata"`
+	SchemaVersion int                    `json:"schema_version,omitempty"` // Unset for version 1 payloads
+	ScanID        string                 `json:"scan_id"`
+	Findings      []VerifiedFinding      `json:"findings"`
+	Metadata      map[string]interface{} `json:"metadata"`
+}
+
+// ErrNoFindings is returned when a verified scan payload carries no findings
+var ErrNoFindings = errors.New("no findings provided")
+
+// VerifiedIngestResult summarises an SDK-verified ingestion
+type VerifiedIngestResult struct {
+	ScanRunID  uuid.UUID `json:"scan_run_id"`
+	ScanID     string    `json:"scan_id"`
+	Received   int       `json:"received_findings"`
+	Accepted   int       `json:"accepted_findings"`
+	Suppressed int       `json:"suppressed_findings"`
 }
 
 // IngestSDKVerified processes SDK-validated findings
 // This is the simplified Phase 2 ingestion that trusts SDK validation
 func (s *IngestionService) IngestSDKVerified(ctx context.Context, input VerifiedScanInput) error {
-	adapter := NewSDKAdapter()
+	_, err := s.IngestSDKVerifiedStream(ctx, &sliceFindingStream{input: input})
+	return err
+}
+
+// IngestSDKVerifiedStream processes SDK-validated findings as the stream yields
+// them, so a payload never has to be held in memory as a whole. Any stream error
+// rolls back the whole ingestion, as does a signed stream whose signature does not
+// verify; a verified signer is recorded on the scan run.
+func (s *IngestionService) IngestSDKVerifiedStream(ctx context.Context, stream VerifiedFindingStream) (*VerifiedIngestResult, error) {
+	ctx, perf := scanperf.Start(ctx)
+	adapter := NewSDKAdapterWithMapping(s.classifier.CategoryMapping(ctx))
+	jurisdiction := s.classifier.Jurisdiction(ctx)
+
+	suppressions, err := loadSuppressions(ctx, s.repo)
+	if err != nil {
+		return nil, err
+	}
+	tally := newSuppressionTally()
+
+	if err := s.quotas.AdmitScanRun(ctx); err != nil {
+		return nil, err
+	}
+	budget, err := s.quotas.FindingBudget(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	// Start transaction
-	tx, err := s.repo.BeginTx(ctx)
+	stopDB := perf.Time(scanperf.StageDB)
+	tx, err := s.repo.BeginTransaction(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	// Create scan run
 	scanRun := &entity.ScanRun{
-		ID:     uuid.New(),
-		Status: "completed",
+		ID:              uuid.New(),
+		Status:          "completed",
+		SignatureStatus: entity.ScanSignatureUnsigned,
 		Metadata: map[string]interface{}{
 			"sdk_scan":    true,
-			"scan_id":     input.ScanID,
+			"scan_id":     stream.ScanID(),
 			"sdk_version": "2.0",
 		},
 	}
 
 	if err := tx.CreateScanRun(ctx, scanRun); err != nil {
-		return fmt.Errorf("failed to create scan run: %w", err)
+		return nil, fmt.Errorf("failed to create scan run: %w", err)
 	}
+	stopDB()
 
 	// Track assets and stats
 	assetMap := make(map[uuid.UUID]bool)
+	receivedFindingsCount := 0
 	acceptedFindingsCount := 0
+	var criticalFindings, createdFindings []*entity.Finding
 
 	// Process each finding
-	for _, vf := range input.Findings {
+	for {
+		vf, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		receivedFindingsCount++
+
 		fmt.Printf("🔍 Processing finding: PII type = '%s'\n", vf.PIIType)
 
 		// CRITICAL: Validate PII type against locked scope (LAW 3)
-		// Backend MUST reject findings with PII types not in the locked 11 India types
-		if !IsLockedPIIType(vf.PIIType) {
-			fmt.Printf("⚠️  REJECTED finding: PII type '%s' not in locked scope (11 India PIIs only)\n", vf.PIIType)
+		// Backend MUST reject findings with PII types outside the tenant's jurisdiction profile
+		if !jurisdiction.Allows(vf.PIIType) {
+			fmt.Printf("⚠️  REJECTED finding: PII type '%s' not in %s jurisdiction scope\n", vf.PIIType, jurisdiction.Code)
 			continue // Skip this finding - do not ingest
 		}
 
+		if rule := suppressions.match(vf.PIIType, vf.Source.Host, vf.Source.Path); rule != nil {
+			tally.add(rule)
+			continue // Suppressed by an admin rule - do not ingest
+		}
+
+		// Past the findings quota the whole ingestion is rolled back, or the finding downsampled away
+		admitted, err := budget.Admit(determineSeverity(vf.PIIType))
+		if err != nil {
+			return nil, err
+		}
+		if !admitted {
+			continue
+		}
+
 		fmt.Printf("✅ Accepted finding: PII type '%s' is valid\n", vf.PIIType)
 		acceptedFindingsCount++
 
-		assetID, err := s.processSingleSDKFinding(ctx, tx, adapter, scanRun.ID, &vf)
+		finding, err := s.processSingleSDKFinding(ctx, tx, adapter, scanRun.ID, vf)
 		if err != nil {
 			// Log error but continue processing other findings
 			fmt.Printf("Error processing finding: %v\n", err)
 			continue
 		}
 
-		assetMap[assetID] = true
+		assetMap[finding.AssetID] = true
+		createdFindings = append(createdFindings, finding)
+		if strings.EqualFold(finding.Severity, "Critical") {
+			criticalFindings = append(criticalFindings, finding)
+		}
+	}
+
+	// A signed payload is only trusted once all of it has been read
+	signer, err := streamSigner(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+	if signer != nil {
+		scanRun.SignatureStatus = entity.ScanSignatureVerified
+		scanRun.SignerKeyID = &signer.ID
+		scanRun.SignerName = signer.ScannerName
+	}
+
+	if receivedFindingsCount == 0 {
+		return nil, ErrNoFindings
 	}
 
 	// Update asset stats (TotalFindings, RiskScore)
+	stopDB = perf.Time(scanperf.StageDB)
 	for assetID := range assetMap {
 		if err := s.recalculateAssetRisk(ctx, assetID); err != nil {
 			fmt.Printf("⚠️ Failed to recalculate risk for asset %s: %v\n", assetID, err)
@@ -82,50 +173,93 @@ func (s *IngestionService) IngestSDKVerified(ctx context.Context, input Verified
 	// Update ScanRun total counts
 	scanRun.TotalFindings = acceptedFindingsCount
 	scanRun.TotalAssets = len(assetMap)
+	tally.annotate(scanRun)
+	budget.annotate(scanRun)
+	// A streamed payload may carry scan_id after its findings
+	scanRun.Metadata["scan_id"] = stream.ScanID()
+	scanRun.Metadata["schema_version"] = stream.SchemaVersion()
+	stopDB()
+	performance := perf.Report(scanRun.TotalFindings, scanRun.TotalAssets, perf.Wait(0))
+	scanRun.Metadata["performance"] = performance
 
 	if err := tx.UpdateScanRun(ctx, scanRun); err != nil {
-		return fmt.Errorf("failed to update scan run with final stats: %w", err)
+		return nil, fmt.Errorf("failed to update scan run with final stats: %w", err)
 	}
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.recordLineageSyncTime(ctx, scanRun.ID, perf, performance)
+	s.publishFindingsCreated(ctx, createdFindings)
+
+	if err := s.repo.RecordSuppressionHits(ctx, tally.byRule); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
 	}
 
-	return nil
+	s.onIngestionComplete(ctx, scanRun, criticalFindings)
+
+	return &VerifiedIngestResult{
+		ScanRunID:  scanRun.ID,
+		ScanID:     stream.ScanID(),
+		Received:   receivedFindingsCount,
+		Accepted:   acceptedFindingsCount,
+		Suppressed: tally.total,
+	}, nil
 }
 
 func (s *IngestionService) processSingleSDKFinding(
 	ctx context.Context,
-	tx *persistence.PostgresTransaction,
+	tx repository.Transaction,
 	adapter *SDKAdapter,
 	scanRunID uuid.UUID,
 	vf *VerifiedFinding,
-) (uuid.UUID, error) {
+) (*entity.Finding, error) {
 	// 1. Get or create asset using AssetManager
 	asset := adapter.MapToAsset(vf)
 
 	// Delegate to AssetManager (single source of truth)
+	perf := scanperf.FromContext(ctx)
+	stopDB := perf.Time(scanperf.StageDB)
 	assetID, _, err := s.assetManager.CreateOrUpdateAsset(ctx, asset)
+	stopDB()
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to create/update asset: %w", err)
+		return nil, fmt.Errorf("failed to create/update asset: %w", err)
 	}
 	asset.ID = assetID
 
-	// 2. Create finding
+	// The SDK classified the finding; mapping its verdict is all that is left
+	stopClassification := perf.Time(scanperf.StageClassification)
 	finding := adapter.MapToFinding(vf, scanRunID, asset.ID)
+	classification := adapter.MapToClassification(vf, finding.ID)
+
+	// The SDK does not report a language, so it is detected from the excerpt here
+	language := langdetect.Detect(vf.ContextExcerpt)
+	finding.EnrichmentSignals["language"] = language.Language
+	finding.EnrichmentSignals["language_confidence"] = language.Confidence
+	s.language.downgradeVerified(finding, classification, language.Language)
+	s.language.annotate(finding, language.Language)
+	stopClassification()
+	s.applySampleTextPolicy(ctx, finding)
+
+	// 2. Create finding
+	defer perf.Time(scanperf.StageDB)()
 	if err := tx.CreateFinding(ctx, finding); err != nil {
-		return assetID, fmt.Errorf("failed to create finding: %w", err)
+		return nil, fmt.Errorf("failed to create finding: %w", err)
+	}
+
+	// The SDK sends the value hash as the finding's only match
+	if err := recordObservation(ctx, tx, finding, observationValueHash(vf.ValueHash)); err != nil {
+		return nil, err
 	}
 
 	// 3. Create classification
-	classification := adapter.MapToClassification(vf, finding.ID)
 	if err := tx.CreateClassification(ctx, classification); err != nil {
-		return assetID, fmt.Errorf("failed to create classification: %w", err)
+		return nil, fmt.Errorf("failed to create classification: %w", err)
 	}
 
 	// Note: Lineage sync is now handled automatically by AssetService
 	// No need to call it here - loose coupling achieved!
 
-	return assetID, nil
+	return finding, nil
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+)
+
+func TestIngestionFilterReason(t *testing.T) {
+	filter := NewIngestionFilter(entity.IngestionFilterDrop, 0.6)
+	cases := []struct {
+		decision MultiSignalDecision
+		want     string
+	}{
+		{MultiSignalDecision{Classification: "Non-PII", FinalScore: 0.9}, entity.DropReasonNonPII},
+		{MultiSignalDecision{Classification: "Personal Data", FinalScore: 0.5}, entity.DropReasonLowConfidence},
+		{MultiSignalDecision{Classification: "Personal Data", FinalScore: 0.6}, ""},
+	}
+	for _, tc := range cases {
+		if got := filter.reason(&tc.decision); got != tc.want {
+			t.Errorf("reason(%s, %.2f) = %q, want %q", tc.decision.Classification, tc.decision.FinalScore, got, tc.want)
+		}
+	}
+
+	off := NewIngestionFilter(entity.IngestionFilterOff, 0.6)
+	if got := off.reason(&MultiSignalDecision{Classification: "Non-PII"}); got != "" {
+		t.Errorf("expected the off filter to keep everything, got %q", got)
+	}
+	if fallback := NewIngestionFilter("bogus", 0); fallback.Mode != entity.IngestionFilterDrop || fallback.MinConfidence != IngestionConfidenceThreshold {
+		t.Errorf("expected an invalid filter to fall back to the default, got %+v", fallback)
+	}
+}
+
+func TestIngestScanRecordsDroppedFindings(t *testing.T) {
+	input := func() *HawkeyeScanInput {
+		return &HawkeyeScanInput{FS: []HawkeyeFinding{
+			{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}, SampleText: "asha.rao@example.in"},
+			{FilePath: "/data/orders.csv", DataSource: "fs", PatternName: "ORDER_NUMBER", Matches: []string{"ORD-001"}, SampleText: "ORD-001"},
+			{FilePath: "/data/orders.csv", DataSource: "fs", PatternName: "ORDER_NUMBER", Matches: []string{"ORD-002"}, SampleText: "ORD-002"},
+		}}
+	}
+
+	for _, mode := range []string{entity.IngestionFilterDrop, entity.IngestionFilterAudit} {
+		repo := memory.NewRepository()
+		s := newMemoryIngestionService(repo)
+		s.SetFilter(NewIngestionFilter(mode, IngestionConfidenceThreshold))
+
+		result, err := s.IngestScan(context.Background(), input())
+		if err != nil {
+			t.Fatalf("%s: IngestScan: %v", mode, err)
+		}
+
+		ledger := repo.DroppedFindings(result.ScanRunID)
+		if len(ledger) != 1 || ledger[0].Reason != entity.DropReasonNonPII || ledger[0].PatternName != "ORDER_NUMBER" || ledger[0].Findings != 2 {
+			t.Fatalf("%s: expected 2 Non-PII ORDER_NUMBER findings in the ledger, got %+v", mode, ledger)
+		}
+		enforced := mode == entity.IngestionFilterDrop
+		if ledger[0].Enforced != enforced {
+			t.Errorf("%s: expected enforced %t, got %t", mode, enforced, ledger[0].Enforced)
+		}
+
+		stored := len(repo.Findings())
+		if enforced && (stored != 1 || result.Dropped != 2) {
+			t.Errorf("drop: expected 1 stored and 2 dropped findings, got %d stored, %d dropped", stored, result.Dropped)
+		}
+		if !enforced && (stored != 3 || result.Dropped != 0) {
+			t.Errorf("audit: expected every finding stored, got %d stored, %d dropped", stored, result.Dropped)
+		}
+
+		run, _ := repo.GetScanRunByID(context.Background(), result.ScanRunID)
+		if summary, ok := run.Metadata["ingestion_filter"].(map[string]interface{}); !ok || summary["findings"] != 2 {
+			t.Errorf("%s: expected the filter totals in the scan run metadata, got %v", mode, run.Metadata)
+		}
+	}
+}
@@ -7,28 +7,81 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/scanperf"
 	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/canonical"
 	"github.com/arc-platform/backend/pkg/normalization"
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 )
 
 // IngestionService handles scan ingestion and normalization
 type IngestionService struct {
-	repo         *persistence.PostgresRepository
+	repo         repository.IngestionRepository
 	classifier   *ClassificationService
 	enrichment   *EnrichmentService
 	assetManager interfaces.AssetManager
+
+	// Optional: refreshed after each successful ingestion
+	summaryService *DashboardSummaryService
+
+	// Live progress and critical finding notifications
+	events interfaces.EventPublisher
+
+	// finding.created events for downstream consumers
+	integration interfaces.EventPublisher
+
+	// Asset groups ingested concurrently by IngestScan
+	workers int
+
+	// Matches stored per finding; the rest are only counted. Unlimited when 0
+	maxMatches int
+
+	// Optional: candidate classifier version compared against the primary one
+	shadow *ShadowClassificationService
+
+	// How sample text is stored; masked when unset
+	sampleText *SampleTextPolicyService
+
+	// Optional: per-tenant findings and scan run quotas; unenforced when unset
+	quotas *QuotaService
+
+	// How transactions are retried after transient Postgres errors
+	retry persistence.RetryOptions
+
+	// Optional: moves scanned assets into the org unit of their connection
+	orgUnits OrgUnitInheritor
+
+	// Which Non-PII and low-confidence findings are dropped, and whether
+	filter IngestionFilter
+
+	// Record counts at which severity is raised a tier
+	volume VolumeEscalation
+
+	// What happens to findings in languages outside the supported ones
+	language LanguagePolicy
+
+	// How asset paths are canonicalized; the asset manager's, so groups match its stable IDs
+	pathPolicies canonical.Policies
+}
+
+// OrgUnitInheritor moves the assets a scan run found into the org unit of the
+// connection it scanned
+type OrgUnitInheritor interface {
+	InheritConnectionOrgUnit(ctx context.Context, scanRunID uuid.UUID) (int, error)
 }
 
 // NewIngestionService creates a new ingestion service
 func NewIngestionService(
-	repo *persistence.PostgresRepository,
+	repo repository.IngestionRepository,
 	classifier *ClassificationService,
 	enrichment *EnrichmentService,
 	assetManager interfaces.AssetManager,
@@ -38,28 +91,210 @@ func NewIngestionService(
 		classifier:   classifier,
 		enrichment:   enrichment,
 		assetManager: assetManager,
+		events:       &interfaces.NoOpEventPublisher{},
+		integration:  &interfaces.NoOpEventPublisher{},
+		workers:      defaultIngestionWorkers,
+		retry:        persistence.DefaultRetryOptions,
+		filter:       NewIngestionFilter(entity.IngestionFilterDrop, IngestionConfidenceThreshold),
+		volume:       NewVolumeEscalation([]string{"1000", "100000"}),
+		language:     NewLanguagePolicy(entity.LanguageHandlingTag, []string{"en"}, DefaultLanguageDowngradeFactor),
+		pathPolicies: canonical.NewPolicies(nil, false),
+	}
+}
+
+// SetShadowClassifier sends each classified finding to a candidate engine for comparison
+func (s *IngestionService) SetShadowClassifier(shadow *ShadowClassificationService) {
+	s.shadow = shadow
+}
+
+// SetSampleTextPolicy selects how finding sample text is stored per tenant
+func (s *IngestionService) SetSampleTextPolicy(sampleText *SampleTextPolicyService) {
+	s.sampleText = sampleText
+}
+
+// applySampleTextPolicy replaces a finding's raw sample text with the one stored
+// under the tenant's policy, with the hash of the raw text
+func (s *IngestionService) applySampleTextPolicy(ctx context.Context, finding *entity.Finding) {
+	prepared := s.sampleText.Prepare(ctx, finding.PatternName, finding.SampleText, finding.Matches)
+	finding.SampleText = prepared.Text
+	finding.SampleTextSHA256 = prepared.SHA256
+	finding.SampleTextRedacted = prepared.Redacted
+}
+
+// SetQuotas enforces per-tenant quotas on ingestion
+func (s *IngestionService) SetQuotas(quotas *QuotaService) {
+	s.quotas = quotas
+}
+
+// SetSummaryService enables dashboard summary refreshes after ingestion
+func (s *IngestionService) SetSummaryService(summaryService *DashboardSummaryService) {
+	s.summaryService = summaryService
+}
+
+// SetEventPublisher enables live scan progress and critical finding events
+func (s *IngestionService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// SetIntegrationEvents enables finding.created events for downstream consumers
+func (s *IngestionService) SetIntegrationEvents(events interfaces.EventPublisher) {
+	if events != nil {
+		s.integration = events
+	}
+}
+
+// SetTransactionRetry sets how ingestion transactions are retried after transient
+// Postgres errors such as serialization failures and dropped connections
+func (s *IngestionService) SetTransactionRetry(opts persistence.RetryOptions) {
+	s.retry = opts
+}
+
+// SetOrgUnitInheritance moves the assets of each ingested scan into the org unit
+// of the connection it scanned
+func (s *IngestionService) SetOrgUnitInheritance(orgUnits OrgUnitInheritor) {
+	s.orgUnits = orgUnits
+}
+
+// SetFilter sets which findings are filtered at ingestion, and whether they are dropped
+func (s *IngestionService) SetFilter(filter IngestionFilter) {
+	s.filter = filter
+}
+
+// SetVolumeEscalation sets the record counts at which finding severity is raised
+func (s *IngestionService) SetVolumeEscalation(volume VolumeEscalation) {
+	s.volume = volume
+}
+
+// SetLanguagePolicy sets how findings in unsupported languages are handled
+func (s *IngestionService) SetLanguagePolicy(language LanguagePolicy) {
+	s.language = language
+}
+
+// SetPathPolicies sets how asset paths are canonicalized when findings are
+// grouped by asset; it must match the asset manager's policies
+func (s *IngestionService) SetPathPolicies(policies canonical.Policies) {
+	s.pathPolicies = policies
+}
+
+// SetWorkers bounds how many asset groups IngestScan processes concurrently
+func (s *IngestionService) SetWorkers(workers int) {
+	if workers > 0 {
+		s.workers = workers
+	}
+}
+
+// SetMaxMatchesPerFinding caps the matches stored for each finding. Findings over
+// the cap keep their first max matches and record the total reported.
+func (s *IngestionService) SetMaxMatchesPerFinding(max int) {
+	if max > 0 {
+		s.maxMatches = max
+	}
+}
+
+// maxCriticalFindingEvents caps per-finding notifications for a single ingestion;
+// the scan_completed event still carries the full critical count
+const maxCriticalFindingEvents = 25
+
+// publishProgress reports ingestion progress roughly every 5% of the findings
+func (s *IngestionService) publishProgress(ctx context.Context, scanRunID uuid.UUID, processed, total int) {
+	step := total / 20
+	if step < 1 {
+		step = 1
+	}
+	if processed == 0 || processed%step != 0 {
+		return
+	}
+
+	s.events.Publish(ctx, interfaces.EventScanProgress, map[string]interface{}{
+		"scan_id":   scanRunID,
+		"stage":     "ingestion",
+		"processed": processed,
+		"total":     total,
+		"progress":  processed * 100 / total,
+	})
+}
+
+// onIngestionComplete runs post-commit hooks for a successful ingestion
+func (s *IngestionService) onIngestionComplete(ctx context.Context, scanRun *entity.ScanRun, critical []*entity.Finding) {
+	if s.summaryService != nil {
+		s.summaryService.RequestRefresh()
+	}
+
+	if s.orgUnits != nil {
+		if moved, err := s.orgUnits.InheritConnectionOrgUnit(ctx, scanRun.ID); err != nil {
+			log.Printf("WARNING: Failed to move assets of scan %s into their connection's org unit: %v", scanRun.ID, err)
+		} else if moved > 0 {
+			log.Printf("🏢 Moved %d assets of scan %s into their connection's org unit", moved, scanRun.ID)
+		}
+	}
+
+	for i, finding := range critical {
+		if i == maxCriticalFindingEvents {
+			break
+		}
+		s.events.Publish(ctx, interfaces.EventCriticalFinding, map[string]interface{}{
+			"finding_id":   finding.ID,
+			"scan_id":      scanRun.ID,
+			"asset_id":     finding.AssetID,
+			"pattern_name": finding.PatternName,
+			"severity":     finding.Severity,
+		})
+	}
+
+	s.events.Publish(ctx, interfaces.EventScanCompleted, map[string]interface{}{
+		"scan_id":           scanRun.ID,
+		"status":            scanRun.Status,
+		"total_findings":    scanRun.TotalFindings,
+		"total_assets":      scanRun.TotalAssets,
+		"critical_findings": len(critical),
+	})
+}
+
+// publishFindingsCreated announces committed findings to downstream consumers.
+// Matched values and sample text are left out; consumers fetch them through the API.
+func (s *IngestionService) publishFindingsCreated(ctx context.Context, findings []*entity.Finding) {
+	for _, finding := range findings {
+		s.integration.Publish(ctx, interfaces.EventFindingCreated, map[string]interface{}{
+			"finding_id":       finding.ID,
+			"scan_run_id":      finding.ScanRunID,
+			"asset_id":         finding.AssetID,
+			"pattern_name":     finding.PatternName,
+			"severity":         finding.Severity,
+			"confidence_score": finding.ConfidenceScore,
+			"environment":      finding.Environment,
+			"match_count":      max(finding.TotalMatches, len(finding.Matches)),
+			"created_at":       finding.CreatedAt,
+		})
 	}
 }
 
 // HawkeyeScanInput represents the Hawk-eye scanner JSON format
 type HawkeyeScanInput struct {
-	ScanID     string           `json:"scan_id"` // Added for correlation
-	FS         []HawkeyeFinding `json:"fs"`
-	PostgreSQL []HawkeyeFinding `json:"postgresql"`
+	// SchemaVersion follows the ingest-verified versions; Hawk-eye findings
+	// have not changed shape between them
+	SchemaVersion int              `json:"schema_version,omitempty"`
+	ScanID        string           `json:"scan_id"` // Added for correlation
+	FS            []HawkeyeFinding `json:"fs"`
+	PostgreSQL    []HawkeyeFinding `json:"postgresql"`
 }
 
 // HawkeyeFinding represents a single finding from Hawk-eye
 type HawkeyeFinding struct {
 	Host                string                 `json:"host"`
+	Bucket              string                 `json:"bucket,omitempty"` // S3 findings name their bucket here and the object key in FilePath
 	FilePath            string                 `json:"file_path"`
 	PatternName         string                 `json:"pattern_name"`
 	Matches             []string               `json:"matches"`
+	TotalMatches        int                    `json:"total_matches,omitempty"` // Set by scanners that send only some of the matches they found
 	SampleText          string                 `json:"sample_text"`
 	Profile             string                 `json:"profile"`
 	DataSource          string                 `json:"data_source"`
 	FileData            map[string]interface{} `json:"file_data"`
 	Severity            string                 `json:"severity"`
 	SeverityDescription string                 `json:"severity_description"`
+	Locations           []entity.MatchLocation `json:"locations,omitempty"` // Where each match sits in the source, if the scanner reports it
 }
 
 // IngestScanResult represents the result of ingestion
@@ -69,53 +304,194 @@ type IngestScanResult struct {
 	TotalAssets   int       `json:"total_assets"`
 	AssetsCreated int       `json:"assets_created"`
 	PatternsFound int       `json:"patterns_found"`
+	Suppressed    int       `json:"suppressed_findings"`
+	Dropped       int       `json:"dropped_findings"`    // Non-PII and low-confidence findings not stored
+	Retries       int       `json:"transaction_retries"` // Transactions run again after transient Postgres errors
 }
 
-// IngestScan processes Hawk-eye scan output and normalizes it into the database
+// IngestionConfidenceThreshold is the default classification score a PII finding needs to be stored
+const IngestionConfidenceThreshold = 0.45
+
+// defaultIngestionWorkers bounds how many asset groups are ingested at once
+const defaultIngestionWorkers = 4
+
+// assetGroup is the findings of a single asset, ingested together in one transaction
+type assetGroup struct {
+	key      string
+	findings []HawkeyeFinding
+}
+
+// assetGroupResult summarises one ingested asset group
+type assetGroupResult struct {
+	assetID   uuid.UUID
+	isNew     bool
+	sanitized int
+	critical  []*entity.Finding
+	created   []*entity.Finding
+	dropped   droppedTally
+	retries   int
+}
+
+// IngestScan processes Hawk-eye scan output and normalizes it into the database.
+// Findings are grouped by asset and the groups are ingested concurrently, each in
+// its own transaction; a failed group marks the scan run failed.
 func (s *IngestionService) IngestScan(ctx context.Context, input *HawkeyeScanInput) (*IngestScanResult, error) {
+	if _, err := normalizeSchemaVersion(input.SchemaVersion); err != nil {
+		return nil, err
+	}
 	if len(input.FS) == 0 && len(input.PostgreSQL) == 0 {
 		return nil, fmt.Errorf("no findings in scan input")
 	}
 
-	// BEGIN TRANSACTION - Critical fix for ING-001
-	tx, err := s.repo.BeginTx(ctx)
+	// Combine findings
+	allFindings := append(input.FS, input.PostgreSQL...)
+
+	ctx, perf := scanperf.Start(ctx)
+
+	// Suppressed findings are dropped before anything is persisted; only their counts are kept
+	suppressions, err := loadSuppressions(ctx, s.repo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, err
 	}
+	findings, tally := applySuppressions(allFindings, suppressions)
 
-	// Ensure rollback on panic or error
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-			log.Printf("PANIC during ingestion, transaction rolled back: %v", r)
-			// Don't re-panic - log and return error instead
-			// The panic value is logged above
+	findings, budget, err := s.applyFindingQuota(ctx, findings)
+	if err != nil {
+		return nil, err
+	}
+
+	stopDB := perf.Time(scanperf.StageDB)
+	scanRun, retries, err := s.openScanRun(ctx, input.ScanID, allFindings)
+	if err != nil {
+		return nil, err
+	}
+
+	// Patterns are resolved up front so concurrent groups never race to create the same one
+	patternMap := make(map[string]uuid.UUID) // pattern name -> UUID
+	for i := range findings {
+		if _, err := s.getOrCreatePattern(ctx, &findings[i], patternMap); err != nil {
+			s.failScanRun(ctx, scanRun)
+			return nil, fmt.Errorf("failed to get/create pattern: %w", err)
 		}
-	}()
+	}
+	stopDB()
+
+	groups := groupFindingsByAsset(findings, s.pathPolicies)
+	results := make([]assetGroupResult, len(groups))
+	var processed int64
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(s.workers)
+	for i, group := range groups {
+		g.Go(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("PANIC during ingestion of asset group %s, transaction rolled back: %v", group.key, r)
+					err = fmt.Errorf("panic during ingestion: %v", r)
+				}
+			}()
+
+			results[i], err = s.ingestAssetGroup(groupCtx, scanRun, group, patternMap, &processed, len(findings))
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		s.failScanRun(ctx, scanRun)
+		return nil, err
+	}
 
-	// Combine findings
-	allFindings := append(input.FS, input.PostgreSQL...)
+	// Merge in group order so totals and notifications don't depend on scheduling
+	assetIDs := make(map[uuid.UUID]bool)
+	assetsCreated := 0
+	sanitizationCount := 0
+	var criticalFindings []*entity.Finding
+	dropped := make(droppedTally)
+	for _, result := range results {
+		assetIDs[result.assetID] = true
+		if result.isNew {
+			assetsCreated++
+		}
+		sanitizationCount += result.sanitized
+		criticalFindings = append(criticalFindings, result.critical...)
+		dropped.merge(result.dropped)
+		retries += result.retries
+	}
 
-	// Try to link to existing ScanRun if ScanID is provided in input
-	// (Check first finding's custom field or top-level metadata if available)
+	// Track sanitization in scan metadata
+	if sanitizationCount > 0 {
+		if existingCount, ok := scanRun.Metadata["sanitized_findings"].(int); ok {
+			scanRun.Metadata["sanitized_findings"] = existingCount + sanitizationCount
+		} else {
+			scanRun.Metadata["sanitized_findings"] = sanitizationCount
+		}
+	}
+
+	tally.annotate(scanRun)
+	budget.annotate(scanRun)
+	dropped.annotate(scanRun, s.filter)
+
+	// Update scan run totals
+	scanRun.Status = "completed"
+	scanRun.TotalFindings = len(findings)
+	scanRun.TotalAssets = len(assetIDs)
+	performance := perf.Report(scanRun.TotalFindings, scanRun.TotalAssets, perf.Wait(0))
+	scanRun.Metadata["performance"] = performance
+	saveRetries, err := s.saveScanRun(ctx, scanRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update scan run: %w", err)
+	}
+	retries += saveRetries
+	s.recordLineageSyncTime(ctx, scanRun.ID, perf, performance)
+
+	if err := s.repo.RecordSuppressionHits(ctx, tally.byRule); err != nil {
+		log.Printf("WARNING: %v", err)
+	}
+	if err := s.repo.RecordDroppedFindings(ctx, scanRun.ID, dropped.counts(s.filter.Enforced())); err != nil {
+		log.Printf("WARNING: %v", err)
+	}
+
+	s.onIngestionComplete(ctx, scanRun, criticalFindings)
+
+	result := &IngestScanResult{
+		ScanRunID:     scanRun.ID,
+		TotalFindings: scanRun.TotalFindings,
+		TotalAssets:   scanRun.TotalAssets,
+		AssetsCreated: assetsCreated,
+		PatternsFound: len(patternMap),
+		Suppressed:    tally.total,
+		Retries:       retries,
+	}
+	if s.filter.Enforced() {
+		result.Dropped = dropped.total()
+	}
+	return result, nil
+}
+
+// openScanRun links the ingestion to an existing scan run, or creates one, and
+// marks it running while asset groups are ingested. It returns the number of
+// times the transaction was retried.
+func (s *IngestionService) openScanRun(ctx context.Context, scanID string, allFindings []HawkeyeFinding) (*entity.ScanRun, int, error) {
 	var scanRun *entity.ScanRun
 
-	// Check if scan_id is in the input (we added this field to struct)
-	if input.ScanID != "" {
-		if id, err := uuid.Parse(input.ScanID); err == nil {
+	// Try to link to existing ScanRun if ScanID is provided in input
+	if scanID != "" {
+		if id, err := uuid.Parse(scanID); err == nil {
 			scanRun, err = s.repo.GetScanRunByID(ctx, id)
 			if err != nil {
-				log.Printf("WARNING: specific scan_id %s not found, creating new", input.ScanID)
+				log.Printf("WARNING: specific scan_id %s not found, creating new", scanID)
 			}
 		}
 	}
 
-	// Also check first finding's profile or metadata if available
-	if scanRun == nil && len(allFindings) > 0 {
-		// Fallback logic could go here
-	}
+	// The run is prepared once so a retried transaction writes the same row
+	create := scanRun == nil
+	if create {
+		// Only scan runs created here count against the daily quota; a run started
+		// through the scan API was counted when it was triggered
+		if err := s.quotas.AdmitScanRun(ctx); err != nil {
+			return nil, 0, err
+		}
 
-	if scanRun == nil {
 		profileName := allFindings[0].Profile
 		if profileName == "" {
 			profileName = "default"
@@ -127,289 +503,518 @@ func (s *IngestionService) IngestScan(ctx context.Context, input *HawkeyeScanInp
 			ScanStartedAt:   time.Now().Add(-5 * time.Minute), // Approximate
 			ScanCompletedAt: time.Now(),
 			Host:            allFindings[0].Host,
-			Status:          "completed",
+			Status:          "running",
 			Metadata:        map[string]interface{}{},
 		}
 
-		if err := tx.CreateScanRun(ctx, scanRun); err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("failed to create scan run: %w", err)
+		// The scanner names the connection profile it scanned
+		connectionID, err := s.repo.FindConnectionIDByProfile(ctx, profileName)
+		if err != nil {
+			log.Printf("WARNING: failed to link scan run to connection %s: %v", profileName, err)
 		}
+		scanRun.ConnectionID = connectionID
 	} else {
 		// Update existing scan run
-		scanRun.Status = "completed"
+		scanRun.Status = "running"
 		scanRun.ScanCompletedAt = time.Now()
 		if scanRun.Metadata == nil {
 			scanRun.Metadata = make(map[string]interface{})
 		}
-
-		if err := tx.UpdateScanRun(ctx, scanRun); err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("failed to update scan run: %w", err)
-		}
 	}
 
-	// Track created assets and patterns
-	assetMap := make(map[string]uuid.UUID)   // stableID -> UUID
-	patternMap := make(map[string]uuid.UUID) // pattern name -> UUID
-	assetsCreated := 0
-
-	// Process each finding
-	for _, hawkeyeFinding := range allFindings {
-		// Build asset from finding data
-		asset := s.buildAssetFromFinding(&hawkeyeFinding, scanRun)
-
-		// Delegate asset creation to AssetManager (single source of truth)
-		assetID, isNew, err := s.assetManager.CreateOrUpdateAsset(ctx, asset)
+	retries, err := persistence.RetryTransient(ctx, s.retry, "ingest_open_scan_run", func(ctx context.Context) error {
+		tx, err := s.repo.BeginTransaction(ctx)
 		if err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("failed to create/update asset: %w", err)
+			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
+		defer tx.Rollback()
 
-		assetMap[asset.StableID] = assetID
-		if isNew {
-			assetsCreated++
+		if create {
+			if err := tx.CreateScanRun(ctx, scanRun); err != nil {
+				return fmt.Errorf("failed to create scan run: %w", err)
+			}
+		} else if err := tx.UpdateScanRun(ctx, scanRun); err != nil {
+			return fmt.Errorf("failed to update scan run: %w", err)
 		}
 
-		// Get or create pattern
-		patternID, err := s.getOrCreatePattern(ctx, &hawkeyeFinding, patternMap)
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit scan run: %w", persistence.CommitError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, retries, err
+	}
+	return scanRun, retries, nil
+}
+
+// applyFindingQuota admits findings against the tenant's findings quota, dropping
+// the ones downsampled away. The returned budget is nil when no quota applies.
+func (s *IngestionService) applyFindingQuota(ctx context.Context, findings []HawkeyeFinding) ([]HawkeyeFinding, *FindingBudget, error) {
+	budget, err := s.quotas.FindingBudget(ctx)
+	if err != nil || budget == nil {
+		return findings, nil, err
+	}
+	if err := budget.Check(len(findings)); err != nil {
+		return nil, nil, err
+	}
+
+	kept := make([]HawkeyeFinding, 0, len(findings))
+	for _, f := range findings {
+		admitted, err := budget.Admit(f.Severity)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get/create pattern: %w", err)
+			return nil, nil, err
 		}
+		if admitted {
+			kept = append(kept, f)
+		}
+	}
+	return kept, budget, nil
+}
 
-		// ENRICHMENT LAYER - Add contextual intelligence
-		// Extract column name if this is a database finding
-		columnName := ""
-		if colVal, ok := hawkeyeFinding.FileData["column_name"]; ok {
-			if colStr, ok := colVal.(string); ok {
-				columnName = colStr
-			}
+// saveScanRun persists scan run status and totals, returning the number of
+// times the transaction was retried
+func (s *IngestionService) saveScanRun(ctx context.Context, scanRun *entity.ScanRun) (int, error) {
+	return persistence.RetryTransient(ctx, s.retry, "ingest_save_scan_run", func(ctx context.Context) error {
+		tx, err := s.repo.BeginTransaction(ctx)
+		if err != nil {
+			return err
 		}
+		defer tx.Rollback()
 
-		matchSample := ""
-		if len(hawkeyeFinding.Matches) > 0 {
-			matchSample = hawkeyeFinding.Matches[0]
+		if err := tx.UpdateScanRun(ctx, scanRun); err != nil {
+			return err
 		}
+		// Rewriting the same status and totals is harmless, so a commit with an
+		// unknown outcome may be retried too
+		return tx.Commit()
+	})
+}
 
-		// CRITICAL FIX #3: Normalize before classification
-		normalizedMatch := normalization.Normalize(matchSample)
+// failScanRun marks a scan run failed after an asset group could not be ingested.
+// Groups already committed are kept.
+func (s *IngestionService) failScanRun(ctx context.Context, scanRun *entity.ScanRun) {
+	scanRun.Status = "failed"
+	if _, err := s.saveScanRun(ctx, scanRun); err != nil {
+		log.Printf("WARNING: Failed to mark scan run %s as failed: %v", scanRun.ID, err)
+	}
+}
 
-		// Perform enrichment
-		enrichmentSignals := s.enrichment.Enrich(ctx, EnrichmentContext{
-			FilePath:    hawkeyeFinding.FilePath,
-			MatchValue:  normalizedMatch, // Use normalized value
-			PatternName: hawkeyeFinding.PatternName,
-			AssetType:   "file",
-			ColumnName:  columnName,
-		})
+// lineageSyncTimeout bounds how long a scan run's profile waits for its lineage syncs
+const lineageSyncTimeout = 2 * time.Minute
 
-		// Calculate enrichment score (this becomes the Context Score in multi-signal)
-		enrichmentScore := s.enrichment.GetEnrichmentScore(enrichmentSignals)
+// recordLineageSyncTime rewrites the scan run's performance profile once the
+// lineage syncs of its assets, which outlive the ingestion, have finished
+func (s *IngestionService) recordLineageSyncTime(ctx context.Context, scanRunID uuid.UUID, perf *scanperf.Profile, performance *entity.ScanPerformance) {
+	if !performance.Neo4jSyncPending {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		finished := perf.Wait(lineageSyncTimeout)
+		updated := *performance
+		updated.Neo4jSyncMs = perf.Total(scanperf.StageNeo4jSync).Milliseconds()
+		updated.Neo4jSyncs = perf.Syncs()
+		updated.Neo4jSyncPending = !finished
+		if err := s.repo.SetScanRunPerformance(ctx, scanRunID, &updated); err != nil {
+			log.Printf("WARNING: Failed to record lineage sync time of scan run %s: %v", scanRunID, err)
+		}
+	}()
+}
 
-		// Classify finding using multi-signal engine
-		multiSignalInput := MultiSignalInput{
-			PatternName:       hawkeyeFinding.PatternName,
-			FilePath:          hawkeyeFinding.FilePath,
-			MatchValue:        normalizedMatch,
-			ColumnName:        columnName,
-			FileData:          hawkeyeFinding.FileData,
-			EnrichmentScore:   enrichmentScore,
-			EnrichmentSignals: enrichmentSignals,
+// groupFindingsByAsset splits findings by the asset they belong to, keeping the
+// input order both across groups and within each group
+func groupFindingsByAsset(findings []HawkeyeFinding, policies canonical.Policies) []*assetGroup {
+	var groups []*assetGroup
+	byKey := make(map[string]*assetGroup)
+
+	for _, f := range findings {
+		key := assetGroupKey(&f, policies)
+		group, ok := byKey[key]
+		if !ok {
+			group = &assetGroup{key: key}
+			byKey[key] = group
+			groups = append(groups, group)
 		}
+		group.findings = append(group.findings, f)
+	}
+	return groups
+}
+
+// assetGroupKey is the stable ID AssetService gives the finding's asset, so every
+// finding of an asset lands in the same group and no two groups race for one asset
+func assetGroupKey(f *HawkeyeFinding, policies canonical.Policies) string {
+	return canonical.StableID(f.DataSource, assetHost(f), f.FilePath, policies)
+}
 
-		decision, err := s.classifier.ClassifyMultiSignal(ctx, multiSignalInput)
+// ingestAssetGroup creates or updates the group's asset and stores its findings,
+// classifications and review states in a single transaction
+func (s *IngestionService) ingestAssetGroup(
+	ctx context.Context,
+	scanRun *entity.ScanRun,
+	group *assetGroup,
+	patternMap map[string]uuid.UUID,
+	processed *int64,
+	total int,
+) (assetGroupResult, error) {
+	result := assetGroupResult{}
+
+	// Build asset from finding data
+	asset := s.buildAssetFromFinding(&group.findings[0], scanRun)
+
+	// Delegate asset creation to AssetManager (single source of truth)
+	perf := scanperf.FromContext(ctx)
+	stopDB := perf.Time(scanperf.StageDB)
+	assetID, isNew, err := s.assetManager.CreateOrUpdateAsset(ctx, asset)
+	stopDB()
+	if err != nil {
+		return result, fmt.Errorf("failed to create/update asset: %w", err)
+	}
+	result.assetID = assetID
+	result.isNew = isNew
+
+	// Classified findings for the shadow engine, submitted once the findings are committed
+	var shadowSamples []ShadowSample
+
+	// The whole transaction is run again after a transient error, starting over
+	// with the findings and progress of the failed attempt discarded
+	result.retries, err = persistence.RetryTransient(ctx, s.retry, "ingest_asset_group", func(ctx context.Context) error {
+		result.sanitized, result.created, result.critical, shadowSamples = 0, nil, nil, nil
+		result.dropped = make(droppedTally)
+		done := 0
+
+		err := s.ingestAssetGroupTx(ctx, scanRun, assetID, group, patternMap, &result, &shadowSamples, func() {
+			done++
+			s.publishProgress(ctx, scanRun.ID, int(atomic.AddInt64(processed, 1)), total)
+		})
 		if err != nil {
-			log.Printf("ERROR: Classification failed for %s: %v", hawkeyeFinding.PatternName, err)
-			continue
+			atomic.AddInt64(processed, -int64(done))
 		}
+		return err
+	})
+	if err != nil {
+		return result, err
+	}
+	s.publishFindingsCreated(ctx, result.created)
+	s.shadow.Submit(ctx, shadowSamples)
+
+	// Recalculate robust risk score based on all findings
+	stopDB = perf.Time(scanperf.StageDB)
+	if err := s.recalculateAssetRisk(ctx, assetID); err != nil {
+		// Log error but continue with other assets
+		log.Printf("Error recalculating risk for asset %s: %v", assetID, err)
+	}
+	stopDB()
 
-		// Filter Non-PII at ingestion time (60-80% DB size reduction)
-		// Only store findings that are confirmed PII with sufficient confidence
-		if decision.Classification == "Non-PII" || decision.FinalScore < 0.45 {
-			// Skip low-confidence and Non-PII findings to prevent database bloat
-			continue
-		}
+	// Note: Lineage sync is now handled by AssetService automatically
+	// No need to call it here - loose coupling achieved!
 
-		// Sanitize inputs for Postgres (remove null bytes) with logging
-		sanitizedMatches := make([]string, len(hawkeyeFinding.Matches))
-		sanitizationCount := 0
-		for i, m := range hawkeyeFinding.Matches {
-			if strings.Contains(m, "\u0000") {
-				sanitizationCount++
-				log.Printf("WARNING: Null byte detected in finding %s at %s (removed)",
-					hawkeyeFinding.PatternName, hawkeyeFinding.FilePath)
-			}
-			sanitizedMatches[i] = strings.ReplaceAll(m, "\u0000", "")
-		}
-		sanitizedSample := strings.ReplaceAll(hawkeyeFinding.SampleText, "\u0000", "")
+	return result, nil
+}
 
-		// Track sanitization in scan metadata
-		if sanitizationCount > 0 {
-			if scanRun.Metadata == nil {
-				scanRun.Metadata = make(map[string]interface{})
-			}
-			if existingCount, ok := scanRun.Metadata["sanitized_findings"].(int); ok {
-				scanRun.Metadata["sanitized_findings"] = existingCount + sanitizationCount
-			} else {
-				scanRun.Metadata["sanitized_findings"] = sanitizationCount
-			}
-		}
+// ingestAssetGroupTx stores the group's findings in one transaction, calling
+// progress after each finding
+func (s *IngestionService) ingestAssetGroupTx(
+	ctx context.Context,
+	scanRun *entity.ScanRun,
+	assetID uuid.UUID,
+	group *assetGroup,
+	patternMap map[string]uuid.UUID,
+	result *assetGroupResult,
+	shadowSamples *[]ShadowSample,
+	progress func(),
+) error {
+	perf := scanperf.FromContext(ctx)
+	stopDB := perf.Time(scanperf.StageDB)
+	tx, err := s.repo.BeginTransaction(ctx)
+	stopDB()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Value hashes already stored for this asset in this scan; the first occurrence wins
+	seen := make(map[string]bool)
 
-		// Convert enrichment signals to map for storage
-		enrichmentMap := map[string]interface{}{
-			"asset_semantics":   enrichmentSignals.AssetSemantics,
-			"environment":       enrichmentSignals.Environment,
-			"entropy":           enrichmentSignals.Entropy,
-			"charset_diversity": enrichmentSignals.CharsetDiversity,
-			"token_shape":       enrichmentSignals.TokenShape,
-			"value_hash":        enrichmentSignals.ValueHash,
-			"historical_count":  enrichmentSignals.HistoricalCount,
-		}
-
-		// Generate normalized hash for deduplication
-		// Use pkg/normalization when available, inline implementation for now
-		normalizedValue := strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(matchSample, " ", ""), "-", ""))
-		hash := sha256.Sum256([]byte(normalizedValue))
-		valueHash := hex.EncodeToString(hash[:])
-		_ = valueHash // Will be used when entity.Finding has NormalizedValueHash field
-
-		// Check for duplicates (same asset, pattern, and value hash in this scan)
-		// Note: This requires adding GetFindingByHash to repository interface
-		// For now, we'll just add the hash and rely on the unique index to prevent duplication
-		// The database migration 000003_add_deduplication.up.sql adds:
-		// CREATE UNIQUE INDEX idx_findings_unique ON findings(asset_id, pattern_name, normalized_value_hash, scan_run_id)
-
-		// Calculate dynamic severity based on classification, confidence, and context
-		dynamicSeverity := calculateDynamicSeverity(
-			decision.Classification,
-			decision.ConfidenceLevel,
-			hawkeyeFinding.FileData,
-		)
-
-		// Calculate risk score for prioritization (0-100)
-		riskScore := calculateComprehensiveRiskScore(
-			decision.Classification,
-			decision.ConfidenceLevel,
-			hawkeyeFinding.FileData,
-		)
-
-		// Classification: Test vs Prod
-		environment := "PROD"
-		status := "pending"
-
-		if isTestArtifact(hawkeyeFinding.FilePath) || isSemanticTestData(hawkeyeFinding.SampleText) {
-			environment = "TEST"
-			status = "ignored"
-		}
-
-		// Create finding with deduplication hash
-		finding := &entity.Finding{
-			ID:                  uuid.New(),
-			ScanRunID:           scanRun.ID,
-			AssetID:             assetID,
-			PatternID:           &patternID,
-			PatternName:         hawkeyeFinding.PatternName,
-			Matches:             sanitizedMatches,
-			SampleText:          sanitizedSample,
-			Severity:            dynamicSeverity, // Now calculated from classification+confidence+context
-			SeverityDescription: fmt.Sprintf("Risk Score: %d/100 | %s", riskScore, decision.Justification),
-			ConfidenceScore:     &decision.FinalScore,
-			Environment:         environment,
-			Context:             decision.SignalBreakdown,
-			EnrichmentSignals:   enrichmentMap,
-			EnrichmentScore:     &enrichmentScore,
-			EnrichmentFailed:    enrichmentSignals.EnrichmentFailed,
-			CreatedAt:           time.Now(),
-			UpdatedAt:           time.Now(),
-		}
-
-		if err := tx.CreateFinding(ctx, finding); err != nil {
-			// Check if error is due to duplicate (unique constraint violation)
-			if strings.Contains(err.Error(), "idx_findings_unique") {
-				// Duplicate detected - skip silently or log
-				log.Printf("DEBUG: Duplicate finding skipped for %s at %s", hawkeyeFinding.PatternName, hawkeyeFinding.FilePath)
-				continue
+	for i := range group.findings {
+		hawkeyeFinding := &group.findings[i]
+
+		finding, sanitized, err := s.ingestFinding(ctx, tx, scanRun, assetID, patternMap[hawkeyeFinding.PatternName], hawkeyeFinding, seen, result.dropped, shadowSamples)
+		if err != nil {
+			return err
+		}
+		result.sanitized += sanitized
+		if finding != nil {
+			result.created = append(result.created, finding)
+			if strings.EqualFold(finding.Severity, "Critical") {
+				result.critical = append(result.critical, finding)
 			}
-			tx.Rollback()
-			return nil, fmt.Errorf("failed to create finding: %w", err)
 		}
+		progress()
+	}
 
-		// Save Classification
-		classification := &entity.Classification{
-			ID:                 uuid.New(),
-			FindingID:          finding.ID,
-			ClassificationType: decision.Classification,
-			SubCategory:        decision.SubCategory,
-			ConfidenceScore:    decision.FinalScore,
-			Justification:      decision.Justification,
-			DPDPACategory:      decision.DPDPACategory,
-			RequiresConsent:    decision.RequiresConsent,
-		}
+	defer perf.Time(scanperf.StageDB)()
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", persistence.CommitError(err))
+	}
+	return nil
+}
 
-		if err := tx.CreateClassification(ctx, classification); err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("failed to create classification: %w", err)
+// ingestFinding classifies a single finding and stores it within the group's
+// transaction. It returns a nil finding when the finding is filtered or a duplicate;
+// filtered findings are counted in dropped. With shadow classification enabled,
+// every classified finding is added to shadow.
+func (s *IngestionService) ingestFinding(
+	ctx context.Context,
+	tx repository.Transaction,
+	scanRun *entity.ScanRun,
+	assetID, patternID uuid.UUID,
+	hawkeyeFinding *HawkeyeFinding,
+	seen map[string]bool,
+	dropped droppedTally,
+	shadow *[]ShadowSample,
+) (*entity.Finding, int, error) {
+	// ENRICHMENT LAYER - Add contextual intelligence
+	// Extract column name if this is a database finding
+	columnName := ""
+	if colVal, ok := hawkeyeFinding.FileData["column_name"]; ok {
+		if colStr, ok := colVal.(string); ok {
+			columnName = colStr
 		}
+	}
 
-		// Create review state (Logic moved upstream)
-		reviewState := &entity.ReviewState{
-			ID:        uuid.New(),
-			FindingID: finding.ID,
-			Status:    status,
-		}
+	matchSample := ""
+	if len(hawkeyeFinding.Matches) > 0 {
+		matchSample = hawkeyeFinding.Matches[0]
+	}
 
-		if err := tx.CreateReviewState(ctx, reviewState); err != nil {
-			tx.Rollback()
-			return nil, fmt.Errorf("failed to create review state: %w", err)
-		}
+	// Deduplicate on asset, pattern and normalized value within this scan.
+	// Groups are per asset and walked in input order, so the result is deterministic.
+	valueHash := observationValueHash(matchSample)
+	dedupeKey := hawkeyeFinding.PatternName + ":" + valueHash
+	if seen[dedupeKey] {
+		log.Printf("DEBUG: Duplicate finding skipped for %s at %s", hawkeyeFinding.PatternName, hawkeyeFinding.FilePath)
+		return nil, 0, nil
 	}
+	seen[dedupeKey] = true
+
+	// CRITICAL FIX #3: Normalize before classification
+	normalizedMatch := normalization.Normalize(matchSample)
+
+	// Perform enrichment
+	stopClassification := scanperf.FromContext(ctx).Time(scanperf.StageClassification)
+	enrichmentSignals := s.enrichment.Enrich(ctx, EnrichmentContext{
+		FilePath:    hawkeyeFinding.FilePath,
+		MatchValue:  normalizedMatch, // Use normalized value
+		PatternName: hawkeyeFinding.PatternName,
+		AssetType:   "file",
+		ColumnName:  columnName,
+		SampleText:  hawkeyeFinding.SampleText,
+	})
 
-	// Update asset total findings and create relationships
-	for stableID, assetID := range assetMap {
-		// Count findings for this asset
-		count, err := s.repo.CountFindings(ctx, repository.FindingFilters{
-			AssetID: &assetID,
-		})
+	// Calculate enrichment score (this becomes the Context Score in multi-signal)
+	enrichmentScore := s.enrichment.GetEnrichmentScore(enrichmentSignals)
+
+	// Classify finding using multi-signal engine
+	multiSignalInput := MultiSignalInput{
+		PatternName:       hawkeyeFinding.PatternName,
+		FilePath:          hawkeyeFinding.FilePath,
+		MatchValue:        normalizedMatch,
+		ColumnName:        columnName,
+		FileData:          hawkeyeFinding.FileData,
+		EnrichmentScore:   enrichmentScore,
+		EnrichmentSignals: enrichmentSignals,
+	}
 
-		if err != nil {
-			return nil, fmt.Errorf("failed to count findings: %w", err)
-		}
+	decision, err := s.classifier.ClassifyMultiSignal(ctx, multiSignalInput)
+	stopClassification()
+	if err != nil {
+		log.Printf("ERROR: Classification failed for %s: %v", hawkeyeFinding.PatternName, err)
+		return nil, 0, nil
+	}
+
+	shadowSample := ShadowSample{ScanRunID: scanRun.ID, Input: multiSignalInput, Primary: decision}
+
+	// Content outside the supported languages is misclassified more often. The
+	// shadow engine is still compared with the engine's own verdict.
+	if s.language.factor(enrichmentSignals.Language) < 1 {
+		engineDecision := *decision
+		shadowSample.Primary = &engineDecision
+		s.language.downgrade(decision, enrichmentSignals.Language)
+	}
 
-		// Update asset with finding count
-		asset, _ := s.repo.GetAssetByStableID(ctx, stableID)
-		if asset != nil {
-			asset.TotalFindings = count
-			// Recalculate robust risk score based on all findings
-			if err := s.recalculateAssetRisk(ctx, assetID); err != nil {
-				// Log error but continue with other assets
-				fmt.Printf("Error recalculating risk for asset %s: %v\n", stableID, err)
+	// Filter Non-PII and low-confidence findings at ingestion time (60-80% DB size
+	// reduction). Each is counted in the ledger; audit mode stores it anyway.
+	if reason := s.filter.reason(decision); reason != "" {
+		dropped.add(reason, hawkeyeFinding.PatternName, decision.FinalScore)
+		if s.filter.Enforced() {
+			if s.shadow.Enabled() {
+				*shadow = append(*shadow, shadowSample)
 			}
+			return nil, 0, nil
+		}
+	}
 
-			// Note: Lineage sync is now handled by AssetService automatically
-			// No need to call it here - loose coupling achieved!
+	// Sanitize inputs for Postgres (remove null bytes) with logging
+	sanitizedMatches := make([]string, len(hawkeyeFinding.Matches))
+	sanitizationCount := 0
+	for i, m := range hawkeyeFinding.Matches {
+		if strings.Contains(m, "\u0000") {
+			sanitizationCount++
+			log.Printf("WARNING: Null byte detected in finding %s at %s (removed)",
+				hawkeyeFinding.PatternName, hawkeyeFinding.FilePath)
 		}
+		sanitizedMatches[i] = strings.ReplaceAll(m, "\u0000", "")
+	}
+	sanitizedSample := strings.ReplaceAll(hawkeyeFinding.SampleText, "\u0000", "")
+
+	// Convert enrichment signals to map for storage
+	enrichmentMap := map[string]interface{}{
+		"asset_semantics":     enrichmentSignals.AssetSemantics,
+		"environment":         enrichmentSignals.Environment,
+		"entropy":             enrichmentSignals.Entropy,
+		"charset_diversity":   enrichmentSignals.CharsetDiversity,
+		"token_shape":         enrichmentSignals.TokenShape,
+		"value_hash":          enrichmentSignals.ValueHash,
+		"historical_count":    enrichmentSignals.HistoricalCount,
+		"language":            enrichmentSignals.Language,
+		"language_confidence": enrichmentSignals.LanguageConfidence,
 	}
 
-	// Update scan run totals
-	scanRun.TotalFindings = len(allFindings)
-	scanRun.TotalAssets = len(assetMap)
-	if err := tx.UpdateScanRun(ctx, scanRun); err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("failed to update scan run: %w", err)
+	// Calculate dynamic severity based on classification, confidence, and context
+	dynamicSeverity := calculateDynamicSeverity(
+		decision.Classification,
+		decision.ConfidenceLevel,
+		hawkeyeFinding.FileData,
+	)
+
+	// Calculate risk score for prioritization (0-100)
+	riskBreakdown := riskScoreBreakdown(
+		decision.Classification,
+		decision.ConfidenceLevel,
+		hawkeyeFinding.FileData,
+	)
+
+	// Findings over many records are raised a tier per volume threshold they reach
+	affected := EstimateAffectedRecords(max(hawkeyeFinding.TotalMatches, len(hawkeyeFinding.Matches)), hawkeyeFinding.FileData)
+	volumeTiers := 0
+	if dynamicSeverity != "Info" {
+		volumeTiers = s.volume.Tiers(affected)
 	}
+	dynamicSeverity = s.volume.Escalate(dynamicSeverity, volumeTiers)
+	riskBreakdown.applyVolume(affected, volumeTiers)
+	riskScore := riskBreakdown.Score
 
-	// CRITICAL FIX: Commit the transaction to persist all changes
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	// Classification: Test vs Prod
+	environment := "PROD"
+	status := "pending"
+
+	if isTestArtifact(hawkeyeFinding.FilePath) || isSemanticTestData(hawkeyeFinding.SampleText) {
+		environment = "TEST"
+		status = "ignored"
 	}
 
-	return &IngestScanResult{
-		ScanRunID:     scanRun.ID,
-		TotalFindings: scanRun.TotalFindings,
-		TotalAssets:   scanRun.TotalAssets,
-		AssetsCreated: assetsCreated,
-		PatternsFound: len(patternMap),
-	}, nil
+	// Create finding
+	finding := &entity.Finding{
+		ID:                  uuid.New(),
+		ScanRunID:           scanRun.ID,
+		AssetID:             assetID,
+		PatternID:           &patternID,
+		PatternName:         hawkeyeFinding.PatternName,
+		Matches:             sanitizedMatches,
+		TotalMatches:        hawkeyeFinding.TotalMatches,
+		SampleText:          sanitizedSample,
+		Severity:            dynamicSeverity, // Now calculated from classification+confidence+context
+		SeverityDescription: fmt.Sprintf("Risk Score: %d/100 | %s%s", riskScore, decision.Justification, volumeDescription(affected, volumeTiers)),
+		ConfidenceScore:     &decision.FinalScore,
+		Environment:         environment,
+		Context:             decision.SignalBreakdown,
+		Locations:           validMatchLocations(hawkeyeFinding),
+		EnrichmentSignals:   enrichmentMap,
+		EnrichmentScore:     &enrichmentScore,
+		EnrichmentFailed:    enrichmentSignals.EnrichmentFailed,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+	}
+	s.language.annotate(finding, enrichmentSignals.Language)
+	s.applySampleTextPolicy(ctx, finding)
+	// Capped after the sample text is masked so every match is masked in it
+	finding.CapMatches(s.maxMatches)
+
+	// Save Classification
+	classification := &entity.Classification{
+		ID:                 uuid.New(),
+		FindingID:          finding.ID,
+		ClassificationType: decision.Classification,
+		SubCategory:        decision.SubCategory,
+		ConfidenceScore:    decision.FinalScore,
+		Justification:      decision.Justification,
+		DPDPACategory:      decision.DPDPACategory,
+		RequiresConsent:    decision.RequiresConsent,
+		SignalBreakdown:    decision.SignalBreakdown,
+		EngineVersion:      decision.EngineVersion,
+	}
+
+	// Create review state (Logic moved upstream)
+	reviewState := &entity.ReviewState{
+		ID:        uuid.New(),
+		FindingID: finding.ID,
+		Status:    status,
+	}
+
+	if err := storeFinding(ctx, tx, finding, valueHash, classification, reviewState); err != nil {
+		return nil, 0, err
+	}
+
+	if s.shadow.Enabled() {
+		shadowSample.FindingID = &finding.ID
+		*shadow = append(*shadow, shadowSample)
+	}
+
+	return finding, sanitizationCount, nil
+}
+
+// storeFinding writes a finding with its observation, classification and review state
+func storeFinding(ctx context.Context, tx repository.Transaction, finding *entity.Finding, valueHash string, classification *entity.Classification, reviewState *entity.ReviewState) error {
+	defer scanperf.FromContext(ctx).Time(scanperf.StageDB)()
+
+	if err := tx.CreateFinding(ctx, finding); err != nil {
+		return fmt.Errorf("failed to create finding: %w", err)
+	}
+	if err := recordObservation(ctx, tx, finding, valueHash); err != nil {
+		return err
+	}
+	if err := tx.CreateClassification(ctx, classification); err != nil {
+		return fmt.Errorf("failed to create classification: %w", err)
+	}
+	if err := tx.CreateReviewState(ctx, reviewState); err != nil {
+		return fmt.Errorf("failed to create review state: %w", err)
+	}
+	return nil
+}
+
+// observationValueHash identifies a finding's value across scans: the SHA-256 of the
+// match with spaces and dashes removed, lowercased. Migration 000023 backfills with the same formula.
+func observationValueHash(match string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(match, " ", ""), "-", ""))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// recordObservation links a stored finding to the scan run that observed it
+func recordObservation(ctx context.Context, tx repository.Transaction, finding *entity.Finding, valueHash string) error {
+	err := tx.RecordFindingObservation(ctx, &entity.FindingObservation{
+		ID:          uuid.New(),
+		FindingID:   &finding.ID,
+		ScanRunID:   finding.ScanRunID,
+		AssetID:     finding.AssetID,
+		PatternName: finding.PatternName,
+		ValueHash:   valueHash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record finding observation: %w", err)
+	}
+	return nil
 }
 
 // recalculateAssetRisk derives the risk score from findings severity and count
@@ -423,9 +1028,17 @@ func (s *IngestionService) recalculateAssetRisk(ctx context.Context, assetID uui
 	// Since I can't easily modify the repo interface without touching multiple files,
 	// I will use ListFindings logic with a limit, or just count.
 
+	// The asset was just scanned, which resets its freshness SLA and any
+	// overdue penalty before the score is recomputed
+	if err := s.repo.MarkAssetScanned(ctx, assetID, time.Now()); err != nil {
+		return err
+	}
+
 	// Actually, I can use CountFindings for count.
+	// Findings under an active risk waiver do not add to the asset's risk
 	count, err := s.repo.CountFindings(ctx, repository.FindingFilters{
-		AssetID: &assetID,
+		AssetID:       &assetID,
+		ExcludeWaived: true,
 	})
 	if err != nil {
 		return err
@@ -465,8 +1078,36 @@ func (s *IngestionService) recalculateAssetRisk(ctx context.Context, assetID uui
 		}
 	}
 
-	// 4. Update Asset
-	return s.repo.UpdateAssetStats(ctx, assetID, baseScore, count)
+	// 4. Proliferation: values also stored in other assets widen the exposure
+	if count > 0 {
+		sharing, err := s.repo.CountAssetsSharingValues(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		baseScore = applyProliferation(baseScore, sharing)
+	}
+
+	// 5. Update Asset
+	return s.repo.UpdateAssetStats(ctx, assetID, baseScore, count, entity.RiskCauseScanIngestion)
+}
+
+const (
+	proliferationPointsPerAsset = 2
+	maxProliferationPoints      = 10
+)
+
+// applyProliferation raises a risk score by the number of other assets holding
+// the same PII values, capped so proliferation alone cannot dominate severity
+func applyProliferation(score, sharingAssets int) int {
+	bonus := sharingAssets * proliferationPointsPerAsset
+	if bonus > maxProliferationPoints {
+		bonus = maxProliferationPoints
+	}
+	score += bonus
+	if score > 100 {
+		score = 100
+	}
+	return score
 }
 
 func (s *IngestionService) hasFindingWithSeverity(ctx context.Context, assetID uuid.UUID, severity string) (bool, error) {
@@ -482,8 +1123,9 @@ func (s *IngestionService) hasFindingWithSeverity(ctx context.Context, assetID u
 	}
 
 	count, err := s.repo.CountFindings(ctx, repository.FindingFilters{
-		AssetID:  &assetID,
-		Severity: targetSev,
+		AssetID:       &assetID,
+		Severity:      targetSev,
+		ExcludeWaived: true,
 	})
 
 	if count > 0 {
@@ -494,8 +1136,9 @@ func (s *IngestionService) hasFindingWithSeverity(ctx context.Context, assetID u
 	if severity == "Critical" && targetSev == "Highest" {
 		// Also check "Critical" just in case
 		c2, err := s.repo.CountFindings(ctx, repository.FindingFilters{
-			AssetID:  &assetID,
-			Severity: "Critical",
+			AssetID:       &assetID,
+			Severity:      "Critical",
+			ExcludeWaived: true,
 		})
 		return c2 > 0, err
 	}
@@ -569,7 +1212,7 @@ func extractTableName(filePath string) string {
 func getFileName(path string) string {
 	// Simple extraction - in production use filepath.Base
 	for i := len(path) - 1; i >= 0; i-- {
-		if path[i] == '/' {
+		if path[i] == '/' || path[i] == '\\' {
 			return path[i+1:]
 		}
 	}
@@ -633,21 +1276,74 @@ func (s *IngestionService) buildAssetFromFinding(finding *HawkeyeFinding, scanRu
 		env = "Development"
 	}
 
+	host := assetHost(finding)
+	assetType := "file"
+	metadata := finding.FileData
+	if bucket := s3Bucket(finding); bucket != "" {
+		// Objects belong to their bucket, which stands in for the host, and are
+		// rolled up by the prefixes of their key
+		assetType = "s3_object"
+		metadata = make(map[string]interface{}, len(finding.FileData)+2)
+		for k, v := range finding.FileData {
+			metadata[k] = v
+		}
+		metadata["bucket"] = bucket
+		metadata["prefix"] = s3ObjectPrefix(finding.FilePath)
+	}
+
 	return &entity.Asset{
-		AssetType:    "file",
+		AssetType:    assetType,
 		Name:         getFileName(finding.FilePath),
 		Path:         finding.FilePath,
 		DataSource:   finding.DataSource,
-		Host:         finding.Host,
+		Host:         host,
 		Environment:  env,
 		Owner:        owner,
-		SourceSystem: fmt.Sprintf("%s://%s", finding.DataSource, finding.Host),
-		FileMetadata: finding.FileData,
+		SourceSystem: fmt.Sprintf("%s://%s", finding.DataSource, host),
+		FileMetadata: metadata,
 		RiskScore:    calculateRiskScore(finding.Severity),
 		// StableID will be generated by AssetService
 	}
 }
 
+// assetHost is the host of a finding's asset: the bucket of S3 objects, and for
+// file servers the server named in a UNC path when the scanner reported none
+func assetHost(finding *HawkeyeFinding) string {
+	if bucket := s3Bucket(finding); bucket != "" {
+		return bucket
+	}
+	host := finding.Host
+	if host == "" && canonical.IsRemoteFile(finding.DataSource) {
+		// SMB scanners may name the server only in a UNC path
+		remote := canonical.RemoteFile(finding.DataSource, "", finding.FilePath, canonical.Policy{CaseSensitive: true})
+		if u, err := url.Parse(remote); err == nil {
+			host = u.Host
+		}
+	}
+	return host
+}
+
+// s3Bucket returns the bucket of an S3 finding, which scanners report in bucket
+// or host, or "" for other sources
+func s3Bucket(f *HawkeyeFinding) string {
+	if canonical.DataSource(f.DataSource) != "s3" {
+		return ""
+	}
+	if f.Bucket != "" {
+		return f.Bucket
+	}
+	return f.Host
+}
+
+// s3ObjectPrefix is the innermost prefix of an object key, or "" at the bucket root
+func s3ObjectPrefix(key string) string {
+	prefixes := canonical.S3Prefixes(key)
+	if len(prefixes) == 0 {
+		return ""
+	}
+	return prefixes[len(prefixes)-1]
+}
+
 // Ensure interface compatibility
 var _ json.Marshaler = (*HawkeyeScanInput)(nil)
 
@@ -739,9 +1435,25 @@ func isProductionEnvironment(fileData map[string]interface{}) bool {
 	return true
 }
 
+// RiskScoreBreakdown records the inputs behind a finding's 0-100 risk score
+type RiskScoreBreakdown struct {
+	ClassificationWeight  float64 `json:"classification_weight"`
+	ConfidenceMultiplier  float64 `json:"confidence_multiplier"`
+	EnvironmentMultiplier float64 `json:"environment_multiplier"`
+	Production            bool    `json:"production"`
+	AffectedRecords       int64   `json:"affected_records,omitempty"` // Estimated from match volume and row counts
+	VolumeTiers           int     `json:"volume_tiers,omitempty"`     // Volume thresholds reached; each adds to the score
+	Score                 int     `json:"score"`
+}
+
 // calculateComprehensiveRiskScore provides numeric risk score (0-100) for sorting and prioritization
 // Combines classification sensitivity, confidence level, and environment context
 func calculateComprehensiveRiskScore(classification, confidence string, fileData map[string]interface{}) int {
+	return riskScoreBreakdown(classification, confidence, fileData).Score
+}
+
+// riskScoreBreakdown computes the risk score together with the weights that produced it
+func riskScoreBreakdown(classification, confidence string, fileData map[string]interface{}) RiskScoreBreakdown {
 	// Base weights for classification types
 	var classificationWeight float64
 	switch classification {
@@ -769,19 +1481,16 @@ func calculateComprehensiveRiskScore(classification, confidence string, fileData
 	}
 
 	// Environment context multiplier
+	production := isProductionEnvironment(fileData)
 	contextMultiplier := 1.0
-	if !isProductionEnvironment(fileData) {
+	if !production {
 		contextMultiplier = 0.3 // Test/dev data is 70% less critical
 	}
 
 	// Calculate weighted score
 	// Formula: (ClassWeight * 0.6) + (Confidence * 20) + (Context * 20)
 	// This ensures classification type dominates, but confidence/context can adjust prioritization
-
 	baseScore := classificationWeight * 0.6
-
-	//     environment = "TEST"
-	// }
 	confidenceScore := (confidenceMultiplier * 100) * 0.2
 	contextScore := (contextMultiplier * 100) * 0.2
 
@@ -789,29 +1498,38 @@ func calculateComprehensiveRiskScore(classification, confidence string, fileData
 
 	// Ensure bounds 0-100
 	if totalScore > 100 {
-		return 100
+		totalScore = 100
 	}
 	if totalScore < 0 {
-		return 0
+		totalScore = 0
 	}
 
-	return totalScore
+	return RiskScoreBreakdown{
+		ClassificationWeight:  classificationWeight,
+		ConfidenceMultiplier:  confidenceMultiplier,
+		EnvironmentMultiplier: contextMultiplier,
+		Production:            production,
+		Score:                 totalScore,
+	}
 }
 
-// ClearAllScanData deletes all previous scan data for clean scan-replace workflow
-func (s *IngestionService) ClearAllScanData(ctx context.Context) error {
-	log.Println("Clearing all previous scan data...")
-	_, err := s.repo.GetDB().ExecContext(ctx, `
-		TRUNCATE findings, assets, classifications, 
-		asset_relationships, review_states, scan_runs, finding_feedback 
-		CASCADE
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to clear scan data: %w", err)
+// validMatchLocations keeps the scanner-reported locations that point at one of the
+// finding's matches and fully address either a file position or a database record.
+// Anything else is dropped so remediation falls back to the asset-level heuristics.
+func validMatchLocations(hf *HawkeyeFinding) []entity.MatchLocation {
+	var locations []entity.MatchLocation
+	for _, loc := range hf.Locations {
+		if loc.MatchIndex < 0 || loc.MatchIndex >= len(hf.Matches) {
+			log.Printf("WARNING: Dropping location for match %d of %s at %s: index out of range",
+				loc.MatchIndex, hf.PatternName, hf.FilePath)
+			continue
+		}
+		if !loc.IsFileLocation() && !loc.IsRecordLocation() {
+			continue
+		}
+		locations = append(locations, loc)
 	}
-
-	log.Println("✅ All previous scan data cleared successfully")
-	return nil
+	return locations
 }
 
 // isTestArtifact checks if the file path indicates a test or mock file
@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/arc-platform/backend/pkg/canonical"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func TestGroupFindingsByAsset(t *testing.T) {
+	findings := []HawkeyeFinding{
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "email"},
+		{FilePath: "public.customers", DataSource: "postgresql", Host: "db-1", PatternName: "pan"},
+		{FilePath: "/DATA/Users.csv", DataSource: "fs", PatternName: "phone"},
+		{FilePath: "public.customers", DataSource: "postgresql", Host: "db-2", PatternName: "pan"},
+		{FilePath: "public.customers", DataSource: "postgresql", Host: "db-1", PatternName: "email"},
+	}
+
+	groups := groupFindingsByAsset(findings, canonical.NewPolicies(nil, false))
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 asset groups, got %d", len(groups))
+	}
+
+	// Groups keep first-appearance order, findings keep input order within a group
+	want := [][]string{
+		{"email", "phone"},
+		{"pan", "email"},
+		{"pan"},
+	}
+	for i, group := range groups {
+		if len(group.findings) != len(want[i]) {
+			t.Fatalf("group %d (%s): expected %d findings, got %d", i, group.key, len(want[i]), len(group.findings))
+		}
+		for j, f := range group.findings {
+			if f.PatternName != want[i][j] {
+				t.Errorf("group %d finding %d: expected %s, got %s", i, j, want[i][j], f.PatternName)
+			}
+		}
+	}
+}
+
+func TestGroupFindingsByAssetOnFileServers(t *testing.T) {
+	findings := []HawkeyeFinding{
+		{FilePath: "/exports/customers.csv", DataSource: "sftp", Host: "files-1", PatternName: "email"},
+		{FilePath: "/exports/customers.csv", DataSource: "sftp", Host: "files-2", PatternName: "email"},
+		{FilePath: `\\fileserver\hr\payroll.xlsx`, DataSource: "smb", PatternName: "pan"},
+		{FilePath: "hr/payroll.xlsx", DataSource: "smb", Host: "FileServer", PatternName: "email"},
+	}
+
+	groups := groupFindingsByAsset(findings, canonical.NewPolicies(nil, false))
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 asset groups, got %d", len(groups))
+	}
+	if groups[2].key != canonical.StableID("smb", "fileserver", "hr/payroll.xlsx", canonical.Policies{}) || len(groups[2].findings) != 2 {
+		t.Errorf("expected both SMB findings in one group, got %s with %d", groups[2].key, len(groups[2].findings))
+	}
+
+	asset := (&IngestionService{}).buildAssetFromFinding(&findings[2], &entity.ScanRun{})
+	if asset.Host != "fileserver" || asset.Name != "payroll.xlsx" {
+		t.Errorf("expected the server and name from the UNC path, got host %q name %q", asset.Host, asset.Name)
+	}
+}
+
+func TestGroupFindingsByAssetInS3Buckets(t *testing.T) {
+	findings := []HawkeyeFinding{
+		{FilePath: "exports/2024/customers.csv", DataSource: "s3", Bucket: "finance", PatternName: "email"},
+		{FilePath: "exports/2024/customers.csv", DataSource: "s3", Bucket: "marketing", PatternName: "email"},
+		{FilePath: "exports/2024/customers.csv", DataSource: "s3", Bucket: "Finance", PatternName: "pan"},
+	}
+
+	groups := groupFindingsByAsset(findings, canonical.NewPolicies(nil, false))
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 asset groups, got %d", len(groups))
+	}
+	if groups[0].key != canonical.StableID("s3", "finance", "exports/2024/customers.csv", canonical.Policies{}) || len(groups[0].findings) != 2 {
+		t.Errorf("expected both finance findings in one group, got %s with %d", groups[0].key, len(groups[0].findings))
+	}
+
+	asset := (&IngestionService{}).buildAssetFromFinding(&findings[0], &entity.ScanRun{})
+	if asset.Host != "finance" || asset.AssetType != "s3_object" || asset.SourceSystem != "s3://finance" {
+		t.Errorf("expected an s3_object in the finance bucket, got %+v", asset)
+	}
+	if asset.FileMetadata["bucket"] != "finance" || asset.FileMetadata["prefix"] != "exports/2024/" {
+		t.Errorf("expected bucket and prefix metadata, got %v", asset.FileMetadata)
+	}
+}
+
+func TestGroupFindingsByAssetMergesPathVariants(t *testing.T) {
+	findings := []HawkeyeFinding{
+		{FilePath: "/data/exports/", DataSource: "fs", PatternName: "email"},
+		{FilePath: "/Data/exports", DataSource: "fs", PatternName: "pan"},
+		{FilePath: "/data//exports/./", DataSource: "fs", PatternName: "phone"},
+		{FilePath: "public.customers", DataSource: "postgres", Host: "db-1:5432", PatternName: "email"},
+		{FilePath: "public.customers", DataSource: "postgresql", Host: "DB-1", PatternName: "pan"},
+	}
+	policies := canonical.NewPolicies(nil, false)
+
+	groups := groupFindingsByAsset(findings, policies)
+	if len(groups) != 2 {
+		t.Fatalf("expected path variants to share a group, got %d groups", len(groups))
+	}
+	if len(groups[0].findings) != 3 || len(groups[1].findings) != 2 {
+		t.Errorf("expected 3 and 2 findings, got %d and %d", len(groups[0].findings), len(groups[1].findings))
+	}
+
+	// The group key is the stable ID AssetService stores the asset under
+	svc := &IngestionService{}
+	for _, group := range groups {
+		asset := svc.buildAssetFromFinding(&group.findings[0], &entity.ScanRun{})
+		if want := canonical.StableID(asset.DataSource, asset.Host, asset.Path, policies); group.key != want {
+			t.Errorf("group key %s differs from the asset's stable ID %s", group.key, want)
+		}
+	}
+
+	// Case-sensitive sources keep differently cased paths apart
+	groups = groupFindingsByAsset(findings[:2], canonical.NewPolicies([]string{"fs"}, false))
+	if len(groups) != 2 {
+		t.Errorf("expected distinct groups on a case-sensitive source, got %d", len(groups))
+	}
+}
+
+func TestObservationValueHash(t *testing.T) {
+	// Formatting differences between scans must not split an exposure
+	same := []string{"1234 5678 9012", "1234-5678-9012", "123456789012"}
+	for _, v := range same[1:] {
+		if observationValueHash(v) != observationValueHash(same[0]) {
+			t.Errorf("expected %q and %q to hash alike", v, same[0])
+		}
+	}
+	if observationValueHash("ABCDE1234F") != observationValueHash("abcde1234f") {
+		t.Error("expected the hash to ignore case")
+	}
+	if observationValueHash("abcde1234f") == observationValueHash("abcde1234g") {
+		t.Error("expected different values to hash differently")
+	}
+	// Must match sha256(lower(...)) as used by the 000023 backfill
+	if got := observationValueHash(""); got != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("unexpected hash of empty value: %s", got)
+	}
+}
+
+// memoryAssetManager stands in for AssetService, keeping assets in the in-memory repository
+type memoryAssetManager struct {
+	repo *memory.Repository
+
+	mu     sync.Mutex
+	byPath map[string]uuid.UUID
+}
+
+func (m *memoryAssetManager) CreateOrUpdateAsset(ctx context.Context, asset *entity.Asset) (uuid.UUID, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id, ok := m.byPath[asset.Path]; ok {
+		return id, false, nil
+	}
+	asset.ID = uuid.New()
+	m.byPath[asset.Path] = asset.ID
+	m.repo.PutAsset(asset)
+	return asset.ID, true, nil
+}
+
+func (m *memoryAssetManager) GetAssetByStableID(ctx context.Context, stableID string) (*entity.Asset, error) {
+	return nil, nil
+}
+
+func (m *memoryAssetManager) UpdateAssetStats(ctx context.Context, assetID uuid.UUID, riskScore, findingCount int) error {
+	return nil
+}
+
+func (m *memoryAssetManager) PathPolicies() canonical.Policies {
+	return canonical.NewPolicies(nil, false)
+}
+
+func newMemoryIngestionService(repo *memory.Repository) *IngestionService {
+	cfg := &config.Config{Classification: config.ClassificationConfig{
+		WeightRules: 0.40, WeightContext: 0.30, WeightEntropy: 0.10, Threshold: 0.60,
+	}}
+	return NewIngestionService(
+		repo,
+		NewClassificationService(repo, cfg),
+		NewEnrichmentService(nil, nil),
+		&memoryAssetManager{repo: repo, byPath: make(map[string]uuid.UUID)},
+	)
+}
+
+func TestIngestScanInMemory(t *testing.T) {
+	repo := memory.NewRepository()
+	rule := &entity.SuppressionRule{ID: uuid.New(), Name: "logs", PatternName: "EMAIL_ADDRESS", PathGlob: "/var/log/**", IsActive: true}
+	repo.PutSuppressionRule(rule)
+
+	s := newMemoryIngestionService(repo)
+	result, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}, SampleText: "asha.rao@example.in"},
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"ravi.k@example.in"}, SampleText: "ravi.k@example.in"},
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}, SampleText: "asha.rao@example.in"},
+		{FilePath: "/var/log/app/app.log", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"ops@example.in"}, SampleText: "ops@example.in"},
+	}})
+	if err != nil {
+		t.Fatalf("IngestScan: %v", err)
+	}
+
+	if result.Suppressed != 1 || result.TotalAssets != 1 || result.AssetsCreated != 1 || result.PatternsFound != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	// The repeated value is stored once, with its classification, review state and observation
+	findings := repo.Findings()
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 stored findings, got %d", len(findings))
+	}
+	for _, f := range findings {
+		if repo.Classification(f.ID) == nil || repo.ReviewState(f.ID) == nil {
+			t.Errorf("finding %s is missing its classification or review state", f.ID)
+		}
+	}
+	if got := len(repo.Observations()); got != 2 {
+		t.Errorf("expected 2 observations, got %d", got)
+	}
+
+	run, err := repo.GetScanRunByID(context.Background(), result.ScanRunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != "completed" || run.Metadata["suppressed_findings"] != 1 {
+		t.Errorf("unexpected scan run: status %s, metadata %v", run.Status, run.Metadata)
+	}
+	if got := repo.SuppressionRule(rule.ID).HitCount; got != 1 {
+		t.Errorf("expected the rule to record 1 hit, got %d", got)
+	}
+	if asset := repo.Asset(findings[0].AssetID); asset == nil || asset.TotalFindings != 2 {
+		t.Errorf("expected the asset stats to count 2 findings, got %+v", asset)
+	}
+	if _, ok := repo.AssetScannedAt(findings[0].AssetID); !ok {
+		t.Error("expected ingestion to record when the asset was scanned")
+	}
+}
+
+func TestIngestScanMarksFailedRun(t *testing.T) {
+	repo := memory.NewRepository()
+	s := newMemoryIngestionService(repo)
+
+	s.assetManager = failingAssetManager{}
+	_, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}},
+	}})
+	if err == nil {
+		t.Fatal("expected ingestion to fail")
+	}
+
+	if got := len(repo.Findings()); got != 0 {
+		t.Errorf("expected no findings to be stored, got %d", got)
+	}
+	latest, _ := repo.GetLatestScanRun(context.Background())
+	if latest == nil || latest.Status != "failed" {
+		t.Errorf("expected the scan run to be marked failed, got %+v", latest)
+	}
+}
+
+func TestIngestScanCapsMatchesPerFinding(t *testing.T) {
+	repo := memory.NewRepository()
+	s := newMemoryIngestionService(repo)
+	s.SetMaxMatchesPerFinding(2)
+
+	_, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{{
+		FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS",
+		Matches:      []string{"asha.rao@example.in", "ravi.k@example.in", "meera.n@example.in"},
+		TotalMatches: 40,
+		SampleText:   "asha.rao@example.in, ravi.k@example.in, meera.n@example.in",
+		Locations: []entity.MatchLocation{
+			{MatchIndex: 0, Line: 1, Column: 1}, {MatchIndex: 1, Line: 2, Column: 1}, {MatchIndex: 2, Line: 3, Column: 1},
+		},
+	}}})
+	if err != nil {
+		t.Fatalf("IngestScan: %v", err)
+	}
+
+	findings := repo.Findings()
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 stored finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if len(f.Matches) != 2 || f.TotalMatches != 40 || !f.MatchesTruncated {
+		t.Errorf("expected 2 of 40 matches stored, got %d of %d (truncated %t)", len(f.Matches), f.TotalMatches, f.MatchesTruncated)
+	}
+	if len(f.Locations) != 2 {
+		t.Errorf("expected the location of the dropped match to be dropped too, got %+v", f.Locations)
+	}
+}
+
+func TestFindingSetMatchTotal(t *testing.T) {
+	f := &entity.Finding{Matches: []string{"a", "b"}}
+
+	// Rows stored before totals were kept have none
+	f.SetMatchTotal(0)
+	if f.TotalMatches != 2 || f.MatchesTruncated {
+		t.Errorf("expected a missing total to count the stored matches, got %d (truncated %t)", f.TotalMatches, f.MatchesTruncated)
+	}
+
+	f.SetMatchTotal(5)
+	if f.TotalMatches != 5 || !f.MatchesTruncated {
+		t.Errorf("expected 5 matches with the stored ones truncated, got %d (truncated %t)", f.TotalMatches, f.MatchesTruncated)
+	}
+}
+
+func TestIngestScanLinksConnection(t *testing.T) {
+	repo := memory.NewRepository()
+	s := newMemoryIngestionService(repo)
+	crm := &entity.Connection{ID: uuid.New(), SourceType: "database", ProfileName: "crm-db"}
+	repo.PutConnection(crm)
+	// A profile name shared by two source types is ambiguous and left unlinked
+	repo.PutConnection(&entity.Connection{ID: uuid.New(), SourceType: "database", ProfileName: "shared"})
+	repo.PutConnection(&entity.Connection{ID: uuid.New(), SourceType: "filesystem", ProfileName: "shared"})
+
+	for profile, want := range map[string]*uuid.UUID{"crm-db": &crm.ID, "shared": nil, "": nil} {
+		result, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+			{FilePath: "/data/" + profile + ".csv", DataSource: "fs", Profile: profile, PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}},
+		}})
+		if err != nil {
+			t.Fatalf("%q: IngestScan: %v", profile, err)
+		}
+		run, _ := repo.GetScanRunByID(context.Background(), result.ScanRunID)
+		if (run.ConnectionID == nil) != (want == nil) || (want != nil && *run.ConnectionID != *want) {
+			t.Errorf("%q: expected connection %v, got %v", profile, want, run.ConnectionID)
+		}
+	}
+}
+
+// conflictingRepository fails the given commits with a serialization failure,
+// as Postgres does when concurrent transactions conflict
+type conflictingRepository struct {
+	*memory.Repository
+	failCommits map[int]bool
+
+	mu      sync.Mutex
+	commits int
+}
+
+func (r *conflictingRepository) BeginTransaction(ctx context.Context) (repository.Transaction, error) {
+	tx, err := r.Repository.BeginTransaction(ctx)
+	return &conflictingTransaction{Transaction: tx, repo: r}, err
+}
+
+type conflictingTransaction struct {
+	repository.Transaction
+	repo *conflictingRepository
+}
+
+func (t *conflictingTransaction) Commit() error {
+	t.repo.mu.Lock()
+	t.repo.commits++
+	fail := t.repo.failCommits[t.repo.commits]
+	t.repo.mu.Unlock()
+	if fail {
+		t.Transaction.Rollback()
+		return &pq.Error{Code: "40001", Message: "could not serialize access due to concurrent update"}
+	}
+	return t.Transaction.Commit()
+}
+
+func TestIngestScanRetriesTransientCommitFailures(t *testing.T) {
+	mem := memory.NewRepository()
+	// Commit 1 opens the scan run; the asset group's first two commits conflict
+	repo := &conflictingRepository{Repository: mem, failCommits: map[int]bool{2: true, 3: true}}
+	s := newMemoryIngestionService(mem)
+	s.repo = repo
+	s.SetTransactionRetry(persistence.RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	result, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}},
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"ravi.k@example.in"}},
+	}})
+	if err != nil {
+		t.Fatalf("IngestScan: %v", err)
+	}
+	if result.Retries != 2 {
+		t.Errorf("expected 2 retries, got %d", result.Retries)
+	}
+	if got := len(mem.Findings()); got != 2 {
+		t.Errorf("expected each finding stored once, got %d", got)
+	}
+
+	// Out of attempts, the error surfaces and the run is marked failed
+	repo.failCommits = map[int]bool{}
+	for i := repo.commits + 2; i <= repo.commits+4; i++ {
+		repo.failCommits[i] = true
+	}
+	_, err = s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+		{FilePath: "/data/orders.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"ops@example.in"}},
+	}})
+	if !persistence.IsRetryable(err) {
+		t.Errorf("expected the serialization failure after the last attempt, got %v", err)
+	}
+	if latest, _ := mem.GetLatestScanRun(context.Background()); latest == nil || latest.Status != "failed" {
+		t.Errorf("expected the scan run to be marked failed, got %+v", latest)
+	}
+}
+
+// failingAssetManager fails every asset write, failing the asset group
+type failingAssetManager struct{}
+
+func (failingAssetManager) CreateOrUpdateAsset(ctx context.Context, asset *entity.Asset) (uuid.UUID, bool, error) {
+	return uuid.Nil, false, errors.New("asset store unavailable")
+}
+
+func (failingAssetManager) GetAssetByStableID(ctx context.Context, stableID string) (*entity.Asset, error) {
+	return nil, nil
+}
+
+func (failingAssetManager) UpdateAssetStats(ctx context.Context, assetID uuid.UUID, riskScore, findingCount int) error {
+	return nil
+}
+
+func (failingAssetManager) PathPolicies() canonical.Policies {
+	return canonical.NewPolicies(nil, false)
+}
+
+func TestProliferationRaisesRisk(t *testing.T) {
+	repo := memory.NewRepository()
+	s := newMemoryIngestionService(repo)
+	_, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}, SampleText: "asha.rao@example.in"},
+		{FilePath: "/backup/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"Asha.Rao@example.in"}, SampleText: "Asha.Rao@example.in"},
+		{FilePath: "/data/other.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"ravi.k@example.in"}, SampleText: "ravi.k@example.in"},
+	}})
+	if err != nil {
+		t.Fatalf("IngestScan: %v", err)
+	}
+
+	sharing := make(map[string]int)
+	for _, f := range repo.Findings() {
+		n, err := repo.CountAssetsSharingValues(context.Background(), f.AssetID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sharing[repo.Asset(f.AssetID).Path] = n
+	}
+	if sharing["/data/users.csv"] != 1 || sharing["/backup/users.csv"] != 1 || sharing["/data/other.csv"] != 0 {
+		t.Errorf("unexpected sharing counts: %v", sharing)
+	}
+
+	for _, tc := range []struct{ score, sharing, want int }{
+		{40, 0, 40}, {40, 3, 46}, {40, 20, 50}, {95, 5, 100},
+	} {
+		if got := applyProliferation(tc.score, tc.sharing); got != tc.want {
+			t.Errorf("applyProliferation(%d, %d) = %d, want %d", tc.score, tc.sharing, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// jurisdictionCacheTTL bounds how long an edited profile or a changed tenant
+// selection takes to reach ingestion on every replica
+const jurisdictionCacheTTL = time.Minute
+
+// ErrJurisdictionNotFound is returned for a profile code with no stored profile
+var ErrJurisdictionNotFound = errors.New("jurisdiction profile not found")
+
+// JurisdictionService selects the PII scope profile that applies to each tenant.
+// Profiles live in the jurisdiction_profiles table, so they are edited there and
+// picked up without a redeploy once the cache expires or Refresh is called.
+type JurisdictionService struct {
+	repo        repository.JurisdictionRepository
+	auditLogger interfaces.AuditLogger
+
+	mu               sync.RWMutex
+	profiles         map[string]*entity.JurisdictionProfile
+	profilesLoadedAt time.Time
+	tenants          map[uuid.UUID]cachedTenantJurisdiction
+}
+
+type cachedTenantJurisdiction struct {
+	code     string
+	loadedAt time.Time
+}
+
+// NewJurisdictionService creates a new jurisdiction service
+func NewJurisdictionService(repo repository.JurisdictionRepository, auditLogger interfaces.AuditLogger) *JurisdictionService {
+	return &JurisdictionService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		tenants:     make(map[uuid.UUID]cachedTenantJurisdiction),
+	}
+}
+
+// ListProfiles returns every jurisdiction profile, or the built-in India profile
+// when none is stored
+func (s *JurisdictionService) ListProfiles(ctx context.Context) ([]*entity.JurisdictionProfile, error) {
+	profiles, err := s.repo.ListJurisdictionProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return []*entity.JurisdictionProfile{entity.DefaultJurisdictionProfile()}, nil
+	}
+	return profiles, nil
+}
+
+// GetProfile returns the profile with the given code
+func (s *JurisdictionService) GetProfile(ctx context.Context, code string) (*entity.JurisdictionProfile, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	profile, err := s.repo.GetJurisdictionProfile(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		if code == entity.DefaultJurisdiction {
+			return entity.DefaultJurisdictionProfile(), nil
+		}
+		return nil, ErrJurisdictionNotFound
+	}
+	return profile, nil
+}
+
+// GetTenantProfile returns the profile the tenant in ctx selected, or the default one
+func (s *JurisdictionService) GetTenantProfile(ctx context.Context) (*entity.JurisdictionProfile, error) {
+	code, err := s.repo.GetTenantJurisdiction(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		code = entity.DefaultJurisdiction
+	}
+	return s.GetProfile(ctx, code)
+}
+
+// SetTenantProfile selects the profile whose PII scope applies to the tenant's
+// ingestion from the next scan on
+func (s *JurisdictionService) SetTenantProfile(ctx context.Context, code, updatedBy string) (*entity.JurisdictionProfile, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.GetProfile(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	// The built-in default has no row for tenant_jurisdictions to reference
+	if profile.Version == 0 {
+		return nil, ErrJurisdictionNotFound
+	}
+	if err := s.repo.SetTenantJurisdiction(ctx, profile.Code, updatedBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.tenants, tenantID)
+	s.mu.Unlock()
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "JURISDICTION_PROFILE_SELECTED", "jurisdiction_profile", profile.Code, map[string]interface{}{
+			"framework": profile.Framework,
+			"version":   profile.Version,
+			"pii_types": len(profile.PIITypes),
+		})
+	}
+	return profile, nil
+}
+
+// Refresh drops cached profiles and selections so edits in the database apply at once
+func (s *JurisdictionService) Refresh() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles = nil
+	s.tenants = make(map[uuid.UUID]cachedTenantJurisdiction)
+}
+
+// Resolve returns the profile that ingestion applies for the tenant in ctx. It is
+// cached briefly and falls back to the built-in India profile if it cannot be loaded.
+func (s *JurisdictionService) Resolve(ctx context.Context) *entity.JurisdictionProfile {
+	if s == nil {
+		return entity.DefaultJurisdictionProfile()
+	}
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return entity.DefaultJurisdictionProfile()
+	}
+
+	s.mu.RLock()
+	cached, ok := s.tenants[tenantID]
+	s.mu.RUnlock()
+	if !ok || time.Since(cached.loadedAt) >= jurisdictionCacheTTL {
+		code, err := s.repo.GetTenantJurisdiction(ctx)
+		if err != nil {
+			return entity.DefaultJurisdictionProfile()
+		}
+		cached = cachedTenantJurisdiction{code: code, loadedAt: time.Now()}
+		s.mu.Lock()
+		s.tenants[tenantID] = cached
+		s.mu.Unlock()
+	}
+
+	code := cached.code
+	if code == "" {
+		code = entity.DefaultJurisdiction
+	}
+	if profile := s.cachedProfile(ctx, code); profile != nil {
+		return profile
+	}
+	return entity.DefaultJurisdictionProfile()
+}
+
+// IsAllowedPIIType reports whether a PII type is in the scope of the tenant in ctx
+func (s *JurisdictionService) IsAllowedPIIType(ctx context.Context, piiType string) bool {
+	return s.Resolve(ctx).Allows(piiType)
+}
+
+// cachedProfile returns a profile from the cache of all profiles, reloading it once stale
+func (s *JurisdictionService) cachedProfile(ctx context.Context, code string) *entity.JurisdictionProfile {
+	s.mu.RLock()
+	profiles, loadedAt := s.profiles, s.profilesLoadedAt
+	s.mu.RUnlock()
+
+	if profiles == nil || time.Since(loadedAt) >= jurisdictionCacheTTL {
+		list, err := s.repo.ListJurisdictionProfiles(ctx)
+		if err != nil {
+			return profiles[code]
+		}
+		profiles = make(map[string]*entity.JurisdictionProfile, len(list))
+		for _, profile := range list {
+			profiles[profile.Code] = profile
+		}
+		s.mu.Lock()
+		s.profiles, s.profilesLoadedAt = profiles, time.Now()
+		s.mu.Unlock()
+	}
+	return profiles[code]
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+)
+
+func euProfile() *entity.JurisdictionProfile {
+	return &entity.JurisdictionProfile{
+		Code:      "EU",
+		Name:      "European Union",
+		Framework: entity.ComplianceFrameworkGDPR,
+		Version:   1,
+		PIITypes: map[string]entity.PIITypeRule{
+			"IBAN_CODE":   {Category: "Financial Data", RequiresConsent: true},
+			"CREDIT_CARD": {Validator: "luhn", Category: "Financial Data", RequiresConsent: true},
+		},
+	}
+}
+
+func TestJurisdictionProfileScopesIngestion(t *testing.T) {
+	repo := memory.NewRepository()
+	repo.PutJurisdictionProfile(euProfile())
+	jurisdictions := NewJurisdictionService(repo, nil)
+	ingestion := newMemoryIngestionService(repo)
+	ingestion.classifier.SetJurisdictions(jurisdictions)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	// Until a tenant selects a profile, the India scope applies
+	if profile := jurisdictions.Resolve(ctx); profile.Code != entity.DefaultJurisdiction || !profile.Allows("in_pan") {
+		t.Fatalf("expected the default India profile, got %+v", profile)
+	}
+	if _, err := jurisdictions.SetTenantProfile(ctx, "XX", "admin@example.in"); !errors.Is(err, ErrJurisdictionNotFound) {
+		t.Fatalf("expected ErrJurisdictionNotFound, got %v", err)
+	}
+	if _, err := jurisdictions.SetTenantProfile(ctx, "eu", "admin@example.in"); err != nil {
+		t.Fatalf("SetTenantProfile: %v", err)
+	}
+
+	err := ingestion.IngestSDKVerified(ctx, VerifiedScanInput{ScanID: "scan-eu", Findings: []VerifiedFinding{
+		{PIIType: "IN_PAN", ValueHash: "h1", Source: SourceLocation{Path: "/data/customers.csv", DataSource: "filesystem", Host: "fs01"}},
+		{PIIType: "IBAN_CODE", ValueHash: "h2", Source: SourceLocation{Path: "/data/customers.csv", DataSource: "filesystem", Host: "fs01"}},
+	}})
+	if err != nil {
+		t.Fatalf("IngestSDKVerified: %v", err)
+	}
+	findings := repo.Findings()
+	if len(findings) != 1 {
+		t.Fatalf("expected only the IBAN finding in the EU scope, got %d findings", len(findings))
+	}
+
+	// Categories come from the profile while the tenant has no GDPR mapping of its own
+	classification := repo.Classification(findings[0].ID)
+	if classification == nil || classification.SubCategory != "IBAN_CODE" || classification.DPDPACategory != "Financial Data" {
+		t.Errorf("unexpected classification: %+v", classification)
+	}
+	if mapping := ingestion.classifier.CategoryMapping(ctx); mapping.Framework != entity.ComplianceFrameworkGDPR {
+		t.Errorf("expected the GDPR mapping, got %s", mapping.Framework)
+	}
+}
+
+func TestJurisdictionValidatorGate(t *testing.T) {
+	repo := memory.NewRepository()
+	repo.PutJurisdictionProfile(euProfile())
+	jurisdictions := NewJurisdictionService(repo, nil)
+	classifier := newMemoryIngestionService(repo).classifier
+	classifier.SetJurisdictions(jurisdictions)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+	if _, err := jurisdictions.SetTenantProfile(ctx, "EU", "admin@example.in"); err != nil {
+		t.Fatalf("SetTenantProfile: %v", err)
+	}
+
+	for value, discarded := range map[string]bool{
+		"4111 1111 1111 1111": false,
+		"4111 1111 1111 1112": true,
+		"4111 **** **** 1112": false, // Masked values cannot be checked
+	} {
+		decision, err := classifier.ClassifyMultiSignal(ctx, MultiSignalInput{PatternName: "CREDIT_CARD", FilePath: "/data/cards.csv", MatchValue: value})
+		if err != nil {
+			t.Fatalf("ClassifyMultiSignal: %v", err)
+		}
+		if got := decision.ConfidenceLevel == "DISCARD"; got != discarded {
+			t.Errorf("%s: expected discarded=%v, got %s (%s)", value, discarded, decision.ConfidenceLevel, decision.Justification)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/pkg/langdetect"
+)
+
+// DefaultLanguageDowngradeFactor scales the confidence of out-of-scope findings
+// in downgrade mode when no valid factor is configured
+const DefaultLanguageDowngradeFactor = 0.5
+
+// LanguagePolicy decides what happens to findings whose sample text is in a
+// language outside the supported ones. Classification is tuned for English
+// content, so elsewhere its verdicts are less reliable: such findings are either
+// held for a separate review queue or have their confidence scaled down. Findings
+// whose language cannot be told, such as bare values, are always in scope.
+type LanguagePolicy struct {
+	Mode            string   `json:"mode"`             // tag, downgrade or off
+	Supported       []string `json:"supported"`        // ISO 639-1 codes
+	DowngradeFactor float64  `json:"downgrade_factor"` // Confidence multiplier in downgrade mode
+}
+
+// NewLanguagePolicy validates a configured policy. An unknown mode falls back to
+// tag, no supported languages to English, and a factor outside (0, 1) to
+// DefaultLanguageDowngradeFactor.
+func NewLanguagePolicy(mode string, supported []string, downgradeFactor float64) LanguagePolicy {
+	switch mode {
+	case entity.LanguageHandlingTag, entity.LanguageHandlingDowngrade, entity.LanguageHandlingOff:
+	default:
+		log.Printf("WARNING: Unknown language handling %q, tagging out-of-scope findings for review", mode)
+		mode = entity.LanguageHandlingTag
+	}
+
+	policy := LanguagePolicy{Mode: mode, Supported: []string{}, DowngradeFactor: downgradeFactor}
+	for _, language := range supported {
+		if language = strings.ToLower(strings.TrimSpace(language)); language != "" {
+			policy.Supported = append(policy.Supported, language)
+		}
+	}
+	if len(policy.Supported) == 0 {
+		policy.Supported = []string{"en"}
+	}
+	if downgradeFactor <= 0 || downgradeFactor >= 1 {
+		policy.DowngradeFactor = DefaultLanguageDowngradeFactor
+	}
+	return policy
+}
+
+// Supports reports whether findings in a language are in scope
+func (p LanguagePolicy) Supports(language string) bool {
+	if language == "" || language == langdetect.Undetermined {
+		return true
+	}
+	for _, supported := range p.Supported {
+		if supported == language {
+			return true
+		}
+	}
+	return false
+}
+
+// factor is what the confidence of a finding in language is multiplied by
+func (p LanguagePolicy) factor(language string) float64 {
+	if p.Mode != entity.LanguageHandlingDowngrade || p.Supports(language) {
+		return 1
+	}
+	return p.DowngradeFactor
+}
+
+// downgrade scales a decision's score for content in an unsupported language
+func (p LanguagePolicy) downgrade(decision *MultiSignalDecision, language string) {
+	factor := p.factor(language)
+	if factor == 1 || decision.Classification == "Non-PII" {
+		return
+	}
+	decision.FinalScore *= factor
+	decision.Justification += downgradeNote(factor, language)
+}
+
+// downgradeVerified scales the scores of an SDK-verified finding and its
+// classification for content in an unsupported language
+func (p LanguagePolicy) downgradeVerified(finding *entity.Finding, classification *entity.Classification, language string) {
+	factor := p.factor(language)
+	if factor == 1 {
+		return
+	}
+	classification.ConfidenceScore *= factor
+	classification.Justification += downgradeNote(factor, language)
+	if finding.ConfidenceScore != nil {
+		scaled := *finding.ConfidenceScore * factor
+		finding.ConfidenceScore = &scaled
+	}
+}
+
+func downgradeNote(factor float64, language string) string {
+	return fmt.Sprintf(" | Confidence x%.2f: content detected as %q, outside the supported languages", factor, language)
+}
+
+// annotate records the detected language in the finding's context and, in tag
+// mode, holds a finding in an unsupported language for the language review queue
+func (p LanguagePolicy) annotate(finding *entity.Finding, language string) {
+	if language == "" {
+		return
+	}
+	if finding.Context == nil {
+		finding.Context = make(map[string]interface{})
+	}
+	finding.Context[entity.FindingLanguageKey] = language
+	if p.Mode == entity.LanguageHandlingTag && !p.Supports(language) {
+		finding.Context[entity.FindingLanguageReviewKey] = true
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+)
+
+func TestNewLanguagePolicyFallbacks(t *testing.T) {
+	p := NewLanguagePolicy("ignore", []string{" ", ""}, 1.5)
+	if p.Mode != entity.LanguageHandlingTag {
+		t.Errorf("expected an unknown mode to fall back to tag, got %q", p.Mode)
+	}
+	if len(p.Supported) != 1 || p.Supported[0] != "en" {
+		t.Errorf("expected no supported languages to fall back to English, got %v", p.Supported)
+	}
+	if p.DowngradeFactor != DefaultLanguageDowngradeFactor {
+		t.Errorf("expected an out-of-range factor to fall back to the default, got %v", p.DowngradeFactor)
+	}
+
+	p = NewLanguagePolicy(entity.LanguageHandlingDowngrade, []string{"EN", " hi "}, 0.3)
+	if !p.Supports("hi") || p.Supports("fr") || p.DowngradeFactor != 0.3 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+	if !p.Supports("und") || !p.Supports("") {
+		t.Error("expected undetermined content to always be in scope")
+	}
+}
+
+func TestLanguagePolicyDowngrade(t *testing.T) {
+	p := NewLanguagePolicy(entity.LanguageHandlingDowngrade, []string{"en"}, 0.5)
+
+	decision := &MultiSignalDecision{Classification: "Sensitive Personal Data", FinalScore: 0.8}
+	p.downgrade(decision, "fr")
+	if decision.FinalScore != 0.4 || decision.Justification == "" {
+		t.Errorf("expected the French finding to be downgraded, got %+v", decision)
+	}
+
+	decision = &MultiSignalDecision{Classification: "Sensitive Personal Data", FinalScore: 0.8}
+	p.downgrade(decision, "en")
+	if decision.FinalScore != 0.8 {
+		t.Errorf("expected the English finding to keep its score, got %v", decision.FinalScore)
+	}
+
+	score := 0.9
+	finding := &entity.Finding{ConfidenceScore: &score}
+	classification := &entity.Classification{ConfidenceScore: 0.9}
+	p.downgradeVerified(finding, classification, "de")
+	if classification.ConfidenceScore != 0.45 || *finding.ConfidenceScore != 0.45 {
+		t.Errorf("expected both verified scores to be halved, got %v and %v", classification.ConfidenceScore, *finding.ConfidenceScore)
+	}
+	if score != 0.9 {
+		t.Error("expected the caller's score not to be modified in place")
+	}
+
+	// Downgrade mode only scales scores; nothing is held for review
+	finding = &entity.Finding{}
+	p.annotate(finding, "de")
+	if finding.Context[entity.FindingLanguageKey] != "de" || finding.Context[entity.FindingLanguageReviewKey] != nil {
+		t.Errorf("unexpected context: %v", finding.Context)
+	}
+}
+
+func TestLanguagePolicyTag(t *testing.T) {
+	p := NewLanguagePolicy(entity.LanguageHandlingTag, nil, 0)
+
+	if p.factor("hi") != 1 {
+		t.Error("expected tag mode to leave scores alone")
+	}
+
+	finding := &entity.Finding{}
+	p.annotate(finding, "hi")
+	if finding.Context[entity.FindingLanguageKey] != "hi" || finding.Context[entity.FindingLanguageReviewKey] != true {
+		t.Errorf("expected the Hindi finding to be held for language review, got %v", finding.Context)
+	}
+
+	finding = &entity.Finding{}
+	p.annotate(finding, "en")
+	if _, held := finding.Context[entity.FindingLanguageReviewKey]; held {
+		t.Error("expected the English finding to stay in the main queue")
+	}
+
+	finding = &entity.Finding{}
+	p.annotate(finding, "")
+	if finding.Context != nil {
+		t.Errorf("expected no context without a detected language, got %v", finding.Context)
+	}
+}
+
+func TestIngestScanTagsUnsupportedLanguage(t *testing.T) {
+	repo := memory.NewRepository()
+	s := newMemoryIngestionService(repo)
+
+	_, err := s.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{{
+		FilePath: "/data/grahak.txt", DataSource: "fs", PatternName: "EMAIL_ADDRESS",
+		Matches:    []string{"asha.rao@example.in"},
+		SampleText: "ग्राहक का ईमेल पता asha.rao@example.in है और फोन नंबर नीचे दिया गया है",
+	}}})
+	if err != nil {
+		t.Fatalf("IngestScan: %v", err)
+	}
+
+	findings := repo.Findings()
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 stored finding, got %d", len(findings))
+	}
+	if ctx := findings[0].Context; ctx[entity.FindingLanguageKey] != "hi" || ctx[entity.FindingLanguageReviewKey] != true {
+		t.Errorf("expected the finding to be tagged as Hindi for language review, got %v", ctx)
+	}
+}
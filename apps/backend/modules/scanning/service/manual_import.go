@@ -0,0 +1,417 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+const (
+	maxManualImportRows      = 5000
+	maxManualImportNoteChars = 2000
+	defaultManualDataSource  = "manual"
+)
+
+var (
+	// ErrManualImportInvalid is returned when a non-dry-run import has invalid rows;
+	// nothing is imported
+	ErrManualImportInvalid = errors.New("manual import has invalid rows")
+	// ErrManualImportFormat is returned for a file that cannot be read as CSV or XLSX
+	ErrManualImportFormat = errors.New("invalid import file")
+)
+
+// manualImportColumns maps accepted header names to the fields of a row
+var manualImportColumns = map[string]string{
+	"asset_path":  "asset_path",
+	"path":        "asset_path",
+	"pii_type":    "pii_type",
+	"type":        "pii_type",
+	"severity":    "severity",
+	"note":        "note",
+	"notes":       "note",
+	"data_source": "data_source",
+	"host":        "host",
+}
+
+// manualSeverities are the accepted severities, by lowercased name
+var manualSeverities = map[string]string{
+	"critical": "Critical",
+	"high":     "High",
+	"medium":   "Medium",
+	"low":      "Low",
+}
+
+// ManualFindingRow is one exposure tracked outside the scanners
+type ManualFindingRow struct {
+	Row        int    `json:"row"` // Line in the file, counting the header as 1
+	AssetPath  string `json:"asset_path"`
+	PIIType    string `json:"pii_type"`
+	Severity   string `json:"severity"` // Defaults from the PII type when blank
+	Note       string `json:"note,omitempty"`
+	DataSource string `json:"data_source"`
+	Host       string `json:"host,omitempty"`
+}
+
+// ManualImportError describes why a row was rejected
+type ManualImportError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ManualImportResult previews or reports a manual findings import
+type ManualImportResult struct {
+	DryRun    bool                `json:"dry_run"`
+	FileName  string              `json:"file_name"`
+	TotalRows int                 `json:"total_rows"`
+	ValidRows int                 `json:"valid_rows"`
+	Errors    []ManualImportError `json:"errors"`
+	Rows      []ManualFindingRow  `json:"rows"` // Valid rows as they will be (or were) imported
+	ScanRunID *uuid.UUID          `json:"scan_run_id,omitempty"`
+	Imported  int                 `json:"imported"`
+	Assets    int                 `json:"assets"`
+}
+
+// ParseManualFindings reads the rows of a CSV or XLSX import file. The first row
+// is the header; asset_path and pii_type columns are required. Rows are numbered
+// as the file's lines (or sheet rows) so errors can point back at them.
+func ParseManualFindings(fileName string, body []byte) ([]ManualFindingRow, error) {
+	var records [][]string
+	var err error
+	if isXLSX(fileName, body) {
+		records, err = readXLSXRows(body)
+	} else {
+		records, err = readCSVRows(body)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrManualImportFormat, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrManualImportFormat)
+	}
+
+	fields := make([]string, len(records[0]))
+	present := make(map[string]bool)
+	for i, name := range records[0] {
+		key := strings.ToLower(strings.TrimSpace(strings.ReplaceAll(name, " ", "_")))
+		fields[i] = manualImportColumns[key]
+		present[fields[i]] = true
+	}
+	for _, required := range []string{"asset_path", "pii_type"} {
+		if !present[required] {
+			return nil, fmt.Errorf("%w: missing %s column", ErrManualImportFormat, required)
+		}
+	}
+
+	var rows []ManualFindingRow
+	for i, record := range records[1:] {
+		row := ManualFindingRow{Row: i + 2}
+		blank := true
+		for col, value := range record {
+			if col >= len(fields) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			if value != "" {
+				blank = false
+			}
+			switch fields[col] {
+			case "asset_path":
+				row.AssetPath = value
+			case "pii_type":
+				row.PIIType = value
+			case "severity":
+				row.Severity = value
+			case "note":
+				row.Note = value
+			case "data_source":
+				row.DataSource = value
+			case "host":
+				row.Host = value
+			}
+		}
+		if blank {
+			continue // Spreadsheets often carry trailing empty rows
+		}
+		rows = append(rows, row)
+		if len(rows) > maxManualImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrManualImportFormat, maxManualImportRows)
+		}
+	}
+	return rows, nil
+}
+
+// readCSVRows reads a CSV file, keeping blank lines as empty records so that
+// record i is line i+1 of the file
+func readCSVRows(body []byte) ([][]string, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		for len(records) < line-1 {
+			records = append(records, nil)
+		}
+		records = append(records, record)
+	}
+}
+
+// isXLSX detects a workbook by extension or by its ZIP signature
+func isXLSX(fileName string, body []byte) bool {
+	return strings.HasSuffix(strings.ToLower(fileName), ".xlsx") || bytes.HasPrefix(body, []byte("PK\x03\x04"))
+}
+
+// validateManualFindings normalizes rows in place and returns the valid ones
+// along with the reasons the others were rejected
+func validateManualFindings(rows []ManualFindingRow, jurisdiction *entity.JurisdictionProfile) ([]ManualFindingRow, []ManualImportError) {
+	valid := make([]ManualFindingRow, 0, len(rows))
+	errs := []ManualImportError{}
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		var rowErrs []ManualImportError
+		reject := func(field, message string) {
+			rowErrs = append(rowErrs, ManualImportError{Row: row.Row, Field: field, Message: message})
+		}
+
+		if row.AssetPath == "" {
+			reject("asset_path", "asset_path is required")
+		}
+
+		row.PIIType = strings.ToUpper(row.PIIType)
+		switch {
+		case row.PIIType == "":
+			reject("pii_type", "pii_type is required")
+		case !jurisdiction.Allows(row.PIIType):
+			reject("pii_type", fmt.Sprintf("pii_type %q is not a supported PII type in the %s jurisdiction", row.PIIType, jurisdiction.Code))
+		}
+
+		if row.Severity == "" {
+			if row.PIIType != "" {
+				row.Severity = determineSeverity(row.PIIType)
+			}
+		} else if severity, ok := manualSeverities[strings.ToLower(row.Severity)]; ok {
+			row.Severity = severity
+		} else {
+			reject("severity", "severity must be Critical, High, Medium or Low")
+		}
+
+		if len([]rune(row.Note)) > maxManualImportNoteChars {
+			reject("note", fmt.Sprintf("note must be at most %d characters", maxManualImportNoteChars))
+		}
+
+		if row.DataSource == "" {
+			row.DataSource = defaultManualDataSource
+		}
+		row.DataSource = strings.ToLower(row.DataSource)
+
+		if len(rowErrs) == 0 {
+			key := strings.ToLower(strings.Join([]string{row.DataSource, row.Host, row.AssetPath, row.PIIType}, "\x00"))
+			if first, ok := seen[key]; ok {
+				reject("", fmt.Sprintf("duplicates row %d", first))
+			} else {
+				seen[key] = row.Row
+			}
+		}
+
+		if len(rowErrs) > 0 {
+			errs = append(errs, rowErrs...)
+			continue
+		}
+		valid = append(valid, row)
+	}
+	return valid, errs
+}
+
+// ImportManualFindings validates manual findings and, unless dryRun, stores them
+// as findings of a dedicated scan run. Imports are all-or-nothing: when any row is
+// invalid nothing is stored and ErrManualImportInvalid is returned with the result.
+// Imported findings carry Context["provenance"] = "manual" so they can be told
+// apart from scanner findings, and are never flagged stale by later scans.
+func (s *IngestionService) ImportManualFindings(ctx context.Context, fileName string, rows []ManualFindingRow, dryRun bool, importedBy string) (*ManualImportResult, error) {
+	valid, errs := validateManualFindings(rows, s.classifier.Jurisdiction(ctx))
+	result := &ManualImportResult{
+		DryRun:    dryRun,
+		FileName:  fileName,
+		TotalRows: len(rows),
+		ValidRows: len(valid),
+		Errors:    errs,
+		Rows:      valid,
+	}
+	if dryRun {
+		return result, nil
+	}
+	if len(errs) > 0 {
+		return result, ErrManualImportInvalid
+	}
+	if len(valid) == 0 {
+		return result, fmt.Errorf("%w: no rows to import", ErrManualImportFormat)
+	}
+
+	// An import is all or nothing, so it is never downsampled to fit the findings quota
+	if err := s.quotas.AdmitScanRun(ctx); err != nil {
+		return nil, err
+	}
+	budget, err := s.quotas.FindingBudget(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := budget.Reject(len(valid)); err != nil {
+		return nil, err
+	}
+
+	// Resolve assets before the transaction, as scanner ingestion does
+	assetIDs := make([]uuid.UUID, len(valid))
+	assets := make(map[uuid.UUID]bool)
+	for i, row := range valid {
+		assetID, _, err := s.assetManager.CreateOrUpdateAsset(ctx, manualFindingAsset(row))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create/update asset for row %d: %w", row.Row, err)
+		}
+		assetIDs[i] = assetID
+		assets[assetID] = true
+	}
+
+	tx, err := s.repo.BeginTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	scanRun := &entity.ScanRun{
+		ID:              uuid.New(),
+		ProfileName:     entity.ManualImportProfile,
+		ScanStartedAt:   now,
+		ScanCompletedAt: now,
+		Status:          "completed",
+		TotalFindings:   len(valid),
+		TotalAssets:     len(assets),
+		Metadata: map[string]interface{}{
+			"manual_import": true,
+			"file_name":     fileName,
+			"imported_by":   importedBy,
+		},
+	}
+	if err := tx.CreateScanRun(ctx, scanRun); err != nil {
+		return nil, fmt.Errorf("failed to create scan run: %w", err)
+	}
+
+	dpdpa := s.classifier.CategoryMapping(ctx)
+	var created, critical []*entity.Finding
+	for i, row := range valid {
+		finding := &entity.Finding{
+			ID:                  uuid.New(),
+			ScanRunID:           scanRun.ID,
+			AssetID:             assetIDs[i],
+			PatternName:         row.PIIType,
+			Matches:             []string{},
+			SampleText:          row.Note,
+			Severity:            row.Severity,
+			SeverityDescription: getSeverityDescription(row.Severity),
+			ConfidenceScore:     floatPtr(1.0),
+			Environment:         "PROD",
+			Context: map[string]interface{}{
+				"provenance":  entity.FindingProvenanceManual,
+				"note":        row.Note,
+				"imported_by": importedBy,
+				"import_file": fileName,
+				"import_row":  row.Row,
+				"data_source": row.DataSource,
+				"host":        row.Host,
+			},
+			EnrichmentSignals: map[string]interface{}{"manual_entry": true},
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}
+		s.applySampleTextPolicy(ctx, finding)
+		if err := tx.CreateFinding(ctx, finding); err != nil {
+			return nil, fmt.Errorf("failed to create finding for row %d: %w", row.Row, err)
+		}
+
+		category := dpdpa.ForPIIType(row.PIIType)
+		justification := "Manually reported by " + importedBy
+		if row.Note != "" {
+			justification += ": " + row.Note
+		}
+		classification := &entity.Classification{
+			ID:                 uuid.New(),
+			FindingID:          finding.ID,
+			ClassificationType: mapPIITypeToClassification(row.PIIType),
+			SubCategory:        row.PIIType, // Lineage aggregates on the PII type, as for SDK findings
+			ConfidenceScore:    1.0,
+			Justification:      justification,
+			DPDPACategory:      category.Category,
+			RequiresConsent:    category.RequiresConsent,
+			RetentionPeriod:    getRetentionPeriod(row.PIIType),
+			SignalBreakdown:    map[string]interface{}{"provenance": entity.FindingProvenanceManual},
+			EngineVersion:      entity.FindingProvenanceManual,
+		}
+		if err := tx.CreateClassification(ctx, classification); err != nil {
+			return nil, fmt.Errorf("failed to create classification for row %d: %w", row.Row, err)
+		}
+
+		// Someone already looked at a manually reported exposure
+		if err := tx.CreateReviewState(ctx, &entity.ReviewState{
+			ID:        uuid.New(),
+			FindingID: finding.ID,
+			Status:    "confirmed",
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create review state for row %d: %w", row.Row, err)
+		}
+
+		created = append(created, finding)
+		if finding.Severity == "Critical" {
+			critical = append(critical, finding)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	s.publishFindingsCreated(ctx, created)
+
+	for assetID := range assets {
+		if err := s.recalculateAssetRisk(ctx, assetID); err != nil {
+			fmt.Printf("⚠️ Failed to recalculate risk for asset %s: %v\n", assetID, err)
+		}
+	}
+	s.onIngestionComplete(ctx, scanRun, critical)
+
+	result.ScanRunID = &scanRun.ID
+	result.Imported = len(created)
+	result.Assets = len(assets)
+	return result, nil
+}
+
+// manualFindingAsset describes the asset a manual row belongs to. Assets already
+// known by path (or, for databases, by host and table) are reused unchanged.
+func manualFindingAsset(row ManualFindingRow) *entity.Asset {
+	return &entity.Asset{
+		AssetType:    row.DataSource,
+		Name:         getFileName(row.AssetPath),
+		Path:         row.AssetPath,
+		DataSource:   row.DataSource,
+		Host:         row.Host,
+		Environment:  "Production",
+		SourceSystem: entity.FindingProvenanceManual,
+		FileMetadata: map[string]interface{}{"manual_import": true},
+	}
+}
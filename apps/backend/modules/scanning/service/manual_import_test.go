@@ -0,0 +1,145 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+)
+
+const manualImportCSV = "\xef\xbb\xbfPath,PII Type,Severity,Notes\n" +
+	"/finance/payroll.xlsx,in_pan,,Found during audit\n" +
+	"\n" +
+	"/hr/onboarding.pdf,IN_AADHAAR,low,Scanned copies\n"
+
+func TestParseManualFindingsCSV(t *testing.T) {
+	rows, err := ParseManualFindings("findings.csv", []byte(manualImportCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected blank rows to be skipped, got %d rows", len(rows))
+	}
+	want := ManualFindingRow{Row: 4, AssetPath: "/hr/onboarding.pdf", PIIType: "IN_AADHAAR", Severity: "low", Note: "Scanned copies"}
+	if rows[1] != want {
+		t.Errorf("row = %+v, want %+v", rows[1], want)
+	}
+
+	if _, err := ParseManualFindings("findings.csv", []byte("path,note\n/a,b\n")); !errors.Is(err, ErrManualImportFormat) {
+		t.Errorf("expected a missing pii_type column to be rejected, got %v", err)
+	}
+}
+
+func TestParseManualFindingsXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Findings" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/findings.xml"/></Relationships>`,
+		"xl/sharedStrings.xml":       `<sst><si><t>asset_path</t></si><si><t>pii_type</t></si><si><r><t>/crm/</t></r><r><t>leads.csv</t></r></si></sst>`,
+		"xl/worksheets/findings.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="inlineStr"><is><t>note</t></is></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>2</v></c><c r="B3" t="inlineStr"><is><t>IN_PASSPORT</t></is></c><c r="D3"><v>42</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+
+	rows, err := ParseManualFindings("findings.xlsx", buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ManualFindingRow{Row: 3, AssetPath: "/crm/leads.csv", PIIType: "IN_PASSPORT", Note: "42"}
+	if len(rows) != 1 || rows[0] != want {
+		t.Errorf("rows = %+v, want [%+v]", rows, want)
+	}
+
+	if _, err := ParseManualFindings("broken.xlsx", []byte("not a zip")); !errors.Is(err, ErrManualImportFormat) {
+		t.Errorf("expected an invalid workbook to be rejected, got %v", err)
+	}
+}
+
+func TestValidateManualFindings(t *testing.T) {
+	valid, errs := validateManualFindings([]ManualFindingRow{
+		{Row: 2, AssetPath: "/a.csv", PIIType: "in_pan"},
+		{Row: 3, AssetPath: "", PIIType: "SSN", Severity: "urgent"},
+		{Row: 4, AssetPath: "/A.csv", PIIType: "IN_PAN"},
+		{Row: 5, AssetPath: "/b.csv", PIIType: "IN_AADHAAR", Severity: "MEDIUM", DataSource: "FS"},
+	}, entity.DefaultJurisdictionProfile())
+
+	if len(valid) != 2 {
+		t.Fatalf("expected 2 valid rows, got %+v", valid)
+	}
+	if valid[0].PIIType != "IN_PAN" || valid[0].Severity != "Critical" || valid[0].DataSource != "manual" {
+		t.Errorf("expected defaults to be applied, got %+v", valid[0])
+	}
+	if valid[1].Severity != "Medium" || valid[1].DataSource != "fs" {
+		t.Errorf("expected severity and data source to be normalized, got %+v", valid[1])
+	}
+
+	fields := map[int][]string{}
+	for _, e := range errs {
+		fields[e.Row] = append(fields[e.Row], e.Field)
+	}
+	if len(fields[3]) != 3 || len(fields[4]) != 1 {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestImportManualFindings(t *testing.T) {
+	repo := memory.NewRepository()
+	s := newMemoryIngestionService(repo)
+	ctx := context.Background()
+	rows, err := ParseManualFindings("findings.csv", []byte(manualImportCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := s.ImportManualFindings(ctx, "findings.csv", rows, true, "dpo@example.in")
+	if err != nil || preview.ValidRows != 2 || preview.ScanRunID != nil {
+		t.Fatalf("unexpected dry run: %+v, %v", preview, err)
+	}
+	if len(repo.Findings()) != 0 {
+		t.Fatal("expected a dry run not to store findings")
+	}
+
+	invalid := append([]ManualFindingRow{{Row: 9, AssetPath: "/x", PIIType: "UNKNOWN"}}, rows...)
+	if _, err := s.ImportManualFindings(ctx, "findings.csv", invalid, false, "dpo@example.in"); !errors.Is(err, ErrManualImportInvalid) {
+		t.Fatalf("expected ErrManualImportInvalid, got %v", err)
+	}
+	if len(repo.Findings()) != 0 {
+		t.Fatal("expected an invalid import to store nothing")
+	}
+
+	result, err := s.ImportManualFindings(ctx, "findings.csv", rows, false, "dpo@example.in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 2 || result.Assets != 2 || result.ScanRunID == nil {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	run, err := repo.GetScanRunByID(ctx, *result.ScanRunID)
+	if err != nil || run.ProfileName != entity.ManualImportProfile {
+		t.Errorf("expected a manual import scan run, got %+v (%v)", run, err)
+	}
+	for _, f := range repo.Findings() {
+		if f.Context["provenance"] != entity.FindingProvenanceManual || f.Context["imported_by"] != "dpo@example.in" {
+			t.Errorf("finding %s is not marked as manual: %v", f.ID, f.Context)
+		}
+		if c := repo.Classification(f.ID); c == nil || c.SubCategory != f.PatternName {
+			t.Errorf("finding %s has classification %+v", f.ID, c)
+		}
+		if asset := repo.Asset(f.AssetID); asset == nil || asset.RiskScore == 0 {
+			t.Errorf("expected the asset risk to be recalculated, got %+v", asset)
+		}
+	}
+}
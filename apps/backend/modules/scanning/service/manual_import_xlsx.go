@@ -0,0 +1,197 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXLSXPartBytes bounds each decompressed part of a workbook
+const maxXLSXPartBytes = 32 << 20
+
+// readXLSXRows returns the cell text of the first worksheet of an XLSX workbook,
+// one slice per row, with row i holding sheet row i+1. Only shared and inline strings, numbers and booleans are
+// read; formulas yield their cached value.
+func readXLSXRows(body []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid xlsx file: %w", err)
+	}
+	parts := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		parts[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if f, ok := parts["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []xlsxRichText `xml:"si"`
+		}
+		if err := decodeXLSXPart(f, &sst); err != nil {
+			return nil, err
+		}
+		shared = make([]string, len(sst.Items))
+		for i, item := range sst.Items {
+			shared[i] = item.text()
+		}
+	}
+
+	f, ok := parts[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("invalid xlsx file: missing %s", sheetPath)
+	}
+	var sheet struct {
+		Rows []struct {
+			Ref   int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string       `xml:"r,attr"`
+				Type   string       `xml:"t,attr"`
+				Value  string       `xml:"v"`
+				Inline xlsxRichText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXLSXPart(f, &sheet); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, r := range sheet.Rows {
+		// Rows absent from the sheet are empty; keep them so row i is sheet row i+1
+		for r.Ref > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+
+		var row []string
+		for i, c := range r.Cells {
+			col := i
+			if c.Ref != "" {
+				if col, err = xlsxColumnIndex(c.Ref); err != nil {
+					return nil, err
+				}
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, fmt.Errorf("invalid xlsx file: bad shared string in %s", c.Ref)
+				}
+				row[col] = shared[idx]
+			case "inlineStr":
+				row[col] = c.Inline.text()
+			case "b":
+				row[col] = map[string]string{"1": "TRUE", "0": "FALSE"}[c.Value]
+			default:
+				row[col] = c.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// xlsxRichText is a string item that is either plain or split into formatted runs
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) text() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+// firstSheetPath resolves the part holding the workbook's first sheet
+func firstSheetPath(parts map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+
+	wb, ok := parts["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("invalid xlsx file: missing xl/workbook.xml")
+	}
+	var workbook struct {
+		Sheets []struct {
+			RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXLSXPart(wb, &workbook); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("invalid xlsx file: workbook has no sheets")
+	}
+
+	relsPart, ok := parts["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return fallback, nil
+	}
+	var rels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXLSXPart(relsPart, &rels); err != nil {
+		return "", err
+	}
+	for _, rel := range rels.Items {
+		if rel.ID == workbook.Sheets[0].RelID {
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return fallback, nil
+}
+
+func decodeXLSXPart(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("invalid xlsx file: %w", err)
+	}
+	defer rc.Close()
+
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXLSXPartBytes)).Decode(v); err != nil {
+		return fmt.Errorf("invalid xlsx file: failed to parse %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex returns the zero-based column of a cell reference such as "C7"
+func xlsxColumnIndex(ref string) (int, error) {
+	col := 0
+	for _, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			col = col*26 + int(r-'A'+1)
+			continue
+		}
+		break
+	}
+	if col == 0 || col > 16384 {
+		return 0, fmt.Errorf("invalid xlsx file: bad cell reference %q", ref)
+	}
+	return col - 1, nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+)
+
+// Quotas named in QuotaExceededError
+const (
+	QuotaFindings        = "max_findings"
+	QuotaScanRunsPerDay  = "max_scan_runs_per_day"
+	quotaDownsampledMeta = "quota_downsampled"
+)
+
+// ErrInvalidQuota is returned for quota overrides that fail validation
+var ErrInvalidQuota = errors.New("invalid quota")
+
+// QuotaExceededError is returned when an ingestion would take a tenant past a quota.
+// Nothing from the rejected ingestion is stored.
+type QuotaExceededError struct {
+	Quota      string
+	Limit      int
+	Used       int
+	Incoming   int           // Findings the ingestion would have added, when known
+	RetryAfter time.Duration // Until the quota resets; zero when it does not reset by itself
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("quota %s exceeded: %d of %d used", e.Quota, e.Used, e.Limit)
+}
+
+// TenantQuotaInput replaces a tenant's quota overrides; nil fields keep the default
+type TenantQuotaInput struct {
+	MaxFindings       *int    `json:"max_findings"`
+	MaxScanRunsPerDay *int    `json:"max_scan_runs_per_day"`
+	OverageBehavior   *string `json:"overage_behavior"`
+}
+
+// QuotaService enforces the per-tenant ingestion quotas: stored findings and scan
+// runs per UTC day. The quotas are soft: they are checked against counts read when
+// an ingestion starts, so concurrent ingestions may overshoot them slightly.
+type QuotaService struct {
+	repo        repository.QuotaRepository
+	defaults    config.QuotaConfig
+	auditLogger interfaces.AuditLogger
+	now         func() time.Time
+}
+
+// NewQuotaService creates a quota service. An unknown default overage behavior
+// falls back to rejecting.
+func NewQuotaService(repo repository.QuotaRepository, defaults config.QuotaConfig, auditLogger interfaces.AuditLogger) *QuotaService {
+	if defaults.OverageBehavior != entity.QuotaOverageDownsample && defaults.OverageBehavior != entity.QuotaOverageReject {
+		if defaults.OverageBehavior != "" {
+			log.Printf("WARN: Unknown QUOTA_OVERAGE_BEHAVIOR %q; rejecting ingestion over quota", defaults.OverageBehavior)
+		}
+		defaults.OverageBehavior = entity.QuotaOverageReject
+	}
+	if defaults.DownsampleEvery < 0 {
+		defaults.DownsampleEvery = 0
+	}
+	return &QuotaService{
+		repo:        repo,
+		defaults:    defaults,
+		auditLogger: auditLogger,
+		now:         time.Now,
+	}
+}
+
+// effectiveQuota is the quotas that apply to a tenant
+type effectiveQuota struct {
+	maxFindings       int
+	maxScanRunsPerDay int
+	overageBehavior   string
+	overrides         *entity.TenantQuota
+}
+
+func (s *QuotaService) resolve(ctx context.Context) (*effectiveQuota, error) {
+	overrides, err := s.repo.GetTenantQuota(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := &effectiveQuota{
+		maxFindings:       s.defaults.MaxFindings,
+		maxScanRunsPerDay: s.defaults.MaxScanRunsPerDay,
+		overageBehavior:   s.defaults.OverageBehavior,
+		overrides:         overrides,
+	}
+	if overrides != nil {
+		if overrides.MaxFindings != nil {
+			q.maxFindings = *overrides.MaxFindings
+		}
+		if overrides.MaxScanRunsPerDay != nil {
+			q.maxScanRunsPerDay = *overrides.MaxScanRunsPerDay
+		}
+		if overrides.OverageBehavior != nil {
+			q.overageBehavior = *overrides.OverageBehavior
+		}
+	}
+	return q, nil
+}
+
+// startOfDay is the UTC midnight the daily scan run quota counts from
+func (s *QuotaService) startOfDay() time.Time {
+	now := s.now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AdmitScanRun checks that the tenant in ctx may create another scan run today
+func (s *QuotaService) AdmitScanRun(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	q, err := s.resolve(ctx)
+	if err != nil || q.maxScanRunsPerDay <= 0 {
+		return err
+	}
+	start := s.startOfDay()
+	runs, _, err := s.repo.CountScanRunsSince(ctx, start)
+	if err != nil {
+		return err
+	}
+	if runs >= q.maxScanRunsPerDay {
+		return &QuotaExceededError{
+			Quota:      QuotaScanRunsPerDay,
+			Limit:      q.maxScanRunsPerDay,
+			Used:       runs,
+			RetryAfter: start.AddDate(0, 0, 1).Sub(s.now()),
+		}
+	}
+	return nil
+}
+
+// FindingBudget returns the findings quota left to the tenant in ctx for one ingestion
+func (s *QuotaService) FindingBudget(ctx context.Context) (*FindingBudget, error) {
+	if s == nil {
+		return nil, nil
+	}
+	q, err := s.resolve(ctx)
+	if err != nil || q.maxFindings <= 0 {
+		return nil, err
+	}
+	stored, err := s.repo.CountStoredFindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &FindingBudget{
+		limit:           q.maxFindings,
+		stored:          stored,
+		downsample:      q.overageBehavior == entity.QuotaOverageDownsample,
+		downsampleEvery: s.defaults.DownsampleEvery,
+	}, nil
+}
+
+// Usage reports the tenant's usage against its effective quotas
+func (s *QuotaService) Usage(ctx context.Context) (*entity.QuotaUsage, error) {
+	q, err := s.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repo.CountStoredFindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	start := s.startOfDay()
+	runs, downsampled, err := s.repo.CountScanRunsSince(ctx, start)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &entity.QuotaUsage{
+		StoredFindings:       stored,
+		MaxFindings:          q.maxFindings,
+		ScanRunsToday:        runs,
+		MaxScanRunsPerDay:    q.maxScanRunsPerDay,
+		DownsampledToday:     downsampled,
+		OverageBehavior:      q.overageBehavior,
+		ScanRunQuotaResetsAt: start.AddDate(0, 0, 1),
+		Overrides:            q.overrides,
+	}
+	if q.overageBehavior == entity.QuotaOverageDownsample {
+		usage.DownsampleEvery = s.defaults.DownsampleEvery
+	}
+	return usage, nil
+}
+
+// SetTenantQuota replaces the quota overrides of the tenant in ctx
+func (s *QuotaService) SetTenantQuota(ctx context.Context, input TenantQuotaInput, updatedBy string) (*entity.QuotaUsage, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if input.MaxFindings != nil && *input.MaxFindings < 0 {
+		return nil, fmt.Errorf("%w: max_findings must not be negative", ErrInvalidQuota)
+	}
+	if input.MaxScanRunsPerDay != nil && *input.MaxScanRunsPerDay < 0 {
+		return nil, fmt.Errorf("%w: max_scan_runs_per_day must not be negative", ErrInvalidQuota)
+	}
+	if input.OverageBehavior != nil {
+		behavior := strings.ToLower(strings.TrimSpace(*input.OverageBehavior))
+		if behavior != entity.QuotaOverageReject && behavior != entity.QuotaOverageDownsample {
+			return nil, fmt.Errorf("%w: overage_behavior %q must be reject or downsample", ErrInvalidQuota, *input.OverageBehavior)
+		}
+		input.OverageBehavior = &behavior
+	}
+
+	quota := &entity.TenantQuota{
+		MaxFindings:       input.MaxFindings,
+		MaxScanRunsPerDay: input.MaxScanRunsPerDay,
+		OverageBehavior:   input.OverageBehavior,
+		UpdatedBy:         updatedBy,
+	}
+	if err := s.repo.SetTenantQuota(ctx, quota); err != nil {
+		return nil, err
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "TENANT_QUOTA_CHANGED", "tenant", tenantID.String(), map[string]interface{}{
+			"max_findings":          input.MaxFindings,
+			"max_scan_runs_per_day": input.MaxScanRunsPerDay,
+			"overage_behavior":      input.OverageBehavior,
+		})
+	}
+	return s.Usage(ctx)
+}
+
+// FindingBudget admits the findings of one ingestion against the findings quota.
+// A nil budget admits everything.
+type FindingBudget struct {
+	limit           int
+	stored          int
+	admitted        int
+	downsample      bool
+	downsampleEvery int
+	overQuota       int // Non-critical findings seen past the quota
+	downsampled     int
+}
+
+// Check rejects an ingestion of incoming findings that would go past the quota when
+// the tenant rejects overage. Downsampling tenants are never rejected up front.
+func (b *FindingBudget) Check(incoming int) error {
+	if b == nil || b.downsample || b.stored+incoming <= b.limit {
+		return nil
+	}
+	return b.exceeded(incoming)
+}
+
+// Reject rejects an ingestion of incoming findings that would go past the quota,
+// whatever the overage behavior
+func (b *FindingBudget) Reject(incoming int) error {
+	if b == nil || b.stored+incoming <= b.limit {
+		return nil
+	}
+	return b.exceeded(incoming)
+}
+
+// Admit reports whether a finding of the given severity is stored. Past the quota,
+// rejecting tenants get an error; downsampling tenants keep critical findings and
+// 1 in downsampleEvery of the others.
+func (b *FindingBudget) Admit(severity string) (bool, error) {
+	if b == nil {
+		return true, nil
+	}
+	if b.stored+b.admitted < b.limit {
+		b.admitted++
+		return true, nil
+	}
+	if !b.downsample {
+		return false, b.exceeded(b.admitted + 1)
+	}
+	if isCriticalSeverity(severity) {
+		b.admitted++
+		return true, nil
+	}
+	b.overQuota++
+	if b.downsampleEvery > 0 && (b.overQuota-1)%b.downsampleEvery == 0 {
+		b.admitted++
+		return true, nil
+	}
+	b.downsampled++
+	return false, nil
+}
+
+// Downsampled is the number of findings Admit dropped
+func (b *FindingBudget) Downsampled() int {
+	if b == nil {
+		return 0
+	}
+	return b.downsampled
+}
+
+func (b *FindingBudget) exceeded(incoming int) error {
+	return &QuotaExceededError{Quota: QuotaFindings, Limit: b.limit, Used: b.stored, Incoming: incoming}
+}
+
+// annotate records the findings dropped by downsampling on the scan run
+func (b *FindingBudget) annotate(scanRun *entity.ScanRun) {
+	if n := b.Downsampled(); n > 0 {
+		if scanRun.Metadata == nil {
+			scanRun.Metadata = make(map[string]interface{})
+		}
+		scanRun.Metadata[quotaDownsampledMeta] = n
+	}
+}
+
+// isCriticalSeverity reports whether a severity is the highest; the scanner calls it "Highest"
+func isCriticalSeverity(severity string) bool {
+	return strings.EqualFold(severity, "Critical") || strings.EqualFold(severity, "Highest")
+}
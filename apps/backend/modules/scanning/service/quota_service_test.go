@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+type fakeQuotaRepo struct {
+	quota    *entity.TenantQuota
+	findings int
+	runs     int
+	since    time.Time
+}
+
+func (r *fakeQuotaRepo) GetTenantQuota(ctx context.Context) (*entity.TenantQuota, error) {
+	return r.quota, nil
+}
+
+func (r *fakeQuotaRepo) SetTenantQuota(ctx context.Context, quota *entity.TenantQuota) error {
+	r.quota = quota
+	return nil
+}
+
+func (r *fakeQuotaRepo) CountStoredFindings(ctx context.Context) (int, error) {
+	return r.findings, nil
+}
+
+func (r *fakeQuotaRepo) CountScanRunsSince(ctx context.Context, since time.Time) (int, int, error) {
+	r.since = since
+	return r.runs, 0, nil
+}
+
+func intPtr(v int) *int { return &v }
+
+func strPtr(v string) *string { return &v }
+
+func TestAdmitScanRun(t *testing.T) {
+	repo := &fakeQuotaRepo{runs: 5}
+	s := NewQuotaService(repo, config.QuotaConfig{MaxScanRunsPerDay: 5}, nil)
+	s.now = func() time.Time { return time.Date(2026, 3, 9, 22, 30, 0, 0, time.UTC) }
+
+	err := s.AdmitScanRun(context.Background())
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("expected a quota error, got %v", err)
+	}
+	if quotaErr.Quota != QuotaScanRunsPerDay || quotaErr.RetryAfter != 90*time.Minute {
+		t.Errorf("unexpected error: %+v", quotaErr)
+	}
+	if !repo.since.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected runs counted from UTC midnight, got %v", repo.since)
+	}
+
+	// A tenant override of 0 lifts the default
+	repo.quota = &entity.TenantQuota{MaxScanRunsPerDay: intPtr(0)}
+	if err := s.AdmitScanRun(context.Background()); err != nil {
+		t.Errorf("expected no limit, got %v", err)
+	}
+
+	var unset *QuotaService
+	if err := unset.AdmitScanRun(context.Background()); err != nil {
+		t.Errorf("a nil service enforces nothing, got %v", err)
+	}
+}
+
+func TestFindingBudgetReject(t *testing.T) {
+	s := NewQuotaService(&fakeQuotaRepo{findings: 8}, config.QuotaConfig{MaxFindings: 10}, nil)
+	budget, err := s.FindingBudget(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := budget.Check(2); err != nil {
+		t.Errorf("expected 2 findings to fit, got %v", err)
+	}
+	var quotaErr *QuotaExceededError
+	if err := budget.Check(3); !errors.As(err, &quotaErr) || quotaErr.Quota != QuotaFindings || quotaErr.Incoming != 3 {
+		t.Errorf("expected a findings quota error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if ok, err := budget.Admit("Low"); !ok || err != nil {
+			t.Fatalf("finding %d: expected it admitted, got %v %v", i, ok, err)
+		}
+	}
+	if _, err := budget.Admit("Critical"); !errors.As(err, &quotaErr) {
+		t.Errorf("expected a quota error past the limit, got %v", err)
+	}
+}
+
+func TestFindingBudgetDownsample(t *testing.T) {
+	repo := &fakeQuotaRepo{findings: 10, quota: &entity.TenantQuota{OverageBehavior: strPtr(entity.QuotaOverageDownsample)}}
+	s := NewQuotaService(repo, config.QuotaConfig{MaxFindings: 10, DownsampleEvery: 3}, nil)
+	budget, err := s.FindingBudget(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := budget.Check(100); err != nil {
+		t.Errorf("downsampling tenants are not rejected up front, got %v", err)
+	}
+
+	var kept []string
+	for i, severity := range []string{"Low", "High", "Critical", "Medium", "Highest", "Low", "Low"} {
+		ok, err := budget.Admit(severity)
+		if err != nil {
+			t.Fatalf("finding %d: %v", i, err)
+		}
+		if ok {
+			kept = append(kept, severity)
+		}
+	}
+
+	// Critical findings are all kept; the 1st and 4th of the other five are sampled
+	want := []string{"Low", "Critical", "Highest", "Low"}
+	if len(kept) != len(want) {
+		t.Fatalf("expected %v kept, got %v", want, kept)
+	}
+	for i := range want {
+		if kept[i] != want[i] {
+			t.Fatalf("expected %v kept, got %v", want, kept)
+		}
+	}
+	if budget.Downsampled() != 3 {
+		t.Errorf("expected 3 downsampled, got %d", budget.Downsampled())
+	}
+
+	run := &entity.ScanRun{ID: uuid.New()}
+	budget.annotate(run)
+	if run.Metadata["quota_downsampled"] != 3 {
+		t.Errorf("expected the scan run annotated, got %v", run.Metadata)
+	}
+}
+
+func TestSetTenantQuota(t *testing.T) {
+	repo := &fakeQuotaRepo{}
+	s := NewQuotaService(repo, config.QuotaConfig{MaxFindings: 1000, OverageBehavior: "bogus"}, nil)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	usage, err := s.SetTenantQuota(ctx, TenantQuotaInput{MaxScanRunsPerDay: intPtr(20), OverageBehavior: strPtr(" Downsample ")}, "admin@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.MaxFindings != 1000 || usage.MaxScanRunsPerDay != 20 || usage.OverageBehavior != entity.QuotaOverageDownsample {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if repo.quota.UpdatedBy != "admin@example.com" {
+		t.Errorf("expected the updater recorded, got %+v", repo.quota)
+	}
+
+	if _, err := s.SetTenantQuota(ctx, TenantQuotaInput{MaxFindings: intPtr(-1)}, "admin@example.com"); !errors.Is(err, ErrInvalidQuota) {
+		t.Error("expected a negative quota to be rejected")
+	}
+	if _, err := s.SetTenantQuota(ctx, TenantQuotaInput{OverageBehavior: strPtr("drop")}, "admin@example.com"); !errors.Is(err, ErrInvalidQuota) {
+		t.Error("expected an unknown overage behavior to be rejected")
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	maskingservice "github.com/arc-platform/backend/modules/masking/service"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/redaction"
+	"github.com/google/uuid"
+)
+
+const (
+	// SampleTextRedactJobType redacts samples stored before the policy applied to them
+	SampleTextRedactJobType = "sample_text.redact"
+
+	// sampleTextRedactBatch is how many samples a redaction job rewrites per transaction
+	sampleTextRedactBatch = 500
+
+	// sampleTextCacheTTL bounds how long a changed tenant toggle takes to reach
+	// ingestion on every replica
+	sampleTextCacheTTL = time.Minute
+)
+
+// SampleTextPolicyView is the sample text policy that applies to a tenant
+type SampleTextPolicyView struct {
+	DefaultPolicy   string     `json:"default_policy"`
+	StoreFull       bool       `json:"store_full"`
+	EffectivePolicy string     `json:"effective_policy"`
+	UpdatedBy       string     `json:"updated_by,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// SampleTextPolicyService decides how finding sample text is stored. Samples are
+// prepared under the SAMPLE_TEXT_POLICY default unless the tenant opted in to full
+// storage; samples stored before redaction are rewritten by a background job.
+type SampleTextPolicyService struct {
+	repo          repository.SampleTextPolicyRepository
+	defaultPolicy string
+	auditLogger   interfaces.AuditLogger
+	jobs          *jobs.Queue // Nil when the job queue is disabled
+	templates     *maskingservice.MaskingTemplateService
+
+	mu      sync.RWMutex
+	tenants map[uuid.UUID]cachedSampleTextPolicy
+}
+
+type cachedSampleTextPolicy struct {
+	storeFull bool
+	loadedAt  time.Time
+}
+
+// NewSampleTextPolicyService creates a sample text policy service. An unknown
+// default policy falls back to masking.
+func NewSampleTextPolicyService(repo repository.SampleTextPolicyRepository, defaultPolicy string, auditLogger interfaces.AuditLogger, queue *jobs.Queue) *SampleTextPolicyService {
+	if !redaction.IsPolicy(defaultPolicy) {
+		log.Printf("WARN: Unknown SAMPLE_TEXT_POLICY %q; masking sample text", defaultPolicy)
+		defaultPolicy = redaction.PolicyMask
+	}
+	return &SampleTextPolicyService{
+		repo:          repo,
+		defaultPolicy: defaultPolicy,
+		auditLogger:   auditLogger,
+		jobs:          queue,
+		tenants:       make(map[uuid.UUID]cachedSampleTextPolicy),
+	}
+}
+
+// SetMaskingTemplates masks samples with the tenant's masking templates; the
+// built-in templates apply otherwise
+func (s *SampleTextPolicyService) SetMaskingTemplates(templates *maskingservice.MaskingTemplateService) {
+	s.templates = templates
+}
+
+// DefaultPolicy returns the policy for tenants that did not opt in to full storage
+func (s *SampleTextPolicyService) DefaultPolicy() string {
+	if s == nil {
+		return redaction.PolicyMask
+	}
+	return s.defaultPolicy
+}
+
+// Resolve returns the policy for the tenant in ctx. It is cached briefly and
+// falls back to the default if the tenant's choice cannot be loaded.
+func (s *SampleTextPolicyService) Resolve(ctx context.Context) string {
+	if s == nil {
+		return redaction.PolicyMask
+	}
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return s.defaultPolicy
+	}
+
+	s.mu.RLock()
+	cached, ok := s.tenants[tenantID]
+	s.mu.RUnlock()
+	if !ok || time.Since(cached.loadedAt) >= sampleTextCacheTTL {
+		policy, err := s.repo.GetTenantSampleTextPolicy(ctx)
+		if err != nil {
+			return s.defaultPolicy
+		}
+		cached = cachedSampleTextPolicy{storeFull: policy != nil && policy.StoreFull, loadedAt: time.Now()}
+		s.mu.Lock()
+		s.tenants[tenantID] = cached
+		s.mu.Unlock()
+	}
+	if cached.storeFull {
+		return redaction.PolicyFull
+	}
+	return s.defaultPolicy
+}
+
+// Prepare prepares a raw sample of piiType for storage under the policy and
+// masking templates of the tenant in ctx
+func (s *SampleTextPolicyService) Prepare(ctx context.Context, piiType, sample string, matches []string) redaction.Sample {
+	if s == nil {
+		return redaction.DefaultTemplates().Apply(redaction.PolicyMask, piiType, sample, matches)
+	}
+	return s.templates.Templates(ctx).Apply(s.Resolve(ctx), piiType, sample, matches)
+}
+
+// GetTenantPolicy returns the policy that applies to the tenant in ctx
+func (s *SampleTextPolicyService) GetTenantPolicy(ctx context.Context) (*SampleTextPolicyView, error) {
+	policy, err := s.repo.GetTenantSampleTextPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	view := &SampleTextPolicyView{DefaultPolicy: s.defaultPolicy, EffectivePolicy: s.defaultPolicy}
+	if policy != nil {
+		view.StoreFull = policy.StoreFull
+		view.UpdatedBy = policy.UpdatedBy
+		view.UpdatedAt = &policy.UpdatedAt
+		if policy.StoreFull {
+			view.EffectivePolicy = redaction.PolicyFull
+		}
+	}
+	return view, nil
+}
+
+// SetTenantStoreFull opts the tenant in ctx in to, or out of, full sample storage
+// from the next scan on. Opting out queues redaction of the samples already stored.
+func (s *SampleTextPolicyService) SetTenantStoreFull(ctx context.Context, storeFull bool, updatedBy string) (*SampleTextPolicyView, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetTenantSampleTextPolicy(ctx, storeFull, updatedBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.tenants, tenantID)
+	s.mu.Unlock()
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "SAMPLE_TEXT_POLICY_CHANGED", "tenant", tenantID.String(), map[string]interface{}{
+			"store_full":     storeFull,
+			"default_policy": s.defaultPolicy,
+		})
+	}
+
+	if !storeFull && s.defaultPolicy != redaction.PolicyFull && s.jobs != nil {
+		if _, err := s.jobs.Enqueue(ctx, SampleTextRedactJobType, map[string]interface{}{}, jobs.EnqueueOptions{}); err != nil {
+			log.Printf("WARN: Failed to queue sample text redaction: %v", err)
+		}
+	}
+	return s.GetTenantPolicy(ctx)
+}
+
+// RegisterJobs registers the redaction job with the queue
+func (s *SampleTextPolicyService) RegisterJobs(queue *jobs.Queue) {
+	queue.Register(SampleTextRedactJobType, s.redactJob, jobs.HandlerOptions{Timeout: 30 * time.Minute})
+}
+
+// EnqueueBackfill queues redaction of samples stored before the policy applied to
+// them, across all tenants. Nothing is queued when none are left.
+func (s *SampleTextPolicyService) EnqueueBackfill(ctx context.Context) error {
+	if s.jobs == nil || s.defaultPolicy == redaction.PolicyFull {
+		return nil
+	}
+	pending, err := s.repo.ListUnredactedSamples(ctx, nil, 1)
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+	_, err = s.jobs.Enqueue(ctx, SampleTextRedactJobType, map[string]interface{}{}, jobs.EnqueueOptions{})
+	return err
+}
+
+// redactJob rewrites stored samples under the default policy in batches, for the
+// job's tenant or, for a system job, every tenant without full storage
+func (s *SampleTextPolicyService) redactJob(ctx context.Context, job *entity.Job) error {
+	if s.defaultPolicy == redaction.PolicyFull {
+		return nil
+	}
+	total := 0
+	for {
+		samples, err := s.repo.ListUnredactedSamples(ctx, job.TenantID, sampleTextRedactBatch)
+		if err != nil {
+			return err
+		}
+		if len(samples) == 0 {
+			break
+		}
+		for i := range samples {
+			tenantCtx := context.WithValue(ctx, "tenant_id", samples[i].TenantID)
+			prepared := s.templates.Templates(tenantCtx).Apply(s.defaultPolicy, samples[i].PIIType, samples[i].SampleText, samples[i].Matches)
+			samples[i].SampleText = prepared.Text
+			if samples[i].SHA256 == "" {
+				samples[i].SHA256 = prepared.SHA256
+			}
+		}
+		if err := s.repo.SaveRedactedSamples(ctx, samples); err != nil {
+			return err
+		}
+		total += len(samples)
+	}
+	if total > 0 {
+		log.Printf("🔒 Redacted %d stored finding samples under the %s policy", total, s.defaultPolicy)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/google/uuid"
+)
+
+func TestSampleTextPolicyDuringIngestion(t *testing.T) {
+	repo := memory.NewRepository()
+	queue := jobs.NewQueue(repo, config.JobsConfig{MaxAttempts: 3})
+	policy := NewSampleTextPolicyService(repo, "unknown", nil, queue)
+	policy.RegisterJobs(queue)
+	ingestion := newMemoryIngestionService(repo)
+	ingestion.SetSampleTextPolicy(policy)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	ingest := func(scanID, email string) *entity.Finding {
+		t.Helper()
+		_, err := ingestion.IngestScan(ctx, &HawkeyeScanInput{ScanID: scanID, FS: []HawkeyeFinding{
+			{FilePath: "/data/" + scanID + ".csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{email}, SampleText: "contact " + email + " acct 55512345678"},
+		}})
+		if err != nil {
+			t.Fatalf("IngestScan: %v", err)
+		}
+		findings := repo.Findings()
+		return findings[len(findings)-1]
+	}
+
+	// An unknown default falls back to masking the match with its template, and long digit runs
+	masked := ingest("scan-1", "asha.rao@example.in")
+	if masked.SampleText != "contact aXXX.XXX@example.in acct XXXXXXX5678" || !masked.SampleTextRedacted || len(masked.SampleTextSHA256) != 64 {
+		t.Fatalf("expected a masked sample, got %+v", masked)
+	}
+
+	// A tenant opted in to full storage keeps the sample as scanned
+	if view, err := policy.SetTenantStoreFull(ctx, true, "admin@example.in"); err != nil || view.EffectivePolicy != "full" {
+		t.Fatalf("SetTenantStoreFull: %+v, %v", view, err)
+	}
+	full := ingest("scan-2", "ravi.k@example.in")
+	if full.SampleText != "contact ravi.k@example.in acct 55512345678" || full.SampleTextRedacted {
+		t.Fatalf("expected the full sample, got %+v", full)
+	}
+	if samples, _ := repo.ListUnredactedSamples(ctx, nil, 10); len(samples) != 0 {
+		t.Fatalf("full samples of an opted-in tenant must not be redacted, got %d", len(samples))
+	}
+
+	// Opting out queues redaction of what was stored in full
+	if _, err := policy.SetTenantStoreFull(ctx, false, "admin@example.in"); err != nil {
+		t.Fatalf("SetTenantStoreFull: %v", err)
+	}
+	queued, _, err := queue.List(ctx, entity.JobFilter{Type: SampleTextRedactJobType})
+	if err != nil || len(queued) != 1 {
+		t.Fatalf("expected one queued redaction job, got %d (%v)", len(queued), err)
+	}
+	if err := policy.redactJob(ctx, queued[0]); err != nil {
+		t.Fatalf("redactJob: %v", err)
+	}
+	for _, f := range repo.Findings() {
+		if f.ID == full.ID && (f.SampleText != "contact rXXX.X@example.in acct XXXXXXX5678" || !f.SampleTextRedacted || f.SampleTextSHA256 != full.SampleTextSHA256) {
+			t.Errorf("expected the stored sample to be redacted with its hash kept, got %+v", f)
+		}
+	}
+}
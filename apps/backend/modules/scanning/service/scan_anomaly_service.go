@@ -0,0 +1,556 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// scanAnomalyBatchSize bounds the scan runs checked in one detection pass
+const scanAnomalyBatchSize = 100
+
+// ScanAnomalySettingsInput changes a tenant's anomaly detection settings; unset fields keep their value
+type ScanAnomalySettingsInput struct {
+	Enabled         *bool              `json:"enabled"`
+	SpikeFactor     *float64           `json:"spike_factor"`
+	MinMatches      *int               `json:"min_matches"`
+	BaselineRuns    *int               `json:"baseline_runs"`
+	PIISpikeFactors map[string]float64 `json:"pii_spike_factors"` // Replaces all overrides when set
+}
+
+// ScanAnomalySuppressionInput is a suppression window to create
+type ScanAnomalySuppressionInput struct {
+	AssetID      *uuid.UUID `json:"asset_id"`
+	ConnectionID *uuid.UUID `json:"connection_id"`
+	PIIType      string     `json:"pii_type"`
+	StartsAt     *time.Time `json:"starts_at"` // Now when unset
+	EndsAt       time.Time  `json:"ends_at" binding:"required"`
+	Reason       string     `json:"reason" binding:"required,max=1000"`
+}
+
+// ScanAnomalyReport summarizes one detection pass
+type ScanAnomalyReport struct {
+	RunsChecked int `json:"runs_checked"`
+	Anomalies   int `json:"anomalies"`  // Alerted
+	Suppressed  int `json:"suppressed"` // Recorded inside a suppression window
+}
+
+// ScanAnomalyService compares each completed scan run's matches per PII type
+// against the previous runs of the same asset and connection, and alerts on
+// volume spikes such as a sudden 10x in Aadhaar matches that suggests a data dump
+type ScanAnomalyService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+	events      interfaces.EventPublisher
+	defaults    config.ScanAnomalyConfig
+}
+
+// NewScanAnomalyService creates a new scan anomaly service
+func NewScanAnomalyService(repo *persistence.PostgresRepository, cfg config.ScanAnomalyConfig, auditLogger interfaces.AuditLogger) *ScanAnomalyService {
+	if cfg.SpikeFactor <= 1 {
+		cfg.SpikeFactor = 10
+	}
+	if cfg.MinMatches < 1 {
+		cfg.MinMatches = 50
+	}
+	if cfg.BaselineRuns < 1 {
+		cfg.BaselineRuns = 5
+	}
+	if cfg.MinBaselineRuns < 1 {
+		cfg.MinBaselineRuns = 3
+	}
+	return &ScanAnomalyService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		events:      &interfaces.NoOpEventPublisher{},
+		defaults:    cfg,
+	}
+}
+
+// SetEventPublisher streams detected anomalies to clients
+func (s *ScanAnomalyService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// GetSettings returns the tenant's settings, or the configured defaults
+func (s *ScanAnomalyService) GetSettings(ctx context.Context) (*entity.ScanAnomalySettings, error) {
+	settings, err := s.repo.GetScanAnomalySettings(ctx)
+	if err != nil || settings != nil {
+		return settings, err
+	}
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ScanAnomalySettings{
+		TenantID:        tenantID,
+		Enabled:         true,
+		SpikeFactor:     s.defaults.SpikeFactor,
+		MinMatches:      s.defaults.MinMatches,
+		BaselineRuns:    s.defaults.BaselineRuns,
+		PIISpikeFactors: map[string]float64{},
+	}, nil
+}
+
+// UpdateSettings changes the tenant's anomaly detection settings
+func (s *ScanAnomalyService) UpdateSettings(ctx context.Context, input ScanAnomalySettingsInput, updatedBy string) (*entity.ScanAnomalySettings, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Enabled != nil {
+		settings.Enabled = *input.Enabled
+	}
+	if input.SpikeFactor != nil {
+		settings.SpikeFactor = *input.SpikeFactor
+	}
+	if input.MinMatches != nil {
+		settings.MinMatches = *input.MinMatches
+	}
+	if input.BaselineRuns != nil {
+		settings.BaselineRuns = *input.BaselineRuns
+	}
+	if input.PIISpikeFactors != nil {
+		settings.PIISpikeFactors = make(map[string]float64, len(input.PIISpikeFactors))
+		for piiType, factor := range input.PIISpikeFactors {
+			settings.PIISpikeFactors[strings.TrimSpace(piiType)] = factor
+		}
+	}
+	if err := validateScanAnomalySettings(settings); err != nil {
+		return nil, err
+	}
+
+	settings.UpdatedBy = updatedBy
+	if err := s.repo.SetScanAnomalySettings(ctx, settings); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	settings.UpdatedAt = &now
+
+	s.record(ctx, "SCAN_ANOMALY_SETTINGS_CHANGED", settings.TenantID.String(), map[string]interface{}{
+		"enabled":           settings.Enabled,
+		"spike_factor":      settings.SpikeFactor,
+		"min_matches":       settings.MinMatches,
+		"baseline_runs":     settings.BaselineRuns,
+		"pii_spike_factors": settings.PIISpikeFactors,
+	})
+	return settings, nil
+}
+
+func validateScanAnomalySettings(settings *entity.ScanAnomalySettings) error {
+	if settings.SpikeFactor <= 1 {
+		return fmt.Errorf("spike_factor must be greater than 1")
+	}
+	if settings.MinMatches < 1 {
+		return fmt.Errorf("min_matches must be at least 1")
+	}
+	if settings.BaselineRuns < 1 || settings.BaselineRuns > 50 {
+		return fmt.Errorf("baseline_runs must be between 1 and 50")
+	}
+	for piiType, factor := range settings.PIISpikeFactors {
+		if piiType == "" {
+			return fmt.Errorf("pii_spike_factors keys must be PII types")
+		}
+		if factor <= 1 {
+			return fmt.Errorf("pii_spike_factors[%s] must be greater than 1", piiType)
+		}
+	}
+	return nil
+}
+
+// ListAnomalies returns a page of the tenant's anomalies and how many match the filter
+func (s *ScanAnomalyService) ListAnomalies(ctx context.Context, filter entity.ScanAnomalyFilter) ([]*entity.ScanAnomaly, int, error) {
+	switch filter.Status {
+	case "", entity.ScanAnomalyOpen, entity.ScanAnomalyAcknowledged, entity.ScanAnomalySuppressed:
+	default:
+		return nil, 0, fmt.Errorf("invalid status %q", filter.Status)
+	}
+	if filter.Limit < 1 || filter.Limit > 500 {
+		filter.Limit = 50
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.repo.ListScanAnomalies(ctx, filter)
+}
+
+// Acknowledge marks an open anomaly as looked at
+func (s *ScanAnomalyService) Acknowledge(ctx context.Context, id uuid.UUID, acknowledgedBy string) (*entity.ScanAnomaly, error) {
+	anomaly, err := s.repo.AcknowledgeScanAnomaly(ctx, id, acknowledgedBy)
+	if err != nil {
+		return nil, err
+	}
+	if anomaly == nil {
+		return nil, fmt.Errorf("open scan anomaly not found")
+	}
+
+	s.record(ctx, "SCAN_VOLUME_ANOMALY_ACKNOWLEDGED", id.String(), map[string]interface{}{
+		"scan_run_id": anomaly.ScanRunID,
+		"pii_type":    anomaly.PIIType,
+	})
+	return anomaly, nil
+}
+
+// CreateSuppression adds a window in which matching anomalies are recorded without alerting
+func (s *ScanAnomalyService) CreateSuppression(ctx context.Context, input ScanAnomalySuppressionInput, createdBy string) (*entity.ScanAnomalySuppression, error) {
+	suppression := &entity.ScanAnomalySuppression{
+		ID:           uuid.New(),
+		AssetID:      input.AssetID,
+		ConnectionID: input.ConnectionID,
+		PIIType:      strings.TrimSpace(input.PIIType),
+		StartsAt:     time.Now(),
+		EndsAt:       input.EndsAt,
+		Reason:       strings.TrimSpace(input.Reason),
+		CreatedBy:    createdBy,
+	}
+	if input.StartsAt != nil {
+		suppression.StartsAt = *input.StartsAt
+	}
+	if suppression.Reason == "" {
+		return nil, fmt.Errorf("reason must be set")
+	}
+	if !suppression.EndsAt.After(suppression.StartsAt) {
+		return nil, fmt.Errorf("ends_at must be after starts_at")
+	}
+
+	if err := s.repo.CreateScanAnomalySuppression(ctx, suppression); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "SCAN_ANOMALY_SUPPRESSION_CREATED", suppression.ID.String(), map[string]interface{}{
+		"asset_id":      suppression.AssetID,
+		"connection_id": suppression.ConnectionID,
+		"pii_type":      suppression.PIIType,
+		"starts_at":     suppression.StartsAt,
+		"ends_at":       suppression.EndsAt,
+		"reason":        suppression.Reason,
+	})
+	return suppression, nil
+}
+
+// ListSuppressions returns the suppression windows that have not ended
+func (s *ScanAnomalyService) ListSuppressions(ctx context.Context) ([]*entity.ScanAnomalySuppression, error) {
+	return s.repo.ListScanAnomalySuppressions(ctx, time.Now())
+}
+
+// DeleteSuppression removes a suppression window
+func (s *ScanAnomalyService) DeleteSuppression(ctx context.Context, id uuid.UUID) error {
+	deleted, err := s.repo.DeleteScanAnomalySuppression(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("suppression window not found")
+	}
+
+	s.record(ctx, "SCAN_ANOMALY_SUPPRESSION_DELETED", id.String(), nil)
+	return nil
+}
+
+// Detect checks the tenant's completed scan runs that have not been checked yet,
+// oldest first. Runs of a tenant that disabled detection are marked checked
+// without comparing, so re-enabling it does not alert on old runs.
+func (s *ScanAnomalyService) Detect(ctx context.Context) (*ScanAnomalyReport, error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ScanAnomalyReport{}
+	for {
+		runs, err := s.repo.ListUncheckedScanRuns(ctx, scanAnomalyBatchSize)
+		if err != nil {
+			return report, err
+		}
+		if len(runs) == 0 {
+			return report, nil
+		}
+
+		suppressions, err := s.repo.ListScanAnomalySuppressions(ctx, runs[0].ScanCompletedAt)
+		if err != nil {
+			return report, err
+		}
+		for _, run := range runs {
+			var anomalies []*entity.ScanAnomaly
+			if settings.Enabled {
+				anomalies, err = s.detectRun(ctx, run, settings)
+				if err != nil {
+					return report, fmt.Errorf("scan run %s: %w", run.ID, err)
+				}
+				applyScanAnomalySuppressions(anomalies, suppressions, run.ScanCompletedAt)
+			}
+
+			recorded, err := s.repo.RecordScanAnomalies(ctx, run.ID, anomalies)
+			if err != nil {
+				return report, fmt.Errorf("scan run %s: %w", run.ID, err)
+			}
+			report.RunsChecked++
+			for _, anomaly := range recorded {
+				if anomaly.Status == entity.ScanAnomalySuppressed {
+					report.Suppressed++
+					continue
+				}
+				report.Anomalies++
+				s.alert(ctx, anomaly)
+			}
+		}
+		if len(runs) < scanAnomalyBatchSize {
+			return report, nil
+		}
+	}
+}
+
+// detectRun compares a scan run's matches with the previous runs of its assets and connection
+func (s *ScanAnomalyService) detectRun(ctx context.Context, run *entity.ScanRun, settings *entity.ScanAnomalySettings) ([]*entity.ScanAnomaly, error) {
+	current, err := s.repo.ListScanRunMatchCounts(ctx, []uuid.UUID{run.ID})
+	if err != nil || len(current) == 0 {
+		return nil, err
+	}
+
+	assetRuns, err := s.repo.ListPriorAssetScanRuns(ctx, run, settings.BaselineRuns)
+	if err != nil {
+		return nil, err
+	}
+	connectionRuns, err := s.repo.ListPriorConnectionScanRuns(ctx, run, settings.BaselineRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var priorIDs []uuid.UUID
+	for _, ids := range append([][]uuid.UUID{connectionRuns}, mapValues(assetRuns)...) {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				priorIDs = append(priorIDs, id)
+			}
+		}
+	}
+	history, err := s.repo.ListScanRunMatchCounts(ctx, priorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return detectScanAnomalies(run, current, history, assetRuns, connectionRuns, settings, s.defaults.MinBaselineRuns), nil
+}
+
+func mapValues(m map[uuid.UUID][]uuid.UUID) [][]uuid.UUID {
+	values := make([][]uuid.UUID, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// alert streams an open anomaly to the tenant's clients and audits it
+func (s *ScanAnomalyService) alert(ctx context.Context, anomaly *entity.ScanAnomaly) {
+	s.events.Publish(ctx, interfaces.EventScanVolumeAnomaly, anomaly)
+	s.record(ctx, "SCAN_VOLUME_ANOMALY", anomaly.ID.String(), map[string]interface{}{
+		"scan_run_id":      anomaly.ScanRunID,
+		"scope":            anomaly.Scope,
+		"asset_id":         anomaly.AssetID,
+		"connection_id":    anomaly.ConnectionID,
+		"pii_type":         anomaly.PIIType,
+		"observed_matches": anomaly.ObservedMatches,
+		"baseline_matches": anomaly.BaselineMatches,
+		"ratio":            anomaly.Ratio,
+	})
+}
+
+func (s *ScanAnomalyService) record(ctx context.Context, action, resourceID string, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, "scan_anomaly", resourceID, metadata); err != nil {
+		log.Printf("WARN: Failed to audit %s: %v", action, err)
+	}
+}
+
+// StartDetectionWorker periodically checks every tenant's new scan runs until ctx is cancelled
+func (s *ScanAnomalyService) StartDetectionWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 15
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("📈 Starting scan anomaly detection worker (interval: %d minutes)", intervalMinutes)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Scan anomaly detection worker stopped")
+			return
+		case <-ticker.C:
+			s.detectAllTenants(ctx)
+		}
+	}
+}
+
+// detectAllTenants runs detection once per tenant with unchecked scan runs
+func (s *ScanAnomalyService) detectAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListScanAnomalyTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Error listing tenants for scan anomaly detection: %v", err)
+		return
+	}
+
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		report, err := s.Detect(tenantCtx)
+		if err != nil {
+			log.Printf("❌ Scan anomaly detection failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if report.Anomalies > 0 {
+			log.Printf("📈 Detected %d scan volume anomal(ies) for tenant %s", report.Anomalies, tenantID)
+		}
+	}
+}
+
+// ============================================================================
+// Detection
+// ============================================================================
+
+// scanRunPII keys match counts by scan run, asset and PII type
+type scanRunPII struct {
+	runID   uuid.UUID
+	assetID uuid.UUID
+	piiType string
+}
+
+// detectScanAnomalies compares a run's matches per PII type against the median of
+// previous runs: per asset against the asset's previous runs, and, for a run of a
+// connection, summed over all assets against the connection's previous runs. A
+// previous run without the PII type counts as zero matches. Subjects with fewer
+// than minBaselineRuns previous runs have no baseline yet and are skipped.
+func detectScanAnomalies(run *entity.ScanRun, current, history []entity.ScanRunMatchCount, assetRuns map[uuid.UUID][]uuid.UUID, connectionRuns []uuid.UUID, settings *entity.ScanAnomalySettings, minBaselineRuns int) []*entity.ScanAnomaly {
+	past := make(map[scanRunPII]int, len(history))
+	pastByRun := make(map[uuid.UUID]map[string]int)
+	for _, c := range history {
+		past[scanRunPII{c.ScanRunID, c.AssetID, c.PIIType}] += c.Matches
+		if pastByRun[c.ScanRunID] == nil {
+			pastByRun[c.ScanRunID] = make(map[string]int)
+		}
+		pastByRun[c.ScanRunID][c.PIIType] += c.Matches
+	}
+
+	var anomalies []*entity.ScanAnomaly
+	observedByType := make(map[string]int)
+	for _, c := range current {
+		observedByType[c.PIIType] += c.Matches
+
+		prior := assetRuns[c.AssetID]
+		if len(prior) < minBaselineRuns {
+			continue
+		}
+		baseline := make([]float64, len(prior))
+		for i, runID := range prior {
+			baseline[i] = float64(past[scanRunPII{runID, c.AssetID, c.PIIType}])
+		}
+		assetID := c.AssetID
+		if anomaly := scanAnomalyFor(c.PIIType, c.Matches, baseline, settings); anomaly != nil {
+			anomaly.Scope = entity.ScanAnomalyScopeAsset
+			anomaly.AssetID = &assetID
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	if run.ConnectionID != nil && len(connectionRuns) >= minBaselineRuns {
+		for piiType, observed := range observedByType {
+			baseline := make([]float64, len(connectionRuns))
+			for i, runID := range connectionRuns {
+				baseline[i] = float64(pastByRun[runID][piiType])
+			}
+			if anomaly := scanAnomalyFor(piiType, observed, baseline, settings); anomaly != nil {
+				anomaly.Scope = entity.ScanAnomalyScopeConnection
+				anomalies = append(anomalies, anomaly)
+			}
+		}
+	}
+
+	for _, anomaly := range anomalies {
+		anomaly.ID = uuid.New()
+		anomaly.ScanRunID = run.ID
+		anomaly.ConnectionID = run.ConnectionID
+		anomaly.Status = entity.ScanAnomalyOpen
+	}
+	sort.Slice(anomalies, func(i, j int) bool { return anomalies[i].Ratio > anomalies[j].Ratio })
+	return anomalies
+}
+
+// scanAnomalyFor returns an anomaly when observed reaches the minimum matches and
+// the PII type's spike factor times the baseline median, which counts as at least 1
+func scanAnomalyFor(piiType string, observed int, baseline []float64, settings *entity.ScanAnomalySettings) *entity.ScanAnomaly {
+	if observed < settings.MinMatches {
+		return nil
+	}
+	factor := settings.SpikeFactor
+	if override, ok := settings.PIISpikeFactors[piiType]; ok {
+		factor = override
+	}
+
+	median := medianOf(baseline)
+	ratio := float64(observed) / max(median, 1)
+	if ratio < factor {
+		return nil
+	}
+	return &entity.ScanAnomaly{
+		PIIType:         piiType,
+		ObservedMatches: observed,
+		BaselineMatches: median,
+		BaselineRuns:    len(baseline),
+		Ratio:           ratio,
+	}
+}
+
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// applyScanAnomalySuppressions marks anomalies of a run completed inside a
+// matching suppression window as suppressed
+func applyScanAnomalySuppressions(anomalies []*entity.ScanAnomaly, suppressions []*entity.ScanAnomalySuppression, completedAt time.Time) {
+	for _, anomaly := range anomalies {
+		for _, s := range suppressions {
+			if completedAt.Before(s.StartsAt) || !completedAt.Before(s.EndsAt) {
+				continue
+			}
+			if s.AssetID != nil && (anomaly.AssetID == nil || *anomaly.AssetID != *s.AssetID) {
+				continue
+			}
+			if s.ConnectionID != nil && (anomaly.ConnectionID == nil || *anomaly.ConnectionID != *s.ConnectionID) {
+				continue
+			}
+			if s.PIIType != "" && !strings.EqualFold(s.PIIType, anomaly.PIIType) {
+				continue
+			}
+			id := s.ID
+			anomaly.Status = entity.ScanAnomalySuppressed
+			anomaly.SuppressionID = &id
+			break
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestDetectScanAnomalies(t *testing.T) {
+	settings := &entity.ScanAnomalySettings{
+		Enabled:         true,
+		SpikeFactor:     10,
+		MinMatches:      50,
+		BaselineRuns:    5,
+		PIISpikeFactors: map[string]float64{"EMAIL": 3},
+	}
+	connectionID := uuid.New()
+	run := &entity.ScanRun{ID: uuid.New(), ConnectionID: &connectionID}
+	dump, steady, fresh := uuid.New(), uuid.New(), uuid.New()
+	prior := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	var history []entity.ScanRunMatchCount
+	for _, runID := range prior {
+		history = append(history,
+			entity.ScanRunMatchCount{ScanRunID: runID, AssetID: dump, PIIType: "AADHAAR", Matches: 20},
+			entity.ScanRunMatchCount{ScanRunID: runID, AssetID: dump, PIIType: "EMAIL", Matches: 30},
+			entity.ScanRunMatchCount{ScanRunID: runID, AssetID: steady, PIIType: "AADHAAR", Matches: 400},
+		)
+	}
+	current := []entity.ScanRunMatchCount{
+		// Aadhaar matches jump 20 -> 1000: a data dump
+		{ScanRunID: run.ID, AssetID: dump, PIIType: "AADHAAR", Matches: 1000},
+		// 3x emails is under the global factor but reaches the EMAIL override
+		{ScanRunID: run.ID, AssetID: dump, PIIType: "EMAIL", Matches: 95},
+		// A PII type first seen on the asset, below the minimum matches
+		{ScanRunID: run.ID, AssetID: dump, PIIType: "PAN", Matches: 40},
+		{ScanRunID: run.ID, AssetID: steady, PIIType: "AADHAAR", Matches: 410},
+		// An asset without enough previous runs has no baseline yet
+		{ScanRunID: run.ID, AssetID: fresh, PIIType: "PAN", Matches: 5000},
+	}
+	assetRuns := map[uuid.UUID][]uuid.UUID{dump: prior, steady: prior, fresh: prior[:1]}
+
+	anomalies := detectScanAnomalies(run, current, history, assetRuns, prior, settings, 3)
+	found := make(map[string]*entity.ScanAnomaly)
+	for _, a := range anomalies {
+		key := a.Scope + "/" + a.PIIType
+		if a.AssetID != nil && *a.AssetID != dump {
+			key += "/other"
+		}
+		found[key] = a
+		if a.ScanRunID != run.ID || a.ConnectionID == nil || *a.ConnectionID != connectionID || a.Status != entity.ScanAnomalyOpen {
+			t.Errorf("unexpected anomaly fields %+v", a)
+		}
+	}
+
+	aadhaar := found["asset/AADHAAR"]
+	if aadhaar == nil || aadhaar.BaselineMatches != 20 || aadhaar.Ratio != 50 || aadhaar.BaselineRuns != 3 {
+		t.Errorf("expected a 50x Aadhaar spike on the asset, got %+v", aadhaar)
+	}
+	if found["asset/EMAIL"] == nil {
+		t.Error("expected the EMAIL override factor to apply")
+	}
+	if found["asset/PAN"] != nil || found["asset/PAN/other"] != nil || found["asset/AADHAAR/other"] != nil {
+		t.Errorf("unexpected asset anomalies %v", found)
+	}
+
+	// Summed over the connection, PAN (5040 vs 0) and EMAIL (95 vs 30) spike; Aadhaar (1410 vs 420) does not
+	if found["connection/EMAIL"] == nil || found["connection/PAN"] == nil || found["connection/PAN"].AssetID != nil {
+		t.Errorf("expected connection PAN and EMAIL spikes, got %v", found)
+	}
+	if found["connection/AADHAAR"] != nil {
+		t.Errorf("unexpected connection Aadhaar anomaly %+v", found["connection/AADHAAR"])
+	}
+	if len(anomalies) != 4 {
+		t.Errorf("expected 4 anomalies, got %d", len(anomalies))
+	}
+}
+
+func TestMedianOf(t *testing.T) {
+	if got := medianOf([]float64{5, 1, 3}); got != 3 {
+		t.Errorf("median = %v, want 3", got)
+	}
+	if got := medianOf([]float64{0, 10, 2, 4}); got != 3 {
+		t.Errorf("median = %v, want 3", got)
+	}
+	if got := medianOf(nil); got != 0 {
+		t.Errorf("median of nothing = %v, want 0", got)
+	}
+}
+
+func TestApplyScanAnomalySuppressions(t *testing.T) {
+	assetID, otherAsset := uuid.New(), uuid.New()
+	completed := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	window := func(asset *uuid.UUID, piiType string, from, to time.Time) *entity.ScanAnomalySuppression {
+		return &entity.ScanAnomalySuppression{ID: uuid.New(), AssetID: asset, PIIType: piiType, StartsAt: from, EndsAt: to}
+	}
+	day := 24 * time.Hour
+
+	suppressions := []*entity.ScanAnomalySuppression{
+		window(nil, "", completed.Add(-2*day), completed.Add(-day)), // Ended before the run
+		window(&otherAsset, "", completed.Add(-day), completed.Add(day)),
+		window(&assetID, "aadhaar", completed.Add(-day), completed.Add(day)),
+	}
+	anomalies := []*entity.ScanAnomaly{
+		{Scope: entity.ScanAnomalyScopeAsset, AssetID: &assetID, PIIType: "AADHAAR", Status: entity.ScanAnomalyOpen},
+		{Scope: entity.ScanAnomalyScopeAsset, AssetID: &assetID, PIIType: "PAN", Status: entity.ScanAnomalyOpen},
+		{Scope: entity.ScanAnomalyScopeConnection, PIIType: "AADHAAR", Status: entity.ScanAnomalyOpen},
+	}
+	applyScanAnomalySuppressions(anomalies, suppressions, completed)
+
+	if anomalies[0].Status != entity.ScanAnomalySuppressed || *anomalies[0].SuppressionID != suppressions[2].ID {
+		t.Errorf("expected the asset's Aadhaar window to suppress, got %+v", anomalies[0])
+	}
+	if anomalies[1].Status != entity.ScanAnomalyOpen || anomalies[2].Status != entity.ScanAnomalyOpen {
+		t.Errorf("expected other anomalies to stay open, got %+v %+v", anomalies[1], anomalies[2])
+	}
+}
+
+func TestValidateScanAnomalySettings(t *testing.T) {
+	valid := entity.ScanAnomalySettings{SpikeFactor: 10, MinMatches: 50, BaselineRuns: 5}
+	if err := validateScanAnomalySettings(&valid); err != nil {
+		t.Fatal(err)
+	}
+	for _, invalid := range []entity.ScanAnomalySettings{
+		{SpikeFactor: 1, MinMatches: 50, BaselineRuns: 5},
+		{SpikeFactor: 10, MinMatches: 0, BaselineRuns: 5},
+		{SpikeFactor: 10, MinMatches: 50, BaselineRuns: 51},
+		{SpikeFactor: 10, MinMatches: 50, BaselineRuns: 5, PIISpikeFactors: map[string]float64{"EMAIL": 0.5}},
+	} {
+		if err := validateScanAnomalySettings(&invalid); err == nil {
+			t.Errorf("expected %+v to be invalid", invalid)
+		}
+	}
+}
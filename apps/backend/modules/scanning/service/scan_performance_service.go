@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// DefaultScanPerformanceDays is how far back performance trends look without ?days
+const DefaultScanPerformanceDays = 30
+
+var (
+	// ErrScanPerformanceNotFound is returned for scan runs that do not exist or
+	// were ingested before scan runs were profiled
+	ErrScanPerformanceNotFound = errors.New("no performance profile recorded for scan run")
+	// ErrInvalidPerformanceWindow is returned for trend windows out of range
+	ErrInvalidPerformanceWindow = errors.New("invalid scan performance window")
+)
+
+// ScanPerformanceTrends aggregates the ingestion profiles of scan runs over time.
+// Overall weights each period by its scans.
+type ScanPerformanceTrends struct {
+	Since    time.Time                          `json:"since"`
+	Interval string                             `json:"interval"`
+	Overall  ScanPerformanceOverall             `json:"overall"`
+	Points   []entity.ScanPerformanceTrendPoint `json:"points"`
+}
+
+// ScanPerformanceOverall totals the profiled scan runs of a trend window
+type ScanPerformanceOverall struct {
+	Scans                int     `json:"scans"`
+	Findings             int     `json:"findings"`
+	AvgFindingsPerSecond float64 `json:"avg_findings_per_second"`
+	AvgDurationMs        float64 `json:"avg_duration_ms"`
+	AvgDBMs              float64 `json:"avg_db_ms"`
+	AvgClassificationMs  float64 `json:"avg_classification_ms"`
+	AvgNeo4jSyncMs       float64 `json:"avg_neo4j_sync_ms"`
+	AvgQueueWaitMs       float64 `json:"avg_queue_wait_ms"`
+	MaxQueueWaitMs       int64   `json:"max_queue_wait_ms"`
+}
+
+// ScanPerformanceService reads the ingestion profiles recorded on scan runs, for
+// capacity planning
+type ScanPerformanceService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewScanPerformanceService creates a new scan performance service
+func NewScanPerformanceService(repo *persistence.PostgresRepository) *ScanPerformanceService {
+	return &ScanPerformanceService{repo: repo}
+}
+
+// ForScanRun returns the ingestion profile of one of the tenant's scan runs
+func (s *ScanPerformanceService) ForScanRun(ctx context.Context, scanRunID uuid.UUID) (*entity.ScanPerformance, error) {
+	perf, err := s.repo.GetScanRunPerformance(ctx, scanRunID)
+	if err != nil {
+		return nil, err
+	}
+	if perf == nil {
+		return nil, ErrScanPerformanceNotFound
+	}
+	return perf, nil
+}
+
+// Trends aggregates the profiles of the tenant's scan runs started in the last
+// days days (default 30, at most 365) per day or week (default day)
+func (s *ScanPerformanceService) Trends(ctx context.Context, days int, interval string) (*ScanPerformanceTrends, error) {
+	if days == 0 {
+		days = DefaultScanPerformanceDays
+	}
+	if days < 0 || days > 365 {
+		return nil, fmt.Errorf("%w: days must be between 1 and 365", ErrInvalidPerformanceWindow)
+	}
+	if interval == "" {
+		interval = "day"
+	}
+	if interval != "day" && interval != "week" {
+		return nil, fmt.Errorf("%w: interval must be day or week", ErrInvalidPerformanceWindow)
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -(days - 1))
+	points, err := s.repo.GetScanPerformanceTrends(ctx, since, interval)
+	if err != nil {
+		return nil, err
+	}
+	return &ScanPerformanceTrends{
+		Since:    since,
+		Interval: interval,
+		Overall:  overallScanPerformance(points),
+		Points:   points,
+	}, nil
+}
+
+// overallScanPerformance combines period averages into averages over every scan
+func overallScanPerformance(points []entity.ScanPerformanceTrendPoint) ScanPerformanceOverall {
+	var overall ScanPerformanceOverall
+	for _, p := range points {
+		n := float64(p.Scans)
+		overall.Scans += p.Scans
+		overall.Findings += p.Findings
+		overall.AvgFindingsPerSecond += p.AvgFindingsPerSecond * n
+		overall.AvgDurationMs += p.AvgDurationMs * n
+		overall.AvgDBMs += p.AvgDBMs * n
+		overall.AvgClassificationMs += p.AvgClassificationMs * n
+		overall.AvgNeo4jSyncMs += p.AvgNeo4jSyncMs * n
+		overall.AvgQueueWaitMs += p.AvgQueueWaitMs * n
+		overall.MaxQueueWaitMs = max(overall.MaxQueueWaitMs, p.MaxQueueWaitMs)
+	}
+	if overall.Scans > 0 {
+		n := float64(overall.Scans)
+		overall.AvgFindingsPerSecond /= n
+		overall.AvgDurationMs /= n
+		overall.AvgDBMs /= n
+		overall.AvgClassificationMs /= n
+		overall.AvgNeo4jSyncMs /= n
+		overall.AvgQueueWaitMs /= n
+	}
+	return overall
+}
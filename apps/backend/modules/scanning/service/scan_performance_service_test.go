@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestOverallScanPerformance(t *testing.T) {
+	overall := overallScanPerformance([]entity.ScanPerformanceTrendPoint{
+		{Scans: 1, Findings: 100, AvgFindingsPerSecond: 10, AvgDBMs: 1000, AvgQueueWaitMs: 0, MaxQueueWaitMs: 0},
+		{Scans: 3, Findings: 900, AvgFindingsPerSecond: 30, AvgDBMs: 2000, AvgQueueWaitMs: 200, MaxQueueWaitMs: 500},
+	})
+
+	// Periods count by their scans, not equally
+	if overall.Scans != 4 || overall.Findings != 1000 || overall.MaxQueueWaitMs != 500 {
+		t.Errorf("unexpected totals: %+v", overall)
+	}
+	if overall.AvgFindingsPerSecond != 25 || overall.AvgDBMs != 1750 || overall.AvgQueueWaitMs != 150 {
+		t.Errorf("unexpected averages: %+v", overall)
+	}
+
+	if empty := overallScanPerformance(nil); empty != (ScanPerformanceOverall{}) {
+		t.Errorf("expected zero totals, got %+v", empty)
+	}
+}
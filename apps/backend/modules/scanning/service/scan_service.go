@@ -7,21 +7,37 @@ import (
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
 	"github.com/google/uuid"
 )
 
 // ScanService manages scan execution and state
 type ScanService struct {
-	repo *persistence.PostgresRepository
+	repo   *persistence.PostgresRepository
+	events interfaces.EventPublisher
+	quotas *QuotaService
 }
 
 // NewScanService creates a new scan service
 func NewScanService(repo *persistence.PostgresRepository) *ScanService {
 	return &ScanService{
-		repo: repo,
+		repo:   repo,
+		events: &interfaces.NoOpEventPublisher{},
 	}
 }
 
+// SetEventPublisher enables live scan status events
+func (s *ScanService) SetEventPublisher(events interfaces.EventPublisher) {
+	if events != nil {
+		s.events = events
+	}
+}
+
+// SetQuotas enforces the per-tenant daily scan run quota on triggered scans
+func (s *ScanService) SetQuotas(quotas *QuotaService) {
+	s.quotas = quotas
+}
+
 // TriggerScanRequest represents a scan trigger request
 type TriggerScanRequest struct {
 	Name          string   `json:"name" binding:"required,min=1,max=100"`
@@ -32,6 +48,10 @@ type TriggerScanRequest struct {
 
 // CreateScanRun creates a new scan run entity
 func (s *ScanService) CreateScanRun(ctx context.Context, req *TriggerScanRequest, triggeredBy string) (*entity.ScanRun, error) {
+	if err := s.quotas.AdmitScanRun(ctx); err != nil {
+		return nil, err
+	}
+
 	scanRun := &entity.ScanRun{
 		ID:            uuid.New(),
 		ProfileName:   req.Name,
@@ -47,6 +67,15 @@ func (s *ScanService) CreateScanRun(ctx context.Context, req *TriggerScanRequest
 		},
 	}
 
+	// A scan of one source is linked to its connection; multi-source scans list theirs in metadata
+	if len(req.Sources) == 1 {
+		connectionID, err := s.repo.FindConnectionIDByProfile(ctx, req.Sources[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to find connection: %w", err)
+		}
+		scanRun.ConnectionID = connectionID
+	}
+
 	if err := s.repo.CreateScanRun(ctx, scanRun); err != nil {
 		return nil, fmt.Errorf("failed to create scan run: %w", err)
 	}
@@ -73,6 +102,12 @@ func (s *ScanService) UpdateScanStatus(ctx context.Context, scanID uuid.UUID, st
 		return fmt.Errorf("failed to update scan run: %w", err)
 	}
 
+	s.events.Publish(ctx, interfaces.EventScanProgress, map[string]interface{}{
+		"scan_id": scanID,
+		"stage":   "status",
+		"status":  status,
+	})
+
 	return nil
 }
 
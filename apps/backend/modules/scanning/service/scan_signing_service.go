@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/scansign"
+	"github.com/google/uuid"
+)
+
+// scanSigningCacheTTL bounds how long a changed strict mode takes to reach
+// ingestion on every replica
+const scanSigningCacheTTL = time.Minute
+
+// maxSigningKeyIDLength matches scanner_signing_keys.key_id
+const maxSigningKeyIDLength = 100
+
+var (
+	// ErrSignatureRequired is returned for unsigned payloads of a tenant in strict mode
+	ErrSignatureRequired = errors.New("scan payloads must be signed")
+	// ErrInvalidSignature is returned for a payload whose signature does not verify
+	ErrInvalidSignature = errors.New("invalid scan payload signature")
+)
+
+// SignedFindingStream is a VerifiedFindingStream read from a payload that may be signed
+type SignedFindingStream interface {
+	VerifiedFindingStream
+	// Signer verifies the payload once Next has returned io.EOF. It returns the
+	// signing key, or nil for an unsigned payload.
+	Signer(ctx context.Context) (*entity.ScannerSigningKey, error)
+}
+
+// streamSigner returns the verified signer of stream, or nil when it is unsigned
+func streamSigner(ctx context.Context, stream VerifiedFindingStream) (*entity.ScannerSigningKey, error) {
+	signed, ok := stream.(SignedFindingStream)
+	if !ok {
+		return nil, nil
+	}
+	return signed.Signer(ctx)
+}
+
+// ScanSigningPolicyView is the scan signing policy that applies to a tenant
+type ScanSigningPolicyView struct {
+	RequireSignature bool       `json:"require_signature"`
+	ActiveKeys       int        `json:"active_keys"`
+	UpdatedBy        string     `json:"updated_by,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// RegisterSigningKeyInput is the payload for registering a scanner's public key
+type RegisterSigningKeyInput struct {
+	KeyID       string `json:"key_id" binding:"required"`
+	ScannerName string `json:"scanner_name" binding:"required"`
+	// PublicKey is a base64 raw Ed25519 key or a PEM public key
+	PublicKey string `json:"public_key" binding:"required"`
+}
+
+// ScanSigningService manages the keys scanners sign their payloads with and
+// verifies signed payloads at ingestion. Tenants in strict mode have unsigned
+// payloads rejected.
+type ScanSigningService struct {
+	repo        repository.ScanSigningRepository
+	auditLogger interfaces.AuditLogger
+
+	mu      sync.RWMutex
+	tenants map[uuid.UUID]cachedScanSigningPolicy
+}
+
+type cachedScanSigningPolicy struct {
+	requireSignature bool
+	loadedAt         time.Time
+}
+
+// NewScanSigningService creates a scan signing service
+func NewScanSigningService(repo repository.ScanSigningRepository, auditLogger interfaces.AuditLogger) *ScanSigningService {
+	return &ScanSigningService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		tenants:     make(map[uuid.UUID]cachedScanSigningPolicy),
+	}
+}
+
+// RegisterKey registers a scanner's Ed25519 public key under the key ID the
+// scanner will send in the X-Scan-Key-Id header
+func (s *ScanSigningService) RegisterKey(ctx context.Context, input RegisterSigningKeyInput, createdBy string) (*entity.ScannerSigningKey, error) {
+	keyID := strings.TrimSpace(input.KeyID)
+	if keyID == "" || len(keyID) > maxSigningKeyIDLength || strings.ContainsAny(keyID, " \t\r\n") {
+		return nil, fmt.Errorf("invalid key_id: must be 1 to %d characters without spaces", maxSigningKeyIDLength)
+	}
+	scannerName := strings.TrimSpace(input.ScannerName)
+	if scannerName == "" {
+		return nil, fmt.Errorf("scanner_name must not be empty")
+	}
+	publicKey, err := scansign.ParsePublicKey(input.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &entity.ScannerSigningKey{
+		ID:          uuid.New(),
+		KeyID:       keyID,
+		ScannerName: scannerName,
+		Algorithm:   "ed25519",
+		PublicKey:   publicKey,
+		Fingerprint: scansign.Fingerprint(publicKey),
+		CreatedBy:   createdBy,
+	}
+	if err := s.repo.CreateScannerSigningKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "SCAN_SIGNING_KEY_REGISTERED", key.ID.String(), map[string]interface{}{
+		"key_id":       key.KeyID,
+		"scanner_name": key.ScannerName,
+		"fingerprint":  key.Fingerprint,
+	})
+	return key, nil
+}
+
+// ListKeys returns the tenant's signing keys, active ones first
+func (s *ScanSigningService) ListKeys(ctx context.Context) ([]*entity.ScannerSigningKey, error) {
+	return s.repo.ListScannerSigningKeys(ctx)
+}
+
+// RevokeKey stops a key from verifying payloads. Upload sessions whose chunks it
+// signed can no longer be finalized.
+func (s *ScanSigningService) RevokeKey(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.ScannerSigningKey, error) {
+	key, err := s.repo.RevokeScannerSigningKey(ctx, id, revokedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "SCAN_SIGNING_KEY_REVOKED", key.ID.String(), map[string]interface{}{
+		"key_id":       key.KeyID,
+		"scanner_name": key.ScannerName,
+		"fingerprint":  key.Fingerprint,
+	})
+	return key, nil
+}
+
+// GetPolicy returns the signing policy that applies to the tenant in ctx
+func (s *ScanSigningService) GetPolicy(ctx context.Context) (*ScanSigningPolicyView, error) {
+	policy, err := s.repo.GetTenantScanSigningPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := s.repo.ListScannerSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &ScanSigningPolicyView{ActiveKeys: countActiveKeys(keys)}
+	if policy != nil {
+		view.RequireSignature = policy.RequireSignature
+		view.UpdatedBy = policy.UpdatedBy
+		view.UpdatedAt = &policy.UpdatedAt
+	}
+	return view, nil
+}
+
+// SetRequireSignature turns strict mode on or off for the tenant in ctx. Strict
+// mode needs an active key, or no scanner could ingest at all.
+func (s *ScanSigningService) SetRequireSignature(ctx context.Context, requireSignature bool, updatedBy string) (*ScanSigningPolicyView, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if requireSignature {
+		keys, err := s.repo.ListScannerSigningKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if countActiveKeys(keys) == 0 {
+			return nil, fmt.Errorf("register a signing key before requiring signatures")
+		}
+	}
+	if err := s.repo.SetTenantScanSigningPolicy(ctx, requireSignature, updatedBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.tenants, tenantID)
+	s.mu.Unlock()
+
+	s.audit(ctx, "SCAN_SIGNING_POLICY_CHANGED", tenantID.String(), map[string]interface{}{
+		"require_signature": requireSignature,
+	})
+	return s.GetPolicy(ctx)
+}
+
+// RequiresSignature reports whether the tenant in ctx is in strict mode. It is
+// cached briefly; an error means the policy could not be loaded and the payload
+// should not be accepted.
+func (s *ScanSigningService) RequiresSignature(ctx context.Context) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	cached, ok := s.tenants[tenantID]
+	s.mu.RUnlock()
+	if !ok || time.Since(cached.loadedAt) >= scanSigningCacheTTL {
+		policy, err := s.repo.GetTenantScanSigningPolicy(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to load scan signing policy: %w", err)
+		}
+		cached = cachedScanSigningPolicy{requireSignature: policy != nil && policy.RequireSignature, loadedAt: time.Now()}
+		s.mu.Lock()
+		s.tenants[tenantID] = cached
+		s.mu.Unlock()
+	}
+	return cached.requireSignature, nil
+}
+
+// Begin starts the check of a payload sent with the given X-Scan-Key-Id and
+// X-Scan-Signature headers. Decode the payload from the returned reader and wrap
+// the decoded stream with PayloadSignature.Stream; the signature is verified once
+// the stream is exhausted. Unsigned payloads of a tenant in strict mode are
+// rejected here, before anything is read.
+func (s *ScanSigningService) Begin(ctx context.Context, keyID, signature string, body io.Reader) (io.Reader, *PayloadSignature, error) {
+	if s == nil {
+		return body, nil, nil
+	}
+	keyID, signature = strings.TrimSpace(keyID), strings.TrimSpace(signature)
+
+	if keyID == "" && signature == "" {
+		required, err := s.RequiresSignature(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if required {
+			s.rejected(ctx, "", "payload is not signed")
+			return nil, nil, ErrSignatureRequired
+		}
+		return body, nil, nil
+	}
+	if keyID == "" || signature == "" {
+		s.rejected(ctx, keyID, "both signature headers are needed")
+		return nil, nil, fmt.Errorf("%w: send both %s and %s", ErrInvalidSignature, scansign.HeaderKeyID, scansign.HeaderSignature)
+	}
+
+	key, err := s.activeKey(ctx, keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	verifier, err := scansign.NewVerifier(key.PublicKey, signature)
+	if err != nil {
+		s.rejected(ctx, keyID, err.Error())
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	payload := &PayloadSignature{service: s, key: key, verifier: verifier}
+	payload.body = io.TeeReader(body, verifier)
+	return payload.body, payload, nil
+}
+
+// activeKey returns the tenant's key named keyID, unless it is unknown or revoked
+func (s *ScanSigningService) activeKey(ctx context.Context, keyID string) (*entity.ScannerSigningKey, error) {
+	key, err := s.repo.GetScannerSigningKeyByKeyID(ctx, keyID)
+	if errors.Is(err, repository.ErrSigningKeyNotFound) {
+		s.rejected(ctx, keyID, "unknown signing key")
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidSignature, keyID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.Revoked() {
+		s.rejected(ctx, keyID, "signing key is revoked")
+		return nil, fmt.Errorf("%w: signing key %q is revoked", ErrInvalidSignature, keyID)
+	}
+	return key, nil
+}
+
+// sessionSigner returns the key an upload session's chunks were signed with, as
+// long as it has not been revoked since
+func (s *ScanSigningService) sessionSigner(ctx context.Context, session *entity.ScanUploadSession) (*entity.ScannerSigningKey, error) {
+	if session.SignerKeyID == nil {
+		return nil, nil
+	}
+	if s == nil {
+		return nil, fmt.Errorf("%w: scan signing is not configured", ErrInvalidSignature)
+	}
+	key, err := s.repo.GetScannerSigningKey(ctx, *session.SignerKeyID)
+	if errors.Is(err, repository.ErrSigningKeyNotFound) {
+		return nil, fmt.Errorf("%w: the session's signing key was deleted", ErrInvalidSignature)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.Revoked() {
+		s.rejected(ctx, key.KeyID, "signing key was revoked after the chunks were uploaded")
+		return nil, fmt.Errorf("%w: signing key %q is revoked", ErrInvalidSignature, key.KeyID)
+	}
+	return key, nil
+}
+
+// rejected records a refused payload in the audit log
+func (s *ScanSigningService) rejected(ctx context.Context, keyID, reason string) {
+	s.audit(ctx, "SCAN_SIGNATURE_REJECTED", keyID, map[string]interface{}{
+		"key_id": keyID,
+		"reason": reason,
+	})
+}
+
+func (s *ScanSigningService) audit(ctx context.Context, action, resourceID string, details map[string]interface{}) {
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, "scanner_signing_key", resourceID, details)
+	}
+}
+
+func countActiveKeys(keys []*entity.ScannerSigningKey) int {
+	active := 0
+	for _, key := range keys {
+		if !key.Revoked() {
+			active++
+		}
+	}
+	return active
+}
+
+// PayloadSignature is the signature of a payload being read
+type PayloadSignature struct {
+	service  *ScanSigningService
+	key      *entity.ScannerSigningKey
+	verifier *scansign.Verifier
+	body     io.Reader
+}
+
+// Verify reads what the decoder left of the body and checks the signature over
+// all of it, returning the signing key
+func (p *PayloadSignature) Verify(ctx context.Context) (*entity.ScannerSigningKey, error) {
+	if _, err := io.Copy(io.Discard, p.body); err != nil {
+		return nil, err
+	}
+	if !p.verifier.Verify() {
+		p.service.rejected(ctx, p.key.KeyID, "signature does not match the payload")
+		return nil, fmt.Errorf("%w: signature does not match the payload for key %q", ErrInvalidSignature, p.key.KeyID)
+	}
+	return p.key, nil
+}
+
+// Stream pairs a stream decoded from the payload with its signature. A nil
+// PayloadSignature, for an unsigned payload, returns stream unchanged.
+func (p *PayloadSignature) Stream(stream VerifiedFindingStream) VerifiedFindingStream {
+	if p == nil {
+		return stream
+	}
+	return &signedFindingStream{VerifiedFindingStream: stream, signature: p}
+}
+
+type signedFindingStream struct {
+	VerifiedFindingStream
+	signature *PayloadSignature
+}
+
+func (s *signedFindingStream) Signer(ctx context.Context) (*entity.ScannerSigningKey, error) {
+	return s.signature.Verify(ctx)
+}
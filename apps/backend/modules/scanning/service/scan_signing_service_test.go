@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/arc-platform/backend/pkg/scansign"
+	"github.com/google/uuid"
+)
+
+type recordingAuditLogger struct {
+	mu      sync.Mutex
+	actions []string
+}
+
+func (l *recordingAuditLogger) Record(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.actions = append(l.actions, action)
+	return nil
+}
+
+func (l *recordingAuditLogger) count(action string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, a := range l.actions {
+		if a == action {
+			n++
+		}
+	}
+	return n
+}
+
+const signedScanBody = `{"scan_id": "scan-edge", "findings": [
+	{"pii_type": "IN_PAN", "value_hash": "h1", "source": {"path": "/data/customers.csv", "data_source": "filesystem", "host": "fs01"}}
+]}`
+
+func TestSignedIngestion(t *testing.T) {
+	repo := memory.NewRepository()
+	audit := &recordingAuditLogger{}
+	signing := NewScanSigningService(repo, audit)
+	ingestion := newMemoryIngestionService(repo)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := signing.RegisterKey(ctx, RegisterSigningKeyInput{
+		KeyID:       "edge-01",
+		ScannerName: "Edge scanner 01",
+		PublicKey:   base64.StdEncoding.EncodeToString(public),
+	}, "admin@example.in")
+	if err != nil {
+		t.Fatalf("RegisterKey: %v", err)
+	}
+	if _, err := signing.RegisterKey(ctx, RegisterSigningKeyInput{KeyID: "edge-01", ScannerName: "dup", PublicKey: base64.StdEncoding.EncodeToString(public)}, "admin@example.in"); err == nil {
+		t.Fatal("expected a duplicate key ID to be refused")
+	}
+
+	ingest := func(keyID, signature, body string) (*VerifiedIngestResult, error) {
+		t.Helper()
+		reader, payload, err := signing.Begin(ctx, keyID, signature, strings.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		return ingestion.IngestSDKVerifiedStream(ctx, payload.Stream(NewVerifiedScanDecoder(reader, 0)))
+	}
+
+	// A valid signature is recorded on the scan run
+	result, err := ingest("edge-01", scansign.Sign(private, []byte(signedScanBody)), signedScanBody)
+	if err != nil {
+		t.Fatalf("signed ingestion: %v", err)
+	}
+	run, err := repo.GetScanRunByID(ctx, result.ScanRunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.SignatureStatus != entity.ScanSignatureVerified || run.SignerKeyID == nil || *run.SignerKeyID != key.ID || run.SignerName != "Edge scanner 01" {
+		t.Errorf("expected the scan run to name its signer, got %+v", run)
+	}
+
+	// A tampered body is rolled back after its findings were read
+	tampered := strings.Replace(signedScanBody, "fs01", "fs02", 1)
+	if _, err := ingest("edge-01", scansign.Sign(private, []byte(signedScanBody)), tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for a tampered body, got %v", err)
+	}
+	if got := len(repo.Findings()); got != 1 {
+		t.Errorf("expected the tampered payload to store nothing, got %d findings", got)
+	}
+
+	// Unknown keys and half-signed requests are refused before reading the body
+	if _, err := ingest("edge-99", scansign.Sign(private, []byte(signedScanBody)), signedScanBody); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for an unknown key, got %v", err)
+	}
+	if _, err := ingest("edge-01", "", signedScanBody); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature without a signature, got %v", err)
+	}
+
+	// Unsigned payloads are accepted until the tenant turns on strict mode
+	result, err = ingest("", "", signedScanBody)
+	if err != nil {
+		t.Fatalf("unsigned ingestion: %v", err)
+	}
+	if run, _ := repo.GetScanRunByID(ctx, result.ScanRunID); run.SignatureStatus != entity.ScanSignatureUnsigned || run.SignerKeyID != nil {
+		t.Errorf("expected an unsigned scan run, got %+v", run)
+	}
+	if _, err := signing.SetRequireSignature(ctx, true, "admin@example.in"); err != nil {
+		t.Fatalf("SetRequireSignature: %v", err)
+	}
+	if _, err := ingest("", "", signedScanBody); !errors.Is(err, ErrSignatureRequired) {
+		t.Errorf("expected ErrSignatureRequired in strict mode, got %v", err)
+	}
+	if _, err := ingest("edge-01", scansign.Sign(private, []byte(signedScanBody)), signedScanBody); err != nil {
+		t.Errorf("signed ingestion in strict mode: %v", err)
+	}
+
+	// A revoked key no longer verifies anything
+	if _, err := signing.RevokeKey(ctx, key.ID, "admin@example.in"); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+	if _, err := ingest("edge-01", scansign.Sign(private, []byte(signedScanBody)), signedScanBody); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a revoked key, got %v", err)
+	}
+	if _, err := signing.sessionSigner(ctx, &entity.ScanUploadSession{SignerKeyID: &key.ID}); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected an upload session signed with a revoked key to be refused, got %v", err)
+	}
+
+	if got := audit.count("SCAN_SIGNATURE_REJECTED"); got != 6 {
+		t.Errorf("expected 6 rejected payloads in the audit log, got %d", got)
+	}
+}
+
+func TestStrictModeNeedsAnActiveKey(t *testing.T) {
+	repo := memory.NewRepository()
+	signing := NewScanSigningService(repo, nil)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	if _, err := signing.SetRequireSignature(ctx, true, "admin@example.in"); err == nil {
+		t.Fatal("expected strict mode without keys to be refused")
+	}
+	if _, err := signing.RegisterKey(ctx, RegisterSigningKeyInput{KeyID: "edge-01", ScannerName: "Edge", PublicKey: "not a key"}, "admin@example.in"); !errors.Is(err, scansign.ErrInvalidPublicKey) {
+		t.Errorf("expected ErrInvalidPublicKey, got %v", err)
+	}
+	if _, err := signing.RegisterKey(ctx, RegisterSigningKeyInput{KeyID: "edge 01", ScannerName: "Edge", PublicKey: "x"}, "admin@example.in"); err == nil {
+		t.Error("expected a key ID with spaces to be refused")
+	}
+
+	// Unsigned payloads pass through untouched
+	reader, payload, err := signing.Begin(ctx, "", "", strings.NewReader("{}"))
+	if err != nil || payload != nil {
+		t.Fatalf("Begin: %v, %v", payload, err)
+	}
+	if body, _ := io.ReadAll(reader); string(body) != "{}" {
+		t.Errorf("unexpected body %q", body)
+	}
+}
@@ -38,10 +38,18 @@ type SourceLocation struct {
 }
 
 // SDKAdapter maps SDK findings to existing entity structures
-type SDKAdapter struct{}
+type SDKAdapter struct {
+	dpdpaMapping *entity.ComplianceMapping
+}
 
+// NewSDKAdapter creates an adapter that assigns the built-in DPDPA categories
 func NewSDKAdapter() *SDKAdapter {
-	return &SDKAdapter{}
+	return NewSDKAdapterWithMapping(entity.DefaultComplianceMapping(entity.ComplianceFrameworkDPDPA))
+}
+
+// NewSDKAdapterWithMapping creates an adapter that assigns DPDPA categories from a tenant's mapping
+func NewSDKAdapterWithMapping(dpdpaMapping *entity.ComplianceMapping) *SDKAdapter {
+	return &SDKAdapter{dpdpaMapping: dpdpaMapping}
 }
 
 // MapToAsset creates an Asset entity from SDK finding
@@ -112,7 +120,7 @@ func (a *SDKAdapter) MapToFinding(vf *VerifiedFinding, scanRunID, assetID uuid.U
 // Semantic lineage service relies on SubCategory to create PII_Category nodes in Neo4j graph
 func (a *SDKAdapter) MapToClassification(vf *VerifiedFinding, findingID uuid.UUID) *entity.Classification {
 	classificationType := mapPIITypeToClassification(vf.PIIType)
-	dpdpaCategory := getDPDPACategory(vf.PIIType)
+	dpdpaCategory := a.dpdpaMapping.ForPIIType(vf.PIIType)
 
 	// Simplified scoring: SDK already validated
 	finalScore := 0.6*vf.MLConfidence + 0.25*calculateContextScore(vf.ContextKeywords) + 0.15*1.0
@@ -124,8 +132,8 @@ func (a *SDKAdapter) MapToClassification(vf *VerifiedFinding, findingID uuid.UUI
 		SubCategory:        vf.PIIType, // CRITICAL: Must be PII type for lineage (IN_AADHAAR, CREDIT_CARD, etc.)
 		ConfidenceScore:    finalScore,
 		Justification:      generateJustification(vf),
-		DPDPACategory:      dpdpaCategory,
-		RequiresConsent:    requiresConsent(vf.PIIType),
+		DPDPACategory:      dpdpaCategory.Category,
+		RequiresConsent:    dpdpaCategory.RequiresConsent,
 		RetentionPeriod:    getRetentionPeriod(vf.PIIType),
 		SignalBreakdown: map[string]interface{}{
 			"rule_signal": map[string]interface{}{
@@ -149,6 +157,13 @@ func (a *SDKAdapter) MapToClassification(vf *VerifiedFinding, findingID uuid.UUI
 				"weight":         0.0,
 				"weighted_score": 0.0,
 			},
+			"validation_signal": map[string]interface{}{
+				"confidence":     1.0,
+				"weight":         0.15,
+				"weighted_score": 0.15,
+				"explanation":    "Passed " + joinStrings(vf.ValidatorsPassed) + " (" + vf.ValidationMethod + ")",
+			},
+			"category_mapping": categoryMappingSignal(a.dpdpaMapping),
 		},
 		EngineVersion: "sdk-v" + vf.SDKVersion,
 		RuleScore:     floatPtr(0.0),
@@ -211,27 +226,6 @@ func mapPIITypeToClassification(piiType string) string {
 	return "Personal Data"
 }
 
-func getDPDPACategory(piiType string) string {
-	// DPDPA 2023 categories for India PIIs only
-	mapping := map[string]string{
-		"IN_AADHAAR":         "Sensitive Personal Data",
-		"IN_PAN":             "Financial Identifier",
-		"IN_PASSPORT":        "Government Identifier",
-		"CREDIT_CARD":        "Financial Data",
-		"IN_UPI":             "Financial Identifier",
-		"IN_IFSC":            "Financial Identifier",
-		"IN_BANK_ACCOUNT":    "Financial Data",
-		"IN_PHONE":           "Contact Information",
-		"EMAIL_ADDRESS":      "Contact Information",
-		"IN_VOTER_ID":        "Government Identifier",
-		"IN_DRIVING_LICENSE": "Government Identifier",
-	}
-	if cat, ok := mapping[piiType]; ok {
-		return cat
-	}
-	return "General Personal Data"
-}
-
 func requiresConsent(piiType string) bool {
 	// India DPDPA 2023: Sensitive data requiring explicit consent
 	sensitiveTypes := []string{"IN_AADHAAR", "IN_PAN", "IN_PASSPORT", "CREDIT_CARD", "IN_DRIVING_LICENSE"}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ErrNoShadowResults is returned by Compare when no candidate version has stored results
+var ErrNoShadowResults = errors.New("no shadow classification results recorded")
+
+// ShadowSample is a primary classification waiting to be repeated by the candidate engine
+type ShadowSample struct {
+	ScanRunID uuid.UUID
+	FindingID *uuid.UUID // Nil when the primary engine discarded the finding
+	Input     MultiSignalInput
+	Primary   *MultiSignalDecision
+}
+
+// shadowJob carries a sample with the context, and so the tenant, it was ingested under
+type shadowJob struct {
+	ctx    context.Context
+	sample ShadowSample
+}
+
+// ShadowTypeComparison is the divergence of one PII type between the two engines
+type ShadowTypeComparison struct {
+	entity.ShadowDivergenceCounts
+	DivergenceRate float64 `json:"divergence_rate"`
+}
+
+// ShadowComparison reports how a candidate version's outcomes differ from the primary engine's
+type ShadowComparison struct {
+	PrimaryVersion string                 `json:"primary_version"`
+	ShadowVersion  string                 `json:"shadow_version"`
+	Active         bool                   `json:"active"` // The candidate is classifying incoming findings on this replica
+	Since          time.Time              `json:"since"`
+	Total          int                    `json:"total"`
+	Diverged       int                    `json:"diverged"`
+	DivergenceRate float64                `json:"divergence_rate"`
+	Dropped        int64                  `json:"dropped"` // Samples not compared because the queue was full, since startup
+	ByPIIType      []ShadowTypeComparison `json:"by_pii_type"`
+}
+
+// ShadowClassificationService repeats the classification of incoming findings with
+// a candidate engine version and stores both outcomes for comparison. The candidate
+// runs off the ingestion path and never changes what is stored as a finding.
+type ShadowClassificationService struct {
+	repo       repository.ShadowClassificationRepository
+	primary    *ClassificationService
+	candidate  *ClassificationService // Nil when shadow mode is disabled
+	threshold  float64
+	sampleRate float64
+	workers    int
+	jobs       chan shadowJob
+	dropped    atomic.Int64
+}
+
+// NewShadowClassificationService creates a shadow classification service. With no
+// candidate version configured it only serves comparisons of stored results.
+func NewShadowClassificationService(repo repository.ShadowClassificationRepository, primary *ClassificationService, cfg config.ShadowClassificationConfig) *ShadowClassificationService {
+	s := &ShadowClassificationService{
+		repo:       repo,
+		primary:    primary,
+		threshold:  cfg.Threshold,
+		sampleRate: cfg.SampleRate,
+		workers:    cfg.Workers,
+	}
+	if s.workers < 1 {
+		s.workers = 1
+	}
+	queueSize := cfg.QueueSize
+	if queueSize < 1 {
+		queueSize = 1000
+	}
+
+	if cfg.Version != "" && cfg.Version != primary.engineVersion {
+		s.candidate = primary.withEngine(cfg.Version, cfg.Weights)
+		s.jobs = make(chan shadowJob, queueSize)
+	} else if cfg.Version != "" {
+		log.Printf("WARN: CLASSIFIER_SHADOW_VERSION matches CLASSIFIER_VERSION (%s); shadow classification disabled", cfg.Version)
+	}
+	return s
+}
+
+// Enabled reports whether a candidate engine classifies incoming findings
+func (s *ShadowClassificationService) Enabled() bool {
+	return s != nil && s.candidate != nil
+}
+
+// Submit queues samples for the candidate engine. Samples beyond the configured
+// rate are skipped and, when the queue is full, dropped rather than slowing ingestion.
+func (s *ShadowClassificationService) Submit(ctx context.Context, samples []ShadowSample) {
+	if !s.Enabled() {
+		return
+	}
+	// Workers outlive the ingestion request but keep its tenant
+	jobCtx := context.WithoutCancel(ctx)
+	for _, sample := range samples {
+		if s.sampleRate < 1 && rand.Float64() >= s.sampleRate {
+			continue
+		}
+		select {
+		case s.jobs <- shadowJob{ctx: jobCtx, sample: sample}:
+		default:
+			if s.dropped.Add(1)%100 == 1 {
+				log.Printf("WARN: Shadow classification queue full; %d samples dropped so far", s.dropped.Load())
+			}
+		}
+	}
+}
+
+// StartWorkers classifies queued samples with the candidate engine until ctx is cancelled
+func (s *ShadowClassificationService) StartWorkers(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+	log.Printf("🔬 Shadow classification enabled: %s alongside %s (%d workers)", s.candidate.engineVersion, s.primary.engineVersion, s.workers)
+	for i := 0; i < s.workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.jobs:
+					if err := s.compare(job.ctx, job.sample); err != nil {
+						log.Printf("WARN: Shadow classification failed for %s: %v", job.sample.Input.PatternName, err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// compare classifies one sample with the candidate engine and stores both outcomes
+func (s *ShadowClassificationService) compare(ctx context.Context, sample ShadowSample) error {
+	decision, err := s.candidate.ClassifyMultiSignal(ctx, sample.Input)
+	if err != nil {
+		return err
+	}
+
+	result := &entity.ShadowClassification{
+		ScanRunID:   sample.ScanRunID,
+		FindingID:   sample.FindingID,
+		PatternName: sample.Input.PatternName,
+		Primary:     classificationOutcome(sample.Primary, IngestionConfidenceThreshold),
+		Shadow:      classificationOutcome(decision, s.threshold),
+	}
+	result.Divergences = outcomeDivergences(result.Primary, result.Shadow)
+	return s.repo.RecordShadowClassification(ctx, result)
+}
+
+// Compare reports the divergence of a candidate version's results since the given
+// time. An empty version selects the active candidate, or else the latest stored one.
+func (s *ShadowClassificationService) Compare(ctx context.Context, version string, since time.Time) (*ShadowComparison, error) {
+	if version == "" && s.Enabled() {
+		version = s.candidate.engineVersion
+	}
+	if version == "" {
+		versions, err := s.repo.ListShadowVersions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return nil, ErrNoShadowResults
+		}
+		version = versions[0]
+	}
+
+	counts, err := s.repo.GetShadowDivergenceCounts(ctx, version, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ShadowComparison{
+		PrimaryVersion: s.primary.engineVersion,
+		ShadowVersion:  version,
+		Active:         s.Enabled() && s.candidate.engineVersion == version,
+		Since:          since,
+		Dropped:        s.dropped.Load(),
+		ByPIIType:      make([]ShadowTypeComparison, 0, len(counts)),
+	}
+	for _, c := range counts {
+		report.Total += c.Total
+		report.Diverged += c.Diverged
+		report.ByPIIType = append(report.ByPIIType, ShadowTypeComparison{
+			ShadowDivergenceCounts: c,
+			DivergenceRate:         divergenceRate(c.Diverged, c.Total),
+		})
+	}
+	report.DivergenceRate = divergenceRate(report.Diverged, report.Total)
+	return report, nil
+}
+
+// ListVersions returns the candidate versions with stored results, newest first
+func (s *ShadowClassificationService) ListVersions(ctx context.Context) ([]string, error) {
+	return s.repo.ListShadowVersions(ctx)
+}
+
+// keptAtIngestion reports whether a decision passes the ingestion filter at threshold
+func keptAtIngestion(decision *MultiSignalDecision, threshold float64) bool {
+	return decision.Classification != "Non-PII" && decision.FinalScore >= threshold
+}
+
+func classificationOutcome(decision *MultiSignalDecision, threshold float64) entity.ClassificationOutcome {
+	return entity.ClassificationOutcome{
+		Version:         decision.EngineVersion,
+		Classification:  decision.Classification,
+		SubCategory:     decision.SubCategory,
+		ConfidenceLevel: decision.ConfidenceLevel,
+		Score:           decision.FinalScore,
+		Kept:            keptAtIngestion(decision, threshold),
+	}
+}
+
+// outcomeDivergences lists the outcome fields on which the two engines disagree
+func outcomeDivergences(primary, shadow entity.ClassificationOutcome) []string {
+	divergences := []string{}
+	if primary.Classification != shadow.Classification {
+		divergences = append(divergences, entity.DivergenceClassification)
+	}
+	if primary.SubCategory != shadow.SubCategory {
+		divergences = append(divergences, entity.DivergenceSubCategory)
+	}
+	if primary.ConfidenceLevel != shadow.ConfidenceLevel {
+		divergences = append(divergences, entity.DivergenceConfidenceLevel)
+	}
+	if primary.Kept != shadow.Kept {
+		divergences = append(divergences, entity.DivergenceKept)
+	}
+	return divergences
+}
+
+func divergenceRate(diverged, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(diverged)/float64(total)*10000) / 10000
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+)
+
+// drainShadowQueue compares every queued sample in place of the workers
+func drainShadowQueue(t *testing.T, s *ShadowClassificationService) {
+	t.Helper()
+	for len(s.jobs) > 0 {
+		job := <-s.jobs
+		if err := s.compare(job.ctx, job.sample); err != nil {
+			t.Fatalf("compare: %v", err)
+		}
+	}
+}
+
+func TestShadowClassificationDuringIngestion(t *testing.T) {
+	repo := memory.NewRepository()
+	ingestion := newMemoryIngestionService(repo)
+
+	// The candidate keeps nothing, so every finding the primary engine stores diverges
+	shadow := NewShadowClassificationService(repo, ingestion.classifier, config.ShadowClassificationConfig{
+		Version:    "v2.1-candidate",
+		Weights:    ingestion.classifier.config.Classification,
+		Threshold:  1.1,
+		SampleRate: 1.0,
+		QueueSize:  10,
+	})
+	ingestion.SetShadowClassifier(shadow)
+
+	_, err := ingestion.IngestScan(context.Background(), &HawkeyeScanInput{FS: []HawkeyeFinding{
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"asha.rao@example.in"}, SampleText: "asha.rao@example.in"},
+		{FilePath: "/data/users.csv", DataSource: "fs", PatternName: "EMAIL_ADDRESS", Matches: []string{"ravi.k@example.in"}, SampleText: "ravi.k@example.in"},
+		{FilePath: "/data/ids.csv", DataSource: "fs", PatternName: "IN_AADHAAR", Matches: []string{"123456789012"}, SampleText: "123456789012"},
+	}})
+	if err != nil {
+		t.Fatalf("IngestScan: %v", err)
+	}
+
+	// The candidate never changes what is stored
+	if got := len(repo.Findings()); got != 2 {
+		t.Fatalf("expected 2 stored findings, got %d", got)
+	}
+
+	drainShadowQueue(t, shadow)
+	results := repo.ShadowResults()
+	if len(results) != 3 {
+		t.Fatalf("expected 3 shadow results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Shadow.Version != "v2.1-candidate" || r.Primary.Version == r.Shadow.Version {
+			t.Errorf("unexpected versions: primary %q, shadow %q", r.Primary.Version, r.Shadow.Version)
+		}
+		if (r.FindingID != nil) != r.Primary.Kept {
+			t.Errorf("%s: finding ID should be set only for findings the primary engine kept", r.PatternName)
+		}
+	}
+
+	comparison, err := shadow.Compare(context.Background(), "", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Compare: %v", err)
+	}
+	if !comparison.Active || comparison.Total != 3 || comparison.Diverged != 2 {
+		t.Errorf("unexpected comparison: %+v", comparison)
+	}
+	byType := make(map[string]ShadowTypeComparison)
+	for _, c := range comparison.ByPIIType {
+		byType[c.PatternName] = c
+	}
+	if email := byType["EMAIL_ADDRESS"]; email.NewlyDiscarded != 2 || email.DivergenceRate != 1 {
+		t.Errorf("unexpected EMAIL_ADDRESS comparison: %+v", email)
+	}
+	if aadhaar := byType["IN_AADHAAR"]; aadhaar.Total != 1 || aadhaar.Diverged != 0 {
+		t.Errorf("unexpected IN_AADHAAR comparison: %+v", aadhaar)
+	}
+}
+
+func TestShadowClassificationDisabled(t *testing.T) {
+	repo := memory.NewRepository()
+	primary := newMemoryIngestionService(repo).classifier
+
+	// Without a candidate, or with the primary's own version, nothing is queued
+	for _, version := range []string{"", primary.engineVersion} {
+		shadow := NewShadowClassificationService(repo, primary, config.ShadowClassificationConfig{Version: version})
+		if shadow.Enabled() {
+			t.Errorf("version %q: expected shadow classification to be disabled", version)
+		}
+		shadow.Submit(context.Background(), []ShadowSample{{Primary: &MultiSignalDecision{}}})
+	}
+
+	if _, err := NewShadowClassificationService(repo, primary, config.ShadowClassificationConfig{}).Compare(context.Background(), "", time.Time{}); err != ErrNoShadowResults {
+		t.Errorf("expected ErrNoShadowResults, got %v", err)
+	}
+}
+
+func TestOutcomeDivergences(t *testing.T) {
+	primary := entity.ClassificationOutcome{Classification: "Sensitive Personal Data", SubCategory: "Financial", ConfidenceLevel: "HIGH", Kept: true}
+
+	if got := outcomeDivergences(primary, primary); len(got) != 0 {
+		t.Errorf("identical outcomes should not diverge, got %v", got)
+	}
+
+	shadow := primary
+	shadow.ConfidenceLevel = "NEEDS_REVIEW"
+	shadow.Kept = false
+	got := outcomeDivergences(primary, shadow)
+	if len(got) != 2 || got[0] != entity.DivergenceConfidenceLevel || got[1] != entity.DivergenceKept {
+		t.Errorf("unexpected divergences: %v", got)
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/pkg/pathglob"
+	"github.com/google/uuid"
+)
+
+// SuppressionService manages pattern-level suppression rules scoped by path or host
+type SuppressionService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+}
+
+// NewSuppressionService creates a new suppression service
+func NewSuppressionService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger) *SuppressionService {
+	return &SuppressionService{
+		repo:        repo,
+		auditLogger: auditLogger,
+	}
+}
+
+// SuppressionRuleInput is the payload for creating a suppression rule
+type SuppressionRuleInput struct {
+	Name        string     `json:"name" binding:"required"`
+	PatternName string     `json:"pattern_name" binding:"required"`
+	PathGlob    string     `json:"path_glob"`
+	HostGlob    string     `json:"host_glob"`
+	Reason      string     `json:"reason"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateRule validates and stores a suppression rule. It applies from the next ingestion on.
+func (s *SuppressionService) CreateRule(ctx context.Context, input SuppressionRuleInput, createdBy string) (*entity.SuppressionRule, error) {
+	rule := &entity.SuppressionRule{
+		ID:          uuid.New(),
+		Name:        strings.TrimSpace(input.Name),
+		PatternName: strings.ToUpper(strings.TrimSpace(input.PatternName)),
+		PathGlob:    strings.TrimSpace(input.PathGlob),
+		HostGlob:    strings.ToLower(strings.TrimSpace(input.HostGlob)),
+		Reason:      input.Reason,
+		IsActive:    true,
+		ExpiresAt:   input.ExpiresAt,
+		CreatedBy:   createdBy,
+	}
+
+	if err := validateSuppressionRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSuppressionRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, "SUPPRESSION_RULE_CREATED", rule.ID.String(), map[string]interface{}{
+		"name":         rule.Name,
+		"pattern_name": rule.PatternName,
+		"path_glob":    rule.PathGlob,
+		"host_glob":    rule.HostGlob,
+		"reason":       rule.Reason,
+	})
+
+	return rule, nil
+}
+
+// GetRule retrieves a suppression rule
+func (s *SuppressionService) GetRule(ctx context.Context, id uuid.UUID) (*entity.SuppressionRule, error) {
+	return s.repo.GetSuppressionRule(ctx, id)
+}
+
+// ListRules returns all suppression rules with their hit counts
+func (s *SuppressionService) ListRules(ctx context.Context) ([]*entity.SuppressionRule, error) {
+	return s.repo.ListSuppressionRules(ctx)
+}
+
+// SetRuleActive enables or disables a suppression rule
+func (s *SuppressionService) SetRuleActive(ctx context.Context, id uuid.UUID, active bool) (*entity.SuppressionRule, error) {
+	rule, err := s.repo.SetSuppressionRuleActive(ctx, id, active)
+	if err != nil {
+		return nil, err
+	}
+
+	action := "SUPPRESSION_RULE_DISABLED"
+	if active {
+		action = "SUPPRESSION_RULE_ENABLED"
+	}
+	s.record(ctx, action, id.String(), map[string]interface{}{"name": rule.Name})
+
+	return rule, nil
+}
+
+// DeleteRule removes a suppression rule. Findings it suppressed were never stored
+// and reappear on the next scan.
+func (s *SuppressionService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	rule, err := s.repo.GetSuppressionRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteSuppressionRule(ctx, id); err != nil {
+		return err
+	}
+
+	s.record(ctx, "SUPPRESSION_RULE_DELETED", id.String(), map[string]interface{}{
+		"name":         rule.Name,
+		"pattern_name": rule.PatternName,
+		"hit_count":    rule.HitCount,
+	})
+	return nil
+}
+
+func (s *SuppressionService) record(ctx context.Context, action, resourceID string, metadata map[string]interface{}) {
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, action, "suppression_rule", resourceID, metadata)
+	}
+}
+
+// validateSuppressionRule checks the rule is named, scoped and its globs compile
+func validateSuppressionRule(rule *entity.SuppressionRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+	if rule.PatternName == "" {
+		return fmt.Errorf("pattern_name must be set")
+	}
+	// A rule covering every path on every host would silently disable the pattern
+	if rule.PathGlob == "" && rule.HostGlob == "" {
+		return fmt.Errorf("at least one of path_glob or host_glob must be set")
+	}
+	if rule.ExpiresAt != nil && !rule.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+
+	for field, glob := range map[string]string{"path_glob": rule.PathGlob, "host_glob": rule.HostGlob} {
+		if glob == "" {
+			continue
+		}
+		if _, err := pathglob.Compile(glob); err != nil {
+			return fmt.Errorf("%s contains invalid pattern %q: %w", field, glob, err)
+		}
+	}
+	return nil
+}
+
+// compiledSuppressionRule is a rule with its globs compiled for matching
+type compiledSuppressionRule struct {
+	rule *entity.SuppressionRule
+	path *regexp.Regexp // nil matches any path
+	host *regexp.Regexp // nil matches any host
+}
+
+// suppressionSet is the tenant's active rules, compiled once per ingestion
+type suppressionSet struct {
+	byPattern map[string][]compiledSuppressionRule
+}
+
+// compileSuppressionRules compiles rules for matching. Rules whose globs no longer
+// compile are skipped rather than failing the ingestion.
+func compileSuppressionRules(rules []*entity.SuppressionRule) *suppressionSet {
+	set := &suppressionSet{byPattern: make(map[string][]compiledSuppressionRule)}
+
+	for _, rule := range rules {
+		compiled := compiledSuppressionRule{rule: rule}
+		var err error
+		if rule.PathGlob != "" {
+			if compiled.path, err = pathglob.Compile(rule.PathGlob); err != nil {
+				continue
+			}
+		}
+		if rule.HostGlob != "" {
+			if compiled.host, err = pathglob.Compile(strings.ToLower(rule.HostGlob)); err != nil {
+				continue
+			}
+		}
+
+		pattern := strings.ToUpper(rule.PatternName)
+		set.byPattern[pattern] = append(set.byPattern[pattern], compiled)
+	}
+	return set
+}
+
+// match returns the first rule suppressing a finding of the pattern at host and path, or nil
+func (s *suppressionSet) match(patternName, host, path string) *entity.SuppressionRule {
+	rules := s.byPattern[strings.ToUpper(strings.TrimSpace(patternName))]
+	if len(rules) == 0 {
+		return nil
+	}
+
+	path = strings.ReplaceAll(path, "\\", "/")
+	host = strings.ToLower(host)
+	for _, r := range rules {
+		if r.path != nil && !r.path.MatchString(path) {
+			continue
+		}
+		if r.host != nil && !r.host.MatchString(host) {
+			continue
+		}
+		return r.rule
+	}
+	return nil
+}
+
+// suppressionTally counts findings suppressed during one ingestion
+type suppressionTally struct {
+	total  int
+	byRule map[uuid.UUID]int
+}
+
+func newSuppressionTally() *suppressionTally {
+	return &suppressionTally{byRule: make(map[uuid.UUID]int)}
+}
+
+func (t *suppressionTally) add(rule *entity.SuppressionRule) {
+	t.total++
+	t.byRule[rule.ID]++
+}
+
+// annotate records the suppressed counts in the scan run metadata so they are
+// visible alongside the run's totals
+func (t *suppressionTally) annotate(scanRun *entity.ScanRun) {
+	if t.total == 0 {
+		return
+	}
+	if scanRun.Metadata == nil {
+		scanRun.Metadata = make(map[string]interface{})
+	}
+
+	byRule := make(map[string]int, len(t.byRule))
+	for id, count := range t.byRule {
+		byRule[id.String()] = count
+	}
+	scanRun.Metadata["suppressed_findings"] = t.total
+	scanRun.Metadata["suppressed_by_rule"] = byRule
+}
+
+// applySuppressions drops findings matched by a suppression rule, keeping the
+// order of the remaining findings
+func applySuppressions(findings []HawkeyeFinding, set *suppressionSet) ([]HawkeyeFinding, *suppressionTally) {
+	tally := newSuppressionTally()
+	kept := make([]HawkeyeFinding, 0, len(findings))
+
+	for _, f := range findings {
+		if rule := set.match(f.PatternName, f.Host, f.FilePath); rule != nil {
+			tally.add(rule)
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept, tally
+}
+
+// loadSuppressions compiles the tenant's active suppression rules
+func loadSuppressions(ctx context.Context, repo repository.SuppressionRepository) (*suppressionSet, error) {
+	rules, err := repo.ListActiveSuppressionRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load suppression rules: %w", err)
+	}
+	return compileSuppressionRules(rules), nil
+}
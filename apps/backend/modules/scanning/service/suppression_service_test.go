@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func TestApplySuppressions(t *testing.T) {
+	logsOnStaging := &entity.SuppressionRule{ID: uuid.New(), PatternName: "EMAIL_ADDRESS", PathGlob: "/var/log/**", HostGlob: "staging-*"}
+	fixtures := &entity.SuppressionRule{ID: uuid.New(), PatternName: "IN_PAN", PathGlob: "**/fixtures/**"}
+	set := compileSuppressionRules([]*entity.SuppressionRule{logsOnStaging, fixtures})
+
+	findings := []HawkeyeFinding{
+		{PatternName: "EMAIL_ADDRESS", Host: "staging-web1", FilePath: "/var/log/app/server.log"},
+		{PatternName: "EMAIL_ADDRESS", Host: "Staging-Web2", FilePath: "/var/log/auth.log"},
+		{PatternName: "EMAIL_ADDRESS", Host: "prod-web1", FilePath: "/var/log/app/server.log"},
+		{PatternName: "EMAIL_ADDRESS", Host: "staging-web1", FilePath: "/srv/data/users.csv"},
+		{PatternName: "IN_PHONE", Host: "staging-web1", FilePath: "/var/log/app/server.log"},
+		{PatternName: "in_pan", Host: "ci", FilePath: "C:\\repo\\fixtures\\pan.txt"},
+	}
+
+	kept, tally := applySuppressions(findings, set)
+
+	if len(kept) != 3 {
+		t.Fatalf("expected 3 findings to be kept, got %d", len(kept))
+	}
+	if kept[0].Host != "prod-web1" || kept[1].FilePath != "/srv/data/users.csv" || kept[2].PatternName != "IN_PHONE" {
+		t.Errorf("unexpected findings kept: %+v", kept)
+	}
+	if tally.total != 3 || tally.byRule[logsOnStaging.ID] != 2 || tally.byRule[fixtures.ID] != 1 {
+		t.Errorf("unexpected tally: total=%d byRule=%v", tally.total, tally.byRule)
+	}
+
+	scanRun := &entity.ScanRun{}
+	tally.annotate(scanRun)
+	if scanRun.Metadata["suppressed_findings"] != 3 {
+		t.Errorf("expected suppressed count in scan run metadata, got %v", scanRun.Metadata)
+	}
+}
+
+func TestValidateSuppressionRule(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		rule    entity.SuppressionRule
+		wantErr string
+	}{
+		{"valid", entity.SuppressionRule{Name: "logs", PatternName: "EMAIL_ADDRESS", PathGlob: "/var/log/**"}, ""},
+		{"host only", entity.SuppressionRule{Name: "staging", PatternName: "EMAIL_ADDRESS", HostGlob: "staging-*"}, ""},
+		{"unscoped", entity.SuppressionRule{Name: "all", PatternName: "EMAIL_ADDRESS"}, "must be set"},
+		{"no pattern", entity.SuppressionRule{Name: "logs", PathGlob: "/var/log/**"}, "pattern_name"},
+		{"expired", entity.SuppressionRule{Name: "logs", PatternName: "EMAIL_ADDRESS", PathGlob: "/tmp/**", ExpiresAt: &past}, "future"},
+	}
+
+	for _, tt := range tests {
+		err := validateSuppressionRule(&tt.rule)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
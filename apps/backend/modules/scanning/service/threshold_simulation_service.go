@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+)
+
+// maxSimulatedThresholds bounds how many thresholds one simulation compares
+const maxSimulatedThresholds = 10
+
+// ThresholdSimulationService reports how stored findings would fall against
+// hypothetical ingestion confidence thresholds. It only reads data.
+type ThresholdSimulationService struct {
+	repo *persistence.PostgresRepository
+}
+
+// NewThresholdSimulationService creates a new threshold simulation service
+func NewThresholdSimulationService(repo *persistence.PostgresRepository) *ThresholdSimulationService {
+	return &ThresholdSimulationService{repo: repo}
+}
+
+// ThresholdSimulationRequest is the payload of a threshold simulation
+type ThresholdSimulationRequest struct {
+	Thresholds []float64 `json:"thresholds" binding:"required,min=1,max=10,dive,gte=0,lte=1"`
+}
+
+// ThresholdSimulation is the outcome of a simulation over the tenant's stored findings
+type ThresholdSimulation struct {
+	CurrentThreshold float64             `json:"current_threshold"`
+	TotalFindings    int                 `json:"total_findings"`
+	UnscoredFindings int                 `json:"unscored_findings"` // Stored without a confidence score; never counted as included or excluded
+	Scenarios        []ThresholdScenario `json:"scenarios"`
+}
+
+// ThresholdScenario is how scored findings split at one threshold
+type ThresholdScenario struct {
+	Threshold  float64              `json:"threshold"`
+	Included   int                  `json:"included"`
+	Excluded   int                  `json:"excluded"`
+	BySeverity []ThresholdBreakdown `json:"by_severity"`
+	ByPIIType  []ThresholdBreakdown `json:"by_pii_type"`
+	Note       string               `json:"note,omitempty"`
+}
+
+// ThresholdBreakdown is the included/excluded split of one severity or PII type
+type ThresholdBreakdown struct {
+	Key      string `json:"key"`
+	Included int    `json:"included"`
+	Excluded int    `json:"excluded"`
+}
+
+// Simulate evaluates each threshold against the stored confidence scores.
+// Thresholds are compared at 0.001 resolution.
+func (s *ThresholdSimulationService) Simulate(ctx context.Context, thresholds []float64) (*ThresholdSimulation, error) {
+	if len(thresholds) == 0 || len(thresholds) > maxSimulatedThresholds {
+		return nil, fmt.Errorf("thresholds must contain between 1 and %d values", maxSimulatedThresholds)
+	}
+	for _, t := range thresholds {
+		if t < 0 || t > 1 {
+			return nil, fmt.Errorf("invalid threshold %v: thresholds must be between 0 and 1", t)
+		}
+	}
+
+	buckets, err := s.repo.ListConfidenceBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return simulateThresholds(buckets, thresholds), nil
+}
+
+// simulateThresholds splits the buckets at each threshold. Thresholds are rounded
+// to three decimals to match the bucket resolution and de-duplicated.
+func simulateThresholds(buckets []entity.ConfidenceBucket, thresholds []float64) *ThresholdSimulation {
+	result := &ThresholdSimulation{
+		CurrentThreshold: IngestionConfidenceThreshold,
+		Scenarios:        []ThresholdScenario{},
+	}
+	for _, b := range buckets {
+		result.TotalFindings += b.Count
+		if b.Score == nil {
+			result.UnscoredFindings += b.Count
+		}
+	}
+
+	seen := make(map[float64]bool)
+	for _, raw := range thresholds {
+		threshold := math.Round(raw*1000) / 1000
+		if seen[threshold] {
+			continue
+		}
+		seen[threshold] = true
+
+		scenario := ThresholdScenario{Threshold: threshold}
+		bySeverity := make(map[string]*ThresholdBreakdown)
+		byPIIType := make(map[string]*ThresholdBreakdown)
+		for _, b := range buckets {
+			if b.Score == nil {
+				continue
+			}
+			// Bucket scores carry float noise from the database; compare on the same grid
+			included := math.Round(*b.Score*1000) >= math.Round(threshold*1000)
+			tallyThreshold(bySeverity, b.Severity, b.Count, included)
+			tallyThreshold(byPIIType, b.PIIType, b.Count, included)
+			if included {
+				scenario.Included += b.Count
+			} else {
+				scenario.Excluded += b.Count
+			}
+		}
+		scenario.BySeverity = sortedBreakdowns(bySeverity)
+		scenario.ByPIIType = sortedBreakdowns(byPIIType)
+		if threshold < IngestionConfidenceThreshold {
+			scenario.Note = fmt.Sprintf("findings scoring below the current threshold of %.2f were never stored, so lowering it shows no additional inclusions", IngestionConfidenceThreshold)
+		}
+		result.Scenarios = append(result.Scenarios, scenario)
+	}
+
+	sort.Slice(result.Scenarios, func(i, j int) bool {
+		return result.Scenarios[i].Threshold < result.Scenarios[j].Threshold
+	})
+	return result
+}
+
+func tallyThreshold(tally map[string]*ThresholdBreakdown, key string, count int, included bool) {
+	if key == "" {
+		key = "UNKNOWN"
+	}
+	entry, ok := tally[key]
+	if !ok {
+		entry = &ThresholdBreakdown{Key: key}
+		tally[key] = entry
+	}
+	if included {
+		entry.Included += count
+	} else {
+		entry.Excluded += count
+	}
+}
+
+// sortedBreakdowns orders breakdowns by total findings, largest first
+func sortedBreakdowns(tally map[string]*ThresholdBreakdown) []ThresholdBreakdown {
+	result := make([]ThresholdBreakdown, 0, len(tally))
+	for _, entry := range tally {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		ti, tj := result[i].Included+result[i].Excluded, result[j].Included+result[j].Excluded
+		if ti != tj {
+			return ti > tj
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
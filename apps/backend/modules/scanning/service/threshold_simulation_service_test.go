@@ -0,0 +1,46 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestSimulateThresholds(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	buckets := []entity.ConfidenceBucket{
+		{Severity: "HIGH", PIIType: "IN_PAN", Score: score(0.45), Count: 3},
+		{Severity: "HIGH", PIIType: "IN_PAN", Score: score(0.9), Count: 5},
+		{Severity: "LOW", PIIType: "EMAIL_ADDRESS", Score: score(0.599), Count: 4},
+		{Severity: "LOW", PIIType: "EMAIL_ADDRESS", Score: score(0.6), Count: 2},
+		{Severity: "LOW", PIIType: "EMAIL_ADDRESS", Score: nil, Count: 1},
+	}
+
+	sim := simulateThresholds(buckets, []float64{0.6, 0.3, 0.6000001})
+
+	if sim.TotalFindings != 15 || sim.UnscoredFindings != 1 {
+		t.Fatalf("unexpected totals: total=%d unscored=%d", sim.TotalFindings, sim.UnscoredFindings)
+	}
+	if len(sim.Scenarios) != 2 {
+		t.Fatalf("expected duplicate thresholds to collapse into 2 scenarios, got %d", len(sim.Scenarios))
+	}
+
+	low := sim.Scenarios[0]
+	if low.Threshold != 0.3 || low.Included != 14 || low.Excluded != 0 || low.Note == "" {
+		t.Errorf("unexpected scenario below the current threshold: %+v", low)
+	}
+
+	high := sim.Scenarios[1]
+	if high.Threshold != 0.6 || high.Included != 7 || high.Excluded != 7 || high.Note != "" {
+		t.Errorf("unexpected scenario at 0.6: %+v", high)
+	}
+	want := map[string][2]int{"IN_PAN": {5, 3}, "EMAIL_ADDRESS": {2, 4}}
+	for _, b := range high.ByPIIType {
+		if got := [2]int{b.Included, b.Excluded}; got != want[b.Key] {
+			t.Errorf("%s: got included/excluded %v, want %v", b.Key, got, want[b.Key])
+		}
+	}
+	if high.BySeverity[0].Key != "HIGH" || high.BySeverity[0].Included != 5 || high.BySeverity[1].Excluded != 4 {
+		t.Errorf("unexpected severity breakdown: %+v", high.BySeverity)
+	}
+}
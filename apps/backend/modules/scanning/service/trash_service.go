@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// purgeBatchLimit caps the trash batches purged per worker run
+const purgeBatchLimit = 100
+
+// TrashService soft-deletes scan data, restores it within the retention window and
+// purges it permanently once the window has passed
+type TrashService struct {
+	repo        *persistence.PostgresRepository
+	cfg         config.TrashConfig
+	auditLogger interfaces.AuditLogger
+	lineageSync interfaces.LineageSync
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(repo *persistence.PostgresRepository, cfg config.TrashConfig, auditLogger interfaces.AuditLogger) *TrashService {
+	if cfg.RetentionDays < 1 {
+		cfg.RetentionDays = 30
+	}
+	return &TrashService{
+		repo:        repo,
+		cfg:         cfg,
+		auditLogger: auditLogger,
+		lineageSync: &interfaces.NoOpLineageSync{},
+	}
+}
+
+// SetLineageSync removes deleted assets from the lineage graph, and adds them back on restore
+func (s *TrashService) SetLineageSync(lineageSync interfaces.LineageSync) {
+	if lineageSync != nil {
+		s.lineageSync = lineageSync
+	}
+}
+
+// TrashScanData moves all of the tenant's assets, findings and scan runs to the trash
+func (s *TrashService) TrashScanData(ctx context.Context, deletedBy string) (*entity.TrashBatch, error) {
+	batch, err := s.repo.TrashScanData(ctx, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	return s.trashed(ctx, batch), nil
+}
+
+// DeleteAsset moves an asset and its findings to the trash and removes the asset from
+// the lineage graph. Their classifications and review states are hidden with the findings.
+// With force the batch is purged at once instead of after the retention window; findings
+// with remediation records are still retained. Postgres is the source of truth: a lineage
+// failure is reported in the result rather than returned as an error.
+func (s *TrashService) DeleteAsset(ctx context.Context, assetID uuid.UUID, deletedBy string, force bool) (*entity.AssetDeletion, error) {
+	batch, err := s.repo.TrashAsset(ctx, assetID, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	deletion := &entity.AssetDeletion{TrashBatch: s.trashed(ctx, batch)}
+
+	if force {
+		purged, err := s.repo.PurgeTrashBatch(ctx, batch.ID)
+		if err != nil {
+			return nil, fmt.Errorf("asset moved to trash but could not be purged: %w", err)
+		}
+		if purged != nil {
+			log.Printf("🗑️  Purged trash batch %s (%s), %d rows retained", purged.ID, purged.Scope, purged.RetainedCount)
+			s.audit(ctx, "TRASH_BATCH_PURGED", purged, map[string]interface{}{
+				"retained_count": purged.RetainedCount,
+			})
+			deletion.TrashBatch = s.withPurgeAfter(purged)
+			deletion.Purged = true
+		}
+	}
+
+	if err := s.lineageSync.RemoveAssetNode(ctx, assetID); err != nil {
+		log.Printf("⚠️  Lineage cleanup after deleting asset %s failed: %v", assetID, err)
+		deletion.LineageError = err.Error()
+	} else {
+		deletion.LineageRemoved = s.lineageSync.IsAvailable()
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.Record(ctx, "ASSET_DELETED", "asset", assetID.String(), map[string]interface{}{
+			"deleted_by":      deletedBy,
+			"trash_batch_id":  batch.ID.String(),
+			"force":           force,
+			"finding_count":   batch.FindingCount,
+			"lineage_removed": deletion.LineageRemoved,
+		})
+	}
+	return deletion, nil
+}
+
+// TrashScanRun moves a scan run and its findings to the trash
+func (s *TrashService) TrashScanRun(ctx context.Context, scanRunID uuid.UUID, deletedBy string) (*entity.TrashBatch, error) {
+	batch, err := s.repo.TrashScanRun(ctx, scanRunID, deletedBy)
+	if err != nil {
+		return nil, err
+	}
+	return s.trashed(ctx, batch), nil
+}
+
+// GetBatch retrieves a trash batch
+func (s *TrashService) GetBatch(ctx context.Context, id uuid.UUID) (*entity.TrashBatch, error) {
+	batch, err := s.repo.GetTrashBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withPurgeAfter(batch), nil
+}
+
+// ListBatches lists the tenant's pending trash batches, or all of them with includeClosed
+func (s *TrashService) ListBatches(ctx context.Context, includeClosed bool, limit, offset int) ([]*entity.TrashBatch, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	batches, err := s.repo.ListTrashBatches(ctx, includeClosed, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if batches == nil {
+		batches = []*entity.TrashBatch{}
+	}
+	for _, batch := range batches {
+		s.withPurgeAfter(batch)
+	}
+	return batches, nil
+}
+
+// RestoreBatch brings a trash batch back if its retention window has not passed
+func (s *TrashService) RestoreBatch(ctx context.Context, id uuid.UUID, restoredBy string) (*entity.TrashBatch, error) {
+	batch, err := s.repo.RestoreTrashBatch(ctx, id, restoredBy, s.cfg.RetentionDays)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("♻️  Restored trash batch %s (%s): %d assets, %d findings, %d scan runs",
+		batch.ID, batch.Scope, batch.AssetCount, batch.FindingCount, batch.ScanRunCount)
+
+	// A deleted asset was removed from the lineage graph; put it back
+	if batch.Scope == entity.TrashScopeAsset && batch.ResourceID != nil {
+		if err := s.lineageSync.SyncAssetToNeo4j(ctx, *batch.ResourceID); err != nil {
+			log.Printf("⚠️  Lineage sync after restoring asset %s failed: %v", *batch.ResourceID, err)
+		}
+	}
+	s.audit(ctx, "TRASH_BATCH_RESTORED", batch, nil)
+	return batch, nil
+}
+
+// StartPurgeWorker periodically purges trash batches past the retention window for every tenant
+func (s *TrashService) StartPurgeWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes < 1 {
+		intervalMinutes = 60
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🗑️  Starting trash purge worker (interval: %dm, retention: %dd)", intervalMinutes, s.cfg.RetentionDays)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Trash purge worker stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.PurgeExpired(ctx); err != nil {
+				log.Printf("❌ Trash purge failed: %v", err)
+			}
+		}
+	}
+}
+
+// PurgeExpired permanently deletes trash batches older than the retention window and
+// returns how many were purged
+func (s *TrashService) PurgeExpired(ctx context.Context) (int, error) {
+	batches, err := s.repo.ListExpiredTrashBatches(ctx, s.cfg.RetentionDays, purgeBatchLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, expired := range batches {
+		batch, err := s.repo.PurgeTrashBatch(ctx, expired.ID)
+		if err != nil {
+			log.Printf("❌ Failed to purge trash batch %s: %v", expired.ID, err)
+			continue
+		}
+		if batch == nil {
+			continue // Restored or purged by another replica
+		}
+		purged++
+
+		log.Printf("🗑️  Purged trash batch %s (%s), %d rows retained", batch.ID, batch.Scope, batch.RetainedCount)
+		tenantCtx := context.WithValue(ctx, "tenant_id", batch.TenantID)
+		s.audit(tenantCtx, "TRASH_BATCH_PURGED", batch, map[string]interface{}{
+			"retained_count": batch.RetainedCount,
+		})
+	}
+
+	return purged, nil
+}
+
+func (s *TrashService) trashed(ctx context.Context, batch *entity.TrashBatch) *entity.TrashBatch {
+	log.Printf("🗑️  Trashed %s: %d assets, %d findings, %d scan runs (batch %s)",
+		batch.Scope, batch.AssetCount, batch.FindingCount, batch.ScanRunCount, batch.ID)
+	s.audit(ctx, "TRASH_BATCH_CREATED", batch, nil)
+	return s.withPurgeAfter(batch)
+}
+
+// withPurgeAfter sets the end of the restore window on a pending batch
+func (s *TrashService) withPurgeAfter(batch *entity.TrashBatch) *entity.TrashBatch {
+	if batch.IsPending() {
+		purgeAfter := batch.DeletedAt.AddDate(0, 0, s.cfg.RetentionDays)
+		batch.PurgeAfter = &purgeAfter
+	}
+	return batch
+}
+
+func (s *TrashService) audit(ctx context.Context, action string, batch *entity.TrashBatch, extra map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"scope":          batch.Scope,
+		"asset_count":    batch.AssetCount,
+		"finding_count":  batch.FindingCount,
+		"scan_run_count": batch.ScanRunCount,
+	}
+	if batch.ResourceID != nil {
+		metadata["resource_id"] = batch.ResourceID.String()
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	_ = s.auditLogger.Record(ctx, action, "trash_batch", batch.ID.String(), metadata)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestTrashServicePurgeAfter(t *testing.T) {
+	s := NewTrashService(nil, config.TrashConfig{RetentionDays: 14}, nil)
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	pending := s.withPurgeAfter(&entity.TrashBatch{DeletedAt: deletedAt})
+	if pending.PurgeAfter == nil || !pending.PurgeAfter.Equal(deletedAt.AddDate(0, 0, 14)) {
+		t.Errorf("expected a pending batch to be purged 14 days after deletion, got %v", pending.PurgeAfter)
+	}
+
+	restoredAt := deletedAt.Add(time.Hour)
+	restored := s.withPurgeAfter(&entity.TrashBatch{DeletedAt: deletedAt, RestoredAt: &restoredAt})
+	if restored.PurgeAfter != nil {
+		t.Errorf("expected no purge date on a restored batch, got %v", restored.PurgeAfter)
+	}
+}
+
+func TestNewTrashServiceDefaultsRetention(t *testing.T) {
+	s := NewTrashService(nil, config.TrashConfig{}, nil)
+	if s.cfg.RetentionDays != 30 {
+		t.Errorf("expected a 30 day default retention, got %d", s.cfg.RetentionDays)
+	}
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// staleFinalizeAfter is how long a finalizing session may go without progress
+// before another finalize call may take it over, e.g. after a backend restart
+const staleFinalizeAfter = 30 * time.Minute
+
+// UploadSessionService manages resumable chunked scan uploads. Chunks are kept in
+// Postgres, so an upload survives backend restarts and can be resumed by
+// re-sending only the missing chunks.
+type UploadSessionService struct {
+	repo      *persistence.PostgresRepository
+	ingestion *IngestionService
+	signing   *ScanSigningService // Nil accepts unsigned chunks only
+	ttl       time.Duration
+}
+
+// NewUploadSessionService creates a new upload session service
+func NewUploadSessionService(repo *persistence.PostgresRepository, ingestion *IngestionService, ttl time.Duration) *UploadSessionService {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &UploadSessionService{
+		repo:      repo,
+		ingestion: ingestion,
+		ttl:       ttl,
+	}
+}
+
+// SetScanSigning sets the service checking chunk signatures and strict mode
+func (s *UploadSessionService) SetScanSigning(signing *ScanSigningService) {
+	s.signing = signing
+}
+
+// CreateSessionInput is the payload for opening an upload session
+type CreateSessionInput struct {
+	ScanID         string `json:"scan_id"`
+	ExpectedChunks *int   `json:"expected_chunks,omitempty"`
+}
+
+// CreateSession opens an upload session
+func (s *UploadSessionService) CreateSession(ctx context.Context, input CreateSessionInput, createdBy string) (*entity.ScanUploadSession, error) {
+	if input.ExpectedChunks != nil && *input.ExpectedChunks <= 0 {
+		return nil, fmt.Errorf("expected_chunks must be positive")
+	}
+
+	session := &entity.ScanUploadSession{
+		ID:             uuid.New(),
+		ScanID:         input.ScanID,
+		Status:         entity.UploadSessionOpen,
+		ExpectedChunks: input.ExpectedChunks,
+		ReceivedChunks: []int{},
+		CreatedBy:      createdBy,
+		ExpiresAt:      time.Now().Add(s.ttl),
+	}
+
+	if err := s.repo.CreateScanUploadSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSession returns a session with the chunks received so far, so a scanner can
+// work out which chunks to resend
+func (s *UploadSessionService) GetSession(ctx context.Context, id uuid.UUID) (*entity.ScanUploadSession, error) {
+	return s.repo.GetScanUploadSession(ctx, id)
+}
+
+// UploadChunk stores the findings of one numbered chunk. Chunks of a session are
+// all signed with the same key, or all unsigned.
+func (s *UploadSessionService) UploadChunk(ctx context.Context, sessionID uuid.UUID, chunkNumber int, stream VerifiedFindingStream) (*entity.ScanUploadChunk, error) {
+	session, err := s.repo.GetScanUploadSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSessionWritable(session); err != nil {
+		return nil, err
+	}
+	if chunkNumber <= 0 {
+		return nil, fmt.Errorf("invalid chunk number %d: must be positive", chunkNumber)
+	}
+	if session.ExpectedChunks != nil && chunkNumber > *session.ExpectedChunks {
+		return nil, fmt.Errorf("invalid chunk number %d: session expects %d chunks", chunkNumber, *session.ExpectedChunks)
+	}
+
+	findings := []VerifiedFinding{}
+	for {
+		vf, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, *vf)
+	}
+
+	signer, err := streamSigner(ctx, stream)
+	if err != nil {
+		return nil, err
+	}
+	var signerKeyID *uuid.UUID
+	if signer != nil {
+		signerKeyID = &signer.ID
+	}
+	if err := s.repo.RecordScanUploadChunkSigner(ctx, sessionID, signerKeyID); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(findings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chunk: %w", err)
+	}
+	sum := sha256.Sum256(payload)
+
+	chunk := &entity.ScanUploadChunk{
+		SessionID:    sessionID,
+		ChunkNumber:  chunkNumber,
+		FindingCount: len(findings),
+		Checksum:     hex.EncodeToString(sum[:]),
+	}
+	if err := s.repo.SaveScanUploadChunk(ctx, chunk, payload); err != nil {
+		return nil, err
+	}
+	return chunk, nil
+}
+
+// Finalize claims the session and ingests its chunks, in chunk order, as a single
+// scan in the background. The returned session is in the finalizing state; poll
+// GetSession for the outcome. A failed ingestion reopens the session with the error.
+func (s *UploadSessionService) Finalize(ctx context.Context, id uuid.UUID) (*entity.ScanUploadSession, error) {
+	session, err := s.repo.GetScanUploadSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status == entity.UploadSessionCompleted {
+		return nil, fmt.Errorf("upload session is already completed")
+	}
+	if !session.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("upload session expired")
+	}
+
+	// Refused here rather than failing the ingestion after the request returns
+	if err := s.ingestion.quotas.AdmitScanRun(ctx); err != nil {
+		return nil, err
+	}
+	if session.SignerKeyID == nil {
+		// Strict mode may have been turned on after unsigned chunks were stored
+		required, err := s.signing.RequiresSignature(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			return nil, ErrSignatureRequired
+		}
+	}
+
+	session, err = s.repo.ClaimScanUploadSession(ctx, id, staleFinalizeAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chunks can't change once claimed, so this check is final
+	if err := checkChunksComplete(session.ReceivedChunks, session.ExpectedChunks); err != nil {
+		if reopenErr := s.repo.ReopenScanUploadSession(ctx, id, err.Error()); reopenErr != nil {
+			log.Printf("WARNING: %v", reopenErr)
+		}
+		return nil, err
+	}
+
+	// Ingestion outlives the finalize request but keeps its tenant
+	go s.ingest(context.WithoutCancel(ctx), session)
+
+	return session, nil
+}
+
+// ingest runs the ingestion of a claimed session
+func (s *UploadSessionService) ingest(ctx context.Context, session *entity.ScanUploadSession) {
+	stream := &uploadChunkStream{ctx: ctx, repo: s.repo, signing: s.signing, session: session}
+
+	result, err := s.ingestion.IngestSDKVerifiedStream(ctx, stream)
+	if err != nil {
+		log.Printf("ERROR: Ingestion of upload session %s failed: %v", session.ID, err)
+		if reopenErr := s.repo.ReopenScanUploadSession(ctx, session.ID, err.Error()); reopenErr != nil {
+			log.Printf("WARNING: %v", reopenErr)
+		}
+		return
+	}
+
+	if err := s.repo.CompleteScanUploadSession(ctx, session.ID, result.ScanRunID); err != nil {
+		log.Printf("ERROR: Upload session %s ingested as scan run %s but could not be completed: %v",
+			session.ID, result.ScanRunID, err)
+		return
+	}
+	log.Printf("📦 Upload session %s ingested: %d findings from %d chunks (scan run %s)",
+		session.ID, result.Received, len(session.ReceivedChunks), result.ScanRunID)
+}
+
+// StartExpiryWorker periodically deletes unfinished sessions past their expiry
+func (s *UploadSessionService) StartExpiryWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.repo.DeleteExpiredScanUploadSessions(ctx)
+			if err != nil {
+				log.Printf("WARNING: %v", err)
+			} else if n > 0 {
+				log.Printf("🧹 Deleted %d expired upload sessions", n)
+			}
+		}
+	}
+}
+
+// checkSessionWritable reports why a session can't take chunks, if it can't
+func checkSessionWritable(session *entity.ScanUploadSession) error {
+	if session.Status != entity.UploadSessionOpen {
+		return fmt.Errorf("upload session is already %s", session.Status)
+	}
+	if !session.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("upload session expired")
+	}
+	return nil
+}
+
+// checkChunksComplete requires chunks 1..N without gaps, where N is the expected
+// chunk count when one was declared
+func checkChunksComplete(received []int, expected *int) error {
+	if len(received) == 0 {
+		return fmt.Errorf("upload session has no chunks")
+	}
+
+	last := received[len(received)-1]
+	if expected != nil {
+		last = *expected
+	}
+
+	have := make(map[int]bool, len(received))
+	for _, n := range received {
+		have[n] = true
+	}
+	var missing []int
+	for n := 1; n <= last; n++ {
+		if !have[n] {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("upload session is missing chunks %v", missing)
+	}
+	return nil
+}
+
+// uploadChunkStream yields the findings of a session's chunks in chunk order,
+// loading one chunk at a time. Each chunk's signature was verified on upload, so
+// the session's signer only has to still be trusted.
+type uploadChunkStream struct {
+	ctx     context.Context
+	repo    *persistence.PostgresRepository
+	signing *ScanSigningService
+	session *entity.ScanUploadSession
+
+	chunk    int // Index into session.ReceivedChunks of the next chunk to load
+	findings []VerifiedFinding
+	next     int
+}
+
+func (s *uploadChunkStream) Next() (*VerifiedFinding, error) {
+	for s.next >= len(s.findings) {
+		if s.chunk >= len(s.session.ReceivedChunks) {
+			return nil, io.EOF
+		}
+
+		number := s.session.ReceivedChunks[s.chunk]
+		raw, err := s.repo.GetScanUploadChunkFindings(s.ctx, s.session.ID, number)
+		if err != nil {
+			return nil, err
+		}
+		s.findings = nil
+		if err := json.Unmarshal(raw, &s.findings); err != nil {
+			return nil, fmt.Errorf("failed to decode chunk %d: %w", number, err)
+		}
+		s.chunk++
+		s.next = 0
+	}
+
+	s.next++
+	return &s.findings[s.next-1], nil
+}
+
+func (s *uploadChunkStream) ScanID() string {
+	return s.session.ScanID
+}
+
+// SchemaVersion is always current: chunks are upgraded as they are uploaded
+func (s *uploadChunkStream) SchemaVersion() int {
+	return CurrentSchemaVersion
+}
+
+func (s *uploadChunkStream) Signer(ctx context.Context) (*entity.ScannerSigningKey, error) {
+	return s.signing.sessionSigner(ctx, s.session)
+}
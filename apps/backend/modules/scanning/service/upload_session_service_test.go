@@ -0,0 +1,52 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestCheckChunksComplete(t *testing.T) {
+	three := 3
+
+	tests := []struct {
+		name     string
+		received []int
+		expected *int
+		wantErr  string
+	}{
+		{"contiguous", []int{1, 2, 3}, nil, ""},
+		{"contiguous with expected count", []int{1, 2, 3}, &three, ""},
+		{"gap", []int{1, 3}, nil, "missing chunks [2]"},
+		{"first chunk missing", []int{2}, nil, "missing chunks [1]"},
+		{"trailing chunks missing", []int{1}, &three, "missing chunks [2 3]"},
+		{"nothing uploaded", []int{}, nil, "no chunks"},
+	}
+
+	for _, tt := range tests {
+		err := checkChunksComplete(tt.received, tt.expected)
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestCheckSessionWritable(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	if err := checkSessionWritable(&entity.ScanUploadSession{Status: entity.UploadSessionOpen, ExpiresAt: future}); err != nil {
+		t.Errorf("expected an open session to accept chunks, got %v", err)
+	}
+	if err := checkSessionWritable(&entity.ScanUploadSession{Status: entity.UploadSessionFinalizing, ExpiresAt: future}); err == nil {
+		t.Error("expected a finalizing session to reject chunks")
+	}
+	if err := checkSessionWritable(&entity.ScanUploadSession{Status: entity.UploadSessionOpen, ExpiresAt: past}); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected an expired session to reject chunks, got %v", err)
+	}
+}
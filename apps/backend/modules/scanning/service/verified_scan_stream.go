@@ -0,0 +1,229 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// VerifiedFindingStream yields SDK-verified findings one at a time
+type VerifiedFindingStream interface {
+	// Next returns the next finding, or io.EOF once the payload is exhausted
+	Next() (*VerifiedFinding, error)
+	// ScanID returns the payload's scan_id. It is only final once Next has returned io.EOF.
+	ScanID() string
+	// SchemaVersion returns the schema version the payload was sent in
+	SchemaVersion() int
+}
+
+// PayloadLimitError reports a verified scan payload exceeding an ingestion limit
+type PayloadLimitError struct {
+	Limit string // "max_findings" or "max_payload_bytes"
+	Max   int64
+}
+
+func (e *PayloadLimitError) Error() string {
+	return fmt.Sprintf("payload exceeds %s limit of %d", e.Limit, e.Max)
+}
+
+// PayloadDecodeError reports a malformed verified scan payload
+type PayloadDecodeError struct {
+	Err error
+}
+
+func (e *PayloadDecodeError) Error() string {
+	return fmt.Sprintf("invalid scan payload: %v", e.Err)
+}
+
+func (e *PayloadDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// VerifiedScanDecoder streams the findings array of a VerifiedScanInput JSON body,
+// decoding one finding per Next call instead of unmarshalling the whole payload.
+// Findings are upgraded from the payload's schema_version to the current one, so
+// schema_version must come before the findings array.
+type VerifiedScanDecoder struct {
+	dec         *json.Decoder
+	maxFindings int
+
+	scanID     string
+	version    int // 0 until schema_version or the findings array is read
+	count      int
+	started    bool
+	inFindings bool
+	done       bool
+}
+
+// NewVerifiedScanDecoder creates a decoder over r. A positive maxFindings fails the
+// stream with a PayloadLimitError once more findings than that are read.
+func NewVerifiedScanDecoder(r io.Reader, maxFindings int) *VerifiedScanDecoder {
+	return &VerifiedScanDecoder{
+		dec:         json.NewDecoder(r),
+		maxFindings: maxFindings,
+	}
+}
+
+// ScanID returns the scan_id read so far
+func (d *VerifiedScanDecoder) ScanID() string {
+	return d.scanID
+}
+
+// SchemaVersion returns the payload's schema version, version 1 when it has none
+func (d *VerifiedScanDecoder) SchemaVersion() int {
+	if d.version == 0 {
+		return MinSchemaVersion
+	}
+	return d.version
+}
+
+// Next decodes the next finding of the payload
+func (d *VerifiedScanDecoder) Next() (*VerifiedFinding, error) {
+	if d.done {
+		return nil, io.EOF
+	}
+
+	if !d.started {
+		if err := d.expectDelim('{'); err != nil {
+			return nil, err
+		}
+		d.started = true
+	}
+
+	for {
+		if d.inFindings {
+			if d.dec.More() {
+				return d.nextFinding()
+			}
+			if err := d.expectDelim(']'); err != nil {
+				return nil, err
+			}
+			d.inFindings = false
+		}
+
+		if !d.dec.More() {
+			if err := d.expectDelim('}'); err != nil {
+				return nil, err
+			}
+			d.done = true
+			return nil, io.EOF
+		}
+
+		if err := d.readField(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// nextFinding decodes one element of the findings array
+func (d *VerifiedScanDecoder) nextFinding() (*VerifiedFinding, error) {
+	d.count++
+	if d.maxFindings > 0 && d.count > d.maxFindings {
+		return nil, &PayloadLimitError{Limit: "max_findings", Max: int64(d.maxFindings)}
+	}
+
+	var vf VerifiedFinding
+	if err := d.dec.Decode(&vf); err != nil {
+		return nil, &PayloadDecodeError{Err: fmt.Errorf("finding %d: %w", d.count, err)}
+	}
+	upgradeFinding(d.version, &vf)
+	return &vf, nil
+}
+
+// readField consumes one top-level field, entering the findings array when it is reached
+func (d *VerifiedScanDecoder) readField() error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return &PayloadDecodeError{Err: err}
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return &PayloadDecodeError{Err: fmt.Errorf("unexpected token %v", tok)}
+	}
+
+	switch key {
+	case "scan_id":
+		if err := d.dec.Decode(&d.scanID); err != nil {
+			return &PayloadDecodeError{Err: fmt.Errorf("scan_id: %w", err)}
+		}
+	case "schema_version":
+		if d.version != 0 {
+			return &PayloadDecodeError{Err: fmt.Errorf("schema_version must come before findings")}
+		}
+		var version int
+		if err := d.dec.Decode(&version); err != nil {
+			return &PayloadDecodeError{Err: fmt.Errorf("schema_version: %w", err)}
+		}
+		if version == 0 {
+			return &PayloadDecodeError{Err: fmt.Errorf("schema_version must be a positive integer")}
+		}
+		if d.version, err = normalizeSchemaVersion(version); err != nil {
+			return err
+		}
+	case "findings", "verified_findings":
+		if d.version == 0 {
+			d.version = MinSchemaVersion
+		}
+		if key == "verified_findings" && d.version != MinSchemaVersion {
+			return &PayloadDecodeError{Err: fmt.Errorf("verified_findings was renamed findings in schema_version 2")}
+		}
+		tok, err := d.dec.Token()
+		if err != nil {
+			return &PayloadDecodeError{Err: err}
+		}
+		if tok == nil {
+			return nil // "findings": null
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return &PayloadDecodeError{Err: fmt.Errorf("findings must be an array")}
+		}
+		d.inFindings = true
+	default:
+		// Metadata and unknown fields are small; skip them whole
+		var skipped json.RawMessage
+		if err := d.dec.Decode(&skipped); err != nil {
+			return &PayloadDecodeError{Err: fmt.Errorf("%s: %w", key, err)}
+		}
+	}
+	return nil
+}
+
+func (d *VerifiedScanDecoder) expectDelim(want json.Delim) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return &PayloadDecodeError{Err: err}
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return &PayloadDecodeError{Err: fmt.Errorf("expected %q, got %v", want, tok)}
+	}
+	return nil
+}
+
+// sliceFindingStream adapts an already decoded VerifiedScanInput to a stream
+type sliceFindingStream struct {
+	input VerifiedScanInput
+	next  int
+}
+
+func (s *sliceFindingStream) Next() (*VerifiedFinding, error) {
+	version, err := normalizeSchemaVersion(s.input.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if s.next >= len(s.input.Findings) {
+		return nil, io.EOF
+	}
+	s.next++
+	vf := s.input.Findings[s.next-1]
+	upgradeFinding(version, &vf)
+	return &vf, nil
+}
+
+func (s *sliceFindingStream) ScanID() string {
+	return s.input.ScanID
+}
+
+func (s *sliceFindingStream) SchemaVersion() int {
+	version, _ := normalizeSchemaVersion(s.input.SchemaVersion)
+	return version
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func drainStream(t *testing.T, stream VerifiedFindingStream) ([]*VerifiedFinding, error) {
+	t.Helper()
+	var findings []*VerifiedFinding
+	for {
+		vf, err := stream.Next()
+		if err == io.EOF {
+			return findings, nil
+		}
+		if err != nil {
+			return findings, err
+		}
+		findings = append(findings, vf)
+	}
+}
+
+func TestVerifiedScanDecoder(t *testing.T) {
+	body := `{
+		"metadata": {"source": "sdk", "nested": [1, 2, {"a": null}]},
+		"findings": [
+			{"pii_type": "IN_PAN", "source": {"path": "/data/a.csv", "host": "db1"}},
+			{"pii_type": "EMAIL_ADDRESS", "source": {"path": "/data/b.csv", "host": "db1"}}
+		],
+		"scan_id": "scan-42"
+	}`
+
+	decoder := NewVerifiedScanDecoder(strings.NewReader(body), 0)
+	findings, err := drainStream(t, decoder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(findings) != 2 || findings[0].PIIType != "IN_PAN" || findings[1].Source.Path != "/data/b.csv" {
+		t.Errorf("unexpected findings: %+v", findings)
+	}
+	// scan_id after the findings array is still picked up
+	if decoder.ScanID() != "scan-42" {
+		t.Errorf("expected scan_id scan-42, got %q", decoder.ScanID())
+	}
+	if _, err := decoder.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF after the payload, got %v", err)
+	}
+}
+
+func TestVerifiedScanDecoder_MaxFindings(t *testing.T) {
+	body := `{"scan_id": "s", "findings": [{"pii_type": "IN_PAN"}, {"pii_type": "IN_PAN"}, {"pii_type": "IN_PAN"}]}`
+
+	findings, err := drainStream(t, NewVerifiedScanDecoder(strings.NewReader(body), 2))
+
+	var limitErr *PayloadLimitError
+	if !errors.As(err, &limitErr) || limitErr.Limit != "max_findings" || limitErr.Max != 2 {
+		t.Fatalf("expected a max_findings limit error, got %v", err)
+	}
+	if len(findings) != 2 {
+		t.Errorf("expected the limit to trip on the third finding, got %d findings", len(findings))
+	}
+}
+
+func TestVerifiedScanDecoder_Malformed(t *testing.T) {
+	cases := map[string]string{
+		"not an object":      `[{"pii_type": "IN_PAN"}]`,
+		"findings not array": `{"findings": {"pii_type": "IN_PAN"}}`,
+		"truncated":          `{"scan_id": "s", "findings": [{"pii_type": "IN_PAN"}, {"pii_ty`,
+		"bad finding":        `{"findings": [{"pii_type": 7}]}`,
+	}
+
+	for name, body := range cases {
+		_, err := drainStream(t, NewVerifiedScanDecoder(strings.NewReader(body), 0))
+		var decodeErr *PayloadDecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("%s: expected a decode error, got %v", name, err)
+		}
+	}
+}
+
+func TestVerifiedScanDecoder_NoFindings(t *testing.T) {
+	for _, body := range []string{`{"scan_id": "s"}`, `{"findings": null}`, `{"findings": []}`} {
+		findings, err := drainStream(t, NewVerifiedScanDecoder(strings.NewReader(body), 0))
+		if err != nil || len(findings) != 0 {
+			t.Errorf("%s: expected an empty stream, got %d findings, err %v", body, len(findings), err)
+		}
+	}
+}
+
+func TestVerifiedScanDecoder_SchemaVersion(t *testing.T) {
+	// Unversioned payloads are version 1 and upgraded
+	legacy := `{"scan_id": "s", "verified_findings": [
+		{"pii_type": "IN_PAN", "source": {"path": "/a", "data_source": "fs"}, "detected_at": "2026-03-01T10:20:30.123456"}
+	]}`
+	decoder := NewVerifiedScanDecoder(strings.NewReader(legacy), 0)
+	findings, err := drainStream(t, decoder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoder.SchemaVersion() != 1 || len(findings) != 1 {
+		t.Fatalf("expected one version 1 finding, got version %d and %d findings", decoder.SchemaVersion(), len(findings))
+	}
+	if findings[0].Source.DataSource != "filesystem" || findings[0].DetectedAt != "2026-03-01T10:20:30.123456Z" {
+		t.Errorf("expected the legacy finding to be upgraded, got %+v", findings[0])
+	}
+
+	// Current payloads are taken as they are
+	current := `{"schema_version": 2, "findings": [{"pii_type": "IN_PAN", "source": {"data_source": "fs"}, "detected_at": "2026-03-01T10:20:30"}]}`
+	decoder = NewVerifiedScanDecoder(strings.NewReader(current), 0)
+	findings, err = drainStream(t, decoder)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoder.SchemaVersion() != 2 || findings[0].Source.DataSource != "fs" || findings[0].DetectedAt != "2026-03-01T10:20:30" {
+		t.Errorf("expected a version 2 finding unchanged, got version %d, %+v", decoder.SchemaVersion(), findings[0])
+	}
+}
+
+func TestVerifiedScanDecoder_UnsupportedSchemaVersion(t *testing.T) {
+	for _, body := range []string{
+		`{"schema_version": 3, "findings": [{"pii_type": "IN_PAN"}]}`,
+		`{"schema_version": -1, "findings": [{"pii_type": "IN_PAN"}]}`,
+	} {
+		_, err := drainStream(t, NewVerifiedScanDecoder(strings.NewReader(body), 0))
+		var versionErr *UnsupportedSchemaVersionError
+		if !errors.As(err, &versionErr) {
+			t.Errorf("%s: expected an unsupported schema version error, got %v", body, err)
+		}
+	}
+
+	for name, body := range map[string]string{
+		"after findings":   `{"findings": [{"pii_type": "IN_PAN"}], "schema_version": 2}`,
+		"zero":             `{"schema_version": 0, "findings": []}`,
+		"renamed findings": `{"schema_version": 2, "verified_findings": []}`,
+		"not a number":     `{"schema_version": "2", "findings": []}`,
+	} {
+		_, err := drainStream(t, NewVerifiedScanDecoder(strings.NewReader(body), 0))
+		var decodeErr *PayloadDecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("%s: expected a decode error, got %v", name, err)
+		}
+	}
+}
+
+func TestSliceFindingStream_SchemaVersion(t *testing.T) {
+	stream := &sliceFindingStream{input: VerifiedScanInput{Findings: []VerifiedFinding{
+		{PIIType: "IN_PAN", Source: SourceLocation{DataSource: "postgres"}},
+	}}}
+	vf, err := stream.Next()
+	if err != nil || vf.Source.DataSource != "postgresql" || stream.SchemaVersion() != 1 {
+		t.Errorf("expected an upgraded version 1 finding, got %+v, %v", vf, err)
+	}
+
+	stream = &sliceFindingStream{input: VerifiedScanInput{SchemaVersion: 9, Findings: []VerifiedFinding{{PIIType: "IN_PAN"}}}}
+	var versionErr *UnsupportedSchemaVersionError
+	if _, err := stream.Next(); !errors.As(err, &versionErr) {
+		t.Errorf("expected an unsupported schema version error, got %v", err)
+	}
+}
+
+func TestNewIngestionCapabilities(t *testing.T) {
+	caps := NewIngestionCapabilities(IngestionLimits{MaxFindings: 10}, SigningCapability{})
+
+	if caps.SchemaVersion.Current != CurrentSchemaVersion || len(caps.SchemaVersion.Supported) != CurrentSchemaVersion-MinSchemaVersion+1 {
+		t.Errorf("unexpected schema versions: %+v", caps.SchemaVersion)
+	}
+	if caps.PayloadFields[0] != "schema_version" {
+		t.Errorf("expected schema_version among the payload fields, got %v", caps.PayloadFields)
+	}
+	found := false
+	for _, f := range caps.FindingFields {
+		found = found || f == "value_hash"
+	}
+	if !found {
+		t.Errorf("expected value_hash among the finding fields, got %v", caps.FindingFields)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// volumeRiskBonus is added to the risk score for each volume tier a finding reaches
+const volumeRiskBonus = 10
+
+// Severity tiers in ascending order, as findings store them
+var severityTiers = []string{"Info", "Low", "Medium", "High", "Critical"}
+
+// Keys scanners use in file_data for the size of the scanned table or file, and for
+// how much of it they read when they sampled
+var (
+	rowCountKeys    = []string{"row_count", "total_rows", "record_count", "rows"}
+	rowsScannedKeys = []string{"rows_scanned", "scanned_rows", "sample_rows"}
+)
+
+// VolumeEscalation raises the severity of findings that likely affect many records:
+// a single email in a log is not a table of five million emails. Each threshold a
+// finding's estimated record count reaches raises its severity one tier, up to
+// Critical. Non-PII (Info) findings are never raised.
+type VolumeEscalation struct {
+	Thresholds []int64 `json:"thresholds"` // Ascending; escalation is off when empty
+}
+
+// NewVolumeEscalation parses configured thresholds. Entries that are not positive
+// integers are skipped with a warning; "off" disables escalation.
+func NewVolumeEscalation(thresholds []string) VolumeEscalation {
+	seen := map[int64]bool{}
+	v := VolumeEscalation{Thresholds: []int64{}}
+	for _, raw := range thresholds {
+		raw = strings.TrimSpace(raw)
+		if strings.EqualFold(raw, "off") {
+			return VolumeEscalation{Thresholds: []int64{}}
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || n <= 0 {
+			log.Printf("WARNING: Ignoring volume severity threshold %q: not a positive integer", raw)
+			continue
+		}
+		if !seen[n] {
+			seen[n] = true
+			v.Thresholds = append(v.Thresholds, n)
+		}
+	}
+	sort.Slice(v.Thresholds, func(i, j int) bool { return v.Thresholds[i] < v.Thresholds[j] })
+	return v
+}
+
+// Tiers returns how many thresholds affected records reach
+func (v VolumeEscalation) Tiers(affected int64) int {
+	tiers := 0
+	for _, t := range v.Thresholds {
+		if affected >= t {
+			tiers++
+		}
+	}
+	return tiers
+}
+
+// Escalate raises severity by tiers, up to Critical. Info and unknown severities are kept.
+func (v VolumeEscalation) Escalate(severity string, tiers int) string {
+	if tiers <= 0 {
+		return severity
+	}
+	for i, s := range severityTiers {
+		if s == severity {
+			if i == 0 {
+				return severity
+			}
+			return severityTiers[min(i+tiers, len(severityTiers)-1)]
+		}
+	}
+	return severity
+}
+
+// EstimateAffectedRecords estimates the records a finding affects from its match
+// count. When the scanner read only part of a table or file, reported as rows
+// scanned of a row count in file_data, the matches are extrapolated to the whole
+// of it, but never past its row count.
+func EstimateAffectedRecords(totalMatches int, fileData map[string]interface{}) int64 {
+	affected := int64(totalMatches)
+	rowCount := fileDataCount(fileData, rowCountKeys)
+	scanned := fileDataCount(fileData, rowsScannedKeys)
+	if rowCount > 0 && scanned > 0 && scanned < rowCount {
+		extrapolated := int64(math.Round(float64(totalMatches) * float64(rowCount) / float64(scanned)))
+		affected = max(affected, min(extrapolated, rowCount))
+	}
+	return affected
+}
+
+// applyVolume adds the volume tiers to a risk score breakdown
+func (b *RiskScoreBreakdown) applyVolume(affected int64, tiers int) {
+	b.AffectedRecords = affected
+	b.VolumeTiers = tiers
+	b.Score = min(b.Score+tiers*volumeRiskBonus, 100)
+}
+
+// volumeDescription explains a volume escalation in the severity description
+func volumeDescription(affected int64, tiers int) string {
+	if tiers == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" | Volume: ~%d records, severity +%d", affected, tiers)
+}
+
+// fileDataCount reads the first of keys holding a number, as JSON numbers or strings
+func fileDataCount(fileData map[string]interface{}, keys []string) int64 {
+	for _, key := range keys {
+		switch v := fileData[key].(type) {
+		case float64:
+			if v > 0 {
+				return int64(v)
+			}
+		case int:
+			if v > 0 {
+				return int64(v)
+			}
+		case int64:
+			if v > 0 {
+				return v
+			}
+		case string:
+			if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+package service
+
+import "testing"
+
+func TestNewVolumeEscalation(t *testing.T) {
+	v := NewVolumeEscalation([]string{"100000", " 1000 ", "abc", "-5", "1000"})
+	if len(v.Thresholds) != 2 || v.Thresholds[0] != 1000 || v.Thresholds[1] != 100000 {
+		t.Errorf("expected sorted, deduplicated thresholds, got %v", v.Thresholds)
+	}
+	if off := NewVolumeEscalation([]string{"1000", "off"}); len(off.Thresholds) != 0 {
+		t.Errorf("expected off to disable escalation, got %v", off.Thresholds)
+	}
+}
+
+func TestVolumeEscalation(t *testing.T) {
+	v := NewVolumeEscalation([]string{"1000", "100000"})
+	for _, tc := range []struct {
+		severity string
+		affected int64
+		want     string
+	}{
+		{"Low", 1, "Low"},
+		{"Low", 999, "Low"},
+		{"Low", 1000, "Medium"},
+		{"Low", 5_000_000, "High"},
+		{"High", 5_000_000, "Critical"},
+		{"Critical", 5_000_000, "Critical"},
+		{"Info", 5_000_000, "Info"},
+	} {
+		if got := v.Escalate(tc.severity, v.Tiers(tc.affected)); got != tc.want {
+			t.Errorf("%s with %d records: got %s, want %s", tc.severity, tc.affected, got, tc.want)
+		}
+	}
+
+	if got := NewVolumeEscalation(nil).Escalate("Low", NewVolumeEscalation(nil).Tiers(5_000_000)); got != "Low" {
+		t.Errorf("expected no escalation without thresholds, got %s", got)
+	}
+}
+
+func TestEstimateAffectedRecords(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		matches  int
+		fileData map[string]interface{}
+		want     int64
+	}{
+		{"matches only", 12, nil, 12},
+		{"fully scanned table", 40, map[string]interface{}{"row_count": float64(5000), "rows_scanned": float64(5000)}, 40},
+		{"sampled table", 50, map[string]interface{}{"row_count": float64(5_000_000), "rows_scanned": float64(1000)}, 250_000},
+		{"capped at row count", 3000, map[string]interface{}{"total_rows": "10000", "sample_rows": "1000"}, 10_000},
+		{"row count without sampling", 7, map[string]interface{}{"row_count": float64(5_000_000)}, 7},
+	} {
+		if got := EstimateAffectedRecords(tc.matches, tc.fileData); got != tc.want {
+			t.Errorf("%s: got %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRiskScoreVolumeBonus(t *testing.T) {
+	b := riskScoreBreakdown("Personal Data", "CONFIRMED", nil)
+	base := b.Score
+	b.applyVolume(250_000, 2)
+	if b.Score != min(base+2*volumeRiskBonus, 100) || b.AffectedRecords != 250_000 || b.VolumeTiers != 2 {
+		t.Errorf("unexpected breakdown %+v (base %d)", b, base)
+	}
+	b.applyVolume(1, 9)
+	if b.Score != 100 {
+		t.Errorf("expected the score to be capped at 100, got %d", b.Score)
+	}
+}
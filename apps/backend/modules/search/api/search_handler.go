@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/search/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles findings search endpoints
+type SearchHandler struct {
+	service *service.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(service *service.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search returns the tenant's findings matching q over sample text, asset paths
+// and pattern names, most relevant first, with highlighted fragments
+// GET /api/v1/search?q=aadhaar&severity=high&limit=20&offset=0
+func (h *SearchHandler) Search(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	results, err := h.service.Search(sharedapi.RequestContext(c), service.SearchQuery{
+		Text:     c.Query("q"),
+		Severity: c.Query("severity"),
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidQuery) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Highlighted samples are PII reads
+	for _, hit := range results.Hits {
+		if _, ok := hit.Highlights["sample_text"]; ok {
+			middleware.RecordAccessedFindings(c, hit.FindingID)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"data": results})
+}
+
+// GetStatus returns the index size and how far findings have been indexed
+// GET /api/v1/search/status
+func (h *SearchHandler) GetStatus(c *gin.Context) {
+	status, err := h.service.Status(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": status})
+}
+
+// Sync indexes findings changed since the last run without waiting for the worker
+// POST /api/v1/search/sync
+func (h *SearchHandler) Sync(c *gin.Context) {
+	result, err := h.service.Sync(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error(), "data": result})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// Rebuild empties the index so the next sync indexes every finding again
+// POST /api/v1/search/rebuild
+func (h *SearchHandler) Rebuild(c *gin.Context) {
+	if err := h.service.Rebuild(sharedapi.RequestContext(c)); err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Search index reset; the next sync indexes all findings"})
+}
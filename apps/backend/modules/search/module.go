@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/search/api"
+	"github.com/arc-platform/backend/modules/search/service"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/opensearch"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchModule serves full-text search over findings, indexed by a background
+// sync within seconds of ingest. It is inactive unless SEARCH_BACKEND is set.
+type SearchModule struct {
+	searchHandler  *api.SearchHandler // nil unless a search backend is configured
+	authMiddleware *middleware.AuthMiddleware
+	deps           *interfaces.ModuleDependencies
+
+	workerCtx    context.Context
+	cancelWorker context.CancelFunc
+}
+
+func (m *SearchModule) Name() string {
+	return "search"
+}
+
+func (m *SearchModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Printf("🔎 Initializing Search Module...")
+
+	if deps.Config == nil || deps.Config.Search.Backend == "" {
+		log.Printf("🔎 Search disabled: SEARCH_BACKEND is not set")
+		return nil
+	}
+
+	cfg := deps.Config.Search
+	var index service.SearchIndex
+	switch cfg.Backend {
+	case "embedded":
+		index = service.NewEmbeddedIndex(cfg.IndexPath)
+	case "opensearch":
+		if cfg.OpenSearchURL == "" {
+			return fmt.Errorf("SEARCH_OPENSEARCH_URL is required for the opensearch search backend")
+		}
+		index = service.NewOpenSearchIndex(opensearch.New(cfg.OpenSearchURL, cfg.OpenSearchUser, cfg.OpenSearchPassword), cfg.OpenSearchIndex)
+	default:
+		return fmt.Errorf("unknown search backend %q: expected embedded or opensearch", cfg.Backend)
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	searchService := service.NewSearchService(repo, index, cfg.BatchSize, cfg.SettleSeconds)
+	m.searchHandler = api.NewSearchHandler(searchService)
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+	go searchService.StartSyncWorker(m.workerContext(), cfg.SyncIntervalSeconds)
+
+	log.Printf("✅ Search Module initialized (backend: %s)", cfg.Backend)
+	return nil
+}
+
+func (m *SearchModule) RegisterRoutes(router *gin.RouterGroup) {
+	if m.searchHandler == nil {
+		return
+	}
+
+	router.GET("/search", m.authMiddleware.ScopeToOrgUnit(), m.deps.AccessAudit.Middleware(), m.searchHandler.Search)
+
+	// The index is shared by every tenant, so managing it is admin-only
+	admin := router.Group("/search", m.authMiddleware.RequireRole("admin"))
+	admin.GET("/status", m.searchHandler.GetStatus)
+	admin.POST("/sync", m.searchHandler.Sync)
+	admin.POST("/rebuild", m.searchHandler.Rebuild)
+
+	log.Printf("🔎 Search routes registered")
+}
+
+func (m *SearchModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Search Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
+	return nil
+}
+
+// workerContext returns the context shared by background workers, cancelled on shutdown
+func (m *SearchModule) workerContext() context.Context {
+	if m.workerCtx == nil {
+		m.workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+	}
+	return m.workerCtx
+}
+
+func NewSearchModule() *SearchModule {
+	return &SearchModule{}
+}
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// BM25 parameters, the defaults OpenSearch uses too
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// compactSlack is how many superseded log entries are tolerated on top of one
+// per live document before the log is rewritten
+const compactSlack = 10000
+
+// EmbeddedIndex is an in-process inverted index ranking hits with BM25, for
+// single-node deployments without a search cluster. It is kept in memory and,
+// when given a path, in an append-only log of writes replayed on start and
+// compacted once mostly superseded.
+type EmbeddedIndex struct {
+	path string
+
+	mu         sync.RWMutex
+	opened     bool
+	docs       map[uuid.UUID]*embeddedDoc
+	tenants    map[uuid.UUID]*tenantPostings
+	log        *os.File
+	logWriter  *bufio.Writer
+	logEntries int
+}
+
+type embeddedDoc struct {
+	doc    *entity.SearchDocument
+	terms  map[string]float64 // Term frequency weighted by field boost
+	length float64
+}
+
+// tenantPostings is one tenant's part of the index, so relevance statistics
+// and lookups never cross tenants
+type tenantPostings struct {
+	postings    map[string]map[uuid.UUID]float64
+	docs        int
+	totalLength float64
+}
+
+// embeddedLogEntry is one line of the index log: a document written or deleted
+type embeddedLogEntry struct {
+	Doc    *entity.SearchDocument `json:"doc,omitempty"`
+	Delete *uuid.UUID             `json:"delete,omitempty"`
+}
+
+// NewEmbeddedIndex creates an embedded index kept in the log file at path, or
+// only in memory when path is empty
+func NewEmbeddedIndex(path string) *EmbeddedIndex {
+	return &EmbeddedIndex{
+		path:    path,
+		docs:    make(map[uuid.UUID]*embeddedDoc),
+		tenants: make(map[uuid.UUID]*tenantPostings),
+	}
+}
+
+// Name identifies the index in sync watermarks
+func (x *EmbeddedIndex) Name() string {
+	return "search-embedded"
+}
+
+// EnsureSchema loads the index log on first use. An index only kept in memory,
+// or whose log does not exist yet, starts fresh.
+func (x *EmbeddedIndex) EnsureSchema(ctx context.Context) (bool, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.opened {
+		return false, nil
+	}
+	if x.path == "" {
+		x.opened = true
+		return true, nil
+	}
+
+	fresh := false
+	file, err := os.Open(x.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		fresh = true
+	case err != nil:
+		return false, fmt.Errorf("failed to open search index: %w", err)
+	default:
+		err = x.replay(file)
+		file.Close()
+		if err != nil {
+			return false, err
+		}
+	}
+	if err := x.openLog(); err != nil {
+		return false, err
+	}
+	x.opened = true
+	return fresh, nil
+}
+
+// replay applies the writes in a log. A torn last line, left by a crash mid
+// write, ends the replay; the watermark never moved past it.
+func (x *EmbeddedIndex) replay(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry embeddedLogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			break
+		}
+		switch {
+		case entry.Doc != nil:
+			x.put(entry.Doc)
+		case entry.Delete != nil:
+			x.remove(*entry.Delete)
+		}
+		x.logEntries++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read search index: %w", err)
+	}
+	return nil
+}
+
+func (x *EmbeddedIndex) openLog() error {
+	file, err := os.OpenFile(x.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open search index: %w", err)
+	}
+	x.log = file
+	x.logWriter = bufio.NewWriter(file)
+	return nil
+}
+
+func (x *EmbeddedIndex) append(entry embeddedLogEntry) error {
+	if x.logWriter == nil {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := x.logWriter.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	x.logEntries++
+	return nil
+}
+
+// Upsert indexes docs, replacing earlier versions
+func (x *EmbeddedIndex) Upsert(ctx context.Context, docs []*entity.SearchDocument) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, doc := range docs {
+		x.put(doc)
+		if err := x.append(embeddedLogEntry{Doc: doc}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delete removes documents; unknown IDs are ignored
+func (x *EmbeddedIndex) Delete(ctx context.Context, ids []uuid.UUID) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := x.docs[id]; !ok {
+			continue
+		}
+		x.remove(id)
+		if err := x.append(embeddedLogEntry{Delete: &id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush syncs the log to disk, compacting it once mostly superseded
+func (x *EmbeddedIndex) Flush(ctx context.Context) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if x.logWriter == nil {
+		return nil
+	}
+	if err := x.logWriter.Flush(); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	if err := x.log.Sync(); err != nil {
+		return fmt.Errorf("failed to sync search index: %w", err)
+	}
+	if x.logEntries > 2*len(x.docs)+compactSlack {
+		return x.compact()
+	}
+	return nil
+}
+
+// compact rewrites the log with one entry per live document
+func (x *EmbeddedIndex) compact() error {
+	tmp := x.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to compact search index: %w", err)
+	}
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, d := range x.docs {
+		if err := enc.Encode(embeddedLogEntry{Doc: d.doc}); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to compact search index: %w", err)
+		}
+	}
+	if err := w.Flush(); err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to compact search index: %w", err)
+	}
+
+	x.log.Close()
+	if err := os.Rename(tmp, x.path); err != nil {
+		return fmt.Errorf("failed to compact search index: %w", err)
+	}
+	x.logEntries = len(x.docs)
+	return x.openLog()
+}
+
+// put indexes doc in memory, replacing an earlier version
+func (x *EmbeddedIndex) put(doc *entity.SearchDocument) {
+	x.remove(doc.ID)
+
+	d := &embeddedDoc{doc: doc, terms: make(map[string]float64)}
+	for _, field := range searchFields {
+		for _, token := range tokenize(field.value(doc)) {
+			d.terms[token] += field.boost
+			d.length += field.boost
+		}
+	}
+	x.docs[doc.ID] = d
+
+	tp := x.tenants[doc.TenantID]
+	if tp == nil {
+		tp = &tenantPostings{postings: make(map[string]map[uuid.UUID]float64)}
+		x.tenants[doc.TenantID] = tp
+	}
+	for term, tf := range d.terms {
+		if tp.postings[term] == nil {
+			tp.postings[term] = make(map[uuid.UUID]float64)
+		}
+		tp.postings[term][doc.ID] = tf
+	}
+	tp.docs++
+	tp.totalLength += d.length
+}
+
+func (x *EmbeddedIndex) remove(id uuid.UUID) {
+	d, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	delete(x.docs, id)
+	tp := x.tenants[d.doc.TenantID]
+	for term := range d.terms {
+		delete(tp.postings[term], id)
+		if len(tp.postings[term]) == 0 {
+			delete(tp.postings, term)
+		}
+	}
+	tp.docs--
+	tp.totalLength -= d.length
+	if tp.docs == 0 {
+		delete(x.tenants, d.doc.TenantID)
+	}
+}
+
+// Search ranks a tenant's documents matching any query term with BM25
+func (x *EmbeddedIndex) Search(ctx context.Context, tenantID uuid.UUID, query SearchQuery) (*SearchResults, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	results := &SearchResults{Query: query.Text, Hits: []*SearchHit{}, Limit: query.Limit, Offset: query.Offset}
+	tp := x.tenants[tenantID]
+	if tp == nil {
+		return results, nil
+	}
+
+	terms := make(map[string]bool)
+	for _, token := range tokenize(query.Text) {
+		terms[token] = true
+	}
+	var assets map[uuid.UUID]bool
+	if query.AssetIDs != nil {
+		assets = make(map[uuid.UUID]bool, len(query.AssetIDs))
+		for _, id := range query.AssetIDs {
+			assets[id] = true
+		}
+	}
+	n := float64(tp.docs)
+	avgLength := tp.totalLength / n
+	scores := make(map[uuid.UUID]float64)
+	for term := range terms {
+		postings := tp.postings[term]
+		idf := math.Log(1 + (n-float64(len(postings))+0.5)/(float64(len(postings))+0.5))
+		for id, tf := range postings {
+			d := x.docs[id]
+			if query.Severity != "" && !strings.EqualFold(d.doc.Severity, query.Severity) {
+				continue
+			}
+			if assets != nil && !assets[d.doc.AssetID] {
+				continue
+			}
+			norm := bm25K1 * (1 - bm25B + bm25B*d.length/avgLength)
+			scores[id] += idf * tf * (bm25K1 + 1) / (tf + norm)
+		}
+	}
+
+	ranked := make([]*embeddedDoc, 0, len(scores))
+	for id := range scores {
+		ranked = append(ranked, x.docs[id])
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i].doc, ranked[j].doc
+		if scores[a.ID] != scores[b.ID] {
+			return scores[a.ID] > scores[b.ID]
+		}
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+
+	results.Total = len(ranked)
+	if query.Offset < len(ranked) {
+		ranked = ranked[query.Offset:]
+		if len(ranked) > query.Limit {
+			ranked = ranked[:query.Limit]
+		}
+		for _, d := range ranked {
+			hit := newSearchHit(d.doc, scores[d.doc.ID])
+			for _, field := range searchFields {
+				if fragment := highlight(field.value(d.doc), terms); fragment != "" {
+					if hit.Highlights == nil {
+						hit.Highlights = make(map[string][]string)
+					}
+					hit.Highlights[field.name] = []string{fragment}
+				}
+			}
+			results.Hits = append(results.Hits, hit)
+		}
+	}
+	return results, nil
+}
+
+// Count returns how many documents are indexed
+func (x *EmbeddedIndex) Count(ctx context.Context) (int, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.docs), nil
+}
+
+// Reset drops every document and empties the log
+func (x *EmbeddedIndex) Reset(ctx context.Context) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.docs = make(map[uuid.UUID]*embeddedDoc)
+	x.tenants = make(map[uuid.UUID]*tenantPostings)
+	x.logEntries = 0
+	if x.log == nil {
+		return nil
+	}
+	x.log.Close()
+	if err := os.Truncate(x.path, 0); err != nil {
+		return fmt.Errorf("failed to reset search index: %w", err)
+	}
+	return x.openLog()
+}
+
+func newSearchHit(doc *entity.SearchDocument, score float64) *SearchHit {
+	return &SearchHit{
+		FindingID:   doc.ID,
+		AssetID:     doc.AssetID,
+		AssetName:   doc.AssetName,
+		AssetPath:   doc.AssetPath,
+		Host:        doc.Host,
+		DataSource:  doc.DataSource,
+		PatternName: doc.PatternName,
+		Severity:    doc.Severity,
+		Score:       score,
+	}
+}
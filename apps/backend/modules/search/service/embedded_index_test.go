@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func searchDoc(tenantID uuid.UUID, pattern, path, sample string) *entity.SearchDocument {
+	return &entity.SearchDocument{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		AssetID:     uuid.New(),
+		AssetName:   filepath.Base(path),
+		AssetPath:   path,
+		DataSource:  "fs",
+		PatternName: pattern,
+		Severity:    "High",
+		SampleText:  sample,
+		UpdatedAt:   time.Now(),
+	}
+}
+
+func hitIDs(results *SearchResults) []uuid.UUID {
+	ids := make([]uuid.UUID, len(results.Hits))
+	for i, hit := range results.Hits {
+		ids[i] = hit.FindingID
+	}
+	return ids
+}
+
+func TestEmbeddedIndexSearch(t *testing.T) {
+	ctx := context.Background()
+	tenant, other := uuid.New(), uuid.New()
+	payroll := searchDoc(tenant, "PAN", "/data/hr/payroll.csv", "employee pan XXXXX1234F in payroll export")
+	aadhaar := searchDoc(tenant, "Aadhaar", "/data/customers/kyc.csv", "aadhaar XXXX XXXX 9012")
+	aadhaar.Severity = "Critical"
+	notes := searchDoc(tenant, "Email", "/home/notes.txt", "mail payroll team about the aadhaar upload")
+	foreign := searchDoc(other, "Aadhaar", "/data/aadhaar.csv", "aadhaar")
+
+	x := NewEmbeddedIndex("")
+	if fresh, err := x.EnsureSchema(ctx); err != nil || !fresh {
+		t.Fatalf("expected an in-memory index to start fresh, got %v %v", fresh, err)
+	}
+	if err := x.Upsert(ctx, []*entity.SearchDocument{payroll, aadhaar, notes, foreign}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The finding whose pattern and sample both match ranks above a passing mention
+	results, err := x.Search(ctx, tenant, SearchQuery{Text: "Aadhaar", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 2 || results.Hits[0].FindingID != aadhaar.ID || results.Hits[1].FindingID != notes.ID {
+		t.Fatalf("unexpected ranking %v", hitIDs(results))
+	}
+	if got := results.Hits[0].Highlights["pattern_name"]; len(got) != 1 || got[0] != "<em>Aadhaar</em>" {
+		t.Errorf("unexpected pattern highlight %v", got)
+	}
+
+	// Paths match on their segments
+	results, _ = x.Search(ctx, tenant, SearchQuery{Text: "kyc", Limit: 10})
+	if results.Total != 1 || results.Hits[0].Highlights["asset_path"][0] != "/data/customers/<em>kyc</em>.csv" {
+		t.Errorf("unexpected path search %+v", results.Hits)
+	}
+
+	// Filters narrow hits without changing the query
+	results, _ = x.Search(ctx, tenant, SearchQuery{Text: "aadhaar payroll", Severity: "critical", Limit: 10})
+	if results.Total != 1 || results.Hits[0].FindingID != aadhaar.ID {
+		t.Errorf("expected only the critical finding, got %v", hitIDs(results))
+	}
+	results, _ = x.Search(ctx, tenant, SearchQuery{Text: "aadhaar payroll", AssetIDs: []uuid.UUID{payroll.AssetID}, Limit: 10})
+	if results.Total != 1 || results.Hits[0].FindingID != payroll.ID {
+		t.Errorf("expected only the finding on the scoped asset, got %v", hitIDs(results))
+	}
+	results, _ = x.Search(ctx, tenant, SearchQuery{Text: "aadhaar payroll", AssetIDs: []uuid.UUID{}, Limit: 10})
+	if results.Total != 0 {
+		t.Errorf("expected a scope without assets to match nothing, got %v", hitIDs(results))
+	}
+
+	// Paging keeps the total
+	results, _ = x.Search(ctx, tenant, SearchQuery{Text: "aadhaar payroll", Limit: 1, Offset: 1})
+	if results.Total != 3 || len(results.Hits) != 1 {
+		t.Errorf("expected the second of 3 hits, got %d of %d", len(results.Hits), results.Total)
+	}
+
+	// Other tenants' findings never match
+	results, _ = x.Search(ctx, other, SearchQuery{Text: "payroll", Limit: 10})
+	if results.Total != 0 {
+		t.Errorf("expected no hits across tenants, got %v", hitIDs(results))
+	}
+
+	// Updates replace the earlier version and deletes remove it
+	aadhaar.SampleText = "masked"
+	aadhaar.PatternName = "Masked"
+	if err := x.Upsert(ctx, []*entity.SearchDocument{aadhaar}); err != nil {
+		t.Fatal(err)
+	}
+	if err := x.Delete(ctx, []uuid.UUID{notes.ID, uuid.New()}); err != nil {
+		t.Fatal(err)
+	}
+	results, _ = x.Search(ctx, tenant, SearchQuery{Text: "aadhaar", Limit: 10})
+	if results.Total != 0 {
+		t.Errorf("expected updated and deleted findings not to match, got %v", hitIDs(results))
+	}
+	if n, _ := x.Count(ctx); n != 3 {
+		t.Errorf("expected 3 documents, got %d", n)
+	}
+}
+
+func TestEmbeddedIndexPersists(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "search.jsonl")
+	tenant := uuid.New()
+	kept := searchDoc(tenant, "PAN", "/data/pan.csv", "pan")
+	removed := searchDoc(tenant, "PAN", "/data/old.csv", "pan")
+
+	x := NewEmbeddedIndex(path)
+	if fresh, err := x.EnsureSchema(ctx); err != nil || !fresh {
+		t.Fatalf("expected a new index file to start fresh, got %v %v", fresh, err)
+	}
+	x.Upsert(ctx, []*entity.SearchDocument{kept, removed})
+	x.Delete(ctx, []uuid.UUID{removed.ID})
+	if err := x.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A torn write after the last flush is dropped on replay
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	f.WriteString(`{"doc":{"id":`)
+	f.Close()
+
+	reopened := NewEmbeddedIndex(path)
+	if fresh, err := reopened.EnsureSchema(ctx); err != nil || fresh {
+		t.Fatalf("expected the index to be loaded from its file, got %v %v", fresh, err)
+	}
+	results, _ := reopened.Search(ctx, tenant, SearchQuery{Text: "pan", Limit: 10})
+	if results.Total != 1 || results.Hits[0].FindingID != kept.ID {
+		t.Errorf("expected only the kept finding after reload, got %v", hitIDs(results))
+	}
+
+	if err := reopened.Reset(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if info, _ := os.Stat(path); info.Size() != 0 {
+		t.Errorf("expected reset to empty the index file, size %d", info.Size())
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	terms := map[string]bool{"aadhaar": true}
+
+	if got := highlight("no match here", terms); got != "" {
+		t.Errorf("expected no fragment, got %q", got)
+	}
+	if got := highlight("<b>Aadhaar</b>: 1234", terms); got != "&lt;b&gt;<em>Aadhaar</em>&lt;/b&gt;: 1234" {
+		t.Errorf("expected escaped text around the match, got %q", got)
+	}
+
+	long := strings.Repeat("filler ", 30) + "aadhaar number " + strings.Repeat("padding ", 30)
+	got := highlight(long, terms)
+	if !strings.Contains(got, "<em>aadhaar</em>") || len(got) > fragmentSize+len("<em></em>") {
+		t.Errorf("expected a bounded fragment around the match, got %q (%d bytes)", got, len(got))
+	}
+	if strings.HasPrefix(got, "<em>") {
+		t.Errorf("expected context before the match, got %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// SearchIndex is a full-text index of findings, shared by every tenant. Every
+// search is scoped to one tenant.
+type SearchIndex interface {
+	// Name identifies the index in sync watermarks
+	Name() string
+	// EnsureSchema prepares the index; fresh reports it started empty, so the
+	// sync watermark must be reset for everything to be indexed again
+	EnsureSchema(ctx context.Context) (fresh bool, err error)
+	Upsert(ctx context.Context, docs []*entity.SearchDocument) error
+	Delete(ctx context.Context, ids []uuid.UUID) error
+	// Flush makes writes so far durable; the watermark only advances past them afterwards
+	Flush(ctx context.Context) error
+	Search(ctx context.Context, tenantID uuid.UUID, query SearchQuery) (*SearchResults, error)
+	Count(ctx context.Context) (int, error)
+	// Reset drops everything indexed so far
+	Reset(ctx context.Context) error
+}
+
+// SearchQuery is a tenant's full-text query
+type SearchQuery struct {
+	Text     string
+	Severity string // Optional exact severity filter
+	// AssetIDs limits hits to findings on these assets when not nil, for
+	// users scoped to an org unit
+	AssetIDs []uuid.UUID
+	Limit    int
+	Offset   int
+}
+
+// SearchHit is one matching finding. Highlights hold fragments of the matching
+// fields, HTML-escaped, with matched terms wrapped in <em> tags.
+type SearchHit struct {
+	FindingID   uuid.UUID           `json:"finding_id"`
+	AssetID     uuid.UUID           `json:"asset_id"`
+	AssetName   string              `json:"asset_name"`
+	AssetPath   string              `json:"asset_path"`
+	Host        string              `json:"host,omitempty"`
+	DataSource  string              `json:"data_source"`
+	PatternName string              `json:"pattern_name"`
+	Severity    string              `json:"severity"`
+	Score       float64             `json:"score"`
+	Highlights  map[string][]string `json:"highlights,omitempty"`
+}
+
+// SearchResults is one page of hits, most relevant first
+type SearchResults struct {
+	Query  string       `json:"query"`
+	Total  int          `json:"total"`
+	Hits   []*SearchHit `json:"hits"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// searchField is a document field searched and highlighted, with its weight
+type searchField struct {
+	name  string
+	boost float64
+	value func(*entity.SearchDocument) string
+}
+
+// searchFields are the fields both backends search, a match on the pattern or
+// asset counting more than one in the sample
+var searchFields = []searchField{
+	{"pattern_name", 2, func(d *entity.SearchDocument) string { return d.PatternName }},
+	{"asset_name", 1.5, func(d *entity.SearchDocument) string { return d.AssetName }},
+	{"asset_path", 1.5, func(d *entity.SearchDocument) string { return d.AssetPath }},
+	{"host", 1, func(d *entity.SearchDocument) string { return d.Host }},
+	{"sample_text", 1, func(d *entity.SearchDocument) string { return d.SampleText }},
+}
+
+// tokenPattern splits text the same way in both backends: runs of letters and digits
+const tokenPattern = `[^\p{L}\p{Nd}]+`
+
+type tokenSpan struct {
+	start, end int // Byte offsets in the text
+}
+
+// tokenize lowercases the runs of letters and digits in text
+func tokenize(text string) []string {
+	spans := tokenSpans(text)
+	tokens := make([]string, len(spans))
+	for i, span := range spans {
+		tokens[i] = strings.ToLower(text[span.start:span.end])
+	}
+	return tokens
+}
+
+func tokenSpans(text string) []tokenSpan {
+	var spans []tokenSpan
+	start := -1
+	for i, r := range text {
+		isToken := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isToken && start < 0:
+			start = i
+		case !isToken && start >= 0:
+			spans = append(spans, tokenSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, tokenSpan{start, len(text)})
+	}
+	return spans
+}
+
+const (
+	fragmentSize = 150 // Bytes of text around the first match kept in a highlight
+	fragmentLead = 40  // Bytes of that kept before the first match
+)
+
+// highlight returns the fragment of text around its first token in terms, with
+// every matching token wrapped in <em> tags, or "" when no token matches
+func highlight(text string, terms map[string]bool) string {
+	spans := tokenSpans(text)
+	first := -1
+	for i, span := range spans {
+		if terms[strings.ToLower(text[span.start:span.end])] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	from := 0
+	if spans[first].start > fragmentLead {
+		from = spans[first].start
+		for i := first - 1; i >= 0 && spans[first].start-spans[i].start <= fragmentLead; i-- {
+			from = spans[i].start
+		}
+	}
+	to := len(text)
+	if to-from > fragmentSize {
+		to = spans[first].end
+		for _, span := range spans[first+1:] {
+			if span.end-from > fragmentSize {
+				break
+			}
+			to = span.end
+		}
+	}
+
+	var b strings.Builder
+	pos := from
+	for _, span := range spans {
+		if span.start < from || span.end > to {
+			continue
+		}
+		if !terms[strings.ToLower(text[span.start:span.end])] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[pos:span.start]))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(text[span.start:span.end]))
+		b.WriteString("</em>")
+		pos = span.end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/opensearch"
+	"github.com/google/uuid"
+)
+
+// OpenSearchIndex keeps findings in an OpenSearch index shared by the nodes of
+// a cluster, with a document per finding keyed by its ID
+type OpenSearchIndex struct {
+	client *opensearch.Client
+	index  string
+}
+
+// NewOpenSearchIndex creates an index backed by the named OpenSearch index
+func NewOpenSearchIndex(client *opensearch.Client, index string) *OpenSearchIndex {
+	return &OpenSearchIndex{client: client, index: index}
+}
+
+// Name identifies the index in sync watermarks
+func (x *OpenSearchIndex) Name() string {
+	return "search-opensearch"
+}
+
+func (x *OpenSearchIndex) path(suffix string) string {
+	return "/" + url.PathEscape(x.index) + suffix
+}
+
+// EnsureSchema creates the index when it does not exist. Text fields are split
+// into runs of letters and digits like the embedded index, so paths such as
+// /data/customers.csv match "customers".
+func (x *OpenSearchIndex) EnsureSchema(ctx context.Context) (bool, error) {
+	err := x.client.JSON(ctx, http.MethodHead, x.path(""), nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !opensearch.IsNotFound(err) {
+		return false, err
+	}
+
+	text := map[string]interface{}{"type": "text", "analyzer": "finding_text"}
+	mapping := map[string]interface{}{
+		"settings": map[string]interface{}{
+			"analysis": map[string]interface{}{
+				"tokenizer": map[string]interface{}{
+					"finding_tokens": map[string]interface{}{"type": "pattern", "pattern": tokenPattern},
+				},
+				"analyzer": map[string]interface{}{
+					"finding_text": map[string]interface{}{
+						"type":      "custom",
+						"tokenizer": "finding_tokens",
+						"filter":    []string{"lowercase"},
+					},
+				},
+			},
+		},
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"id":           map[string]string{"type": "keyword"},
+				"tenant_id":    map[string]string{"type": "keyword"},
+				"asset_id":     map[string]string{"type": "keyword"},
+				"data_source":  map[string]string{"type": "keyword"},
+				"severity":     map[string]string{"type": "keyword", "normalizer": "lowercase"},
+				"pattern_name": text,
+				"asset_name":   text,
+				"asset_path":   text,
+				"host":         text,
+				"sample_text":  text,
+				"created_at":   map[string]string{"type": "date"},
+				"updated_at":   map[string]string{"type": "date"},
+			},
+		},
+	}
+	if err := x.client.JSON(ctx, http.MethodPut, x.path(""), mapping, nil); err != nil {
+		return false, fmt.Errorf("failed to create search index: %w", err)
+	}
+	return true, nil
+}
+
+// bulkResponse reports per-action failures of a _bulk request
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+// firstError returns the first failed action, ignoring deletes of missing documents
+func (r *bulkResponse) firstError() error {
+	if !r.Errors {
+		return nil
+	}
+	for _, item := range r.Items {
+		for action, result := range item {
+			if result.Status < 300 || (action == "delete" && result.Status == http.StatusNotFound) {
+				continue
+			}
+			return fmt.Errorf("opensearch: %s failed with %d: %s: %s", action, result.Status, result.Error.Type, result.Error.Reason)
+		}
+	}
+	return nil
+}
+
+func (x *OpenSearchIndex) bulk(ctx context.Context, lines []interface{}) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	var resp bulkResponse
+	if err := x.client.Bulk(ctx, x.path("/_bulk"), lines, &resp); err != nil {
+		return err
+	}
+	return resp.firstError()
+}
+
+// Upsert indexes docs, replacing earlier versions
+func (x *OpenSearchIndex) Upsert(ctx context.Context, docs []*entity.SearchDocument) error {
+	lines := make([]interface{}, 0, 2*len(docs))
+	for _, doc := range docs {
+		lines = append(lines, map[string]interface{}{"index": map[string]string{"_id": doc.ID.String()}}, doc)
+	}
+	return x.bulk(ctx, lines)
+}
+
+// Delete removes documents; unknown IDs are ignored
+func (x *OpenSearchIndex) Delete(ctx context.Context, ids []uuid.UUID) error {
+	lines := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		lines = append(lines, map[string]interface{}{"delete": map[string]string{"_id": id.String()}})
+	}
+	return x.bulk(ctx, lines)
+}
+
+// Flush is a no-op: OpenSearch acknowledges bulk writes once they are durable
+// in its translog
+func (x *OpenSearchIndex) Flush(ctx context.Context) error {
+	return nil
+}
+
+// Search runs a multi_match query over the searched fields, filtered to the tenant
+func (x *OpenSearchIndex) Search(ctx context.Context, tenantID uuid.UUID, query SearchQuery) (*SearchResults, error) {
+	fields := make([]string, len(searchFields))
+	highlightFields := make(map[string]interface{}, len(searchFields))
+	for i, field := range searchFields {
+		fields[i] = field.name + "^" + strconv.FormatFloat(field.boost, 'f', -1, 64)
+		highlightFields[field.name] = map[string]interface{}{}
+	}
+	filters := []interface{}{map[string]interface{}{"term": map[string]string{"tenant_id": tenantID.String()}}}
+	if query.Severity != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]string{"severity": query.Severity}})
+	}
+	if query.AssetIDs != nil {
+		assets := make([]string, len(query.AssetIDs))
+		for i, id := range query.AssetIDs {
+			assets[i] = id.String()
+		}
+		filters = append(filters, map[string]interface{}{"terms": map[string][]string{"asset_id": assets}})
+	}
+	body := map[string]interface{}{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{"query": query.Text, "fields": fields},
+				},
+				"filter": filters,
+			},
+		},
+		"highlight": map[string]interface{}{
+			"encoder":             "html",
+			"pre_tags":            []string{"<em>"},
+			"post_tags":           []string{"</em>"},
+			"fragment_size":       fragmentSize,
+			"number_of_fragments": 1,
+			"fields":              highlightFields,
+		},
+	}
+
+	var resp struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score     float64               `json:"_score"`
+				Source    entity.SearchDocument `json:"_source"`
+				Highlight map[string][]string   `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := x.client.JSON(ctx, http.MethodPost, x.path("/_search"), body, &resp); err != nil {
+		return nil, err
+	}
+
+	results := &SearchResults{Query: query.Text, Total: resp.Hits.Total.Value, Hits: []*SearchHit{}, Limit: query.Limit, Offset: query.Offset}
+	for i := range resp.Hits.Hits {
+		h := &resp.Hits.Hits[i]
+		hit := newSearchHit(&h.Source, h.Score)
+		if len(h.Highlight) > 0 {
+			hit.Highlights = h.Highlight
+		}
+		results.Hits = append(results.Hits, hit)
+	}
+	return results, nil
+}
+
+// Count returns how many documents are indexed
+func (x *OpenSearchIndex) Count(ctx context.Context) (int, error) {
+	var resp struct {
+		Count int `json:"count"`
+	}
+	if err := x.client.JSON(ctx, http.MethodGet, x.path("/_count"), nil, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Count, nil
+}
+
+// Reset deletes the index and creates it again empty
+func (x *OpenSearchIndex) Reset(ctx context.Context) error {
+	if err := x.client.JSON(ctx, http.MethodDelete, x.path(""), nil, nil); err != nil && !opensearch.IsNotFound(err) {
+		return err
+	}
+	_, err := x.EnsureSchema(ctx)
+	return err
+}
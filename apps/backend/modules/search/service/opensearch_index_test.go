@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/opensearch"
+	"github.com/google/uuid"
+)
+
+func TestOpenSearchIndex(t *testing.T) {
+	tenant := uuid.New()
+	doc := searchDoc(tenant, "Aadhaar", "/data/kyc.csv", "aadhaar XXXX 9012")
+
+	var created bool
+	var bulkBody string
+	var searchBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case r.Method == http.MethodHead:
+			if !created {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == http.MethodPut && r.URL.Path == "/findings":
+			created = true
+		case r.URL.Path == "/findings/_bulk":
+			bulkBody = string(body)
+			w.Write([]byte(`{"errors": true, "items": [{"delete": {"status": 404}}]}`))
+		case r.URL.Path == "/findings/_search":
+			json.Unmarshal(body, &searchBody)
+			w.Write([]byte(`{"hits": {"total": {"value": 1}, "hits": [{"_score": 2.5,
+				"_source": {"id": "` + doc.ID.String() + `", "asset_path": "/data/kyc.csv", "severity": "High"},
+				"highlight": {"asset_path": ["/data/<em>kyc</em>.csv"]}}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	x := NewOpenSearchIndex(opensearch.New(server.URL, "", ""), "findings")
+	if fresh, err := x.EnsureSchema(ctx); err != nil || !fresh {
+		t.Fatalf("expected the index to be created, got %v %v", fresh, err)
+	}
+	if fresh, err := x.EnsureSchema(ctx); err != nil || fresh {
+		t.Fatalf("expected the existing index to be kept, got %v %v", fresh, err)
+	}
+
+	if err := x.Upsert(ctx, []*entity.SearchDocument{doc}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(bulkBody, `{"index":{"_id":"`+doc.ID.String()+`"}}`) {
+		t.Errorf("unexpected bulk body %q", bulkBody)
+	}
+	// Deleting a finding that was never indexed is not an error
+	if err := x.Delete(ctx, []uuid.UUID{uuid.New()}); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := x.Search(ctx, tenant, SearchQuery{Text: "kyc", Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 1 || results.Hits[0].FindingID != doc.ID || results.Hits[0].Highlights["asset_path"][0] != "/data/<em>kyc</em>.csv" {
+		t.Errorf("unexpected results %+v", results.Hits[0])
+	}
+	filters, _ := json.Marshal(searchBody["query"])
+	if !strings.Contains(string(filters), `{"term":{"tenant_id":"`+tenant.String()+`"}}`) {
+		t.Errorf("expected the search to be filtered to the tenant, got %s", filters)
+	}
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSearchSyncSeconds = 5
+	defaultSearchBatchSize   = 1000
+	defaultSearchLimit       = 20
+	maxSearchLimit           = 100
+	maxSearchQueryLength     = 500
+)
+
+// ErrInvalidQuery is returned for empty or oversized queries and bad paging
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// SearchStatus is where the index stands against PostgreSQL
+type SearchStatus struct {
+	Index     string                     `json:"index"`
+	Documents int                        `json:"documents"`
+	Watermark *entity.AnalyticsWatermark `json:"watermark"`
+}
+
+// SearchSyncResult counts the findings one sync run indexed and removed
+type SearchSyncResult struct {
+	Indexed    int       `json:"indexed"`
+	Removed    int       `json:"removed"`
+	SyncedTo   time.Time `json:"synced_to"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+// SearchService indexes findings of every tenant and serves each tenant's
+// full-text searches. Sync follows findings in (updated_at, id) order from a
+// watermark kept with the analytics sink ones, stopping a settle window short
+// of now so rows of transactions still in flight are not skipped.
+type SearchService struct {
+	repo      *persistence.PostgresRepository
+	index     SearchIndex
+	batchSize int
+	settle    time.Duration
+
+	mu          sync.Mutex // one sync run at a time
+	schemaReady bool
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(repo *persistence.PostgresRepository, index SearchIndex, batchSize, settleSeconds int) *SearchService {
+	if batchSize < 1 {
+		batchSize = defaultSearchBatchSize
+	}
+	if settleSeconds < 0 {
+		settleSeconds = 0
+	}
+	return &SearchService{
+		repo:      repo,
+		index:     index,
+		batchSize: batchSize,
+		settle:    time.Duration(settleSeconds) * time.Second,
+	}
+}
+
+// Search returns the caller's tenant's findings matching query, most relevant first
+func (s *SearchService) Search(ctx context.Context, query SearchQuery) (*SearchResults, error) {
+	tenantID, err := persistence.EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Text = strings.TrimSpace(query.Text)
+	switch {
+	case query.Text == "":
+		return nil, fmt.Errorf("%w: q is required", ErrInvalidQuery)
+	case len(query.Text) > maxSearchQueryLength:
+		return nil, fmt.Errorf("%w: q is longer than %d characters", ErrInvalidQuery, maxSearchQueryLength)
+	case query.Offset < 0 || query.Limit < 0:
+		return nil, fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidQuery)
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+
+	assets, scoped, err := s.repo.ListOrgUnitScopeAssetIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if scoped {
+		query.AssetIDs = assets
+	}
+
+	if err := s.ensureSchema(ctx); err != nil {
+		return nil, err
+	}
+	return s.index.Search(ctx, tenantID, query)
+}
+
+// Status returns the index size and sync watermark
+func (s *SearchService) Status(ctx context.Context) (*SearchStatus, error) {
+	documents, err := s.index.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+	watermark, err := s.repo.GetAnalyticsWatermark(ctx, s.index.Name(), entity.SearchStreamFindings)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchStatus{Index: s.index.Name(), Documents: documents, Watermark: watermark}, nil
+}
+
+// ensureSchema prepares the index once, resetting the watermark when the
+// index starts empty so every finding is indexed again
+func (s *SearchService) ensureSchema(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ensureSchemaLocked(ctx)
+}
+
+func (s *SearchService) ensureSchemaLocked(ctx context.Context) error {
+	if s.schemaReady {
+		return nil
+	}
+	fresh, err := s.index.EnsureSchema(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prepare search index: %w", err)
+	}
+	if fresh {
+		if err := s.repo.ResetAnalyticsWatermarks(ctx, s.index.Name()); err != nil {
+			return err
+		}
+	}
+	s.schemaReady = true
+	return nil
+}
+
+// Sync indexes every finding changed since the last run and removes deleted ones
+func (s *SearchService) Sync(ctx context.Context) (*SearchSyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	started := time.Now()
+	if err := s.ensureSchemaLocked(ctx); err != nil {
+		return nil, err
+	}
+	until := started.Add(-s.settle)
+	result := &SearchSyncResult{SyncedTo: until}
+
+	if err := s.syncFindings(ctx, until, started, result); err != nil {
+		if recordErr := s.repo.RecordAnalyticsSyncError(ctx, s.index.Name(), entity.SearchStreamFindings, err.Error(), started); recordErr != nil {
+			log.Printf("WARN: Failed to record search sync error: %v", recordErr)
+		}
+		return result, fmt.Errorf("failed to sync search index: %w", err)
+	}
+	result.DurationMs = time.Since(started).Milliseconds()
+	return result, nil
+}
+
+// syncFindings indexes findings batch by batch, advancing the watermark after
+// each flushed batch so a failed run resumes where it stopped
+func (s *SearchService) syncFindings(ctx context.Context, until, runAt time.Time, result *SearchSyncResult) error {
+	w, err := s.repo.GetAnalyticsWatermark(ctx, s.index.Name(), entity.SearchStreamFindings)
+	if err != nil {
+		return err
+	}
+
+	for first := true; ; first = false {
+		docs, err := s.repo.ListFindingsForSearch(ctx, w.SyncedUntil, w.LastID, until, s.batchSize)
+		if err != nil {
+			return err
+		}
+
+		var live []*entity.SearchDocument
+		var deleted []uuid.UUID
+		for _, doc := range docs {
+			if doc.Deleted {
+				deleted = append(deleted, doc.ID)
+			} else {
+				live = append(live, doc)
+			}
+		}
+		if err := s.index.Upsert(ctx, live); err != nil {
+			return err
+		}
+		if err := s.index.Delete(ctx, deleted); err != nil {
+			return err
+		}
+		if err := s.index.Flush(ctx); err != nil {
+			return err
+		}
+		result.Indexed += len(live)
+		result.Removed += len(deleted)
+
+		if len(docs) > 0 {
+			last := docs[len(docs)-1]
+			w.SyncedUntil, w.LastID = last.UpdatedAt, last.ID
+		}
+		if len(docs) > 0 || first {
+			if err := s.repo.SaveAnalyticsWatermark(ctx, w, len(docs), runAt); err != nil {
+				return err
+			}
+		}
+		if len(docs) < s.batchSize {
+			return nil
+		}
+	}
+}
+
+// Rebuild empties the index and its watermark, so the next sync indexes every
+// finding again and drops findings purged from PostgreSQL
+func (s *SearchService) Rebuild(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureSchemaLocked(ctx); err != nil {
+		return err
+	}
+	if err := s.index.Reset(ctx); err != nil {
+		return fmt.Errorf("failed to reset search index: %w", err)
+	}
+	return s.repo.ResetAnalyticsWatermarks(ctx, s.index.Name())
+}
+
+// StartSyncWorker indexes new and changed findings every few seconds, so they
+// are searchable shortly after ingest
+func (s *SearchService) StartSyncWorker(ctx context.Context, intervalSeconds int) {
+	if intervalSeconds < 1 {
+		intervalSeconds = defaultSearchSyncSeconds
+	}
+
+	ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	log.Printf("🕰️  Starting search sync worker (index: %s, interval: %d seconds)", s.index.Name(), intervalSeconds)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Search sync worker stopped")
+			return
+		case <-ticker.C:
+			result, err := s.Sync(ctx)
+			if err != nil {
+				log.Printf("❌ Error syncing search index %s: %v", s.index.Name(), err)
+				continue
+			}
+			if result.Indexed+result.Removed > 0 {
+				log.Printf("✅ Search index %s: %d findings indexed, %d removed", s.index.Name(), result.Indexed, result.Removed)
+			}
+		}
+	}
+}
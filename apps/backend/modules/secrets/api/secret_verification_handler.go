@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/arc-platform/backend/modules/secrets/service"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SecretVerificationHandler handles the verification opt-in and secret verifications
+type SecretVerificationHandler struct {
+	service *service.SecretVerificationService
+}
+
+// NewSecretVerificationHandler creates a new secret verification handler
+func NewSecretVerificationHandler(service *service.SecretVerificationService) *SecretVerificationHandler {
+	return &SecretVerificationHandler{service: service}
+}
+
+// GetSettings handles GET /api/v1/secrets/verification/settings
+func (h *SecretVerificationHandler) GetSettings(c *gin.Context) {
+	settings, err := h.service.GetSettings(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get secret verification settings", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// UpdateSettings handles PUT /api/v1/secrets/verification/settings
+func (h *SecretVerificationHandler) UpdateSettings(c *gin.Context) {
+	var input service.SecretVerificationSettingsInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(sharedapi.RequestContext(c), input, actorName(c))
+	if err != nil {
+		c.JSON(statusForVerificationError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": settings})
+}
+
+// Run handles POST /api/v1/secrets/verification/run
+func (h *SecretVerificationHandler) Run(c *gin.Context) {
+	result, err := h.service.Run(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(statusForVerificationError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// GetVerification handles GET /api/v1/findings/:id/secret-verification
+func (h *SecretVerificationHandler) GetVerification(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid finding ID"})
+		return
+	}
+
+	verification, err := h.service.GetVerification(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get secret verification", "details": err.Error()})
+		return
+	}
+	if verification == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Finding has not been verified"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": verification})
+}
+
+// VerifyFinding handles POST /api/v1/findings/:id/secret-verification
+func (h *SecretVerificationHandler) VerifyFinding(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid finding ID"})
+		return
+	}
+
+	verification, err := h.service.VerifyFinding(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForVerificationError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": verification})
+}
+
+// actorName returns the caller's email, or "system" for anonymous requests
+func actorName(c *gin.Context) string {
+	if email, ok := c.Get("user_email"); ok {
+		if s, ok := email.(string); ok && s != "" {
+			return s
+		}
+	}
+	return "system"
+}
+
+func statusForVerificationError(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSecretVerificationDisabled):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrFindingNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrNotSecretFinding), errors.Is(err, service.ErrUnsupportedSecretProvider):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
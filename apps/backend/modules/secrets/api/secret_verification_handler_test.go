@@ -0,0 +1,28 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/secrets/service"
+)
+
+func TestStatusForVerificationError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{service.ErrSecretVerificationDisabled, http.StatusConflict},
+		{service.ErrFindingNotFound, http.StatusNotFound},
+		{service.ErrNotSecretFinding, http.StatusBadRequest},
+		{fmt.Errorf("%w %q", service.ErrUnsupportedSecretProvider, "gitlab"), http.StatusBadRequest},
+		// Database errors mentioning a missing relation are not the caller's fault
+		{errors.New(`pq: relation "secret_verifications" does not exist: not found`), http.StatusInternalServerError},
+	} {
+		if got := statusForVerificationError(tc.err); got != tc.want {
+			t.Errorf("statusForVerificationError(%v) = %d, want %d", tc.err, got, tc.want)
+		}
+	}
+}
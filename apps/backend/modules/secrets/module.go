@@ -0,0 +1,88 @@
+package secrets
+
+import (
+	"context"
+	"log"
+
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/secrets/api"
+	"github.com/arc-platform/backend/modules/secrets/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/gin-gonic/gin"
+)
+
+// SecretsModule verifies whether credentials found by Secrets findings are live,
+// for tenants that opt in, marking findings ACTIVE_SECRET or STALE
+type SecretsModule struct {
+	verificationService *service.SecretVerificationService
+	verificationHandler *api.SecretVerificationHandler
+
+	// The opt-in and probes are managed by admins
+	authMiddleware *middleware.AuthMiddleware
+
+	deps         *interfaces.ModuleDependencies
+	cancelWorker context.CancelFunc
+}
+
+// NewSecretsModule creates a new secrets module
+func NewSecretsModule() *SecretsModule {
+	return &SecretsModule{}
+}
+
+// Name returns the module name
+func (m *SecretsModule) Name() string {
+	return "secrets"
+}
+
+// Initialize sets up the module and starts the verification worker when enabled
+func (m *SecretsModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("🔑 Initializing Secrets Module...")
+
+	var cfg config.SecretVerificationConfig
+	if deps.Config != nil {
+		cfg = deps.Config.SecretVerify
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.verificationService = service.NewSecretVerificationService(repo, deps.AuditLogger, cfg)
+	m.verificationHandler = api.NewSecretVerificationHandler(m.verificationService)
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	if cfg.WorkerEnabled {
+		var workerCtx context.Context
+		workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+		go m.verificationService.StartWorker(workerCtx, cfg.IntervalMinutes)
+	}
+
+	log.Println("✅ Secrets Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *SecretsModule) RegisterRoutes(router *gin.RouterGroup) {
+	admin := m.authMiddleware.RequireRole("admin")
+
+	verification := router.Group("/secrets/verification")
+	{
+		verification.GET("/settings", m.verificationHandler.GetSettings)
+		verification.PUT("/settings", admin, m.verificationHandler.UpdateSettings)
+		verification.POST("/run", admin, m.verificationHandler.Run)
+	}
+
+	router.GET("/findings/:id/secret-verification", m.verificationHandler.GetVerification)
+	router.POST("/findings/:id/secret-verification", admin, m.verificationHandler.VerifyFinding)
+
+	log.Printf("🔑 Secrets routes registered")
+}
+
+// Shutdown stops the verification worker
+func (m *SecretsModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Secrets Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
+	return nil
+}
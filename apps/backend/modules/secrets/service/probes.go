@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// credential is a secret read out of a finding's matches
+type credential struct {
+	ID     string // Access key ID for AWS; empty for bearer tokens
+	Secret string
+}
+
+// probeResult is what a provider said about a credential
+type probeResult struct {
+	Status   string
+	Identity string
+	Detail   string
+}
+
+// probe checks whether one provider's credentials are live. Verify must only make
+// read-only calls that change nothing on the provider's side.
+type probe interface {
+	Provider() string
+	// Extract finds a credential of the provider in a finding's values
+	Extract(values []string) (credential, bool)
+	Verify(ctx context.Context, cred credential) probeResult
+}
+
+func inconclusive(format string, args ...interface{}) probeResult {
+	return probeResult{Status: entity.SecretStatusUnverified, Detail: fmt.Sprintf(format, args...)}
+}
+
+// ============================================================================
+// AWS: STS GetCallerIdentity, which needs no permissions and has no side effects
+// ============================================================================
+
+var (
+	awsAccessKeyIDPattern = regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)
+	awsSecretKeyPattern   = regexp.MustCompile(`(?:^|[^A-Za-z0-9/+=])([A-Za-z0-9/+=]{40})(?:$|[^A-Za-z0-9/+=])`)
+)
+
+type awsProbe struct {
+	region   string
+	endpoint string // Overrides the STS endpoint; for tests
+	client   *http.Client
+}
+
+func (p *awsProbe) Provider() string { return entity.SecretProviderAWS }
+
+// Extract pairs the first access key ID with the first secret access key. Only
+// long-term (AKIA) keys are taken; temporary keys need a session token.
+func (p *awsProbe) Extract(values []string) (credential, bool) {
+	var cred credential
+	for _, v := range values {
+		if cred.ID == "" {
+			cred.ID = awsAccessKeyIDPattern.FindString(v)
+		}
+		if cred.Secret == "" {
+			for _, m := range awsSecretKeyPattern.FindAllStringSubmatch(v, -1) {
+				if isMixedCase(m[1]) {
+					cred.Secret = m[1]
+					break
+				}
+			}
+		}
+	}
+	return cred, cred.ID != "" && cred.Secret != ""
+}
+
+func (p *awsProbe) Verify(ctx context.Context, cred credential) probeResult {
+	cfg := &aws.Config{
+		Region:      aws.String(p.region),
+		Credentials: credentials.NewStaticCredentials(cred.ID, cred.Secret, ""),
+		HTTPClient:  p.client,
+		MaxRetries:  aws.Int(0),
+	}
+	if p.endpoint != "" {
+		cfg.Endpoint = aws.String(p.endpoint)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return inconclusive("failed to create AWS session: %v", err)
+	}
+
+	out, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok {
+			switch aerr.Code() {
+			case "InvalidClientTokenId":
+				return probeResult{Status: entity.SecretStatusStale, Detail: "access key ID is unknown or deactivated"}
+			case "SignatureDoesNotMatch":
+				return inconclusive("access key ID exists but the secret found with it does not match")
+			}
+			return inconclusive("STS returned %s", aerr.Code())
+		}
+		return inconclusive("STS request failed: %v", err)
+	}
+	return probeResult{Status: entity.SecretStatusActive, Identity: aws.StringValue(out.Arn)}
+}
+
+// isMixedCase tells base64 secrets from hex digests and other 40 character tokens
+func isMixedCase(s string) bool {
+	return strings.ToLower(s) != s && strings.ToUpper(s) != s
+}
+
+// ============================================================================
+// GitHub: GET /user with the token
+// ============================================================================
+
+var githubTokenPattern = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{82})\b`)
+
+type githubProbe struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *githubProbe) Provider() string { return entity.SecretProviderGitHub }
+
+func (p *githubProbe) Extract(values []string) (credential, bool) {
+	return extractToken(githubTokenPattern, values)
+}
+
+func (p *githubProbe) Verify(ctx context.Context, cred credential) probeResult {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/user", nil)
+	if err != nil {
+		return inconclusive("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+cred.Secret)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "arc-hawk-secret-verification")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return inconclusive("GitHub request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var user struct {
+			Login string `json:"login"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&user); err != nil {
+			return inconclusive("failed to read GitHub response: %v", err)
+		}
+		return probeResult{Status: entity.SecretStatusActive, Identity: user.Login}
+	case http.StatusUnauthorized:
+		return probeResult{Status: entity.SecretStatusStale, Detail: "GitHub rejected the token"}
+	default:
+		return inconclusive("GitHub returned HTTP %d", resp.StatusCode)
+	}
+}
+
+// ============================================================================
+// Slack: auth.test with the token
+// ============================================================================
+
+var slackTokenPattern = regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,250}\b`)
+
+// Slack errors meaning the token itself is no longer valid
+var slackStaleErrors = map[string]bool{
+	"invalid_auth": true, "account_inactive": true, "token_revoked": true, "token_expired": true, "not_authed": true,
+}
+
+type slackProbe struct {
+	baseURL string
+	client  *http.Client
+}
+
+func (p *slackProbe) Provider() string { return entity.SecretProviderSlack }
+
+func (p *slackProbe) Extract(values []string) (credential, bool) {
+	return extractToken(slackTokenPattern, values)
+}
+
+func (p *slackProbe) Verify(ctx context.Context, cred credential) probeResult {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/auth.test", nil)
+	if err != nil {
+		return inconclusive("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+cred.Secret)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return inconclusive("Slack request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return inconclusive("Slack returned HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		Team  string `json:"team"`
+		User  string `json:"user"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return inconclusive("failed to read Slack response: %v", err)
+	}
+	switch {
+	case result.OK:
+		return probeResult{Status: entity.SecretStatusActive, Identity: strings.Trim(result.Team+"/"+result.User, "/")}
+	case slackStaleErrors[result.Error]:
+		return probeResult{Status: entity.SecretStatusStale, Detail: "Slack rejected the token: " + result.Error}
+	default:
+		return inconclusive("Slack returned %s", result.Error)
+	}
+}
+
+func extractToken(pattern *regexp.Regexp, values []string) (credential, bool) {
+	for _, v := range values {
+		if token := pattern.FindString(v); token != "" {
+			return credential{Secret: token}, true
+		}
+	}
+	return credential{}, false
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSecretVerificationDisabled is returned when the tenant has not opted in to verification
+	ErrSecretVerificationDisabled = errors.New("secret verification is not enabled for this tenant")
+	ErrNotSecretFinding           = errors.New("invalid finding: only Secrets findings can be verified")
+	ErrUnsupportedSecretProvider  = errors.New("invalid provider")
+	ErrFindingNotFound            = persistence.ErrFindingNotFound
+)
+
+// Severities in ascending order, as findings store them
+var severityLevels = []string{"Info", "Low", "Medium", "High", "Critical"}
+
+// SecretVerificationSettingsInput is the payload for the tenant opt-in
+type SecretVerificationSettingsInput struct {
+	Enabled   bool     `json:"enabled"`
+	Providers []string `json:"providers"` // aws, github, slack; empty for all
+}
+
+// SecretVerificationRunResult reports a verification run for one tenant
+type SecretVerificationRunResult struct {
+	Checked    int `json:"checked"`
+	Active     int `json:"active"`
+	Stale      int `json:"stale"`
+	Unverified int `json:"unverified"`
+	Failed     int `json:"failed"`
+}
+
+// SecretVerificationService checks whether the credentials of Secrets findings are
+// live, for tenants that opted in, and raises or lowers finding severity accordingly
+type SecretVerificationService struct {
+	repo        *persistence.PostgresRepository
+	auditLogger interfaces.AuditLogger
+	probes      []probe
+	batchSize   int
+	recheck     time.Duration
+}
+
+// NewSecretVerificationService creates a new secret verification service
+func NewSecretVerificationService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger, cfg config.SecretVerificationConfig) *SecretVerificationService {
+	timeout := time.Duration(cfg.HTTPTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	region := cfg.AWSRegion
+	if region == "" {
+		region = "us-east-1"
+	}
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	recheckDays := cfg.RecheckDays
+	if recheckDays <= 0 {
+		recheckDays = 7
+	}
+
+	client := &http.Client{Timeout: timeout}
+	return &SecretVerificationService{
+		repo:        repo,
+		auditLogger: auditLogger,
+		probes: []probe{
+			&awsProbe{region: region, client: client},
+			&githubProbe{baseURL: "https://api.github.com", client: client},
+			&slackProbe{baseURL: "https://slack.com", client: client},
+		},
+		batchSize: batchSize,
+		recheck:   time.Duration(recheckDays) * 24 * time.Hour,
+	}
+}
+
+// GetSettings returns the tenant's opt-in; tenants that never set it are disabled
+func (s *SecretVerificationService) GetSettings(ctx context.Context) (*entity.SecretVerificationSettings, error) {
+	settings, err := s.repo.GetSecretVerificationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		tenantID, err := persistence.EnsureTenantID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		settings = &entity.SecretVerificationSettings{TenantID: tenantID, Providers: []string{}}
+	}
+	return settings, nil
+}
+
+// UpdateSettings opts the tenant in to, or out of, verification
+func (s *SecretVerificationService) UpdateSettings(ctx context.Context, input SecretVerificationSettingsInput, updatedBy string) (*entity.SecretVerificationSettings, error) {
+	providers, err := normalizeProviders(input.Providers)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &entity.SecretVerificationSettings{Enabled: input.Enabled, Providers: providers, UpdatedBy: updatedBy}
+	if err := s.repo.SetSecretVerificationSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "SECRET_VERIFICATION_SETTINGS_UPDATED", "secret_verification_settings", settings.TenantID.String(), map[string]interface{}{
+		"enabled":   settings.Enabled,
+		"providers": settings.Providers,
+	})
+	return settings, nil
+}
+
+// GetVerification returns the latest verification of a finding, or nil
+func (s *SecretVerificationService) GetVerification(ctx context.Context, findingID uuid.UUID) (*entity.SecretVerification, error) {
+	return s.repo.GetSecretVerification(ctx, findingID)
+}
+
+// VerifyFinding probes the credential of one Secrets finding now
+func (s *SecretVerificationService) VerifyFinding(ctx context.Context, findingID uuid.UUID) (*entity.SecretVerification, error) {
+	settings, err := s.repo.GetSecretVerificationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil || !settings.Enabled {
+		return nil, ErrSecretVerificationDisabled
+	}
+
+	finding, err := s.repo.GetFindingByID(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	isSecret, err := s.repo.IsSecretFinding(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	if !isSecret {
+		return nil, ErrNotSecretFinding
+	}
+
+	return s.verifyFinding(ctx, settings, finding, map[string]probeResult{})
+}
+
+// Run verifies a batch of the tenant's unverified or due Secrets findings
+func (s *SecretVerificationService) Run(ctx context.Context) (*SecretVerificationRunResult, error) {
+	settings, err := s.repo.GetSecretVerificationSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil || !settings.Enabled {
+		return nil, ErrSecretVerificationDisabled
+	}
+
+	ids, err := s.repo.ListSecretFindingsToVerify(ctx, time.Now().Add(-s.recheck), s.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// A credential repeated across findings is probed once per run
+	seen := map[string]probeResult{}
+	result := &SecretVerificationRunResult{}
+	for _, id := range ids {
+		finding, err := s.repo.GetFindingByID(ctx, id)
+		if err != nil {
+			log.Printf("WARN: Failed to load finding %s for secret verification: %v", id, err)
+			result.Failed++
+			continue
+		}
+		v, err := s.verifyFinding(ctx, settings, finding, seen)
+		if err != nil {
+			log.Printf("WARN: Failed to verify secret of finding %s: %v", id, err)
+			result.Failed++
+			continue
+		}
+		result.Checked++
+		switch v.Status {
+		case entity.SecretStatusActive:
+			result.Active++
+		case entity.SecretStatusStale:
+			result.Stale++
+		default:
+			result.Unverified++
+		}
+	}
+	return result, nil
+}
+
+// StartWorker verifies due Secrets findings every interval, for every tenant that opted in
+func (s *SecretVerificationService) StartWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes <= 0 {
+		intervalMinutes = 60
+	}
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🔑 Secret verification worker started (every %d minutes)", intervalMinutes)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Secret verification worker stopped")
+			return
+		case <-ticker.C:
+			s.runAllTenants(ctx)
+		}
+	}
+}
+
+func (s *SecretVerificationService) runAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListSecretVerificationTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Secret verification failed to list tenants: %v", err)
+		return
+	}
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		result, err := s.Run(tenantCtx)
+		if err != nil {
+			log.Printf("❌ Secret verification failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if result.Checked > 0 {
+			log.Printf("🔑 Secret verification for tenant %s: %d active, %d stale, %d unverified, %d failed",
+				tenantID, result.Active, result.Stale, result.Unverified, result.Failed)
+		}
+	}
+}
+
+// verifyFinding probes the finding's credential, stores the outcome and adjusts its severity
+func (s *SecretVerificationService) verifyFinding(ctx context.Context, settings *entity.SecretVerificationSettings, finding *entity.Finding, seen map[string]probeResult) (*entity.SecretVerification, error) {
+	previous, err := s.repo.GetSecretVerification(ctx, finding.ID)
+	if err != nil {
+		return nil, err
+	}
+	original := finding.Severity
+	if previous != nil {
+		original = previous.OriginalSeverity
+	}
+
+	provider, result := s.probe(ctx, settings, findingValues(finding), seen)
+	v := &entity.SecretVerification{
+		ID:               uuid.New(),
+		FindingID:        finding.ID,
+		Provider:         provider,
+		Status:           result.Status,
+		Identity:         result.Identity,
+		Detail:           result.Detail,
+		OriginalSeverity: original,
+		Severity:         verifiedSeverity(original, result.Status),
+	}
+	if err := s.repo.SaveSecretVerification(ctx, v); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "SECRET_VERIFIED", "finding", finding.ID.String(), map[string]interface{}{
+		"provider": v.Provider,
+		"status":   v.Status,
+		"severity": v.Severity,
+	})
+	return v, nil
+}
+
+// probe runs the first enabled provider whose credential is found in values
+func (s *SecretVerificationService) probe(ctx context.Context, settings *entity.SecretVerificationSettings, values []string, seen map[string]probeResult) (string, probeResult) {
+	for _, p := range s.probes {
+		if !settings.AllowsProvider(p.Provider()) {
+			continue
+		}
+		cred, ok := p.Extract(values)
+		if !ok {
+			continue
+		}
+		key := credentialKey(p.Provider(), cred)
+		if result, ok := seen[key]; ok {
+			return p.Provider(), result
+		}
+		result := p.Verify(ctx, cred)
+		seen[key] = result
+		return p.Provider(), result
+	}
+	return "", inconclusive("no verifiable credential found in the finding")
+}
+
+func (s *SecretVerificationService) audit(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, resourceType, resourceID, metadata); err != nil {
+		log.Printf("WARN: Failed to record %s audit event: %v", action, err)
+	}
+}
+
+// findingValues returns the values a credential may be read from. Matches hold the
+// raw values; the sample text adds what surrounds them, such as the secret key
+// stored next to an access key ID, when it is not masked.
+func findingValues(f *entity.Finding) []string {
+	values := make([]string, 0, len(f.Matches)+1)
+	values = append(values, f.Matches...)
+	if f.SampleText != "" {
+		values = append(values, f.SampleText)
+	}
+	return values
+}
+
+// verifiedSeverity raises live credentials to Critical and lowers stale ones one
+// level, down to Low; inconclusive probes keep the original severity
+func verifiedSeverity(original, status string) string {
+	level := -1
+	for i, s := range severityLevels {
+		if strings.EqualFold(s, original) {
+			level = i
+		}
+	}
+	switch status {
+	case entity.SecretStatusActive:
+		return "Critical"
+	case entity.SecretStatusStale:
+		if level > 1 {
+			return severityLevels[level-1]
+		}
+	}
+	return original
+}
+
+// credentialKey identifies a credential within a run without keeping it in memory as a key
+func credentialKey(provider string, cred credential) string {
+	sum := sha256.Sum256([]byte(provider + "\x00" + cred.ID + "\x00" + cred.Secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeProviders(providers []string) ([]string, error) {
+	out := []string{}
+	seen := map[string]bool{}
+	for _, p := range providers {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p == "" || seen[p] {
+			continue
+		}
+		supported := false
+		for _, known := range entity.SecretProviders {
+			supported = supported || known == p
+		}
+		if !supported {
+			return nil, fmt.Errorf("%w %q: must be one of %s", ErrUnsupportedSecretProvider, p, strings.Join(entity.SecretProviders, ", "))
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out, nil
+}
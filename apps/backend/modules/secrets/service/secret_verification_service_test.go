@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestVerifiedSeverity(t *testing.T) {
+	for _, tc := range []struct {
+		original, status, want string
+	}{
+		{"Medium", entity.SecretStatusActive, "Critical"},
+		{"High", entity.SecretStatusStale, "Medium"},
+		{"Medium", entity.SecretStatusStale, "Low"},
+		{"Low", entity.SecretStatusStale, "Low"},
+		{"High", entity.SecretStatusUnverified, "High"},
+	} {
+		if got := verifiedSeverity(tc.original, tc.status); got != tc.want {
+			t.Errorf("verifiedSeverity(%s, %s) = %s, want %s", tc.original, tc.status, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeProviders(t *testing.T) {
+	providers, err := normalizeProviders([]string{" AWS ", "github", "aws", ""})
+	if err != nil || len(providers) != 2 || providers[0] != "aws" || providers[1] != "github" {
+		t.Errorf("unexpected providers %v %v", providers, err)
+	}
+	if _, err := normalizeProviders([]string{"gitlab"}); !errors.Is(err, ErrUnsupportedSecretProvider) {
+		t.Error("expected an unsupported provider to be rejected")
+	}
+}
+
+func TestSettingsAllowProvider(t *testing.T) {
+	var unset *entity.SecretVerificationSettings
+	if unset.AllowsProvider(entity.SecretProviderAWS) {
+		t.Error("expected tenants without settings to be opted out")
+	}
+	if (&entity.SecretVerificationSettings{Providers: []string{"aws"}}).AllowsProvider("aws") {
+		t.Error("expected disabled settings to allow no provider")
+	}
+	only := &entity.SecretVerificationSettings{Enabled: true, Providers: []string{"github"}}
+	if only.AllowsProvider("aws") || !only.AllowsProvider("github") {
+		t.Error("expected only the listed providers to be allowed")
+	}
+	if !(&entity.SecretVerificationSettings{Enabled: true}).AllowsProvider("slack") {
+		t.Error("expected an empty provider list to allow every provider")
+	}
+}
+
+type countingProbe struct {
+	calls int
+}
+
+func (p *countingProbe) Provider() string { return entity.SecretProviderGitHub }
+
+func (p *countingProbe) Extract(values []string) (credential, bool) {
+	return extractToken(githubTokenPattern, values)
+}
+
+func (p *countingProbe) Verify(ctx context.Context, cred credential) probeResult {
+	p.calls++
+	return probeResult{Status: entity.SecretStatusActive}
+}
+
+func TestProbeSkipsDisabledProvidersAndRepeats(t *testing.T) {
+	p := &countingProbe{}
+	s := &SecretVerificationService{probes: []probe{p}}
+	values := []string{"ghp_" + "0123456789abcdefghijABCDEFGHIJ012345"}
+	seen := map[string]probeResult{}
+
+	disabled := &entity.SecretVerificationSettings{Enabled: true, Providers: []string{"aws"}}
+	if provider, r := s.probe(context.Background(), disabled, values, seen); provider != "" || r.Status != entity.SecretStatusUnverified {
+		t.Errorf("expected no probe for a disabled provider, got %s %+v", provider, r)
+	}
+
+	enabled := &entity.SecretVerificationSettings{Enabled: true}
+	for i := 0; i < 3; i++ {
+		if provider, r := s.probe(context.Background(), enabled, values, seen); provider != "github" || r.Status != entity.SecretStatusActive {
+			t.Errorf("expected an active GitHub token, got %s %+v", provider, r)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("expected a repeated credential to be probed once, got %d calls", p.calls)
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestContext returns the request context carrying the tenant and user set by the
+// auth middleware, using the keys expected by persistence.EnsureTenantID and the audit logger.
+// Anonymous requests fall back to the Nil UUID (default tenant).
+func RequestContext(c *gin.Context) context.Context {
+	ctx := c.Request.Context()
+
+	if tenantID, exists := c.Get("tenant_id"); exists {
+		ctx = context.WithValue(ctx, "tenant_id", tenantID)
+	} else if ctx.Value("tenant_id") == nil {
+		ctx = context.WithValue(ctx, "tenant_id", uuid.Nil)
+	}
+
+	if userID, exists := c.Get("user_id"); exists {
+		ctx = context.WithValue(ctx, "user_id", userID)
+	}
+
+	return ctx
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Machine-readable error codes returned in the error envelope
+const (
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeMalformedBody        = "MALFORMED_BODY"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodeQuotaExceeded        = "QUOTA_EXCEEDED"
+	CodeInvalidSignature     = "INVALID_SIGNATURE"
+	CodeSignatureRequired    = "SIGNATURE_REQUIRED"
+	CodeUnsupportedSchema    = "UNSUPPORTED_SCHEMA_VERSION"
+	CodeInternal             = "INTERNAL_ERROR"
+	CodeUnavailable          = "SERVICE_UNAVAILABLE"
+)
+
+// CatalogueEntry documents an error code for API consumers
+type CatalogueEntry struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// ErrorCatalogue lists every error code the API returns, with its HTTP status
+var ErrorCatalogue = []CatalogueEntry{
+	{CodeBadRequest, http.StatusBadRequest, "The request is invalid, e.g. a malformed path or query parameter"},
+	{CodeMalformedBody, http.StatusBadRequest, "The request body is not valid JSON or a field has the wrong type"},
+	{CodeValidationFailed, http.StatusUnprocessableEntity, "The request body is well-formed but fails validation; details lists each failing field"},
+	{CodeUnauthorized, http.StatusUnauthorized, "Authentication is missing or invalid"},
+	{CodeForbidden, http.StatusForbidden, "The caller lacks the role or permission required"},
+	{CodeNotFound, http.StatusNotFound, "The requested resource does not exist for the tenant"},
+	{CodeConflict, http.StatusConflict, "The request conflicts with the resource's current state"},
+	{CodeIdempotencyKeyReused, http.StatusUnprocessableEntity, "The Idempotency-Key was already used for a request with a different URL or body"},
+	{CodePayloadTooLarge, http.StatusRequestEntityTooLarge, "The request body exceeds the configured limit"},
+	{CodeRateLimited, http.StatusTooManyRequests, "Too many requests; retry after the indicated delay"},
+	{CodeQuotaExceeded, http.StatusPaymentRequired, "The tenant's findings quota is used up; delete findings or raise the quota"},
+	{CodeInvalidSignature, http.StatusUnauthorized, "The scan payload's signature headers name an unknown or revoked key, or the signature does not match the body"},
+	{CodeSignatureRequired, http.StatusForbidden, "The tenant requires signed scan payloads and the payload is unsigned"},
+	{CodeUnsupportedSchema, http.StatusBadRequest, "The scan payload's schema_version is older or newer than the backend accepts; GET /api/v1/scans/capabilities lists the supported versions"},
+	{CodeInternal, http.StatusInternalServerError, "An unexpected server error occurred"},
+	{CodeUnavailable, http.StatusServiceUnavailable, "A dependency is temporarily unavailable"},
+}
+
+// StatusForCode returns the HTTP status of a catalogued error code
+func StatusForCode(code string) int {
+	for _, entry := range ErrorCatalogue {
+		if entry.Code == code {
+			return entry.Status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// Fail sends a catalogued error in the standard envelope with the code's HTTP status
+func Fail(c *gin.Context, code, message string, details interface{}) {
+	Error(c, StatusForCode(code), code, message, details)
+}
+
+// GetErrorCatalogue handles GET /api/v1/errors
+func GetErrorCatalogue(c *gin.Context) {
+	Success(c, ErrorCatalogue)
+}
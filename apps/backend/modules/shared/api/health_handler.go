@@ -3,9 +3,12 @@ package api
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/arc-platform/backend/modules/shared/infrastructure/circuitbreaker"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/kafka"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
 	"github.com/gin-gonic/gin"
 )
@@ -14,6 +17,7 @@ import (
 type HealthHandler struct {
 	db        *sql.DB
 	neo4jRepo *persistence.Neo4jRepository
+	kafka     *kafka.Producer // nil when Kafka publishing is disabled
 }
 
 // NewHealthHandler creates a new health handler
@@ -24,6 +28,11 @@ func NewHealthHandler(db *sql.DB, neo4jRepo *persistence.Neo4jRepository) *Healt
 	}
 }
 
+// SetKafkaProducer adds the Kafka event producer to the component report
+func (h *HealthHandler) SetKafkaProducer(producer *kafka.Producer) {
+	h.kafka = producer
+}
+
 // ComponentHealth represents the health status of a system component
 type ComponentHealth struct {
 	Name      string    `json:"name"`
@@ -31,6 +40,13 @@ type ComponentHealth struct {
 	LastCheck time.Time `json:"last_check"`
 	Message   string    `json:"message,omitempty"`
 	Details   string    `json:"details,omitempty"`
+
+	// Neo4j only: breaker state and lineage syncs deferred while it is not closed
+	CircuitBreaker *circuitbreaker.Snapshot `json:"circuit_breaker,omitempty"`
+	DeferredSyncs  *int                     `json:"deferred_syncs,omitempty"`
+
+	// Kafka only: integration event delivery counters
+	EventDelivery *kafka.Stats `json:"event_delivery,omitempty"`
 }
 
 // HealthResponse represents the overall health response
@@ -75,6 +91,15 @@ func (h *HealthHandler) GetComponentsHealth(c *gin.Context) {
 		degraded = true // Scanner offline is degraded, not critical
 	}
 
+	// Check Kafka event delivery; downstream consumers falling behind is degraded, not critical
+	if h.kafka != nil {
+		kafkaHealth := h.checkKafka()
+		components = append(components, kafkaHealth)
+		if kafkaHealth.Status != "online" {
+			degraded = true
+		}
+	}
+
 	// Determine overall status
 	status := "healthy"
 	if !overallHealthy {
@@ -125,6 +150,12 @@ func (h *HealthHandler) checkNeo4j(ctx context.Context) ComponentHealth {
 		LastCheck: time.Now(),
 	}
 
+	breaker := h.neo4jRepo.CircuitBreaker().Snapshot()
+	health.CircuitBreaker = &breaker
+	if pending, _, err := persistence.NewPostgresRepository(h.db).GetLineageOutboxStats(ctx); err == nil {
+		health.DeferredSyncs = &pending
+	}
+
 	driver := h.neo4jRepo.GetDriver()
 	if driver == nil {
 		health.Status = "offline"
@@ -139,11 +170,43 @@ func (h *HealthHandler) checkNeo4j(ctx context.Context) ComponentHealth {
 		return health
 	}
 
+	if breaker.State != circuitbreaker.StateClosed {
+		health.Status = "degraded"
+		health.Message = "Circuit breaker " + string(breaker.State) + " - lineage syncs deferred"
+		if health.DeferredSyncs != nil {
+			health.Details = fmt.Sprintf("%d lineage sync(s) waiting in the outbox", *health.DeferredSyncs)
+		}
+		return health
+	}
+
 	health.Status = "online"
 	health.Message = "Graph database operational"
 	return health
 }
 
+func (h *HealthHandler) checkKafka() ComponentHealth {
+	stats := h.kafka.Stats()
+	health := ComponentHealth{
+		Name:          "Kafka Event Bus",
+		LastCheck:     time.Now(),
+		EventDelivery: &stats,
+	}
+
+	if !stats.Healthy() {
+		health.Status = "degraded"
+		health.Message = "Event delivery failing"
+		health.Details = stats.LastError
+		return health
+	}
+
+	health.Status = "online"
+	health.Message = "Publishing to " + stats.Topic
+	if stats.Dropped > 0 {
+		health.Details = fmt.Sprintf("%d event(s) dropped because the buffer was full", stats.Dropped)
+	}
+	return health
+}
+
 func (h *HealthHandler) checkScanner(ctx context.Context) ComponentHealth {
 	health := ComponentHealth{
 		Name:      "Scanner Service",
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// JobsHandler lets admins inspect, retry and cancel background jobs
+type JobsHandler struct {
+	queue *jobs.Queue
+}
+
+// NewJobsHandler creates a new jobs handler
+func NewJobsHandler(queue *jobs.Queue) *JobsHandler {
+	return &JobsHandler{queue: queue}
+}
+
+// ListJobs handles GET /api/v1/admin/jobs?status=&type=&limit=&offset=
+func (h *JobsHandler) ListJobs(c *gin.Context) {
+	filter := entity.JobFilter{
+		Status: c.Query("status"),
+		Type:   c.Query("type"),
+		Limit:  50,
+	}
+	if filter.Status != "" && !entity.IsJobStatus(filter.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of pending, running, succeeded, failed, cancelled"})
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 500"})
+			return
+		}
+		filter.Limit = limit
+	}
+	if v := c.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative integer"})
+			return
+		}
+		filter.Offset = offset
+	}
+
+	list, total, err := h.queue.List(RequestContext(c), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list jobs",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": list, "total": total})
+}
+
+// GetJob handles GET /api/v1/admin/jobs/:id
+func (h *JobsHandler) GetJob(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.queue.Get(RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForJobError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": job})
+}
+
+// RetryJob handles POST /api/v1/admin/jobs/:id/retry. Only failed or cancelled
+// jobs can be retried; they run again with a fresh attempt budget.
+func (h *JobsHandler) RetryJob(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.queue.Retry(RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForJobError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": job})
+}
+
+// CancelJob handles POST /api/v1/admin/jobs/:id/cancel. Only pending jobs can be
+// cancelled; a running attempt is left to finish.
+func (h *JobsHandler) CancelJob(c *gin.Context) {
+	id, ok := parseJobID(c)
+	if !ok {
+		return
+	}
+
+	job, err := h.queue.Cancel(RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForJobError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": job})
+}
+
+func parseJobID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func statusForJobError(err error) int {
+	switch {
+	case errors.Is(err, jobs.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, jobs.ErrJobState):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
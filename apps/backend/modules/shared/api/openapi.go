@@ -0,0 +1,627 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OpenAPIVersion is the OpenAPI version of the generated document
+const OpenAPIVersion = "3.0.3"
+
+// Operation describes one route for the OpenAPI document: what it is for, its
+// query parameters and the Go types of its request and response bodies. Routes
+// without an Operation are still listed, with a generic response.
+type Operation struct {
+	Method      string // GET, POST, ...
+	Path        string // As registered with gin, e.g. /api/v1/assets/:id
+	Summary     string
+	Description string
+	Query       []Parameter
+	Request     interface{} // Zero value of the request body type, nil for none
+	Status      int         // Success status, 200 unless set
+	Response    interface{} // Zero value of the response body type; see Data and Envelope
+}
+
+// Parameter documents a query parameter
+type Parameter struct {
+	Name        string
+	Type        string // string, integer, boolean or number
+	Description string
+	Required    bool
+}
+
+// OperationDocumenter is implemented by modules that describe their routes
+type OperationDocumenter interface {
+	OpenAPIOperations() []Operation
+}
+
+// wrappedResponse marks a response body that wraps the described type
+type wrappedResponse struct {
+	field string
+	value interface{}
+}
+
+// Data describes a response sent as gin.H{"data": value}
+func Data(value interface{}) interface{} {
+	return wrappedResponse{field: "data", value: value}
+}
+
+// Envelope describes a response sent by Success or Created, i.e. an APIResponse
+// with value as its data
+func Envelope(value interface{}) interface{} {
+	return wrappedResponse{field: "envelope", value: value}
+}
+
+// legacyError is the error body of handlers that predate the error envelope
+type legacyError struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+	Details string `json:"details,omitempty"`
+}
+
+// OpenAPIDocument is a generated OpenAPI 3 document
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components OpenAPIComponents                       `json:"components"`
+}
+
+// OpenAPIInfo identifies the API
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIComponents holds the named schemas and the bearer token scheme
+type OpenAPIComponents struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes how requests authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// OpenAPIOperation is one method on a path
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *OpenAPIBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security"`
+}
+
+// OpenAPIParameter is a path or query parameter
+type OpenAPIParameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// OpenAPIBody is a JSON request body
+type OpenAPIBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse is a response for one status
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType carries a body schema
+type OpenAPIMediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is the subset of JSON Schema the generator emits
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// BuildOpenAPI generates the document for every registered route. Routes in
+// public skip the bearer token requirement. It also returns the operations that
+// match no route, so stale descriptions can be reported.
+func BuildOpenAPI(routes gin.RoutesInfo, public map[string]bool, operations []Operation) (*OpenAPIDocument, []string) {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    OpenAPIInfo{Title: "ARC-Hawk API", Version: "v1"},
+		Paths:   make(map[string]map[string]*OpenAPIOperation),
+		Components: OpenAPIComponents{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+	schemas := newSchemaRegistry(doc.Components.Schemas)
+	errorSchema := &Schema{OneOf: []*Schema{schemas.of(reflect.TypeOf(APIResponse{})), schemas.of(reflect.TypeOf(legacyError{}))}}
+
+	described := make(map[string]Operation, len(operations))
+	for _, op := range operations {
+		described[op.Method+" "+op.Path] = op
+	}
+
+	handlerNames := make(map[string]int)
+	for _, route := range routes {
+		handlerNames[handlerName(route.Handler)]++
+	}
+
+	sorted := append(gin.RoutesInfo(nil), routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	for _, route := range sorted {
+		path, params := openAPIPath(route.Path)
+		operationID := handlerName(route.Handler)
+		if operationID == "" || handlerNames[operationID] > 1 {
+			operationID = derivedOperationID(route.Method, route.Path)
+		}
+
+		op := &OpenAPIOperation{
+			OperationID: operationID,
+			Tags:        []string{routeTag(route.Path)},
+			Responses: map[string]*OpenAPIResponse{
+				"default": {Description: "Error", Content: jsonContent(errorSchema)},
+			},
+			Security: []map[string][]string{{"bearerAuth": {}}},
+		}
+		if public[route.Path] {
+			op.Security = []map[string][]string{}
+		}
+		for _, name := range params {
+			op.Parameters = append(op.Parameters, OpenAPIParameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+
+		status, response := http.StatusOK, &OpenAPIResponse{Description: "Success"}
+		if d, ok := described[route.Method+" "+route.Path]; ok {
+			delete(described, route.Method+" "+route.Path)
+			op.Summary, op.Description = d.Summary, d.Description
+			for _, q := range d.Query {
+				op.Parameters = append(op.Parameters, OpenAPIParameter{
+					Name:        q.Name,
+					In:          "query",
+					Description: q.Description,
+					Required:    q.Required,
+					Schema:      &Schema{Type: parameterType(q.Type)},
+				})
+			}
+			if d.Request != nil {
+				op.RequestBody = &OpenAPIBody{Required: true, Content: jsonContent(schemas.response(d.Request))}
+			}
+			if d.Status != 0 {
+				status = d.Status
+			}
+			if d.Response != nil {
+				response.Content = jsonContent(schemas.response(d.Response))
+			}
+		}
+		op.Responses[fmt.Sprintf("%d", status)] = response
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*OpenAPIOperation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	var unmatched []string
+	for key := range described {
+		unmatched = append(unmatched, key)
+	}
+	sort.Strings(unmatched)
+	return doc, unmatched
+}
+
+// OpenAPIHandler serves the document generated once every route is registered
+type OpenAPIHandler struct {
+	mu     sync.RWMutex
+	public map[string]bool
+	doc    *OpenAPIDocument
+}
+
+// NewOpenAPIHandler creates a handler for routes with the given public paths
+func NewOpenAPIHandler(public map[string]bool) *OpenAPIHandler {
+	return &OpenAPIHandler{public: public}
+}
+
+// Build generates the document from the router's routes and returns the
+// operations that match no route
+func (h *OpenAPIHandler) Build(routes gin.RoutesInfo, operations []Operation) []string {
+	doc, unmatched := BuildOpenAPI(routes, h.public, operations)
+	h.mu.Lock()
+	h.doc = doc
+	h.mu.Unlock()
+	return unmatched
+}
+
+// GetSpec handles GET /api/v1/openapi.json
+func (h *OpenAPIHandler) GetSpec(c *gin.Context) {
+	h.mu.RLock()
+	doc := h.doc
+	h.mu.RUnlock()
+	if doc == nil {
+		Fail(c, CodeUnavailable, "OpenAPI document is not built yet", nil)
+		return
+	}
+	c.JSON(http.StatusOK, doc)
+}
+
+// OpenAPIOperations describes the routes served by this package
+func OpenAPIOperations() []Operation {
+	return []Operation{
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/errors",
+			Summary:  "List the error codes returned in the error envelope",
+			Response: Envelope([]CatalogueEntry{}),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/admin/jobs",
+			Summary: "List background jobs",
+			Query: []Parameter{
+				{Name: "status", Type: "string", Description: "pending, running, succeeded, failed or cancelled"},
+				{Name: "type", Type: "string", Description: "Job type"},
+				{Name: "limit", Type: "integer", Description: "1 to 500, default 50"},
+				{Name: "offset", Type: "integer"},
+			},
+			Response: jobListResponse{},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/api/v1/admin/jobs/:id",
+			Summary:  "Get a background job",
+			Response: Data(entity.Job{}),
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/admin/jobs/:id/retry",
+			Summary:  "Retry a failed or cancelled job",
+			Response: Data(entity.Job{}),
+		},
+		{
+			Method:   http.MethodPost,
+			Path:     "/api/v1/admin/jobs/:id/cancel",
+			Summary:  "Cancel a pending job",
+			Response: Data(entity.Job{}),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/openapi.json",
+			Summary:     "Get this OpenAPI document",
+			Description: "Generated at startup from the registered routes",
+		},
+	}
+}
+
+// handlerName is the method or function name of a route's handler, without
+// package and receiver; empty for closures
+func handlerName(full string) string {
+	name := strings.TrimSuffix(full, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" || strings.HasPrefix(name, "func") {
+		return ""
+	}
+	return name
+}
+
+// derivedOperationID builds an ID from the method and path, e.g.
+// GET /api/v1/assets/:id becomes getAssetsById
+func derivedOperationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/api/v1"), "/") {
+		if segment == "" {
+			continue
+		}
+		if segment[0] == ':' || segment[0] == '*' {
+			b.WriteString("By")
+			segment = segment[1:]
+		}
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// openAPIPath converts gin's :name and *name parameters to {name}
+func openAPIPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	var params []string
+	for i, segment := range segments {
+		if segment != "" && (segment[0] == ':' || segment[0] == '*') {
+			params = append(params, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// routeTag groups routes by their first segment under /api/v1
+func routeTag(path string) string {
+	trimmed := strings.TrimPrefix(path, "/api/v1")
+	for _, segment := range strings.Split(trimmed, "/") {
+		if segment != "" && segment[0] != ':' && segment[0] != '*' {
+			return strings.TrimSuffix(segment, ".json")
+		}
+	}
+	return "root"
+}
+
+func parameterType(t string) string {
+	if t == "" {
+		return "string"
+	}
+	return t
+}
+
+func jsonContent(schema *Schema) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{"application/json": {Schema: schema}}
+}
+
+// jobListResponse is the page of jobs sent by ListJobs
+type jobListResponse struct {
+	Data  []entity.Job `json:"data"`
+	Total int          `json:"total"`
+}
+
+// schemaRegistry turns Go types into schemas, naming structs as components
+type schemaRegistry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaRegistry(components map[string]*Schema) *schemaRegistry {
+	return &schemaRegistry{components: components, names: make(map[reflect.Type]string)}
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// response builds the schema of a response or request body, unwrapping Data
+// and Envelope
+func (r *schemaRegistry) response(v interface{}) *Schema {
+	w, ok := v.(wrappedResponse)
+	if !ok {
+		return r.of(reflect.TypeOf(v))
+	}
+	inner := r.response(w.value)
+	if w.field == "envelope" {
+		return &Schema{Type: "object", Properties: map[string]*Schema{
+			"success": {Type: "boolean"},
+			"data":    inner,
+		}}
+	}
+	return &Schema{Type: "object", Properties: map[string]*Schema{w.field: inner}}
+}
+
+func (r *schemaRegistry) of(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.of(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.of(t.Elem())}
+	case reflect.Struct:
+		return r.object(t)
+	}
+	return &Schema{}
+}
+
+// object registers a struct as a component and returns a reference to it;
+// anonymous structs are inlined
+func (r *schemaRegistry) object(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return r.properties(t)
+	}
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := r.components[name]; taken {
+			name = t.String()
+			name = strings.ReplaceAll(name, ".", "")
+			name = strings.ToUpper(name[:1]) + name[1:]
+		}
+		r.names[t] = name
+		r.components[name] = &Schema{} // Placeholder so recursive types terminate
+		r.components[name] = r.properties(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// properties lists a struct's JSON fields, flattening embedded structs
+func (r *schemaRegistry) properties(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(s, t)
+	return s
+}
+
+func (r *schemaRegistry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.addFields(s, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = r.of(field.Type)
+	}
+}
+
+// ValidateResponse checks a response body against the schema documented for
+// the route and status: every field sent must be documented with its type
+func (d *OpenAPIDocument) ValidateResponse(method, path string, status int, body []byte) error {
+	converted, _ := openAPIPath(path)
+	op := d.Paths[converted][strings.ToLower(method)]
+	if op == nil {
+		return fmt.Errorf("%s %s is not in the document", method, path)
+	}
+	response := op.Responses[fmt.Sprintf("%d", status)]
+	if response == nil {
+		response = op.Responses["default"]
+	}
+	media, ok := response.Content["application/json"]
+	if !ok {
+		return fmt.Errorf("%s %s documents no body for status %d", method, path, status)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("response is not JSON: %w", err)
+	}
+	return d.validate(media.Schema, value, "$")
+}
+
+func (d *OpenAPIDocument) validate(schema *Schema, value interface{}, at string) error {
+	if schema.Ref != "" {
+		resolved := d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if resolved == nil {
+			return fmt.Errorf("%s: unknown schema %s", at, schema.Ref)
+		}
+		return d.validate(resolved, value, at)
+	}
+	if len(schema.OneOf) > 0 {
+		for _, option := range schema.OneOf {
+			if d.validate(option, value, at) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: matches none of the documented shapes", at)
+	}
+	if value == nil {
+		return nil
+	}
+
+	switch schema.Type {
+	case "":
+		return nil
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", at, value)
+		}
+		for key, field := range obj {
+			fieldSchema := schema.AdditionalProperties
+			if schema.Properties != nil {
+				fieldSchema = schema.Properties[key]
+			}
+			if fieldSchema == nil {
+				return fmt.Errorf("%s.%s: not documented", at, key)
+			}
+			if err := d.validate(fieldSchema, field, at+"."+key); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", at, value)
+		}
+		for i, item := range items {
+			if err := d.validate(schema.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", at, value)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s: expected %s, got %T", at, schema.Type, value)
+		}
+		if schema.Type == "integer" && n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected integer, got %v", at, n)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", at, value)
+		}
+	}
+	return nil
+}
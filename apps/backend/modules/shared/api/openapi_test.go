@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type specWidget struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Score     *float64   `json:"score,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	Parent    *specOwner `json:"parent,omitempty"`
+	internal  string
+}
+
+type specOwner struct {
+	Email string `json:"email"`
+}
+
+type specWidgetDetails struct {
+	*specWidget
+	Tags []string `json:"tags"`
+}
+
+type specHandlers struct{}
+
+func (specHandlers) GetWidget(c *gin.Context)    { c.JSON(http.StatusOK, gin.H{}) }
+func (specHandlers) ListWidgets(c *gin.Context)  { c.JSON(http.StatusOK, gin.H{}) }
+func (specHandlers) Login(c *gin.Context)        { c.JSON(http.StatusOK, gin.H{}) }
+func (specHandlers) DeleteWidget(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+
+type otherHandlers struct{}
+
+func (otherHandlers) Login(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+
+func specRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h, other := specHandlers{}, otherHandlers{}
+	v1 := router.Group("/api/v1")
+	v1.GET("/widgets", h.ListWidgets)
+	v1.GET("/widgets/:id", h.GetWidget)
+	v1.DELETE("/widgets/:id", h.DeleteWidget)
+	v1.POST("/auth/login", h.Login)
+	v1.GET("/sso/login/:tenant", other.Login)
+	v1.GET("/files/*path", func(c *gin.Context) {})
+	v1.GET("/errors", GetErrorCatalogue)
+	return router
+}
+
+func TestBuildOpenAPIListsEveryRoute(t *testing.T) {
+	router := specRouter()
+	doc, unmatched := BuildOpenAPI(router.Routes(), map[string]bool{"/api/v1/auth/login": true}, []Operation{
+		{Method: http.MethodGet, Path: "/api/v1/widgets/:id", Summary: "Get a widget", Response: Data(specWidgetDetails{})},
+		{Method: http.MethodGet, Path: "/api/v1/gone", Summary: "Removed"},
+	})
+
+	if doc.OpenAPI != OpenAPIVersion {
+		t.Errorf("openapi = %q", doc.OpenAPI)
+	}
+	if len(unmatched) != 1 || unmatched[0] != "GET /api/v1/gone" {
+		t.Errorf("unmatched = %v, want the operation without a route", unmatched)
+	}
+
+	count := 0
+	for _, methods := range doc.Paths {
+		count += len(methods)
+	}
+	if count != len(router.Routes()) {
+		t.Errorf("document has %d operations, router %d routes", count, len(router.Routes()))
+	}
+
+	get := doc.Paths["/api/v1/widgets/{id}"]["get"]
+	if get == nil {
+		t.Fatal("GET /api/v1/widgets/{id} missing")
+	}
+	if get.OperationID != "GetWidget" || get.Summary != "Get a widget" || get.Tags[0] != "widgets" {
+		t.Errorf("operation = %+v", get)
+	}
+	if len(get.Parameters) != 1 || get.Parameters[0].Name != "id" || get.Parameters[0].In != "path" {
+		t.Errorf("parameters = %+v", get.Parameters)
+	}
+	if len(get.Security) != 1 {
+		t.Errorf("protected route security = %v", get.Security)
+	}
+
+	if files := doc.Paths["/api/v1/files/{path}"]["get"]; files == nil || files.OperationID != "getFilesByPath" {
+		t.Errorf("closure route operation = %+v, want a derived ID", files)
+	}
+	if login := doc.Paths["/api/v1/auth/login"]["post"]; login == nil || login.OperationID != "postAuthLogin" || len(login.Security) != 0 {
+		t.Errorf("public login operation = %+v, want a derived ID and no security", login)
+	}
+	if sso := doc.Paths["/api/v1/sso/login/{tenant}"]["get"]; sso == nil || sso.OperationID != "getSsoLoginByTenant" {
+		t.Errorf("duplicate handler name operation = %+v, want a derived ID", sso)
+	}
+}
+
+func TestBuildOpenAPISchemas(t *testing.T) {
+	doc, _ := BuildOpenAPI(specRouter().Routes(), nil, []Operation{
+		{Method: http.MethodGet, Path: "/api/v1/widgets/:id", Response: Data(specWidgetDetails{})},
+	})
+
+	details := doc.Components.Schemas["specWidgetDetails"]
+	if details == nil {
+		t.Fatalf("specWidgetDetails not registered: %v", doc.Components.Schemas)
+	}
+	for _, name := range []string{"id", "name", "score", "created_at", "parent", "tags"} {
+		if details.Properties[name] == nil {
+			t.Errorf("property %s missing from %v", name, details.Properties)
+		}
+	}
+	if details.Properties["internal"] != nil {
+		t.Error("unexported field documented")
+	}
+	if id := details.Properties["id"]; id.Type != "string" || id.Format != "uuid" {
+		t.Errorf("id = %+v", id)
+	}
+	if created := details.Properties["created_at"]; created.Format != "date-time" {
+		t.Errorf("created_at = %+v", created)
+	}
+	if score := details.Properties["score"]; score.Type != "number" || !score.Nullable {
+		t.Errorf("score = %+v", score)
+	}
+	if parent := details.Properties["parent"]; parent.Ref != "#/components/schemas/specOwner" {
+		t.Errorf("parent = %+v", parent)
+	}
+
+	response := doc.Paths["/api/v1/widgets/{id}"]["get"].Responses["200"].Content["application/json"].Schema
+	if response.Properties["data"].Ref != "#/components/schemas/specWidgetDetails" {
+		t.Errorf("response schema = %+v", response)
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	router := specRouter()
+	doc, _ := BuildOpenAPI(router.Routes(), nil, append(OpenAPIOperations(),
+		Operation{Method: http.MethodGet, Path: "/api/v1/widgets/:id", Response: Data(specWidgetDetails{})},
+	))
+
+	// The catalogue handler's real response matches its description
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/errors", nil))
+	if err := doc.ValidateResponse(http.MethodGet, "/api/v1/errors", rec.Code, rec.Body.Bytes()); err != nil {
+		t.Errorf("error catalogue: %v", err)
+	}
+
+	cases := []struct {
+		body    string
+		wantErr bool
+	}{
+		{body: `{"data": {"id": "7f1c", "name": "w", "score": 0.5, "parent": {"email": "a@b.c"}, "tags": ["x"]}}`},
+		{body: `{"data": {"id": "7f1c", "score": null, "parent": null}}`},
+		{body: `{"data": {"name": 3}}`, wantErr: true},
+		{body: `{"data": {"tags": "x"}}`, wantErr: true},
+		{body: `{"data": {"colour": "red"}}`, wantErr: true},
+		{body: `{"data": {"parent": {"phone": "1"}}}`, wantErr: true},
+	}
+	for _, tc := range cases {
+		err := doc.ValidateResponse(http.MethodGet, "/api/v1/widgets/:id", http.StatusOK, []byte(tc.body))
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tc.body, err, tc.wantErr)
+		}
+	}
+
+	// Errors are checked against the envelope or the legacy body
+	if err := doc.ValidateResponse(http.MethodGet, "/api/v1/widgets/:id", http.StatusNotFound, []byte(`{"error": "Widget not found"}`)); err != nil {
+		t.Errorf("legacy error: %v", err)
+	}
+	if err := doc.ValidateResponse(http.MethodGet, "/api/v1/widgets/:id", http.StatusNotFound, []byte(`{"success": false, "error": {"code": "NOT_FOUND", "message": "x"}}`)); err != nil {
+		t.Errorf("envelope error: %v", err)
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewOpenAPIHandler(nil)
+	router.GET("/api/v1/openapi.json", handler.GetSpec)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("before Build: status %d, want 503", rec.Code)
+	}
+
+	if unmatched := handler.Build(router.Routes(), OpenAPIOperations()); len(unmatched) == 0 {
+		t.Error("operations for unregistered routes were not reported")
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("after Build: status %d", rec.Code)
+	}
+	var doc OpenAPIDocument
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if op := doc.Paths["/api/v1/openapi.json"]["get"]; op == nil || op.Summary == "" {
+		t.Errorf("document does not describe itself: %+v", doc.Paths)
+	}
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IfMatchVersion reads the resource version an update was based on from the
+// If-Match header. It accepts a bare version or an entity tag such as "3" or W/"3";
+// ok is false when the header is absent.
+func IfMatchVersion(c *gin.Context) (version int, ok bool, err error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		return 0, false, nil
+	}
+	tag := strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+	version, err = strconv.Atoi(tag)
+	if err != nil || version < 0 {
+		return 0, true, fmt.Errorf("If-Match must be a resource version, got %s", value)
+	}
+	return version, true, nil
+}
+
+// SetVersionETag sets the ETag header to a resource version, for clients to echo in If-Match
+func SetVersionETag(c *gin.Context, version int) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
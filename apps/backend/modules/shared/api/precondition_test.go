@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct {
+		header  string
+		version int
+		ok      bool
+		wantErr bool
+	}{
+		{header: "", ok: false},
+		{header: "3", version: 3, ok: true},
+		{header: `"0"`, version: 0, ok: true},
+		{header: `W/"12"`, version: 12, ok: true},
+		{header: `"abc"`, ok: true, wantErr: true},
+		{header: "-1", ok: true, wantErr: true},
+	}
+	for _, tc := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+		if tc.header != "" {
+			c.Request.Header.Set("If-Match", tc.header)
+		}
+
+		version, ok, err := IfMatchVersion(c)
+		if (err != nil) != tc.wantErr || ok != tc.ok || (!tc.wantErr && version != tc.version) {
+			t.Errorf("If-Match %q: got version %d, ok %v, err %v", tc.header, version, ok, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes one field that failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+var registerTagNames sync.Once
+
+// RegisterValidation makes validation errors name fields by their JSON names.
+// Binding still uses gin's `binding` struct tags, evaluated by go-playground/validator.
+func RegisterValidation() {
+	registerTagNames.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			switch name {
+			case "-":
+				return ""
+			case "":
+				return field.Name
+			}
+			return name
+		})
+	})
+}
+
+// BindJSON binds and validates the request body into dto. On failure it records the
+// error on the context for the error envelope middleware, aborts, and returns false.
+func BindJSON(c *gin.Context, dto interface{}) bool {
+	if err := c.ShouldBindJSON(dto); err != nil {
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// BindOptionalJSON is BindJSON for endpoints whose body may be omitted entirely
+func BindOptionalJSON(c *gin.Context, dto interface{}) bool {
+	if err := c.ShouldBindJSON(dto); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(err).SetType(gin.ErrorTypeBind)
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// RenderBindError sends a binding failure in the standard envelope: 422 with per-field
+// details when validation fails, 400 when the body cannot be decoded
+func RenderBindError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		Fail(c, CodeValidationFailed, "Request validation failed", fieldErrors(validationErrs))
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		Fail(c, CodeMalformedBody, "Request body is required", nil)
+	case errors.As(err, &typeErr):
+		Fail(c, CodeMalformedBody, fmt.Sprintf("Field %q must be of type %s", typeErr.Field, typeErr.Type), nil)
+	default:
+		Fail(c, CodeMalformedBody, "Request body is not valid JSON", err.Error())
+	}
+}
+
+func fieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fieldMessage(fe),
+		})
+	}
+	return fields
+}
+
+// fieldPath drops the top-level struct name from the namespace, e.g. "Request.rules[0].name"
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "min":
+		if fe.Kind() == reflect.String || fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+			return "must have at least " + fe.Param() + " items or characters"
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if fe.Kind() == reflect.String || fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+			return "must have at most " + fe.Param() + " items or characters"
+		}
+		return "must be at most " + fe.Param()
+	case "gt", "gte", "lt", "lte":
+		return fmt.Sprintf("must be %s %s", comparisonWords[fe.Tag()], fe.Param())
+	case "email":
+		return "must be a valid email address"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "url":
+		return "must be a valid URL"
+	case "dive":
+		return "contains an invalid item"
+	default:
+		return "failed the " + fe.Tag() + " rule"
+	}
+}
+
+var comparisonWords = map[string]string{
+	"gt":  "greater than",
+	"gte": "at least",
+	"lt":  "less than",
+	"lte": "at most",
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type testRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Status string `json:"status" binding:"omitempty,oneof=open closed"`
+}
+
+func bindTestRequest(body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	RegisterValidation()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req testRequest
+	if !BindJSON(c, &req) {
+		RenderBindError(c, c.Errors.Last().Err)
+	}
+	return w
+}
+
+func TestRenderBindErrorValidation(t *testing.T) {
+	w := bindTestRequest(`{"status":"pending"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want 422", w.Code)
+	}
+
+	var resp struct {
+		Error struct {
+			Code    string       `json:"code"`
+			Details []FieldError `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error.Code != CodeValidationFailed {
+		t.Errorf("code = %q, want %q", resp.Error.Code, CodeValidationFailed)
+	}
+	if len(resp.Error.Details) != 2 {
+		t.Fatalf("details = %+v, want 2 field errors", resp.Error.Details)
+	}
+	if resp.Error.Details[0].Field != "name" || resp.Error.Details[0].Rule != "required" {
+		t.Errorf("details[0] = %+v, want name/required", resp.Error.Details[0])
+	}
+	if resp.Error.Details[1].Field != "status" || resp.Error.Details[1].Message != "must be one of: open, closed" {
+		t.Errorf("details[1] = %+v, want status oneof", resp.Error.Details[1])
+	}
+}
+
+func TestRenderBindErrorMalformed(t *testing.T) {
+	for _, body := range []string{``, `{"name":`, `{"name":1}`} {
+		w := bindTestRequest(body)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), CodeMalformedBody) {
+			t.Errorf("body %q: got %d %s, want 400 %s", body, w.Code, w.Body.String(), CodeMalformedBody)
+		}
+	}
+}
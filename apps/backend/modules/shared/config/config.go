@@ -3,11 +3,56 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
 	Classification ClassificationConfig
 	PIIStorage     PIIStorageConfig
+	FindingAging   FindingAgingConfig
+	Archive        ArchiveConfig
+	AuditExport    AuditExportConfig
+	Events         EventsConfig
+	Neo4j          Neo4jConfig
+	Neo4jBreaker   Neo4jBreakerConfig
+	Neo4jWrites    Neo4jWriteQueueConfig
+	Ingestion      IngestionConfig
+	Alerting       AlertingConfig
+	Trash          TrashConfig
+	Evidence       EvidenceConfig
+	Kafka          KafkaConfig
+	FPClustering   FPClusteringConfig
+	SSO            SSOConfig
+	Sessions       SessionConfig
+	Approvals      RemediationApprovalConfig
+	Batches        RemediationBatchConfig
+	Reporting      ReportingConfig
+	Idempotency    IdempotencyConfig
+	Shadow         ShadowClassificationConfig
+	AccessAudit    AccessAuditConfig
+	Jobs           JobsConfig
+	SampleText     SampleTextConfig
+	Coverage       ConnectionCoverageConfig
+	Health         ConnectionHealthConfig
+	Policy         PolicyConfig
+	Quota          QuotaConfig
+	Query          QueryGuardConfig
+	Inventory      InventoryConfig
+	Payload        FindingPayloadConfig
+	Waivers        RiskWaiverConfig
+	Freshness      ScanFreshnessConfig
+	AnalyticsSink  AnalyticsSinkConfig
+	TenantExport   TenantExportConfig
+	Search         SearchConfig
+	AssetPaths     AssetPathConfig
+	PostureScore   ComplianceScoreConfig
+	ScanAnomaly    ScanAnomalyConfig
+	Ticketing      TicketingConfig
+	Sharing        SharingConfig
+	SecretVerify   SecretVerificationConfig
+	Erasure        ErasureConfig
+	Metrics        MetricsConfig
+	Proxy          ProxyConfig
 }
 
 type ClassificationConfig struct {
@@ -17,6 +62,502 @@ type ClassificationConfig struct {
 	Threshold     float64
 }
 
+// FindingAgingConfig controls stale-finding detection
+type FindingAgingConfig struct {
+	Enabled         bool
+	ScanWindow      int    // Number of recent asset scans a finding must appear in
+	Action          string // "auto_close" or "queue"
+	IntervalMinutes int
+}
+
+// ArchiveConfig controls exporting old findings to object storage
+type ArchiveConfig struct {
+	Enabled         bool   // Run the archiver in the background
+	Provider        string // "s3", "gcs" or "local"
+	Bucket          string
+	Region          string
+	Endpoint        string // Optional S3-compatible endpoint override
+	Prefix          string // Key prefix for archive objects
+	AccessKey       string
+	SecretKey       string
+	LocalPath       string // Root directory for the local provider
+	OlderThanDays   int    // Minimum scan run age before findings are archived
+	ClosedOnly      bool   // Only archive resolved or false-positive findings
+	IntervalMinutes int
+}
+
+// EvidenceConfig controls finding evidence attachments and the object store they are kept in.
+// Attachments are disabled unless a bucket or local path is configured.
+type EvidenceConfig struct {
+	Provider               string // "s3", "gcs" or "local"
+	Bucket                 string
+	Region                 string
+	Endpoint               string // Optional S3-compatible endpoint override
+	Prefix                 string // Key prefix for evidence objects
+	AccessKey              string
+	SecretKey              string
+	LocalPath              string   // Root directory for the local provider
+	MaxSizeKB              int      // Largest attachment accepted
+	AllowedTypes           []string // Accepted content types
+	URLExpiryMinutes       int      // Lifetime of signed download URLs
+	CleanupIntervalMinutes int      // How often objects of deleted evidence are removed from storage
+}
+
+// StoreConfig returns the object store settings in the form the archive store expects
+func (c EvidenceConfig) StoreConfig() ArchiveConfig {
+	return ArchiveConfig{
+		Provider:  c.Provider,
+		Bucket:    c.Bucket,
+		Region:    c.Region,
+		Endpoint:  c.Endpoint,
+		Prefix:    c.Prefix,
+		AccessKey: c.AccessKey,
+		SecretKey: c.SecretKey,
+		LocalPath: c.LocalPath,
+	}
+}
+
+// AuditExportConfig controls forwarding audit events to SIEM collectors
+type AuditExportConfig struct {
+	Enabled            bool
+	Format             string // "cef" or "json"
+	SyslogNetwork      string // "udp", "tcp" or "tcp+tls"
+	SyslogAddress      string // host:port; empty disables the syslog sink
+	HTTPEndpoint       string // HTTPS collector URL; empty disables the HTTPS sink
+	HTTPToken          string // Bearer token sent to the collector
+	HTTPTimeoutSeconds int
+	Actions            []string // Only export these actions; empty exports everything
+	IntervalSeconds    int
+	BatchSize          int
+}
+
+// EventsConfig controls the live event stream
+type EventsConfig struct {
+	PubSub           string // "postgres" to share events across replicas, "local" for in-process only
+	BufferSize       int    // Events buffered per subscriber before it starts missing events
+	HeartbeatSeconds int    // Keep-alive interval for idle streams
+}
+
+// FPClusteringConfig controls the job that clusters false-positive feedback into
+// suppression and FP-learning rule suggestions
+type FPClusteringConfig struct {
+	Enabled         bool
+	IntervalMinutes int
+	WindowDays      int     // Feedback older than this is ignored
+	MinSupport      int     // False positives a cluster needs before a rule is suggested
+	MinPrecision    float64 // Share of false positives among all feedback in a cluster
+	PathDepth       int     // Directory levels kept when grouping by path prefix
+}
+
+// SSOConfig controls the OIDC login flow. Providers themselves are configured per tenant.
+type SSOConfig struct {
+	SuccessRedirectURL string // Frontend URL that receives the tokens; the callback returns JSON when empty
+	StateTTLMinutes    int    // How long a started login may take before the callback is rejected
+	HTTPTimeoutSeconds int    // Timeout for discovery, JWKS and token requests to the IdP
+}
+
+// SessionConfig controls server-side session checks and cleanup of revocation records
+type SessionConfig struct {
+	ValidationCacheSeconds int // Cache live-session checks this long; 0 checks Postgres on every request
+	CleanupIntervalMinutes int
+	RetentionDays          int // Ended sessions are kept this long for the session history
+}
+
+// KafkaConfig controls publishing integration events (finding, classification and
+// remediation changes) to Kafka. Publishing is disabled unless brokers are configured.
+type KafkaConfig struct {
+	Brokers         []string // Bootstrap host:port list
+	Topic           string
+	ClientID        string
+	TLS             bool
+	SASLMechanism   string // "" or "PLAIN"
+	SASLUsername    string
+	SASLPassword    string
+	Acks            int      // 0, 1 or -1 (all in-sync replicas)
+	BufferSize      int      // Events held in memory while the cluster is slow or unreachable
+	BatchSize       int      // Events sent per produce request
+	FlushIntervalMs int      // Longest time an event waits for its batch to fill
+	TimeoutSeconds  int      // Network and broker acknowledgement timeout
+	EventTypes      []string // Only publish these event types; empty publishes all of them
+}
+
+// RemediationApprovalConfig controls the four-eyes approval of remediation. Gated
+// requests wait for a second user with the remediation:approve permission.
+type RemediationApprovalConfig struct {
+	Enabled         bool
+	Actions         []string // Action types that need approval; DELETE when empty
+	ImpactThreshold int      // Requests touching more findings than this need approval; 0 gates every request
+	ExpiryHours     int      // Pending requests lapse after this long
+}
+
+// RemediationBatchConfig throttles bulk remediation batches. A batch's findings are
+// grouped by the connection they were found on, and each connection is remediated
+// within the limits of its connector type.
+type RemediationBatchConfig struct {
+	Concurrency     int      // Findings remediated at once per connection, for connector types without a limit
+	RatePerSecond   float64  // Findings started per second per connection; 0 is unlimited
+	ConnectorLimits []string // Per connector type, "type=concurrency:rate", e.g. "postgresql=2:5"
+	MaxFindings     int      // Most findings in one batch
+}
+
+// ReportingConfig controls custom report rendering and scheduled delivery. Emailed
+// reports use the SMTP settings of AlertingConfig.
+type ReportingConfig struct {
+	SchedulerEnabled      bool
+	IntervalSeconds       int // How often due report schedules are looked for
+	DeliveryHourUTC       int // Hour of day scheduled reports go out; weekly on Mondays, monthly on the 1st
+	MaxRows               int // Rows rendered per report; larger results are truncated
+	WebhookTimeoutSeconds int
+}
+
+// ShadowClassificationConfig runs a candidate classifier version alongside the
+// primary one so its outcomes can be compared before it is promoted
+type ShadowClassificationConfig struct {
+	Version    string // Candidate engine version; empty disables shadow mode
+	Weights    ClassificationConfig
+	Threshold  float64 // Score a finding needs to be kept under the candidate
+	SampleRate float64 // Fraction of classified findings also sent to the candidate
+	QueueSize  int     // Pending shadow classifications; more are dropped
+	Workers    int
+}
+
+// AccessAuditConfig controls read-access auditing of endpoints that return PII sample values
+type AccessAuditConfig struct {
+	Enabled          bool
+	RequirePurpose   bool    // Reject reads without a purpose parameter
+	AnomalyFactor    float64 // A day's views above this multiple of the user's usual daily volume are anomalous
+	AnomalyMinViews  int     // Days with fewer finding views are never anomalous
+	ReportWindowDays int     // Default look-back of the access report
+}
+
+// JobsConfig controls the persistent background job queue
+type JobsConfig struct {
+	Enabled             bool
+	Workers             int // Jobs run concurrently on each replica
+	PollIntervalSeconds int // How often due jobs are claimed
+	MaxAttempts         int // Attempts of a job before it is marked failed, unless it sets its own
+	BackoffBaseSeconds  int // Delay before the first retry; doubled on each further attempt
+	BackoffMaxSeconds   int // Upper bound of the retry delay
+	StaleAfterMinutes   int // Running jobs locked longer than this are assumed orphaned and requeued
+}
+
+// SampleTextConfig controls how finding sample text is stored
+type SampleTextConfig struct {
+	Policy string // "mask", "hash_only" or "full"; tenants may opt in to full storage
+}
+
+// ConnectionCoverageConfig controls alerts for connections that are not being scanned
+type ConnectionCoverageConfig struct {
+	StaleAfterDays     int // A connection without a scan for this long is reported
+	CheckIntervalHours int // How often connections are checked; 0 disables the alert
+}
+
+// ConnectionHealthConfig controls the scheduled re-test of stored connection credentials
+type ConnectionHealthConfig struct {
+	IntervalMinutes int // How often every connection is tested; 0 disables the check
+	Concurrency     int // Connections tested at once
+}
+
+// PolicyConfig controls the nightly evaluation of tenant policy rules
+type PolicyConfig struct {
+	EvaluatorEnabled      bool
+	IntervalSeconds       int // How often due policy rules are looked for
+	EvaluationHourUTC     int // Hour of day rules are evaluated
+	WebhookTimeoutSeconds int
+}
+
+// ComplianceScoreConfig weighs the components of the tenant compliance posture
+// score and controls its daily snapshots. Weights are normalized to sum to 1.
+type ComplianceScoreConfig struct {
+	WeightExposure          float64
+	WeightVelocity          float64
+	WeightBacklog           float64
+	WeightPolicy            float64
+	VelocityWindowDays      int // Findings found and closed within this many days measure remediation velocity
+	ReviewSLADays           int // Findings pending review for longer count as backlog
+	SnapshotIntervalMinutes int // How often each tenant's snapshot for the day is refreshed
+}
+
+// ScanAnomalyConfig controls the job that compares each completed scan run's matches per
+// PII type against the asset's and connection's previous runs. The thresholds are the
+// defaults of tenants that have not set their own.
+type ScanAnomalyConfig struct {
+	Enabled         bool
+	IntervalMinutes int
+	SpikeFactor     float64 // Matches over the baseline median that make a spike
+	MinMatches      int     // Spikes below this many matches are ignored
+	BaselineRuns    int     // Previous scan runs the baseline is taken over
+	MinBaselineRuns int     // Previous scan runs needed before an asset or connection is compared at all
+}
+
+// TicketingConfig controls calls to the Jira and ServiceNow integrations and the job
+// that pushes ARC status changes to linked tickets
+type TicketingConfig struct {
+	HTTPTimeoutSeconds  int
+	SyncEnabled         bool
+	SyncIntervalMinutes int
+}
+
+// SharingConfig bounds dashboard share links and how long a link's snapshot is reused
+type SharingConfig struct {
+	DefaultTTLHours int // Lifetime of links created without an expiry
+	MaxTTLDays      int // Links may not be valid for longer
+	CacheSeconds    int // A link's widgets are recomputed at most this often
+}
+
+// SecretVerificationConfig controls the liveness probes of credentials in Secrets
+// findings. Probes only run for tenants that opted in.
+type SecretVerificationConfig struct {
+	WorkerEnabled      bool
+	IntervalMinutes    int
+	BatchSize          int    // Findings verified per tenant and run
+	RecheckDays        int    // Verified findings are probed again after this many days
+	HTTPTimeoutSeconds int    // Timeout of each probe
+	AWSRegion          string // Region of the STS endpoint
+}
+
+// ErasureConfig controls data principal erasure requests
+type ErasureConfig struct {
+	// SigningKey signs erasure certificates: a base64 Ed25519 seed or private key,
+	// or a PEM PKCS#8 private key. Without one, erasures are disabled unless
+	// AllowGeneratedKey is set.
+	SigningKey string
+	// AllowGeneratedKey signs with a key generated at startup when SigningKey is
+	// unset; certificates then cannot be verified after a restart. Defaults on
+	// outside GIN_MODE=release.
+	AllowGeneratedKey bool
+}
+
+// MetricsConfig controls access to the Prometheus metrics. With neither a token nor
+// a listen address /metrics is not served.
+type MetricsConfig struct {
+	Token      string // Bearer token required for /metrics on the API port
+	ListenAddr string // Separate internal listener serving /metrics without a token, e.g. "127.0.0.1:9090"
+}
+
+// ProxyConfig lists the reverse proxies whose X-Forwarded-For and X-Real-IP headers
+// are trusted for the client IP. With none, the client IP is the connecting address.
+type ProxyConfig struct {
+	TrustedProxies []string // IPs or CIDRs
+}
+
+// QuotaConfig holds the default per-tenant ingestion quotas; admins may override them per tenant
+type QuotaConfig struct {
+	MaxFindings       int    // Findings a tenant may store; 0 is unlimited
+	MaxScanRunsPerDay int    // Scan runs a tenant may ingest per UTC day; 0 is unlimited
+	OverageBehavior   string // "reject" or "downsample" once the findings quota is reached
+	DownsampleEvery   int    // When downsampling, 1 in this many non-critical findings is kept; 0 keeps none
+}
+
+// QueryGuardConfig bounds Postgres queries by class: interactive request-path
+// queries, reports and background batch sweeps. A timeout of 0 is unbounded.
+type QueryGuardConfig struct {
+	InteractiveTimeoutSeconds int
+	ReportTimeoutSeconds      int
+	BatchTimeoutSeconds       int
+	InteractiveSlowMs         int // Soft thresholds: slower queries are counted and logged
+	ReportSlowMs              int
+	BatchSlowMs               int
+	PageSize                  int // Rows per page when batch sweeps iterate a table
+}
+
+// InventoryConfig holds the cloud accounts whose inventories can be imported as
+// source systems. AWS uses the default credential chain unless keys are set;
+// Azure is enabled by setting a service principal and subscriptions.
+type InventoryConfig struct {
+	AWSRegions         []string
+	AWSAccessKey       string
+	AWSSecretKey       string
+	AzureTenantID      string
+	AzureClientID      string
+	AzureClientSecret  string
+	AzureSubscriptions []string
+	EnvironmentTags    []string // Tag keys read as the environment, first match wins
+	OwnerTags          []string // Tag keys read as the owner, first match wins
+}
+
+// FindingPayloadConfig controls tiered storage of finding context and enrichment
+// signals: payloads over the inline limit are kept in a side table
+type FindingPayloadConfig struct {
+	InlineLimitBytes int // Largest combined payload kept in the findings row; 0 keeps every payload inline
+}
+
+// RiskWaiverConfig bounds accepted-risk waivers and controls their expiry sweep
+type RiskWaiverConfig struct {
+	MaxDays              int // Longest a waiver may run before it must be renewed
+	WarningDays          int // Admins are alerted this many days before a waiver expires
+	SweepIntervalMinutes int // How often waivers are expired, alerted and applied to new findings
+}
+
+// ScanFreshnessConfig sets the scan frequency assets are held to and how often it is checked
+type ScanFreshnessConfig struct {
+	DefaultFrequencyHours int // Assets without their own frequency must be rescanned this often
+	IntervalMinutes       int // How often the SLA job flags overdue assets
+}
+
+// AnalyticsSinkConfig controls mirroring findings and classifications into a columnar
+// store for heavy analytical queries. The sink is disabled unless a ClickHouse URL is set.
+type AnalyticsSinkConfig struct {
+	ClickHouseURL       string // HTTP interface, e.g. http://clickhouse:8123
+	ClickHouseDatabase  string
+	ClickHouseUser      string
+	ClickHousePassword  string
+	SyncIntervalMinutes int  // How often new and changed rows are mirrored
+	BatchSize           int  // Rows read from PostgreSQL and inserted per batch
+	SettleSeconds       int  // Rows changed more recently wait for the next sync, so slow transactions are not skipped
+	ReadFromSink        bool // Serve analytics endpoints from the sink instead of PostgreSQL
+}
+
+// AssetPathConfig controls how asset paths are canonicalized into stable IDs.
+// Paths are lowercased unless their data source is listed as case-sensitive.
+type AssetPathConfig struct {
+	CaseSensitiveSources []string // Data sources whose paths differ by case, e.g. s3
+	KeepTrailingSlash    bool     // Keep "/data/" and "/data" as different assets
+}
+
+// SearchConfig controls the full-text search index over findings. Search is
+// disabled unless a backend is set.
+type SearchConfig struct {
+	Backend             string // "embedded" for a single node, "opensearch" for clusters
+	IndexPath           string // Embedded: file the index is kept in; empty keeps it in memory and rebuilds it on start
+	OpenSearchURL       string // e.g. http://opensearch:9200
+	OpenSearchIndex     string
+	OpenSearchUser      string
+	OpenSearchPassword  string
+	SyncIntervalSeconds int // How often new and changed findings are indexed
+	BatchSize           int // Findings read from PostgreSQL and indexed per batch
+	SettleSeconds       int // Findings changed more recently wait for the next sync, so slow transactions are not skipped
+}
+
+// TenantExportConfig controls full tenant data exports and the object store their
+// bundles are kept in. Exports are disabled unless a bucket or local path is configured.
+type TenantExportConfig struct {
+	Provider  string // "s3", "gcs" or "local"
+	Bucket    string
+	Region    string
+	Endpoint  string // Optional S3-compatible endpoint override
+	Prefix    string // Key prefix for export bundles
+	AccessKey string
+	SecretKey string
+	LocalPath string // Root directory for the local provider
+}
+
+// StoreConfig returns the object store settings in the form the archive store expects
+func (c TenantExportConfig) StoreConfig() ArchiveConfig {
+	return ArchiveConfig{
+		Provider:  c.Provider,
+		Bucket:    c.Bucket,
+		Region:    c.Region,
+		Endpoint:  c.Endpoint,
+		Prefix:    c.Prefix,
+		AccessKey: c.AccessKey,
+		SecretKey: c.SecretKey,
+		LocalPath: c.LocalPath,
+	}
+}
+
+// IdempotencyConfig controls Idempotency-Key handling on ingestion and remediation endpoints
+type IdempotencyConfig struct {
+	Enabled                bool
+	WindowHours            int // Responses are replayed for repeated keys within this window
+	CleanupIntervalMinutes int // How often expired keys are purged
+}
+
+// Neo4jConfig tunes the Neo4j driver for clusters. Point NEO4J_URI at a neo4j://
+// routing address to send reads to followers and read replicas.
+type Neo4jConfig struct {
+	Database                            string // Database sessions open
+	MaxConnectionPoolSize               int    // Connections kept per cluster member; 0 keeps the driver default
+	ConnectionAcquisitionTimeoutSeconds int    // Wait for a pooled connection; 0 keeps the driver default
+	MaxConnectionLifetimeMinutes        int    // Pooled connections are replaced after this long; 0 keeps the driver default
+	FetchSize                           int    // Records pulled per batch; 0 keeps the driver default
+	CausalConsistency                   bool   // Reads on followers wait for this instance's earlier writes
+	SchemaBootstrap                     bool   // Create graph constraints and indexes at startup
+}
+
+// Neo4jBreakerConfig controls the circuit breaker around Neo4j and the deferred lineage outbox
+type Neo4jBreakerConfig struct {
+	FailureThreshold        int // Consecutive Neo4j failures that open the breaker
+	CooldownSeconds         int // Time the breaker stays open before probing Neo4j again
+	OperationTimeoutSeconds int // Upper bound for a single Neo4j call, including driver retries
+	OutboxIntervalSeconds   int // How often deferred lineage syncs are replayed
+}
+
+// Neo4jWriteQueueConfig bounds concurrent lineage writes to Neo4j, overall and per
+// priority lane: real-time asset syncs, then full resyncs, then outbox replays
+type Neo4jWriteQueueConfig struct {
+	MaxConcurrency       int // Writes in flight across all lanes
+	RealtimeConcurrency  int
+	ResyncConcurrency    int
+	ReconcileConcurrency int
+	RealtimeMaxQueued    int // Real-time syncs waiting beyond this are deferred to the outbox; 0 for no bound
+}
+
+// IngestionConfig controls scan ingestion
+type IngestionConfig struct {
+	Workers      int // Asset groups ingested concurrently; each holds one database connection
+	MaxPayloadMB int // Largest verified scan body accepted by the ingestion endpoint
+	MaxFindings  int // Most findings accepted in a single verified scan body or upload chunk
+
+	MaxMatchesPerFinding int // Matches stored per finding; the rest are only counted. 0 stores all
+
+	// Non-PII and low-confidence findings are counted in the dropped findings ledger and,
+	// in "drop" mode, not stored; "audit" stores them anyway and "off" disables the filter
+	FilterMode          string
+	FilterMinConfidence float64 // PII scoring under this is low confidence
+
+	// Record counts, ascending, at which a finding's severity is raised one more tier;
+	// counts are the finding's matches, extrapolated to the table when the scanner sampled it
+	VolumeSeverityThresholds []string
+
+	// Findings whose sample text is in a language outside SupportedLanguages are
+	// held for the language review queue ("tag"), have their confidence multiplied
+	// by LanguageDowngradeFactor ("downgrade"), or only have the language recorded ("off")
+	LanguageHandling        string
+	SupportedLanguages      []string // ISO 639-1 codes
+	LanguageDowngradeFactor float64
+
+	UploadSessionTTLHours int // Unfinished chunked upload sessions are discarded after this long
+
+	// Backpressure: ingestion jobs are admitted up to a concurrency limit that
+	// shrinks while Postgres is slow, and rejected with 503 once it is too slow
+	AdmissionEnabled             bool
+	AdmissionMinConcurrent       int
+	AdmissionMaxConcurrent       int
+	AdmissionMaxQueue            int // Jobs that may wait for a slot
+	AdmissionQueueTimeoutSeconds int // Longest a job waits before it is rejected
+	DBLatencyTargetMs            int // Probe latency above which the limit shrinks
+	DBLatencyShedMs              int // Probe latency above which new jobs are rejected
+	DBProbeIntervalSeconds       int
+	RetryAfterSeconds            int // Retry-After sent with 503 responses
+
+	// Transactions failing with a transient Postgres error, such as a serialization
+	// failure or a dropped connection, are run again with jittered backoff
+	TxRetryMaxAttempts int // Attempts including the first; 1 disables retries
+	TxRetryBaseDelayMs int
+	TxRetryMaxDelayMs  int
+}
+
+// AlertingConfig controls alert rule emails and scheduled digests
+type AlertingConfig struct {
+	Enabled         bool
+	SMTPHost        string // Empty disables sending; rules are still evaluated and logged
+	SMTPPort        int
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPFrom        string
+	IntervalSeconds int    // How often alert rules are evaluated against new findings
+	DigestHourUTC   int    // Hour of day digests are sent; weekly digests go out on Mondays
+	DashboardURL    string // Optional link included in emails
+}
+
+// TrashConfig controls soft-deleted scan data
+type TrashConfig struct {
+	PurgeEnabled         bool
+	RetentionDays        int // Trashed data can be restored for this long, then it is purged
+	PurgeIntervalMinutes int
+}
+
 type PIIStringMode string
 
 const (
@@ -40,7 +581,394 @@ func LoadConfig() *Config {
 		PIIStorage: PIIStorageConfig{
 			Mode: getPIIMode(),
 		},
+		FindingAging: FindingAgingConfig{
+			Enabled:         getEnvBool("STALE_DETECTION_ENABLED", false),
+			ScanWindow:      getEnvInt("STALE_DETECTION_SCAN_WINDOW", 3),
+			Action:          getEnvString("STALE_DETECTION_ACTION", "queue"),
+			IntervalMinutes: getEnvInt("STALE_DETECTION_INTERVAL_MINUTES", 60),
+		},
+		Archive: ArchiveConfig{
+			Enabled:         getEnvBool("FINDING_ARCHIVE_ENABLED", false),
+			Provider:        getEnvString("FINDING_ARCHIVE_PROVIDER", "s3"),
+			Bucket:          getEnvString("FINDING_ARCHIVE_BUCKET", ""),
+			Region:          getEnvString("FINDING_ARCHIVE_REGION", "us-east-1"),
+			Endpoint:        getEnvString("FINDING_ARCHIVE_ENDPOINT", ""),
+			Prefix:          getEnvString("FINDING_ARCHIVE_PREFIX", "finding-archives"),
+			AccessKey:       getEnvString("FINDING_ARCHIVE_ACCESS_KEY", ""),
+			SecretKey:       getEnvString("FINDING_ARCHIVE_SECRET_KEY", ""),
+			LocalPath:       getEnvString("FINDING_ARCHIVE_LOCAL_PATH", ""),
+			OlderThanDays:   getEnvInt("FINDING_ARCHIVE_OLDER_THAN_DAYS", 365),
+			ClosedOnly:      getEnvBool("FINDING_ARCHIVE_CLOSED_ONLY", true),
+			IntervalMinutes: getEnvInt("FINDING_ARCHIVE_INTERVAL_MINUTES", 1440),
+		},
+		AuditExport: AuditExportConfig{
+			Enabled:            getEnvBool("AUDIT_EXPORT_ENABLED", false),
+			Format:             strings.ToLower(getEnvString("AUDIT_EXPORT_FORMAT", "cef")),
+			SyslogNetwork:      strings.ToLower(getEnvString("AUDIT_EXPORT_SYSLOG_NETWORK", "udp")),
+			SyslogAddress:      getEnvString("AUDIT_EXPORT_SYSLOG_ADDRESS", ""),
+			HTTPEndpoint:       getEnvString("AUDIT_EXPORT_HTTP_ENDPOINT", ""),
+			HTTPToken:          getEnvString("AUDIT_EXPORT_HTTP_TOKEN", ""),
+			HTTPTimeoutSeconds: getEnvInt("AUDIT_EXPORT_HTTP_TIMEOUT_SECONDS", 30),
+			Actions:            getEnvList("AUDIT_EXPORT_ACTIONS"),
+			IntervalSeconds:    getEnvInt("AUDIT_EXPORT_INTERVAL_SECONDS", 10),
+			BatchSize:          getEnvInt("AUDIT_EXPORT_BATCH_SIZE", 500),
+		},
+		Events: EventsConfig{
+			PubSub:           strings.ToLower(getEnvString("EVENTS_PUBSUB", "postgres")),
+			BufferSize:       getEnvInt("EVENTS_BUFFER_SIZE", 64),
+			HeartbeatSeconds: getEnvInt("EVENTS_HEARTBEAT_SECONDS", 15),
+		},
+		Kafka: KafkaConfig{
+			Brokers:         getEnvList("KAFKA_BROKERS"),
+			Topic:           getEnvString("KAFKA_TOPIC", "arc-hawk.events"),
+			ClientID:        getEnvString("KAFKA_CLIENT_ID", "arc-hawk"),
+			TLS:             getEnvBool("KAFKA_TLS", false),
+			SASLMechanism:   strings.ToUpper(getEnvString("KAFKA_SASL_MECHANISM", "")),
+			SASLUsername:    getEnvString("KAFKA_SASL_USERNAME", ""),
+			SASLPassword:    getEnvString("KAFKA_SASL_PASSWORD", ""),
+			Acks:            getEnvInt("KAFKA_ACKS", -1),
+			BufferSize:      getEnvInt("KAFKA_BUFFER_SIZE", 10000),
+			BatchSize:       getEnvInt("KAFKA_BATCH_SIZE", 500),
+			FlushIntervalMs: getEnvInt("KAFKA_FLUSH_INTERVAL_MS", 500),
+			TimeoutSeconds:  getEnvInt("KAFKA_TIMEOUT_SECONDS", 10),
+			EventTypes:      getEnvList("KAFKA_EVENT_TYPES"),
+		},
+		FPClustering: FPClusteringConfig{
+			Enabled:         getEnvBool("FP_CLUSTERING_ENABLED", false),
+			IntervalMinutes: getEnvInt("FP_CLUSTERING_INTERVAL_MINUTES", 1440),
+			WindowDays:      getEnvInt("FP_CLUSTERING_WINDOW_DAYS", 90),
+			MinSupport:      getEnvInt("FP_CLUSTERING_MIN_SUPPORT", 5),
+			MinPrecision:    getEnvFloat("FP_CLUSTERING_MIN_PRECISION", 0.9),
+			PathDepth:       getEnvInt("FP_CLUSTERING_PATH_DEPTH", 3),
+		},
+		SSO: SSOConfig{
+			SuccessRedirectURL: getEnvString("SSO_SUCCESS_REDIRECT_URL", ""),
+			StateTTLMinutes:    getEnvInt("SSO_STATE_TTL_MINUTES", 10),
+			HTTPTimeoutSeconds: getEnvInt("SSO_HTTP_TIMEOUT_SECONDS", 10),
+		},
+		Sessions: SessionConfig{
+			ValidationCacheSeconds: getEnvInt("SESSION_VALIDATION_CACHE_SECONDS", 0),
+			CleanupIntervalMinutes: getEnvInt("SESSION_CLEANUP_INTERVAL_MINUTES", 60),
+			RetentionDays:          getEnvInt("SESSION_RETENTION_DAYS", 30),
+		},
+		Approvals: RemediationApprovalConfig{
+			Enabled:         getEnvBool("REMEDIATION_APPROVAL_ENABLED", true),
+			Actions:         getEnvList("REMEDIATION_APPROVAL_ACTIONS"),
+			ImpactThreshold: getEnvInt("REMEDIATION_APPROVAL_IMPACT_THRESHOLD", 0),
+			ExpiryHours:     getEnvInt("REMEDIATION_APPROVAL_EXPIRY_HOURS", 72),
+		},
+		Batches: RemediationBatchConfig{
+			Concurrency:     getEnvInt("REMEDIATION_BATCH_CONCURRENCY", 2),
+			RatePerSecond:   getEnvFloat("REMEDIATION_BATCH_RATE_PER_SECOND", 5),
+			ConnectorLimits: getEnvListDefault("REMEDIATION_BATCH_CONNECTOR_LIMITS", []string{"filesystem=8:0", "s3=4:20"}),
+			MaxFindings:     getEnvInt("REMEDIATION_BATCH_MAX_FINDINGS", 10000),
+		},
+		Reporting: ReportingConfig{
+			SchedulerEnabled:      getEnvBool("REPORT_SCHEDULER_ENABLED", true),
+			IntervalSeconds:       getEnvInt("REPORT_SCHEDULER_INTERVAL_SECONDS", 60),
+			DeliveryHourUTC:       getEnvInt("REPORT_DELIVERY_HOUR_UTC", 7),
+			MaxRows:               getEnvInt("REPORT_MAX_ROWS", 10000),
+			WebhookTimeoutSeconds: getEnvInt("REPORT_WEBHOOK_TIMEOUT_SECONDS", 30),
+		},
+		Shadow: ShadowClassificationConfig{
+			Version: getEnvString("CLASSIFIER_SHADOW_VERSION", ""),
+			Weights: ClassificationConfig{
+				WeightRules:   getEnvFloat("CLASSIFIER_SHADOW_WEIGHT_RULES", getEnvFloat("CLASSIFICATION_WEIGHT_RULES", 0.40)),
+				WeightContext: getEnvFloat("CLASSIFIER_SHADOW_WEIGHT_CONTEXT", getEnvFloat("CLASSIFICATION_WEIGHT_CONTEXT", 0.30)),
+				WeightEntropy: getEnvFloat("CLASSIFIER_SHADOW_WEIGHT_ENTROPY", getEnvFloat("CLASSIFICATION_WEIGHT_ENTROPY", 0.10)),
+				Threshold:     getEnvFloat("CLASSIFIER_SHADOW_CLASSIFICATION_THRESHOLD", getEnvFloat("CLASSIFICATION_THRESHOLD", 0.60)),
+			},
+			Threshold:  getEnvFloat("CLASSIFIER_SHADOW_INGESTION_THRESHOLD", 0.45),
+			SampleRate: getEnvFloat("CLASSIFIER_SHADOW_SAMPLE_RATE", 1.0),
+			QueueSize:  getEnvInt("CLASSIFIER_SHADOW_QUEUE_SIZE", 1000),
+			Workers:    getEnvInt("CLASSIFIER_SHADOW_WORKERS", 2),
+		},
+		AccessAudit: AccessAuditConfig{
+			Enabled:          getEnvBool("ACCESS_AUDIT_ENABLED", true),
+			RequirePurpose:   getEnvBool("ACCESS_AUDIT_REQUIRE_PURPOSE", false),
+			AnomalyFactor:    getEnvFloat("ACCESS_AUDIT_ANOMALY_FACTOR", 5.0),
+			AnomalyMinViews:  getEnvInt("ACCESS_AUDIT_ANOMALY_MIN_VIEWS", 200),
+			ReportWindowDays: getEnvInt("ACCESS_AUDIT_REPORT_WINDOW_DAYS", 30),
+		},
+		Jobs: JobsConfig{
+			Enabled:             getEnvBool("JOBS_ENABLED", true),
+			Workers:             getEnvInt("JOBS_WORKERS", 4),
+			PollIntervalSeconds: getEnvInt("JOBS_POLL_INTERVAL_SECONDS", 5),
+			MaxAttempts:         getEnvInt("JOBS_MAX_ATTEMPTS", 5),
+			BackoffBaseSeconds:  getEnvInt("JOBS_BACKOFF_BASE_SECONDS", 30),
+			BackoffMaxSeconds:   getEnvInt("JOBS_BACKOFF_MAX_SECONDS", 3600),
+			StaleAfterMinutes:   getEnvInt("JOBS_STALE_AFTER_MINUTES", 15),
+		},
+		SampleText: SampleTextConfig{
+			Policy: getEnvString("SAMPLE_TEXT_POLICY", "mask"),
+		},
+		Coverage: ConnectionCoverageConfig{
+			StaleAfterDays:     getEnvInt("CONNECTION_STALE_AFTER_DAYS", 30),
+			CheckIntervalHours: getEnvInt("CONNECTION_COVERAGE_CHECK_HOURS", 24),
+		},
+		Health: ConnectionHealthConfig{
+			IntervalMinutes: getEnvInt("CONNECTION_HEALTH_INTERVAL_MINUTES", 60),
+			Concurrency:     getEnvInt("CONNECTION_HEALTH_CONCURRENCY", 4),
+		},
+		Policy: PolicyConfig{
+			EvaluatorEnabled:      getEnvBool("POLICY_EVALUATOR_ENABLED", true),
+			IntervalSeconds:       getEnvInt("POLICY_EVALUATOR_INTERVAL_SECONDS", 300),
+			EvaluationHourUTC:     getEnvInt("POLICY_EVALUATION_HOUR_UTC", 2),
+			WebhookTimeoutSeconds: getEnvInt("POLICY_WEBHOOK_TIMEOUT_SECONDS", 30),
+		},
+		PostureScore: ComplianceScoreConfig{
+			WeightExposure:          getEnvFloat("COMPLIANCE_SCORE_WEIGHT_EXPOSURE", 0.35),
+			WeightVelocity:          getEnvFloat("COMPLIANCE_SCORE_WEIGHT_VELOCITY", 0.25),
+			WeightBacklog:           getEnvFloat("COMPLIANCE_SCORE_WEIGHT_BACKLOG", 0.2),
+			WeightPolicy:            getEnvFloat("COMPLIANCE_SCORE_WEIGHT_POLICY", 0.2),
+			VelocityWindowDays:      getEnvInt("COMPLIANCE_SCORE_VELOCITY_WINDOW_DAYS", 30),
+			ReviewSLADays:           getEnvInt("COMPLIANCE_SCORE_REVIEW_SLA_DAYS", 7),
+			SnapshotIntervalMinutes: getEnvInt("COMPLIANCE_SCORE_SNAPSHOT_INTERVAL_MINUTES", 60),
+		},
+		ScanAnomaly: ScanAnomalyConfig{
+			Enabled:         getEnvBool("SCAN_ANOMALY_ENABLED", true),
+			IntervalMinutes: getEnvInt("SCAN_ANOMALY_INTERVAL_MINUTES", 15),
+			SpikeFactor:     getEnvFloat("SCAN_ANOMALY_SPIKE_FACTOR", 10),
+			MinMatches:      getEnvInt("SCAN_ANOMALY_MIN_MATCHES", 50),
+			BaselineRuns:    getEnvInt("SCAN_ANOMALY_BASELINE_RUNS", 5),
+			MinBaselineRuns: getEnvInt("SCAN_ANOMALY_MIN_BASELINE_RUNS", 3),
+		},
+		Ticketing: TicketingConfig{
+			HTTPTimeoutSeconds:  getEnvInt("TICKETING_HTTP_TIMEOUT_SECONDS", 30),
+			SyncEnabled:         getEnvBool("TICKETING_SYNC_ENABLED", true),
+			SyncIntervalMinutes: getEnvInt("TICKETING_SYNC_INTERVAL_MINUTES", 5),
+		},
+		Sharing: SharingConfig{
+			DefaultTTLHours: getEnvInt("SHARE_LINK_DEFAULT_TTL_HOURS", 168),
+			MaxTTLDays:      getEnvInt("SHARE_LINK_MAX_TTL_DAYS", 90),
+			CacheSeconds:    getEnvInt("SHARE_LINK_CACHE_SECONDS", 60),
+		},
+		SecretVerify: SecretVerificationConfig{
+			WorkerEnabled:      getEnvBool("SECRET_VERIFICATION_WORKER_ENABLED", true),
+			IntervalMinutes:    getEnvInt("SECRET_VERIFICATION_INTERVAL_MINUTES", 60),
+			BatchSize:          getEnvInt("SECRET_VERIFICATION_BATCH_SIZE", 50),
+			RecheckDays:        getEnvInt("SECRET_VERIFICATION_RECHECK_DAYS", 7),
+			HTTPTimeoutSeconds: getEnvInt("SECRET_VERIFICATION_HTTP_TIMEOUT_SECONDS", 10),
+			AWSRegion:          getEnvString("SECRET_VERIFICATION_AWS_REGION", "us-east-1"),
+		},
+		Erasure: ErasureConfig{
+			SigningKey:        getEnvString("ERASURE_CERTIFICATE_SIGNING_KEY", ""),
+			AllowGeneratedKey: getEnvBool("ERASURE_ALLOW_GENERATED_SIGNING_KEY", getEnvString("GIN_MODE", "debug") != "release"),
+		},
+		Metrics: MetricsConfig{
+			Token:      getEnvString("METRICS_TOKEN", ""),
+			ListenAddr: getEnvString("METRICS_LISTEN_ADDR", ""),
+		},
+		Proxy: ProxyConfig{
+			TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+		},
+		Quota: QuotaConfig{
+			MaxFindings:       getEnvInt("QUOTA_MAX_FINDINGS", 0),
+			MaxScanRunsPerDay: getEnvInt("QUOTA_MAX_SCAN_RUNS_PER_DAY", 0),
+			OverageBehavior:   getEnvString("QUOTA_OVERAGE_BEHAVIOR", "reject"),
+			DownsampleEvery:   getEnvInt("QUOTA_DOWNSAMPLE_EVERY", 10),
+		},
+		Query: QueryGuardConfig{
+			InteractiveTimeoutSeconds: getEnvInt("QUERY_INTERACTIVE_TIMEOUT_SECONDS", 15),
+			ReportTimeoutSeconds:      getEnvInt("QUERY_REPORT_TIMEOUT_SECONDS", 60),
+			BatchTimeoutSeconds:       getEnvInt("QUERY_BATCH_TIMEOUT_SECONDS", 300),
+			InteractiveSlowMs:         getEnvInt("QUERY_INTERACTIVE_SLOW_MS", 500),
+			ReportSlowMs:              getEnvInt("QUERY_REPORT_SLOW_MS", 5000),
+			BatchSlowMs:               getEnvInt("QUERY_BATCH_SLOW_MS", 30000),
+			PageSize:                  getEnvInt("QUERY_PAGE_SIZE", 500),
+		},
+		Inventory: InventoryConfig{
+			AWSRegions:         getEnvList("INVENTORY_AWS_REGIONS"),
+			AWSAccessKey:       getEnvString("INVENTORY_AWS_ACCESS_KEY", ""),
+			AWSSecretKey:       getEnvString("INVENTORY_AWS_SECRET_KEY", ""),
+			AzureTenantID:      getEnvString("INVENTORY_AZURE_TENANT_ID", ""),
+			AzureClientID:      getEnvString("INVENTORY_AZURE_CLIENT_ID", ""),
+			AzureClientSecret:  getEnvString("INVENTORY_AZURE_CLIENT_SECRET", ""),
+			AzureSubscriptions: getEnvList("INVENTORY_AZURE_SUBSCRIPTIONS"),
+			EnvironmentTags:    getEnvListDefault("INVENTORY_ENVIRONMENT_TAGS", []string{"Environment", "env"}),
+			OwnerTags:          getEnvListDefault("INVENTORY_OWNER_TAGS", []string{"Owner", "owner"}),
+		},
+		Payload: FindingPayloadConfig{
+			InlineLimitBytes: getEnvInt("FINDING_PAYLOAD_INLINE_LIMIT_BYTES", 2048),
+		},
+		Waivers: RiskWaiverConfig{
+			MaxDays:              getEnvInt("RISK_WAIVER_MAX_DAYS", 365),
+			WarningDays:          getEnvInt("RISK_WAIVER_WARNING_DAYS", 7),
+			SweepIntervalMinutes: getEnvInt("RISK_WAIVER_SWEEP_INTERVAL_MINUTES", 60),
+		},
+		AnalyticsSink: AnalyticsSinkConfig{
+			ClickHouseURL:       getEnvString("ANALYTICS_CLICKHOUSE_URL", ""),
+			ClickHouseDatabase:  getEnvString("ANALYTICS_CLICKHOUSE_DATABASE", "arc_hawk"),
+			ClickHouseUser:      getEnvString("ANALYTICS_CLICKHOUSE_USER", "default"),
+			ClickHousePassword:  getEnvString("ANALYTICS_CLICKHOUSE_PASSWORD", ""),
+			SyncIntervalMinutes: getEnvInt("ANALYTICS_SYNC_INTERVAL_MINUTES", 5),
+			BatchSize:           getEnvInt("ANALYTICS_SYNC_BATCH_SIZE", 5000),
+			SettleSeconds:       getEnvInt("ANALYTICS_SYNC_SETTLE_SECONDS", 120),
+			ReadFromSink:        getEnvBool("ANALYTICS_READ_FROM_SINK", false),
+		},
+		TenantExport: TenantExportConfig{
+			Provider:  getEnvString("TENANT_EXPORT_PROVIDER", "s3"),
+			Bucket:    getEnvString("TENANT_EXPORT_BUCKET", ""),
+			Region:    getEnvString("TENANT_EXPORT_REGION", "us-east-1"),
+			Endpoint:  getEnvString("TENANT_EXPORT_ENDPOINT", ""),
+			Prefix:    getEnvString("TENANT_EXPORT_PREFIX", "tenant-exports"),
+			AccessKey: getEnvString("TENANT_EXPORT_ACCESS_KEY", ""),
+			SecretKey: getEnvString("TENANT_EXPORT_SECRET_KEY", ""),
+			LocalPath: getEnvString("TENANT_EXPORT_LOCAL_PATH", ""),
+		},
+		AssetPaths: AssetPathConfig{
+			CaseSensitiveSources: getEnvList("ASSET_PATH_CASE_SENSITIVE_SOURCES"),
+			KeepTrailingSlash:    getEnvBool("ASSET_PATH_KEEP_TRAILING_SLASH", false),
+		},
+		Search: SearchConfig{
+			Backend:             getEnvString("SEARCH_BACKEND", ""),
+			IndexPath:           getEnvString("SEARCH_INDEX_PATH", ""),
+			OpenSearchURL:       getEnvString("SEARCH_OPENSEARCH_URL", ""),
+			OpenSearchIndex:     getEnvString("SEARCH_OPENSEARCH_INDEX", "arc-hawk-findings"),
+			OpenSearchUser:      getEnvString("SEARCH_OPENSEARCH_USER", ""),
+			OpenSearchPassword:  getEnvString("SEARCH_OPENSEARCH_PASSWORD", ""),
+			SyncIntervalSeconds: getEnvInt("SEARCH_SYNC_INTERVAL_SECONDS", 5),
+			BatchSize:           getEnvInt("SEARCH_SYNC_BATCH_SIZE", 1000),
+			SettleSeconds:       getEnvInt("SEARCH_SYNC_SETTLE_SECONDS", 10),
+		},
+		Freshness: ScanFreshnessConfig{
+			DefaultFrequencyHours: getEnvInt("SCAN_FRESHNESS_DEFAULT_HOURS", 168),
+			IntervalMinutes:       getEnvInt("SCAN_FRESHNESS_INTERVAL_MINUTES", 60),
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled:                getEnvBool("IDEMPOTENCY_ENABLED", true),
+			WindowHours:            getEnvInt("IDEMPOTENCY_WINDOW_HOURS", 24),
+			CleanupIntervalMinutes: getEnvInt("IDEMPOTENCY_CLEANUP_INTERVAL_MINUTES", 60),
+		},
+		Neo4j: Neo4jConfig{
+			Database:                            getEnvString("NEO4J_DATABASE", "neo4j"),
+			MaxConnectionPoolSize:               getEnvInt("NEO4J_MAX_CONNECTION_POOL_SIZE", 0),
+			ConnectionAcquisitionTimeoutSeconds: getEnvInt("NEO4J_CONNECTION_ACQUISITION_TIMEOUT_SECONDS", 0),
+			MaxConnectionLifetimeMinutes:        getEnvInt("NEO4J_MAX_CONNECTION_LIFETIME_MINUTES", 0),
+			FetchSize:                           getEnvInt("NEO4J_FETCH_SIZE", 0),
+			CausalConsistency:                   getEnvBool("NEO4J_CAUSAL_CONSISTENCY", true),
+			SchemaBootstrap:                     getEnvBool("NEO4J_SCHEMA_BOOTSTRAP", true),
+		},
+		Neo4jBreaker: Neo4jBreakerConfig{
+			FailureThreshold:        getEnvInt("NEO4J_BREAKER_FAILURE_THRESHOLD", 5),
+			CooldownSeconds:         getEnvInt("NEO4J_BREAKER_COOLDOWN_SECONDS", 30),
+			OperationTimeoutSeconds: getEnvInt("NEO4J_OPERATION_TIMEOUT_SECONDS", 10),
+			OutboxIntervalSeconds:   getEnvInt("LINEAGE_OUTBOX_INTERVAL_SECONDS", 30),
+		},
+		Neo4jWrites: Neo4jWriteQueueConfig{
+			MaxConcurrency:       getEnvInt("NEO4J_WRITE_MAX_CONCURRENCY", 4),
+			RealtimeConcurrency:  getEnvInt("NEO4J_WRITE_REALTIME_CONCURRENCY", 4),
+			ResyncConcurrency:    getEnvInt("NEO4J_WRITE_RESYNC_CONCURRENCY", 2),
+			ReconcileConcurrency: getEnvInt("NEO4J_WRITE_RECONCILE_CONCURRENCY", 1),
+			RealtimeMaxQueued:    getEnvInt("NEO4J_WRITE_REALTIME_MAX_QUEUED", 100),
+		},
+		Ingestion: IngestionConfig{
+			Workers:      getEnvInt("INGESTION_WORKERS", 4),
+			MaxPayloadMB: getEnvInt("INGESTION_MAX_PAYLOAD_MB", 100),
+			MaxFindings:  getEnvInt("INGESTION_MAX_FINDINGS", 50000),
+
+			MaxMatchesPerFinding: getEnvInt("INGESTION_MAX_MATCHES_PER_FINDING", 1000),
+
+			FilterMode:          getEnvString("INGESTION_FILTER_MODE", "drop"),
+			FilterMinConfidence: getEnvFloat("INGESTION_FILTER_MIN_CONFIDENCE", 0.45),
+
+			VolumeSeverityThresholds: getEnvListDefault("INGESTION_VOLUME_SEVERITY_THRESHOLDS", []string{"1000", "100000"}),
+
+			LanguageHandling:        getEnvString("INGESTION_LANGUAGE_HANDLING", "tag"),
+			SupportedLanguages:      getEnvListDefault("INGESTION_SUPPORTED_LANGUAGES", []string{"en"}),
+			LanguageDowngradeFactor: getEnvFloat("INGESTION_LANGUAGE_DOWNGRADE_FACTOR", 0.5),
+
+			UploadSessionTTLHours: getEnvInt("INGESTION_UPLOAD_SESSION_TTL_HOURS", 24),
+
+			AdmissionEnabled:             getEnvBool("INGESTION_ADMISSION_ENABLED", true),
+			AdmissionMinConcurrent:       getEnvInt("INGESTION_ADMISSION_MIN_CONCURRENT", 1),
+			AdmissionMaxConcurrent:       getEnvInt("INGESTION_ADMISSION_MAX_CONCURRENT", 8),
+			AdmissionMaxQueue:            getEnvInt("INGESTION_ADMISSION_MAX_QUEUE", 16),
+			AdmissionQueueTimeoutSeconds: getEnvInt("INGESTION_ADMISSION_QUEUE_TIMEOUT_SECONDS", 10),
+			DBLatencyTargetMs:            getEnvInt("INGESTION_DB_LATENCY_TARGET_MS", 100),
+			DBLatencyShedMs:              getEnvInt("INGESTION_DB_LATENCY_SHED_MS", 1000),
+			DBProbeIntervalSeconds:       getEnvInt("INGESTION_DB_PROBE_INTERVAL_SECONDS", 2),
+			RetryAfterSeconds:            getEnvInt("INGESTION_RETRY_AFTER_SECONDS", 5),
+
+			TxRetryMaxAttempts: getEnvInt("INGESTION_TX_RETRY_MAX_ATTEMPTS", 3),
+			TxRetryBaseDelayMs: getEnvInt("INGESTION_TX_RETRY_BASE_DELAY_MS", 100),
+			TxRetryMaxDelayMs:  getEnvInt("INGESTION_TX_RETRY_MAX_DELAY_MS", 2000),
+		},
+		Alerting: AlertingConfig{
+			Enabled:         getEnvBool("ALERTING_ENABLED", false),
+			SMTPHost:        getEnvString("SMTP_HOST", ""),
+			SMTPPort:        getEnvInt("SMTP_PORT", 587),
+			SMTPUsername:    getEnvString("SMTP_USERNAME", ""),
+			SMTPPassword:    getEnvString("SMTP_PASSWORD", ""),
+			SMTPFrom:        getEnvString("SMTP_FROM", "arc-hawk@localhost"),
+			IntervalSeconds: getEnvInt("ALERTING_INTERVAL_SECONDS", 60),
+			DigestHourUTC:   getEnvInt("ALERTING_DIGEST_HOUR_UTC", 8),
+			DashboardURL:    getEnvString("ALERTING_DASHBOARD_URL", ""),
+		},
+		Trash: TrashConfig{
+			PurgeEnabled:         getEnvBool("TRASH_PURGE_ENABLED", true),
+			RetentionDays:        getEnvInt("TRASH_RETENTION_DAYS", 30),
+			PurgeIntervalMinutes: getEnvInt("TRASH_PURGE_INTERVAL_MINUTES", 60),
+		},
+		Evidence: EvidenceConfig{
+			Provider:               getEnvString("FINDING_EVIDENCE_PROVIDER", "s3"),
+			Bucket:                 getEnvString("FINDING_EVIDENCE_BUCKET", ""),
+			Region:                 getEnvString("FINDING_EVIDENCE_REGION", "us-east-1"),
+			Endpoint:               getEnvString("FINDING_EVIDENCE_ENDPOINT", ""),
+			Prefix:                 getEnvString("FINDING_EVIDENCE_PREFIX", "finding-evidence"),
+			AccessKey:              getEnvString("FINDING_EVIDENCE_ACCESS_KEY", ""),
+			SecretKey:              getEnvString("FINDING_EVIDENCE_SECRET_KEY", ""),
+			LocalPath:              getEnvString("FINDING_EVIDENCE_LOCAL_PATH", ""),
+			MaxSizeKB:              getEnvInt("FINDING_EVIDENCE_MAX_SIZE_KB", 5120),
+			AllowedTypes:           getEnvList("FINDING_EVIDENCE_ALLOWED_TYPES"),
+			URLExpiryMinutes:       getEnvInt("FINDING_EVIDENCE_URL_EXPIRY_MINUTES", 15),
+			CleanupIntervalMinutes: getEnvInt("FINDING_EVIDENCE_CLEANUP_INTERVAL_MINUTES", 10),
+		},
+	}
+}
+
+func getEnvString(key, defaultVal string) string {
+	if val, exists := os.LookupEnv(key); exists && val != "" {
+		return val
+	}
+	return defaultVal
+}
+
+// getEnvList reads a comma-separated list, dropping empty entries
+func getEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// getEnvListDefault reads a comma-separated list, or returns defaultVal when unset
+func getEnvListDefault(key string, defaultVal []string) []string {
+	if values := getEnvList(key); len(values) > 0 {
+		return values
+	}
+	return defaultVal
+}
+
+func getEnvInt(key string, defaultVal int) int {
+	if val, exists := os.LookupEnv(key); exists {
+		if i, err := strconv.Atoi(val); err == nil {
+			return i
+		}
 	}
+	return defaultVal
+}
+
+func getEnvBool(key string, defaultVal bool) bool {
+	if val, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return defaultVal
 }
 
 func getEnvFloat(key string, defaultVal float64) float64 {
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Digest frequencies
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// Alert delivery kinds and outcomes
+const (
+	AlertDeliveryKindAlert  = "alert"
+	AlertDeliveryKindDigest = "digest"
+
+	AlertDeliverySent    = "sent"
+	AlertDeliveryFailed  = "failed"
+	AlertDeliverySkipped = "skipped" // No mail transport configured
+)
+
+// AlertRule emails its recipients as soon as new findings match its filters.
+// An empty filter matches any value.
+type AlertRule struct {
+	ID                  uuid.UUID  `json:"id"`
+	TenantID            uuid.UUID  `json:"tenant_id"`
+	Name                string     `json:"name"`
+	Severities          []string   `json:"severities"`
+	ClassificationTypes []string   `json:"classification_types"`
+	Environments        []string   `json:"environments"`
+	PatternNames        []string   `json:"pattern_names"`
+	Recipients          []string   `json:"recipients"`
+	IsActive            bool       `json:"is_active"`
+	EvaluatedUntil      time.Time  `json:"evaluated_until"` // Findings created up to here have been evaluated
+	LastTriggeredAt     *time.Time `json:"last_triggered_at,omitempty"`
+	CreatedBy           string     `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// AlertDigest emails a summary of new findings and remediation progress on a schedule
+type AlertDigest struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	Name       string     `json:"name"`
+	Frequency  string     `json:"frequency"`
+	Recipients []string   `json:"recipients"`
+	IsActive   bool       `json:"is_active"`
+	NextRunAt  time.Time  `json:"next_run_at"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// AlertDelivery records one alert or digest email attempt
+type AlertDelivery struct {
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	RuleID       *uuid.UUID `json:"rule_id,omitempty"`
+	DigestID     *uuid.UUID `json:"digest_id,omitempty"`
+	Kind         string     `json:"kind"`
+	Recipients   []string   `json:"recipients"`
+	Subject      string     `json:"subject"`
+	FindingCount int        `json:"finding_count"`
+	Status       string     `json:"status"`
+	Error        string     `json:"error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// AlertFinding is a new finding that matched an alert rule
+type AlertFinding struct {
+	FindingID          uuid.UUID `json:"finding_id"`
+	AssetID            uuid.UUID `json:"asset_id"`
+	AssetName          string    `json:"asset_name"`
+	PatternName        string    `json:"pattern_name"`
+	Severity           string    `json:"severity"`
+	ClassificationType string    `json:"classification_type"`
+	Environment        string    `json:"environment"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// DigestAssetCount is an asset with its number of new findings in a digest period
+type DigestAssetCount struct {
+	AssetID   uuid.UUID `json:"asset_id"`
+	AssetName string    `json:"asset_name"`
+	Count     int       `json:"count"`
+}
+
+// AlertDigestSummary is the content of a digest for one period
+type AlertDigestSummary struct {
+	PeriodStart          time.Time          `json:"period_start"`
+	PeriodEnd            time.Time          `json:"period_end"`
+	NewFindings          int                `json:"new_findings"`
+	NewBySeverity        map[string]int     `json:"new_by_severity"`
+	NewByClassification  map[string]int     `json:"new_by_classification"`
+	TopAssets            []DigestAssetCount `json:"top_assets"`
+	RemediationsByStatus map[string]int     `json:"remediations_by_status"`
+	FindingsRemediated   int                `json:"findings_remediated"` // Distinct findings with a completed remediation
+	ReviewsByStatus      map[string]int     `json:"reviews_by_status"`
+	OpenCriticalFindings int                `json:"open_critical_findings"`
+}
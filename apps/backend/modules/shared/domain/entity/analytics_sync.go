@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tables mirrored into an analytics sink
+const (
+	AnalyticsStreamFindings        = "findings"
+	AnalyticsStreamClassifications = "classifications"
+)
+
+// AnalyticsWatermark is how far one table has been mirrored into a sink. Rows are
+// mirrored in (updated_at, id) order, so the pair is the position to resume from.
+type AnalyticsWatermark struct {
+	Sink        string     `json:"sink"`
+	Stream      string     `json:"stream"`
+	SyncedUntil time.Time  `json:"synced_until"`
+	LastID      uuid.UUID  `json:"last_id"`
+	RowsSynced  int64      `json:"rows_synced"`
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// AnalyticsFinding is a finding as mirrored into an analytics sink, with the asset
+// dimensions analytical queries group on. Matches and sample text are never mirrored.
+type AnalyticsFinding struct {
+	ID              uuid.UUID `json:"id"`
+	TenantID        uuid.UUID `json:"tenant_id"`
+	AssetID         uuid.UUID `json:"asset_id"`
+	ScanRunID       uuid.UUID `json:"scan_run_id"`
+	PatternName     string    `json:"pattern_name"`
+	Severity        string    `json:"severity"`
+	ConfidenceScore *float64  `json:"confidence_score"`
+	Environment     string    `json:"environment"`
+	DataSource      string    `json:"data_source"`
+	Host            string    `json:"host"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Deleted         bool      `json:"deleted"`
+}
+
+// AnalyticsClassification is a classification as mirrored into an analytics sink
+type AnalyticsClassification struct {
+	ID                 uuid.UUID `json:"id"`
+	TenantID           uuid.UUID `json:"tenant_id"`
+	FindingID          uuid.UUID `json:"finding_id"`
+	ClassificationType string    `json:"classification_type"`
+	SubCategory        string    `json:"sub_category"`
+	ConfidenceScore    float64   `json:"confidence_score"`
+	DPDPACategory      string    `json:"dpdpa_category"`
+	RequiresConsent    bool      `json:"requires_consent"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
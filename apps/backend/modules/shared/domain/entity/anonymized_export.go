@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FindingExportRow is a finding with its asset and latest classification, as read
+// for an anonymized dataset export. Values are decrypted and hold real PII.
+type FindingExportRow struct {
+	FindingID       uuid.UUID
+	ScanRunID       uuid.UUID
+	AssetID         uuid.UUID
+	AssetName       string
+	AssetPath       string
+	Host            string
+	DataSource      string
+	Environment     string
+	PatternName     string
+	Matches         []string
+	SampleText      string
+	Severity        string
+	ConfidenceScore *float64
+	CreatedAt       time.Time
+
+	// Latest classification; ClassificationType is empty when there is none
+	ClassificationType  string
+	SubCategory         string
+	ClassificationScore float64
+	DPDPACategory       string
+	RequiresConsent     bool
+	Justification       string
+}
@@ -25,6 +25,7 @@ type Asset struct {
 	IsMasked        bool                   `json:"is_masked"`
 	MaskedAt        *time.Time             `json:"masked_at,omitempty"`
 	MaskingStrategy string                 `json:"masking_strategy,omitempty"`
+	OrgUnitID       *uuid.UUID             `json:"org_unit_id,omitempty"` // Department or team owning the asset
 	CreatedAt       time.Time              `json:"created_at"`
 	UpdatedAt       time.Time              `json:"updated_at"`
 }
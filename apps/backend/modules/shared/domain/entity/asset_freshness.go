@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssetFreshness is how recently an asset was scanned against the frequency it
+// must be rescanned at. Assets never scanned count from when they were discovered.
+type AssetFreshness struct {
+	AssetID                uuid.UUID  `json:"asset_id"`
+	AssetName              string     `json:"asset_name"`
+	Path                   string     `json:"path"`
+	Host                   string     `json:"host"`
+	Environment            string     `json:"environment"`
+	RiskScore              int        `json:"risk_score"`
+	LastScannedAt          *time.Time `json:"last_scanned_at,omitempty"`
+	NeverScanned           bool       `json:"never_scanned"`
+	ExpectedFrequencyHours int        `json:"expected_frequency_hours"`
+	CustomFrequency        bool       `json:"custom_frequency"` // False when the default frequency applies
+	DueAt                  time.Time  `json:"due_at"`
+	Overdue                bool       `json:"overdue"`
+	OverdueHours           int        `json:"overdue_hours"`
+	OverdueSince           *time.Time `json:"overdue_since,omitempty"` // Due date the SLA job flagged the asset at
+	FreshnessPenalty       int        `json:"freshness_penalty"`       // Risk points included in risk_score
+	DiscoveredAt           time.Time  `json:"-"`
+}
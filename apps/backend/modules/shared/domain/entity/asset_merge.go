@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// MovedRelationship records an asset relationship re-pointed from the merged-away asset.
+// End is "source" or "target", naming the side of the edge that was re-pointed.
+type MovedRelationship struct {
+	ID  uuid.UUID `json:"id"`
+	End string    `json:"end"`
+}
+
+// AssetMergeResult describes what a merge moved onto the surviving asset.
+// Together with the snapshots it is enough to reverse the merge by hand.
+type AssetMergeResult struct {
+	SourceAssetID          uuid.UUID           `json:"source_asset_id"`
+	TargetAssetID          uuid.UUID           `json:"target_asset_id"`
+	SourceAsset            json.RawMessage     `json:"source_asset"`
+	TargetAssetBefore      json.RawMessage     `json:"target_asset_before"`
+	MovedFindingIDs        []uuid.UUID         `json:"moved_finding_ids"`
+	DroppedFindings        []json.RawMessage   `json:"dropped_findings,omitempty"`
+	DroppedClassifications []json.RawMessage   `json:"dropped_classifications,omitempty"`
+	DroppedReviewStates    []json.RawMessage   `json:"dropped_review_states,omitempty"`
+	MovedRelationships     []MovedRelationship `json:"moved_relationships"`
+	DroppedRelationships   []json.RawMessage   `json:"dropped_relationships,omitempty"`
+	MovedMaskingLogIDs     []uuid.UUID         `json:"moved_masking_log_ids"`
+	RemediationActionIDs   []uuid.UUID         `json:"remediation_action_ids"`
+	LineageSynced          bool                `json:"lineage_synced"`
+	LineageError           string              `json:"lineage_error,omitempty"`
+}
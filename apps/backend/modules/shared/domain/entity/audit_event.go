@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent is an audit log row in the shape forwarded to SIEM collectors
+type AuditEvent struct {
+	ID           uuid.UUID              `json:"id"`
+	TenantID     *uuid.UUID             `json:"tenant_id,omitempty"`
+	EventType    string                 `json:"event_type"`
+	Action       string                 `json:"action"`
+	UserID       string                 `json:"user_id"`
+	ResourceType string                 `json:"resource_type,omitempty"`
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	IPAddress    string                 `json:"ip_address,omitempty"`
+	UserAgent    string                 `json:"user_agent,omitempty"`
+	Result       string                 `json:"result,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	EventTime    time.Time              `json:"event_time"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// AuditExportCursor is the high-water mark of events delivered to an export sink
+type AuditExportCursor struct {
+	SinkName      string    `json:"sink_name"`
+	LastCreatedAt time.Time `json:"last_created_at"`
+	LastEventID   uuid.UUID `json:"last_event_id"`
+	ExportedCount int64     `json:"exported_count"`
+	LastError     string    `json:"last_error,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
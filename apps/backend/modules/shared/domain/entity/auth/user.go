@@ -9,10 +9,11 @@ import (
 type UserRole string
 
 const (
-	RoleAdmin    UserRole = "admin"
-	RoleAuditor  UserRole = "auditor"
-	RoleOperator UserRole = "operator"
-	RoleViewer   UserRole = "viewer"
+	RoleAdmin      UserRole = "admin"
+	RoleAuditor    UserRole = "auditor"
+	RoleOperator   UserRole = "operator"
+	RoleRemediator UserRole = "remediator"
+	RoleViewer     UserRole = "viewer"
 )
 
 type Permission string
@@ -45,6 +46,10 @@ var RolePermissions = map[UserRole][]Permission{
 		PermissionSourceManage, PermissionSourceRead,
 		PermissionReport,
 	},
+	RoleRemediator: {
+		PermissionScanRead, PermissionSourceRead, PermissionReport,
+		PermissionRemediate, PermissionRemediateApprove,
+	},
 	RoleViewer: {
 		PermissionScanRead, PermissionSourceRead, PermissionReport,
 	},
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantBenchmarkMetrics are one tenant's raw exposure figures used to build
+// cross-tenant baselines. They never leave the service layer.
+type TenantBenchmarkMetrics struct {
+	TenantID               uuid.UUID
+	AssetCount             int
+	FindingCount           int
+	EligibleFindings       int            // Findings detected at least 30 days ago
+	RemediatedWithin30Days int            // Eligible findings remediated or resolved within 30 days of detection
+	PatternCounts          map[string]int // Findings per PII type
+}
+
+// BenchmarkParticipation reports whether a tenant contributes to benchmark baselines
+type BenchmarkParticipation struct {
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	OptedOut   bool       `json:"opted_out"`
+	OptedOutBy string     `json:"opted_out_by,omitempty"`
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ValueFlow is an asset outside a set of assets that holds copies of PII values
+// observed in the set
+type ValueFlow struct {
+	AssetID      uuid.UUID `json:"asset_id"`
+	AssetName    string    `json:"asset_name"`
+	AssetPath    string    `json:"asset_path"`
+	Host         string    `json:"host,omitempty"`
+	System       string    `json:"system"` // Source system, or the data source when none is recorded
+	Environment  string    `json:"environment,omitempty"`
+	RiskScore    int       `json:"risk_score"`
+	PatternNames []string  `json:"pattern_names"`
+	SharedValues int       `json:"shared_values"`
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Compliance frameworks whose category taxonomy can be mapped per tenant
+const (
+	ComplianceFrameworkDPDPA = "DPDPA"
+	ComplianceFrameworkGDPR  = "GDPR"
+	ComplianceFrameworkCCPA  = "CCPA"
+	ComplianceFrameworkPDPA  = "PDPA"
+)
+
+// IsComplianceFramework reports whether framework is a supported compliance framework
+func IsComplianceFramework(framework string) bool {
+	switch framework {
+	case ComplianceFrameworkDPDPA, ComplianceFrameworkGDPR, ComplianceFrameworkCCPA, ComplianceFrameworkPDPA:
+		return true
+	}
+	return false
+}
+
+// CategoryMapping is the framework category and consent requirement assigned to a finding
+type CategoryMapping struct {
+	Category        string `json:"category"`
+	RequiresConsent bool   `json:"requires_consent"`
+}
+
+// ComplianceMapping is one version of a tenant's mapping onto a framework's categories.
+// ClassificationCategories apply to findings classified by the multi-signal engine;
+// PIITypeCategories apply to SDK-verified findings, which carry a PII type.
+type ComplianceMapping struct {
+	ID                       uuid.UUID                  `json:"id"`
+	TenantID                 uuid.UUID                  `json:"tenant_id"`
+	Framework                string                     `json:"framework"`
+	Version                  int                        `json:"version"` // 0 for the built-in default
+	ClassificationCategories map[string]CategoryMapping `json:"classification_categories"`
+	PIITypeCategories        map[string]CategoryMapping `json:"pii_type_categories"`
+	Comment                  string                     `json:"comment,omitempty"`
+	CreatedBy                string                     `json:"created_by,omitempty"`
+	CreatedAt                time.Time                  `json:"created_at"`
+}
+
+// IsDefault reports whether the mapping is the built-in one rather than a tenant version
+func (m *ComplianceMapping) IsDefault() bool {
+	return m.Version == 0
+}
+
+// ForClassification returns the category of a multi-signal classification
+func (m *ComplianceMapping) ForClassification(classification string) CategoryMapping {
+	if mapping, ok := m.ClassificationCategories[classification]; ok {
+		return mapping
+	}
+	return CategoryMapping{Category: "N/A"}
+}
+
+// ForPIIType returns the category of an SDK-verified PII type
+func (m *ComplianceMapping) ForPIIType(piiType string) CategoryMapping {
+	if mapping, ok := m.PIITypeCategories[piiType]; ok {
+		return mapping
+	}
+	return CategoryMapping{Category: "General Personal Data"}
+}
+
+// DefaultComplianceMapping returns the built-in mapping used until a tenant saves its own.
+// Only DPDPA ships with categories; other frameworks start empty.
+func DefaultComplianceMapping(framework string) *ComplianceMapping {
+	mapping := &ComplianceMapping{
+		Framework:                framework,
+		ClassificationCategories: map[string]CategoryMapping{},
+		PIITypeCategories:        map[string]CategoryMapping{},
+	}
+	if framework != ComplianceFrameworkDPDPA {
+		return mapping
+	}
+
+	mapping.ClassificationCategories = map[string]CategoryMapping{
+		"Sensitive Personal Data": {Category: "Sensitive Personal Data", RequiresConsent: true},
+		"Personal Data":           {Category: "Personal Data", RequiresConsent: true},
+		"Secrets":                 {Category: "N/A"},
+	}
+
+	// DPDPA 2023 categories for the locked India PII types
+	mapping.PIITypeCategories = map[string]CategoryMapping{
+		"IN_AADHAAR":         {Category: "Sensitive Personal Data", RequiresConsent: true},
+		"IN_PAN":             {Category: "Financial Identifier", RequiresConsent: true},
+		"IN_PASSPORT":        {Category: "Government Identifier", RequiresConsent: true},
+		"CREDIT_CARD":        {Category: "Financial Data", RequiresConsent: true},
+		"IN_UPI":             {Category: "Financial Identifier"},
+		"IN_IFSC":            {Category: "Financial Identifier"},
+		"IN_BANK_ACCOUNT":    {Category: "Financial Data"},
+		"IN_PHONE":           {Category: "Contact Information"},
+		"EMAIL_ADDRESS":      {Category: "Contact Information"},
+		"IN_VOTER_ID":        {Category: "Government Identifier"},
+		"IN_DRIVING_LICENSE": {Category: "Government Identifier", RequiresConsent: true},
+	}
+	return mapping
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Compliance posture score components
+const (
+	ComplianceComponentExposure = "pii_exposure"
+	ComplianceComponentVelocity = "remediation_velocity"
+	ComplianceComponentBacklog  = "review_backlog"
+	ComplianceComponentPolicy   = "policy_violations"
+)
+
+// ComplianceScoreInputs are the tenant counts the compliance posture score is computed from
+type ComplianceScoreInputs struct {
+	TotalAssets    int `json:"total_assets"`
+	ExposedAssets  int `json:"exposed_assets"` // Unmasked assets with open critical or high findings
+	OpenFindings   int `json:"open_findings"`
+	OverdueReviews int `json:"overdue_reviews"` // Findings pending review past the review SLA
+	NewFindings    int `json:"new_findings"`    // Found within the velocity window
+	ClosedFindings int `json:"closed_findings"` // Resolved or remediated within the velocity window
+	ActiveRules    int `json:"active_rules"`
+	ViolatedRules  int `json:"violated_rules"`
+	OpenViolations int `json:"open_violations"`
+}
+
+// ComplianceScoreComponent is one part of the compliance posture score
+type ComplianceScoreComponent struct {
+	Name    string         `json:"name"`
+	Score   float64        `json:"score"`  // 0-100, higher is better
+	Weight  float64        `json:"weight"` // Share of the overall score
+	Details map[string]int `json:"details"`
+}
+
+// ComplianceScoreSnapshot is a tenant's compliance posture score on one day
+type ComplianceScoreSnapshot struct {
+	TenantID   uuid.UUID                   `json:"-"`
+	Date       string                      `json:"date"` // YYYY-MM-DD
+	Score      float64                     `json:"score"`
+	Components []*ComplianceScoreComponent `json:"components"`
+	ComputedAt time.Time                   `json:"computed_at"`
+}
@@ -17,7 +17,30 @@ type Connection struct {
 	ValidationStatus string                 `json:"validation_status"` // 'pending', 'valid', 'invalid'
 	LastValidatedAt  *time.Time             `json:"last_validated_at,omitempty"`
 	ValidationError  *string                `json:"validation_error,omitempty"`
+	LastScannedAt    *time.Time             `json:"last_scanned_at,omitempty"` // Start of the latest linked scan run
 	CreatedBy        string                 `json:"created_by"`
 	CreatedAt        time.Time              `json:"created_at"`
 	UpdatedAt        time.Time              `json:"updated_at"`
 }
+
+// ConnectionTrendPoint is one day of scans and findings on a connection
+type ConnectionTrendPoint struct {
+	Day      time.Time `json:"day"`
+	Scans    int       `json:"scans"`
+	Findings int       `json:"findings"`
+	Critical int       `json:"critical"`
+	High     int       `json:"high"`
+}
+
+// ConnectionHealth is the latest test result and failure streak of a connection
+type ConnectionHealth struct {
+	ConnectionID        uuid.UUID  `json:"connection_id"`
+	SourceType          string     `json:"source_type"`
+	ProfileName         string     `json:"profile_name"`
+	ValidationStatus    string     `json:"validation_status"`
+	LastValidatedAt     *time.Time `json:"last_validated_at,omitempty"`
+	ValidationError     *string    `json:"validation_error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	FailingSince        *time.Time `json:"failing_since,omitempty"`
+	LastHealthyAt       *time.Time `json:"last_healthy_at,omitempty"`
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Lawful bases a consent policy can rest on
+const (
+	LawfulBasisExplicit           = "explicit"
+	LawfulBasisLegitimateInterest = "legitimate_interest"
+	LawfulBasisContractual        = "contractual"
+	LawfulBasisLegalObligation    = "legal_obligation"
+)
+
+// LawfulBases lists the accepted lawful bases
+var LawfulBases = []string{LawfulBasisExplicit, LawfulBasisLegitimateInterest, LawfulBasisContractual, LawfulBasisLegalObligation}
+
+// ConsentPolicy is the consent basis for processing a data principal category's data for one purpose
+type ConsentPolicy struct {
+	ID                uuid.UUID          `json:"id"`
+	TenantID          uuid.UUID          `json:"tenant_id"`
+	PrincipalCategory string             `json:"principal_category"`
+	Purpose           string             `json:"purpose"`
+	LawfulBasis       string             `json:"lawful_basis"`
+	Description       string             `json:"description"`
+	IsActive          bool               `json:"is_active"`
+	IsValid           bool               `json:"is_valid"` // Active and, for explicit consent, backed by a live artifact
+	PIITypes          []string           `json:"pii_types"`
+	Artifacts         []*ConsentArtifact `json:"artifacts,omitempty"`
+	CreatedBy         string             `json:"created_by"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+// ConsentArtifact is evidence backing a consent policy, such as a signed form or published notice
+type ConsentArtifact struct {
+	ID               uuid.UUID              `json:"id"`
+	TenantID         uuid.UUID              `json:"tenant_id"`
+	PolicyID         uuid.UUID              `json:"policy_id"`
+	ArtifactType     string                 `json:"artifact_type"`
+	Reference        string                 `json:"reference"`
+	Version          string                 `json:"version"`
+	CollectedAt      time.Time              `json:"collected_at"`
+	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`
+	WithdrawnAt      *time.Time             `json:"withdrawn_at,omitempty"`
+	WithdrawalReason string                 `json:"withdrawal_reason,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	CreatedBy        string                 `json:"created_by"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// IsLive reports whether the artifact has neither expired nor been withdrawn at the given time
+func (a *ConsentArtifact) IsLive(at time.Time) bool {
+	if a.WithdrawnAt != nil {
+		return false
+	}
+	return a.ExpiresAt == nil || a.ExpiresAt.After(at)
+}
+
+// ConsentBasisGap is an asset holding consent-requiring PII with no valid mapped consent basis
+type ConsentBasisGap struct {
+	AssetID      uuid.UUID `json:"asset_id"`
+	AssetName    string    `json:"asset_name"`
+	AssetPath    string    `json:"asset_path"`
+	DataSource   string    `json:"data_source"`
+	PIIType      string    `json:"pii_type"`
+	FindingCount int       `json:"finding_count"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+	Reason       string    `json:"reason"` // UNMAPPED or NO_VALID_BASIS
+}
+
+// PIIConsentMapping lists the consent policies a PII category is mapped to
+type PIIConsentMapping struct {
+	PIIType       string      `json:"pii_type"`
+	PolicyIDs     []uuid.UUID `json:"policy_ids"`
+	HasValidBasis bool        `json:"has_valid_basis"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// DashboardSummaryRow is one pre-aggregated row of the dashboard finding summary view
+type DashboardSummaryRow struct {
+	Severity           string    `json:"severity"`
+	ClassificationType string    `json:"classification_type"`
+	Environment        string    `json:"environment"`
+	FindingCount       int       `json:"finding_count"`
+	AssetCount         int       `json:"asset_count"`
+	LastFindingAt      time.Time `json:"last_finding_at"`
+}
+
+// SummaryRefreshState describes when a summary view was last refreshed
+type SummaryRefreshState struct {
+	ViewName          string     `json:"view_name"`
+	RefreshedAt       time.Time  `json:"refreshed_at"`
+	RefreshDurationMs int        `json:"refresh_duration_ms"`
+	LastError         string     `json:"last_error,omitempty"`
+	DataChangedAt     *time.Time `json:"data_changed_at,omitempty"`
+}
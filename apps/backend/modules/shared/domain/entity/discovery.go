@@ -0,0 +1,132 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AssetTypeTable marks assets that represent a database table
+const AssetTypeTable = "table"
+
+// AssetTypeFile marks assets that represent a file or object
+const AssetTypeFile = "file"
+
+// Coverage statuses of discovered tables and files
+const (
+	CoverageScanned      = "scanned"       // Findings have been recorded for the location
+	CoverageNoFindings   = "no_findings"   // The connection was scanned but nothing was recorded for the location
+	CoverageNeverScanned = "never_scanned" // No completed scan run exists for the location's connection
+)
+
+// DiscoveredColumn describes a table column found by schema discovery
+type DiscoveredColumn struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+	Nullable bool   `json:"nullable"`
+	Position int    `json:"position"`
+}
+
+// DiscoveredTable describes a table found by schema discovery.
+// RowCount comes from catalog statistics and is an estimate.
+type DiscoveredTable struct {
+	Database string             `json:"database"`
+	Schema   string             `json:"schema"`
+	Name     string             `json:"name"`
+	RowCount int64              `json:"row_count"`
+	Columns  []DiscoveredColumn `json:"columns"`
+}
+
+// DiscoveredFile describes a file or object enumerated on a storage connection
+type DiscoveredFile struct {
+	Path       string     `json:"path"`
+	SizeBytes  int64      `json:"size_bytes"`
+	ModifiedAt *time.Time `json:"modified_at,omitempty"`
+}
+
+// DiscoveryResult summarises one discovery crawl of a connection.
+// Truncated is set when file enumeration stopped at its limit.
+type DiscoveryResult struct {
+	ConnectionID uuid.UUID `json:"connection_id"`
+	ProfileName  string    `json:"profile_name"`
+	SourceType   string    `json:"source_type"`
+	Schemas      []string  `json:"schemas"`
+	Tables       int       `json:"tables"`
+	Columns      int       `json:"columns"`
+	NewAssets    int       `json:"new_assets"`
+	Files        int       `json:"files"`
+	Truncated    bool      `json:"truncated,omitempty"`
+	DiscoveredAt time.Time `json:"discovered_at"`
+}
+
+// TableCoverage reports whether a discovered table has been covered by scans
+type TableCoverage struct {
+	AssetID       uuid.UUID  `json:"asset_id"`
+	ConnectionID  string     `json:"connection_id"`
+	ProfileName   string     `json:"profile_name"`
+	DataSource    string     `json:"data_source"`
+	Host          string     `json:"host"`
+	Path          string     `json:"path"`
+	RowCount      int64      `json:"row_count"`
+	ColumnCount   int        `json:"column_count"`
+	ColumnNames   []string   `json:"-"`
+	FindingCount  int        `json:"finding_count"`
+	LastFindingAt *time.Time `json:"last_finding_at,omitempty"`
+	LastScanAt    *time.Time `json:"last_connection_scan_at,omitempty"`
+	Status        string     `json:"status"`
+}
+
+// CoverageSummary counts discovered tables per coverage status
+type CoverageSummary struct {
+	DiscoveredTables int `json:"discovered_tables"`
+	Scanned          int `json:"scanned"`
+	NoFindings       int `json:"no_findings"`
+	NeverScanned     int `json:"never_scanned"`
+}
+
+// FileCoverage reports whether an enumerated file has been covered by scans
+type FileCoverage struct {
+	ConnectionID  string     `json:"connection_id"`
+	ProfileName   string     `json:"profile_name"`
+	SourceType    string     `json:"source_type"`
+	Path          string     `json:"path"`
+	SizeBytes     int64      `json:"size_bytes"`
+	ModifiedAt    *time.Time `json:"modified_at,omitempty"`
+	FindingCount  int        `json:"finding_count"`
+	LastFindingAt *time.Time `json:"last_finding_at,omitempty"`
+	LastScanAt    *time.Time `json:"last_connection_scan_at,omitempty"`
+	Status        string     `json:"status"`
+}
+
+// ConnectionCoverage compares what discovery found behind a connection with what
+// scans have covered. Scanned counts include locations of a scanned connection
+// without findings.
+type ConnectionCoverage struct {
+	ConnectionID       uuid.UUID  `json:"connection_id"`
+	ProfileName        string     `json:"profile_name"`
+	SourceType         string     `json:"source_type"`
+	LastScanAt         *time.Time `json:"last_scan_at,omitempty"`
+	TablesDiscovered   int        `json:"tables_discovered"`
+	TablesScanned      int        `json:"tables_scanned"`
+	TablesWithFindings int        `json:"tables_with_findings"`
+	FilesEnumerated    int        `json:"files_enumerated"`
+	FilesScanned       int        `json:"files_scanned"`
+	FilesWithFindings  int        `json:"files_with_findings"`
+	NeverScanned       int        `json:"never_scanned"`
+	HighRiskGaps       int        `json:"high_risk_gaps"`
+	CoveragePercent    float64    `json:"coverage_percent"`
+}
+
+// CoverageGap is a discovered table or file that no scan has reached, with the
+// heuristic risk that makes it worth scanning first
+type CoverageGap struct {
+	ConnectionID string   `json:"connection_id"`
+	ProfileName  string   `json:"profile_name"`
+	SourceType   string   `json:"source_type"`
+	Kind         string   `json:"kind"`
+	Location     string   `json:"location"`
+	RowCount     int64    `json:"row_count,omitempty"`
+	SizeBytes    int64    `json:"size_bytes,omitempty"`
+	RiskScore    int      `json:"risk_score"`
+	RiskReasons  []string `json:"risk_reasons"`
+}
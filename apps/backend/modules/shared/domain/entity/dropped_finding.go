@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons the ingestion filter drops a finding
+const (
+	DropReasonNonPII        = "non_pii"        // Classified Non-PII, including validation gate rejections
+	DropReasonLowConfidence = "low_confidence" // PII scoring under the filter's minimum confidence
+)
+
+// Ingestion filter modes
+const (
+	IngestionFilterDrop  = "drop"  // Filtered findings are not stored
+	IngestionFilterAudit = "audit" // Filtered findings are stored, and counted as if dropped
+	IngestionFilterOff   = "off"   // Nothing is filtered or counted
+)
+
+// DroppedFindingCount is how many findings of a pattern the ingestion filter
+// matched in a scan run for one reason. Enforced is false for counts taken in
+// audit mode, whose findings were stored.
+type DroppedFindingCount struct {
+	ScanRunID     uuid.UUID `json:"scan_run_id"`
+	Reason        string    `json:"reason"`
+	PatternName   string    `json:"pattern_name"`
+	Enforced      bool      `json:"enforced"`
+	Findings      int       `json:"findings"`
+	MaxConfidence float64   `json:"max_confidence"` // Highest classification score among them
+	RecordedAt    time.Time `json:"recorded_at"`
+}
+
+// DroppedFindingSummary totals the ledger over scan runs by reason and pattern
+type DroppedFindingSummary struct {
+	Reason        string  `json:"reason"`
+	PatternName   string  `json:"pattern_name"`
+	Enforced      bool    `json:"enforced"`
+	Findings      int     `json:"findings"`
+	ScanRuns      int     `json:"scan_runs"`
+	MaxConfidence float64 `json:"max_confidence"`
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Erasure request statuses
+const (
+	ErasurePendingApproval = "pending_approval"
+	ErasureRejected        = "rejected"
+	ErasureExecuting       = "executing" // Approved; targets are being erased
+	ErasureCompleted       = "completed" // Every target was erased
+	ErasurePartial         = "partial"   // Some targets were erased; the rest failed or need manual erasure
+	ErasureFailed          = "failed"    // No target could be erased
+)
+
+// Erasure target modes: how a target is erased
+const (
+	ErasureModeAutomated = "automated" // Through the source's connector, match by match
+	ErasureModeManual    = "manual"    // The connector cannot erase it; the owner must
+)
+
+// Erasure target statuses
+const (
+	ErasureTargetPlanned        = "planned"
+	ErasureTargetErased         = "erased"
+	ErasureTargetFailed         = "failed"
+	ErasureTargetManualRequired = "manual_required"
+)
+
+// ErasureRequest is a data principal's request to erase one value, identified by
+// its normalized value hash, everywhere it was found. The raw value is never stored.
+type ErasureRequest struct {
+	ID                 uuid.UUID        `json:"id"`
+	TenantID           uuid.UUID        `json:"tenant_id"`
+	ValueHash          string           `json:"value_hash"`
+	RequesterReference string           `json:"requester_reference"` // The data principal's request, such as a ticket number
+	ActionType         string           `json:"action_type"`         // DELETE or MASK
+	Justification      string           `json:"justification,omitempty"`
+	Status             string           `json:"status"`
+	RequestedBy        string           `json:"requested_by"`
+	RequestedAt        time.Time        `json:"requested_at"`
+	DecidedBy          string           `json:"decided_by,omitempty"`
+	DecidedAt          *time.Time       `json:"decided_at,omitempty"`
+	DecisionComment    string           `json:"decision_comment,omitempty"`
+	CompletedAt        *time.Time       `json:"completed_at,omitempty"`
+	Targets            []*ErasureTarget `json:"targets"`
+}
+
+// ErasureTarget is one finding holding the value, and how and whether it was erased
+type ErasureTarget struct {
+	ID         uuid.UUID  `json:"id"`
+	FindingID  uuid.UUID  `json:"finding_id"`
+	AssetID    uuid.UUID  `json:"asset_id"`
+	AssetName  string     `json:"asset_name"`
+	AssetPath  string     `json:"asset_path"`
+	System     string     `json:"system"`
+	SourceType string     `json:"source_type"`
+	Matches    int        `json:"matches"` // Located matches of the value in the finding
+	Mode       string     `json:"mode"`
+	Reason     string     `json:"reason,omitempty"` // Why a target is manual
+	Status     string     `json:"status"`
+	ActionID   string     `json:"action_id,omitempty"` // Remediation action of an erased target
+	Error      string     `json:"error,omitempty"`
+	ExecutedAt *time.Time `json:"executed_at,omitempty"`
+}
+
+// ErasureCertificate is the signed record of an executed erasure request, for the
+// requester. Document is the exact signed JSON; Signature is Ed25519 over it.
+type ErasureCertificate struct {
+	RequestID      uuid.UUID       `json:"request_id"`
+	Document       json.RawMessage `json:"document"`
+	Signature      string          `json:"signature"`
+	Algorithm      string          `json:"algorithm"`
+	KeyFingerprint string          `json:"key_fingerprint"`
+	IssuedAt       time.Time       `json:"issued_at"`
+}
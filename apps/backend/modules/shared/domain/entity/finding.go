@@ -6,6 +6,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// Finding provenance, recorded as Context["provenance"]. Findings without one came from a scanner.
+const (
+	FindingProvenanceManual = "manual" // Imported from a compliance team's spreadsheet
+
+	// ManualImportProfile is the profile name of the scan run created for each manual import
+	ManualImportProfile = "manual_import"
+)
+
 // Finding represents an individual PII or secret detection
 type Finding struct {
 	ID                  uuid.UUID              `json:"id"`
@@ -15,16 +23,75 @@ type Finding struct {
 	PatternID           *uuid.UUID             `json:"pattern_id,omitempty"`
 	PatternName         string                 `json:"pattern_name"`
 	Matches             []string               `json:"matches"`
+	TotalMatches        int                    `json:"total_matches"`     // Matches the scanner reported
+	MatchesTruncated    bool                   `json:"matches_truncated"` // Matches keeps only the first of TotalMatches
 	MaskedValue         string                 `json:"masked_value,omitempty"`
-	SampleText          string                 `json:"sample_text"`
+	SampleText          string                 `json:"sample_text"`                  // Masked unless the tenant stores full samples
+	SampleTextSHA256    string                 `json:"sample_text_sha256,omitempty"` // Of the sample as scanned
+	SampleTextRedacted  bool                   `json:"sample_text_redacted"`
 	Severity            string                 `json:"severity"`
 	SeverityDescription string                 `json:"severity_description"`
 	ConfidenceScore     *float64               `json:"confidence_score,omitempty"`
 	Environment         string                 `json:"environment"` // "PROD" or "TEST"
 	Context             map[string]interface{} `json:"context,omitempty"`
+	Locations           []MatchLocation        `json:"locations,omitempty"`
 	EnrichmentSignals   map[string]interface{} `json:"enrichment_signals,omitempty"`
 	EnrichmentScore     *float64               `json:"enrichment_score,omitempty"`
 	EnrichmentFailed    bool                   `json:"enrichment_failed"`
+	PayloadOffloaded    bool                   `json:"payload_offloaded,omitempty"` // Context is a summary and signals are unloaded; see LoadFindingPayloads
 	CreatedAt           time.Time              `json:"created_at"`
 	UpdatedAt           time.Time              `json:"updated_at"`
 }
+
+// CapMatches keeps at most max matches and the locations pointing at them,
+// recording how many were reported. A max of 0 or less keeps every match.
+func (f *Finding) CapMatches(max int) {
+	f.SetMatchTotal(f.TotalMatches)
+	if max <= 0 || len(f.Matches) <= max {
+		return
+	}
+	f.Matches = f.Matches[:max]
+	f.MatchesTruncated = true
+
+	kept := f.Locations[:0]
+	for _, loc := range f.Locations {
+		if loc.MatchIndex < max {
+			kept = append(kept, loc)
+		}
+	}
+	f.Locations = kept
+}
+
+// SetMatchTotal records how many matches were reported for a finding whose stored
+// matches may have been capped. Totals below the stored count, such as the 0 of
+// findings stored before totals were kept, mean every match was stored.
+func (f *Finding) SetMatchTotal(total int) {
+	if total < len(f.Matches) {
+		total = len(f.Matches)
+	}
+	f.TotalMatches = total
+	f.MatchesTruncated = total > len(f.Matches)
+}
+
+// MatchLocation pins one entry of Finding.Matches to its place in the source.
+// File sources set Line and Column; database sources set Table, Field and the
+// primary key of the row holding the value.
+type MatchLocation struct {
+	MatchIndex       int    `json:"match_index"`
+	Line             int    `json:"line,omitempty"`   // 1-based
+	Column           int    `json:"column,omitempty"` // 1-based, in characters
+	Table            string `json:"table,omitempty"`
+	Field            string `json:"field,omitempty"`
+	PrimaryKeyColumn string `json:"pk_column,omitempty"`
+	PrimaryKey       string `json:"pk,omitempty"`
+}
+
+// IsFileLocation reports whether the location addresses a line and column
+func (l MatchLocation) IsFileLocation() bool {
+	return l.Line > 0 && l.Column > 0
+}
+
+// IsRecordLocation reports whether the location addresses a database record
+func (l MatchLocation) IsRecordLocation() bool {
+	return l.Table != "" && l.Field != "" && l.PrimaryKeyColumn != "" && l.PrimaryKey != ""
+}
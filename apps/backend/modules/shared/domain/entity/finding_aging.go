@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stale finding actions
+const (
+	StaleActionAutoClose = "auto_close" // Mark stale findings as resolved
+	StaleActionQueue     = "queue"      // Move stale findings to the resolved-candidate queue
+)
+
+// Review statuses assigned to stale findings
+const (
+	ReviewStatusResolved          = "resolved"
+	ReviewStatusResolvedCandidate = "resolved_candidate"
+)
+
+// StaleFinding represents a finding that was not re-observed in recent scans of its asset
+type StaleFinding struct {
+	FindingID    uuid.UUID  `json:"finding_id"`
+	AssetID      uuid.UUID  `json:"asset_id"`
+	AssetName    string     `json:"asset_name"`
+	ScanRunID    uuid.UUID  `json:"scan_run_id"`
+	PatternName  string     `json:"pattern_name"`
+	Severity     string     `json:"severity"`
+	FirstSeenAt  time.Time  `json:"first_seen_at"`
+	StaleAt      *time.Time `json:"stale_at,omitempty"`
+	ReviewStatus string     `json:"review_status"`
+}
+
+// FindingAgingBucket groups open findings by age since first observation
+type FindingAgingBucket struct {
+	Label   string `json:"label"`
+	MinDays int    `json:"min_days"`
+	MaxDays int    `json:"max_days,omitempty"` // 0 means unbounded
+	Count   int    `json:"count"`
+}
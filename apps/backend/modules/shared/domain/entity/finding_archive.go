@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Finding archive statuses
+const (
+	ArchiveStatusArchived = "archived" // Findings live in object storage only
+	ArchiveStatusRestored = "restored" // Findings were rehydrated into Postgres
+)
+
+// ClosedReviewStatuses are the review statuses that mark a finding as closed
+var ClosedReviewStatuses = []string{ReviewStatusResolved, "false_positive"}
+
+// ArchiveFormatJSONLGzip is the gzip-compressed JSON Lines archive format
+const ArchiveFormatJSONLGzip = "jsonl.gz"
+
+// FindingArchive records a scan run's findings exported to object storage
+type FindingArchive struct {
+	ID                  uuid.UUID  `json:"id"`
+	TenantID            uuid.UUID  `json:"tenant_id"`
+	ScanRunID           uuid.UUID  `json:"scan_run_id"`
+	StorageProvider     string     `json:"storage_provider"`
+	Bucket              string     `json:"bucket"`
+	ObjectKey           string     `json:"object_key"`
+	ManifestKey         string     `json:"manifest_key"`
+	Format              string     `json:"format"`
+	FindingCount        int        `json:"finding_count"`
+	ClassificationCount int        `json:"classification_count"`
+	ReviewStateCount    int        `json:"review_state_count"`
+	SizeBytes           int64      `json:"size_bytes"`
+	ChecksumSHA256      string     `json:"checksum_sha256"`
+	Status              string     `json:"status"`
+	ArchivedBy          string     `json:"archived_by"`
+	ArchivedAt          time.Time  `json:"archived_at"`
+	RestoredAt          *time.Time `json:"restored_at,omitempty"`
+}
+
+// ArchiveManifest is written next to each archive object and describes its contents
+type ArchiveManifest struct {
+	ArchiveID           uuid.UUID       `json:"archive_id"`
+	TenantID            uuid.UUID       `json:"tenant_id"`
+	ScanRunID           uuid.UUID       `json:"scan_run_id"`
+	ScanRun             json.RawMessage `json:"scan_run"`
+	ObjectKey           string          `json:"object_key"`
+	Format              string          `json:"format"`
+	FindingCount        int             `json:"finding_count"`
+	ClassificationCount int             `json:"classification_count"`
+	ReviewStateCount    int             `json:"review_state_count"`
+	RelatedCounts       map[string]int  `json:"related_counts,omitempty"` // Rows of other tables referencing the findings
+	SizeBytes           int64           `json:"size_bytes"`
+	ChecksumSHA256      string          `json:"checksum_sha256"`
+	ArchivedAt          time.Time       `json:"archived_at"`
+}
+
+// ArchivedScanRunRows holds the raw rows of a scan run selected for archiving.
+// Rows are kept as JSON objects so every column survives a round trip.
+type ArchivedScanRunRows struct {
+	ScanRun         json.RawMessage   `json:"scan_run"`
+	Findings        []json.RawMessage `json:"findings"`
+	Classifications []json.RawMessage `json:"classifications"`
+	ReviewStates    []json.RawMessage `json:"review_states"`
+	// Related holds the rows of the other tables referencing the findings, by table
+	Related    map[string][]json.RawMessage `json:"related,omitempty"`
+	FindingIDs []uuid.UUID                  `json:"-"`
+}
+
+// ArchivableScanRun is a scan run with findings eligible for archiving
+type ArchivableScanRun struct {
+	ScanRunID       uuid.UUID `json:"scan_run_id"`
+	ProfileName     string    `json:"profile_name"`
+	ScanCompletedAt time.Time `json:"scan_completed_at"`
+	FindingCount    int       `json:"finding_count"`
+}
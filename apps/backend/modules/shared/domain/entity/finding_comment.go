@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FindingComment is a reviewer's comment on a finding. Comments form one level of
+// threads: replies point at the thread's first comment through ParentID.
+type FindingComment struct {
+	ID        uuid.UUID         `json:"id"`
+	TenantID  uuid.UUID         `json:"tenant_id"`
+	FindingID uuid.UUID         `json:"finding_id"`
+	ParentID  *uuid.UUID        `json:"parent_id,omitempty"`
+	AuthorID  *uuid.UUID        `json:"author_id,omitempty"`
+	Author    string            `json:"author"`
+	Body      string            `json:"body"`
+	Mentions  []string          `json:"mentions"` // Emails of mentioned tenant users
+	CreatedAt time.Time         `json:"created_at"`
+	EditedAt  *time.Time        `json:"edited_at,omitempty"`
+	DeletedAt *time.Time        `json:"deleted_at,omitempty"`
+	Replies   []*FindingComment `json:"replies,omitempty"` // Filled when comments are listed as threads
+}
+
+// Deleted reports whether the comment was deleted; it stays as a placeholder in its thread
+func (c *FindingComment) Deleted() bool {
+	return c.DeletedAt != nil
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tenant data key statuses
+const (
+	DataKeyActive  = "active"  // Encrypts new finding values
+	DataKeyRetired = "retired" // Only decrypts values not yet re-encrypted or held in archives
+)
+
+// TenantDataKey describes one version of a tenant's finding encryption key. The
+// key material is stored wrapped by the master key and never leaves the repository.
+type TenantDataKey struct {
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	Version   int        `json:"version"`
+	Status    string     `json:"status"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+// FindingKeyVersionCount is how many of a tenant's findings are stored under a
+// key version; a nil version counts findings stored in plaintext
+type FindingKeyVersionCount struct {
+	KeyVersion *int `json:"key_version"`
+	Findings   int  `json:"findings"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Finding evidence kinds
+const (
+	EvidenceKindScreenshot      = "screenshot"
+	EvidenceKindMaskedExcerpt   = "masked_excerpt"
+	EvidenceKindValidationProof = "validation_proof"
+	EvidenceKindOther           = "other"
+)
+
+// IsEvidenceKind reports whether kind is a known evidence kind
+func IsEvidenceKind(kind string) bool {
+	switch kind {
+	case EvidenceKindScreenshot, EvidenceKindMaskedExcerpt, EvidenceKindValidationProof, EvidenceKindOther:
+		return true
+	}
+	return false
+}
+
+// FindingEvidence is an artifact attached to a finding and stored in object storage
+type FindingEvidence struct {
+	ID              uuid.UUID `json:"id"`
+	TenantID        uuid.UUID `json:"tenant_id"`
+	FindingID       uuid.UUID `json:"finding_id"`
+	Kind            string    `json:"kind"`
+	FileName        string    `json:"file_name"`
+	ContentType     string    `json:"content_type"`
+	SizeBytes       int64     `json:"size_bytes"`
+	ChecksumSHA256  string    `json:"checksum_sha256"`
+	StorageProvider string    `json:"storage_provider"`
+	Bucket          string    `json:"bucket"`
+	ObjectKey       string    `json:"-"`
+	Description     string    `json:"description"`
+	UploadedBy      string    `json:"uploaded_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// EvidenceObjectDeletion is a stored object whose evidence row was deleted
+type EvidenceObjectDeletion struct {
+	ID        int64
+	ObjectKey string
+	Attempts  int
+}
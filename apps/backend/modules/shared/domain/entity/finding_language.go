@@ -0,0 +1,26 @@
+package entity
+
+// Finding language, recorded by ingestion as Context["language"] from the sample
+// text around the match. Findings in a language outside the tenant's scope may
+// also carry Context["language_review"], holding them for the language review queue.
+const (
+	FindingLanguageKey       = "language"
+	FindingLanguageReviewKey = "language_review"
+)
+
+// Handling of findings whose content is outside the supported languages
+const (
+	LanguageHandlingTag       = "tag"       // Held for the language review queue instead of the main one
+	LanguageHandlingDowngrade = "downgrade" // Confidence scaled down; the finding stays in the main queue
+	LanguageHandlingOff       = "off"       // Language is recorded but nothing else changes
+)
+
+// LanguageFindingCount totals a tenant's findings in one detected language.
+// Findings stored before languages were detected count as undetermined ("und").
+type LanguageFindingCount struct {
+	Language       string  `json:"language"`
+	Supported      bool    `json:"supported"`
+	Findings       int     `json:"findings"`
+	LanguageReview int     `json:"language_review"` // Held for the language review queue
+	AvgConfidence  float64 `json:"avg_confidence"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FindingObservation records one scan run observing an exposure. An exposure is
+// identified across scans by asset, pattern and value hash.
+type FindingObservation struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	FindingID   *uuid.UUID `json:"finding_id,omitempty"` // Nil once the finding row is archived or merged away
+	ScanRunID   uuid.UUID  `json:"scan_run_id"`
+	AssetID     uuid.UUID  `json:"asset_id"`
+	PatternName string     `json:"pattern_name"`
+	ValueHash   string     `json:"value_hash"`
+	ObservedAt  time.Time  `json:"observed_at"`
+}
+
+// FindingExposure is the observation history of the exposure behind a finding
+type FindingExposure struct {
+	FindingID        uuid.UUID            `json:"finding_id"`
+	AssetID          uuid.UUID            `json:"asset_id"`
+	PatternName      string               `json:"pattern_name"`
+	FirstObservedAt  time.Time            `json:"first_observed_at"`
+	LastObservedAt   time.Time            `json:"last_observed_at"`
+	ObservationCount int                  `json:"observation_count"`
+	ExposureDays     int                  `json:"exposure_days"` // Whole days from first to last observation
+	Observations     []FindingObservation `json:"observations"`
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FP rule suggestion kinds
+const (
+	FPSuggestionSuppression = "suppression" // Suppress the pattern under a path prefix
+	FPSuggestionFPLearning  = "fp_learning" // Learn the sampled values as false positives wherever they appear
+)
+
+// FP rule suggestion statuses
+const (
+	FPSuggestionPending  = "pending"
+	FPSuggestionApproved = "approved"
+	FPSuggestionRejected = "rejected"
+)
+
+// FPRuleSuggestion is a rule proposed from a cluster of false-positive feedback.
+// It has no effect until an admin approves it.
+type FPRuleSuggestion struct {
+	ID               uuid.UUID   `json:"id"`
+	TenantID         uuid.UUID   `json:"tenant_id"`
+	Kind             string      `json:"kind"`
+	Fingerprint      string      `json:"fingerprint"`
+	PatternName      string      `json:"pattern_name"`
+	PathPrefix       string      `json:"path_prefix,omitempty"`
+	TokenShape       string      `json:"token_shape,omitempty"`
+	FalsePositives   int         `json:"false_positives"`
+	Confirmed        int         `json:"confirmed"` // Confirmed feedback in the same cluster, evidence against the rule
+	Precision        float64     `json:"precision"` // False positives over all feedback in the cluster
+	AssetCount       int         `json:"asset_count"`
+	SampleFindingIDs []uuid.UUID `json:"sample_finding_ids"`
+	Status           string      `json:"status"`
+	AppliedRuleIDs   []uuid.UUID `json:"applied_rule_ids,omitempty"`
+	ReviewedBy       string      `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time  `json:"reviewed_at,omitempty"`
+	ReviewNote       string      `json:"review_note,omitempty"`
+	GeneratedAt      time.Time   `json:"generated_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// FeedbackSignal is one CONFIRMED or FALSE_POSITIVE feedback with the finding
+// attributes clustering works on
+type FeedbackSignal struct {
+	FindingID    uuid.UUID
+	AssetID      uuid.UUID
+	PatternName  string
+	Path         string
+	FeedbackType string
+	Sample       string // First matched value of the finding
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyRecord is the stored outcome of a request sent with an Idempotency-Key
+// header. Keys are scoped to a tenant and endpoint; a record without a status code
+// belongs to a request that is still being processed.
+type IdempotencyRecord struct {
+	TenantID    uuid.UUID
+	Endpoint    string
+	Key         string
+	RequestHash string // SHA-256 of the method, URL and body of the first request
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the first request finished and its response was stored
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
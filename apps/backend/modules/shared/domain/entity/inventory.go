@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cloud inventory providers
+const (
+	InventoryProviderAWS   = "aws"
+	InventoryProviderAzure = "azure"
+)
+
+// InventorySystem is a host or managed database imported from a cloud inventory.
+// Assets whose host matches one of its Hosts belong to it in the lineage graph
+// and take its environment and owner.
+type InventorySystem struct {
+	ID           uuid.UUID         `json:"id"`
+	TenantID     uuid.UUID         `json:"tenant_id"`
+	Provider     string            `json:"provider"`
+	ResourceID   string            `json:"resource_id"`   // Instance ID, DB identifier or Azure resource ID
+	ResourceType string            `json:"resource_type"` // e.g. ec2_instance, rds_instance, microsoft.compute/virtualmachines
+	Name         string            `json:"name"`
+	Account      string            `json:"account,omitempty"` // AWS account or Azure subscription
+	Region       string            `json:"region,omitempty"`
+	Hosts        []string          `json:"hosts"` // Lower-cased DNS names, IPs and identifiers assets may report as their host
+	Environment  string            `json:"environment,omitempty"`
+	Owner        string            `json:"owner,omitempty"`
+	Tags         map[string]string `json:"tags"`
+	LastSeenAt   time.Time         `json:"last_seen_at"`
+}
+
+// SystemID is the lineage graph ID of the system
+func (s *InventorySystem) SystemID() string {
+	return "system-" + s.Provider + ":" + s.ResourceID
+}
+
+// InventoryImportResult summarizes one import of a cloud inventory
+type InventoryImportResult struct {
+	Provider       string `json:"provider"`
+	Systems        int    `json:"systems"`
+	Removed        int    `json:"removed"`         // Systems of a previous import no longer in the inventory
+	AssetsMatched  int    `json:"assets_matched"`  // Assets whose host matched an imported system
+	AssetsEnriched int    `json:"assets_enriched"` // Matched assets whose environment or owner changed
+	LineageSynced  int    `json:"lineage_synced"`  // Matched assets relinked to their system in the graph
+}
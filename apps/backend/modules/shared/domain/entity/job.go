@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Job states
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusSucceeded = "succeeded"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+// IsJobStatus reports whether status is a job state
+func IsJobStatus(status string) bool {
+	switch status {
+	case JobStatusPending, JobStatusRunning, JobStatusSucceeded, JobStatusFailed, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Job is a unit of background work persisted in the jobs table
+type Job struct {
+	ID          uuid.UUID              `json:"id"`
+	TenantID    *uuid.UUID             `json:"tenant_id,omitempty"` // Nil for system jobs
+	Type        string                 `json:"type"`
+	Payload     map[string]interface{} `json:"payload"`
+	Status      string                 `json:"status"`
+	Attempts    int                    `json:"attempts"`
+	MaxAttempts int                    `json:"max_attempts"`
+	ScheduledAt time.Time              `json:"scheduled_at"` // When the next attempt is due
+	LastError   string                 `json:"last_error,omitempty"`
+	LockedBy    string                 `json:"locked_by,omitempty"` // Worker running the current attempt
+	LockedAt    *time.Time             `json:"locked_at,omitempty"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// JobFilter selects jobs to list
+type JobFilter struct {
+	Status string
+	Type   string
+	Limit  int
+	Offset int
+}
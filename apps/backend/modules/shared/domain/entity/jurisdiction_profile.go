@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultJurisdiction is the profile of tenants that have not selected one
+const DefaultJurisdiction = "IN"
+
+// PIITypeRule is how a jurisdiction treats one PII type in its scope
+type PIITypeRule struct {
+	Description     string `json:"description,omitempty"`
+	Validator       string `json:"validator,omitempty"` // pkg/validation name applied to unmasked match values
+	Category        string `json:"category"`
+	RequiresConsent bool   `json:"requires_consent"`
+}
+
+// JurisdictionProfile is the PII scope of a jurisdiction: which PII types are
+// ingested, how their values are validated, and the framework categories they map to
+type JurisdictionProfile struct {
+	Code      string                 `json:"code"`
+	Name      string                 `json:"name"`
+	Framework string                 `json:"framework"`
+	PIITypes  map[string]PIITypeRule `json:"pii_types"`
+	Version   int                    `json:"version"` // 0 for the built-in default
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// Allows reports whether a PII type is in the profile's scope
+func (p *JurisdictionProfile) Allows(piiType string) bool {
+	_, ok := p.Rule(piiType)
+	return ok
+}
+
+// Rule returns the profile's rule for a PII type
+func (p *JurisdictionProfile) Rule(piiType string) (PIITypeRule, bool) {
+	rule, ok := p.PIITypes[strings.ToUpper(strings.TrimSpace(piiType))]
+	return rule, ok
+}
+
+// SortedPIITypes lists the PII types in scope in order
+func (p *JurisdictionProfile) SortedPIITypes() []string {
+	types := make([]string, 0, len(p.PIITypes))
+	for piiType := range p.PIITypes {
+		types = append(types, piiType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ComplianceMapping returns the profile's categories as the built-in mapping of its
+// framework, used until the tenant saves a mapping of its own
+func (p *JurisdictionProfile) ComplianceMapping() *ComplianceMapping {
+	mapping := DefaultComplianceMapping(ComplianceFrameworkDPDPA)
+	mapping.Framework = p.Framework
+	mapping.PIITypeCategories = make(map[string]CategoryMapping, len(p.PIITypes))
+	for piiType, rule := range p.PIITypes {
+		mapping.PIITypeCategories[piiType] = CategoryMapping{Category: rule.Category, RequiresConsent: rule.RequiresConsent}
+	}
+	return mapping
+}
+
+// DefaultJurisdictionProfile returns the built-in India profile: the 11 locked India
+// PII types with their DPDPA categories. It applies when profiles cannot be loaded.
+func DefaultJurisdictionProfile() *JurisdictionProfile {
+	dpdpa := DefaultComplianceMapping(ComplianceFrameworkDPDPA)
+	profile := &JurisdictionProfile{
+		Code:      DefaultJurisdiction,
+		Name:      "India",
+		Framework: ComplianceFrameworkDPDPA,
+		PIITypes:  make(map[string]PIITypeRule, len(dpdpa.PIITypeCategories)),
+	}
+	for piiType, category := range dpdpa.PIITypeCategories {
+		profile.PIITypes[piiType] = PIITypeRule{Category: category.Category, RequiresConsent: category.RequiresConsent}
+	}
+	aadhaar := profile.PIITypes["IN_AADHAAR"]
+	aadhaar.Validator = "aadhaar"
+	profile.PIITypes["IN_AADHAAR"] = aadhaar
+	return profile
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/google/uuid"
+
+// AssetLineageState is the lineage-relevant view of an asset in one store:
+// the properties copied to its Neo4j node and the PII types it currently exposes
+type AssetLineageState struct {
+	AssetID       uuid.UUID `json:"asset_id"`
+	Name          string    `json:"name"`
+	Host          string    `json:"host"`
+	Environment   string    `json:"environment"`
+	RiskScore     int       `json:"risk_score"`
+	TotalFindings int       `json:"total_findings"`
+	PIITypes      []string  `json:"pii_types"` // Sorted
+}
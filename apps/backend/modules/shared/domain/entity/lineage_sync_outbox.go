@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LineageSyncOutboxEntry is an asset whose lineage sync is waiting for Neo4j to recover
+type LineageSyncOutboxEntry struct {
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	AssetID     uuid.UUID `json:"asset_id"`
+	Reason      string    `json:"reason,omitempty"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaskingTemplate is a tenant's template for masking values of one PII type
+type MaskingTemplate struct {
+	TenantID    uuid.UUID `json:"tenant_id"`
+	PIIType     string    `json:"pii_type"`
+	Style       string    `json:"style"` // partial, email, format, redact
+	KeepFirst   int       `json:"keep_first"`
+	KeepLast    int       `json:"keep_last"`
+	Format      string    `json:"format,omitempty"`
+	MaskChar    string    `json:"mask_char,omitempty"`
+	Replacement string    `json:"replacement,omitempty"`
+	UpdatedBy   string    `json:"updated_by"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organizational unit kinds; teams sit directly under a department
+const (
+	OrgUnitDepartment = "department"
+	OrgUnitTeam       = "team"
+)
+
+// How an asset came to belong to its organizational unit
+const (
+	OrgUnitSourceManual     = "manual"     // Assigned directly; connection assignments leave it alone
+	OrgUnitSourceConnection = "connection" // Inherited from the connection that scanned it
+)
+
+// OrgUnit is a department or team that owns assets, connections and users.
+// Analysts assigned to a unit only see findings on its assets and, for a
+// department, on the assets of its teams.
+type OrgUnit struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"` // The department of a team
+	Kind        string     `json:"kind"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	CreatedBy   string     `json:"created_by"`
+	Assets      int        `json:"assets"`      // Assets assigned to the unit itself
+	Connections int        `json:"connections"` // Connections assigned to the unit itself
+	Users       int        `json:"users"`       // Users assigned to the unit itself
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// GraphID is the lineage graph ID of the unit
+func (u *OrgUnit) GraphID() string {
+	return "org-unit-" + u.ID.String()
+}
+
+// OrgUnitAssignment moves assets, connections and users into a unit, or out of
+// any unit when OrgUnitID is nil
+type OrgUnitAssignment struct {
+	OrgUnitID     *uuid.UUID  `json:"org_unit_id"`
+	AssetIDs      []uuid.UUID `json:"asset_ids"`
+	ConnectionIDs []uuid.UUID `json:"connection_ids"`
+	UserIDs       []uuid.UUID `json:"user_ids"`
+}
+
+// OrgUnitAssignmentResult counts the rows an assignment changed
+type OrgUnitAssignmentResult struct {
+	Assets          int `json:"assets"`
+	Connections     int `json:"connections"`
+	Users           int `json:"users"`
+	InheritedAssets int `json:"inherited_assets"` // Assets scanned by an assigned connection that followed it
+}
+
+// OrgUnitRollup aggregates the risk of the assets a unit owns. A department's
+// rollup includes its teams' assets. The row without an OrgUnitID covers
+// assets in no unit.
+type OrgUnitRollup struct {
+	OrgUnitID        *uuid.UUID `json:"org_unit_id"`
+	ParentID         *uuid.UUID `json:"parent_id,omitempty"`
+	Kind             string     `json:"kind,omitempty"`
+	Name             string     `json:"name"`
+	Assets           int        `json:"assets"`
+	HighRiskAssets   int        `json:"high_risk_assets"` // Assets at or above the rollup's risk threshold
+	MaxRiskScore     int        `json:"max_risk_score"`
+	AvgRiskScore     float64    `json:"avg_risk_score"`
+	TotalFindings    int        `json:"total_findings"`
+	CriticalFindings int        `json:"critical_findings"`
+	HighFindings     int        `json:"high_findings"`
+	MediumFindings   int        `json:"medium_findings"`
+	LowFindings      int        `json:"low_findings"`
+	WaivedFindings   int        `json:"waived_findings"` // Under an active risk waiver; not in the severity counts
+}
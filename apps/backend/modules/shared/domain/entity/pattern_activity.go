@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// PatternActivity counts a tenant's findings of one pattern created in one period,
+// with the latest CONFIRMED or FALSE_POSITIVE feedback verdict on them
+type PatternActivity struct {
+	PatternName     string
+	Period          time.Time // Start of the day or week
+	Findings        int
+	Confirmed       int
+	FalsePositives  int
+	ConfidenceSum   float64 // Over findings with a confidence score
+	ConfidenceCount int
+
+	// Confidence of the findings with each verdict
+	ConfirmedConfidenceSum   float64
+	ConfirmedConfidenceCount int
+	FPConfidenceSum          float64
+	FPConfidenceCount        int
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// AuditActionFindingsViewed is the audit_logs action recorded for reads of finding PII
+const AuditActionFindingsViewed = "FINDINGS_VIEWED"
+
+// PIIViewer summarizes one user's reads of finding PII
+type PIIViewer struct {
+	UserID           string    `json:"user_id"`
+	UserEmail        string    `json:"user_email,omitempty"`
+	Requests         int       `json:"requests"`
+	FindingViews     int       `json:"finding_views"`
+	DistinctFindings int       `json:"distinct_findings"`
+	WithoutPurpose   int       `json:"requests_without_purpose"`
+	LastAccessAt     time.Time `json:"last_access_at"`
+}
+
+// PIIAccessDay is the number of findings one user viewed on one day
+type PIIAccessDay struct {
+	UserID       string    `json:"user_id"`
+	UserEmail    string    `json:"user_email,omitempty"`
+	Day          time.Time `json:"day"`
+	FindingViews int       `json:"finding_views"`
+}
@@ -0,0 +1,12 @@
+package entity
+
+// PIIHeatmapCell counts a tenant's PII findings sharing a PII type and the
+// grouped asset dimensions. Dimensions that were not grouped on are empty.
+type PIIHeatmapCell struct {
+	Environment  string
+	DataSource   string
+	Host         string
+	PIIType      string
+	FindingCount int
+	MaxSeverity  string // Highest severity among the findings, as stored
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Policy violation states
+const (
+	PolicyViolationOpen     = "open"
+	PolicyViolationResolved = "resolved" // The finding no longer matches the rule
+)
+
+// PolicyRule is a tenant compliance rule: findings for which its expression holds
+// violate it. Rules are evaluated nightly; new violations are posted to the
+// rule's webhook, signed with its secret, which is stored encrypted and never returned.
+type PolicyRule struct {
+	ID                     uuid.UUID  `json:"id"`
+	TenantID               uuid.UUID  `json:"tenant_id"`
+	Name                   string     `json:"name"`
+	Description            string     `json:"description,omitempty"`
+	Expression             string     `json:"expression"`
+	Severity               string     `json:"severity"`
+	WebhookURL             string     `json:"webhook_url,omitempty"`
+	WebhookSecret          string     `json:"webhook_secret,omitempty"` // Write-only
+	WebhookSecretEncrypted []byte     `json:"-"`
+	HasWebhookSecret       bool       `json:"has_webhook_secret,omitempty"`
+	IsActive               bool       `json:"is_active"`
+	NextEvaluationAt       time.Time  `json:"next_evaluation_at"`
+	LastEvaluatedAt        *time.Time `json:"last_evaluated_at,omitempty"`
+	OpenViolations         int        `json:"open_violations"`
+	CreatedBy              string     `json:"created_by"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+// PolicyViolation is a finding that violates a policy rule
+type PolicyViolation struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	RuleID          uuid.UUID  `json:"rule_id"`
+	RuleName        string     `json:"rule_name"`
+	Severity        string     `json:"severity"` // The rule's severity
+	FindingID       uuid.UUID  `json:"finding_id"`
+	AssetID         uuid.UUID  `json:"asset_id"`
+	AssetName       string     `json:"asset_name"`
+	PatternName     string     `json:"pattern_name"`
+	Environment     string     `json:"environment"`
+	Status          string     `json:"status"`
+	FirstDetectedAt time.Time  `json:"first_detected_at"`
+	LastEvaluatedAt time.Time  `json:"last_evaluated_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+// PolicyViolationFilter selects policy violations; empty fields match any value
+type PolicyViolationFilter struct {
+	RuleID   *uuid.UUID
+	Status   string
+	Severity string
+	Limit    int
+	Offset   int
+}
+
+// PolicyFindingFacts are the finding attributes policy expressions are evaluated over
+type PolicyFindingFacts struct {
+	FindingID          uuid.UUID
+	AssetID            uuid.UUID
+	AssetName          string
+	AssetPath          string
+	DataSource         string
+	Environment        string
+	PatternName        string
+	PIIType            string // Top classification's sub-category, else the pattern name
+	ClassificationType string
+	Severity           string
+	Confidence         float64
+	ReviewStatus       string   // "open" when never reviewed
+	Remediations       []string // Action types of the finding's completed, active remediations
+	CreatedAt          time.Time
+}
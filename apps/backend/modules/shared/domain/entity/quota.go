@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+)
+
+// Quota overage behaviors, applied once a tenant's findings quota is reached
+const (
+	QuotaOverageReject     = "reject"     // Ingestion is refused with 402
+	QuotaOverageDownsample = "downsample" // Critical findings are kept, others sampled
+)
+
+// TenantQuota overrides the configured ingestion quotas for one tenant; nil fields
+// keep the default
+type TenantQuota struct {
+	MaxFindings       *int      `json:"max_findings"`
+	MaxScanRunsPerDay *int      `json:"max_scan_runs_per_day"`
+	OverageBehavior   *string   `json:"overage_behavior"`
+	UpdatedBy         string    `json:"updated_by"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// QuotaUsage is a tenant's usage against its effective quotas. A limit of 0 is unlimited.
+type QuotaUsage struct {
+	StoredFindings       int          `json:"stored_findings"`
+	MaxFindings          int          `json:"max_findings"`
+	ScanRunsToday        int          `json:"scan_runs_today"`
+	MaxScanRunsPerDay    int          `json:"max_scan_runs_per_day"`
+	DownsampledToday     int          `json:"downsampled_findings_today"`
+	OverageBehavior      string       `json:"overage_behavior"`
+	DownsampleEvery      int          `json:"downsample_every,omitempty"`
+	ScanRunQuotaResetsAt time.Time    `json:"scan_run_quota_resets_at"`
+	Overrides            *TenantQuota `json:"overrides,omitempty"`
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Remediation approval request statuses
+const (
+	RemediationRequestPendingApproval = "pending_approval"
+	RemediationRequestApproved        = "approved" // Approved and executing
+	RemediationRequestRejected        = "rejected"
+	RemediationRequestExpired         = "expired"
+	RemediationRequestExecuted        = "executed" // At least one finding was remediated; see Errors for the rest
+	RemediationRequestFailed          = "failed"
+)
+
+// RemediationFinding is a finding joined with the asset details a connector
+// needs to remediate it
+type RemediationFinding struct {
+	ID           string
+	AssetID      string
+	SystemID     string
+	AssetName    string
+	Location     string // Asset path/location
+	AssetPath    string
+	SourceSystem string
+	SourceType   string
+	FieldName    string
+	PIIType      string
+	RecordID     string
+	SampleText   string
+	Context      string
+	Matches      []string
+	Locations    []MatchLocation
+}
+
+// RemediationAction is a stored remediation attempt. Metadata holds what is
+// needed to roll it back: the original value or per-match snapshots.
+type RemediationAction struct {
+	ID         string
+	FindingID  string
+	ActionType string
+	ExecutedBy string
+	ExecutedAt time.Time
+	Status     string
+	Metadata   json.RawMessage
+}
+
+// RemediationApprovalRequest is a remediation held back until a second user approves it
+type RemediationApprovalRequest struct {
+	ID              string     `json:"id"`
+	FindingIDs      []string   `json:"finding_ids"`
+	ActionType      string     `json:"action_type"`
+	Impact          int        `json:"impact"`
+	Justification   string     `json:"justification"`
+	Status          string     `json:"status"`
+	RequestedBy     string     `json:"requested_by"`
+	RequestedAt     time.Time  `json:"requested_at"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	DecidedBy       string     `json:"decided_by,omitempty"`
+	DecidedAt       *time.Time `json:"decided_at,omitempty"`
+	DecisionComment string     `json:"decision_comment,omitempty"`
+	ActionIDs       []string   `json:"action_ids"`
+	Errors          []string   `json:"errors"`
+	ExecutedAt      *time.Time `json:"executed_at,omitempty"`
+}
+
+// Remediation simulation statuses
+const (
+	RemediationSimulationSucceeded = "simulated" // Every target was found; Changes holds the would-be changes
+	RemediationSimulationFailed    = "failed"    // The real run would fail; see Error
+)
+
+// RemediationSimulation is a dry run of a remediation against the source. Nothing
+// in the source is changed; Changes lists each target's before and after values.
+type RemediationSimulation struct {
+	ID          string          `json:"id"`
+	FindingID   string          `json:"finding_id"`
+	ActionType  string          `json:"action_type"`
+	Status      string          `json:"status"`
+	Changes     json.RawMessage `json:"changes"`
+	Error       string          `json:"error,omitempty"`
+	SimulatedBy string          `json:"simulated_by"`
+	SimulatedAt time.Time       `json:"simulated_at"`
+}
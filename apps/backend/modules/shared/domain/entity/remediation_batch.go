@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Remediation batch statuses
+const (
+	RemediationBatchPending   = "pending" // Queued, or resumed and waiting for a worker
+	RemediationBatchRunning   = "running"
+	RemediationBatchPaused    = "paused" // Stops after the findings in flight; resuming continues with the rest
+	RemediationBatchCompleted = "completed"
+	RemediationBatchFailed    = "failed"
+)
+
+// Remediation batch item statuses
+const (
+	RemediationBatchItemPending   = "pending"
+	RemediationBatchItemCompleted = "completed"
+	RemediationBatchItemFailed    = "failed"
+)
+
+// RemediationBatch is a bulk remediation run by the job queue. Its findings are
+// grouped by the connection they were found on, and each connection is throttled
+// by the limits of its connector type.
+type RemediationBatch struct {
+	ID            uuid.UUID                    `json:"id"`
+	TenantID      uuid.UUID                    `json:"tenant_id"`
+	ActionType    string                       `json:"action_type"`
+	Status        string                       `json:"status"`
+	RequestedBy   string                       `json:"requested_by"`
+	TotalFindings int                          `json:"total_findings"`
+	JobID         *uuid.UUID                   `json:"job_id,omitempty"`
+	Error         string                       `json:"error,omitempty"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	StartedAt     *time.Time                   `json:"started_at,omitempty"`
+	CompletedAt   *time.Time                   `json:"completed_at,omitempty"`
+	Connections   []RemediationBatchConnection `json:"connections,omitempty"` // Progress per connection; set when one batch is read
+}
+
+// RemediationBatchConnection is the progress of a batch on one connection
+type RemediationBatchConnection struct {
+	Connection string `json:"connection"` // Source system of the findings' assets
+	SourceType string `json:"source_type"`
+	Total      int    `json:"total"`
+	Pending    int    `json:"pending"`
+	Completed  int    `json:"completed"`
+	Failed     int    `json:"failed"`
+}
+
+// RemediationBatchItem is one finding of a batch
+type RemediationBatchItem struct {
+	ID         uuid.UUID  `json:"id"`
+	BatchID    uuid.UUID  `json:"batch_id"`
+	FindingID  uuid.UUID  `json:"finding_id"`
+	Connection string     `json:"connection"`
+	SourceType string     `json:"source_type"`
+	Status     string     `json:"status"`
+	ActionID   string     `json:"action_id,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+// AssetPIISummary aggregates the open findings of one PII type on an asset
+type AssetPIISummary struct {
+	PIIType         string `json:"pii_type"`
+	FindingCount    int    `json:"finding_count"`
+	MaxSeverity     string `json:"max_severity"`
+	RemediatedCount int    `json:"remediated_count"` // Findings with a completed remediation action
+}
+
+// RemediationOutcomeStats summarises past remediation attempts of one action type
+type RemediationOutcomeStats struct {
+	ActionType string `json:"action_type"`
+	Completed  int    `json:"completed"`
+	Failed     int    `json:"failed"`
+	RolledBack int    `json:"rolled_back"`
+}
+
+// Attempts returns the number of attempts that reached a terminal state
+func (s RemediationOutcomeStats) Attempts() int {
+	return s.Completed + s.Failed + s.RolledBack
+}
+
+// SuccessRate is the share of terminal attempts that completed and stayed in place
+func (s RemediationOutcomeStats) SuccessRate() float64 {
+	if s.Attempts() == 0 {
+		return 0
+	}
+	return float64(s.Completed) / float64(s.Attempts())
+}
+
+// RemediationRecommendation is a suggested remediation for an asset with its reasoning
+type RemediationRecommendation struct {
+	ActionType   string   `json:"action_type"` // MASK, ENCRYPT, DELETE
+	Title        string   `json:"title"`
+	Priority     string   `json:"priority"` // critical, high, medium, low
+	Score        int      `json:"score"`    // 0-100, higher is more urgent
+	PIITypes     []string `json:"pii_types"`
+	FindingCount int      `json:"finding_count"`
+	Reasoning    string   `json:"reasoning"`
+}
+
+// AssetRemediationRecommendations is the recommendation set for a single asset
+type AssetRemediationRecommendations struct {
+	AssetID         string                      `json:"asset_id"`
+	AssetName       string                      `json:"asset_name"`
+	Environment     string                      `json:"environment"`
+	PIISummary      []AssetPIISummary           `json:"pii_summary"`
+	Recommendations []RemediationRecommendation `json:"recommendations"`
+}
@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Report output formats
+const (
+	ReportFormatCSV  = "csv"
+	ReportFormatJSON = "json"
+	ReportFormatPDF  = "pdf"
+)
+
+// Report schedule frequencies
+const (
+	ReportDaily   = "daily"
+	ReportWeekly  = "weekly"
+	ReportMonthly = "monthly"
+)
+
+// Report delivery targets
+const (
+	ReportTargetEmail   = "email"
+	ReportTargetWebhook = "webhook"
+)
+
+// Report run triggers and outcomes
+const (
+	ReportTriggerSchedule = "schedule"
+	ReportTriggerManual   = "manual"
+
+	ReportRunDelivered = "delivered"
+	ReportRunPartial   = "partial" // Some targets failed
+	ReportRunFailed    = "failed"
+	ReportRunSkipped   = "skipped" // No target could be delivered to, e.g. email is not configured
+)
+
+// ReportColumns are the finding fields a report can show. ReportGroupFields can
+// also be grouped on, in which case each row carries a finding count.
+var (
+	ReportColumns = []string{
+		"finding_id", "asset_name", "asset_path", "data_source", "environment", "pattern_name",
+		"severity", "classification_type", "confidence", "review_status", "created_at",
+	}
+	ReportGroupFields = []string{
+		"asset_name", "data_source", "environment", "pattern_name", "severity",
+		"classification_type", "review_status", "created_date", "created_month",
+	}
+)
+
+// ReportTemplate is a saved findings report: which findings, how they are grouped,
+// which columns are shown and how they are charted
+type ReportTemplate struct {
+	ID          uuid.UUID     `json:"id"`
+	TenantID    uuid.UUID     `json:"tenant_id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Filters     ReportFilters `json:"filters"`
+	GroupBy     []string      `json:"group_by"`
+	Columns     []string      `json:"columns"`
+	Chart       *ReportChart  `json:"chart,omitempty"`
+	CreatedBy   string        `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// ReportFilters selects the findings a report covers. An empty filter matches any value.
+type ReportFilters struct {
+	Severities          []string `json:"severities,omitempty"`
+	ClassificationTypes []string `json:"classification_types,omitempty"`
+	Environments        []string `json:"environments,omitempty"`
+	PatternNames        []string `json:"pattern_names,omitempty"`
+	DataSources         []string `json:"data_sources,omitempty"`
+	ReviewStatuses      []string `json:"review_statuses,omitempty"` // "open" matches findings never reviewed
+	SinceDays           int      `json:"since_days,omitempty"`      // Only findings created in the last N days; 0 for all
+}
+
+// ReportChart describes a chart of the finding count per value of one grouping
+type ReportChart struct {
+	Type  string `json:"type"`  // "bar", "pie" or "line"
+	Field string `json:"field"` // One of the template's group_by fields
+}
+
+// ReportSchedule generates a template on a recurring schedule and delivers it
+type ReportSchedule struct {
+	ID         uuid.UUID      `json:"id"`
+	TenantID   uuid.UUID      `json:"tenant_id"`
+	TemplateID uuid.UUID      `json:"template_id"`
+	Name       string         `json:"name"`
+	Frequency  string         `json:"frequency"`
+	Format     string         `json:"format"`
+	Targets    []ReportTarget `json:"targets"`
+	IsActive   bool           `json:"is_active"`
+	NextRunAt  time.Time      `json:"next_run_at"`
+	LastRunAt  *time.Time     `json:"last_run_at,omitempty"`
+	CreatedBy  string         `json:"created_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// ReportTarget is where a scheduled report is delivered: email recipients or a webhook URL.
+// Webhook bodies are signed with the secret, which is stored encrypted and never returned.
+type ReportTarget struct {
+	Type            string   `json:"type"`
+	Recipients      []string `json:"recipients,omitempty"`
+	URL             string   `json:"url,omitempty"`
+	Secret          string   `json:"secret,omitempty"` // Write-only
+	SecretEncrypted []byte   `json:"-"`
+	HasSecret       bool     `json:"has_secret,omitempty"`
+}
+
+// ReportRun records one generation of a scheduled report and its deliveries
+type ReportRun struct {
+	ID         uuid.UUID        `json:"id"`
+	TenantID   uuid.UUID        `json:"tenant_id"`
+	ScheduleID *uuid.UUID       `json:"schedule_id,omitempty"`
+	TemplateID *uuid.UUID       `json:"template_id,omitempty"`
+	Trigger    string           `json:"trigger"`
+	Format     string           `json:"format"`
+	RowCount   int              `json:"row_count"`
+	Status     string           `json:"status"`
+	Error      string           `json:"error,omitempty"`
+	Deliveries []ReportDelivery `json:"deliveries"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ReportDelivery is the outcome of delivering a report run to one target
+type ReportDelivery struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination"` // Recipients or webhook host; never the secret
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewPriorityPolicy weighs the factors that order a tenant's review queue.
+// Each factor is scored 0-100 and the priority is their weighted mean.
+type ReviewPriorityPolicy struct {
+	TenantID          uuid.UUID          `json:"tenant_id"`
+	RiskWeight        float64            `json:"risk_weight"`
+	PIIWeight         float64            `json:"pii_weight"`
+	EnvironmentWeight float64            `json:"environment_weight"`
+	ExposureWeight    float64            `json:"exposure_weight"`
+	PIICriticality    map[string]float64 `json:"pii_criticality"` // PII type -> 0-100, overriding the built-in criticality
+	UpdatedBy         string             `json:"updated_by,omitempty"`
+	UpdatedAt         *time.Time         `json:"updated_at,omitempty"` // Nil while the tenant uses the defaults
+}
+
+// ReviewPriorityFacts are the attributes of a pending finding its priority is scored from
+type ReviewPriorityFacts struct {
+	FindingID          uuid.UUID
+	TenantID           uuid.UUID
+	PIIType            string // Top classification's sub-category, else the pattern name
+	ClassificationType string
+	Severity           string
+	Environment        string // The asset's environment, else the finding's
+	SharingAssets      int    // Other assets holding the finding's value
+}
+
+// FindingReviewPriority is the scored priority of a finding awaiting review
+type FindingReviewPriority struct {
+	FindingID        uuid.UUID `json:"finding_id"`
+	TenantID         uuid.UUID `json:"-"`
+	Score            float64   `json:"score"`
+	RiskScore        float64   `json:"risk_score"`
+	PIIScore         float64   `json:"pii_score"`
+	EnvironmentScore float64   `json:"environment_score"`
+	ExposureScore    float64   `json:"exposure_score"`
+	PIIType          string    `json:"pii_type"`
+	SharingAssets    int       `json:"sharing_assets"`
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+// ReviewQueueItem is a pending finding in the review queue, highest priority first
+type ReviewQueueItem struct {
+	FindingReviewPriority
+	AssetID     uuid.UUID `json:"asset_id"`
+	AssetName   string    `json:"asset_name"`
+	AssetPath   string    `json:"asset_path"`
+	PatternName string    `json:"pattern_name"`
+	Severity    string    `json:"severity"`
+	Environment string    `json:"environment"`
+	Language    string    `json:"language,omitempty"` // Detected in the sample text; empty for findings stored before detection
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ReviewQueueFilter selects review queue items; empty fields match any value
+type ReviewQueueFilter struct {
+	PIIType string
+	// LanguageReview selects the language review queue: findings held there for
+	// content outside the supported languages, which the main queue leaves out
+	LanguageReview bool
+	Limit          int
+	Offset         int
+}
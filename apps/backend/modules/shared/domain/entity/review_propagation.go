@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewPropagationSettings is whether a tenant lets review decisions be copied to
+// identical findings
+type ReviewPropagationSettings struct {
+	Enabled   bool       `json:"enabled"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// IdenticalFinding is another finding of the same pattern and normalized value
+// hash as a reviewed finding, on any asset of the tenant
+type IdenticalFinding struct {
+	FindingID    uuid.UUID `json:"finding_id"`
+	AssetID      uuid.UUID `json:"asset_id"`
+	AssetName    string    `json:"asset_name"`
+	AssetPath    string    `json:"asset_path"`
+	PatternName  string    `json:"pattern_name"`
+	ReviewStatus string    `json:"review_status"` // Empty when never reviewed
+}
@@ -14,6 +14,9 @@ type ReviewState struct {
 	ReviewedBy string     `json:"reviewed_by,omitempty"`
 	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
 	Comments   string     `json:"comments,omitempty"`
-	CreatedAt  time.Time  `json:"created_at"`
-	UpdatedAt  time.Time  `json:"updated_at"`
+	Version    int        `json:"version"` // Incremented on every update; 0 before the first review
+	// PropagatedFrom is the finding whose decision was copied to this one; nil for reviewer decisions
+	PropagatedFrom *uuid.UUID `json:"propagated_from,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 }
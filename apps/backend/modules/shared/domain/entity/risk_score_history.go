@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Causes of an asset risk score change
+const (
+	RiskCauseBaseline      = "baseline"       // Score the asset had when history tracking started
+	RiskCauseScanIngestion = "scan_ingestion" // Recalculated after scan findings were ingested
+	RiskCauseAssetMerge    = "asset_merge"    // Took over the score of an asset merged into it
+	RiskCauseRecalculation = "recalculation"  // Recalculated outside of ingestion
+	RiskCauseScanOverdue   = "scan_overdue"   // Freshness penalty changed while the asset was overdue for a scan
+)
+
+// RiskScorePoint is one recorded change of an asset's risk score
+type RiskScorePoint struct {
+	AssetID       uuid.UUID `json:"asset_id"`
+	Score         int       `json:"score"`
+	PreviousScore *int      `json:"previous_score,omitempty"`
+	Delta         int       `json:"delta"`
+	Cause         string    `json:"cause"`
+	ComputedAt    time.Time `json:"computed_at"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Risk waiver statuses
+const (
+	RiskWaiverActive  = "active"
+	RiskWaiverExpired = "expired"
+	RiskWaiverRevoked = "revoked"
+)
+
+// RiskWaiver accepts the risk of one finding, or of a pattern's findings under path
+// and host globs, until it expires. Waived findings stay stored but are left out of
+// risk rollups while the waiver is active.
+type RiskWaiver struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	FindingID      *uuid.UUID `json:"finding_id,omitempty"`
+	PatternName    string     `json:"pattern_name,omitempty"`
+	PathGlob       string     `json:"path_glob,omitempty"` // Empty matches any path
+	HostGlob       string     `json:"host_glob,omitempty"` // Empty matches any host
+	Justification  string     `json:"justification"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	ExpiryWarnedAt *time.Time `json:"expiry_warned_at,omitempty"`
+	WaivedFindings int        `json:"waived_findings"` // Findings the waiver covers now
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RevokedBy      string     `json:"revoked_by,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
+// WaiverCandidate is an unwaived finding that a scope waiver may cover
+type WaiverCandidate struct {
+	FindingID uuid.UUID
+	Path      string
+	Host      string
+}
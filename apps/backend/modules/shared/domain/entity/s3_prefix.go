@@ -0,0 +1,27 @@
+package entity
+
+// S3PrefixRollup totals the objects and findings in a bucket, or under one prefix
+// of a bucket. Objects are the S3 assets; prefixes are not stored, they are read
+// from object keys.
+type S3PrefixRollup struct {
+	Bucket         string `json:"bucket"`
+	Prefix         string `json:"prefix"` // Ends in "/"; empty for the whole bucket
+	Objects        int    `json:"objects"`
+	Findings       int    `json:"findings"`
+	Critical       int    `json:"critical"`
+	High           int    `json:"high"`
+	Medium         int    `json:"medium"`
+	Low            int    `json:"low"`
+	MaxRiskScore   int    `json:"max_risk_score"`
+	ExposedObjects int    `json:"exposed_objects"` // Objects with at least one finding
+}
+
+// S3PrefixListing is one level of a bucket: the prefixes directly under a prefix,
+// each rolled up, and the objects stored directly at it
+type S3PrefixListing struct {
+	Bucket   string           `json:"bucket"`
+	Prefix   string           `json:"prefix"`
+	Total    S3PrefixRollup   `json:"total"`
+	Prefixes []S3PrefixRollup `json:"prefixes"`
+	Objects  []*Asset         `json:"objects"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantSampleTextPolicy records a tenant's choice to store full finding sample text
+// instead of the SAMPLE_TEXT_POLICY default
+type TenantSampleTextPolicy struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	StoreFull bool      `json:"store_full"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StoredSample is the sample text of one finding, read for or written by redaction
+type StoredSample struct {
+	FindingID  uuid.UUID
+	TenantID   uuid.UUID
+	SampleText string
+	SHA256     string
+	Matches    []string
+	PIIType    string // Pattern name; picks the masking template
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scan anomaly scopes: the baseline a scan run's matches were compared against
+const (
+	ScanAnomalyScopeAsset      = "asset"      // The asset's previous scan runs
+	ScanAnomalyScopeConnection = "connection" // The connection's previous scan runs, all assets together
+)
+
+// Scan anomaly statuses
+const (
+	ScanAnomalyOpen         = "open"
+	ScanAnomalyAcknowledged = "acknowledged"
+	ScanAnomalySuppressed   = "suppressed" // Detected inside a suppression window; not alerted
+)
+
+// ScanAnomalySettings is how sensitive a tenant's volume spike detection is
+type ScanAnomalySettings struct {
+	TenantID        uuid.UUID          `json:"tenant_id"`
+	Enabled         bool               `json:"enabled"`
+	SpikeFactor     float64            `json:"spike_factor"`      // Matches over the baseline median that make a spike
+	MinMatches      int                `json:"min_matches"`       // Spikes below this many matches are ignored
+	BaselineRuns    int                `json:"baseline_runs"`     // Previous scan runs the baseline is taken over
+	PIISpikeFactors map[string]float64 `json:"pii_spike_factors"` // PII type -> spike factor overriding SpikeFactor
+	UpdatedBy       string             `json:"updated_by,omitempty"`
+	UpdatedAt       *time.Time         `json:"updated_at,omitempty"` // Nil while the tenant uses the defaults
+}
+
+// ScanAnomalySuppression mutes anomalies detected between StartsAt and EndsAt,
+// narrowed to an asset, a connection and/or a PII type when set
+type ScanAnomalySuppression struct {
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	AssetID      *uuid.UUID `json:"asset_id,omitempty"`
+	ConnectionID *uuid.UUID `json:"connection_id,omitempty"`
+	PIIType      string     `json:"pii_type,omitempty"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	Reason       string     `json:"reason"`
+	CreatedBy    string     `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ScanAnomaly is a PII type whose matches in a scan run spiked over its baseline
+type ScanAnomaly struct {
+	ID              uuid.UUID  `json:"id"`
+	TenantID        uuid.UUID  `json:"tenant_id"`
+	ScanRunID       uuid.UUID  `json:"scan_run_id"`
+	Scope           string     `json:"scope"`
+	AssetID         *uuid.UUID `json:"asset_id,omitempty"` // Set for the asset scope
+	AssetName       string     `json:"asset_name,omitempty"`
+	ConnectionID    *uuid.UUID `json:"connection_id,omitempty"`
+	PIIType         string     `json:"pii_type"`
+	ObservedMatches int        `json:"observed_matches"`
+	BaselineMatches float64    `json:"baseline_matches"` // Median over the baseline runs
+	BaselineRuns    int        `json:"baseline_runs"`
+	Ratio           float64    `json:"ratio"` // Observed over the baseline, which counts as at least 1
+	Status          string     `json:"status"`
+	SuppressionID   *uuid.UUID `json:"suppression_id,omitempty"`
+	DetectedAt      time.Time  `json:"detected_at"`
+	AcknowledgedBy  string     `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt  *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// ScanAnomalyFilter narrows a listing of scan anomalies
+type ScanAnomalyFilter struct {
+	Status    string
+	ScanRunID *uuid.UUID
+	Limit     int
+	Offset    int
+}
+
+// ScanRunMatchCount is how many matches of a PII type a scan run found on an asset
+type ScanRunMatchCount struct {
+	ScanRunID uuid.UUID
+	AssetID   uuid.UUID
+	PIIType   string
+	Matches   int
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// ScanPerformance profiles the ingestion of one scan run. It is kept in the scan
+// run's metadata under "performance". Stage times are summed across ingestion
+// workers, so together they may exceed DurationMs.
+type ScanPerformance struct {
+	Findings          int       `json:"findings"`
+	Assets            int       `json:"assets"`
+	DurationMs        int64     `json:"duration_ms"` // Wall time from ingestion starting to the scan run being saved
+	FindingsPerSecond float64   `json:"findings_per_second"`
+	DBMs              int64     `json:"db_ms"`
+	ClassificationMs  int64     `json:"classification_ms"`
+	Neo4jSyncMs       int64     `json:"neo4j_sync_ms"`
+	Neo4jSyncs        int       `json:"neo4j_syncs"`
+	Neo4jSyncPending  bool      `json:"neo4j_sync_pending"` // Lineage syncs were still running when last recorded
+	QueueWaitMs       int64     `json:"queue_wait_ms"`      // Time held back by ingestion admission
+	RecordedAt        time.Time `json:"recorded_at"`
+}
+
+// ScanPerformanceTrendPoint aggregates the profiled scan runs of one period
+type ScanPerformanceTrendPoint struct {
+	Period               time.Time `json:"period"`
+	Scans                int       `json:"scans"`
+	Findings             int       `json:"findings"`
+	AvgFindingsPerSecond float64   `json:"avg_findings_per_second"`
+	AvgDurationMs        float64   `json:"avg_duration_ms"`
+	P95DurationMs        float64   `json:"p95_duration_ms"`
+	AvgDBMs              float64   `json:"avg_db_ms"`
+	AvgClassificationMs  float64   `json:"avg_classification_ms"`
+	AvgNeo4jSyncMs       float64   `json:"avg_neo4j_sync_ms"`
+	AvgQueueWaitMs       float64   `json:"avg_queue_wait_ms"`
+	MaxQueueWaitMs       int64     `json:"max_queue_wait_ms"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScanProfile defines what the scanner should read from a connection
+type ScanProfile struct {
+	ID             uuid.UUID `json:"id"`
+	TenantID       uuid.UUID `json:"tenant_id"`
+	ConnectionID   uuid.UUID `json:"connection_id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	IncludeGlobs   []string  `json:"include_globs"`
+	ExcludeGlobs   []string  `json:"exclude_globs"`
+	TableAllowList []string  `json:"table_allow_list"`
+	TableDenyList  []string  `json:"table_deny_list"`
+	SampleSize     int       `json:"sample_size"`
+	PIITypes       []string  `json:"pii_types"` // Empty means all in-scope PII types
+	IsDefault      bool      `json:"is_default"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
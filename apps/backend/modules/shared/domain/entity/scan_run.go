@@ -11,6 +11,7 @@ type ScanRun struct {
 	ID              uuid.UUID              `json:"id"`
 	TenantID        uuid.UUID              `json:"tenant_id"`
 	ProfileName     string                 `json:"profile_name"`
+	ConnectionID    *uuid.UUID             `json:"connection_id,omitempty"` // Nil when no single connection was scanned
 	ScanStartedAt   time.Time              `json:"scan_started_at"`
 	ScanCompletedAt time.Time              `json:"scan_completed_at"`
 	Host            string                 `json:"host"`
@@ -18,6 +19,9 @@ type ScanRun struct {
 	TotalAssets     int                    `json:"total_assets"`
 	Status          string                 `json:"status"`
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	SignatureStatus string                 `json:"signature_status"`        // unsigned or verified
+	SignerKeyID     *uuid.UUID             `json:"signer_key_id,omitempty"` // Nil when unsigned or the key was deleted
+	SignerName      string                 `json:"signer_name,omitempty"`
 	CreatedAt       time.Time              `json:"created_at"`
 	UpdatedAt       time.Time              `json:"updated_at"`
 }
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Signature status of a scan run
+const (
+	ScanSignatureUnsigned = "unsigned"
+	ScanSignatureVerified = "verified"
+)
+
+// ScannerSigningKey is a public key a scanner signs its ingestion payloads with.
+// Scanners name the key by KeyID in the X-Scan-Key-Id header.
+type ScannerSigningKey struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	KeyID       string     `json:"key_id"`
+	ScannerName string     `json:"scanner_name"`
+	Algorithm   string     `json:"algorithm"`
+	PublicKey   []byte     `json:"public_key"` // Raw Ed25519 key, base64 in JSON
+	Fingerprint string     `json:"fingerprint"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy   string     `json:"revoked_by,omitempty"`
+}
+
+// Revoked reports whether the key no longer verifies payloads
+func (k *ScannerSigningKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// TenantScanSigningPolicy records a tenant's choice to reject unsigned scan payloads
+type TenantScanSigningPolicy struct {
+	TenantID         uuid.UUID `json:"tenant_id"`
+	RequireSignature bool      `json:"require_signature"`
+	UpdatedBy        string    `json:"updated_by"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
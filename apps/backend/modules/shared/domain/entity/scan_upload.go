@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scan upload session statuses
+const (
+	UploadSessionOpen       = "open"
+	UploadSessionFinalizing = "finalizing"
+	UploadSessionCompleted  = "completed"
+)
+
+// ScanUploadSession is a resumable chunked upload of one scan's findings
+type ScanUploadSession struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	ScanID         string     `json:"scan_id"`
+	Status         string     `json:"status"`
+	ExpectedChunks *int       `json:"expected_chunks,omitempty"`
+	ReceivedChunks []int      `json:"received_chunks"`
+	TotalFindings  int        `json:"total_findings"`
+	ScanRunID      *uuid.UUID `json:"scan_run_id,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	SignerKeyID    *uuid.UUID `json:"signer_key_id,omitempty"` // Key every chunk was signed with
+	UnsignedChunks bool       `json:"unsigned_chunks"`
+	CreatedBy      string     `json:"created_by"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// ScanUploadChunk is the receipt for a stored chunk
+type ScanUploadChunk struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	ChunkNumber  int       `json:"chunk_number"`
+	FindingCount int       `json:"finding_count"`
+	Checksum     string    `json:"checksum"`
+	ReceivedAt   time.Time `json:"received_at"`
+}
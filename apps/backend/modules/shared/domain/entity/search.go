@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SearchStreamFindings is the watermark stream findings are indexed for search under
+const SearchStreamFindings = "findings"
+
+// SearchDocument is a finding as indexed for full-text search. Sample text is
+// indexed as stored, so samples masked by the tenant's policy stay masked and
+// encrypted samples are left out.
+type SearchDocument struct {
+	ID          uuid.UUID `json:"id"`
+	TenantID    uuid.UUID `json:"tenant_id"`
+	AssetID     uuid.UUID `json:"asset_id"`
+	AssetName   string    `json:"asset_name"`
+	AssetPath   string    `json:"asset_path"`
+	Host        string    `json:"host"`
+	DataSource  string    `json:"data_source"`
+	PatternName string    `json:"pattern_name"`
+	Severity    string    `json:"severity"`
+	SampleText  string    `json:"sample_text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Deleted     bool      `json:"-"`
+}
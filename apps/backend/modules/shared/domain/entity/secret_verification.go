@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of verifying the credential of a Secrets finding
+const (
+	SecretStatusActive     = "ACTIVE_SECRET" // The credential authenticated
+	SecretStatusStale      = "STALE"         // The provider rejected the credential as unknown or revoked
+	SecretStatusUnverified = "UNVERIFIED"    // No verifiable credential, or the probe was inconclusive
+)
+
+// Providers whose credentials can be verified
+const (
+	SecretProviderAWS    = "aws"    // STS GetCallerIdentity
+	SecretProviderGitHub = "github" // GET /user
+	SecretProviderSlack  = "slack"  // auth.test
+)
+
+// SecretProviders lists the supported providers in probe order
+var SecretProviders = []string{SecretProviderAWS, SecretProviderGitHub, SecretProviderSlack}
+
+// SecretVerificationSettings is a tenant's opt-in to credential liveness probes.
+// Probes authenticate against third parties with found credentials, so they never
+// run for a tenant that has not enabled them.
+type SecretVerificationSettings struct {
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Enabled   bool      `json:"enabled"`
+	Providers []string  `json:"providers"` // Empty for all supported providers
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AllowsProvider reports whether probes against provider are enabled
+func (s *SecretVerificationSettings) AllowsProvider(provider string) bool {
+	if s == nil || !s.Enabled {
+		return false
+	}
+	if len(s.Providers) == 0 {
+		return true
+	}
+	for _, p := range s.Providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretVerification is the latest verification of a Secrets finding. It never
+// holds the credential.
+type SecretVerification struct {
+	ID               uuid.UUID `json:"id"`
+	TenantID         uuid.UUID `json:"tenant_id"`
+	FindingID        uuid.UUID `json:"finding_id"`
+	Provider         string    `json:"provider,omitempty"`
+	Status           string    `json:"status"`
+	Identity         string    `json:"identity,omitempty"`
+	Detail           string    `json:"detail,omitempty"`
+	OriginalSeverity string    `json:"original_severity"`
+	Severity         string    `json:"severity"` // Severity of the finding after verification
+	CheckedAt        time.Time `json:"checked_at"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcome fields compared between the primary and shadow classifier
+const (
+	DivergenceClassification  = "classification"
+	DivergenceSubCategory     = "sub_category"
+	DivergenceConfidenceLevel = "confidence_level"
+	DivergenceKept            = "kept" // One engine would store the finding, the other would discard it
+)
+
+// ClassificationOutcome is what one classifier version decided for a finding
+type ClassificationOutcome struct {
+	Version         string  `json:"version"`
+	Classification  string  `json:"classification"`
+	SubCategory     string  `json:"sub_category"`
+	ConfidenceLevel string  `json:"confidence_level"`
+	Score           float64 `json:"score"`
+	Kept            bool    `json:"kept"`
+}
+
+// ShadowClassification pairs the primary outcome for a finding with a candidate
+// engine's outcome for the same input
+type ShadowClassification struct {
+	ID          uuid.UUID             `json:"id"`
+	TenantID    uuid.UUID             `json:"tenant_id"`
+	ScanRunID   uuid.UUID             `json:"scan_run_id"`
+	FindingID   *uuid.UUID            `json:"finding_id,omitempty"` // Nil when the primary engine discarded the finding
+	PatternName string                `json:"pattern_name"`
+	Primary     ClassificationOutcome `json:"primary"`
+	Shadow      ClassificationOutcome `json:"shadow"`
+	Divergences []string              `json:"divergences"`
+	CreatedAt   time.Time             `json:"created_at"`
+}
+
+// ShadowDivergenceCounts tallies shadow results for one PII type
+type ShadowDivergenceCounts struct {
+	PatternName     string `json:"pii_type"`
+	Total           int    `json:"total"`
+	Diverged        int    `json:"diverged"`
+	Classification  int    `json:"classification_changed"`
+	SubCategory     int    `json:"sub_category_changed"`
+	ConfidenceLevel int    `json:"confidence_level_changed"`
+	NewlyKept       int    `json:"newly_kept"`      // Discarded by the primary engine, kept by the candidate
+	NewlyDiscarded  int    `json:"newly_discarded"` // Kept by the primary engine, discarded by the candidate
+}
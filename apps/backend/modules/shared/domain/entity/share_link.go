@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Dashboard widgets a share link may expose. Each is an aggregate; none carries sample values.
+const (
+	ShareWidgetPIISummary      = "pii_summary"      // Finding counts by severity, classification and environment
+	ShareWidgetComplianceScore = "compliance_score" // Posture score, its components and daily history
+	ShareWidgetRiskTrend       = "risk_trend"       // Daily PII counts by severity
+	ShareWidgetPIIHeatmap      = "pii_heatmap"      // Finding counts by environment and data source per PII type
+)
+
+// ShareLink is a read-only link to a snapshot of dashboard widgets, opened without
+// authentication. Only a hash of its token is stored; the token is returned once.
+type ShareLink struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	Name           string     `json:"name"`
+	Widgets        []string   `json:"widgets"`
+	Environment    string     `json:"environment,omitempty"`
+	Days           int        `json:"days"`
+	IPAllowlist    []string   `json:"ip_allowlist"`
+	Token          string     `json:"token,omitempty"` // Set only in the create response
+	TokenHash      string     `json:"-"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedBy      string     `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	RevokedBy      string     `json:"revoked_by,omitempty"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	AccessCount    int        `json:"access_count"`
+}
+
+// Active reports whether the link can be opened at now
+func (l *ShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuppressionRule drops a pattern's findings under matching paths or hosts before they are persisted
+type SuppressionRule struct {
+	ID            uuid.UUID  `json:"id"`
+	TenantID      uuid.UUID  `json:"tenant_id"`
+	Name          string     `json:"name"`
+	PatternName   string     `json:"pattern_name"`
+	PathGlob      string     `json:"path_glob"` // Empty matches any path
+	HostGlob      string     `json:"host_glob"` // Empty matches any host
+	Reason        string     `json:"reason"`
+	IsActive      bool       `json:"is_active"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	HitCount      int64      `json:"hit_count"`
+	LastMatchedAt *time.Time `json:"last_matched_at,omitempty"`
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tenant export statuses
+const (
+	TenantExportPending   = "pending"
+	TenantExportRunning   = "running"
+	TenantExportCompleted = "completed"
+	TenantExportFailed    = "failed"
+)
+
+// TenantExportFormatVersion is the layout version of export bundles, recorded in
+// each manifest
+const TenantExportFormatVersion = 1
+
+// TenantExport records a bundle of a tenant's data built for offboarding or a
+// regulator request
+type TenantExport struct {
+	ID              uuid.UUID             `json:"id"`
+	TenantID        uuid.UUID             `json:"tenant_id"`
+	Status          string                `json:"status"`
+	Reason          string                `json:"reason,omitempty"`
+	RequestedBy     string                `json:"requested_by"`
+	RequestedAt     time.Time             `json:"requested_at"`
+	JobID           *uuid.UUID            `json:"job_id,omitempty"`
+	StorageProvider string                `json:"storage_provider,omitempty"`
+	Bucket          string                `json:"bucket,omitempty"`
+	ObjectKey       string                `json:"object_key,omitempty"`
+	SizeBytes       int64                 `json:"size_bytes"`
+	ChecksumSHA256  string                `json:"checksum_sha256,omitempty"` // Of the whole bundle
+	Manifest        *TenantExportManifest `json:"manifest,omitempty"`
+	Error           string                `json:"error,omitempty"`
+	CompletedAt     *time.Time            `json:"completed_at,omitempty"`
+}
+
+// TenantExportManifest is manifest.json at the root of a bundle
+type TenantExportManifest struct {
+	ExportID      uuid.UUID          `json:"export_id"`
+	TenantID      uuid.UUID          `json:"tenant_id"`
+	FormatVersion int                `json:"format_version"`
+	GeneratedAt   time.Time          `json:"generated_at"`
+	Files         []TenantExportFile `json:"files"`
+}
+
+// TenantExportFile describes one JSON Lines file of a bundle
+type TenantExportFile struct {
+	Name      string `json:"name"`
+	Table     string `json:"table"`
+	Rows      int    `json:"rows"`
+	SizeBytes int64  `json:"size_bytes"`
+	SHA256    string `json:"sha256"`
+}
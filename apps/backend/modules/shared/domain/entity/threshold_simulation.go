@@ -0,0 +1,11 @@
+package entity
+
+// ConfidenceBucket counts the stored findings of one severity and PII type whose
+// confidence score floors to Score at 0.001 resolution. Score is nil for findings
+// stored without a confidence score.
+type ConfidenceBucket struct {
+	Severity string   `json:"severity"`
+	PIIType  string   `json:"pii_type"`
+	Score    *float64 `json:"score,omitempty"`
+	Count    int      `json:"count"`
+}
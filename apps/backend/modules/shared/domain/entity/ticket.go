@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Issue tracker providers
+const (
+	TicketProviderJira       = "jira"
+	TicketProviderServiceNow = "servicenow"
+)
+
+// TicketTarget is where new tickets are filed: for Jira, Queue is the project
+// key and Type the issue type; for ServiceNow, Queue is the assignment group
+// and Type the table
+type TicketTarget struct {
+	Queue string `json:"queue"`
+	Type  string `json:"type,omitempty"`
+}
+
+// TicketQueueMapping routes new tickets by finding severity, falling back to Default
+type TicketQueueMapping struct {
+	Default    TicketTarget            `json:"default"`
+	BySeverity map[string]TicketTarget `json:"by_severity,omitempty"` // Lowercase severity -> target
+}
+
+// TargetFor returns the target for a severity
+func (m TicketQueueMapping) TargetFor(severity string) TicketTarget {
+	if t, ok := m.BySeverity[strings.ToLower(severity)]; ok {
+		return t
+	}
+	return m.Default
+}
+
+// TicketIntegration is a tenant's Jira or ServiceNow instance. The API token and
+// webhook secret are stored encrypted and never returned.
+type TicketIntegration struct {
+	ID                     uuid.UUID          `json:"id"`
+	TenantID               uuid.UUID          `json:"tenant_id"`
+	Provider               string             `json:"provider"`
+	Name                   string             `json:"name"`
+	BaseURL                string             `json:"base_url"`
+	Username               string             `json:"username,omitempty"` // Jira account email or ServiceNow user
+	APITokenEncrypted      []byte             `json:"-"`
+	HasAPIToken            bool               `json:"has_api_token"`
+	WebhookSecretEncrypted []byte             `json:"-"`
+	HasWebhookSecret       bool               `json:"has_webhook_secret"`
+	QueueMapping           TicketQueueMapping `json:"queue_mapping"`
+	ResolvedStatuses       []string           `json:"resolved_statuses"` // Tracker statuses that resolve the linked finding
+	Enabled                bool               `json:"enabled"`
+	CreatedBy              string             `json:"created_by"`
+	CreatedAt              time.Time          `json:"created_at"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+}
+
+// IsResolvedStatus reports whether a tracker status is one of the integration's resolved statuses
+func (i *TicketIntegration) IsResolvedStatus(status string) bool {
+	for _, s := range i.ResolvedStatuses {
+		if strings.EqualFold(strings.TrimSpace(s), strings.TrimSpace(status)) {
+			return true
+		}
+	}
+	return false
+}
+
+// TicketLink is a tracker ticket linked to a finding or a remediation request
+type TicketLink struct {
+	ID                   uuid.UUID  `json:"id"`
+	TenantID             uuid.UUID  `json:"tenant_id"`
+	IntegrationID        uuid.UUID  `json:"integration_id"`
+	Provider             string     `json:"provider"`
+	FindingID            *uuid.UUID `json:"finding_id,omitempty"`
+	RemediationRequestID *uuid.UUID `json:"remediation_request_id,omitempty"`
+	TicketKey            string     `json:"ticket_key"`
+	ExternalID           string     `json:"external_id"`
+	TicketType           string     `json:"ticket_type,omitempty"`
+	URL                  string     `json:"url,omitempty"`
+	TrackerStatus        string     `json:"tracker_status,omitempty"`
+	Resolved             bool       `json:"resolved"`
+	LastSyncedStatus     string     `json:"last_synced_status,omitempty"` // ARC status the tracker last saw
+	CreatedBy            string     `json:"created_by"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// TicketSyncCandidate is a linked ticket whose ARC status moved since it was last synced
+type TicketSyncCandidate struct {
+	Link      *TicketLink
+	ARCStatus string // Finding review status ("open" when never reviewed) or remediation request status
+}
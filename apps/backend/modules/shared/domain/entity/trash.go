@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Trash batch scopes
+const (
+	TrashScopeScanData = "scan_data" // Every asset, finding and scan run of the tenant
+	TrashScopeAsset    = "asset"     // One asset and its findings
+	TrashScopeScanRun  = "scan_run"  // One scan run and its findings
+)
+
+// TrashBatch groups rows soft-deleted together so they can be restored or purged together
+type TrashBatch struct {
+	ID            uuid.UUID  `json:"id"`
+	TenantID      uuid.UUID  `json:"tenant_id"`
+	Scope         string     `json:"scope"`
+	ResourceID    *uuid.UUID `json:"resource_id,omitempty"`
+	AssetCount    int        `json:"asset_count"`
+	FindingCount  int        `json:"finding_count"`
+	ScanRunCount  int        `json:"scan_run_count"`
+	RetainedCount int        `json:"retained_count"` // Rows kept at purge for remediation and archive records
+	DeletedBy     string     `json:"deleted_by"`
+	DeletedAt     time.Time  `json:"deleted_at"`
+	RestoredBy    string     `json:"restored_by,omitempty"`
+	RestoredAt    *time.Time `json:"restored_at,omitempty"`
+	PurgedAt      *time.Time `json:"purged_at,omitempty"`
+	PurgeAfter    *time.Time `json:"purge_after,omitempty"` // End of the restore window while the batch is pending
+}
+
+// AssetDeletion is the outcome of deleting an asset: its trash batch, whether it was
+// purged at once, and whether its lineage graph node was removed
+type AssetDeletion struct {
+	*TrashBatch
+	Purged         bool   `json:"purged"`
+	LineageRemoved bool   `json:"lineage_removed"`
+	LineageError   string `json:"lineage_error,omitempty"`
+}
+
+// IsPending reports whether the batch has been neither restored nor purged
+func (b *TrashBatch) IsPending() bool {
+	return b.RestoredAt == nil && b.PurgedAt == nil
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ValueOccurrence is one asset a normalized PII value was observed in
+type ValueOccurrence struct {
+	AssetID          uuid.UUID  `json:"asset_id"`
+	AssetName        string     `json:"asset_name"`
+	AssetPath        string     `json:"asset_path"`
+	DataSource       string     `json:"data_source"`
+	Host             string     `json:"host,omitempty"`
+	System           string     `json:"system"` // Source system, or the data source when none is recorded
+	Environment      string     `json:"environment,omitempty"`
+	PatternNames     []string   `json:"pattern_names"`
+	ObservationCount int        `json:"observation_count"`
+	FirstObservedAt  time.Time  `json:"first_observed_at"`
+	LastObservedAt   time.Time  `json:"last_observed_at"`
+	LatestFindingID  *uuid.UUID `json:"latest_finding_id,omitempty"` // Nil once every finding row was archived
+}
+
+// ValueOccurrences is where one normalized PII value is stored across the estate
+type ValueOccurrences struct {
+	ValueHash    string            `json:"value_hash"`
+	PatternNames []string          `json:"pattern_names"`
+	AssetCount   int               `json:"asset_count"`
+	SystemCount  int               `json:"system_count"`
+	Summary      string            `json:"summary"` // e.g. "Aadhaar appears in 7 assets across 3 systems"
+	Occurrences  []ValueOccurrence `json:"occurrences"`
+}
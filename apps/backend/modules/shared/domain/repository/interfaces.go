@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// The interfaces below cover what the ingestion, classification and remediation
+// services, shadow classification, jurisdiction profiles, scan signing, the job queue
+// and the idempotency middleware need from storage. persistence.PostgresRepository
+// implements all of them; persistence/memory provides an in-memory implementation
+// for unit tests.
+
+// Transaction groups the writes of one ingestion so they commit or roll back together
+type Transaction interface {
+	CreateScanRun(ctx context.Context, scanRun *entity.ScanRun) error
+	UpdateScanRun(ctx context.Context, scanRun *entity.ScanRun) error
+	CreateFinding(ctx context.Context, finding *entity.Finding) error
+	CreateClassification(ctx context.Context, classification *entity.Classification) error
+	CreateReviewState(ctx context.Context, reviewState *entity.ReviewState) error
+	RecordFindingObservation(ctx context.Context, obs *entity.FindingObservation) error
+	Commit() error
+	Rollback() error
+}
+
+// ScanRunRepository reads scan runs
+type ScanRunRepository interface {
+	GetScanRunByID(ctx context.Context, id uuid.UUID) (*entity.ScanRun, error)
+	// GetLatestScanRun returns nil when no scan has run yet
+	GetLatestScanRun(ctx context.Context) (*entity.ScanRun, error)
+	// FindConnectionIDByProfile returns nil unless exactly one connection has the profile name
+	FindConnectionIDByProfile(ctx context.Context, profileName string) (*uuid.UUID, error)
+}
+
+// PatternRepository stores detection patterns
+type PatternRepository interface {
+	// GetPatternByName returns nil when the pattern does not exist
+	GetPatternByName(ctx context.Context, name string) (*entity.Pattern, error)
+	CreatePattern(ctx context.Context, pattern *entity.Pattern) error
+}
+
+// SuppressionRepository reads the suppression rules applied at ingestion
+type SuppressionRepository interface {
+	ListActiveSuppressionRules(ctx context.Context) ([]*entity.SuppressionRule, error)
+	RecordSuppressionHits(ctx context.Context, hits map[uuid.UUID]int) error
+}
+
+// IngestionRepository is the storage IngestionService writes scans through
+type IngestionRepository interface {
+	ScanRunRepository
+	PatternRepository
+	SuppressionRepository
+
+	BeginTransaction(ctx context.Context) (Transaction, error)
+	CountFindings(ctx context.Context, filters FindingFilters) (int, error)
+	UpdateAssetStats(ctx context.Context, id uuid.UUID, score int, totalFindings int, cause string) error
+	MarkAssetScanned(ctx context.Context, id uuid.UUID, at time.Time) error
+	CountAssetsSharingValues(ctx context.Context, assetID uuid.UUID) (int, error)
+	// RecordDroppedFindings adds the ingestion filter's counts for a scan run to the ledger
+	RecordDroppedFindings(ctx context.Context, scanRunID uuid.UUID, counts []entity.DroppedFindingCount) error
+	// SetScanRunPerformance replaces a scan run's performance profile, even once it is completed
+	SetScanRunPerformance(ctx context.Context, scanRunID uuid.UUID, perf *entity.ScanPerformance) error
+}
+
+// ClassificationRepository is the storage ClassificationService depends on
+type ClassificationRepository interface {
+	PatternRepository
+}
+
+// RemediationRepository stores remediation actions and approval requests.
+// Request methods are scoped to the tenant in ctx when there is one.
+type RemediationRepository interface {
+	GetRemediationFinding(ctx context.Context, findingID string) (*entity.RemediationFinding, error)
+	// GetFindingPreviewSample returns a finding's sample text and PII type
+	GetFindingPreviewSample(ctx context.Context, findingID string) (string, string, error)
+	GetRemediationSourceConfig(ctx context.Context, sourceName string) (map[string]interface{}, error)
+
+	// CreateRemediationAction stores a PENDING action executed now
+	CreateRemediationAction(ctx context.Context, action *entity.RemediationAction) error
+	UpdateRemediationActionStatus(ctx context.Context, id, status string) error
+	UpdateRemediationActionMetadata(ctx context.Context, id string, metadata []byte) error
+	// EndRemediationAction closes the period the action was in effect
+	EndRemediationAction(ctx context.Context, id string) error
+	GetRemediationAction(ctx context.Context, id string) (*entity.RemediationAction, error)
+	// The list methods return actions newest first, without their metadata
+	ListRemediationActionsByFinding(ctx context.Context, findingID string) ([]*entity.RemediationAction, error)
+	ListRemediationActionsByAsset(ctx context.Context, assetID string) ([]*entity.RemediationAction, error)
+	ListRemediationActions(ctx context.Context, actionType string, limit, offset int) ([]*entity.RemediationAction, int, error)
+	RecordRemediationAuditLog(ctx context.Context, eventType, userID, resourceType, resourceID string, metadata map[string]interface{}) error
+
+	// CreateRemediationRequest stores a pending request and fills in its generated fields
+	CreateRemediationRequest(ctx context.Context, req *entity.RemediationApprovalRequest) error
+	// GetRemediationRequest returns nil when the request does not exist
+	GetRemediationRequest(ctx context.Context, id string) (*entity.RemediationApprovalRequest, error)
+	ListRemediationRequests(ctx context.Context, status string, limit, offset int) ([]*entity.RemediationApprovalRequest, int, error)
+	// DecideRemediationRequest moves a pending, unexpired request to status. It
+	// returns nil when the request was no longer pending.
+	DecideRemediationRequest(ctx context.Context, id, status, decidedBy, comment string) (*entity.RemediationApprovalRequest, error)
+	CompleteRemediationRequest(ctx context.Context, id, status string, actionIDs, failures []string) (*entity.RemediationApprovalRequest, error)
+	// ExpireRemediationRequests lapses pending requests past their expiry and returns them
+	ExpireRemediationRequests(ctx context.Context) ([]*entity.RemediationApprovalRequest, error)
+
+	// CreateRemediationSimulation stores a dry run and fills in its ID and time
+	CreateRemediationSimulation(ctx context.Context, sim *entity.RemediationSimulation) error
+	// GetRemediationSimulation returns nil when the simulation does not exist
+	GetRemediationSimulation(ctx context.Context, id string) (*entity.RemediationSimulation, error)
+	// ListRemediationSimulationsByFinding returns a finding's dry runs, newest first
+	ListRemediationSimulationsByFinding(ctx context.Context, findingID string) ([]*entity.RemediationSimulation, error)
+}
+
+// IdempotencyRepository stores Idempotency-Key reservations and the responses they
+// produced, scoped to the tenant in ctx
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey claims key for a new request. It returns nil when the
+	// key was free or its previous record had expired, and the live record otherwise.
+	ReserveIdempotencyKey(ctx context.Context, endpoint, key string, expiresAt time.Time) (*entity.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error
+	// ReleaseIdempotencyKey drops a reservation so the request can be retried
+	ReleaseIdempotencyKey(ctx context.Context, endpoint, key string) error
+	PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// ShadowClassificationRepository stores outcomes of a candidate classifier version,
+// scoped to the tenant in ctx
+type ShadowClassificationRepository interface {
+	RecordShadowClassification(ctx context.Context, result *entity.ShadowClassification) error
+	// GetShadowDivergenceCounts tallies a candidate version's results since the given time by PII type
+	GetShadowDivergenceCounts(ctx context.Context, shadowVersion string, since time.Time) ([]entity.ShadowDivergenceCounts, error)
+	// ListShadowVersions returns the candidate versions with stored results, newest first
+	ListShadowVersions(ctx context.Context) ([]string, error)
+}
+
+// JobRepository persists background jobs. Claiming and completing jobs spans all
+// tenants; listing and the admin retry and cancel are scoped to the tenant in ctx.
+type JobRepository interface {
+	// CreateJob stores a pending job for the tenant in ctx, or a system job when ctx has none
+	CreateJob(ctx context.Context, job *entity.Job) error
+	// ClaimDueJobs marks up to limit due pending jobs of the given types running for workerID
+	ClaimDueJobs(ctx context.Context, jobTypes []string, workerID string, limit int) ([]*entity.Job, error)
+	CompleteJob(ctx context.Context, id uuid.UUID) error
+	// FailJob records a failed attempt: the job is retried at retryAt, or marked failed when it is nil
+	FailJob(ctx context.Context, id uuid.UUID, jobErr string, retryAt *time.Time) error
+	// RequeueStaleJobs returns running jobs locked before lockedBefore to pending
+	RequeueStaleJobs(ctx context.Context, lockedBefore time.Time) (int64, error)
+	ListJobs(ctx context.Context, filter entity.JobFilter) ([]*entity.Job, int, error)
+	// GetJob returns nil when the tenant has no job with the ID
+	GetJob(ctx context.Context, id uuid.UUID) (*entity.Job, error)
+	// RetryJob makes a failed or cancelled job due now with a fresh attempt budget.
+	// It returns false when the job is in another state.
+	RetryJob(ctx context.Context, id uuid.UUID) (bool, error)
+	// CancelJob cancels a pending job. It returns false when the job is in another state.
+	CancelJob(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// JurisdictionRepository reads the shared jurisdiction profiles and the selection of
+// the tenant in ctx
+type JurisdictionRepository interface {
+	ListJurisdictionProfiles(ctx context.Context) ([]*entity.JurisdictionProfile, error)
+	// GetJurisdictionProfile returns nil when no profile has the code
+	GetJurisdictionProfile(ctx context.Context, code string) (*entity.JurisdictionProfile, error)
+	// GetTenantJurisdiction returns "" when the tenant has not selected a profile
+	GetTenantJurisdiction(ctx context.Context) (string, error)
+	SetTenantJurisdiction(ctx context.Context, code, updatedBy string) error
+}
+
+// SampleTextPolicyRepository stores the sample text storage choice of the tenant in
+// ctx and finds samples stored before the redaction policy applied to them
+type SampleTextPolicyRepository interface {
+	// GetTenantSampleTextPolicy returns nil when the tenant keeps the default policy
+	GetTenantSampleTextPolicy(ctx context.Context) (*entity.TenantSampleTextPolicy, error)
+	SetTenantSampleTextPolicy(ctx context.Context, storeFull bool, updatedBy string) error
+	// ListUnredactedSamples returns up to limit unredacted samples of tenants without
+	// full storage, across all tenants when tenantID is nil
+	ListUnredactedSamples(ctx context.Context, tenantID *uuid.UUID, limit int) ([]entity.StoredSample, error)
+	// SaveRedactedSamples replaces stored samples and marks them redacted
+	SaveRedactedSamples(ctx context.Context, samples []entity.StoredSample) error
+}
+
+// MaskingTemplateRepository stores the masking templates of the tenant in ctx
+type MaskingTemplateRepository interface {
+	ListMaskingTemplates(ctx context.Context) ([]*entity.MaskingTemplate, error)
+	// SaveMaskingTemplate creates or replaces the template of its PII type
+	SaveMaskingTemplate(ctx context.Context, template *entity.MaskingTemplate) error
+	// DeleteMaskingTemplate reports whether the PII type had a template
+	DeleteMaskingTemplate(ctx context.Context, piiType string) (bool, error)
+}
+
+// Errors returned by ScanSigningRepository implementations
+var (
+	ErrSigningKeyNotFound = errors.New("signing key not found")
+	ErrSigningKeyExists   = errors.New("a signing key with this key ID already exists")
+)
+
+// ScanSigningRepository stores the scanner signing keys and the strict mode choice
+// of the tenant in ctx
+type ScanSigningRepository interface {
+	// CreateScannerSigningKey returns ErrSigningKeyExists when the key ID is taken,
+	// including by a revoked key
+	CreateScannerSigningKey(ctx context.Context, key *entity.ScannerSigningKey) error
+	ListScannerSigningKeys(ctx context.Context) ([]*entity.ScannerSigningKey, error)
+	GetScannerSigningKey(ctx context.Context, id uuid.UUID) (*entity.ScannerSigningKey, error)
+	// GetScannerSigningKeyByKeyID returns revoked keys too, so callers can tell them apart
+	GetScannerSigningKeyByKeyID(ctx context.Context, keyID string) (*entity.ScannerSigningKey, error)
+	// RevokeScannerSigningKey returns ErrSigningKeyNotFound unless an active key has the ID
+	RevokeScannerSigningKey(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.ScannerSigningKey, error)
+	// GetTenantScanSigningPolicy returns nil when the tenant accepts unsigned payloads
+	GetTenantScanSigningPolicy(ctx context.Context) (*entity.TenantScanSigningPolicy, error)
+	SetTenantScanSigningPolicy(ctx context.Context, requireSignature bool, updatedBy string) error
+}
+
+// QuotaRepository stores the quota overrides of the tenant in ctx and counts what
+// its quotas limit
+type QuotaRepository interface {
+	// GetTenantQuota returns nil when the tenant keeps the default quotas
+	GetTenantQuota(ctx context.Context) (*entity.TenantQuota, error)
+	SetTenantQuota(ctx context.Context, quota *entity.TenantQuota) error
+	// CountStoredFindings counts the tenant's findings that are not deleted
+	CountStoredFindings(ctx context.Context) (int, error)
+	// CountScanRunsSince counts the tenant's scan runs created at or after since, and
+	// the findings those runs dropped by downsampling
+	CountScanRunsSince(ctx context.Context, since time.Time) (runs int, downsampled int, err error)
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -11,6 +13,13 @@ type FindingFilters struct {
 	Severity    string
 	PatternName string
 	DataSource  string
+	OrgUnitID   *uuid.UUID // Findings on assets of the unit or, for a department, of its teams
+	Bucket      string     // Findings on S3 objects in the bucket
+	Prefix      string     // With Bucket, findings on objects whose key starts with the prefix
+
+	ExcludeWaived bool // Leave out findings under an active risk waiver
+
+	AsOf *time.Time // Findings as they were at this time, read from their history
 }
 
 // RelationshipFilters defines filters for relationship queries
@@ -19,3 +28,12 @@ type RelationshipFilters struct {
 	SourceAssetID    *uuid.UUID
 	TargetAssetID    *uuid.UUID
 }
+
+// AuditEventFilters defines filters for audit event export queries
+type AuditEventFilters struct {
+	AfterCreatedAt *time.Time // Keyset cursor: only events after (AfterCreatedAt, AfterID)
+	AfterID        uuid.UUID
+	Since          *time.Time
+	Until          *time.Time
+	Actions        []string // Empty means all actions
+}
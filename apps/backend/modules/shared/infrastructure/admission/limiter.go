@@ -0,0 +1,353 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrOverloaded is wrapped by every rejection so callers can map it to 503
+var ErrOverloaded = errors.New("admission: overloaded")
+
+// Rejection reasons
+const (
+	ReasonLatency      = "database_latency" // The last probes were slower than LatencyShed or failed
+	ReasonQueueFull    = "queue_full"
+	ReasonQueueTimeout = "queue_timeout"
+)
+
+// RejectedError is returned when a job is not admitted
+type RejectedError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("admission rejected: %s", e.Reason)
+}
+
+func (e *RejectedError) Unwrap() error {
+	return ErrOverloaded
+}
+
+// Settings configures a limiter
+type Settings struct {
+	MinConcurrency int           // The limit never shrinks below this
+	MaxConcurrency int           // Nor grows above this; it starts here
+	MaxQueue       int           // Jobs that may wait for a slot; more are rejected
+	QueueTimeout   time.Duration // Longest a job waits for a slot
+	LatencyTarget  time.Duration // Probes slower than this shrink the limit, faster than half of it grow it
+	LatencyShed    time.Duration // Probes slower than this (or failing) reject new jobs outright
+	RetryAfter     time.Duration // Suggested client back-off on rejection
+}
+
+// Snapshot is a point-in-time view of a limiter, suitable for status endpoints
+type Snapshot struct {
+	Name              string  `json:"name"`
+	Limit             int     `json:"limit"`
+	InFlight          int     `json:"in_flight"`
+	Queued            int     `json:"queued"`
+	MaxQueue          int     `json:"max_queue"`
+	LatencyMs         float64 `json:"latency_ms"` // Smoothed probe latency
+	LatencyTargetMs   float64 `json:"latency_target_ms"`
+	LatencyShedMs     float64 `json:"latency_shed_ms"`
+	Shedding          bool    `json:"shedding"`
+	Admitted          int64   `json:"admitted"`
+	Delayed           int64   `json:"delayed"` // Admitted after waiting in the queue
+	Rejected          int64   `json:"rejected"`
+	LastProbeError    string  `json:"last_probe_error,omitempty"`
+	RetryAfterSeconds int     `json:"retry_after_seconds"`
+}
+
+var (
+	limitGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "admission_concurrency_limit",
+		Help: "Current adaptive concurrency limit",
+	}, []string{"name"})
+	inFlightGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "admission_in_flight",
+		Help: "Jobs currently admitted",
+	}, []string{"name"})
+	queuedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "admission_queued",
+		Help: "Jobs waiting for a slot",
+	}, []string{"name"})
+	latencyGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "admission_probe_latency_seconds",
+		Help: "Smoothed latency of the dependency probe",
+	}, []string{"name"})
+	admittedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "admission_admitted_total",
+		Help: "Jobs admitted, including those that waited in the queue",
+	}, []string{"name"})
+	delayedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "admission_delayed_total",
+		Help: "Jobs admitted after waiting in the queue",
+	}, []string{"name"})
+	rejectedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "admission_rejected_total",
+		Help: "Jobs rejected, by reason",
+	}, []string{"name", "reason"})
+)
+
+// latencySmoothing weighs each probe in the moving latency average
+const latencySmoothing = 0.3
+
+// Limiter admits jobs up to a concurrency limit that adapts to the latency of
+// a dependency: the limit halves when probes fail, shrinks while they are
+// slower than the target and grows back by one while they are well under it.
+// Jobs over the limit wait in a bounded queue. It is safe for concurrent use.
+type Limiter struct {
+	name     string
+	settings Settings
+
+	mu        sync.Mutex
+	limit     int
+	inFlight  int
+	queued    int
+	latency   time.Duration
+	probeErr  string
+	shedding  bool
+	admitted  int64
+	delayed   int64
+	rejected  int64
+	slotFreed chan struct{} // Closed and replaced whenever a slot may have opened
+}
+
+// New creates a limiter starting at MaxConcurrency
+func New(name string, settings Settings) *Limiter {
+	if settings.MaxConcurrency < 1 {
+		settings.MaxConcurrency = 8
+	}
+	if settings.MinConcurrency < 1 {
+		settings.MinConcurrency = 1
+	}
+	if settings.MinConcurrency > settings.MaxConcurrency {
+		settings.MinConcurrency = settings.MaxConcurrency
+	}
+	if settings.LatencyTarget <= 0 {
+		settings.LatencyTarget = 100 * time.Millisecond
+	}
+	if settings.LatencyShed <= settings.LatencyTarget {
+		settings.LatencyShed = 10 * settings.LatencyTarget
+	}
+	if settings.RetryAfter <= 0 {
+		settings.RetryAfter = 5 * time.Second
+	}
+
+	l := &Limiter{
+		name:      name,
+		settings:  settings,
+		limit:     settings.MaxConcurrency,
+		slotFreed: make(chan struct{}),
+	}
+	limitGauge.WithLabelValues(name).Set(float64(l.limit))
+	return l
+}
+
+// Acquire admits a job, waiting up to QueueTimeout for a slot. The returned
+// release must be called once the job finishes. Rejections are *RejectedError.
+func (l *Limiter) Acquire(ctx context.Context) (func(), error) {
+	l.mu.Lock()
+	if l.shedding {
+		defer l.mu.Unlock()
+		return nil, l.reject(ReasonLatency)
+	}
+	if l.inFlight < l.limit {
+		defer l.mu.Unlock()
+		return l.admit(false), nil
+	}
+	if l.queued >= l.settings.MaxQueue {
+		defer l.mu.Unlock()
+		return nil, l.reject(ReasonQueueFull)
+	}
+
+	l.queued++
+	queuedGauge.WithLabelValues(l.name).Set(float64(l.queued))
+	timeout := time.NewTimer(l.settings.QueueTimeout)
+	defer timeout.Stop()
+
+	for {
+		slotFreed := l.slotFreed
+		l.mu.Unlock()
+
+		timedOut := false
+		var ctxErr error
+		select {
+		case <-slotFreed:
+		case <-timeout.C:
+			timedOut = true
+		case <-ctx.Done():
+			ctxErr = ctx.Err()
+		}
+
+		l.mu.Lock()
+		switch {
+		case ctxErr != nil:
+			l.leaveQueue()
+			l.mu.Unlock()
+			return nil, ctxErr
+		case l.shedding:
+			err := l.rejectQueued(ReasonLatency)
+			l.mu.Unlock()
+			return nil, err
+		case l.inFlight < l.limit:
+			l.leaveQueue()
+			release := l.admit(true)
+			l.mu.Unlock()
+			return release, nil
+		case timedOut:
+			err := l.rejectQueued(ReasonQueueTimeout)
+			l.mu.Unlock()
+			return nil, err
+		}
+	}
+}
+
+// Observe feeds one probe of the dependency into the limit
+func (l *Limiter) Observe(latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err != nil {
+		l.probeErr = err.Error()
+		l.shedding = true
+		l.setLimit(l.limit / 2)
+		l.wake()
+		return
+	}
+	l.probeErr = ""
+
+	if l.latency == 0 {
+		l.latency = latency
+	} else {
+		l.latency = time.Duration(latencySmoothing*float64(latency) + (1-latencySmoothing)*float64(l.latency))
+	}
+	latencyGauge.WithLabelValues(l.name).Set(l.latency.Seconds())
+
+	l.shedding = l.latency >= l.settings.LatencyShed
+	switch {
+	case l.latency > l.settings.LatencyTarget:
+		l.setLimit(l.limit * 3 / 4)
+	case l.latency < l.settings.LatencyTarget/2:
+		l.setLimit(l.limit + 1)
+	}
+	if l.shedding {
+		// Queued jobs are rejected rather than left to time out
+		l.wake()
+	}
+}
+
+// StartProbe times probe every interval and feeds the result to Observe until
+// ctx is cancelled. Each probe is bounded by twice LatencyShed.
+func (l *Limiter) StartProbe(ctx context.Context, interval time.Duration, probe func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			probeCtx, cancel := context.WithTimeout(ctx, 2*l.settings.LatencyShed)
+			start := time.Now()
+			err := probe(probeCtx)
+			cancel()
+			if ctx.Err() != nil {
+				return
+			}
+			l.Observe(time.Since(start), err)
+		}
+	}
+}
+
+// Snapshot returns the limiter's current state and counters
+func (l *Limiter) Snapshot() Snapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return Snapshot{
+		Name:              l.name,
+		Limit:             l.limit,
+		InFlight:          l.inFlight,
+		Queued:            l.queued,
+		MaxQueue:          l.settings.MaxQueue,
+		LatencyMs:         float64(l.latency) / float64(time.Millisecond),
+		LatencyTargetMs:   float64(l.settings.LatencyTarget) / float64(time.Millisecond),
+		LatencyShedMs:     float64(l.settings.LatencyShed) / float64(time.Millisecond),
+		Shedding:          l.shedding,
+		Admitted:          l.admitted,
+		Delayed:           l.delayed,
+		Rejected:          l.rejected,
+		LastProbeError:    l.probeErr,
+		RetryAfterSeconds: int(l.settings.RetryAfter.Seconds()),
+	}
+}
+
+// admit takes a slot; callers must hold mu
+func (l *Limiter) admit(delayed bool) func() {
+	l.inFlight++
+	l.admitted++
+	inFlightGauge.WithLabelValues(l.name).Set(float64(l.inFlight))
+	admittedCounter.WithLabelValues(l.name).Inc()
+	if delayed {
+		l.delayed++
+		delayedCounter.WithLabelValues(l.name).Inc()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.inFlight--
+			inFlightGauge.WithLabelValues(l.name).Set(float64(l.inFlight))
+			l.wake()
+		})
+	}
+}
+
+// reject counts a rejection; callers must hold mu
+func (l *Limiter) reject(reason string) error {
+	l.rejected++
+	rejectedCounter.WithLabelValues(l.name, reason).Inc()
+	return &RejectedError{Reason: reason, RetryAfter: l.settings.RetryAfter}
+}
+
+// rejectQueued rejects a job that was waiting in the queue; callers must hold mu
+func (l *Limiter) rejectQueued(reason string) error {
+	l.leaveQueue()
+	return l.reject(reason)
+}
+
+// leaveQueue removes a waiting job from the queue; callers must hold mu
+func (l *Limiter) leaveQueue() {
+	l.queued--
+	queuedGauge.WithLabelValues(l.name).Set(float64(l.queued))
+}
+
+// setLimit clamps and applies a new limit; callers must hold mu
+func (l *Limiter) setLimit(limit int) {
+	if limit < l.settings.MinConcurrency {
+		limit = l.settings.MinConcurrency
+	}
+	if limit > l.settings.MaxConcurrency {
+		limit = l.settings.MaxConcurrency
+	}
+	if limit > l.limit {
+		l.wake()
+	}
+	l.limit = limit
+	limitGauge.WithLabelValues(l.name).Set(float64(limit))
+}
+
+// wake lets queued jobs re-check for a slot; callers must hold mu
+func (l *Limiter) wake() {
+	close(l.slotFreed)
+	l.slotFreed = make(chan struct{})
+}
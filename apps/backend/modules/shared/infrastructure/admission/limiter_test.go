@@ -0,0 +1,127 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestLimiter(max, queue int) *Limiter {
+	return New("test", Settings{
+		MinConcurrency: 1,
+		MaxConcurrency: max,
+		MaxQueue:       queue,
+		QueueTimeout:   50 * time.Millisecond,
+		LatencyTarget:  100 * time.Millisecond,
+		LatencyShed:    time.Second,
+		RetryAfter:     3 * time.Second,
+	})
+}
+
+func TestLimiterQueuesThenRejects(t *testing.T) {
+	l := newTestLimiter(1, 1)
+	l.settings.QueueTimeout = 5 * time.Second
+	ctx := context.Background()
+
+	release, err := l.Acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A second job waits for the slot and is admitted once it frees up
+	admitted := make(chan error, 1)
+	go func() {
+		release2, err := l.Acquire(ctx)
+		if err == nil {
+			release2()
+		}
+		admitted <- err
+	}()
+	for l.Snapshot().Queued == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The queue is full, so a third job is rejected immediately
+	_, err = l.Acquire(ctx)
+	var rejected *RejectedError
+	if !errors.As(err, &rejected) || rejected.Reason != ReasonQueueFull {
+		t.Fatalf("expected a queue_full rejection, got %v", err)
+	}
+	if !errors.Is(err, ErrOverloaded) || rejected.RetryAfter != 3*time.Second {
+		t.Errorf("unexpected rejection: %+v", rejected)
+	}
+
+	release()
+	release() // Releasing twice frees a single slot
+	if err := <-admitted; err != nil {
+		t.Fatalf("expected the queued job to be admitted, got %v", err)
+	}
+
+	snapshot := l.Snapshot()
+	if snapshot.InFlight != 0 || snapshot.Queued != 0 || snapshot.Admitted != 2 || snapshot.Delayed != 1 || snapshot.Rejected != 1 {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
+
+func TestLimiterQueueTimeout(t *testing.T) {
+	l := newTestLimiter(1, 1)
+	release, _ := l.Acquire(context.Background())
+	defer release()
+
+	var rejected *RejectedError
+	if _, err := l.Acquire(context.Background()); !errors.As(err, &rejected) || rejected.Reason != ReasonQueueTimeout {
+		t.Fatalf("expected a queue_timeout rejection, got %v", err)
+	}
+	if l.Snapshot().Queued != 0 {
+		t.Error("expected the timed out job to leave the queue")
+	}
+}
+
+func TestLimiterAdaptsToLatency(t *testing.T) {
+	l := newTestLimiter(8, 0)
+
+	l.Observe(400*time.Millisecond, nil)
+	if got := l.Snapshot().Limit; got != 6 {
+		t.Fatalf("expected a slow probe to shrink the limit to 6, got %d", got)
+	}
+
+	l.Observe(0, errors.New("connection refused"))
+	snapshot := l.Snapshot()
+	if snapshot.Limit != 3 || !snapshot.Shedding || snapshot.LastProbeError == "" {
+		t.Fatalf("expected a failed probe to halve the limit and shed, got %+v", snapshot)
+	}
+	var rejected *RejectedError
+	if _, err := l.Acquire(context.Background()); !errors.As(err, &rejected) || rejected.Reason != ReasonLatency {
+		t.Fatalf("expected a database_latency rejection while shedding, got %v", err)
+	}
+
+	// Fast probes bring the smoothed latency down and the limit back up
+	for i := 0; i < 20; i++ {
+		l.Observe(time.Millisecond, nil)
+	}
+	snapshot = l.Snapshot()
+	if snapshot.Limit != 8 || snapshot.Shedding {
+		t.Fatalf("expected the limit to recover to 8, got %+v", snapshot)
+	}
+	release, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestLimiterCancelledWhileQueued(t *testing.T) {
+	l := newTestLimiter(1, 1)
+	release, _ := l.Acquire(context.Background())
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if snapshot := l.Snapshot(); snapshot.Queued != 0 || snapshot.Rejected != 0 {
+		t.Errorf("a cancelled job should not count as rejected: %+v", snapshot)
+	}
+}
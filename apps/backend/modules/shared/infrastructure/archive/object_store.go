@@ -0,0 +1,216 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Supported archive storage providers
+const (
+	ProviderS3    = "s3"
+	ProviderGCS   = "gcs"
+	ProviderLocal = "local"
+)
+
+// gcsEndpoint is the S3-compatible XML API endpoint of Google Cloud Storage
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// ErrPresignUnsupported is returned by stores that cannot issue signed download URLs
+var ErrPresignUnsupported = errors.New("object store does not support signed URLs")
+
+// ObjectStore stores archive objects in a bucket
+type ObjectStore interface {
+	Provider() string
+	Bucket() string
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	// PresignGet returns a time-limited URL that downloads the object as fileName
+	PresignGet(ctx context.Context, key, fileName string, ttl time.Duration) (string, error)
+}
+
+// NewObjectStore creates the object store selected by the archive configuration
+func NewObjectStore(cfg config.ArchiveConfig) (ObjectStore, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case ProviderS3:
+		return newS3Store(ProviderS3, cfg, cfg.Endpoint)
+	case ProviderGCS:
+		// GCS is accessed through its S3 interoperability API using HMAC keys
+		endpoint := cfg.Endpoint
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		return newS3Store(ProviderGCS, cfg, endpoint)
+	case ProviderLocal:
+		if cfg.LocalPath == "" {
+			return nil, fmt.Errorf("archive local path is required for the local provider")
+		}
+		return &LocalStore{root: cfg.LocalPath, bucket: cfg.Bucket}, nil
+	default:
+		return nil, fmt.Errorf("unsupported archive provider: %s", cfg.Provider)
+	}
+}
+
+// S3Store stores archives in S3 or an S3-compatible service
+type S3Store struct {
+	provider string
+	client   *s3.S3
+	bucket   string
+}
+
+func newS3Store(provider string, cfg config.ArchiveConfig, endpoint string) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("archive bucket is required for the %s provider", provider)
+	}
+
+	awsConfig := &aws.Config{Region: aws.String(cfg.Region)}
+	if endpoint != "" {
+		awsConfig.Endpoint = aws.String(endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+	// Without static keys the default AWS credential chain is used
+	if cfg.AccessKey != "" && cfg.SecretKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, cfg.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	return &S3Store{provider: provider, client: s3.New(sess), bucket: cfg.Bucket}, nil
+}
+
+// Provider returns the storage provider name
+func (s *S3Store) Provider() string { return s.provider }
+
+// Bucket returns the bucket archives are written to
+func (s *S3Store) Bucket() string { return s.bucket }
+
+// Put uploads an object
+func (s *S3Store) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get downloads an object
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	result, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer result.Body.Close()
+
+	return io.ReadAll(result.Body)
+}
+
+// Delete removes an object; deleting a missing object succeeds
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+// PresignGet returns a signed GET URL valid for ttl
+func (s *S3Store) PresignGet(ctx context.Context, key, fileName string, ttl time.Duration) (string, error) {
+	req, _ := s.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(s.bucket),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(fmt.Sprintf("attachment; filename=%q", fileName)),
+	})
+	req.SetContext(ctx)
+
+	url, err := req.Presign(ttl)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign download URL for %s: %w", key, err)
+	}
+	return url, nil
+}
+
+// LocalStore keeps archives on the local filesystem (development and air-gapped installs)
+type LocalStore struct {
+	root   string
+	bucket string
+}
+
+// Provider returns the storage provider name
+func (s *LocalStore) Provider() string { return ProviderLocal }
+
+// Bucket returns the directory name archives are written to
+func (s *LocalStore) Bucket() string { return s.bucket }
+
+// Put writes an object below the archive root
+func (s *LocalStore) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	return os.WriteFile(path, body, 0o640)
+}
+
+// Get reads an object below the archive root
+func (s *LocalStore) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// Delete removes an object below the archive root; deleting a missing object succeeds
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// PresignGet is not supported on the local filesystem
+func (s *LocalStore) PresignGet(ctx context.Context, key, fileName string, ttl time.Duration) (string, error) {
+	return "", ErrPresignUnsupported
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	base := filepath.Join(s.root, s.bucket)
+	path := filepath.Join(base, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, filepath.Clean(base)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid archive key: %s", key)
+	}
+	return path, nil
+}
@@ -0,0 +1,119 @@
+package auditexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// Supported event payload formats
+const (
+	FormatCEF  = "cef"
+	FormatJSON = "json"
+)
+
+// CEF header values identifying ARC-Hawk as the event source
+const (
+	cefVendor  = "ARC-Hawk"
+	cefProduct = "ARC-Hawk Backend"
+	cefVersion = "1.0"
+)
+
+// FormatEvent renders an audit event in the requested format
+func FormatEvent(format string, event *entity.AuditEvent) (string, error) {
+	switch format {
+	case FormatCEF:
+		return FormatCEFEvent(event), nil
+	case FormatJSON:
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return "", err
+		}
+		return string(payload), nil
+	default:
+		return "", fmt.Errorf("unsupported audit export format: %s", format)
+	}
+}
+
+// FormatCEFEvent renders an audit event as an ArcSight Common Event Format (CEF:0) record
+func FormatCEFEvent(event *entity.AuditEvent) string {
+	ext := []string{
+		"rt=" + fmt.Sprint(event.EventTime.UnixMilli()),
+		"externalId=" + cefExtension(event.ID.String()),
+		"act=" + cefExtension(event.Action),
+	}
+	if event.UserID != "" {
+		ext = append(ext, "suser="+cefExtension(event.UserID))
+	}
+	if event.IPAddress != "" {
+		ext = append(ext, "src="+cefExtension(event.IPAddress))
+	}
+	if event.UserAgent != "" {
+		ext = append(ext, "requestClientApplication="+cefExtension(event.UserAgent))
+	}
+	if event.Result != "" {
+		ext = append(ext, "outcome="+cefExtension(event.Result))
+	}
+	if event.ResourceType != "" {
+		ext = append(ext, "cs1Label=resourceType", "cs1="+cefExtension(event.ResourceType))
+	}
+	if event.ResourceID != "" {
+		ext = append(ext, "cs2Label=resourceId", "cs2="+cefExtension(event.ResourceID))
+	}
+	if event.TenantID != nil {
+		ext = append(ext, "cs3Label=tenantId", "cs3="+cefExtension(event.TenantID.String()))
+	}
+	if len(event.Metadata) > 0 {
+		if metadata, err := json.Marshal(event.Metadata); err == nil {
+			ext = append(ext, "cs4Label=metadata", "cs4="+cefExtension(string(metadata)))
+		}
+	}
+
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s",
+		cefHeader(cefVendor), cefHeader(cefProduct), cefHeader(cefVersion),
+		cefHeader(event.Action), cefHeader(cefName(event)), cefSeverity(event),
+		strings.Join(ext, " "))
+}
+
+// cefHeader escapes a CEF header field (backslash and pipe)
+func cefHeader(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return stripNewlines(value)
+}
+
+// cefExtension escapes a CEF extension value (backslash, equals and newlines)
+func cefExtension(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "=", `\=`)
+	value = strings.ReplaceAll(value, "\r\n", `\n`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return strings.ReplaceAll(value, "\r", `\n`)
+}
+
+func stripNewlines(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
+
+func cefName(event *entity.AuditEvent) string {
+	name := strings.ToLower(strings.ReplaceAll(event.Action, "_", " "))
+	if event.ResourceType != "" {
+		name += " (" + event.ResourceType + ")"
+	}
+	return name
+}
+
+// cefSeverity maps audit outcomes and destructive actions to the CEF 0-10 scale
+func cefSeverity(event *entity.AuditEvent) int {
+	action := strings.ToUpper(event.Action)
+	switch {
+	case strings.EqualFold(event.Result, "FAILED"), strings.Contains(action, "FAIL"), strings.Contains(action, "DENIED"):
+		return 7
+	case strings.Contains(action, "DELETE"), strings.Contains(action, "REMEDIAT"), strings.Contains(action, "ARCHIV"):
+		return 5
+	default:
+		return 3
+	}
+}
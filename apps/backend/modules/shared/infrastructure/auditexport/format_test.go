@@ -0,0 +1,75 @@
+package auditexport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+func testEvent() *entity.AuditEvent {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &entity.AuditEvent{
+		ID:           uuid.MustParse("11111111-2222-3333-4444-555555555555"),
+		Action:       "FINDING_DELETED",
+		UserID:       "alice",
+		ResourceType: "finding",
+		ResourceID:   "a=b",
+		IPAddress:    "10.0.0.1",
+		Result:       "SUCCESS",
+		Metadata:     map[string]interface{}{"note": "line1\nline2"},
+		EventTime:    created,
+		CreatedAt:    created,
+	}
+}
+
+func TestFormatCEFEvent(t *testing.T) {
+	got := FormatCEFEvent(testEvent())
+
+	wantPrefix := "CEF:0|ARC-Hawk|ARC-Hawk Backend|1.0|FINDING_DELETED|finding deleted (finding)|5|"
+	if !strings.HasPrefix(got, wantPrefix) {
+		t.Fatalf("unexpected CEF header:\n got %q\nwant prefix %q", got, wantPrefix)
+	}
+
+	for _, want := range []string{
+		"rt=1772366400000",
+		"suser=alice",
+		"src=10.0.0.1",
+		"outcome=SUCCESS",
+		`cs2=a\=b`,
+		`cs4={"note":"line1\\nline2"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("CEF record missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "\n") {
+		t.Errorf("CEF record must be a single line: %q", got)
+	}
+}
+
+func TestCEFHeaderEscaping(t *testing.T) {
+	event := testEvent()
+	event.Action = `EXPORT|RUN\X`
+
+	got := FormatCEFEvent(event)
+	if !strings.Contains(got, `|EXPORT\|RUN\\X|`) {
+		t.Errorf("header pipes and backslashes not escaped: %q", got)
+	}
+}
+
+func TestFormatSyslogMessage(t *testing.T) {
+	got := FormatSyslogMessage("host1", testEvent(), "payload")
+	want := "<86>1 2026-03-01T12:00:00Z host1 arc-hawk - FINDING_DELETED - payload"
+	if got != want {
+		t.Errorf("FormatSyslogMessage() = %q, want %q", got, want)
+	}
+
+	failed := testEvent()
+	failed.Result = "FAILED"
+	if got := FormatSyslogMessage("host1", failed, "payload"); !strings.HasPrefix(got, "<84>1 ") {
+		t.Errorf("failed events should use warning severity: %q", got)
+	}
+}
@@ -0,0 +1,267 @@
+package auditexport
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// Sink delivers batches of audit events to an external collector
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, events []*entity.AuditEvent) error
+	Close() error
+}
+
+// NewSinks builds the sinks enabled by configuration
+func NewSinks(cfg config.AuditExportConfig) ([]Sink, error) {
+	var sinks []Sink
+
+	if cfg.SyslogAddress != "" {
+		sink, err := NewSyslogSink(cfg.SyslogNetwork, cfg.SyslogAddress, cfg.Format)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if cfg.HTTPEndpoint != "" {
+		sinks = append(sinks, NewHTTPSink(cfg.HTTPEndpoint, cfg.HTTPToken, cfg.Format, time.Duration(cfg.HTTPTimeoutSeconds)*time.Second))
+	}
+
+	return sinks, nil
+}
+
+// ============================================================================
+// Syslog
+// ============================================================================
+
+// SyslogSink forwards events as RFC 5424 syslog messages over udp, tcp or tcp+tls.
+// The connection is dialled lazily and re-established after a write failure.
+type SyslogSink struct {
+	network  string
+	address  string
+	format   string
+	hostname string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink validates the network and returns a syslog sink
+func NewSyslogSink(network, address, format string) (*SyslogSink, error) {
+	switch network {
+	case "udp", "tcp", "tcp+tls":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network: %s", network)
+	}
+	if format != FormatCEF && format != FormatJSON {
+		return nil, fmt.Errorf("unsupported audit export format: %s", format)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	return &SyslogSink{network: network, address: address, format: format, hostname: hostname}, nil
+}
+
+// Name identifies the sink in export cursors
+func (s *SyslogSink) Name() string {
+	return "syslog"
+}
+
+// Send writes one syslog message per event
+func (s *SyslogSink) Send(ctx context.Context, events []*entity.AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog %s: %w", s.address, err)
+		}
+		s.conn = conn
+	}
+
+	for _, event := range events {
+		payload, err := FormatEvent(s.format, event)
+		if err != nil {
+			return err
+		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = s.conn.SetWriteDeadline(deadline)
+		}
+		if _, err := s.conn.Write(s.frame(event, payload)); err != nil {
+			s.conn.Close()
+			s.conn = nil
+			return fmt.Errorf("failed to write to syslog %s: %w", s.address, err)
+		}
+	}
+
+	return nil
+}
+
+// Close releases the syslog connection
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if s.network == "tcp+tls" {
+		host, _, err := net.SplitHostPort(s.address)
+		if err != nil {
+			return nil, err
+		}
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		return tlsDialer.DialContext(ctx, "tcp", s.address)
+	}
+	return dialer.DialContext(ctx, s.network, s.address)
+}
+
+// frame builds an RFC 5424 message. Stream transports use octet-counting framing (RFC 6587).
+func (s *SyslogSink) frame(event *entity.AuditEvent, payload string) []byte {
+	msg := FormatSyslogMessage(s.hostname, event, payload)
+	if s.network == "udp" {
+		return []byte(msg)
+	}
+	return []byte(fmt.Sprintf("%d %s", len(msg), msg))
+}
+
+// FormatSyslogMessage renders an RFC 5424 header (facility authpriv) followed by the payload
+func FormatSyslogMessage(hostname string, event *entity.AuditEvent, payload string) string {
+	const facilityAuthPriv = 10
+	severity := 6 // informational
+	if cefSeverity(event) >= 7 {
+		severity = 4 // warning
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s arc-hawk - %s - %s",
+		facilityAuthPriv*8+severity,
+		event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		hostname,
+		syslogMsgID(event.Action),
+		payload)
+}
+
+// syslogMsgID trims an action to the printable, 32-character MSGID field
+func syslogMsgID(action string) string {
+	id := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, action)
+	if id == "" {
+		return "-"
+	}
+	if len(id) > 32 {
+		id = id[:32]
+	}
+	return id
+}
+
+// ============================================================================
+// HTTPS collector
+// ============================================================================
+
+// HTTPSink posts batches to an HTTPS collector. JSON batches are sent as
+// newline-delimited JSON; CEF batches as newline-delimited plain text.
+type HTTPSink struct {
+	endpoint string
+	token    string
+	format   string
+	client   *http.Client
+}
+
+// NewHTTPSink returns a collector sink; a zero timeout defaults to 30 seconds
+func NewHTTPSink(endpoint, token, format string, timeout time.Duration) *HTTPSink {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &HTTPSink{
+		endpoint: endpoint,
+		token:    token,
+		format:   format,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Name identifies the sink in export cursors
+func (s *HTTPSink) Name() string {
+	return "https"
+}
+
+// Send posts the whole batch in a single request
+func (s *HTTPSink) Send(ctx context.Context, events []*entity.AuditEvent) error {
+	var body bytes.Buffer
+	for _, event := range events {
+		var line string
+		var err error
+		if s.format == FormatCEF {
+			line = FormatCEFEvent(event)
+		} else {
+			var payload []byte
+			payload, err = json.Marshal(event)
+			line = string(payload)
+		}
+		if err != nil {
+			return err
+		}
+		body.WriteString(line)
+		body.WriteByte('\n')
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	if s.format == FormatCEF {
+		req.Header.Set("Content-Type", "text/plain")
+	} else {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post audit events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("audit collector returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// Close is a no-op; HTTP connections are pooled by the client
+func (s *HTTPSink) Close() error {
+	return nil
+}
@@ -0,0 +1,222 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// State is the position of a circuit breaker
+type State string
+
+const (
+	// StateClosed lets every call through and counts consecutive failures
+	StateClosed State = "closed"
+	// StateOpen rejects calls until the cool-down has elapsed
+	StateOpen State = "open"
+	// StateHalfOpen lets a single probe call through to test recovery
+	StateHalfOpen State = "half_open"
+)
+
+// ErrOpen is returned instead of calling a dependency whose breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// Settings configures when a breaker trips and how long it stays open
+type Settings struct {
+	FailureThreshold int           // Consecutive failures that open the breaker
+	Cooldown         time.Duration // Time spent open before a probe is allowed
+	// IsFailure decides whether an error counts against the dependency.
+	// Defaults to every error; context cancellation by the caller never counts.
+	IsFailure func(error) bool
+}
+
+// Snapshot is a point-in-time view of a breaker, suitable for health reporting
+type Snapshot struct {
+	Name                string     `json:"name"`
+	State               State      `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	FailureThreshold    int        `json:"failure_threshold"`
+	CooldownSeconds     float64    `json:"cooldown_seconds"`
+	Trips               int64      `json:"trips"`
+	Rejected            int64      `json:"rejected"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// Breaker stops calls to a failing dependency so callers fail fast instead of
+// each waiting for a timeout. It is safe for concurrent use.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+	isFailure func(error) bool
+	onChange  func(name string, from, to State)
+	now       func() time.Time
+
+	mu            sync.Mutex
+	state         State
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+	trips         int64
+	rejected      int64
+	lastError     string
+}
+
+// New creates a closed breaker
+func New(name string, settings Settings) *Breaker {
+	if settings.FailureThreshold < 1 {
+		settings.FailureThreshold = 5
+	}
+	if settings.Cooldown <= 0 {
+		settings.Cooldown = 30 * time.Second
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(error) bool { return true }
+	}
+
+	return &Breaker{
+		name:      name,
+		threshold: settings.FailureThreshold,
+		cooldown:  settings.Cooldown,
+		isFailure: settings.IsFailure,
+		now:       time.Now,
+		state:     StateClosed,
+	}
+}
+
+// OnStateChange registers a callback invoked after every state transition
+func (b *Breaker) OnStateChange(fn func(name string, from, to State)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onChange = fn
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be
+// followed by exactly one Record with the call's outcome.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			b.rejected++
+			return ErrOpen
+		}
+		b.transition(StateHalfOpen)
+		b.probeInFlight = true
+		return nil
+	case StateHalfOpen:
+		if b.probeInFlight {
+			b.rejected++
+			return ErrOpen
+		}
+		b.probeInFlight = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record reports the outcome of a call admitted by Allow
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case err != nil && errors.Is(err, context.Canceled):
+		// The caller gave up; this says nothing about the dependency
+		b.probeInFlight = false
+	case err != nil && b.isFailure(err):
+		b.failures++
+		b.lastError = err.Error()
+		b.probeInFlight = false
+		if b.state == StateHalfOpen || b.failures >= b.threshold {
+			b.open()
+		}
+	default:
+		b.failures = 0
+		b.probeInFlight = false
+		if b.state != StateClosed {
+			b.transition(StateClosed)
+		}
+	}
+}
+
+// Execute runs fn if the breaker allows it and records the outcome
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// Ready reports whether a call would currently be allowed, without admitting one
+func (b *Breaker) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		return b.now().Sub(b.openedAt) >= b.cooldown
+	case StateHalfOpen:
+		return !b.probeInFlight
+	default:
+		return true
+	}
+}
+
+// State returns the current breaker state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Snapshot returns the breaker's current state and counters
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := Snapshot{
+		Name:                b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		FailureThreshold:    b.threshold,
+		CooldownSeconds:     b.cooldown.Seconds(),
+		Trips:               b.trips,
+		Rejected:            b.rejected,
+		LastError:           b.lastError,
+	}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		retryAt := openedAt.Add(b.cooldown)
+		snapshot.OpenedAt = &openedAt
+		snapshot.RetryAt = &retryAt
+	}
+	return snapshot
+}
+
+// open trips the breaker; callers must hold mu
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.trips++
+	b.transition(StateOpen)
+}
+
+// transition changes state and notifies the listener; callers must hold mu
+func (b *Breaker) transition(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	if b.onChange != nil {
+		b.onChange(b.name, from, to)
+	}
+}
@@ -0,0 +1,139 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errUnavailable = errors.New("connection refused")
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*Breaker, *time.Time) {
+	clock := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	b := New("neo4j", Settings{FailureThreshold: threshold, Cooldown: cooldown})
+	b.now = func() time.Time { return clock }
+	return b, &clock
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		_ = b.Execute(func() error { return errUnavailable })
+	}
+	if b.State() != StateClosed {
+		t.Fatalf("expected closed below the threshold, got %s", b.State())
+	}
+
+	_ = b.Execute(func() error { return errUnavailable })
+	if b.State() != StateOpen {
+		t.Fatalf("expected open at the threshold, got %s", b.State())
+	}
+
+	called := false
+	err := b.Execute(func() error { called = true; return nil })
+	if !errors.Is(err, ErrOpen) || called {
+		t.Fatalf("expected an open breaker to reject without calling, got err=%v called=%v", err, called)
+	}
+
+	snapshot := b.Snapshot()
+	if snapshot.Trips != 1 || snapshot.Rejected != 1 || snapshot.LastError != errUnavailable.Error() {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(2, time.Minute)
+
+	_ = b.Execute(func() error { return errUnavailable })
+	_ = b.Execute(func() error { return nil })
+	_ = b.Execute(func() error { return errUnavailable })
+
+	if b.State() != StateClosed {
+		t.Errorf("expected non-consecutive failures to keep the breaker closed, got %s", b.State())
+	}
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	b, clock := newTestBreaker(1, 30*time.Second)
+	_ = b.Execute(func() error { return errUnavailable })
+
+	if b.Ready() {
+		t.Fatal("expected the breaker not to be ready during the cool-down")
+	}
+	*clock = clock.Add(30 * time.Second)
+	if !b.Ready() {
+		t.Fatal("expected the breaker to be ready after the cool-down")
+	}
+
+	// Only one probe is admitted while half-open
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected the probe to be allowed, got %v", err)
+	}
+	if b.State() != StateHalfOpen {
+		t.Fatalf("expected half-open, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected a second concurrent probe to be rejected, got %v", err)
+	}
+
+	// A failed probe re-opens for another full cool-down
+	b.Record(errUnavailable)
+	if b.State() != StateOpen || b.Ready() {
+		t.Fatalf("expected a failed probe to re-open the breaker, got %s", b.State())
+	}
+
+	*clock = clock.Add(30 * time.Second)
+	if err := b.Execute(func() error { return nil }); err != nil {
+		t.Fatalf("expected the probe to succeed, got %v", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("expected a successful probe to close the breaker, got %s", b.State())
+	}
+	if b.Snapshot().Trips != 2 {
+		t.Errorf("expected 2 trips, got %d", b.Snapshot().Trips)
+	}
+}
+
+func TestBreakerIgnoresCallerCancellationAndClassifiedErrors(t *testing.T) {
+	errSyntax := errors.New("syntax error")
+	b := New("neo4j", Settings{
+		FailureThreshold: 1,
+		IsFailure:        func(err error) bool { return !errors.Is(err, errSyntax) },
+	})
+
+	_ = b.Execute(func() error { return context.Canceled })
+	_ = b.Execute(func() error { return errSyntax })
+	if b.State() != StateClosed {
+		t.Fatalf("expected cancellations and non-failures to keep the breaker closed, got %s", b.State())
+	}
+
+	_ = b.Execute(func() error { return context.DeadlineExceeded })
+	if b.State() != StateOpen {
+		t.Errorf("expected a timeout to count as a failure, got %s", b.State())
+	}
+}
+
+func TestBreakerReportsTransitions(t *testing.T) {
+	b, clock := newTestBreaker(1, time.Second)
+
+	var transitions []string
+	b.OnStateChange(func(name string, from, to State) {
+		transitions = append(transitions, string(from)+"->"+string(to))
+	})
+
+	_ = b.Execute(func() error { return errUnavailable })
+	*clock = clock.Add(time.Second)
+	_ = b.Execute(func() error { return nil })
+
+	want := []string{"closed->open", "open->half_open", "half_open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transition %d: expected %s, got %s", i, want[i], transitions[i])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package clickhouse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxErrorBody bounds how much of a failed response is kept in the error
+const maxErrorBody = 1024
+
+// Client talks to ClickHouse over its HTTP interface, so no native driver is
+// needed. Queries take {name:Type} placeholders bound from params and name
+// tables with their database.
+type Client struct {
+	baseURL  string
+	user     string
+	password string
+	http     *http.Client
+}
+
+// New creates a client for the HTTP interface at baseURL, e.g. http://clickhouse:8123
+func New(baseURL, user, password string) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		user:     user,
+		password: password,
+		http:     &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+// Exec runs a statement that returns no rows
+func (c *Client) Exec(ctx context.Context, query string, params map[string]string) error {
+	return c.do(ctx, query, bindParams(params), nil, nil)
+}
+
+// InsertJSONEachRow inserts rows, each marshalled to one JSON object, into table
+func (c *Client) InsertJSONEachRow(ctx context.Context, table string, rows []interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, row := range rows {
+		if err := enc.Encode(row); err != nil {
+			return fmt.Errorf("clickhouse: failed to encode row: %w", err)
+		}
+	}
+	settings := map[string]string{"date_time_input_format": "best_effort"}
+	return c.do(ctx, "INSERT INTO "+table+" FORMAT JSONEachRow", settings, &body, nil)
+}
+
+// Query runs a SELECT and decodes its rows into dest, a pointer to a slice of
+// structs tagged with the selected column names
+func (c *Client) Query(ctx context.Context, query string, params map[string]string, dest interface{}) error {
+	var result struct {
+		Data json.RawMessage `json:"data"`
+	}
+	settings := bindParams(params)
+	// Counts are UInt64, which ClickHouse quotes in JSON by default
+	settings["output_format_json_quote_64bit_integers"] = "0"
+	if err := c.do(ctx, query+" FORMAT JSON", settings, nil, &result); err != nil {
+		return err
+	}
+	if len(result.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(result.Data, dest); err != nil {
+		return fmt.Errorf("clickhouse: failed to decode rows: %w", err)
+	}
+	return nil
+}
+
+// bindParams names query parameters the way the HTTP interface expects them
+func bindParams(params map[string]string) map[string]string {
+	settings := make(map[string]string, len(params)+1)
+	for name, value := range params {
+		settings["param_"+name] = value
+	}
+	return settings
+}
+
+// do sends query, in the URL when there is a body and as the body otherwise
+func (c *Client) do(ctx context.Context, query string, settings map[string]string, body io.Reader, dest interface{}) error {
+	values := url.Values{}
+	for name, value := range settings {
+		values.Set(name, value)
+	}
+	if body == nil {
+		body = strings.NewReader(query)
+	} else {
+		values.Set("query", query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/?"+values.Encode(), body)
+	if err != nil {
+		return fmt.Errorf("clickhouse: %w", err)
+	}
+	if c.user != "" {
+		req.Header.Set("X-ClickHouse-User", c.user)
+		req.Header.Set("X-ClickHouse-Key", c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("clickhouse: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return fmt.Errorf("clickhouse: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if dest == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("clickhouse: failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package clickhouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	var lastQuery, lastBody string
+	var lastValues map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-ClickHouse-User") != "arc" || r.Header.Get("X-ClickHouse-Key") != "secret" {
+			t.Errorf("expected credentials in headers, got %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		lastValues = r.URL.Query()
+		lastQuery, lastBody = r.URL.Query().Get("query"), string(body)
+		switch {
+		case strings.Contains(lastBody, "broken"):
+			http.Error(w, "Code: 62. DB::Exception: Syntax error", http.StatusBadRequest)
+		case strings.HasSuffix(lastBody, "FORMAT JSON"):
+			w.Write([]byte(`{"meta": [], "data": [{"day": "2026-03-01", "findings": 7}], "rows": 1}`))
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/", "arc", "secret")
+	ctx := context.Background()
+
+	// Queries travel in the body with their parameters bound in the URL
+	var rows []struct {
+		Day      string `json:"day"`
+		Findings int    `json:"findings"`
+	}
+	if err := c.Query(ctx, "SELECT day, count() AS findings FROM db.t WHERE tenant_id = {tenant:UUID}", map[string]string{"tenant": "t-1"}, &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Findings != 7 {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+	if lastValues["param_tenant"][0] != "t-1" || lastValues["output_format_json_quote_64bit_integers"][0] != "0" {
+		t.Errorf("expected bound parameters, got %v", lastValues)
+	}
+
+	// Inserts send the statement in the URL and one JSON object per line
+	if err := c.InsertJSONEachRow(ctx, "db.t", []interface{}{map[string]int{"a": 1}, map[string]int{"a": 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if lastQuery != "INSERT INTO db.t FORMAT JSONEachRow" || lastBody != "{\"a\":1}\n{\"a\":2}\n" {
+		t.Errorf("unexpected insert %q with body %q", lastQuery, lastBody)
+	}
+
+	// Server errors carry ClickHouse's message
+	if err := c.Exec(ctx, "broken", nil); err == nil || !strings.Contains(err.Error(), "Syntax error") {
+		t.Errorf("expected the server error, got %v", err)
+	}
+}
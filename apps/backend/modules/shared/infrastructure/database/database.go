@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strconv"
 
 	_ "github.com/lib/pq"
 )
@@ -16,6 +17,10 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// Session statement_timeout for every connection in milliseconds; 0 keeps the
+	// server default. Query classes set their own, tighter timeouts on top.
+	StatementTimeoutMs int
 }
 
 // NewConfig creates a new database configuration from environment variables
@@ -27,17 +32,27 @@ func NewConfig() *Config {
 		Password: getEnv("DB_PASSWORD", ""),
 		DBName:   getEnv("DB_NAME", "arc_platform"),
 		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+		StatementTimeoutMs: getEnvInt("DB_STATEMENT_TIMEOUT_MS", 0),
 	}
 }
 
-// Connect establishes a connection to the database
-func Connect(config *Config) (*sql.DB, error) {
+// DSN returns the lib/pq connection string for this configuration
+func (c *Config) DSN() string {
 	dsn := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode,
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode,
 	)
+	// lib/pq passes unknown keys to the server as run-time parameters
+	if c.StatementTimeoutMs > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", c.StatementTimeoutMs)
+	}
+	return dsn
+}
 
-	db, err := sql.Open("postgres", dsn)
+// Connect establishes a connection to the database
+func Connect(config *Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", config.DSN())
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -61,3 +76,11 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt retrieves an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,230 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// notifyChannel is the Postgres channel shared by every backend replica
+const notifyChannel = "arc_live_events"
+
+// maxNotifyPayload stays below Postgres' 8000 byte NOTIFY payload limit
+const maxNotifyPayload = 7900
+
+// Event is a live update delivered to subscribers of one tenant
+type Event struct {
+	ID        string          `json:"id"`
+	TenantID  uuid.UUID       `json:"tenant_id"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Subscription receives the events of a single tenant
+type Subscription struct {
+	tenantID uuid.UUID
+	types    map[string]bool
+	events   chan Event
+	dropped  atomic.Int64
+	bus      *Bus
+}
+
+// Events returns the channel events are delivered on
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns how many events were discarded because the subscriber fell behind
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close removes the subscription from the bus
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+// Bus fans live events out to subscribers. With a database attached, events are
+// published through Postgres NOTIFY so that subscribers on every replica receive
+// them; otherwise, or while the listener is down, they are delivered in-process only.
+type Bus struct {
+	db         *sql.DB
+	bufferSize int
+	listening  atomic.Bool
+
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+// NewBus creates an event bus. db may be nil for single-replica, in-process delivery.
+func NewBus(db *sql.DB, bufferSize int) *Bus {
+	if bufferSize < 1 {
+		bufferSize = 64
+	}
+	return &Bus{
+		db:         db,
+		bufferSize: bufferSize,
+		subs:       make(map[*Subscription]struct{}),
+	}
+}
+
+// Listen subscribes to the shared Postgres channel and dispatches received events
+// until ctx is cancelled. The listener reconnects on its own after connection loss.
+func (b *Bus) Listen(ctx context.Context, dsn string) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+			b.listening.Store(true)
+		case pq.ListenerEventDisconnected, pq.ListenerEventConnectionAttemptFailed:
+			b.listening.Store(false)
+			if err != nil {
+				log.Printf("⚠️  Event bus listener disconnected: %v", err)
+			}
+		}
+	})
+
+	if err := listener.Listen(notifyChannel); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to listen on %s: %w", notifyChannel, err)
+	}
+	b.listening.Store(true)
+
+	go func() {
+		defer listener.Close()
+		ping := time.NewTicker(90 * time.Second)
+		defer ping.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				b.listening.Store(false)
+				return
+			case n := <-listener.Notify:
+				// A nil notification signals a reconnect; events sent meanwhile are lost
+				if n == nil {
+					continue
+				}
+				var event Event
+				if err := json.Unmarshal([]byte(n.Extra), &event); err != nil {
+					log.Printf("WARN: Dropping malformed live event: %v", err)
+					continue
+				}
+				b.dispatch(event)
+			case <-ping.C:
+				go listener.Ping()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Publish sends an event to the subscribers of the tenant in ctx
+func (b *Bus) Publish(ctx context.Context, eventType string, data interface{}) {
+	tenantID, err := persistence.GetTenantID(ctx)
+	if err != nil {
+		tenantID = uuid.Nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("WARN: Failed to encode %s event: %v", eventType, err)
+		return
+	}
+
+	event := Event{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Type:      eventType,
+		Data:      payload,
+		Timestamp: time.Now().UTC(),
+	}
+
+	if b.db != nil && b.listening.Load() {
+		message, err := json.Marshal(event)
+		if err == nil && len(message) <= maxNotifyPayload {
+			// The publisher's request may finish before the notify is sent
+			notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+			defer cancel()
+
+			_, err = b.db.ExecContext(notifyCtx, `SELECT pg_notify($1, $2)`, notifyChannel, string(message))
+			if err == nil {
+				return
+			}
+			log.Printf("WARN: Failed to publish %s event to other replicas: %v", eventType, err)
+		} else if err == nil {
+			log.Printf("WARN: %s event too large for NOTIFY (%d bytes); delivering locally only", eventType, len(message))
+		}
+	}
+
+	b.dispatch(event)
+}
+
+// Subscribe registers a subscriber for a tenant. An empty types list receives every event type.
+func (b *Bus) Subscribe(tenantID uuid.UUID, types []string) *Subscription {
+	sub := &Subscription{
+		tenantID: tenantID,
+		events:   make(chan Event, b.bufferSize),
+		bus:      b,
+	}
+	if len(types) > 0 {
+		sub.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			sub.types[t] = true
+		}
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+// SubscriberCount returns the number of active subscriptions on this replica
+func (b *Bus) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+func (b *Bus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+// dispatch delivers an event to matching local subscribers without blocking;
+// a subscriber whose buffer is full misses the event
+func (b *Bus) dispatch(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs {
+		if sub.tenantID != event.TenantID {
+			continue
+		}
+		if sub.types != nil && !sub.types[event.Type] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func tenantContext(tenantID uuid.UUID) context.Context {
+	return context.WithValue(context.Background(), "tenant_id", tenantID)
+}
+
+func TestBusDeliversPerTenant(t *testing.T) {
+	bus := NewBus(nil, 4)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	subA := bus.Subscribe(tenantA, nil)
+	defer subA.Close()
+	subB := bus.Subscribe(tenantB, nil)
+	defer subB.Close()
+
+	bus.Publish(tenantContext(tenantA), "scan_progress", map[string]int{"progress": 50})
+
+	select {
+	case event := <-subA.Events():
+		if event.Type != "scan_progress" || event.TenantID != tenantA || string(event.Data) != `{"progress":50}` {
+			t.Errorf("unexpected event: %+v", event)
+		}
+	default:
+		t.Fatal("tenant A subscriber did not receive the event")
+	}
+
+	select {
+	case event := <-subB.Events():
+		t.Errorf("tenant B subscriber received another tenant's event: %+v", event)
+	default:
+	}
+}
+
+func TestBusTypeFilterAndOverflow(t *testing.T) {
+	bus := NewBus(nil, 1)
+	tenant := uuid.New()
+	ctx := tenantContext(tenant)
+
+	sub := bus.Subscribe(tenant, []string{"critical_finding"})
+
+	bus.Publish(ctx, "scan_progress", nil)
+	bus.Publish(ctx, "critical_finding", nil)
+	bus.Publish(ctx, "critical_finding", nil) // buffer of one is full
+
+	if got := len(sub.Events()); got != 1 {
+		t.Fatalf("expected 1 buffered event, got %d", got)
+	}
+	if sub.Dropped() != 1 {
+		t.Errorf("expected 1 dropped event, got %d", sub.Dropped())
+	}
+
+	sub.Close()
+	if bus.SubscriberCount() != 0 {
+		t.Errorf("expected no subscribers after Close, got %d", bus.SubscriberCount())
+	}
+	<-sub.Events()
+	if _, ok := <-sub.Events(); ok {
+		t.Error("expected the events channel to be closed")
+	}
+}
@@ -0,0 +1,308 @@
+// Package jobs runs background work persisted in the jobs table. Modules register a
+// handler per job type when they initialize and enqueue jobs from anywhere; every
+// replica claims due jobs, so a job runs once whichever replica enqueued it.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrJobNotFound is returned when the tenant has no job with the ID
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobState is returned when a job cannot be retried or cancelled in its current state
+	ErrJobState = errors.New("job cannot be changed in its current state")
+	// ErrUnknownJobType is returned when enqueueing a type no handler is registered for
+	ErrUnknownJobType = errors.New("no handler registered for job type")
+)
+
+// Handler runs one attempt of a job. ctx carries the job's tenant. A returned error
+// schedules another attempt with exponential backoff until the attempts run out.
+type Handler func(ctx context.Context, job *entity.Job) error
+
+// HandlerOptions tune how jobs of one type are run
+type HandlerOptions struct {
+	MaxAttempts int           // Defaults to the queue's JOBS_MAX_ATTEMPTS
+	Timeout     time.Duration // Bounds one attempt; zero leaves it unbounded
+}
+
+// EnqueueOptions tune one enqueued job
+type EnqueueOptions struct {
+	RunAt       time.Time // Zero runs the job as soon as a worker is free
+	MaxAttempts int       // Defaults to the handler's
+}
+
+type registration struct {
+	handler Handler
+	opts    HandlerOptions
+}
+
+// Queue claims due jobs and runs them with the handlers registered for their types
+type Queue struct {
+	repo     repository.JobRepository
+	cfg      config.JobsConfig
+	workerID string
+	now      func() time.Time // Tests align it with the repository clock
+
+	mu       sync.RWMutex
+	handlers map[string]registration
+
+	slots chan struct{} // Bounds jobs running at once
+	wg    sync.WaitGroup
+}
+
+// NewQueue creates a job queue
+func NewQueue(repo repository.JobRepository, cfg config.JobsConfig) *Queue {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.PollIntervalSeconds < 1 {
+		cfg.PollIntervalSeconds = 5
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.BackoffBaseSeconds < 1 {
+		cfg.BackoffBaseSeconds = 30
+	}
+	if cfg.BackoffMaxSeconds < cfg.BackoffBaseSeconds {
+		cfg.BackoffMaxSeconds = cfg.BackoffBaseSeconds
+	}
+	if cfg.StaleAfterMinutes < 1 {
+		cfg.StaleAfterMinutes = 15
+	}
+
+	host, _ := os.Hostname()
+	return &Queue{
+		repo:     repo,
+		cfg:      cfg,
+		workerID: fmt.Sprintf("%s-%d-%s", host, os.Getpid(), uuid.NewString()[:8]),
+		now:      time.Now,
+		handlers: make(map[string]registration),
+		slots:    make(chan struct{}, cfg.Workers),
+	}
+}
+
+// Register sets the handler of a job type. Call it while modules initialize, before Start.
+func (q *Queue) Register(jobType string, handler Handler, opts HandlerOptions) {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = q.cfg.MaxAttempts
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = registration{handler: handler, opts: opts}
+}
+
+// Enqueue persists a job for the tenant in ctx, or a system job when ctx has none
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload map[string]interface{}, opts EnqueueOptions) (*entity.Job, error) {
+	q.mu.RLock()
+	reg, ok := q.handlers[jobType]
+	q.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+
+	job := &entity.Job{
+		Type:        jobType,
+		Payload:     payload,
+		MaxAttempts: opts.MaxAttempts,
+		ScheduledAt: opts.RunAt,
+	}
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = reg.opts.MaxAttempts
+	}
+	if job.ScheduledAt.IsZero() {
+		job.ScheduledAt = q.now()
+	}
+	if err := q.repo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Start claims and runs due jobs until ctx is cancelled, then waits for running
+// attempts to return
+func (q *Queue) Start(ctx context.Context) {
+	log.Printf("🧰 Job queue started: %d workers, types %v", q.cfg.Workers, q.jobTypes())
+	ticker := time.NewTicker(time.Duration(q.cfg.PollIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if _, err := q.poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("WARN: Job queue poll failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			q.wg.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll requeues orphaned jobs and starts as many due jobs as there are free workers.
+// It returns the number of jobs started.
+func (q *Queue) poll(ctx context.Context) (int, error) {
+	staleBefore := q.now().Add(-time.Duration(q.cfg.StaleAfterMinutes) * time.Minute)
+	if n, err := q.repo.RequeueStaleJobs(ctx, staleBefore); err != nil {
+		return 0, err
+	} else if n > 0 {
+		log.Printf("WARN: Requeued %d jobs orphaned by a lost worker", n)
+	}
+
+	types := q.jobTypes()
+	free := cap(q.slots) - len(q.slots)
+	if len(types) == 0 || free == 0 {
+		return 0, nil
+	}
+
+	jobs, err := q.repo.ClaimDueJobs(ctx, types, q.workerID, free)
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range jobs {
+		q.slots <- struct{}{}
+		q.wg.Add(1)
+		go func(job *entity.Job) {
+			defer func() {
+				<-q.slots
+				q.wg.Done()
+			}()
+			q.run(ctx, job)
+		}(job)
+	}
+	return len(jobs), nil
+}
+
+// run makes one attempt at a claimed job and records its outcome
+func (q *Queue) run(ctx context.Context, job *entity.Job) {
+	q.mu.RLock()
+	reg, ok := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	var err error
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrUnknownJobType, job.Type)
+	} else {
+		err = q.attempt(ctx, reg, job)
+	}
+
+	// The outcome is recorded even when shutdown cancelled the attempt
+	recordCtx := context.WithoutCancel(ctx)
+	if err == nil {
+		if err := q.repo.CompleteJob(recordCtx, job.ID); err != nil {
+			log.Printf("ERROR: Failed to complete job %s: %v", job.ID, err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts {
+		next := q.now().Add(q.Backoff(job.Attempts))
+		retryAt = &next
+		log.Printf("WARN: Job %s (%s) attempt %d/%d failed, retrying at %s: %v", job.ID, job.Type, job.Attempts, job.MaxAttempts, next.Format(time.RFC3339), err)
+	} else {
+		log.Printf("ERROR: Job %s (%s) failed after %d attempts: %v", job.ID, job.Type, job.Attempts, err)
+	}
+	if err := q.repo.FailJob(recordCtx, job.ID, err.Error(), retryAt); err != nil {
+		log.Printf("ERROR: Failed to record failure of job %s: %v", job.ID, err)
+	}
+}
+
+// attempt calls the handler under the job's tenant, turning a panic into an error
+func (q *Queue) attempt(ctx context.Context, reg registration, job *entity.Job) (err error) {
+	if job.TenantID != nil {
+		ctx = context.WithValue(ctx, "tenant_id", *job.TenantID)
+	}
+	if reg.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, reg.opts.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return reg.handler(ctx, job)
+}
+
+// Backoff is the delay before the attempt following the given one: the base delay
+// doubled for each earlier attempt, capped at the maximum
+func (q *Queue) Backoff(attempt int) time.Duration {
+	base := time.Duration(q.cfg.BackoffBaseSeconds) * time.Second
+	max := time.Duration(q.cfg.BackoffMaxSeconds) * time.Second
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// List returns the tenant's jobs matching the filter with their total
+func (q *Queue) List(ctx context.Context, filter entity.JobFilter) ([]*entity.Job, int, error) {
+	return q.repo.ListJobs(ctx, filter)
+}
+
+// Get returns one of the tenant's jobs
+func (q *Queue) Get(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	job, err := q.repo.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// Retry makes a failed or cancelled job due now with a fresh attempt budget
+func (q *Queue) Retry(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	return q.transition(ctx, id, q.repo.RetryJob)
+}
+
+// Cancel cancels a pending job
+func (q *Queue) Cancel(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	return q.transition(ctx, id, q.repo.CancelJob)
+}
+
+func (q *Queue) transition(ctx context.Context, id uuid.UUID, change func(context.Context, uuid.UUID) (bool, error)) (*entity.Job, error) {
+	if _, err := q.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	changed, err := change(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return nil, ErrJobState
+	}
+	return q.Get(ctx, id)
+}
+
+// jobTypes lists the registered job types in order
+func (q *Queue) jobTypes() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	types := make([]string, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
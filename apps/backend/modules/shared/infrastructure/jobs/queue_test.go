@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+)
+
+// newTestQueue creates a queue that reads the repository's clock
+func newTestQueue(repo *memory.Repository) *Queue {
+	q := NewQueue(repo, config.JobsConfig{Workers: 2, MaxAttempts: 3, BackoffBaseSeconds: 30, BackoffMaxSeconds: 100})
+	q.now = func() time.Time { return repo.Now() }
+	return q
+}
+
+// pollAndWait runs one poll and waits for the attempts it started
+func pollAndWait(t *testing.T, q *Queue) int {
+	t.Helper()
+	started, err := q.poll(context.Background())
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	q.wg.Wait()
+	return started
+}
+
+func TestQueueRetriesWithBackoffUntilAttemptsRunOut(t *testing.T) {
+	repo := memory.NewRepository()
+	q := newTestQueue(repo)
+
+	calls := 0
+	q.Register("export", func(ctx context.Context, job *entity.Job) error {
+		calls++
+		return errors.New("destination unavailable")
+	}, HandlerOptions{})
+
+	job, err := q.Enqueue(context.Background(), "export", map[string]interface{}{"report": "ropa"}, EnqueueOptions{})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if started := pollAndWait(t, q); started != 1 {
+			t.Fatalf("attempt %d: expected 1 job started, got %d", attempt, started)
+		}
+		// The retry is not due until its backoff has passed
+		if attempt < 3 && pollAndWait(t, q) != 0 {
+			t.Fatalf("attempt %d: retry ran before its backoff", attempt)
+		}
+		offset := time.Duration(attempt) * time.Hour
+		repo.Now = func() time.Time { return time.Now().Add(offset) }
+	}
+
+	stored, _ := q.Get(context.Background(), job.ID)
+	if calls != 3 || stored.Status != entity.JobStatusFailed || stored.Attempts != 3 {
+		t.Fatalf("expected failure after 3 attempts, got %d calls, status %s, attempts %d", calls, stored.Status, stored.Attempts)
+	}
+	if stored.LastError != "destination unavailable" {
+		t.Errorf("unexpected last error %q", stored.LastError)
+	}
+	if pollAndWait(t, q) != 0 {
+		t.Error("a failed job should not run again")
+	}
+
+	// Retrying gives the job a fresh attempt budget
+	if _, err := q.Retry(context.Background(), job.ID); err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if pollAndWait(t, q) != 1 || calls != 4 {
+		t.Errorf("expected the retried job to run, got %d calls", calls)
+	}
+}
+
+func TestQueueRunsJobsAndRecoversPanics(t *testing.T) {
+	repo := memory.NewRepository()
+	q := newTestQueue(repo)
+
+	q.Register("ok", func(ctx context.Context, job *entity.Job) error { return nil }, HandlerOptions{})
+	q.Register("panics", func(ctx context.Context, job *entity.Job) error { panic("nil map") }, HandlerOptions{MaxAttempts: 1})
+
+	ok, _ := q.Enqueue(context.Background(), "ok", nil, EnqueueOptions{})
+	panics, _ := q.Enqueue(context.Background(), "panics", nil, EnqueueOptions{})
+	later, _ := q.Enqueue(context.Background(), "ok", nil, EnqueueOptions{RunAt: repo.Now().Add(time.Hour)})
+
+	if started := pollAndWait(t, q); started != 2 {
+		t.Fatalf("expected 2 due jobs started, got %d", started)
+	}
+	if job, _ := q.Get(context.Background(), ok.ID); job.Status != entity.JobStatusSucceeded || job.CompletedAt == nil {
+		t.Errorf("expected the job to succeed, got %+v", job)
+	}
+	if job, _ := q.Get(context.Background(), panics.ID); job.Status != entity.JobStatusFailed {
+		t.Errorf("expected the panicking job to fail, got %s", job.Status)
+	}
+
+	// Only pending jobs can be cancelled
+	if _, err := q.Cancel(context.Background(), later.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if _, err := q.Cancel(context.Background(), ok.ID); !errors.Is(err, ErrJobState) {
+		t.Errorf("expected ErrJobState cancelling a succeeded job, got %v", err)
+	}
+	if _, err := q.Enqueue(context.Background(), "unregistered", nil, EnqueueOptions{}); !errors.Is(err, ErrUnknownJobType) {
+		t.Errorf("expected ErrUnknownJobType, got %v", err)
+	}
+}
+
+func TestQueueBackoff(t *testing.T) {
+	q := newTestQueue(memory.NewRepository())
+	for attempt, want := range map[int]time.Duration{1: 30 * time.Second, 2: 60 * time.Second, 3: 100 * time.Second, 10: 100 * time.Second} {
+		if got := q.Backoff(attempt); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
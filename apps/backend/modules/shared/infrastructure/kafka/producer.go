@@ -0,0 +1,589 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/google/uuid"
+)
+
+// SchemaVersion is the version of the event envelope and payloads. It is bumped
+// whenever a field is removed or changes meaning; added fields keep the version.
+const SchemaVersion = 1
+
+// maxDeliveryAttempts bounds how often a batch is retried before its events are counted as failed
+const maxDeliveryAttempts = 3
+
+// Envelope wraps every event published to Kafka
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	Source        string          `json:"source"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// Stats reports the producer's delivery counters since start
+type Stats struct {
+	Topic          string     `json:"topic"`
+	Published      int64      `json:"published"` // Events accepted into the buffer
+	Delivered      int64      `json:"delivered"` // Events acknowledged by the brokers
+	Failed         int64      `json:"failed"`    // Events given up on after retries
+	Dropped        int64      `json:"dropped"`   // Events discarded because the buffer was full
+	Buffered       int        `json:"buffered"`  // Events waiting to be sent
+	LastError      string     `json:"last_error,omitempty"`
+	LastErrorAt    *time.Time `json:"last_error_at,omitempty"`
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+}
+
+// Healthy reports whether the most recent delivery attempt succeeded
+func (s Stats) Healthy() bool {
+	if s.LastErrorAt == nil {
+		return true
+	}
+	return s.LastDeliveryAt != nil && s.LastDeliveryAt.After(*s.LastErrorAt)
+}
+
+// Producer publishes integration events to a Kafka topic. Publish only enqueues
+// into a bounded buffer; a background loop batches events and sends them to the
+// partition leaders, so a slow or unreachable cluster never blocks a request.
+// Events are keyed by tenant, which keeps each tenant's events in order.
+type Producer struct {
+	cfg      config.KafkaConfig
+	types    map[string]bool
+	timeout  time.Duration
+	interval time.Duration
+
+	queue   chan *record
+	done    chan struct{}
+	stopped chan struct{}
+	closing atomic.Bool
+	once    sync.Once
+
+	// Owned by the delivery loop
+	md    *metadata
+	conns map[string]*brokerConn
+
+	published atomic.Int64
+	delivered atomic.Int64
+	failed    atomic.Int64
+	dropped   atomic.Int64
+
+	mu             sync.Mutex
+	lastError      string
+	lastErrorAt    time.Time
+	lastDeliveryAt time.Time
+}
+
+// NewProducer validates the configuration and starts the delivery loop
+func NewProducer(cfg config.KafkaConfig) (*Producer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka: no brokers configured")
+	}
+	if cfg.Topic == "" {
+		return nil, fmt.Errorf("kafka: topic must be set")
+	}
+	switch cfg.Acks {
+	case 0, 1, -1:
+	default:
+		return nil, fmt.Errorf("kafka: acks must be 0, 1 or -1")
+	}
+	switch strings.ToUpper(cfg.SASLMechanism) {
+	case "", "PLAIN":
+	default:
+		return nil, fmt.Errorf("kafka: unsupported SASL mechanism %s", cfg.SASLMechanism)
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = "arc-hawk"
+	}
+	if cfg.BufferSize < 1 {
+		cfg.BufferSize = 10000
+	}
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = 500
+	}
+
+	p := &Producer{
+		cfg:      cfg,
+		timeout:  time.Duration(cfg.TimeoutSeconds) * time.Second,
+		interval: time.Duration(cfg.FlushIntervalMs) * time.Millisecond,
+		queue:    make(chan *record, cfg.BufferSize),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		conns:    make(map[string]*brokerConn),
+	}
+	if p.timeout <= 0 {
+		p.timeout = 10 * time.Second
+	}
+	if p.interval <= 0 {
+		p.interval = 500 * time.Millisecond
+	}
+	if len(cfg.EventTypes) > 0 {
+		p.types = make(map[string]bool, len(cfg.EventTypes))
+		for _, t := range cfg.EventTypes {
+			p.types[t] = true
+		}
+	}
+
+	go p.run()
+	return p, nil
+}
+
+// Publish enqueues an event for the tenant in ctx. It never blocks: when the
+// buffer is full the event is dropped and counted.
+func (p *Producer) Publish(ctx context.Context, eventType string, data interface{}) {
+	if p.types != nil && !p.types[eventType] {
+		return
+	}
+	if p.closing.Load() {
+		p.dropped.Add(1)
+		return
+	}
+
+	tenantID, err := persistence.GetTenantID(ctx)
+	if err != nil {
+		tenantID = uuid.Nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("WARN: Failed to encode %s event for Kafka: %v", eventType, err)
+		return
+	}
+
+	envelope := Envelope{
+		ID:            uuid.New().String(),
+		Type:          eventType,
+		SchemaVersion: SchemaVersion,
+		Source:        "arc-hawk",
+		TenantID:      tenantID,
+		OccurredAt:    time.Now().UTC(),
+		Data:          payload,
+	}
+	value, err := json.Marshal(envelope)
+	if err != nil {
+		log.Printf("WARN: Failed to encode %s event for Kafka: %v", eventType, err)
+		return
+	}
+
+	rec := &record{
+		key:   []byte(tenantID.String()),
+		value: value,
+		headers: []recordHeader{
+			{key: "event_type", value: []byte(eventType)},
+			{key: "schema_version", value: []byte(strconv.Itoa(SchemaVersion))},
+			{key: "content_type", value: []byte("application/json")},
+		},
+		timestamp: envelope.OccurredAt,
+	}
+
+	select {
+	case p.queue <- rec:
+		p.published.Add(1)
+	default:
+		p.dropped.Add(1)
+	}
+}
+
+// Stats returns a snapshot of the delivery counters
+func (p *Producer) Stats() Stats {
+	s := Stats{
+		Topic:     p.cfg.Topic,
+		Published: p.published.Load(),
+		Delivered: p.delivered.Load(),
+		Failed:    p.failed.Load(),
+		Dropped:   p.dropped.Load(),
+		Buffered:  len(p.queue),
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s.LastError = p.lastError
+	if !p.lastErrorAt.IsZero() {
+		at := p.lastErrorAt
+		s.LastErrorAt = &at
+	}
+	if !p.lastDeliveryAt.IsZero() {
+		at := p.lastDeliveryAt
+		s.LastDeliveryAt = &at
+	}
+	return s
+}
+
+// Close stops accepting events and flushes the buffer, waiting at most for the
+// configured timeout per remaining delivery attempt
+func (p *Producer) Close() error {
+	p.once.Do(func() {
+		p.closing.Store(true)
+		close(p.done)
+	})
+
+	select {
+	case <-p.stopped:
+	case <-time.After(maxDeliveryAttempts * p.timeout):
+		log.Printf("WARN: Kafka producer closed with %d events still buffered", len(p.queue))
+	}
+	return nil
+}
+
+// run batches queued events until Close, then drains what is left
+func (p *Producer) run() {
+	defer close(p.stopped)
+	defer p.closeConns()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	batch := make([]*record, 0, p.cfg.BatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			p.deliver(batch)
+			batch = make([]*record, 0, p.cfg.BatchSize)
+		}
+	}
+
+	for {
+		select {
+		case rec := <-p.queue:
+			batch = append(batch, rec)
+			if len(batch) >= p.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-p.done:
+			for {
+				select {
+				case rec := <-p.queue:
+					batch = append(batch, rec)
+					if len(batch) >= p.cfg.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver sends a batch, retrying the records that were not acknowledged with
+// fresh metadata
+func (p *Producer) deliver(records []*record) {
+	pending := records
+	var lastErr error
+
+	for attempt := 0; attempt < maxDeliveryAttempts && len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 250 * time.Millisecond)
+		}
+		if p.md == nil {
+			if err := p.refreshMetadata(); err != nil {
+				lastErr = err
+				continue
+			}
+		}
+
+		var err error
+		pending, err = p.produce(pending)
+		if err != nil {
+			lastErr = err
+			p.md = nil
+		}
+	}
+
+	if delivered := len(records) - len(pending); delivered > 0 {
+		p.delivered.Add(int64(delivered))
+		p.mu.Lock()
+		p.lastDeliveryAt = time.Now().UTC()
+		p.mu.Unlock()
+	}
+	if len(pending) > 0 {
+		p.failed.Add(int64(len(pending)))
+		p.mu.Lock()
+		p.lastError = lastErr.Error()
+		p.lastErrorAt = time.Now().UTC()
+		p.mu.Unlock()
+		log.Printf("WARN: Failed to deliver %d events to Kafka topic %s: %v", len(pending), p.cfg.Topic, lastErr)
+	}
+}
+
+// produce sends records to their partition leaders and returns the records
+// that were not acknowledged, with the last error seen
+func (p *Producer) produce(records []*record) ([]*record, error) {
+	partitions := make([]int32, 0, len(p.md.leaders))
+	for partition := range p.md.leaders {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	// leader -> partition -> records
+	byLeader := make(map[int32]map[int32][]*record)
+	var failed []*record
+	var lastErr error
+	for _, rec := range records {
+		partition := partitions[partitionFor(rec.key, len(partitions))]
+		leader := p.md.leaders[partition]
+		if leader < 0 {
+			failed = append(failed, rec)
+			lastErr = KafkaError(5)
+			continue
+		}
+		if byLeader[leader] == nil {
+			byLeader[leader] = make(map[int32][]*record)
+		}
+		byLeader[leader][partition] = append(byLeader[leader][partition], rec)
+	}
+
+	for leader, assigned := range byLeader {
+		batches := make(map[int32][]byte, len(assigned))
+		for partition, recs := range assigned {
+			batches[partition] = encodeRecordBatch(recs)
+		}
+
+		errs, err := p.sendProduce(leader, batches)
+		if err != nil {
+			for _, recs := range assigned {
+				failed = append(failed, recs...)
+			}
+			lastErr = err
+			continue
+		}
+		for partition, recs := range assigned {
+			if perr, ok := errs[partition]; !ok || perr != nil {
+				failed = append(failed, recs...)
+				if perr != nil {
+					lastErr = fmt.Errorf("partition %d: %w", partition, perr)
+				} else {
+					lastErr = fmt.Errorf("partition %d missing from produce response", partition)
+				}
+			}
+		}
+	}
+
+	return failed, lastErr
+}
+
+func (p *Producer) sendProduce(leader int32, batches map[int32][]byte) (map[int32]error, error) {
+	addr, ok := p.md.brokers[leader]
+	if !ok {
+		return nil, fmt.Errorf("kafka: unknown leader node %d", leader)
+	}
+	conn, err := p.conn(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	body := encodeProduceRequest(p.cfg.Topic, int16(p.cfg.Acks), p.timeout, batches)
+	if p.cfg.Acks == 0 {
+		// The broker sends no response without acknowledgements
+		if err := conn.send(apiProduce, produceVersion, body); err != nil {
+			p.dropConn(addr)
+			return nil, err
+		}
+		errs := make(map[int32]error, len(batches))
+		for partition := range batches {
+			errs[partition] = nil
+		}
+		return errs, nil
+	}
+
+	resp, err := conn.roundTrip(apiProduce, produceVersion, body)
+	if err != nil {
+		p.dropConn(addr)
+		return nil, err
+	}
+	return decodeProduceResponse(resp)
+}
+
+// refreshMetadata asks the known brokers, then the bootstrap list, for the topic's partition leaders
+func (p *Producer) refreshMetadata() error {
+	candidates := append([]string{}, p.cfg.Brokers...)
+	if p.md != nil {
+		for _, addr := range p.md.brokers {
+			candidates = append(candidates, addr)
+		}
+	}
+
+	var lastErr error
+	for _, addr := range candidates {
+		conn, err := p.conn(addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp, err := conn.roundTrip(apiMetadata, metadataVersion, encodeMetadataRequest(p.cfg.Topic))
+		if err != nil {
+			p.dropConn(addr)
+			lastErr = err
+			continue
+		}
+		md, err := decodeMetadataResponse(resp, p.cfg.Topic)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		p.md = md
+		return nil
+	}
+	return fmt.Errorf("failed to load Kafka metadata: %w", lastErr)
+}
+
+func (p *Producer) conn(addr string) (*brokerConn, error) {
+	if c, ok := p.conns[addr]; ok {
+		return c, nil
+	}
+	c, err := dialBroker(addr, p.cfg, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[addr] = c
+	return c, nil
+}
+
+func (p *Producer) dropConn(addr string) {
+	if c, ok := p.conns[addr]; ok {
+		c.conn.Close()
+		delete(p.conns, addr)
+	}
+}
+
+func (p *Producer) closeConns() {
+	for addr := range p.conns {
+		p.dropConn(addr)
+	}
+}
+
+// ============================================================================
+// Broker connections
+// ============================================================================
+
+// brokerConn is one connection to a broker; requests on it are strictly sequential
+type brokerConn struct {
+	conn          net.Conn
+	clientID      string
+	timeout       time.Duration
+	correlationID int32
+}
+
+// dialBroker connects, optionally over TLS, and authenticates with SASL/PLAIN when configured
+func dialBroker(addr string, cfg config.KafkaConfig, timeout time.Duration) (*brokerConn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	var err error
+	if cfg.TLS {
+		host, _, splitErr := net.SplitHostPort(addr)
+		if splitErr != nil {
+			return nil, splitErr
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Kafka broker %s: %w", addr, err)
+	}
+
+	c := &brokerConn{conn: conn, clientID: cfg.ClientID, timeout: timeout}
+	if cfg.SASLMechanism != "" {
+		if err := c.authenticatePlain(cfg.SASLUsername, cfg.SASLPassword); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("Kafka SASL authentication with %s failed: %w", addr, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *brokerConn) authenticatePlain(username, password string) error {
+	handshake := encoder{}
+	handshake.string("PLAIN")
+	resp, err := c.roundTrip(apiSaslHandshake, saslHandshakeVersion, handshake.buf)
+	if err != nil {
+		return err
+	}
+	d := &decoder{buf: resp}
+	if code := d.int16(); code != 0 {
+		return KafkaError(code)
+	}
+
+	auth := encoder{}
+	auth.bytes([]byte("\x00" + username + "\x00" + password))
+	resp, err = c.roundTrip(apiSaslAuthenticate, saslAuthenticateVersion, auth.buf)
+	if err != nil {
+		return err
+	}
+	d = &decoder{buf: resp}
+	if code := d.int16(); code != 0 {
+		if msg := d.string(); msg != "" {
+			return fmt.Errorf("%w: %s", KafkaError(code), msg)
+		}
+		return KafkaError(code)
+	}
+	return d.err
+}
+
+// send writes a request without waiting for a response
+func (c *brokerConn) send(apiKey, apiVersion int16, body []byte) error {
+	c.correlationID++
+
+	header := encoder{}
+	header.int16(apiKey)
+	header.int16(apiVersion)
+	header.int32(c.correlationID)
+	header.string(c.clientID)
+
+	frame := encoder{}
+	frame.int32(int32(len(header.buf) + len(body)))
+	frame.buf = append(frame.buf, header.buf...)
+	frame.buf = append(frame.buf, body...)
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write(frame.buf); err != nil {
+		return fmt.Errorf("failed to write Kafka request: %w", err)
+	}
+	return nil
+}
+
+// roundTrip sends a request and returns the response body after the correlation id
+func (c *brokerConn) roundTrip(apiKey, apiVersion int16, body []byte) ([]byte, error) {
+	if err := c.send(apiKey, apiVersion, body); err != nil {
+		return nil, err
+	}
+
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * c.timeout))
+	sizeBuf := make([]byte, 4)
+	if _, err := io.ReadFull(c.conn, sizeBuf); err != nil {
+		return nil, fmt.Errorf("failed to read Kafka response: %w", err)
+	}
+	d := &decoder{buf: sizeBuf}
+	size := d.int32()
+	if size < 4 || size > 64<<20 {
+		return nil, fmt.Errorf("kafka: invalid response size %d", size)
+	}
+
+	resp := make([]byte, size)
+	if _, err := io.ReadFull(c.conn, resp); err != nil {
+		return nil, fmt.Errorf("failed to read Kafka response: %w", err)
+	}
+	d = &decoder{buf: resp}
+	if id := d.int32(); id != c.correlationID {
+		return nil, fmt.Errorf("kafka: response for request %d, expected %d", id, c.correlationID)
+	}
+	return resp[4:], nil
+}
@@ -0,0 +1,295 @@
+package kafka
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+)
+
+func TestMurmur2MatchesJavaClient(t *testing.T) {
+	// Reference values from org.apache.kafka.common.utils.Utils.murmur2
+	cases := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+	}
+	for key, want := range cases {
+		if got := murmur2([]byte(key)); got != want {
+			t.Errorf("murmur2(%q) = %d, want %d", key, got, want)
+		}
+	}
+}
+
+// fakeBroker answers Metadata and Produce requests for a single-partition topic
+type fakeBroker struct {
+	t        *testing.T
+	ln       net.Listener
+	topic    string
+	mu       sync.Mutex
+	received [][]byte // record values
+	failNext int      // produce requests to reject with NOT_LEADER_FOR_PARTITION
+}
+
+func newFakeBroker(t *testing.T, topic string) *fakeBroker {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &fakeBroker{t: t, ln: ln, topic: topic}
+	go b.serve()
+	t.Cleanup(func() { ln.Close() })
+	return b
+}
+
+func (b *fakeBroker) serve() {
+	for {
+		conn, err := b.ln.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *fakeBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		sizeBuf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, sizeBuf); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(sizeBuf))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		d := &decoder{buf: req}
+		apiKey := d.int16()
+		d.int16() // version
+		correlationID := d.int32()
+		d.string() // client id
+
+		var body []byte
+		switch apiKey {
+		case apiMetadata:
+			body = b.metadataResponse()
+		case apiProduce:
+			body = b.produceResponse(d)
+		default:
+			b.t.Errorf("unexpected api key %d", apiKey)
+			return
+		}
+
+		resp := encoder{}
+		resp.int32(int32(4 + len(body)))
+		resp.int32(correlationID)
+		resp.buf = append(resp.buf, body...)
+		if _, err := conn.Write(resp.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) metadataResponse() []byte {
+	host, portStr, _ := net.SplitHostPort(b.ln.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	e := encoder{}
+	e.arrayLen(1)
+	e.int32(1)
+	e.string(host)
+	e.int32(int32(port))
+	e.nullString() // rack
+	e.int32(1)     // controller
+	e.arrayLen(1)
+	e.int16(0)
+	e.string(b.topic)
+	e.int8(0)
+	e.arrayLen(1)
+	e.int16(0)
+	e.int32(0) // partition
+	e.int32(1) // leader
+	e.arrayLen(1)
+	e.int32(1)
+	e.arrayLen(1)
+	e.int32(1)
+	return e.buf
+}
+
+func (b *fakeBroker) produceResponse(d *decoder) []byte {
+	d.string() // transactional id
+	d.int16()  // acks
+	d.int32()  // timeout
+	d.arrayLen()
+	topic := d.string()
+	d.arrayLen()
+	partition := d.int32()
+	batch := d.bytes()
+	if d.err != nil {
+		b.t.Errorf("malformed produce request: %v", d.err)
+	}
+
+	b.mu.Lock()
+	code := int16(0)
+	if b.failNext > 0 {
+		b.failNext--
+		code = 6
+	} else {
+		b.received = append(b.received, decodeBatchValues(b.t, batch)...)
+	}
+	b.mu.Unlock()
+
+	e := encoder{}
+	e.arrayLen(1)
+	e.string(topic)
+	e.arrayLen(1)
+	e.int32(partition)
+	e.int16(code)
+	e.int64(0)
+	e.int64(-1)
+	e.int32(0) // throttle time
+	return e.buf
+}
+
+func (b *fakeBroker) values() [][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([][]byte{}, b.received...)
+}
+
+// decodeBatchValues checks the batch CRC and returns the record values
+func decodeBatchValues(t *testing.T, batch []byte) [][]byte {
+	d := &decoder{buf: batch}
+	d.int64() // base offset
+	d.int32() // length
+	d.int32() // leader epoch
+	if magic := d.int8(); magic != 2 {
+		t.Errorf("magic = %d, want 2", magic)
+	}
+	crc := uint32(d.int32())
+	if got := crc32.Checksum(batch[d.off:], castagnoli); got != crc {
+		t.Errorf("batch crc = %x, want %x", crc, got)
+	}
+	d.take(2 + 4 + 8 + 8 + 8 + 2 + 4)
+	count := int(d.int32())
+
+	rest := batch[d.off:]
+	values := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		_, n := binary.Varint(rest) // record length
+		rest = rest[n:]
+		rest = rest[1:] // attributes
+		_, n = binary.Varint(rest)
+		rest = rest[n:]
+		_, n = binary.Varint(rest)
+		rest = rest[n:]
+		keyLen, n := binary.Varint(rest)
+		rest = rest[n+int(keyLen):]
+		valueLen, n := binary.Varint(rest)
+		rest = rest[n:]
+		values = append(values, rest[:valueLen])
+		rest = rest[valueLen:]
+		headers, n := binary.Varint(rest)
+		rest = rest[n:]
+		for h := int64(0); h < headers*2; h++ {
+			l, n := binary.Varint(rest)
+			rest = rest[n+int(l):]
+		}
+	}
+	return values
+}
+
+func TestProducerDeliversEnvelopes(t *testing.T) {
+	broker := newFakeBroker(t, "arc-hawk.events")
+	// The first produce is rejected; the producer refreshes metadata and retries
+	broker.failNext = 1
+
+	p, err := NewProducer(config.KafkaConfig{
+		Brokers:         []string{broker.ln.Addr().String()},
+		Topic:           "arc-hawk.events",
+		Acks:            -1,
+		FlushIntervalMs: 10,
+		TimeoutSeconds:  2,
+		EventTypes:      []string{"finding.created"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Publish(context.Background(), "finding.created", map[string]string{"finding_id": "f-1"})
+	p.Publish(context.Background(), "scan.progress", map[string]string{"ignored": "yes"})
+	p.Close()
+
+	values := broker.values()
+	if len(values) != 1 {
+		t.Fatalf("broker received %d events, want 1", len(values))
+	}
+	var env Envelope
+	if err := json.Unmarshal(values[0], &env); err != nil {
+		t.Fatalf("invalid envelope: %v", err)
+	}
+	if env.Type != "finding.created" || env.SchemaVersion != SchemaVersion || string(env.Data) != `{"finding_id":"f-1"}` {
+		t.Errorf("unexpected envelope: %+v", env)
+	}
+
+	stats := p.Stats()
+	if stats.Published != 1 || stats.Delivered != 1 || stats.Failed != 0 || !stats.Healthy() {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestProducerCountsUndeliverableEvents(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	p, err := NewProducer(config.KafkaConfig{
+		Brokers:         []string{addr},
+		Topic:           "arc-hawk.events",
+		Acks:            1,
+		FlushIntervalMs: 10,
+		TimeoutSeconds:  1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Publish(context.Background(), "remediation.executed", map[string]string{"action_id": "a-1"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for p.Stats().Failed == 0 && time.Now().Before(deadline) {
+		time.Sleep(20 * time.Millisecond)
+	}
+	p.Close()
+
+	stats := p.Stats()
+	if stats.Failed != 1 || stats.Delivered != 0 || stats.Healthy() || stats.LastError == "" {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestNewProducerRejectsInvalidConfig(t *testing.T) {
+	cases := []config.KafkaConfig{
+		{Topic: "events"},
+		{Brokers: []string{"localhost:9092"}},
+		{Brokers: []string{"localhost:9092"}, Topic: "events", Acks: 2},
+		{Brokers: []string{"localhost:9092"}, Topic: "events", SASLMechanism: "SCRAM-SHA-512"},
+	}
+	for _, cfg := range cases {
+		if _, err := NewProducer(cfg); err == nil {
+			t.Errorf("expected %+v to be rejected", cfg)
+		}
+	}
+}
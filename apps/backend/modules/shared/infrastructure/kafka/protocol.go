@@ -0,0 +1,395 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+// Kafka API keys and the versions this producer speaks. These versions are
+// supported by every broker from 0.11 on.
+const (
+	apiProduce          int16 = 0
+	apiMetadata         int16 = 3
+	apiSaslHandshake    int16 = 17
+	apiSaslAuthenticate int16 = 36
+
+	produceVersion          int16 = 3
+	metadataVersion         int16 = 1
+	saslHandshakeVersion    int16 = 1
+	saslAuthenticateVersion int16 = 0
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+var errShortResponse = errors.New("kafka: truncated response")
+
+// kafkaErrors names the broker error codes a producer commonly sees
+var kafkaErrors = map[int16]string{
+	1:  "OFFSET_OUT_OF_RANGE",
+	2:  "CORRUPT_MESSAGE",
+	3:  "UNKNOWN_TOPIC_OR_PARTITION",
+	5:  "LEADER_NOT_AVAILABLE",
+	6:  "NOT_LEADER_FOR_PARTITION",
+	7:  "REQUEST_TIMED_OUT",
+	10: "MESSAGE_TOO_LARGE",
+	19: "NOT_ENOUGH_REPLICAS",
+	20: "NOT_ENOUGH_REPLICAS_AFTER_APPEND",
+	29: "TOPIC_AUTHORIZATION_FAILED",
+	33: "UNSUPPORTED_SASL_MECHANISM",
+	34: "ILLEGAL_SASL_STATE",
+	35: "UNSUPPORTED_VERSION",
+	58: "SASL_AUTHENTICATION_FAILED",
+}
+
+// KafkaError is a non-zero error code returned by a broker
+type KafkaError int16
+
+func (e KafkaError) Error() string {
+	if name, ok := kafkaErrors[int16(e)]; ok {
+		return "kafka: " + name
+	}
+	return fmt.Sprintf("kafka: error code %d", int16(e))
+}
+
+// ============================================================================
+// Encoding
+// ============================================================================
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) int8(v int8)   { e.buf = append(e.buf, byte(v)) }
+func (e *encoder) int16(v int16) { e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v)) }
+func (e *encoder) int32(v int32) { e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v)) }
+func (e *encoder) int64(v int64) { e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v)) }
+
+func (e *encoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+func (e *encoder) nullString() {
+	e.int16(-1)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) arrayLen(n int) {
+	e.int32(int32(n))
+}
+
+type decoder struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || d.off+n > len(d.buf) {
+		d.err = errShortResponse
+		return nil
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b
+}
+
+func (d *decoder) int8() int8 {
+	if b := d.take(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *decoder) int16() int16 {
+	if b := d.take(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *decoder) int32() int32 {
+	if b := d.take(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *decoder) int64() int64 {
+	if b := d.take(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+func (d *decoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.take(int(n)))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.take(int(n))
+}
+
+// arrayLen reads an array length; a null array reads as empty
+func (d *decoder) arrayLen() int {
+	n := d.int32()
+	if n < 0 || d.err != nil {
+		return 0
+	}
+	// Every element is at least one byte; anything larger is a corrupt length
+	if int(n) > len(d.buf)-d.off {
+		d.err = errShortResponse
+		return 0
+	}
+	return int(n)
+}
+
+// ============================================================================
+// Requests and responses
+// ============================================================================
+
+// record is one message of a record batch
+type record struct {
+	key       []byte
+	value     []byte
+	headers   []recordHeader
+	timestamp time.Time
+}
+
+type recordHeader struct {
+	key   string
+	value []byte
+}
+
+// encodeRecordBatch builds an uncompressed v2 record batch (magic 2)
+func encodeRecordBatch(records []*record) []byte {
+	first := records[0].timestamp.UnixMilli()
+	max := first
+
+	var body []byte
+	for i, r := range records {
+		ts := r.timestamp.UnixMilli()
+		if ts > max {
+			max = ts
+		}
+
+		var rec []byte
+		rec = append(rec, 0) // attributes
+		rec = binary.AppendVarint(rec, ts-first)
+		rec = binary.AppendVarint(rec, int64(i))
+		rec = appendVarBytes(rec, r.key)
+		rec = appendVarBytes(rec, r.value)
+		rec = binary.AppendVarint(rec, int64(len(r.headers)))
+		for _, h := range r.headers {
+			rec = appendVarBytes(rec, []byte(h.key))
+			rec = appendVarBytes(rec, h.value)
+		}
+
+		body = binary.AppendVarint(body, int64(len(rec)))
+		body = append(body, rec...)
+	}
+
+	// The CRC covers everything from the attributes to the end of the batch
+	tail := encoder{}
+	tail.int16(0) // attributes: no compression, create time
+	tail.int32(int32(len(records) - 1))
+	tail.int64(first)
+	tail.int64(max)
+	tail.int64(-1) // producer id: not idempotent
+	tail.int16(-1) // producer epoch
+	tail.int32(-1) // base sequence
+	tail.int32(int32(len(records)))
+	tail.buf = append(tail.buf, body...)
+
+	batch := encoder{}
+	batch.int64(0)                                // base offset, assigned by the broker
+	batch.int32(int32(4 + 1 + 4 + len(tail.buf))) // length after this field
+	batch.int32(-1)                               // partition leader epoch
+	batch.int8(2)                                 // magic
+	batch.buf = binary.BigEndian.AppendUint32(batch.buf, crc32.Checksum(tail.buf, castagnoli))
+	batch.buf = append(batch.buf, tail.buf...)
+
+	return batch.buf
+}
+
+func appendVarBytes(buf, b []byte) []byte {
+	if b == nil {
+		return binary.AppendVarint(buf, -1)
+	}
+	buf = binary.AppendVarint(buf, int64(len(b)))
+	return append(buf, b...)
+}
+
+// encodeProduceRequest builds a Produce v3 body for one topic
+func encodeProduceRequest(topic string, acks int16, timeout time.Duration, batches map[int32][]byte) []byte {
+	e := encoder{}
+	e.nullString() // transactional id
+	e.int16(acks)
+	e.int32(int32(timeout / time.Millisecond))
+	e.arrayLen(1)
+	e.string(topic)
+	e.arrayLen(len(batches))
+	for partition, batch := range batches {
+		e.int32(partition)
+		e.bytes(batch)
+	}
+	return e.buf
+}
+
+// decodeProduceResponse returns the error of each partition in a Produce v3 response
+func decodeProduceResponse(body []byte) (map[int32]error, error) {
+	d := &decoder{buf: body}
+	result := make(map[int32]error)
+	for t := d.arrayLen(); t > 0; t-- {
+		d.string()
+		for p := d.arrayLen(); p > 0; p-- {
+			partition := d.int32()
+			code := d.int16()
+			d.int64() // base offset
+			d.int64() // log append time
+			if code != 0 {
+				result[partition] = KafkaError(code)
+			} else {
+				result[partition] = nil
+			}
+		}
+	}
+	d.int32() // throttle time
+	return result, d.err
+}
+
+// metadata is the part of a Metadata response the producer needs
+type metadata struct {
+	brokers map[int32]string // node id -> host:port
+	leaders map[int32]int32  // partition -> leader node id, -1 while unavailable
+}
+
+func encodeMetadataRequest(topic string) []byte {
+	e := encoder{}
+	e.arrayLen(1)
+	e.string(topic)
+	return e.buf
+}
+
+// decodeMetadataResponse parses a Metadata v1 response for a single topic
+func decodeMetadataResponse(body []byte, topic string) (*metadata, error) {
+	d := &decoder{buf: body}
+	md := &metadata{brokers: make(map[int32]string), leaders: make(map[int32]int32)}
+
+	for n := d.arrayLen(); n > 0; n-- {
+		node := d.int32()
+		host := d.string()
+		port := d.int32()
+		d.string() // rack
+		md.brokers[node] = fmt.Sprintf("%s:%d", host, port)
+	}
+	d.int32() // controller id
+
+	var topicErr error
+	found := false
+	for t := d.arrayLen(); t > 0; t-- {
+		code := d.int16()
+		name := d.string()
+		d.int8() // is internal
+		partitions := d.arrayLen()
+		for ; partitions > 0; partitions-- {
+			pcode := d.int16()
+			partition := d.int32()
+			leader := d.int32()
+			for r := d.arrayLen(); r > 0; r-- {
+				d.int32()
+			}
+			for r := d.arrayLen(); r > 0; r-- {
+				d.int32()
+			}
+			if name != topic {
+				continue
+			}
+			if pcode != 0 && pcode != 9 { // REPLICA_NOT_AVAILABLE still has a usable leader
+				leader = -1
+			}
+			md.leaders[partition] = leader
+		}
+		if name == topic {
+			found = true
+			if code != 0 {
+				topicErr = KafkaError(code)
+			}
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if !found {
+		return nil, fmt.Errorf("kafka: topic %s missing from metadata", topic)
+	}
+	if topicErr != nil {
+		return nil, fmt.Errorf("topic %s: %w", topic, topicErr)
+	}
+	if len(md.leaders) == 0 {
+		return nil, fmt.Errorf("kafka: topic %s has no partitions", topic)
+	}
+	return md, nil
+}
+
+// murmur2 is the hash of Kafka's default partitioner, so events land on the
+// same partition as records keyed the same way by Java clients
+func murmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+	length := len(data)
+	h := seed ^ uint32(length)
+
+	for i := 0; i+4 <= length; i += 4 {
+		k := binary.LittleEndian.Uint32(data[i:])
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length & 3 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
+
+// partitionFor picks the partition for a key the way Kafka's default partitioner does
+func partitionFor(key []byte, partitions int) int {
+	return int(murmur2(key)&0x7fffffff) % partitions
+}
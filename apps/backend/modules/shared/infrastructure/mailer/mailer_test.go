@@ -0,0 +1,103 @@
+package mailer
+
+import (
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+)
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	raw := string(Format("arc@example.com", Message{
+		To:      []string{"dpo@example.com", "sec@example.com"},
+		Subject: "Alert\r\nBcc: attacker@example.com",
+		Body:    "line one\nline two",
+	}, date))
+
+	headers, body, ok := strings.Cut(raw, "\r\n\r\n")
+	if !ok {
+		t.Fatalf("expected a blank line between headers and body:\n%q", raw)
+	}
+
+	if !strings.Contains(headers, "To: dpo@example.com, sec@example.com\r\n") {
+		t.Errorf("expected every recipient in the To header, got:\n%s", headers)
+	}
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("expected line breaks in the subject to be neutralised, got:\n%s", headers)
+	}
+	if !strings.Contains(headers, "Date: Mon, 02 Mar 2026 08:00:00 +0000") {
+		t.Errorf("expected an RFC 1123 date header, got:\n%s", headers)
+	}
+	if body != "line one\r\nline two\r\n" {
+		t.Errorf("expected CRLF body lines, got %q", body)
+	}
+}
+
+func TestNewWithoutHost(t *testing.T) {
+	if m := New(config.AlertingConfig{SMTPPort: 587}); m != nil {
+		t.Errorf("expected no mailer without an SMTP host, got %T", m)
+	}
+	if m := New(config.AlertingConfig{SMTPHost: "smtp.example.com", SMTPPort: 587}); m == nil {
+		t.Error("expected an SMTP mailer when a host is configured")
+	}
+}
+
+func TestFormatWithAttachment(t *testing.T) {
+	data := []byte(strings.Repeat("severity,count\n", 10))
+	raw := string(Format("arc@example.com", Message{
+		To:      []string{"dpo@example.com"},
+		Subject: "Weekly report",
+		Body:    "See attached.",
+		Attachments: []Attachment{
+			{Filename: "report.csv", ContentType: "text/csv", Data: data},
+		},
+	}, time.Now()))
+
+	headers, body, _ := strings.Cut(raw, "\r\n\r\n")
+	_, params, err := mime.ParseMediaType(headerValue(headers, "Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		t.Fatalf("expected a multipart content type with a boundary, got:\n%s", headers)
+	}
+
+	reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	text, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("expected a text part: %v", err)
+	}
+	if got, _ := io.ReadAll(text); string(got) != "See attached." {
+		t.Errorf("expected the body as the first part, got %q", got)
+	}
+
+	attachment, err := reader.NextPart()
+	if err != nil {
+		t.Fatalf("expected an attachment part: %v", err)
+	}
+	if attachment.FileName() != "report.csv" {
+		t.Errorf("expected filename report.csv, got %q", attachment.FileName())
+	}
+	encoded, _ := io.ReadAll(attachment)
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	if err != nil || string(decoded) != string(data) {
+		t.Errorf("expected the attachment to round-trip, got %q (%v)", decoded, err)
+	}
+	for _, line := range strings.Split(string(encoded), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("expected base64 lines of at most 76 characters, got %d", len(line))
+		}
+	}
+}
+
+func headerValue(headers, name string) string {
+	for _, line := range strings.Split(headers, "\r\n") {
+		if v, ok := strings.CutPrefix(line, name+": "); ok {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBody bounds how much of a failed response is kept in the error
+const maxErrorBody = 1024
+
+// StatusError is a response OpenSearch answered with a non-2xx status
+type StatusError struct {
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("opensearch: %d %s: %s", e.Status, http.StatusText(e.Status), e.Body)
+}
+
+// IsNotFound reports whether err is a 404 response, e.g. for a missing index
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Status == http.StatusNotFound
+}
+
+// Client talks to the OpenSearch REST API over plain HTTP, so no client library
+// is needed
+type Client struct {
+	baseURL  string
+	user     string
+	password string
+	http     *http.Client
+}
+
+// New creates a client for the cluster at baseURL, e.g. http://opensearch:9200
+func New(baseURL, user, password string) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		user:     user,
+		password: password,
+		http:     &http.Client{Timeout: time.Minute},
+	}
+}
+
+// JSON sends payload, when not nil, marshalled to JSON and decodes the response into dest
+func (c *Client) JSON(ctx context.Context, method, path string, payload, dest interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("opensearch: failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+	return c.do(ctx, method, path, "application/json", body, dest)
+}
+
+// Bulk sends actions to the _bulk API, each line marshalled to one JSON object
+func (c *Client) Bulk(ctx context.Context, path string, lines []interface{}, dest interface{}) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, line := range lines {
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("opensearch: failed to encode bulk line: %w", err)
+		}
+	}
+	return c.do(ctx, http.MethodPost, path, "application/x-ndjson", &body, dest)
+}
+
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("opensearch: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("opensearch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &StatusError{Status: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	}
+	if dest == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("opensearch: failed to decode response: %w", err)
+	}
+	return nil
+}
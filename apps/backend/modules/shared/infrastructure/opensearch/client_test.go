@@ -0,0 +1,63 @@
+package opensearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient(t *testing.T) {
+	var lastBody, lastContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "arc" || password != "secret" {
+			t.Errorf("expected basic auth credentials, got %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		lastBody, lastContentType = string(body), r.Header.Get("Content-Type")
+		switch r.URL.Path {
+		case "/missing":
+			http.Error(w, `{"error":{"type":"index_not_found_exception"}}`, http.StatusNotFound)
+		case "/findings/_search":
+			w.Write([]byte(`{"hits": {"total": {"value": 3}}}`))
+		default:
+			w.Write([]byte(`{"errors": false}`))
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/", "arc", "secret")
+	ctx := context.Background()
+
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+	}
+	if err := c.JSON(ctx, http.MethodPost, "/findings/_search", map[string]int{"size": 1}, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Hits.Total.Value != 3 || lastBody != `{"size":1}` || lastContentType != "application/json" {
+		t.Errorf("unexpected search round trip: %+v, body %q, type %q", result, lastBody, lastContentType)
+	}
+
+	// Bulk requests send one JSON object per line
+	if err := c.Bulk(ctx, "/_bulk", []interface{}{map[string]int{"a": 1}, map[string]int{"b": 2}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if lastBody != "{\"a\":1}\n{\"b\":2}\n" || lastContentType != "application/x-ndjson" {
+		t.Errorf("unexpected bulk body %q (%s)", lastBody, lastContentType)
+	}
+
+	err := c.JSON(ctx, http.MethodHead, "/missing", nil, nil)
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected the status in the error, got %v", err)
+	}
+}
@@ -0,0 +1,608 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Alerting Repository Implementation
+// ============================================================================
+
+const alertRuleColumns = `id, tenant_id, name, severities, classification_types, environments, pattern_names,
+	recipients, is_active, evaluated_until, last_triggered_at, created_by, created_at, updated_at`
+
+const alertDigestColumns = `id, tenant_id, name, frequency, recipients, is_active, next_run_at, last_sent_at,
+	created_by, created_at, updated_at`
+
+// CreateAlertRule stores a new alert rule for the tenant
+func (r *PostgresRepository) CreateAlertRule(ctx context.Context, rule *entity.AlertRule) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	rule.TenantID = tenantID
+
+	query := `
+		INSERT INTO alert_rules (id, tenant_id, name, severities, classification_types, environments,
+			pattern_names, recipients, is_active, evaluated_until, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		rule.ID, rule.TenantID, rule.Name, pq.Array(rule.Severities), pq.Array(rule.ClassificationTypes),
+		pq.Array(rule.Environments), pq.Array(rule.PatternNames), pq.Array(rule.Recipients),
+		rule.IsActive, rule.EvaluatedUntil, rule.CreatedBy,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("alert rule %q already exists", rule.Name)
+		}
+		return fmt.Errorf("failed to create alert rule: %w", err)
+	}
+	return nil
+}
+
+// GetAlertRule retrieves an alert rule by ID
+func (r *PostgresRepository) GetAlertRule(ctx context.Context, id uuid.UUID) (*entity.AlertRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE id = $1 AND tenant_id = $2`
+
+	rule, err := scanAlertRule(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("alert rule not found")
+	}
+	return rule, err
+}
+
+// ListAlertRules retrieves all alert rules of the tenant
+func (r *PostgresRepository) ListAlertRules(ctx context.Context) ([]*entity.AlertRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE tenant_id = $1 ORDER BY name`
+	return r.queryAlertRules(ctx, query, tenantID)
+}
+
+// ListActiveAlertRules retrieves the enabled alert rules of every tenant (used by the alert worker)
+func (r *PostgresRepository) ListActiveAlertRules(ctx context.Context) ([]*entity.AlertRule, error) {
+	query := `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE is_active ORDER BY evaluated_until`
+	return r.queryAlertRules(ctx, query)
+}
+
+// UpdateAlertRule replaces the name, filters, recipients and active flag of an alert rule.
+// Re-enabling a rule moves its cursor to enabledAt so findings from while it was off are not alerted.
+func (r *PostgresRepository) UpdateAlertRule(ctx context.Context, rule *entity.AlertRule, enabledAt time.Time) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE alert_rules SET name = $1, severities = $2, classification_types = $3, environments = $4,
+			pattern_names = $5, recipients = $6,
+			evaluated_until = CASE WHEN $7 AND NOT is_active THEN $8 ELSE evaluated_until END,
+			is_active = $7
+		WHERE id = $9 AND tenant_id = $10
+		RETURNING ` + alertRuleColumns
+
+	updated, err := scanAlertRule(r.db.QueryRowContext(ctx, query,
+		rule.Name, pq.Array(rule.Severities), pq.Array(rule.ClassificationTypes), pq.Array(rule.Environments),
+		pq.Array(rule.PatternNames), pq.Array(rule.Recipients), rule.IsActive, enabledAt, rule.ID, tenantID,
+	))
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("alert rule not found")
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("alert rule %q already exists", rule.Name)
+		}
+		return fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	*rule = *updated
+	return nil
+}
+
+// DeleteAlertRule deletes an alert rule by ID
+func (r *PostgresRepository) DeleteAlertRule(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("alert rule not found")
+	}
+	return nil
+}
+
+// AdvanceAlertRule moves a rule's cursor from one position to another. It reports
+// false when the cursor was no longer at from, i.e. another replica took the window.
+func (r *PostgresRepository) AdvanceAlertRule(ctx context.Context, id uuid.UUID, from, to time.Time, triggered bool) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE alert_rules
+		SET evaluated_until = $3, last_triggered_at = CASE WHEN $4 THEN NOW() ELSE last_triggered_at END
+		WHERE id = $1 AND evaluated_until = $2`,
+		id, from, to, triggered)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance alert rule: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// ListAlertRuleMatches returns up to limit findings created in (after, until] that
+// match the rule's filters, oldest first, plus the total number of matches
+func (r *PostgresRepository) ListAlertRuleMatches(ctx context.Context, rule *entity.AlertRule, after, until time.Time, limit int) ([]entity.AlertFinding, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT f.id, f.asset_id, COALESCE(a.name, ''), f.pattern_name, COALESCE(f.severity, ''),
+			COALESCE(c.classification_type, ''), COALESCE(f.environment, ''), f.created_at,
+			COUNT(*) OVER ()
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN LATERAL (
+			SELECT classification_type FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+		  AND f.created_at > $2 AND f.created_at <= $3
+		  AND (cardinality($4::text[]) = 0 OR LOWER(f.severity) = ANY($4::text[]))
+		  AND (cardinality($5::text[]) = 0 OR LOWER(c.classification_type) = ANY($5::text[]))
+		  AND (cardinality($6::text[]) = 0 OR LOWER(f.environment) = ANY($6::text[]))
+		  AND (cardinality($7::text[]) = 0 OR LOWER(f.pattern_name) = ANY($7::text[]))
+		ORDER BY f.created_at, f.id
+		LIMIT $8`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, after, until,
+		pq.Array(lowerAll(rule.Severities)), pq.Array(lowerAll(rule.ClassificationTypes)),
+		pq.Array(lowerAll(rule.Environments)), pq.Array(lowerAll(rule.PatternNames)), limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query alert rule matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []entity.AlertFinding
+	total := 0
+	for rows.Next() {
+		var m entity.AlertFinding
+		if err := rows.Scan(
+			&m.FindingID, &m.AssetID, &m.AssetName, &m.PatternName, &m.Severity,
+			&m.ClassificationType, &m.Environment, &m.CreatedAt, &total,
+		); err != nil {
+			return nil, 0, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, total, rows.Err()
+}
+
+// CreateAlertDigest stores a new digest subscription for the tenant
+func (r *PostgresRepository) CreateAlertDigest(ctx context.Context, digest *entity.AlertDigest) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	digest.TenantID = tenantID
+
+	query := `
+		INSERT INTO alert_digests (id, tenant_id, name, frequency, recipients, is_active, next_run_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		digest.ID, digest.TenantID, digest.Name, digest.Frequency, pq.Array(digest.Recipients),
+		digest.IsActive, digest.NextRunAt, digest.CreatedBy,
+	).Scan(&digest.CreatedAt, &digest.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("alert digest %q already exists", digest.Name)
+		}
+		return fmt.Errorf("failed to create alert digest: %w", err)
+	}
+	return nil
+}
+
+// GetAlertDigest retrieves a digest subscription by ID
+func (r *PostgresRepository) GetAlertDigest(ctx context.Context, id uuid.UUID) (*entity.AlertDigest, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + alertDigestColumns + ` FROM alert_digests WHERE id = $1 AND tenant_id = $2`
+
+	digest, err := scanAlertDigest(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("alert digest not found")
+	}
+	return digest, err
+}
+
+// ListAlertDigests retrieves all digest subscriptions of the tenant
+func (r *PostgresRepository) ListAlertDigests(ctx context.Context) ([]*entity.AlertDigest, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + alertDigestColumns + ` FROM alert_digests WHERE tenant_id = $1 ORDER BY name`
+	return r.queryAlertDigests(ctx, query, tenantID)
+}
+
+// ListDueAlertDigests retrieves the enabled digests of every tenant whose next run is at or before now
+func (r *PostgresRepository) ListDueAlertDigests(ctx context.Context, now time.Time) ([]*entity.AlertDigest, error) {
+	query := `SELECT ` + alertDigestColumns + ` FROM alert_digests WHERE is_active AND next_run_at <= $1 ORDER BY next_run_at`
+	return r.queryAlertDigests(ctx, query, now)
+}
+
+// UpdateAlertDigest replaces the name, frequency, recipients, active flag and next run of a digest
+func (r *PostgresRepository) UpdateAlertDigest(ctx context.Context, digest *entity.AlertDigest) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE alert_digests SET name = $1, frequency = $2, recipients = $3, is_active = $4, next_run_at = $5
+		WHERE id = $6 AND tenant_id = $7
+		RETURNING ` + alertDigestColumns
+
+	updated, err := scanAlertDigest(r.db.QueryRowContext(ctx, query,
+		digest.Name, digest.Frequency, pq.Array(digest.Recipients), digest.IsActive, digest.NextRunAt,
+		digest.ID, tenantID,
+	))
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("alert digest not found")
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("alert digest %q already exists", digest.Name)
+		}
+		return fmt.Errorf("failed to update alert digest: %w", err)
+	}
+
+	*digest = *updated
+	return nil
+}
+
+// DeleteAlertDigest deletes a digest subscription by ID
+func (r *PostgresRepository) DeleteAlertDigest(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM alert_digests WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("alert digest not found")
+	}
+	return nil
+}
+
+// ClaimAlertDigest moves a due digest's next run from its current value to next.
+// It reports false when another replica already claimed this run.
+func (r *PostgresRepository) ClaimAlertDigest(ctx context.Context, id uuid.UUID, current, next time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE alert_digests SET next_run_at = $3
+		WHERE id = $1 AND next_run_at = $2 AND is_active`,
+		id, current, next)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim alert digest: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// MarkAlertDigestSent records the end of the period a digest last covered
+func (r *PostgresRepository) MarkAlertDigestSent(ctx context.Context, id uuid.UUID, periodEnd time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE alert_digests SET last_sent_at = $2 WHERE id = $1`, id, periodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to mark alert digest sent: %w", err)
+	}
+	return nil
+}
+
+// GetAlertDigestSummary summarizes the tenant's new findings and remediation progress in (since, until]
+func (r *PostgresRepository) GetAlertDigestSummary(ctx context.Context, since, until time.Time) (*entity.AlertDigestSummary, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entity.AlertDigestSummary{
+		PeriodStart:          since,
+		PeriodEnd:            until,
+		NewBySeverity:        map[string]int{},
+		NewByClassification:  map[string]int{},
+		TopAssets:            []entity.DigestAssetCount{},
+		RemediationsByStatus: map[string]int{},
+		ReviewsByStatus:      map[string]int{},
+	}
+
+	newFindings := `
+		SELECT COALESCE(NULLIF(f.severity, ''), 'Unknown'), COALESCE(c.classification_type, 'Unclassified'), COUNT(*)
+		FROM findings f
+		LEFT JOIN LATERAL (
+			SELECT classification_type FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.created_at > $2 AND f.created_at <= $3
+		GROUP BY 1, 2`
+	err = r.scanGroupedCounts(ctx, newFindings, []interface{}{tenantID, since, until}, func(rows *sql.Rows) error {
+		var severity, classification string
+		var count int
+		if err := rows.Scan(&severity, &classification, &count); err != nil {
+			return err
+		}
+		summary.NewBySeverity[severity] += count
+		summary.NewByClassification[classification] += count
+		summary.NewFindings += count
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize new findings: %w", err)
+	}
+
+	topAssets := `
+		SELECT f.asset_id, COALESCE(a.name, ''), COUNT(*)
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.created_at > $2 AND f.created_at <= $3
+		GROUP BY f.asset_id, a.name
+		ORDER BY COUNT(*) DESC, a.name
+		LIMIT 5`
+	err = r.scanGroupedCounts(ctx, topAssets, []interface{}{tenantID, since, until}, func(rows *sql.Rows) error {
+		var asset entity.DigestAssetCount
+		if err := rows.Scan(&asset.AssetID, &asset.AssetName, &asset.Count); err != nil {
+			return err
+		}
+		summary.TopAssets = append(summary.TopAssets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize top assets: %w", err)
+	}
+
+	remediations := `
+		SELECT ra.status, COUNT(*), COUNT(DISTINCT ra.finding_id) FILTER (WHERE ra.status = 'COMPLETED')
+		FROM remediation_actions ra
+		JOIN findings f ON f.id = ra.finding_id
+		WHERE f.tenant_id = $1 AND ra.executed_at > $2 AND ra.executed_at <= $3
+		GROUP BY ra.status`
+	err = r.scanGroupedCounts(ctx, remediations, []interface{}{tenantID, since, until}, func(rows *sql.Rows) error {
+		var status string
+		var count, remediated int
+		if err := rows.Scan(&status, &count, &remediated); err != nil {
+			return err
+		}
+		summary.RemediationsByStatus[status] = count
+		summary.FindingsRemediated += remediated
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize remediations: %w", err)
+	}
+
+	reviews := `
+		SELECT rs.status, COUNT(*)
+		FROM review_states rs
+		JOIN findings f ON f.id = rs.finding_id
+		WHERE f.tenant_id = $1 AND rs.reviewed_at > $2 AND rs.reviewed_at <= $3
+		GROUP BY rs.status`
+	err = r.scanGroupedCounts(ctx, reviews, []interface{}{tenantID, since, until}, func(rows *sql.Rows) error {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return err
+		}
+		summary.ReviewsByStatus[status] = count
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize reviews: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT f.id)
+		FROM findings f
+		LEFT JOIN review_states rs ON rs.finding_id = f.id
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.stale_at IS NULL
+		  AND UPPER(f.severity) = 'CRITICAL'
+		  AND (rs.status IS NULL OR NOT rs.status = ANY($2::text[]))
+		  AND NOT EXISTS (
+			SELECT 1 FROM remediation_actions ra
+			WHERE ra.finding_id = f.id AND ra.status = 'COMPLETED'
+		  )`,
+		tenantID, pq.Array(entity.ClosedReviewStatuses),
+	).Scan(&summary.OpenCriticalFindings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open critical findings: %w", err)
+	}
+
+	return summary, nil
+}
+
+// RecordAlertDelivery logs an alert or digest email attempt
+func (r *PostgresRepository) RecordAlertDelivery(ctx context.Context, delivery *entity.AlertDelivery) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO alert_deliveries (id, tenant_id, rule_id, digest_id, kind, recipients, subject, finding_count, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''))
+		RETURNING created_at`,
+		delivery.ID, delivery.TenantID, delivery.RuleID, delivery.DigestID, delivery.Kind,
+		pq.Array(delivery.Recipients), delivery.Subject, delivery.FindingCount, delivery.Status, delivery.Error,
+	).Scan(&delivery.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record alert delivery: %w", err)
+	}
+	return nil
+}
+
+// ListAlertDeliveries returns the tenant's most recent alert and digest emails
+func (r *PostgresRepository) ListAlertDeliveries(ctx context.Context, limit, offset int) ([]*entity.AlertDelivery, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, tenant_id, rule_id, digest_id, kind, recipients, subject, finding_count, status,
+			COALESCE(error, ''), created_at
+		FROM alert_deliveries
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`,
+		tenantID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.AlertDelivery
+	for rows.Next() {
+		d := &entity.AlertDelivery{}
+		var ruleID, digestID uuid.NullUUID
+		var recipients pq.StringArray
+		if err := rows.Scan(
+			&d.ID, &d.TenantID, &ruleID, &digestID, &d.Kind, &recipients, &d.Subject,
+			&d.FindingCount, &d.Status, &d.Error, &d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if ruleID.Valid {
+			d.RuleID = &ruleID.UUID
+		}
+		if digestID.Valid {
+			d.DigestID = &digestID.UUID
+		}
+		d.Recipients = recipients
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *PostgresRepository) scanGroupedCounts(ctx context.Context, query string, args []interface{}, scan func(*sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *PostgresRepository) queryAlertRules(ctx context.Context, query string, args ...interface{}) ([]*entity.AlertRule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []*entity.AlertRule
+	for rows.Next() {
+		rule, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *PostgresRepository) queryAlertDigests(ctx context.Context, query string, args ...interface{}) ([]*entity.AlertDigest, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert digests: %w", err)
+	}
+	defer rows.Close()
+
+	var digests []*entity.AlertDigest
+	for rows.Next() {
+		digest, err := scanAlertDigest(rows)
+		if err != nil {
+			return nil, err
+		}
+		digests = append(digests, digest)
+	}
+	return digests, rows.Err()
+}
+
+func scanAlertRule(row rowScanner) (*entity.AlertRule, error) {
+	rule := &entity.AlertRule{}
+	var severities, classifications, environments, patterns, recipients pq.StringArray
+
+	err := row.Scan(
+		&rule.ID, &rule.TenantID, &rule.Name, &severities, &classifications, &environments, &patterns,
+		&recipients, &rule.IsActive, &rule.EvaluatedUntil, &rule.LastTriggeredAt, &rule.CreatedBy,
+		&rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Severities = severities
+	rule.ClassificationTypes = classifications
+	rule.Environments = environments
+	rule.PatternNames = patterns
+	rule.Recipients = recipients
+	return rule, nil
+}
+
+func scanAlertDigest(row rowScanner) (*entity.AlertDigest, error) {
+	digest := &entity.AlertDigest{}
+	var recipients pq.StringArray
+
+	err := row.Scan(
+		&digest.ID, &digest.TenantID, &digest.Name, &digest.Frequency, &recipients, &digest.IsActive,
+		&digest.NextRunAt, &digest.LastSentAt, &digest.CreatedBy, &digest.CreatedAt, &digest.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	digest.Recipients = recipients
+	return digest, nil
+}
+
+// lowerAll lowercases filter values for case-insensitive matching
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, v := range values {
+		lowered[i] = strings.ToLower(v)
+	}
+	return lowered
+}
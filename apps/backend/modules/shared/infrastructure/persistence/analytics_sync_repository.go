@@ -0,0 +1,150 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Analytics Sink Sync Repository Implementation
+// ============================================================================
+// The sync mirrors every tenant's rows, so these reads are not tenant scoped.
+
+// GetAnalyticsWatermark returns how far a stream has been mirrored into a sink; a
+// stream never synced starts at the beginning
+func (r *PostgresRepository) GetAnalyticsWatermark(ctx context.Context, sink, stream string) (*entity.AnalyticsWatermark, error) {
+	w := &entity.AnalyticsWatermark{Sink: sink, Stream: stream, SyncedUntil: time.Unix(0, 0).UTC()}
+	var (
+		lastRun   sql.NullTime
+		lastError sql.NullString
+	)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT synced_until, last_id, rows_synced, last_run_at, last_error, updated_at
+		FROM analytics_sync_watermarks WHERE sink = $1 AND stream = $2`, sink, stream).
+		Scan(&w.SyncedUntil, &w.LastID, &w.RowsSynced, &lastRun, &lastError, &w.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return w, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics watermark: %w", err)
+	}
+	if lastRun.Valid {
+		w.LastRunAt = &lastRun.Time
+	}
+	w.LastError = lastError.String
+	return w, nil
+}
+
+// ListAnalyticsWatermarks returns the watermarks of every stream of a sink
+func (r *PostgresRepository) ListAnalyticsWatermarks(ctx context.Context, sink string) ([]*entity.AnalyticsWatermark, error) {
+	watermarks := []*entity.AnalyticsWatermark{}
+	for _, stream := range []string{entity.AnalyticsStreamFindings, entity.AnalyticsStreamClassifications} {
+		w, err := r.GetAnalyticsWatermark(ctx, sink, stream)
+		if err != nil {
+			return nil, err
+		}
+		watermarks = append(watermarks, w)
+	}
+	return watermarks, nil
+}
+
+// SaveAnalyticsWatermark advances a stream's watermark after a batch was mirrored
+// and clears its last error
+func (r *PostgresRepository) SaveAnalyticsWatermark(ctx context.Context, w *entity.AnalyticsWatermark, rows int, runAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO analytics_sync_watermarks (sink, stream, synced_until, last_id, rows_synced, last_run_at, last_error, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULL, NOW())
+		ON CONFLICT (sink, stream) DO UPDATE SET
+			synced_until = EXCLUDED.synced_until, last_id = EXCLUDED.last_id,
+			rows_synced = analytics_sync_watermarks.rows_synced + EXCLUDED.rows_synced,
+			last_run_at = EXCLUDED.last_run_at, last_error = NULL, updated_at = NOW()`,
+		w.Sink, w.Stream, w.SyncedUntil, w.LastID, rows, runAt)
+	if err != nil {
+		return fmt.Errorf("failed to save analytics watermark: %w", err)
+	}
+	return nil
+}
+
+// RecordAnalyticsSyncError keeps the error of a failed sync run without moving the watermark
+func (r *PostgresRepository) RecordAnalyticsSyncError(ctx context.Context, sink, stream, message string, runAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO analytics_sync_watermarks (sink, stream, last_run_at, last_error)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (sink, stream) DO UPDATE SET
+			last_run_at = EXCLUDED.last_run_at, last_error = EXCLUDED.last_error, updated_at = NOW()`,
+		sink, stream, runAt, message)
+	return err
+}
+
+// ResetAnalyticsWatermarks makes the next sync mirror a sink from the beginning
+func (r *PostgresRepository) ResetAnalyticsWatermarks(ctx context.Context, sink string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM analytics_sync_watermarks WHERE sink = $1`, sink)
+	return err
+}
+
+// ListFindingsForAnalytics returns findings of every tenant changed after the
+// watermark position and before until, in (updated_at, id) order
+func (r *PostgresRepository) ListFindingsForAnalytics(ctx context.Context, after time.Time, afterID uuid.UUID, until time.Time, limit int) ([]*entity.AnalyticsFinding, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id, f.tenant_id, f.asset_id, f.scan_run_id, f.pattern_name, f.severity, f.confidence_score,
+			COALESCE(a.environment, ''), a.data_source, COALESCE(a.host, ''),
+			f.created_at, f.updated_at, f.deleted_at IS NOT NULL
+		FROM findings f
+		JOIN assets a ON a.id = f.asset_id
+		WHERE f.tenant_id IS NOT NULL AND (f.updated_at, f.id) > ($1, $2) AND f.updated_at < $3
+		ORDER BY f.updated_at, f.id
+		LIMIT $4`, after, afterID, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list findings for analytics: %w", err)
+	}
+	defer rows.Close()
+
+	findings := []*entity.AnalyticsFinding{}
+	for rows.Next() {
+		f := &entity.AnalyticsFinding{}
+		var confidence sql.NullFloat64
+		if err := rows.Scan(&f.ID, &f.TenantID, &f.AssetID, &f.ScanRunID, &f.PatternName, &f.Severity, &confidence,
+			&f.Environment, &f.DataSource, &f.Host, &f.CreatedAt, &f.UpdatedAt, &f.Deleted); err != nil {
+			return nil, err
+		}
+		if confidence.Valid {
+			f.ConfidenceScore = &confidence.Float64
+		}
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// ListClassificationsForAnalytics returns classifications of every tenant changed
+// after the watermark position and before until, in (updated_at, id) order
+func (r *PostgresRepository) ListClassificationsForAnalytics(ctx context.Context, after time.Time, afterID uuid.UUID, until time.Time, limit int) ([]*entity.AnalyticsClassification, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.id, f.tenant_id, c.finding_id, c.classification_type, COALESCE(c.sub_category, ''),
+			c.confidence_score, COALESCE(c.dpdpa_category, ''), COALESCE(c.requires_consent, false),
+			c.created_at, c.updated_at
+		FROM classifications c
+		JOIN findings f ON f.id = c.finding_id
+		WHERE f.tenant_id IS NOT NULL AND (c.updated_at, c.id) > ($1, $2) AND c.updated_at < $3
+		ORDER BY c.updated_at, c.id
+		LIMIT $4`, after, afterID, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classifications for analytics: %w", err)
+	}
+	defer rows.Close()
+
+	classifications := []*entity.AnalyticsClassification{}
+	for rows.Next() {
+		c := &entity.AnalyticsClassification{}
+		if err := rows.Scan(&c.ID, &c.TenantID, &c.FindingID, &c.ClassificationType, &c.SubCategory,
+			&c.ConfidenceScore, &c.DPDPACategory, &c.RequiresConsent, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		classifications = append(classifications, c)
+	}
+	return classifications, rows.Err()
+}
@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Anonymized Export Repository Implementation
+// ============================================================================
+
+// IterateFindingsForExport calls fn with the tenant's findings joined to their
+// asset and latest classification a page at a time, in id order, with match
+// values and samples decrypted
+func (r *PostgresRepository) IterateFindingsForExport(ctx context.Context, fn func(rows []*entity.FindingExportRow) error) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT f.id, f.scan_run_id, f.asset_id, COALESCE(a.name, ''), COALESCE(a.path, ''), COALESCE(a.host, ''),
+			COALESCE(a.data_source, ''), COALESCE(a.environment, ''), f.pattern_name, f.matches,
+			COALESCE(f.sample_text, ''), f.severity, f.confidence_score, f.created_at,
+			COALESCE(c.classification_type, ''), COALESCE(c.sub_category, ''), COALESCE(c.confidence_score, 0),
+			COALESCE(c.dpdpa_category, ''), COALESCE(c.requires_consent, false), COALESCE(c.justification, '')
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN LATERAL (
+			SELECT * FROM classifications WHERE finding_id = f.id ORDER BY created_at DESC LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.id > $2
+		ORDER BY f.id
+		LIMIT $3`
+
+	return iterateByID(ctx, func(ctx context.Context, after uuid.UUID, limit int) ([]*entity.FindingExportRow, uuid.UUID, error) {
+		var page []*entity.FindingExportRow
+		err := r.withStatementTimeout(ctx, QueryBatch, "iterate_findings_for_export", func(ctx context.Context, tx *sql.Tx) error {
+			rows, err := tx.QueryContext(ctx, query, tenantID, after, limit)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				row := &entity.FindingExportRow{}
+				var confidence sql.NullFloat64
+				if err := rows.Scan(&row.FindingID, &row.ScanRunID, &row.AssetID, &row.AssetName, &row.AssetPath,
+					&row.Host, &row.DataSource, &row.Environment, &row.PatternName, pq.Array(&row.Matches),
+					&row.SampleText, &row.Severity, &confidence, &row.CreatedAt,
+					&row.ClassificationType, &row.SubCategory, &row.ClassificationScore,
+					&row.DPDPACategory, &row.RequiresConsent, &row.Justification); err != nil {
+					return err
+				}
+				if confidence.Valid {
+					row.ConfidenceScore = &confidence.Float64
+				}
+				page = append(page, row)
+			}
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			for _, row := range page {
+				if err := openFindingValues(ctx, tx, tenantID, row.Matches, &row.SampleText); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil || len(page) == 0 {
+			return nil, uuid.Nil, err
+		}
+		return page, page[len(page)-1].FindingID, nil
+	}, fn)
+}
@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Asset Scan Freshness Repository Implementation
+// ============================================================================
+
+const assetFreshnessColumns = `
+	id, name, path, host, environment, COALESCE(risk_score, 0), last_scanned_at,
+	expected_scan_frequency_hours, scan_overdue_since, freshness_penalty, COALESCE(created_at, CURRENT_TIMESTAMP)`
+
+// assetDueSQL is when an asset's next scan is due; $2 is the default frequency in hours
+const assetDueSQL = `COALESCE(last_scanned_at, created_at, CURRENT_TIMESTAMP) + make_interval(hours => COALESCE(expected_scan_frequency_hours, $2))`
+
+// GetAssetFreshness returns the scan freshness of one asset, leaving the due date
+// to the caller
+func (r *PostgresRepository) GetAssetFreshness(ctx context.Context, assetID uuid.UUID) (*entity.AssetFreshness, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	row := r.db.QueryRowContext(ctx, `SELECT `+assetFreshnessColumns+`
+		FROM assets WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`, assetID, tenantID)
+	freshness, err := scanAssetFreshness(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("asset not found: %s", assetID)
+	}
+	return freshness, err
+}
+
+// ListOverdueAssets returns live assets whose next scan was due before now,
+// longest overdue first. Assets without their own frequency use defaultHours.
+func (r *PostgresRepository) ListOverdueAssets(ctx context.Context, defaultHours int, now time.Time, limit int) ([]*entity.AssetFreshness, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + assetFreshnessColumns + `
+		FROM assets
+		WHERE tenant_id = $1 AND deleted_at IS NULL AND ` + assetDueSQL + ` < $3
+		ORDER BY ` + assetDueSQL + ` ASC, id
+		LIMIT $4`
+	return r.queryAssetFreshness(ctx, query, tenantID, defaultHours, now, limit)
+}
+
+// ListFreshnessCandidates returns the assets the SLA job must evaluate: those
+// overdue now and those still flagged or penalized from an earlier run
+func (r *PostgresRepository) ListFreshnessCandidates(ctx context.Context, defaultHours int, now time.Time) ([]*entity.AssetFreshness, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT ` + assetFreshnessColumns + `
+		FROM assets
+		WHERE tenant_id = $1 AND deleted_at IS NULL
+			AND (` + assetDueSQL + ` < $3 OR scan_overdue_since IS NOT NULL OR freshness_penalty <> 0)`
+	return r.queryAssetFreshness(ctx, query, tenantID, defaultHours, now)
+}
+
+// SetAssetScanFrequency sets how often an asset must be scanned; nil restores the default
+func (r *PostgresRepository) SetAssetScanFrequency(ctx context.Context, assetID uuid.UUID, hours *int) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE assets SET expected_scan_frequency_hours = $3
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`, assetID, tenantID, hours)
+	if err != nil {
+		return fmt.Errorf("failed to set scan frequency: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("asset not found: %s", assetID)
+	}
+	return nil
+}
+
+// SetFreshnessPenalty swaps an asset's freshness penalty for a new one, moving its
+// risk score by the difference, and flags it overdue since overdueSince (nil clears
+// the flag). Score changes are recorded in the risk score history.
+func (r *PostgresRepository) SetFreshnessPenalty(ctx context.Context, assetID uuid.UUID, penalty int, overdueSince *time.Time) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `
+		WITH prev AS (
+			SELECT id, COALESCE(risk_score, 0) AS risk_score, freshness_penalty FROM assets
+			WHERE id = $2 AND tenant_id = $3 AND deleted_at IS NULL FOR UPDATE
+		), updated AS (
+			UPDATE assets a SET
+				risk_score = LEAST(GREATEST(prev.risk_score - prev.freshness_penalty + $1, 0), 100),
+				freshness_penalty = $1,
+				scan_overdue_since = CASE WHEN $5::timestamp IS NULL THEN NULL ELSE COALESCE(a.scan_overdue_since, $5) END
+			FROM prev WHERE a.id = prev.id
+			RETURNING a.id, a.risk_score
+		)
+		` + recordRiskChangeSQL
+	_, err = r.db.ExecContext(ctx, query, penalty, assetID, tenantID, entity.RiskCauseScanOverdue, overdueSince)
+	if err != nil {
+		return fmt.Errorf("failed to set freshness penalty: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) queryAssetFreshness(ctx context.Context, query string, args ...interface{}) ([]*entity.AssetFreshness, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset freshness: %w", err)
+	}
+	defer rows.Close()
+
+	assets := []*entity.AssetFreshness{}
+	for rows.Next() {
+		freshness, err := scanAssetFreshness(rows)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, freshness)
+	}
+	return assets, rows.Err()
+}
+
+func scanAssetFreshness(row interface{ Scan(...interface{}) error }) (*entity.AssetFreshness, error) {
+	f := &entity.AssetFreshness{}
+	var (
+		host, environment sql.NullString
+		lastScanned       sql.NullTime
+		overdueSince      sql.NullTime
+		frequency         sql.NullInt64
+	)
+	if err := row.Scan(&f.AssetID, &f.AssetName, &f.Path, &host, &environment, &f.RiskScore,
+		&lastScanned, &frequency, &overdueSince, &f.FreshnessPenalty, &f.DiscoveredAt); err != nil {
+		return nil, err
+	}
+	f.Host = host.String
+	f.Environment = environment.String
+	if lastScanned.Valid {
+		f.LastScannedAt = &lastScanned.Time
+	}
+	if overdueSince.Valid {
+		f.OverdueSince = &overdueSince.Time
+	}
+	if frequency.Valid {
+		f.ExpectedFrequencyHours = int(frequency.Int64)
+		f.CustomFrequency = true
+	}
+	return f, nil
+}
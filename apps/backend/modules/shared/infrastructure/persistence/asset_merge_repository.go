@@ -0,0 +1,279 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Asset Merge Repository Implementation
+// ============================================================================
+
+// collidingFindingFilter selects findings of the source asset ($1) that duplicate a
+// target asset ($2) finding of the same value in the same scan run. They cannot be
+// re-pointed without breaking idx_findings_unique, so the target's copy wins.
+const collidingFindingFilter = `
+	f.asset_id = $1 AND EXISTS (
+		SELECT 1 FROM findings t
+		WHERE t.asset_id = $2
+		  AND t.pattern_name = f.pattern_name
+		  AND t.normalized_value_hash = f.normalized_value_hash
+		  AND t.scan_run_id = f.scan_run_id
+	)`
+
+// MergeAssets folds the source asset into the target asset in a single transaction:
+// findings, asset relationships and masking audit entries are re-pointed to the target,
+// the target's counters are refreshed and the source asset is deleted. Remediation
+// actions reference findings and follow them implicitly.
+func (r *PostgresRepository) MergeAssets(ctx context.Context, sourceID, targetID uuid.UUID) (*entity.AssetMergeResult, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &entity.AssetMergeResult{SourceAssetID: sourceID, TargetAssetID: targetID}
+
+	// Lock both assets in a stable order so concurrent merges cannot deadlock
+	rows, err := tx.QueryContext(ctx, `
+		SELECT a.id, row_to_json(a) FROM assets a
+		WHERE a.id = ANY($1::uuid[]) AND a.tenant_id = $2
+		ORDER BY a.id
+		FOR UPDATE`,
+		pq.Array(uuidStrings([]uuid.UUID{sourceID, targetID})), tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock assets: %w", err)
+	}
+	for rows.Next() {
+		var id uuid.UUID
+		var snapshot json.RawMessage
+		if err := rows.Scan(&id, &snapshot); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if id == sourceID {
+			result.SourceAsset = snapshot
+		} else {
+			result.TargetAssetBefore = snapshot
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if result.SourceAsset == nil || result.TargetAssetBefore == nil {
+		return nil, fmt.Errorf("asset not found")
+	}
+
+	if err := r.dropCollidingFindings(ctx, tx, sourceID, targetID, result); err != nil {
+		return nil, err
+	}
+
+	result.MovedFindingIDs, err = queryTxIDs(ctx, tx,
+		`UPDATE findings SET asset_id = $2, updated_at = NOW() WHERE asset_id = $1 RETURNING id`,
+		sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move findings: %w", err)
+	}
+
+	// Exposure history follows the findings to the surviving asset
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE finding_observations SET asset_id = $2 WHERE asset_id = $1`, sourceID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to move finding observations: %w", err)
+	}
+
+	if err := r.moveAssetRelationships(ctx, tx, sourceID, targetID, result); err != nil {
+		return nil, err
+	}
+
+	result.MovedMaskingLogIDs, err = queryTxIDs(ctx, tx,
+		`UPDATE masking_audit_log SET asset_id = $2 WHERE asset_id = $1 RETURNING id`,
+		sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move masking audit log: %w", err)
+	}
+
+	result.RemediationActionIDs, err = queryTxIDs(ctx, tx,
+		`SELECT id FROM remediation_actions WHERE finding_id = ANY($1::uuid[]) ORDER BY executed_at, id`,
+		pq.Array(uuidStrings(result.MovedFindingIDs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediation actions: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		WITH prev AS (
+			SELECT id, risk_score FROM assets WHERE id = $2 AND tenant_id = $3
+		), updated AS (
+			UPDATE assets t SET
+				total_findings = (SELECT COUNT(*) FROM findings f WHERE f.asset_id = t.id),
+				risk_score = GREATEST(t.risk_score, (SELECT s.risk_score FROM assets s WHERE s.id = $1)),
+				updated_at = NOW()
+			FROM prev WHERE t.id = prev.id
+			RETURNING t.id, t.risk_score
+		)
+		`+recordRiskChangeSQL,
+		sourceID, targetID, tenantID, entity.RiskCauseAssetMerge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update target asset: %w", err)
+	}
+
+	// The lineage outbox row of the source asset goes with it (ON DELETE CASCADE)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM assets WHERE id = $1 AND tenant_id = $2`, sourceID, tenantID); err != nil {
+		return nil, fmt.Errorf("failed to delete merged asset: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// dropCollidingFindings deletes source findings the target already holds, keeping
+// their rows (and cascaded classifications and review states) in the result for undo
+func (r *PostgresRepository) dropCollidingFindings(ctx context.Context, tx *sql.Tx, sourceID, targetID uuid.UUID, result *entity.AssetMergeResult) error {
+	var referenced int
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM findings f
+		WHERE `+collidingFindingFilter+`
+		  AND (EXISTS (SELECT 1 FROM remediation_actions ra WHERE ra.finding_id = f.id)
+		    OR EXISTS (SELECT 1 FROM policy_executions pe WHERE pe.finding_id = f.id))`,
+		sourceID, targetID).Scan(&referenced)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicate findings: %w", err)
+	}
+	if referenced > 0 {
+		return fmt.Errorf("%d duplicate findings of the source asset have remediation or policy history and cannot be merged", referenced)
+	}
+
+	result.DroppedClassifications, err = queryTxJSONRows(ctx, tx, `
+		SELECT row_to_json(c) FROM classifications c
+		JOIN findings f ON f.id = c.finding_id
+		WHERE `+collidingFindingFilter+`
+		ORDER BY c.finding_id, c.id`,
+		sourceID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to export duplicate classifications: %w", err)
+	}
+
+	result.DroppedReviewStates, err = queryTxJSONRows(ctx, tx, `
+		SELECT row_to_json(rs) FROM review_states rs
+		JOIN findings f ON f.id = rs.finding_id
+		WHERE `+collidingFindingFilter+`
+		ORDER BY rs.finding_id, rs.id`,
+		sourceID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to export duplicate review states: %w", err)
+	}
+
+	result.DroppedFindings, err = queryTxJSONRows(ctx, tx, `
+		DELETE FROM findings f
+		WHERE `+collidingFindingFilter+`
+		RETURNING row_to_json(f)`,
+		sourceID, targetID)
+	if err != nil {
+		return fmt.Errorf("failed to drop duplicate findings: %w", err)
+	}
+
+	return nil
+}
+
+// moveAssetRelationships re-points relationship edges from the source to the target.
+// Edges between the two assets would become self-loops and edges the target already
+// has would violate the unique constraint; both are deleted and kept in the result.
+func (r *PostgresRepository) moveAssetRelationships(ctx context.Context, tx *sql.Tx, sourceID, targetID uuid.UUID, result *entity.AssetMergeResult) error {
+	dropQueries := []string{
+		`DELETE FROM asset_relationships r
+		WHERE r.source_asset_id IN ($1, $2) AND r.target_asset_id IN ($1, $2)
+		  AND (r.source_asset_id = $1 OR r.target_asset_id = $1)
+		RETURNING row_to_json(r)`,
+		`DELETE FROM asset_relationships r
+		WHERE r.source_asset_id = $1 AND EXISTS (
+			SELECT 1 FROM asset_relationships o
+			WHERE o.source_asset_id = $2 AND o.target_asset_id = r.target_asset_id
+			  AND o.relationship_type = r.relationship_type)
+		RETURNING row_to_json(r)`,
+		`DELETE FROM asset_relationships r
+		WHERE r.target_asset_id = $1 AND EXISTS (
+			SELECT 1 FROM asset_relationships o
+			WHERE o.target_asset_id = $2 AND o.source_asset_id = r.source_asset_id
+			  AND o.relationship_type = r.relationship_type)
+		RETURNING row_to_json(r)`,
+	}
+	for _, query := range dropQueries {
+		dropped, err := queryTxJSONRows(ctx, tx, query, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to drop duplicate relationships: %w", err)
+		}
+		result.DroppedRelationships = append(result.DroppedRelationships, dropped...)
+	}
+
+	moves := []struct {
+		end   string
+		query string
+	}{
+		{"source", `UPDATE asset_relationships SET source_asset_id = $2 WHERE source_asset_id = $1 RETURNING id`},
+		{"target", `UPDATE asset_relationships SET target_asset_id = $2 WHERE target_asset_id = $1 RETURNING id`},
+	}
+	result.MovedRelationships = []entity.MovedRelationship{}
+	for _, move := range moves {
+		ids, err := queryTxIDs(ctx, tx, move.query, sourceID, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to move relationships: %w", err)
+		}
+		for _, id := range ids {
+			result.MovedRelationships = append(result.MovedRelationships, entity.MovedRelationship{ID: id, End: move.end})
+		}
+	}
+
+	return nil
+}
+
+func queryTxIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func queryTxJSONRows(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]json.RawMessage, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []json.RawMessage
+	for rows.Next() {
+		var row json.RawMessage
+		if err := rows.Scan(&row); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
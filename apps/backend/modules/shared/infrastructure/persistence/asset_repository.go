@@ -4,17 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // ============================================================================
 // AssetRepository Implementation
 // ============================================================================
 
+// ErrAssetNotFound is returned for an asset ID with no live asset in the tenant
+var ErrAssetNotFound = errors.New("asset not found")
+
 func (r *PostgresRepository) CreateAsset(ctx context.Context, asset *entity.Asset) error {
 	metadataJSON, err := json.Marshal(asset.FileMetadata)
 	if err != nil {
@@ -49,8 +54,8 @@ func (r *PostgresRepository) GetAssetByID(ctx context.Context, id uuid.UUID) (*e
 
 	query := `
 		SELECT id, tenant_id, stable_id, asset_type, name, path, data_source, host, 
-			environment, owner, source_system, file_metadata, risk_score, total_findings, created_at, updated_at
-		FROM assets WHERE id = $1 AND tenant_id = $2`
+			environment, owner, source_system, file_metadata, risk_score, total_findings, org_unit_id, created_at, updated_at
+		FROM assets WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
 
 	asset := &entity.Asset{}
 	var metadataJSON []byte
@@ -58,12 +63,12 @@ func (r *PostgresRepository) GetAssetByID(ctx context.Context, id uuid.UUID) (*e
 	err = r.db.QueryRowContext(ctx, query, id, tenantID).Scan(
 		&asset.ID, &asset.TenantID, &asset.StableID, &asset.AssetType, &asset.Name, &asset.Path,
 		&asset.DataSource, &asset.Host, &asset.Environment, &asset.Owner, &asset.SourceSystem,
-		&metadataJSON, &asset.RiskScore, &asset.TotalFindings, &asset.CreatedAt, &asset.UpdatedAt,
+		&metadataJSON, &asset.RiskScore, &asset.TotalFindings, &asset.OrgUnitID, &asset.CreatedAt, &asset.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("asset not found")
+			return nil, ErrAssetNotFound
 		}
 		return nil, err
 	}
@@ -113,7 +118,7 @@ func (r *PostgresRepository) GetAssetByStableID(ctx context.Context, stableID st
 	return asset, nil
 }
 
-func (r *PostgresRepository) ListAssets(ctx context.Context, limit, offset int) ([]*entity.Asset, error) {
+func (r *PostgresRepository) ListAssets(ctx context.Context, limit, offset int) (assets []*entity.Asset, err error) {
 	tenantID, err := EnsureTenantID(ctx)
 	if err != nil {
 		return nil, err
@@ -123,16 +128,58 @@ func (r *PostgresRepository) ListAssets(ctx context.Context, limit, offset int)
 		SELECT id, tenant_id, stable_id, asset_type, name, path, data_source, host, 
 			environment, owner, source_system, file_metadata, risk_score, total_findings, created_at, updated_at
 		FROM assets 
-		WHERE tenant_id = $1
+		WHERE tenant_id = $1 AND deleted_at IS NULL
 		ORDER BY risk_score DESC
 		LIMIT $2 OFFSET $3`
 
+	ctx, done := beginQuery(ctx, QueryInteractive, "list_assets")
+	defer func() { done(err) }()
+
 	rows, err := r.db.QueryContext(ctx, query, tenantID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanAssetRows(rows)
+}
+
+// IterateAssets calls fn with the tenant's assets a page at a time, in id order,
+// for sweeps over every asset. Each page is a separate batch query.
+func (r *PostgresRepository) IterateAssets(ctx context.Context, fn func(assets []*entity.Asset) error) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT id, tenant_id, stable_id, asset_type, name, path, data_source, host,
+			environment, owner, source_system, file_metadata, risk_score, total_findings, created_at, updated_at
+		FROM assets
+		WHERE tenant_id = $1 AND deleted_at IS NULL AND id > $2
+		ORDER BY id
+		LIMIT $3`
+
+	return iterateByID(ctx, func(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Asset, uuid.UUID, error) {
+		var page []*entity.Asset
+		err := r.withStatementTimeout(ctx, QueryBatch, "iterate_assets", func(ctx context.Context, tx *sql.Tx) error {
+			rows, err := tx.QueryContext(ctx, query, tenantID, after, limit)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			page, err = scanAssetRows(rows)
+			return err
+		})
+		if err != nil || len(page) == 0 {
+			return nil, uuid.Nil, err
+		}
+		return page, page[len(page)-1].ID, nil
+	}, fn)
+}
+
+// scanAssetRows reads assets selected in ListAssets column order
+func scanAssetRows(rows *sql.Rows) ([]*entity.Asset, error) {
 	var assets []*entity.Asset
 	for rows.Next() {
 		asset := &entity.Asset{}
@@ -159,23 +206,88 @@ func (r *PostgresRepository) ListAssets(ctx context.Context, limit, offset int)
 	return assets, rows.Err()
 }
 
-func (r *PostgresRepository) UpdateAssetRiskScore(ctx context.Context, id uuid.UUID, score int) error {
+// UpdateAssetRiskScore sets an asset's risk score and records the change in its risk history
+func (r *PostgresRepository) UpdateAssetRiskScore(ctx context.Context, id uuid.UUID, score int, cause string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `
+		WITH prev AS (
+			SELECT id, risk_score FROM assets WHERE id = $2 AND tenant_id = $3 FOR UPDATE
+		), updated AS (
+			UPDATE assets a SET risk_score = $1 FROM prev WHERE a.id = prev.id RETURNING a.id, a.risk_score
+		)
+		` + recordRiskChangeSQL
+	_, err = r.db.ExecContext(ctx, query, score, id, tenantID, cause)
+	return err
+}
+
+// UpdateAssetStats sets an asset's risk score and finding count, recording a
+// risk history entry when the score changed
+func (r *PostgresRepository) UpdateAssetStats(ctx context.Context, id uuid.UUID, score int, totalFindings int, cause string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	query := `
+		WITH prev AS (
+			SELECT id, risk_score FROM assets WHERE id = $2 AND tenant_id = $3 FOR UPDATE
+		), updated AS (
+			UPDATE assets a SET risk_score = $1, total_findings = $5 FROM prev WHERE a.id = prev.id RETURNING a.id, a.risk_score
+		)
+		` + recordRiskChangeSQL
+	_, err = r.db.ExecContext(ctx, query, score, id, tenantID, cause, totalFindings)
+	return err
+}
+
+// MarkAssetScanned records that an asset was scanned at the given time, which
+// clears its overdue flag and freshness penalty. The caller recomputes the
+// risk score straight after.
+func (r *PostgresRepository) MarkAssetScanned(ctx context.Context, id uuid.UUID, at time.Time) error {
 	tenantID, err := EnsureTenantID(ctx)
 	if err != nil {
 		return err
 	}
-	query := `UPDATE assets SET risk_score = $1 WHERE id = $2 AND tenant_id = $3`
-	_, err = r.db.ExecContext(ctx, query, score, id, tenantID)
+	query := `
+		UPDATE assets
+		SET last_scanned_at = GREATEST(COALESCE(last_scanned_at, $3), $3),
+			scan_overdue_since = NULL, freshness_penalty = 0
+		WHERE id = $1 AND tenant_id = $2`
+	_, err = r.db.ExecContext(ctx, query, id, tenantID, at)
 	return err
 }
 
-func (r *PostgresRepository) UpdateAssetStats(ctx context.Context, id uuid.UUID, score int, totalFindings int) error {
+// UpdateAssetStableID re-keys an asset after its canonical identity changed
+func (r *PostgresRepository) UpdateAssetStableID(ctx context.Context, id uuid.UUID, stableID string) error {
 	tenantID, err := EnsureTenantID(ctx)
 	if err != nil {
 		return err
 	}
-	query := `UPDATE assets SET risk_score = $1, total_findings = $2 WHERE id = $3 AND tenant_id = $4`
-	_, err = r.db.ExecContext(ctx, query, score, totalFindings, id, tenantID)
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE assets SET stable_id = $3, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID, stableID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("stable ID %s is already used by another asset", stableID)
+		}
+		return fmt.Errorf("failed to update asset stable ID: %w", err)
+	}
+	return nil
+}
+
+// MergeAssetMetadata merges keys into an asset's file metadata, overwriting existing keys
+func (r *PostgresRepository) MergeAssetMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	query := `UPDATE assets SET file_metadata = COALESCE(file_metadata, '{}'::jsonb) || $1::jsonb, updated_at = NOW() WHERE id = $2 AND tenant_id = $3`
+	_, err = r.db.ExecContext(ctx, query, metadataJSON, id, tenantID)
 	return err
 }
 
@@ -189,7 +301,7 @@ func (r *PostgresRepository) GetHighRiskAssets(ctx context.Context, threshold in
 		SELECT id, tenant_id, stable_id, asset_type, name, path, data_source, host, 
 			environment, owner, source_system, file_metadata, risk_score, total_findings, created_at, updated_at
 		FROM assets 
-		WHERE risk_score >= $1 AND tenant_id = $2
+		WHERE risk_score >= $1 AND tenant_id = $2 AND deleted_at IS NULL
 		ORDER BY risk_score DESC`
 
 	rows, err := r.db.QueryContext(ctx, query, threshold, tenantID)
@@ -258,7 +370,7 @@ func (r *PostgresRepository) GetMaskedAssets(ctx context.Context) ([]*entity.Ass
 			environment, owner, source_system, file_metadata, risk_score, total_findings,
 			is_masked, masked_at, masking_strategy, created_at, updated_at
 		FROM assets 
-		WHERE is_masked = true AND tenant_id = $1
+		WHERE is_masked = true AND tenant_id = $1 AND deleted_at IS NULL
 		ORDER BY masked_at DESC`
 
 	rows, err := r.db.QueryContext(ctx, query, tenantID)
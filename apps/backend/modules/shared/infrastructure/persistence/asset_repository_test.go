@@ -25,7 +25,7 @@ func TestPostgresRepository_ListAssets_TenantIsolation(t *testing.T) {
 
 	// Expectation: Query MUST include "WHERE tenant_id = $1"
 	// We use regex to match the query flexible
-	query := `SELECT id, tenant_id, .* FROM assets WHERE tenant_id = \$1 ORDER BY risk_score DESC LIMIT \$2 OFFSET \$3`
+	query := `SELECT id, tenant_id, .* FROM assets WHERE tenant_id = \$1 AND deleted_at IS NULL ORDER BY risk_score DESC LIMIT \$2 OFFSET \$3`
 
 	rows := sqlmock.NewRows([]string{
 		"id", "tenant_id", "stable_id", "asset_type", "name", "path", "data_source", "host",
@@ -0,0 +1,159 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Audit Export Repository Implementation
+// ============================================================================
+
+// ListAuditEvents returns audit events in delivery order (created_at, id).
+// Audit export spans every tenant, so no tenant filter is applied.
+func (r *PostgresRepository) ListAuditEvents(ctx context.Context, filters repository.AuditEventFilters, limit int) ([]*entity.AuditEvent, error) {
+	conditions := []string{"1=1"}
+	args := []interface{}{}
+
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filters.AfterCreatedAt != nil {
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) > (%s, %s)",
+			addArg(*filters.AfterCreatedAt), addArg(filters.AfterID)))
+	}
+	if filters.Since != nil {
+		conditions = append(conditions, "created_at >= "+addArg(*filters.Since))
+	}
+	if filters.Until != nil {
+		conditions = append(conditions, "created_at <= "+addArg(*filters.Until))
+	}
+	if len(filters.Actions) > 0 {
+		conditions = append(conditions, "action = ANY("+addArg(pq.Array(filters.Actions))+"::text[])")
+	}
+
+	query := `
+		SELECT id, tenant_id, COALESCE(event_type, ''), action, COALESCE(user_id, ''),
+			COALESCE(resource_type, ''), COALESCE(resource_id, ''), COALESCE(ip_address, ''),
+			COALESCE(user_agent, ''), COALESCE(result, ''), metadata,
+			COALESCE(event_time, created_at), created_at
+		FROM audit_logs
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at, id
+		LIMIT ` + addArg(limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*entity.AuditEvent
+	for rows.Next() {
+		event := &entity.AuditEvent{}
+		var tenantID uuid.NullUUID
+		var metadata []byte
+
+		if err := rows.Scan(
+			&event.ID, &tenantID, &event.EventType, &event.Action, &event.UserID,
+			&event.ResourceType, &event.ResourceID, &event.IPAddress,
+			&event.UserAgent, &event.Result, &metadata,
+			&event.EventTime, &event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if tenantID.Valid {
+			event.TenantID = &tenantID.UUID
+		}
+		if len(metadata) > 0 {
+			// Metadata is best-effort context; an unparsable payload is dropped, not fatal
+			_ = json.Unmarshal(metadata, &event.Metadata)
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetAuditExportCursor returns the export cursor of a sink, or nil if the sink never exported
+func (r *PostgresRepository) GetAuditExportCursor(ctx context.Context, sinkName string) (*entity.AuditExportCursor, error) {
+	cursor := &entity.AuditExportCursor{}
+	var lastError sql.NullString
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT sink_name, last_created_at, last_event_id, exported_count, last_error, updated_at
+		FROM audit_export_cursors WHERE sink_name = $1`, sinkName,
+	).Scan(&cursor.SinkName, &cursor.LastCreatedAt, &cursor.LastEventID, &cursor.ExportedCount, &lastError, &cursor.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cursor.LastError = lastError.String
+	return cursor, nil
+}
+
+// AdvanceAuditExportCursor moves a sink's cursor to the last delivered event and clears its error
+func (r *PostgresRepository) AdvanceAuditExportCursor(ctx context.Context, sinkName string, last *entity.AuditEvent, delivered int) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_export_cursors (sink_name, last_created_at, last_event_id, exported_count, last_error, updated_at)
+		VALUES ($1, $2, $3, $4, NULL, NOW())
+		ON CONFLICT (sink_name) DO UPDATE SET
+			last_created_at = EXCLUDED.last_created_at,
+			last_event_id = EXCLUDED.last_event_id,
+			exported_count = audit_export_cursors.exported_count + EXCLUDED.exported_count,
+			last_error = NULL,
+			updated_at = NOW()`,
+		sinkName, last.CreatedAt, last.ID, delivered)
+	if err != nil {
+		return fmt.Errorf("failed to advance audit export cursor: %w", err)
+	}
+	return nil
+}
+
+// RecordAuditExportError stores the last delivery error of a sink without moving its cursor.
+// Sinks that have never delivered an event have no cursor row and are left untouched.
+func (r *PostgresRepository) RecordAuditExportError(ctx context.Context, sinkName string, exportErr error) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE audit_export_cursors SET last_error = $2, updated_at = NOW() WHERE sink_name = $1`,
+		sinkName, exportErr.Error())
+	return err
+}
+
+// ListAuditExportCursors returns the cursors of every sink that has exported
+func (r *PostgresRepository) ListAuditExportCursors(ctx context.Context) ([]*entity.AuditExportCursor, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT sink_name, last_created_at, last_event_id, exported_count, last_error, updated_at
+		FROM audit_export_cursors ORDER BY sink_name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cursors []*entity.AuditExportCursor
+	for rows.Next() {
+		cursor := &entity.AuditExportCursor{}
+		var lastError sql.NullString
+		if err := rows.Scan(&cursor.SinkName, &cursor.LastCreatedAt, &cursor.LastEventID,
+			&cursor.ExportedCount, &lastError, &cursor.UpdatedAt); err != nil {
+			return nil, err
+		}
+		cursor.LastError = lastError.String
+		cursors = append(cursors, cursor)
+	}
+
+	return cursors, rows.Err()
+}
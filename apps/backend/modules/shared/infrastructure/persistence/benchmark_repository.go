@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Benchmark Repository Implementation
+// ============================================================================
+
+// ListTenantBenchmarkMetrics returns the exposure figures of every tenant that has not
+// opted out of benchmarking. It spans all tenants and must only feed aggregates.
+func (r *PostgresRepository) ListTenantBenchmarkMetrics(ctx context.Context) ([]*entity.TenantBenchmarkMetrics, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.tenant_id,
+			(SELECT COUNT(*) FROM assets a WHERE a.tenant_id = f.tenant_id AND a.deleted_at IS NULL),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE f.created_at <= NOW() - INTERVAL '30 days'),
+			COUNT(*) FILTER (WHERE f.created_at <= NOW() - INTERVAL '30 days' AND (
+				EXISTS (
+					SELECT 1 FROM remediation_actions ra
+					WHERE ra.finding_id = f.id AND ra.status = 'COMPLETED'
+					  AND ra.executed_at <= f.created_at + INTERVAL '30 days'
+				)
+				OR EXISTS (
+					SELECT 1 FROM review_states rs
+					WHERE rs.finding_id = f.id AND rs.status = $1
+					  AND COALESCE(rs.reviewed_at, rs.created_at) <= f.created_at + INTERVAL '30 days'
+				)
+			))
+		FROM findings f
+		WHERE f.tenant_id IS NOT NULL AND f.deleted_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM benchmark_opt_outs o WHERE o.tenant_id = f.tenant_id)
+		GROUP BY f.tenant_id`,
+		entity.ReviewStatusResolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query benchmark metrics: %w", err)
+	}
+	defer rows.Close()
+
+	byTenant := make(map[uuid.UUID]*entity.TenantBenchmarkMetrics)
+	var metrics []*entity.TenantBenchmarkMetrics
+	for rows.Next() {
+		m := &entity.TenantBenchmarkMetrics{PatternCounts: make(map[string]int)}
+		if err := rows.Scan(&m.TenantID, &m.AssetCount, &m.FindingCount, &m.EligibleFindings, &m.RemediatedWithin30Days); err != nil {
+			return nil, err
+		}
+		byTenant[m.TenantID] = m
+		metrics = append(metrics, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	patternRows, err := r.db.QueryContext(ctx, `
+		SELECT f.tenant_id, f.pattern_name, COUNT(*)
+		FROM findings f
+		WHERE f.tenant_id IS NOT NULL AND f.deleted_at IS NULL
+		  AND NOT EXISTS (SELECT 1 FROM benchmark_opt_outs o WHERE o.tenant_id = f.tenant_id)
+		GROUP BY f.tenant_id, f.pattern_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query benchmark pattern counts: %w", err)
+	}
+	defer patternRows.Close()
+
+	for patternRows.Next() {
+		var tenantID uuid.UUID
+		var pattern string
+		var count int
+		if err := patternRows.Scan(&tenantID, &pattern, &count); err != nil {
+			return nil, err
+		}
+		if m, ok := byTenant[tenantID]; ok {
+			m.PatternCounts[pattern] = count
+		}
+	}
+
+	return metrics, patternRows.Err()
+}
+
+// GetBenchmarkParticipation reports whether the tenant has opted out of benchmarking
+func (r *PostgresRepository) GetBenchmarkParticipation(ctx context.Context) (*entity.BenchmarkParticipation, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &entity.BenchmarkParticipation{TenantID: tenantID}
+	var optedOutAt sql.NullTime
+	err = r.db.QueryRowContext(ctx,
+		`SELECT opted_out_by, opted_out_at FROM benchmark_opt_outs WHERE tenant_id = $1`, tenantID,
+	).Scan(&p.OptedOutBy, &optedOutAt)
+	if err == sql.ErrNoRows {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benchmark participation: %w", err)
+	}
+
+	p.OptedOut = true
+	p.OptedOutAt = &optedOutAt.Time
+	return p, nil
+}
+
+// SetBenchmarkOptOut opts the tenant out of, or back into, benchmark baselines
+func (r *PostgresRepository) SetBenchmarkOptOut(ctx context.Context, optOut bool, updatedBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if optOut {
+		_, err = r.db.ExecContext(ctx, `
+			INSERT INTO benchmark_opt_outs (tenant_id, opted_out_by)
+			VALUES ($1, $2)
+			ON CONFLICT (tenant_id) DO NOTHING`,
+			tenantID, updatedBy)
+	} else {
+		_, err = r.db.ExecContext(ctx, `DELETE FROM benchmark_opt_outs WHERE tenant_id = $1`, tenantID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update benchmark participation: %w", err)
+	}
+	return nil
+}
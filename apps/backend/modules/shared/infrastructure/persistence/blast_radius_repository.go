@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Blast Radius Repository Implementation
+// ============================================================================
+
+// GetAssetRiskScores returns the risk score of each given asset that belongs to the
+// tenant and is not deleted; other IDs are left out
+func (r *PostgresRepository) GetAssetRiskScores(ctx context.Context, assetIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, risk_score FROM assets
+		WHERE tenant_id = $1 AND id = ANY($2) AND deleted_at IS NULL`,
+		tenantID, pq.Array(assetIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset risk scores: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make(map[uuid.UUID]int, len(assetIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		var score int
+		if err := rows.Scan(&id, &score); err != nil {
+			return nil, err
+		}
+		scores[id] = score
+	}
+	return scores, rows.Err()
+}
+
+// ListValueFlows returns the tenant's other assets holding values observed in the
+// given assets, those sharing the most values first
+func (r *PostgresRepository) ListValueFlows(ctx context.Context, assetIDs []uuid.UUID) ([]entity.ValueFlow, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		WITH own AS (
+			SELECT DISTINCT value_hash FROM finding_observations
+			WHERE tenant_id = $1 AND asset_id = ANY($2)
+		)
+		SELECT other.asset_id, a.name, a.path, COALESCE(a.host, ''),
+			COALESCE(NULLIF(a.source_system, ''), a.data_source, ''), COALESCE(a.environment, ''), a.risk_score,
+			ARRAY_AGG(DISTINCT other.pattern_name), COUNT(DISTINCT other.value_hash)
+		FROM own
+		JOIN finding_observations other
+		  ON other.tenant_id = $1 AND other.value_hash = own.value_hash AND NOT (other.asset_id = ANY($2))
+		JOIN assets a ON a.id = other.asset_id AND a.deleted_at IS NULL
+		GROUP BY other.asset_id, a.name, a.path, a.host, a.source_system, a.data_source, a.environment, a.risk_score
+		ORDER BY COUNT(DISTINCT other.value_hash) DESC, a.name`,
+		tenantID, pq.Array(assetIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query value flows: %w", err)
+	}
+	defer rows.Close()
+
+	flows := []entity.ValueFlow{}
+	for rows.Next() {
+		var f entity.ValueFlow
+		if err := rows.Scan(&f.AssetID, &f.AssetName, &f.AssetPath, &f.Host, &f.System, &f.Environment,
+			&f.RiskScore, pq.Array(&f.PatternNames), &f.SharedValues); err != nil {
+			return nil, err
+		}
+		flows = append(flows, f)
+	}
+	return flows, rows.Err()
+}
@@ -2,7 +2,7 @@ package persistence
 
 import (
 	"context"
-
+	"encoding/json"
 	"fmt"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
@@ -14,16 +14,23 @@ import (
 // ============================================================================
 
 func (r *PostgresRepository) CreateClassification(ctx context.Context, classification *entity.Classification) error {
+	breakdownJSON, err := marshalSignalBreakdown(classification.SignalBreakdown)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO classifications (id, finding_id, classification_type, sub_category, 
-			confidence_score, justification, dpdpa_category, requires_consent, retention_period)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			confidence_score, justification, dpdpa_category, requires_consent, retention_period,
+			signal_breakdown, classifier_version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, COALESCE(NULLIF($11, ''), 'v2.0-multisignal'))
 		RETURNING created_at, updated_at`
 
 	return r.db.QueryRowContext(ctx, query,
 		classification.ID, classification.FindingID, classification.ClassificationType,
 		classification.SubCategory, classification.ConfidenceScore, classification.Justification,
 		classification.DPDPACategory, classification.RequiresConsent, classification.RetentionPeriod,
+		breakdownJSON, classification.EngineVersion,
 	).Scan(&classification.CreatedAt, &classification.UpdatedAt)
 }
 
@@ -31,7 +38,7 @@ func (r *PostgresRepository) GetClassificationsByFindingID(ctx context.Context,
 	query := `
 		SELECT id, finding_id, classification_type, sub_category, confidence_score, 
 			justification, dpdpa_category, requires_consent, retention_period, 
-			created_at, updated_at
+			signal_breakdown, COALESCE(classifier_version, ''), created_at, updated_at
 		FROM classifications 
 		WHERE finding_id = $1`
 
@@ -45,17 +52,24 @@ func (r *PostgresRepository) GetClassificationsByFindingID(ctx context.Context,
 	for rows.Next() {
 		c := &entity.Classification{}
 		var retentionPeriod *string // Use pointer to handle NULL
+		var breakdownJSON []byte
 
 		err := rows.Scan(
 			&c.ID, &c.FindingID, &c.ClassificationType, &c.SubCategory,
 			&c.ConfidenceScore, &c.Justification, &c.DPDPACategory,
 			&c.RequiresConsent, &retentionPeriod,
-			&c.CreatedAt, &c.UpdatedAt,
+			&breakdownJSON, &c.EngineVersion, &c.CreatedAt, &c.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		if len(breakdownJSON) > 0 {
+			if err := json.Unmarshal(breakdownJSON, &c.SignalBreakdown); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal signal breakdown: %w", err)
+			}
+		}
+
 		// Handle NULL retention_period
 		if retentionPeriod != nil {
 			c.RetentionPeriod = *retentionPeriod
@@ -159,3 +173,11 @@ func (r *PostgresRepository) GetClassificationSummary(ctx context.Context) (map[
 
 	return summary, rows.Err()
 }
+
+// marshalSignalBreakdown encodes a classification's signal breakdown, storing NULL when there is none
+func marshalSignalBreakdown(breakdown map[string]interface{}) ([]byte, error) {
+	if len(breakdown) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(breakdown)
+}
@@ -0,0 +1,148 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Compliance Mapping Repository Implementation
+// ============================================================================
+
+const complianceMappingColumns = `id, tenant_id, framework, version, classification_categories, pii_type_categories,
+	comment, created_by, created_at`
+
+// CreateComplianceMapping stores the mapping as the tenant's next version for its framework
+func (r *PostgresRepository) CreateComplianceMapping(ctx context.Context, mapping *entity.ComplianceMapping) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	mapping.TenantID = tenantID
+
+	classificationJSON, err := json.Marshal(mapping.ClassificationCategories)
+	if err != nil {
+		return fmt.Errorf("failed to marshal classification categories: %w", err)
+	}
+	piiTypeJSON, err := json.Marshal(mapping.PIITypeCategories)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PII type categories: %w", err)
+	}
+
+	query := `
+		INSERT INTO compliance_mappings (id, tenant_id, framework, version, classification_categories, pii_type_categories, comment, created_by)
+		SELECT $1, $2, $3, COALESCE(MAX(version), 0) + 1, $4, $5, $6, $7
+		FROM compliance_mappings
+		WHERE tenant_id = $2 AND framework = $3
+		RETURNING version, created_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		mapping.ID, mapping.TenantID, mapping.Framework, classificationJSON, piiTypeJSON,
+		mapping.Comment, mapping.CreatedBy,
+	).Scan(&mapping.Version, &mapping.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("%s mapping was updated concurrently, please retry", mapping.Framework)
+		}
+		return fmt.Errorf("failed to create compliance mapping: %w", err)
+	}
+
+	return nil
+}
+
+// GetCurrentComplianceMapping retrieves the tenant's latest mapping version for a framework.
+// It returns nil when the tenant has not saved a mapping for the framework.
+func (r *PostgresRepository) GetCurrentComplianceMapping(ctx context.Context, framework string) (*entity.ComplianceMapping, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + complianceMappingColumns + `
+		FROM compliance_mappings
+		WHERE tenant_id = $1 AND framework = $2
+		ORDER BY version DESC
+		LIMIT 1`
+
+	mapping, err := scanComplianceMapping(r.db.QueryRowContext(ctx, query, tenantID, framework))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return mapping, err
+}
+
+// GetComplianceMappingVersion retrieves one version of the tenant's mapping for a framework
+func (r *PostgresRepository) GetComplianceMappingVersion(ctx context.Context, framework string, version int) (*entity.ComplianceMapping, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + complianceMappingColumns + `
+		FROM compliance_mappings
+		WHERE tenant_id = $1 AND framework = $2 AND version = $3`
+
+	mapping, err := scanComplianceMapping(r.db.QueryRowContext(ctx, query, tenantID, framework, version))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("compliance mapping version not found")
+	}
+	return mapping, err
+}
+
+// ListComplianceMappings retrieves the tenant's mapping history for a framework, newest first
+func (r *PostgresRepository) ListComplianceMappings(ctx context.Context, framework string) ([]*entity.ComplianceMapping, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + complianceMappingColumns + `
+		FROM compliance_mappings
+		WHERE tenant_id = $1 AND framework = $2
+		ORDER BY version DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, framework)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var mappings []*entity.ComplianceMapping
+	for rows.Next() {
+		mapping, err := scanComplianceMapping(rows)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, rows.Err()
+}
+
+func scanComplianceMapping(row rowScanner) (*entity.ComplianceMapping, error) {
+	mapping := &entity.ComplianceMapping{}
+	var classificationJSON, piiTypeJSON []byte
+
+	err := row.Scan(
+		&mapping.ID, &mapping.TenantID, &mapping.Framework, &mapping.Version,
+		&classificationJSON, &piiTypeJSON, &mapping.Comment, &mapping.CreatedBy, &mapping.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(classificationJSON, &mapping.ClassificationCategories); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal classification categories: %w", err)
+	}
+	if err := json.Unmarshal(piiTypeJSON, &mapping.PIITypeCategories); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PII type categories: %w", err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Compliance Score Repository Implementation
+// ============================================================================
+
+// GetComplianceScoreInputs counts what the tenant's compliance posture score is
+// computed from. Findings are open unless deleted or last reviewed as resolved
+// or false positive; reviews are overdue once pending since before reviewDue,
+// and velocity counts findings found and closed since velocitySince.
+func (r *PostgresRepository) GetComplianceScoreInputs(ctx context.Context, reviewDue, velocitySince time.Time) (*entity.ComplianceScoreInputs, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &entity.ComplianceScoreInputs{}
+	err = r.db.QueryRowContext(ctx, `
+		WITH live AS (
+			SELECT f.id, f.asset_id, f.severity, f.created_at,
+				COALESCE((
+					SELECT status FROM review_states
+					WHERE finding_id = f.id
+					ORDER BY updated_at DESC
+					LIMIT 1
+				), 'pending') AS review_status
+			FROM findings f
+			WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+		), open_findings AS (
+			SELECT * FROM live WHERE review_status NOT IN ('resolved', 'false_positive')
+		), closed AS (
+			SELECT rs.finding_id FROM review_states rs
+			JOIN findings f ON f.id = rs.finding_id
+			WHERE f.tenant_id = $1 AND rs.status = 'resolved' AND rs.updated_at >= $3
+			UNION
+			SELECT ra.finding_id FROM remediation_actions ra
+			JOIN findings f ON f.id = ra.finding_id
+			WHERE f.tenant_id = $1 AND ra.status = 'COMPLETED' AND ra.executed_at >= $3
+		)
+		SELECT
+			(SELECT COUNT(*) FROM assets WHERE tenant_id = $1 AND deleted_at IS NULL),
+			(SELECT COUNT(DISTINCT o.asset_id) FROM open_findings o
+				JOIN assets a ON a.id = o.asset_id
+				WHERE LOWER(o.severity) IN ('critical', 'high') AND NOT a.is_masked AND a.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM open_findings),
+			(SELECT COUNT(*) FROM open_findings WHERE review_status = 'pending' AND created_at < $2),
+			(SELECT COUNT(*) FROM findings WHERE tenant_id = $1 AND created_at >= $3),
+			(SELECT COUNT(*) FROM closed),
+			(SELECT COUNT(*) FROM policy_rules WHERE tenant_id = $1 AND is_active),
+			(SELECT COUNT(DISTINCT v.rule_id) FROM policy_violations v
+				JOIN policy_rules p ON p.id = v.rule_id
+				WHERE v.tenant_id = $1 AND v.status = 'open' AND p.is_active),
+			(SELECT COUNT(*) FROM policy_violations v
+				JOIN policy_rules p ON p.id = v.rule_id
+				WHERE v.tenant_id = $1 AND v.status = 'open' AND p.is_active)`,
+		tenantID, reviewDue, velocitySince,
+	).Scan(&in.TotalAssets, &in.ExposedAssets, &in.OpenFindings, &in.OverdueReviews, &in.NewFindings,
+		&in.ClosedFindings, &in.ActiveRules, &in.ViolatedRules, &in.OpenViolations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count compliance score inputs: %w", err)
+	}
+	return in, nil
+}
+
+// SaveComplianceScoreSnapshot stores the tenant's score for the snapshot's day,
+// replacing an earlier one from the same day
+func (r *PostgresRepository) SaveComplianceScoreSnapshot(ctx context.Context, snapshot *entity.ComplianceScoreSnapshot) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	components, err := json.Marshal(snapshot.Components)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO compliance_score_snapshots (tenant_id, snapshot_date, score, components, computed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id, snapshot_date) DO UPDATE SET
+			score = EXCLUDED.score, components = EXCLUDED.components, computed_at = EXCLUDED.computed_at`,
+		tenantID, snapshot.Date, snapshot.Score, components, snapshot.ComputedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save compliance score snapshot: %w", err)
+	}
+	return nil
+}
+
+// ListComplianceScoreSnapshots returns the tenant's daily scores since the given day, oldest first
+func (r *PostgresRepository) ListComplianceScoreSnapshots(ctx context.Context, since time.Time) ([]*entity.ComplianceScoreSnapshot, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT to_char(snapshot_date, 'YYYY-MM-DD'), score, components, computed_at
+		FROM compliance_score_snapshots
+		WHERE tenant_id = $1 AND snapshot_date >= $2::date
+		ORDER BY snapshot_date`, tenantID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance score snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []*entity.ComplianceScoreSnapshot{}
+	for rows.Next() {
+		s := &entity.ComplianceScoreSnapshot{TenantID: tenantID}
+		var components []byte
+		if err := rows.Scan(&s.Date, &s.Score, &components, &s.ComputedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(components, &s.Components); err != nil {
+			return nil, fmt.Errorf("failed to decode compliance score components: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
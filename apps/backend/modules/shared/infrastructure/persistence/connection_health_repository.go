@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Connection Health Repository Implementation
+// ============================================================================
+
+// RecordConnectionHealth stores the result of a connection test and updates its
+// failure streak, returning the validation status it had before. A nil
+// validationError marks the connection valid.
+func (r *PostgresRepository) RecordConnectionHealth(ctx context.Context, id uuid.UUID, validationError *string, at time.Time) (string, error) {
+	status := "valid"
+	if validationError != nil {
+		status = "invalid"
+	}
+
+	var previous string
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE connections c
+		SET validation_status = $2,
+		    validation_error = $3,
+		    last_validated_at = $4,
+		    consecutive_failures = CASE WHEN $2 = 'valid' THEN 0 ELSE c.consecutive_failures + 1 END,
+		    failing_since = CASE WHEN $2 = 'valid' THEN NULL ELSE COALESCE(c.failing_since, $4) END,
+		    last_healthy_at = CASE WHEN $2 = 'valid' THEN $4 ELSE c.last_healthy_at END
+		FROM (SELECT id, validation_status FROM connections WHERE id = $1 FOR UPDATE) old
+		WHERE c.id = old.id
+		RETURNING COALESCE(old.validation_status, 'pending')`,
+		id, status, validationError, at,
+	).Scan(&previous)
+	if err != nil {
+		return "", fmt.Errorf("failed to record connection health: %w", err)
+	}
+	return previous, nil
+}
+
+// ListConnectionHealth returns the health of every stored connection, failing
+// connections first and the longest failing first among them
+func (r *PostgresRepository) ListConnectionHealth(ctx context.Context) ([]*entity.ConnectionHealth, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, source_type, profile_name, COALESCE(validation_status, 'pending'), last_validated_at,
+		       validation_error, consecutive_failures, failing_since, last_healthy_at
+		FROM connections
+		ORDER BY failing_since ASC NULLS LAST, profile_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connection health: %w", err)
+	}
+	defer rows.Close()
+
+	health := []*entity.ConnectionHealth{}
+	for rows.Next() {
+		h := &entity.ConnectionHealth{}
+		if err := rows.Scan(&h.ConnectionID, &h.SourceType, &h.ProfileName, &h.ValidationStatus, &h.LastValidatedAt,
+			&h.ValidationError, &h.ConsecutiveFailures, &h.FailingSince, &h.LastHealthyAt); err != nil {
+			return nil, err
+		}
+		health = append(health, h)
+	}
+	return health, rows.Err()
+}
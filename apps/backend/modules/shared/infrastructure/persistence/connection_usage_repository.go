@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Connection Usage Repository Implementation
+// ============================================================================
+
+// connectionScanFilter matches the scan runs of connection c: those linked to it, and
+// unlinked multi-source scans listing its profile among their sources
+const connectionScanFilter = `s.deleted_at IS NULL
+	AND (s.connection_id = c.id OR (s.connection_id IS NULL AND s.metadata->'sources' ? c.profile_name))`
+
+// FindConnectionIDByProfile returns the ID of the only connection with the profile
+// name, or nil when none or several source types share it
+func (r *PostgresRepository) FindConnectionIDByProfile(ctx context.Context, profileName string) (*uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM connections WHERE profile_name = $1 LIMIT 2`, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find connection: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil || len(ids) != 1 {
+		return nil, err
+	}
+	return &ids[0], nil
+}
+
+// ListConnectionScanRuns returns a connection's scan runs, newest first, with their total
+func (r *PostgresRepository) ListConnectionScanRuns(ctx context.Context, connectionID uuid.UUID, limit, offset int) ([]*entity.ScanRun, int, error) {
+	var total int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM scan_runs s JOIN connections c ON c.id = $1
+		WHERE `+connectionScanFilter, connectionID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count connection scan runs: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT s.id, s.profile_name, s.connection_id, s.scan_started_at, s.scan_completed_at, s.host,
+			s.total_findings, s.total_assets, s.status, s.metadata, s.created_at, s.updated_at
+		FROM scan_runs s JOIN connections c ON c.id = $1
+		WHERE `+connectionScanFilter+`
+		ORDER BY s.scan_started_at DESC
+		LIMIT $2 OFFSET $3`, connectionID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list connection scan runs: %w", err)
+	}
+	defer rows.Close()
+
+	scanRuns := []*entity.ScanRun{}
+	for rows.Next() {
+		scanRun := &entity.ScanRun{}
+		var metadataJSON []byte
+		if err := rows.Scan(
+			&scanRun.ID, &scanRun.ProfileName, &scanRun.ConnectionID, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
+			&scanRun.Host, &scanRun.TotalFindings, &scanRun.TotalAssets, &scanRun.Status,
+			&metadataJSON, &scanRun.CreatedAt, &scanRun.UpdatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if len(metadataJSON) > 0 {
+			if err := json.Unmarshal(metadataJSON, &scanRun.Metadata); err != nil {
+				return nil, 0, fmt.Errorf("failed to unmarshal metadata: %w", err)
+			}
+		}
+		scanRuns = append(scanRuns, scanRun)
+	}
+	return scanRuns, total, rows.Err()
+}
+
+// ListConnectionFindingTrend returns, per UTC day since the given time, the scans of
+// a connection and the findings they recorded. Days without scans are omitted.
+func (r *PostgresRepository) ListConnectionFindingTrend(ctx context.Context, connectionID uuid.UUID, since time.Time) ([]entity.ConnectionTrendPoint, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT date_trunc('day', s.scan_started_at AT TIME ZONE 'UTC') AS day,
+			COUNT(DISTINCT s.id),
+			COUNT(f.id),
+			COUNT(f.id) FILTER (WHERE LOWER(f.severity) = 'critical'),
+			COUNT(f.id) FILTER (WHERE LOWER(f.severity) = 'high')
+		FROM scan_runs s
+		JOIN connections c ON c.id = $1
+		LEFT JOIN findings f ON f.scan_run_id = s.id AND f.deleted_at IS NULL
+		WHERE `+connectionScanFilter+` AND s.scan_started_at >= $2
+		GROUP BY day
+		ORDER BY day`, connectionID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connection finding trend: %w", err)
+	}
+	defer rows.Close()
+
+	points := []entity.ConnectionTrendPoint{}
+	for rows.Next() {
+		var p entity.ConnectionTrendPoint
+		if err := rows.Scan(&p.Day, &p.Scans, &p.Findings, &p.Critical, &p.High); err != nil {
+			return nil, err
+		}
+		p.Day = time.Date(p.Day.Year(), p.Day.Month(), p.Day.Day(), 0, 0, 0, 0, time.UTC)
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
+
+// ListConnectionsNotScannedSince returns connections whose latest scan started before
+// cutoff, or that were never scanned, least recently scanned first
+func (r *PostgresRepository) ListConnectionsNotScannedSince(ctx context.Context, cutoff time.Time) ([]*entity.Connection, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT c.id, c.source_type, c.profile_name, c.validation_status,
+		       c.last_validated_at, c.created_by, c.created_at, c.updated_at, ls.last_scanned_at
+		FROM connections c
+		LEFT JOIN LATERAL (
+			SELECT MAX(s.scan_started_at) AS last_scanned_at FROM scan_runs s WHERE `+connectionScanFilter+`
+		) ls ON true
+		WHERE ls.last_scanned_at IS NULL OR ls.last_scanned_at < $1
+		ORDER BY ls.last_scanned_at ASC NULLS FIRST, c.created_at`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unscanned connections: %w", err)
+	}
+	defer rows.Close()
+
+	connections := []*entity.Connection{}
+	for rows.Next() {
+		conn := &entity.Connection{}
+		var lastScannedAt sql.NullTime
+		if err := rows.Scan(&conn.ID, &conn.SourceType, &conn.ProfileName,
+			&conn.ValidationStatus, &conn.LastValidatedAt, &conn.CreatedBy,
+			&conn.CreatedAt, &conn.UpdatedAt, &lastScannedAt); err != nil {
+			return nil, err
+		}
+		if lastScannedAt.Valid {
+			conn.LastScannedAt = &lastScannedAt.Time
+		}
+		connections = append(connections, conn)
+	}
+	return connections, rows.Err()
+}
@@ -0,0 +1,371 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Consent Registry Repository Implementation
+// ============================================================================
+
+const consentPolicyColumns = `p.id, p.tenant_id, p.principal_category, p.purpose, p.lawful_basis, p.description,
+	p.is_active, v.is_valid, p.created_by, p.created_at, p.updated_at,
+	COALESCE(ARRAY(SELECT m.pii_type FROM pii_consent_mappings m WHERE m.policy_id = p.id ORDER BY m.pii_type), '{}')`
+
+const consentArtifactColumns = `id, tenant_id, policy_id, artifact_type, reference, version, collected_at,
+	expires_at, withdrawn_at, COALESCE(withdrawal_reason, ''), metadata, created_by, created_at`
+
+// CreateConsentPolicy registers a consent policy for a principal category and purpose
+func (r *PostgresRepository) CreateConsentPolicy(ctx context.Context, policy *entity.ConsentPolicy) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	policy.TenantID = tenantID
+
+	query := `
+		INSERT INTO consent_policies (id, tenant_id, principal_category, purpose, lawful_basis, description, is_active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		policy.ID, policy.TenantID, policy.PrincipalCategory, policy.Purpose,
+		policy.LawfulBasis, policy.Description, policy.IsActive, policy.CreatedBy,
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("consent policy already exists for this principal category and purpose")
+		}
+		return fmt.Errorf("failed to create consent policy: %w", err)
+	}
+
+	return nil
+}
+
+// GetConsentPolicy returns a consent policy with its mapped PII types and artifacts
+func (r *PostgresRepository) GetConsentPolicy(ctx context.Context, id uuid.UUID) (*entity.ConsentPolicy, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + consentPolicyColumns + `
+		FROM consent_policies p
+		JOIN consent_policy_validity v ON v.policy_id = p.id
+		WHERE p.id = $1 AND p.tenant_id = $2`
+
+	policy, err := scanConsentPolicy(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("consent policy not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := r.listConsentArtifacts(ctx, tenantID, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	policy.Artifacts = artifacts[id]
+
+	return policy, nil
+}
+
+// ListConsentPolicies returns every consent policy of the tenant with mapped PII types and artifacts
+func (r *PostgresRepository) ListConsentPolicies(ctx context.Context) ([]*entity.ConsentPolicy, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + consentPolicyColumns + `
+		FROM consent_policies p
+		JOIN consent_policy_validity v ON v.policy_id = p.id
+		WHERE p.tenant_id = $1
+		ORDER BY p.principal_category, p.purpose`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consent policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []*entity.ConsentPolicy{}
+	var ids []uuid.UUID
+	for rows.Next() {
+		policy, err := scanConsentPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+		ids = append(ids, policy.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	artifacts, err := r.listConsentArtifacts(ctx, tenantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		policy.Artifacts = artifacts[policy.ID]
+	}
+
+	return policies, nil
+}
+
+// CreateConsentArtifact records evidence for a consent policy of the current tenant
+func (r *PostgresRepository) CreateConsentArtifact(ctx context.Context, artifact *entity.ConsentArtifact) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	artifact.TenantID = tenantID
+
+	metadataJSON, err := json.Marshal(artifact.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO consent_artifacts (id, tenant_id, policy_id, artifact_type, reference, version, collected_at, expires_at, metadata, created_by)
+		SELECT $1, $2, p.id, $4, $5, $6, $7, $8, $9, $10
+		FROM consent_policies p
+		WHERE p.id = $3 AND p.tenant_id = $2
+		RETURNING created_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		artifact.ID, artifact.TenantID, artifact.PolicyID, artifact.ArtifactType, artifact.Reference,
+		artifact.Version, artifact.CollectedAt, artifact.ExpiresAt, metadataJSON, artifact.CreatedBy,
+	).Scan(&artifact.CreatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("consent policy not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create consent artifact: %w", err)
+	}
+
+	return nil
+}
+
+// WithdrawConsentArtifact marks an artifact withdrawn so it no longer backs its policy
+func (r *PostgresRepository) WithdrawConsentArtifact(ctx context.Context, id uuid.UUID, reason string) (*entity.ConsentArtifact, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE consent_artifacts
+		SET withdrawn_at = NOW(), withdrawal_reason = $3
+		WHERE id = $1 AND tenant_id = $2 AND withdrawn_at IS NULL
+		RETURNING ` + consentArtifactColumns
+
+	artifact, err := scanConsentArtifact(r.db.QueryRowContext(ctx, query, id, tenantID, reason))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("consent artifact not found or already withdrawn")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to withdraw consent artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// SetPIIConsentMappings replaces the consent policies a PII type is mapped to.
+// An empty policy list removes the PII type's mappings.
+func (r *PostgresRepository) SetPIIConsentMappings(ctx context.Context, piiType string, policyIDs []uuid.UUID, createdBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM pii_consent_mappings WHERE tenant_id = $1 AND pii_type = $2`, tenantID, piiType); err != nil {
+		return fmt.Errorf("failed to clear consent mappings: %w", err)
+	}
+
+	if len(policyIDs) > 0 {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO pii_consent_mappings (tenant_id, pii_type, policy_id, created_by)
+			SELECT $1, $2, p.id, $4
+			FROM consent_policies p
+			WHERE p.tenant_id = $1 AND p.id = ANY($3::uuid[])`,
+			tenantID, piiType, pq.Array(uuidStrings(policyIDs)), createdBy)
+		if err != nil {
+			return fmt.Errorf("failed to map consent policies: %w", err)
+		}
+		if inserted, _ := result.RowsAffected(); int(inserted) != len(policyIDs) {
+			return fmt.Errorf("consent policy not found")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListPIIConsentMappings returns the mapped consent policies per PII type
+func (r *PostgresRepository) ListPIIConsentMappings(ctx context.Context) ([]*entity.PIIConsentMapping, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT m.pii_type, ARRAY_AGG(m.policy_id ORDER BY m.created_at), BOOL_OR(v.is_valid)
+		FROM pii_consent_mappings m
+		JOIN consent_policy_validity v ON v.policy_id = m.policy_id
+		WHERE m.tenant_id = $1
+		GROUP BY m.pii_type
+		ORDER BY m.pii_type`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consent mappings: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := []*entity.PIIConsentMapping{}
+	for rows.Next() {
+		mapping := &entity.PIIConsentMapping{}
+		var policyIDs []string
+		if err := rows.Scan(&mapping.PIIType, pq.Array(&policyIDs), &mapping.HasValidBasis); err != nil {
+			return nil, err
+		}
+		for _, raw := range policyIDs {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				return nil, err
+			}
+			mapping.PolicyIDs = append(mapping.PolicyIDs, id)
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, rows.Err()
+}
+
+// ListConsentBasisGaps returns the assets holding consent-requiring PII without a valid
+// mapped consent basis. piiType filters to one PII type when non-empty.
+func (r *PostgresRepository) ListConsentBasisGaps(ctx context.Context, piiType string) ([]*entity.ConsentBasisGap, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT g.asset_id, g.asset_name, g.asset_path, g.data_source, g.pii_type, g.finding_count, g.last_seen_at,
+			CASE WHEN EXISTS (
+				SELECT 1 FROM pii_consent_mappings m WHERE m.tenant_id = g.tenant_id AND m.pii_type = g.pii_type
+			) THEN 'NO_VALID_BASIS' ELSE 'UNMAPPED' END
+		FROM consent_basis_gaps g
+		WHERE g.tenant_id = $1 AND ($2 = '' OR g.pii_type = $2)
+		ORDER BY g.finding_count DESC, g.asset_name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, piiType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query consent basis gaps: %w", err)
+	}
+	defer rows.Close()
+
+	gaps := []*entity.ConsentBasisGap{}
+	for rows.Next() {
+		gap := &entity.ConsentBasisGap{}
+		if err := rows.Scan(&gap.AssetID, &gap.AssetName, &gap.AssetPath, &gap.DataSource, &gap.PIIType,
+			&gap.FindingCount, &gap.LastSeenAt, &gap.Reason); err != nil {
+			return nil, err
+		}
+		gaps = append(gaps, gap)
+	}
+
+	return gaps, rows.Err()
+}
+
+func (r *PostgresRepository) listConsentArtifacts(ctx context.Context, tenantID uuid.UUID, policyIDs []uuid.UUID) (map[uuid.UUID][]*entity.ConsentArtifact, error) {
+	result := make(map[uuid.UUID][]*entity.ConsentArtifact)
+	if len(policyIDs) == 0 {
+		return result, nil
+	}
+
+	query := `
+		SELECT ` + consentArtifactColumns + `
+		FROM consent_artifacts
+		WHERE tenant_id = $1 AND policy_id = ANY($2::uuid[])
+		ORDER BY collected_at DESC, id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, pq.Array(uuidStrings(policyIDs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consent artifacts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		artifact, err := scanConsentArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+		result[artifact.PolicyID] = append(result[artifact.PolicyID], artifact)
+	}
+
+	return result, rows.Err()
+}
+
+func scanConsentPolicy(row rowScanner) (*entity.ConsentPolicy, error) {
+	policy := &entity.ConsentPolicy{}
+	err := row.Scan(
+		&policy.ID, &policy.TenantID, &policy.PrincipalCategory, &policy.Purpose, &policy.LawfulBasis,
+		&policy.Description, &policy.IsActive, &policy.IsValid, &policy.CreatedBy, &policy.CreatedAt,
+		&policy.UpdatedAt, pq.Array(&policy.PIITypes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if policy.PIITypes == nil {
+		policy.PIITypes = []string{}
+	}
+	return policy, nil
+}
+
+func scanConsentArtifact(row rowScanner) (*entity.ConsentArtifact, error) {
+	artifact := &entity.ConsentArtifact{}
+	var expiresAt, withdrawnAt sql.NullTime
+	var metadataJSON []byte
+
+	err := row.Scan(
+		&artifact.ID, &artifact.TenantID, &artifact.PolicyID, &artifact.ArtifactType, &artifact.Reference,
+		&artifact.Version, &artifact.CollectedAt, &expiresAt, &withdrawnAt, &artifact.WithdrawalReason,
+		&metadataJSON, &artifact.CreatedBy, &artifact.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		artifact.ExpiresAt = &expiresAt.Time
+	}
+	if withdrawnAt.Valid {
+		artifact.WithdrawnAt = &withdrawnAt.Time
+	}
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &artifact.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,195 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Coverage Repository Implementation
+// ============================================================================
+
+// ListTableCoverage returns every table asset created by schema discovery together
+// with the findings recorded for it and the last completed scan of its connection.
+// Findings count for a table when they are attached to its asset, or when their
+// context names the table on the same data source (scanner paths vary per source).
+// connectionID filters to one connection when non-empty.
+func (r *PostgresRepository) ListTableCoverage(ctx context.Context, connectionID string) ([]*entity.TableCoverage, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT a.id,
+			COALESCE(a.file_metadata->>'connection_id', ''),
+			COALESCE(a.file_metadata->>'profile_name', ''),
+			a.data_source, a.host, a.path,
+			COALESCE((a.file_metadata->>'row_count')::bigint, 0),
+			COALESCE(jsonb_array_length(a.file_metadata->'columns'), 0),
+			COALESCE((SELECT array_agg(col->>'name') FROM jsonb_array_elements(a.file_metadata->'columns') col), '{}'),
+			COALESCE(fs.finding_count, 0), fs.last_finding_at, sr.last_scan_at
+		FROM assets a
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS finding_count, MAX(f.created_at) AS last_finding_at
+			FROM findings f
+			WHERE f.tenant_id = a.tenant_id
+			  AND f.deleted_at IS NULL
+			  AND (f.asset_id = a.id OR (
+				f.context->>'data_source' = a.data_source
+				AND LOWER(f.context->>'table') IN (LOWER(a.file_metadata->>'table'), LOWER(a.path))
+			  ))
+		) fs ON true
+		LEFT JOIN LATERAL (
+			SELECT MAX(s.scan_completed_at) AS last_scan_at
+			FROM scan_runs s
+			WHERE s.status = 'completed'
+			  AND (s.profile_name = a.file_metadata->>'profile_name'
+				OR s.metadata->'sources' ? (a.file_metadata->>'profile_name'))
+		) sr ON true
+		WHERE a.tenant_id = $1
+		  AND a.asset_type = $2
+		  AND a.file_metadata ? 'discovered_at'
+		  AND ($3 = '' OR a.file_metadata->>'connection_id' = $3)
+		ORDER BY a.data_source, a.host, a.path`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, entity.AssetTypeTable, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table coverage: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []*entity.TableCoverage
+	for rows.Next() {
+		t := &entity.TableCoverage{}
+		var lastFindingAt, lastScanAt sql.NullTime
+		if err := rows.Scan(
+			&t.AssetID, &t.ConnectionID, &t.ProfileName, &t.DataSource, &t.Host, &t.Path,
+			&t.RowCount, &t.ColumnCount, pq.Array(&t.ColumnNames), &t.FindingCount, &lastFindingAt, &lastScanAt,
+		); err != nil {
+			return nil, err
+		}
+		if lastFindingAt.Valid {
+			t.LastFindingAt = &lastFindingAt.Time
+		}
+		if lastScanAt.Valid {
+			t.LastScanAt = &lastScanAt.Time
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, rows.Err()
+}
+
+// ReplaceDiscoveredFiles records the files enumerated on a connection and drops
+// those that were not seen again, so the inventory mirrors the latest crawl
+func (r *PostgresRepository) ReplaceDiscoveredFiles(ctx context.Context, connectionID uuid.UUID, files []entity.DiscoveredFile, discoveredAt time.Time) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO discovered_files (tenant_id, connection_id, path, size_bytes, modified_at, discovered_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (tenant_id, connection_id, path) DO UPDATE
+		SET size_bytes = EXCLUDED.size_bytes, modified_at = EXCLUDED.modified_at, discovered_at = EXCLUDED.discovered_at`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare file insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, f := range files {
+		if _, err := stmt.ExecContext(ctx, tenantID, connectionID, f.Path, f.SizeBytes, f.ModifiedAt, discoveredAt); err != nil {
+			return fmt.Errorf("failed to record file %s: %w", f.Path, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM discovered_files
+		WHERE tenant_id = $1 AND connection_id = $2 AND discovered_at < $3`,
+		tenantID, connectionID, discoveredAt); err != nil {
+		return fmt.Errorf("failed to drop stale files: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListFileCoverage returns every enumerated file together with the findings recorded
+// on file assets at the same location and the last completed scan of its connection.
+// Scanners report S3 objects and files with varying prefixes, so an asset matches
+// when its path equals the file's path or ends with it after a separator.
+// connectionID filters to one connection when non-empty.
+func (r *PostgresRepository) ListFileCoverage(ctx context.Context, connectionID string) ([]*entity.FileCoverage, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH last_scans AS (
+			SELECT c.id AS connection_id, MAX(s.scan_completed_at) AS last_scan_at
+			FROM connections c
+			JOIN scan_runs s ON s.status = 'completed'
+				AND (s.profile_name = c.profile_name OR s.metadata->'sources' ? c.profile_name)
+			GROUP BY c.id
+		)
+		SELECT df.connection_id::text, c.profile_name, c.source_type, df.path, df.size_bytes, df.modified_at,
+			COALESCE(fs.finding_count, 0), fs.last_finding_at, ls.last_scan_at
+		FROM discovered_files df
+		JOIN connections c ON c.id = df.connection_id
+		LEFT JOIN last_scans ls ON ls.connection_id = df.connection_id
+		LEFT JOIN LATERAL (
+			SELECT COUNT(f.id) AS finding_count, MAX(f.created_at) AS last_finding_at
+			FROM assets a
+			JOIN findings f ON f.asset_id = a.id AND f.deleted_at IS NULL
+			WHERE a.tenant_id = df.tenant_id
+			  AND a.asset_type = $2
+			  AND (a.path = df.path OR RIGHT(a.path, LENGTH(df.path) + 1) = '/' || df.path)
+		) fs ON true
+		WHERE df.tenant_id = $1
+		  AND ($3 = '' OR df.connection_id::text = $3)
+		ORDER BY c.profile_name, df.path`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, entity.AssetTypeFile, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file coverage: %w", err)
+	}
+	defer rows.Close()
+
+	var files []*entity.FileCoverage
+	for rows.Next() {
+		f := &entity.FileCoverage{}
+		var modifiedAt, lastFindingAt, lastScanAt sql.NullTime
+		if err := rows.Scan(
+			&f.ConnectionID, &f.ProfileName, &f.SourceType, &f.Path, &f.SizeBytes, &modifiedAt,
+			&f.FindingCount, &lastFindingAt, &lastScanAt,
+		); err != nil {
+			return nil, err
+		}
+		if modifiedAt.Valid {
+			f.ModifiedAt = &modifiedAt.Time
+		}
+		if lastFindingAt.Valid {
+			f.LastFindingAt = &lastFindingAt.Time
+		}
+		if lastScanAt.Valid {
+			f.LastScanAt = &lastScanAt.Time
+		}
+		files = append(files, f)
+	}
+
+	return files, rows.Err()
+}
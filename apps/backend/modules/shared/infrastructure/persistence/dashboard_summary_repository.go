@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Dashboard Summary Repository Implementation
+// ============================================================================
+
+// DashboardSummaryView is the materialized view backing dashboard summary endpoints
+const DashboardSummaryView = "dashboard_finding_summary"
+
+// RefreshDashboardSummary recomputes the dashboard summary view without blocking readers
+func (r *PostgresRepository) RefreshDashboardSummary(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	_, refreshErr := r.db.ExecContext(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+DashboardSummaryView)
+	duration := time.Since(start)
+
+	var lastError sql.NullString
+	if refreshErr != nil {
+		lastError = sql.NullString{String: refreshErr.Error(), Valid: true}
+	}
+
+	// Only advance refreshed_at on success so the staleness indicator stays honest
+	query := `
+		INSERT INTO summary_view_refreshes (view_name, refreshed_at, refresh_duration_ms, last_error)
+		VALUES ($1, NOW(), $2, $3)
+		ON CONFLICT (view_name) DO UPDATE SET
+			refreshed_at = CASE WHEN $3::text IS NULL THEN NOW() ELSE summary_view_refreshes.refreshed_at END,
+			refresh_duration_ms = $2,
+			last_error = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, DashboardSummaryView, duration.Milliseconds(), lastError); err != nil {
+		return duration, fmt.Errorf("failed to record summary refresh: %w", err)
+	}
+
+	if refreshErr != nil {
+		return duration, fmt.Errorf("failed to refresh %s: %w", DashboardSummaryView, refreshErr)
+	}
+	return duration, nil
+}
+
+// GetDashboardSummaryRows returns the pre-aggregated summary rows for the current tenant
+func (r *PostgresRepository) GetDashboardSummaryRows(ctx context.Context, environment string) ([]*entity.DashboardSummaryRow, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT severity, classification_type, environment, finding_count, asset_count, last_finding_at
+		FROM dashboard_finding_summary
+		WHERE tenant_id = $1 AND ($2 = '' OR environment = $2)
+		ORDER BY finding_count DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, environment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dashboard summary: %w", err)
+	}
+	defer rows.Close()
+
+	var result []*entity.DashboardSummaryRow
+	for rows.Next() {
+		row := &entity.DashboardSummaryRow{}
+		if err := rows.Scan(
+			&row.Severity, &row.ClassificationType, &row.Environment,
+			&row.FindingCount, &row.AssetCount, &row.LastFindingAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// GetSummaryRefreshState returns the last refresh of a summary view and the
+// current tenant's latest data change
+func (r *PostgresRepository) GetSummaryRefreshState(ctx context.Context, viewName string) (*entity.SummaryRefreshState, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &entity.SummaryRefreshState{ViewName: viewName}
+	var lastError sql.NullString
+
+	err = r.db.QueryRowContext(ctx, `
+		SELECT refreshed_at, COALESCE(refresh_duration_ms, 0), last_error
+		FROM summary_view_refreshes WHERE view_name = $1`,
+		viewName,
+	).Scan(&state.RefreshedAt, &state.RefreshDurationMs, &lastError)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get refresh state: %w", err)
+	}
+	state.LastError = lastError.String
+
+	var dataChangedAt sql.NullTime
+	// Only the tenant's own ingestion makes its summary stale
+	if err := r.db.QueryRowContext(ctx, `SELECT MAX(created_at) FROM findings WHERE tenant_id = $1`, tenantID).Scan(&dataChangedAt); err != nil {
+		return nil, fmt.Errorf("failed to get last data change: %w", err)
+	}
+	if dataChangedAt.Valid {
+		state.DataChangedAt = &dataChangedAt.Time
+	}
+
+	return state, nil
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSummaryRefreshState_TenantScoped(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	refreshedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	changedAt := refreshedAt.Add(time.Minute)
+	mock.ExpectQuery(`FROM summary_view_refreshes WHERE view_name = \$1`).
+		WithArgs(DashboardSummaryView).
+		WillReturnRows(sqlmock.NewRows([]string{"refreshed_at", "refresh_duration_ms", "last_error"}).AddRow(refreshedAt, 120, nil))
+	// Another tenant's findings must not make this tenant's summary stale
+	mock.ExpectQuery(`SELECT MAX\(created_at\) FROM findings WHERE tenant_id = \$1`).
+		WithArgs(tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(changedAt))
+
+	state, err := repo.GetSummaryRefreshState(ctx, DashboardSummaryView)
+	assert.NoError(t, err)
+	assert.Equal(t, refreshedAt, state.RefreshedAt)
+	assert.Equal(t, changedAt, *state.DataChangedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSummaryRefreshState_RequiresTenant(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	_, err = NewPostgresRepository(db).GetSummaryRefreshState(context.Background(), DashboardSummaryView)
+	assert.Error(t, err)
+}
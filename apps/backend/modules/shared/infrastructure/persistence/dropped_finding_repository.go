@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Dropped Findings Ledger Implementation
+// ============================================================================
+
+// RecordDroppedFindings adds the ingestion filter's counts for a scan run to the
+// ledger. A scan run ingested in several requests accumulates its counts.
+func (r *PostgresRepository) RecordDroppedFindings(ctx context.Context, scanRunID uuid.UUID, counts []entity.DroppedFindingCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, c := range counts {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO dropped_findings (tenant_id, scan_run_id, reason, pattern_name, enforced, finding_count, max_confidence)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (scan_run_id, reason, pattern_name, enforced) DO UPDATE
+			SET finding_count = dropped_findings.finding_count + EXCLUDED.finding_count,
+			    max_confidence = GREATEST(dropped_findings.max_confidence, EXCLUDED.max_confidence),
+			    recorded_at = NOW()`,
+			tenantID, scanRunID, c.Reason, c.PatternName, c.Enforced, c.Findings, c.MaxConfidence)
+		if err != nil {
+			return fmt.Errorf("failed to record dropped findings: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit dropped findings: %w", err)
+	}
+	return nil
+}
+
+// ListDroppedFindings returns a scan run's ledger entries, most dropped first
+func (r *PostgresRepository) ListDroppedFindings(ctx context.Context, scanRunID uuid.UUID) ([]entity.DroppedFindingCount, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT scan_run_id, reason, pattern_name, enforced, finding_count, max_confidence, recorded_at
+		FROM dropped_findings
+		WHERE tenant_id = $1 AND scan_run_id = $2
+		ORDER BY finding_count DESC, reason, pattern_name`, tenantID, scanRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dropped findings: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]entity.DroppedFindingCount, 0)
+	for rows.Next() {
+		var c entity.DroppedFindingCount
+		if err := rows.Scan(&c.ScanRunID, &c.Reason, &c.PatternName, &c.Enforced, &c.Findings, &c.MaxConfidence, &c.RecordedAt); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// SummarizeDroppedFindings totals the tenant's ledger entries recorded in [since, until)
+// by reason and pattern, most dropped first
+func (r *PostgresRepository) SummarizeDroppedFindings(ctx context.Context, since, until time.Time) ([]entity.DroppedFindingSummary, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT reason, pattern_name, enforced, SUM(finding_count), COUNT(DISTINCT scan_run_id), MAX(max_confidence)
+		FROM dropped_findings
+		WHERE tenant_id = $1 AND recorded_at >= $2 AND recorded_at < $3
+		GROUP BY reason, pattern_name, enforced
+		ORDER BY 4 DESC, reason, pattern_name`, tenantID, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize dropped findings: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make([]entity.DroppedFindingSummary, 0)
+	for rows.Next() {
+		var s entity.DroppedFindingSummary
+		if err := rows.Scan(&s.Reason, &s.PatternName, &s.Enforced, &s.Findings, &s.ScanRuns, &s.MaxConfidence); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+	return summaries, rows.Err()
+}
@@ -0,0 +1,313 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Erasure Request Repository Implementation
+// ============================================================================
+
+const erasureRequestColumns = `id, tenant_id, value_hash, requester_reference, action_type, justification, status,
+	requested_by, requested_at, decided_by, decided_at, decision_comment, completed_at`
+
+const erasureTargetColumns = `id, finding_id, asset_id, asset_name, asset_path, system, source_type, matches,
+	mode, reason, status, action_id, error, executed_at`
+
+// ListValueFindingIDs returns the tenant's open findings a value hash was observed
+// in, oldest first
+func (r *PostgresRepository) ListValueFindingIDs(ctx context.Context, valueHash string) (ids []uuid.UUID, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done := beginQuery(ctx, QueryInteractive, "list_value_finding_ids")
+	defer func() { done(err) }()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id
+		FROM findings f
+		JOIN assets a ON a.id = f.asset_id AND a.deleted_at IS NULL
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+			AND f.id IN (SELECT o.finding_id FROM finding_observations o WHERE o.tenant_id = $1 AND o.value_hash = $2)
+		ORDER BY f.created_at, f.id`, tenantID, valueHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list findings of value: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// CreateErasureRequest records a pending erasure request for the tenant in ctx
+// with its planned targets
+func (r *PostgresRepository) CreateErasureRequest(ctx context.Context, req *entity.ErasureRequest) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if req.ID == uuid.Nil {
+		req.ID = uuid.New()
+	}
+	req.TenantID = tenantID
+	req.Status = entity.ErasurePendingApproval
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO erasure_requests (id, tenant_id, value_hash, requester_reference, action_type, justification, status, requested_by, requested_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING requested_at`,
+		req.ID, tenantID, req.ValueHash, req.RequesterReference, req.ActionType, req.Justification, req.Status, req.RequestedBy,
+	).Scan(&req.RequestedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create erasure request: %w", err)
+	}
+
+	for _, target := range req.Targets {
+		if target.ID == uuid.Nil {
+			target.ID = uuid.New()
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO erasure_targets (id, request_id, finding_id, asset_id, asset_name, asset_path, system, source_type, matches, mode, reason, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+			target.ID, req.ID, target.FindingID, target.AssetID, target.AssetName, target.AssetPath, target.System,
+			target.SourceType, target.Matches, target.Mode, target.Reason, target.Status); err != nil {
+			return fmt.Errorf("failed to create erasure target: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetErasureRequest returns one of the tenant's erasure requests with its targets,
+// or nil when the tenant has no such request
+func (r *PostgresRepository) GetErasureRequest(ctx context.Context, id uuid.UUID) (*entity.ErasureRequest, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := scanErasureRequest(r.db.QueryRowContext(ctx,
+		`SELECT `+erasureRequestColumns+` FROM erasure_requests WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadErasureTargets(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// ListErasureRequests returns the tenant's erasure requests, newest first and
+// without their targets, optionally filtered by status
+func (r *PostgresRepository) ListErasureRequests(ctx context.Context, status string, limit, offset int) ([]*entity.ErasureRequest, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM erasure_requests WHERE tenant_id = $1 AND ($2 = '' OR status = $2)`,
+		tenantID, status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count erasure requests: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+erasureRequestColumns+` FROM erasure_requests
+		WHERE tenant_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY requested_at DESC, id
+		LIMIT $3 OFFSET $4`,
+		tenantID, status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list erasure requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*entity.ErasureRequest{}
+	for rows.Next() {
+		req, err := scanErasureRequest(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, total, rows.Err()
+}
+
+// DecideErasureRequest moves a pending request to status. The update is
+// conditional, so two approvers racing on the same request cannot both decide it;
+// the loser gets nil.
+func (r *PostgresRepository) DecideErasureRequest(ctx context.Context, id uuid.UUID, status, decidedBy, comment string) (*entity.ErasureRequest, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := scanErasureRequest(r.db.QueryRowContext(ctx, `
+		UPDATE erasure_requests
+		SET status = $3, decided_by = $4, decided_at = NOW(), decision_comment = $5
+		WHERE id = $1 AND tenant_id = $2 AND status = $6
+		RETURNING `+erasureRequestColumns,
+		id, tenantID, status, decidedBy, comment, entity.ErasurePendingApproval))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadErasureTargets(ctx, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// FinishErasureTarget records the outcome of erasing one target
+func (r *PostgresRepository) FinishErasureTarget(ctx context.Context, requestID uuid.UUID, target *entity.ErasureTarget) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE erasure_targets t
+		SET status = $4, action_id = NULLIF($5, '')::uuid, error = $6, executed_at = $7
+		FROM erasure_requests e
+		WHERE t.id = $1 AND t.request_id = $2 AND e.id = t.request_id AND e.tenant_id = $3`,
+		target.ID, requestID, tenantID, target.Status, target.ActionID, target.Error, target.ExecutedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update erasure target: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("erasure target not found")
+	}
+	return nil
+}
+
+// CompleteErasureRequest records the final status of an executed request
+func (r *PostgresRepository) CompleteErasureRequest(ctx context.Context, id uuid.UUID, status string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE erasure_requests SET status = $3, completed_at = NOW()
+		WHERE id = $1 AND tenant_id = $2`, id, tenantID, status)
+	if err != nil {
+		return fmt.Errorf("failed to update erasure request: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("erasure request not found")
+	}
+	return nil
+}
+
+// SaveErasureCertificate stores the certificate of an executed request. A request
+// has one certificate; it is never reissued.
+func (r *PostgresRepository) SaveErasureCertificate(ctx context.Context, cert *entity.ErasureCertificate) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO erasure_certificates (request_id, document, signature, algorithm, key_fingerprint, issued_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		cert.RequestID, string(cert.Document), cert.Signature, cert.Algorithm, cert.KeyFingerprint, cert.IssuedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save erasure certificate: %w", err)
+	}
+	return nil
+}
+
+// GetErasureCertificate returns the certificate of one of the tenant's requests,
+// or nil when none was issued
+func (r *PostgresRepository) GetErasureCertificate(ctx context.Context, requestID uuid.UUID) (*entity.ErasureCertificate, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cert entity.ErasureCertificate
+	var document string
+	err = r.db.QueryRowContext(ctx, `
+		SELECT c.request_id, c.document, c.signature, c.algorithm, c.key_fingerprint, c.issued_at
+		FROM erasure_certificates c
+		JOIN erasure_requests e ON e.id = c.request_id
+		WHERE c.request_id = $1 AND e.tenant_id = $2`, requestID, tenantID,
+	).Scan(&cert.RequestID, &document, &cert.Signature, &cert.Algorithm, &cert.KeyFingerprint, &cert.IssuedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get erasure certificate: %w", err)
+	}
+	cert.Document = []byte(document)
+	return &cert, nil
+}
+
+// loadErasureTargets reads the targets of a request, by system and asset
+func (r *PostgresRepository) loadErasureTargets(ctx context.Context, req *entity.ErasureRequest) error {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+erasureTargetColumns+` FROM erasure_targets
+		WHERE request_id = $1
+		ORDER BY system, asset_path, finding_id`, req.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list erasure targets: %w", err)
+	}
+	defer rows.Close()
+
+	req.Targets = []*entity.ErasureTarget{}
+	for rows.Next() {
+		var target entity.ErasureTarget
+		var actionID uuid.NullUUID
+		var executedAt sql.NullTime
+		if err := rows.Scan(&target.ID, &target.FindingID, &target.AssetID, &target.AssetName, &target.AssetPath,
+			&target.System, &target.SourceType, &target.Matches, &target.Mode, &target.Reason, &target.Status,
+			&actionID, &target.Error, &executedAt); err != nil {
+			return err
+		}
+		if actionID.Valid {
+			target.ActionID = actionID.UUID.String()
+		}
+		if executedAt.Valid {
+			target.ExecutedAt = &executedAt.Time
+		}
+		req.Targets = append(req.Targets, &target)
+	}
+	return rows.Err()
+}
+
+func scanErasureRequest(row interface{ Scan(...interface{}) error }) (*entity.ErasureRequest, error) {
+	var req entity.ErasureRequest
+	var decidedAt, completedAt sql.NullTime
+	if err := row.Scan(&req.ID, &req.TenantID, &req.ValueHash, &req.RequesterReference, &req.ActionType,
+		&req.Justification, &req.Status, &req.RequestedBy, &req.RequestedAt, &req.DecidedBy, &decidedAt,
+		&req.DecisionComment, &completedAt); err != nil {
+		return nil, err
+	}
+	if decidedAt.Valid {
+		req.DecidedAt = &decidedAt.Time
+	}
+	if completedAt.Valid {
+		req.CompletedAt = &completedAt.Time
+	}
+	return &req, nil
+}
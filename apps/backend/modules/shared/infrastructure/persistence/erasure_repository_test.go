@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecideErasureRequest_NoLongerPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID, id := uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	// The update only applies to pending requests; a request decided first by
+	// another approver matches no row
+	mock.ExpectQuery(`UPDATE erasure_requests\s+SET status = \$3, decided_by = \$4, decided_at = NOW\(\), decision_comment = \$5\s+WHERE id = \$1 AND tenant_id = \$2 AND status = \$6`).
+		WithArgs(id, tenantID, entity.ErasureExecuting, "ravi", "ok", entity.ErasurePendingApproval).
+		WillReturnError(sql.ErrNoRows)
+
+	req, err := repo.DecideErasureRequest(ctx, id, entity.ErasureExecuting, "ravi", "ok")
+	assert.NoError(t, err)
+	assert.Nil(t, req)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListValueFindingIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	hash := "5d41402abc4b2a76b9719d911017c592aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectQuery(`f.deleted_at IS NULL\s+AND f.id IN \(SELECT o.finding_id FROM finding_observations o WHERE o.tenant_id = \$1 AND o.value_hash = \$2\)`).
+		WithArgs(tenantID, hash).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(first).AddRow(second))
+
+	ids, err := repo.ListValueFindingIDs(ctx, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{first, second}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,217 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Finding Aging Repository Implementation
+// ============================================================================
+
+// assetScanRanksCTE ranks every scan run that produced findings on an asset,
+// newest first, so "the last N scans of the same asset" can be expressed as scan_rank <= N.
+// Manually imported findings are not scans: they neither count as one nor go stale.
+const assetScanRanksCTE = `
+	WITH asset_scans AS (
+		SELECT asset_id, scan_run_id,
+			DENSE_RANK() OVER (PARTITION BY asset_id ORDER BY MAX(created_at) DESC) AS scan_rank
+		FROM findings
+		WHERE tenant_id = $1 AND deleted_at IS NULL
+		  AND COALESCE(context->>'provenance', '') <> 'manual'
+		GROUP BY asset_id, scan_run_id
+	)`
+
+// ListStaleFindingCandidates returns open findings that were not re-observed in the
+// last scanWindow scans of their asset. A finding counts as re-observed when a newer
+// finding on the same asset has the same pattern and either the same value hash or
+// at least one overlapping match.
+func (r *PostgresRepository) ListStaleFindingCandidates(ctx context.Context, scanWindow, limit int) ([]*entity.StaleFinding, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := assetScanRanksCTE + `
+		SELECT f.id, f.asset_id, COALESCE(a.name, ''), f.scan_run_id, f.pattern_name, f.severity, f.created_at
+		FROM findings f
+		JOIN asset_scans s ON s.asset_id = f.asset_id AND s.scan_run_id = f.scan_run_id
+		LEFT JOIN assets a ON a.id = f.asset_id
+		WHERE f.tenant_id = $1
+		  AND f.deleted_at IS NULL
+		  AND f.stale_at IS NULL
+		  AND s.scan_rank > $2
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM findings recent
+			JOIN asset_scans rs ON rs.asset_id = recent.asset_id AND rs.scan_run_id = recent.scan_run_id
+			WHERE recent.asset_id = f.asset_id
+			  AND recent.pattern_name = f.pattern_name
+			  AND rs.scan_rank <= $2
+			  AND (recent.normalized_value_hash = f.normalized_value_hash OR recent.matches && f.matches)
+		  )
+		ORDER BY f.created_at ASC
+		LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, scanWindow, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale findings: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []*entity.StaleFinding
+	for rows.Next() {
+		sf := &entity.StaleFinding{ReviewStatus: "pending"}
+		if err := rows.Scan(
+			&sf.FindingID, &sf.AssetID, &sf.AssetName, &sf.ScanRunID,
+			&sf.PatternName, &sf.Severity, &sf.FirstSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		findings = append(findings, sf)
+	}
+
+	return findings, rows.Err()
+}
+
+// MarkFindingsStale flags findings as stale and records the scan window used
+func (r *PostgresRepository) MarkFindingsStale(ctx context.Context, findingIDs []uuid.UUID, scanWindow int) (int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(findingIDs) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, len(findingIDs))
+	for i, id := range findingIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		UPDATE findings
+		SET stale_at = NOW(), stale_scan_window = $1, updated_at = NOW()
+		WHERE id = ANY($2::uuid[]) AND tenant_id = $3 AND stale_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, scanWindow, pq.Array(ids), tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark findings stale: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+// ListStaleFindings returns findings already flagged as stale with their latest review status
+func (r *PostgresRepository) ListStaleFindings(ctx context.Context, reviewStatus string, limit, offset int) ([]*entity.StaleFinding, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT f.id, f.asset_id, COALESCE(a.name, ''), f.scan_run_id, f.pattern_name, f.severity,
+			f.created_at, f.stale_at, COALESCE(rs.status, 'pending')
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN LATERAL (
+			SELECT status FROM review_states
+			WHERE finding_id = f.id
+			ORDER BY created_at DESC
+			LIMIT 1
+		) rs ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.stale_at IS NOT NULL
+		  AND ($2 = '' OR COALESCE(rs.status, 'pending') = $2)
+		ORDER BY f.stale_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, reviewStatus, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale findings: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []*entity.StaleFinding
+	for rows.Next() {
+		sf := &entity.StaleFinding{}
+		if err := rows.Scan(
+			&sf.FindingID, &sf.AssetID, &sf.AssetName, &sf.ScanRunID, &sf.PatternName,
+			&sf.Severity, &sf.FirstSeenAt, &sf.StaleAt, &sf.ReviewStatus,
+		); err != nil {
+			return nil, err
+		}
+		findings = append(findings, sf)
+	}
+
+	return findings, rows.Err()
+}
+
+// CountFindingsByAge buckets open (non-stale) findings by days since first observation
+func (r *PostgresRepository) CountFindingsByAge(ctx context.Context, buckets []entity.FindingAgingBucket) ([]entity.FindingAgingBucket, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT COUNT(*)
+		FROM findings
+		WHERE tenant_id = $1 AND deleted_at IS NULL AND stale_at IS NULL
+		  AND created_at <= NOW() - make_interval(days => $2)
+		  AND ($3 = 0 OR created_at > NOW() - make_interval(days => $3))`
+
+	result := make([]entity.FindingAgingBucket, len(buckets))
+	for i, bucket := range buckets {
+		result[i] = bucket
+		if err := r.db.QueryRowContext(ctx, query, tenantID, bucket.MinDays, bucket.MaxDays).Scan(&result[i].Count); err != nil {
+			return nil, fmt.Errorf("failed to count findings for bucket %s: %w", bucket.Label, err)
+		}
+	}
+
+	return result, nil
+}
+
+// CountStaleFindings returns the number of findings currently flagged as stale
+func (r *PostgresRepository) CountStaleFindings(ctx context.Context) (int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM findings
+		WHERE tenant_id = $1 AND deleted_at IS NULL AND stale_at IS NOT NULL`,
+		tenantID,
+	).Scan(&count)
+	return count, err
+}
+
+// ListFindingTenantIDs returns every tenant that owns findings (used by background jobs)
+func (r *PostgresRepository) ListFindingTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT tenant_id FROM findings WHERE tenant_id IS NOT NULL`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+
+	return tenantIDs, rows.Err()
+}
@@ -0,0 +1,366 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Finding Archive Repository Implementation
+// ============================================================================
+
+// archivableFindingFilter selects findings of old scan runs that may leave Postgres.
+// Parameters: $1 tenant, $2 minimum age in days, $3 closed-only flag, $4 closed statuses.
+// Findings referenced by remediation or policy audit trails are never archived.
+const archivableFindingFilter = `
+	f.tenant_id = $1
+	AND f.deleted_at IS NULL
+	AND sr.scan_completed_at <= NOW() - make_interval(days => $2)
+	AND (NOT $3 OR EXISTS (
+		SELECT 1 FROM review_states rs
+		WHERE rs.finding_id = f.id AND rs.status = ANY($4::text[])
+	))
+	AND NOT EXISTS (SELECT 1 FROM remediation_actions ra WHERE ra.finding_id = f.id)
+	AND NOT EXISTS (SELECT 1 FROM policy_executions pe WHERE pe.finding_id = f.id)`
+
+// ArchivedFindingTables are the other tables whose rows reference findings and are
+// deleted with them by ON DELETE CASCADE. Archives carry their rows, and restores
+// insert them in this order after findings, classifications and review states.
+// Offloaded payloads are archived inline with their findings instead.
+var ArchivedFindingTables = []string{
+	"finding_evidence",
+	"finding_comments",
+	"risk_waivers", // Single-finding waivers, before the finding_waivers rows pointing at them
+	"finding_waivers",
+	"ticket_links",
+	"policy_violations",
+	"secret_verifications",
+	"finding_review_priorities",
+	"remediation_simulations",
+}
+
+const findingArchiveColumns = `id, COALESCE(tenant_id, '00000000-0000-0000-0000-000000000000'::uuid), scan_run_id,
+	storage_provider, bucket, object_key, manifest_key, format, finding_count, classification_count,
+	review_state_count, size_bytes, checksum_sha256, status, archived_by, archived_at, restored_at`
+
+// ListArchivableScanRuns returns the oldest scan runs that still hold archivable findings
+func (r *PostgresRepository) ListArchivableScanRuns(ctx context.Context, olderThanDays int, closedOnly bool, limit int) ([]*entity.ArchivableScanRun, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT sr.id, sr.profile_name, sr.scan_completed_at, COUNT(f.id)
+		FROM findings f
+		JOIN scan_runs sr ON sr.id = f.scan_run_id
+		WHERE ` + archivableFindingFilter + `
+		GROUP BY sr.id, sr.profile_name, sr.scan_completed_at
+		ORDER BY sr.scan_completed_at ASC
+		LIMIT $5`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, olderThanDays, closedOnly, pq.Array(entity.ClosedReviewStatuses), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archivable scan runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*entity.ArchivableScanRun
+	for rows.Next() {
+		run := &entity.ArchivableScanRun{}
+		if err := rows.Scan(&run.ScanRunID, &run.ProfileName, &run.ScanCompletedAt, &run.FindingCount); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+// LoadScanRunArchiveRows exports the archivable findings of a scan run, together with
+// their classifications and review states, as raw JSON rows
+func (r *PostgresRepository) LoadScanRunArchiveRows(ctx context.Context, scanRunID uuid.UUID, olderThanDays int, closedOnly bool) (*entity.ArchivedScanRunRows, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ArchivedScanRunRows{}
+
+	err = r.db.QueryRowContext(ctx, `SELECT row_to_json(sr) FROM scan_runs sr WHERE sr.id = $1`, scanRunID).Scan(&result.ScanRun)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("scan run not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Offloaded payloads are archived inline so a restore needs no side table rows
+	query := `
+		SELECT f.id, CASE WHEN f.payload_offloaded THEN (to_jsonb(f) || jsonb_build_object(
+				'context', p.context, 'enrichment_signals', p.enrichment_signals, 'payload_offloaded', false))::json
+			ELSE row_to_json(f) END
+		FROM findings f
+		JOIN scan_runs sr ON sr.id = f.scan_run_id
+		LEFT JOIN finding_payloads p ON p.finding_id = f.id
+		WHERE f.scan_run_id = $5 AND ` + archivableFindingFilter + `
+		ORDER BY f.created_at, f.id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, olderThanDays, closedOnly, pq.Array(entity.ClosedReviewStatuses), scanRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export findings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var row json.RawMessage
+		if err := rows.Scan(&id, &row); err != nil {
+			return nil, err
+		}
+		result.FindingIDs = append(result.FindingIDs, id)
+		result.Findings = append(result.Findings, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(result.FindingIDs) == 0 {
+		return result, nil
+	}
+
+	ids := uuidStrings(result.FindingIDs)
+
+	result.Classifications, err = r.queryJSONRows(ctx,
+		`SELECT row_to_json(c) FROM classifications c WHERE c.finding_id = ANY($1::uuid[]) ORDER BY c.finding_id, c.id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export classifications: %w", err)
+	}
+
+	result.ReviewStates, err = r.queryJSONRows(ctx,
+		`SELECT row_to_json(rs) FROM review_states rs WHERE rs.finding_id = ANY($1::uuid[]) ORDER BY rs.finding_id, rs.id`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export review states: %w", err)
+	}
+
+	for _, table := range ArchivedFindingTables {
+		related, err := r.queryJSONRows(ctx,
+			fmt.Sprintf(`SELECT row_to_json(t) FROM %s t WHERE t.finding_id = ANY($1::uuid[]) ORDER BY t.finding_id`, table),
+			pq.Array(ids))
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", table, err)
+		}
+		if len(related) > 0 {
+			if result.Related == nil {
+				result.Related = make(map[string][]json.RawMessage)
+			}
+			result.Related[table] = related
+		}
+	}
+
+	return result, nil
+}
+
+// CompleteFindingArchive records an uploaded archive and deletes its findings from Postgres.
+// Classifications, review states and the ArchivedFindingTables rows, all in the
+// archive, are removed by ON DELETE CASCADE.
+func (r *PostgresRepository) CompleteFindingArchive(ctx context.Context, archive *entity.FindingArchive, findingIDs []uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	archive.TenantID = tenantID
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO finding_archives (id, tenant_id, scan_run_id, storage_provider, bucket, object_key, manifest_key,
+			format, finding_count, classification_count, review_state_count, size_bytes, checksum_sha256, status, archived_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING archived_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		archive.ID, archive.TenantID, archive.ScanRunID, archive.StorageProvider, archive.Bucket,
+		archive.ObjectKey, archive.ManifestKey, archive.Format, archive.FindingCount,
+		archive.ClassificationCount, archive.ReviewStateCount, archive.SizeBytes,
+		archive.ChecksumSHA256, archive.Status, archive.ArchivedBy,
+	).Scan(&archive.ArchivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record finding archive: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM findings WHERE id = ANY($1::uuid[]) AND tenant_id = $2`,
+		pq.Array(uuidStrings(findingIDs)), tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete archived findings: %w", err)
+	}
+
+	// The upload holds exactly these rows; refuse to delete a different set
+	if deleted, _ := result.RowsAffected(); int(deleted) != len(findingIDs) {
+		return fmt.Errorf("archived %d findings but %d matched for deletion", len(findingIDs), deleted)
+	}
+
+	return tx.Commit()
+}
+
+// RestoreFindingArchive rehydrates archived rows into Postgres and marks the archive restored.
+// Rows that already exist are left untouched so a restore can be retried safely.
+func (r *PostgresRepository) RestoreFindingArchive(ctx context.Context, archiveID uuid.UUID, rows *entity.ArchivedScanRunRows) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	type tableRows struct {
+		name string
+		rows []json.RawMessage
+	}
+	tables := []tableRows{
+		{"findings", rows.Findings},
+		{"classifications", rows.Classifications},
+		{"review_states", rows.ReviewStates},
+	}
+	for _, name := range ArchivedFindingTables {
+		tables = append(tables, tableRows{name, rows.Related[name]})
+	}
+
+	for _, table := range tables {
+		if len(table.rows) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(table.rows)
+		if err != nil {
+			return err
+		}
+
+		query := fmt.Sprintf(
+			`INSERT INTO %[1]s SELECT * FROM json_populate_recordset(NULL::%[1]s, $1::json) ON CONFLICT DO NOTHING`,
+			table.name)
+		if _, err := tx.ExecContext(ctx, query, string(payload)); err != nil {
+			return fmt.Errorf("failed to restore %s: %w", table.name, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE finding_archives SET status = $1, restored_at = NOW() WHERE id = $2 AND status = $3`,
+		entity.ArchiveStatusRestored, archiveID, entity.ArchiveStatusArchived)
+	if err != nil {
+		return fmt.Errorf("failed to mark archive restored: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("finding archive not found or already restored")
+	}
+
+	return tx.Commit()
+}
+
+// GetFindingArchive retrieves a finding archive by ID for the current tenant
+func (r *PostgresRepository) GetFindingArchive(ctx context.Context, id uuid.UUID) (*entity.FindingArchive, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + findingArchiveColumns + ` FROM finding_archives WHERE id = $1 AND tenant_id = $2`
+
+	archive, err := scanFindingArchive(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("finding archive not found")
+	}
+	return archive, err
+}
+
+// ListFindingArchives lists archives for the current tenant, optionally filtered by scan run and status
+func (r *PostgresRepository) ListFindingArchives(ctx context.Context, scanRunID *uuid.UUID, status string, limit, offset int) ([]*entity.FindingArchive, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + findingArchiveColumns + `
+		FROM finding_archives
+		WHERE tenant_id = $1
+		  AND ($2::uuid IS NULL OR scan_run_id = $2)
+		  AND ($3 = '' OR status = $3)
+		ORDER BY archived_at DESC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, scanRunID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var archives []*entity.FindingArchive
+	for rows.Next() {
+		archive, err := scanFindingArchive(rows)
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, archive)
+	}
+
+	return archives, rows.Err()
+}
+
+func (r *PostgresRepository) queryJSONRows(ctx context.Context, query string, args ...interface{}) ([]json.RawMessage, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []json.RawMessage
+	for rows.Next() {
+		var row json.RawMessage
+		if err := rows.Scan(&row); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+func scanFindingArchive(row rowScanner) (*entity.FindingArchive, error) {
+	archive := &entity.FindingArchive{}
+	var restoredAt sql.NullTime
+
+	err := row.Scan(
+		&archive.ID, &archive.TenantID, &archive.ScanRunID, &archive.StorageProvider, &archive.Bucket,
+		&archive.ObjectKey, &archive.ManifestKey, &archive.Format, &archive.FindingCount,
+		&archive.ClassificationCount, &archive.ReviewStateCount, &archive.SizeBytes,
+		&archive.ChecksumSHA256, &archive.Status, &archive.ArchivedBy, &archive.ArchivedAt, &restoredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if restoredAt.Valid {
+		archive.RestoredAt = &restoredAt.Time
+	}
+	return archive, nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// TestArchivesCoverCascadingFindingReferences fails when a migration adds a table
+// whose rows are deleted with their finding but would not be archived with it
+func TestArchivesCoverCascadingFindingReferences(t *testing.T) {
+	files, err := filepath.Glob("../../../../migrations_versioned/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+
+	archived := map[string]bool{"classifications": true, "review_states": true, "finding_payloads": true}
+	for _, table := range ArchivedFindingTables {
+		archived[table] = true
+	}
+
+	table := regexp.MustCompile(`(?i)(?:CREATE TABLE(?: IF NOT EXISTS)?|ALTER TABLE)\s+(\w+)`)
+	cascade := regexp.MustCompile(`(?i)REFERENCES findings\s*\(id\)\s+ON DELETE CASCADE`)
+	for _, file := range files {
+		body, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		current := ""
+		for _, line := range regexp.MustCompile(`\r?\n`).Split(string(body), -1) {
+			if m := table.FindStringSubmatch(line); m != nil {
+				current = m[1]
+			}
+			if cascade.MatchString(line) && !archived[current] {
+				t.Errorf("%s: %s rows are deleted with their finding but not archived", filepath.Base(file), current)
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Finding Comment Repository Implementation
+// ============================================================================
+
+const findingCommentColumns = `id, tenant_id, finding_id, parent_id, author_id, author, body, mentions,
+	created_at, edited_at, deleted_at`
+
+// CreateFindingComment records a comment on a finding
+func (r *PostgresRepository) CreateFindingComment(ctx context.Context, comment *entity.FindingComment) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	comment.TenantID = tenantID
+	if comment.Mentions == nil {
+		comment.Mentions = []string{}
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO finding_comments (id, tenant_id, finding_id, parent_id, author_id, author, body, mentions)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at`,
+		comment.ID, comment.TenantID, comment.FindingID, comment.ParentID, comment.AuthorID,
+		comment.Author, comment.Body, pq.Array(comment.Mentions),
+	).Scan(&comment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record finding comment: %w", err)
+	}
+	return nil
+}
+
+// ListFindingComments returns every comment on a finding, deleted ones included, oldest first
+func (r *PostgresRepository) ListFindingComments(ctx context.Context, findingID uuid.UUID) ([]*entity.FindingComment, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+findingCommentColumns+` FROM finding_comments
+		WHERE finding_id = $1 AND tenant_id = $2
+		ORDER BY created_at, id`, findingID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finding comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []*entity.FindingComment{}
+	for rows.Next() {
+		comment, err := scanFindingComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// GetFindingComment returns one comment on a finding
+func (r *PostgresRepository) GetFindingComment(ctx context.Context, findingID, id uuid.UUID) (*entity.FindingComment, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := scanFindingComment(r.db.QueryRowContext(ctx, `
+		SELECT `+findingCommentColumns+` FROM finding_comments
+		WHERE id = $1 AND finding_id = $2 AND tenant_id = $3`, id, findingID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("finding comment not found")
+	}
+	return comment, err
+}
+
+// UpdateFindingComment replaces the body and mentions of a comment that has not been deleted
+func (r *PostgresRepository) UpdateFindingComment(ctx context.Context, comment *entity.FindingComment) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		UPDATE finding_comments SET body = $4, mentions = $5, edited_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND finding_id = $2 AND tenant_id = $3 AND deleted_at IS NULL
+		RETURNING edited_at`,
+		comment.ID, comment.FindingID, tenantID, comment.Body, pq.Array(comment.Mentions),
+	).Scan(&comment.EditedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("finding comment not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update finding comment: %w", err)
+	}
+	return nil
+}
+
+// DeleteFindingComment clears a comment's body but keeps the row, so replies stay in their thread
+func (r *PostgresRepository) DeleteFindingComment(ctx context.Context, findingID, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE finding_comments SET body = '', mentions = '{}', deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND finding_id = $2 AND tenant_id = $3 AND deleted_at IS NULL`,
+		id, findingID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete finding comment: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("finding comment not found")
+	}
+	return nil
+}
+
+// CountFindingComments returns the number of comments that have not been deleted on
+// each of the given findings. Findings without comments are left out of the map.
+func (r *PostgresRepository) CountFindingComments(ctx context.Context, findingIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int)
+	if len(findingIDs) == 0 {
+		return counts, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(findingIDs))
+	for i, id := range findingIDs {
+		ids[i] = id.String()
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT finding_id, COUNT(*) FROM finding_comments
+		WHERE tenant_id = $1 AND finding_id = ANY($2::uuid[]) AND deleted_at IS NULL
+		GROUP BY finding_id`, tenantID, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count finding comments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var findingID uuid.UUID
+		var count int
+		if err := rows.Scan(&findingID, &count); err != nil {
+			return nil, err
+		}
+		counts[findingID] = count
+	}
+	return counts, rows.Err()
+}
+
+func scanFindingComment(row rowScanner) (*entity.FindingComment, error) {
+	comment := &entity.FindingComment{}
+	var parentID, authorID uuid.NullUUID
+	var editedAt, deletedAt sql.NullTime
+	err := row.Scan(
+		&comment.ID, &comment.TenantID, &comment.FindingID, &parentID, &authorID, &comment.Author,
+		&comment.Body, pq.Array(&comment.Mentions), &comment.CreatedAt, &editedAt, &deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		comment.ParentID = &parentID.UUID
+	}
+	if authorID.Valid {
+		comment.AuthorID = &authorID.UUID
+	}
+	if editedAt.Valid {
+		comment.EditedAt = &editedAt.Time
+	}
+	if deletedAt.Valid {
+		comment.DeletedAt = &deletedAt.Time
+	}
+	if comment.Mentions == nil {
+		comment.Mentions = []string{}
+	}
+	return comment, nil
+}
@@ -0,0 +1,272 @@
+package persistence
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FindingKeyWrapper seals and opens tenant data keys with the master key. The
+// encryption service keyed by ENCRYPTION_KEY implements it.
+type FindingKeyWrapper interface {
+	Encrypt(data interface{}) ([]byte, error)
+	Decrypt(ciphertext []byte, dest interface{}) error
+}
+
+// ErrFindingEncryptionUnavailable is returned when encrypted finding values are
+// read or a data key is created without a master key configured
+var ErrFindingEncryptionUnavailable = errors.New("finding encryption is not configured; set ENCRYPTION_KEY")
+
+const (
+	// sealedValuePrefix marks a value encrypted under a tenant data key; the key
+	// version and the base64 nonce and ciphertext follow
+	sealedValuePrefix = "arcenc:"
+
+	// activeDataKeyTTL bounds how long a new data key takes to reach ingestion on
+	// every replica
+	activeDataKeyTTL = time.Minute
+)
+
+// findingKeyring caches unwrapped tenant data keys. Key material of a version
+// never changes, so it is kept for the life of the process.
+type findingKeyring struct {
+	wrapper FindingKeyWrapper
+
+	mu     sync.RWMutex
+	keys   map[dataKeyID][]byte
+	active map[uuid.UUID]cachedActiveKey
+}
+
+type dataKeyID struct {
+	tenantID uuid.UUID
+	version  int
+}
+
+type cachedActiveKey struct {
+	version  int // 0 when the tenant stores values in plaintext
+	loadedAt time.Time
+}
+
+var findingKeys atomic.Pointer[findingKeyring]
+
+// ConfigureFindingEncryption sets the master key that wraps tenant data keys for
+// every repository. Without one, tenants' finding values are stored in plaintext
+// and encrypted values cannot be read.
+func ConfigureFindingEncryption(wrapper FindingKeyWrapper) {
+	if wrapper == nil {
+		findingKeys.Store(nil)
+		return
+	}
+	findingKeys.Store(&findingKeyring{
+		wrapper: wrapper,
+		keys:    make(map[dataKeyID][]byte),
+		active:  make(map[uuid.UUID]cachedActiveKey),
+	})
+}
+
+// rowQuerier is satisfied by *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// activeKey returns the tenant's active data key, or version 0 when it has none
+func (k *findingKeyring) activeKey(ctx context.Context, q rowQuerier, tenantID uuid.UUID) (int, []byte, error) {
+	k.mu.RLock()
+	cached, ok := k.active[tenantID]
+	k.mu.RUnlock()
+	if !ok || time.Since(cached.loadedAt) >= activeDataKeyTTL {
+		cached = cachedActiveKey{loadedAt: time.Now()}
+		err := q.QueryRowContext(ctx, `
+			SELECT version FROM tenant_data_keys WHERE tenant_id = $1 AND status = 'active'`,
+			tenantID).Scan(&cached.version)
+		if err != nil && err != sql.ErrNoRows {
+			return 0, nil, fmt.Errorf("failed to get active data key: %w", err)
+		}
+		k.mu.Lock()
+		k.active[tenantID] = cached
+		k.mu.Unlock()
+	}
+	if cached.version == 0 {
+		return 0, nil, nil
+	}
+	key, err := k.key(ctx, q, tenantID, cached.version)
+	return cached.version, key, err
+}
+
+// key returns the unwrapped data key of a tenant and version
+func (k *findingKeyring) key(ctx context.Context, q rowQuerier, tenantID uuid.UUID, version int) ([]byte, error) {
+	id := dataKeyID{tenantID: tenantID, version: version}
+	k.mu.RLock()
+	key, ok := k.keys[id]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	var wrapped []byte
+	err := q.QueryRowContext(ctx, `
+		SELECT wrapped_key FROM tenant_data_keys WHERE tenant_id = $1 AND version = $2`,
+		tenantID, version).Scan(&wrapped)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("data key version %d not found for tenant", version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data key: %w", err)
+	}
+	if err := k.wrapper.Decrypt(wrapped, &key); err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key version %d: %w", version, err)
+	}
+
+	k.mu.Lock()
+	k.keys[id] = key
+	k.mu.Unlock()
+	return key, nil
+}
+
+// forget drops the cached active version so a new key applies at once on this replica
+func (k *findingKeyring) forget(tenantID uuid.UUID) {
+	k.mu.Lock()
+	delete(k.active, tenantID)
+	k.mu.Unlock()
+}
+
+// sealFindingValues encrypts a finding's matches and sample text under the
+// tenant's active data key. It returns the key version, or nil when the tenant
+// stores values in plaintext. Empty values are left empty.
+func sealFindingValues(ctx context.Context, q rowQuerier, tenantID uuid.UUID, matches []string, sample string) ([]string, string, *int, error) {
+	keyring := findingKeys.Load()
+	if keyring == nil {
+		return matches, sample, nil, nil
+	}
+	version, key, err := keyring.activeKey(ctx, q, tenantID)
+	if err != nil || version == 0 {
+		return matches, sample, nil, err
+	}
+
+	sealed := make([]string, len(matches))
+	for i, match := range matches {
+		if sealed[i], err = sealValue(key, version, tenantID, match); err != nil {
+			return nil, "", nil, err
+		}
+	}
+	if sample, err = sealValue(key, version, tenantID, sample); err != nil {
+		return nil, "", nil, err
+	}
+	return sealed, sample, &version, nil
+}
+
+// openFindingValues decrypts sealed matches and sample text in place. Values
+// stored in plaintext pass through unchanged.
+func openFindingValues(ctx context.Context, q rowQuerier, tenantID uuid.UUID, matches []string, sample *string) error {
+	for i := range matches {
+		opened, err := openValue(ctx, q, tenantID, matches[i])
+		if err != nil {
+			return err
+		}
+		matches[i] = opened
+	}
+	if sample != nil {
+		opened, err := openValue(ctx, q, tenantID, *sample)
+		if err != nil {
+			return err
+		}
+		*sample = opened
+	}
+	return nil
+}
+
+// valueTenant picks the tenant whose keys open a row's values: the caller's, or
+// the row's own for system jobs spanning tenants
+func valueTenant(ctx context.Context, rowTenant uuid.UUID) uuid.UUID {
+	if tenantID, err := GetTenantID(ctx); err == nil {
+		return tenantID
+	}
+	return rowTenant
+}
+
+func openValue(ctx context.Context, q rowQuerier, tenantID uuid.UUID, value string) (string, error) {
+	if !strings.HasPrefix(value, sealedValuePrefix) {
+		return value, nil
+	}
+	keyring := findingKeys.Load()
+	if keyring == nil {
+		return "", ErrFindingEncryptionUnavailable
+	}
+
+	version, payload, err := parseSealedValue(value)
+	if err != nil {
+		return "", err
+	}
+	key, err := keyring.key(ctx, q, tenantID, version)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newValueCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(payload) < gcm.NonceSize() {
+		return "", errors.New("sealed finding value is too short")
+	}
+	nonce, ciphertext := payload[:gcm.NonceSize()], payload[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, tenantID[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt finding value: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// sealValue encrypts value with AES-256-GCM, binding it to the tenant so it
+// cannot be opened under another tenant's key
+func sealValue(key []byte, version int, tenantID uuid.UUID, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	gcm, err := newValueCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(value), tenantID[:])
+	return sealedValuePrefix + strconv.Itoa(version) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func parseSealedValue(value string) (int, []byte, error) {
+	versionText, encoded, ok := strings.Cut(strings.TrimPrefix(value, sealedValuePrefix), ":")
+	if !ok {
+		return 0, nil, errors.New("malformed sealed finding value")
+	}
+	version, err := strconv.Atoi(versionText)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed sealed finding value: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed sealed finding value: %w", err)
+	}
+	return version, payload, nil
+}
+
+func newValueCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
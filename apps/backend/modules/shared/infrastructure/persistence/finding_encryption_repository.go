@@ -0,0 +1,215 @@
+package persistence
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"io"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Finding Encryption Repository Implementation
+// ============================================================================
+
+// CreateTenantDataKey generates a data key for the tenant in ctx, wraps it with
+// the master key and makes it the active key, retiring the previous one. The
+// first key turns on encryption of new finding values.
+func (r *PostgresRepository) CreateTenantDataKey(ctx context.Context, createdBy string) (*entity.TenantDataKey, error) {
+	keyring := findingKeys.Load()
+	if keyring == nil {
+		return nil, ErrFindingEncryptionUnavailable
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	wrapped, err := keyring.wrapper.Encrypt(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Serialize concurrent rotations of the same tenant
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext('tenant_data_keys:' || $1::text))`, tenantID); err != nil {
+		return nil, fmt.Errorf("failed to lock tenant data keys: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE tenant_data_keys SET status = $2, retired_at = NOW()
+		WHERE tenant_id = $1 AND status = $3`,
+		tenantID, entity.DataKeyRetired, entity.DataKeyActive); err != nil {
+		return nil, fmt.Errorf("failed to retire data key: %w", err)
+	}
+
+	dataKey := &entity.TenantDataKey{TenantID: tenantID, Status: entity.DataKeyActive, CreatedBy: createdBy}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO tenant_data_keys (tenant_id, version, wrapped_key, status, created_by)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4 FROM tenant_data_keys WHERE tenant_id = $1
+		RETURNING version, created_at`,
+		tenantID, wrapped, entity.DataKeyActive, createdBy).Scan(&dataKey.Version, &dataKey.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store data key: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	keyring.forget(tenantID)
+	return dataKey, nil
+}
+
+// ListTenantDataKeys returns the key versions of the tenant in ctx, newest first
+func (r *PostgresRepository) ListTenantDataKeys(ctx context.Context) ([]entity.TenantDataKey, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tenant_id, version, status, created_by, created_at, retired_at
+		FROM tenant_data_keys WHERE tenant_id = $1
+		ORDER BY version DESC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []entity.TenantDataKey{}
+	for rows.Next() {
+		var key entity.TenantDataKey
+		var retiredAt sql.NullTime
+		if err := rows.Scan(&key.TenantID, &key.Version, &key.Status, &key.CreatedBy, &key.CreatedAt, &retiredAt); err != nil {
+			return nil, err
+		}
+		if retiredAt.Valid {
+			key.RetiredAt = &retiredAt.Time
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// CountFindingsByKeyVersion counts the tenant's findings per key version they
+// are stored under, plaintext first
+func (r *PostgresRepository) CountFindingsByKeyVersion(ctx context.Context) ([]entity.FindingKeyVersionCount, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT encryption_key_version, COUNT(*) FROM findings
+		WHERE tenant_id = $1
+		GROUP BY encryption_key_version
+		ORDER BY encryption_key_version NULLS FIRST`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count findings by key version: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []entity.FindingKeyVersionCount{}
+	for rows.Next() {
+		var count entity.FindingKeyVersionCount
+		var version sql.NullInt64
+		if err := rows.Scan(&version, &count.Findings); err != nil {
+			return nil, err
+		}
+		if version.Valid {
+			v := int(version.Int64)
+			count.KeyVersion = &v
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// ReencryptFindings rewrites up to limit of the tenant's findings that are in
+// plaintext or under an older key so they are sealed under the active key, and
+// returns how many it rewrote. It does nothing for tenants without a key.
+func (r *PostgresRepository) ReencryptFindings(ctx context.Context, limit int) (int, error) {
+	keyring := findingKeys.Load()
+	if keyring == nil {
+		return 0, ErrFindingEncryptionUnavailable
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version, _, err := keyring.activeKey(ctx, r.db, tenantID)
+	if err != nil || version == 0 {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, matches, sample_text FROM findings
+		WHERE tenant_id = $1 AND encryption_key_version IS DISTINCT FROM $2
+		ORDER BY id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED`, tenantID, version, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list findings to re-encrypt: %w", err)
+	}
+	type storedValues struct {
+		id      uuid.UUID
+		matches []string
+		sample  string
+	}
+	var batch []storedValues
+	for rows.Next() {
+		var v storedValues
+		if err := rows.Scan(&v.id, pq.Array(&v.matches), &v.sample); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		UPDATE findings SET matches = $2, sample_text = $3, encryption_key_version = $4
+		WHERE id = $1`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	for _, v := range batch {
+		if err := openFindingValues(ctx, tx, tenantID, v.matches, &v.sample); err != nil {
+			return 0, fmt.Errorf("failed to decrypt finding %s: %w", v.id, err)
+		}
+		matches, sample, sealedVersion, err := sealFindingValues(ctx, tx, tenantID, v.matches, v.sample)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encrypt finding %s: %w", v.id, err)
+		}
+		if _, err := stmt.ExecContext(ctx, v.id, pq.Array(matches), sample, sealedVersion); err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt finding %s: %w", v.id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(batch), nil
+}
@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonKeyWrapper stands in for the master key; wrapping is not under test
+type jsonKeyWrapper struct{}
+
+func (jsonKeyWrapper) Encrypt(data interface{}) ([]byte, error) { return json.Marshal(data) }
+
+func (jsonKeyWrapper) Decrypt(ciphertext []byte, dest interface{}) error {
+	return json.Unmarshal(ciphertext, dest)
+}
+
+func TestSealFindingValues_RoundTrip(t *testing.T) {
+	ConfigureFindingEncryption(jsonKeyWrapper{})
+	defer ConfigureFindingEncryption(nil)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	tenantID, otherTenant := uuid.New(), uuid.New()
+	wrapped, _ := json.Marshal(make([]byte, 32))
+	mock.ExpectQuery(`SELECT version FROM tenant_data_keys`).WithArgs(tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectQuery(`SELECT wrapped_key FROM tenant_data_keys`).WithArgs(tenantID, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"wrapped_key"}).AddRow(wrapped))
+
+	matches, sample, version, err := sealFindingValues(ctx, db, tenantID, []string{"asha@example.com", ""}, "email: asha@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, *version)
+	assert.True(t, strings.HasPrefix(matches[0], "arcenc:3:"))
+	assert.Equal(t, "", matches[1], "empty values stay empty")
+	assert.NotContains(t, sample, "asha")
+
+	// Opening uses the cached key without another lookup
+	stored := sample
+	assert.NoError(t, openFindingValues(ctx, db, tenantID, matches, &sample))
+	assert.Equal(t, []string{"asha@example.com", ""}, matches)
+	assert.Equal(t, "email: asha@example.com", sample)
+
+	// Values are bound to their tenant even if another tenant's key were the same
+	mock.ExpectQuery(`SELECT wrapped_key FROM tenant_data_keys`).WithArgs(otherTenant, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"wrapped_key"}).AddRow(wrapped))
+	_, err = openValue(ctx, db, otherTenant, stored)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSealFindingValues_PlaintextWithoutKey(t *testing.T) {
+	ConfigureFindingEncryption(jsonKeyWrapper{})
+	defer ConfigureFindingEncryption(nil)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	mock.ExpectQuery(`SELECT version FROM tenant_data_keys`).WithArgs(tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+	matches, sample, version, err := sealFindingValues(context.Background(), db, tenantID, []string{"4111111111111111"}, "card 4111111111111111")
+	assert.NoError(t, err)
+	assert.Nil(t, version)
+	assert.Equal(t, []string{"4111111111111111"}, matches)
+	assert.Equal(t, "card 4111111111111111", sample)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOpenValue_RequiresMasterKey(t *testing.T) {
+	ConfigureFindingEncryption(nil)
+
+	plain, err := openValue(context.Background(), nil, uuid.New(), "plain value")
+	assert.NoError(t, err)
+	assert.Equal(t, "plain value", plain)
+
+	_, err = openValue(context.Background(), nil, uuid.New(), "arcenc:1:AAAA")
+	assert.ErrorIs(t, err, ErrFindingEncryptionUnavailable)
+}
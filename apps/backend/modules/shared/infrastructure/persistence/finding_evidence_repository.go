@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Finding Evidence Repository Implementation
+// ============================================================================
+
+const findingEvidenceColumns = `id, tenant_id, finding_id, kind, file_name, content_type, size_bytes,
+	checksum_sha256, storage_provider, bucket, object_key, description, uploaded_by, created_at`
+
+// CreateFindingEvidence records an uploaded evidence object
+func (r *PostgresRepository) CreateFindingEvidence(ctx context.Context, evidence *entity.FindingEvidence) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	evidence.TenantID = tenantID
+
+	query := `
+		INSERT INTO finding_evidence (id, tenant_id, finding_id, kind, file_name, content_type, size_bytes,
+			checksum_sha256, storage_provider, bucket, object_key, description, uploaded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		evidence.ID, evidence.TenantID, evidence.FindingID, evidence.Kind, evidence.FileName,
+		evidence.ContentType, evidence.SizeBytes, evidence.ChecksumSHA256, evidence.StorageProvider,
+		evidence.Bucket, evidence.ObjectKey, evidence.Description, evidence.UploadedBy,
+	).Scan(&evidence.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record finding evidence: %w", err)
+	}
+	return nil
+}
+
+// ListFindingEvidence returns the evidence attached to a finding, oldest first
+func (r *PostgresRepository) ListFindingEvidence(ctx context.Context, findingID uuid.UUID) ([]*entity.FindingEvidence, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+findingEvidenceColumns+` FROM finding_evidence
+		WHERE finding_id = $1 AND tenant_id = $2
+		ORDER BY created_at, id`, findingID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finding evidence: %w", err)
+	}
+	defer rows.Close()
+
+	evidence := []*entity.FindingEvidence{}
+	for rows.Next() {
+		item, err := scanFindingEvidence(rows)
+		if err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, item)
+	}
+	return evidence, rows.Err()
+}
+
+// GetFindingEvidence returns one evidence item of a finding
+func (r *PostgresRepository) GetFindingEvidence(ctx context.Context, findingID, id uuid.UUID) (*entity.FindingEvidence, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	evidence, err := scanFindingEvidence(r.db.QueryRowContext(ctx, `
+		SELECT `+findingEvidenceColumns+` FROM finding_evidence
+		WHERE id = $1 AND finding_id = $2 AND tenant_id = $3`, id, findingID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("finding evidence not found")
+	}
+	return evidence, err
+}
+
+// DeleteFindingEvidence deletes an evidence row. Its object is queued for removal
+// from storage by a database trigger, as it is when the finding itself is purged.
+func (r *PostgresRepository) DeleteFindingEvidence(ctx context.Context, findingID, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM finding_evidence WHERE id = $1 AND finding_id = $2 AND tenant_id = $3`,
+		id, findingID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete finding evidence: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("finding evidence not found")
+	}
+	return nil
+}
+
+// ListEvidenceObjectDeletions returns queued object removals for one store, fewest failed
+// attempts first so a stuck object cannot hold up the rest.
+// Runs across tenants for the background cleanup worker.
+func (r *PostgresRepository) ListEvidenceObjectDeletions(ctx context.Context, provider, bucket string, limit int) ([]*entity.EvidenceObjectDeletion, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, object_key, attempts FROM evidence_object_deletions
+		WHERE storage_provider = $1 AND bucket = $2
+		ORDER BY attempts, id
+		LIMIT $3`, provider, bucket, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query evidence object deletions: %w", err)
+	}
+	defer rows.Close()
+
+	var deletions []*entity.EvidenceObjectDeletion
+	for rows.Next() {
+		deletion := &entity.EvidenceObjectDeletion{}
+		if err := rows.Scan(&deletion.ID, &deletion.ObjectKey, &deletion.Attempts); err != nil {
+			return nil, err
+		}
+		deletions = append(deletions, deletion)
+	}
+	return deletions, rows.Err()
+}
+
+// CompleteEvidenceObjectDeletion removes a queued deletion once its object is gone
+func (r *PostgresRepository) CompleteEvidenceObjectDeletion(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM evidence_object_deletions WHERE id = $1`, id)
+	return err
+}
+
+// FailEvidenceObjectDeletion records a failed removal attempt so it is retried later
+func (r *PostgresRepository) FailEvidenceObjectDeletion(ctx context.Context, id int64, reason string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE evidence_object_deletions SET attempts = attempts + 1, last_error = $2
+		WHERE id = $1`, id, reason)
+	return err
+}
+
+func scanFindingEvidence(row rowScanner) (*entity.FindingEvidence, error) {
+	evidence := &entity.FindingEvidence{}
+	err := row.Scan(
+		&evidence.ID, &evidence.TenantID, &evidence.FindingID, &evidence.Kind, &evidence.FileName,
+		&evidence.ContentType, &evidence.SizeBytes, &evidence.ChecksumSHA256, &evidence.StorageProvider,
+		&evidence.Bucket, &evidence.ObjectKey, &evidence.Description, &evidence.UploadedBy, &evidence.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
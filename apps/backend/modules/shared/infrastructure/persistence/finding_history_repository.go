@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Finding history (as-of reads)
+// ============================================================================
+
+// GetReviewStatusesAsOf returns the review status findings had at asOf, read from
+// review_states_history. Findings that had not been reviewed by then are left out.
+func (r *PostgresRepository) GetReviewStatusesAsOf(ctx context.Context, findingIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID]string, error) {
+	statuses := make(map[uuid.UUID]string)
+	if len(findingIDs) == 0 {
+		return statuses, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT rh.finding_id, rh.status FROM review_states_history rh
+		WHERE rh.finding_id = ANY($1::uuid[])
+			AND rh.valid_from <= $3 AND (rh.valid_to IS NULL OR rh.valid_to > $3)
+			AND EXISTS (SELECT 1 FROM findings_history h WHERE h.finding_id = rh.finding_id AND h.tenant_id = $2)`,
+		pq.Array(uuidStrings(findingIDs)), tenantID, asOf.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review statuses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var findingID uuid.UUID
+		var status string
+		if err := rows.Scan(&findingID, &status); err != nil {
+			return nil, err
+		}
+		statuses[findingID] = status
+	}
+	return statuses, rows.Err()
+}
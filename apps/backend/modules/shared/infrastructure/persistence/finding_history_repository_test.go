@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountFindings_AsOfReadsHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID, assetID := uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+	asOf := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM findings_history h\s+CROSS JOIN LATERAL jsonb_populate_record\(NULL::findings, h.row_data\) f.*`+
+		`h.valid_from <= \$2 AND \(h.valid_to IS NULL OR h.valid_to > \$2\)\s+AND \(f.deleted_at IS NULL OR f.deleted_at > \$2\).*`+
+		`AND f.asset_id = \$3`).
+		WithArgs(tenantID, asOf, assetID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	count, err := repo.CountFindings(ctx, repository.FindingFilters{AssetID: &assetID, AsOf: &asOf})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	// Without as_of the current rows are read
+	mock.ExpectQuery(`FROM findings f\s+LEFT JOIN classifications c ON f.id = c.finding_id\s+WHERE f.tenant_id = \$1 AND f.deleted_at IS NULL.*AND f.asset_id = \$2`).
+		WithArgs(tenantID, assetID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+	count, err = repo.CountFindings(ctx, repository.FindingFilters{AssetID: &assetID})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReviewStatusesAsOf(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID, reviewed, unreviewed := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+	asOf := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM review_states_history rh.*rh.valid_from <= \$3 AND \(rh.valid_to IS NULL OR rh.valid_to > \$3\)`).
+		WithArgs(sqlmock.AnyArg(), tenantID, asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"finding_id", "status"}).AddRow(reviewed, "false_positive"))
+
+	statuses, err := repo.GetReviewStatusesAsOf(ctx, []uuid.UUID{reviewed, unreviewed}, asOf)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]string{reviewed: "false_positive"}, statuses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
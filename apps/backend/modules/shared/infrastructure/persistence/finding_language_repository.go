@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Finding Language Repository Implementation
+// ============================================================================
+
+// CountFindingsByLanguage totals the tenant's findings created since the given
+// time per language detected in their sample text, most findings first. Findings
+// stored before detection are counted as undetermined.
+func (r *PostgresRepository) CountFindingsByLanguage(ctx context.Context, since time.Time) ([]entity.LanguageFindingCount, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT COALESCE(NULLIF(context->>'` + entity.FindingLanguageKey + `', ''), 'und'), COUNT(*),
+			COUNT(*) FILTER (WHERE context->>'` + entity.FindingLanguageReviewKey + `' = 'true'),
+			COALESCE(AVG(confidence_score), 0)::float8
+		FROM findings
+		WHERE tenant_id = $1 AND deleted_at IS NULL AND created_at >= $2
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`
+
+	counts := []entity.LanguageFindingCount{}
+	err = r.scanGroupedCounts(ctx, query, []interface{}{tenantID, since}, func(rows *sql.Rows) error {
+		var c entity.LanguageFindingCount
+		if err := rows.Scan(&c.Language, &c.Findings, &c.LanguageReview, &c.AvgConfidence); err != nil {
+			return err
+		}
+		counts = append(counts, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count findings by language: %w", err)
+	}
+	return counts, nil
+}
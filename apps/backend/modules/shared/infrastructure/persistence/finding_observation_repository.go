@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Finding Observation Repository Implementation
+// ============================================================================
+
+// exposureSpansCTE summarizes every exposure of the tenant, optionally limited to
+// one pattern, as its first and last observation
+const exposureSpansCTE = `
+	WITH exposures AS (
+		SELECT asset_id, pattern_name, value_hash,
+			MIN(observed_at) AS first_observed_at, MAX(observed_at) AS last_observed_at
+		FROM finding_observations
+		WHERE tenant_id = $1 AND ($2 = '' OR pattern_name = $2)
+		GROUP BY asset_id, pattern_name, value_hash
+	)`
+
+// RecordFindingObservation records that a scan run observed a finding within a transaction
+func (t *PostgresTransaction) RecordFindingObservation(ctx context.Context, obs *entity.FindingObservation) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	obs.TenantID = tenantID
+
+	query := `
+		INSERT INTO finding_observations (id, tenant_id, finding_id, scan_run_id, asset_id, pattern_name, value_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (finding_id, scan_run_id) DO NOTHING
+		RETURNING observed_at`
+
+	err = t.tx.QueryRowContext(ctx, query,
+		obs.ID, obs.TenantID, obs.FindingID, obs.ScanRunID, obs.AssetID, obs.PatternName, obs.ValueHash,
+	).Scan(&obs.ObservedAt)
+	if err == sql.ErrNoRows {
+		return nil // Already recorded
+	}
+	return err
+}
+
+// GetFindingExposure returns every observation of the exposure behind a finding, oldest first
+func (r *PostgresRepository) GetFindingExposure(ctx context.Context, findingID uuid.UUID) (*entity.FindingExposure, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT o.id, o.tenant_id, o.finding_id, o.scan_run_id, o.asset_id, o.pattern_name, o.value_hash, o.observed_at
+		FROM finding_observations o
+		JOIN finding_observations self
+		  ON self.asset_id = o.asset_id AND self.pattern_name = o.pattern_name AND self.value_hash = o.value_hash
+		WHERE self.finding_id = $1 AND self.tenant_id = $2 AND o.tenant_id = $2
+		ORDER BY o.observed_at ASC, o.id`
+
+	rows, err := r.db.QueryContext(ctx, query, findingID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finding observations: %w", err)
+	}
+	defer rows.Close()
+
+	exposure := &entity.FindingExposure{FindingID: findingID, Observations: []entity.FindingObservation{}}
+	for rows.Next() {
+		var obs entity.FindingObservation
+		var linked uuid.NullUUID
+		if err := rows.Scan(
+			&obs.ID, &obs.TenantID, &linked, &obs.ScanRunID, &obs.AssetID,
+			&obs.PatternName, &obs.ValueHash, &obs.ObservedAt,
+		); err != nil {
+			return nil, err
+		}
+		if linked.Valid {
+			obs.FindingID = &linked.UUID
+		}
+		exposure.Observations = append(exposure.Observations, obs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(exposure.Observations) == 0 {
+		return nil, fmt.Errorf("finding not found")
+	}
+
+	first := exposure.Observations[0]
+	last := exposure.Observations[len(exposure.Observations)-1]
+	exposure.AssetID = first.AssetID
+	exposure.PatternName = first.PatternName
+	exposure.FirstObservedAt = first.ObservedAt
+	exposure.LastObservedAt = last.ObservedAt
+	exposure.ObservationCount = len(exposure.Observations)
+	exposure.ExposureDays = int(last.ObservedAt.Sub(first.ObservedAt).Hours() / 24)
+
+	return exposure, nil
+}
+
+// CountExposuresByAge buckets exposures by whole days between their first and last
+// observation. An empty patternName counts every pattern.
+func (r *PostgresRepository) CountExposuresByAge(ctx context.Context, patternName string, buckets []entity.FindingAgingBucket) ([]entity.FindingAgingBucket, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := exposureSpansCTE + `
+		SELECT COUNT(*)
+		FROM exposures
+		WHERE last_observed_at - first_observed_at >= make_interval(days => $3)
+		  AND ($4 = 0 OR last_observed_at - first_observed_at < make_interval(days => $4))`
+
+	result := make([]entity.FindingAgingBucket, len(buckets))
+	for i, bucket := range buckets {
+		result[i] = bucket
+		if err := r.db.QueryRowContext(ctx, query, tenantID, patternName, bucket.MinDays, bucket.MaxDays).Scan(&result[i].Count); err != nil {
+			return nil, fmt.Errorf("failed to count exposures for bucket %s: %w", bucket.Label, err)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// FindingPayloadOptions controls where finding context and enrichment signals are
+// stored. Payloads over the inline limit move to the finding_payloads side table,
+// leaving the findings row with a summary that list queries and SQL filters on
+// top-level context keys keep working against.
+type FindingPayloadOptions struct {
+	InlineLimit int // Largest combined payload JSON kept in the findings row, in bytes; 0 keeps every payload inline
+}
+
+// DefaultFindingPayloadOptions are used until ConfigureFindingPayloads is called.
+// The limit matches the one migration 000052 relocated existing payloads with.
+var DefaultFindingPayloadOptions = FindingPayloadOptions{InlineLimit: 2048}
+
+// maxSummaryValueBytes is the largest encoded top-level context value kept in the
+// summary of an offloaded context
+const maxSummaryValueBytes = 256
+
+var findingPayloads atomic.Pointer[FindingPayloadOptions]
+
+// ConfigureFindingPayloads replaces the payload storage options for every
+// repository. A negative inline limit keeps every payload inline.
+func ConfigureFindingPayloads(opts FindingPayloadOptions) {
+	if opts.InlineLimit < 0 {
+		opts.InlineLimit = 0
+	}
+	findingPayloads.Store(&opts)
+}
+
+func findingPayloadOptions() FindingPayloadOptions {
+	if opts := findingPayloads.Load(); opts != nil {
+		return *opts
+	}
+	return DefaultFindingPayloadOptions
+}
+
+// splitFindingPayload decides what the findings row stores of a finding's context
+// and enrichment signals. Over the inline limit the row gets the context summary
+// and no signals, and offloaded is set: the full payloads then go to the side table.
+func splitFindingPayload(contextJSON, enrichmentJSON []byte) (rowContext, rowEnrichment []byte, offloaded bool) {
+	limit := findingPayloadOptions().InlineLimit
+	if limit == 0 || len(contextJSON)+len(enrichmentJSON) <= limit {
+		return contextJSON, enrichmentJSON, false
+	}
+	return summarizeContext(contextJSON), nil, true
+}
+
+// summarizeContext keeps the short string, number and boolean values of a
+// context object, which is what queries filter on: data_source, table, column,
+// provenance. Nested values and long strings are left to the side table.
+func summarizeContext(contextJSON []byte) []byte {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(contextJSON, &values); err != nil || values == nil {
+		return nil
+	}
+	summary := make(map[string]json.RawMessage, len(values))
+	for key, value := range values {
+		if len(value) == 0 || len(value) > maxSummaryValueBytes {
+			continue
+		}
+		switch value[0] {
+		case '{', '[', 'n':
+			continue
+		}
+		summary[key] = value
+	}
+	out, err := json.Marshal(summary)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertFindingPayload stores the full payloads of an offloaded finding
+func insertFindingPayload(ctx context.Context, ex execer, findingID uuid.UUID, contextJSON, enrichmentJSON []byte) error {
+	_, err := ex.ExecContext(ctx, `
+		INSERT INTO finding_payloads (finding_id, context, enrichment_signals, size_bytes)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (finding_id) DO UPDATE SET context = EXCLUDED.context,
+			enrichment_signals = EXCLUDED.enrichment_signals, size_bytes = EXCLUDED.size_bytes`,
+		findingID, nullJSON(contextJSON), nullJSON(enrichmentJSON), len(contextJSON)+len(enrichmentJSON))
+	if err != nil {
+		return fmt.Errorf("failed to store finding payload: %w", err)
+	}
+	return nil
+}
+
+// nullJSON stores empty and JSON null payloads as SQL NULL
+func nullJSON(data []byte) interface{} {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return data
+}
+
+// LoadFindingPayloads replaces the context summaries of offloaded findings with
+// their full context and enrichment signals. List queries return summaries only;
+// callers that need the full payloads load them for the findings at hand.
+func (r *PostgresRepository) LoadFindingPayloads(ctx context.Context, findings []*entity.Finding) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*entity.Finding)
+	var ids []uuid.UUID
+	for _, finding := range findings {
+		if finding.PayloadOffloaded {
+			byID[finding.ID] = finding
+			ids = append(ids, finding.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.finding_id, p.context, p.enrichment_signals
+		FROM finding_payloads p
+		JOIN findings f ON f.id = p.finding_id
+		WHERE p.finding_id = ANY($1::uuid[]) AND f.tenant_id = $2`,
+		pq.Array(uuidStrings(ids)), tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to load finding payloads: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var contextJSON, enrichmentJSON []byte
+		if err := rows.Scan(&id, &contextJSON, &enrichmentJSON); err != nil {
+			return err
+		}
+		finding := byID[id]
+		if finding == nil {
+			continue
+		}
+
+		finding.Context, finding.EnrichmentSignals = nil, nil
+		if len(contextJSON) > 0 {
+			if err := json.Unmarshal(contextJSON, &finding.Context); err != nil {
+				return fmt.Errorf("failed to unmarshal context: %w", err)
+			}
+		}
+		if len(enrichmentJSON) > 0 {
+			if err := json.Unmarshal(enrichmentJSON, &finding.EnrichmentSignals); err != nil {
+				return fmt.Errorf("failed to unmarshal enrichment signals: %w", err)
+			}
+		}
+		finding.PayloadOffloaded = false
+	}
+	return rows.Err()
+}
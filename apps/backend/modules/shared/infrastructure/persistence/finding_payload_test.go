@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFindingPayload_SummarizesLargeContext(t *testing.T) {
+	ConfigureFindingPayloads(FindingPayloadOptions{InlineLimit: 256})
+	defer ConfigureFindingPayloads(DefaultFindingPayloadOptions)
+
+	small := []byte(`{"table":"users"}`)
+	rowContext, rowEnrichment, offloaded := splitFindingPayload(small, []byte(`{"entropy":3.1}`))
+	assert.False(t, offloaded)
+	assert.Equal(t, small, rowContext)
+	assert.Equal(t, `{"entropy":3.1}`, string(rowEnrichment))
+
+	large, _ := json.Marshal(map[string]interface{}{
+		"data_source": "postgresql",
+		"table":       "users",
+		"row_count":   12,
+		"masked":      false,
+		"columns":     []string{"id", "email"},
+		"snippet":     strings.Repeat("x", 400),
+		"owner":       nil,
+	})
+	rowContext, rowEnrichment, offloaded = splitFindingPayload(large, []byte(`{"entropy":3.1}`))
+	assert.True(t, offloaded)
+	assert.Nil(t, rowEnrichment)
+
+	var summary map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rowContext, &summary))
+	assert.Equal(t, map[string]interface{}{
+		"data_source": "postgresql", "table": "users", "row_count": float64(12), "masked": false,
+	}, summary, "short scalar values stay queryable in the row")
+
+	// A limit of 0 keeps everything inline
+	ConfigureFindingPayloads(FindingPayloadOptions{InlineLimit: 0})
+	_, _, offloaded = splitFindingPayload(large, nil)
+	assert.False(t, offloaded)
+}
+
+func TestLoadFindingPayloads_FillsOffloadedFindings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	offloaded := &entity.Finding{ID: uuid.New(), PayloadOffloaded: true, Context: map[string]interface{}{"table": "users"}}
+	inline := &entity.Finding{ID: uuid.New(), Context: map[string]interface{}{"table": "orders"}}
+
+	mock.ExpectQuery(`SELECT p.finding_id, p.context, p.enrichment_signals`).
+		WithArgs(sqlmock.AnyArg(), tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"finding_id", "context", "enrichment_signals"}).
+			AddRow(offloaded.ID, []byte(`{"table":"users","columns":["id","email"]}`), []byte(`{"entropy":3.1}`)))
+
+	repo := NewPostgresRepository(db)
+	assert.NoError(t, repo.LoadFindingPayloads(ctx, []*entity.Finding{offloaded, inline}))
+	assert.False(t, offloaded.PayloadOffloaded)
+	assert.Equal(t, []interface{}{"id", "email"}, offloaded.Context["columns"])
+	assert.Equal(t, 3.1, offloaded.EnrichmentSignals["entropy"])
+	assert.Equal(t, "orders", inline.Context["table"], "inline findings are left as they are")
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Nothing offloaded, no query
+	assert.NoError(t, repo.LoadFindingPayloads(ctx, []*entity.Finding{inline}))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
@@ -16,12 +17,20 @@ import (
 // FindingRepository Implementation
 // ============================================================================
 
+// ErrFindingNotFound is returned for a finding ID with no finding in the tenant
+var ErrFindingNotFound = errors.New("finding not found")
+
 func (r *PostgresRepository) CreateFinding(ctx context.Context, finding *entity.Finding) error {
 	contextJSON, err := json.Marshal(finding.Context)
 	if err != nil {
 		return fmt.Errorf("failed to marshal context: %w", err)
 	}
 
+	locationsJSON, err := marshalMatchLocations(finding.Locations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal match locations: %w", err)
+	}
+
 	// Enforce Tenant ID
 	tenantID, err := EnsureTenantID(ctx)
 	if err != nil {
@@ -29,17 +38,42 @@ func (r *PostgresRepository) CreateFinding(ctx context.Context, finding *entity.
 	}
 	finding.TenantID = tenantID
 
+	matches, sampleText, keyVersion, err := sealFindingValues(ctx, r.db, tenantID, finding.Matches, finding.SampleText)
+	if err != nil {
+		return err
+	}
+
+	rowContext, _, offloaded := splitFindingPayload(contextJSON, nil)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO findings (id, tenant_id, scan_run_id, asset_id, pattern_id, pattern_name, 
-			matches, sample_text, severity, severity_description, confidence_score, environment, context)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			matches, sample_text, severity, severity_description, confidence_score, environment, context,
+			match_locations, sample_text_sha256, sample_text_redacted, encryption_key_version, payload_offloaded, total_matches)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, ''), $16, $17, $18, NULLIF($19, 0))
 		RETURNING created_at, updated_at`
 
-	return r.db.QueryRowContext(ctx, query,
+	err = tx.QueryRowContext(ctx, query,
 		finding.ID, finding.TenantID, finding.ScanRunID, finding.AssetID, finding.PatternID, finding.PatternName,
-		pq.Array(finding.Matches), finding.SampleText, finding.Severity, finding.SeverityDescription,
-		finding.ConfidenceScore, finding.Environment, contextJSON,
+		pq.Array(matches), sampleText, finding.Severity, finding.SeverityDescription,
+		finding.ConfidenceScore, finding.Environment, rowContext, locationsJSON,
+		finding.SampleTextSHA256, finding.SampleTextRedacted, keyVersion, offloaded, finding.TotalMatches,
 	).Scan(&finding.CreatedAt, &finding.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if offloaded {
+		if err := insertFindingPayload(ctx, tx, finding.ID, contextJSON, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (r *PostgresRepository) GetFindingByID(ctx context.Context, id uuid.UUID) (*entity.Finding, error) {
@@ -50,34 +84,63 @@ func (r *PostgresRepository) GetFindingByID(ctx context.Context, id uuid.UUID) (
 
 	query := `
 		SELECT id, tenant_id, scan_run_id, asset_id, pattern_id, pattern_name, matches, sample_text, 
-			severity, severity_description, confidence_score, environment, context, created_at, updated_at
-		FROM findings WHERE id = $1 AND tenant_id = $2`
+			severity, severity_description, confidence_score, environment, context,
+			enrichment_signals, match_locations, COALESCE(sample_text_sha256, ''), sample_text_redacted,
+			payload_offloaded, COALESCE(total_matches, 0), created_at, updated_at
+		FROM findings WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
 
 	finding := &entity.Finding{}
-	var contextJSON []byte
+	var contextJSON, enrichmentJSON, locationsJSON []byte
+	var totalMatches int
 
 	err = r.db.QueryRowContext(ctx, query, id, tenantID).Scan(
 		&finding.ID, &finding.TenantID, &finding.ScanRunID, &finding.AssetID, &finding.PatternID, &finding.PatternName,
 		pq.Array(&finding.Matches), &finding.SampleText, &finding.Severity, &finding.SeverityDescription,
-		&finding.ConfidenceScore, &finding.Environment, &contextJSON, &finding.CreatedAt, &finding.UpdatedAt,
+		&finding.ConfidenceScore, &finding.Environment, &contextJSON, &enrichmentJSON, &locationsJSON,
+		&finding.SampleTextSHA256, &finding.SampleTextRedacted, &finding.PayloadOffloaded, &totalMatches, &finding.CreatedAt, &finding.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("finding not found")
+			return nil, ErrFindingNotFound
 		}
 		return nil, err
 	}
+	finding.SetMatchTotal(totalMatches)
+	if err := openFindingValues(ctx, r.db, tenantID, finding.Matches, &finding.SampleText); err != nil {
+		return nil, err
+	}
 
 	if len(contextJSON) > 0 {
 		if err := json.Unmarshal(contextJSON, &finding.Context); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal context: %w", err)
 		}
 	}
+	if len(enrichmentJSON) > 0 {
+		if err := json.Unmarshal(enrichmentJSON, &finding.EnrichmentSignals); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal enrichment signals: %w", err)
+		}
+	}
+	if len(locationsJSON) > 0 {
+		if err := json.Unmarshal(locationsJSON, &finding.Locations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal match locations: %w", err)
+		}
+	}
+	if err := r.LoadFindingPayloads(ctx, []*entity.Finding{finding}); err != nil {
+		return nil, err
+	}
 
 	return finding, nil
 }
 
+// marshalMatchLocations stores findings without located matches as NULL
+func marshalMatchLocations(locations []entity.MatchLocation) ([]byte, error) {
+	if len(locations) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(locations)
+}
+
 func (r *PostgresRepository) ListFindingsByScanRun(ctx context.Context, scanRunID uuid.UUID, limit, offset int) ([]*entity.Finding, error) {
 	tenantID, err := EnsureTenantID(ctx)
 	if err != nil {
@@ -86,10 +149,11 @@ func (r *PostgresRepository) ListFindingsByScanRun(ctx context.Context, scanRunI
 
 	query := `
 		SELECT f.id, f.tenant_id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, f.matches, f.sample_text, 
-			f.severity, f.severity_description, f.confidence_score, f.environment, f.context, f.created_at, f.updated_at
+			f.severity, f.severity_description, f.confidence_score, f.environment, f.context,
+			COALESCE(f.sample_text_sha256, ''), f.sample_text_redacted, f.payload_offloaded, COALESCE(f.total_matches, 0), f.created_at, f.updated_at
 		FROM findings f
 		LEFT JOIN classifications c ON f.id = c.finding_id
-		WHERE f.scan_run_id = $1 AND f.tenant_id = $2 AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+		WHERE f.scan_run_id = $1 AND f.tenant_id = $2 AND f.deleted_at IS NULL AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
 		ORDER BY f.created_at DESC
 		LIMIT $3 OFFSET $4`
 
@@ -104,67 +168,101 @@ func (r *PostgresRepository) ListFindingsByAsset(ctx context.Context, assetID uu
 
 	query := `
 		SELECT f.id, f.tenant_id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, f.matches, f.sample_text, 
-			f.severity, f.severity_description, f.confidence_score, f.environment, f.context, f.created_at, f.updated_at
+			f.severity, f.severity_description, f.confidence_score, f.environment, f.context,
+			COALESCE(f.sample_text_sha256, ''), f.sample_text_redacted, f.payload_offloaded, COALESCE(f.total_matches, 0), f.created_at, f.updated_at
 		FROM findings f
 		LEFT JOIN classifications c ON f.id = c.finding_id
-		WHERE f.asset_id = $1 AND f.tenant_id = $2 AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+		WHERE f.asset_id = $1 AND f.tenant_id = $2 AND f.deleted_at IS NULL AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
 		ORDER BY f.created_at DESC
 		LIMIT $3 OFFSET $4`
 
 	return r.scanFindings(ctx, query, assetID, tenantID, limit, offset)
 }
 
-func (r *PostgresRepository) ListFindings(ctx context.Context, filters repository.FindingFilters, limit, offset int) ([]*entity.Finding, error) {
+func (r *PostgresRepository) ListFindings(ctx context.Context, filters repository.FindingFilters, limit, offset int) (findings []*entity.Finding, err error) {
 	tenantID, err := EnsureTenantID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// AUTO-EXCLUDE Non-PII: Join with classifications to filter out false positives
+	where, args := findingFilterClause(ctx, tenantID, filters)
 	query := `
 		SELECT DISTINCT f.id, f.tenant_id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, f.matches, f.sample_text, 
-			f.severity, f.severity_description, f.confidence_score, f.environment, f.context, f.created_at, f.updated_at
-		FROM findings f
-		LEFT JOIN classifications c ON f.id = c.finding_id
-		WHERE f.tenant_id = $1 AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')`
+			f.severity, f.severity_description, f.confidence_score, f.environment, f.context,
+			COALESCE(f.sample_text_sha256, ''), f.sample_text_redacted, f.payload_offloaded, COALESCE(f.total_matches, 0), f.created_at, f.updated_at
+		` + where + fmt.Sprintf(" ORDER BY f.created_at DESC LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	ctx, done := beginQuery(ctx, QueryInteractive, "list_findings")
+	defer func() { done(err) }()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanFindingsFromRows(ctx, rows)
+}
 
+// findingFilterClause builds the FROM and WHERE clauses of finding list queries.
+// Non-PII findings are excluded. With filters.AsOf set, the versions of findings
+// valid at that time are read from findings_history instead of the current rows;
+// classifications are always the current ones.
+func findingFilterClause(ctx context.Context, tenantID uuid.UUID, filters repository.FindingFilters) (string, []interface{}) {
 	args := []interface{}{tenantID}
-	argCount := 2
+	var query string
+	if filters.AsOf != nil {
+		args = append(args, filters.AsOf.UTC())
+		query = `
+		FROM findings_history h
+		CROSS JOIN LATERAL jsonb_populate_record(NULL::findings, h.row_data) f
+		LEFT JOIN classifications c ON f.id = c.finding_id
+		WHERE h.tenant_id = $1 AND h.valid_from <= $2 AND (h.valid_to IS NULL OR h.valid_to > $2)
+			AND (f.deleted_at IS NULL OR f.deleted_at > $2)`
+	} else {
+		query = `
+		FROM findings f
+		LEFT JOIN classifications c ON f.id = c.finding_id
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL`
+	}
+	// AUTO-EXCLUDE Non-PII: Join with classifications to filter out false positives
+	query += " AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')"
 
 	if filters.ScanRunID != nil {
-		query += fmt.Sprintf(" AND scan_run_id = $%d", argCount)
 		args = append(args, *filters.ScanRunID)
-		argCount++
+		query += fmt.Sprintf(" AND f.scan_run_id = $%d", len(args))
 	}
 
 	if filters.AssetID != nil {
-		query += fmt.Sprintf(" AND asset_id = $%d", argCount)
 		args = append(args, *filters.AssetID)
-		argCount++
+		query += fmt.Sprintf(" AND f.asset_id = $%d", len(args))
 	}
 
 	if filters.Severity != "" {
-		query += fmt.Sprintf(" AND severity = ANY(string_to_array($%d, ','))", argCount)
 		args = append(args, filters.Severity)
-		argCount++
+		query += fmt.Sprintf(" AND f.severity = ANY(string_to_array($%d, ','))", len(args))
 	}
 
 	if filters.PatternName != "" {
-		query += fmt.Sprintf(" AND pattern_name ILIKE $%d", argCount)
 		args = append(args, "%"+filters.PatternName+"%")
-		argCount++
+		query += fmt.Sprintf(" AND f.pattern_name ILIKE $%d", len(args))
 	}
 
-	query += fmt.Sprintf(" ORDER BY f.created_at DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
-	args = append(args, limit, offset)
-
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, err
+	if filters.Bucket != "" {
+		args = append(args, filters.Bucket)
+		bucketArg := len(args)
+		args = append(args, likePrefix(filters.Prefix))
+		query += fmt.Sprintf(` AND f.asset_id IN (
+			SELECT a.id FROM assets a
+			WHERE a.tenant_id = $1 AND a.data_source = 's3' AND a.host = $%d AND a.path LIKE $%d)`, bucketArg, len(args))
 	}
-	defer rows.Close()
 
-	return r.scanFindingsFromRows(rows)
+	query, args = appendOrgUnitFilters(ctx, query, args, filters.OrgUnitID)
+	if filters.ExcludeWaived {
+		query += " AND NOT EXISTS (SELECT 1 FROM finding_waivers fw WHERE fw.finding_id = f.id)"
+	}
+	return query, args
 }
 
 // ListGlobalFindings retrieves findings across all tenants (for system dashboard)
@@ -172,14 +270,18 @@ func (r *PostgresRepository) ListGlobalFindings(ctx context.Context, limit, offs
 	// Bypass tenant check
 	query := `
 		SELECT DISTINCT f.id, f.tenant_id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, f.matches, f.sample_text, 
-			f.severity, f.severity_description, f.confidence_score, f.environment, f.context, f.created_at, f.updated_at
+			f.severity, f.severity_description, f.confidence_score, f.environment, f.context,
+			COALESCE(f.sample_text_sha256, ''), f.sample_text_redacted, f.payload_offloaded, COALESCE(f.total_matches, 0), f.created_at, f.updated_at
 		FROM findings f
 		LEFT JOIN classifications c ON f.id = c.finding_id
-		WHERE (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+		WHERE f.deleted_at IS NULL AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
 		ORDER BY f.created_at DESC
 		LIMIT $1 OFFSET $2`
 
-	return r.scanFindings(ctx, query, limit, offset)
+	ctx, done := beginQuery(ctx, QueryReport, "list_global_findings")
+	findings, err := r.scanFindings(ctx, query, limit, offset)
+	done(err)
+	return findings, err
 }
 
 func (r *PostgresRepository) CountFindings(ctx context.Context, filters repository.FindingFilters) (int, error) {
@@ -188,42 +290,14 @@ func (r *PostgresRepository) CountFindings(ctx context.Context, filters reposito
 		return 0, err
 	}
 
-	// AUTO-EXCLUDE Non-PII: Join with classifications to filter out false positives
+	where, args := findingFilterClause(ctx, tenantID, filters)
 	query := `
-		SELECT COUNT(DISTINCT f.id) 
-		FROM findings f
-		LEFT JOIN classifications c ON f.id = c.finding_id
-		WHERE f.tenant_id = $1 AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')`
-
-	args := []interface{}{tenantID}
-	argCount := 2
-
-	if filters.ScanRunID != nil {
-		query += fmt.Sprintf(" AND scan_run_id = $%d", argCount)
-		args = append(args, *filters.ScanRunID)
-		argCount++
-	}
-
-	if filters.AssetID != nil {
-		query += fmt.Sprintf(" AND asset_id = $%d", argCount)
-		args = append(args, *filters.AssetID)
-		argCount++
-	}
-
-	if filters.Severity != "" {
-		query += fmt.Sprintf(" AND severity = ANY(string_to_array($%d, ','))", argCount)
-		args = append(args, filters.Severity)
-		argCount++
-	}
-
-	if filters.PatternName != "" {
-		query += fmt.Sprintf(" AND pattern_name ILIKE $%d", argCount)
-		args = append(args, "%"+filters.PatternName+"%")
-		argCount++
-	}
+		SELECT COUNT(DISTINCT f.id) ` + where
 
+	ctx, done := beginQuery(ctx, QueryInteractive, "count_findings")
 	var count int
 	err = r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	done(err)
 	return count, err
 }
 
@@ -234,23 +308,29 @@ func (r *PostgresRepository) scanFindings(ctx context.Context, query string, arg
 	}
 	defer rows.Close()
 
-	return r.scanFindingsFromRows(rows)
+	return r.scanFindingsFromRows(ctx, rows)
 }
 
-func (r *PostgresRepository) scanFindingsFromRows(rows *sql.Rows) ([]*entity.Finding, error) {
+func (r *PostgresRepository) scanFindingsFromRows(ctx context.Context, rows *sql.Rows) ([]*entity.Finding, error) {
 	var findings []*entity.Finding
 	for rows.Next() {
 		finding := &entity.Finding{}
 		var contextJSON []byte
+		var totalMatches int
 
 		err := rows.Scan(
 			&finding.ID, &finding.TenantID, &finding.ScanRunID, &finding.AssetID, &finding.PatternID, &finding.PatternName,
 			pq.Array(&finding.Matches), &finding.SampleText, &finding.Severity, &finding.SeverityDescription,
-			&finding.ConfidenceScore, &finding.Environment, &contextJSON, &finding.CreatedAt, &finding.UpdatedAt,
+			&finding.ConfidenceScore, &finding.Environment, &contextJSON,
+			&finding.SampleTextSHA256, &finding.SampleTextRedacted, &finding.PayloadOffloaded, &totalMatches, &finding.CreatedAt, &finding.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
+		finding.SetMatchTotal(totalMatches)
+		if err := openFindingValues(ctx, r.db, valueTenant(ctx, finding.TenantID), finding.Matches, &finding.SampleText); err != nil {
+			return nil, err
+		}
 
 		if len(contextJSON) > 0 {
 			if err := json.Unmarshal(contextJSON, &finding.Context); err != nil {
@@ -280,7 +360,8 @@ func (r *PostgresRepository) GetFeedbackForDataset(ctx context.Context) ([]entit
 	query := `
 		SELECT 
 			fb.id, fb.finding_id, fb.user_id, fb.feedback_type, fb.original_classification, fb.proposed_classification, fb.comments, fb.created_at, fb.processed,
-			f.id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, f.matches, f.sample_text, f.severity, f.severity_description, f.confidence_score, f.context, f.created_at, f.updated_at
+			f.id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, f.matches, f.sample_text, f.severity, f.severity_description, f.confidence_score, f.context, f.created_at, f.updated_at,
+			f.tenant_id
 		FROM finding_feedback fb
 		JOIN findings f ON fb.finding_id = f.id
 		WHERE fb.feedback_type IN ('CONFIRMED', 'FALSE_POSITIVE')
@@ -297,14 +378,19 @@ func (r *PostgresRepository) GetFeedbackForDataset(ctx context.Context) ([]entit
 	for rows.Next() {
 		var item entity.FeedbackWithFinding
 		var contextJSON []byte
+		var rowTenant uuid.NullUUID
 
 		err := rows.Scan(
 			&item.Feedback.ID, &item.Feedback.FindingID, &item.Feedback.UserID, &item.Feedback.FeedbackType, &item.Feedback.OriginalClassification, &item.Feedback.ProposedClassification, &item.Feedback.Comments, &item.Feedback.CreatedAt, &item.Feedback.Processed,
 			&item.Finding.ID, &item.Finding.ScanRunID, &item.Finding.AssetID, &item.Finding.PatternID, &item.Finding.PatternName, pq.Array(&item.Finding.Matches), &item.Finding.SampleText, &item.Finding.Severity, &item.Finding.SeverityDescription, &item.Finding.ConfidenceScore, &contextJSON, &item.Finding.CreatedAt, &item.Finding.UpdatedAt,
+			&rowTenant,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan feedback row: %w", err)
 		}
+		if err := openFindingValues(ctx, r.db, valueTenant(ctx, rowTenant.UUID), item.Finding.Matches, &item.Finding.SampleText); err != nil {
+			return nil, err
+		}
 
 		if len(contextJSON) > 0 {
 			if err := json.Unmarshal(contextJSON, &item.Finding.Context); err != nil {
@@ -360,11 +446,11 @@ func (r *PostgresRepository) GetFindingsByAssetWithMasking(ctx context.Context,
 	query := `
 		SELECT f.id, f.tenant_id, f.scan_run_id, f.asset_id, f.pattern_id, f.pattern_name, 
 			f.matches, f.masked_value, f.sample_text, f.severity, f.severity_description, 
-			f.confidence_score, f.context, f.created_at, f.updated_at,
+			f.confidence_score, f.context, COALESCE(f.total_matches, 0), f.created_at, f.updated_at,
 			a.is_masked
 		FROM findings f
 		JOIN assets a ON f.asset_id = a.id
-		WHERE f.asset_id = $1 AND f.tenant_id = $2
+		WHERE f.asset_id = $1 AND f.tenant_id = $2 AND f.deleted_at IS NULL
 		ORDER BY f.created_at DESC`
 
 	rows, err := r.db.QueryContext(ctx, query, assetID, tenantID)
@@ -378,16 +464,21 @@ func (r *PostgresRepository) GetFindingsByAssetWithMasking(ctx context.Context,
 		finding := &entity.Finding{}
 		var contextJSON []byte
 		var isMasked bool
+		var totalMatches int
 
 		err := rows.Scan(
 			&finding.ID, &finding.TenantID, &finding.ScanRunID, &finding.AssetID, &finding.PatternID, &finding.PatternName,
 			pq.Array(&finding.Matches), &finding.MaskedValue, &finding.SampleText, &finding.Severity, &finding.SeverityDescription,
-			&finding.ConfidenceScore, &contextJSON, &finding.CreatedAt, &finding.UpdatedAt,
+			&finding.ConfidenceScore, &contextJSON, &totalMatches, &finding.CreatedAt, &finding.UpdatedAt,
 			&isMasked,
 		)
 		if err != nil {
 			return nil, err
 		}
+		finding.SetMatchTotal(totalMatches)
+		if err := openFindingValues(ctx, r.db, tenantID, finding.Matches, &finding.SampleText); err != nil {
+			return nil, err
+		}
 
 		if len(contextJSON) > 0 {
 			if err := json.Unmarshal(contextJSON, &finding.Context); err != nil {
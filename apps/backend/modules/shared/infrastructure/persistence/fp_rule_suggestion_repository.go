@@ -0,0 +1,234 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// FP Rule Suggestion Repository Implementation
+// ============================================================================
+
+const fpRuleSuggestionColumns = `id, tenant_id, kind, fingerprint, pattern_name, path_prefix, token_shape,
+	false_positives, confirmed, asset_count, sample_finding_ids, status, applied_rule_ids,
+	COALESCE(reviewed_by, ''), reviewed_at, review_note, generated_at, updated_at`
+
+// ListFeedbackSignals returns the tenant's CONFIRMED and FALSE_POSITIVE feedback since
+// the given time, joined with the finding's pattern, asset path and first match.
+// Only the latest feedback per finding counts, so a reversed verdict is not double counted.
+func (r *PostgresRepository) ListFeedbackSignals(ctx context.Context, since time.Time) ([]entity.FeedbackSignal, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT DISTINCT ON (fb.finding_id)
+			f.id, f.asset_id, f.pattern_name, COALESCE(a.path, ''), fb.feedback_type,
+			COALESCE(f.matches[1], '')
+		FROM finding_feedback fb
+		JOIN findings f ON f.id = fb.finding_id
+		JOIN assets a ON a.id = f.asset_id
+		WHERE f.tenant_id = $1
+			AND fb.feedback_type IN ('CONFIRMED', 'FALSE_POSITIVE')
+			AND fb.created_at >= $2
+		ORDER BY fb.finding_id, fb.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback signals: %w", err)
+	}
+	defer rows.Close()
+
+	var signals []entity.FeedbackSignal
+	for rows.Next() {
+		var s entity.FeedbackSignal
+		if err := rows.Scan(&s.FindingID, &s.AssetID, &s.PatternName, &s.Path, &s.FeedbackType, &s.Sample); err != nil {
+			return nil, fmt.Errorf("failed to scan feedback signal: %w", err)
+		}
+		if s.Sample, err = openValue(ctx, r.db, tenantID, s.Sample); err != nil {
+			return nil, err
+		}
+		signals = append(signals, s)
+	}
+	return signals, rows.Err()
+}
+
+// UpsertFPRuleSuggestion stores a suggestion, refreshing the counts of a pending
+// suggestion for the same cluster. Reviewed suggestions are left untouched so a
+// rejected rule is not proposed again. Reports whether a new suggestion was created.
+func (r *PostgresRepository) UpsertFPRuleSuggestion(ctx context.Context, s *entity.FPRuleSuggestion) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+	s.TenantID = tenantID
+
+	query := `
+		INSERT INTO fp_rule_suggestions (id, tenant_id, kind, fingerprint, pattern_name, path_prefix, token_shape,
+			false_positives, confirmed, asset_count, sample_finding_ids)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11::uuid[])
+		ON CONFLICT (tenant_id, fingerprint) DO UPDATE SET
+			false_positives = EXCLUDED.false_positives,
+			confirmed = EXCLUDED.confirmed,
+			asset_count = EXCLUDED.asset_count,
+			sample_finding_ids = EXCLUDED.sample_finding_ids,
+			updated_at = NOW()
+		WHERE fp_rule_suggestions.status = 'pending'
+		RETURNING (xmax = 0)`
+
+	var inserted bool
+	err = r.db.QueryRowContext(ctx, query,
+		s.ID, tenantID, s.Kind, s.Fingerprint, s.PatternName, s.PathPrefix, s.TokenShape,
+		s.FalsePositives, s.Confirmed, s.AssetCount, pq.Array(uuidStrings(s.SampleFindingIDs)),
+	).Scan(&inserted)
+	if err == sql.ErrNoRows {
+		// Already reviewed
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to store rule suggestion: %w", err)
+	}
+	return inserted, nil
+}
+
+// ListFPRuleSuggestions returns the tenant's suggestions, optionally filtered by status, largest clusters first
+func (r *PostgresRepository) ListFPRuleSuggestions(ctx context.Context, status string) ([]*entity.FPRuleSuggestion, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + fpRuleSuggestionColumns + ` FROM fp_rule_suggestions
+		WHERE tenant_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY false_positives DESC, generated_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := make([]*entity.FPRuleSuggestion, 0)
+	for rows.Next() {
+		s, err := scanFPRuleSuggestion(rows)
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, s)
+	}
+	return suggestions, rows.Err()
+}
+
+// GetFPRuleSuggestion retrieves a suggestion by ID
+func (r *PostgresRepository) GetFPRuleSuggestion(ctx context.Context, id uuid.UUID) (*entity.FPRuleSuggestion, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + fpRuleSuggestionColumns + ` FROM fp_rule_suggestions WHERE id = $1 AND tenant_id = $2`
+	s, err := scanFPRuleSuggestion(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("rule suggestion not found")
+	}
+	return s, err
+}
+
+// ReviewFPRuleSuggestion moves a pending suggestion to approved or rejected
+func (r *PostgresRepository) ReviewFPRuleSuggestion(ctx context.Context, id uuid.UUID, status, reviewedBy, note string, appliedRuleIDs []uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE fp_rule_suggestions
+		SET status = $1, reviewed_by = $2, review_note = $3, applied_rule_ids = $4::uuid[],
+			reviewed_at = NOW(), updated_at = NOW()
+		WHERE id = $5 AND tenant_id = $6 AND status = 'pending'`
+
+	res, err := r.db.ExecContext(ctx, query, status, reviewedBy, note, pq.Array(uuidStrings(appliedRuleIDs)), id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to review rule suggestion: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("rule suggestion not found or already reviewed")
+	}
+	return nil
+}
+
+// ListFindingSamples returns the asset, scan run, pattern and first match of the given findings
+func (r *PostgresRepository) ListFindingSamples(ctx context.Context, ids []uuid.UUID) ([]*entity.Finding, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, asset_id, scan_run_id, pattern_name, COALESCE(matches[1], '')
+		FROM findings
+		WHERE tenant_id = $1 AND id = ANY($2::uuid[])`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, pq.Array(uuidStrings(ids)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finding samples: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []*entity.Finding
+	for rows.Next() {
+		f := &entity.Finding{}
+		var sample string
+		if err := rows.Scan(&f.ID, &f.AssetID, &f.ScanRunID, &f.PatternName, &sample); err != nil {
+			return nil, fmt.Errorf("failed to scan finding sample: %w", err)
+		}
+		if sample, err = openValue(ctx, r.db, tenantID, sample); err != nil {
+			return nil, err
+		}
+		if sample != "" {
+			f.Matches = []string{sample}
+		}
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+func scanFPRuleSuggestion(row rowScanner) (*entity.FPRuleSuggestion, error) {
+	s := &entity.FPRuleSuggestion{}
+	var samples, applied pq.StringArray
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&s.ID, &s.TenantID, &s.Kind, &s.Fingerprint, &s.PatternName, &s.PathPrefix, &s.TokenShape,
+		&s.FalsePositives, &s.Confirmed, &s.AssetCount, &samples, &s.Status, &applied,
+		&s.ReviewedBy, &reviewedAt, &s.ReviewNote, &s.GeneratedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if reviewedAt.Valid {
+		s.ReviewedAt = &reviewedAt.Time
+	}
+	if total := s.FalsePositives + s.Confirmed; total > 0 {
+		s.Precision = float64(s.FalsePositives) / float64(total)
+	}
+	s.SampleFindingIDs = parseUUIDStrings(samples)
+	s.AppliedRuleIDs = parseUUIDStrings(applied)
+	return s, nil
+}
+
+func parseUUIDStrings(values []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		if id, err := uuid.Parse(v); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Idempotency Key Repository Implementation
+// ============================================================================
+
+// ReserveIdempotencyKey claims a key for a new request, replacing an expired record.
+// It returns the live record when the key is already taken.
+func (r *PostgresRepository) ReserveIdempotencyKey(ctx context.Context, endpoint, key string, expiresAt time.Time) (*entity.IdempotencyRecord, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A key released between the insert and the lookup is free again, so try once more
+	for attempt := 0; attempt < 2; attempt++ {
+		var createdAt time.Time
+		err := r.db.QueryRowContext(ctx, `
+			INSERT INTO idempotency_keys (tenant_id, endpoint, idempotency_key, expires_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, endpoint, idempotency_key) DO UPDATE
+			SET request_hash = NULL, status_code = NULL, content_type = NULL, response_body = NULL,
+			    created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+			RETURNING created_at
+		`, tenantID, endpoint, key, expiresAt).Scan(&createdAt)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+
+		record := &entity.IdempotencyRecord{TenantID: tenantID, Endpoint: endpoint, Key: key}
+		var requestHash, contentType sql.NullString
+		var statusCode sql.NullInt64
+		err = r.db.QueryRowContext(ctx, `
+			SELECT request_hash, status_code, content_type, response_body, created_at, expires_at
+			FROM idempotency_keys
+			WHERE tenant_id = $1 AND endpoint = $2 AND idempotency_key = $3
+		`, tenantID, endpoint, key).Scan(
+			&requestHash, &statusCode, &contentType, &record.Body, &record.CreatedAt, &record.ExpiresAt,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+		record.RequestHash = requestHash.String
+		record.StatusCode = int(statusCode.Int64)
+		record.ContentType = contentType.String
+		return record, nil
+	}
+
+	return nil, fmt.Errorf("idempotency key %q is contended", key)
+}
+
+// CompleteIdempotencyKey stores the response produced for a reserved key
+func (r *PostgresRepository) CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE idempotency_keys
+		SET request_hash = $4, status_code = $5, content_type = $6, response_body = $7
+		WHERE tenant_id = $1 AND endpoint = $2 AND idempotency_key = $3
+	`, tenantID, record.Endpoint, record.Key, record.RequestHash, record.StatusCode, record.ContentType, record.Body)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("idempotency key not found")
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a key so a retried request is processed again
+func (r *PostgresRepository) ReleaseIdempotencyKey(ctx context.Context, endpoint, key string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE tenant_id = $1 AND endpoint = $2 AND idempotency_key = $3
+	`, tenantID, endpoint, key)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpiredIdempotencyKeys deletes expired keys of every tenant
+func (r *PostgresRepository) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
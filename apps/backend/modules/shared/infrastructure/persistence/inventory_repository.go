@@ -0,0 +1,201 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Inventory Repository Implementation
+// ============================================================================
+
+const inventorySystemColumns = `id, tenant_id, provider, resource_id, resource_type, name,
+	COALESCE(account, ''), COALESCE(region, ''), hosts, COALESCE(environment, ''), COALESCE(owner, ''),
+	tags, last_seen_at`
+
+// ReplaceInventorySystems stores the systems of one provider's inventory for the
+// tenant in ctx, removing the provider's systems that are no longer in it, and
+// returns how many were removed
+func (r *PostgresRepository) ReplaceInventorySystems(ctx context.Context, provider string, systems []entity.InventorySystem) (int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO inventory_systems (tenant_id, provider, resource_id, resource_type, name, account, region, hosts, environment, owner, tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (tenant_id, provider, resource_id) DO UPDATE SET
+			resource_type = EXCLUDED.resource_type,
+			name = EXCLUDED.name,
+			account = EXCLUDED.account,
+			region = EXCLUDED.region,
+			hosts = EXCLUDED.hosts,
+			environment = EXCLUDED.environment,
+			owner = EXCLUDED.owner,
+			tags = EXCLUDED.tags,
+			last_seen_at = NOW()`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	resourceIDs := make([]string, 0, len(systems))
+	for _, system := range systems {
+		tags, err := json.Marshal(system.Tags)
+		if err != nil {
+			return 0, err
+		}
+		hosts := make([]string, 0, len(system.Hosts))
+		for _, host := range system.Hosts {
+			hosts = append(hosts, strings.ToLower(host))
+		}
+		if _, err := stmt.ExecContext(ctx, tenantID, provider, system.ResourceID, system.ResourceType, system.Name,
+			system.Account, system.Region, pq.Array(hosts), system.Environment, system.Owner, tags); err != nil {
+			return 0, fmt.Errorf("failed to store inventory system %s: %w", system.ResourceID, err)
+		}
+		resourceIDs = append(resourceIDs, system.ResourceID)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		DELETE FROM inventory_systems
+		WHERE tenant_id = $1 AND provider = $2 AND resource_id <> ALL($3)`,
+		tenantID, provider, pq.Array(resourceIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove stale inventory systems: %w", err)
+	}
+	removed, _ := result.RowsAffected()
+
+	return int(removed), tx.Commit()
+}
+
+// ListInventorySystems returns the tenant's imported systems, optionally of one provider
+func (r *PostgresRepository) ListInventorySystems(ctx context.Context, provider string) ([]entity.InventorySystem, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+inventorySystemColumns+`
+		FROM inventory_systems
+		WHERE tenant_id = $1 AND ($2 = '' OR provider = $2)
+		ORDER BY provider, name`, tenantID, provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory systems: %w", err)
+	}
+	defer rows.Close()
+
+	systems := []entity.InventorySystem{}
+	for rows.Next() {
+		system, err := scanInventorySystem(rows)
+		if err != nil {
+			return nil, err
+		}
+		systems = append(systems, *system)
+	}
+	return systems, rows.Err()
+}
+
+// GetInventorySystemForHost returns the imported system of a tenant that an
+// asset host belongs to, or nil when the host matches none
+func (r *PostgresRepository) GetInventorySystemForHost(ctx context.Context, tenantID uuid.UUID, host string) (*entity.InventorySystem, error) {
+	if host == "" {
+		return nil, nil
+	}
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+inventorySystemColumns+`
+		FROM inventory_systems
+		WHERE tenant_id = $1 AND hosts @> ARRAY[lower($2)]
+		ORDER BY last_seen_at DESC
+		LIMIT 1`, tenantID, host)
+	system, err := scanInventorySystem(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory system: %w", err)
+	}
+	return system, nil
+}
+
+// ApplyInventoryToAssets copies the environment and owner of a provider's
+// systems onto the tenant's assets on their hosts, where the inventory has a
+// value. It returns every matched asset and how many of them changed.
+func (r *PostgresRepository) ApplyInventoryToAssets(ctx context.Context, provider string) ([]uuid.UUID, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	const matched = `
+		SELECT DISTINCT ON (a.id) a.id, s.environment, s.owner
+		FROM assets a
+		JOIN inventory_systems s ON s.tenant_id = a.tenant_id AND s.hosts @> ARRAY[lower(a.host)]
+		WHERE a.tenant_id = $1 AND s.provider = $2 AND a.deleted_at IS NULL
+		ORDER BY a.id, s.last_seen_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM (`+matched+`) m`, tenantID, provider)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to match assets to inventory: %w", err)
+	}
+	defer rows.Close()
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return nil, 0, nil
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		WITH matched AS (`+matched+`)
+		UPDATE assets a SET
+			environment = COALESCE(NULLIF(m.environment, ''), a.environment),
+			owner = COALESCE(NULLIF(m.owner, ''), a.owner),
+			updated_at = NOW()
+		FROM matched m
+		WHERE a.id = m.id
+		  AND (a.environment IS DISTINCT FROM COALESCE(NULLIF(m.environment, ''), a.environment)
+		    OR a.owner IS DISTINCT FROM COALESCE(NULLIF(m.owner, ''), a.owner))`,
+		tenantID, provider)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to enrich assets from inventory: %w", err)
+	}
+	enriched, _ := result.RowsAffected()
+	return ids, int(enriched), nil
+}
+
+func scanInventorySystem(row rowScanner) (*entity.InventorySystem, error) {
+	var system entity.InventorySystem
+	var tags []byte
+	if err := row.Scan(&system.ID, &system.TenantID, &system.Provider, &system.ResourceID, &system.ResourceType,
+		&system.Name, &system.Account, &system.Region, pq.Array(&system.Hosts), &system.Environment, &system.Owner,
+		&tags, &system.LastSeenAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(tags, &system.Tags); err != nil {
+		return nil, fmt.Errorf("failed to decode inventory tags: %w", err)
+	}
+	return &system, nil
+}
@@ -0,0 +1,260 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Job Repository Implementation
+// ============================================================================
+
+const jobColumns = `id, tenant_id, job_type, payload, status, attempts, max_attempts, scheduled_at,
+	COALESCE(last_error, ''), COALESCE(locked_by, ''), locked_at, completed_at, created_at, updated_at`
+
+// CreateJob stores a pending job for the tenant in ctx, or a system job when ctx has none
+func (r *PostgresRepository) CreateJob(ctx context.Context, job *entity.Job) error {
+	if tenantID, err := GetTenantID(ctx); err == nil {
+		job.TenantID = &tenantID
+	}
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	if job.Payload == nil {
+		job.Payload = map[string]interface{}{}
+	}
+	payloadJSON, err := json.Marshal(job.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job payload: %w", err)
+	}
+	job.Status = entity.JobStatusPending
+
+	query := `
+		INSERT INTO jobs (id, tenant_id, job_type, payload, status, max_attempts, scheduled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		job.ID, job.TenantID, job.Type, payloadJSON, job.Status, job.MaxAttempts, job.ScheduledAt,
+	).Scan(&job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueJobs marks up to limit due pending jobs of the given types running for
+// workerID. Replicas claiming at once skip each other's rows.
+func (r *PostgresRepository) ClaimDueJobs(ctx context.Context, jobTypes []string, workerID string, limit int) ([]*entity.Job, error) {
+	query := `
+		UPDATE jobs SET status = $1, attempts = attempts + 1, locked_by = $2, locked_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status = $3 AND scheduled_at <= NOW() AND job_type = ANY($4)
+			ORDER BY scheduled_at, created_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := r.db.QueryContext(ctx, query,
+		entity.JobStatusRunning, workerID, entity.JobStatusPending, pq.Array(jobTypes), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*entity.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// CompleteJob marks a running job succeeded
+func (r *PostgresRepository) CompleteJob(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE jobs SET status = $2, last_error = NULL, locked_by = NULL, locked_at = NULL,
+			completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, id, entity.JobStatusSucceeded, entity.JobStatusRunning); err != nil {
+		return fmt.Errorf("failed to complete job: %w", err)
+	}
+	return nil
+}
+
+// FailJob records a failed attempt of a running job: it is retried at retryAt, or
+// marked failed when retryAt is nil
+func (r *PostgresRepository) FailJob(ctx context.Context, id uuid.UUID, jobErr string, retryAt *time.Time) error {
+	var query string
+	args := []interface{}{id, jobErr, entity.JobStatusRunning}
+	if retryAt != nil {
+		query = `
+			UPDATE jobs SET status = $4, scheduled_at = $5, last_error = $2, locked_by = NULL, locked_at = NULL, updated_at = NOW()
+			WHERE id = $1 AND status = $3`
+		args = append(args, entity.JobStatusPending, *retryAt)
+	} else {
+		query = `
+			UPDATE jobs SET status = $4, last_error = $2, locked_by = NULL, locked_at = NULL,
+				completed_at = NOW(), updated_at = NOW()
+			WHERE id = $1 AND status = $3`
+		args = append(args, entity.JobStatusFailed)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record job failure: %w", err)
+	}
+	return nil
+}
+
+// RequeueStaleJobs returns running jobs locked before lockedBefore, whose worker
+// is assumed to have died, to pending
+func (r *PostgresRepository) RequeueStaleJobs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	query := `
+		UPDATE jobs SET status = $1, last_error = 'worker lost during attempt', locked_by = NULL, locked_at = NULL, updated_at = NOW()
+		WHERE status = $2 AND locked_at < $3`
+
+	result, err := r.db.ExecContext(ctx, query, entity.JobStatusPending, entity.JobStatusRunning, lockedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue stale jobs: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// ListJobs retrieves the tenant's jobs, newest first, with the total matching the filter
+func (r *PostgresRepository) ListJobs(ctx context.Context, filter entity.JobFilter) ([]*entity.Job, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{"tenant_id = $1"}
+	args := []interface{}{tenantID}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("job_type = $%d", len(args)))
+	}
+	where := strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM jobs WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	args = append(args, limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM jobs WHERE %s ORDER BY created_at DESC, id LIMIT $%d OFFSET $%d`,
+		jobColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []*entity.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, total, rows.Err()
+}
+
+// GetJob retrieves one of the tenant's jobs, or nil when it has none with the ID
+func (r *PostgresRepository) GetJob(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return job, err
+}
+
+// RetryJob makes one of the tenant's failed or cancelled jobs due now with a fresh
+// attempt budget. It returns false when the job is in another state.
+func (r *PostgresRepository) RetryJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET status = $3, attempts = 0, scheduled_at = NOW(), completed_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status IN ($4, $5)`,
+		id, tenantID, entity.JobStatusPending, entity.JobStatusFailed, entity.JobStatusCancelled)
+	if err != nil {
+		return false, fmt.Errorf("failed to retry job: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// CancelJob cancels one of the tenant's pending jobs. It returns false when the job
+// is in another state.
+func (r *PostgresRepository) CancelJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET status = $3, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status = $4`,
+		id, tenantID, entity.JobStatusCancelled, entity.JobStatusPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func scanJob(row rowScanner) (*entity.Job, error) {
+	job := &entity.Job{}
+	var tenantID uuid.NullUUID
+	var payloadJSON []byte
+	var lockedAt, completedAt sql.NullTime
+	if err := row.Scan(&job.ID, &tenantID, &job.Type, &payloadJSON, &job.Status, &job.Attempts, &job.MaxAttempts,
+		&job.ScheduledAt, &job.LastError, &job.LockedBy, &lockedAt, &completedAt, &job.CreatedAt, &job.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if tenantID.Valid {
+		job.TenantID = &tenantID.UUID
+	}
+	if lockedAt.Valid {
+		job.LockedAt = &lockedAt.Time
+	}
+	if completedAt.Valid {
+		job.CompletedAt = &completedAt.Time
+	}
+	if err := json.Unmarshal(payloadJSON, &job.Payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job payload: %w", err)
+	}
+	return job, nil
+}
@@ -0,0 +1,95 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Jurisdiction Repository Implementation
+// ============================================================================
+
+const jurisdictionProfileColumns = `code, name, framework, pii_types, version, updated_at`
+
+// ListJurisdictionProfiles retrieves every jurisdiction profile. Profiles are shared
+// by all tenants and edited in the database.
+func (r *PostgresRepository) ListJurisdictionProfiles(ctx context.Context) ([]*entity.JurisdictionProfile, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+jurisdictionProfileColumns+` FROM jurisdiction_profiles ORDER BY code`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jurisdiction profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := []*entity.JurisdictionProfile{}
+	for rows.Next() {
+		profile, err := scanJurisdictionProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// GetJurisdictionProfile retrieves a profile by code, or nil when none has it
+func (r *PostgresRepository) GetJurisdictionProfile(ctx context.Context, code string) (*entity.JurisdictionProfile, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+jurisdictionProfileColumns+` FROM jurisdiction_profiles WHERE code = $1`, code)
+	profile, err := scanJurisdictionProfile(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return profile, err
+}
+
+// GetTenantJurisdiction returns the profile code the tenant selected, or "" when it has not
+func (r *PostgresRepository) GetTenantJurisdiction(ctx context.Context) (string, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var code string
+	err = r.db.QueryRowContext(ctx, `SELECT profile_code FROM tenant_jurisdictions WHERE tenant_id = $1`, tenantID).Scan(&code)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get tenant jurisdiction: %w", err)
+	}
+	return code, nil
+}
+
+// SetTenantJurisdiction selects the tenant's jurisdiction profile
+func (r *PostgresRepository) SetTenantJurisdiction(ctx context.Context, code, updatedBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO tenant_jurisdictions (tenant_id, profile_code, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET profile_code = EXCLUDED.profile_code, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, code, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant jurisdiction: %w", err)
+	}
+	return nil
+}
+
+func scanJurisdictionProfile(row rowScanner) (*entity.JurisdictionProfile, error) {
+	var profile entity.JurisdictionProfile
+	var piiTypesJSON []byte
+	if err := row.Scan(&profile.Code, &profile.Name, &profile.Framework, &piiTypesJSON, &profile.Version, &profile.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(piiTypesJSON, &profile.PIITypes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PII types of jurisdiction %s: %w", profile.Code, err)
+	}
+	return &profile, nil
+}
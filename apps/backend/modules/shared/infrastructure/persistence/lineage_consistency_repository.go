@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Lineage Consistency Repository Implementation
+// ============================================================================
+
+// ListAssetLineageStates returns what the lineage graph should hold for every live asset of
+// the tenant. PII types follow the sync's rules: the first classification of each live,
+// non Non-PII finding, at or above minConfidence, with a PII type set.
+func (r *PostgresRepository) ListAssetLineageStates(ctx context.Context, minConfidence float64) ([]*entity.AssetLineageState, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT a.id, a.name, COALESCE(a.host, ''), COALESCE(a.environment, ''),
+			COALESCE(a.risk_score, 0), COALESCE(a.total_findings, 0),
+			ARRAY(
+				SELECT DISTINCT c.sub_category
+				FROM findings f
+				JOIN LATERAL (
+					SELECT sub_category, classification_type, confidence_score
+					FROM classifications WHERE finding_id = f.id LIMIT 1
+				) c ON TRUE
+				WHERE f.asset_id = a.id AND f.deleted_at IS NULL
+				  AND c.classification_type <> 'Non-PII'
+				  AND c.confidence_score >= $2
+				  AND COALESCE(c.sub_category, '') <> ''
+				ORDER BY 1
+			)
+		FROM assets a
+		WHERE a.tenant_id = $1 AND a.deleted_at IS NULL
+		ORDER BY a.id`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, minConfidence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset lineage states: %w", err)
+	}
+	defer rows.Close()
+
+	states := []*entity.AssetLineageState{}
+	for rows.Next() {
+		state := &entity.AssetLineageState{}
+		if err := rows.Scan(&state.AssetID, &state.Name, &state.Host, &state.Environment,
+			&state.RiskScore, &state.TotalFindings, pq.Array(&state.PIITypes)); err != nil {
+			return nil, fmt.Errorf("failed to scan asset lineage state: %w", err)
+		}
+		states = append(states, state)
+	}
+	return states, rows.Err()
+}
+
+// ListAssetTenantIDs returns every tenant that owns live assets (used by cross-tenant tooling)
+func (r *PostgresRepository) ListAssetTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT tenant_id FROM assets
+		WHERE tenant_id IS NOT NULL AND deleted_at IS NULL
+		ORDER BY tenant_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	return tenantIDs, rows.Err()
+}
+
+// FilterLiveAssetIDs returns the given IDs that belong to a live asset of any tenant
+func (r *PostgresRepository) FilterLiveAssetIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id::text FROM assets
+		WHERE id::text = ANY($1::text[]) AND deleted_at IS NULL`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query live assets: %w", err)
+	}
+	defer rows.Close()
+
+	live := make(map[string]bool, len(ids))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		live[id] = true
+	}
+	return live, rows.Err()
+}
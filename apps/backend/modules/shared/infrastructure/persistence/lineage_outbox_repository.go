@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Lineage Sync Outbox Repository Implementation
+// ============================================================================
+
+// EnqueueLineageSync defers an asset's lineage sync. An asset already waiting
+// keeps its row; only the request time and reason are refreshed.
+func (r *PostgresRepository) EnqueueLineageSync(ctx context.Context, assetID uuid.UUID, reason string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO lineage_sync_outbox (tenant_id, asset_id, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (asset_id) DO UPDATE
+		SET reason = EXCLUDED.reason, requested_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.ExecContext(ctx, query, tenantID, assetID, reason); err != nil {
+		return fmt.Errorf("failed to enqueue lineage sync: %w", err)
+	}
+	return nil
+}
+
+// enqueueLineageSyncs defers the lineage syncs of several assets within the
+// transaction that changed them
+func enqueueLineageSyncs(ctx context.Context, ex execer, tenantID uuid.UUID, assetIDs []uuid.UUID, reason string) error {
+	if len(assetIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO lineage_sync_outbox (tenant_id, asset_id, reason)
+		SELECT DISTINCT $1::uuid, asset_id, $3 FROM unnest($2::uuid[]) AS asset_id
+		ON CONFLICT (asset_id) DO UPDATE
+		SET reason = EXCLUDED.reason, requested_at = CURRENT_TIMESTAMP`
+
+	if _, err := ex.ExecContext(ctx, query, tenantID, pq.Array(uuidStrings(assetIDs)), reason); err != nil {
+		return fmt.Errorf("failed to enqueue lineage syncs: %w", err)
+	}
+	return nil
+}
+
+// ListPendingLineageSyncs returns deferred syncs, oldest first.
+// The outbox is drained for every tenant, so no tenant filter is applied.
+func (r *PostgresRepository) ListPendingLineageSyncs(ctx context.Context, limit int) ([]*entity.LineageSyncOutboxEntry, error) {
+	query := `
+		SELECT id, tenant_id, asset_id, COALESCE(reason, ''), attempts,
+			COALESCE(last_error, ''), requested_at, created_at
+		FROM lineage_sync_outbox
+		ORDER BY created_at, id
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lineage sync outbox: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*entity.LineageSyncOutboxEntry{}
+	for rows.Next() {
+		entry := &entity.LineageSyncOutboxEntry{}
+		if err := rows.Scan(&entry.ID, &entry.TenantID, &entry.AssetID, &entry.Reason, &entry.Attempts,
+			&entry.LastError, &entry.RequestedAt, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan lineage sync outbox entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// CompleteLineageSync removes a synced entry unless the asset was deferred
+// again after requestedAt, in which case it stays queued for the next pass
+func (r *PostgresRepository) CompleteLineageSync(ctx context.Context, id uuid.UUID, requestedAt time.Time) error {
+	query := `DELETE FROM lineage_sync_outbox WHERE id = $1 AND requested_at = $2`
+	if _, err := r.db.ExecContext(ctx, query, id, requestedAt); err != nil {
+		return fmt.Errorf("failed to complete lineage sync: %w", err)
+	}
+	return nil
+}
+
+// RecordLineageSyncFailure counts a failed replay of a deferred sync
+func (r *PostgresRepository) RecordLineageSyncFailure(ctx context.Context, id uuid.UUID, syncErr string) error {
+	query := `
+		UPDATE lineage_sync_outbox
+		SET attempts = attempts + 1, last_error = $2
+		WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, syncErr); err != nil {
+		return fmt.Errorf("failed to record lineage sync failure: %w", err)
+	}
+	return nil
+}
+
+// GetLineageOutboxStats returns the number of deferred syncs and when the oldest was queued
+func (r *PostgresRepository) GetLineageOutboxStats(ctx context.Context) (int, *time.Time, error) {
+	var pending int
+	var oldest sql.NullTime
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*), MIN(created_at) FROM lineage_sync_outbox`).Scan(&pending, &oldest)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read lineage sync outbox stats: %w", err)
+	}
+	if !oldest.Valid {
+		return pending, nil, nil
+	}
+	return pending, &oldest.Time, nil
+}
@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Masking Template Repository Implementation
+// ============================================================================
+
+// ListMaskingTemplates returns the tenant's masking templates by PII type
+func (r *PostgresRepository) ListMaskingTemplates(ctx context.Context) ([]*entity.MaskingTemplate, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT tenant_id, pii_type, style, keep_first, keep_last, format, mask_char, replacement, updated_by, updated_at
+		FROM masking_templates WHERE tenant_id = $1
+		ORDER BY pii_type`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list masking templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*entity.MaskingTemplate{}
+	for rows.Next() {
+		t := &entity.MaskingTemplate{}
+		if err := rows.Scan(&t.TenantID, &t.PIIType, &t.Style, &t.KeepFirst, &t.KeepLast, &t.Format,
+			&t.MaskChar, &t.Replacement, &t.UpdatedBy, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// SaveMaskingTemplate creates or replaces the tenant's template for its PII type
+func (r *PostgresRepository) SaveMaskingTemplate(ctx context.Context, t *entity.MaskingTemplate) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO masking_templates (tenant_id, pii_type, style, keep_first, keep_last, format, mask_char, replacement, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (tenant_id, pii_type) DO UPDATE SET
+			style = EXCLUDED.style, keep_first = EXCLUDED.keep_first, keep_last = EXCLUDED.keep_last,
+			format = EXCLUDED.format, mask_char = EXCLUDED.mask_char, replacement = EXCLUDED.replacement,
+			updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		RETURNING updated_at`,
+		tenantID, t.PIIType, t.Style, t.KeepFirst, t.KeepLast, t.Format, t.MaskChar, t.Replacement, t.UpdatedBy,
+	).Scan(&t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save masking template: %w", err)
+	}
+	t.TenantID = tenantID
+	return nil
+}
+
+// DeleteMaskingTemplate removes the tenant's template for a PII type, reporting
+// whether there was one
+func (r *PostgresRepository) DeleteMaskingTemplate(ctx context.Context, piiType string) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM masking_templates WHERE tenant_id = $1 AND pii_type = $2`, tenantID, piiType)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete masking template: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
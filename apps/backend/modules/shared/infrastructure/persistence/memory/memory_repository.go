@@ -0,0 +1,1510 @@
+// Package memory is an in-memory implementation of the repository interfaces the
+// ingestion, classification and remediation services, shadow classification,
+// jurisdiction profiles, sample text redaction, scan signing, the job queue and the
+// idempotency middleware depend on. It lets those
+// services be unit tested without a database.
+//
+// Data is not partitioned by tenant and nothing outlives the Repository. Lookups
+// that find nothing return the same errors (or nils) as PostgresRepository.
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+)
+
+// Ensure interface compatibility
+var (
+	_ repository.IngestionRepository            = (*Repository)(nil)
+	_ repository.ClassificationRepository       = (*Repository)(nil)
+	_ repository.RemediationRepository          = (*Repository)(nil)
+	_ repository.IdempotencyRepository          = (*Repository)(nil)
+	_ repository.ShadowClassificationRepository = (*Repository)(nil)
+	_ repository.JurisdictionRepository         = (*Repository)(nil)
+	_ repository.JobRepository                  = (*Repository)(nil)
+	_ repository.SampleTextPolicyRepository     = (*Repository)(nil)
+	_ repository.ScanSigningRepository          = (*Repository)(nil)
+	_ repository.MaskingTemplateRepository      = (*Repository)(nil)
+	_ repository.Transaction                    = (*Transaction)(nil)
+)
+
+// AuditLogEntry is an event recorded through RecordRemediationAuditLog
+type AuditLogEntry struct {
+	EventType    string
+	UserID       string
+	ResourceType string
+	ResourceID   string
+	Metadata     map[string]interface{}
+}
+
+// Repository keeps every record in maps guarded by one mutex. It is safe for
+// concurrent use, as ingestion requires.
+type Repository struct {
+	// Now is the repository's clock; tests may replace it to move time forward
+	Now func() time.Time
+
+	mu sync.Mutex
+
+	scanRuns        map[uuid.UUID]*entity.ScanRun
+	findings        []*entity.Finding
+	classifications map[uuid.UUID]*entity.Classification // By finding ID
+	reviewStates    map[uuid.UUID]*entity.ReviewState    // By finding ID
+	observations    []*entity.FindingObservation
+	patterns        map[string]*entity.Pattern
+	suppression     []*entity.SuppressionRule
+	assets          map[uuid.UUID]*entity.Asset
+	scannedAt       map[uuid.UUID]time.Time // Last scan per asset ID
+	sourceConfigs   map[string]map[string]interface{}
+	connections     []*entity.Connection
+	actions         []*entity.RemediationAction
+	requests        []*entity.RemediationApprovalRequest
+	simulations     []*entity.RemediationSimulation
+	auditLogs       []AuditLogEntry
+	idempotencyKeys map[string]*entity.IdempotencyRecord // By endpoint and key
+	shadowResults   []*entity.ShadowClassification
+	jurisdictions   map[string]*entity.JurisdictionProfile // By code
+	jurisdiction    string                                 // The tenant's selected profile code
+	jobs            []*entity.Job
+	sampleText      *entity.TenantSampleTextPolicy // The tenant's sample text storage choice
+	signingKeys     []*entity.ScannerSigningKey
+	scanSigning     *entity.TenantScanSigningPolicy    // The tenant's strict mode choice
+	maskTemplates   map[string]*entity.MaskingTemplate // By PII type
+	dropped         []*entity.DroppedFindingCount
+}
+
+// NewRepository creates an empty in-memory repository
+func NewRepository() *Repository {
+	return &Repository{
+		Now:             time.Now,
+		scanRuns:        make(map[uuid.UUID]*entity.ScanRun),
+		classifications: make(map[uuid.UUID]*entity.Classification),
+		reviewStates:    make(map[uuid.UUID]*entity.ReviewState),
+		patterns:        make(map[string]*entity.Pattern),
+		assets:          make(map[uuid.UUID]*entity.Asset),
+		scannedAt:       make(map[uuid.UUID]time.Time),
+		sourceConfigs:   make(map[string]map[string]interface{}),
+		idempotencyKeys: make(map[string]*entity.IdempotencyRecord),
+		jurisdictions:   make(map[string]*entity.JurisdictionProfile),
+		maskTemplates:   make(map[string]*entity.MaskingTemplate),
+	}
+}
+
+// ============================================================================
+// Seeding and inspection
+// ============================================================================
+
+// PutAsset stores or replaces an asset
+func (r *Repository) PutAsset(asset *entity.Asset) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *asset
+	r.assets[asset.ID] = &stored
+}
+
+// Asset returns a copy of a stored asset, or nil
+func (r *Repository) Asset(id uuid.UUID) *entity.Asset {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	asset, ok := r.assets[id]
+	if !ok {
+		return nil
+	}
+	stored := *asset
+	return &stored
+}
+
+// PutFinding stores a finding outside of a transaction
+func (r *Repository) PutFinding(finding *entity.Finding) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *finding
+	r.findings = append(r.findings, &stored)
+}
+
+// Findings returns copies of every stored finding in insertion order
+func (r *Repository) Findings() []*entity.Finding {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	findings := make([]*entity.Finding, len(r.findings))
+	for i, f := range r.findings {
+		stored := *f
+		findings[i] = &stored
+	}
+	return findings
+}
+
+// Classification returns the classification stored for a finding, or nil
+func (r *Repository) Classification(findingID uuid.UUID) *entity.Classification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.classifications[findingID]
+	if !ok {
+		return nil
+	}
+	stored := *c
+	return &stored
+}
+
+// ReviewState returns the review state stored for a finding, or nil
+func (r *Repository) ReviewState(findingID uuid.UUID) *entity.ReviewState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rs, ok := r.reviewStates[findingID]
+	if !ok {
+		return nil
+	}
+	stored := *rs
+	return &stored
+}
+
+// Observations returns every recorded finding observation in insertion order
+func (r *Repository) Observations() []*entity.FindingObservation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*entity.FindingObservation(nil), r.observations...)
+}
+
+// PutSuppressionRule stores a suppression rule
+func (r *Repository) PutSuppressionRule(rule *entity.SuppressionRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *rule
+	r.suppression = append(r.suppression, &stored)
+}
+
+// SuppressionRule returns a copy of a stored suppression rule, or nil
+func (r *Repository) SuppressionRule(id uuid.UUID) *entity.SuppressionRule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rule := range r.suppression {
+		if rule.ID == id {
+			stored := *rule
+			return &stored
+		}
+	}
+	return nil
+}
+
+// PutSourceConfig stores the connection configuration of a source profile
+func (r *Repository) PutSourceConfig(name string, config map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sourceConfigs[name] = config
+}
+
+// PutConnection stores a connection that scan runs may be linked to
+func (r *Repository) PutConnection(conn *entity.Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *conn
+	r.connections = append(r.connections, &stored)
+}
+
+// AuditLogs returns every recorded remediation audit event in order
+func (r *Repository) AuditLogs() []AuditLogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]AuditLogEntry(nil), r.auditLogs...)
+}
+
+// ShadowResults returns copies of every recorded shadow classification in order
+func (r *Repository) ShadowResults() []*entity.ShadowClassification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := make([]*entity.ShadowClassification, len(r.shadowResults))
+	for i, result := range r.shadowResults {
+		stored := *result
+		results[i] = &stored
+	}
+	return results
+}
+
+// PutJurisdictionProfile stores or replaces a jurisdiction profile
+func (r *Repository) PutJurisdictionProfile(profile *entity.JurisdictionProfile) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jurisdictions[profile.Code] = copyJurisdictionProfile(profile)
+}
+
+// ============================================================================
+// Ingestion
+// ============================================================================
+
+// BeginTransaction starts a transaction whose writes are applied on Commit
+func (r *Repository) BeginTransaction(ctx context.Context) (repository.Transaction, error) {
+	return &Transaction{repo: r}, nil
+}
+
+// GetScanRunByID retrieves a scan run
+func (r *Repository) GetScanRunByID(ctx context.Context, id uuid.UUID) (*entity.ScanRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.scanRuns[id]
+	if !ok {
+		return nil, fmt.Errorf("scan run not found")
+	}
+	return copyScanRun(run), nil
+}
+
+// GetLatestScanRun returns the scan run that started last, or nil
+func (r *Repository) GetLatestScanRun(ctx context.Context) (*entity.ScanRun, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *entity.ScanRun
+	for _, run := range r.scanRuns {
+		if latest == nil || run.ScanStartedAt.After(latest.ScanStartedAt) {
+			latest = run
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return copyScanRun(latest), nil
+}
+
+// SetScanRunPerformance replaces a scan run's performance profile, even once it is completed
+func (r *Repository) SetScanRunPerformance(ctx context.Context, scanRunID uuid.UUID, perf *entity.ScanPerformance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.scanRuns[scanRunID]
+	if !ok {
+		return fmt.Errorf("scan run not found")
+	}
+	updated := copyScanRun(run)
+	if updated.Metadata == nil {
+		updated.Metadata = make(map[string]interface{})
+	}
+	stored := *perf
+	updated.Metadata["performance"] = &stored
+	r.scanRuns[scanRunID] = updated
+	return nil
+}
+
+// FindConnectionIDByProfile returns the ID of the only connection with the profile name, or nil
+func (r *Repository) FindConnectionIDByProfile(ctx context.Context, profileName string) (*uuid.UUID, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found *uuid.UUID
+	for _, conn := range r.connections {
+		if conn.ProfileName != profileName {
+			continue
+		}
+		if found != nil {
+			return nil, nil
+		}
+		id := conn.ID
+		found = &id
+	}
+	return found, nil
+}
+
+// GetPatternByName retrieves a pattern, or nil when it does not exist
+func (r *Repository) GetPatternByName(ctx context.Context, name string) (*entity.Pattern, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pattern, ok := r.patterns[name]
+	if !ok {
+		return nil, nil
+	}
+	stored := *pattern
+	return &stored, nil
+}
+
+// CreatePattern stores a new pattern; names are unique
+func (r *Repository) CreatePattern(ctx context.Context, pattern *entity.Pattern) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.patterns[pattern.Name]; exists {
+		return fmt.Errorf("pattern %q already exists", pattern.Name)
+	}
+	pattern.CreatedAt = r.Now()
+	pattern.UpdatedAt = pattern.CreatedAt
+	stored := *pattern
+	r.patterns[pattern.Name] = &stored
+	return nil
+}
+
+// ListActiveSuppressionRules returns the rules that are enabled and not expired
+func (r *Repository) ListActiveSuppressionRules(ctx context.Context) ([]*entity.SuppressionRule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	var rules []*entity.SuppressionRule
+	for _, rule := range r.suppression {
+		if rule.IsActive && (rule.ExpiresAt == nil || rule.ExpiresAt.After(now)) {
+			stored := *rule
+			rules = append(rules, &stored)
+		}
+	}
+	return rules, nil
+}
+
+// RecordSuppressionHits adds the number of findings each rule suppressed to its running total
+func (r *Repository) RecordSuppressionHits(ctx context.Context, hits map[uuid.UUID]int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	for _, rule := range r.suppression {
+		if count, ok := hits[rule.ID]; ok {
+			rule.HitCount += int64(count)
+			rule.LastMatchedAt = &now
+		}
+	}
+	return nil
+}
+
+// RecordDroppedFindings adds the ingestion filter's counts for a scan run to the ledger
+func (r *Repository) RecordDroppedFindings(ctx context.Context, scanRunID uuid.UUID, counts []entity.DroppedFindingCount) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	for _, c := range counts {
+		var stored *entity.DroppedFindingCount
+		for _, d := range r.dropped {
+			if d.ScanRunID == scanRunID && d.Reason == c.Reason && d.PatternName == c.PatternName && d.Enforced == c.Enforced {
+				stored = d
+			}
+		}
+		if stored == nil {
+			stored = &entity.DroppedFindingCount{ScanRunID: scanRunID, Reason: c.Reason, PatternName: c.PatternName, Enforced: c.Enforced}
+			r.dropped = append(r.dropped, stored)
+		}
+		stored.Findings += c.Findings
+		stored.MaxConfidence = max(stored.MaxConfidence, c.MaxConfidence)
+		stored.RecordedAt = now
+	}
+	return nil
+}
+
+// DroppedFindings returns copies of a scan run's ledger entries in recording order
+func (r *Repository) DroppedFindings(scanRunID uuid.UUID) []entity.DroppedFindingCount {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var counts []entity.DroppedFindingCount
+	for _, d := range r.dropped {
+		if d.ScanRunID == scanRunID {
+			counts = append(counts, *d)
+		}
+	}
+	return counts
+}
+
+// CountFindings counts findings matching filters, leaving out Non-PII ones.
+// DataSource is ignored, as it is by PostgresRepository.
+func (r *Repository) CountFindings(ctx context.Context, filters repository.FindingFilters) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var severities []string
+	if filters.Severity != "" {
+		severities = strings.Split(filters.Severity, ",")
+	}
+
+	count := 0
+	for _, f := range r.findings {
+		if c, ok := r.classifications[f.ID]; ok && c.ClassificationType == "Non-PII" {
+			continue
+		}
+		if filters.ScanRunID != nil && f.ScanRunID != *filters.ScanRunID {
+			continue
+		}
+		if filters.AssetID != nil && f.AssetID != *filters.AssetID {
+			continue
+		}
+		if severities != nil && !containsString(severities, f.Severity) {
+			continue
+		}
+		if filters.PatternName != "" && !strings.Contains(strings.ToLower(f.PatternName), strings.ToLower(filters.PatternName)) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// UpdateAssetStats sets an asset's risk score and finding count. Unknown assets
+// are ignored.
+func (r *Repository) UpdateAssetStats(ctx context.Context, id uuid.UUID, score int, totalFindings int, cause string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if asset, ok := r.assets[id]; ok {
+		asset.RiskScore = score
+		asset.TotalFindings = totalFindings
+	}
+	return nil
+}
+
+// MarkAssetScanned records when an asset was last scanned
+func (r *Repository) MarkAssetScanned(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if at.After(r.scannedAt[id]) {
+		r.scannedAt[id] = at
+	}
+	return nil
+}
+
+// AssetScannedAt returns when an asset was last marked scanned
+func (r *Repository) AssetScannedAt(id uuid.UUID) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	at, ok := r.scannedAt[id]
+	return at, ok
+}
+
+// CountAssetsSharingValues counts the other assets with an observation of a value
+// observed in the given asset
+func (r *Repository) CountAssetsSharingValues(ctx context.Context, assetID uuid.UUID) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	own := make(map[string]bool)
+	for _, obs := range r.observations {
+		if obs.AssetID == assetID {
+			own[obs.ValueHash] = true
+		}
+	}
+	others := make(map[uuid.UUID]bool)
+	for _, obs := range r.observations {
+		if obs.AssetID != assetID && own[obs.ValueHash] {
+			others[obs.AssetID] = true
+		}
+	}
+	return len(others), nil
+}
+
+// ============================================================================
+// Transaction
+// ============================================================================
+
+// Transaction queues writes and applies them to the repository on Commit
+type Transaction struct {
+	repo *Repository
+	ops  []func()
+	done bool
+}
+
+// CreateScanRun queues a new scan run
+func (t *Transaction) CreateScanRun(ctx context.Context, scanRun *entity.ScanRun) error {
+	scanRun.CreatedAt = t.repo.Now()
+	scanRun.UpdatedAt = scanRun.CreatedAt
+	stored := copyScanRun(scanRun)
+	return t.queue(func() { t.repo.scanRuns[stored.ID] = stored })
+}
+
+// UpdateScanRun queues an update of a scan run's status, totals, metadata and signer
+func (t *Transaction) UpdateScanRun(ctx context.Context, scanRun *entity.ScanRun) error {
+	update := copyScanRun(scanRun)
+	return t.queue(func() {
+		if run, ok := t.repo.scanRuns[update.ID]; ok {
+			run.TotalFindings = update.TotalFindings
+			run.TotalAssets = update.TotalAssets
+			run.Metadata = update.Metadata
+			run.Status = update.Status
+			if update.SignatureStatus != "" {
+				run.SignatureStatus = update.SignatureStatus
+				run.SignerKeyID = update.SignerKeyID
+				run.SignerName = update.SignerName
+			}
+			run.UpdatedAt = t.repo.Now()
+		}
+	})
+}
+
+// CreateFinding queues a new finding
+func (t *Transaction) CreateFinding(ctx context.Context, finding *entity.Finding) error {
+	stored := *finding
+	return t.queue(func() { t.repo.findings = append(t.repo.findings, &stored) })
+}
+
+// CreateClassification queues a finding's classification
+func (t *Transaction) CreateClassification(ctx context.Context, classification *entity.Classification) error {
+	stored := *classification
+	return t.queue(func() { t.repo.classifications[stored.FindingID] = &stored })
+}
+
+// CreateReviewState queues a finding's review state
+func (t *Transaction) CreateReviewState(ctx context.Context, reviewState *entity.ReviewState) error {
+	stored := *reviewState
+	stored.Version = 1
+	return t.queue(func() { t.repo.reviewStates[stored.FindingID] = &stored })
+}
+
+// RecordFindingObservation queues an observation of a finding by a scan run
+func (t *Transaction) RecordFindingObservation(ctx context.Context, obs *entity.FindingObservation) error {
+	stored := *obs
+	return t.queue(func() { t.repo.observations = append(t.repo.observations, &stored) })
+}
+
+// Commit applies the queued writes atomically
+func (t *Transaction) Commit() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+
+	t.repo.mu.Lock()
+	defer t.repo.mu.Unlock()
+	for _, op := range t.ops {
+		op()
+	}
+	return nil
+}
+
+// Rollback discards the queued writes
+func (t *Transaction) Rollback() error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.done = true
+	t.ops = nil
+	return nil
+}
+
+func (t *Transaction) queue(op func()) error {
+	if t.done {
+		return sql.ErrTxDone
+	}
+	t.ops = append(t.ops, op)
+	return nil
+}
+
+// ============================================================================
+// Remediation
+// ============================================================================
+
+// GetRemediationFinding retrieves a finding with its asset's details
+func (r *Repository) GetRemediationFinding(ctx context.Context, findingID string) (*entity.RemediationFinding, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := r.findByID(findingID)
+	if f == nil {
+		return nil, sql.ErrNoRows
+	}
+	asset, ok := r.assets[f.AssetID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+
+	return &entity.RemediationFinding{
+		ID:           f.ID.String(),
+		AssetID:      asset.ID.String(),
+		AssetName:    asset.Name,
+		Location:     asset.Path,
+		AssetPath:    asset.Path,
+		SourceSystem: asset.SourceSystem,
+		SourceType:   asset.DataSource,
+		PIIType:      f.PatternName,
+		SampleText:   f.SampleText,
+		Matches:      append([]string(nil), f.Matches...),
+		Locations:    append([]entity.MatchLocation(nil), f.Locations...),
+	}, nil
+}
+
+// GetFindingPreviewSample returns a finding's sample text and PII type
+func (r *Repository) GetFindingPreviewSample(ctx context.Context, findingID string) (string, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := r.findByID(findingID)
+	if f == nil {
+		return "", "", sql.ErrNoRows
+	}
+	return f.SampleText, f.PatternName, nil
+}
+
+// GetRemediationSourceConfig returns the configuration stored with PutSourceConfig
+func (r *Repository) GetRemediationSourceConfig(ctx context.Context, sourceName string) (map[string]interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	config, ok := r.sourceConfigs[sourceName]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return config, nil
+}
+
+// CreateRemediationAction stores a PENDING action executed now
+func (r *Repository) CreateRemediationAction(ctx context.Context, action *entity.RemediationAction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	action.Status = "PENDING"
+	action.ExecutedAt = r.Now()
+	stored := *action
+	r.actions = append(r.actions, &stored)
+	return nil
+}
+
+// UpdateRemediationActionStatus sets the status of a remediation action
+func (r *Repository) UpdateRemediationActionStatus(ctx context.Context, id, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if action := r.actionByID(id); action != nil {
+		action.Status = status
+	}
+	return nil
+}
+
+// UpdateRemediationActionMetadata replaces the rollback metadata of a remediation action
+func (r *Repository) UpdateRemediationActionMetadata(ctx context.Context, id string, metadata []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if action := r.actionByID(id); action != nil {
+		action.Metadata = append(json.RawMessage(nil), metadata...)
+	}
+	return nil
+}
+
+// EndRemediationAction is a no-op; the in-memory store keeps no effective period
+func (r *Repository) EndRemediationAction(ctx context.Context, id string) error {
+	return nil
+}
+
+// GetRemediationAction retrieves a remediation action with its metadata
+func (r *Repository) GetRemediationAction(ctx context.Context, id string) (*entity.RemediationAction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	action := r.actionByID(id)
+	if action == nil {
+		return nil, sql.ErrNoRows
+	}
+	stored := *action
+	return &stored, nil
+}
+
+// ListRemediationActionsByFinding lists the actions taken on a finding, newest first
+func (r *Repository) ListRemediationActionsByFinding(ctx context.Context, findingID string) ([]*entity.RemediationAction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.listActions(func(a *entity.RemediationAction) bool { return a.FindingID == findingID }), nil
+}
+
+// ListRemediationActionsByAsset lists the actions taken on an asset's findings, newest first
+func (r *Repository) ListRemediationActionsByAsset(ctx context.Context, assetID string) ([]*entity.RemediationAction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.listActions(func(a *entity.RemediationAction) bool {
+		f := r.findByID(a.FindingID)
+		return f != nil && f.AssetID.String() == assetID
+	}), nil
+}
+
+// ListRemediationActions pages through all actions, optionally of one action type
+func (r *Repository) ListRemediationActions(ctx context.Context, actionType string, limit, offset int) ([]*entity.RemediationAction, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	actions := r.listActions(func(a *entity.RemediationAction) bool {
+		return actionType == "" || actionType == "ALL" || a.ActionType == actionType
+	})
+	return page(actions, limit, offset), len(actions), nil
+}
+
+// RecordRemediationAuditLog records a remediation audit event
+func (r *Repository) RecordRemediationAuditLog(ctx context.Context, eventType, userID, resourceType, resourceID string, metadata map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.auditLogs = append(r.auditLogs, AuditLogEntry{
+		EventType:    eventType,
+		UserID:       userID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Metadata:     metadata,
+	})
+	return nil
+}
+
+// CreateRemediationRequest stores a pending remediation request
+func (r *Repository) CreateRemediationRequest(ctx context.Context, req *entity.RemediationApprovalRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req.ID = uuid.New().String()
+	req.Status = entity.RemediationRequestPendingApproval
+	req.RequestedAt = r.Now()
+	req.ActionIDs = []string{}
+	req.Errors = []string{}
+	r.requests = append(r.requests, copyRequest(req))
+	return nil
+}
+
+// GetRemediationRequest retrieves a remediation request, or nil
+func (r *Repository) GetRemediationRequest(ctx context.Context, id string) (*entity.RemediationApprovalRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req := r.requestByID(id)
+	if req == nil {
+		return nil, nil
+	}
+	return copyRequest(req), nil
+}
+
+// ListRemediationRequests returns remediation requests, newest first, optionally filtered by status
+func (r *Repository) ListRemediationRequests(ctx context.Context, status string, limit, offset int) ([]*entity.RemediationApprovalRequest, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	requests := make([]*entity.RemediationApprovalRequest, 0)
+	for i := len(r.requests) - 1; i >= 0; i-- {
+		if status == "" || r.requests[i].Status == status {
+			requests = append(requests, copyRequest(r.requests[i]))
+		}
+	}
+	return page(requests, limit, offset), len(requests), nil
+}
+
+// DecideRemediationRequest moves a pending, unexpired request to status, or returns nil
+func (r *Repository) DecideRemediationRequest(ctx context.Context, id, status, decidedBy, comment string) (*entity.RemediationApprovalRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req := r.requestByID(id)
+	now := r.Now()
+	if req == nil || req.Status != entity.RemediationRequestPendingApproval || !req.ExpiresAt.After(now) {
+		return nil, nil
+	}
+	req.Status = status
+	req.DecidedBy = decidedBy
+	req.DecidedAt = &now
+	req.DecisionComment = comment
+	return copyRequest(req), nil
+}
+
+// CompleteRemediationRequest records the outcome of executing an approved request
+func (r *Repository) CompleteRemediationRequest(ctx context.Context, id, status string, actionIDs, failures []string) (*entity.RemediationApprovalRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req := r.requestByID(id)
+	if req == nil {
+		return nil, sql.ErrNoRows
+	}
+	now := r.Now()
+	req.Status = status
+	req.ActionIDs = append([]string{}, actionIDs...)
+	req.Errors = append([]string{}, failures...)
+	req.ExecutedAt = &now
+	return copyRequest(req), nil
+}
+
+// ExpireRemediationRequests lapses pending requests past their expiry and returns them
+func (r *Repository) ExpireRemediationRequests(ctx context.Context) ([]*entity.RemediationApprovalRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	var expired []*entity.RemediationApprovalRequest
+	for _, req := range r.requests {
+		if req.Status == entity.RemediationRequestPendingApproval && !req.ExpiresAt.After(now) {
+			req.Status = entity.RemediationRequestExpired
+			expired = append(expired, copyRequest(req))
+		}
+	}
+	return expired, nil
+}
+
+// CreateRemediationSimulation stores a remediation dry run
+func (r *Repository) CreateRemediationSimulation(ctx context.Context, sim *entity.RemediationSimulation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sim.ID = uuid.New().String()
+	sim.SimulatedAt = r.Now()
+	if len(sim.Changes) == 0 {
+		sim.Changes = json.RawMessage("[]")
+	}
+	r.simulations = append(r.simulations, copySimulation(sim))
+	return nil
+}
+
+// GetRemediationSimulation retrieves a remediation dry run, or nil
+func (r *Repository) GetRemediationSimulation(ctx context.Context, id string) (*entity.RemediationSimulation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sim := range r.simulations {
+		if sim.ID == id {
+			return copySimulation(sim), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListRemediationSimulationsByFinding returns a finding's dry runs, newest first
+func (r *Repository) ListRemediationSimulationsByFinding(ctx context.Context, findingID string) ([]*entity.RemediationSimulation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sims := make([]*entity.RemediationSimulation, 0)
+	for i := len(r.simulations) - 1; i >= 0; i-- {
+		if r.simulations[i].FindingID == findingID {
+			sims = append(sims, copySimulation(r.simulations[i]))
+		}
+	}
+	return sims, nil
+}
+
+// ============================================================================
+// Idempotency keys
+// ============================================================================
+
+// ReserveIdempotencyKey claims a key, replacing an expired record, or returns the live record
+func (r *Repository) ReserveIdempotencyKey(ctx context.Context, endpoint, key string, expiresAt time.Time) (*entity.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	if record, ok := r.idempotencyKeys[idempotencyKey(endpoint, key)]; ok && record.ExpiresAt.After(now) {
+		return copyIdempotencyRecord(record), nil
+	}
+	r.idempotencyKeys[idempotencyKey(endpoint, key)] = &entity.IdempotencyRecord{
+		Endpoint:  endpoint,
+		Key:       key,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+	return nil, nil
+}
+
+// CompleteIdempotencyKey stores the response produced for a reserved key
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, record *entity.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.idempotencyKeys[idempotencyKey(record.Endpoint, record.Key)]
+	if !ok {
+		return fmt.Errorf("idempotency key not found")
+	}
+	stored.RequestHash = record.RequestHash
+	stored.StatusCode = record.StatusCode
+	stored.ContentType = record.ContentType
+	stored.Body = append([]byte(nil), record.Body...)
+	return nil
+}
+
+// ReleaseIdempotencyKey deletes a key
+func (r *Repository) ReleaseIdempotencyKey(ctx context.Context, endpoint, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.idempotencyKeys, idempotencyKey(endpoint, key))
+	return nil
+}
+
+// PurgeExpiredIdempotencyKeys deletes expired keys
+func (r *Repository) PurgeExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	var purged int64
+	for k, record := range r.idempotencyKeys {
+		if !record.ExpiresAt.After(now) {
+			delete(r.idempotencyKeys, k)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// ============================================================================
+// Shadow classification
+// ============================================================================
+
+// RecordShadowClassification stores a candidate engine's outcome next to the primary one
+func (r *Repository) RecordShadowClassification(ctx context.Context, result *entity.ShadowClassification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if result.ID == uuid.Nil {
+		result.ID = uuid.New()
+	}
+	result.CreatedAt = r.Now()
+	stored := *result
+	stored.Divergences = append([]string{}, result.Divergences...)
+	r.shadowResults = append(r.shadowResults, &stored)
+	return nil
+}
+
+// GetShadowDivergenceCounts tallies a candidate version's results since the given
+// time by PII type, most compared first
+func (r *Repository) GetShadowDivergenceCounts(ctx context.Context, shadowVersion string, since time.Time) ([]entity.ShadowDivergenceCounts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	byPattern := make(map[string]*entity.ShadowDivergenceCounts)
+	for _, result := range r.shadowResults {
+		if result.Shadow.Version != shadowVersion || result.CreatedAt.Before(since) {
+			continue
+		}
+		c, ok := byPattern[result.PatternName]
+		if !ok {
+			c = &entity.ShadowDivergenceCounts{PatternName: result.PatternName}
+			byPattern[result.PatternName] = c
+		}
+		c.Total++
+		if len(result.Divergences) > 0 {
+			c.Diverged++
+		}
+		if containsString(result.Divergences, entity.DivergenceClassification) {
+			c.Classification++
+		}
+		if containsString(result.Divergences, entity.DivergenceSubCategory) {
+			c.SubCategory++
+		}
+		if containsString(result.Divergences, entity.DivergenceConfidenceLevel) {
+			c.ConfidenceLevel++
+		}
+		if result.Shadow.Kept && !result.Primary.Kept {
+			c.NewlyKept++
+		}
+		if result.Primary.Kept && !result.Shadow.Kept {
+			c.NewlyDiscarded++
+		}
+	}
+
+	counts := make([]entity.ShadowDivergenceCounts, 0, len(byPattern))
+	for _, c := range byPattern {
+		counts = append(counts, *c)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Total != counts[j].Total {
+			return counts[i].Total > counts[j].Total
+		}
+		return counts[i].PatternName < counts[j].PatternName
+	})
+	return counts, nil
+}
+
+// ListShadowVersions returns the candidate versions with stored results, newest first
+func (r *Repository) ListShadowVersions(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	versions := []string{}
+	for i := len(r.shadowResults) - 1; i >= 0; i-- {
+		if v := r.shadowResults[i].Shadow.Version; !containsString(versions, v) {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// ============================================================================
+// Jurisdiction profiles
+// ============================================================================
+
+// ListJurisdictionProfiles returns copies of every profile ordered by code
+func (r *Repository) ListJurisdictionProfiles(ctx context.Context) ([]*entity.JurisdictionProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profiles := make([]*entity.JurisdictionProfile, 0, len(r.jurisdictions))
+	for _, profile := range r.jurisdictions {
+		profiles = append(profiles, copyJurisdictionProfile(profile))
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Code < profiles[j].Code })
+	return profiles, nil
+}
+
+// GetJurisdictionProfile returns a copy of a profile, or nil
+func (r *Repository) GetJurisdictionProfile(ctx context.Context, code string) (*entity.JurisdictionProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, ok := r.jurisdictions[code]
+	if !ok {
+		return nil, nil
+	}
+	return copyJurisdictionProfile(profile), nil
+}
+
+// GetTenantJurisdiction returns the selected profile code, or ""
+func (r *Repository) GetTenantJurisdiction(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.jurisdiction, nil
+}
+
+// SetTenantJurisdiction selects a profile
+func (r *Repository) SetTenantJurisdiction(ctx context.Context, code, updatedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jurisdiction = code
+	return nil
+}
+
+// ============================================================================
+// Jobs
+// ============================================================================
+
+// CreateJob stores a pending job
+func (r *Repository) CreateJob(ctx context.Context, job *entity.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	if job.Payload == nil {
+		job.Payload = map[string]interface{}{}
+	}
+	job.Status = entity.JobStatusPending
+	job.CreatedAt = r.Now()
+	job.UpdatedAt = job.CreatedAt
+	if job.ScheduledAt.IsZero() {
+		job.ScheduledAt = job.CreatedAt
+	}
+	stored := *job
+	r.jobs = append(r.jobs, &stored)
+	return nil
+}
+
+// ClaimDueJobs marks up to limit due pending jobs of the given types running
+func (r *Repository) ClaimDueJobs(ctx context.Context, jobTypes []string, workerID string, limit int) ([]*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.Now()
+	due := []*entity.Job{}
+	for _, job := range r.jobs {
+		if job.Status == entity.JobStatusPending && !job.ScheduledAt.After(now) && containsString(jobTypes, job.Type) {
+			due = append(due, job)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].ScheduledAt.Before(due[j].ScheduledAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*entity.Job, len(due))
+	for i, job := range due {
+		job.Status = entity.JobStatusRunning
+		job.Attempts++
+		job.LockedBy = workerID
+		job.LockedAt = &now
+		job.UpdatedAt = now
+		stored := *job
+		claimed[i] = &stored
+	}
+	return claimed, nil
+}
+
+// CompleteJob marks a running job succeeded
+func (r *Repository) CompleteJob(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if job := r.jobByID(id); job != nil && job.Status == entity.JobStatusRunning {
+		now := r.Now()
+		job.Status = entity.JobStatusSucceeded
+		job.LastError, job.LockedBy, job.LockedAt = "", "", nil
+		job.CompletedAt = &now
+		job.UpdatedAt = now
+	}
+	return nil
+}
+
+// FailJob records a failed attempt of a running job
+func (r *Repository) FailJob(ctx context.Context, id uuid.UUID, jobErr string, retryAt *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobByID(id)
+	if job == nil || job.Status != entity.JobStatusRunning {
+		return nil
+	}
+	now := r.Now()
+	job.LastError, job.LockedBy, job.LockedAt = jobErr, "", nil
+	job.UpdatedAt = now
+	if retryAt != nil {
+		job.Status = entity.JobStatusPending
+		job.ScheduledAt = *retryAt
+	} else {
+		job.Status = entity.JobStatusFailed
+		job.CompletedAt = &now
+	}
+	return nil
+}
+
+// RequeueStaleJobs returns running jobs locked before lockedBefore to pending
+func (r *Repository) RequeueStaleJobs(ctx context.Context, lockedBefore time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var requeued int64
+	for _, job := range r.jobs {
+		if job.Status == entity.JobStatusRunning && job.LockedAt != nil && job.LockedAt.Before(lockedBefore) {
+			job.Status = entity.JobStatusPending
+			job.LastError = "worker lost during attempt"
+			job.LockedBy, job.LockedAt = "", nil
+			requeued++
+		}
+	}
+	return requeued, nil
+}
+
+// ListJobs returns copies of the jobs matching the filter, newest first
+func (r *Repository) ListJobs(ctx context.Context, filter entity.JobFilter) ([]*entity.Job, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := []*entity.Job{}
+	for i := len(r.jobs) - 1; i >= 0; i-- {
+		job := r.jobs[i]
+		if (filter.Status == "" || job.Status == filter.Status) && (filter.Type == "" || job.Type == filter.Type) {
+			stored := *job
+			matched = append(matched, &stored)
+		}
+	}
+	total := len(matched)
+	if filter.Offset >= len(matched) {
+		return []*entity.Job{}, total, nil
+	}
+	matched = matched[filter.Offset:]
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total, nil
+}
+
+// GetJob returns a copy of a job, or nil
+func (r *Repository) GetJob(ctx context.Context, id uuid.UUID) (*entity.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobByID(id)
+	if job == nil {
+		return nil, nil
+	}
+	stored := *job
+	return &stored, nil
+}
+
+// RetryJob makes a failed or cancelled job due now with a fresh attempt budget
+func (r *Repository) RetryJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobByID(id)
+	if job == nil || (job.Status != entity.JobStatusFailed && job.Status != entity.JobStatusCancelled) {
+		return false, nil
+	}
+	job.Status = entity.JobStatusPending
+	job.Attempts = 0
+	job.ScheduledAt = r.Now()
+	job.CompletedAt = nil
+	return true, nil
+}
+
+// CancelJob cancels a pending job
+func (r *Repository) CancelJob(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job := r.jobByID(id)
+	if job == nil || job.Status != entity.JobStatusPending {
+		return false, nil
+	}
+	now := r.Now()
+	job.Status = entity.JobStatusCancelled
+	job.CompletedAt = &now
+	return true, nil
+}
+
+// ============================================================================
+// Sample text policy
+// ============================================================================
+
+// GetTenantSampleTextPolicy returns a copy of the tenant's choice, or nil
+func (r *Repository) GetTenantSampleTextPolicy(ctx context.Context) (*entity.TenantSampleTextPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sampleText == nil {
+		return nil, nil
+	}
+	stored := *r.sampleText
+	return &stored, nil
+}
+
+// SetTenantSampleTextPolicy records whether the tenant stores full sample text
+func (r *Repository) SetTenantSampleTextPolicy(ctx context.Context, storeFull bool, updatedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sampleText = &entity.TenantSampleTextPolicy{StoreFull: storeFull, UpdatedBy: updatedBy, UpdatedAt: r.Now()}
+	return nil
+}
+
+// ListUnredactedSamples returns up to limit unredacted samples unless the tenant stores full samples
+func (r *Repository) ListUnredactedSamples(ctx context.Context, tenantID *uuid.UUID, limit int) ([]entity.StoredSample, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	samples := []entity.StoredSample{}
+	if r.sampleText != nil && r.sampleText.StoreFull {
+		return samples, nil
+	}
+	for _, f := range r.findings {
+		if len(samples) == limit {
+			break
+		}
+		if f.SampleTextRedacted || f.SampleText == "" || (tenantID != nil && f.TenantID != *tenantID) {
+			continue
+		}
+		samples = append(samples, entity.StoredSample{
+			FindingID:  f.ID,
+			TenantID:   f.TenantID,
+			SampleText: f.SampleText,
+			SHA256:     f.SampleTextSHA256,
+			Matches:    append([]string(nil), f.Matches...),
+			PIIType:    f.PatternName,
+		})
+	}
+	return samples, nil
+}
+
+// SaveRedactedSamples replaces stored samples and marks them redacted
+func (r *Repository) SaveRedactedSamples(ctx context.Context, samples []entity.StoredSample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, sample := range samples {
+		if f := r.findByID(sample.FindingID.String()); f != nil {
+			f.SampleText = sample.SampleText
+			if f.SampleTextSHA256 == "" {
+				f.SampleTextSHA256 = sample.SHA256
+			}
+			f.SampleTextRedacted = true
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Masking templates
+// ============================================================================
+
+// ListMaskingTemplates returns copies of the tenant's masking templates by PII type
+func (r *Repository) ListMaskingTemplates(ctx context.Context) ([]*entity.MaskingTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	templates := make([]*entity.MaskingTemplate, 0, len(r.maskTemplates))
+	for _, t := range r.maskTemplates {
+		stored := *t
+		templates = append(templates, &stored)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].PIIType < templates[j].PIIType })
+	return templates, nil
+}
+
+// SaveMaskingTemplate creates or replaces the template of its PII type
+func (r *Repository) SaveMaskingTemplate(ctx context.Context, template *entity.MaskingTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	template.UpdatedAt = r.Now()
+	stored := *template
+	r.maskTemplates[template.PIIType] = &stored
+	return nil
+}
+
+// DeleteMaskingTemplate reports whether the PII type had a template
+func (r *Repository) DeleteMaskingTemplate(ctx context.Context, piiType string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.maskTemplates[piiType]
+	delete(r.maskTemplates, piiType)
+	return ok, nil
+}
+
+// ============================================================================
+// Scan signing
+// ============================================================================
+
+// CreateScannerSigningKey stores a key unless its key ID is taken
+func (r *Repository) CreateScannerSigningKey(ctx context.Context, key *entity.ScannerSigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.signingKeys {
+		if existing.KeyID == key.KeyID {
+			return repository.ErrSigningKeyExists
+		}
+	}
+	key.CreatedAt = r.Now()
+	stored := *key
+	r.signingKeys = append(r.signingKeys, &stored)
+	return nil
+}
+
+// ListScannerSigningKeys returns copies of every key, active ones first
+func (r *Repository) ListScannerSigningKeys(ctx context.Context) ([]*entity.ScannerSigningKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]*entity.ScannerSigningKey, 0, len(r.signingKeys))
+	for i := len(r.signingKeys) - 1; i >= 0; i-- {
+		listed := *r.signingKeys[i]
+		keys = append(keys, &listed)
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return !keys[i].Revoked() && keys[j].Revoked() })
+	return keys, nil
+}
+
+// GetScannerSigningKey returns a copy of the key with the ID
+func (r *Repository) GetScannerSigningKey(ctx context.Context, id uuid.UUID) (*entity.ScannerSigningKey, error) {
+	return r.signingKey(func(k *entity.ScannerSigningKey) bool { return k.ID == id })
+}
+
+// GetScannerSigningKeyByKeyID returns a copy of the key scanners name keyID
+func (r *Repository) GetScannerSigningKeyByKeyID(ctx context.Context, keyID string) (*entity.ScannerSigningKey, error) {
+	return r.signingKey(func(k *entity.ScannerSigningKey) bool { return k.KeyID == keyID })
+}
+
+// RevokeScannerSigningKey marks an active key revoked
+func (r *Repository) RevokeScannerSigningKey(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.ScannerSigningKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range r.signingKeys {
+		if key.ID == id && !key.Revoked() {
+			now := r.Now()
+			key.RevokedAt = &now
+			key.RevokedBy = revokedBy
+			revoked := *key
+			return &revoked, nil
+		}
+	}
+	return nil, repository.ErrSigningKeyNotFound
+}
+
+// GetTenantScanSigningPolicy returns a copy of the tenant's choice, or nil
+func (r *Repository) GetTenantScanSigningPolicy(ctx context.Context) (*entity.TenantScanSigningPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.scanSigning == nil {
+		return nil, nil
+	}
+	stored := *r.scanSigning
+	return &stored, nil
+}
+
+// SetTenantScanSigningPolicy records whether the tenant rejects unsigned scan payloads
+func (r *Repository) SetTenantScanSigningPolicy(ctx context.Context, requireSignature bool, updatedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scanSigning = &entity.TenantScanSigningPolicy{RequireSignature: requireSignature, UpdatedBy: updatedBy, UpdatedAt: r.Now()}
+	return nil
+}
+
+func (r *Repository) signingKey(match func(*entity.ScannerSigningKey) bool) (*entity.ScannerSigningKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range r.signingKeys {
+		if match(key) {
+			found := *key
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrSigningKeyNotFound
+}
+
+// ============================================================================
+// Helpers; callers hold r.mu
+// ============================================================================
+
+func (r *Repository) jobByID(id uuid.UUID) *entity.Job {
+	for _, job := range r.jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func (r *Repository) findByID(id string) *entity.Finding {
+	for _, f := range r.findings {
+		if f.ID.String() == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func (r *Repository) actionByID(id string) *entity.RemediationAction {
+	for _, a := range r.actions {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (r *Repository) requestByID(id string) *entity.RemediationApprovalRequest {
+	for _, req := range r.requests {
+		if req.ID == id {
+			return req
+		}
+	}
+	return nil
+}
+
+// listActions returns matching actions newest first, without their metadata
+func (r *Repository) listActions(match func(*entity.RemediationAction) bool) []*entity.RemediationAction {
+	var actions []*entity.RemediationAction
+	for _, a := range r.actions {
+		if match(a) {
+			listed := *a
+			listed.Metadata = nil
+			actions = append(actions, &listed)
+		}
+	}
+	// Stable, so actions executed at the same instant keep insertion order reversed
+	for i, j := 0, len(actions)-1; i < j; i, j = i+1, j-1 {
+		actions[i], actions[j] = actions[j], actions[i]
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].ExecutedAt.After(actions[j].ExecutedAt)
+	})
+	return actions
+}
+
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func copyScanRun(run *entity.ScanRun) *entity.ScanRun {
+	stored := *run
+	if run.Metadata != nil {
+		stored.Metadata = make(map[string]interface{}, len(run.Metadata))
+		for k, v := range run.Metadata {
+			stored.Metadata[k] = v
+		}
+	}
+	return &stored
+}
+
+func idempotencyKey(endpoint, key string) string {
+	return endpoint + "\x00" + key
+}
+
+func copyIdempotencyRecord(record *entity.IdempotencyRecord) *entity.IdempotencyRecord {
+	c := *record
+	c.Body = append([]byte(nil), record.Body...)
+	return &c
+}
+
+func copyJurisdictionProfile(profile *entity.JurisdictionProfile) *entity.JurisdictionProfile {
+	c := *profile
+	c.PIITypes = make(map[string]entity.PIITypeRule, len(profile.PIITypes))
+	for piiType, rule := range profile.PIITypes {
+		c.PIITypes[piiType] = rule
+	}
+	return &c
+}
+
+func copySimulation(sim *entity.RemediationSimulation) *entity.RemediationSimulation {
+	stored := *sim
+	stored.Changes = append(json.RawMessage(nil), sim.Changes...)
+	return &stored
+}
+
+func copyRequest(req *entity.RemediationApprovalRequest) *entity.RemediationApprovalRequest {
+	stored := *req
+	stored.FindingIDs = append([]string(nil), req.FindingIDs...)
+	stored.ActionIDs = append([]string{}, req.ActionIDs...)
+	stored.Errors = append([]string{}, req.Errors...)
+	return &stored
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
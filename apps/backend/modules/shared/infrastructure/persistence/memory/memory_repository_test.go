@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+)
+
+func TestTransactionAppliesWritesOnCommit(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepository()
+	assetID := uuid.New()
+
+	tx, _ := r.BeginTransaction(ctx)
+	finding := &entity.Finding{ID: uuid.New(), AssetID: assetID, PatternName: "EMAIL_ADDRESS", Severity: "High"}
+	if err := tx.CreateFinding(ctx, finding); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := r.CountFindings(ctx, repository.FindingFilters{}); n != 0 {
+		t.Fatalf("uncommitted finding is visible: %d", n)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != sql.ErrTxDone {
+		t.Errorf("expected rollback after commit to return ErrTxDone, got %v", err)
+	}
+
+	if n, _ := r.CountFindings(ctx, repository.FindingFilters{AssetID: &assetID, Severity: "Critical,High"}); n != 1 {
+		t.Errorf("expected the committed finding to be counted, got %d", n)
+	}
+
+	tx, _ = r.BeginTransaction(ctx)
+	tx.CreateFinding(ctx, &entity.Finding{ID: uuid.New(), AssetID: assetID})
+	tx.Rollback()
+	if got := len(r.Findings()); got != 1 {
+		t.Errorf("expected rolled back writes to be discarded, got %d findings", got)
+	}
+}
+
+func TestCountFindingsSkipsNonPII(t *testing.T) {
+	ctx := context.Background()
+	r := NewRepository()
+
+	tx, _ := r.BeginTransaction(ctx)
+	for _, classification := range []string{"Sensitive Personal Data", "Non-PII"} {
+		finding := &entity.Finding{ID: uuid.New(), PatternName: "EMAIL_ADDRESS"}
+		tx.CreateFinding(ctx, finding)
+		tx.CreateClassification(ctx, &entity.Classification{ID: uuid.New(), FindingID: finding.ID, ClassificationType: classification})
+	}
+	tx.Commit()
+
+	if n, _ := r.CountFindings(ctx, repository.FindingFilters{PatternName: "email"}); n != 1 {
+		t.Errorf("expected 1 PII finding, got %d", n)
+	}
+}
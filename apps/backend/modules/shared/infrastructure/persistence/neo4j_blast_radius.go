@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// SystemExposure is an asset owned by a system together with one PII category it
+// currently exposes. PIIType is empty for an asset with no open exposure.
+type SystemExposure struct {
+	SystemID      string
+	SystemHost    string
+	AssetID       string
+	AssetName     string
+	AssetPath     string
+	Environment   string
+	PIIType       string
+	DPDPACategory string
+	RiskLevel     string
+	FindingCount  int64
+}
+
+// GetSystemExposures walks System → Asset → PII_Category from one system, matched
+// by node ID or host, following only EXPOSES edges whose window is still open
+func (r *Neo4jRepository) GetSystemExposures(ctx context.Context, system string) ([]SystemExposure, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (sys:System)-[:SYSTEM_OWNS_ASSET]->(asset:Asset)
+			WHERE sys.id = $system OR sys.host = $system
+			OPTIONAL MATCH (asset)-[e:EXPOSES]->(pii:PII_Category)
+			WHERE e.valid_to IS NULL
+			RETURN sys.id, sys.host, asset.id, asset.name, asset.path, asset.environment,
+			       coalesce(pii.pii_type, pii.type), pii.dpdpa_category, pii.risk_level, e.finding_count
+			ORDER BY asset.name
+		`
+		result, err := tx.Run(ctx, query, map[string]interface{}{"system": system})
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		exposures := []SystemExposure{}
+		for _, record := range records {
+			v := record.Values
+			exposure := SystemExposure{}
+			exposure.SystemID, _ = v[0].(string)
+			exposure.SystemHost, _ = v[1].(string)
+			exposure.AssetID, _ = v[2].(string)
+			exposure.AssetName, _ = v[3].(string)
+			exposure.AssetPath, _ = v[4].(string)
+			exposure.Environment, _ = v[5].(string)
+			exposure.PIIType, _ = v[6].(string)
+			exposure.DPDPACategory, _ = v[7].(string)
+			exposure.RiskLevel, _ = v[8].(string)
+			exposure.FindingCount, _ = v[9].(int64)
+			exposures = append(exposures, exposure)
+		}
+		return exposures, nil
+	})
+	r.breaker.Record(err)
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]SystemExposure), nil
+}
@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"context"
+	"sort"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// GetAssetLineageStates returns the graph's view of the given assets, keyed by asset ID.
+// Assets without a node are absent from the result. PII types are those with an open
+// exposure window.
+func (r *Neo4jRepository) GetAssetLineageStates(ctx context.Context, assetIDs []string) (map[string]*entity.AssetLineageState, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			UNWIND $assetIDs AS assetID
+			MATCH (a:Asset {id: assetID})
+			OPTIONAL MATCH (a)-[e:EXPOSES]->(p:PII_Category)
+			WHERE e.valid_to IS NULL
+			RETURN a.id, a.name, a.host, a.environment, a.risk_score, a.total_findings,
+				collect(DISTINCT p.pii_type) AS pii_types
+		`
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"assetIDs": assetIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		states := make(map[string]*entity.AssetLineageState, len(records))
+		for _, record := range records {
+			id, _ := record.Values[0].(string)
+			state := &entity.AssetLineageState{PIITypes: []string{}}
+			state.AssetID, _ = uuid.Parse(id)
+			state.Name, _ = record.Values[1].(string)
+			state.Host, _ = record.Values[2].(string)
+			state.Environment, _ = record.Values[3].(string)
+			riskScore, _ := record.Values[4].(int64)
+			totalFindings, _ := record.Values[5].(int64)
+			state.RiskScore = int(riskScore)
+			state.TotalFindings = int(totalFindings)
+			if piiTypes, ok := record.Values[6].([]interface{}); ok {
+				for _, piiType := range piiTypes {
+					if s, ok := piiType.(string); ok && s != "" {
+						state.PIITypes = append(state.PIITypes, s)
+					}
+				}
+			}
+			sort.Strings(state.PIITypes)
+			states[id] = state
+		}
+		return states, nil
+	})
+	r.breaker.Record(err)
+
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]*entity.AssetLineageState), nil
+}
+
+// ListAssetNodeIDs returns the ID of every Asset node in the graph
+func (r *Neo4jRepository) ListAssetNodeIDs(ctx context.Context) ([]string, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, `MATCH (a:Asset) RETURN a.id ORDER BY a.id`, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, 0, len(records))
+		for _, record := range records {
+			if id, ok := record.Values[0].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	})
+	r.breaker.Record(err)
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]string), nil
+}
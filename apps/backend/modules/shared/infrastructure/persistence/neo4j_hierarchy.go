@@ -15,10 +15,13 @@ import (
 // CreatePIICategoryNode creates or updates a PII_Category node
 // PII_Category represents specific PII types (IN_AADHAAR, CREDIT_CARD, etc.)
 func (r *Neo4jRepository) CreatePIICategoryNode(ctx context.Context, piiType string, metadata map[string]interface{}) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MERGE (pii:PII_Category {type: $type})
 			SET pii.pii_type = $type,
@@ -41,6 +44,7 @@ func (r *Neo4jRepository) CreatePIICategoryNode(ctx context.Context, piiType str
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
@@ -48,10 +52,13 @@ func (r *Neo4jRepository) CreatePIICategoryNode(ctx context.Context, piiType str
 // CreateHierarchyRelationship creates relationships using frozen semantic contract
 // Allowed edge types: SYSTEM_OWNS_ASSET, EXPOSES
 func (r *Neo4jRepository) CreateHierarchyRelationship(ctx context.Context, parentID, childID, relType string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		var query string
 
 		switch relType {
@@ -82,14 +89,45 @@ func (r *Neo4jRepository) CreateHierarchyRelationship(ctx context.Context, paren
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
+
+	return err
+}
+
+// RemoveOtherSystemOwners drops SYSTEM_OWNS_ASSET edges to an asset from every
+// system but systemID, for when an asset moves to another system
+func (r *Neo4jRepository) RemoveOtherSystemOwners(ctx context.Context, assetID, systemID string) error {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (sys:System)-[r:SYSTEM_OWNS_ASSET]->(asset:Asset {id: $assetID})
+			WHERE sys.id <> $systemID
+			DELETE r
+		`
+		params := map[string]interface{}{
+			"assetID":  assetID,
+			"systemID": systemID,
+		}
+		_, err := tx.Run(ctx, query, params)
+		return nil, err
+	})
+	r.breaker.Record(err)
 
 	return err
 }
 
 // GetSemanticGraph retrieves the 3-level hierarchy from Neo4j
 func (r *Neo4jRepository) GetSemanticGraph(ctx context.Context, systemFilter, riskFilter string) ([]Node, []Edge, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer done()
 
 	nodes := []Node{}
 	edges := []Edge{}
@@ -130,79 +168,17 @@ func (r *Neo4jRepository) GetSemanticGraph(ctx context.Context, systemFilter, ri
 			assetVal, _ := record.Get("asset")
 			piiVal, _ := record.Get("pii")
 
-			// Process System node
-			if sysVal != nil {
-				if node, ok := sysVal.(neo4j.Node); ok {
-					id, _ := node.Props["id"].(string)
-					host, _ := node.Props["host"].(string)
-					// Use host as label for System nodes
-					label := host
-					if label == "" {
-						label = id // Fallback to ID if host is empty
-					}
-					if id != "" && !nodeMap[id] {
-						nodes = append(nodes, Node{
-							ID:    id,
-							Label: label,
-							Type:  "system",
-							Metadata: map[string]interface{}{
-								"host": host,
-							},
-						})
-						nodeMap[id] = true
-					}
-				}
+			if node, ok := systemGraphNode(sysVal); ok && !nodeMap[node.ID] {
+				nodes = append(nodes, node)
+				nodeMap[node.ID] = true
 			}
-
-			// Process Asset node
-			if assetVal != nil {
-				if node, ok := assetVal.(neo4j.Node); ok {
-					id, _ := node.Props["id"].(string)
-					name, _ := node.Props["name"].(string)
-					path, _ := node.Props["path"].(string)
-					// Use name if available, otherwise path, otherwise ID
-					label := name
-					if label == "" {
-						label = path
-					}
-					if label == "" {
-						label = id
-					}
-					if id != "" && !nodeMap[id] {
-						nodes = append(nodes, Node{
-							ID:    id,
-							Label: label,
-							Type:  "asset",
-							Metadata: map[string]interface{}{
-								"path":        path,
-								"environment": node.Props["environment"],
-							},
-						})
-						nodeMap[id] = true
-					}
-				}
+			if node, ok := assetGraphNode(assetVal); ok && !nodeMap[node.ID] {
+				nodes = append(nodes, node)
+				nodeMap[node.ID] = true
 			}
-
-			// Process PII_Category node (replaces old DataCategory + PIIType)
-			if piiVal != nil {
-				if node, ok := piiVal.(neo4j.Node); ok {
-					piiType, _ := node.Props["type"].(string)
-					if piiType != "" && !nodeMap[piiType] {
-						nodes = append(nodes, Node{
-							ID:    piiType,
-							Label: piiType,
-							Type:  "pii_category",
-							Metadata: map[string]interface{}{
-								"pii_type":       piiType,
-								"finding_count":  node.Props["finding_count"],
-								"risk_level":     node.Props["risk_level"],
-								"avg_confidence": node.Props["avg_confidence"],
-								"dpdpa_category": node.Props["dpdpa_category"],
-							},
-						})
-						nodeMap[piiType] = true
-					}
-				}
+			if node, ok := piiCategoryGraphNode(piiVal); ok && !nodeMap[node.ID] {
+				nodes = append(nodes, node)
+				nodeMap[node.ID] = true
 			}
 
 			// Build edges from 3-level hierarchy
@@ -253,8 +229,13 @@ func (r *Neo4jRepository) GetSemanticGraph(ctx context.Context, systemFilter, ri
 			}
 		}
 
-		return nil, nil
+		// Prefixes of the S3 objects shown, then the departments and teams owning the assets
+		if err := appendS3PrefixGraph(ctx, tx, &nodes, &edges, nodeMap, edgeMap); err != nil {
+			return nil, err
+		}
+		return nil, appendOrgUnitGraph(ctx, tx, &nodes, &edges, nodeMap, edgeMap)
 	})
+	r.breaker.Record(err)
 
 	if err != nil {
 		return nil, nil, err
@@ -265,10 +246,85 @@ func (r *Neo4jRepository) GetSemanticGraph(ctx context.Context, systemFilter, ri
 	return nodes, edges, nil
 }
 
+// systemGraphNode converts a System node, labelled by host
+func systemGraphNode(v interface{}) (Node, bool) {
+	node, ok := v.(neo4j.Node)
+	if !ok {
+		return Node{}, false
+	}
+	id, _ := node.Props["id"].(string)
+	host, _ := node.Props["host"].(string)
+	// Use host as label for System nodes
+	label := host
+	if label == "" {
+		label = id // Fallback to ID if host is empty
+	}
+	return Node{
+		ID:    id,
+		Label: label,
+		Type:  "system",
+		Metadata: map[string]interface{}{
+			"host": host,
+		},
+	}, id != ""
+}
+
+// assetGraphNode converts an Asset node, labelled by name or path
+func assetGraphNode(v interface{}) (Node, bool) {
+	node, ok := v.(neo4j.Node)
+	if !ok {
+		return Node{}, false
+	}
+	id, _ := node.Props["id"].(string)
+	name, _ := node.Props["name"].(string)
+	path, _ := node.Props["path"].(string)
+	// Use name if available, otherwise path, otherwise ID
+	label := name
+	if label == "" {
+		label = path
+	}
+	if label == "" {
+		label = id
+	}
+	return Node{
+		ID:    id,
+		Label: label,
+		Type:  "asset",
+		Metadata: map[string]interface{}{
+			"path":        path,
+			"environment": node.Props["environment"],
+		},
+	}, id != ""
+}
+
+// piiCategoryGraphNode converts a PII_Category node (replaces old DataCategory + PIIType)
+func piiCategoryGraphNode(v interface{}) (Node, bool) {
+	node, ok := v.(neo4j.Node)
+	if !ok {
+		return Node{}, false
+	}
+	piiType, _ := node.Props["type"].(string)
+	return Node{
+		ID:    piiType,
+		Label: piiType,
+		Type:  "pii_category",
+		Metadata: map[string]interface{}{
+			"pii_type":       piiType,
+			"finding_count":  node.Props["finding_count"],
+			"risk_level":     node.Props["risk_level"],
+			"avg_confidence": node.Props["avg_confidence"],
+			"dpdpa_category": node.Props["dpdpa_category"],
+		},
+	}, piiType != ""
+}
+
 // GetPIIAggregations returns aggregated PII type statistics
 func (r *Neo4jRepository) GetPIIAggregations(ctx context.Context) ([]map[string]interface{}, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
 
 	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		// FROZEN SEMANTIC CONTRACT: 3-level hierarchy only
@@ -313,6 +369,7 @@ func (r *Neo4jRepository) GetPIIAggregations(ctx context.Context) ([]map[string]
 
 		return aggregations, nil
 	})
+	r.breaker.Record(err)
 
 	if err != nil {
 		return nil, err
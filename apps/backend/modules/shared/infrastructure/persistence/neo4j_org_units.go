@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// === Organizational Units ===
+// Node Type: OrgUnit (a department or a team), alongside the 3-level hierarchy
+// Edge Types: ORG_UNIT_CONTAINS (department → team), ORG_UNIT_OWNS_ASSET (unit → Asset)
+
+// SetAssetOrgUnit links an asset to the unit that owns it, and a team to its
+// department, dropping the asset's links to any other unit. With no unit the
+// asset's links are only dropped. Units left without links stay in the graph
+// but are not returned by GetSemanticGraph.
+func (r *Neo4jRepository) SetAssetOrgUnit(ctx context.Context, assetID string, unit, department *entity.OrgUnit) error {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		if unit == nil {
+			_, err := tx.Run(ctx, `
+				MATCH (:OrgUnit)-[r:ORG_UNIT_OWNS_ASSET]->(:Asset {id: $assetID})
+				DELETE r
+			`, map[string]interface{}{"assetID": assetID})
+			return nil, err
+		}
+
+		if _, err := tx.Run(ctx, `
+			MATCH (asset:Asset {id: $assetID})
+			MERGE (ou:OrgUnit {id: $unitID})
+			SET ou.name = $name, ou.kind = $kind, ou.updated_at = datetime()
+			MERGE (ou)-[r:ORG_UNIT_OWNS_ASSET]->(asset)
+			SET r.updated_at = datetime()
+			WITH asset, ou
+			OPTIONAL MATCH (other:OrgUnit)-[old:ORG_UNIT_OWNS_ASSET]->(asset)
+			WHERE other.id <> ou.id
+			DELETE old
+		`, map[string]interface{}{
+			"assetID": assetID,
+			"unitID":  unit.GraphID(),
+			"name":    unit.Name,
+			"kind":    unit.Kind,
+		}); err != nil {
+			return nil, err
+		}
+
+		if department == nil {
+			return nil, nil
+		}
+		_, err := tx.Run(ctx, `
+			MATCH (team:OrgUnit {id: $teamID})
+			MERGE (dept:OrgUnit {id: $departmentID})
+			SET dept.name = $name, dept.kind = $kind, dept.updated_at = datetime()
+			MERGE (dept)-[r:ORG_UNIT_CONTAINS]->(team)
+			SET r.updated_at = datetime()
+		`, map[string]interface{}{
+			"teamID":       unit.GraphID(),
+			"departmentID": department.GraphID(),
+			"name":         department.Name,
+			"kind":         department.Kind,
+		})
+		return nil, err
+	})
+	r.breaker.Record(err)
+
+	return err
+}
+
+// appendOrgUnitGraph adds the units owning the graph's assets, and the
+// departments of those that are teams, with the edges between them
+func appendOrgUnitGraph(ctx context.Context, tx neo4j.ManagedTransaction, nodes *[]Node, edges *[]Edge, nodeMap, edgeMap map[string]bool) error {
+	var assetIDs []string
+	for _, node := range *nodes {
+		if node.Type == "asset" {
+			assetIDs = append(assetIDs, node.ID)
+		}
+	}
+	if len(assetIDs) == 0 {
+		return nil
+	}
+
+	result, err := tx.Run(ctx, `
+		MATCH (ou:OrgUnit)-[:ORG_UNIT_OWNS_ASSET]->(asset:Asset)
+		WHERE asset.id IN $assetIDs
+		OPTIONAL MATCH (dept:OrgUnit)-[:ORG_UNIT_CONTAINS]->(ou)
+		RETURN ou, asset.id AS asset_id, dept
+	`, map[string]interface{}{"assetIDs": assetIDs})
+	if err != nil {
+		return err
+	}
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	addEdge := func(source, target, relType, label string) {
+		edgeID := fmt.Sprintf("%s-%s-%s", source, relType, target)
+		if !edgeMap[edgeID] {
+			*edges = append(*edges, Edge{ID: edgeID, Source: source, Target: target, Type: relType, Label: label})
+			edgeMap[edgeID] = true
+		}
+	}
+
+	for _, record := range records {
+		ouVal, _ := record.Get("ou")
+		deptVal, _ := record.Get("dept")
+		assetVal, _ := record.Get("asset_id")
+		assetID, _ := assetVal.(string)
+
+		unit, ok := orgUnitGraphNode(ouVal)
+		if !ok {
+			continue
+		}
+		if !nodeMap[unit.ID] {
+			*nodes = append(*nodes, unit)
+			nodeMap[unit.ID] = true
+		}
+		if assetID != "" {
+			addEdge(unit.ID, assetID, "ORG_UNIT_OWNS_ASSET", "owns")
+		}
+
+		if dept, ok := orgUnitGraphNode(deptVal); ok {
+			if !nodeMap[dept.ID] {
+				*nodes = append(*nodes, dept)
+				nodeMap[dept.ID] = true
+			}
+			addEdge(dept.ID, unit.ID, "ORG_UNIT_CONTAINS", "contains")
+		}
+	}
+	return nil
+}
+
+// orgUnitGraphNode converts an OrgUnit node, labelled by name
+func orgUnitGraphNode(v interface{}) (Node, bool) {
+	node, ok := v.(neo4j.Node)
+	if !ok {
+		return Node{}, false
+	}
+	id, _ := node.Props["id"].(string)
+	name, _ := node.Props["name"].(string)
+	label := name
+	if label == "" {
+		label = id
+	}
+	return Node{
+		ID:    id,
+		Label: label,
+		Type:  "org_unit",
+		Metadata: map[string]interface{}{
+			"kind": node.Props["kind"],
+		},
+	}, id != ""
+}
@@ -2,9 +2,15 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/circuitbreaker"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
@@ -34,16 +40,69 @@ type LineageGraph struct {
 	Edges []Edge `json:"edges"`
 }
 
-// Neo4jRepository handles all Neo4j graph database operations
+// Default resilience settings, overridable through SetCircuitBreaker
+const (
+	defaultNeo4jOperationTimeout = 10 * time.Second
+	defaultNeo4jFailureThreshold = 5
+	defaultNeo4jBreakerCooldown  = 30 * time.Second
+)
+
+// defaultNeo4jDatabase is the database sessions open when none is configured
+const defaultNeo4jDatabase = "neo4j"
+
+// Neo4jOptions tunes the driver for clustered deployments. Zero values keep the
+// driver defaults.
+type Neo4jOptions struct {
+	Database                     string        // Database sessions open; "neo4j" when empty
+	MaxConnectionPoolSize        int           // Connections kept per server
+	ConnectionAcquisitionTimeout time.Duration // Wait for a free pooled connection
+	MaxConnectionLifetime        time.Duration // Pooled connections older than this are replaced
+	FetchSize                    int           // Records pulled per batch
+	// CausalConsistency chains bookmarks across sessions so reads routed to
+	// followers observe this process's earlier writes
+	CausalConsistency bool
+	// SkipSchemaBootstrap leaves constraints and indexes to an operator instead of
+	// creating them when the repository is built
+	SkipSchemaBootstrap bool
+}
+
+// Neo4jRepository handles all Neo4j graph database operations. With a neo4j://
+// routing URI, reads go to followers and read replicas and writes to the leader.
 type Neo4jRepository struct {
-	driver neo4j.DriverWithContext
+	driver           neo4j.DriverWithContext
+	database         string
+	bookmarks        neo4j.BookmarkManager
+	fetchSize        int
+	breaker          *circuitbreaker.Breaker
+	operationTimeout time.Duration
 }
 
-// NewNeo4jRepository creates a new Neo4j repository
+// NewNeo4jRepository creates a new Neo4j repository with the driver defaults
 func NewNeo4jRepository(uri, username, password string) (*Neo4jRepository, error) {
+	return NewNeo4jRepositoryWithOptions(uri, username, password, Neo4jOptions{})
+}
+
+// NewNeo4jRepositoryWithOptions creates a new Neo4j repository. The URI may use a
+// direct bolt:// scheme or a neo4j:// routing scheme for clusters.
+func NewNeo4jRepositoryWithOptions(uri, username, password string, opts Neo4jOptions) (*Neo4jRepository, error) {
+	if _, err := neo4jURIScheme(uri); err != nil {
+		return nil, err
+	}
+
 	driver, err := neo4j.NewDriverWithContext(
 		uri,
 		neo4j.BasicAuth(username, password, ""),
+		func(c *neo4j.Config) {
+			if opts.MaxConnectionPoolSize > 0 {
+				c.MaxConnectionPoolSize = opts.MaxConnectionPoolSize
+			}
+			if opts.ConnectionAcquisitionTimeout > 0 {
+				c.ConnectionAcquisitionTimeout = opts.ConnectionAcquisitionTimeout
+			}
+			if opts.MaxConnectionLifetime > 0 {
+				c.MaxConnectionLifetime = opts.MaxConnectionLifetime
+			}
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
@@ -57,9 +116,117 @@ func NewNeo4jRepository(uri, username, password string) (*Neo4jRepository, error
 		return nil, fmt.Errorf("failed to verify Neo4j connectivity: %w", err)
 	}
 
-	return &Neo4jRepository{
-		driver: driver,
-	}, nil
+	repo := &Neo4jRepository{
+		driver:           driver,
+		database:         opts.Database,
+		fetchSize:        opts.FetchSize,
+		operationTimeout: defaultNeo4jOperationTimeout,
+	}
+	if repo.database == "" {
+		repo.database = defaultNeo4jDatabase
+	}
+	if opts.CausalConsistency {
+		repo.bookmarks = neo4j.NewBookmarkManager(neo4j.BookmarkManagerConfig{})
+	}
+	repo.SetCircuitBreaker(NewNeo4jCircuitBreaker(defaultNeo4jFailureThreshold, defaultNeo4jBreakerCooldown), 0)
+
+	// MERGE on an unconstrained key can create duplicates under concurrent syncs;
+	// a failed bootstrap is retried on the next startup rather than blocking this one
+	if !opts.SkipSchemaBootstrap {
+		if err := repo.EnsureSchema(ctx); err != nil {
+			log.Printf("⚠️  Neo4j schema bootstrap incomplete: %v", err)
+		}
+	}
+	return repo, nil
+}
+
+// IsRoutingURI reports whether a Neo4j URI uses cluster routing
+func IsRoutingURI(uri string) bool {
+	scheme, err := neo4jURIScheme(uri)
+	return err == nil && strings.HasPrefix(scheme, "neo4j")
+}
+
+// neo4jURIScheme validates the scheme of a Neo4j URI
+func neo4jURIScheme(uri string) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid Neo4j URI: %w", err)
+	}
+	switch scheme := strings.ToLower(parsed.Scheme); scheme {
+	case "bolt", "bolt+s", "bolt+ssc", "neo4j", "neo4j+s", "neo4j+ssc":
+		return scheme, nil
+	default:
+		return "", fmt.Errorf("unsupported Neo4j URI scheme %q: use bolt:// or neo4j:// (optionally +s or +ssc)", parsed.Scheme)
+	}
+}
+
+// NewNeo4jCircuitBreaker creates a breaker that trips on Neo4j connectivity
+// problems and timeouts, but not on errors the server reports for a query
+func NewNeo4jCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitbreaker.Breaker {
+	return circuitbreaker.New("neo4j", circuitbreaker.Settings{
+		FailureThreshold: failureThreshold,
+		Cooldown:         cooldown,
+		IsFailure:        isNeo4jUnavailable,
+	})
+}
+
+// SetCircuitBreaker replaces the breaker guarding Neo4j calls.
+// A positive operationTimeout bounds each call, including driver retries.
+func (r *Neo4jRepository) SetCircuitBreaker(breaker *circuitbreaker.Breaker, operationTimeout time.Duration) {
+	if breaker == nil {
+		return
+	}
+	breaker.OnStateChange(func(name string, from, to circuitbreaker.State) {
+		log.Printf("⚡ Circuit breaker %s: %s → %s", name, from, to)
+	})
+	r.breaker = breaker
+	if operationTimeout > 0 {
+		r.operationTimeout = operationTimeout
+	}
+}
+
+// CircuitBreaker returns the breaker guarding Neo4j calls
+func (r *Neo4jRepository) CircuitBreaker() *circuitbreaker.Breaker {
+	return r.breaker
+}
+
+// IsAvailable reports whether Neo4j calls are currently let through.
+// It is false while the breaker is open, so callers can defer work instead of failing.
+func (r *Neo4jRepository) IsAvailable() bool {
+	return r.breaker.Ready()
+}
+
+// openSession admits a call through the circuit breaker and opens a session
+// bounded by the operation timeout. The access mode lets a routing driver send
+// reads to followers. The caller must Record the outcome on the breaker and call
+// done when finished.
+func (r *Neo4jRepository) openSession(ctx context.Context, accessMode neo4j.AccessMode) (context.Context, neo4j.SessionWithContext, func(), error) {
+	if err := r.breaker.Allow(); err != nil {
+		return ctx, nil, nil, fmt.Errorf("neo4j unavailable: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.operationTimeout)
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName:    r.database,
+		AccessMode:      accessMode,
+		BookmarkManager: r.bookmarks,
+		FetchSize:       r.fetchSize,
+	})
+	done := func() {
+		session.Close(ctx)
+		cancel()
+	}
+	return ctx, session, done, nil
+}
+
+// isNeo4jUnavailable separates an unreachable or overloaded Neo4j from query
+// errors, which prove the server is up and must not trip the breaker
+func isNeo4jUnavailable(err error) bool {
+	var neo4jErr *neo4j.Neo4jError
+	if errors.As(err, &neo4jErr) {
+		return neo4jErr.Classification() != "ClientError"
+	}
+	return !neo4j.IsUsageError(err)
 }
 
 // GetDriver returns the underlying Neo4j driver
@@ -77,37 +244,51 @@ func (r *Neo4jRepository) Close(ctx context.Context) error {
 
 // CreateSystemNode creates or updates a system node in Neo4j
 func (r *Neo4jRepository) CreateSystemNode(ctx context.Context, systemID, label string, metadata map[string]interface{}) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MERGE (s:System {id: $systemID})
 			SET s.label = $label,
 			    s.host = $host,
 			    s.source_system = $sourceSystem,
 			    s.updated_at = datetime()
+			SET s += $inventory
 			RETURN s
 		`
+		// Systems imported from a cloud inventory carry its provider, region and tags
+		inventory, _ := metadata["inventory"].(map[string]interface{})
+		if inventory == nil {
+			inventory = map[string]interface{}{}
+		}
 		params := map[string]interface{}{
 			"systemID":     systemID,
 			"label":        label,
 			"host":         metadata["host"],
 			"sourceSystem": metadata["source_system"],
+			"inventory":    inventory,
 		}
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
 
 // CreateAssetNode creates or updates an asset node in Neo4j
 func (r *Neo4jRepository) CreateAssetNode(ctx context.Context, asset *entity.Asset) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MERGE (a:Asset {id: $id})
 			SET a.name = $name,
@@ -139,16 +320,20 @@ func (r *Neo4jRepository) CreateAssetNode(ctx context.Context, asset *entity.Ass
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
 
 // CreateFindingNode creates or updates a finding node in Neo4j
 func (r *Neo4jRepository) CreateFindingNode(ctx context.Context, finding *entity.Finding, classification *entity.Classification) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MERGE (f:Finding {id: $id})
 			SET f.pattern_name = $patternName,
@@ -174,7 +359,7 @@ func (r *Neo4jRepository) CreateFindingNode(ctx context.Context, finding *entity
 			"id":             finding.ID.String(),
 			"patternName":    finding.PatternName,
 			"severity":       finding.Severity,
-			"matchesCount":   len(finding.Matches),
+			"matchesCount":   max(finding.TotalMatches, len(finding.Matches)),
 			"classification": classificationType,
 			"confidence":     confidence,
 			"riskScore":      riskScore,
@@ -182,16 +367,20 @@ func (r *Neo4jRepository) CreateFindingNode(ctx context.Context, finding *entity
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
 
 // CreateClassificationNode creates or updates a classification node in Neo4j
 func (r *Neo4jRepository) CreateClassificationNode(ctx context.Context, classification *entity.Classification) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MERGE (c:Classification {type: $type})
 			SET c.dpdpa_category = $dpdpaCategory,
@@ -207,6 +396,7 @@ func (r *Neo4jRepository) CreateClassificationNode(ctx context.Context, classifi
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
@@ -215,10 +405,13 @@ func (r *Neo4jRepository) CreateClassificationNode(ctx context.Context, classifi
 
 // CreateExposesRelationship creates an EXPOSES relationship (Asset -> Finding)
 func (r *Neo4jRepository) CreateExposesRelationship(ctx context.Context, assetID, findingID string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MATCH (a:Asset {id: $assetID})
 			MATCH (f:Finding {id: $findingID})
@@ -232,16 +425,20 @@ func (r *Neo4jRepository) CreateExposesRelationship(ctx context.Context, assetID
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
 
 // CreateClassifiedAsRelationship creates a CLASSIFIED_AS relationship (Finding -> Classification)
 func (r *Neo4jRepository) CreateClassifiedAsRelationship(ctx context.Context, findingID, classificationType string) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MATCH (f:Finding {id: $findingID})
 			MATCH (c:Classification {type: $classificationType})
@@ -255,6 +452,33 @@ func (r *Neo4jRepository) CreateClassifiedAsRelationship(ctx context.Context, fi
 		_, err := tx.Run(ctx, query, params)
 		return nil, err
 	})
+	r.breaker.Record(err)
+
+	return err
+}
+
+// === Node Removal Methods ===
+
+// DeleteAssetNode removes an asset node and all of its relationships
+func (r *Neo4jRepository) DeleteAssetNode(ctx context.Context, assetID string) error {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (a:Asset {id: $assetID})
+			DETACH DELETE a
+		`
+		params := map[string]interface{}{
+			"assetID": assetID,
+		}
+		_, err := tx.Run(ctx, query, params)
+		return nil, err
+	})
+	r.breaker.Record(err)
 
 	return err
 }
@@ -263,8 +487,11 @@ func (r *Neo4jRepository) CreateClassifiedAsRelationship(ctx context.Context, fi
 
 // GetLineageGraph retrieves the complete lineage graph from Neo4j
 func (r *Neo4jRepository) GetLineageGraph(ctx context.Context) (*LineageGraph, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
 
 	nodes := []Node{}
 	edges := []Edge{}
@@ -411,6 +638,7 @@ func (r *Neo4jRepository) GetLineageGraph(ctx context.Context) (*LineageGraph, e
 
 		return nil, nil
 	})
+	r.breaker.Record(err)
 
 	if err != nil {
 		return nil, err
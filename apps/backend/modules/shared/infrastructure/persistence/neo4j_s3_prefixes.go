@@ -0,0 +1,143 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// === S3 Prefixes ===
+// Node Type: S3Prefix (a folder of an S3 bucket), alongside the 3-level hierarchy
+// Edge Types: BUCKET_CONTAINS_PREFIX (bucket System → outermost prefix),
+// PREFIX_CONTAINS (prefix → nested prefix, innermost prefix → Asset)
+
+// S3PrefixGraphID identifies the node of a prefix within a bucket
+func S3PrefixGraphID(bucket, prefix string) string {
+	return fmt.Sprintf("s3prefix-%s/%s", bucket, prefix)
+}
+
+// SetAssetS3Prefixes links an S3 object to the chain of prefixes of its key, from
+// the bucket's System node down, dropping the object's links to any other prefix.
+// Objects at the bucket root have no prefixes and are only unlinked.
+func (r *Neo4jRepository) SetAssetS3Prefixes(ctx context.Context, assetID, systemID, bucket string, prefixes []string) error {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		innermost := ""
+		if len(prefixes) > 0 {
+			innermost = S3PrefixGraphID(bucket, prefixes[len(prefixes)-1])
+		}
+		if _, err := tx.Run(ctx, `
+			MATCH (other:S3Prefix)-[old:PREFIX_CONTAINS]->(:Asset {id: $assetID})
+			WHERE other.id <> $prefixID
+			DELETE old
+		`, map[string]interface{}{"assetID": assetID, "prefixID": innermost}); err != nil {
+			return nil, err
+		}
+		if len(prefixes) == 0 {
+			return nil, nil
+		}
+
+		parentID, parentLabel, parentRel := systemID, "System", "BUCKET_CONTAINS_PREFIX"
+		for _, prefix := range prefixes {
+			prefixID := S3PrefixGraphID(bucket, prefix)
+			// Labels and relationship types cannot be parameters; both are constants here
+			if _, err := tx.Run(ctx, `
+				MATCH (parent:`+parentLabel+` {id: $parentID})
+				MERGE (p:S3Prefix {id: $prefixID})
+				SET p.bucket = $bucket, p.prefix = $prefix, p.updated_at = datetime()
+				MERGE (parent)-[r:`+parentRel+`]->(p)
+				SET r.updated_at = datetime()
+			`, map[string]interface{}{
+				"parentID": parentID,
+				"prefixID": prefixID,
+				"bucket":   bucket,
+				"prefix":   prefix,
+			}); err != nil {
+				return nil, err
+			}
+			parentID, parentLabel, parentRel = prefixID, "S3Prefix", "PREFIX_CONTAINS"
+		}
+
+		_, err := tx.Run(ctx, `
+			MATCH (p:S3Prefix {id: $prefixID}), (asset:Asset {id: $assetID})
+			MERGE (p)-[r:PREFIX_CONTAINS]->(asset)
+			SET r.updated_at = datetime()
+		`, map[string]interface{}{"prefixID": innermost, "assetID": assetID})
+		return nil, err
+	})
+	r.breaker.Record(err)
+
+	return err
+}
+
+// appendS3PrefixGraph adds the prefixes between the graph's S3 objects and their
+// bucket, with the edges between them
+func appendS3PrefixGraph(ctx context.Context, tx neo4j.ManagedTransaction, nodes *[]Node, edges *[]Edge, nodeMap, edgeMap map[string]bool) error {
+	var assetIDs []string
+	for _, node := range *nodes {
+		if node.Type == "asset" {
+			assetIDs = append(assetIDs, node.ID)
+		}
+	}
+	if len(assetIDs) == 0 {
+		return nil
+	}
+
+	result, err := tx.Run(ctx, `
+		MATCH path = (:System)-[:BUCKET_CONTAINS_PREFIX]->(:S3Prefix)-[:PREFIX_CONTAINS*0..]->(:S3Prefix)-[:PREFIX_CONTAINS]->(asset:Asset)
+		WHERE asset.id IN $assetIDs
+		RETURN nodes(path) AS chain
+	`, map[string]interface{}{"assetIDs": assetIDs})
+	if err != nil {
+		return err
+	}
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		chainVal, _ := record.Get("chain")
+		chain, _ := chainVal.([]interface{})
+		var previous string
+		for i, v := range chain {
+			node, ok := v.(neo4j.Node)
+			if !ok {
+				break
+			}
+			id, _ := node.Props["id"].(string)
+			if i > 0 && i < len(chain)-1 && !nodeMap[id] {
+				prefix, _ := node.Props["prefix"].(string)
+				*nodes = append(*nodes, Node{
+					ID:    id,
+					Label: prefix,
+					Type:  "s3_prefix",
+					Metadata: map[string]interface{}{
+						"bucket": node.Props["bucket"],
+						"prefix": prefix,
+					},
+				})
+				nodeMap[id] = true
+			}
+			if i > 0 {
+				relType, label := "PREFIX_CONTAINS", "contains"
+				if i == 1 {
+					relType = "BUCKET_CONTAINS_PREFIX"
+				}
+				edgeID := fmt.Sprintf("%s-%s-%s", previous, relType, id)
+				if !edgeMap[edgeID] {
+					*edges = append(*edges, Edge{ID: edgeID, Source: previous, Target: id, Type: relType, Label: label})
+					edgeMap[edgeID] = true
+				}
+			}
+			previous = id
+		}
+	}
+	return nil
+}
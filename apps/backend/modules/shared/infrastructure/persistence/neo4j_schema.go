@@ -0,0 +1,172 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// neo4jSchemaVersion is the version of neo4jSchema. Bump it whenever a statement is
+// added so existing graphs pick the change up on their next startup.
+const neo4jSchemaVersion = 3
+
+// neo4jSchemaName identifies this application's SchemaVersion node
+const neo4jSchemaName = "arc-hawk"
+
+// neo4jSchemaStatement is one constraint or index the graph writes rely on
+type neo4jSchemaStatement struct {
+	Name   string
+	Cypher string
+	// Duplicates finds keys that break a uniqueness constraint, so a failed
+	// constraint can be explained; empty for indexes
+	Duplicates string
+}
+
+// neo4jSchema lists the constraints behind every MERGE key and the indexes behind
+// risk filters and lookups. Every statement is idempotent.
+var neo4jSchema = []neo4jSchemaStatement{
+	uniqueConstraint("system_id_unique", "System", "id"),
+	uniqueConstraint("asset_id_unique", "Asset", "id"),
+	uniqueConstraint("pii_category_type_unique", "PII_Category", "type"),
+	uniqueConstraint("finding_id_unique", "Finding", "id"),
+	uniqueConstraint("classification_type_unique", "Classification", "type"),
+	uniqueConstraint("org_unit_id_unique", "OrgUnit", "id"),
+	uniqueConstraint("s3_prefix_id_unique", "S3Prefix", "id"),
+	nodeIndex("system_host", "System", "host"),
+	nodeIndex("asset_risk_score", "Asset", "risk_score"),
+	nodeIndex("finding_risk_score", "Finding", "risk_score"),
+	nodeIndex("pii_category_risk_level", "PII_Category", "risk_level"),
+	nodeIndex("pii_category_pii_type", "PII_Category", "pii_type"),
+}
+
+func uniqueConstraint(name, label, property string) neo4jSchemaStatement {
+	return neo4jSchemaStatement{
+		Name:   name,
+		Cypher: fmt.Sprintf("CREATE CONSTRAINT %s IF NOT EXISTS FOR (n:%s) REQUIRE n.%s IS UNIQUE", name, label, property),
+		Duplicates: fmt.Sprintf(
+			"MATCH (n:%s) WHERE n.%s IS NOT NULL WITH n.%s AS key, count(*) AS nodes WHERE nodes > 1 RETURN count(key)",
+			label, property, property),
+	}
+}
+
+func nodeIndex(name, label, property string) neo4jSchemaStatement {
+	return neo4jSchemaStatement{
+		Name:   name,
+		Cypher: fmt.Sprintf("CREATE INDEX %s IF NOT EXISTS FOR (n:%s) ON (n.%s)", name, label, property),
+	}
+}
+
+// EnsureSchema creates the graph's constraints and indexes unless the recorded
+// schema version is already current. Statements that fail, typically a uniqueness
+// constraint over duplicate nodes, are reported together and the version is left
+// unchanged so they are retried on the next startup.
+func (r *Neo4jRepository) EnsureSchema(ctx context.Context) error {
+	applied, err := r.schemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read Neo4j schema version: %w", err)
+	}
+	if applied >= neo4jSchemaVersion {
+		return nil
+	}
+
+	var failed []string
+	for _, stmt := range neo4jSchema {
+		if err := r.runSchemaStatement(ctx, stmt.Cypher); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v%s", stmt.Name, err, r.duplicateHint(ctx, stmt)))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d Neo4j schema statements failed: %s", len(failed), len(neo4jSchema), strings.Join(failed, "; "))
+	}
+
+	if err := r.runSchemaStatement(ctx, `
+		MERGE (v:SchemaVersion {name: $name})
+		SET v.version = $version, v.applied_at = datetime()
+	`, "name", neo4jSchemaName, "version", neo4jSchemaVersion); err != nil {
+		return fmt.Errorf("failed to record Neo4j schema version: %w", err)
+	}
+	log.Printf("✅ Neo4j schema migrated from version %d to %d", applied, neo4jSchemaVersion)
+	return nil
+}
+
+// schemaVersion returns the recorded schema version, 0 for a graph never bootstrapped
+func (r *Neo4jRepository) schemaVersion(ctx context.Context) (int, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	version, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, `MATCH (v:SchemaVersion {name: $name}) RETURN v.version`, map[string]interface{}{
+			"name": neo4jSchemaName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result.Next(ctx) {
+			version, _ := result.Record().Values[0].(int64)
+			return int(version), nil
+		}
+		return 0, result.Err()
+	})
+	r.breaker.Record(err)
+	if err != nil {
+		return 0, err
+	}
+	return version.(int), nil
+}
+
+// runSchemaStatement runs one statement in an auto-commit transaction, which schema
+// commands require. params alternate names and values.
+func (r *Neo4jRepository) runSchemaStatement(ctx context.Context, cypher string, params ...interface{}) error {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	values := make(map[string]interface{}, len(params)/2)
+	for i := 0; i+1 < len(params); i += 2 {
+		values[params[i].(string)] = params[i+1]
+	}
+	result, err := session.Run(ctx, cypher, values)
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
+	r.breaker.Record(err)
+	return err
+}
+
+// duplicateHint counts the keys that stop a uniqueness constraint from being created
+func (r *Neo4jRepository) duplicateHint(ctx context.Context, stmt neo4jSchemaStatement) string {
+	if stmt.Duplicates == "" {
+		return ""
+	}
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return ""
+	}
+	defer done()
+
+	count, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, stmt.Duplicates, nil)
+		if err != nil {
+			return nil, err
+		}
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		n, _ := record.Values[0].(int64)
+		return n, nil
+	})
+	r.breaker.Record(err)
+	if err != nil || count.(int64) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d keys are shared by more than one node; merge them first)", count.(int64))
+}
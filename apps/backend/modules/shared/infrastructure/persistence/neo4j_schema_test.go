@@ -0,0 +1,31 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNeo4jSchemaStatementsAreIdempotent(t *testing.T) {
+	names := make(map[string]bool, len(neo4jSchema))
+	for _, stmt := range neo4jSchema {
+		if names[stmt.Name] {
+			t.Errorf("schema statement name %q is used twice", stmt.Name)
+		}
+		names[stmt.Name] = true
+
+		// Startup runs every statement against graphs that may already have it
+		if !strings.Contains(stmt.Cypher, "IF NOT EXISTS") {
+			t.Errorf("%s is not idempotent: %s", stmt.Name, stmt.Cypher)
+		}
+		if strings.Contains(stmt.Cypher, "IS UNIQUE") != (stmt.Duplicates != "") {
+			t.Errorf("%s: uniqueness constraints, and only they, need a duplicate check", stmt.Name)
+		}
+	}
+
+	// The MERGE keys the lineage sync relies on are constrained
+	for _, name := range []string{"system_id_unique", "asset_id_unique", "pii_category_type_unique", "org_unit_id_unique", "s3_prefix_id_unique"} {
+		if !names[name] {
+			t.Errorf("missing constraint %s", name)
+		}
+	}
+}
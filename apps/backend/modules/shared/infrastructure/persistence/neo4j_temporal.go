@@ -6,19 +6,34 @@ import (
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
 )
 
+// ExposureWindow is the period an asset exposed a PII type, taken from an EXPOSES edge.
+// Finding count and confidence are as of the window's last sync.
+type ExposureWindow struct {
+	PIIType       string     `json:"pii_type"`
+	DPDPACategory string     `json:"dpdpa_category,omitempty"`
+	ValidFrom     time.Time  `json:"valid_from"`
+	ValidTo       *time.Time `json:"valid_to,omitempty"` // Nil while the exposure is ongoing
+	FindingCount  int64      `json:"finding_count"`
+	AvgConfidence float64    `json:"avg_confidence"`
+}
+
 // CreateTemporalExposesRelationship creates a temporal EXPOSES relationship
 // This implements the immutable lineage model with exposure windows
 func (r *Neo4jRepository) CreateTemporalExposesRelationship(ctx context.Context, assetID, piiType string, findingCount int, avgConfidence float64) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		// Check if an active EXPOSES edge already exists (until IS NULL)
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		// Check if an active EXPOSES edge already exists (valid_to IS NULL)
 		checkQuery := `
 			MATCH (a:Asset {id: $assetID})-[r:EXPOSES]->(p:PII_Category {pii_type: $piiType})
-			WHERE r.until IS NULL
+			WHERE r.valid_to IS NULL
 			RETURN r
 		`
 		checkResult, err := tx.Run(ctx, checkQuery, map[string]interface{}{
@@ -33,7 +48,7 @@ func (r *Neo4jRepository) CreateTemporalExposesRelationship(ctx context.Context,
 		if checkResult.Next(ctx) {
 			updateQuery := `
 				MATCH (a:Asset {id: $assetID})-[r:EXPOSES]->(p:PII_Category {pii_type: $piiType})
-				WHERE r.until IS NULL
+				WHERE r.valid_to IS NULL
 				SET r.finding_count = $findingCount,
 				    r.avg_confidence = $avgConfidence,
 				    r.last_updated = datetime()
@@ -48,13 +63,13 @@ func (r *Neo4jRepository) CreateTemporalExposesRelationship(ctx context.Context,
 			return nil, err
 		}
 
-		// No active edge exists, create a new one with temporal properties
+		// No active edge exists, open a new exposure window
 		createQuery := `
 			MATCH (a:Asset {id: $assetID})
 			MATCH (p:PII_Category {pii_type: $piiType})
 			CREATE (a)-[r:EXPOSES {
-				since: datetime(),
-				until: null,
+				valid_from: datetime(),
+				valid_to: null,
 				finding_count: $findingCount,
 				avg_confidence: $avgConfidence,
 				first_detected: datetime(),
@@ -70,21 +85,25 @@ func (r *Neo4jRepository) CreateTemporalExposesRelationship(ctx context.Context,
 		})
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
 
-// CloseExposureWindow closes an exposure window by setting the 'until' timestamp
+// CloseExposureWindow closes an exposure window by setting the 'valid_to' timestamp
 // This is called when PII is no longer detected in an asset
 func (r *Neo4jRepository) CloseExposureWindow(ctx context.Context, assetID, piiType string, closedAt time.Time) error {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: "neo4j"})
-	defer session.Close(ctx)
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return err
+	}
+	defer done()
 
-	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
 			MATCH (a:Asset {id: $assetID})-[r:EXPOSES]->(p:PII_Category {pii_type: $piiType})
-			WHERE r.until IS NULL
-			SET r.until = $closedAt
+			WHERE r.valid_to IS NULL
+			SET r.valid_to = $closedAt
 			RETURN r
 		`
 		_, err := tx.Run(ctx, query, map[string]interface{}{
@@ -94,6 +113,224 @@ func (r *Neo4jRepository) CloseExposureWindow(ctx context.Context, assetID, piiT
 		})
 		return nil, err
 	})
+	r.breaker.Record(err)
 
 	return err
 }
+
+// CloseStaleExposures closes the asset's open exposure windows for PII types that are
+// no longer detected, and returns how many were closed
+func (r *Neo4jRepository) CloseStaleExposures(ctx context.Context, assetID string, activePIITypes []string, closedAt time.Time) (int, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeWrite)
+	if err != nil {
+		return 0, err
+	}
+	defer done()
+
+	if activePIITypes == nil {
+		activePIITypes = []string{}
+	}
+
+	closed, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (a:Asset {id: $assetID})-[r:EXPOSES]->(p:PII_Category)
+			WHERE r.valid_to IS NULL AND NOT p.pii_type IN $activePIITypes
+			SET r.valid_to = $closedAt
+			RETURN count(r) AS closed
+		`
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"assetID":        assetID,
+			"activePIITypes": activePIITypes,
+			"closedAt":       closedAt,
+		})
+		if err != nil {
+			return 0, err
+		}
+		record, err := result.Single(ctx)
+		if err != nil {
+			return 0, err
+		}
+		count, _ := record.Values[0].(int64)
+		return int(count), nil
+	})
+	r.breaker.Record(err)
+
+	if err != nil {
+		return 0, err
+	}
+	return closed.(int), nil
+}
+
+// GetSemanticGraphAt rebuilds the 3-level hierarchy as it stood at a point in time.
+// Only assets with an exposure window open at that time are included.
+func (r *Neo4jRepository) GetSemanticGraphAt(ctx context.Context, at time.Time, systemFilter string) ([]Node, []Edge, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer done()
+
+	nodes := []Node{}
+	edges := []Edge{}
+	nodeMap := make(map[string]bool)
+	edgeMap := make(map[string]bool)
+
+	_, err = session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (asset:Asset)-[e:EXPOSES]->(pii:PII_Category)
+			WHERE e.valid_from <= $at AND (e.valid_to IS NULL OR e.valid_to > $at)
+			OPTIONAL MATCH (sys:System)-[:SYSTEM_OWNS_ASSET]->(asset)
+			WITH sys, asset, e, pii
+			WHERE $systemFilter = '' OR sys.host = $systemFilter
+			RETURN sys, asset, e, pii
+			ORDER BY sys.host, asset.name
+			LIMIT 5000
+		`
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"at":           at,
+			"systemFilter": systemFilter,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		addNode := func(node Node, ok bool) {
+			if ok && !nodeMap[node.ID] {
+				nodes = append(nodes, node)
+				nodeMap[node.ID] = true
+			}
+		}
+
+		for _, record := range records {
+			sysVal, _ := record.Get("sys")
+			assetVal, _ := record.Get("asset")
+			edgeVal, _ := record.Get("e")
+			piiVal, _ := record.Get("pii")
+
+			sysNode, hasSystem := systemGraphNode(sysVal)
+			assetNode, hasAsset := assetGraphNode(assetVal)
+			piiNode, hasPII := piiCategoryGraphNode(piiVal)
+			addNode(sysNode, hasSystem)
+			addNode(assetNode, hasAsset)
+			addNode(piiNode, hasPII)
+
+			if hasSystem && hasAsset {
+				edgeID := fmt.Sprintf("%s-SYSTEM_OWNS_ASSET-%s", sysNode.ID, assetNode.ID)
+				if !edgeMap[edgeID] {
+					edges = append(edges, Edge{
+						ID:     edgeID,
+						Source: sysNode.ID,
+						Target: assetNode.ID,
+						Type:   "SYSTEM_OWNS_ASSET",
+						Label:  "owns",
+					})
+					edgeMap[edgeID] = true
+				}
+			}
+
+			if hasAsset && hasPII {
+				metadata := map[string]interface{}{}
+				if rel, ok := edgeVal.(neo4j.Relationship); ok {
+					window := exposureWindowFromProps(rel.Props)
+					metadata["valid_from"] = window.ValidFrom
+					metadata["valid_to"] = window.ValidTo
+					metadata["finding_count"] = window.FindingCount
+					metadata["avg_confidence"] = window.AvgConfidence
+				}
+				edges = append(edges, Edge{
+					ID:       fmt.Sprintf("%s-EXPOSES-%s", assetNode.ID, piiNode.ID),
+					Source:   assetNode.ID,
+					Target:   piiNode.ID,
+					Type:     "EXPOSES",
+					Label:    "contains",
+					Metadata: metadata,
+				})
+			}
+		}
+
+		return nil, nil
+	})
+	r.breaker.Record(err)
+
+	if err != nil {
+		return nil, nil, err
+	}
+	return nodes, edges, nil
+}
+
+// GetAssetExposureHistory returns every exposure window of an asset, newest first
+func (r *Neo4jRepository) GetAssetExposureHistory(ctx context.Context, assetID string) ([]ExposureWindow, error) {
+	ctx, session, done, err := r.openSession(ctx, neo4j.AccessModeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := `
+			MATCH (a:Asset {id: $assetID})-[e:EXPOSES]->(p:PII_Category)
+			WHERE e.valid_from IS NOT NULL
+			RETURN p.pii_type AS pii_type, p.dpdpa_category AS dpdpa_category, e
+			ORDER BY e.valid_from DESC
+		`
+		result, err := tx.Run(ctx, query, map[string]interface{}{
+			"assetID": assetID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		records, err := result.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		windows := []ExposureWindow{}
+		for _, record := range records {
+			rel, ok := record.Values[2].(neo4j.Relationship)
+			if !ok {
+				continue
+			}
+			window := exposureWindowFromProps(rel.Props)
+			window.PIIType, _ = record.Values[0].(string)
+			window.DPDPACategory, _ = record.Values[1].(string)
+			windows = append(windows, window)
+		}
+		return windows, nil
+	})
+	r.breaker.Record(err)
+
+	if err != nil {
+		return nil, err
+	}
+	return result.([]ExposureWindow), nil
+}
+
+func exposureWindowFromProps(props map[string]interface{}) ExposureWindow {
+	window := ExposureWindow{}
+	if validFrom := neo4jTime(props["valid_from"]); validFrom != nil {
+		window.ValidFrom = *validFrom
+	}
+	window.ValidTo = neo4jTime(props["valid_to"])
+	window.FindingCount, _ = props["finding_count"].(int64)
+	window.AvgConfidence, _ = props["avg_confidence"].(float64)
+	return window
+}
+
+// neo4jTime converts a temporal property to a time, or nil when it is unset
+func neo4jTime(v interface{}) *time.Time {
+	switch t := v.(type) {
+	case time.Time:
+		return &t
+	case dbtype.LocalDateTime:
+		converted := t.Time()
+		return &converted
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,464 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Organizational Unit Repository Implementation
+// ============================================================================
+
+var (
+	ErrOrgUnitNotFound = errors.New("org unit not found")
+	ErrOrgUnitExists   = errors.New("an org unit with this name already exists under the same parent")
+	ErrOrgUnitHasTeams = errors.New("department still has teams; delete or move them first")
+)
+
+// orgUnitScopeKey is the context key of the unit a request's finding reads are
+// limited to
+const orgUnitScopeKey = "org_unit_scope"
+
+// WithOrgUnitScope limits the finding reads made with ctx to assets owned by the
+// unit or, for a department, by its teams
+func WithOrgUnitScope(ctx context.Context, orgUnitID uuid.UUID) context.Context {
+	return context.WithValue(ctx, orgUnitScopeKey, orgUnitID)
+}
+
+// OrgUnitScope returns the unit finding reads made with ctx are limited to
+func OrgUnitScope(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(orgUnitScopeKey).(uuid.UUID)
+	return id, ok && id != uuid.Nil
+}
+
+// orgUnitAssetCondition matches an asset ID column against the assets of the
+// unit given as parameter arg, including those of its teams
+func orgUnitAssetCondition(column string, arg int) string {
+	return fmt.Sprintf(`%s IN (
+		SELECT oa.id FROM assets oa JOIN org_units ou ON ou.id = oa.org_unit_id
+		WHERE ou.id = $%d::uuid OR ou.parent_id = $%d::uuid)`, column, arg, arg)
+}
+
+// appendOrgUnitFilters limits a findings query, with findings aliased f, to the
+// requested unit and to the unit ctx is scoped to
+func appendOrgUnitFilters(ctx context.Context, query string, args []interface{}, orgUnitID *uuid.UUID) (string, []interface{}) {
+	if orgUnitID != nil {
+		args = append(args, *orgUnitID)
+		query += " AND " + orgUnitAssetCondition("f.asset_id", len(args))
+	}
+	if scope, ok := OrgUnitScope(ctx); ok {
+		args = append(args, scope)
+		query += " AND " + orgUnitAssetCondition("f.asset_id", len(args))
+	}
+	return query, args
+}
+
+// orgUnitReason is the lineage outbox reason of syncs queued by unit changes
+const orgUnitReason = "org unit change"
+
+const orgUnitColumns = `
+	u.id, u.tenant_id, u.parent_id, u.kind, u.name, COALESCE(u.description, ''), u.created_by,
+	(SELECT COUNT(*) FROM assets a WHERE a.org_unit_id = u.id AND a.deleted_at IS NULL),
+	(SELECT COUNT(*) FROM connections c WHERE c.org_unit_id = u.id),
+	(SELECT COUNT(*) FROM users us WHERE us.org_unit_id = u.id),
+	u.created_at, u.updated_at`
+
+func scanOrgUnit(row interface{ Scan(...interface{}) error }) (*entity.OrgUnit, error) {
+	unit := &entity.OrgUnit{}
+	err := row.Scan(&unit.ID, &unit.TenantID, &unit.ParentID, &unit.Kind, &unit.Name, &unit.Description,
+		&unit.CreatedBy, &unit.Assets, &unit.Connections, &unit.Users, &unit.CreatedAt, &unit.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
+// CreateOrgUnit stores a department, or a team under a department of the same tenant
+func (r *PostgresRepository) CreateOrgUnit(ctx context.Context, unit *entity.OrgUnit) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if unit.ID == uuid.Nil {
+		unit.ID = uuid.New()
+	}
+	unit.TenantID = tenantID
+
+	// The parent must be a department of the tenant; a team parent inserts nothing
+	query := `
+		INSERT INTO org_units (id, tenant_id, parent_id, kind, name, description, created_by)
+		SELECT $1, $2, $3::uuid, $4, $5, NULLIF($6, ''), $7
+		WHERE $3::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM org_units WHERE id = $3::uuid AND tenant_id = $2 AND kind = 'department')
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query, unit.ID, tenantID, unit.ParentID, unit.Kind, unit.Name,
+		unit.Description, unit.CreatedBy).Scan(&unit.CreatedAt, &unit.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: parent department %s", ErrOrgUnitNotFound, unit.ParentID)
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrOrgUnitExists
+		}
+		return fmt.Errorf("failed to create org unit: %w", err)
+	}
+	return nil
+}
+
+// GetOrgUnit retrieves a unit of the tenant
+func (r *PostgresRepository) GetOrgUnit(ctx context.Context, id uuid.UUID) (*entity.OrgUnit, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unit, err := scanOrgUnit(r.db.QueryRowContext(ctx,
+		`SELECT `+orgUnitColumns+` FROM org_units u WHERE u.id = $1 AND u.tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, ErrOrgUnitNotFound
+	}
+	return unit, err
+}
+
+// ListOrgUnits returns the tenant's units, each department followed by its teams
+func (r *PostgresRepository) ListOrgUnits(ctx context.Context) ([]*entity.OrgUnit, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+orgUnitColumns+`
+		FROM org_units u
+		LEFT JOIN org_units p ON p.id = u.parent_id
+		WHERE u.tenant_id = $1
+		ORDER BY LOWER(COALESCE(p.name, u.name)), u.parent_id NULLS FIRST, LOWER(u.name)`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org units: %w", err)
+	}
+	defer rows.Close()
+
+	units := []*entity.OrgUnit{}
+	for rows.Next() {
+		unit, err := scanOrgUnit(rows)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, unit)
+	}
+	return units, rows.Err()
+}
+
+// UpdateOrgUnit renames a unit or changes its description. Its assets and those
+// of its teams are queued for a lineage sync so the graph shows the new name.
+func (r *PostgresRepository) UpdateOrgUnit(ctx context.Context, unit *entity.OrgUnit) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE org_units SET name = $1, description = NULLIF($2, ''), updated_at = NOW()
+		WHERE id = $3 AND tenant_id = $4
+		RETURNING updated_at`, unit.Name, unit.Description, unit.ID, tenantID).Scan(&unit.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrOrgUnitNotFound
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrOrgUnitExists
+		}
+		return fmt.Errorf("failed to update org unit: %w", err)
+	}
+
+	if err := r.enqueueOrgUnitAssets(ctx, tx, tenantID, unit.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteOrgUnit removes a unit. Its assets, connections and users are left in no
+// unit and its assets are queued for a lineage sync. A department with teams is
+// refused.
+func (r *PostgresRepository) DeleteOrgUnit(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var teams int
+	err = tx.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM org_units t WHERE t.parent_id = u.id)
+		FROM org_units u WHERE u.id = $1 AND u.tenant_id = $2
+		FOR UPDATE`, id, tenantID).Scan(&teams)
+	if err == sql.ErrNoRows {
+		return ErrOrgUnitNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load org unit: %w", err)
+	}
+	if teams > 0 {
+		return ErrOrgUnitHasTeams
+	}
+
+	if err := r.enqueueOrgUnitAssets(ctx, tx, tenantID, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM org_units WHERE id = $1 AND tenant_id = $2`, id, tenantID); err != nil {
+		return fmt.Errorf("failed to delete org unit: %w", err)
+	}
+	return tx.Commit()
+}
+
+// enqueueOrgUnitAssets queues a lineage sync for the assets of a unit and its teams
+func (r *PostgresRepository) enqueueOrgUnitAssets(ctx context.Context, tx *sql.Tx, tenantID, id uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO lineage_sync_outbox (tenant_id, asset_id, reason)
+		SELECT $1, a.id, $3 FROM assets a
+		WHERE a.tenant_id = $1 AND a.deleted_at IS NULL AND `+orgUnitAssetCondition("a.id", 2)+`
+		ON CONFLICT (asset_id) DO UPDATE
+		SET reason = EXCLUDED.reason, requested_at = CURRENT_TIMESTAMP`, tenantID, id, orgUnitReason)
+	if err != nil {
+		return fmt.Errorf("failed to queue lineage sync for org unit assets: %w", err)
+	}
+	return nil
+}
+
+// AssignOrgUnit moves assets, connections and users into a unit, or out of any
+// unit when the assignment has no unit. Assets scanned by an assigned connection
+// follow it unless they were assigned directly; an asset scanned through
+// connections in different units ends up in the last one assigned. Every asset
+// that moved is queued for a lineage sync.
+func (r *PostgresRepository) AssignOrgUnit(ctx context.Context, assignment *entity.OrgUnitAssignment) (*entity.OrgUnitAssignmentResult, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	unitID := assignment.OrgUnitID
+	if unitID != nil {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM org_units WHERE id = $1 AND tenant_id = $2)`,
+			*unitID, tenantID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to load org unit: %w", err)
+		}
+		if !exists {
+			return nil, ErrOrgUnitNotFound
+		}
+	}
+
+	result := &entity.OrgUnitAssignmentResult{}
+	var moved []uuid.UUID
+
+	if len(assignment.AssetIDs) > 0 {
+		// A direct assignment is manual even when it clears the unit, so a later
+		// connection assignment does not pull the asset back in
+		ids, err := queryIDs(ctx, tx, `
+			UPDATE assets SET org_unit_id = $1::uuid, org_unit_source = $2, updated_at = NOW()
+			WHERE tenant_id = $3 AND id = ANY($4::uuid[]) AND deleted_at IS NULL
+			RETURNING id`, unitID, entity.OrgUnitSourceManual, tenantID, pq.Array(uuidStrings(assignment.AssetIDs)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign assets: %w", err)
+		}
+		result.Assets = len(ids)
+		moved = append(moved, ids...)
+	}
+
+	if len(assignment.ConnectionIDs) > 0 {
+		ids, err := queryIDs(ctx, tx, `
+			UPDATE connections SET org_unit_id = $1::uuid, updated_at = NOW()
+			WHERE id = ANY($2::uuid[])
+			RETURNING id`, unitID, pq.Array(uuidStrings(assignment.ConnectionIDs)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign connections: %w", err)
+		}
+		result.Connections = len(ids)
+
+		inherited, err := queryIDs(ctx, tx, `
+			UPDATE assets a SET org_unit_id = $1::uuid,
+				org_unit_source = CASE WHEN $1::uuid IS NULL THEN NULL ELSE $2 END, updated_at = NOW()
+			WHERE a.tenant_id = $3 AND a.deleted_at IS NULL
+			  AND a.org_unit_source IS DISTINCT FROM 'manual'
+			  AND a.org_unit_id IS DISTINCT FROM $1::uuid
+			  AND a.id IN (
+				SELECT f.asset_id FROM findings f JOIN scan_runs sr ON sr.id = f.scan_run_id
+				WHERE sr.connection_id = ANY($4::uuid[]) AND f.tenant_id = $3)
+			RETURNING a.id`, unitID, entity.OrgUnitSourceConnection, tenantID, pq.Array(uuidStrings(ids)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to move assets scanned by assigned connections: %w", err)
+		}
+		result.InheritedAssets = len(inherited)
+		moved = append(moved, inherited...)
+	}
+
+	if len(assignment.UserIDs) > 0 {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE users SET org_unit_id = $1::uuid, updated_at = NOW()
+			WHERE tenant_id = $2 AND id = ANY($3::uuid[])`, unitID, tenantID, pq.Array(uuidStrings(assignment.UserIDs)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign users: %w", err)
+		}
+		users, _ := res.RowsAffected()
+		result.Users = int(users)
+	}
+
+	if err := enqueueLineageSyncs(ctx, tx, tenantID, moved, orgUnitReason); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// InheritConnectionOrgUnit moves the assets a scan run found into the unit of the
+// connection it scanned, unless they were assigned directly. It returns how many
+// assets moved; they are queued for a lineage sync.
+func (r *PostgresRepository) InheritConnectionOrgUnit(ctx context.Context, scanRunID uuid.UUID) (int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	ids, err := queryIDs(ctx, tx, `
+		UPDATE assets a SET org_unit_id = c.org_unit_id, org_unit_source = $3, updated_at = NOW()
+		FROM scan_runs sr JOIN connections c ON c.id = sr.connection_id
+		WHERE sr.id = $1 AND c.org_unit_id IS NOT NULL
+		  AND a.tenant_id = $2 AND a.deleted_at IS NULL
+		  AND a.org_unit_source IS DISTINCT FROM 'manual'
+		  AND a.org_unit_id IS DISTINCT FROM c.org_unit_id
+		  AND a.id IN (SELECT asset_id FROM findings WHERE scan_run_id = $1 AND tenant_id = $2)
+		RETURNING a.id`, scanRunID, tenantID, entity.OrgUnitSourceConnection)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move scanned assets into their connection's org unit: %w", err)
+	}
+	if err := enqueueLineageSyncs(ctx, tx, tenantID, ids, orgUnitReason); err != nil {
+		return 0, err
+	}
+	return len(ids), tx.Commit()
+}
+
+// GetOrgUnitRollup aggregates asset risk and finding severities per unit of the
+// given kind. Findings under an active risk waiver are counted apart from the
+// severities. A department's row includes its teams' assets; a row without a unit
+// covers assets in none and is only returned to unscoped callers. A scoped
+// caller sees the rows of its own unit and the units under it.
+func (r *PostgresRepository) GetOrgUnitRollup(ctx context.Context, kind string, highRiskThreshold int) (rollup []*entity.OrgUnitRollup, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, scoped := OrgUnitScope(ctx)
+	query := `
+		WITH units AS (
+			SELECT u.id, u.parent_id, u.kind, u.name FROM org_units u
+			WHERE u.tenant_id = $1 AND u.kind = $2
+			  AND ($4::uuid IS NULL OR u.id = $4::uuid OR u.parent_id = $4::uuid)
+			UNION ALL
+			SELECT NULL, NULL, '', 'Unassigned' WHERE $4::uuid IS NULL
+		),
+		asset_findings AS (
+			SELECT f.asset_id,
+				COUNT(*) FILTER (WHERE fw.finding_id IS NULL AND LOWER(f.severity) = 'critical') AS critical,
+				COUNT(*) FILTER (WHERE fw.finding_id IS NULL AND LOWER(f.severity) = 'high') AS high,
+				COUNT(*) FILTER (WHERE fw.finding_id IS NULL AND LOWER(f.severity) = 'medium') AS medium,
+				COUNT(*) FILTER (WHERE fw.finding_id IS NULL AND LOWER(f.severity) = 'low') AS low,
+				COUNT(fw.finding_id) AS waived
+			FROM findings f
+			LEFT JOIN finding_waivers fw ON fw.finding_id = f.id
+			WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+			GROUP BY f.asset_id
+		)
+		SELECT u.id, u.parent_id, u.kind, u.name,
+			COUNT(a.id),
+			COUNT(a.id) FILTER (WHERE a.risk_score >= $3),
+			COALESCE(MAX(a.risk_score), 0),
+			COALESCE(AVG(a.risk_score), 0),
+			COALESCE(SUM(a.total_findings), 0),
+			COALESCE(SUM(af.critical), 0), COALESCE(SUM(af.high), 0),
+			COALESCE(SUM(af.medium), 0), COALESCE(SUM(af.low), 0), COALESCE(SUM(af.waived), 0)
+		FROM units u
+		LEFT JOIN assets a ON a.tenant_id = $1 AND a.deleted_at IS NULL AND (
+			(u.id IS NULL AND a.org_unit_id IS NULL) OR
+			a.org_unit_id IN (SELECT c.id FROM org_units c WHERE c.id = u.id OR c.parent_id = u.id))
+		LEFT JOIN asset_findings af ON af.asset_id = a.id
+		GROUP BY u.id, u.parent_id, u.kind, u.name
+		ORDER BY COALESCE(MAX(a.risk_score), 0) DESC, u.name`
+
+	var scopeArg *uuid.UUID
+	if scoped {
+		scopeArg = &scope
+	}
+
+	ctx, done := beginQuery(ctx, QueryReport, "org_unit_rollup")
+	defer func() { done(err) }()
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, kind, highRiskThreshold, scopeArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build org unit rollup: %w", err)
+	}
+	defer rows.Close()
+
+	rollup = []*entity.OrgUnitRollup{}
+	for rows.Next() {
+		row := &entity.OrgUnitRollup{}
+		if err := rows.Scan(&row.OrgUnitID, &row.ParentID, &row.Kind, &row.Name, &row.Assets, &row.HighRiskAssets,
+			&row.MaxRiskScore, &row.AvgRiskScore, &row.TotalFindings, &row.CriticalFindings, &row.HighFindings,
+			&row.MediumFindings, &row.LowFindings, &row.WaivedFindings); err != nil {
+			return nil, err
+		}
+		rollup = append(rollup, row)
+	}
+	return rollup, rows.Err()
+}
+
+// queryIDs runs a statement returning one UUID column and collects it
+func queryIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
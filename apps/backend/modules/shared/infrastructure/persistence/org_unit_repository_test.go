@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountFindings_LimitedToOrgUnitScope(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID, department, team := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	// Unscoped callers are not filtered by unit
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT f.id\)`).
+		WithArgs(tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	count, err := repo.CountFindings(ctx, repository.FindingFilters{})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, count)
+
+	// A requested unit and the caller's scope both apply
+	mock.ExpectQuery(`f.asset_id IN \(\s+SELECT oa.id FROM assets oa JOIN org_units ou ON ou.id = oa.org_unit_id\s+WHERE ou.id = \$3::uuid OR ou.parent_id = \$3::uuid\)`).
+		WithArgs(tenantID, team, department).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	count, err = repo.CountFindings(WithOrgUnitScope(ctx, department), repository.FindingFilters{OrgUnitID: &team})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrgUnitScope(t *testing.T) {
+	_, scoped := OrgUnitScope(context.Background())
+	assert.False(t, scoped)
+
+	_, scoped = OrgUnitScope(WithOrgUnitScope(context.Background(), uuid.Nil))
+	assert.False(t, scoped, "the nil unit is no scope")
+
+	id := uuid.New()
+	got, scoped := OrgUnitScope(WithOrgUnitScope(context.Background(), id))
+	assert.True(t, scoped)
+	assert.Equal(t, id, got)
+}
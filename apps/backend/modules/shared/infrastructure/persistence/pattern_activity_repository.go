@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Pattern Activity Repository Implementation
+// ============================================================================
+
+// GetPatternActivity counts the tenant's findings created since the given time per
+// pattern and period ("day" or "week"), with their confirmed and false positive
+// verdicts and confidence scores. As for FP rule suggestions, only the latest
+// feedback per finding counts.
+func (r *PostgresRepository) GetPatternActivity(ctx context.Context, since time.Time, period string) ([]entity.PatternActivity, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if period != "day" && period != "week" {
+		return nil, fmt.Errorf("invalid pattern activity period %q", period)
+	}
+
+	query := `
+		WITH verdicts AS (
+			SELECT DISTINCT ON (fb.finding_id) fb.finding_id, fb.feedback_type
+			FROM finding_feedback fb
+			JOIN findings f ON f.id = fb.finding_id
+			WHERE f.tenant_id = $1 AND f.created_at >= $2
+				AND fb.feedback_type IN ('CONFIRMED', 'FALSE_POSITIVE')
+			ORDER BY fb.finding_id, fb.created_at DESC
+		)
+		SELECT f.pattern_name, date_trunc($3, f.created_at), COUNT(*),
+			COUNT(*) FILTER (WHERE v.feedback_type = 'CONFIRMED'),
+			COUNT(*) FILTER (WHERE v.feedback_type = 'FALSE_POSITIVE'),
+			COALESCE(SUM(f.confidence_score), 0)::float8, COUNT(f.confidence_score),
+			COALESCE(SUM(f.confidence_score) FILTER (WHERE v.feedback_type = 'CONFIRMED'), 0)::float8,
+			COUNT(f.confidence_score) FILTER (WHERE v.feedback_type = 'CONFIRMED'),
+			COALESCE(SUM(f.confidence_score) FILTER (WHERE v.feedback_type = 'FALSE_POSITIVE'), 0)::float8,
+			COUNT(f.confidence_score) FILTER (WHERE v.feedback_type = 'FALSE_POSITIVE')
+		FROM findings f
+		LEFT JOIN verdicts v ON v.finding_id = f.id
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.created_at >= $2
+		GROUP BY 1, 2
+		ORDER BY 1, 2`
+
+	activity := []entity.PatternActivity{}
+	err = r.scanGroupedCounts(ctx, query, []interface{}{tenantID, since, period}, func(rows *sql.Rows) error {
+		var a entity.PatternActivity
+		if err := rows.Scan(&a.PatternName, &a.Period, &a.Findings, &a.Confirmed, &a.FalsePositives,
+			&a.ConfidenceSum, &a.ConfidenceCount, &a.ConfirmedConfidenceSum, &a.ConfirmedConfidenceCount,
+			&a.FPConfidenceSum, &a.FPConfidenceCount); err != nil {
+			return err
+		}
+		activity = append(activity, a)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pattern activity: %w", err)
+	}
+	return activity, nil
+}
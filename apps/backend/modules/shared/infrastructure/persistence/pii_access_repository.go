@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// PII Access Audit Repository Implementation
+// ============================================================================
+
+// GetTopPIIViewers returns the users who viewed the most findings since the given time
+func (r *PostgresRepository) GetTopPIIViewers(ctx context.Context, since time.Time, limit int) ([]entity.PIIViewer, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.user_id, COALESCE(MAX(a.metadata->>'user_email'), ''),
+			COUNT(DISTINCT a.id), COUNT(f.id), COUNT(DISTINCT f.id),
+			COUNT(DISTINCT a.id) FILTER (WHERE COALESCE(a.metadata->>'purpose', '') = ''),
+			MAX(a.created_at)
+		FROM audit_logs a
+		LEFT JOIN LATERAL jsonb_array_elements_text(COALESCE(a.metadata->'finding_ids', '[]'::jsonb)) AS f(id) ON true
+		WHERE a.tenant_id = $1 AND a.action = $2 AND a.created_at >= $3
+		GROUP BY a.user_id
+		ORDER BY COUNT(f.id) DESC, a.user_id
+		LIMIT $4`, tenantID, entity.AuditActionFindingsViewed, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PII viewers: %w", err)
+	}
+	defer rows.Close()
+
+	viewers := []entity.PIIViewer{}
+	for rows.Next() {
+		var v entity.PIIViewer
+		if err := rows.Scan(&v.UserID, &v.UserEmail, &v.Requests, &v.FindingViews,
+			&v.DistinctFindings, &v.WithoutPurpose, &v.LastAccessAt); err != nil {
+			return nil, err
+		}
+		viewers = append(viewers, v)
+	}
+	return viewers, rows.Err()
+}
+
+// GetDailyPIIAccess returns each user's finding views per day since the given time
+func (r *PostgresRepository) GetDailyPIIAccess(ctx context.Context, since time.Time) ([]entity.PIIAccessDay, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id, COALESCE(MAX(metadata->>'user_email'), ''), date_trunc('day', created_at) AS day,
+			COALESCE(SUM(jsonb_array_length(COALESCE(metadata->'finding_ids', '[]'::jsonb))), 0)
+		FROM audit_logs
+		WHERE tenant_id = $1 AND action = $2 AND created_at >= $3
+		GROUP BY user_id, day
+		ORDER BY user_id, day`, tenantID, entity.AuditActionFindingsViewed, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily PII access: %w", err)
+	}
+	defer rows.Close()
+
+	days := []entity.PIIAccessDay{}
+	for rows.Next() {
+		var d entity.PIIAccessDay
+		if err := rows.Scan(&d.UserID, &d.UserEmail, &d.Day, &d.FindingViews); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// PII Heatmap Repository Implementation
+// ============================================================================
+
+// heatmapDimensionSQL maps the dimensions a heatmap can be grouped on to their columns.
+// A finding's own environment wins over its asset's.
+var heatmapDimensionSQL = map[string]string{
+	"environment": `COALESCE(NULLIF(f.environment, ''), a.environment, '')`,
+	"data_source": `COALESCE(a.data_source, '')`,
+	"host":        `COALESCE(a.host, '')`,
+}
+
+// GetPIIHeatmapCells counts the tenant's PII findings grouped by the given
+// dimensions and PII type, with the highest severity in each group. The PII
+// type comes from the classification sub-category, falling back to the pattern name.
+func (r *PostgresRepository) GetPIIHeatmapCells(ctx context.Context, dimensions []string) ([]entity.PIIHeatmapCell, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[string]bool, len(dimensions))
+	for _, dim := range dimensions {
+		if _, ok := heatmapDimensionSQL[dim]; !ok {
+			return nil, fmt.Errorf("invalid heatmap dimension %q", dim)
+		}
+		grouped[dim] = true
+	}
+
+	// Columns are always environment, data source, host; ungrouped ones select ''
+	selects := make([]string, 0, 3)
+	groups := []string{"4"}
+	for i, dim := range []string{"environment", "data_source", "host"} {
+		if grouped[dim] {
+			selects = append(selects, heatmapDimensionSQL[dim])
+			groups = append(groups, fmt.Sprint(i+1))
+		} else {
+			selects = append(selects, `''`)
+		}
+	}
+
+	query := `
+		SELECT ` + strings.Join(selects, ", ") + `,
+			COALESCE(NULLIF(c.sub_category, ''), f.pattern_name),
+			COUNT(*),
+			COALESCE((ARRAY_AGG(f.severity ORDER BY CASE UPPER(f.severity)
+				WHEN 'CRITICAL' THEN 1 WHEN 'HIGH' THEN 2 WHEN 'MEDIUM' THEN 3 ELSE 4 END))[1], '')
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN LATERAL (
+			SELECT classification_type, sub_category FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+		  AND (c.classification_type IS NULL OR c.classification_type != 'Non-PII')
+		GROUP BY ` + strings.Join(groups, ", ") + `
+		ORDER BY COUNT(*) DESC`
+
+	cells := []entity.PIIHeatmapCell{}
+	err = r.scanGroupedCounts(ctx, query, []interface{}{tenantID}, func(rows *sql.Rows) error {
+		var cell entity.PIIHeatmapCell
+		if err := rows.Scan(&cell.Environment, &cell.DataSource, &cell.Host, &cell.PIIType, &cell.FindingCount, &cell.MaxSeverity); err != nil {
+			return err
+		}
+		cells = append(cells, cell)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PII heatmap: %w", err)
+	}
+	return cells, nil
+}
@@ -0,0 +1,334 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Policy Rule Repository Implementation
+// ============================================================================
+
+const policyRuleColumns = `p.id, p.tenant_id, p.name, COALESCE(p.description, ''), p.expression, p.severity,
+	COALESCE(p.webhook_url, ''), p.webhook_secret_encrypted, p.is_active, p.next_evaluation_at, p.last_evaluated_at,
+	(SELECT COUNT(*) FROM policy_violations v WHERE v.rule_id = p.id AND v.status = 'open'),
+	p.created_by, p.created_at, p.updated_at`
+
+// CreatePolicyRule stores a new policy rule for the tenant
+func (r *PostgresRepository) CreatePolicyRule(ctx context.Context, rule *entity.PolicyRule) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	rule.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO policy_rules (id, tenant_id, name, description, expression, severity, webhook_url,
+			webhook_secret_encrypted, is_active, next_evaluation_at, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''), $8, $9, $10, $11)
+		RETURNING created_at, updated_at`,
+		rule.ID, rule.TenantID, rule.Name, rule.Description, rule.Expression, rule.Severity, rule.WebhookURL,
+		rule.WebhookSecretEncrypted, rule.IsActive, rule.NextEvaluationAt, rule.CreatedBy,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("policy rule %q already exists", rule.Name)
+		}
+		return fmt.Errorf("failed to create policy rule: %w", err)
+	}
+	return nil
+}
+
+// GetPolicyRule retrieves a policy rule by ID
+func (r *PostgresRepository) GetPolicyRule(ctx context.Context, id uuid.UUID) (*entity.PolicyRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := scanPolicyRule(r.db.QueryRowContext(ctx,
+		`SELECT `+policyRuleColumns+` FROM policy_rules p WHERE p.id = $1 AND p.tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("policy rule not found")
+	}
+	return rule, err
+}
+
+// ListPolicyRules retrieves all policy rules of the tenant
+func (r *PostgresRepository) ListPolicyRules(ctx context.Context) ([]*entity.PolicyRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryPolicyRules(ctx, `SELECT `+policyRuleColumns+` FROM policy_rules p WHERE p.tenant_id = $1 ORDER BY p.name`, tenantID)
+}
+
+// ListDuePolicyRules retrieves the active rules of every tenant whose next evaluation is at or before now
+func (r *PostgresRepository) ListDuePolicyRules(ctx context.Context, now time.Time) ([]*entity.PolicyRule, error) {
+	return r.queryPolicyRules(ctx, `
+		SELECT `+policyRuleColumns+` FROM policy_rules p
+		WHERE p.is_active AND p.next_evaluation_at <= $1
+		ORDER BY p.tenant_id, p.next_evaluation_at`, now)
+}
+
+// UpdatePolicyRule replaces the definition, webhook, active flag and next evaluation of a rule
+func (r *PostgresRepository) UpdatePolicyRule(ctx context.Context, rule *entity.PolicyRule) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE policy_rules SET name = $1, description = NULLIF($2, ''), expression = $3, severity = $4,
+			webhook_url = NULLIF($5, ''), webhook_secret_encrypted = $6, is_active = $7, next_evaluation_at = $8
+		WHERE id = $9 AND tenant_id = $10`,
+		rule.Name, rule.Description, rule.Expression, rule.Severity, rule.WebhookURL, rule.WebhookSecretEncrypted,
+		rule.IsActive, rule.NextEvaluationAt, rule.ID, tenantID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("policy rule %q already exists", rule.Name)
+		}
+		return fmt.Errorf("failed to update policy rule: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("policy rule not found")
+	}
+	return nil
+}
+
+// DeletePolicyRule deletes a policy rule and its violations
+func (r *PostgresRepository) DeletePolicyRule(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM policy_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("policy rule not found")
+	}
+	return nil
+}
+
+// ClaimPolicyRule moves a due rule's next evaluation from its current value to next.
+// It reports false when another replica already claimed this evaluation.
+func (r *PostgresRepository) ClaimPolicyRule(ctx context.Context, id uuid.UUID, current, next time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE policy_rules SET next_evaluation_at = $3
+		WHERE id = $1 AND next_evaluation_at = $2 AND is_active`,
+		id, current, next)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim policy rule: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// ListPolicyFindingFacts returns up to limit of the tenant's findings with an ID
+// after afterID, in ID order, with the attributes policy expressions are evaluated over.
+// The PII type comes from the classification sub-category, falling back to the pattern name.
+func (r *PostgresRepository) ListPolicyFindingFacts(ctx context.Context, afterID uuid.UUID, limit int) ([]*entity.PolicyFindingFacts, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id, f.asset_id, COALESCE(a.name, ''), COALESCE(a.path, ''), COALESCE(a.data_source, ''),
+			COALESCE(f.environment, ''), f.pattern_name, COALESCE(NULLIF(c.sub_category, ''), f.pattern_name),
+			COALESCE(c.classification_type, ''),
+			COALESCE(f.severity, ''), COALESCE(f.confidence_score, 0), COALESCE(rs.status, 'open'),
+			COALESCE(ra.action_types, '{}'), f.created_at
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN LATERAL (
+			SELECT classification_type, sub_category FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		LEFT JOIN LATERAL (
+			SELECT status FROM review_states
+			WHERE finding_id = f.id
+			ORDER BY updated_at DESC
+			LIMIT 1
+		) rs ON true
+		LEFT JOIN LATERAL (
+			SELECT array_agg(DISTINCT UPPER(action_type)) AS action_types FROM remediation_actions
+			WHERE finding_id = f.id AND status = 'COMPLETED' AND effective_until IS NULL
+		) ra ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND f.id > $2
+		ORDER BY f.id
+		LIMIT $3`, tenantID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy finding facts: %w", err)
+	}
+	defer rows.Close()
+
+	var facts []*entity.PolicyFindingFacts
+	for rows.Next() {
+		f := &entity.PolicyFindingFacts{}
+		if err := rows.Scan(&f.FindingID, &f.AssetID, &f.AssetName, &f.AssetPath, &f.DataSource,
+			&f.Environment, &f.PatternName, &f.PIIType, &f.ClassificationType, &f.Severity, &f.Confidence, &f.ReviewStatus,
+			pq.Array(&f.Remediations), &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		facts = append(facts, f)
+	}
+	return facts, rows.Err()
+}
+
+// RecordPolicyEvaluation stores the outcome of evaluating a rule at evaluatedAt: the
+// violating findings are opened, or kept open, and open violations no longer among
+// them are resolved. It returns the findings whose violation is new or reopened and
+// the number resolved.
+func (r *PostgresRepository) RecordPolicyEvaluation(ctx context.Context, rule *entity.PolicyRule, violating []uuid.UUID, evaluatedAt time.Time) (opened []uuid.UUID, resolved int, err error) {
+	ids := make([]string, len(violating))
+	for i, id := range violating {
+		ids[i] = id.String()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		INSERT INTO policy_violations (tenant_id, rule_id, finding_id, status, first_detected_at, last_evaluated_at)
+		SELECT $1, $2, unnest($3::uuid[]), 'open', $4, $4
+		ON CONFLICT (rule_id, finding_id) DO UPDATE SET
+			status = 'open',
+			last_evaluated_at = EXCLUDED.last_evaluated_at,
+			first_detected_at = CASE WHEN policy_violations.status = 'resolved'
+				THEN EXCLUDED.first_detected_at ELSE policy_violations.first_detected_at END,
+			resolved_at = NULL
+		RETURNING finding_id, first_detected_at = $4::timestamp`,
+		rule.TenantID, rule.ID, pq.Array(ids), evaluatedAt)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to record policy violations: %w", err)
+	}
+	for rows.Next() {
+		var findingID uuid.UUID
+		var isNew bool
+		if err := rows.Scan(&findingID, &isNew); err != nil {
+			rows.Close()
+			return nil, 0, err
+		}
+		if isNew {
+			opened = append(opened, findingID)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE policy_violations SET status = 'resolved', resolved_at = $2
+		WHERE rule_id = $1 AND status = 'open' AND last_evaluated_at <> $2`,
+		rule.ID, evaluatedAt)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to resolve policy violations: %w", err)
+	}
+	n, _ := result.RowsAffected()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE policy_rules SET last_evaluated_at = $2 WHERE id = $1`, rule.ID, evaluatedAt); err != nil {
+		return nil, 0, fmt.Errorf("failed to stamp policy rule: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+	return opened, int(n), nil
+}
+
+// ListPolicyViolations returns the tenant's policy violations matching the filter,
+// newest first, with their total
+func (r *PostgresRepository) ListPolicyViolations(ctx context.Context, filter entity.PolicyViolationFilter) ([]*entity.PolicyViolation, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	const where = `
+		WHERE v.tenant_id = $1
+		  AND ($2::uuid IS NULL OR v.rule_id = $2)
+		  AND ($3 = '' OR v.status = $3)
+		  AND ($4 = '' OR p.severity = $4)`
+
+	var total int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM policy_violations v JOIN policy_rules p ON p.id = v.rule_id`+where,
+		tenantID, filter.RuleID, filter.Status, filter.Severity).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count policy violations: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT v.id, v.tenant_id, v.rule_id, p.name, p.severity, v.finding_id, f.asset_id, COALESCE(a.name, ''),
+			f.pattern_name, COALESCE(f.environment, ''), v.status, v.first_detected_at, v.last_evaluated_at, v.resolved_at
+		FROM policy_violations v
+		JOIN policy_rules p ON p.id = v.rule_id
+		JOIN findings f ON f.id = v.finding_id
+		LEFT JOIN assets a ON a.id = f.asset_id`+where+`
+		ORDER BY v.first_detected_at DESC, v.id
+		LIMIT $5 OFFSET $6`,
+		tenantID, filter.RuleID, filter.Status, filter.Severity, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list policy violations: %w", err)
+	}
+	defer rows.Close()
+
+	violations := []*entity.PolicyViolation{}
+	for rows.Next() {
+		v := &entity.PolicyViolation{}
+		if err := rows.Scan(&v.ID, &v.TenantID, &v.RuleID, &v.RuleName, &v.Severity, &v.FindingID, &v.AssetID,
+			&v.AssetName, &v.PatternName, &v.Environment, &v.Status, &v.FirstDetectedAt, &v.LastEvaluatedAt,
+			&v.ResolvedAt); err != nil {
+			return nil, 0, err
+		}
+		violations = append(violations, v)
+	}
+	return violations, total, rows.Err()
+}
+
+func (r *PostgresRepository) queryPolicyRules(ctx context.Context, query string, args ...interface{}) ([]*entity.PolicyRule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query policy rules: %w", err)
+	}
+	defer rows.Close()
+
+	rules := []*entity.PolicyRule{}
+	for rows.Next() {
+		rule, err := scanPolicyRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func scanPolicyRule(row rowScanner) (*entity.PolicyRule, error) {
+	rule := &entity.PolicyRule{}
+	err := row.Scan(
+		&rule.ID, &rule.TenantID, &rule.Name, &rule.Description, &rule.Expression, &rule.Severity,
+		&rule.WebhookURL, &rule.WebhookSecretEncrypted, &rule.IsActive, &rule.NextEvaluationAt,
+		&rule.LastEvaluatedAt, &rule.OpenViolations, &rule.CreatedBy, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rule.HasWebhookSecret = len(rule.WebhookSecretEncrypted) > 0
+	return rule, nil
+}
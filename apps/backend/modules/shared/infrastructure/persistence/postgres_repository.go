@@ -8,6 +8,7 @@ import (
 	authentity "github.com/arc-platform/backend/modules/auth/entity"
 	fplearningentity "github.com/arc-platform/backend/modules/fplearning/entity"
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
 	"github.com/google/uuid"
 )
 
@@ -22,6 +23,16 @@ type PostgresTransaction struct {
 	db *sql.DB
 }
 
+// Ensure interface compatibility
+var (
+	_ repository.IngestionRepository            = (*PostgresRepository)(nil)
+	_ repository.ClassificationRepository       = (*PostgresRepository)(nil)
+	_ repository.RemediationRepository          = (*PostgresRepository)(nil)
+	_ repository.IdempotencyRepository          = (*PostgresRepository)(nil)
+	_ repository.ShadowClassificationRepository = (*PostgresRepository)(nil)
+	_ repository.Transaction                    = (*PostgresTransaction)(nil)
+)
+
 // NewPostgresRepository creates a new PostgreSQL repository
 func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 	return &PostgresRepository{db: db}
@@ -40,6 +51,15 @@ func (r *PostgresRepository) BeginTx(ctx context.Context) (*PostgresTransaction,
 	}, nil
 }
 
+// BeginTransaction starts a transaction behind the repository.Transaction interface
+func (r *PostgresRepository) BeginTransaction(ctx context.Context) (repository.Transaction, error) {
+	tx, err := r.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
 // Commit commits the transaction
 func (t *PostgresTransaction) Commit() error {
 	return t.tx.Commit()
@@ -125,9 +145,10 @@ func (r *PostgresRepository) GetConnectionByProfile(ctx context.Context, sourceT
 // ListConnections retrieves all connections (without decrypted config)
 func (r *PostgresRepository) ListConnections(ctx context.Context) ([]*entity.Connection, error) {
 	query := `
-		SELECT id, source_type, profile_name, validation_status,
-		       last_validated_at, created_by, created_at, updated_at
-		FROM connections ORDER BY created_at DESC
+		SELECT c.id, c.source_type, c.profile_name, c.validation_status,
+		       c.last_validated_at, c.created_by, c.created_at, c.updated_at,
+		       (SELECT MAX(s.scan_started_at) FROM scan_runs s WHERE ` + connectionScanFilter + `)
+		FROM connections c ORDER BY c.created_at DESC
 	`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
@@ -138,12 +159,16 @@ func (r *PostgresRepository) ListConnections(ctx context.Context) ([]*entity.Con
 	var connections []*entity.Connection
 	for rows.Next() {
 		conn := &entity.Connection{}
+		var lastScannedAt sql.NullTime
 		err := rows.Scan(&conn.ID, &conn.SourceType, &conn.ProfileName,
 			&conn.ValidationStatus, &conn.LastValidatedAt, &conn.CreatedBy,
-			&conn.CreatedAt, &conn.UpdatedAt)
+			&conn.CreatedAt, &conn.UpdatedAt, &lastScannedAt)
 		if err != nil {
 			return nil, err
 		}
+		if lastScannedAt.Valid {
+			conn.LastScannedAt = &lastScannedAt.Time
+		}
 		connections = append(connections, conn)
 	}
 	return connections, rows.Err()
@@ -187,13 +212,13 @@ func (r *PostgresRepository) CreateUser(ctx context.Context, user *authentity.Us
 // GetUserByID retrieves a user by ID
 func (r *PostgresRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*authentity.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, role, tenant_id, is_active, last_login_at, created_at, updated_at
+		SELECT id, email, password_hash, first_name, last_name, role, tenant_id, is_active, org_unit_id, last_login_at, created_at, updated_at
 		FROM users WHERE id = $1
 	`
 	user := &authentity.User{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.FirstName, &user.LastName,
-		&user.Role, &user.TenantID, &user.IsActive, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.Role, &user.TenantID, &user.IsActive, &user.OrgUnitID, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -204,13 +229,13 @@ func (r *PostgresRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*au
 // GetUserByEmail retrieves a user by email
 func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (*authentity.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, role, tenant_id, is_active, last_login_at, created_at, updated_at
+		SELECT id, email, password_hash, first_name, last_name, role, tenant_id, is_active, org_unit_id, last_login_at, created_at, updated_at
 		FROM users WHERE email = $1
 	`
 	user := &authentity.User{}
 	err := r.db.QueryRowContext(ctx, query, email).Scan(
 		&user.ID, &user.Email, &user.PasswordHash, &user.FirstName, &user.LastName,
-		&user.Role, &user.TenantID, &user.IsActive, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
+		&user.Role, &user.TenantID, &user.IsActive, &user.OrgUnitID, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -221,7 +246,7 @@ func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (
 // GetUsersByTenant retrieves all users for a tenant
 func (r *PostgresRepository) GetUsersByTenant(ctx context.Context, tenantID uuid.UUID) ([]*authentity.User, error) {
 	query := `
-		SELECT id, email, password_hash, first_name, last_name, role, tenant_id, is_active, last_login_at, created_at, updated_at
+		SELECT id, email, password_hash, first_name, last_name, role, tenant_id, is_active, org_unit_id, last_login_at, created_at, updated_at
 		FROM users WHERE tenant_id = $1 ORDER BY created_at DESC
 	`
 	rows, err := r.db.QueryContext(ctx, query, tenantID)
@@ -235,7 +260,7 @@ func (r *PostgresRepository) GetUsersByTenant(ctx context.Context, tenantID uuid
 		user := &authentity.User{}
 		err := rows.Scan(
 			&user.ID, &user.Email, &user.PasswordHash, &user.FirstName, &user.LastName,
-			&user.Role, &user.TenantID, &user.IsActive, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
+			&user.Role, &user.TenantID, &user.IsActive, &user.OrgUnitID, &user.LastLoginAt, &user.CreatedAt, &user.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,188 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// QueryClass groups queries that share a time budget
+type QueryClass string
+
+const (
+	QueryInteractive QueryClass = "interactive" // Request-path lookups and paged lists
+	QueryReport      QueryClass = "report"      // Dashboards, aggregates and exports
+	QueryBatch       QueryClass = "batch"       // Background sweeps over whole tables
+)
+
+// QueryBudget bounds one class of queries
+type QueryBudget struct {
+	Timeout       time.Duration // Context deadline and statement_timeout; 0 is unbounded
+	SlowThreshold time.Duration // Queries slower than this are counted and logged; 0 disables
+}
+
+// QueryGuardOptions configures the query budgets and cursor page size
+type QueryGuardOptions struct {
+	Interactive QueryBudget
+	Report      QueryBudget
+	Batch       QueryBudget
+	PageSize    int // Rows per page of cursor iteration
+}
+
+// DefaultQueryGuardOptions are used until ConfigureQueryGuards is called
+var DefaultQueryGuardOptions = QueryGuardOptions{
+	Interactive: QueryBudget{Timeout: 15 * time.Second, SlowThreshold: 500 * time.Millisecond},
+	Report:      QueryBudget{Timeout: 60 * time.Second, SlowThreshold: 5 * time.Second},
+	Batch:       QueryBudget{Timeout: 5 * time.Minute, SlowThreshold: 30 * time.Second},
+	PageSize:    500,
+}
+
+var queryGuards atomic.Pointer[QueryGuardOptions]
+
+// ConfigureQueryGuards replaces the query budgets for every repository. A page size
+// of zero or less keeps the default.
+func ConfigureQueryGuards(opts QueryGuardOptions) {
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultQueryGuardOptions.PageSize
+	}
+	queryGuards.Store(&opts)
+}
+
+func currentQueryGuards() *QueryGuardOptions {
+	if opts := queryGuards.Load(); opts != nil {
+		return opts
+	}
+	return &DefaultQueryGuardOptions
+}
+
+func queryBudget(class QueryClass) QueryBudget {
+	opts := currentQueryGuards()
+	switch class {
+	case QueryReport:
+		return opts.Report
+	case QueryBatch:
+		return opts.Batch
+	default:
+		return opts.Interactive
+	}
+}
+
+var (
+	queryDurationHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of guarded Postgres queries",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 30, 120, 600},
+	}, []string{"class"})
+	slowQueryCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_slow_queries_total",
+		Help: "Guarded Postgres queries slower than their class's soft threshold",
+	}, []string{"class", "query"})
+	queryTimeoutCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_timeouts_total",
+		Help: "Guarded Postgres queries stopped by their deadline or statement_timeout",
+	}, []string{"class", "query"})
+)
+
+// beginQuery bounds a query by its class's timeout. It returns the context to run
+// the query with and a func to call with the query's error once its rows are read,
+// which records the duration and any slow query or timeout.
+func beginQuery(ctx context.Context, class QueryClass, name string) (context.Context, func(error)) {
+	budget := queryBudget(class)
+	cancel := context.CancelFunc(func() {})
+	if budget.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, budget.Timeout)
+	}
+
+	start := time.Now()
+	return ctx, func(err error) {
+		cancel()
+		elapsed := time.Since(start)
+		queryDurationHistogram.WithLabelValues(string(class)).Observe(elapsed.Seconds())
+
+		if isQueryTimeout(err) {
+			queryTimeoutCounter.WithLabelValues(string(class), name).Inc()
+			log.Printf("WARN: %s query %s stopped after %s: %v", class, name, elapsed.Round(time.Millisecond), err)
+			return
+		}
+		if budget.SlowThreshold > 0 && elapsed > budget.SlowThreshold {
+			slowQueryCounter.WithLabelValues(string(class), name).Inc()
+			log.Printf("WARN: Slow %s query %s took %s (threshold %s)", class, name,
+				elapsed.Round(time.Millisecond), budget.SlowThreshold)
+		}
+	}
+}
+
+// isQueryTimeout reports whether err is a context deadline or a query Postgres
+// canceled, which is how statement_timeout surfaces
+func isQueryTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "57014" // query_canceled
+}
+
+// withStatementTimeout runs fn in a transaction whose statement_timeout is the
+// class's timeout, so Postgres stops a runaway query even when the client's
+// cancel request never reaches it
+func (r *PostgresRepository) withStatementTimeout(ctx context.Context, class QueryClass, name string, fn func(ctx context.Context, tx *sql.Tx) error) (err error) {
+	ctx, done := beginQuery(ctx, class, name)
+	defer func() { done(err) }()
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if timeout := queryBudget(class).Timeout; timeout > 0 {
+		ms := strconv.FormatInt(timeout.Milliseconds(), 10)
+		if _, err := tx.ExecContext(ctx, `SELECT set_config('statement_timeout', $1, true)`, ms); err != nil {
+			return fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+	}
+	if err := fn(ctx, tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// iterateByID pages through rows in id order, starting each page after the last
+// id of the previous one. Keyset pages cost the same however deep the sweep goes,
+// unlike OFFSET, and each page is its own bounded query.
+func iterateByID[T any](
+	ctx context.Context,
+	fetch func(ctx context.Context, after uuid.UUID, limit int) ([]T, uuid.UUID, error),
+	fn func(page []T) error,
+) error {
+	pageSize := currentQueryGuards().PageSize
+	after := uuid.Nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, last, err := fetch(ctx, after, pageSize)
+		if err != nil {
+			return err
+		}
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		if len(page) < pageSize {
+			return nil
+		}
+		after = last
+	}
+}
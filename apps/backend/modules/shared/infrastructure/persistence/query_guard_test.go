@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func assetRows(ids ...uuid.UUID) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "tenant_id", "stable_id", "asset_type", "name", "path", "data_source", "host",
+		"environment", "owner", "source_system", "file_metadata", "risk_score", "total_findings",
+		"created_at", "updated_at",
+	})
+	for _, id := range ids {
+		rows.AddRow(id, uuid.Nil, "stable", "file", "asset", "/tmp", "filesystem", "localhost",
+			"prod", "", "scanner", nil, 10, 1, time.Now(), time.Now())
+	}
+	return rows
+}
+
+func TestPostgresRepository_IterateAssets_PagesByID(t *testing.T) {
+	opts := DefaultQueryGuardOptions
+	opts.PageSize = 2
+	opts.Batch.Timeout = 90 * time.Second
+	ConfigureQueryGuards(opts)
+	defer ConfigureQueryGuards(DefaultQueryGuardOptions)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepository(db)
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	query := `SELECT id, tenant_id, .* FROM assets WHERE tenant_id = \$1 AND deleted_at IS NULL AND id > \$2 ORDER BY id LIMIT \$3`
+	for _, page := range []struct {
+		after uuid.UUID
+		rows  *sqlmock.Rows
+	}{
+		{uuid.Nil, assetRows(first, second)},
+		{second, assetRows(third)},
+	} {
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT set_config\('statement_timeout', \$1, true\)`).
+			WithArgs("90000").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(query).WithArgs(tenantID, page.after, 2).WillReturnRows(page.rows)
+		mock.ExpectCommit()
+	}
+
+	var seen []uuid.UUID
+	err = repo.IterateAssets(ctx, func(assets []*entity.Asset) error {
+		for _, a := range assets {
+			seen = append(seen, a.ID)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{first, second, third}, seen)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresRepository_IterateAssets_StopsOnCallbackError(t *testing.T) {
+	opts := DefaultQueryGuardOptions
+	opts.PageSize = 1
+	ConfigureQueryGuards(opts)
+	defer ConfigureQueryGuards(DefaultQueryGuardOptions)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepository(db)
+	ctx := context.WithValue(context.Background(), "tenant_id", uuid.New())
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`set_config`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM assets`).WillReturnRows(assetRows(uuid.New()))
+	mock.ExpectCommit()
+
+	err = repo.IterateAssets(ctx, func([]*entity.Asset) error { return fmt.Errorf("stop") })
+	assert.EqualError(t, err, "stop")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsQueryTimeout(t *testing.T) {
+	assert.True(t, isQueryTimeout(fmt.Errorf("list: %w", context.DeadlineExceeded)))
+	assert.True(t, isQueryTimeout(&pq.Error{Code: "57014"}))
+	assert.False(t, isQueryTimeout(&pq.Error{Code: "23505"}))
+	assert.False(t, isQueryTimeout(nil))
+}
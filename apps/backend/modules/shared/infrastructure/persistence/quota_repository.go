@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Quota Repository Implementation
+// ============================================================================
+
+// GetTenantQuota returns the tenant's quota overrides, or nil
+func (r *PostgresRepository) GetTenantQuota(ctx context.Context) (*entity.TenantQuota, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var maxFindings, maxScanRuns sql.NullInt64
+	var behavior sql.NullString
+	quota := &entity.TenantQuota{}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT max_findings, max_scan_runs_per_day, overage_behavior, updated_by, updated_at
+		FROM tenant_quotas WHERE tenant_id = $1`, tenantID,
+	).Scan(&maxFindings, &maxScanRuns, &behavior, &quota.UpdatedBy, &quota.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant quota: %w", err)
+	}
+	if maxFindings.Valid {
+		v := int(maxFindings.Int64)
+		quota.MaxFindings = &v
+	}
+	if maxScanRuns.Valid {
+		v := int(maxScanRuns.Int64)
+		quota.MaxScanRunsPerDay = &v
+	}
+	if behavior.Valid {
+		quota.OverageBehavior = &behavior.String
+	}
+	return quota, nil
+}
+
+// SetTenantQuota replaces the tenant's quota overrides
+func (r *PostgresRepository) SetTenantQuota(ctx context.Context, quota *entity.TenantQuota) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO tenant_quotas (tenant_id, max_findings, max_scan_runs_per_day, overage_behavior, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET max_findings = EXCLUDED.max_findings, max_scan_runs_per_day = EXCLUDED.max_scan_runs_per_day,
+		    overage_behavior = EXCLUDED.overage_behavior, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, quota.MaxFindings, quota.MaxScanRunsPerDay, quota.OverageBehavior, quota.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant quota: %w", err)
+	}
+	return nil
+}
+
+// CountStoredFindings counts the tenant's findings that are not deleted
+func (r *PostgresRepository) CountStoredFindings(ctx context.Context) (int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM findings WHERE tenant_id = $1 AND deleted_at IS NULL`, tenantID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stored findings: %w", err)
+	}
+	return count, nil
+}
+
+// CountScanRunsSince counts the tenant's scan runs created since the given time and
+// the findings they dropped by downsampling, recorded as metadata.quota_downsampled
+func (r *PostgresRepository) CountScanRunsSince(ctx context.Context, since time.Time) (int, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var runs, downsampled int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM((metadata->>'quota_downsampled')::int), 0)
+		FROM scan_runs WHERE tenant_id = $1 AND created_at >= $2`, tenantID, since,
+	).Scan(&runs, &downsampled)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count scan runs: %w", err)
+	}
+	return runs, downsampled, nil
+}
@@ -0,0 +1,267 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Remediation Batch Repository Implementation
+// ============================================================================
+
+// ErrRemediationBatchFindings is returned when a batch names findings the tenant does not have
+var ErrRemediationBatchFindings = errors.New("remediation batch findings not found")
+
+const remediationBatchColumns = `id, tenant_id, action_type, status, requested_by, total_findings, job_id, error,
+	created_at, started_at, completed_at`
+
+// CreateRemediationBatch records a pending batch for the tenant in ctx with one
+// item per finding, each tagged with the connection and connector type of its
+// asset. Findings the tenant does not have fail the whole batch.
+func (r *PostgresRepository) CreateRemediationBatch(ctx context.Context, batch *entity.RemediationBatch, findingIDs []uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if batch.ID == uuid.Nil {
+		batch.ID = uuid.New()
+	}
+	batch.TenantID = tenantID
+	batch.Status = entity.RemediationBatchPending
+	batch.TotalFindings = len(findingIDs)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO remediation_batches (id, tenant_id, action_type, status, requested_by, total_findings, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING created_at`,
+		batch.ID, tenantID, batch.ActionType, batch.Status, batch.RequestedBy, batch.TotalFindings,
+	).Scan(&batch.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create remediation batch: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO remediation_batch_items (batch_id, finding_id, connection, source_type, status)
+		SELECT $1, f.id, COALESCE(a.source_system, ''), COALESCE(a.data_source, ''), $4
+		FROM findings f
+		JOIN assets a ON a.id = f.asset_id
+		WHERE f.id = ANY($2::uuid[]) AND f.tenant_id = $3 AND f.deleted_at IS NULL`,
+		batch.ID, pq.Array(uuidStrings(findingIDs)), tenantID, entity.RemediationBatchItemPending)
+	if err != nil {
+		return fmt.Errorf("failed to create remediation batch items: %w", err)
+	}
+	if n, _ := result.RowsAffected(); int(n) != len(findingIDs) {
+		return fmt.Errorf("%w: %d of %d", ErrRemediationBatchFindings, len(findingIDs)-int(n), len(findingIDs))
+	}
+
+	return tx.Commit()
+}
+
+// SetRemediationBatchJob links a batch to the job running it
+func (r *PostgresRepository) SetRemediationBatchJob(ctx context.Context, id, jobID uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, `UPDATE remediation_batches SET job_id = $3 WHERE id = $1 AND tenant_id = $2`, id, tenantID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to update remediation batch: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("remediation batch not found")
+	}
+	return nil
+}
+
+// TransitionRemediationBatch moves a batch to status if it is in one of from, and
+// reports whether it was. Starting a batch records when it first ran; completing
+// or failing it records when it ended.
+func (r *PostgresRepository) TransitionRemediationBatch(ctx context.Context, id uuid.UUID, status, message string, from ...string) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE remediation_batches SET status = $3, error = $4,
+			started_at = CASE WHEN $3 = $6 THEN COALESCE(started_at, NOW()) ELSE started_at END,
+			completed_at = CASE WHEN $3 IN ($7, $8) THEN NOW() ELSE completed_at END
+		WHERE id = $1 AND tenant_id = $2 AND status = ANY($5::text[])`,
+		id, tenantID, status, message, pq.Array(from),
+		entity.RemediationBatchRunning, entity.RemediationBatchCompleted, entity.RemediationBatchFailed)
+	if err != nil {
+		return false, fmt.Errorf("failed to update remediation batch: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// GetRemediationBatch returns one of the tenant's batches with its progress per
+// connection, or nil when the tenant has no such batch
+func (r *PostgresRepository) GetRemediationBatch(ctx context.Context, id uuid.UUID) (*entity.RemediationBatch, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := scanRemediationBatch(r.db.QueryRowContext(ctx,
+		`SELECT `+remediationBatchColumns+` FROM remediation_batches WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	batch.Connections = []entity.RemediationBatchConnection{}
+	err = r.scanGroupedCounts(ctx, `
+		SELECT connection, source_type,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = $2),
+			COUNT(*) FILTER (WHERE status = $3),
+			COUNT(*) FILTER (WHERE status = $4)
+		FROM remediation_batch_items
+		WHERE batch_id = $1
+		GROUP BY connection, source_type
+		ORDER BY connection, source_type`,
+		[]interface{}{id, entity.RemediationBatchItemPending, entity.RemediationBatchItemCompleted, entity.RemediationBatchItemFailed},
+		func(rows *sql.Rows) error {
+			var c entity.RemediationBatchConnection
+			if err := rows.Scan(&c.Connection, &c.SourceType, &c.Total, &c.Pending, &c.Completed, &c.Failed); err != nil {
+				return err
+			}
+			batch.Connections = append(batch.Connections, c)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count remediation batch progress: %w", err)
+	}
+	return batch, nil
+}
+
+// GetRemediationBatchStatus returns just the status of one of the tenant's batches,
+// or "" when the tenant has no such batch
+func (r *PostgresRepository) GetRemediationBatchStatus(ctx context.Context, id uuid.UUID) (string, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return "", err
+	}
+	var status string
+	err = r.db.QueryRowContext(ctx, `SELECT status FROM remediation_batches WHERE id = $1 AND tenant_id = $2`, id, tenantID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return status, err
+}
+
+// ListRemediationBatches returns the tenant's batches, newest first, without their progress
+func (r *PostgresRepository) ListRemediationBatches(ctx context.Context, limit int) ([]*entity.RemediationBatch, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+remediationBatchColumns+` FROM remediation_batches
+		WHERE tenant_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2`, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediation batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := []*entity.RemediationBatch{}
+	for rows.Next() {
+		batch, err := scanRemediationBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, rows.Err()
+}
+
+// ListPendingRemediationBatchItems returns the items of a batch not yet run, by connection
+func (r *PostgresRepository) ListPendingRemediationBatchItems(ctx context.Context, batchID uuid.UUID) ([]*entity.RemediationBatchItem, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.id, i.batch_id, i.finding_id, i.connection, i.source_type, i.status
+		FROM remediation_batch_items i
+		JOIN remediation_batches b ON b.id = i.batch_id
+		WHERE i.batch_id = $1 AND b.tenant_id = $2 AND i.status = $3
+		ORDER BY i.connection, i.id`,
+		batchID, tenantID, entity.RemediationBatchItemPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediation batch items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*entity.RemediationBatchItem
+	for rows.Next() {
+		var item entity.RemediationBatchItem
+		if err := rows.Scan(&item.ID, &item.BatchID, &item.FindingID, &item.Connection, &item.SourceType, &item.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// FinishRemediationBatchItem records the outcome of one item of a batch
+func (r *PostgresRepository) FinishRemediationBatchItem(ctx context.Context, item *entity.RemediationBatchItem) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE remediation_batch_items i
+		SET status = $3, action_id = NULLIF($4, '')::uuid, error = $5, finished_at = NOW()
+		FROM remediation_batches b
+		WHERE i.id = $1 AND b.id = i.batch_id AND b.tenant_id = $2`,
+		item.ID, tenantID, item.Status, item.ActionID, item.Error)
+	if err != nil {
+		return fmt.Errorf("failed to update remediation batch item: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("remediation batch item not found")
+	}
+	return nil
+}
+
+func scanRemediationBatch(row interface{ Scan(...interface{}) error }) (*entity.RemediationBatch, error) {
+	var batch entity.RemediationBatch
+	var jobID uuid.NullUUID
+	var startedAt, completedAt sql.NullTime
+	if err := row.Scan(&batch.ID, &batch.TenantID, &batch.ActionType, &batch.Status, &batch.RequestedBy,
+		&batch.TotalFindings, &jobID, &batch.Error, &batch.CreatedAt, &startedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	if jobID.Valid {
+		batch.JobID = &jobID.UUID
+	}
+	if startedAt.Valid {
+		batch.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		batch.CompletedAt = &completedAt.Time
+	}
+	return &batch, nil
+}
@@ -0,0 +1,105 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Remediation Recommendation Repository Implementation
+// ============================================================================
+
+// GetAssetPIISummary groups the open findings of an asset by PII type.
+// The PII type comes from the classification sub-category, falling back to the pattern name.
+func (r *PostgresRepository) GetAssetPIISummary(ctx context.Context, assetID uuid.UUID) ([]entity.AssetPIISummary, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH open_findings AS (
+			SELECT f.id, f.severity,
+				COALESCE(NULLIF(c.sub_category, ''), f.pattern_name) AS pii_type
+			FROM findings f
+			LEFT JOIN LATERAL (
+				SELECT sub_category FROM classifications
+				WHERE finding_id = f.id
+				ORDER BY confidence_score DESC
+				LIMIT 1
+			) c ON true
+			LEFT JOIN review_states rs ON rs.finding_id = f.id
+			WHERE f.asset_id = $1 AND f.tenant_id = $2
+			  AND f.deleted_at IS NULL
+			  AND (rs.status IS NULL OR NOT rs.status = ANY($3::text[]))
+		)
+		SELECT o.pii_type, COUNT(DISTINCT o.id),
+			COALESCE((ARRAY_AGG(o.severity ORDER BY CASE UPPER(o.severity)
+				WHEN 'CRITICAL' THEN 1 WHEN 'HIGH' THEN 2 WHEN 'MEDIUM' THEN 3 ELSE 4 END))[1], ''),
+			COUNT(DISTINCT o.id) FILTER (WHERE EXISTS (
+				SELECT 1 FROM remediation_actions ra
+				WHERE ra.finding_id = o.id AND ra.status = 'COMPLETED'
+			))
+		FROM open_findings o
+		GROUP BY o.pii_type
+		ORDER BY COUNT(DISTINCT o.id) DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, assetID, tenantID, pq.Array(entity.ClosedReviewStatuses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset PII summary: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []entity.AssetPIISummary
+	for rows.Next() {
+		var s entity.AssetPIISummary
+		if err := rows.Scan(&s.PIIType, &s.FindingCount, &s.MaxSeverity, &s.RemediatedCount); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+
+	return summaries, rows.Err()
+}
+
+// GetRemediationOutcomeStats counts terminal remediation outcomes per action type.
+// When assetID is set only actions on that asset's findings are counted,
+// otherwise the whole tenant history is used.
+func (r *PostgresRepository) GetRemediationOutcomeStats(ctx context.Context, assetID *uuid.UUID) ([]entity.RemediationOutcomeStats, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ra.action_type,
+			COUNT(*) FILTER (WHERE ra.status = 'COMPLETED'),
+			COUNT(*) FILTER (WHERE ra.status = 'FAILED'),
+			COUNT(*) FILTER (WHERE ra.status = 'ROLLED_BACK')
+		FROM remediation_actions ra
+		JOIN findings f ON f.id = ra.finding_id
+		WHERE f.tenant_id = $1
+		  AND ($2::uuid IS NULL OR f.asset_id = $2)
+		GROUP BY ra.action_type`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query remediation outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []entity.RemediationOutcomeStats
+	for rows.Next() {
+		var s entity.RemediationOutcomeStats
+		if err := rows.Scan(&s.ActionType, &s.Completed, &s.Failed, &s.RolledBack); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,461 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Remediation Repository Implementation
+// ============================================================================
+
+const remediationActionColumns = `id, finding_id, action_type, executed_by, executed_at, status`
+
+const remediationRequestColumns = `id, finding_ids, action_type, impact, justification, status, requested_by,
+	requested_at, expires_at, COALESCE(decided_by, ''), decided_at, decision_comment, action_ids, errors, executed_at`
+
+const remediationSimulationColumns = `id, finding_id, action_type, status, changes, error, simulated_by, simulated_at`
+
+// GetRemediationFinding retrieves a finding with the asset details needed to remediate it
+func (r *PostgresRepository) GetRemediationFinding(ctx context.Context, findingID string) (*entity.RemediationFinding, error) {
+	query := `
+		SELECT f.id, f.asset_id, a.name, a.path, COALESCE(a.source_system, ''), a.data_source,
+		       f.pattern_name, f.sample_text, COALESCE(f.context::text, ''), f.matches, f.match_locations, f.tenant_id
+		FROM findings f
+		JOIN assets a ON f.asset_id = a.id
+		WHERE f.id = $1
+	`
+
+	var finding entity.RemediationFinding
+	var locationsJSON []byte
+	var rowTenant uuid.NullUUID
+	err := r.db.QueryRowContext(ctx, query, findingID).Scan(
+		&finding.ID, &finding.AssetID, &finding.AssetName, &finding.AssetPath,
+		&finding.SourceSystem, &finding.SourceType, &finding.PIIType,
+		&finding.SampleText, &finding.Context, pq.Array(&finding.Matches), &locationsJSON, &rowTenant,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := openFindingValues(ctx, r.db, valueTenant(ctx, rowTenant.UUID), finding.Matches, &finding.SampleText); err != nil {
+		return nil, err
+	}
+	finding.Location = finding.AssetPath
+
+	if len(locationsJSON) > 0 {
+		if err := json.Unmarshal(locationsJSON, &finding.Locations); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal match locations: %w", err)
+		}
+	}
+
+	return &finding, nil
+}
+
+// GetFindingPreviewSample returns a finding's sample text and PII type
+func (r *PostgresRepository) GetFindingPreviewSample(ctx context.Context, findingID string) (string, string, error) {
+	var sampleText, piiType string
+	var rowTenant uuid.NullUUID
+	err := r.db.QueryRowContext(ctx, `
+		SELECT sample_text, pattern_name, tenant_id
+		FROM findings
+		WHERE id = $1
+	`, findingID).Scan(&sampleText, &piiType, &rowTenant)
+	if err != nil {
+		return "", "", err
+	}
+	err = openFindingValues(ctx, r.db, valueTenant(ctx, rowTenant.UUID), nil, &sampleText)
+	return sampleText, piiType, err
+}
+
+// GetRemediationSourceConfig returns the connection configuration of a source profile
+func (r *PostgresRepository) GetRemediationSourceConfig(ctx context.Context, sourceName string) (map[string]interface{}, error) {
+	var configJSON string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT configuration FROM source_profiles WHERE name = $1
+	`, sourceName).Scan(&configJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// CreateRemediationAction stores a PENDING action executed now
+func (r *PostgresRepository) CreateRemediationAction(ctx context.Context, action *entity.RemediationAction) error {
+	action.Status = "PENDING"
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO remediation_actions
+		(id, finding_id, action_type, executed_by, executed_at, effective_from, status, metadata)
+		VALUES ($1, $2, $3, $4, NOW(), NOW(), $5, $6)
+		RETURNING executed_at
+	`, action.ID, action.FindingID, action.ActionType, action.ExecutedBy, action.Status, []byte(action.Metadata),
+	).Scan(&action.ExecutedAt)
+}
+
+// UpdateRemediationActionStatus sets the status of a remediation action
+func (r *PostgresRepository) UpdateRemediationActionStatus(ctx context.Context, id, status string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE remediation_actions
+		SET status = $1
+		WHERE id = $2
+	`, status, id)
+	return err
+}
+
+// UpdateRemediationActionMetadata replaces the rollback metadata of a remediation action
+func (r *PostgresRepository) UpdateRemediationActionMetadata(ctx context.Context, id string, metadata []byte) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE remediation_actions SET metadata = $1 WHERE id = $2`, metadata, id)
+	return err
+}
+
+// EndRemediationAction closes the period the action was in effect
+func (r *PostgresRepository) EndRemediationAction(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE remediation_actions
+		SET effective_until = NOW()
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// GetRemediationAction retrieves a remediation action with its metadata
+func (r *PostgresRepository) GetRemediationAction(ctx context.Context, id string) (*entity.RemediationAction, error) {
+	var action entity.RemediationAction
+	var metadata sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+		SELECT `+remediationActionColumns+`, metadata
+		FROM remediation_actions
+		WHERE id = $1
+	`, id).Scan(
+		&action.ID, &action.FindingID, &action.ActionType,
+		&action.ExecutedBy, &action.ExecutedAt, &action.Status, &metadata,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.Valid {
+		action.Metadata = json.RawMessage(metadata.String)
+	}
+	return &action, nil
+}
+
+// ListRemediationActionsByFinding lists the actions taken on a finding, newest first
+func (r *PostgresRepository) ListRemediationActionsByFinding(ctx context.Context, findingID string) ([]*entity.RemediationAction, error) {
+	return r.queryRemediationActions(ctx, `
+		SELECT `+remediationActionColumns+`
+		FROM remediation_actions
+		WHERE finding_id = $1
+		ORDER BY executed_at DESC
+	`, findingID)
+}
+
+// ListRemediationActionsByAsset lists the actions taken on an asset's findings, newest first
+func (r *PostgresRepository) ListRemediationActionsByAsset(ctx context.Context, assetID string) ([]*entity.RemediationAction, error) {
+	return r.queryRemediationActions(ctx, `
+		SELECT ra.id, ra.finding_id, ra.action_type, ra.executed_by, ra.executed_at, ra.status
+		FROM remediation_actions ra
+		JOIN findings f ON ra.finding_id = f.id::text
+		WHERE f.asset_id = $1
+		ORDER BY ra.executed_at DESC
+	`, assetID)
+}
+
+// ListRemediationActions pages through all actions, optionally of one action type.
+// It also returns the total number of matching actions.
+func (r *PostgresRepository) ListRemediationActions(ctx context.Context, actionType string, limit, offset int) ([]*entity.RemediationAction, int, error) {
+	query := `
+		SELECT ` + remediationActionColumns + `
+		FROM remediation_actions
+		WHERE 1=1
+	`
+	countQuery := `SELECT COUNT(*) FROM remediation_actions WHERE 1=1`
+
+	args := []interface{}{}
+	argCount := 1
+
+	if actionType != "" && actionType != "ALL" {
+		filterClause := fmt.Sprintf(" AND action_type = $%d", argCount)
+		query += filterClause
+		countQuery += filterClause
+		args = append(args, actionType)
+		argCount++
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count remediation actions: %w", err)
+	}
+
+	query += fmt.Sprintf(" ORDER BY executed_at DESC LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	args = append(args, limit, offset)
+
+	actions, err := r.queryRemediationActions(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list remediation actions: %w", err)
+	}
+	return actions, total, nil
+}
+
+func (r *PostgresRepository) queryRemediationActions(ctx context.Context, query string, args ...interface{}) ([]*entity.RemediationAction, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var actions []*entity.RemediationAction
+	for rows.Next() {
+		var action entity.RemediationAction
+		if err := rows.Scan(&action.ID, &action.FindingID, &action.ActionType, &action.ExecutedBy, &action.ExecutedAt, &action.Status); err != nil {
+			return nil, err
+		}
+		actions = append(actions, &action)
+	}
+	return actions, rows.Err()
+}
+
+// RecordRemediationAuditLog writes a remediation event to the audit log
+func (r *PostgresRepository) RecordRemediationAuditLog(ctx context.Context, eventType, userID, resourceType, resourceID string, metadata map[string]interface{}) error {
+	metadataJSON, _ := json.Marshal(metadata)
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO audit_logs
+		(event_type, event_time, user_id, resource_type, resource_id, action, metadata)
+		VALUES ($1, NOW(), $2, $3, $4, $5, $6)
+	`, eventType, userID, resourceType, resourceID, eventType, metadataJSON)
+	return err
+}
+
+// CreateRemediationRequest stores a pending remediation request for the tenant in ctx
+func (r *PostgresRepository) CreateRemediationRequest(ctx context.Context, req *entity.RemediationApprovalRequest) error {
+	row := r.db.QueryRowContext(ctx, `
+		INSERT INTO remediation_requests (tenant_id, finding_ids, action_type, impact, justification, requested_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+remediationRequestColumns,
+		optionalTenantID(ctx), pq.Array(req.FindingIDs), req.ActionType, req.Impact, req.Justification,
+		req.RequestedBy, req.ExpiresAt)
+	created, err := scanRemediationRequest(row)
+	if err != nil {
+		return err
+	}
+	*req = *created
+	return nil
+}
+
+// GetRemediationRequest retrieves a remediation request. It returns nil when the
+// request does not exist.
+func (r *PostgresRepository) GetRemediationRequest(ctx context.Context, id string) (*entity.RemediationApprovalRequest, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+remediationRequestColumns+` FROM remediation_requests
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`,
+		id, optionalTenantID(ctx))
+	req, err := scanRemediationRequest(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return req, err
+}
+
+// ListRemediationRequests returns remediation requests, newest first, optionally
+// filtered by status. It also returns the total number of matching requests.
+func (r *PostgresRepository) ListRemediationRequests(ctx context.Context, status string, limit, offset int) ([]*entity.RemediationApprovalRequest, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM remediation_requests
+		WHERE ($1::uuid IS NULL OR tenant_id = $1) AND ($2 = '' OR status = $2)`,
+		optionalTenantID(ctx), status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count remediation requests: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+remediationRequestColumns+` FROM remediation_requests
+		WHERE ($1::uuid IS NULL OR tenant_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY requested_at DESC
+		LIMIT $3 OFFSET $4`,
+		optionalTenantID(ctx), status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list remediation requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := make([]*entity.RemediationApprovalRequest, 0)
+	for rows.Next() {
+		req, err := scanRemediationRequest(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		requests = append(requests, req)
+	}
+	return requests, total, rows.Err()
+}
+
+// DecideRemediationRequest moves a pending, unexpired request to status. The
+// update is conditional, so two approvers racing on the same request cannot both
+// decide it; the loser gets nil.
+func (r *PostgresRepository) DecideRemediationRequest(ctx context.Context, id, status, decidedBy, comment string) (*entity.RemediationApprovalRequest, error) {
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE remediation_requests
+		SET status = $1, decided_by = $2, decided_at = NOW(), decision_comment = $3
+		WHERE id = $4 AND status = $5 AND expires_at > NOW()
+		RETURNING `+remediationRequestColumns,
+		status, decidedBy, comment, id, entity.RemediationRequestPendingApproval)
+	req, err := scanRemediationRequest(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return req, err
+}
+
+// CompleteRemediationRequest records the outcome of executing an approved request
+func (r *PostgresRepository) CompleteRemediationRequest(ctx context.Context, id, status string, actionIDs, failures []string) (*entity.RemediationApprovalRequest, error) {
+	row := r.db.QueryRowContext(ctx, `
+		UPDATE remediation_requests
+		SET status = $1, action_ids = $2, errors = $3, executed_at = NOW()
+		WHERE id = $4
+		RETURNING `+remediationRequestColumns,
+		status, pq.Array(actionIDs), pq.Array(failures), id)
+	return scanRemediationRequest(row)
+}
+
+// ExpireRemediationRequests lapses pending requests past their expiry and returns them
+func (r *PostgresRepository) ExpireRemediationRequests(ctx context.Context) ([]*entity.RemediationApprovalRequest, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE remediation_requests SET status = $1
+		WHERE status = $2 AND expires_at <= NOW() AND ($3::uuid IS NULL OR tenant_id = $3)
+		RETURNING `+remediationRequestColumns,
+		entity.RemediationRequestExpired, entity.RemediationRequestPendingApproval, optionalTenantID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var expired []*entity.RemediationApprovalRequest
+	for rows.Next() {
+		req, err := scanRemediationRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		expired = append(expired, req)
+	}
+	return expired, rows.Err()
+}
+
+// CreateRemediationSimulation stores a remediation dry run for the tenant in ctx
+func (r *PostgresRepository) CreateRemediationSimulation(ctx context.Context, sim *entity.RemediationSimulation) error {
+	changes := []byte(sim.Changes)
+	if len(changes) == 0 {
+		changes = []byte("[]")
+	}
+	row := r.db.QueryRowContext(ctx, `
+		INSERT INTO remediation_simulations (tenant_id, finding_id, action_type, status, changes, error, simulated_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+remediationSimulationColumns,
+		optionalTenantID(ctx), sim.FindingID, sim.ActionType, sim.Status, changes, sim.Error, sim.SimulatedBy)
+	created, err := scanRemediationSimulation(row)
+	if err != nil {
+		return err
+	}
+	*sim = *created
+	return nil
+}
+
+// GetRemediationSimulation retrieves a remediation dry run. It returns nil when
+// the simulation does not exist.
+func (r *PostgresRepository) GetRemediationSimulation(ctx context.Context, id string) (*entity.RemediationSimulation, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil
+	}
+	row := r.db.QueryRowContext(ctx, `
+		SELECT `+remediationSimulationColumns+` FROM remediation_simulations
+		WHERE id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)`,
+		id, optionalTenantID(ctx))
+	sim, err := scanRemediationSimulation(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return sim, err
+}
+
+// ListRemediationSimulationsByFinding returns a finding's dry runs, newest first
+func (r *PostgresRepository) ListRemediationSimulationsByFinding(ctx context.Context, findingID string) ([]*entity.RemediationSimulation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+remediationSimulationColumns+` FROM remediation_simulations
+		WHERE finding_id = $1 AND ($2::uuid IS NULL OR tenant_id = $2)
+		ORDER BY simulated_at DESC`,
+		findingID, optionalTenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remediation simulations: %w", err)
+	}
+	defer rows.Close()
+
+	sims := make([]*entity.RemediationSimulation, 0)
+	for rows.Next() {
+		sim, err := scanRemediationSimulation(rows)
+		if err != nil {
+			return nil, err
+		}
+		sims = append(sims, sim)
+	}
+	return sims, rows.Err()
+}
+
+// optionalTenantID returns the caller's tenant, or nil when the request is not
+// tenant-scoped (anonymous access with AUTH_REQUIRED=false)
+func optionalTenantID(ctx context.Context) interface{} {
+	tenantID, err := GetTenantID(ctx)
+	if err != nil || tenantID == uuid.Nil {
+		return nil
+	}
+	return tenantID
+}
+
+func scanRemediationRequest(row rowScanner) (*entity.RemediationApprovalRequest, error) {
+	req := &entity.RemediationApprovalRequest{}
+	var decidedAt, executedAt sql.NullTime
+	err := row.Scan(
+		&req.ID, pq.Array(&req.FindingIDs), &req.ActionType, &req.Impact, &req.Justification, &req.Status,
+		&req.RequestedBy, &req.RequestedAt, &req.ExpiresAt, &req.DecidedBy, &decidedAt, &req.DecisionComment,
+		pq.Array(&req.ActionIDs), pq.Array(&req.Errors), &executedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if decidedAt.Valid {
+		req.DecidedAt = &decidedAt.Time
+	}
+	if executedAt.Valid {
+		req.ExecutedAt = &executedAt.Time
+	}
+	if req.ActionIDs == nil {
+		req.ActionIDs = []string{}
+	}
+	if req.Errors == nil {
+		req.Errors = []string{}
+	}
+	return req, nil
+}
+
+func scanRemediationSimulation(row rowScanner) (*entity.RemediationSimulation, error) {
+	sim := &entity.RemediationSimulation{}
+	var changes []byte
+	err := row.Scan(&sim.ID, &sim.FindingID, &sim.ActionType, &sim.Status, &changes, &sim.Error,
+		&sim.SimulatedBy, &sim.SimulatedAt)
+	if err != nil {
+		return nil, err
+	}
+	sim.Changes = json.RawMessage(changes)
+	return sim, nil
+}
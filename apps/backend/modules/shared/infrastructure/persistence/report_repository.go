@@ -0,0 +1,588 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Report Builder Repository Implementation
+// ============================================================================
+
+const reportTemplateColumns = `id, tenant_id, name, COALESCE(description, ''), filters, group_by, columns, chart,
+	created_by, created_at, updated_at`
+
+const reportScheduleColumns = `id, tenant_id, template_id, name, frequency, format, targets, is_active,
+	next_run_at, last_run_at, created_by, created_at, updated_at`
+
+// reportFieldSQL maps the report fields of entity.ReportColumns and
+// entity.ReportGroupFields to their SQL. Only these expressions are ever
+// interpolated into report queries.
+var reportFieldSQL = map[string]string{
+	"finding_id":          `f.id::text`,
+	"asset_name":          `COALESCE(a.name, '')`,
+	"asset_path":          `COALESCE(a.path, '')`,
+	"data_source":         `COALESCE(a.data_source, '')`,
+	"environment":         `COALESCE(f.environment, '')`,
+	"pattern_name":        `f.pattern_name`,
+	"severity":            `COALESCE(f.severity, '')`,
+	"classification_type": `COALESCE(c.classification_type, '')`,
+	"confidence":          `COALESCE(f.confidence_score, 0)::text`,
+	"review_status":       `COALESCE(rs.status, 'open')`,
+	"created_at":          `to_char(f.created_at, 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`,
+	"created_date":        `to_char(f.created_at, 'YYYY-MM-DD')`,
+	"created_month":       `to_char(f.created_at, 'YYYY-MM')`,
+}
+
+// storedReportTarget is how a report target is kept in the targets column;
+// the plaintext webhook secret is never written
+type storedReportTarget struct {
+	Type            string   `json:"type"`
+	Recipients      []string `json:"recipients,omitempty"`
+	URL             string   `json:"url,omitempty"`
+	SecretEncrypted []byte   `json:"secret_encrypted,omitempty"`
+}
+
+// CreateReportTemplate stores a new report template for the tenant
+func (r *PostgresRepository) CreateReportTemplate(ctx context.Context, tmpl *entity.ReportTemplate) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	tmpl.TenantID = tenantID
+
+	filters, chart, err := marshalReportTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO report_templates (id, tenant_id, name, description, filters, group_by, columns, chart, created_by)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		tmpl.ID, tmpl.TenantID, tmpl.Name, tmpl.Description, filters, pq.Array(tmpl.GroupBy),
+		pq.Array(tmpl.Columns), chart, tmpl.CreatedBy,
+	).Scan(&tmpl.CreatedAt, &tmpl.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("report template %q already exists", tmpl.Name)
+		}
+		return fmt.Errorf("failed to create report template: %w", err)
+	}
+	return nil
+}
+
+// GetReportTemplate retrieves a report template by ID
+func (r *PostgresRepository) GetReportTemplate(ctx context.Context, id uuid.UUID) (*entity.ReportTemplate, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + reportTemplateColumns + ` FROM report_templates WHERE id = $1 AND tenant_id = $2`
+
+	tmpl, err := scanReportTemplate(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("report template not found")
+	}
+	return tmpl, err
+}
+
+// ListReportTemplates retrieves all report templates of the tenant
+func (r *PostgresRepository) ListReportTemplates(ctx context.Context) ([]*entity.ReportTemplate, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+reportTemplateColumns+` FROM report_templates WHERE tenant_id = $1 ORDER BY name`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*entity.ReportTemplate
+	for rows.Next() {
+		tmpl, err := scanReportTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+	return templates, rows.Err()
+}
+
+// UpdateReportTemplate replaces the definition of a report template
+func (r *PostgresRepository) UpdateReportTemplate(ctx context.Context, tmpl *entity.ReportTemplate) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	filters, chart, err := marshalReportTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE report_templates SET name = $1, description = NULLIF($2, ''), filters = $3, group_by = $4,
+			columns = $5, chart = $6
+		WHERE id = $7 AND tenant_id = $8
+		RETURNING ` + reportTemplateColumns
+
+	updated, err := scanReportTemplate(r.db.QueryRowContext(ctx, query,
+		tmpl.Name, tmpl.Description, filters, pq.Array(tmpl.GroupBy), pq.Array(tmpl.Columns), chart,
+		tmpl.ID, tenantID,
+	))
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("report template not found")
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("report template %q already exists", tmpl.Name)
+		}
+		return fmt.Errorf("failed to update report template: %w", err)
+	}
+
+	*tmpl = *updated
+	return nil
+}
+
+// DeleteReportTemplate deletes a report template and its schedules; run history is kept
+func (r *PostgresRepository) DeleteReportTemplate(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM report_templates WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("report template not found")
+	}
+	return nil
+}
+
+// QueryReportRows evaluates a template against the tenant's findings. Grouped
+// templates return one row per group with its finding count, largest first.
+// At most limit rows are returned; truncated reports whether more exist.
+func (r *PostgresRepository) QueryReportRows(ctx context.Context, tmpl *entity.ReportTemplate, limit int) (columns []string, rows [][]string, truncated bool, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var selects []string
+	if len(tmpl.GroupBy) > 0 {
+		columns = append(append(columns, tmpl.GroupBy...), "finding_count")
+		for _, field := range tmpl.GroupBy {
+			expr, ok := reportFieldSQL[field]
+			if !ok {
+				return nil, nil, false, fmt.Errorf("invalid report field %q", field)
+			}
+			selects = append(selects, expr)
+		}
+		selects = append(selects, `COUNT(*)::text`)
+	} else {
+		columns = append(columns, tmpl.Columns...)
+		for _, field := range tmpl.Columns {
+			expr, ok := reportFieldSQL[field]
+			if !ok {
+				return nil, nil, false, fmt.Errorf("invalid report field %q", field)
+			}
+			selects = append(selects, expr)
+		}
+	}
+
+	f := tmpl.Filters
+	var since *time.Time
+	if f.SinceDays > 0 {
+		t := time.Now().UTC().AddDate(0, 0, -f.SinceDays)
+		since = &t
+	}
+
+	query := `
+		SELECT ` + strings.Join(selects, ", ") + `
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN LATERAL (
+			SELECT classification_type FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		LEFT JOIN LATERAL (
+			SELECT status FROM review_states
+			WHERE finding_id = f.id
+			ORDER BY updated_at DESC
+			LIMIT 1
+		) rs ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+		  AND ($2::timestamp IS NULL OR f.created_at > $2)
+		  AND (cardinality($3::text[]) = 0 OR LOWER(f.severity) = ANY($3::text[]))
+		  AND (cardinality($4::text[]) = 0 OR LOWER(c.classification_type) = ANY($4::text[]))
+		  AND (cardinality($5::text[]) = 0 OR LOWER(f.environment) = ANY($5::text[]))
+		  AND (cardinality($6::text[]) = 0 OR LOWER(f.pattern_name) = ANY($6::text[]))
+		  AND (cardinality($7::text[]) = 0 OR LOWER(a.data_source) = ANY($7::text[]))
+		  AND (cardinality($8::text[]) = 0 OR LOWER(COALESCE(rs.status, 'open')) = ANY($8::text[]))`
+
+	if len(tmpl.GroupBy) > 0 {
+		groups := make([]string, len(tmpl.GroupBy))
+		for i := range tmpl.GroupBy {
+			groups[i] = fmt.Sprint(i + 1)
+		}
+		query += `
+		GROUP BY ` + strings.Join(groups, ", ") + `
+		ORDER BY COUNT(*) DESC, ` + strings.Join(groups, ", ")
+	} else {
+		query += `
+		ORDER BY f.created_at DESC, f.id`
+	}
+	query += `
+		LIMIT $9`
+
+	result, err := r.db.QueryContext(ctx, query, tenantID, since,
+		pq.Array(lowerAll(f.Severities)), pq.Array(lowerAll(f.ClassificationTypes)),
+		pq.Array(lowerAll(f.Environments)), pq.Array(lowerAll(f.PatternNames)),
+		pq.Array(lowerAll(f.DataSources)), pq.Array(lowerAll(f.ReviewStatuses)), limit+1)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to query report rows: %w", err)
+	}
+	defer result.Close()
+
+	rows = [][]string{}
+	for result.Next() {
+		row := make([]string, len(columns))
+		dest := make([]interface{}, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := result.Scan(dest...); err != nil {
+			return nil, nil, false, err
+		}
+		rows = append(rows, row)
+	}
+	if err := result.Err(); err != nil {
+		return nil, nil, false, err
+	}
+
+	if len(rows) > limit {
+		rows, truncated = rows[:limit], true
+	}
+	return columns, rows, truncated, nil
+}
+
+// CreateReportSchedule stores a new report schedule for the tenant
+func (r *PostgresRepository) CreateReportSchedule(ctx context.Context, schedule *entity.ReportSchedule) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	schedule.TenantID = tenantID
+
+	targets, err := marshalReportTargets(schedule.Targets)
+	if err != nil {
+		return err
+	}
+
+	// The template must belong to the same tenant
+	query := `
+		INSERT INTO report_schedules (id, tenant_id, template_id, name, frequency, format, targets, is_active,
+			next_run_at, created_by)
+		SELECT $1, $2, t.id, $4, $5, $6, $7, $8, $9, $10
+		FROM report_templates t WHERE t.id = $3 AND t.tenant_id = $2
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		schedule.ID, schedule.TenantID, schedule.TemplateID, schedule.Name, schedule.Frequency, schedule.Format,
+		targets, schedule.IsActive, schedule.NextRunAt, schedule.CreatedBy,
+	).Scan(&schedule.CreatedAt, &schedule.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("report template not found")
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("report schedule %q already exists", schedule.Name)
+		}
+		return fmt.Errorf("failed to create report schedule: %w", err)
+	}
+	return nil
+}
+
+// GetReportSchedule retrieves a report schedule by ID
+func (r *PostgresRepository) GetReportSchedule(ctx context.Context, id uuid.UUID) (*entity.ReportSchedule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedules WHERE id = $1 AND tenant_id = $2`
+
+	schedule, err := scanReportSchedule(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("report schedule not found")
+	}
+	return schedule, err
+}
+
+// ListReportSchedules retrieves all report schedules of the tenant
+func (r *PostgresRepository) ListReportSchedules(ctx context.Context) ([]*entity.ReportSchedule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedules WHERE tenant_id = $1 ORDER BY name`
+	return r.queryReportSchedules(ctx, query, tenantID)
+}
+
+// ListDueReportSchedules retrieves the enabled schedules of every tenant whose next run is at or before now
+func (r *PostgresRepository) ListDueReportSchedules(ctx context.Context, now time.Time) ([]*entity.ReportSchedule, error) {
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedules WHERE is_active AND next_run_at <= $1 ORDER BY next_run_at`
+	return r.queryReportSchedules(ctx, query, now)
+}
+
+// UpdateReportSchedule replaces the name, frequency, format, targets, active flag and next run of a schedule
+func (r *PostgresRepository) UpdateReportSchedule(ctx context.Context, schedule *entity.ReportSchedule) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	targets, err := marshalReportTargets(schedule.Targets)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE report_schedules SET name = $1, frequency = $2, format = $3, targets = $4, is_active = $5,
+			next_run_at = $6
+		WHERE id = $7 AND tenant_id = $8
+		RETURNING ` + reportScheduleColumns
+
+	updated, err := scanReportSchedule(r.db.QueryRowContext(ctx, query,
+		schedule.Name, schedule.Frequency, schedule.Format, targets, schedule.IsActive, schedule.NextRunAt,
+		schedule.ID, tenantID,
+	))
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("report schedule not found")
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("report schedule %q already exists", schedule.Name)
+		}
+		return fmt.Errorf("failed to update report schedule: %w", err)
+	}
+
+	*schedule = *updated
+	return nil
+}
+
+// DeleteReportSchedule deletes a report schedule by ID; its run history is kept
+func (r *PostgresRepository) DeleteReportSchedule(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM report_schedules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("report schedule not found")
+	}
+	return nil
+}
+
+// ClaimReportSchedule moves a due schedule's next run from its current value to next
+// and stamps the run. It reports false when another replica already claimed this run.
+func (r *PostgresRepository) ClaimReportSchedule(ctx context.Context, id uuid.UUID, current, next time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE report_schedules SET next_run_at = $3, last_run_at = NOW()
+		WHERE id = $1 AND next_run_at = $2 AND is_active`,
+		id, current, next)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim report schedule: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+// RecordReportRun logs a report run and its per-target deliveries
+func (r *PostgresRepository) RecordReportRun(ctx context.Context, run *entity.ReportRun) error {
+	deliveries, err := json.Marshal(run.Deliveries)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO report_runs (id, tenant_id, schedule_id, template_id, trigger, format, row_count, status, error, deliveries)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10)
+		RETURNING created_at`,
+		run.ID, run.TenantID, run.ScheduleID, run.TemplateID, run.Trigger, run.Format, run.RowCount,
+		run.Status, run.Error, deliveries,
+	).Scan(&run.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record report run: %w", err)
+	}
+	return nil
+}
+
+// ListReportRuns returns the tenant's most recent report runs, optionally for one schedule
+func (r *PostgresRepository) ListReportRuns(ctx context.Context, scheduleID *uuid.UUID, limit, offset int) ([]*entity.ReportRun, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, tenant_id, schedule_id, template_id, trigger, format, row_count, status,
+			COALESCE(error, ''), deliveries, created_at
+		FROM report_runs
+		WHERE tenant_id = $1 AND ($2::uuid IS NULL OR schedule_id = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`,
+		tenantID, scheduleID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*entity.ReportRun
+	for rows.Next() {
+		run := &entity.ReportRun{}
+		var scheduleRef, templateRef uuid.NullUUID
+		var deliveries []byte
+		if err := rows.Scan(
+			&run.ID, &run.TenantID, &scheduleRef, &templateRef, &run.Trigger, &run.Format, &run.RowCount,
+			&run.Status, &run.Error, &deliveries, &run.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if scheduleRef.Valid {
+			run.ScheduleID = &scheduleRef.UUID
+		}
+		if templateRef.Valid {
+			run.TemplateID = &templateRef.UUID
+		}
+		if err := json.Unmarshal(deliveries, &run.Deliveries); err != nil {
+			return nil, fmt.Errorf("failed to decode report run deliveries: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (r *PostgresRepository) queryReportSchedules(ctx context.Context, query string, args ...interface{}) ([]*entity.ReportSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*entity.ReportSchedule
+	for rows.Next() {
+		schedule, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func marshalReportTemplate(tmpl *entity.ReportTemplate) (filters, chart []byte, err error) {
+	if filters, err = json.Marshal(tmpl.Filters); err != nil {
+		return nil, nil, err
+	}
+	if tmpl.Chart != nil {
+		if chart, err = json.Marshal(tmpl.Chart); err != nil {
+			return nil, nil, err
+		}
+	}
+	return filters, chart, nil
+}
+
+func marshalReportTargets(targets []entity.ReportTarget) ([]byte, error) {
+	stored := make([]storedReportTarget, len(targets))
+	for i, t := range targets {
+		stored[i] = storedReportTarget{
+			Type:            t.Type,
+			Recipients:      t.Recipients,
+			URL:             t.URL,
+			SecretEncrypted: t.SecretEncrypted,
+		}
+	}
+	return json.Marshal(stored)
+}
+
+func scanReportTemplate(row rowScanner) (*entity.ReportTemplate, error) {
+	tmpl := &entity.ReportTemplate{}
+	var filters, chart []byte
+	var groupBy, columns pq.StringArray
+
+	err := row.Scan(
+		&tmpl.ID, &tmpl.TenantID, &tmpl.Name, &tmpl.Description, &filters, &groupBy, &columns, &chart,
+		&tmpl.CreatedBy, &tmpl.CreatedAt, &tmpl.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(filters, &tmpl.Filters); err != nil {
+		return nil, fmt.Errorf("failed to decode report filters: %w", err)
+	}
+	if len(chart) > 0 {
+		tmpl.Chart = &entity.ReportChart{}
+		if err := json.Unmarshal(chart, tmpl.Chart); err != nil {
+			return nil, fmt.Errorf("failed to decode report chart: %w", err)
+		}
+	}
+	tmpl.GroupBy = groupBy
+	tmpl.Columns = columns
+	return tmpl, nil
+}
+
+func scanReportSchedule(row rowScanner) (*entity.ReportSchedule, error) {
+	schedule := &entity.ReportSchedule{}
+	var targets []byte
+
+	err := row.Scan(
+		&schedule.ID, &schedule.TenantID, &schedule.TemplateID, &schedule.Name, &schedule.Frequency,
+		&schedule.Format, &targets, &schedule.IsActive, &schedule.NextRunAt, &schedule.LastRunAt,
+		&schedule.CreatedBy, &schedule.CreatedAt, &schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []storedReportTarget
+	if err := json.Unmarshal(targets, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode report targets: %w", err)
+	}
+	schedule.Targets = make([]entity.ReportTarget, len(stored))
+	for i, t := range stored {
+		schedule.Targets[i] = entity.ReportTarget{
+			Type:            t.Type,
+			Recipients:      t.Recipients,
+			URL:             t.URL,
+			SecretEncrypted: t.SecretEncrypted,
+			HasSecret:       len(t.SecretEncrypted) > 0,
+		}
+	}
+	return schedule, nil
+}
@@ -0,0 +1,220 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Review Priority Repository Implementation
+// ============================================================================
+
+// pendingReviewCondition holds for findings of f that are not deleted and have not been reviewed
+const pendingReviewCondition = `
+	f.deleted_at IS NULL
+	AND COALESCE((
+		SELECT status FROM review_states
+		WHERE finding_id = f.id
+		ORDER BY updated_at DESC
+		LIMIT 1
+	), 'pending') = 'pending'`
+
+// GetReviewPriorityPolicy returns the tenant's review priority policy, or nil
+func (r *PostgresRepository) GetReviewPriorityPolicy(ctx context.Context) (*entity.ReviewPriorityPolicy, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &entity.ReviewPriorityPolicy{TenantID: tenantID}
+	var criticality []byte
+	var updatedAt time.Time
+	err = r.db.QueryRowContext(ctx, `
+		SELECT risk_weight, pii_weight, environment_weight, exposure_weight, pii_criticality, updated_by, updated_at
+		FROM review_priority_policies WHERE tenant_id = $1`, tenantID,
+	).Scan(&policy.RiskWeight, &policy.PIIWeight, &policy.EnvironmentWeight, &policy.ExposureWeight,
+		&criticality, &policy.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review priority policy: %w", err)
+	}
+	if err := json.Unmarshal(criticality, &policy.PIICriticality); err != nil {
+		return nil, fmt.Errorf("failed to decode PII criticality: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	return policy, nil
+}
+
+// SetReviewPriorityPolicy stores the tenant's review priority policy. Findings
+// scored before it are stale from then on.
+func (r *PostgresRepository) SetReviewPriorityPolicy(ctx context.Context, policy *entity.ReviewPriorityPolicy) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	criticality, err := json.Marshal(policy.PIICriticality)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO review_priority_policies
+			(tenant_id, risk_weight, pii_weight, environment_weight, exposure_weight, pii_criticality, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET risk_weight = EXCLUDED.risk_weight, pii_weight = EXCLUDED.pii_weight,
+		    environment_weight = EXCLUDED.environment_weight, exposure_weight = EXCLUDED.exposure_weight,
+		    pii_criticality = EXCLUDED.pii_criticality, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, policy.RiskWeight, policy.PIIWeight, policy.EnvironmentWeight, policy.ExposureWeight,
+		criticality, policy.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set review priority policy: %w", err)
+	}
+	return nil
+}
+
+// ListUnscoredReviewFacts returns up to limit of the tenant's pending findings that
+// have no priority yet or were scored before scoredBefore (nil: only unscored ones)
+func (r *PostgresRepository) ListUnscoredReviewFacts(ctx context.Context, scoredBefore *time.Time, limit int) ([]*entity.ReviewPriorityFacts, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id, f.tenant_id, COALESCE(NULLIF(c.sub_category, ''), f.pattern_name),
+			COALESCE(c.classification_type, ''), COALESCE(f.severity, ''),
+			COALESCE(NULLIF(a.environment, ''), f.environment, ''),
+			(
+				SELECT COUNT(DISTINCT other.asset_id)
+				FROM finding_observations own
+				JOIN finding_observations other
+				  ON other.tenant_id = own.tenant_id AND other.value_hash = own.value_hash AND other.asset_id != f.asset_id
+				JOIN assets oa ON oa.id = other.asset_id AND oa.deleted_at IS NULL
+				WHERE own.finding_id = f.id
+			)
+		FROM findings f
+		LEFT JOIN assets a ON a.id = f.asset_id
+		LEFT JOIN finding_review_priorities p ON p.finding_id = f.id
+		LEFT JOIN LATERAL (
+			SELECT classification_type, sub_category FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND (p.finding_id IS NULL OR p.computed_at < $2::timestamp)
+		  AND `+pendingReviewCondition+`
+		ORDER BY f.id
+		LIMIT $3`, tenantID, scoredBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unscored review findings: %w", err)
+	}
+	defer rows.Close()
+
+	var facts []*entity.ReviewPriorityFacts
+	for rows.Next() {
+		f := &entity.ReviewPriorityFacts{}
+		if err := rows.Scan(&f.FindingID, &f.TenantID, &f.PIIType, &f.ClassificationType, &f.Severity,
+			&f.Environment, &f.SharingAssets); err != nil {
+			return nil, err
+		}
+		facts = append(facts, f)
+	}
+	return facts, rows.Err()
+}
+
+// SaveReviewPriorities stores finding priorities, replacing earlier scores
+func (r *PostgresRepository) SaveReviewPriorities(ctx context.Context, priorities []*entity.FindingReviewPriority) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO finding_review_priorities
+			(finding_id, tenant_id, score, risk_score, pii_score, environment_score, exposure_score,
+			 pii_type, sharing_assets, computed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (finding_id) DO UPDATE
+		SET score = EXCLUDED.score, risk_score = EXCLUDED.risk_score, pii_score = EXCLUDED.pii_score,
+		    environment_score = EXCLUDED.environment_score, exposure_score = EXCLUDED.exposure_score,
+		    pii_type = EXCLUDED.pii_type, sharing_assets = EXCLUDED.sharing_assets, computed_at = NOW()`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, p := range priorities {
+		if _, err := stmt.ExecContext(ctx, p.FindingID, p.TenantID, p.Score, p.RiskScore, p.PIIScore,
+			p.EnvironmentScore, p.ExposureScore, p.PIIType, p.SharingAssets); err != nil {
+			return fmt.Errorf("failed to save review priority of finding %s: %w", p.FindingID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListReviewQueue returns the tenant's scored pending findings, highest priority
+// first and oldest first among equals, with the total matching the filter
+func (r *PostgresRepository) ListReviewQueue(ctx context.Context, filter entity.ReviewQueueFilter) ([]*entity.ReviewQueueItem, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var scope *uuid.UUID
+	if id, ok := OrgUnitScope(ctx); ok {
+		scope = &id
+	}
+	where := `p.tenant_id = $1 AND ($2 = '' OR p.pii_type = $2) AND ` + pendingReviewCondition +
+		` AND ($3::uuid IS NULL OR ` + orgUnitAssetCondition("f.asset_id", 3) + `)` +
+		` AND (COALESCE(f.context->>'` + entity.FindingLanguageReviewKey + `', '') = 'true') = $4`
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM finding_review_priorities p
+		JOIN findings f ON f.id = p.finding_id
+		WHERE `+where, tenantID, filter.PIIType, scope, filter.LanguageReview,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count review queue: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.finding_id, p.score, p.risk_score, p.pii_score, p.environment_score, p.exposure_score,
+			p.pii_type, p.sharing_assets, p.computed_at,
+			f.asset_id, COALESCE(a.name, ''), COALESCE(a.path, ''), f.pattern_name, COALESCE(f.severity, ''),
+			COALESCE(NULLIF(a.environment, ''), f.environment, ''), COALESCE(f.context->>'`+entity.FindingLanguageKey+`', ''), f.created_at
+		FROM finding_review_priorities p
+		JOIN findings f ON f.id = p.finding_id
+		LEFT JOIN assets a ON a.id = f.asset_id
+		WHERE `+where+`
+		ORDER BY p.score DESC, f.created_at ASC, f.id
+		LIMIT $5 OFFSET $6`, tenantID, filter.PIIType, scope, filter.LanguageReview, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list review queue: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*entity.ReviewQueueItem{}
+	for rows.Next() {
+		item := &entity.ReviewQueueItem{}
+		if err := rows.Scan(&item.FindingID, &item.Score, &item.RiskScore, &item.PIIScore, &item.EnvironmentScore,
+			&item.ExposureScore, &item.PIIType, &item.SharingAssets, &item.ComputedAt,
+			&item.AssetID, &item.AssetName, &item.AssetPath, &item.PatternName, &item.Severity,
+			&item.Environment, &item.Language, &item.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		item.TenantID = tenantID
+		items = append(items, item)
+	}
+	return items, total, rows.Err()
+}
@@ -0,0 +1,155 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Review Propagation Repository Implementation
+// ============================================================================
+
+// GetReviewPropagationSettings returns whether the tenant propagates review
+// decisions; tenants that never chose are disabled
+func (r *PostgresRepository) GetReviewPropagationSettings(ctx context.Context) (*entity.ReviewPropagationSettings, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &entity.ReviewPropagationSettings{}
+	var updatedAt sql.NullTime
+	err = r.db.QueryRowContext(ctx,
+		`SELECT enabled, updated_by, updated_at FROM review_propagation_settings WHERE tenant_id = $1`, tenantID,
+	).Scan(&settings.Enabled, &settings.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review propagation settings: %w", err)
+	}
+	if updatedAt.Valid {
+		settings.UpdatedAt = &updatedAt.Time
+	}
+	return settings, nil
+}
+
+// SetReviewPropagationEnabled turns review propagation on or off for the tenant
+func (r *PostgresRepository) SetReviewPropagationEnabled(ctx context.Context, enabled bool, updatedBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO review_propagation_settings (tenant_id, enabled, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, enabled, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to update review propagation settings: %w", err)
+	}
+	return nil
+}
+
+// ListIdenticalFindings returns the tenant's other live findings observed with the
+// same pattern and normalized value hash as the given finding, on any asset
+func (r *PostgresRepository) ListIdenticalFindings(ctx context.Context, findingID uuid.UUID) ([]*entity.IdenticalFinding, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		WITH source AS (
+			SELECT DISTINCT pattern_name, value_hash FROM finding_observations
+			WHERE tenant_id = $1 AND finding_id = $2
+		)
+		SELECT DISTINCT ON (f.id) f.id, f.asset_id, a.name, a.path, f.pattern_name, COALESCE(rs.status, '')
+		FROM source s
+		JOIN finding_observations o
+		  ON o.tenant_id = $1 AND o.pattern_name = s.pattern_name AND o.value_hash = s.value_hash
+		JOIN findings f ON f.id = o.finding_id AND f.deleted_at IS NULL
+		JOIN assets a ON a.id = f.asset_id AND a.deleted_at IS NULL
+		LEFT JOIN LATERAL (
+			SELECT status FROM review_states WHERE finding_id = f.id ORDER BY created_at DESC LIMIT 1
+		) rs ON TRUE
+		WHERE f.id != $2
+		ORDER BY f.id`,
+		tenantID, findingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identical findings: %w", err)
+	}
+	defer rows.Close()
+
+	findings := []*entity.IdenticalFinding{}
+	for rows.Next() {
+		f := &entity.IdenticalFinding{}
+		if err := rows.Scan(&f.FindingID, &f.AssetID, &f.AssetName, &f.AssetPath, &f.PatternName, &f.ReviewStatus); err != nil {
+			return nil, err
+		}
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// PropagateReviewDecision copies a review decision to the target findings that are
+// still unreviewed or pending, returning those it changed. Targets a reviewer
+// decided in the meantime are left alone.
+func (r *PostgresRepository) PropagateReviewDecision(ctx context.Context, sourceID uuid.UUID, status, reviewedBy, comments string, targets []uuid.UUID) ([]uuid.UUID, error) {
+	if len(targets) == 0 {
+		return []uuid.UUID{}, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids := pq.Array(uuidStrings(targets))
+	propagated := []uuid.UUID{}
+	collect := func(rows *sql.Rows, err error) error {
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			propagated = append(propagated, id)
+		}
+		return rows.Err()
+	}
+
+	if err := collect(tx.QueryContext(ctx, `
+		UPDATE review_states
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW(), comments = $3, propagated_from = $4, version = version + 1
+		WHERE finding_id = ANY($5::uuid[]) AND status = 'pending'
+		RETURNING finding_id`,
+		status, reviewedBy, comments, sourceID, ids)); err != nil {
+		return nil, fmt.Errorf("failed to update pending review states: %w", err)
+	}
+	if err := collect(tx.QueryContext(ctx, `
+		INSERT INTO review_states (finding_id, status, reviewed_by, reviewed_at, comments, propagated_from)
+		SELECT t.id, $1, $2, NOW(), $3, $4
+		FROM unnest($5::uuid[]) AS t(id)
+		WHERE NOT EXISTS (SELECT 1 FROM review_states WHERE finding_id = t.id)
+		RETURNING finding_id`,
+		status, reviewedBy, comments, sourceID, ids)); err != nil {
+		return nil, fmt.Errorf("failed to create review states: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return propagated, nil
+}
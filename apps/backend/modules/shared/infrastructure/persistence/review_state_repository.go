@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"errors"
 
 	"database/sql"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrReviewStateConflict is returned when a review state changed since the version being updated was read
+var ErrReviewStateConflict = errors.New("review state was changed by another update")
+
 // ============================================================================
 // ReviewStateRepository Implementation
 // ============================================================================
@@ -17,17 +21,36 @@ func (r *PostgresRepository) CreateReviewState(ctx context.Context, reviewState
 	query := `
 		INSERT INTO review_states (id, finding_id, status, reviewed_by, reviewed_at, comments)
 		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING created_at, updated_at`
+		RETURNING version, created_at, updated_at`
 
 	return r.db.QueryRowContext(ctx, query,
 		reviewState.ID, reviewState.FindingID, reviewState.Status,
 		reviewState.ReviewedBy, reviewState.ReviewedAt, reviewState.Comments,
-	).Scan(&reviewState.CreatedAt, &reviewState.UpdatedAt)
+	).Scan(&reviewState.Version, &reviewState.CreatedAt, &reviewState.UpdatedAt)
+}
+
+// CreateFirstReviewState creates the first review state of a finding, returning
+// ErrReviewStateConflict when another review created one first
+func (r *PostgresRepository) CreateFirstReviewState(ctx context.Context, reviewState *entity.ReviewState) error {
+	query := `
+		INSERT INTO review_states (id, finding_id, status, reviewed_by, reviewed_at, comments)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE NOT EXISTS (SELECT 1 FROM review_states WHERE finding_id = $2)
+		RETURNING version, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		reviewState.ID, reviewState.FindingID, reviewState.Status,
+		reviewState.ReviewedBy, reviewState.ReviewedAt, reviewState.Comments,
+	).Scan(&reviewState.Version, &reviewState.CreatedAt, &reviewState.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrReviewStateConflict
+	}
+	return err
 }
 
 func (r *PostgresRepository) GetReviewStateByFindingID(ctx context.Context, findingID uuid.UUID) (*entity.ReviewState, error) {
 	query := `
-		SELECT id, finding_id, status, reviewed_by, reviewed_at, comments, created_at, updated_at
+		SELECT id, finding_id, status, reviewed_by, reviewed_at, comments, version, propagated_from, created_at, updated_at
 		FROM review_states 
 		WHERE finding_id = $1
 		ORDER BY created_at DESC
@@ -36,7 +59,7 @@ func (r *PostgresRepository) GetReviewStateByFindingID(ctx context.Context, find
 	rs := &entity.ReviewState{}
 	err := r.db.QueryRowContext(ctx, query, findingID).Scan(
 		&rs.ID, &rs.FindingID, &rs.Status, &rs.ReviewedBy,
-		&rs.ReviewedAt, &rs.Comments, &rs.CreatedAt, &rs.UpdatedAt,
+		&rs.ReviewedAt, &rs.Comments, &rs.Version, &rs.PropagatedFrom, &rs.CreatedAt, &rs.UpdatedAt,
 	)
 
 	if err != nil {
@@ -49,15 +72,24 @@ func (r *PostgresRepository) GetReviewStateByFindingID(ctx context.Context, find
 	return rs, nil
 }
 
+// UpdateReviewState saves a review state read at reviewState.Version and bumps the
+// version. It returns ErrReviewStateConflict when the stored version has moved on.
 func (r *PostgresRepository) UpdateReviewState(ctx context.Context, reviewState *entity.ReviewState) error {
 	query := `
 		UPDATE review_states 
-		SET status = $1, reviewed_by = $2, reviewed_at = $3, comments = $4
-		WHERE id = $5`
+		SET status = $1, reviewed_by = $2, reviewed_at = $3, comments = $4, propagated_from = NULL, version = version + 1
+		WHERE id = $5 AND version = $6
+		RETURNING version, updated_at`
 
-	_, err := r.db.ExecContext(ctx, query,
+	err := r.db.QueryRowContext(ctx, query,
 		reviewState.Status, reviewState.ReviewedBy, reviewState.ReviewedAt,
-		reviewState.Comments, reviewState.ID,
-	)
+		reviewState.Comments, reviewState.ID, reviewState.Version,
+	).Scan(&reviewState.Version, &reviewState.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrReviewStateConflict
+	}
+	if err == nil {
+		reviewState.PropagatedFrom = nil
+	}
 	return err
 }
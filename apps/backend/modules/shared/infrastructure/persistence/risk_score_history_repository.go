@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Risk Score History Repository Implementation
+// ============================================================================
+
+// recordRiskChangeSQL completes a statement whose CTEs "prev" and "updated" hold
+// an asset's risk score before and after an update. It appends a history row
+// only when the score actually changed. $3 is the tenant and $4 the cause.
+const recordRiskChangeSQL = `
+		INSERT INTO risk_score_history (tenant_id, asset_id, score, previous_score, cause)
+		SELECT $3, updated.id, updated.risk_score, prev.risk_score, $4
+		FROM updated JOIN prev ON prev.id = updated.id
+		WHERE updated.risk_score IS DISTINCT FROM prev.risk_score`
+
+// ListRiskScoreHistory returns an asset's risk score changes within [since, until], oldest first.
+// When there are more than limit changes, the most recent limit are returned.
+func (r *PostgresRepository) ListRiskScoreHistory(ctx context.Context, assetID uuid.UUID, since, until time.Time, limit int) ([]*entity.RiskScorePoint, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT asset_id, score, previous_score, cause, computed_at
+		FROM (
+			SELECT asset_id, score, previous_score, cause, computed_at, id
+			FROM risk_score_history
+			WHERE asset_id = $1 AND tenant_id = $2 AND computed_at >= $3 AND computed_at <= $4
+			ORDER BY computed_at DESC, id DESC
+			LIMIT $5
+		) recent
+		ORDER BY computed_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, assetID, tenantID, since, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query risk score history: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]*entity.RiskScorePoint, 0)
+	for rows.Next() {
+		point := &entity.RiskScorePoint{}
+		var previous sql.NullInt64
+		if err := rows.Scan(&point.AssetID, &point.Score, &previous, &point.Cause, &point.ComputedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan risk score history: %w", err)
+		}
+		if previous.Valid {
+			prev := int(previous.Int64)
+			point.PreviousScore = &prev
+			point.Delta = point.Score - prev
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
@@ -0,0 +1,327 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Risk Waiver Repository Implementation
+// ============================================================================
+
+const riskWaiverColumns = `w.id, w.tenant_id, w.finding_id, w.pattern_name, w.path_glob, w.host_glob,
+	w.justification, w.status, w.expires_at, w.expiry_warned_at, w.created_by, w.created_at,
+	COALESCE(w.revoked_by, ''), w.revoked_at,
+	(SELECT COUNT(*) FROM finding_waivers fw WHERE fw.waiver_id = w.id)`
+
+// CreateRiskWaiver records a new active waiver
+func (r *PostgresRepository) CreateRiskWaiver(ctx context.Context, waiver *entity.RiskWaiver) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	waiver.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO risk_waivers (id, tenant_id, finding_id, pattern_name, path_glob, host_glob,
+			justification, status, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at`,
+		waiver.ID, waiver.TenantID, waiver.FindingID, waiver.PatternName, waiver.PathGlob, waiver.HostGlob,
+		waiver.Justification, waiver.Status, waiver.ExpiresAt, waiver.CreatedBy,
+	).Scan(&waiver.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create risk waiver: %w", err)
+	}
+	return nil
+}
+
+// GetRiskWaiver returns a waiver with the number of findings it covers
+func (r *PostgresRepository) GetRiskWaiver(ctx context.Context, id uuid.UUID) (*entity.RiskWaiver, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	waiver, err := scanRiskWaiver(r.db.QueryRowContext(ctx, `
+		SELECT `+riskWaiverColumns+` FROM risk_waivers w
+		WHERE w.id = $1 AND w.tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("risk waiver not found")
+	}
+	return waiver, err
+}
+
+// ListRiskWaivers returns the tenant's waivers, soonest to expire first. An empty
+// status lists every waiver.
+func (r *PostgresRepository) ListRiskWaivers(ctx context.Context, status string) ([]*entity.RiskWaiver, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryRiskWaivers(ctx, `
+		SELECT `+riskWaiverColumns+` FROM risk_waivers w
+		WHERE w.tenant_id = $1 AND ($2 = '' OR w.status = $2)
+		ORDER BY w.expires_at, w.id`, tenantID, status)
+}
+
+// ListExpiringRiskWaivers returns active waivers expiring before the given time
+// whose admins have not been alerted yet
+func (r *PostgresRepository) ListExpiringRiskWaivers(ctx context.Context, before time.Time) ([]*entity.RiskWaiver, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryRiskWaivers(ctx, `
+		SELECT `+riskWaiverColumns+` FROM risk_waivers w
+		WHERE w.tenant_id = $1 AND w.status = 'active' AND w.expires_at <= $2 AND w.expiry_warned_at IS NULL
+		ORDER BY w.expires_at, w.id`, tenantID, before)
+}
+
+// MarkRiskWaiverWarned records that admins were alerted about a waiver's expiry
+func (r *PostgresRepository) MarkRiskWaiverWarned(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx,
+		`UPDATE risk_waivers SET expiry_warned_at = NOW() WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	return err
+}
+
+// RevokeRiskWaiver ends an active waiver early; its findings count toward risk again
+func (r *PostgresRepository) RevokeRiskWaiver(ctx context.Context, id uuid.UUID, revokedBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE risk_waivers SET status = 'revoked', revoked_by = $3, revoked_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND status = 'active'`, id, tenantID, revokedBy)
+	if err != nil {
+		return fmt.Errorf("failed to revoke risk waiver: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("active risk waiver not found")
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM finding_waivers WHERE waiver_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to release waived findings: %w", err)
+	}
+	return tx.Commit()
+}
+
+// ExpireRiskWaivers marks the tenant's active waivers past their expiry as expired,
+// releases their findings and returns them
+func (r *PostgresRepository) ExpireRiskWaivers(ctx context.Context, now time.Time) ([]*entity.RiskWaiver, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expired, err := r.queryRiskWaivers(ctx, `
+		SELECT `+riskWaiverColumns+` FROM risk_waivers w
+		WHERE w.tenant_id = $1 AND w.status = 'active' AND w.expires_at <= $2
+		ORDER BY w.expires_at, w.id`, tenantID, now)
+	if err != nil || len(expired) == 0 {
+		return expired, err
+	}
+
+	ids := make([]string, len(expired))
+	for i, w := range expired {
+		ids[i] = w.ID.String()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE risk_waivers SET status = 'expired'
+		WHERE tenant_id = $1 AND id = ANY($2::uuid[]) AND status = 'active'`, tenantID, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to expire risk waivers: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM finding_waivers WHERE waiver_id = ANY($1::uuid[])`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to release waived findings: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	for _, w := range expired {
+		w.Status = entity.RiskWaiverExpired
+	}
+	return expired, nil
+}
+
+// ListWaiverCandidates returns the tenant's unwaived findings of a pattern with the
+// path and host of their assets, for matching against a scope waiver's globs
+func (r *PostgresRepository) ListWaiverCandidates(ctx context.Context, patternName string) ([]*entity.WaiverCandidate, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id, COALESCE(a.path, ''), COALESCE(a.host, '')
+		FROM findings f
+		JOIN assets a ON a.id = f.asset_id
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL AND UPPER(f.pattern_name) = UPPER($2)
+		  AND NOT EXISTS (SELECT 1 FROM finding_waivers fw WHERE fw.finding_id = f.id)`, tenantID, patternName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query waiver candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*entity.WaiverCandidate
+	for rows.Next() {
+		c := &entity.WaiverCandidate{}
+		if err := rows.Scan(&c.FindingID, &c.Path, &c.Host); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// WaiveFindings puts findings under a waiver. Findings already covered by another
+// waiver keep it unless replace is set, as it is for single-finding waivers.
+func (r *PostgresRepository) WaiveFindings(ctx context.Context, waiverID uuid.UUID, findingIDs []uuid.UUID, replace bool) (int, error) {
+	if len(findingIDs) == 0 {
+		return 0, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, len(findingIDs))
+	for i, id := range findingIDs {
+		ids[i] = id.String()
+	}
+	conflict := `DO NOTHING`
+	if replace {
+		conflict = `DO UPDATE SET waiver_id = EXCLUDED.waiver_id, waived_at = NOW()`
+	}
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO finding_waivers (finding_id, waiver_id, tenant_id)
+		SELECT f.id, $2, $1 FROM findings f
+		WHERE f.tenant_id = $1 AND f.id = ANY($3::uuid[])
+		ON CONFLICT (finding_id) `+conflict, tenantID, waiverID, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to waive findings: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return int(n), nil
+}
+
+// GetFindingWaiverIDs returns the waiver covering each of the given findings.
+// Findings without one are left out of the map.
+func (r *PostgresRepository) GetFindingWaiverIDs(ctx context.Context, findingIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	waivers := make(map[uuid.UUID]uuid.UUID)
+	if len(findingIDs) == 0 {
+		return waivers, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(findingIDs))
+	for i, id := range findingIDs {
+		ids[i] = id.String()
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT finding_id, waiver_id FROM finding_waivers
+		WHERE tenant_id = $1 AND finding_id = ANY($2::uuid[])`, tenantID, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query finding waivers: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var findingID, waiverID uuid.UUID
+		if err := rows.Scan(&findingID, &waiverID); err != nil {
+			return nil, err
+		}
+		waivers[findingID] = waiverID
+	}
+	return waivers, rows.Err()
+}
+
+// ListRiskWaiverTenantIDs returns the tenants with active waivers.
+// Runs across tenants for the background waiver sweep.
+func (r *PostgresRepository) ListRiskWaiverTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT tenant_id FROM risk_waivers WHERE status = 'active'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	return tenantIDs, rows.Err()
+}
+
+func (r *PostgresRepository) queryRiskWaivers(ctx context.Context, query string, args ...interface{}) ([]*entity.RiskWaiver, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query risk waivers: %w", err)
+	}
+	defer rows.Close()
+
+	waivers := []*entity.RiskWaiver{}
+	for rows.Next() {
+		waiver, err := scanRiskWaiver(rows)
+		if err != nil {
+			return nil, err
+		}
+		waivers = append(waivers, waiver)
+	}
+	return waivers, rows.Err()
+}
+
+func scanRiskWaiver(row rowScanner) (*entity.RiskWaiver, error) {
+	waiver := &entity.RiskWaiver{}
+	var findingID uuid.NullUUID
+	var warnedAt, revokedAt sql.NullTime
+	err := row.Scan(
+		&waiver.ID, &waiver.TenantID, &findingID, &waiver.PatternName, &waiver.PathGlob, &waiver.HostGlob,
+		&waiver.Justification, &waiver.Status, &waiver.ExpiresAt, &warnedAt, &waiver.CreatedBy, &waiver.CreatedAt,
+		&waiver.RevokedBy, &revokedAt, &waiver.WaivedFindings,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if findingID.Valid {
+		waiver.FindingID = &findingID.UUID
+	}
+	if warnedAt.Valid {
+		waiver.ExpiryWarnedAt = &warnedAt.Time
+	}
+	if revokedAt.Valid {
+		waiver.RevokedAt = &revokedAt.Time
+	}
+	return waiver, nil
+}
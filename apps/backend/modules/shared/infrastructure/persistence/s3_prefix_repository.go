@@ -0,0 +1,192 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// S3 Prefix Repository Implementation
+// ============================================================================
+
+// S3 objects are assets with data source s3, their bucket as host and their key as
+// path. Prefixes are read from keys, so rollups need no table of their own.
+
+// s3RollupColumns totals the objects and findings joined in s3RollupFrom, in
+// s3RollupDest order. Non-PII findings are left out, as in finding lists.
+const s3RollupColumns = `
+	COUNT(DISTINCT a.id),
+	COUNT(f.id),
+	COUNT(f.id) FILTER (WHERE f.severity = 'Critical'),
+	COUNT(f.id) FILTER (WHERE f.severity = 'High'),
+	COUNT(f.id) FILTER (WHERE f.severity = 'Medium'),
+	COUNT(f.id) FILTER (WHERE f.severity = 'Low'),
+	COALESCE(MAX(a.risk_score), 0),
+	COUNT(DISTINCT f.asset_id)`
+
+const s3RollupFrom = `
+	FROM assets a
+	LEFT JOIN findings f ON f.asset_id = a.id AND f.deleted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM classifications c WHERE c.finding_id = f.id AND c.classification_type = 'Non-PII')`
+
+// s3ObjectCondition limits assets aliased a to the tenant's S3 objects, in bucket
+// and under prefix when given, and to the unit ctx is scoped to
+func s3ObjectCondition(ctx context.Context, tenantID uuid.UUID, bucket, prefix string) (string, []interface{}) {
+	args := []interface{}{tenantID}
+	where := ` WHERE a.tenant_id = $1 AND a.deleted_at IS NULL AND a.data_source = 's3' AND a.host <> ''`
+	if bucket != "" {
+		args = append(args, bucket)
+		where += fmt.Sprintf(" AND a.host = $%d", len(args))
+	}
+	if prefix != "" {
+		args = append(args, likePrefix(prefix))
+		where += fmt.Sprintf(" AND a.path LIKE $%d", len(args))
+	}
+	if scope, ok := OrgUnitScope(ctx); ok {
+		args = append(args, scope)
+		where += " AND " + orgUnitAssetCondition("a.id", len(args))
+	}
+	return where, args
+}
+
+// likePrefix matches strings starting with prefix in a LIKE pattern
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
+
+// s3RollupDest is where s3RollupColumns are scanned to, after any leading columns
+func s3RollupDest(rollup *entity.S3PrefixRollup, leading ...interface{}) []interface{} {
+	return append(leading, &rollup.Objects, &rollup.Findings, &rollup.Critical, &rollup.High, &rollup.Medium, &rollup.Low,
+		&rollup.MaxRiskScore, &rollup.ExposedObjects)
+}
+
+// ListS3Buckets rolls up the tenant's S3 objects and findings by bucket, most findings first
+func (r *PostgresRepository) ListS3Buckets(ctx context.Context) (rollups []entity.S3PrefixRollup, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := s3ObjectCondition(ctx, tenantID, "", "")
+	query := `SELECT a.host, ` + s3RollupColumns + s3RollupFrom + where + `
+		GROUP BY a.host
+		ORDER BY 3 DESC, a.host`
+
+	ctx, done := beginQuery(ctx, QueryInteractive, "list_s3_buckets")
+	defer func() { done(err) }()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list S3 buckets: %w", err)
+	}
+	defer rows.Close()
+
+	rollups = []entity.S3PrefixRollup{}
+	for rows.Next() {
+		var rollup entity.S3PrefixRollup
+		if err := rows.Scan(s3RollupDest(&rollup, &rollup.Bucket)...); err != nil {
+			return nil, err
+		}
+		rollups = append(rollups, rollup)
+	}
+	return rollups, rows.Err()
+}
+
+// GetS3PrefixRollup totals the objects and findings under a prefix of a bucket;
+// an empty prefix totals the whole bucket
+func (r *PostgresRepository) GetS3PrefixRollup(ctx context.Context, bucket, prefix string) (rollup *entity.S3PrefixRollup, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := s3ObjectCondition(ctx, tenantID, bucket, prefix)
+	query := `SELECT ` + s3RollupColumns + s3RollupFrom + where
+
+	ctx, done := beginQuery(ctx, QueryInteractive, "get_s3_prefix_rollup")
+	defer func() { done(err) }()
+
+	rollup = &entity.S3PrefixRollup{Bucket: bucket, Prefix: prefix}
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(s3RollupDest(rollup)...); err != nil {
+		return nil, fmt.Errorf("failed to roll up S3 prefix: %w", err)
+	}
+	return rollup, nil
+}
+
+// ListS3ChildPrefixes rolls up the prefixes directly under prefix in a bucket, one
+// level deeper: under "exports/" they are "exports/2024/", "exports/2025/" and so on
+func (r *PostgresRepository) ListS3ChildPrefixes(ctx context.Context, bucket, prefix string) (rollups []entity.S3PrefixRollup, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := s3ObjectCondition(ctx, tenantID, bucket, prefix)
+	args = append(args, prefix)
+	rest := fmt.Sprintf("substr(a.path, length($%d::text) + 1)", len(args))
+	query := fmt.Sprintf(`SELECT $%d::text || split_part(%s, '/', 1) || '/' AS child, `, len(args), rest) +
+		s3RollupColumns + s3RollupFrom + where + fmt.Sprintf(` AND strpos(%s, '/') > 0
+		GROUP BY child
+		ORDER BY 3 DESC, child`, rest)
+
+	ctx, done := beginQuery(ctx, QueryInteractive, "list_s3_child_prefixes")
+	defer func() { done(err) }()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list S3 prefixes: %w", err)
+	}
+	defer rows.Close()
+
+	rollups = []entity.S3PrefixRollup{}
+	for rows.Next() {
+		rollup := entity.S3PrefixRollup{Bucket: bucket}
+		if err := rows.Scan(s3RollupDest(&rollup, &rollup.Prefix)...); err != nil {
+			return nil, err
+		}
+		rollups = append(rollups, rollup)
+	}
+	return rollups, rows.Err()
+}
+
+// ListS3Objects lists the objects under prefix in a bucket, riskiest first. Unless
+// recursive, only objects stored directly at the prefix are listed, not those
+// under deeper prefixes.
+func (r *PostgresRepository) ListS3Objects(ctx context.Context, bucket, prefix string, recursive bool, limit, offset int) (assets []*entity.Asset, err error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := s3ObjectCondition(ctx, tenantID, bucket, prefix)
+	if !recursive {
+		args = append(args, prefix)
+		where += fmt.Sprintf(" AND strpos(substr(a.path, length($%d::text) + 1), '/') = 0", len(args))
+	}
+	query := `
+		SELECT a.id, a.tenant_id, a.stable_id, a.asset_type, a.name, a.path, a.data_source, a.host,
+			a.environment, a.owner, a.source_system, a.file_metadata, a.risk_score, a.total_findings, a.created_at, a.updated_at
+		FROM assets a` + where + fmt.Sprintf(`
+		ORDER BY a.risk_score DESC, a.path
+		LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	ctx, done := beginQuery(ctx, QueryInteractive, "list_s3_objects")
+	defer func() { done(err) }()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list S3 objects: %w", err)
+	}
+	defer rows.Close()
+
+	assets, err = scanAssetRows(rows)
+	if assets == nil && err == nil {
+		assets = []*entity.Asset{}
+	}
+	return assets, err
+}
@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var s3RollupRowColumns = []string{"objects", "findings", "critical", "high", "medium", "low", "max_risk", "exposed"}
+
+func TestListS3ChildPrefixes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	// LIKE wildcards in the prefix match literally; only keys one level deeper group
+	mock.ExpectQuery(`SELECT \$4::text \|\| split_part\(substr\(a.path, length\(\$4::text\) \+ 1\), '/', 1\) \|\| '/' AS child.*a.host = \$2 AND a.path LIKE \$3 AND strpos`).
+		WithArgs(tenantID, "finance", `raw\_exports/%`, "raw_exports/").
+		WillReturnRows(sqlmock.NewRows(append([]string{"child"}, s3RollupRowColumns...)).
+			AddRow("raw_exports/2024/", 12, 40, 3, 10, 20, 7, 85, 9).
+			AddRow("raw_exports/2025/", 4, 2, 0, 0, 2, 0, 30, 1))
+
+	rollups, err := repo.ListS3ChildPrefixes(ctx, "finance", "raw_exports/")
+	assert.NoError(t, err)
+	assert.Len(t, rollups, 2)
+	assert.Equal(t, "finance", rollups[0].Bucket)
+	assert.Equal(t, "raw_exports/2024/", rollups[0].Prefix)
+	assert.Equal(t, 40, rollups[0].Findings)
+	assert.Equal(t, 3, rollups[0].Critical)
+	assert.Equal(t, 9, rollups[0].ExposedObjects)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListS3Objects_DirectlyAtPrefix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	mock.ExpectQuery(`a.host = \$2 AND a.path LIKE \$3 AND strpos\(substr\(a.path, length\(\$4::text\) \+ 1\), '/'\) = 0\s+ORDER BY a.risk_score DESC, a.path\s+LIMIT \$5 OFFSET \$6`).
+		WithArgs(tenantID, "finance", "exports/%", "exports/", 50, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	assets, err := repo.ListS3Objects(ctx, "finance", "exports/", false, 50, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, assets)
+	assert.NotNil(t, assets)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountFindings_InS3Prefix(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	mock.ExpectQuery(`f.asset_id IN \(\s+SELECT a.id FROM assets a\s+WHERE a.tenant_id = \$1 AND a.data_source = 's3' AND a.host = \$2 AND a.path LIKE \$3\)`).
+		WithArgs(tenantID, "finance", "exports/%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+	count, err := repo.CountFindings(ctx, repository.FindingFilters{Bucket: "finance", Prefix: "exports/"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Sample Text Policy Repository Implementation
+// ============================================================================
+
+// GetTenantSampleTextPolicy returns the tenant's sample text storage choice, or nil
+func (r *PostgresRepository) GetTenantSampleTextPolicy(ctx context.Context) (*entity.TenantSampleTextPolicy, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &entity.TenantSampleTextPolicy{TenantID: tenantID}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT store_full, updated_by, updated_at
+		FROM tenant_sample_text_policies WHERE tenant_id = $1`, tenantID,
+	).Scan(&policy.StoreFull, &policy.UpdatedBy, &policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant sample text policy: %w", err)
+	}
+	return policy, nil
+}
+
+// SetTenantSampleTextPolicy records whether the tenant stores full sample text
+func (r *PostgresRepository) SetTenantSampleTextPolicy(ctx context.Context, storeFull bool, updatedBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO tenant_sample_text_policies (tenant_id, store_full, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET store_full = EXCLUDED.store_full, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, storeFull, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant sample text policy: %w", err)
+	}
+	return nil
+}
+
+// ListUnredactedSamples returns samples stored before redaction applied to them.
+// Tenants storing full samples are skipped; a nil tenantID spans all tenants.
+func (r *PostgresRepository) ListUnredactedSamples(ctx context.Context, tenantID *uuid.UUID, limit int) ([]entity.StoredSample, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id, f.tenant_id, f.sample_text, COALESCE(f.sample_text_sha256, ''), f.matches, f.pattern_name
+		FROM findings f
+		LEFT JOIN tenant_sample_text_policies p ON p.tenant_id = f.tenant_id
+		WHERE f.sample_text_redacted = FALSE AND f.sample_text <> ''
+		  AND COALESCE(p.store_full, FALSE) = FALSE
+		  AND ($1::uuid IS NULL OR f.tenant_id = $1)
+		ORDER BY f.id
+		LIMIT $2`, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unredacted samples: %w", err)
+	}
+	defer rows.Close()
+
+	samples := []entity.StoredSample{}
+	for rows.Next() {
+		var sample entity.StoredSample
+		if err := rows.Scan(&sample.FindingID, &sample.TenantID, &sample.SampleText, &sample.SHA256, pq.Array(&sample.Matches), &sample.PIIType); err != nil {
+			return nil, err
+		}
+		if err := openFindingValues(ctx, r.db, sample.TenantID, sample.Matches, &sample.SampleText); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
+
+// SaveRedactedSamples stores redacted samples, keeping the hash of the original
+func (r *PostgresRepository) SaveRedactedSamples(ctx context.Context, samples []entity.StoredSample) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		UPDATE findings
+		SET sample_text = $2, sample_text_sha256 = COALESCE(sample_text_sha256, NULLIF($3, '')),
+		    sample_text_redacted = TRUE
+		WHERE id = $1`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, sample := range samples {
+		// The redacted sample is sealed again under the tenant's active key, if any
+		_, sampleText, _, err := sealFindingValues(ctx, tx, sample.TenantID, nil, sample.SampleText)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, sample.FindingID, sampleText, sample.SHA256); err != nil {
+			return fmt.Errorf("failed to redact sample of finding %s: %w", sample.FindingID, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,444 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Scan Anomaly Repository Implementation
+// ============================================================================
+
+// scanRunMatchCountsSQL sums a scan run's matches per asset and PII type, the
+// PII type being the top classification's sub-category, else the pattern name.
+// A finding stores at least one match.
+const scanRunMatchCountsSQL = `
+		SELECT f.scan_run_id, f.asset_id, COALESCE(NULLIF(c.sub_category, ''), f.pattern_name),
+			SUM(GREATEST(COALESCE(f.total_matches, cardinality(f.matches)), 1))
+		FROM findings f
+		LEFT JOIN LATERAL (
+			SELECT sub_category FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND f.scan_run_id = ANY($2::uuid[]) AND f.deleted_at IS NULL`
+
+const scanAnomalyColumns = `
+	sa.id, sa.tenant_id, sa.scan_run_id, sa.scope, sa.asset_id, COALESCE(a.name, ''), sa.connection_id, sa.pii_type,
+	sa.observed_matches, sa.baseline_matches, sa.baseline_runs, sa.ratio, sa.status, sa.suppression_id,
+	sa.detected_at, COALESCE(sa.acknowledged_by, ''), sa.acknowledged_at`
+
+func scanScanAnomaly(row interface{ Scan(...interface{}) error }) (*entity.ScanAnomaly, error) {
+	a := &entity.ScanAnomaly{}
+	err := row.Scan(&a.ID, &a.TenantID, &a.ScanRunID, &a.Scope, &a.AssetID, &a.AssetName, &a.ConnectionID, &a.PIIType,
+		&a.ObservedMatches, &a.BaselineMatches, &a.BaselineRuns, &a.Ratio, &a.Status, &a.SuppressionID,
+		&a.DetectedAt, &a.AcknowledgedBy, &a.AcknowledgedAt)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetScanAnomalySettings returns the tenant's anomaly detection settings, or nil
+// while it uses the defaults
+func (r *PostgresRepository) GetScanAnomalySettings(ctx context.Context) (*entity.ScanAnomalySettings, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &entity.ScanAnomalySettings{TenantID: tenantID}
+	var factors []byte
+	var updatedAt time.Time
+	err = r.db.QueryRowContext(ctx, `
+		SELECT enabled, spike_factor, min_matches, baseline_runs, pii_spike_factors, updated_by, updated_at
+		FROM scan_anomaly_settings WHERE tenant_id = $1`, tenantID,
+	).Scan(&settings.Enabled, &settings.SpikeFactor, &settings.MinMatches, &settings.BaselineRuns,
+		&factors, &settings.UpdatedBy, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scan anomaly settings: %w", err)
+	}
+	if err := json.Unmarshal(factors, &settings.PIISpikeFactors); err != nil {
+		return nil, fmt.Errorf("failed to decode PII spike factors: %w", err)
+	}
+	settings.UpdatedAt = &updatedAt
+	return settings, nil
+}
+
+// SetScanAnomalySettings stores the tenant's anomaly detection settings
+func (r *PostgresRepository) SetScanAnomalySettings(ctx context.Context, settings *entity.ScanAnomalySettings) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	factors, err := json.Marshal(settings.PIISpikeFactors)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO scan_anomaly_settings
+			(tenant_id, enabled, spike_factor, min_matches, baseline_runs, pii_spike_factors, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, spike_factor = EXCLUDED.spike_factor, min_matches = EXCLUDED.min_matches,
+		    baseline_runs = EXCLUDED.baseline_runs, pii_spike_factors = EXCLUDED.pii_spike_factors,
+		    updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, settings.Enabled, settings.SpikeFactor, settings.MinMatches, settings.BaselineRuns,
+		factors, settings.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set scan anomaly settings: %w", err)
+	}
+	return nil
+}
+
+// CreateScanAnomalySuppression stores a suppression window
+func (r *PostgresRepository) CreateScanAnomalySuppression(ctx context.Context, s *entity.ScanAnomalySuppression) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	s.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO scan_anomaly_suppressions
+			(id, tenant_id, asset_id, connection_id, pii_type, starts_at, ends_at, reason, created_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9)
+		RETURNING created_at`,
+		s.ID, s.TenantID, s.AssetID, s.ConnectionID, s.PIIType, s.StartsAt, s.EndsAt, s.Reason, s.CreatedBy,
+	).Scan(&s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create scan anomaly suppression: %w", err)
+	}
+	return nil
+}
+
+// ListScanAnomalySuppressions returns the tenant's suppression windows ending
+// after endsAfter, soonest first
+func (r *PostgresRepository) ListScanAnomalySuppressions(ctx context.Context, endsAfter time.Time) ([]*entity.ScanAnomalySuppression, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, tenant_id, asset_id, connection_id, COALESCE(pii_type, ''), starts_at, ends_at, reason, created_by, created_at
+		FROM scan_anomaly_suppressions
+		WHERE tenant_id = $1 AND ends_at > $2
+		ORDER BY starts_at, id`, tenantID, endsAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scan anomaly suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	suppressions := make([]*entity.ScanAnomalySuppression, 0)
+	for rows.Next() {
+		s := &entity.ScanAnomalySuppression{}
+		if err := rows.Scan(&s.ID, &s.TenantID, &s.AssetID, &s.ConnectionID, &s.PIIType,
+			&s.StartsAt, &s.EndsAt, &s.Reason, &s.CreatedBy, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		suppressions = append(suppressions, s)
+	}
+	return suppressions, rows.Err()
+}
+
+// DeleteScanAnomalySuppression removes a suppression window, reporting whether it existed.
+// Anomalies it suppressed stay suppressed.
+func (r *PostgresRepository) DeleteScanAnomalySuppression(ctx context.Context, id uuid.UUID) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM scan_anomaly_suppressions WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete scan anomaly suppression: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ListScanAnomalyTenantIDs returns the tenants with completed scan runs awaiting
+// anomaly detection (used by the detection worker)
+func (r *PostgresRepository) ListScanAnomalyTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT tenant_id FROM scan_runs
+		WHERE anomaly_checked_at IS NULL AND status = 'completed' AND tenant_id IS NOT NULL
+		ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scan anomaly tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	return tenantIDs, rows.Err()
+}
+
+// ListUncheckedScanRuns returns up to limit of the tenant's completed scan runs
+// awaiting anomaly detection, oldest first
+func (r *PostgresRepository) ListUncheckedScanRuns(ctx context.Context, limit int) ([]*entity.ScanRun, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, tenant_id, connection_id, scan_completed_at FROM scan_runs
+		WHERE tenant_id = $1 AND anomaly_checked_at IS NULL AND status = 'completed' AND deleted_at IS NULL
+		ORDER BY scan_completed_at, id
+		LIMIT $2`, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unchecked scan runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*entity.ScanRun
+	for rows.Next() {
+		run := &entity.ScanRun{Status: "completed"}
+		if err := rows.Scan(&run.ID, &run.TenantID, &run.ConnectionID, &run.ScanCompletedAt); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// ListScanRunMatchCounts returns the matches per asset and PII type of scan runs
+func (r *PostgresRepository) ListScanRunMatchCounts(ctx context.Context, scanRunIDs []uuid.UUID) ([]entity.ScanRunMatchCount, error) {
+	if len(scanRunIDs) == 0 {
+		return nil, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, scanRunMatchCountsSQL+`
+		GROUP BY 1, 2, 3`, tenantID, pq.Array(uuidStrings(scanRunIDs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count scan run matches: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []entity.ScanRunMatchCount
+	for rows.Next() {
+		var c entity.ScanRunMatchCount
+		if err := rows.Scan(&c.ScanRunID, &c.AssetID, &c.PIIType, &c.Matches); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// ListPriorAssetScanRuns returns, for each asset with findings in run, the up to
+// limit completed scan runs before it that found something on the asset, most
+// recent first
+func (r *PostgresRepository) ListPriorAssetScanRuns(ctx context.Context, run *entity.ScanRun, limit int) (map[uuid.UUID][]uuid.UUID, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT asset_id, scan_run_id FROM (
+			SELECT ar.asset_id, ar.scan_run_id,
+				ROW_NUMBER() OVER (PARTITION BY ar.asset_id ORDER BY sr.scan_completed_at DESC, sr.id) AS rn
+			FROM (
+				SELECT DISTINCT f.asset_id, f.scan_run_id FROM findings f
+				WHERE f.tenant_id = $1 AND f.scan_run_id != $2 AND f.deleted_at IS NULL
+				  AND f.asset_id IN (SELECT asset_id FROM findings WHERE scan_run_id = $2)
+			) ar
+			JOIN scan_runs sr ON sr.id = ar.scan_run_id
+			WHERE sr.status = 'completed' AND sr.scan_completed_at < $3
+		) ranked
+		WHERE rn <= $4
+		ORDER BY asset_id, rn`, tenantID, run.ID, run.ScanCompletedAt, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prior asset scan runs: %w", err)
+	}
+	defer rows.Close()
+
+	prior := make(map[uuid.UUID][]uuid.UUID)
+	for rows.Next() {
+		var assetID, runID uuid.UUID
+		if err := rows.Scan(&assetID, &runID); err != nil {
+			return nil, err
+		}
+		prior[assetID] = append(prior[assetID], runID)
+	}
+	return prior, rows.Err()
+}
+
+// ListPriorConnectionScanRuns returns the up to limit completed scan runs of a
+// connection before run, most recent first
+func (r *PostgresRepository) ListPriorConnectionScanRuns(ctx context.Context, run *entity.ScanRun, limit int) ([]uuid.UUID, error) {
+	if run.ConnectionID == nil {
+		return nil, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id FROM scan_runs
+		WHERE tenant_id = $1 AND connection_id = $2 AND id != $3 AND status = 'completed'
+		  AND scan_completed_at < $4 AND deleted_at IS NULL
+		ORDER BY scan_completed_at DESC, id
+		LIMIT $5`, tenantID, *run.ConnectionID, run.ID, run.ScanCompletedAt, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prior connection scan runs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RecordScanAnomalies stores the anomalies detected in a scan run and marks it
+// checked, in one transaction. Anomalies already recorded for the run (by a
+// concurrent detection) are skipped; the ones inserted are returned.
+func (r *PostgresRepository) RecordScanAnomalies(ctx context.Context, scanRunID uuid.UUID, anomalies []*entity.ScanAnomaly) ([]*entity.ScanAnomaly, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE scan_runs SET anomaly_checked_at = NOW()
+		WHERE id = $1 AND tenant_id = $2 AND anomaly_checked_at IS NULL`, scanRunID, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark scan run checked: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		// Another replica checked the run first
+		return nil, err
+	}
+
+	var recorded []*entity.ScanAnomaly
+	for _, a := range anomalies {
+		a.TenantID = tenantID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO scan_anomalies (id, tenant_id, scan_run_id, scope, asset_id, connection_id, pii_type,
+				observed_matches, baseline_matches, baseline_runs, ratio, status, suppression_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			ON CONFLICT DO NOTHING
+			RETURNING detected_at`,
+			a.ID, a.TenantID, scanRunID, a.Scope, a.AssetID, a.ConnectionID, a.PIIType,
+			a.ObservedMatches, a.BaselineMatches, a.BaselineRuns, a.Ratio, a.Status, a.SuppressionID,
+		).Scan(&a.DetectedAt)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record scan anomaly: %w", err)
+		}
+		recorded = append(recorded, a)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit scan anomalies: %w", err)
+	}
+	return recorded, nil
+}
+
+// ListScanAnomalies returns a page of the tenant's anomalies, newest first, and how many match
+func (r *PostgresRepository) ListScanAnomalies(ctx context.Context, filter entity.ScanAnomalyFilter) ([]*entity.ScanAnomaly, int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	where := `sa.tenant_id = $1 AND ($2 = '' OR sa.status = $2) AND ($3::uuid IS NULL OR sa.scan_run_id = $3::uuid)`
+	var scanRunID interface{}
+	if filter.ScanRunID != nil {
+		scanRunID = *filter.ScanRunID
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM scan_anomalies sa WHERE `+where,
+		tenantID, filter.Status, scanRunID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count scan anomalies: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+scanAnomalyColumns+`
+		FROM scan_anomalies sa
+		LEFT JOIN assets a ON a.id = sa.asset_id
+		WHERE `+where+`
+		ORDER BY sa.detected_at DESC, sa.id
+		LIMIT $4 OFFSET $5`, tenantID, filter.Status, scanRunID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list scan anomalies: %w", err)
+	}
+	defer rows.Close()
+
+	anomalies := make([]*entity.ScanAnomaly, 0)
+	for rows.Next() {
+		a, err := scanScanAnomaly(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		anomalies = append(anomalies, a)
+	}
+	return anomalies, total, rows.Err()
+}
+
+// AcknowledgeScanAnomaly marks an open anomaly acknowledged. Returns nil when the
+// tenant has no such open anomaly.
+func (r *PostgresRepository) AcknowledgeScanAnomaly(ctx context.Context, id uuid.UUID, acknowledgedBy string) (*entity.ScanAnomaly, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := scanScanAnomaly(r.db.QueryRowContext(ctx, `
+		WITH sa AS (
+			UPDATE scan_anomalies SET status = 'acknowledged', acknowledged_by = $3, acknowledged_at = NOW()
+			WHERE id = $1 AND tenant_id = $2 AND status = 'open'
+			RETURNING *
+		)
+		SELECT `+scanAnomalyColumns+`
+		FROM sa LEFT JOIN assets a ON a.id = sa.asset_id`, id, tenantID, acknowledgedBy))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge scan anomaly: %w", err)
+	}
+	return a, nil
+}
@@ -0,0 +1,111 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Scan Performance Repository Implementation
+// ============================================================================
+
+// SetScanRunPerformance replaces the performance profile in a scan run's metadata.
+// Unlike UpdateScanRun it also writes completed runs, whose lineage syncs may
+// finish after they are saved; the rest of the metadata is left as it is.
+func (r *PostgresRepository) SetScanRunPerformance(ctx context.Context, scanRunID uuid.UUID, perf *entity.ScanPerformance) error {
+	perfJSON, err := json.Marshal(perf)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scan performance: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE scan_runs
+		SET metadata = jsonb_set(COALESCE(metadata, '{}'::jsonb), '{performance}', $2::jsonb)
+		WHERE id = $1`, scanRunID, perfJSON)
+	if err != nil {
+		return fmt.Errorf("failed to record scan performance: %w", err)
+	}
+	return nil
+}
+
+// GetScanRunPerformance returns the performance profile of one of the tenant's scan
+// runs, or nil when the run does not exist or was ingested before runs were profiled
+func (r *PostgresRepository) GetScanRunPerformance(ctx context.Context, scanRunID uuid.UUID) (*entity.ScanPerformance, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var perfJSON []byte
+	err = r.db.QueryRowContext(ctx, `
+		SELECT metadata->'performance' FROM scan_runs
+		WHERE id = $1 AND COALESCE(tenant_id, $2) = $2 AND deleted_at IS NULL`,
+		scanRunID, tenantID).Scan(&perfJSON)
+	if err == sql.ErrNoRows || (err == nil && len(perfJSON) == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scan performance: %w", err)
+	}
+
+	perf := &entity.ScanPerformance{}
+	if err := json.Unmarshal(perfJSON, perf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scan performance: %w", err)
+	}
+	return perf, nil
+}
+
+// GetScanPerformanceTrends aggregates the performance profiles of the tenant's scan
+// runs started since the given time per UTC period ("day" or "week"). Runs without
+// a profile are left out; periods without profiled runs are omitted.
+func (r *PostgresRepository) GetScanPerformanceTrends(ctx context.Context, since time.Time, period string) ([]entity.ScanPerformanceTrendPoint, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if period != "day" && period != "week" {
+		return nil, fmt.Errorf("invalid scan performance period %q", period)
+	}
+
+	query := `
+		SELECT date_trunc($3, scan_started_at AT TIME ZONE 'UTC'), COUNT(*),
+			COALESCE(SUM((p->>'findings')::bigint), 0),
+			COALESCE(AVG((p->>'findings_per_second')::float8), 0),
+			COALESCE(AVG((p->>'duration_ms')::float8), 0),
+			COALESCE(percentile_cont(0.95) WITHIN GROUP (ORDER BY (p->>'duration_ms')::float8), 0),
+			COALESCE(AVG((p->>'db_ms')::float8), 0),
+			COALESCE(AVG((p->>'classification_ms')::float8), 0),
+			COALESCE(AVG((p->>'neo4j_sync_ms')::float8), 0),
+			COALESCE(AVG((p->>'queue_wait_ms')::float8), 0),
+			COALESCE(MAX((p->>'queue_wait_ms')::bigint), 0)
+		FROM (
+			SELECT scan_started_at, metadata->'performance' AS p FROM scan_runs
+			WHERE COALESCE(tenant_id, $1) = $1 AND deleted_at IS NULL AND scan_started_at >= $2
+		) runs
+		WHERE p IS NOT NULL
+		GROUP BY 1
+		ORDER BY 1`
+
+	points := []entity.ScanPerformanceTrendPoint{}
+	err = r.scanGroupedCounts(ctx, query, []interface{}{tenantID, since, period}, func(rows *sql.Rows) error {
+		var p entity.ScanPerformanceTrendPoint
+		if err := rows.Scan(&p.Period, &p.Scans, &p.Findings, &p.AvgFindingsPerSecond, &p.AvgDurationMs,
+			&p.P95DurationMs, &p.AvgDBMs, &p.AvgClassificationMs, &p.AvgNeo4jSyncMs,
+			&p.AvgQueueWaitMs, &p.MaxQueueWaitMs); err != nil {
+			return err
+		}
+		p.Period = time.Date(p.Period.Year(), p.Period.Month(), p.Period.Day(), 0, 0, 0, 0, time.UTC)
+		points = append(points, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scan performance trends: %w", err)
+	}
+	return points, nil
+}
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetScanRunPerformance_MergesIntoMetadata(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	id := uuid.New()
+	repo := NewPostgresRepository(db)
+
+	// Only the performance key is replaced, and completed runs are written too
+	mock.ExpectExec(`UPDATE scan_runs\s+SET metadata = jsonb_set\(COALESCE\(metadata, '\{\}'::jsonb\), '\{performance\}', \$2::jsonb\)\s+WHERE id = \$1$`).
+		WithArgs(id, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetScanRunPerformance(context.Background(), id, &entity.ScanPerformance{Findings: 10, Neo4jSyncMs: 40})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetScanRunPerformance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID, id := uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+
+	query := `SELECT metadata->'performance' FROM scan_runs\s+WHERE id = \$1 AND COALESCE\(tenant_id, \$2\) = \$2 AND deleted_at IS NULL`
+	mock.ExpectQuery(query).WithArgs(id, tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"performance"}).AddRow([]byte(`{"findings":120,"findings_per_second":60,"db_ms":900}`)))
+	perf, err := repo.GetScanRunPerformance(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.ScanPerformance{Findings: 120, FindingsPerSecond: 60, DBMs: 900}, perf)
+
+	// Runs ingested before profiling have no performance key
+	mock.ExpectQuery(query).WithArgs(id, tenantID).
+		WillReturnRows(sqlmock.NewRows([]string{"performance"}).AddRow(nil))
+	perf, err = repo.GetScanRunPerformance(ctx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, perf)
+
+	mock.ExpectQuery(query).WithArgs(id, tenantID).WillReturnError(sql.ErrNoRows)
+	perf, err = repo.GetScanRunPerformance(ctx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, perf)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetScanPerformanceTrends(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	tenantID := uuid.New()
+	ctx := context.WithValue(context.Background(), "tenant_id", tenantID)
+	repo := NewPostgresRepository(db)
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	day := time.Date(2026, 10, 2, 0, 0, 0, 0, time.Local)
+	mock.ExpectQuery(`FROM \(\s+SELECT scan_started_at, metadata->'performance' AS p FROM scan_runs\s+WHERE COALESCE\(tenant_id, \$1\) = \$1 AND deleted_at IS NULL AND scan_started_at >= \$2\s+\) runs\s+WHERE p IS NOT NULL`).
+		WithArgs(tenantID, since, "week").
+		WillReturnRows(sqlmock.NewRows([]string{"period", "scans", "findings", "fps", "duration", "p95", "db", "classification", "neo4j", "queue", "max_queue"}).
+			AddRow(day, 3, 900, 45.5, 20000.0, 31000.0, 12000.0, 5000.0, 2500.0, 150.0, 400))
+
+	points, err := repo.GetScanPerformanceTrends(ctx, since, "week")
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ScanPerformanceTrendPoint{{
+		Period: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC), Scans: 3, Findings: 900,
+		AvgFindingsPerSecond: 45.5, AvgDurationMs: 20000, P95DurationMs: 31000, AvgDBMs: 12000,
+		AvgClassificationMs: 5000, AvgNeo4jSyncMs: 2500, AvgQueueWaitMs: 150, MaxQueueWaitMs: 400,
+	}}, points)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = repo.GetScanPerformanceTrends(ctx, since, "month")
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// ScanProfileRepository Implementation
+// ============================================================================
+
+var (
+	ErrScanProfileNotFound = errors.New("scan profile not found")
+	ErrScanProfileExists   = errors.New("a scan profile with this name already exists for the connection")
+)
+
+const scanProfileColumns = `id, COALESCE(tenant_id, '00000000-0000-0000-0000-000000000000'::uuid), connection_id, name,
+	COALESCE(description, ''), include_globs, exclude_globs, table_allow_list, table_deny_list,
+	sample_size, pii_types, is_default, created_by, created_at, updated_at`
+
+// CreateScanProfile stores a new scan profile for a connection
+func (r *PostgresRepository) CreateScanProfile(ctx context.Context, profile *entity.ScanProfile) error {
+	// Connections are not tenant-scoped yet; record the tenant when one is present
+	if tenantID, err := GetTenantID(ctx); err == nil {
+		profile.TenantID = tenantID
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if profile.IsDefault {
+		if err := clearDefaultScanProfile(ctx, tx, profile.ConnectionID, profile.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		INSERT INTO scan_profiles (id, tenant_id, connection_id, name, description, include_globs, exclude_globs,
+			table_allow_list, table_deny_list, sample_size, pii_types, is_default, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		profile.ID, profile.TenantID, profile.ConnectionID, profile.Name, profile.Description,
+		pq.Array(profile.IncludeGlobs), pq.Array(profile.ExcludeGlobs),
+		pq.Array(profile.TableAllowList), pq.Array(profile.TableDenyList),
+		profile.SampleSize, pq.Array(profile.PIITypes), profile.IsDefault, profile.CreatedBy,
+	).Scan(&profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrScanProfileExists
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetScanProfile retrieves a scan profile by ID
+func (r *PostgresRepository) GetScanProfile(ctx context.Context, id uuid.UUID) (*entity.ScanProfile, error) {
+	query := `SELECT ` + scanProfileColumns + ` FROM scan_profiles WHERE id = $1`
+
+	profile, err := scanScanProfile(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrScanProfileNotFound
+	}
+	return profile, err
+}
+
+// GetScanProfileByName retrieves a connection's scan profile by name
+func (r *PostgresRepository) GetScanProfileByName(ctx context.Context, connectionID uuid.UUID, name string) (*entity.ScanProfile, error) {
+	query := `SELECT ` + scanProfileColumns + ` FROM scan_profiles WHERE connection_id = $1 AND name = $2`
+
+	profile, err := scanScanProfile(r.db.QueryRowContext(ctx, query, connectionID, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return profile, err
+}
+
+// GetDefaultScanProfile retrieves the default profile of a connection, or nil if none is set
+func (r *PostgresRepository) GetDefaultScanProfile(ctx context.Context, connectionID uuid.UUID) (*entity.ScanProfile, error) {
+	query := `SELECT ` + scanProfileColumns + ` FROM scan_profiles WHERE connection_id = $1 AND is_default`
+
+	profile, err := scanScanProfile(r.db.QueryRowContext(ctx, query, connectionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return profile, err
+}
+
+// ListScanProfilesByConnection retrieves all profiles attached to a connection
+func (r *PostgresRepository) ListScanProfilesByConnection(ctx context.Context, connectionID uuid.UUID) ([]*entity.ScanProfile, error) {
+	query := `SELECT ` + scanProfileColumns + ` FROM scan_profiles WHERE connection_id = $1 ORDER BY is_default DESC, name`
+
+	rows, err := r.db.QueryContext(ctx, query, connectionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []*entity.ScanProfile
+	for rows.Next() {
+		profile, err := scanScanProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// UpdateScanProfile replaces the rules of an existing scan profile
+func (r *PostgresRepository) UpdateScanProfile(ctx context.Context, profile *entity.ScanProfile) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if profile.IsDefault {
+		if err := clearDefaultScanProfile(ctx, tx, profile.ConnectionID, profile.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE scan_profiles
+		SET name = $1, description = $2, include_globs = $3, exclude_globs = $4,
+			table_allow_list = $5, table_deny_list = $6, sample_size = $7, pii_types = $8, is_default = $9
+		WHERE id = $10
+		RETURNING updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		profile.Name, profile.Description, pq.Array(profile.IncludeGlobs), pq.Array(profile.ExcludeGlobs),
+		pq.Array(profile.TableAllowList), pq.Array(profile.TableDenyList),
+		profile.SampleSize, pq.Array(profile.PIITypes), profile.IsDefault, profile.ID,
+	).Scan(&profile.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrScanProfileNotFound
+	}
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrScanProfileExists
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteScanProfile deletes a scan profile by ID
+func (r *PostgresRepository) DeleteScanProfile(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM scan_profiles WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrScanProfileNotFound
+	}
+	return nil
+}
+
+func clearDefaultScanProfile(ctx context.Context, tx *sql.Tx, connectionID, keepID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE scan_profiles SET is_default = false WHERE connection_id = $1 AND id != $2 AND is_default`,
+		connectionID, keepID)
+	if err != nil {
+		return fmt.Errorf("failed to clear default scan profile: %w", err)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanScanProfile(row rowScanner) (*entity.ScanProfile, error) {
+	profile := &entity.ScanProfile{}
+	err := row.Scan(
+		&profile.ID, &profile.TenantID, &profile.ConnectionID, &profile.Name, &profile.Description,
+		pq.Array(&profile.IncludeGlobs), pq.Array(&profile.ExcludeGlobs),
+		pq.Array(&profile.TableAllowList), pq.Array(&profile.TableDenyList),
+		&profile.SampleSize, pq.Array(&profile.PIITypes), &profile.IsDefault,
+		&profile.CreatedBy, &profile.CreatedAt, &profile.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
@@ -22,29 +22,32 @@ func (r *PostgresRepository) CreateScanRun(ctx context.Context, scanRun *entity.
 
 	query := `
 		INSERT INTO scan_runs (id, profile_name, scan_started_at, scan_completed_at, host, 
-			total_findings, total_assets, status, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			total_findings, total_assets, status, metadata, connection_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING created_at, updated_at`
 
 	return r.db.QueryRowContext(ctx, query,
 		scanRun.ID, scanRun.ProfileName, scanRun.ScanStartedAt, scanRun.ScanCompletedAt,
 		scanRun.Host, scanRun.TotalFindings, scanRun.TotalAssets, scanRun.Status, metadataJSON,
+		scanRun.ConnectionID,
 	).Scan(&scanRun.CreatedAt, &scanRun.UpdatedAt)
 }
 
 func (r *PostgresRepository) GetScanRunByID(ctx context.Context, id uuid.UUID) (*entity.ScanRun, error) {
 	query := `
-		SELECT id, profile_name, scan_started_at, scan_completed_at, host, 
-			total_findings, total_assets, status, metadata, created_at, updated_at
-		FROM scan_runs WHERE id = $1`
+		SELECT id, profile_name, connection_id, scan_started_at, scan_completed_at, host, 
+			total_findings, total_assets, status, metadata, signature_status, signer_key_id,
+			COALESCE(signer_name, ''), created_at, updated_at
+		FROM scan_runs WHERE id = $1 AND deleted_at IS NULL`
 
 	scanRun := &entity.ScanRun{}
 	var metadataJSON []byte
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&scanRun.ID, &scanRun.ProfileName, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
+		&scanRun.ID, &scanRun.ProfileName, &scanRun.ConnectionID, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
 		&scanRun.Host, &scanRun.TotalFindings, &scanRun.TotalAssets, &scanRun.Status,
-		&metadataJSON, &scanRun.CreatedAt, &scanRun.UpdatedAt,
+		&metadataJSON, &scanRun.SignatureStatus, &scanRun.SignerKeyID, &scanRun.SignerName,
+		&scanRun.CreatedAt, &scanRun.UpdatedAt,
 	)
 
 	if err != nil {
@@ -65,9 +68,11 @@ func (r *PostgresRepository) GetScanRunByID(ctx context.Context, id uuid.UUID) (
 
 func (r *PostgresRepository) ListScanRuns(ctx context.Context, limit, offset int) ([]*entity.ScanRun, error) {
 	query := `
-		SELECT id, profile_name, scan_started_at, scan_completed_at, host, 
-			total_findings, total_assets, status, metadata, created_at, updated_at
+		SELECT id, profile_name, connection_id, scan_started_at, scan_completed_at, host, 
+			total_findings, total_assets, status, metadata, signature_status, signer_key_id,
+			COALESCE(signer_name, ''), created_at, updated_at
 		FROM scan_runs 
+		WHERE deleted_at IS NULL
 		ORDER BY scan_started_at DESC
 		LIMIT $1 OFFSET $2`
 
@@ -83,9 +88,10 @@ func (r *PostgresRepository) ListScanRuns(ctx context.Context, limit, offset int
 		var metadataJSON []byte
 
 		err := rows.Scan(
-			&scanRun.ID, &scanRun.ProfileName, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
+			&scanRun.ID, &scanRun.ProfileName, &scanRun.ConnectionID, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
 			&scanRun.Host, &scanRun.TotalFindings, &scanRun.TotalAssets, &scanRun.Status,
-			&metadataJSON, &scanRun.CreatedAt, &scanRun.UpdatedAt,
+			&metadataJSON, &scanRun.SignatureStatus, &scanRun.SignerKeyID, &scanRun.SignerName,
+			&scanRun.CreatedAt, &scanRun.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -131,9 +137,11 @@ func (r *PostgresRepository) UpdateScanRun(ctx context.Context, scanRun *entity.
 
 func (r *PostgresRepository) GetLatestScanRun(ctx context.Context) (*entity.ScanRun, error) {
 	query := `
-		SELECT id, profile_name, scan_started_at, scan_completed_at, host, 
-			total_findings, total_assets, status, metadata, created_at, updated_at
+		SELECT id, profile_name, connection_id, scan_started_at, scan_completed_at, host, 
+			total_findings, total_assets, status, metadata, signature_status, signer_key_id,
+			COALESCE(signer_name, ''), created_at, updated_at
 		FROM scan_runs 
+		WHERE deleted_at IS NULL
 		ORDER BY scan_started_at DESC
 		LIMIT 1`
 
@@ -141,9 +149,10 @@ func (r *PostgresRepository) GetLatestScanRun(ctx context.Context) (*entity.Scan
 	var metadataJSON []byte
 
 	err := r.db.QueryRowContext(ctx, query).Scan(
-		&scanRun.ID, &scanRun.ProfileName, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
+		&scanRun.ID, &scanRun.ProfileName, &scanRun.ConnectionID, &scanRun.ScanStartedAt, &scanRun.ScanCompletedAt,
 		&scanRun.Host, &scanRun.TotalFindings, &scanRun.TotalAssets, &scanRun.Status,
-		&metadataJSON, &scanRun.CreatedAt, &scanRun.UpdatedAt,
+		&metadataJSON, &scanRun.SignatureStatus, &scanRun.SignerKeyID, &scanRun.SignerName,
+		&scanRun.CreatedAt, &scanRun.UpdatedAt,
 	)
 
 	if err != nil {
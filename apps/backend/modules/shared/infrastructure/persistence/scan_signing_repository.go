@@ -0,0 +1,169 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Scan Signing Repository Implementation
+// ============================================================================
+
+const scannerSigningKeyColumns = `id, tenant_id, key_id, scanner_name, algorithm, public_key, fingerprint,
+	created_by, created_at, revoked_at, COALESCE(revoked_by, '')`
+
+func scanScannerSigningKey(row rowScanner) (*entity.ScannerSigningKey, error) {
+	key := &entity.ScannerSigningKey{}
+	err := row.Scan(&key.ID, &key.TenantID, &key.KeyID, &key.ScannerName, &key.Algorithm, &key.PublicKey,
+		&key.Fingerprint, &key.CreatedBy, &key.CreatedAt, &key.RevokedAt, &key.RevokedBy)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// CreateScannerSigningKey registers a scanner's public key for the tenant
+func (r *PostgresRepository) CreateScannerSigningKey(ctx context.Context, key *entity.ScannerSigningKey) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	key.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO scanner_signing_keys (id, tenant_id, key_id, scanner_name, algorithm, public_key, fingerprint, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at`,
+		key.ID, key.TenantID, key.KeyID, key.ScannerName, key.Algorithm, key.PublicKey, key.Fingerprint, key.CreatedBy,
+	).Scan(&key.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return repository.ErrSigningKeyExists
+		}
+		return fmt.Errorf("failed to create signing key: %w", err)
+	}
+	return nil
+}
+
+// ListScannerSigningKeys returns the tenant's keys, active ones first
+func (r *PostgresRepository) ListScannerSigningKeys(ctx context.Context) ([]*entity.ScannerSigningKey, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT `+scannerSigningKeyColumns+`
+		FROM scanner_signing_keys WHERE tenant_id = $1
+		ORDER BY revoked_at IS NOT NULL, created_at DESC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*entity.ScannerSigningKey{}
+	for rows.Next() {
+		key, err := scanScannerSigningKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// GetScannerSigningKey retrieves a key of the tenant by its ID
+func (r *PostgresRepository) GetScannerSigningKey(ctx context.Context, id uuid.UUID) (*entity.ScannerSigningKey, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scanScannerSigningKey(r.db.QueryRowContext(ctx, `SELECT `+scannerSigningKeyColumns+`
+		FROM scanner_signing_keys WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSigningKeyNotFound
+	}
+	return key, err
+}
+
+// GetScannerSigningKeyByKeyID retrieves a key of the tenant by the ID scanners send
+func (r *PostgresRepository) GetScannerSigningKeyByKeyID(ctx context.Context, keyID string) (*entity.ScannerSigningKey, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scanScannerSigningKey(r.db.QueryRowContext(ctx, `SELECT `+scannerSigningKeyColumns+`
+		FROM scanner_signing_keys WHERE key_id = $1 AND tenant_id = $2`, keyID, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSigningKeyNotFound
+	}
+	return key, err
+}
+
+// RevokeScannerSigningKey stops an active key from verifying payloads. The key is
+// kept so scan runs it signed still name their signer.
+func (r *PostgresRepository) RevokeScannerSigningKey(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.ScannerSigningKey, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scanScannerSigningKey(r.db.QueryRowContext(ctx, `
+		UPDATE scanner_signing_keys SET revoked_at = NOW(), revoked_by = $3
+		WHERE id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+		RETURNING `+scannerSigningKeyColumns, id, tenantID, revokedBy))
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrSigningKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke signing key: %w", err)
+	}
+	return key, nil
+}
+
+// GetTenantScanSigningPolicy returns the tenant's strict mode choice, or nil
+func (r *PostgresRepository) GetTenantScanSigningPolicy(ctx context.Context) (*entity.TenantScanSigningPolicy, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &entity.TenantScanSigningPolicy{TenantID: tenantID}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT require_signature, updated_by, updated_at
+		FROM tenant_scan_signing_policies WHERE tenant_id = $1`, tenantID,
+	).Scan(&policy.RequireSignature, &policy.UpdatedBy, &policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tenant scan signing policy: %w", err)
+	}
+	return policy, nil
+}
+
+// SetTenantScanSigningPolicy records whether the tenant rejects unsigned scan payloads
+func (r *PostgresRepository) SetTenantScanSigningPolicy(ctx context.Context, requireSignature bool, updatedBy string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO tenant_scan_signing_policies (tenant_id, require_signature, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET require_signature = EXCLUDED.require_signature, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+		tenantID, requireSignature, updatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to set tenant scan signing policy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Scan Upload Session Repository Implementation
+// ============================================================================
+
+const scanUploadSessionColumns = `s.id, s.tenant_id, s.scan_id, s.status, s.expected_chunks,
+	COALESCE(ARRAY(SELECT c.chunk_number FROM scan_upload_chunks c WHERE c.session_id = s.id ORDER BY c.chunk_number), '{}'),
+	COALESCE((SELECT SUM(c.finding_count) FROM scan_upload_chunks c WHERE c.session_id = s.id), 0),
+	s.scan_run_id, COALESCE(s.last_error, ''), s.signer_key_id, s.unsigned_chunks, s.created_by, s.expires_at, s.created_at, s.updated_at, s.completed_at`
+
+// CreateScanUploadSession opens a new upload session for the tenant
+func (r *PostgresRepository) CreateScanUploadSession(ctx context.Context, session *entity.ScanUploadSession) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	session.TenantID = tenantID
+
+	query := `
+		INSERT INTO scan_upload_sessions (id, tenant_id, scan_id, status, expected_chunks, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		session.ID, session.TenantID, session.ScanID, session.Status,
+		session.ExpectedChunks, session.CreatedBy, session.ExpiresAt,
+	).Scan(&session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+	return nil
+}
+
+// GetScanUploadSession retrieves an upload session with its received chunk numbers
+func (r *PostgresRepository) GetScanUploadSession(ctx context.Context, id uuid.UUID) (*entity.ScanUploadSession, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + scanUploadSessionColumns + ` FROM scan_upload_sessions s WHERE s.id = $1 AND s.tenant_id = $2`
+
+	session, err := scanUploadSession(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("upload session not found")
+	}
+	return session, err
+}
+
+// SaveScanUploadChunk stores a chunk of an open, unexpired session. Re-sending a
+// chunk number replaces the earlier upload, so interrupted chunks can simply be retried.
+func (r *PostgresRepository) SaveScanUploadChunk(ctx context.Context, chunk *entity.ScanUploadChunk, findings json.RawMessage) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO scan_upload_chunks (session_id, chunk_number, findings, finding_count, checksum)
+		SELECT s.id, $2, $3, $4, $5
+		FROM scan_upload_sessions s
+		WHERE s.id = $1 AND s.tenant_id = $6 AND s.status = 'open' AND s.expires_at > NOW()
+		ON CONFLICT (session_id, chunk_number) DO UPDATE
+		SET findings = EXCLUDED.findings, finding_count = EXCLUDED.finding_count,
+			checksum = EXCLUDED.checksum, received_at = NOW()
+		RETURNING received_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		chunk.SessionID, chunk.ChunkNumber, []byte(findings), chunk.FindingCount, chunk.Checksum, tenantID,
+	).Scan(&chunk.ReceivedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("upload session is not accepting chunks")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store chunk: %w", err)
+	}
+	return nil
+}
+
+// RecordScanUploadChunkSigner checks a chunk's signer against the earlier chunks of
+// its session and records it. A signed chunk needs every earlier chunk signed with
+// the same key; an unsigned chunk needs every earlier chunk unsigned.
+func (r *PostgresRepository) RecordScanUploadChunkSigner(ctx context.Context, sessionID uuid.UUID, signerKeyID *uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE scan_upload_sessions SET signer_key_id = $3
+		WHERE id = $1 AND tenant_id = $2 AND NOT unsigned_chunks AND (signer_key_id IS NULL OR signer_key_id = $3)`
+	conflict := "upload session already has unsigned chunks or chunks signed with another key"
+	args := []interface{}{sessionID, tenantID, signerKeyID}
+	if signerKeyID == nil {
+		query = `
+			UPDATE scan_upload_sessions SET unsigned_chunks = TRUE
+			WHERE id = $1 AND tenant_id = $2 AND signer_key_id IS NULL`
+		conflict = "upload session already has signed chunks"
+		args = args[:2]
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to record chunk signer: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		if _, err := r.GetScanUploadSession(ctx, sessionID); err != nil {
+			return err
+		}
+		return errors.New(conflict)
+	}
+	return nil
+}
+
+// GetScanUploadChunkFindings returns the stored findings of one chunk
+func (r *PostgresRepository) GetScanUploadChunkFindings(ctx context.Context, sessionID uuid.UUID, chunkNumber int) (json.RawMessage, error) {
+	var findings []byte
+	err := r.db.QueryRowContext(ctx,
+		`SELECT findings FROM scan_upload_chunks WHERE session_id = $1 AND chunk_number = $2`,
+		sessionID, chunkNumber,
+	).Scan(&findings)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("chunk %d not found", chunkNumber)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// ClaimScanUploadSession moves an open session to finalizing so only one finalize
+// runs at a time. A session left finalizing longer than staleAfter, e.g. by a
+// restart mid-ingestion, can be claimed again.
+func (r *PostgresRepository) ClaimScanUploadSession(ctx context.Context, id uuid.UUID, staleAfter time.Duration) (*entity.ScanUploadSession, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE scan_upload_sessions s
+		SET status = 'finalizing', last_error = NULL
+		WHERE s.id = $1 AND s.tenant_id = $2 AND s.expires_at > NOW()
+		  AND (s.status = 'open' OR (s.status = 'finalizing' AND s.updated_at < NOW() - $3 * INTERVAL '1 second'))
+		RETURNING ` + scanUploadSessionColumns
+
+	session, err := scanUploadSession(r.db.QueryRowContext(ctx, query, id, tenantID, int64(staleAfter.Seconds())))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("upload session is already being finalized")
+	}
+	return session, err
+}
+
+// CompleteScanUploadSession marks a session completed and drops its chunk payloads
+func (r *PostgresRepository) CompleteScanUploadSession(ctx context.Context, id, scanRunID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE scan_upload_sessions
+		SET status = 'completed', scan_run_id = $2, completed_at = NOW(), last_error = NULL
+		WHERE id = $1`, id, scanRunID)
+	if err != nil {
+		return fmt.Errorf("failed to complete upload session: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM scan_upload_chunks WHERE session_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete upload chunks: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ReopenScanUploadSession returns a session to open after a failed finalize so the
+// scanner can fix chunks and retry
+func (r *PostgresRepository) ReopenScanUploadSession(ctx context.Context, id uuid.UUID, lastError string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE scan_upload_sessions SET status = 'open', last_error = $2
+		WHERE id = $1 AND status = 'finalizing'`, id, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to reopen upload session: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredScanUploadSessions removes unfinished sessions past their expiry, with
+// their chunks. Sessions still being finalized are left alone unless they have stalled for a day.
+func (r *PostgresRepository) DeleteExpiredScanUploadSessions(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM scan_upload_sessions
+		WHERE expires_at < NOW()
+		  AND (status = 'open' OR (status = 'finalizing' AND updated_at < NOW() - INTERVAL '1 day'))`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired upload sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func scanUploadSession(row rowScanner) (*entity.ScanUploadSession, error) {
+	session := &entity.ScanUploadSession{}
+	var expected sql.NullInt64
+	var chunks pq.Int64Array
+	var scanRunID uuid.NullUUID
+
+	err := row.Scan(
+		&session.ID, &session.TenantID, &session.ScanID, &session.Status, &expected,
+		&chunks, &session.TotalFindings, &scanRunID, &session.LastError, &session.SignerKeyID, &session.UnsignedChunks, &session.CreatedBy,
+		&session.ExpiresAt, &session.CreatedAt, &session.UpdatedAt, &session.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expected.Valid {
+		n := int(expected.Int64)
+		session.ExpectedChunks = &n
+	}
+	if scanRunID.Valid {
+		session.ScanRunID = &scanRunID.UUID
+	}
+	session.ReceivedChunks = make([]int, len(chunks))
+	for i, n := range chunks {
+		session.ReceivedChunks[i] = int(n)
+	}
+	return session, nil
+}
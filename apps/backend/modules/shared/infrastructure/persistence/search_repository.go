@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ListFindingsForSearch returns findings of every tenant changed after the
+// watermark position and before until, in (updated_at, id) order, with the asset
+// fields they are searched by. Encrypted samples are never opened for indexing.
+func (r *PostgresRepository) ListFindingsForSearch(ctx context.Context, after time.Time, afterID uuid.UUID, until time.Time, limit int) ([]*entity.SearchDocument, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id, f.tenant_id, f.asset_id, a.name, a.path, COALESCE(a.host, ''), a.data_source,
+			f.pattern_name, f.severity, COALESCE(f.sample_text, ''),
+			f.created_at, f.updated_at, f.deleted_at IS NOT NULL
+		FROM findings f
+		JOIN assets a ON a.id = f.asset_id
+		WHERE f.tenant_id IS NOT NULL AND (f.updated_at, f.id) > ($1, $2) AND f.updated_at < $3
+		ORDER BY f.updated_at, f.id
+		LIMIT $4`, after, afterID, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list findings for search: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*entity.SearchDocument{}
+	for rows.Next() {
+		d := &entity.SearchDocument{}
+		if err := rows.Scan(&d.ID, &d.TenantID, &d.AssetID, &d.AssetName, &d.AssetPath, &d.Host, &d.DataSource,
+			&d.PatternName, &d.Severity, &d.SampleText, &d.CreatedAt, &d.UpdatedAt, &d.Deleted); err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(d.SampleText, sealedValuePrefix) {
+			d.SampleText = ""
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
+
+// ListOrgUnitScopeAssetIDs returns the assets finding reads made with ctx are
+// limited to by its org unit scope, including those of the unit's teams. scoped
+// is false when ctx is not scoped.
+func (r *PostgresRepository) ListOrgUnitScopeAssetIDs(ctx context.Context) (ids []uuid.UUID, scoped bool, err error) {
+	scope, ok := OrgUnitScope(ctx)
+	if !ok {
+		return nil, false, nil
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id FROM assets a JOIN org_units ou ON ou.id = a.org_unit_id
+		WHERE a.tenant_id = $1 AND (ou.id = $2 OR ou.parent_id = $2)`, tenantID, scope)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to list org unit assets: %w", err)
+	}
+	defer rows.Close()
+
+	ids = []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, true, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, true, rows.Err()
+}
@@ -0,0 +1,193 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Secret Verification Repository Implementation
+// ============================================================================
+
+// GetSecretVerificationSettings returns the tenant's verification opt-in, or nil
+func (r *PostgresRepository) GetSecretVerificationSettings(ctx context.Context) (*entity.SecretVerificationSettings, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &entity.SecretVerificationSettings{TenantID: tenantID}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT enabled, providers, updated_by, updated_at
+		FROM secret_verification_settings WHERE tenant_id = $1`, tenantID,
+	).Scan(&settings.Enabled, pq.Array(&settings.Providers), &settings.UpdatedBy, &settings.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret verification settings: %w", err)
+	}
+	if settings.Providers == nil {
+		settings.Providers = []string{}
+	}
+	return settings, nil
+}
+
+// SetSecretVerificationSettings stores the tenant's verification opt-in
+func (r *PostgresRepository) SetSecretVerificationSettings(ctx context.Context, settings *entity.SecretVerificationSettings) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	settings.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO secret_verification_settings (tenant_id, enabled, providers, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled, providers = EXCLUDED.providers, updated_by = EXCLUDED.updated_by, updated_at = NOW()
+		RETURNING updated_at`,
+		tenantID, settings.Enabled, pq.Array(settings.Providers), settings.UpdatedBy,
+	).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set secret verification settings: %w", err)
+	}
+	return nil
+}
+
+// ListSecretVerificationTenantIDs returns the tenants that opted in to verification
+func (r *PostgresRepository) ListSecretVerificationTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT tenant_id FROM secret_verification_settings WHERE enabled = TRUE`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret verification tenants: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListSecretFindingsToVerify returns the tenant's Secrets findings never verified,
+// or last verified before recheckBefore, oldest first
+func (r *PostgresRepository) ListSecretFindingsToVerify(ctx context.Context, recheckBefore time.Time, limit int) ([]uuid.UUID, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.id
+		FROM findings f
+		JOIN classifications c ON c.finding_id = f.id AND c.classification_type = 'Secrets'
+		LEFT JOIN secret_verifications v ON v.finding_id = f.id
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+		  AND (v.id IS NULL OR v.checked_at < $2)
+		ORDER BY v.checked_at NULLS FIRST, f.created_at
+		LIMIT $3`, tenantID, recheckBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret findings to verify: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// IsSecretFinding reports whether the finding is classified as Secrets
+func (r *PostgresRepository) IsSecretFinding(ctx context.Context, findingID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM classifications WHERE finding_id = $1 AND classification_type = 'Secrets')`,
+		findingID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check finding classification: %w", err)
+	}
+	return exists, nil
+}
+
+// GetSecretVerification returns the latest verification of a finding, or nil
+func (r *PostgresRepository) GetSecretVerification(ctx context.Context, findingID uuid.UUID) (*entity.SecretVerification, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &entity.SecretVerification{}
+	err = r.db.QueryRowContext(ctx, `
+		SELECT v.id, v.tenant_id, v.finding_id, v.provider, v.status, v.identity, v.detail, v.original_severity,
+			f.severity, v.checked_at
+		FROM secret_verifications v
+		JOIN findings f ON f.id = v.finding_id
+		WHERE v.finding_id = $1 AND v.tenant_id = $2`, findingID, tenantID,
+	).Scan(&v.ID, &v.TenantID, &v.FindingID, &v.Provider, &v.Status, &v.Identity, &v.Detail, &v.OriginalSeverity,
+		&v.Severity, &v.CheckedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret verification: %w", err)
+	}
+	return v, nil
+}
+
+// SaveSecretVerification replaces the finding's verification and sets its severity.
+// The original severity of the first verification is kept, so reverifying adjusts
+// from the severity the finding was ingested with.
+func (r *PostgresRepository) SaveSecretVerification(ctx context.Context, v *entity.SecretVerification) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	v.TenantID = tenantID
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO secret_verifications (id, tenant_id, finding_id, provider, status, identity, detail, original_severity, checked_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (finding_id) DO UPDATE SET
+			provider = EXCLUDED.provider,
+			status = EXCLUDED.status,
+			identity = EXCLUDED.identity,
+			detail = EXCLUDED.detail,
+			checked_at = NOW()
+		RETURNING id, original_severity, checked_at`,
+		v.ID, tenantID, v.FindingID, v.Provider, v.Status, v.Identity, v.Detail, v.OriginalSeverity,
+	).Scan(&v.ID, &v.OriginalSeverity, &v.CheckedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save secret verification: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE findings SET severity = $1, updated_at = NOW()
+		WHERE id = $2 AND tenant_id = $3 AND severity IS DISTINCT FROM $1`,
+		v.Severity, v.FindingID, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to update finding severity: %w", err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,233 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Auth Session Repository Implementation
+// ============================================================================
+
+// Sessions are checked on every authenticated request and during refresh,
+// before a tenant is in the context, so methods take IDs explicitly.
+
+const authSessionColumns = `id, user_id, tenant_id, refresh_token_hash, auth_method, ip_address, user_agent,
+	created_at, last_used_at, expires_at, revoked_at, COALESCE(revoked_reason, '')`
+
+// CreateAuthSession stores a new login session
+func (r *PostgresRepository) CreateAuthSession(ctx context.Context, s *authentity.LoginSession) error {
+	query := `
+		INSERT INTO auth_sessions (id, user_id, tenant_id, refresh_token_hash, auth_method, ip_address, user_agent, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, last_used_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		s.ID, s.UserID, s.TenantID, s.TokenHash, s.AuthMethod, s.IPAddress, s.UserAgent, s.ExpiresAt,
+	).Scan(&s.CreatedAt, &s.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// GetAuthSession retrieves a session by ID, revoked or not
+func (r *PostgresRepository) GetAuthSession(ctx context.Context, id uuid.UUID) (*authentity.LoginSession, error) {
+	query := `SELECT ` + authSessionColumns + ` FROM auth_sessions WHERE id = $1`
+	s, err := scanAuthSession(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found")
+	}
+	return s, err
+}
+
+// ListActiveAuthSessions returns a user's sessions that are neither revoked nor expired, newest first
+func (r *PostgresRepository) ListActiveAuthSessions(ctx context.Context, userID uuid.UUID) ([]*authentity.LoginSession, error) {
+	query := `SELECT ` + authSessionColumns + ` FROM auth_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*authentity.LoginSession, 0)
+	for rows.Next() {
+		s, err := scanAuthSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// RotateAuthSessionToken swaps the session's refresh token hash if the presented
+// token is the current one. It reports false when the token was already rotated,
+// the session is revoked or it has expired.
+func (r *PostgresRepository) RotateAuthSessionToken(ctx context.Context, id uuid.UUID, oldHash, newHash string, expiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE auth_sessions
+		SET refresh_token_hash = $1, expires_at = $2, last_used_at = NOW()
+		WHERE id = $3 AND refresh_token_hash = $4 AND revoked_at IS NULL AND expires_at > NOW()`
+
+	res, err := r.db.ExecContext(ctx, query, newHash, expiresAt, id, oldHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// IsAuthSessionActive reports whether the session is live and the access token is not denylisted
+func (r *PostgresRepository) IsAuthSessionActive(ctx context.Context, sessionID uuid.UUID, jti string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM auth_sessions WHERE id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		) AND NOT EXISTS (
+			SELECT 1 FROM revoked_tokens WHERE jti = $2
+		)`
+
+	var active bool
+	if err := r.db.QueryRowContext(ctx, query, sessionID, jti).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to check session: %w", err)
+	}
+	return active, nil
+}
+
+// RevokeAuthSession revokes one session
+func (r *PostgresRepository) RevokeAuthSession(ctx context.Context, id uuid.UUID, reason string) error {
+	query := `UPDATE auth_sessions SET revoked_at = NOW(), revoked_reason = $1 WHERE id = $2 AND revoked_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, reason, id); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserAuthSessions revokes all of a user's live sessions except the given one (uuid.Nil keeps none)
+func (r *PostgresRepository) RevokeUserAuthSessions(ctx context.Context, userID, exceptSessionID uuid.UUID, reason string) (int64, error) {
+	query := `
+		UPDATE auth_sessions SET revoked_at = NOW(), revoked_reason = $1
+		WHERE user_id = $2 AND id <> $3 AND revoked_at IS NULL AND expires_at > NOW()`
+
+	res, err := r.db.ExecContext(ctx, query, reason, userID, exceptSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke user sessions: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// RevokeTenantAuthSessions revokes every live session in a tenant
+func (r *PostgresRepository) RevokeTenantAuthSessions(ctx context.Context, tenantID uuid.UUID, reason string) (int64, error) {
+	query := `
+		UPDATE auth_sessions SET revoked_at = NOW(), revoked_reason = $1
+		WHERE tenant_id = $2 AND revoked_at IS NULL AND expires_at > NOW()`
+
+	res, err := r.db.ExecContext(ctx, query, reason, tenantID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke tenant sessions: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// DenylistToken records a revoked access token until it expires
+func (r *PostgresRepository) DenylistToken(ctx context.Context, jti string, sessionID, userID, tenantID uuid.UUID, reason string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, session_id, user_id, tenant_id, reason, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (jti) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, jti, sessionID, userID, tenantID, reason, expiresAt); err != nil {
+		return fmt.Errorf("failed to denylist token: %w", err)
+	}
+	return nil
+}
+
+// CreateStreamTicket stores a stream ticket
+func (r *PostgresRepository) CreateStreamTicket(ctx context.Context, t *authentity.StreamTicket) error {
+	query := `
+		INSERT INTO stream_tickets (ticket_hash, session_id, token_id, user_id, tenant_id, email, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	if _, err := r.db.ExecContext(ctx, query,
+		t.TicketHash, t.SessionID, t.TokenID, t.UserID, t.TenantID, t.Email, t.Role, t.ExpiresAt,
+	); err != nil {
+		return fmt.Errorf("failed to create stream ticket: %w", err)
+	}
+	return nil
+}
+
+// RedeemStreamTicket deletes a stream ticket and returns it, so each ticket is
+// redeemed at most once. It returns nil when no unexpired ticket has the hash.
+func (r *PostgresRepository) RedeemStreamTicket(ctx context.Context, ticketHash string) (*authentity.StreamTicket, error) {
+	query := `
+		DELETE FROM stream_tickets WHERE ticket_hash = $1
+		RETURNING session_id, token_id, user_id, tenant_id, email, role, expires_at, expires_at > NOW()`
+
+	t := &authentity.StreamTicket{TicketHash: ticketHash}
+	var live bool
+	err := r.db.QueryRowContext(ctx, query, ticketHash).Scan(
+		&t.SessionID, &t.TokenID, &t.UserID, &t.TenantID, &t.Email, &t.Role, &t.ExpiresAt, &live,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem stream ticket: %w", err)
+	}
+	if !live {
+		return nil, nil
+	}
+	return t, nil
+}
+
+// PurgeExpiredAuthRecords drops denylist entries for tokens that have expired,
+// unredeemed stream tickets that have expired, and sessions that ended more than
+// the retention period ago
+func (r *PostgresRepository) PurgeExpiredAuthRecords(ctx context.Context, sessionRetention time.Duration) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge revoked tokens: %w", err)
+	}
+	tokens, _ := res.RowsAffected()
+
+	res, err = r.db.ExecContext(ctx, `DELETE FROM stream_tickets WHERE expires_at < NOW()`)
+	if err != nil {
+		return tokens, fmt.Errorf("failed to purge stream tickets: %w", err)
+	}
+	tickets, _ := res.RowsAffected()
+	tokens += tickets
+
+	res, err = r.db.ExecContext(ctx, `DELETE FROM auth_sessions WHERE LEAST(expires_at, COALESCE(revoked_at, expires_at)) < $1`,
+		time.Now().Add(-sessionRetention))
+	if err != nil {
+		return tokens, fmt.Errorf("failed to purge sessions: %w", err)
+	}
+	sessions, _ := res.RowsAffected()
+	return tokens + sessions, nil
+}
+
+func scanAuthSession(row rowScanner) (*authentity.LoginSession, error) {
+	s := &authentity.LoginSession{}
+	var revokedAt sql.NullTime
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.TenantID, &s.TokenHash, &s.AuthMethod, &s.IPAddress, &s.UserAgent,
+		&s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt, &revokedAt, &s.RevokedReason,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		s.RevokedAt = &revokedAt.Time
+	}
+	return s, nil
+}
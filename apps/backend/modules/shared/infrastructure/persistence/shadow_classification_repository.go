@@ -0,0 +1,118 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Shadow Classification Repository Implementation
+// ============================================================================
+
+// RecordShadowClassification stores a candidate engine's outcome next to the primary one
+func (r *PostgresRepository) RecordShadowClassification(ctx context.Context, result *entity.ShadowClassification) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	result.TenantID = tenantID
+	if result.ID == uuid.Nil {
+		result.ID = uuid.New()
+	}
+	if result.Divergences == nil {
+		result.Divergences = []string{}
+	}
+
+	var scanRunID *uuid.UUID
+	if result.ScanRunID != uuid.Nil {
+		scanRunID = &result.ScanRunID
+	}
+
+	p, s := result.Primary, result.Shadow
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO classification_shadow_results (id, tenant_id, scan_run_id, finding_id, pattern_name,
+			primary_version, primary_classification, primary_sub_category, primary_confidence_level, primary_score, primary_kept,
+			shadow_version, shadow_classification, shadow_sub_category, shadow_confidence_level, shadow_score, shadow_kept,
+			divergences)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING created_at`,
+		result.ID, result.TenantID, scanRunID, result.FindingID, result.PatternName,
+		p.Version, p.Classification, p.SubCategory, p.ConfidenceLevel, p.Score, p.Kept,
+		s.Version, s.Classification, s.SubCategory, s.ConfidenceLevel, s.Score, s.Kept,
+		pq.Array(result.Divergences),
+	).Scan(&result.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record shadow classification: %w", err)
+	}
+	return nil
+}
+
+// GetShadowDivergenceCounts tallies a candidate version's results since the given
+// time by PII type, most compared first
+func (r *PostgresRepository) GetShadowDivergenceCounts(ctx context.Context, shadowVersion string, since time.Time) ([]entity.ShadowDivergenceCounts, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT pattern_name, COUNT(*),
+			COUNT(*) FILTER (WHERE cardinality(divergences) > 0),
+			COUNT(*) FILTER (WHERE 'classification' = ANY(divergences)),
+			COUNT(*) FILTER (WHERE 'sub_category' = ANY(divergences)),
+			COUNT(*) FILTER (WHERE 'confidence_level' = ANY(divergences)),
+			COUNT(*) FILTER (WHERE shadow_kept AND NOT primary_kept),
+			COUNT(*) FILTER (WHERE primary_kept AND NOT shadow_kept)
+		FROM classification_shadow_results
+		WHERE tenant_id = $1 AND shadow_version = $2 AND created_at >= $3
+		GROUP BY pattern_name
+		ORDER BY COUNT(*) DESC, pattern_name`, tenantID, shadowVersion, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shadow classifications: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []entity.ShadowDivergenceCounts{}
+	for rows.Next() {
+		var c entity.ShadowDivergenceCounts
+		if err := rows.Scan(&c.PatternName, &c.Total, &c.Diverged, &c.Classification,
+			&c.SubCategory, &c.ConfidenceLevel, &c.NewlyKept, &c.NewlyDiscarded); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// ListShadowVersions returns the candidate versions with stored results, newest first
+func (r *PostgresRepository) ListShadowVersions(ctx context.Context) ([]string, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT shadow_version FROM classification_shadow_results
+		WHERE tenant_id = $1
+		GROUP BY shadow_version
+		ORDER BY MAX(created_at) DESC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shadow versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []string{}
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
@@ -0,0 +1,125 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Dashboard Share Link Repository Implementation
+// ============================================================================
+
+// ErrShareLinkNotFound is returned for a share link ID with no link in the tenant
+var ErrShareLinkNotFound = errors.New("share link not found")
+
+const shareLinkColumns = `id, tenant_id, name, widgets, environment, days, ip_allowlist, token_hash, expires_at,
+	created_by, created_at, revoked_at, COALESCE(revoked_by, ''), last_accessed_at, access_count`
+
+// CreateShareLink stores a new share link for the tenant
+func (r *PostgresRepository) CreateShareLink(ctx context.Context, link *entity.ShareLink) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	link.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO share_links (id, tenant_id, name, widgets, environment, days, ip_allowlist, token_hash, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at`,
+		link.ID, link.TenantID, link.Name, pq.Array(link.Widgets), link.Environment, link.Days,
+		pq.Array(link.IPAllowlist), link.TokenHash, link.ExpiresAt, link.CreatedBy,
+	).Scan(&link.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+	return nil
+}
+
+// ListShareLinks returns the tenant's share links, newest first
+func (r *PostgresRepository) ListShareLinks(ctx context.Context) ([]*entity.ShareLink, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+shareLinkColumns+` FROM share_links WHERE tenant_id = $1 ORDER BY created_at DESC`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	defer rows.Close()
+
+	links := []*entity.ShareLink{}
+	for rows.Next() {
+		link, err := scanShareLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// RevokeShareLink revokes one of the tenant's share links. Revoking a revoked link
+// keeps its first revocation.
+func (r *PostgresRepository) RevokeShareLink(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.ShareLink, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := scanShareLink(r.db.QueryRowContext(ctx, `
+		UPDATE share_links
+		SET revoked_at = COALESCE(revoked_at, NOW()), revoked_by = COALESCE(revoked_by, $1)
+		WHERE id = $2 AND tenant_id = $3
+		RETURNING `+shareLinkColumns,
+		revokedBy, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, ErrShareLinkNotFound
+	}
+	return link, err
+}
+
+// GetShareLinkByTokenHash retrieves a share link of any tenant by its token hash,
+// for opening it without authentication. It returns nil when no link matches.
+func (r *PostgresRepository) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLink, error) {
+	link, err := scanShareLink(r.db.QueryRowContext(ctx,
+		`SELECT `+shareLinkColumns+` FROM share_links WHERE token_hash = $1`, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return link, err
+}
+
+// RecordShareLinkAccess counts an opening of a share link
+func (r *PostgresRepository) RecordShareLinkAccess(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE share_links SET access_count = access_count + 1, last_accessed_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to record share link access: %w", err)
+	}
+	return nil
+}
+
+func scanShareLink(row rowScanner) (*entity.ShareLink, error) {
+	link := &entity.ShareLink{}
+	err := row.Scan(
+		&link.ID, &link.TenantID, &link.Name, pq.Array(&link.Widgets), &link.Environment, &link.Days,
+		pq.Array(&link.IPAllowlist), &link.TokenHash, &link.ExpiresAt, &link.CreatedBy, &link.CreatedAt,
+		&link.RevokedAt, &link.RevokedBy, &link.LastAccessedAt, &link.AccessCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if link.IPAllowlist == nil {
+		link.IPAllowlist = []string{}
+	}
+	return link, nil
+}
@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	authentity "github.com/arc-platform/backend/modules/auth/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// SSO Provider Repository Implementation
+// ============================================================================
+
+// SSO providers are read before a user is authenticated, so lookups take the
+// tenant explicitly instead of reading it from the request context.
+
+// ErrSSOProviderNotFound is returned when the tenant or ID has no SSO provider
+var ErrSSOProviderNotFound = errors.New("SSO provider not found")
+
+const ssoProviderColumns = `id, tenant_id, name, issuer_url, client_id, client_secret_encrypted, redirect_url,
+	scopes, role_claim, role_mapping, default_role, allowed_domains, jit_provisioning, enforce_sso,
+	break_glass_emails, enabled, created_at, updated_at`
+
+// UpsertSSOProvider creates or replaces the tenant's provider. A nil encrypted
+// secret keeps the stored one, so the secret does not have to be resent on every update.
+func (r *PostgresRepository) UpsertSSOProvider(ctx context.Context, p *authentity.SSOProvider) error {
+	mapping, err := json.Marshal(p.RoleMapping)
+	if err != nil {
+		return fmt.Errorf("failed to marshal role mapping: %w", err)
+	}
+
+	query := `
+		INSERT INTO sso_providers (id, tenant_id, name, issuer_url, client_id, client_secret_encrypted, redirect_url,
+			scopes, role_claim, role_mapping, default_role, allowed_domains, jit_provisioning, enforce_sso,
+			break_glass_emails, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			issuer_url = EXCLUDED.issuer_url,
+			client_id = EXCLUDED.client_id,
+			client_secret_encrypted = COALESCE(EXCLUDED.client_secret_encrypted, sso_providers.client_secret_encrypted),
+			redirect_url = EXCLUDED.redirect_url,
+			scopes = EXCLUDED.scopes,
+			role_claim = EXCLUDED.role_claim,
+			role_mapping = EXCLUDED.role_mapping,
+			default_role = EXCLUDED.default_role,
+			allowed_domains = EXCLUDED.allowed_domains,
+			jit_provisioning = EXCLUDED.jit_provisioning,
+			enforce_sso = EXCLUDED.enforce_sso,
+			break_glass_emails = EXCLUDED.break_glass_emails,
+			enabled = EXCLUDED.enabled,
+			updated_at = NOW()
+		RETURNING id, client_secret_encrypted IS NOT NULL, created_at, updated_at`
+
+	var secret interface{}
+	if p.ClientSecretEncrypted != nil {
+		secret = p.ClientSecretEncrypted
+	}
+
+	err = r.db.QueryRowContext(ctx, query,
+		p.ID, p.TenantID, p.Name, p.IssuerURL, p.ClientID, secret, p.RedirectURL,
+		pq.Array(p.Scopes), p.RoleClaim, mapping, p.DefaultRole, pq.Array(p.AllowedDomains),
+		p.JITProvisioning, p.EnforceSSO, pq.Array(p.BreakGlassEmails), p.Enabled,
+	).Scan(&p.ID, &p.HasClientSecret, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to store SSO provider: %w", err)
+	}
+	return nil
+}
+
+// GetSSOProviderByTenant returns the tenant's provider
+func (r *PostgresRepository) GetSSOProviderByTenant(ctx context.Context, tenantID uuid.UUID) (*authentity.SSOProvider, error) {
+	query := `SELECT ` + ssoProviderColumns + ` FROM sso_providers WHERE tenant_id = $1`
+	p, err := scanSSOProvider(r.db.QueryRowContext(ctx, query, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, ErrSSOProviderNotFound
+	}
+	return p, err
+}
+
+// GetSSOProviderByID returns a provider by ID
+func (r *PostgresRepository) GetSSOProviderByID(ctx context.Context, id uuid.UUID) (*authentity.SSOProvider, error) {
+	query := `SELECT ` + ssoProviderColumns + ` FROM sso_providers WHERE id = $1`
+	p, err := scanSSOProvider(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrSSOProviderNotFound
+	}
+	return p, err
+}
+
+// DeleteSSOProvider removes the tenant's provider
+func (r *PostgresRepository) DeleteSSOProvider(ctx context.Context, tenantID uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM sso_providers WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete SSO provider: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrSSOProviderNotFound
+	}
+	return nil
+}
+
+func scanSSOProvider(row rowScanner) (*authentity.SSOProvider, error) {
+	p := &authentity.SSOProvider{}
+	var scopes, domains, breakGlass pq.StringArray
+	var mapping []byte
+	err := row.Scan(
+		&p.ID, &p.TenantID, &p.Name, &p.IssuerURL, &p.ClientID, &p.ClientSecretEncrypted, &p.RedirectURL,
+		&scopes, &p.RoleClaim, &mapping, &p.DefaultRole, &domains, &p.JITProvisioning, &p.EnforceSSO,
+		&breakGlass, &p.Enabled, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Scopes = scopes
+	p.AllowedDomains = domains
+	p.BreakGlassEmails = breakGlass
+	p.HasClientSecret = len(p.ClientSecretEncrypted) > 0
+	p.RoleMapping = map[string]string{}
+	if len(mapping) > 0 {
+		if err := json.Unmarshal(mapping, &p.RoleMapping); err != nil {
+			return nil, fmt.Errorf("failed to parse role mapping: %w", err)
+		}
+	}
+	return p, nil
+}
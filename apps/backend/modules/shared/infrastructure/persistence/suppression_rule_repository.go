@@ -0,0 +1,179 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Suppression Rule Repository Implementation
+// ============================================================================
+
+const suppressionRuleColumns = `id, tenant_id, name, pattern_name, path_glob, host_glob, reason, is_active,
+	expires_at, hit_count, last_matched_at, created_by, created_at, updated_at`
+
+// CreateSuppressionRule stores a new suppression rule for the tenant
+func (r *PostgresRepository) CreateSuppressionRule(ctx context.Context, rule *entity.SuppressionRule) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	rule.TenantID = tenantID
+
+	query := `
+		INSERT INTO suppression_rules (id, tenant_id, name, pattern_name, path_glob, host_glob, reason, is_active, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		rule.ID, rule.TenantID, rule.Name, rule.PatternName, rule.PathGlob, rule.HostGlob,
+		rule.Reason, rule.IsActive, rule.ExpiresAt, rule.CreatedBy,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("suppression rule %q already exists", rule.Name)
+		}
+		return fmt.Errorf("failed to create suppression rule: %w", err)
+	}
+
+	return nil
+}
+
+// GetSuppressionRule retrieves a suppression rule by ID
+func (r *PostgresRepository) GetSuppressionRule(ctx context.Context, id uuid.UUID) (*entity.SuppressionRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + suppressionRuleColumns + ` FROM suppression_rules WHERE id = $1 AND tenant_id = $2`
+
+	rule, err := scanSuppressionRule(r.db.QueryRowContext(ctx, query, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("suppression rule not found")
+	}
+	return rule, err
+}
+
+// ListSuppressionRules retrieves all suppression rules of the tenant
+func (r *PostgresRepository) ListSuppressionRules(ctx context.Context) ([]*entity.SuppressionRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + suppressionRuleColumns + ` FROM suppression_rules WHERE tenant_id = $1 ORDER BY pattern_name, name`
+	return r.querySuppressionRules(ctx, query, tenantID)
+}
+
+// ListActiveSuppressionRules retrieves the tenant's rules that are enabled and not expired
+func (r *PostgresRepository) ListActiveSuppressionRules(ctx context.Context) ([]*entity.SuppressionRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + suppressionRuleColumns + `
+		FROM suppression_rules
+		WHERE tenant_id = $1 AND is_active AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY created_at`
+	return r.querySuppressionRules(ctx, query, tenantID)
+}
+
+// SetSuppressionRuleActive enables or disables a suppression rule
+func (r *PostgresRepository) SetSuppressionRuleActive(ctx context.Context, id uuid.UUID, active bool) (*entity.SuppressionRule, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE suppression_rules SET is_active = $1
+		WHERE id = $2 AND tenant_id = $3
+		RETURNING ` + suppressionRuleColumns
+
+	rule, err := scanSuppressionRule(r.db.QueryRowContext(ctx, query, active, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("suppression rule not found")
+	}
+	return rule, err
+}
+
+// DeleteSuppressionRule deletes a suppression rule by ID
+func (r *PostgresRepository) DeleteSuppressionRule(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM suppression_rules WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("suppression rule not found")
+	}
+	return nil
+}
+
+// RecordSuppressionHits adds the number of findings each rule suppressed to its running total
+func (r *PostgresRepository) RecordSuppressionHits(ctx context.Context, hits map[uuid.UUID]int) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(hits))
+	counts := make([]int64, 0, len(hits))
+	for id, count := range hits {
+		ids = append(ids, id)
+		counts = append(counts, int64(count))
+	}
+
+	query := `
+		UPDATE suppression_rules s
+		SET hit_count = s.hit_count + h.count, last_matched_at = NOW()
+		FROM unnest($1::uuid[], $2::bigint[]) AS h(id, count)
+		WHERE s.id = h.id`
+
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(uuidStrings(ids)), pq.Array(counts)); err != nil {
+		return fmt.Errorf("failed to record suppression hits: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) querySuppressionRules(ctx context.Context, query string, args ...interface{}) ([]*entity.SuppressionRule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []*entity.SuppressionRule{}
+	for rows.Next() {
+		rule, err := scanSuppressionRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func scanSuppressionRule(row rowScanner) (*entity.SuppressionRule, error) {
+	rule := &entity.SuppressionRule{}
+	err := row.Scan(
+		&rule.ID, &rule.TenantID, &rule.Name, &rule.PatternName, &rule.PathGlob, &rule.HostGlob,
+		&rule.Reason, &rule.IsActive, &rule.ExpiresAt, &rule.HitCount, &rule.LastMatchedAt,
+		&rule.CreatedBy, &rule.CreatedAt, &rule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
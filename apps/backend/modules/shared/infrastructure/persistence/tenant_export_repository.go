@@ -0,0 +1,247 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Tenant Export Repository Implementation
+// ============================================================================
+
+const tenantExportColumns = `id, tenant_id, status, reason, requested_by, requested_at, job_id, storage_provider,
+	bucket, object_key, size_bytes, checksum_sha256, manifest, error, completed_at`
+
+// Tables of a tenant export in bundle order
+const (
+	TenantExportAssets          = "assets"
+	TenantExportFindings        = "findings"
+	TenantExportClassifications = "classifications"
+	TenantExportReviewStates    = "review_states"
+	TenantExportRemediations    = "remediation_actions"
+	TenantExportAuditLogs       = "audit_logs"
+)
+
+// TenantExportTables lists the tables a tenant export bundles
+var TenantExportTables = []string{
+	TenantExportAssets,
+	TenantExportFindings,
+	TenantExportClassifications,
+	TenantExportReviewStates,
+	TenantExportRemediations,
+	TenantExportAuditLogs,
+}
+
+// tenantExportQueries select a page of the tenant's rows of each table as JSON
+// objects, keyed by $1 tenant, $2 last id and $3 page size. Findings leave out
+// their matched values and sample text, and remediation history the original
+// values kept for rollback; the bundle carries metadata, not the PII itself.
+var tenantExportQueries = map[string]string{
+	TenantExportAssets: `
+		SELECT a.id, row_to_json(a) FROM assets a
+		WHERE a.tenant_id = $1 AND a.id > $2 ORDER BY a.id LIMIT $3`,
+	TenantExportFindings: `
+		SELECT f.id, ((CASE WHEN f.payload_offloaded THEN to_jsonb(f) || jsonb_build_object(
+				'context', p.context, 'enrichment_signals', p.enrichment_signals, 'payload_offloaded', false)
+			ELSE to_jsonb(f) END) - 'matches' - 'sample_text')::json
+		FROM findings f
+		LEFT JOIN finding_payloads p ON p.finding_id = f.id
+		WHERE f.tenant_id = $1 AND f.id > $2 ORDER BY f.id LIMIT $3`,
+	TenantExportClassifications: `
+		SELECT c.id, row_to_json(c) FROM classifications c
+		JOIN findings f ON f.id = c.finding_id
+		WHERE f.tenant_id = $1 AND c.id > $2 ORDER BY c.id LIMIT $3`,
+	TenantExportReviewStates: `
+		SELECT rs.id, row_to_json(rs) FROM review_states rs
+		JOIN findings f ON f.id = rs.finding_id
+		WHERE f.tenant_id = $1 AND rs.id > $2 ORDER BY rs.id LIMIT $3`,
+	TenantExportRemediations: `
+		SELECT ra.id, (to_jsonb(ra) || jsonb_build_object('metadata',
+				COALESCE(ra.metadata, '{}'::jsonb) - 'original_value' - 'targets'))::json
+		FROM remediation_actions ra
+		JOIN findings f ON f.id = ra.finding_id
+		WHERE f.tenant_id = $1 AND ra.id > $2 ORDER BY ra.id LIMIT $3`,
+	TenantExportAuditLogs: `
+		SELECT al.id, row_to_json(al) FROM audit_logs al
+		WHERE al.tenant_id = $1 AND al.id > $2 ORDER BY al.id LIMIT $3`,
+}
+
+// IterateTenantExportRows calls fn with the tenant's rows of an exported table a
+// page at a time, in id order, as JSON objects of their columns
+func (r *PostgresRepository) IterateTenantExportRows(ctx context.Context, table string, fn func(rows []json.RawMessage) error) error {
+	query, ok := tenantExportQueries[table]
+	if !ok {
+		return fmt.Errorf("table %s is not exported", table)
+	}
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return iterateByID(ctx, func(ctx context.Context, after uuid.UUID, limit int) ([]json.RawMessage, uuid.UUID, error) {
+		var page []json.RawMessage
+		var last uuid.UUID
+		err := r.withStatementTimeout(ctx, QueryBatch, "iterate_tenant_export_"+table, func(ctx context.Context, tx *sql.Tx) error {
+			rows, err := tx.QueryContext(ctx, query, tenantID, after, limit)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var row json.RawMessage
+				if err := rows.Scan(&last, &row); err != nil {
+					return err
+				}
+				page = append(page, row)
+			}
+			return rows.Err()
+		})
+		if err != nil {
+			return nil, uuid.Nil, fmt.Errorf("failed to export %s: %w", table, err)
+		}
+		return page, last, nil
+	}, fn)
+}
+
+// CreateTenantExport records a requested export for the tenant in ctx
+func (r *PostgresRepository) CreateTenantExport(ctx context.Context, export *entity.TenantExport) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	if export.ID == uuid.Nil {
+		export.ID = uuid.New()
+	}
+	export.TenantID = tenantID
+	export.Status = entity.TenantExportPending
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO tenant_exports (id, tenant_id, status, reason, requested_by, requested_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING requested_at`,
+		export.ID, tenantID, export.Status, export.Reason, export.RequestedBy,
+	).Scan(&export.RequestedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create tenant export: %w", err)
+	}
+	return nil
+}
+
+// SetTenantExportJob links an export to the job building it
+func (r *PostgresRepository) SetTenantExportJob(ctx context.Context, id, jobID uuid.UUID) error {
+	return r.updateTenantExport(ctx, `UPDATE tenant_exports SET job_id = $3 WHERE id = $1 AND tenant_id = $2`, id, jobID)
+}
+
+// StartTenantExport marks an export as being built
+func (r *PostgresRepository) StartTenantExport(ctx context.Context, id uuid.UUID) error {
+	return r.updateTenantExport(ctx, `UPDATE tenant_exports SET status = $3, error = '' WHERE id = $1 AND tenant_id = $2`,
+		id, entity.TenantExportRunning)
+}
+
+// CompleteTenantExport records where a built bundle is stored and what it holds
+func (r *PostgresRepository) CompleteTenantExport(ctx context.Context, export *entity.TenantExport) error {
+	manifest, err := json.Marshal(export.Manifest)
+	if err != nil {
+		return err
+	}
+	return r.updateTenantExport(ctx, `
+		UPDATE tenant_exports SET status = $3, storage_provider = $4, bucket = $5, object_key = $6,
+			size_bytes = $7, checksum_sha256 = $8, manifest = $9, error = '', completed_at = NOW()
+		WHERE id = $1 AND tenant_id = $2`,
+		export.ID, entity.TenantExportCompleted, export.StorageProvider, export.Bucket, export.ObjectKey,
+		export.SizeBytes, export.ChecksumSHA256, manifest)
+}
+
+// FailTenantExport records why an export could not be built
+func (r *PostgresRepository) FailTenantExport(ctx context.Context, id uuid.UUID, message string) error {
+	return r.updateTenantExport(ctx, `UPDATE tenant_exports SET status = $3, error = $4 WHERE id = $1 AND tenant_id = $2`,
+		id, entity.TenantExportFailed, message)
+}
+
+func (r *PostgresRepository) updateTenantExport(ctx context.Context, query string, id uuid.UUID, args ...interface{}) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query, append([]interface{}{id, tenantID}, args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to update tenant export: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("tenant export not found")
+	}
+	return nil
+}
+
+// GetTenantExport returns one of the tenant's exports
+func (r *PostgresRepository) GetTenantExport(ctx context.Context, id uuid.UUID) (*entity.TenantExport, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := scanTenantExport(r.db.QueryRowContext(ctx,
+		`SELECT `+tenantExportColumns+` FROM tenant_exports WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("tenant export not found")
+	}
+	return export, err
+}
+
+// ListTenantExports returns the tenant's exports, newest first
+func (r *PostgresRepository) ListTenantExports(ctx context.Context, limit int) ([]*entity.TenantExport, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+tenantExportColumns+` FROM tenant_exports
+		WHERE tenant_id = $1
+		ORDER BY requested_at DESC, id
+		LIMIT $2`, tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant exports: %w", err)
+	}
+	defer rows.Close()
+
+	exports := []*entity.TenantExport{}
+	for rows.Next() {
+		export, err := scanTenantExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, export)
+	}
+	return exports, rows.Err()
+}
+
+func scanTenantExport(row interface{ Scan(...interface{}) error }) (*entity.TenantExport, error) {
+	var export entity.TenantExport
+	var jobID uuid.NullUUID
+	var manifest []byte
+	var completedAt sql.NullTime
+	if err := row.Scan(&export.ID, &export.TenantID, &export.Status, &export.Reason, &export.RequestedBy,
+		&export.RequestedAt, &jobID, &export.StorageProvider, &export.Bucket, &export.ObjectKey,
+		&export.SizeBytes, &export.ChecksumSHA256, &manifest, &export.Error, &completedAt); err != nil {
+		return nil, err
+	}
+	if jobID.Valid {
+		export.JobID = &jobID.UUID
+	}
+	if len(manifest) > 0 {
+		if err := json.Unmarshal(manifest, &export.Manifest); err != nil {
+			return nil, fmt.Errorf("invalid tenant export manifest: %w", err)
+		}
+	}
+	if completedAt.Valid {
+		export.CompletedAt = &completedAt.Time
+	}
+	return &export, nil
+}
@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// ============================================================================
+// Threshold Simulation Repository Implementation
+// ============================================================================
+
+// ListConfidenceBuckets groups the tenant's live findings by severity, PII type and
+// confidence score floored to three decimals, so any threshold with at most three
+// decimals can be evaluated exactly without reading individual findings.
+// The PII type comes from the classification sub-category, falling back to the pattern name.
+func (r *PostgresRepository) ListConfidenceBuckets(ctx context.Context) ([]entity.ConfidenceBucket, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT COALESCE(f.severity, ''),
+			COALESCE(NULLIF(c.sub_category, ''), f.pattern_name) AS pii_type,
+			FLOOR(f.confidence_score * 1000) / 1000 AS score,
+			COUNT(*)
+		FROM findings f
+		LEFT JOIN LATERAL (
+			SELECT sub_category FROM classifications
+			WHERE finding_id = f.id
+			ORDER BY confidence_score DESC
+			LIMIT 1
+		) c ON true
+		WHERE f.tenant_id = $1 AND f.deleted_at IS NULL
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query confidence buckets: %w", err)
+	}
+	defer rows.Close()
+
+	var buckets []entity.ConfidenceBucket
+	for rows.Next() {
+		var b entity.ConfidenceBucket
+		if err := rows.Scan(&b.Severity, &b.PIIType, &b.Score, &b.Count); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, b)
+	}
+
+	return buckets, rows.Err()
+}
@@ -0,0 +1,359 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Issue Tracker Integration Repository Implementation
+// ============================================================================
+
+var (
+	ErrTicketIntegrationNotFound = errors.New("ticket integration not found")
+	ErrTicketIntegrationExists   = errors.New("ticket integration already exists")
+	ErrTicketAlreadyLinked       = errors.New("ticket is already linked")
+)
+
+const ticketIntegrationColumns = `id, tenant_id, provider, name, base_url, username, api_token_encrypted,
+	webhook_secret_encrypted, queue_mapping, resolved_statuses, enabled, created_by, created_at, updated_at`
+
+const ticketLinkColumns = `l.id, l.tenant_id, l.integration_id, i.provider, l.finding_id, l.remediation_request_id,
+	l.ticket_key, l.external_id, l.ticket_type, l.url, l.tracker_status, l.resolved, l.last_synced_status,
+	l.created_by, l.created_at, l.updated_at`
+
+// CreateTicketIntegration stores a new issue tracker integration for the tenant
+func (r *PostgresRepository) CreateTicketIntegration(ctx context.Context, integration *entity.TicketIntegration) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	integration.TenantID = tenantID
+
+	mapping, err := json.Marshal(integration.QueueMapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode queue mapping: %w", err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO ticket_integrations (id, tenant_id, provider, name, base_url, username, api_token_encrypted,
+			webhook_secret_encrypted, queue_mapping, resolved_statuses, enabled, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING created_at, updated_at`,
+		integration.ID, integration.TenantID, integration.Provider, integration.Name, integration.BaseURL,
+		integration.Username, integration.APITokenEncrypted, integration.WebhookSecretEncrypted, mapping,
+		pq.Array(integration.ResolvedStatuses), integration.Enabled, integration.CreatedBy,
+	).Scan(&integration.CreatedAt, &integration.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("%w: %q", ErrTicketIntegrationExists, integration.Name)
+		}
+		return fmt.Errorf("failed to create ticket integration: %w", err)
+	}
+	return nil
+}
+
+// GetTicketIntegration retrieves one of the tenant's issue tracker integrations
+func (r *PostgresRepository) GetTicketIntegration(ctx context.Context, id uuid.UUID) (*entity.TicketIntegration, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := scanTicketIntegration(r.db.QueryRowContext(ctx,
+		`SELECT `+ticketIntegrationColumns+` FROM ticket_integrations WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, ErrTicketIntegrationNotFound
+	}
+	return integration, err
+}
+
+// GetTicketIntegrationForWebhook retrieves an integration by ID alone. Inbound
+// webhooks carry no tenant; the caller must verify the webhook's signature
+// before acting on the integration's tenant.
+func (r *PostgresRepository) GetTicketIntegrationForWebhook(ctx context.Context, id uuid.UUID) (*entity.TicketIntegration, error) {
+	integration, err := scanTicketIntegration(r.db.QueryRowContext(ctx,
+		`SELECT `+ticketIntegrationColumns+` FROM ticket_integrations WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrTicketIntegrationNotFound
+	}
+	return integration, err
+}
+
+// ListTicketIntegrations returns the tenant's issue tracker integrations by name
+func (r *PostgresRepository) ListTicketIntegrations(ctx context.Context) ([]*entity.TicketIntegration, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+ticketIntegrationColumns+` FROM ticket_integrations WHERE tenant_id = $1 ORDER BY name`, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket integrations: %w", err)
+	}
+	defer rows.Close()
+
+	integrations := []*entity.TicketIntegration{}
+	for rows.Next() {
+		integration, err := scanTicketIntegration(rows)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, integration)
+	}
+	return integrations, rows.Err()
+}
+
+// UpdateTicketIntegration saves an integration's settings and credentials
+func (r *PostgresRepository) UpdateTicketIntegration(ctx context.Context, integration *entity.TicketIntegration) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := json.Marshal(integration.QueueMapping)
+	if err != nil {
+		return fmt.Errorf("failed to encode queue mapping: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE ticket_integrations
+		SET name = $1, base_url = $2, username = $3, api_token_encrypted = $4, webhook_secret_encrypted = $5,
+			queue_mapping = $6, resolved_statuses = $7, enabled = $8, updated_at = NOW()
+		WHERE id = $9 AND tenant_id = $10`,
+		integration.Name, integration.BaseURL, integration.Username, integration.APITokenEncrypted,
+		integration.WebhookSecretEncrypted, mapping, pq.Array(integration.ResolvedStatuses), integration.Enabled,
+		integration.ID, tenantID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("%w: %q", ErrTicketIntegrationExists, integration.Name)
+		}
+		return fmt.Errorf("failed to update ticket integration: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrTicketIntegrationNotFound
+	}
+	return nil
+}
+
+// DeleteTicketIntegration deletes an integration and its ticket links. The
+// tickets themselves are left in the tracker.
+func (r *PostgresRepository) DeleteTicketIntegration(ctx context.Context, id uuid.UUID) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM ticket_integrations WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete ticket integration: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrTicketIntegrationNotFound
+	}
+	return nil
+}
+
+// ListTicketingTenantIDs returns the tenants with enabled integrations (used by the sync worker)
+func (r *PostgresRepository) ListTicketingTenantIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT DISTINCT tenant_id FROM ticket_integrations WHERE enabled ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticketing tenants: %w", err)
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	return tenantIDs, rows.Err()
+}
+
+// CreateTicketLink links a ticket to a finding or a remediation request
+func (r *PostgresRepository) CreateTicketLink(ctx context.Context, link *entity.TicketLink) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	link.TenantID = tenantID
+
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO ticket_links (id, tenant_id, integration_id, finding_id, remediation_request_id, ticket_key,
+			external_id, ticket_type, url, tracker_status, resolved, last_synced_status, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING created_at, updated_at`,
+		link.ID, link.TenantID, link.IntegrationID, link.FindingID, link.RemediationRequestID, link.TicketKey,
+		link.ExternalID, link.TicketType, link.URL, link.TrackerStatus, link.Resolved, link.LastSyncedStatus,
+		link.CreatedBy,
+	).Scan(&link.CreatedAt, &link.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("%w: %s", ErrTicketAlreadyLinked, link.TicketKey)
+		}
+		return fmt.Errorf("failed to create ticket link: %w", err)
+	}
+	return nil
+}
+
+// ListTicketLinks returns the tickets linked to a finding or, when findingID is
+// nil, to a remediation request, newest first
+func (r *PostgresRepository) ListTicketLinks(ctx context.Context, findingID, remediationRequestID *uuid.UUID) ([]*entity.TicketLink, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+ticketLinkColumns+`
+		FROM ticket_links l JOIN ticket_integrations i ON i.id = l.integration_id
+		WHERE l.tenant_id = $1
+		  AND ($2::uuid IS NULL OR l.finding_id = $2)
+		  AND ($3::uuid IS NULL OR l.remediation_request_id = $3)
+		ORDER BY l.created_at DESC`,
+		tenantID, findingID, remediationRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket links: %w", err)
+	}
+	defer rows.Close()
+	return scanTicketLinks(rows)
+}
+
+// ListTicketLinksByTicket returns the integration's links to a ticket, matched
+// by its external ID or key
+func (r *PostgresRepository) ListTicketLinksByTicket(ctx context.Context, integrationID uuid.UUID, externalID, ticketKey string) ([]*entity.TicketLink, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+ticketLinkColumns+`
+		FROM ticket_links l JOIN ticket_integrations i ON i.id = l.integration_id
+		WHERE l.tenant_id = $1 AND l.integration_id = $2
+		  AND ((l.external_id = $3 AND $3 <> '') OR (l.ticket_key = $4 AND $4 <> ''))`,
+		tenantID, integrationID, externalID, ticketKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket links: %w", err)
+	}
+	defer rows.Close()
+	return scanTicketLinks(rows)
+}
+
+// UpdateTicketLinkStatus records the tracker's status for a link and the ARC
+// status the tracker now reflects
+func (r *PostgresRepository) UpdateTicketLinkStatus(ctx context.Context, id uuid.UUID, trackerStatus string, resolved bool, syncedStatus string) error {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(ctx, `
+		UPDATE ticket_links SET tracker_status = $1, resolved = $2, last_synced_status = $3, updated_at = NOW()
+		WHERE id = $4 AND tenant_id = $5`,
+		trackerStatus, resolved, syncedStatus, id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket link: %w", err)
+	}
+	return nil
+}
+
+// ListTicketSyncCandidates returns unresolved links of enabled integrations whose
+// finding review status or remediation request status differs from the status
+// last synced to the tracker
+func (r *PostgresRepository) ListTicketSyncCandidates(ctx context.Context, limit int) ([]*entity.TicketSyncCandidate, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+ticketLinkColumns+`, s.arc_status
+		FROM ticket_links l
+		JOIN ticket_integrations i ON i.id = l.integration_id AND i.enabled
+		LEFT JOIN review_states rs ON rs.finding_id = l.finding_id
+		LEFT JOIN remediation_requests rr ON rr.id = l.remediation_request_id
+		CROSS JOIN LATERAL (
+			SELECT CASE WHEN l.finding_id IS NOT NULL THEN COALESCE(rs.status, 'open') ELSE rr.status END AS arc_status
+		) s
+		WHERE l.tenant_id = $1 AND NOT l.resolved AND s.arc_status IS NOT NULL
+		  AND s.arc_status <> l.last_synced_status
+		ORDER BY l.updated_at
+		LIMIT $2`,
+		tenantID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ticket sync candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*entity.TicketSyncCandidate
+	for rows.Next() {
+		c := &entity.TicketSyncCandidate{}
+		link, err := scanTicketLink(rows, &c.ARCStatus)
+		if err != nil {
+			return nil, err
+		}
+		c.Link = link
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+func scanTicketIntegration(row rowScanner) (*entity.TicketIntegration, error) {
+	integration := &entity.TicketIntegration{}
+	var mapping []byte
+	err := row.Scan(
+		&integration.ID, &integration.TenantID, &integration.Provider, &integration.Name, &integration.BaseURL,
+		&integration.Username, &integration.APITokenEncrypted, &integration.WebhookSecretEncrypted, &mapping,
+		pq.Array(&integration.ResolvedStatuses), &integration.Enabled, &integration.CreatedBy,
+		&integration.CreatedAt, &integration.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(mapping) > 0 {
+		if err := json.Unmarshal(mapping, &integration.QueueMapping); err != nil {
+			return nil, fmt.Errorf("failed to decode queue mapping: %w", err)
+		}
+	}
+	integration.HasAPIToken = len(integration.APITokenEncrypted) > 0
+	integration.HasWebhookSecret = len(integration.WebhookSecretEncrypted) > 0
+	return integration, nil
+}
+
+func scanTicketLink(row rowScanner, extra ...interface{}) (*entity.TicketLink, error) {
+	link := &entity.TicketLink{}
+	dest := []interface{}{
+		&link.ID, &link.TenantID, &link.IntegrationID, &link.Provider, &link.FindingID, &link.RemediationRequestID,
+		&link.TicketKey, &link.ExternalID, &link.TicketType, &link.URL, &link.TrackerStatus, &link.Resolved,
+		&link.LastSyncedStatus, &link.CreatedBy, &link.CreatedAt, &link.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+func scanTicketLinks(rows *sql.Rows) ([]*entity.TicketLink, error) {
+	links := []*entity.TicketLink{}
+	for rows.Next() {
+		link, err := scanTicketLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
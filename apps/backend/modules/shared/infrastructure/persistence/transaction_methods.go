@@ -23,8 +23,8 @@ func (t *PostgresTransaction) CreateScanRun(ctx context.Context, scanRun *entity
 	query := `
 		INSERT INTO scan_runs (
 			id, profile_name, scan_started_at, scan_completed_at, host, status,
-			total_findings, total_assets, metadata, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+			total_findings, total_assets, metadata, connection_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW())
 	`
 
 	_, err = t.tx.ExecContext(ctx, query,
@@ -37,6 +37,7 @@ func (t *PostgresTransaction) CreateScanRun(ctx context.Context, scanRun *entity
 		scanRun.TotalFindings,
 		scanRun.TotalAssets,
 		metadataJSON,
+		scanRun.ConnectionID,
 	)
 
 	return err
@@ -114,30 +115,72 @@ func (t *PostgresTransaction) CreateFinding(ctx context.Context, finding *entity
 		return err
 	}
 
+	var enrichmentJSON []byte
+	if len(finding.EnrichmentSignals) > 0 {
+		if enrichmentJSON, err = json.Marshal(finding.EnrichmentSignals); err != nil {
+			return err
+		}
+	}
+
+	locationsJSON, err := marshalMatchLocations(finding.Locations)
+	if err != nil {
+		return err
+	}
+
+	// Values are sealed under the data key of the tenant in ctx, which the row then
+	// records so they can be opened again; without a tenant they are stored as is
+	matches, sampleText := finding.Matches, finding.SampleText
+	var tenant interface{}
+	var keyVersion *int
+	if tenantID, err := GetTenantID(ctx); err == nil {
+		tenant = tenantID
+		if matches, sampleText, keyVersion, err = sealFindingValues(ctx, t.tx, tenantID, matches, sampleText); err != nil {
+			return err
+		}
+	}
+
+	// Large payloads go to the side table, leaving a summary in the row
+	rowContext, rowEnrichment, offloaded := splitFindingPayload(contextJSON, enrichmentJSON)
+
 	query := `
 		INSERT INTO findings (id, scan_run_id, asset_id, pattern_id, pattern_name, 
-			matches, sample_text, severity, severity_description, confidence_score, context)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			matches, sample_text, severity, severity_description, confidence_score, context,
+			environment, enrichment_signals, enrichment_score, enrichment_failed, match_locations,
+			sample_text_sha256, sample_text_redacted, encryption_key_version, tenant_id, payload_offloaded, total_matches)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, COALESCE(NULLIF($12, ''), 'PROD'), $13, $14, $15, $16,
+			NULLIF($17, ''), $18, $19, $20, $21, NULLIF($22, 0))
 		RETURNING created_at, updated_at`
 
-	return t.tx.QueryRowContext(ctx, query,
+	err = t.tx.QueryRowContext(ctx, query,
 		finding.ID, finding.ScanRunID, finding.AssetID, finding.PatternID, finding.PatternName,
-		pq.Array(finding.Matches), finding.SampleText, finding.Severity, finding.SeverityDescription,
-		finding.ConfidenceScore, contextJSON,
+		pq.Array(matches), sampleText, finding.Severity, finding.SeverityDescription,
+		finding.ConfidenceScore, rowContext,
+		finding.Environment, rowEnrichment, finding.EnrichmentScore, finding.EnrichmentFailed, locationsJSON,
+		finding.SampleTextSHA256, finding.SampleTextRedacted, keyVersion, tenant, offloaded, finding.TotalMatches,
 	).Scan(&finding.CreatedAt, &finding.UpdatedAt)
+	if err != nil || !offloaded {
+		return err
+	}
+	return insertFindingPayload(ctx, t.tx, finding.ID, contextJSON, enrichmentJSON)
 }
 
 // CreateClassification creates a new classification within a transaction
 func (t *PostgresTransaction) CreateClassification(ctx context.Context, classification *entity.Classification) error {
+	breakdownJSON, err := marshalSignalBreakdown(classification.SignalBreakdown)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO classifications (
 			id, finding_id, classification_type, sub_category, confidence_score,
 			justification, dpdpa_category, requires_consent,
+			signal_breakdown, classifier_version,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE(NULLIF($10, ''), 'v2.0-multisignal'), NOW(), NOW())
 	`
 
-	_, err := t.tx.ExecContext(ctx, query,
+	_, err = t.tx.ExecContext(ctx, query,
 		classification.ID,
 		classification.FindingID,
 		classification.ClassificationType,
@@ -146,6 +189,8 @@ func (t *PostgresTransaction) CreateClassification(ctx context.Context, classifi
 		classification.Justification,
 		classification.DPDPACategory,
 		classification.RequiresConsent,
+		breakdownJSON,
+		classification.EngineVersion,
 	)
 
 	return err
@@ -222,7 +267,8 @@ func (t *PostgresTransaction) UpdateAssetStats(ctx context.Context, assetID uuid
 	return err
 }
 
-// UpdateScanRun updates scan run statistics within a transaction
+// UpdateScanRun updates scan run statistics within a transaction. The signer
+// columns are left alone when SignatureStatus is empty.
 func (t *PostgresTransaction) UpdateScanRun(ctx context.Context, scanRun *entity.ScanRun) error {
 	// Marshal metadata to JSON
 	metadataJSON, err := json.Marshal(scanRun.Metadata)
@@ -236,8 +282,11 @@ func (t *PostgresTransaction) UpdateScanRun(ctx context.Context, scanRun *entity
 		    total_assets = $2,
 		    metadata = $3,
 		    status = $4,
+		    signature_status = COALESCE(NULLIF($5, ''), signature_status),
+		    signer_key_id = CASE WHEN $5 = '' THEN signer_key_id ELSE $6 END,
+		    signer_name = CASE WHEN $5 = '' THEN signer_name ELSE NULLIF($7, '') END,
 		    updated_at = NOW()
-		WHERE id = $5
+		WHERE id = $8
 	`
 
 	_, err = t.tx.ExecContext(ctx, query,
@@ -245,6 +294,9 @@ func (t *PostgresTransaction) UpdateScanRun(ctx context.Context, scanRun *entity
 		scanRun.TotalAssets,
 		metadataJSON,
 		scanRun.Status,
+		scanRun.SignatureStatus,
+		scanRun.SignerKeyID,
+		scanRun.SignerName,
 		scanRun.ID,
 	)
 
@@ -0,0 +1,159 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RetryOptions bounds how a transaction is retried after a transient error
+type RetryOptions struct {
+	MaxAttempts int           // Attempts including the first; 1 disables retries
+	BaseDelay   time.Duration // Backoff before the first retry, doubled for each one after
+	MaxDelay    time.Duration // Cap on a single backoff
+}
+
+// DefaultRetryOptions retry a transaction twice within about half a second
+var DefaultRetryOptions = RetryOptions{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// Transient error reasons, used as metric labels
+const (
+	RetryReasonSerialization = "serialization_failure"
+	RetryReasonDeadlock      = "deadlock"
+	RetryReasonLock          = "lock_not_available"
+	RetryReasonConnection    = "connection"
+	RetryReasonUnavailable   = "server_unavailable"
+)
+
+// retryableCodes maps the Postgres error codes worth retrying to their reason.
+// Every other code, such as a constraint violation or a statement timeout, is
+// fatal: running the transaction again would fail the same way.
+var retryableCodes = map[pq.ErrorCode]string{
+	"40001": RetryReasonSerialization, // serialization_failure
+	"40P01": RetryReasonDeadlock,      // deadlock_detected
+	"55P03": RetryReasonLock,          // lock_not_available
+	"08000": RetryReasonConnection,    // connection_exception
+	"08003": RetryReasonConnection,    // connection_does_not_exist
+	"08006": RetryReasonConnection,    // connection_failure
+	"08001": RetryReasonConnection,    // sqlclient_unable_to_establish_sqlconnection
+	"08004": RetryReasonConnection,    // sqlserver_rejected_establishment_of_sqlconnection
+	"57P01": RetryReasonUnavailable,   // admin_shutdown
+	"57P02": RetryReasonUnavailable,   // crash_shutdown
+	"57P03": RetryReasonUnavailable,   // cannot_connect_now
+	"53300": RetryReasonUnavailable,   // too_many_connections
+}
+
+// ErrCommitOutcomeUnknown marks a commit whose connection dropped before Postgres
+// answered. The transaction may have been committed, so it is never retried.
+var ErrCommitOutcomeUnknown = errors.New("commit outcome unknown")
+
+var (
+	transactionRetryCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_transaction_retries_total",
+		Help: "Transactions run again after a transient Postgres error, by reason",
+	}, []string{"operation", "reason"})
+	transactionRetryExhaustedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_transaction_retries_exhausted_total",
+		Help: "Transactions that still failed with a transient error after their last attempt",
+	}, []string{"operation"})
+)
+
+// RetryReason returns why err is worth retrying, or "" when it is fatal. Besides
+// the Postgres codes above, connections dropped before Postgres answered are
+// transient; a canceled or expired context never is.
+func RetryReason(err error) string {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ""
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return retryableCodes[pqErr.Code]
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return RetryReasonConnection
+	}
+	var netErr *net.OpError
+	if errors.As(err, &netErr) {
+		return RetryReasonConnection
+	}
+	return ""
+}
+
+// CommitError marks a commit error from a dropped connection as
+// ErrCommitOutcomeUnknown. A conflict Postgres reported at commit, such as a
+// serialization failure, means the transaction was rolled back and stays retryable.
+func CommitError(err error) error {
+	if RetryReason(err) == RetryReasonConnection {
+		return fmt.Errorf("%w: %v", ErrCommitOutcomeUnknown, err)
+	}
+	return err
+}
+
+// IsRetryable reports whether err is a transient error a transaction can be run
+// again after
+func IsRetryable(err error) bool {
+	return RetryReason(err) != ""
+}
+
+// RetryTransient runs fn, which must begin, run and commit one transaction, until
+// it succeeds, fails with a fatal error or runs out of attempts. Waits between
+// attempts back off exponentially with full jitter so concurrent writers that
+// conflicted do not collide again. It returns the number of retries made.
+func RetryTransient(ctx context.Context, opts RetryOptions, operation string, fn func(ctx context.Context) error) (int, error) {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+
+	retries := 0
+	for {
+		err := fn(ctx)
+		reason := RetryReason(err)
+		if reason == "" {
+			return retries, err
+		}
+		if retries+1 >= opts.MaxAttempts {
+			transactionRetryExhaustedCounter.WithLabelValues(operation).Inc()
+			return retries, err
+		}
+
+		retries++
+		transactionRetryCounter.WithLabelValues(operation, reason).Inc()
+		delay := retryDelay(opts, retries)
+		log.Printf("WARN: %s failed with a transient error (%s), retry %d/%d in %s: %v",
+			operation, reason, retries, opts.MaxAttempts-1, delay.Round(time.Millisecond), err)
+
+		select {
+		case <-ctx.Done():
+			return retries, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryDelay is a random wait up to the exponential backoff of the retry
+func retryDelay(opts RetryOptions, retry int) time.Duration {
+	backoff := opts.BaseDelay << (retry - 1)
+	if backoff <= 0 || (opts.MaxDelay > 0 && backoff > opts.MaxDelay) {
+		backoff = opts.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryReason_ClassifiesPostgresErrors(t *testing.T) {
+	assert.Equal(t, RetryReasonSerialization, RetryReason(fmt.Errorf("commit: %w", &pq.Error{Code: "40001"})))
+	assert.Equal(t, RetryReasonDeadlock, RetryReason(&pq.Error{Code: "40P01"}))
+	assert.Equal(t, RetryReasonUnavailable, RetryReason(&pq.Error{Code: "57P01"}))
+	assert.Equal(t, RetryReasonConnection, RetryReason(driver.ErrBadConn))
+
+	// Fatal errors fail the same way on every attempt
+	assert.Empty(t, RetryReason(&pq.Error{Code: "23505"}), "unique violation")
+	assert.Empty(t, RetryReason(&pq.Error{Code: "57014"}), "statement timeout")
+	assert.Empty(t, RetryReason(context.DeadlineExceeded))
+	assert.Empty(t, RetryReason(errors.New("invalid input")))
+
+	// A commit whose connection dropped may have been applied
+	err := CommitError(driver.ErrBadConn)
+	assert.ErrorIs(t, err, ErrCommitOutcomeUnknown)
+	assert.False(t, IsRetryable(err))
+	assert.True(t, IsRetryable(CommitError(&pq.Error{Code: "40001"})))
+}
+
+func TestRetryTransient(t *testing.T) {
+	opts := RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	ctx := context.Background()
+
+	attempts := 0
+	retries, err := RetryTransient(ctx, opts, "test", func(ctx context.Context) error {
+		if attempts++; attempts < 3 {
+			return &pq.Error{Code: "40001"}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, retries)
+
+	attempts = 0
+	retries, err = RetryTransient(ctx, opts, "test", func(ctx context.Context) error {
+		attempts++
+		return &pq.Error{Code: "23505"}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, retries, "fatal errors are not retried")
+	assert.Equal(t, 1, attempts)
+
+	attempts = 0
+	retries, err = RetryTransient(ctx, opts, "test", func(ctx context.Context) error {
+		attempts++
+		return &pq.Error{Code: "40P01"}
+	})
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, 2, retries)
+	assert.Equal(t, 3, attempts, "gives up after MaxAttempts")
+}
+
+func TestRetryDelayIsBoundedAndJittered(t *testing.T) {
+	opts := RetryOptions{BaseDelay: 100 * time.Millisecond, MaxDelay: 250 * time.Millisecond}
+	for retry := 1; retry <= 10; retry++ {
+		delay := retryDelay(opts, retry)
+		assert.True(t, delay > 0 && delay <= 250*time.Millisecond, "retry %d waited %s", retry, delay)
+	}
+	assert.Equal(t, time.Duration(0), retryDelay(RetryOptions{}, 1))
+}
@@ -0,0 +1,370 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+)
+
+// ============================================================================
+// Trash Repository Implementation
+// ============================================================================
+
+const trashBatchColumns = `id, tenant_id, scope, resource_id, asset_count, finding_count, scan_run_count,
+	retained_count, deleted_by, deleted_at, COALESCE(restored_by, ''), restored_at, purged_at`
+
+// Rows that must outlive a purge: findings with remediation or policy evidence, and
+// the assets and scan runs those findings (or finding archives) still reference.
+const (
+	purgeableFindingFilter = `
+		f.trash_batch_id = $1
+		AND NOT EXISTS (SELECT 1 FROM remediation_actions ra WHERE ra.finding_id = f.id)
+		AND NOT EXISTS (SELECT 1 FROM policy_executions pe WHERE pe.finding_id = f.id)`
+
+	purgeableScanRunFilter = `
+		sr.trash_batch_id = $1
+		AND NOT EXISTS (SELECT 1 FROM findings f WHERE f.scan_run_id = sr.id)
+		AND NOT EXISTS (SELECT 1 FROM finding_archives fa WHERE fa.scan_run_id = sr.id)`
+
+	purgeableAssetFilter = `
+		a.trash_batch_id = $1
+		AND NOT EXISTS (SELECT 1 FROM findings f WHERE f.asset_id = a.id)`
+)
+
+// trashStep soft-deletes the rows of one table matching filter. Filters are
+// evaluated with $1 = batch ID, $2 = tenant ID and $3 = the trashed resource.
+type trashStep struct {
+	table  string
+	filter string
+	count  *int
+}
+
+// TrashScanData soft-deletes every asset, finding and scan run of the tenant as one batch
+func (r *PostgresRepository) TrashScanData(ctx context.Context, deletedBy string) (*entity.TrashBatch, error) {
+	return r.trashRows(ctx, entity.TrashScopeScanData, nil, deletedBy)
+}
+
+// TrashAsset soft-deletes an asset together with its findings
+func (r *PostgresRepository) TrashAsset(ctx context.Context, assetID uuid.UUID, deletedBy string) (*entity.TrashBatch, error) {
+	return r.trashRows(ctx, entity.TrashScopeAsset, &assetID, deletedBy)
+}
+
+// TrashScanRun soft-deletes a scan run together with its findings
+func (r *PostgresRepository) TrashScanRun(ctx context.Context, scanRunID uuid.UUID, deletedBy string) (*entity.TrashBatch, error) {
+	return r.trashRows(ctx, entity.TrashScopeScanRun, &scanRunID, deletedBy)
+}
+
+func (r *PostgresRepository) trashRows(ctx context.Context, scope string, resourceID *uuid.UUID, deletedBy string) (*entity.TrashBatch, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &entity.TrashBatch{TenantID: tenantID, Scope: scope, ResourceID: resourceID, DeletedBy: deletedBy}
+
+	// Scan runs are not always stamped with a tenant, so unowned runs are treated as the caller's.
+	// The first step of a single-resource scope deletes the resource itself.
+	var steps []trashStep
+	switch scope {
+	case entity.TrashScopeScanData:
+		steps = []trashStep{
+			{"findings", "tenant_id = $2", &batch.FindingCount},
+			{"assets", "tenant_id = $2", &batch.AssetCount},
+			{"scan_runs", "COALESCE(tenant_id, $2) = $2", &batch.ScanRunCount},
+		}
+	case entity.TrashScopeAsset:
+		steps = []trashStep{
+			{"assets", "id = $3 AND tenant_id = $2", &batch.AssetCount},
+			{"findings", "asset_id = $3 AND tenant_id = $2", &batch.FindingCount},
+		}
+	case entity.TrashScopeScanRun:
+		steps = []trashStep{
+			{"scan_runs", "id = $3 AND COALESCE(tenant_id, $2) = $2", &batch.ScanRunCount},
+			{"findings", "scan_run_id = $3 AND tenant_id = $2", &batch.FindingCount},
+		}
+	default:
+		return nil, fmt.Errorf("invalid trash scope %q", scope)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO trash_batches (tenant_id, scope, resource_id, deleted_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, deleted_at`,
+		tenantID, scope, resourceID, deletedBy,
+	).Scan(&batch.ID, &batch.DeletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trash batch: %w", err)
+	}
+
+	args := []interface{}{batch.ID, tenantID}
+	if resourceID != nil {
+		args = append(args, *resourceID)
+	}
+
+	for i, step := range steps {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE `+step.table+` SET deleted_at = NOW(), trash_batch_id = $1
+			WHERE `+step.filter+` AND deleted_at IS NULL`,
+			args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to trash %s: %w", step.table, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		*step.count = int(n)
+
+		if i == 0 && resourceID != nil && n == 0 {
+			if scope == entity.TrashScopeAsset {
+				return nil, fmt.Errorf("asset not found")
+			}
+			return nil, fmt.Errorf("scan run not found")
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE trash_batches SET asset_count = $2, finding_count = $3, scan_run_count = $4
+		WHERE id = $1`,
+		batch.ID, batch.AssetCount, batch.FindingCount, batch.ScanRunCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update trash batch: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit trash batch: %w", err)
+	}
+	return batch, nil
+}
+
+// GetTrashBatch retrieves one of the tenant's trash batches
+func (r *PostgresRepository) GetTrashBatch(ctx context.Context, id uuid.UUID) (*entity.TrashBatch, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := scanTrashBatch(r.db.QueryRowContext(ctx,
+		`SELECT `+trashBatchColumns+` FROM trash_batches WHERE id = $1 AND tenant_id = $2`, id, tenantID))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("trash batch not found")
+	}
+	return batch, err
+}
+
+// ListTrashBatches lists the tenant's trash batches, newest first. Unless includeClosed
+// is set only batches that are still restorable or awaiting purge are returned.
+func (r *PostgresRepository) ListTrashBatches(ctx context.Context, includeClosed bool, limit, offset int) ([]*entity.TrashBatch, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ` + trashBatchColumns + ` FROM trash_batches
+		WHERE tenant_id = $1 AND ($2 OR (restored_at IS NULL AND purged_at IS NULL))
+		ORDER BY deleted_at DESC
+		LIMIT $3 OFFSET $4`
+
+	return r.queryTrashBatches(ctx, query, tenantID, includeClosed, limit, offset)
+}
+
+// ListExpiredTrashBatches returns pending batches of every tenant trashed more than
+// retentionDays ago (used by the purge worker)
+func (r *PostgresRepository) ListExpiredTrashBatches(ctx context.Context, retentionDays, limit int) ([]*entity.TrashBatch, error) {
+	query := `
+		SELECT ` + trashBatchColumns + ` FROM trash_batches
+		WHERE restored_at IS NULL AND purged_at IS NULL
+		  AND deleted_at <= NOW() - make_interval(days => $1)
+		ORDER BY deleted_at
+		LIMIT $2`
+
+	return r.queryTrashBatches(ctx, query, retentionDays, limit)
+}
+
+// RestoreTrashBatch brings the rows of a pending batch back, provided it was trashed
+// less than retentionDays ago
+func (r *PostgresRepository) RestoreTrashBatch(ctx context.Context, id uuid.UUID, restoredBy string, retentionDays int) (*entity.TrashBatch, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var expired bool
+	batch, err := scanTrashBatch(tx.QueryRowContext(ctx, `
+		SELECT `+trashBatchColumns+`, deleted_at <= NOW() - make_interval(days => $3)
+		FROM trash_batches WHERE id = $1 AND tenant_id = $2
+		FOR UPDATE`,
+		id, tenantID, retentionDays), &expired)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("trash batch not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case batch.RestoredAt != nil:
+		return nil, fmt.Errorf("trash batch has already been restored")
+	case batch.PurgedAt != nil:
+		return nil, fmt.Errorf("trash batch has already been purged")
+	case expired:
+		return nil, fmt.Errorf("trash batch restore window has expired")
+	}
+
+	for _, table := range []string{"scan_runs", "assets", "findings"} {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE `+table+` SET deleted_at = NULL, trash_batch_id = NULL WHERE trash_batch_id = $1`, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", table, err)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE trash_batches SET restored_by = $2, restored_at = NOW()
+		WHERE id = $1
+		RETURNING restored_at`,
+		id, restoredBy,
+	).Scan(&batch.RestoredAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark trash batch restored: %w", err)
+	}
+	batch.RestoredBy = restoredBy
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return batch, nil
+}
+
+// PurgeTrashBatch permanently deletes the rows of a pending batch. Findings with
+// remediation actions or policy executions, and the assets and scan runs they or
+// finding archives still reference, are kept soft-deleted and counted as retained.
+// Returns nil when the batch was restored or purged concurrently.
+func (r *PostgresRepository) PurgeTrashBatch(ctx context.Context, id uuid.UUID) (*entity.TrashBatch, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	batch, err := scanTrashBatch(tx.QueryRowContext(ctx, `
+		SELECT `+trashBatchColumns+` FROM trash_batches
+		WHERE id = $1 AND restored_at IS NULL AND purged_at IS NULL
+		FOR UPDATE`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Purged findings leave no history behind; deleting them would otherwise close
+	// their last version and keep it for as-of reads
+	statements := []struct{ name, query string }{
+		{"finding history", `
+			DELETE FROM findings_history WHERE finding_id IN (
+				SELECT f.id FROM findings f WHERE ` + purgeableFindingFilter + `
+			)`},
+		{"review history", `
+			DELETE FROM review_states_history WHERE finding_id IN (
+				SELECT f.id FROM findings f WHERE ` + purgeableFindingFilter + `
+			)`},
+		{"findings", `DELETE FROM findings f WHERE ` + purgeableFindingFilter},
+		{"scan state transitions", `
+			DELETE FROM scan_state_transitions WHERE scan_run_id IN (
+				SELECT sr.id FROM scan_runs sr WHERE ` + purgeableScanRunFilter + `
+			)`},
+		{"scan runs", `DELETE FROM scan_runs sr WHERE ` + purgeableScanRunFilter},
+		{"assets", `DELETE FROM assets a WHERE ` + purgeableAssetFilter},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt.query, id); err != nil {
+			return nil, fmt.Errorf("failed to purge %s: %w", stmt.name, err)
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE trash_batches SET purged_at = NOW(), retained_count = (
+			(SELECT COUNT(*) FROM findings WHERE trash_batch_id = $1) +
+			(SELECT COUNT(*) FROM assets WHERE trash_batch_id = $1) +
+			(SELECT COUNT(*) FROM scan_runs WHERE trash_batch_id = $1)
+		)
+		WHERE id = $1
+		RETURNING purged_at, retained_count`, id,
+	).Scan(&batch.PurgedAt, &batch.RetainedCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark trash batch purged: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purge: %w", err)
+	}
+	return batch, nil
+}
+
+// UntrashAsset clears the soft delete of an asset on its own, used when a trashed
+// asset is scanned again. Its trashed findings stay in the trash.
+func (r *PostgresRepository) UntrashAsset(ctx context.Context, id uuid.UUID) (bool, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE assets SET deleted_at = NULL, trash_batch_id = NULL
+		WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL`,
+		id, tenantID)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore asset: %w", err)
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (r *PostgresRepository) queryTrashBatches(ctx context.Context, query string, args ...interface{}) ([]*entity.TrashBatch, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trash batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*entity.TrashBatch
+	for rows.Next() {
+		batch, err := scanTrashBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+	return batches, rows.Err()
+}
+
+// scanTrashBatch scans trashBatchColumns followed by any extra destinations
+func scanTrashBatch(row rowScanner, extra ...interface{}) (*entity.TrashBatch, error) {
+	batch := &entity.TrashBatch{}
+	dest := append([]interface{}{
+		&batch.ID, &batch.TenantID, &batch.Scope, &batch.ResourceID, &batch.AssetCount,
+		&batch.FindingCount, &batch.ScanRunCount, &batch.RetainedCount, &batch.DeletedBy,
+		&batch.DeletedAt, &batch.RestoredBy, &batch.RestoredAt, &batch.PurgedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ============================================================================
+// Value Occurrence Repository Implementation
+// ============================================================================
+
+// ListValueOccurrences returns every asset of the tenant a value hash was observed
+// in, most recently observed first
+func (r *PostgresRepository) ListValueOccurrences(ctx context.Context, valueHash string) ([]entity.ValueOccurrence, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT o.asset_id, a.name, a.path, COALESCE(a.data_source, ''), COALESCE(a.host, ''),
+			COALESCE(NULLIF(a.source_system, ''), a.data_source, ''), COALESCE(a.environment, ''),
+			ARRAY_AGG(DISTINCT o.pattern_name), COUNT(*), MIN(o.observed_at), MAX(o.observed_at),
+			(ARRAY_AGG(o.finding_id ORDER BY o.observed_at DESC) FILTER (WHERE o.finding_id IS NOT NULL))[1]
+		FROM finding_observations o
+		JOIN assets a ON a.id = o.asset_id
+		WHERE o.tenant_id = $1 AND o.value_hash = $2 AND a.deleted_at IS NULL
+		GROUP BY o.asset_id, a.name, a.path, a.data_source, a.host, a.source_system, a.environment
+		ORDER BY MAX(o.observed_at) DESC, a.name`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantID, valueHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query value occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	occurrences := []entity.ValueOccurrence{}
+	for rows.Next() {
+		var o entity.ValueOccurrence
+		var latest uuid.NullUUID
+		if err := rows.Scan(
+			&o.AssetID, &o.AssetName, &o.AssetPath, &o.DataSource, &o.Host, &o.System, &o.Environment,
+			pq.Array(&o.PatternNames), &o.ObservationCount, &o.FirstObservedAt, &o.LastObservedAt, &latest,
+		); err != nil {
+			return nil, err
+		}
+		if latest.Valid {
+			o.LatestFindingID = &latest.UUID
+		}
+		occurrences = append(occurrences, o)
+	}
+
+	return occurrences, rows.Err()
+}
+
+// CountAssetsSharingValues counts the other assets of the tenant holding at least
+// one value observed in the given asset
+func (r *PostgresRepository) CountAssetsSharingValues(ctx context.Context, assetID uuid.UUID) (int, error) {
+	tenantID, err := EnsureTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = r.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT other.asset_id)
+		FROM (
+			SELECT DISTINCT value_hash FROM finding_observations
+			WHERE tenant_id = $1 AND asset_id = $2
+		) own
+		JOIN finding_observations other
+		  ON other.tenant_id = $1 AND other.value_hash = own.value_hash AND other.asset_id != $2
+		JOIN assets a ON a.id = other.asset_id AND a.deleted_at IS NULL`,
+		tenantID, assetID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count assets sharing values: %w", err)
+	}
+	return count, nil
+}
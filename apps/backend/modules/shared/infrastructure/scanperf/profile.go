@@ -0,0 +1,164 @@
+// Package scanperf times the stages of one scan run's ingestion. A Profile rides
+// in the ingestion's context, so the modules it calls into (asset lineage sync,
+// ingestion admission) can add their time without knowing about scan runs.
+package scanperf
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// Stage is a part of ingestion whose time is summed
+type Stage int
+
+const (
+	StageDB             Stage = iota // Postgres writes and reads
+	StageClassification              // Enrichment and classification of findings
+	StageNeo4jSync                   // Lineage syncs of the scan's assets, run asynchronously
+	StageQueueWait                   // Waiting for ingestion admission
+	numStages
+)
+
+type profileKey struct{}
+type queueWaitKey struct{}
+
+// Profile sums the time spent in each stage of an ingestion. Stages are summed
+// across concurrent workers, so together they may exceed the wall time. A nil
+// Profile ignores everything, so callers need not check for one.
+type Profile struct {
+	started time.Time
+	nanos   [numStages]atomic.Int64
+	syncs   atomic.Int64   // Lineage syncs started
+	running atomic.Int64   // Lineage syncs not yet finished
+	pending sync.WaitGroup // Waits for the running syncs
+}
+
+// Start begins profiling an ingestion, counting any admission wait recorded in
+// ctx, and returns the context carrying the profile
+func Start(ctx context.Context) (context.Context, *Profile) {
+	p := &Profile{started: time.Now()}
+	if wait, ok := ctx.Value(queueWaitKey{}).(time.Duration); ok {
+		p.Add(StageQueueWait, wait)
+	}
+	return context.WithValue(ctx, profileKey{}, p), p
+}
+
+// FromContext returns the profile of the ingestion ctx belongs to, or nil
+func FromContext(ctx context.Context) *Profile {
+	p, _ := ctx.Value(profileKey{}).(*Profile)
+	return p
+}
+
+// WithQueueWait records how long a request waited to be admitted, for the
+// profile its ingestion starts
+func WithQueueWait(ctx context.Context, wait time.Duration) context.Context {
+	return context.WithValue(ctx, queueWaitKey{}, wait)
+}
+
+// Add adds d to a stage
+func (p *Profile) Add(stage Stage, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.nanos[stage].Add(int64(d))
+}
+
+// Time starts timing a stage and returns the func that stops it:
+// defer p.Time(scanperf.StageDB)()
+func (p *Profile) Time(stage Stage) func() {
+	if p == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() { p.Add(stage, time.Since(start)) }
+}
+
+// TrackSync starts timing an asynchronous lineage sync and returns the func to
+// call when it finishes. Wait blocks until every tracked sync has finished.
+func (p *Profile) TrackSync() func() {
+	if p == nil {
+		return func() {}
+	}
+	p.syncs.Add(1)
+	p.running.Add(1)
+	p.pending.Add(1)
+	stop := p.Time(StageNeo4jSync)
+	return func() {
+		stop()
+		p.running.Add(-1)
+		p.pending.Done()
+	}
+}
+
+// Wait waits up to timeout for the tracked lineage syncs, reporting whether they all finished
+func (p *Profile) Wait(timeout time.Duration) bool {
+	if p == nil || p.running.Load() == 0 {
+		return true
+	}
+	done := make(chan struct{})
+	go func() {
+		p.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// Elapsed is the wall time since the ingestion started
+func (p *Profile) Elapsed() time.Duration {
+	if p == nil {
+		return 0
+	}
+	return time.Since(p.started)
+}
+
+// Total is the time summed for a stage so far
+func (p *Profile) Total(stage Stage) time.Duration {
+	if p == nil {
+		return 0
+	}
+	return time.Duration(p.nanos[stage].Load())
+}
+
+// Syncs is the number of lineage syncs tracked so far
+func (p *Profile) Syncs() int {
+	if p == nil {
+		return 0
+	}
+	return int(p.syncs.Load())
+}
+
+// Report summarises the profile for the scan run's metadata. syncsDone says
+// whether the tracked lineage syncs have all finished.
+func (p *Profile) Report(findings, assets int, syncsDone bool) *entity.ScanPerformance {
+	elapsed := p.Elapsed()
+	perf := &entity.ScanPerformance{
+		Findings:         findings,
+		Assets:           assets,
+		DurationMs:       elapsed.Milliseconds(),
+		DBMs:             p.Total(StageDB).Milliseconds(),
+		ClassificationMs: p.Total(StageClassification).Milliseconds(),
+		Neo4jSyncMs:      p.Total(StageNeo4jSync).Milliseconds(),
+		Neo4jSyncs:       p.Syncs(),
+		Neo4jSyncPending: !syncsDone,
+		QueueWaitMs:      p.Total(StageQueueWait).Milliseconds(),
+		RecordedAt:       time.Now().UTC(),
+	}
+	if elapsed > 0 {
+		perf.FindingsPerSecond = float64(findings) / elapsed.Seconds()
+	}
+	return perf
+}
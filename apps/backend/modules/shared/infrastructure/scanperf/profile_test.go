@@ -0,0 +1,65 @@
+package scanperf
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestStartCountsQueueWait(t *testing.T) {
+	ctx := WithQueueWait(context.Background(), 250*time.Millisecond)
+	ctx, p := Start(ctx)
+
+	if FromContext(ctx) != p {
+		t.Fatal("expected the profile in the returned context")
+	}
+	if got := p.Total(StageQueueWait); got != 250*time.Millisecond {
+		t.Errorf("queue wait = %v, want 250ms", got)
+	}
+}
+
+func TestTrackSyncWait(t *testing.T) {
+	_, p := Start(context.Background())
+	first, second := p.TrackSync(), p.TrackSync()
+	first()
+
+	if p.Wait(10 * time.Millisecond) {
+		t.Error("expected Wait to time out with a sync pending")
+	}
+	second()
+	if !p.Wait(time.Second) {
+		t.Error("expected Wait to report the syncs finished")
+	}
+	// A finished profile is reported finished even without time to wait
+	if !p.Wait(0) {
+		t.Error("expected Wait(0) to report the syncs finished")
+	}
+	if p.Syncs() != 2 {
+		t.Errorf("syncs = %d, want 2", p.Syncs())
+	}
+}
+
+func TestReport(t *testing.T) {
+	_, p := Start(context.Background())
+	p.Add(StageDB, 1500*time.Millisecond)
+	p.Add(StageClassification, 300*time.Millisecond)
+	p.started = time.Now().Add(-2 * time.Second)
+
+	perf := p.Report(100, 4, true)
+	if perf.Findings != 100 || perf.Assets != 4 || perf.DBMs != 1500 || perf.ClassificationMs != 300 || perf.Neo4jSyncPending {
+		t.Errorf("unexpected report: %+v", perf)
+	}
+	if perf.FindingsPerSecond < 45 || perf.FindingsPerSecond > 50 {
+		t.Errorf("findings per second = %v, want about 50", perf.FindingsPerSecond)
+	}
+}
+
+func TestNilProfile(t *testing.T) {
+	// Code outside an ingestion finds no profile and times nothing
+	p := FromContext(context.Background())
+	p.Time(StageDB)()
+	p.TrackSync()()
+	if !p.Wait(0) || p.Total(StageDB) != 0 || p.Syncs() != 0 {
+		t.Error("expected a nil profile to ignore everything")
+	}
+}
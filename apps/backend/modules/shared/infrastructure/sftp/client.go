@@ -0,0 +1,486 @@
+package sftp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTP version 3 packet types (draft-ietf-secsh-filexfer-02), the version
+// OpenSSH and most servers speak
+const (
+	fxpInit    = 1
+	fxpVersion = 2
+	fxpOpenDir = 11
+	fxpReadDir = 12
+	fxpClose   = 4
+	fxpStatus  = 101
+	fxpHandle  = 102
+	fxpName    = 104
+)
+
+// Status codes of SSH_FXP_STATUS replies
+const (
+	fxOK               = 0
+	fxEOF              = 1
+	fxNoSuchFile       = 2
+	fxPermissionDenied = 3
+)
+
+// Attribute flags of a file's attributes
+const (
+	attrSize        = 0x00000001
+	attrUIDGID      = 0x00000002
+	attrPermissions = 0x00000004
+	attrACModTime   = 0x00000008
+	attrExtended    = 0x80000000
+)
+
+// maxPacket bounds a reply the client accepts, well above the 256 KiB servers send at most
+const maxPacket = 1 << 20
+
+// ErrNotFound is returned for a path that does not exist
+var ErrNotFound = errors.New("sftp: no such file or directory")
+
+// ErrPermissionDenied is returned for a path the user may not read
+var ErrPermissionDenied = errors.New("sftp: permission denied")
+
+// ErrHostKeyNotPinned is returned by Dial for a config without a host key fingerprint
+var ErrHostKeyNotPinned = errors.New("sftp: host key fingerprint is required")
+
+// StatusError is an SSH_FXP_STATUS reply reporting a failure
+type StatusError struct {
+	Code    uint32
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sftp: status %d: %s", e.Code, e.Message)
+}
+
+// Config locates an SFTP server and the credentials to log in with. Either
+// Password or PrivateKey (PEM or OpenSSH format) authenticates.
+type Config struct {
+	Host       string
+	Port       int // 22 when 0
+	User       string
+	Password   string
+	PrivateKey string
+	Passphrase string // Decrypts PrivateKey when it is encrypted
+
+	// HostKeyFingerprint pins the server's key ("SHA256:..." as ssh-keygen -lf
+	// prints it). It is required: servers presenting any other key are refused.
+	HostKeyFingerprint string
+	Timeout            time.Duration // Connection and login; 10s when 0
+}
+
+// FileInfo is an entry of a directory listing
+type FileInfo struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Mode    uint32    `json:"mode"`
+	ModTime time.Time `json:"mod_time"`
+	IsDir   bool      `json:"is_dir"`
+}
+
+// Client is a minimal SFTP client over SSH, enough to list directories
+// without a third-party SFTP library
+type Client struct {
+	conn    *ssh.Client
+	session *ssh.Session
+
+	mu     sync.Mutex
+	r      io.Reader
+	w      io.WriteCloser
+	nextID uint32
+
+	serverVersion string
+	fingerprint   string
+}
+
+// Dial logs in to the server and starts its sftp subsystem
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	pinned := strings.TrimSpace(cfg.HostKeyFingerprint)
+	if pinned == "" {
+		return nil, ErrHostKeyNotPinned
+	}
+	auth, err := authMethods(cfg)
+	if err != nil {
+		return nil, err
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 22
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+
+	var fingerprint string
+	sshConfig := &ssh.ClientConfig{
+		User:            cfg.User,
+		Auth:            auth,
+		HostKeyCallback: pinnedHostKey(pinned, &fingerprint),
+		Timeout:         timeout,
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	// The deadline covers the handshake and login; it is lifted once logged in
+	netConn.SetDeadline(time.Now().Add(timeout))
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, addr, sshConfig)
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	conn := ssh.NewClient(sshConn, chans, reqs)
+
+	session, err := conn.NewSession()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	w, err := session.StdinPipe()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("sftp: subsystem unavailable: %w", err)
+	}
+
+	client, err := NewClient(r, w)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	netConn.SetDeadline(time.Time{})
+	client.conn = conn
+	client.session = session
+	client.serverVersion = string(sshConn.ServerVersion())
+	client.fingerprint = fingerprint
+	return client, nil
+}
+
+// pinnedHostKey accepts only a host key with the pinned fingerprint, recording
+// the fingerprint of the key the server presented
+func pinnedHostKey(pinned string, presented *string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		*presented = ssh.FingerprintSHA256(key)
+		if pinned == "" || *presented != pinned {
+			return fmt.Errorf("sftp: host key %s does not match the pinned fingerprint", *presented)
+		}
+		return nil
+	}
+}
+
+func authMethods(cfg Config) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if cfg.PrivateKey != "" {
+		var signer ssh.Signer
+		var err error
+		if cfg.Passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(cfg.PrivateKey), []byte(cfg.Passphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey([]byte(cfg.PrivateKey))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sftp: invalid private key: %w", err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if cfg.Password != "" {
+		password := cfg.Password
+		methods = append(methods, ssh.Password(password),
+			ssh.KeyboardInteractive(func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = password
+				}
+				return answers, nil
+			}))
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("sftp: a password or private key is required")
+	}
+	return methods, nil
+}
+
+// NewClient speaks SFTP over an already started sftp subsystem, negotiating
+// the protocol version
+func NewClient(r io.Reader, w io.WriteCloser) (*Client, error) {
+	c := &Client{r: r, w: w}
+	init := make([]byte, 0, 9)
+	init = append(init, fxpInit)
+	init = binary.BigEndian.AppendUint32(init, 3)
+	if err := c.writePacket(init); err != nil {
+		return nil, err
+	}
+	reply, err := c.readPacket()
+	if err != nil {
+		return nil, err
+	}
+	if reply[0] != fxpVersion {
+		return nil, fmt.Errorf("sftp: expected a version reply, got packet type %d", reply[0])
+	}
+	return c, nil
+}
+
+// ServerVersion is the SSH identification string of the server
+func (c *Client) ServerVersion() string {
+	return c.serverVersion
+}
+
+// Fingerprint is the SHA-256 fingerprint of the server's host key
+func (c *Client) Fingerprint() string {
+	return c.fingerprint
+}
+
+// ReadDir lists the entries of a directory, without "." and ".."
+func (c *Client) ReadDir(path string) ([]FileInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reply, err := c.request(fxpOpenDir, appendString(nil, path))
+	if err != nil {
+		return nil, err
+	}
+	if reply[0] != fxpHandle {
+		if err := statusOrUnexpected(reply, "handle"); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("sftp: directory opened without a handle")
+	}
+	handle, _, err := readString(reply[5:])
+	if err != nil {
+		return nil, err
+	}
+	defer c.request(fxpClose, appendString(nil, handle))
+
+	var entries []FileInfo
+	for {
+		reply, err := c.request(fxpReadDir, appendString(nil, handle))
+		if err != nil {
+			return nil, err
+		}
+		if reply[0] != fxpName {
+			// The listing ends with an EOF status
+			if err := statusOrUnexpected(reply, "name"); err != nil && !isEOF(err) {
+				return nil, err
+			}
+			return entries, nil
+		}
+		names, err := parseNames(reply[5:])
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range names {
+			if entry.Name != "." && entry.Name != ".." {
+				entries = append(entries, entry)
+			}
+		}
+	}
+}
+
+// Close ends the sftp subsystem and the SSH connection
+func (c *Client) Close() error {
+	c.w.Close()
+	if c.session != nil {
+		c.session.Close()
+	}
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// request sends a packet with a fresh request ID and returns its reply, whose
+// type is at [0] and ID at [1:5]
+func (c *Client) request(packetType byte, payload []byte) ([]byte, error) {
+	c.nextID++
+	id := c.nextID
+	packet := make([]byte, 0, 5+len(payload))
+	packet = append(packet, packetType)
+	packet = binary.BigEndian.AppendUint32(packet, id)
+	packet = append(packet, payload...)
+	if err := c.writePacket(packet); err != nil {
+		return nil, err
+	}
+
+	reply, err := c.readPacket()
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 5 {
+		return nil, errors.New("sftp: short reply")
+	}
+	if got := binary.BigEndian.Uint32(reply[1:5]); got != id {
+		return nil, fmt.Errorf("sftp: reply to request %d, expected %d", got, id)
+	}
+	return reply, nil
+}
+
+func (c *Client) writePacket(packet []byte) error {
+	framed := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(packet)), uint32(len(packet)))
+	_, err := c.w.Write(append(framed, packet...))
+	return err
+}
+
+func (c *Client) readPacket() ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(c.r, length[:]); err != nil {
+		return nil, fmt.Errorf("sftp: failed to read reply: %w", err)
+	}
+	n := binary.BigEndian.Uint32(length[:])
+	if n == 0 || n > maxPacket {
+		return nil, fmt.Errorf("sftp: invalid reply length %d", n)
+	}
+	packet := make([]byte, n)
+	if _, err := io.ReadFull(c.r, packet); err != nil {
+		return nil, fmt.Errorf("sftp: failed to read reply: %w", err)
+	}
+	return packet, nil
+}
+
+// statusOrUnexpected turns a status reply into its error, and any other reply
+// into an error naming the reply expected instead
+func statusOrUnexpected(reply []byte, expected string) error {
+	if reply[0] != fxpStatus {
+		return fmt.Errorf("sftp: expected a %s reply, got packet type %d", expected, reply[0])
+	}
+	body := reply[5:]
+	if len(body) < 4 {
+		return errors.New("sftp: short status reply")
+	}
+	code := binary.BigEndian.Uint32(body)
+	message, _, _ := readString(body[4:])
+	switch code {
+	case fxOK:
+		return nil
+	case fxNoSuchFile:
+		return ErrNotFound
+	case fxPermissionDenied:
+		return ErrPermissionDenied
+	}
+	return &StatusError{Code: code, Message: message}
+}
+
+func isEOF(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == fxEOF
+}
+
+// parseNames reads the entries of an SSH_FXP_NAME reply body
+func parseNames(body []byte) ([]FileInfo, error) {
+	if len(body) < 4 {
+		return nil, errors.New("sftp: short name reply")
+	}
+	count := binary.BigEndian.Uint32(body)
+	body = body[4:]
+
+	entries := make([]FileInfo, 0, min(count, 1024))
+	for i := uint32(0); i < count; i++ {
+		name, rest, err := readString(body)
+		if err != nil {
+			return nil, err
+		}
+		// The ls -l style long name is for display only
+		if _, rest, err = readString(rest); err != nil {
+			return nil, err
+		}
+		entry := FileInfo{Name: name}
+		if body, err = parseAttrs(rest, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// parseAttrs reads a file's attributes into entry and returns what follows them
+func parseAttrs(b []byte, entry *FileInfo) ([]byte, error) {
+	short := errors.New("sftp: short file attributes")
+	if len(b) < 4 {
+		return nil, short
+	}
+	flags := binary.BigEndian.Uint32(b)
+	b = b[4:]
+	if flags&attrSize != 0 {
+		if len(b) < 8 {
+			return nil, short
+		}
+		entry.Size = int64(binary.BigEndian.Uint64(b))
+		b = b[8:]
+	}
+	if flags&attrUIDGID != 0 {
+		if len(b) < 8 {
+			return nil, short
+		}
+		b = b[8:]
+	}
+	if flags&attrPermissions != 0 {
+		if len(b) < 4 {
+			return nil, short
+		}
+		entry.Mode = binary.BigEndian.Uint32(b)
+		entry.IsDir = entry.Mode&0o170000 == 0o040000
+		b = b[4:]
+	}
+	if flags&attrACModTime != 0 {
+		if len(b) < 8 {
+			return nil, short
+		}
+		entry.ModTime = time.Unix(int64(binary.BigEndian.Uint32(b[4:])), 0).UTC()
+		b = b[8:]
+	}
+	if flags&attrExtended != 0 {
+		if len(b) < 4 {
+			return nil, short
+		}
+		count := binary.BigEndian.Uint32(b)
+		b = b[4:]
+		for i := uint32(0); i < 2*count; i++ {
+			var err error
+			if _, b, err = readString(b); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 4 {
+		return "", nil, errors.New("sftp: short string")
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint32(len(b)-4) < n {
+		return "", nil, errors.New("sftp: short string")
+	}
+	return string(b[4 : 4+n]), b[4+n:], nil
+}
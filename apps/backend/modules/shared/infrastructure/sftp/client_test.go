@@ -0,0 +1,177 @@
+package sftp
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// fakeServer answers the SFTP requests ReadDir sends for a single directory
+type fakeServer struct {
+	r       io.Reader
+	w       io.Writer
+	dir     string
+	entries [][]byte // Encoded name entries, returned one per READDIR
+	closed  bool
+}
+
+func (s *fakeServer) serve(t *testing.T) {
+	for {
+		var length [4]byte
+		if _, err := io.ReadFull(s.r, length[:]); err != nil {
+			return
+		}
+		packet := make([]byte, binary.BigEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(s.r, packet); err != nil {
+			return
+		}
+
+		if packet[0] == fxpInit {
+			s.reply(append([]byte{fxpVersion}, 0, 0, 0, 3))
+			continue
+		}
+		id := packet[1:5]
+		switch packet[0] {
+		case fxpOpenDir:
+			path, _, _ := readString(packet[5:])
+			if path != s.dir {
+				s.status(id, fxNoSuchFile, "no such file")
+				continue
+			}
+			s.reply(appendString(append([]byte{fxpHandle}, id...), "h1"))
+		case fxpReadDir:
+			if len(s.entries) == 0 {
+				s.status(id, fxEOF, "eof")
+				continue
+			}
+			reply := binary.BigEndian.AppendUint32(append([]byte{fxpName}, id...), 1)
+			s.reply(append(reply, s.entries[0]...))
+			s.entries = s.entries[1:]
+		case fxpClose:
+			s.closed = true
+			s.status(id, fxOK, "")
+		default:
+			t.Errorf("unexpected packet type %d", packet[0])
+			return
+		}
+	}
+}
+
+func (s *fakeServer) reply(packet []byte) {
+	s.w.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(packet))), packet...))
+}
+
+func (s *fakeServer) status(id []byte, code uint32, message string) {
+	packet := binary.BigEndian.AppendUint32(append([]byte{fxpStatus}, id...), code)
+	packet = appendString(appendString(packet, message), "en")
+	s.reply(packet)
+}
+
+func nameEntry(name string, size uint64, mode, mtime uint32) []byte {
+	b := appendString(appendString(nil, name), "-rw-r--r-- 1 u g "+name)
+	b = binary.BigEndian.AppendUint32(b, attrSize|attrUIDGID|attrPermissions|attrACModTime|attrExtended)
+	b = binary.BigEndian.AppendUint64(b, size)
+	b = binary.BigEndian.AppendUint32(b, 1000)
+	b = binary.BigEndian.AppendUint32(b, 1000)
+	b = binary.BigEndian.AppendUint32(b, mode)
+	b = binary.BigEndian.AppendUint32(b, mtime)
+	b = binary.BigEndian.AppendUint32(b, mtime)
+	b = binary.BigEndian.AppendUint32(b, 1)
+	return appendString(appendString(b, "ext@example.com"), "value")
+}
+
+func newTestClient(t *testing.T, server *fakeServer) *Client {
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	server.r, server.w = serverR, serverW
+	go server.serve(t)
+	t.Cleanup(func() { serverW.Close() })
+
+	client, err := NewClient(clientR, clientW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestReadDir(t *testing.T) {
+	server := &fakeServer{dir: "/exports", entries: [][]byte{
+		nameEntry(".", 0, 0o040755, 0),
+		nameEntry("customers.csv", 2048, 0o100644, 1700000000),
+		nameEntry("archive", 0, 0o040750, 1700000100),
+	}}
+	client := newTestClient(t, server)
+
+	entries, err := client.ReadDir("/exports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries without \".\", got %+v", entries)
+	}
+	if entries[0].Name != "customers.csv" || entries[0].Size != 2048 || entries[0].IsDir || entries[0].ModTime.Unix() != 1700000000 {
+		t.Errorf("unexpected file entry %+v", entries[0])
+	}
+	if entries[1].Name != "archive" || !entries[1].IsDir {
+		t.Errorf("unexpected directory entry %+v", entries[1])
+	}
+	if !server.closed {
+		t.Error("expected the directory handle to be closed")
+	}
+}
+
+func TestReadDirNotFound(t *testing.T) {
+	client := newTestClient(t, &fakeServer{dir: "/exports"})
+
+	if _, err := client.ReadDir("/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAuthMethodsRequireCredentials(t *testing.T) {
+	if _, err := authMethods(Config{User: "scanner"}); err == nil {
+		t.Error("expected a config without a password or key to be rejected")
+	}
+	if _, err := authMethods(Config{User: "scanner", PrivateKey: "not a key"}); err == nil {
+		t.Error("expected an invalid private key to be rejected")
+	}
+}
+
+func TestDialRequiresPinnedHostKey(t *testing.T) {
+	_, err := Dial(context.Background(), Config{Host: "127.0.0.1", User: "scanner", Password: "pw"})
+	if !errors.Is(err, ErrHostKeyNotPinned) {
+		t.Errorf("expected ErrHostKeyNotPinned, got %v", err)
+	}
+}
+
+func TestPinnedHostKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	var presented string
+	if err := pinnedHostKey(fingerprint, &presented)("files", nil, key); err != nil {
+		t.Errorf("expected the pinned key to be accepted, got %v", err)
+	}
+	if presented != fingerprint {
+		t.Errorf("expected the presented fingerprint %s, got %s", fingerprint, presented)
+	}
+	if err := pinnedHostKey("SHA256:other", &presented)("files", nil, key); err == nil {
+		t.Error("expected a different key to be refused")
+	}
+	if err := pinnedHostKey("", &presented)("files", nil, key); err == nil {
+		t.Error("expected any key to be refused without a pin")
+	}
+}
@@ -0,0 +1,529 @@
+package smb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SMB2 commands (MS-SMB2 2.2.1)
+const (
+	cmdNegotiate      = 0x0000
+	cmdSessionSetup   = 0x0001
+	cmdLogoff         = 0x0002
+	cmdTreeConnect    = 0x0003
+	cmdTreeDisconnect = 0x0004
+	cmdCreate         = 0x0005
+	cmdClose          = 0x0006
+	cmdQueryDirectory = 0x000E
+)
+
+// SMB 2.0.2 and 2.1, the dialects signed with HMAC-SHA256
+const (
+	dialect202 = 0x0202
+	dialect210 = 0x0210
+)
+
+const (
+	headerSize = 64
+
+	flagResponse = 0x00000001
+	flagAsync    = 0x00000002
+	flagSigned   = 0x00000008
+
+	securitySigningEnabled = 0x0001
+
+	sessionFlagGuest = 0x0001
+	fileAttrDir      = 0x00000010
+
+	// maxMessage bounds a response the client accepts
+	maxMessage = 1 << 20
+	// listBufferSize is the directory listing returned per QUERY_DIRECTORY, one credit's worth
+	listBufferSize = 65536
+)
+
+// NT status codes the client handles or reports by name
+const (
+	statusSuccess                = 0x00000000
+	statusPending                = 0x00000103
+	statusNoMoreFiles            = 0x80000006
+	statusMoreProcessingRequired = 0xC0000016
+	statusAccessDenied           = 0xC0000022
+	statusObjectNameNotFound     = 0xC0000034
+	statusObjectPathNotFound     = 0xC000003A
+	statusLogonFailure           = 0xC000006D
+	statusAccountRestriction     = 0xC000006E
+	statusPasswordExpired        = 0xC0000071
+	statusAccountDisabled        = 0xC0000072
+	statusNotSupported           = 0xC00000BB
+	statusBadNetworkName         = 0xC00000CC
+	statusNotADirectory          = 0xC0000103
+	statusAccountLockedOut       = 0xC0000234
+)
+
+var statusNames = map[uint32]string{
+	statusAccessDenied:       "access denied",
+	statusObjectNameNotFound: "no such file or directory",
+	statusObjectPathNotFound: "no such file or directory",
+	statusLogonFailure:       "logon failure: unknown user name or bad password",
+	statusAccountRestriction: "account restriction",
+	statusPasswordExpired:    "password expired",
+	statusAccountDisabled:    "account disabled",
+	statusNotSupported:       "not supported",
+	statusBadNetworkName:     "share not found",
+	statusNotADirectory:      "not a directory",
+	statusAccountLockedOut:   "account locked out",
+}
+
+// StatusError is a response failing with an NT status
+type StatusError struct {
+	Command uint16
+	Status  uint32
+}
+
+func (e *StatusError) Error() string {
+	if name, ok := statusNames[e.Status]; ok {
+		return "smb: " + name
+	}
+	return fmt.Sprintf("smb: command %d failed with status 0x%08X", e.Command, e.Status)
+}
+
+// IsLogonFailure reports whether err is a rejected login
+func IsLogonFailure(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.Status {
+	case statusLogonFailure, statusAccountRestriction, statusPasswordExpired, statusAccountDisabled, statusAccountLockedOut:
+		return true
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a missing share, file or directory
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.Status {
+	case statusBadNetworkName, statusObjectNameNotFound, statusObjectPathNotFound:
+		return true
+	}
+	return false
+}
+
+// ErrDialectUnsupported is returned by servers that only accept SMB 3
+var ErrDialectUnsupported = errors.New("smb: server requires SMB 3, only SMB 2.0.2 and 2.1 are supported")
+
+// Config locates an SMB share and the NTLM credentials to log in with
+type Config struct {
+	Host     string
+	Port     int // 445 when 0
+	Share    string
+	Domain   string
+	User     string
+	Password string
+	Timeout  time.Duration // Every request; 10s when 0
+}
+
+// FileInfo is an entry of a directory listing
+type FileInfo struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	IsDir   bool      `json:"is_dir"`
+}
+
+// Client is a minimal SMB2 client, enough to log in with NTLMv2 and list the
+// directories of one share without a third-party SMB library
+type Client struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	timeout time.Duration
+
+	dialect    uint16
+	messageID  uint64
+	sessionID  uint64
+	treeID     uint32
+	signingKey []byte // Nil for guest sessions, which are not signed
+}
+
+// Dial connects to the server, logs in and connects to the share
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.Share == "" {
+		return nil, errors.New("smb: a share is required")
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 445
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(cfg.Host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn, timeout: timeout}
+
+	if err := c.negotiate(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := c.sessionSetup(cfg.Domain, cfg.User, cfg.Password); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := c.treeConnect(fmt.Sprintf(`\\%s\%s`, cfg.Host, strings.Trim(cfg.Share, `\/`))); err != nil {
+		c.send(cmdLogoff, []byte{4, 0, 0, 0})
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Dialect is the negotiated SMB dialect, e.g. "2.1"
+func (c *Client) Dialect() string {
+	if c.dialect == dialect210 {
+		return "2.1"
+	}
+	return "2.0.2"
+}
+
+// ReadDir lists the entries of a directory of the share, given relative to its
+// root with either separator, without "." and ".."
+func (c *Client) ReadDir(dir string) ([]FileInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fileID, err := c.openDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer c.closeFile(fileID)
+
+	var entries []FileInfo
+	flags := byte(0x01) // SMB2_RESTART_SCANS on the first request
+	for {
+		req := make([]byte, 0, 40)
+		req = binary.LittleEndian.AppendUint16(req, 33)
+		req = append(req, 0x01, flags) // FileDirectoryInformation
+		req = binary.LittleEndian.AppendUint32(req, 0)
+		req = append(req, fileID[:]...)
+		pattern := utf16le("*")
+		req = binary.LittleEndian.AppendUint16(req, headerSize+32)
+		req = binary.LittleEndian.AppendUint16(req, uint16(len(pattern)))
+		req = binary.LittleEndian.AppendUint32(req, listBufferSize)
+		req = append(req, pattern...)
+		flags = 0
+
+		resp, err := c.send(cmdQueryDirectory, req)
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.Status == statusNoMoreFiles {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		body := resp[headerSize:]
+		if len(body) < 8 {
+			return nil, errors.New("smb: short directory listing")
+		}
+		offset := int(binary.LittleEndian.Uint16(body[2:]))
+		length := int(binary.LittleEndian.Uint32(body[4:]))
+		if offset < headerSize || offset+length > len(resp) {
+			return nil, errors.New("smb: malformed directory listing")
+		}
+		for _, entry := range parseDirectoryInfo(resp[offset : offset+length]) {
+			if entry.Name != "." && entry.Name != ".." {
+				entries = append(entries, entry)
+			}
+		}
+	}
+}
+
+// Close disconnects from the share, logs off and closes the connection
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.send(cmdTreeDisconnect, []byte{4, 0, 0, 0})
+	c.send(cmdLogoff, []byte{4, 0, 0, 0})
+	return c.conn.Close()
+}
+
+func (c *Client) negotiate() error {
+	var clientGUID [16]byte
+	if _, err := rand.Read(clientGUID[:]); err != nil {
+		return err
+	}
+	req := make([]byte, 0, 40)
+	req = binary.LittleEndian.AppendUint16(req, 36)
+	req = binary.LittleEndian.AppendUint16(req, 2) // Dialect count
+	req = binary.LittleEndian.AppendUint16(req, securitySigningEnabled)
+	req = binary.LittleEndian.AppendUint16(req, 0)
+	req = binary.LittleEndian.AppendUint32(req, 0) // Capabilities
+	req = append(req, clientGUID[:]...)
+	req = binary.LittleEndian.AppendUint64(req, 0)
+	req = binary.LittleEndian.AppendUint16(req, dialect202)
+	req = binary.LittleEndian.AppendUint16(req, dialect210)
+
+	resp, err := c.send(cmdNegotiate, req)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Status == statusNotSupported {
+		return ErrDialectUnsupported
+	}
+	if err != nil {
+		return err
+	}
+	body := resp[headerSize:]
+	if len(body) < 64 {
+		return errors.New("smb: short negotiate response")
+	}
+	c.dialect = binary.LittleEndian.Uint16(body[4:])
+	if c.dialect != dialect202 && c.dialect != dialect210 {
+		return ErrDialectUnsupported
+	}
+	return nil
+}
+
+func (c *Client) sessionSetup(domain, user, password string) error {
+	resp, err := c.send(cmdSessionSetup, sessionSetupRequest(spnegoInit(ntlmNegotiate())))
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != statusMoreProcessingRequired {
+		if err == nil {
+			return errors.New("smb: server accepted the session without authentication")
+		}
+		return err
+	}
+	c.sessionID = binary.LittleEndian.Uint64(resp[40:])
+
+	buf, err := securityBuffer(resp)
+	if err != nil {
+		return err
+	}
+	challenge, err := parseNTLMChallenge(buf)
+	if err != nil {
+		return err
+	}
+	authenticate, sessionKey, err := ntlmAuthenticate(challenge, domain, user, password)
+	if err != nil {
+		return err
+	}
+
+	resp, err = c.send(cmdSessionSetup, sessionSetupRequest(spnegoResponse(authenticate)))
+	if err != nil {
+		return err
+	}
+	if len(resp) < headerSize+4 {
+		return errors.New("smb: short session setup response")
+	}
+	if binary.LittleEndian.Uint16(resp[headerSize+2:])&sessionFlagGuest == 0 {
+		c.signingKey = sessionKey
+	}
+	return nil
+}
+
+func sessionSetupRequest(token []byte) []byte {
+	req := make([]byte, 0, 24+len(token))
+	req = binary.LittleEndian.AppendUint16(req, 25)
+	req = append(req, 0, securitySigningEnabled)
+	req = binary.LittleEndian.AppendUint32(req, 0) // Capabilities
+	req = binary.LittleEndian.AppendUint32(req, 0) // Channel
+	req = binary.LittleEndian.AppendUint16(req, headerSize+24)
+	req = binary.LittleEndian.AppendUint16(req, uint16(len(token)))
+	req = binary.LittleEndian.AppendUint64(req, 0) // Previous session
+	return append(req, token...)
+}
+
+// securityBuffer returns the security token of a session setup response
+func securityBuffer(resp []byte) ([]byte, error) {
+	body := resp[headerSize:]
+	if len(body) < 8 {
+		return nil, errors.New("smb: short session setup response")
+	}
+	offset := int(binary.LittleEndian.Uint16(body[4:]))
+	length := int(binary.LittleEndian.Uint16(body[6:]))
+	if offset < headerSize || offset+length > len(resp) {
+		return nil, errors.New("smb: malformed session setup response")
+	}
+	return resp[offset : offset+length], nil
+}
+
+func (c *Client) treeConnect(path string) error {
+	name := utf16le(path)
+	req := make([]byte, 0, 8+len(name))
+	req = binary.LittleEndian.AppendUint16(req, 9)
+	req = binary.LittleEndian.AppendUint16(req, 0)
+	req = binary.LittleEndian.AppendUint16(req, headerSize+8)
+	req = binary.LittleEndian.AppendUint16(req, uint16(len(name)))
+	req = append(req, name...)
+
+	resp, err := c.send(cmdTreeConnect, req)
+	if err != nil {
+		return err
+	}
+	c.treeID = binary.LittleEndian.Uint32(resp[36:])
+	return nil
+}
+
+// openDirectory opens a directory of the share for listing
+func (c *Client) openDirectory(dir string) ([16]byte, error) {
+	var fileID [16]byte
+	name := utf16le(strings.Trim(strings.ReplaceAll(dir, "/", `\`), `\`))
+
+	req := make([]byte, 0, 56+max(len(name), 1))
+	req = binary.LittleEndian.AppendUint16(req, 57)
+	req = append(req, 0, 0)                        // Security flags, oplock level
+	req = binary.LittleEndian.AppendUint32(req, 2) // Impersonation
+	req = binary.LittleEndian.AppendUint64(req, 0)
+	req = binary.LittleEndian.AppendUint64(req, 0)
+	req = binary.LittleEndian.AppendUint32(req, 0x00000001|0x00000080|0x00100000) // List directory, read attributes, synchronize
+	req = binary.LittleEndian.AppendUint32(req, 0)
+	req = binary.LittleEndian.AppendUint32(req, 0x7)        // Share read, write and delete
+	req = binary.LittleEndian.AppendUint32(req, 1)          // FILE_OPEN
+	req = binary.LittleEndian.AppendUint32(req, 0x00000001) // FILE_DIRECTORY_FILE
+	req = binary.LittleEndian.AppendUint16(req, headerSize+56)
+	req = binary.LittleEndian.AppendUint16(req, uint16(len(name)))
+	req = binary.LittleEndian.AppendUint32(req, 0)
+	req = binary.LittleEndian.AppendUint32(req, 0)
+	if len(name) == 0 {
+		// The buffer may not be empty even for the share's root
+		req = append(req, 0)
+	}
+	req = append(req, name...)
+
+	resp, err := c.send(cmdCreate, req)
+	if err != nil {
+		return fileID, err
+	}
+	if len(resp) < headerSize+80 {
+		return fileID, errors.New("smb: short create response")
+	}
+	copy(fileID[:], resp[headerSize+64:headerSize+80])
+	return fileID, nil
+}
+
+func (c *Client) closeFile(fileID [16]byte) {
+	req := make([]byte, 0, 24)
+	req = binary.LittleEndian.AppendUint16(req, 24)
+	req = binary.LittleEndian.AppendUint16(req, 0)
+	req = binary.LittleEndian.AppendUint32(req, 0)
+	c.send(cmdClose, append(req, fileID[:]...))
+}
+
+// send writes a request and reads its response, failing with a StatusError
+// when the response reports one. The response is returned even then, header
+// included.
+func (c *Client) send(command uint16, body []byte) ([]byte, error) {
+	msg := make([]byte, headerSize, headerSize+len(body))
+	copy(msg, []byte{0xFE, 'S', 'M', 'B'})
+	binary.LittleEndian.PutUint16(msg[4:], headerSize)
+	if c.dialect == dialect210 {
+		binary.LittleEndian.PutUint16(msg[6:], 1) // Credit charge
+	}
+	binary.LittleEndian.PutUint16(msg[12:], command)
+	binary.LittleEndian.PutUint16(msg[14:], 31) // Credits requested
+	binary.LittleEndian.PutUint64(msg[24:], c.messageID)
+	binary.LittleEndian.PutUint32(msg[36:], c.treeID)
+	binary.LittleEndian.PutUint64(msg[40:], c.sessionID)
+	msg = append(msg, body...)
+	c.messageID++
+
+	if c.signingKey != nil && command != cmdNegotiate && command != cmdSessionSetup {
+		binary.LittleEndian.PutUint32(msg[16:], flagSigned)
+		sign(msg, c.signingKey)
+	}
+
+	c.conn.SetDeadline(time.Now().Add(c.timeout))
+	frame := binary.BigEndian.AppendUint32(make([]byte, 0, 4+len(msg)), uint32(len(msg)))
+	if _, err := c.conn.Write(append(frame, msg...)); err != nil {
+		return nil, err
+	}
+
+	for {
+		resp, err := c.readMessage()
+		if err != nil {
+			return nil, err
+		}
+		status := binary.LittleEndian.Uint32(resp[8:])
+		// An interim response announces a reply coming later
+		if status == statusPending && binary.LittleEndian.Uint32(resp[16:])&flagAsync != 0 {
+			continue
+		}
+		if status != statusSuccess {
+			return resp, &StatusError{Command: command, Status: status}
+		}
+		return resp, nil
+	}
+}
+
+func (c *Client) readMessage() ([]byte, error) {
+	var frame [4]byte
+	if _, err := io.ReadFull(c.conn, frame[:]); err != nil {
+		return nil, fmt.Errorf("smb: failed to read response: %w", err)
+	}
+	n := binary.BigEndian.Uint32(frame[:]) & 0x00FFFFFF
+	if n < headerSize || n > maxMessage {
+		return nil, fmt.Errorf("smb: invalid response length %d", n)
+	}
+	resp := make([]byte, n)
+	if _, err := io.ReadFull(c.conn, resp); err != nil {
+		return nil, fmt.Errorf("smb: failed to read response: %w", err)
+	}
+	if string(resp[:4]) != "\xFESMB" {
+		return nil, errors.New("smb: not an SMB2 response")
+	}
+	if binary.LittleEndian.Uint32(resp[16:])&flagResponse == 0 {
+		return nil, errors.New("smb: expected a response")
+	}
+	return resp, nil
+}
+
+// sign sets the SMB 2.x signature of msg: the first 16 bytes of its
+// HMAC-SHA256 under the session key, computed with the signature zeroed
+func sign(msg, key []byte) {
+	for i := 48; i < 64; i++ {
+		msg[i] = 0
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	copy(msg[48:64], mac.Sum(nil))
+}
+
+// parseDirectoryInfo reads FILE_DIRECTORY_INFORMATION entries
+func parseDirectoryInfo(buf []byte) []FileInfo {
+	var entries []FileInfo
+	for len(buf) >= 64 {
+		next := binary.LittleEndian.Uint32(buf)
+		nameLength := int(binary.LittleEndian.Uint32(buf[60:]))
+		if 64+nameLength > len(buf) {
+			break
+		}
+		entries = append(entries, FileInfo{
+			Name:    fromUTF16le(buf[64 : 64+nameLength]),
+			Size:    int64(binary.LittleEndian.Uint64(buf[40:])),
+			ModTime: fromFileTime(binary.LittleEndian.Uint64(buf[24:])),
+			IsDir:   binary.LittleEndian.Uint32(buf[56:])&fileAttrDir != 0,
+		})
+		if next == 0 || int(next) > len(buf) {
+			break
+		}
+		buf = buf[next:]
+	}
+	return entries
+}
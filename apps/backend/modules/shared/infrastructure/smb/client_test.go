@@ -0,0 +1,259 @@
+package smb
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// MS-NLMP 4.2.4 NTLMv2 test vector
+func TestNTOWFv2(t *testing.T) {
+	if got := hex.EncodeToString(ntowfV2("Domain", "User", "Password")); got != "0c868a403bfd7a93a3001ef22ef02e3f" {
+		t.Errorf("NTOWFv2 = %s", got)
+	}
+}
+
+func TestNTLMv2ResponseBlob(t *testing.T) {
+	targetInfo := []byte{2, 0, 2, 0, 'D', 0, 0, 0, 0, 0}
+	var clientChallenge [8]byte
+	copy(clientChallenge[:], "clientch")
+
+	ntResponse, sessionKey := ntlmV2Response("Domain", "User", "Password", [8]byte{}, clientChallenge, 42, targetInfo)
+	blob := ntResponse[16:]
+	if blob[0] != 1 || blob[1] != 1 || binary.LittleEndian.Uint64(blob[8:]) != 42 || string(blob[16:24]) != "clientch" {
+		t.Errorf("unexpected NTLMv2 client blob %x", blob)
+	}
+	if !bytes.Equal(blob[28:28+len(targetInfo)], targetInfo) || len(blob) != 28+len(targetInfo)+4 {
+		t.Errorf("expected the target info in the blob, got %x", blob)
+	}
+	if len(sessionKey) != 16 {
+		t.Errorf("expected a 16 byte session key, got %d", len(sessionKey))
+	}
+}
+
+// fakeServer answers one SMB 2.1 session, checking the NTLMv2 response and
+// the signature of requests against the user's password
+type fakeServer struct {
+	t        *testing.T
+	password string
+	share    string
+	files    []byte // FILE_DIRECTORY_INFORMATION entries of the share's root
+
+	challenge  [8]byte
+	sessionKey []byte
+	listed     bool
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		var frame [4]byte
+		if _, err := io.ReadFull(conn, frame[:]); err != nil {
+			return
+		}
+		req := make([]byte, binary.BigEndian.Uint32(frame[:]))
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+
+		command := binary.LittleEndian.Uint16(req[12:])
+		if binary.LittleEndian.Uint32(req[16:])&flagSigned != 0 {
+			signature := append([]byte{}, req[48:64]...)
+			sign(req, s.sessionKey)
+			if !bytes.Equal(signature, req[48:64]) {
+				s.t.Errorf("command %d has an invalid signature", command)
+			}
+		} else if s.sessionKey != nil && command != cmdSessionSetup {
+			s.t.Errorf("command %d is not signed", command)
+		}
+
+		status, body := s.handle(command, req)
+		resp := make([]byte, headerSize)
+		copy(resp, req[:headerSize])
+		binary.LittleEndian.PutUint32(resp[8:], status)
+		binary.LittleEndian.PutUint32(resp[16:], flagResponse)
+		binary.LittleEndian.PutUint64(resp[40:], 0x11)
+		binary.LittleEndian.PutUint32(resp[36:], 7)
+		resp = append(resp, body...)
+		conn.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(resp))), resp...))
+	}
+}
+
+func (s *fakeServer) handle(command uint16, req []byte) (uint32, []byte) {
+	body := req[headerSize:]
+	switch command {
+	case cmdNegotiate:
+		resp := make([]byte, 64)
+		binary.LittleEndian.PutUint16(resp, 65)
+		binary.LittleEndian.PutUint16(resp[4:], dialect210)
+		return statusSuccess, resp
+	case cmdSessionSetup:
+		offset := binary.LittleEndian.Uint16(body[12:])
+		length := binary.LittleEndian.Uint16(body[14:])
+		buf := req[offset : offset+length]
+		msg := buf[bytes.Index(buf, ntlmSignature):]
+		if binary.LittleEndian.Uint32(msg[8:]) == 1 {
+			return statusMoreProcessingRequired, sessionSetupResponse(s.challengeMessage())
+		}
+		return s.authenticate(msg), sessionSetupResponse(nil)
+	case cmdTreeConnect:
+		offset := binary.LittleEndian.Uint16(body[4:])
+		length := binary.LittleEndian.Uint16(body[6:])
+		if path := fromUTF16le(req[offset : offset+length]); path != s.share {
+			return statusBadNetworkName, make([]byte, 9)
+		}
+		return statusSuccess, make([]byte, 16)
+	case cmdCreate:
+		if binary.LittleEndian.Uint16(body[46:]) != 0 {
+			return statusObjectNameNotFound, make([]byte, 9)
+		}
+		resp := make([]byte, 88)
+		copy(resp[64:], "fileid-of-root!!")
+		return statusSuccess, resp
+	case cmdQueryDirectory:
+		if s.listed {
+			return statusNoMoreFiles, make([]byte, 9)
+		}
+		s.listed = true
+		resp := make([]byte, 8)
+		binary.LittleEndian.PutUint16(resp[2:], headerSize+8)
+		binary.LittleEndian.PutUint32(resp[4:], uint32(len(s.files)))
+		return statusSuccess, append(resp, s.files...)
+	default:
+		return statusSuccess, make([]byte, 4)
+	}
+}
+
+func (s *fakeServer) challengeMessage() []byte {
+	copy(s.challenge[:], "chalenge")
+	targetInfo := []byte{0, 0, 0, 0}
+	msg := append([]byte{}, ntlmSignature...)
+	msg = binary.LittleEndian.AppendUint32(msg, 2)
+	msg = append(msg, make([]byte, 8)...)
+	msg = binary.LittleEndian.AppendUint32(msg, ntlmClientFlags)
+	msg = append(msg, s.challenge[:]...)
+	msg = append(msg, make([]byte, 8)...)
+	msg = binary.LittleEndian.AppendUint16(msg, uint16(len(targetInfo)))
+	msg = binary.LittleEndian.AppendUint16(msg, uint16(len(targetInfo)))
+	msg = binary.LittleEndian.AppendUint32(msg, 48)
+	return append(msg, targetInfo...)
+}
+
+// authenticate checks the NTLMv2 proof of an AUTHENTICATE_MESSAGE
+func (s *fakeServer) authenticate(msg []byte) uint32 {
+	field := func(at int) []byte {
+		length := binary.LittleEndian.Uint16(msg[at:])
+		offset := binary.LittleEndian.Uint32(msg[at+4:])
+		return msg[offset : offset+uint32(length)]
+	}
+	ntResponse := field(20)
+	domain, user := fromUTF16le(field(28)), fromUTF16le(field(36))
+
+	key := ntowfV2(domain, user, s.password)
+	mac := hmac.New(md5.New, key)
+	mac.Write(s.challenge[:])
+	mac.Write(ntResponse[16:])
+	proof := mac.Sum(nil)
+	if !bytes.Equal(proof, ntResponse[:16]) {
+		return statusLogonFailure
+	}
+	mac = hmac.New(md5.New, key)
+	mac.Write(proof)
+	s.sessionKey = mac.Sum(nil)
+	return statusSuccess
+}
+
+func sessionSetupResponse(token []byte) []byte {
+	resp := make([]byte, 8)
+	binary.LittleEndian.PutUint16(resp, 9)
+	binary.LittleEndian.PutUint16(resp[4:], headerSize+8)
+	binary.LittleEndian.PutUint16(resp[6:], uint16(len(token)))
+	return append(resp, token...)
+}
+
+func directoryEntry(name string, size uint64, attributes uint32, last bool) []byte {
+	encoded := utf16le(name)
+	entry := make([]byte, 64, 64+len(encoded)+8)
+	binary.LittleEndian.PutUint64(entry[24:], fileTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+	binary.LittleEndian.PutUint64(entry[40:], size)
+	binary.LittleEndian.PutUint32(entry[56:], attributes)
+	binary.LittleEndian.PutUint32(entry[60:], uint32(len(encoded)))
+	entry = append(entry, encoded...)
+	for len(entry)%8 != 0 {
+		entry = append(entry, 0)
+	}
+	if !last {
+		binary.LittleEndian.PutUint32(entry, uint32(len(entry)))
+	}
+	return entry
+}
+
+func startServer(t *testing.T, server *fakeServer) (string, int) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			server.serve(conn)
+		}
+	}()
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	n, _ := strconv.Atoi(port)
+	return host, n
+}
+
+func TestDialAndReadDir(t *testing.T) {
+	var files []byte
+	files = append(files, directoryEntry(".", 0, fileAttrDir, false)...)
+	files = append(files, directoryEntry("payroll.xlsx", 4096, 0x20, false)...)
+	files = append(files, directoryEntry("Archive", 0, fileAttrDir, true)...)
+	server := &fakeServer{t: t, password: "s3cret", share: `\\127.0.0.1\exports`, files: files}
+	host, port := startServer(t, server)
+
+	client, err := Dial(context.Background(), Config{Host: host, Port: port, Share: "exports", Domain: "CORP", User: "scanner", Password: "s3cret", Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if client.Dialect() != "2.1" {
+		t.Errorf("expected dialect 2.1, got %s", client.Dialect())
+	}
+
+	entries, err := client.ReadDir("/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries without \".\", got %+v", entries)
+	}
+	if entries[0].Name != "payroll.xlsx" || entries[0].Size != 4096 || entries[0].IsDir || entries[0].ModTime.Year() != 2026 {
+		t.Errorf("unexpected file entry %+v", entries[0])
+	}
+	if entries[1].Name != "Archive" || !entries[1].IsDir {
+		t.Errorf("unexpected directory entry %+v", entries[1])
+	}
+
+	if _, err := client.ReadDir("missing"); !IsNotFound(err) {
+		t.Errorf("expected a missing directory to be not found, got %v", err)
+	}
+}
+
+func TestDialWrongPassword(t *testing.T) {
+	host, port := startServer(t, &fakeServer{t: t, password: "s3cret", share: `\\127.0.0.1\exports`})
+
+	_, err := Dial(context.Background(), Config{Host: host, Port: port, Share: "exports", User: "scanner", Password: "wrong", Timeout: 5 * time.Second})
+	if !IsLogonFailure(err) {
+		t.Errorf("expected a logon failure, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package smb
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/md4"
+)
+
+// NTLM negotiate flags (MS-NLMP 2.2.2.5)
+const (
+	ntlmNegotiateUnicode          = 0x00000001
+	ntlmRequestTarget             = 0x00000004
+	ntlmNegotiateSign             = 0x00000010
+	ntlmNegotiateNTLM             = 0x00000200
+	ntlmNegotiateAlwaysSign       = 0x00008000
+	ntlmNegotiateExtendedSecurity = 0x00080000
+	ntlmNegotiateTargetInfo       = 0x00800000
+	ntlmNegotiate128              = 0x20000000
+	ntlmNegotiate56               = 0x80000000
+
+	ntlmClientFlags = ntlmNegotiateUnicode | ntlmRequestTarget | ntlmNegotiateSign | ntlmNegotiateNTLM |
+		ntlmNegotiateAlwaysSign | ntlmNegotiateExtendedSecurity | ntlmNegotiateTargetInfo |
+		ntlmNegotiate128 | ntlmNegotiate56
+)
+
+// avTimestamp is the AV pair carrying the server's time in the challenge
+const avTimestamp = 7
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// ntlmChallenge is the part of a CHALLENGE_MESSAGE the response is computed from
+type ntlmChallenge struct {
+	flags      uint32
+	challenge  [8]byte
+	targetInfo []byte
+}
+
+// ntlmNegotiate builds the NEGOTIATE_MESSAGE opening an NTLM login
+func ntlmNegotiate() []byte {
+	msg := make([]byte, 0, 32)
+	msg = append(msg, ntlmSignature...)
+	msg = binary.LittleEndian.AppendUint32(msg, 1)
+	msg = binary.LittleEndian.AppendUint32(msg, ntlmClientFlags)
+	// Empty domain and workstation fields
+	return append(msg, make([]byte, 16)...)
+}
+
+// parseNTLMChallenge reads the CHALLENGE_MESSAGE in a security buffer, which
+// may still be wrapped in SPNEGO
+func parseNTLMChallenge(buf []byte) (*ntlmChallenge, error) {
+	start := bytes.Index(buf, ntlmSignature)
+	if start < 0 {
+		return nil, errors.New("smb: no NTLM challenge in the session setup response")
+	}
+	msg := buf[start:]
+	if len(msg) < 48 || binary.LittleEndian.Uint32(msg[8:]) != 2 {
+		return nil, errors.New("smb: malformed NTLM challenge")
+	}
+
+	c := &ntlmChallenge{flags: binary.LittleEndian.Uint32(msg[20:])}
+	copy(c.challenge[:], msg[24:32])
+	length := int(binary.LittleEndian.Uint16(msg[40:]))
+	offset := int(binary.LittleEndian.Uint32(msg[44:]))
+	if offset+length > len(msg) {
+		return nil, errors.New("smb: malformed NTLM target info")
+	}
+	c.targetInfo = msg[offset : offset+length]
+	return c, nil
+}
+
+// ntlmAuthenticate builds the AUTHENTICATE_MESSAGE answering challenge with an
+// NTLMv2 response, and returns the session key it establishes
+func ntlmAuthenticate(c *ntlmChallenge, domain, user, password string) ([]byte, []byte, error) {
+	var clientChallenge [8]byte
+	if _, err := rand.Read(clientChallenge[:]); err != nil {
+		return nil, nil, err
+	}
+	timestamp, serverTime := avPairTimestamp(c.targetInfo)
+	if !serverTime {
+		timestamp = fileTime(time.Now())
+	}
+
+	ntResponse, sessionKey := ntlmV2Response(domain, user, password, c.challenge, clientChallenge, timestamp, c.targetInfo)
+	// With the server's time in the target info the LMv2 response is left empty
+	lmResponse := make([]byte, 24)
+	if !serverTime {
+		mac := hmac.New(md5.New, ntowfV2(domain, user, password))
+		mac.Write(c.challenge[:])
+		mac.Write(clientChallenge[:])
+		lmResponse = append(mac.Sum(nil), clientChallenge[:]...)
+	}
+
+	fields := [][]byte{lmResponse, ntResponse, utf16le(domain), utf16le(user), utf16le(""), nil}
+	const headerSize = 64
+	msg := make([]byte, 0, headerSize+len(ntResponse)+128)
+	msg = append(msg, ntlmSignature...)
+	msg = binary.LittleEndian.AppendUint32(msg, 3)
+	offset := headerSize
+	for _, field := range fields {
+		msg = binary.LittleEndian.AppendUint16(msg, uint16(len(field)))
+		msg = binary.LittleEndian.AppendUint16(msg, uint16(len(field)))
+		msg = binary.LittleEndian.AppendUint32(msg, uint32(offset))
+		offset += len(field)
+	}
+	msg = binary.LittleEndian.AppendUint32(msg, c.flags&ntlmClientFlags)
+	for _, field := range fields {
+		msg = append(msg, field...)
+	}
+	return msg, sessionKey, nil
+}
+
+// ntowfV2 is the NTLMv2 key derived from the password, user and domain
+func ntowfV2(domain, user, password string) []byte {
+	h := md4.New()
+	h.Write(utf16le(password))
+	mac := hmac.New(md5.New, h.Sum(nil))
+	mac.Write(utf16le(strings.ToUpper(user) + domain))
+	return mac.Sum(nil)
+}
+
+// ntlmV2Response computes the NT challenge response, which starts with the
+// NTProofStr, and the session base key (MS-NLMP 3.3.2)
+func ntlmV2Response(domain, user, password string, serverChallenge, clientChallenge [8]byte, timestamp uint64, targetInfo []byte) ([]byte, []byte) {
+	key := ntowfV2(domain, user, password)
+
+	temp := []byte{1, 1, 0, 0, 0, 0, 0, 0}
+	temp = binary.LittleEndian.AppendUint64(temp, timestamp)
+	temp = append(temp, clientChallenge[:]...)
+	temp = append(temp, 0, 0, 0, 0)
+	temp = append(temp, targetInfo...)
+	temp = append(temp, 0, 0, 0, 0)
+
+	mac := hmac.New(md5.New, key)
+	mac.Write(serverChallenge[:])
+	mac.Write(temp)
+	ntProof := mac.Sum(nil)
+
+	mac = hmac.New(md5.New, key)
+	mac.Write(ntProof)
+	return append(append([]byte{}, ntProof...), temp...), mac.Sum(nil)
+}
+
+// avPairTimestamp finds the server's time among the target info AV pairs
+func avPairTimestamp(targetInfo []byte) (uint64, bool) {
+	for len(targetInfo) >= 4 {
+		id := binary.LittleEndian.Uint16(targetInfo)
+		length := int(binary.LittleEndian.Uint16(targetInfo[2:]))
+		if id == 0 || len(targetInfo) < 4+length {
+			break
+		}
+		if id == avTimestamp && length == 8 {
+			return binary.LittleEndian.Uint64(targetInfo[4:]), true
+		}
+		targetInfo = targetInfo[4+length:]
+	}
+	return 0, false
+}
+
+// fileTime converts t to 100ns intervals since 1601, the Windows FILETIME
+func fileTime(t time.Time) uint64 {
+	return uint64(t.UnixNano()/100) + 116444736000000000
+}
+
+// fromFileTime converts a Windows FILETIME to a time
+func fromFileTime(ft uint64) time.Time {
+	if ft == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, (int64(ft)-116444736000000000)*100).UTC()
+}
+
+func utf16le(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(units))
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(b[2*i:], u)
+	}
+	return b
+}
+
+func fromUTF16le(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// SPNEGO wrapping of NTLM tokens (RFC 4178), which servers expect in session
+// setup security buffers
+var (
+	oidSPNEGO = []byte{0x06, 0x06, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x02}
+	oidNTLM   = []byte{0x06, 0x0a, 0x2b, 0x06, 0x01, 0x04, 0x01, 0x82, 0x37, 0x02, 0x02, 0x0a}
+)
+
+// spnegoInit wraps the NTLM negotiate message in a NegTokenInit
+func spnegoInit(token []byte) []byte {
+	mechTypes := derTLV(0xa0, derTLV(0x30, oidNTLM))
+	mechToken := derTLV(0xa2, derTLV(0x04, token))
+	negTokenInit := derTLV(0xa0, derTLV(0x30, append(mechTypes, mechToken...)))
+	return derTLV(0x60, append(append([]byte{}, oidSPNEGO...), negTokenInit...))
+}
+
+// spnegoResponse wraps the NTLM authenticate message in a NegTokenResp
+func spnegoResponse(token []byte) []byte {
+	return derTLV(0xa1, derTLV(0x30, derTLV(0xa2, derTLV(0x04, token))))
+}
+
+func derTLV(tag byte, value []byte) []byte {
+	out := []byte{tag}
+	switch n := len(value); {
+	case n < 0x80:
+		out = append(out, byte(n))
+	case n < 0x100:
+		out = append(out, 0x81, byte(n))
+	default:
+		out = append(out, 0x82, byte(n>>8), byte(n))
+	}
+	return append(out, value...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/pkg/canonical"
 	"github.com/google/uuid"
 )
 
@@ -19,4 +20,7 @@ type AssetManager interface {
 
 	// UpdateAssetStats updates finding count and risk score
 	UpdateAssetStats(ctx context.Context, assetID uuid.UUID, riskScore, findingCount int) error
+
+	// PathPolicies returns how asset paths are canonicalized into stable IDs
+	PathPolicies() canonical.Policies
 }
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+)
+
+// Live event types streamed to clients over /api/v1/events
+const (
+	EventScanProgress    = "scan_progress"
+	EventScanCompleted   = "scan_completed"
+	EventCriticalFinding = "critical_finding"
+	EventSyncCompleted   = "sync_completed"
+
+	EventConnectionCoverageStale  = "connection_coverage_stale"
+	EventConnectionHealthFailing  = "connection_health_failing"
+	EventConnectionHealthRestored = "connection_health_restored"
+	EventPolicyViolationsFound    = "policy_violations_found"
+
+	EventRemediationApprovalRequested = "remediation_approval_requested"
+	EventRemediationApprovalDecided   = "remediation_approval_decided"
+
+	EventFindingCommentMention = "finding_comment_mention"
+
+	EventRiskWaiverExpiring = "risk_waiver_expiring"
+	EventRiskWaiverExpired  = "risk_waiver_expired"
+
+	EventAssetScanOverdue = "asset_scan_overdue"
+
+	EventScanVolumeAnomaly = "scan_volume_anomaly"
+)
+
+// Integration event types published to downstream consumers such as Kafka.
+// They are not streamed to clients.
+const (
+	EventFindingCreated        = "finding.created"
+	EventClassificationUpdated = "classification.updated"
+	EventRemediationExecuted   = "remediation.executed"
+)
+
+// EventPublisher defines the contract for publishing live events.
+// The tenant is taken from the context, so subscribers only see their own tenant's events.
+type EventPublisher interface {
+	Publish(ctx context.Context, eventType string, data interface{})
+}
+
+// NoOpEventPublisher drops events when live updates are not wired
+type NoOpEventPublisher struct{}
+
+// Publish does nothing (graceful degradation)
+func (n *NoOpEventPublisher) Publish(ctx context.Context, eventType string, data interface{}) {}
@@ -15,6 +15,12 @@ type LineageSync interface {
 	// SyncAllAssets triggers full lineage synchronization
 	SyncAllAssets(ctx context.Context) error
 
+	// MergeAssetNodes removes a merged-away asset from the graph and resyncs the surviving asset
+	MergeAssetNodes(ctx context.Context, sourceID, targetID uuid.UUID) error
+
+	// RemoveAssetNode removes a deleted asset and its relationships from the graph
+	RemoveAssetNode(ctx context.Context, assetID uuid.UUID) error
+
 	// IsAvailable returns true if lineage service is configured
 	IsAvailable() bool
 }
@@ -33,6 +39,16 @@ func (n *NoOpLineageSync) SyncAllAssets(ctx context.Context) error {
 	return nil
 }
 
+// MergeAssetNodes does nothing (graceful degradation)
+func (n *NoOpLineageSync) MergeAssetNodes(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	return nil
+}
+
+// RemoveAssetNode does nothing (graceful degradation)
+func (n *NoOpLineageSync) RemoveAssetNode(ctx context.Context, assetID uuid.UUID) error {
+	return nil
+}
+
 // IsAvailable always returns false
 func (n *NoOpLineageSync) IsAvailable() bool {
 	return false
@@ -4,7 +4,9 @@ import (
 	"database/sql"
 
 	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/jobs"
 	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -45,6 +47,19 @@ type ModuleDependencies struct {
 	FindingsProvider FindingsProvider
 	LineageSync      LineageSync
 	AuditLogger      AuditLogger
+	EventPublisher   EventPublisher
+
+	// Integration events for downstream consumers; NoOp unless Kafka is configured
+	IntegrationEvents EventPublisher
+
+	// Replays responses to retried POSTs carrying an Idempotency-Key; nil when disabled
+	Idempotency *middleware.Idempotency
+
+	// Records reads of finding PII in audit_logs; nil when disabled
+	AccessAudit *middleware.AccessAudit
+
+	// Persistent background job queue; modules register handlers while initializing
+	Jobs *jobs.Queue
 }
 
 // ModuleRegistry manages all registered modules
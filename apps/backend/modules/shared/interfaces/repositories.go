@@ -16,8 +16,8 @@ type AssetRepository interface {
 	GetAssetByID(ctx context.Context, id uuid.UUID) (*entity.Asset, error)
 	GetAssetByStableID(ctx context.Context, stableID string) (*entity.Asset, error)
 	ListAssets(ctx context.Context, limit, offset int) ([]*entity.Asset, error)
-	UpdateAssetRiskScore(ctx context.Context, id uuid.UUID, score int) error
-	UpdateAssetStats(ctx context.Context, id uuid.UUID, score int, totalFindings int) error
+	UpdateAssetRiskScore(ctx context.Context, id uuid.UUID, score int, cause string) error
+	UpdateAssetStats(ctx context.Context, id uuid.UUID, score int, totalFindings int, cause string) error
 	UpdateMaskingStatus(ctx context.Context, assetID uuid.UUID, isMasked bool, strategy string) error
 }
 
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// AccessPurposeHeader states why PII is being read; the purpose query parameter takes precedence
+	AccessPurposeHeader = "X-Access-Purpose"
+
+	maxAccessPurposeLength = 255
+
+	// accessedFindingsKey holds the finding IDs a handler returned
+	accessedFindingsKey = "access_audit_finding_ids"
+)
+
+// AuditRecorder writes audit_logs entries; interfaces.AuditLogger satisfies it
+type AuditRecorder interface {
+	Record(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) error
+}
+
+// AccessAudit records who read which findings' PII, and why. Handlers behind the
+// middleware report the findings they returned with RecordAccessedFindings; one
+// audit entry is written per successful request that returned any.
+type AccessAudit struct {
+	recorder       AuditRecorder
+	requirePurpose bool
+}
+
+// NewAccessAudit creates the access audit middleware. With requirePurpose, reads
+// without a purpose are rejected with 400 before the handler runs.
+func NewAccessAudit(recorder AuditRecorder, requirePurpose bool) *AccessAudit {
+	return &AccessAudit{recorder: recorder, requirePurpose: requirePurpose}
+}
+
+// RecordAccessedFindings notes findings whose PII the current request returns
+func RecordAccessedFindings(c *gin.Context, ids ...uuid.UUID) {
+	existing, _ := c.Get(accessedFindingsKey)
+	accessed, _ := existing.([]uuid.UUID)
+	c.Set(accessedFindingsKey, append(accessed, ids...))
+}
+
+// Middleware returns a Gin handler to attach to the routes that return finding PII
+func (a *AccessAudit) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil {
+			c.Next()
+			return
+		}
+
+		purpose := strings.TrimSpace(c.Query("purpose"))
+		if purpose == "" {
+			purpose = strings.TrimSpace(c.GetHeader(AccessPurposeHeader))
+		}
+		if len(purpose) > maxAccessPurposeLength {
+			sharedapi.Fail(c, sharedapi.CodeBadRequest, "purpose must be at most 255 characters", nil)
+			c.Abort()
+			return
+		}
+		if purpose == "" && a.requirePurpose {
+			sharedapi.Fail(c, sharedapi.CodeBadRequest, "A purpose query parameter or "+AccessPurposeHeader+" header is required to read findings", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			return
+		}
+		value, _ := c.Get(accessedFindingsKey)
+		ids, _ := value.([]uuid.UUID)
+		if len(ids) == 0 {
+			return
+		}
+
+		findingIDs := make([]string, len(ids))
+		for i, id := range ids {
+			findingIDs[i] = id.String()
+		}
+		resourceID := ""
+		if len(ids) == 1 {
+			resourceID = findingIDs[0]
+		}
+
+		// The response is already written; the audit entry must not be lost with the request
+		ctx := context.WithoutCancel(sharedapi.RequestContext(c))
+		if err := a.recorder.Record(ctx, entity.AuditActionFindingsViewed, "finding", resourceID, map[string]interface{}{
+			"finding_ids":   findingIDs,
+			"finding_count": len(findingIDs),
+			"purpose":       purpose,
+			"endpoint":      c.Request.Method + " " + c.FullPath(),
+			"user_email":    c.GetString("user_email"),
+			"ip_address":    c.ClientIP(),
+		}); err != nil {
+			log.Printf("ERROR: Failed to record findings access: %v", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type recordedAudit struct {
+	action     string
+	resourceID string
+	metadata   map[string]interface{}
+}
+
+type auditRecorderStub struct {
+	entries []recordedAudit
+}
+
+func (s *auditRecorderStub) Record(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) error {
+	s.entries = append(s.entries, recordedAudit{action: action, resourceID: resourceID, metadata: metadata})
+	return nil
+}
+
+// newAuditedRouter serves GET /findings, returning ids with the given status
+func newAuditedRouter(audit *AccessAudit, status int, ids ...uuid.UUID) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/findings", audit.Middleware(), func(c *gin.Context) {
+		RecordAccessedFindings(c, ids...)
+		c.JSON(status, gin.H{"count": len(ids)})
+	})
+	return router
+}
+
+func getFindings(router *gin.Engine, path, purposeHeader string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if purposeHeader != "" {
+		req.Header.Set(AccessPurposeHeader, purposeHeader)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAccessAuditRecordsReads(t *testing.T) {
+	recorder := &auditRecorderStub{}
+	first, second := uuid.New(), uuid.New()
+	router := newAuditedRouter(NewAccessAudit(recorder, false), http.StatusOK, first, second)
+
+	getFindings(router, "/findings?purpose=dsar-1234", "")
+	getFindings(router, "/findings", "incident review")
+
+	if len(recorder.entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(recorder.entries))
+	}
+	entry := recorder.entries[0]
+	if entry.action != entity.AuditActionFindingsViewed || entry.resourceID != "" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	ids, _ := entry.metadata["finding_ids"].([]string)
+	if len(ids) != 2 || ids[0] != first.String() || ids[1] != second.String() {
+		t.Errorf("unexpected finding_ids: %v", entry.metadata["finding_ids"])
+	}
+	if entry.metadata["purpose"] != "dsar-1234" || recorder.entries[1].metadata["purpose"] != "incident review" {
+		t.Errorf("unexpected purposes: %v, %v", entry.metadata["purpose"], recorder.entries[1].metadata["purpose"])
+	}
+}
+
+func TestAccessAuditSkipsEmptyAndFailedReads(t *testing.T) {
+	recorder := &auditRecorderStub{}
+	getFindings(newAuditedRouter(NewAccessAudit(recorder, false), http.StatusOK), "/findings", "")
+	getFindings(newAuditedRouter(NewAccessAudit(recorder, false), http.StatusInternalServerError, uuid.New()), "/findings", "")
+
+	if len(recorder.entries) != 0 {
+		t.Errorf("expected no audit entries, got %d", len(recorder.entries))
+	}
+}
+
+func TestAccessAuditRequiresPurpose(t *testing.T) {
+	recorder := &auditRecorderStub{}
+	id := uuid.New()
+	router := newAuditedRouter(NewAccessAudit(recorder, true), http.StatusOK, id)
+
+	if w := getFindings(router, "/findings", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a purpose, got %d", w.Code)
+	}
+	if w := getFindings(router, "/findings?purpose=audit", ""); w.Code != http.StatusOK {
+		t.Errorf("expected 200 with a purpose, got %d", w.Code)
+	}
+	if len(recorder.entries) != 1 || recorder.entries[0].resourceID != id.String() {
+		t.Errorf("expected one entry for the single finding, got %+v", recorder.entries)
+	}
+}
+
+func TestAccessAuditDisabled(t *testing.T) {
+	var audit *AccessAudit
+	if w := getFindings(newAuditedRouter(audit, http.StatusOK, uuid.New()), "/findings", ""); w.Code != http.StatusOK {
+		t.Errorf("expected a nil AccessAudit to pass requests through, got %d", w.Code)
+	}
+}
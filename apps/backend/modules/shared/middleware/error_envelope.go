@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorEnvelope renders request binding and validation failures recorded by handlers
+// through sharedapi.BindJSON: 422 VALIDATION_FAILED with per-field details, or
+// 400 MALFORMED_BODY when the body cannot be decoded
+func ErrorEnvelope() gin.HandlerFunc {
+	sharedapi.RegisterValidation()
+
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Written() {
+			return
+		}
+		if bindErr := c.Errors.ByType(gin.ErrorTypeBind).Last(); bindErr != nil {
+			sharedapi.RenderBindError(c, bindErr.Err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/domain/repository"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client's key for a retried request
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks a response replayed from an earlier request
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// Responses larger than this are not stored; a retry is processed again
+	maxIdempotentResponseBytes = 1 << 20
+	// Unread request body drained after the handler to finish the request hash
+	maxIdempotentDrainBytes = 1 << 20
+)
+
+// Idempotency replays the stored response when a POST is retried with the same
+// Idempotency-Key header. Keys are scoped to the tenant and route; requests without
+// the header are processed as usual.
+//
+// The first request's body is hashed as the handler reads it, so streamed bodies are
+// not buffered. A key reused with a different request is rejected with 422, and a
+// retry that arrives while the first request is still running gets 409. Server
+// errors release the key so the request can be retried.
+type Idempotency struct {
+	store  repository.IdempotencyRepository
+	window time.Duration
+}
+
+// NewIdempotency creates the idempotency middleware; keys are kept for window
+func NewIdempotency(store repository.IdempotencyRepository, window time.Duration) *Idempotency {
+	return &Idempotency{store: store, window: window}
+}
+
+// StartCleanup purges expired keys every interval until ctx is cancelled
+func (i *Idempotency) StartCleanup(ctx context.Context, interval time.Duration) {
+	if i == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := i.store.PurgeExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				log.Printf("⚠️  Failed to purge idempotency keys: %v", err)
+			} else if purged > 0 {
+				log.Printf("🧹 Purged %d expired idempotency keys", purged)
+			}
+		}
+	}
+}
+
+// Middleware returns a Gin handler to attach to the routes that honour Idempotency-Key
+func (i *Idempotency) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if i == nil || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			sharedapi.Fail(c, sharedapi.CodeBadRequest, "Idempotency-Key must be at most 255 characters", nil)
+			c.Abort()
+			return
+		}
+
+		ctx := sharedapi.RequestContext(c)
+		endpoint := c.Request.Method + " " + c.FullPath()
+
+		existing, err := i.store.ReserveIdempotencyKey(ctx, endpoint, key, time.Now().Add(i.window))
+		if err != nil {
+			log.Printf("ERROR: Failed to reserve idempotency key: %v", err)
+			sharedapi.Fail(c, sharedapi.CodeInternal, "Failed to check Idempotency-Key", nil)
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			i.replay(c, existing)
+			c.Abort()
+			return
+		}
+
+		body := &hashingReader{ReadCloser: c.Request.Body, hash: newRequestHash(c.Request)}
+		c.Request.Body = body
+		writer := &capturingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		completed := false
+		defer func() {
+			// Release the key when the handler panics so retries are not stuck on 409
+			if !completed {
+				if err := i.store.ReleaseIdempotencyKey(context.WithoutCancel(ctx), endpoint, key); err != nil {
+					log.Printf("ERROR: Failed to release idempotency key: %v", err)
+				}
+			}
+		}()
+
+		c.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError || writer.overflow || !body.finish() {
+			return
+		}
+
+		record := &entity.IdempotencyRecord{
+			Endpoint:    endpoint,
+			Key:         key,
+			RequestHash: body.sum(),
+			StatusCode:  status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err := i.store.CompleteIdempotencyKey(context.WithoutCancel(ctx), record); err != nil {
+			log.Printf("ERROR: Failed to store idempotent response: %v", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay answers a repeated key with the stored response, or an error when the
+// first request is still running or was a different request
+func (i *Idempotency) replay(c *gin.Context, record *entity.IdempotencyRecord) {
+	if !record.Completed() {
+		sharedapi.Fail(c, sharedapi.CodeConflict, "A request with this Idempotency-Key is still being processed", nil)
+		return
+	}
+
+	h := newRequestHash(c.Request)
+	if _, err := io.Copy(h, c.Request.Body); err != nil {
+		sharedapi.Fail(c, sharedapi.CodeMalformedBody, "Failed to read request body", nil)
+		return
+	}
+	if hex.EncodeToString(h.Sum(nil)) != record.RequestHash {
+		sharedapi.Fail(c, sharedapi.CodeIdempotencyKeyReused, "Idempotency-Key was already used for a different request", nil)
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+}
+
+// newRequestHash starts a request hash with the method and URL, so a key sent to
+// another resource on the same route is a different request
+func newRequestHash(r *http.Request) hash.Hash {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	return h
+}
+
+// hashingReader hashes a request body as the handler reads it
+type hashingReader struct {
+	io.ReadCloser
+	hash hash.Hash
+	eof  bool
+}
+
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		r.eof = true
+	}
+	return n, err
+}
+
+// finish hashes what the handler left unread, reporting false when the body is
+// too large to drain and the hash cannot be completed
+func (r *hashingReader) finish() bool {
+	if r.eof {
+		return true
+	}
+	_, err := io.Copy(io.Discard, io.LimitReader(r, maxIdempotentDrainBytes))
+	return err == nil && r.eof
+}
+
+func (r *hashingReader) sum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+// capturingWriter keeps a copy of the response body for storage
+type capturingWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *capturingWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *capturingWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *capturingWriter) capture(b []byte) {
+	if w.overflow {
+		return
+	}
+	if w.body.Len()+len(b) > maxIdempotentResponseBytes {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(b)
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence/memory"
+	"github.com/gin-gonic/gin"
+)
+
+// newIdempotentRouter serves POST /execute/:id, counting how often the handler runs
+func newIdempotentRouter(repo *memory.Repository, status int) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	router := gin.New()
+	router.POST("/execute/:id", NewIdempotency(repo, time.Hour).Middleware(), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		calls++
+		c.JSON(status, gin.H{"call": calls, "body": string(body)})
+	})
+	return router, &calls
+}
+
+func postWithKey(router *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	router, calls := newIdempotentRouter(memory.NewRepository(), http.StatusCreated)
+
+	first := postWithKey(router, "/execute/1", "key-1", `{"a":1}`)
+	second := postWithKey(router, "/execute/1", "key-1", `{"a":1}`)
+
+	if *calls != 1 {
+		t.Fatalf("expected the handler to run once, ran %d times", *calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("replayed %d %q, want %d %q", second.Code, second.Body.String(), first.Code, first.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Error("expected the replayed response to be marked")
+	}
+	if first.Header().Get(IdempotentReplayedHeader) != "" {
+		t.Error("expected the first response not to be marked")
+	}
+
+	// Requests without a key are always processed
+	postWithKey(router, "/execute/1", "", `{"a":1}`)
+	postWithKey(router, "/execute/1", "", `{"a":1}`)
+	if *calls != 3 {
+		t.Errorf("expected unkeyed requests to run, handler ran %d times", *calls)
+	}
+}
+
+func TestIdempotencyRejectsReusedKey(t *testing.T) {
+	router, calls := newIdempotentRouter(memory.NewRepository(), http.StatusOK)
+
+	postWithKey(router, "/execute/1", "key-1", `{"a":1}`)
+	if w := postWithKey(router, "/execute/1", "key-1", `{"a":2}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("different body: expected 422, got %d", w.Code)
+	}
+	if w := postWithKey(router, "/execute/2", "key-1", `{"a":1}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("different resource: expected 422, got %d", w.Code)
+	}
+	if *calls != 1 {
+		t.Errorf("expected the handler to run once, ran %d times", *calls)
+	}
+}
+
+func TestIdempotencyInFlightAndExpiry(t *testing.T) {
+	repo := memory.NewRepository()
+	router, calls := newIdempotentRouter(repo, http.StatusOK)
+
+	// A reservation without a stored response belongs to a request still running
+	if _, err := repo.ReserveIdempotencyKey(t.Context(), "POST /execute/:id", "key-1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if w := postWithKey(router, "/execute/1", "key-1", `{}`); w.Code != http.StatusConflict {
+		t.Errorf("in flight: expected 409, got %d", w.Code)
+	}
+
+	// Once the window has passed the key is free again
+	repo.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if w := postWithKey(router, "/execute/1", "key-1", `{}`); w.Code != http.StatusOK || *calls != 1 {
+		t.Errorf("expired: got %d after %d calls", w.Code, *calls)
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	router, calls := newIdempotentRouter(memory.NewRepository(), http.StatusInternalServerError)
+
+	postWithKey(router, "/execute/1", "key-1", `{}`)
+	postWithKey(router, "/execute/1", "key-1", `{}`)
+	if *calls != 2 {
+		t.Errorf("expected a failed request to be retried, handler ran %d times", *calls)
+	}
+}
+
+func TestIdempotencyRejectsLongKey(t *testing.T) {
+	router, calls := newIdempotentRouter(memory.NewRepository(), http.StatusOK)
+
+	if w := postWithKey(router, "/execute/1", strings.Repeat("k", 256), `{}`); w.Code != http.StatusBadRequest || *calls != 0 {
+		t.Errorf("expected 400 without running the handler, got %d after %d calls", w.Code, *calls)
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/sharing/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ShareHandler handles dashboard share links and opening them
+type ShareHandler struct {
+	service *service.ShareService
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(service *service.ShareService) *ShareHandler {
+	return &ShareHandler{service: service}
+}
+
+// ListLinks handles GET /api/v1/dashboard/shares
+func (h *ShareHandler) ListLinks(c *gin.Context) {
+	links, err := h.service.ListLinks(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list share links", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": links, "total": len(links)})
+}
+
+// CreateLink handles POST /api/v1/dashboard/shares
+func (h *ShareHandler) CreateLink(c *gin.Context) {
+	var input service.ShareLinkInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	link, err := h.service.CreateLink(sharedapi.RequestContext(c), input, actorName(c))
+	if err != nil {
+		c.JSON(statusForShareError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": link, "url": "/api/v1/public/shares/" + link.Token})
+}
+
+// RevokeLink handles DELETE /api/v1/dashboard/shares/:id
+func (h *ShareHandler) RevokeLink(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid share link ID"})
+		return
+	}
+
+	link, err := h.service.RevokeLink(sharedapi.RequestContext(c), id, actorName(c))
+	if err != nil {
+		c.JSON(statusForShareError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": link})
+}
+
+// OpenLink handles GET /api/v1/public/shares/:token. It is public; the token is the credential.
+// The allowlist is checked against ClientIP, which honours forwarding headers only
+// from the router's trusted proxies (TRUSTED_PROXIES).
+func (h *ShareHandler) OpenLink(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-Robots-Tag", "noindex")
+
+	snapshot, err := h.service.Open(c.Request.Context(), c.Param("token"), c.ClientIP())
+	if err != nil {
+		status := statusForShareError(err)
+		if status == http.StatusInternalServerError {
+			log.Printf("❌ Failed to open share link: %v", err)
+			c.JSON(status, gin.H{"error": "Failed to load shared dashboard"})
+			return
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": snapshot})
+}
+
+// actorName returns the caller's email, or "system" for anonymous requests
+func actorName(c *gin.Context) string {
+	if email, ok := c.Get("user_email"); ok {
+		if s, ok := email.(string); ok && s != "" {
+			return s
+		}
+	}
+	return "system"
+}
+
+func statusForShareError(err error) int {
+	switch {
+	case errors.Is(err, service.ErrShareLinkNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrShareLinkForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidShareLink):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/sharing/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// openLinkFrom opens a share link limited to 203.0.113.7 from remoteAddr, claiming
+// to be that address in X-Forwarded-For, behind a router trusting trustedProxies
+func openLinkFrom(t *testing.T, trustedProxies []string, remoteAddr string) int {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "tenant_id", "name", "widgets", "environment", "days", "ip_allowlist", "token_hash", "expires_at",
+		"created_by", "created_at", "revoked_at", "revoked_by", "last_accessed_at", "access_count"}
+	mock.ExpectQuery(`FROM share_links WHERE token_hash = \$1`).WillReturnRows(sqlmock.NewRows(columns).AddRow(
+		uuid.New(), uuid.New(), "Auditor view", pq.StringArray{}, "", 30, pq.StringArray{"203.0.113.7"}, "hash",
+		time.Now().Add(time.Hour), "admin@example.com", time.Now(), nil, "", nil, 0))
+	mock.ExpectExec(`UPDATE share_links SET access_count`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		t.Fatal(err)
+	}
+	shares := service.NewShareService(persistence.NewPostgresRepository(db), nil, config.SharingConfig{}, config.ComplianceScoreConfig{})
+	router.GET("/public/shares/:token", NewShareHandler(shares).OpenLink)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/shares/token", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestOpenLinkIgnoresSpoofedForwardedFor(t *testing.T) {
+	if code := openLinkFrom(t, nil, "198.51.100.20:40000"); code != http.StatusForbidden {
+		t.Errorf("expected 403 for a caller outside the allowlist claiming an allowed address, got %d", code)
+	}
+	if code := openLinkFrom(t, []string{"10.0.0.0/8"}, "198.51.100.20:40000"); code != http.StatusForbidden {
+		t.Errorf("expected 403 for X-Forwarded-For from an untrusted peer, got %d", code)
+	}
+	if code := openLinkFrom(t, []string{"10.0.0.0/8"}, "10.1.2.3:40000"); code != http.StatusOK {
+		t.Errorf("expected 200 for an allowed client behind a trusted proxy, got %d", code)
+	}
+}
+
+func TestRevokeUnknownLink(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tenantID, linkID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`UPDATE share_links`).WithArgs("system", linkID, tenantID).WillReturnError(sql.ErrNoRows)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("tenant_id", tenantID) })
+	shares := service.NewShareService(persistence.NewPostgresRepository(db), nil, config.SharingConfig{}, config.ComplianceScoreConfig{})
+	router.DELETE("/shares/:id", NewShareHandler(shares).RevokeLink)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/shares/"+linkID.String(), nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown link, got %d: %s", w.Code, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
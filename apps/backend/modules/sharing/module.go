@@ -0,0 +1,76 @@
+package sharing
+
+import (
+	"log"
+
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/modules/sharing/api"
+	"github.com/arc-platform/backend/modules/sharing/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SharingModule issues read-only share links to dashboard widgets, e.g. a PII
+// summary for an auditor, that open without logging in
+type SharingModule struct {
+	shareService *service.ShareService
+	shareHandler *api.ShareHandler
+
+	// Share links are managed by admins
+	authMiddleware *middleware.AuthMiddleware
+
+	deps *interfaces.ModuleDependencies
+}
+
+// NewSharingModule creates a new sharing module
+func NewSharingModule() *SharingModule {
+	return &SharingModule{}
+}
+
+// Name returns the module name
+func (m *SharingModule) Name() string {
+	return "sharing"
+}
+
+// Initialize sets up the module
+func (m *SharingModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("🔗 Initializing Sharing Module...")
+
+	var cfg config.SharingConfig
+	var scoreCfg config.ComplianceScoreConfig
+	if deps.Config != nil {
+		cfg = deps.Config.Sharing
+		scoreCfg = deps.Config.PostureScore
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.shareService = service.NewShareService(repo, deps.AuditLogger, cfg, scoreCfg)
+	m.shareHandler = api.NewShareHandler(m.shareService)
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	log.Println("✅ Sharing Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *SharingModule) RegisterRoutes(router *gin.RouterGroup) {
+	shares := router.Group("/dashboard/shares", m.authMiddleware.RequireRole("admin"))
+	{
+		shares.GET("", m.shareHandler.ListLinks)
+		shares.POST("", m.shareHandler.CreateLink)
+		shares.DELETE("/:id", m.shareHandler.RevokeLink)
+	}
+
+	// Public: the link token is the credential
+	router.GET("/public/shares/:token", m.shareHandler.OpenLink)
+
+	log.Printf("🔗 Sharing routes registered")
+}
+
+// Shutdown has nothing to release
+func (m *SharingModule) Shutdown() error {
+	return nil
+}
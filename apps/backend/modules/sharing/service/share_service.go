@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	analyticsservice "github.com/arc-platform/backend/modules/analytics/service"
+	complianceservice "github.com/arc-platform/backend/modules/compliance/service"
+	scanningservice "github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// ErrShareLinkNotFound is returned for unknown, expired and revoked links alike
+var ErrShareLinkNotFound = persistence.ErrShareLinkNotFound
+
+// ErrShareLinkForbidden is returned when the caller's IP is not on the link's allowlist
+var ErrShareLinkForbidden = errors.New("share link is not available from this address")
+
+// ErrInvalidShareLink is returned for share link input that fails validation
+var ErrInvalidShareLink = errors.New("invalid share link")
+
+const (
+	defaultShareDays = 30
+	maxShareDays     = 365
+)
+
+// shareWidgets lists the widgets a link may expose, in display order
+var shareWidgets = []string{
+	entity.ShareWidgetPIISummary,
+	entity.ShareWidgetComplianceScore,
+	entity.ShareWidgetRiskTrend,
+	entity.ShareWidgetPIIHeatmap,
+}
+
+// ShareLinkInput is the payload for creating a share link
+type ShareLinkInput struct {
+	Name        string     `json:"name"`
+	Widgets     []string   `json:"widgets"`
+	Environment string     `json:"environment"`  // Narrows the PII summary, e.g. PROD
+	Days        int        `json:"days"`         // Risk trend and score history window; defaults to 30
+	IPAllowlist []string   `json:"ip_allowlist"` // IPs or CIDRs; empty allows any address
+	ExpiresAt   *time.Time `json:"expires_at"`   // Defaults to SHARE_LINK_DEFAULT_TTL_HOURS from now
+}
+
+// ShareSnapshot is what a share link shows: the selected widgets, computed at GeneratedAt
+type ShareSnapshot struct {
+	Name        string                 `json:"name"`
+	Widgets     map[string]interface{} `json:"widgets"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	ExpiresAt   time.Time              `json:"expires_at"`
+}
+
+type cachedSnapshot struct {
+	snapshot *ShareSnapshot
+	until    time.Time
+}
+
+// ShareService manages read-only dashboard share links and renders their widgets.
+// Widgets are aggregates; no finding sample or matched value is ever rendered.
+type ShareService struct {
+	repo        *persistence.PostgresRepository
+	summary     *scanningservice.DashboardSummaryService
+	score       *complianceservice.ComplianceScoreService
+	analytics   *analyticsservice.AnalyticsService
+	auditLogger interfaces.AuditLogger
+
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+	cacheTTL   time.Duration
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedSnapshot
+}
+
+// NewShareService creates a new share service
+func NewShareService(repo *persistence.PostgresRepository, auditLogger interfaces.AuditLogger, cfg config.SharingConfig, scoreCfg config.ComplianceScoreConfig) *ShareService {
+	defaultTTL := time.Duration(cfg.DefaultTTLHours) * time.Hour
+	if defaultTTL <= 0 {
+		defaultTTL = 7 * 24 * time.Hour
+	}
+	maxTTL := time.Duration(cfg.MaxTTLDays) * 24 * time.Hour
+	if maxTTL <= 0 {
+		maxTTL = 90 * 24 * time.Hour
+	}
+	if defaultTTL > maxTTL {
+		defaultTTL = maxTTL
+	}
+	cacheTTL := time.Duration(cfg.CacheSeconds) * time.Second
+	if cacheTTL < 0 {
+		cacheTTL = 0
+	}
+
+	return &ShareService{
+		repo:        repo,
+		summary:     scanningservice.NewDashboardSummaryService(repo),
+		score:       complianceservice.NewComplianceScoreService(repo, scoreCfg),
+		analytics:   analyticsservice.NewAnalyticsService(repo),
+		auditLogger: auditLogger,
+		defaultTTL:  defaultTTL,
+		maxTTL:      maxTTL,
+		cacheTTL:    cacheTTL,
+		cache:       make(map[uuid.UUID]cachedSnapshot),
+	}
+}
+
+// CreateLink validates and stores a new share link. The returned link carries its
+// token, which cannot be retrieved again.
+func (s *ShareService) CreateLink(ctx context.Context, input ShareLinkInput, createdBy string) (*entity.ShareLink, error) {
+	link, err := s.buildLink(input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	link.TokenHash = hashShareToken(token)
+	link.CreatedBy = createdBy
+
+	if err := s.repo.CreateShareLink(ctx, link); err != nil {
+		return nil, err
+	}
+	link.Token = token
+
+	s.audit(ctx, "SHARE_LINK_CREATED", link, map[string]interface{}{
+		"name":         link.Name,
+		"widgets":      link.Widgets,
+		"ip_allowlist": link.IPAllowlist,
+		"expires_at":   link.ExpiresAt,
+	})
+	return link, nil
+}
+
+// ListLinks returns the tenant's share links, without their tokens
+func (s *ShareService) ListLinks(ctx context.Context) ([]*entity.ShareLink, error) {
+	return s.repo.ListShareLinks(ctx)
+}
+
+// RevokeLink stops a share link from opening
+func (s *ShareService) RevokeLink(ctx context.Context, id uuid.UUID, revokedBy string) (*entity.ShareLink, error) {
+	link, err := s.repo.RevokeShareLink(ctx, id, revokedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.cache, id)
+	s.mu.Unlock()
+
+	s.audit(ctx, "SHARE_LINK_REVOKED", link, map[string]interface{}{"name": link.Name})
+	return link, nil
+}
+
+// Open renders the widgets of the link with the given token for a caller at
+// clientIP. Snapshots are reused for SHARE_LINK_CACHE_SECONDS.
+func (s *ShareService) Open(ctx context.Context, token, clientIP string) (*ShareSnapshot, error) {
+	if token == "" {
+		return nil, ErrShareLinkNotFound
+	}
+	link, err := s.repo.GetShareLinkByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if link == nil || !link.Active(now) {
+		return nil, ErrShareLinkNotFound
+	}
+	if !ipAllowed(link.IPAllowlist, clientIP) {
+		return nil, ErrShareLinkForbidden
+	}
+
+	ctx = context.WithValue(ctx, "tenant_id", link.TenantID)
+	if err := s.repo.RecordShareLinkAccess(ctx, link.ID); err != nil {
+		log.Printf("WARN: %v", err)
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[link.ID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.until) {
+		return cached.snapshot, nil
+	}
+
+	snapshot := &ShareSnapshot{
+		Name:        link.Name,
+		Widgets:     make(map[string]interface{}, len(link.Widgets)),
+		GeneratedAt: now.UTC(),
+		ExpiresAt:   link.ExpiresAt,
+	}
+	for _, widget := range link.Widgets {
+		data, err := s.renderWidget(ctx, link, widget)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", widget, err)
+		}
+		snapshot.Widgets[widget] = data
+	}
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		for id, c := range s.cache {
+			if now.After(c.until) {
+				delete(s.cache, id)
+			}
+		}
+		s.cache[link.ID] = cachedSnapshot{snapshot: snapshot, until: now.Add(s.cacheTTL)}
+		s.mu.Unlock()
+	}
+	return snapshot, nil
+}
+
+// renderWidget computes one widget for the link's tenant in ctx
+func (s *ShareService) renderWidget(ctx context.Context, link *entity.ShareLink, widget string) (interface{}, error) {
+	switch widget {
+	case entity.ShareWidgetPIISummary:
+		summary, err := s.summary.GetSummary(ctx, link.Environment)
+		if err != nil {
+			return nil, err
+		}
+		summary.Staleness.LastError = "" // Internal refresh errors are not for outside readers
+		return summary, nil
+	case entity.ShareWidgetComplianceScore:
+		return s.score.Score(ctx, link.Days)
+	case entity.ShareWidgetRiskTrend:
+		return s.analytics.GetRiskTrend(ctx, link.Days)
+	case entity.ShareWidgetPIIHeatmap:
+		// Hosts are left out; rows are environments and data sources only
+		return s.analytics.GetPIIHeatmap(ctx, analyticsservice.DefaultHeatmapDimensions)
+	default:
+		return nil, fmt.Errorf("unknown widget %q", widget)
+	}
+}
+
+// buildLink validates input into a new link
+func (s *ShareService) buildLink(input ShareLinkInput, now time.Time) (*entity.ShareLink, error) {
+	link := &entity.ShareLink{ID: uuid.New(), Days: input.Days, IPAllowlist: []string{}}
+
+	link.Name = strings.TrimSpace(input.Name)
+	if link.Name == "" {
+		return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidShareLink)
+	}
+
+	seen := make(map[string]bool)
+	for _, w := range input.Widgets {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if !containsString(shareWidgets, w) {
+			return nil, fmt.Errorf("%w: widget %q must be one of %s", ErrInvalidShareLink, w, strings.Join(shareWidgets, ", "))
+		}
+		if !seen[w] {
+			seen[w] = true
+			link.Widgets = append(link.Widgets, w)
+		}
+	}
+	if len(link.Widgets) == 0 {
+		return nil, fmt.Errorf("%w: widgets must not be empty", ErrInvalidShareLink)
+	}
+
+	link.Environment = strings.ToUpper(strings.TrimSpace(input.Environment))
+	if link.Days == 0 {
+		link.Days = defaultShareDays
+	}
+	if link.Days < 1 || link.Days > maxShareDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidShareLink, maxShareDays)
+	}
+
+	for _, entry := range input.IPAllowlist {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if net.ParseIP(entry) == nil {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return nil, fmt.Errorf("%w: ip_allowlist entry %q must be an IP address or CIDR", ErrInvalidShareLink, entry)
+			}
+		}
+		link.IPAllowlist = append(link.IPAllowlist, entry)
+	}
+
+	link.ExpiresAt = now.Add(s.defaultTTL)
+	if input.ExpiresAt != nil {
+		link.ExpiresAt = *input.ExpiresAt
+	}
+	if !link.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidShareLink)
+	}
+	if link.ExpiresAt.After(now.Add(s.maxTTL)) {
+		return nil, fmt.Errorf("%w: expires_at must be within %d days", ErrInvalidShareLink, int(s.maxTTL.Hours()/24))
+	}
+	link.ExpiresAt = link.ExpiresAt.UTC()
+	return link, nil
+}
+
+func (s *ShareService) audit(ctx context.Context, action string, link *entity.ShareLink, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, "share_link", link.ID.String(), metadata); err != nil {
+		log.Printf("WARN: Failed to record %s audit event: %v", action, err)
+	}
+}
+
+// ipAllowed reports whether ip matches an allowlist entry; an empty allowlist allows any address
+func ipAllowed(allowlist []string, ip string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, entry := range allowlist {
+		if allowed := net.ParseIP(entry); allowed != nil {
+			if allowed.Equal(addr) {
+				return true
+			}
+			continue
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// newShareToken returns a random, URL-safe 256-bit token
+func newShareToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashShareToken returns the hex SHA-256 of a token, as stored
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	analyticsservice "github.com/arc-platform/backend/modules/analytics/service"
+	complianceservice "github.com/arc-platform/backend/modules/compliance/service"
+	scanningservice "github.com/arc-platform/backend/modules/scanning/service"
+	"github.com/arc-platform/backend/modules/shared/config"
+)
+
+func TestBuildShareLink(t *testing.T) {
+	s := NewShareService(nil, nil, config.SharingConfig{DefaultTTLHours: 24, MaxTTLDays: 30}, config.ComplianceScoreConfig{})
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	link, err := s.buildLink(ShareLinkInput{
+		Name:        " Auditor view ",
+		Widgets:     []string{"PII_Summary", "compliance_score", "pii_summary"},
+		Environment: "prod",
+		IPAllowlist: []string{"203.0.113.7", " 10.0.0.0/8 ", ""},
+	}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Name != "Auditor view" || link.Environment != "PROD" || link.Days != 30 {
+		t.Errorf("unexpected link %+v", link)
+	}
+	if strings.Join(link.Widgets, ",") != "pii_summary,compliance_score" {
+		t.Errorf("expected deduplicated widgets, got %v", link.Widgets)
+	}
+	if len(link.IPAllowlist) != 2 || !link.ExpiresAt.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("unexpected allowlist or expiry %v %v", link.IPAllowlist, link.ExpiresAt)
+	}
+
+	past, far := now.Add(-time.Hour), now.AddDate(0, 0, 31)
+	for _, invalid := range []ShareLinkInput{
+		{Widgets: []string{"pii_summary"}},
+		{Name: "x"},
+		{Name: "x", Widgets: []string{"findings"}},
+		{Name: "x", Widgets: []string{"risk_trend"}, Days: 400},
+		{Name: "x", Widgets: []string{"risk_trend"}, IPAllowlist: []string{"10.0.0.0/33"}},
+		{Name: "x", Widgets: []string{"risk_trend"}, ExpiresAt: &past},
+		{Name: "x", Widgets: []string{"risk_trend"}, ExpiresAt: &far},
+	} {
+		if _, err := s.buildLink(invalid, now); !errors.Is(err, ErrInvalidShareLink) {
+			t.Errorf("expected %+v to be invalid", invalid)
+		}
+	}
+}
+
+func TestIPAllowed(t *testing.T) {
+	allowlist := []string{"203.0.113.7", "10.0.0.0/8", "2001:db8::/32"}
+	for ip, want := range map[string]bool{
+		"203.0.113.7":   true,
+		"10.20.30.40":   true,
+		"2001:db8::1":   true,
+		"203.0.113.8":   false,
+		"not-an-ip":     false,
+		"192.168.1.100": false,
+	} {
+		if got := ipAllowed(allowlist, ip); got != want {
+			t.Errorf("ipAllowed(%s) = %v, want %v", ip, got, want)
+		}
+	}
+	if !ipAllowed(nil, "192.168.1.100") {
+		t.Error("expected an empty allowlist to allow any address")
+	}
+}
+
+func TestShareToken(t *testing.T) {
+	a, err := newShareToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := newShareToken()
+	if a == b || len(a) < 40 {
+		t.Errorf("expected distinct long tokens, got %q %q", a, b)
+	}
+	if hashShareToken(a) == a || len(hashShareToken(a)) != 64 || hashShareToken(a) != hashShareToken(a) {
+		t.Error("expected a stable hex SHA-256 of the token")
+	}
+}
+
+// Share links are opened without authentication, so no widget may carry sample
+// values or matches; this guards the widget types against such fields being added
+func TestShareWidgetsCarryNoSamples(t *testing.T) {
+	forbidden := map[string]bool{
+		"sample_text": true, "matches": true, "masked_value": true, "context": true,
+		"sample": true, "samples": true, "value": true, "raw_value": true,
+	}
+	for _, v := range []interface{}{
+		scanningservice.DashboardSummary{},
+		complianceservice.ComplianceScore{},
+		analyticsservice.RiskTrend{},
+		analyticsservice.PIIHeatmap{},
+	} {
+		checkNoSampleFields(t, reflect.TypeOf(v), forbidden, map[reflect.Type]bool{})
+	}
+}
+
+func checkNoSampleFields(t *testing.T, typ reflect.Type, forbidden map[string]bool, seen map[reflect.Type]bool) {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || seen[typ] {
+		return
+	}
+	seen[typ] = true
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if forbidden[name] {
+			t.Errorf("%s.%s is exposed as %q on share links", typ.Name(), field.Name, name)
+		}
+		checkNoSampleFields(t, field.Type, forbidden, seen)
+	}
+}
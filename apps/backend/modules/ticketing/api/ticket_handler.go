@@ -0,0 +1,247 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/arc-platform/backend/modules/ticketing/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxWebhookBodyBytes bounds inbound tracker webhook payloads
+const maxWebhookBodyBytes = 1 << 20
+
+// TicketHandler handles issue tracker integrations, ticket links and tracker webhooks
+type TicketHandler struct {
+	service *service.TicketService
+}
+
+// NewTicketHandler creates a new ticket handler
+func NewTicketHandler(service *service.TicketService) *TicketHandler {
+	return &TicketHandler{service: service}
+}
+
+// ListIntegrations handles GET /api/v1/ticketing/integrations
+func (h *TicketHandler) ListIntegrations(c *gin.Context) {
+	integrations, err := h.service.ListIntegrations(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list ticket integrations", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": integrations, "total": len(integrations)})
+}
+
+// CreateIntegration handles POST /api/v1/ticketing/integrations
+func (h *TicketHandler) CreateIntegration(c *gin.Context) {
+	var input service.TicketIntegrationInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	integration, err := h.service.CreateIntegration(sharedapi.RequestContext(c), input, actorName(c))
+	if err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": integration})
+}
+
+// GetIntegration handles GET /api/v1/ticketing/integrations/:id
+func (h *TicketHandler) GetIntegration(c *gin.Context) {
+	id, ok := parseID(c, "Invalid integration ID")
+	if !ok {
+		return
+	}
+
+	integration, err := h.service.GetIntegration(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": integration})
+}
+
+// UpdateIntegration handles PUT /api/v1/ticketing/integrations/:id
+func (h *TicketHandler) UpdateIntegration(c *gin.Context) {
+	id, ok := parseID(c, "Invalid integration ID")
+	if !ok {
+		return
+	}
+	var input service.TicketIntegrationInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	integration, err := h.service.UpdateIntegration(sharedapi.RequestContext(c), id, input)
+	if err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": integration})
+}
+
+// DeleteIntegration handles DELETE /api/v1/ticketing/integrations/:id
+func (h *TicketHandler) DeleteIntegration(c *gin.Context) {
+	id, ok := parseID(c, "Invalid integration ID")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteIntegration(sharedapi.RequestContext(c), id); err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ticket integration deleted"})
+}
+
+// ListFindingTickets handles GET /api/v1/findings/:id/tickets
+func (h *TicketHandler) ListFindingTickets(c *gin.Context) {
+	id, ok := parseID(c, "Invalid finding ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.service.ListFindingTickets(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tickets", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": links, "total": len(links)})
+}
+
+// CreateFindingTicket handles POST /api/v1/findings/:id/tickets
+func (h *TicketHandler) CreateFindingTicket(c *gin.Context) {
+	id, ok := parseID(c, "Invalid finding ID")
+	if !ok {
+		return
+	}
+	var input service.TicketInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	link, err := h.service.CreateFindingTicket(sharedapi.RequestContext(c), id, input, actorName(c))
+	if err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": link})
+}
+
+// ListRemediationRequestTickets handles GET /api/v1/remediation/approvals/:id/tickets
+func (h *TicketHandler) ListRemediationRequestTickets(c *gin.Context) {
+	id, ok := parseID(c, "Invalid remediation request ID")
+	if !ok {
+		return
+	}
+
+	links, err := h.service.ListRemediationRequestTickets(sharedapi.RequestContext(c), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list tickets", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": links, "total": len(links)})
+}
+
+// CreateRemediationRequestTicket handles POST /api/v1/remediation/approvals/:id/tickets
+func (h *TicketHandler) CreateRemediationRequestTicket(c *gin.Context) {
+	id, ok := parseID(c, "Invalid remediation request ID")
+	if !ok {
+		return
+	}
+	var input service.TicketInput
+	if !sharedapi.BindJSON(c, &input) {
+		return
+	}
+
+	link, err := h.service.CreateRemediationRequestTicket(sharedapi.RequestContext(c), id, input, actorName(c))
+	if err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": link})
+}
+
+// Sync handles POST /api/v1/ticketing/sync, pushing the tenant's ARC status changes to linked tickets now
+func (h *TicketHandler) Sync(c *gin.Context) {
+	result, err := h.service.Sync(sharedapi.RequestContext(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sync tickets", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+// Webhook handles POST /api/v1/ticketing/webhooks/:integration_id. It is public;
+// the tracker authenticates with the integration's webhook secret.
+func (h *TicketHandler) Webhook(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("integration_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "ticket integration not found"})
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read webhook body"})
+		return
+	}
+
+	result, err := h.service.HandleWebhook(c.Request.Context(), id, body,
+		c.GetHeader("X-Hub-Signature"), c.GetHeader("X-ARC-Webhook-Token"))
+	if err != nil {
+		c.JSON(statusForTicketError(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": result})
+}
+
+func parseID(c *gin.Context, message string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// actorName returns the caller's email, or "system" for anonymous requests
+func actorName(c *gin.Context) string {
+	if email, ok := c.Get("user_email"); ok {
+		if s, ok := email.(string); ok && s != "" {
+			return s
+		}
+	}
+	return "system"
+}
+
+func statusForTicketError(err error) int {
+	switch {
+	case errors.Is(err, service.ErrTicketWebhookUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, service.ErrTicketIntegrationNotFound), errors.Is(err, service.ErrFindingNotFound),
+		errors.Is(err, service.ErrRemediationRequestNotFound), errors.Is(err, service.ErrTrackerTicketNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrTicketIntegrationExists), errors.Is(err, service.ErrTicketAlreadyLinked):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrInvalidTicketIntegration), errors.Is(err, service.ErrInvalidWebhookPayload):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrTrackerRequestFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
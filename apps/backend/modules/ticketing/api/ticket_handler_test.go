@@ -0,0 +1,35 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/ticketing/service"
+)
+
+func TestStatusForTicketError(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{service.ErrTicketWebhookUnauthorized, http.StatusUnauthorized},
+		{service.ErrTicketIntegrationNotFound, http.StatusNotFound},
+		{service.ErrFindingNotFound, http.StatusNotFound},
+		{service.ErrRemediationRequestNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: ServiceNow incident INC0001", service.ErrTrackerTicketNotFound), http.StatusNotFound},
+		{fmt.Errorf("%w: %q", service.ErrTicketIntegrationExists, "Jira"), http.StatusConflict},
+		{fmt.Errorf("%w: SEC-1", service.ErrTicketAlreadyLinked), http.StatusConflict},
+		{fmt.Errorf("%w: name must not be empty", service.ErrInvalidTicketIntegration), http.StatusBadRequest},
+		{fmt.Errorf("%w: no issue key", service.ErrInvalidWebhookPayload), http.StatusBadRequest},
+		{fmt.Errorf("failed to create Jira issue: %w", fmt.Errorf("%w: tracker returned 500", service.ErrTrackerRequestFailed)), http.StatusBadGateway},
+		// Messages alone no longer decide the status
+		{errors.New("failed to decrypt API token: cipher: message authentication failed"), http.StatusInternalServerError},
+		{errors.New(`pq: relation "ticket_links" does not exist: not found`), http.StatusInternalServerError},
+	} {
+		if got := statusForTicketError(tc.err); got != tc.want {
+			t.Errorf("statusForTicketError(%v) = %d, want %d", tc.err, got, tc.want)
+		}
+	}
+}
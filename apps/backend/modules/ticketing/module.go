@@ -0,0 +1,106 @@
+package ticketing
+
+import (
+	"context"
+	"log"
+
+	"github.com/arc-platform/backend/modules/auth/middleware"
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/arc-platform/backend/modules/ticketing/api"
+	"github.com/arc-platform/backend/modules/ticketing/service"
+	"github.com/gin-gonic/gin"
+)
+
+// TicketingModule files Jira and ServiceNow tickets for findings and remediation
+// requests and keeps their status in sync with ARC in both directions
+type TicketingModule struct {
+	ticketService *service.TicketService
+	ticketHandler *api.TicketHandler
+
+	// Integrations are configured by admins
+	authMiddleware *middleware.AuthMiddleware
+
+	deps         *interfaces.ModuleDependencies
+	cancelWorker context.CancelFunc
+}
+
+// NewTicketingModule creates a new ticketing module
+func NewTicketingModule() *TicketingModule {
+	return &TicketingModule{}
+}
+
+// Name returns the module name
+func (m *TicketingModule) Name() string {
+	return "ticketing"
+}
+
+// Initialize sets up the module and starts the ticket sync worker when enabled
+func (m *TicketingModule) Initialize(deps *interfaces.ModuleDependencies) error {
+	m.deps = deps
+	log.Println("🎫 Initializing Ticketing Module...")
+
+	var cfg config.TicketingConfig
+	var dashboardURL string
+	if deps.Config != nil {
+		cfg = deps.Config.Ticketing
+		dashboardURL = deps.Config.Alerting.DashboardURL
+	}
+
+	// Tracker credentials are encrypted at rest; without a key no integration can be configured
+	encryptionService, err := encryption.NewEncryptionService()
+	if err != nil {
+		log.Printf("WARN: Ticket integrations unavailable: %v", err)
+		encryptionService = nil
+	}
+
+	repo := persistence.NewPostgresRepository(deps.DB)
+	m.ticketService = service.NewTicketService(repo, encryptionService, deps.AuditLogger, cfg, dashboardURL)
+	m.ticketHandler = api.NewTicketHandler(m.ticketService)
+	m.authMiddleware = middleware.NewAuthMiddleware(repo)
+
+	if cfg.SyncEnabled {
+		var workerCtx context.Context
+		workerCtx, m.cancelWorker = context.WithCancel(context.Background())
+		go m.ticketService.StartSyncWorker(workerCtx, cfg.SyncIntervalMinutes)
+	}
+
+	log.Println("✅ Ticketing Module initialized")
+	return nil
+}
+
+// RegisterRoutes registers the module's routes
+func (m *TicketingModule) RegisterRoutes(router *gin.RouterGroup) {
+	admin := m.authMiddleware.RequireRole("admin")
+
+	ticketing := router.Group("/ticketing")
+	{
+		ticketing.GET("/integrations", m.ticketHandler.ListIntegrations)
+		ticketing.POST("/integrations", admin, m.ticketHandler.CreateIntegration)
+		ticketing.GET("/integrations/:id", m.ticketHandler.GetIntegration)
+		ticketing.PUT("/integrations/:id", admin, m.ticketHandler.UpdateIntegration)
+		ticketing.DELETE("/integrations/:id", admin, m.ticketHandler.DeleteIntegration)
+		ticketing.POST("/sync", admin, m.ticketHandler.Sync)
+
+		// Public: trackers authenticate with the integration's webhook secret
+		ticketing.POST("/webhooks/:integration_id", m.ticketHandler.Webhook)
+	}
+
+	router.GET("/findings/:id/tickets", m.ticketHandler.ListFindingTickets)
+	router.POST("/findings/:id/tickets", m.ticketHandler.CreateFindingTicket)
+	router.GET("/remediation/approvals/:id/tickets", m.ticketHandler.ListRemediationRequestTickets)
+	router.POST("/remediation/approvals/:id/tickets", m.ticketHandler.CreateRemediationRequestTicket)
+
+	log.Printf("🎫 Ticketing routes registered")
+}
+
+// Shutdown stops the ticket sync worker
+func (m *TicketingModule) Shutdown() error {
+	log.Printf("🔌 Shutting down Ticketing Module...")
+	if m.cancelWorker != nil {
+		m.cancelWorker()
+	}
+	return nil
+}
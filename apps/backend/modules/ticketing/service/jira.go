@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// jiraTracker files issues through the Jira REST API v2, which Jira Cloud and
+// Data Center both serve
+type jiraTracker struct {
+	trackerClient
+	integration *entity.TicketIntegration
+}
+
+type jiraIssue struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Fields struct {
+		Status struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+	} `json:"fields"`
+}
+
+func (t *jiraTracker) Create(ctx context.Context, draft TicketDraft) (*TrackerTicket, error) {
+	issueType := draft.Target.Type
+	if issueType == "" {
+		issueType = "Task"
+	}
+	body := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": draft.Target.Queue},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     draft.Summary,
+			"description": draft.Description,
+			"labels":      []string{"arc-hawk"},
+		},
+	}
+
+	var created jiraIssue
+	if err := t.do(ctx, http.MethodPost, "/rest/api/2/issue", body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create Jira issue: %w", err)
+	}
+	if created.Key == "" {
+		return nil, fmt.Errorf("failed to create Jira issue: no key returned")
+	}
+
+	// New issues start in the workflow's initial status, which the create response omits
+	ticket, err := t.Get(ctx, created.Key)
+	if err != nil {
+		return &TrackerTicket{Key: created.Key, ExternalID: created.Key, Type: issueType, URL: t.browseURL(created.Key)}, nil
+	}
+	return ticket, nil
+}
+
+func (t *jiraTracker) Get(ctx context.Context, key string) (*TrackerTicket, error) {
+	var issue jiraIssue
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "?fields=status,issuetype"
+	if err := t.do(ctx, http.MethodGet, path, nil, &issue); err != nil {
+		return nil, fmt.Errorf("failed to get Jira issue %s: %w", key, err)
+	}
+	return t.ticket(&issue), nil
+}
+
+func (t *jiraTracker) Comment(ctx context.Context, link *entity.TicketLink, body string) error {
+	path := "/rest/api/2/issue/" + url.PathEscape(link.ExternalID) + "/comment"
+	if err := t.do(ctx, http.MethodPost, path, map[string]string{"body": body}, nil); err != nil {
+		return fmt.Errorf("failed to comment on Jira issue %s: %w", link.TicketKey, err)
+	}
+	return nil
+}
+
+// Resolve applies the first available transition into one of the resolved statuses
+func (t *jiraTracker) Resolve(ctx context.Context, link *entity.TicketLink, comment string) (string, error) {
+	var available struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(link.ExternalID) + "/transitions"
+	if err := t.do(ctx, http.MethodGet, path, nil, &available); err != nil {
+		return "", fmt.Errorf("failed to list transitions of Jira issue %s: %w", link.TicketKey, err)
+	}
+
+	for _, tr := range available.Transitions {
+		if !t.integration.IsResolvedStatus(tr.To.Name) {
+			continue
+		}
+		body := map[string]interface{}{
+			"transition": map[string]string{"id": tr.ID},
+			"update": map[string]interface{}{
+				"comment": []map[string]interface{}{{"add": map[string]string{"body": comment}}},
+			},
+		}
+		if err := t.do(ctx, http.MethodPost, path, body, nil); err != nil {
+			return "", fmt.Errorf("failed to transition Jira issue %s: %w", link.TicketKey, err)
+		}
+		return tr.To.Name, nil
+	}
+	return "", fmt.Errorf("Jira issue %s has no transition to a resolved status (%s)", link.TicketKey, strings.Join(t.integration.ResolvedStatuses, ", "))
+}
+
+// ParseWebhook reads a Jira "issue updated" webhook
+func (t *jiraTracker) ParseWebhook(body []byte) (*TrackerTicket, error) {
+	var payload struct {
+		Issue *jiraIssue `json:"issue"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+	}
+	if payload.Issue == nil || payload.Issue.Key == "" {
+		return nil, fmt.Errorf("%w: no issue key", ErrInvalidWebhookPayload)
+	}
+	return t.ticket(payload.Issue), nil
+}
+
+func (t *jiraTracker) ticket(issue *jiraIssue) *TrackerTicket {
+	return &TrackerTicket{
+		Key:        issue.Key,
+		ExternalID: issue.Key,
+		Type:       issue.Fields.IssueType.Name,
+		URL:        t.browseURL(issue.Key),
+		Status:     issue.Fields.Status.Name,
+	}
+}
+
+func (t *jiraTracker) browseURL(key string) string {
+	return t.baseURL + "/browse/" + url.PathEscape(key)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+// serviceNowTracker files records through the ServiceNow Table API. States are
+// the table's numeric state values, e.g. "6" (Resolved) on incidents.
+type serviceNowTracker struct {
+	trackerClient
+	integration *entity.TicketIntegration
+}
+
+type serviceNowRecord struct {
+	SysID  string `json:"sys_id"`
+	Number string `json:"number"`
+	State  string `json:"state"`
+}
+
+func (t *serviceNowTracker) Create(ctx context.Context, draft TicketDraft) (*TrackerTicket, error) {
+	table := tableOrDefault(draft.Target.Type)
+	body := map[string]string{
+		"short_description": draft.Summary,
+		"description":       draft.Description,
+		"correlation_id":    draft.Reference,
+	}
+	if draft.Target.Queue != "" {
+		body["assignment_group"] = draft.Target.Queue
+	}
+
+	var created struct {
+		Result serviceNowRecord `json:"result"`
+	}
+	if err := t.do(ctx, http.MethodPost, "/api/now/table/"+url.PathEscape(table), body, &created); err != nil {
+		return nil, fmt.Errorf("failed to create ServiceNow %s: %w", table, err)
+	}
+	if created.Result.SysID == "" {
+		return nil, fmt.Errorf("failed to create ServiceNow %s: no sys_id returned", table)
+	}
+	return t.ticket(table, &created.Result), nil
+}
+
+func (t *serviceNowTracker) Get(ctx context.Context, key string) (*TrackerTicket, error) {
+	table := tableOrDefault(t.integration.QueueMapping.Default.Type)
+	query := url.Values{
+		"sysparm_query":  {"number=" + key},
+		"sysparm_fields": {"sys_id,number,state"},
+		"sysparm_limit":  {"1"},
+	}
+
+	var found struct {
+		Result []serviceNowRecord `json:"result"`
+	}
+	if err := t.do(ctx, http.MethodGet, "/api/now/table/"+url.PathEscape(table)+"?"+query.Encode(), nil, &found); err != nil {
+		return nil, fmt.Errorf("failed to get ServiceNow %s %s: %w", table, key, err)
+	}
+	if len(found.Result) == 0 {
+		return nil, fmt.Errorf("%w: ServiceNow %s %s", ErrTrackerTicketNotFound, table, key)
+	}
+	return t.ticket(table, &found.Result[0]), nil
+}
+
+func (t *serviceNowTracker) Comment(ctx context.Context, link *entity.TicketLink, body string) error {
+	if err := t.patch(ctx, link, map[string]string{"work_notes": body}); err != nil {
+		return fmt.Errorf("failed to comment on ServiceNow %s: %w", link.TicketKey, err)
+	}
+	return nil
+}
+
+// Resolve sets the record's state to the first of the integration's resolved statuses
+func (t *serviceNowTracker) Resolve(ctx context.Context, link *entity.TicketLink, comment string) (string, error) {
+	state := firstResolvedStatus(t.integration.ResolvedStatuses)
+	if state == "" {
+		return "", fmt.Errorf("no resolved status is configured for ServiceNow %s", link.TicketKey)
+	}
+	body := map[string]string{
+		"state":       state,
+		"close_code":  "Solved (Permanently)",
+		"close_notes": comment,
+		"work_notes":  comment,
+	}
+	if err := t.patch(ctx, link, body); err != nil {
+		return "", fmt.Errorf("failed to resolve ServiceNow %s: %w", link.TicketKey, err)
+	}
+	return state, nil
+}
+
+// ParseWebhook reads the payload of the business rule that notifies ARC:
+// {"sys_id": "...", "number": "...", "state": "..."}
+func (t *serviceNowTracker) ParseWebhook(body []byte) (*TrackerTicket, error) {
+	var record serviceNowRecord
+	if err := json.Unmarshal(body, &record); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookPayload, err)
+	}
+	if record.SysID == "" && record.Number == "" {
+		return nil, fmt.Errorf("%w: no sys_id or number", ErrInvalidWebhookPayload)
+	}
+	return &TrackerTicket{Key: record.Number, ExternalID: record.SysID, Status: record.State}, nil
+}
+
+func (t *serviceNowTracker) patch(ctx context.Context, link *entity.TicketLink, body map[string]string) error {
+	path := "/api/now/table/" + url.PathEscape(tableOrDefault(link.TicketType)) + "/" + url.PathEscape(link.ExternalID)
+	return t.do(ctx, http.MethodPatch, path, body, nil)
+}
+
+func (t *serviceNowTracker) ticket(table string, record *serviceNowRecord) *TrackerTicket {
+	return &TrackerTicket{
+		Key:        record.Number,
+		ExternalID: record.SysID,
+		Type:       table,
+		URL:        t.baseURL + "/nav_to.do?uri=" + url.QueryEscape(table+".do?sys_id="+record.SysID),
+		Status:     record.State,
+	}
+}
+
+func tableOrDefault(table string) string {
+	if table == "" {
+		return "incident"
+	}
+	return table
+}
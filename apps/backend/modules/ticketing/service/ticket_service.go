@@ -0,0 +1,707 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/arc-platform/backend/modules/shared/config"
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/persistence"
+	"github.com/arc-platform/backend/modules/shared/interfaces"
+	"github.com/google/uuid"
+)
+
+// ErrTicketSecretUnavailable is returned when credentials are supplied but cannot be encrypted or decrypted
+var ErrTicketSecretUnavailable = errors.New("ticket integration credentials cannot be stored: ENCRYPTION_KEY is not configured")
+
+// ErrTicketWebhookUnauthorized is returned for inbound webhooks without a valid signature or token
+var ErrTicketWebhookUnauthorized = errors.New("invalid webhook signature")
+
+var (
+	ErrTicketIntegrationNotFound  = persistence.ErrTicketIntegrationNotFound
+	ErrTicketIntegrationExists    = persistence.ErrTicketIntegrationExists
+	ErrTicketAlreadyLinked        = persistence.ErrTicketAlreadyLinked
+	ErrFindingNotFound            = persistence.ErrFindingNotFound
+	ErrRemediationRequestNotFound = errors.New("remediation request not found")
+	ErrInvalidTicketIntegration   = errors.New("invalid ticket integration")
+)
+
+// ticketSyncBatch bounds the links pushed to trackers per tenant and run
+const ticketSyncBatch = 200
+
+// ticketingActor is recorded as the reviewer of findings resolved from a tracker
+const ticketingActor = "ticketing"
+
+var ticketSeverities = map[string]bool{"critical": true, "high": true, "medium": true, "low": true}
+
+// Default resolved statuses: Jira status names; ServiceNow incident states 6 (Resolved) and 7 (Closed)
+var defaultResolvedStatuses = map[string][]string{
+	entity.TicketProviderJira:       {"Done", "Resolved", "Closed"},
+	entity.TicketProviderServiceNow: {"6", "7"},
+}
+
+// TicketIntegrationInput is the payload for creating or replacing an integration
+type TicketIntegrationInput struct {
+	Provider         string                    `json:"provider"` // jira or servicenow; cannot change on update
+	Name             string                    `json:"name"`
+	BaseURL          string                    `json:"base_url"`
+	Username         string                    `json:"username"`
+	APIToken         string                    `json:"api_token"`      // Kept on update when omitted and the base URL is unchanged
+	WebhookSecret    string                    `json:"webhook_secret"` // Kept on update when omitted
+	QueueMapping     entity.TicketQueueMapping `json:"queue_mapping"`
+	ResolvedStatuses []string                  `json:"resolved_statuses"` // Defaults per provider
+	Enabled          *bool                     `json:"enabled,omitempty"` // Defaults to true
+}
+
+// TicketInput files a ticket for a finding or remediation request, or links an existing one
+type TicketInput struct {
+	IntegrationID uuid.UUID `json:"integration_id"`
+	TicketKey     string    `json:"ticket_key"` // Links this existing ticket instead of filing a new one
+	Summary       string    `json:"summary"`    // Overrides the generated summary
+}
+
+// WebhookResult reports what an inbound tracker webhook changed
+type WebhookResult struct {
+	TicketKey        string `json:"ticket_key"`
+	Status           string `json:"status"`
+	Links            int    `json:"links"`
+	FindingsResolved int    `json:"findings_resolved"`
+}
+
+// TicketSyncResult reports a sync run for one tenant
+type TicketSyncResult struct {
+	Candidates int `json:"candidates"`
+	Commented  int `json:"commented"`
+	Resolved   int `json:"resolved"`
+	Failed     int `json:"failed"`
+}
+
+// TicketService manages issue tracker integrations, files tickets for findings and
+// remediation requests, and keeps ticket and ARC statuses in sync
+type TicketService struct {
+	repo         *persistence.PostgresRepository
+	encryption   *encryption.EncryptionService // nil when ENCRYPTION_KEY is unset; integrations cannot be used then
+	auditLogger  interfaces.AuditLogger
+	client       *http.Client
+	dashboardURL string
+}
+
+// NewTicketService creates a new ticket service
+func NewTicketService(repo *persistence.PostgresRepository, enc *encryption.EncryptionService, auditLogger interfaces.AuditLogger, cfg config.TicketingConfig, dashboardURL string) *TicketService {
+	timeout := time.Duration(cfg.HTTPTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &TicketService{
+		repo:         repo,
+		encryption:   enc,
+		auditLogger:  auditLogger,
+		client:       &http.Client{Timeout: timeout},
+		dashboardURL: dashboardURL,
+	}
+}
+
+// CreateIntegration validates and stores a new integration
+func (s *TicketService) CreateIntegration(ctx context.Context, input TicketIntegrationInput, createdBy string) (*entity.TicketIntegration, error) {
+	integration := &entity.TicketIntegration{ID: uuid.New(), Enabled: true, CreatedBy: createdBy}
+	if err := s.applyIntegrationInput(integration, input); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateTicketIntegration(ctx, integration); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "TICKET_INTEGRATION_CREATED", "ticket_integration", integration.ID.String(), map[string]interface{}{
+		"provider": integration.Provider,
+		"name":     integration.Name,
+	})
+	return integration, nil
+}
+
+// GetIntegration returns an integration
+func (s *TicketService) GetIntegration(ctx context.Context, id uuid.UUID) (*entity.TicketIntegration, error) {
+	return s.repo.GetTicketIntegration(ctx, id)
+}
+
+// ListIntegrations returns the tenant's integrations
+func (s *TicketService) ListIntegrations(ctx context.Context) ([]*entity.TicketIntegration, error) {
+	return s.repo.ListTicketIntegrations(ctx)
+}
+
+// UpdateIntegration replaces an integration's settings
+func (s *TicketService) UpdateIntegration(ctx context.Context, id uuid.UUID, input TicketIntegrationInput) (*entity.TicketIntegration, error) {
+	integration, err := s.repo.GetTicketIntegration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyIntegrationInput(integration, input); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateTicketIntegration(ctx, integration); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "TICKET_INTEGRATION_UPDATED", "ticket_integration", integration.ID.String(), map[string]interface{}{
+		"provider": integration.Provider,
+		"name":     integration.Name,
+		"enabled":  integration.Enabled,
+	})
+	return s.repo.GetTicketIntegration(ctx, id)
+}
+
+// DeleteIntegration deletes an integration and its ticket links
+func (s *TicketService) DeleteIntegration(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.DeleteTicketIntegration(ctx, id); err != nil {
+		return err
+	}
+	s.audit(ctx, "TICKET_INTEGRATION_DELETED", "ticket_integration", id.String(), nil)
+	return nil
+}
+
+// ListFindingTickets returns the tickets linked to a finding
+func (s *TicketService) ListFindingTickets(ctx context.Context, findingID uuid.UUID) ([]*entity.TicketLink, error) {
+	return s.repo.ListTicketLinks(ctx, &findingID, nil)
+}
+
+// ListRemediationRequestTickets returns the tickets linked to a remediation request
+func (s *TicketService) ListRemediationRequestTickets(ctx context.Context, requestID uuid.UUID) ([]*entity.TicketLink, error) {
+	return s.repo.ListTicketLinks(ctx, nil, &requestID)
+}
+
+// CreateFindingTicket files a ticket for a finding in the project or queue its
+// severity maps to, or links an existing ticket
+func (s *TicketService) CreateFindingTicket(ctx context.Context, findingID uuid.UUID, input TicketInput, createdBy string) (*entity.TicketLink, error) {
+	finding, err := s.repo.GetFindingByID(ctx, findingID)
+	if err != nil {
+		return nil, err
+	}
+	status := "open"
+	if state, err := s.repo.GetReviewStateByFindingID(ctx, findingID); err != nil {
+		return nil, fmt.Errorf("failed to check review state: %w", err)
+	} else if state != nil && state.Status != "" {
+		status = state.Status
+	}
+
+	assetName := finding.AssetID.String()
+	if asset, err := s.repo.GetAssetByID(ctx, finding.AssetID); err == nil && asset != nil {
+		assetName = asset.Name
+		if asset.Path != "" && asset.Path != asset.Name {
+			assetName += " (" + asset.Path + ")"
+		}
+	}
+
+	link := &entity.TicketLink{FindingID: &finding.ID, LastSyncedStatus: status}
+	draft := TicketDraft{
+		Summary:     fmt.Sprintf("[ARC-Hawk] %s %s finding in %s", finding.Severity, finding.PatternName, assetName),
+		Description: s.findingDescription(finding, assetName),
+		Reference:   "arc-finding:" + finding.ID.String(),
+	}
+	return s.fileTicket(ctx, link, finding.Severity, draft, input, createdBy)
+}
+
+// CreateRemediationRequestTicket files a ticket for a remediation request in the
+// integration's default project or queue, or links an existing ticket
+func (s *TicketService) CreateRemediationRequestTicket(ctx context.Context, requestID uuid.UUID, input TicketInput, createdBy string) (*entity.TicketLink, error) {
+	req, err := s.repo.GetRemediationRequest(ctx, requestID.String())
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, ErrRemediationRequestNotFound
+	}
+
+	link := &entity.TicketLink{RemediationRequestID: &requestID, LastSyncedStatus: req.Status}
+	draft := TicketDraft{
+		Summary:     fmt.Sprintf("[ARC-Hawk] Remediation %s of %d finding(s)", req.ActionType, len(req.FindingIDs)),
+		Description: s.remediationDescription(req),
+		Reference:   "arc-remediation:" + req.ID,
+	}
+	return s.fileTicket(ctx, link, "", draft, input, createdBy)
+}
+
+func (s *TicketService) fileTicket(ctx context.Context, link *entity.TicketLink, severity string, draft TicketDraft, input TicketInput, createdBy string) (*entity.TicketLink, error) {
+	integration, err := s.repo.GetTicketIntegration(ctx, input.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+	if !integration.Enabled {
+		return nil, fmt.Errorf("%w: %s is disabled", ErrInvalidTicketIntegration, integration.Name)
+	}
+	t, err := s.tracker(integration)
+	if err != nil {
+		return nil, err
+	}
+
+	var ticket *TrackerTicket
+	action := "TICKET_CREATED"
+	if key := strings.TrimSpace(input.TicketKey); key != "" {
+		action = "TICKET_LINKED"
+		ticket, err = t.Get(ctx, key)
+	} else {
+		draft.Target = integration.QueueMapping.TargetFor(severity)
+		if summary := strings.TrimSpace(input.Summary); summary != "" {
+			draft.Summary = summary
+		}
+		ticket, err = t.Create(ctx, draft)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	link.ID = uuid.New()
+	link.IntegrationID = integration.ID
+	link.Provider = integration.Provider
+	link.TicketKey = ticket.Key
+	link.ExternalID = ticket.ExternalID
+	link.TicketType = ticket.Type
+	link.URL = ticket.URL
+	link.TrackerStatus = ticket.Status
+	link.Resolved = integration.IsResolvedStatus(ticket.Status)
+	link.CreatedBy = createdBy
+	if err := s.repo.CreateTicketLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, action, "ticket_link", link.ID.String(), map[string]interface{}{
+		"integration_id":         integration.ID,
+		"ticket_key":             link.TicketKey,
+		"finding_id":             link.FindingID,
+		"remediation_request_id": link.RemediationRequestID,
+	})
+	return link, nil
+}
+
+// HandleWebhook applies a tracker's status change to the tickets linked to it. The
+// request must carry either an X-Hub-Signature HMAC-SHA256 of the body or the
+// webhook secret itself as a token. A ticket moving to a resolved status resolves
+// the linked findings; reopening it only reopens the link.
+func (s *TicketService) HandleWebhook(ctx context.Context, integrationID uuid.UUID, body []byte, signature, token string) (*WebhookResult, error) {
+	integration, err := s.repo.GetTicketIntegrationForWebhook(ctx, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(integration.WebhookSecretEncrypted) == 0 || s.encryption == nil {
+		return nil, ErrTicketWebhookUnauthorized
+	}
+	var secret string
+	if err := s.encryption.Decrypt(integration.WebhookSecretEncrypted, &secret); err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+	if !verifyTicketWebhook(secret, body, signature, token) {
+		return nil, ErrTicketWebhookUnauthorized
+	}
+	if !integration.Enabled {
+		return &WebhookResult{}, nil
+	}
+
+	ctx = context.WithValue(ctx, "tenant_id", integration.TenantID)
+	t, err := newTracker(integration, "", s.client)
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := t.ParseWebhook(body)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.repo.ListTicketLinksByTicket(ctx, integration.ID, ticket.ExternalID, ticket.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WebhookResult{TicketKey: ticket.Key, Status: ticket.Status, Links: len(links)}
+	resolved := integration.IsResolvedStatus(ticket.Status)
+	for _, link := range links {
+		if link.TrackerStatus == ticket.Status && link.Resolved == resolved {
+			continue
+		}
+
+		synced := link.LastSyncedStatus
+		if resolved && !link.Resolved && link.FindingID != nil {
+			comment := fmt.Sprintf("Resolved in %s %s (%s)", integration.Name, link.TicketKey, ticket.Status)
+			changed, err := s.resolveFinding(ctx, *link.FindingID, comment)
+			if err != nil {
+				log.Printf("⚠️  Failed to resolve finding %s from ticket %s: %v", link.FindingID, link.TicketKey, err)
+				continue
+			}
+			if changed {
+				result.FindingsResolved++
+			}
+			synced = entity.ReviewStatusResolved // The tracker already knows; nothing to push back
+		}
+
+		if err := s.repo.UpdateTicketLinkStatus(ctx, link.ID, ticket.Status, resolved, synced); err != nil {
+			return nil, err
+		}
+	}
+
+	if result.FindingsResolved > 0 {
+		s.audit(ctx, "TICKET_FINDINGS_RESOLVED", "ticket_integration", integration.ID.String(), map[string]interface{}{
+			"ticket_key":        ticket.Key,
+			"status":            ticket.Status,
+			"findings_resolved": result.FindingsResolved,
+		})
+	}
+	return result, nil
+}
+
+// resolveFinding sets a finding's review status to resolved unless it is already
+// closed. A reviewer saving the finding meanwhile wins; the update is retried on
+// their version.
+func (s *TicketService) resolveFinding(ctx context.Context, findingID uuid.UUID, comment string) (bool, error) {
+	for attempt := 1; ; attempt++ {
+		changed, err := s.saveResolvedFinding(ctx, findingID, comment)
+		if !errors.Is(err, persistence.ErrReviewStateConflict) || attempt == 3 {
+			return changed, err
+		}
+	}
+}
+
+func (s *TicketService) saveResolvedFinding(ctx context.Context, findingID uuid.UUID, comment string) (bool, error) {
+	now := time.Now()
+
+	existing, err := s.repo.GetReviewStateByFindingID(ctx, findingID)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		if isClosedReviewStatus(existing.Status) {
+			return false, nil
+		}
+		existing.Status = entity.ReviewStatusResolved
+		existing.ReviewedBy = ticketingActor
+		existing.ReviewedAt = &now
+		existing.Comments = comment
+		return true, s.repo.UpdateReviewState(ctx, existing)
+	}
+
+	return true, s.repo.CreateFirstReviewState(ctx, &entity.ReviewState{
+		ID:         uuid.New(),
+		FindingID:  findingID,
+		Status:     entity.ReviewStatusResolved,
+		ReviewedBy: ticketingActor,
+		ReviewedAt: &now,
+		Comments:   comment,
+	})
+}
+
+// Sync pushes ARC status changes to the tenant's linked tickets: closed findings
+// and executed remediation requests resolve their tickets, other changes are
+// added as comments. Links that fail are retried at the next run.
+func (s *TicketService) Sync(ctx context.Context) (*TicketSyncResult, error) {
+	candidates, err := s.repo.ListTicketSyncCandidates(ctx, ticketSyncBatch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TicketSyncResult{Candidates: len(candidates)}
+	trackers := make(map[uuid.UUID]tracker)
+	for _, c := range candidates {
+		t, ok := trackers[c.Link.IntegrationID]
+		if !ok {
+			integration, err := s.repo.GetTicketIntegration(ctx, c.Link.IntegrationID)
+			if err == nil {
+				t, err = s.tracker(integration)
+			}
+			if err != nil {
+				log.Printf("⚠️  Ticket integration %s unavailable: %v", c.Link.IntegrationID, err)
+				t = nil
+			}
+			trackers[c.Link.IntegrationID] = t
+		}
+		if t == nil {
+			result.Failed++
+			continue
+		}
+
+		if err := s.syncLink(ctx, t, c.Link, c.ARCStatus); err != nil {
+			log.Printf("⚠️  Failed to sync ticket %s: %v", c.Link.TicketKey, err)
+			result.Failed++
+			continue
+		}
+		if arcStatusResolves(c.Link, c.ARCStatus) {
+			result.Resolved++
+		} else {
+			result.Commented++
+		}
+	}
+	return result, nil
+}
+
+func (s *TicketService) syncLink(ctx context.Context, t tracker, link *entity.TicketLink, arcStatus string) error {
+	subject := "finding"
+	if link.RemediationRequestID != nil {
+		subject = "remediation request"
+	}
+	comment := fmt.Sprintf("ARC-Hawk %s status changed to %q.", subject, arcStatus)
+
+	if arcStatusResolves(link, arcStatus) {
+		status, err := t.Resolve(ctx, link, comment)
+		if err != nil {
+			return err
+		}
+		return s.repo.UpdateTicketLinkStatus(ctx, link.ID, status, true, arcStatus)
+	}
+
+	if err := t.Comment(ctx, link, comment); err != nil {
+		return err
+	}
+	return s.repo.UpdateTicketLinkStatus(ctx, link.ID, link.TrackerStatus, link.Resolved, arcStatus)
+}
+
+// StartSyncWorker pushes ARC status changes to linked tickets every interval, for
+// every tenant with an enabled integration
+func (s *TicketService) StartSyncWorker(ctx context.Context, intervalMinutes int) {
+	if intervalMinutes <= 0 {
+		intervalMinutes = 5
+	}
+	ticker := time.NewTicker(time.Duration(intervalMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	log.Printf("🎫 Ticket sync worker started (every %d minutes)", intervalMinutes)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🛑 Ticket sync worker stopped")
+			return
+		case <-ticker.C:
+			s.syncAllTenants(ctx)
+		}
+	}
+}
+
+func (s *TicketService) syncAllTenants(ctx context.Context) {
+	tenantIDs, err := s.repo.ListTicketingTenantIDs(ctx)
+	if err != nil {
+		log.Printf("❌ Ticket sync failed to list tenants: %v", err)
+		return
+	}
+	for _, tenantID := range tenantIDs {
+		tenantCtx := context.WithValue(ctx, "tenant_id", tenantID)
+		result, err := s.Sync(tenantCtx)
+		if err != nil {
+			log.Printf("❌ Ticket sync failed for tenant %s: %v", tenantID, err)
+			continue
+		}
+		if result.Candidates > 0 {
+			log.Printf("🎫 Ticket sync for tenant %s: %d commented, %d resolved, %d failed",
+				tenantID, result.Commented, result.Resolved, result.Failed)
+		}
+	}
+}
+
+// tracker returns the client for an integration with its API token decrypted
+func (s *TicketService) tracker(integration *entity.TicketIntegration) (tracker, error) {
+	var token string
+	if len(integration.APITokenEncrypted) > 0 {
+		if s.encryption == nil {
+			return nil, ErrTicketSecretUnavailable
+		}
+		if err := s.encryption.Decrypt(integration.APITokenEncrypted, &token); err != nil {
+			return nil, fmt.Errorf("failed to decrypt API token: %w", err)
+		}
+	}
+	return newTracker(integration, token, s.client)
+}
+
+func (s *TicketService) applyIntegrationInput(integration *entity.TicketIntegration, input TicketIntegrationInput) error {
+	provider := strings.ToLower(strings.TrimSpace(input.Provider))
+	switch {
+	case integration.Provider == "":
+		if _, ok := defaultResolvedStatuses[provider]; !ok {
+			return fmt.Errorf("%w: provider %q must be jira or servicenow", ErrInvalidTicketIntegration, input.Provider)
+		}
+		integration.Provider = provider
+	case provider != "" && provider != integration.Provider:
+		return fmt.Errorf("%w: provider must not change; create a new integration instead", ErrInvalidTicketIntegration)
+	}
+
+	integration.Name = strings.TrimSpace(input.Name)
+	if integration.Name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidTicketIntegration)
+	}
+
+	previousURL := integration.BaseURL
+	u, err := url.Parse(strings.TrimSpace(input.BaseURL))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("%w: base url %q must be an absolute http(s) URL", ErrInvalidTicketIntegration, input.BaseURL)
+	}
+	integration.BaseURL = strings.TrimRight(u.String(), "/")
+	integration.Username = strings.TrimSpace(input.Username)
+
+	// Credentials are not carried over to a different host
+	switch {
+	case input.APIToken != "":
+		encrypted, err := s.encrypt(input.APIToken)
+		if err != nil {
+			return err
+		}
+		integration.APITokenEncrypted = encrypted
+	case integration.BaseURL != previousURL:
+		if previousURL != "" {
+			return fmt.Errorf("%w: api_token must be provided when the base url changes", ErrInvalidTicketIntegration)
+		}
+		return fmt.Errorf("%w: api_token must not be empty", ErrInvalidTicketIntegration)
+	}
+	if input.WebhookSecret != "" {
+		encrypted, err := s.encrypt(input.WebhookSecret)
+		if err != nil {
+			return err
+		}
+		integration.WebhookSecretEncrypted = encrypted
+	}
+	integration.HasAPIToken = len(integration.APITokenEncrypted) > 0
+	integration.HasWebhookSecret = len(integration.WebhookSecretEncrypted) > 0
+
+	mapping, err := normalizeQueueMapping(integration.Provider, input.QueueMapping)
+	if err != nil {
+		return err
+	}
+	integration.QueueMapping = mapping
+
+	integration.ResolvedStatuses = nil
+	for _, status := range input.ResolvedStatuses {
+		if status = strings.TrimSpace(status); status != "" {
+			integration.ResolvedStatuses = append(integration.ResolvedStatuses, status)
+		}
+	}
+	if len(integration.ResolvedStatuses) == 0 {
+		integration.ResolvedStatuses = defaultResolvedStatuses[integration.Provider]
+	}
+
+	if input.Enabled != nil {
+		integration.Enabled = *input.Enabled
+	}
+	return nil
+}
+
+func (s *TicketService) encrypt(secret string) ([]byte, error) {
+	if s.encryption == nil {
+		return nil, ErrTicketSecretUnavailable
+	}
+	encrypted, err := s.encryption.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	return encrypted, nil
+}
+
+// normalizeQueueMapping lowercases severities and checks every target names a
+// queue; Jira also needs a project key for each
+func normalizeQueueMapping(provider string, m entity.TicketQueueMapping) (entity.TicketQueueMapping, error) {
+	trim := func(t entity.TicketTarget) entity.TicketTarget {
+		return entity.TicketTarget{Queue: strings.TrimSpace(t.Queue), Type: strings.TrimSpace(t.Type)}
+	}
+
+	result := entity.TicketQueueMapping{Default: trim(m.Default)}
+	if provider == entity.TicketProviderJira && result.Default.Queue == "" {
+		return result, fmt.Errorf("%w: queue_mapping.default.queue must be a Jira project key", ErrInvalidTicketIntegration)
+	}
+	for severity, target := range m.BySeverity {
+		key := strings.ToLower(strings.TrimSpace(severity))
+		if !ticketSeverities[key] {
+			return result, fmt.Errorf("%w: severity %q in queue_mapping must be critical, high, medium or low", ErrInvalidTicketIntegration, severity)
+		}
+		target = trim(target)
+		if provider == entity.TicketProviderJira && target.Queue == "" {
+			return result, fmt.Errorf("%w: queue_mapping.by_severity.%s.queue must be a Jira project key", ErrInvalidTicketIntegration, key)
+		}
+		if result.BySeverity == nil {
+			result.BySeverity = make(map[string]entity.TicketTarget)
+		}
+		result.BySeverity[key] = target
+	}
+	return result, nil
+}
+
+func (s *TicketService) findingDescription(f *entity.Finding, assetName string) string {
+	// Matches and sample text are PII and stay in ARC-Hawk
+	var b strings.Builder
+	fmt.Fprintf(&b, "ARC-Hawk found %s data in %s.\n\n", f.PatternName, assetName)
+	fmt.Fprintf(&b, "Severity: %s\n", f.Severity)
+	if f.SeverityDescription != "" {
+		fmt.Fprintf(&b, "Why: %s\n", f.SeverityDescription)
+	}
+	fmt.Fprintf(&b, "Matches: %d\n", f.TotalMatches)
+	if f.ConfidenceScore != nil {
+		fmt.Fprintf(&b, "Confidence: %.0f%%\n", *f.ConfidenceScore*100)
+	}
+	if f.Environment != "" {
+		fmt.Fprintf(&b, "Environment: %s\n", f.Environment)
+	}
+	fmt.Fprintf(&b, "Finding: %s\n", f.ID)
+	if s.dashboardURL != "" {
+		fmt.Fprintf(&b, "\nReview it at %s/findings/%s\n", strings.TrimRight(s.dashboardURL, "/"), f.ID)
+	}
+	return b.String()
+}
+
+func (s *TicketService) remediationDescription(req *entity.RemediationApprovalRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Remediation %s requested by %s for %d finding(s).\n\n", req.ActionType, req.RequestedBy, len(req.FindingIDs))
+	fmt.Fprintf(&b, "Status: %s\n", req.Status)
+	if req.Justification != "" {
+		fmt.Fprintf(&b, "Justification: %s\n", req.Justification)
+	}
+	fmt.Fprintf(&b, "Request: %s\n", req.ID)
+	fmt.Fprintf(&b, "Findings:\n")
+	for _, id := range req.FindingIDs {
+		fmt.Fprintf(&b, "- %s\n", id)
+	}
+	if s.dashboardURL != "" {
+		fmt.Fprintf(&b, "\nReview it at %s/remediation/approvals/%s\n", strings.TrimRight(s.dashboardURL, "/"), req.ID)
+	}
+	return b.String()
+}
+
+func (s *TicketService) audit(ctx context.Context, action, resourceType, resourceID string, metadata map[string]interface{}) {
+	if s.auditLogger == nil {
+		return
+	}
+	if err := s.auditLogger.Record(ctx, action, resourceType, resourceID, metadata); err != nil {
+		log.Printf("WARN: Failed to record %s audit event: %v", action, err)
+	}
+}
+
+// arcStatusResolves reports whether an ARC status should resolve the linked ticket
+func arcStatusResolves(link *entity.TicketLink, arcStatus string) bool {
+	if link.RemediationRequestID != nil {
+		return arcStatus == entity.RemediationRequestExecuted
+	}
+	return isClosedReviewStatus(arcStatus)
+}
+
+func isClosedReviewStatus(status string) bool {
+	for _, closed := range entity.ClosedReviewStatuses {
+		if status == closed {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyTicketWebhook checks an "sha256=<hex>" HMAC of the body, as Jira sends in
+// X-Hub-Signature, or else a shared token, as a ServiceNow REST message can send
+func verifyTicketWebhook(secret string, body []byte, signature, token string) bool {
+	if secret == "" {
+		return false
+	}
+	if signature != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(strings.ToLower(strings.TrimSpace(signature))), []byte(expected))
+	}
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+}
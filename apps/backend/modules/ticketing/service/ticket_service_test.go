@@ -0,0 +1,123 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+	"github.com/arc-platform/backend/modules/shared/infrastructure/encryption"
+	"github.com/google/uuid"
+)
+
+func TestVerifyTicketWebhook(t *testing.T) {
+	body := []byte(`{"issue":{"key":"SEC-7"}}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !verifyTicketWebhook("s3cret", body, signature, "") {
+		t.Error("expected a valid signature to verify")
+	}
+	if verifyTicketWebhook("s3cret", []byte(`{"issue":{"key":"SEC-8"}}`), signature, "") {
+		t.Error("expected a signature of another body to fail")
+	}
+	if !verifyTicketWebhook("s3cret", body, "", "s3cret") || verifyTicketWebhook("s3cret", body, "", "guess") {
+		t.Error("expected only the shared token to verify")
+	}
+	if verifyTicketWebhook("", body, "", "") {
+		t.Error("expected an integration without a secret to reject webhooks")
+	}
+}
+
+func TestApplyIntegrationInput(t *testing.T) {
+	t.Setenv("ENCRYPTION_KEY", "0123456789abcdef0123456789abcdef")
+	enc, err := encryption.NewEncryptionService()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &TicketService{encryption: enc}
+
+	integration := &entity.TicketIntegration{ID: uuid.New(), Enabled: true}
+	err = s.applyIntegrationInput(integration, TicketIntegrationInput{
+		Provider: "Jira",
+		Name:     " Security Jira ",
+		BaseURL:  "https://acme.atlassian.net/",
+		APIToken: "token",
+		QueueMapping: entity.TicketQueueMapping{
+			Default:    entity.TicketTarget{Queue: "SEC"},
+			BySeverity: map[string]entity.TicketTarget{"Critical": {Queue: "INC", Type: "Incident"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integration.Provider != entity.TicketProviderJira || integration.Name != "Security Jira" || integration.BaseURL != "https://acme.atlassian.net" {
+		t.Errorf("unexpected integration %+v", integration)
+	}
+	if !integration.HasAPIToken || integration.HasWebhookSecret {
+		t.Errorf("expected only the API token to be stored, got %+v", integration)
+	}
+	if got := integration.QueueMapping.TargetFor("CRITICAL"); got.Queue != "INC" {
+		t.Errorf("expected critical findings to go to INC, got %+v", got)
+	}
+	if got := integration.QueueMapping.TargetFor("low"); got.Queue != "SEC" {
+		t.Errorf("expected other findings to go to SEC, got %+v", got)
+	}
+	if !integration.IsResolvedStatus("done") {
+		t.Errorf("expected default resolved statuses, got %v", integration.ResolvedStatuses)
+	}
+
+	// The token is kept on update while the host stays the same, but not carried to another host
+	update := TicketIntegrationInput{Name: "Security Jira", BaseURL: "https://acme.atlassian.net", QueueMapping: integration.QueueMapping}
+	if err := s.applyIntegrationInput(integration, update); err != nil || !integration.HasAPIToken {
+		t.Errorf("expected the token to be kept, got %v", err)
+	}
+	update.BaseURL = "https://evil.example.com"
+	if err := s.applyIntegrationInput(integration, update); !errors.Is(err, ErrInvalidTicketIntegration) {
+		t.Error("expected a new base url without a token to be rejected")
+	}
+	update.BaseURL, update.Provider = "https://acme.atlassian.net", "servicenow"
+	if err := s.applyIntegrationInput(integration, update); !errors.Is(err, ErrInvalidTicketIntegration) {
+		t.Error("expected a provider change to be rejected")
+	}
+
+	for _, invalid := range []TicketIntegrationInput{
+		{Provider: "github", Name: "x", BaseURL: "https://x", APIToken: "t"},
+		{Provider: "jira", Name: "x", BaseURL: "ftp://x", APIToken: "t", QueueMapping: entity.TicketQueueMapping{Default: entity.TicketTarget{Queue: "SEC"}}},
+		{Provider: "jira", Name: "x", BaseURL: "https://x", APIToken: "t"},
+		{Provider: "servicenow", Name: "x", BaseURL: "https://x", APIToken: "t", QueueMapping: entity.TicketQueueMapping{BySeverity: map[string]entity.TicketTarget{"urgent": {}}}},
+	} {
+		if err := s.applyIntegrationInput(&entity.TicketIntegration{}, invalid); !errors.Is(err, ErrInvalidTicketIntegration) {
+			t.Errorf("expected %+v to be invalid", invalid)
+		}
+	}
+}
+
+func TestApplyIntegrationInputWithoutEncryption(t *testing.T) {
+	s := &TicketService{}
+	err := s.applyIntegrationInput(&entity.TicketIntegration{}, TicketIntegrationInput{
+		Provider: "servicenow", Name: "ITSM", BaseURL: "https://acme.service-now.com", APIToken: "password",
+	})
+	if !errors.Is(err, ErrTicketSecretUnavailable) {
+		t.Errorf("expected ErrTicketSecretUnavailable, got %v", err)
+	}
+}
+
+func TestArcStatusResolves(t *testing.T) {
+	findingID, requestID := uuid.New(), uuid.New()
+	finding := &entity.TicketLink{FindingID: &findingID}
+	request := &entity.TicketLink{RemediationRequestID: &requestID}
+
+	if !arcStatusResolves(finding, entity.ReviewStatusResolved) || !arcStatusResolves(finding, "false_positive") {
+		t.Error("expected closed review statuses to resolve finding tickets")
+	}
+	if arcStatusResolves(finding, "confirmed") {
+		t.Error("expected a confirmed finding to keep its ticket open")
+	}
+	if !arcStatusResolves(request, entity.RemediationRequestExecuted) || arcStatusResolves(request, entity.RemediationRequestApproved) {
+		t.Error("expected only executed remediation requests to resolve their tickets")
+	}
+}
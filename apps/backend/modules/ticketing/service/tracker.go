@@ -0,0 +1,134 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+var (
+	// ErrTrackerRequestFailed is returned when the tracker cannot be reached or rejects a request
+	ErrTrackerRequestFailed = errors.New("tracker request failed")
+	// ErrTrackerTicketNotFound is returned when a ticket to link does not exist in the tracker
+	ErrTrackerTicketNotFound = errors.New("ticket not found in the tracker")
+	// ErrInvalidWebhookPayload is returned for tracker webhooks that cannot be read
+	ErrInvalidWebhookPayload = errors.New("invalid webhook payload")
+)
+
+// TicketDraft is a ticket to file in the tracker
+type TicketDraft struct {
+	Target      entity.TicketTarget
+	Summary     string
+	Description string
+	Reference   string // ARC resource the ticket is about, for correlation on the tracker side
+}
+
+// TrackerTicket is a ticket as the tracker reports it
+type TrackerTicket struct {
+	Key        string // Jira issue key or ServiceNow number
+	ExternalID string // Jira issue key or ServiceNow sys_id
+	Type       string // Jira issue type or ServiceNow table
+	URL        string
+	Status     string
+}
+
+// tracker is the client of one issue tracker integration
+type tracker interface {
+	// Create files a new ticket
+	Create(ctx context.Context, draft TicketDraft) (*TrackerTicket, error)
+	// Get looks up an existing ticket by key
+	Get(ctx context.Context, key string) (*TrackerTicket, error)
+	// Comment adds a comment to a linked ticket
+	Comment(ctx context.Context, link *entity.TicketLink, body string) error
+	// Resolve moves a linked ticket to a resolved status, with a comment, and returns the new status
+	Resolve(ctx context.Context, link *entity.TicketLink, comment string) (string, error)
+	// ParseWebhook reads the ticket out of the tracker's webhook payload
+	ParseWebhook(body []byte) (*TrackerTicket, error)
+}
+
+// newTracker returns the client for an integration, authenticated with its decrypted API token
+func newTracker(integration *entity.TicketIntegration, apiToken string, client *http.Client) (tracker, error) {
+	base := trackerClient{
+		baseURL:  strings.TrimRight(integration.BaseURL, "/"),
+		username: integration.Username,
+		token:    apiToken,
+		client:   client,
+	}
+	switch integration.Provider {
+	case entity.TicketProviderJira:
+		return &jiraTracker{trackerClient: base, integration: integration}, nil
+	case entity.TicketProviderServiceNow:
+		return &serviceNowTracker{trackerClient: base, integration: integration}, nil
+	default:
+		return nil, fmt.Errorf("unknown ticket provider %q", integration.Provider)
+	}
+}
+
+// trackerClient sends authenticated JSON requests to a tracker's REST API
+type trackerClient struct {
+	baseURL  string
+	username string
+	token    string
+	client   *http.Client
+}
+
+// do sends a request and decodes a JSON response into out when it is not nil
+func (c *trackerClient) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.token)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token) // Jira Data Center personal access token
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrTrackerRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: tracker returned %d: %s", ErrTrackerRequestFailed, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode tracker response: %w", err)
+	}
+	return nil
+}
+
+// firstResolvedStatus returns the status tickets are moved to when resolved from ARC
+func firstResolvedStatus(statuses []string) string {
+	for _, s := range statuses {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/modules/shared/domain/entity"
+)
+
+func TestJiraTrackerCreateAndResolve(t *testing.T) {
+	var created, transitioned map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "sec@example.com" || pass != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"id":"10001","key":"SEC-7"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/SEC-7":
+			w.Write([]byte(`{"key":"SEC-7","fields":{"status":{"name":"To Do"},"issuetype":{"name":"Bug"}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/SEC-7/transitions":
+			w.Write([]byte(`{"transitions":[{"id":"21","to":{"name":"In Progress"}},{"id":"31","to":{"name":"Done"}}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue/SEC-7/transitions":
+			json.NewDecoder(r.Body).Decode(&transitioned)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	integration := &entity.TicketIntegration{
+		Provider: entity.TicketProviderJira, BaseURL: server.URL + "/", Username: "sec@example.com",
+		ResolvedStatuses: []string{"done"},
+	}
+	tr, err := newTracker(integration, "token", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticket, err := tr.Create(context.Background(), TicketDraft{
+		Target:  entity.TicketTarget{Queue: "SEC", Type: "Bug"},
+		Summary: "[ARC-Hawk] High AADHAAR finding",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.Key != "SEC-7" || ticket.Status != "To Do" || ticket.URL != server.URL+"/browse/SEC-7" {
+		t.Errorf("unexpected ticket %+v", ticket)
+	}
+	fields := created["fields"].(map[string]interface{})
+	if fields["project"].(map[string]interface{})["key"] != "SEC" || fields["issuetype"].(map[string]interface{})["name"] != "Bug" {
+		t.Errorf("unexpected issue fields %v", fields)
+	}
+
+	status, err := tr.Resolve(context.Background(), &entity.TicketLink{TicketKey: "SEC-7", ExternalID: "SEC-7"}, "closed in ARC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "Done" || transitioned["transition"].(map[string]interface{})["id"] != "31" {
+		t.Errorf("expected the transition to Done, got %q %v", status, transitioned)
+	}
+}
+
+func TestJiraTrackerParseWebhook(t *testing.T) {
+	tr, _ := newTracker(&entity.TicketIntegration{Provider: entity.TicketProviderJira, BaseURL: "https://acme.atlassian.net"}, "", http.DefaultClient)
+
+	ticket, err := tr.ParseWebhook([]byte(`{"webhookEvent":"jira:issue_updated","issue":{"id":"10001","key":"SEC-7","fields":{"status":{"name":"Done"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.Key != "SEC-7" || ticket.ExternalID != "SEC-7" || ticket.Status != "Done" {
+		t.Errorf("unexpected ticket %+v", ticket)
+	}
+	if _, err := tr.ParseWebhook([]byte(`{"webhookEvent":"comment_created"}`)); !errors.Is(err, ErrInvalidWebhookPayload) {
+		t.Error("expected a payload without an issue to be rejected")
+	}
+}
+
+func TestServiceNowTrackerCreateAndResolve(t *testing.T) {
+	var created, patched map[string]string
+	var patchPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/now/table/incident":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"result":{"sys_id":"abc123","number":"INC0010001","state":"1"}}`))
+		case r.Method == http.MethodPatch:
+			patchPath = r.URL.Path
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &patched)
+			w.Write([]byte(`{"result":{}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	integration := &entity.TicketIntegration{
+		Provider: entity.TicketProviderServiceNow, BaseURL: server.URL, Username: "arc",
+		ResolvedStatuses: []string{"6", "7"},
+	}
+	tr, _ := newTracker(integration, "secret", server.Client())
+
+	ticket, err := tr.Create(context.Background(), TicketDraft{
+		Target:    entity.TicketTarget{Queue: "Data Protection"},
+		Summary:   "[ARC-Hawk] Critical PAN finding",
+		Reference: "arc-finding:1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticket.Key != "INC0010001" || ticket.ExternalID != "abc123" || ticket.Type != "incident" || ticket.Status != "1" {
+		t.Errorf("unexpected ticket %+v", ticket)
+	}
+	if created["assignment_group"] != "Data Protection" || created["correlation_id"] != "arc-finding:1" {
+		t.Errorf("unexpected record %v", created)
+	}
+	if !strings.Contains(ticket.URL, "incident.do") {
+		t.Errorf("unexpected URL %s", ticket.URL)
+	}
+
+	link := &entity.TicketLink{TicketKey: ticket.Key, ExternalID: ticket.ExternalID, TicketType: ticket.Type}
+	status, err := tr.Resolve(context.Background(), link, "closed in ARC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "6" || patched["state"] != "6" || patchPath != "/api/now/table/incident/abc123" {
+		t.Errorf("expected state 6 on the incident, got %q %v at %s", status, patched, patchPath)
+	}
+}
+
+func TestTrackerErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":{"project":"project is required"}}`))
+	}))
+	defer server.Close()
+
+	tr, _ := newTracker(&entity.TicketIntegration{Provider: entity.TicketProviderJira, BaseURL: server.URL}, "pat", server.Client())
+	_, err := tr.Create(context.Background(), TicketDraft{Summary: "x"})
+	if !errors.Is(err, ErrTrackerRequestFailed) || !strings.Contains(err.Error(), "tracker returned 400") {
+		t.Errorf("expected the tracker's error, got %v", err)
+	}
+}
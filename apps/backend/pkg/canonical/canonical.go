@@ -0,0 +1,347 @@
+package canonical
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Policy controls the parts of canonicalization that depend on the source
+type Policy struct {
+	CaseSensitive     bool // Keep the case of paths and table names; otherwise they are lowercased
+	KeepTrailingSlash bool // "/data/" and "/data" stay distinct
+}
+
+// Policies are the policy of each data source, with a default for the rest
+type Policies struct {
+	Default Policy
+	Sources map[string]Policy
+}
+
+// NewPolicies lowercases every source except the case-sensitive ones listed
+func NewPolicies(caseSensitiveSources []string, keepTrailingSlash bool) Policies {
+	policies := Policies{
+		Default: Policy{KeepTrailingSlash: keepTrailingSlash},
+		Sources: make(map[string]Policy),
+	}
+	for _, source := range caseSensitiveSources {
+		policies.Sources[DataSource(source)] = Policy{CaseSensitive: true, KeepTrailingSlash: keepTrailingSlash}
+	}
+	return policies
+}
+
+// For returns the policy of a data source
+func (p Policies) For(dataSource string) Policy {
+	if policy, ok := p.Sources[DataSource(dataSource)]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+// defaultPorts are the ports of database sources left out of their host
+var defaultPorts = map[string]string{
+	"postgresql": "5432",
+	"mysql":      "3306",
+}
+
+// remoteFilePorts are the default ports of file server sources, whose assets
+// are identified by their server as well as their path
+var remoteFilePorts = map[string]string{
+	"sftp": "22",
+	"smb":  "445",
+}
+
+// urlPorts are the default ports left out of URLs
+var urlPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"sftp":  "22",
+	"smb":   "445",
+}
+
+// dataSourceAliases map other names of a data source to the one assets are stored with
+var dataSourceAliases = map[string]string{
+	"postgres": "postgresql",
+	"cifs":     "smb",
+}
+
+// DataSource lowercases a data source name and maps aliases to one name
+func DataSource(dataSource string) string {
+	ds := strings.ToLower(strings.TrimSpace(dataSource))
+	if alias, ok := dataSourceAliases[ds]; ok {
+		return alias
+	}
+	return ds
+}
+
+// IsDatabase reports whether assets of a data source are tables identified by
+// their host as well as their name
+func IsDatabase(dataSource string) bool {
+	_, ok := defaultPorts[DataSource(dataSource)]
+	return ok
+}
+
+// IsRemoteFile reports whether assets of a data source are files on a server,
+// such as an SFTP server or SMB share, identified by the server and path
+func IsRemoteFile(dataSource string) bool {
+	_, ok := remoteFilePorts[DataSource(dataSource)]
+	return ok
+}
+
+// Identifier is the canonical identity of an asset: its path for files and
+// objects, the URL of files on SFTP and SMB servers and of S3 objects whose
+// bucket is known, or data source, host and table for databases
+func Identifier(dataSource, host, assetPath string, policy Policy) string {
+	ds := DataSource(dataSource)
+	if IsDatabase(ds) {
+		return ds + "::" + Host(ds, host) + "::" + Table(assetPath, policy)
+	}
+	if IsRemoteFile(ds) {
+		return RemoteFile(ds, host, assetPath, policy)
+	}
+	if ds == "s3" && strings.TrimSpace(host) != "" {
+		// Objects ingested before scanners reported their bucket keep their path identity
+		return S3Object(host, assetPath, policy)
+	}
+	return Path(assetPath, policy)
+}
+
+// StableID hashes an asset's canonical identity
+func StableID(dataSource, host, assetPath string, policies Policies) string {
+	hash := sha256.Sum256([]byte(Identifier(dataSource, host, assetPath, policies.For(dataSource))))
+	return hex.EncodeToString(hash[:])
+}
+
+// Path cleans a file or object path: repeated separators and "." and ".."
+// segments are resolved, Windows separators become "/" and URLs are handed to
+// URL. Relative paths stay relative.
+func Path(p string, policy Policy) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	if strings.Contains(p, "://") {
+		return URL(p, policy)
+	}
+
+	trailing := strings.HasSuffix(p, "/") || strings.HasSuffix(p, `\`)
+	if isWindowsPath(p) {
+		p = strings.ReplaceAll(p, `\`, "/")
+		if len(p) > 1 && p[1] == ':' {
+			p = strings.ToLower(p[:1]) + p[1:]
+		}
+	}
+
+	var cleaned string
+	if strings.HasPrefix(p, "//") && !strings.HasPrefix(p, "///") {
+		// UNC share: the double slash names the server
+		cleaned = "/" + path.Clean(p[1:])
+	} else {
+		cleaned = path.Clean(p)
+	}
+	if trailing && policy.KeepTrailingSlash && !strings.HasSuffix(cleaned, "/") {
+		cleaned += "/"
+	}
+	return applyCase(cleaned, policy)
+}
+
+// isWindowsPath reports drive-letter and UNC paths
+func isWindowsPath(p string) bool {
+	if strings.HasPrefix(p, `\\`) {
+		return true
+	}
+	return len(p) > 2 && p[1] == ':' && (p[2] == '\\' || p[2] == '/') &&
+		(p[0] >= 'a' && p[0] <= 'z' || p[0] >= 'A' && p[0] <= 'Z')
+}
+
+// URL canonicalizes an object URL such as s3://bucket/key: the scheme and host
+// are lowercased, credentials, default ports and fragments dropped and the path
+// cleaned like Path
+func URL(raw string, policy Policy) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		return applyCase(raw, policy)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && port != urlPorts[scheme] {
+		host = net.JoinHostPort(host, port)
+	}
+
+	p := u.Path
+	if p != "" {
+		trailing := strings.HasSuffix(p, "/")
+		p = path.Clean("/" + p)
+		if trailing && policy.KeepTrailingSlash && p != "/" {
+			p += "/"
+		}
+		if p == "/" && !policy.KeepTrailingSlash {
+			p = ""
+		}
+	}
+	if u.RawQuery != "" {
+		p += "?" + u.RawQuery
+	}
+	return scheme + "://" + host + applyCase(p, policy)
+}
+
+// RemoteFile canonicalizes a file on an SFTP server or SMB share into a URL,
+// sftp://host/path or smb://server/share/path. Scanners report the server in
+// host, or within the path as a URL or a UNC path (\\server\share\dir\file).
+// The server is lowercased and its default port dropped; the path is cleaned
+// like Path, SMB backslashes becoming "/".
+func RemoteFile(dataSource, host, filePath string, policy Policy) string {
+	ds := DataSource(dataSource)
+	p := strings.TrimSpace(filePath)
+	if ds == "smb" {
+		p = strings.ReplaceAll(p, `\`, "/")
+	}
+
+	switch {
+	case strings.Contains(p, "://"):
+		if u, err := url.Parse(p); err == nil {
+			host, p = u.Host, u.Path
+		}
+	case strings.HasPrefix(p, "//") && !strings.HasPrefix(p, "///"):
+		// UNC path: the double slash names the server
+		host, p, _ = strings.Cut(p[2:], "/")
+	}
+
+	h := strings.TrimSpace(host)
+	if i := strings.LastIndex(h, "@"); i >= 0 {
+		h = h[i+1:]
+	}
+	name, port, err := net.SplitHostPort(h)
+	if err != nil {
+		name, port = strings.Trim(h, "[]"), ""
+	}
+	h = strings.TrimSuffix(strings.ToLower(name), ".")
+	if port != "" && port != remoteFilePorts[ds] {
+		h = net.JoinHostPort(h, port)
+	}
+
+	cleaned := Path("/"+strings.TrimLeft(p, "/"), policy)
+	if cleaned == "/" && !policy.KeepTrailingSlash {
+		cleaned = ""
+	}
+	return ds + "://" + h + cleaned
+}
+
+// S3Object canonicalizes an object in a bucket into s3://bucket/key. The bucket
+// is lowercased, as S3 bucket names are; the key is cleaned like Path. Keys
+// already given as a URL are canonicalized as one.
+func S3Object(bucket, key string, policy Policy) string {
+	k := strings.TrimSpace(key)
+	if strings.Contains(k, "://") {
+		return URL(k, policy)
+	}
+	cleaned := Path("/"+strings.TrimLeft(k, "/"), policy)
+	if cleaned == "/" && !policy.KeepTrailingSlash {
+		cleaned = ""
+	}
+	return "s3://" + strings.ToLower(strings.TrimSpace(bucket)) + cleaned
+}
+
+// S3Prefixes returns the prefixes of an object key from the outermost in, each
+// ending in "/": a/b/c.csv is under a/ and a/b/. Keys at the bucket root have none.
+func S3Prefixes(key string) []string {
+	prefixes := []string{}
+	for i := 0; i < len(key); i++ {
+		if key[i] == '/' && i > 0 {
+			prefixes = append(prefixes, key[:i+1])
+		}
+	}
+	return prefixes
+}
+
+// Host canonicalizes the host of a database source, which scanners report as
+// host[:port], user@host:port or a whole DSN: the result is the lowercased host,
+// its port unless the source's default one, and the database when the DSN names one
+func Host(dataSource, host string) string {
+	h := strings.TrimSpace(host)
+	if h == "" {
+		return ""
+	}
+
+	var hostport, database string
+	switch {
+	case strings.Contains(h, "://"):
+		if u, err := url.Parse(h); err == nil {
+			hostport, database = u.Host, strings.Trim(u.Path, "/")
+		} else {
+			hostport = h
+		}
+	case strings.Contains(h, "@tcp("):
+		// Go MySQL driver DSN: user:password@tcp(host:port)/database?params
+		rest := h[strings.Index(h, "@tcp(")+len("@tcp("):]
+		if end := strings.Index(rest, ")"); end >= 0 {
+			hostport = rest[:end]
+			database, _, _ = strings.Cut(strings.TrimPrefix(rest[end+1:], "/"), "?")
+		} else {
+			hostport = rest
+		}
+	case strings.Contains(h, "="):
+		// libpq key=value DSN
+		var hostName, port string
+		for _, field := range strings.Fields(h) {
+			key, value, _ := strings.Cut(field, "=")
+			switch strings.ToLower(key) {
+			case "host":
+				hostName = value
+			case "port":
+				port = value
+			case "dbname":
+				database = value
+			}
+		}
+		hostport = hostName
+		if port != "" {
+			hostport = net.JoinHostPort(hostName, port)
+		}
+	default:
+		hostport = h
+		if i := strings.LastIndex(hostport, "@"); i >= 0 {
+			hostport = hostport[i+1:]
+		}
+	}
+
+	name, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		name, port = strings.Trim(hostport, "[]"), ""
+	}
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if port == defaultPorts[DataSource(dataSource)] {
+		port = ""
+	}
+
+	canonical := name
+	if port != "" {
+		canonical = net.JoinHostPort(name, port)
+	}
+	if database != "" {
+		canonical += "/" + strings.ToLower(database)
+	}
+	return canonical
+}
+
+// Table canonicalizes a schema-qualified table name: identifier quotes and the
+// spaces around each part are dropped
+func Table(name string, policy Policy) string {
+	parts := strings.Split(strings.TrimSpace(name), ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), "\"`[]")
+	}
+	return applyCase(strings.Join(parts, "."), policy)
+}
+
+func applyCase(s string, policy Policy) string {
+	if policy.CaseSensitive {
+		return s
+	}
+	return strings.ToLower(s)
+}
@@ -0,0 +1,284 @@
+// Package client is a typed Go client for the ARC-Hawk REST API. Its requests
+// and responses follow the OpenAPI document served at /api/v1/openapi.json.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTimeout bounds each request unless the caller supplies an http.Client
+const DefaultTimeout = 30 * time.Second
+
+// Client calls the API of one backend, authenticating with a bearer token
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken authenticates requests with an access token
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient sends requests through the given client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// New creates a client for the backend at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken replaces the access token sent with requests
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// Error is a non-2xx response. Code is set by endpoints that use the error
+// envelope; the others only send a message.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Details string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("api: %d", e.Status)
+	if e.Code != "" {
+		msg += " " + e.Code
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Details != "" {
+		msg += " (" + e.Details + ")"
+	}
+	return msg
+}
+
+// Login authenticates with email and password and uses the access token for
+// later requests
+func (c *Client) Login(ctx context.Context, email, password, tenantID string) (*LoginResult, error) {
+	var result LoginResult
+	req := loginRequest{Email: email, Password: password, TenantID: tenantID}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/auth/login", nil, req, &result); err != nil {
+		return nil, err
+	}
+	c.token = result.AccessToken
+	return &result, nil
+}
+
+// ListAssets returns the first page of assets
+func (c *Client) ListAssets(ctx context.Context) ([]Asset, error) {
+	var resp envelope[[]Asset]
+	if err := c.do(ctx, http.MethodGet, "/api/v1/assets", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetAsset returns one asset
+func (c *Client) GetAsset(ctx context.Context, id uuid.UUID) (*Asset, error) {
+	var resp envelope[Asset]
+	if err := c.do(ctx, http.MethodGet, "/api/v1/assets/"+id.String(), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ListFindings returns a page of findings matching the query
+func (c *Client) ListFindings(ctx context.Context, query FindingsQuery) (*FindingsPage, error) {
+	var resp data[FindingsPage]
+	if err := c.do(ctx, http.MethodGet, "/api/v1/findings", query.values(), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SyncLineage starts a full lineage sync; it runs in the background on the server
+func (c *Client) SyncLineage(ctx context.Context) (*SyncResult, error) {
+	var result SyncResult
+	if err := c.do(ctx, http.MethodPost, "/api/v1/lineage/sync", nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LineageStats counts the nodes and edges of the lineage graph
+func (c *Client) LineageStats(ctx context.Context) (*LineageStats, error) {
+	var resp struct {
+		Stats LineageStats `json:"stats"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/api/v1/lineage/stats", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Stats, nil
+}
+
+// ListJobs returns a page of background jobs; admins only
+func (c *Client) ListJobs(ctx context.Context, query JobsQuery) (*JobsPage, error) {
+	var page JobsPage
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/jobs", query.values(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetJob returns one background job; admins only
+func (c *Client) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	var resp data[Job]
+	if err := c.do(ctx, http.MethodGet, "/api/v1/admin/jobs/"+id.String(), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// OpenAPISpec returns the server's OpenAPI document
+func (c *Client) OpenAPISpec(ctx context.Context) (json.RawMessage, error) {
+	var spec json.RawMessage
+	if err := c.do(ctx, http.MethodGet, "/api/v1/openapi.json", nil, nil, &spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// do sends a JSON request and decodes a 2xx response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeError(resp.StatusCode, payload)
+	}
+	if out == nil || len(payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
+
+// decodeError reads either the error envelope or the older
+// {"error": ..., "message"/"details": ...} body
+func decodeError(status int, payload []byte) error {
+	apiErr := &Error{Status: status}
+
+	var body struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Details interface{}     `json:"details"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		apiErr.Message = strings.TrimSpace(string(payload))
+		return apiErr
+	}
+
+	var detail struct {
+		Code    string      `json:"code"`
+		Message string      `json:"message"`
+		Details interface{} `json:"details"`
+	}
+	var legacy string
+	switch {
+	case json.Unmarshal(body.Error, &detail) == nil && detail.Code != "":
+		apiErr.Code, apiErr.Message = detail.Code, detail.Message
+		apiErr.Details = detailString(detail.Details)
+	case json.Unmarshal(body.Error, &legacy) == nil:
+		apiErr.Message = legacy
+		if body.Message != "" {
+			apiErr.Message += ": " + body.Message
+		}
+		apiErr.Details = detailString(body.Details)
+	default:
+		apiErr.Message = body.Message
+	}
+	return apiErr
+}
+
+func detailString(v interface{}) string {
+	switch d := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return d
+	default:
+		encoded, _ := json.Marshal(d)
+		return string(encoded)
+	}
+}
+
+func setInt(values url.Values, key string, n int) {
+	if n > 0 {
+		values.Set(key, strconv.Itoa(n))
+	}
+}
+
+func setUUID(values url.Values, key string, id *uuid.UUID) {
+	if id != nil {
+		values.Set(key, id.String())
+	}
+}
+
+func setString(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLoginAuthenticatesLaterRequests(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			var req loginRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email != "admin@example.com" || req.TenantID != "t-1" {
+				t.Errorf("login request = %+v, %v", req, err)
+			}
+			if r.Header.Get("Authorization") != "" {
+				t.Error("login sent a token before one was issued")
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "tok-1", "token_type": "Bearer", "user": map[string]string{"email": req.Email}})
+		case "/api/v1/lineage/sync":
+			gotAuth = r.Header.Get("Authorization")
+			json.NewEncoder(w).Encode(map[string]string{"status": "success", "message": "started"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := New(server.URL + "/")
+	result, err := c.Login(context.Background(), "admin@example.com", "password123", "t-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.AccessToken != "tok-1" || result.User.Email != "admin@example.com" {
+		t.Errorf("login result = %+v", result)
+	}
+
+	sync, err := c.SyncLineage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sync.Status != "success" || gotAuth != "Bearer tok-1" {
+		t.Errorf("sync = %+v with Authorization %q", sync, gotAuth)
+	}
+}
+
+func TestQueryParameters(t *testing.T) {
+	assetID := uuid.New()
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = map[string]string{}
+		for key := range r.URL.Query() {
+			got[key] = r.URL.Query().Get(key)
+		}
+		w.Write([]byte(`{"data": {"findings": [], "total": 0, "page": 2, "page_size": 50}}`))
+	}))
+	defer server.Close()
+
+	page, err := New(server.URL, WithToken("t")).ListFindings(context.Background(), FindingsQuery{
+		Severity: "High",
+		AssetID:  &assetID,
+		Page:     2,
+		PageSize: 50,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Page != 2 || page.PageSize != 50 {
+		t.Errorf("page = %+v", page)
+	}
+	want := map[string]string{"severity": "High", "asset_id": assetID.String(), "page": "2", "page_size": "50"}
+	if len(got) != len(want) {
+		t.Errorf("query = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("query %s = %q, want %q", key, got[key], value)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		want   Error
+	}{
+		{
+			name:   "envelope",
+			status: http.StatusNotFound,
+			body:   `{"success": false, "error": {"code": "NOT_FOUND", "message": "Asset not found"}}`,
+			want:   Error{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: "Asset not found"},
+		},
+		{
+			name:   "legacy with details",
+			status: http.StatusInternalServerError,
+			body:   `{"error": "Failed to get findings", "details": "connection refused"}`,
+			want:   Error{Status: http.StatusInternalServerError, Message: "Failed to get findings", Details: "connection refused"},
+		},
+		{
+			name:   "auth",
+			status: http.StatusUnauthorized,
+			body:   `{"error": "unauthorized", "message": "Token expired"}`,
+			want:   Error{Status: http.StatusUnauthorized, Message: "unauthorized: Token expired"},
+		},
+		{
+			name:   "not JSON",
+			status: http.StatusBadGateway,
+			body:   "bad gateway\n",
+			want:   Error{Status: http.StatusBadGateway, Message: "bad gateway"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			_, err := New(server.URL).GetAsset(context.Background(), uuid.New())
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want *Error", err)
+			}
+			if *apiErr != tc.want {
+				t.Errorf("err = %+v, want %+v", *apiErr, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	assetsapi "github.com/arc-platform/backend/modules/assets/api"
+	authapi "github.com/arc-platform/backend/modules/auth/api"
+	lineageapi "github.com/arc-platform/backend/modules/lineage/api"
+	sharedapi "github.com/arc-platform/backend/modules/shared/api"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// serverOperations are the operations the backend describes; the client must
+// only call these and decode only what they document
+func serverOperations() []sharedapi.Operation {
+	operations := sharedapi.OpenAPIOperations()
+	operations = append(operations, assetsapi.OpenAPIOperations()...)
+	operations = append(operations, authapi.OpenAPIOperations()...)
+	return append(operations, lineageapi.OpenAPIOperations()...)
+}
+
+// TestClientMatchesSpec sends every client call to a server that only knows
+// the documented routes, and checks each response type the client decodes
+// against the documented response schema
+func TestClientMatchesSpec(t *testing.T) {
+	operations := serverOperations()
+	routes := make(gin.RoutesInfo, 0, len(operations))
+	for _, op := range operations {
+		routes = append(routes, gin.RouteInfo{Method: op.Method, Path: op.Path})
+	}
+	doc, _ := sharedapi.BuildOpenAPI(routes, nil, operations)
+
+	id := uuid.New()
+	cases := []struct {
+		method, path string
+		wire         interface{}
+		call         func(ctx context.Context, c *Client) error
+	}{
+		{http.MethodPost, "/api/v1/auth/login", LoginResult{}, func(ctx context.Context, c *Client) error {
+			_, err := c.Login(ctx, "a@example.com", "password123", uuid.NewString())
+			return err
+		}},
+		{http.MethodGet, "/api/v1/assets", envelope[[]Asset]{}, func(ctx context.Context, c *Client) error {
+			_, err := c.ListAssets(ctx)
+			return err
+		}},
+		{http.MethodGet, "/api/v1/assets/:id", envelope[Asset]{}, func(ctx context.Context, c *Client) error {
+			_, err := c.GetAsset(ctx, id)
+			return err
+		}},
+		{http.MethodGet, "/api/v1/findings", data[FindingsPage]{}, func(ctx context.Context, c *Client) error {
+			_, err := c.ListFindings(ctx, FindingsQuery{Severity: "High", OrgUnitID: &id, Page: 1})
+			return err
+		}},
+		{http.MethodPost, "/api/v1/lineage/sync", SyncResult{}, func(ctx context.Context, c *Client) error {
+			_, err := c.SyncLineage(ctx)
+			return err
+		}},
+		{http.MethodGet, "/api/v1/lineage/stats", struct {
+			Stats LineageStats `json:"stats"`
+		}{}, func(ctx context.Context, c *Client) error {
+			_, err := c.LineageStats(ctx)
+			return err
+		}},
+		{http.MethodGet, "/api/v1/admin/jobs", JobsPage{}, func(ctx context.Context, c *Client) error {
+			_, err := c.ListJobs(ctx, JobsQuery{Status: "failed", Limit: 10})
+			return err
+		}},
+		{http.MethodGet, "/api/v1/admin/jobs/:id", data[Job]{}, func(ctx context.Context, c *Client) error {
+			_, err := c.GetJob(ctx, id)
+			return err
+		}},
+		{http.MethodGet, "/api/v1/openapi.json", nil, func(ctx context.Context, c *Client) error {
+			_, err := c.OpenAPISpec(ctx)
+			return err
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			// The client reaches the documented route with documented query parameters
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tc.method || !matchesTemplate(tc.path, r.URL.Path) {
+					t.Errorf("client sent %s %s", r.Method, r.URL.Path)
+				}
+				op := documented(doc, tc.method, tc.path)
+				for key := range r.URL.Query() {
+					if !hasQueryParameter(op, key) {
+						t.Errorf("query parameter %s is not documented", key)
+					}
+				}
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			if documented(doc, tc.method, tc.path) == nil {
+				t.Fatalf("%s %s is not documented", tc.method, tc.path)
+			}
+			if err := tc.call(context.Background(), New(server.URL)); err != nil {
+				t.Fatal(err)
+			}
+			if tc.wire == nil {
+				return
+			}
+
+			// Every field the client decodes is documented with its type
+			sample := reflect.New(reflect.TypeOf(tc.wire)).Elem()
+			populate(sample)
+			body, err := json.Marshal(sample.Interface())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := doc.ValidateResponse(tc.method, tc.path, http.StatusOK, body); err != nil {
+				t.Errorf("client type %T does not match the spec: %v", tc.wire, err)
+			}
+		})
+	}
+}
+
+func documented(doc *sharedapi.OpenAPIDocument, method, path string) *sharedapi.OpenAPIOperation {
+	converted := path
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			converted = strings.Replace(converted, segment, "{"+segment[1:]+"}", 1)
+		}
+	}
+	return doc.Paths[converted][strings.ToLower(method)]
+}
+
+func hasQueryParameter(op *sharedapi.OpenAPIOperation, name string) bool {
+	for _, p := range op.Parameters {
+		if p.In == "query" && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTemplate(template, path string) bool {
+	want, got := strings.Split(template, "/"), strings.Split(path, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if !strings.HasPrefix(want[i], ":") && want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// populate fills a value so every field is marshalled: pointers are set,
+// slices and maps get one element
+func populate(v reflect.Value) {
+	switch v.Type() {
+	case reflect.TypeOf(time.Time{}):
+		v.Set(reflect.ValueOf(time.Now()))
+		return
+	case reflect.TypeOf(uuid.UUID{}):
+		v.Set(reflect.ValueOf(uuid.New()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				populate(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		populate(v.Index(0))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(reflect.ValueOf("key").Convert(v.Type().Key()), reflect.Zero(v.Type().Elem()))
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float64:
+		v.SetFloat(0.5)
+	case reflect.Bool:
+		v.SetBool(true)
+	}
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// envelope is the body sent by handlers using the standard success envelope
+type envelope[T any] struct {
+	Success bool `json:"success"`
+	Data    T    `json:"data"`
+}
+
+// data is the body sent by handlers returning {"data": ...}
+type data[T any] struct {
+	Data T `json:"data"`
+}
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	TenantID string `json:"tenant_id"`
+}
+
+// LoginResult holds the tokens issued by Login
+type LoginResult struct {
+	User         User   `json:"user"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+}
+
+// User is the authenticated user
+type User struct {
+	ID        uuid.UUID  `json:"id"`
+	TenantID  uuid.UUID  `json:"tenant_id"`
+	Email     string     `json:"email"`
+	FirstName string     `json:"first_name"`
+	LastName  string     `json:"last_name"`
+	Role      string     `json:"role"`
+	OrgUnitID *uuid.UUID `json:"org_unit_id,omitempty"`
+}
+
+// Asset is a scanned file, table or bucket
+type Asset struct {
+	ID            uuid.UUID  `json:"id"`
+	TenantID      uuid.UUID  `json:"tenant_id"`
+	StableID      string     `json:"stable_id"`
+	AssetType     string     `json:"asset_type"`
+	Name          string     `json:"name"`
+	Path          string     `json:"path"`
+	DataSource    string     `json:"data_source"`
+	Host          string     `json:"host"`
+	Environment   string     `json:"environment"`
+	Owner         string     `json:"owner"`
+	SourceSystem  string     `json:"source_system"`
+	RiskScore     int        `json:"risk_score"`
+	TotalFindings int        `json:"total_findings"`
+	IsMasked      bool       `json:"is_masked"`
+	OrgUnitID     *uuid.UUID `json:"org_unit_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// FindingsQuery filters and pages ListFindings; zero fields are not sent
+type FindingsQuery struct {
+	Severity    string
+	PatternName string
+	DataSource  string
+	ScanRunID   *uuid.UUID
+	AssetID     *uuid.UUID
+	OrgUnitID   *uuid.UUID
+	SortBy      string
+	SortOrder   string
+	Page        int
+	PageSize    int
+}
+
+func (q FindingsQuery) values() url.Values {
+	values := url.Values{}
+	setString(values, "severity", q.Severity)
+	setString(values, "pattern_name", q.PatternName)
+	setString(values, "data_source", q.DataSource)
+	setUUID(values, "scan_run_id", q.ScanRunID)
+	setUUID(values, "asset_id", q.AssetID)
+	setUUID(values, "org_unit_id", q.OrgUnitID)
+	setString(values, "sort_by", q.SortBy)
+	setString(values, "sort_order", q.SortOrder)
+	setInt(values, "page", q.Page)
+	setInt(values, "page_size", q.PageSize)
+	return values
+}
+
+// FindingsPage is one page of findings
+type FindingsPage struct {
+	Findings   []Finding `json:"findings"`
+	Total      int       `json:"total"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+	TotalPages int       `json:"total_pages"`
+}
+
+// Finding is a PII match with the asset it was found in
+type Finding struct {
+	ID              uuid.UUID `json:"id"`
+	ScanRunID       uuid.UUID `json:"scan_run_id"`
+	AssetID         uuid.UUID `json:"asset_id"`
+	PatternName     string    `json:"pattern_name"`
+	Matches         []string  `json:"matches"`
+	SampleText      string    `json:"sample_text"`
+	Severity        string    `json:"severity"`
+	ConfidenceScore *float64  `json:"confidence_score,omitempty"`
+	Environment     string    `json:"environment"`
+	AssetName       string    `json:"asset_name"`
+	AssetPath       string    `json:"asset_path"`
+	SourceSystem    string    `json:"source_system"`
+	ReviewStatus    string    `json:"review_status"`
+	ReviewVersion   int       `json:"review_version"`
+	CommentCount    int       `json:"comment_count"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// SyncResult acknowledges a started lineage sync
+type SyncResult struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// LineageStats counts the nodes and edges of the lineage graph
+type LineageStats struct {
+	TotalSystems       int `json:"total_systems"`
+	TotalAssets        int `json:"total_assets"`
+	TotalPIICategories int `json:"total_pii_categories"`
+	TotalEdges         int `json:"total_edges"`
+}
+
+// JobsQuery filters and pages ListJobs; zero fields are not sent
+type JobsQuery struct {
+	Status string
+	Type   string
+	Limit  int
+	Offset int
+}
+
+func (q JobsQuery) values() url.Values {
+	values := url.Values{}
+	setString(values, "status", q.Status)
+	setString(values, "type", q.Type)
+	setInt(values, "limit", q.Limit)
+	setInt(values, "offset", q.Offset)
+	return values
+}
+
+// JobsPage is one page of background jobs
+type JobsPage struct {
+	Data  []Job `json:"data"`
+	Total int   `json:"total"`
+}
+
+// Job is a background job
+type Job struct {
+	ID          uuid.UUID              `json:"id"`
+	Type        string                 `json:"type"`
+	Payload     map[string]interface{} `json:"payload"`
+	Status      string                 `json:"status"`
+	Attempts    int                    `json:"attempts"`
+	MaxAttempts int                    `json:"max_attempts"`
+	ScheduledAt time.Time              `json:"scheduled_at"`
+	LastError   string                 `json:"last_error,omitempty"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
@@ -0,0 +1,183 @@
+// Package dataset validates the JSON datasets used to test classification, the
+// labeled ground-truth samples and the findings written by the test data
+// generator, against embedded JSON Schemas. Problems are reported with their JSON
+// pointer, line and column so a bad contribution fails before it skews a run.
+package dataset
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Kind names a dataset schema
+type Kind string
+
+// Dataset kinds
+const (
+	KindGroundTruth  Kind = "ground-truth"
+	KindTestFindings Kind = "test-findings"
+)
+
+// Kinds lists the dataset kinds
+var Kinds = []Kind{KindGroundTruth, KindTestFindings}
+
+//go:embed schemas/*.schema.json
+var schemaFiles embed.FS
+
+var schemaFileNames = map[Kind]string{
+	KindGroundTruth:  "schemas/ground_truth.schema.json",
+	KindTestFindings: "schemas/test_findings.schema.json",
+}
+
+// schemas are compiled once; the embedded files are checked by the package tests
+var schemas = mustCompileSchemas()
+
+func mustCompileSchemas() map[Kind]*schema {
+	compiled := make(map[Kind]*schema, len(schemaFileNames))
+	for kind, name := range schemaFileNames {
+		data, err := schemaFiles.ReadFile(name)
+		if err != nil {
+			panic(fmt.Sprintf("dataset: %v", err))
+		}
+		s, err := compileSchema(data)
+		if err != nil {
+			panic(fmt.Sprintf("dataset: %s: %v", name, err))
+		}
+		compiled[kind] = s
+	}
+	return compiled
+}
+
+// Schema returns the JSON Schema document of a dataset kind
+func Schema(kind Kind) ([]byte, error) {
+	name, ok := schemaFileNames[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown dataset kind %q", kind)
+	}
+	return schemaFiles.ReadFile(name)
+}
+
+// GroundTruthSample is a labeled value the classifier is scored against
+type GroundTruthSample struct {
+	Value        string `json:"value"`
+	ExpectedType string `json:"expected_type"` // NON_PII for negative samples
+	ShouldDetect bool   `json:"should_detect"`
+	Description  string `json:"description"`
+}
+
+// Problem is one way a dataset does not match its schema
+type Problem struct {
+	Pointer string // JSON pointer to the offending value; empty for the whole document
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Pointer == "" {
+		return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", p.Line, p.Column, p.Pointer, p.Message)
+}
+
+// ValidationError lists the problems found in a dataset, in document order
+type ValidationError struct {
+	File     string // Set when the dataset was read from a file
+	Kind     Kind
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = p.String()
+		if e.File != "" {
+			lines[i] = e.File + ":" + lines[i]
+		}
+	}
+	return fmt.Sprintf("invalid %s dataset:\n%s", e.Kind, strings.Join(lines, "\n"))
+}
+
+// Validate checks a dataset against the schema of its kind. Malformed JSON and
+// schema violations are returned as a *ValidationError.
+func Validate(kind Kind, data []byte) error {
+	s, ok := schemas[kind]
+	if !ok {
+		return fmt.Errorf("unknown dataset kind %q", kind)
+	}
+
+	root, err := parseNode(data)
+	if err != nil {
+		se := err.(*syntaxError)
+		line, column := lineColumn(data, se.offset)
+		return &ValidationError{Kind: kind, Problems: []Problem{{Line: line, Column: column, Message: se.msg}}}
+	}
+
+	var problems []Problem
+	s.validate(root, "", func(n *node, pointer, msg string) {
+		line, column := lineColumn(data, n.offset)
+		problems = append(problems, Problem{Pointer: pointer, Line: line, Column: column, Message: msg})
+	})
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+	return &ValidationError{Kind: kind, Problems: problems}
+}
+
+// DetectKind guesses a dataset's kind from the fields of its first element
+func DetectKind(data []byte) (Kind, error) {
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil || len(items) == 0 {
+		return "", fmt.Errorf("cannot detect the dataset kind: expected a non-empty array of objects")
+	}
+	switch {
+	case items[0]["should_detect"] != nil || items[0]["expected_type"] != nil:
+		return KindGroundTruth, nil
+	case items[0]["PIIType"] != nil || items[0]["AssetID"] != nil:
+		return KindTestFindings, nil
+	default:
+		return "", fmt.Errorf("cannot detect the dataset kind from its fields")
+	}
+}
+
+// Load validates a dataset and decodes it into v
+func Load(kind Kind, data []byte, v interface{}) error {
+	if err := Validate(kind, data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// LoadFile validates the dataset in a file and decodes it into v
+func LoadFile(kind Kind, path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := Load(kind, data, v); err != nil {
+		if ve, ok := err.(*ValidationError); ok {
+			ve.File = path
+		}
+		return err
+	}
+	return nil
+}
+
+// LoadGroundTruth reads and validates a file of ground-truth samples
+func LoadGroundTruth(path string) ([]GroundTruthSample, error) {
+	var samples []GroundTruthSample
+	if err := LoadFile(KindGroundTruth, path, &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
@@ -0,0 +1,82 @@
+package dataset
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepositoryGroundTruthIsValid(t *testing.T) {
+	files, err := filepath.Glob("../../../../testdata/ground_truth/*.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no ground-truth files found: %v", err)
+	}
+	for _, file := range files {
+		samples, err := LoadGroundTruth(file)
+		if err != nil {
+			t.Errorf("%v", err)
+			continue
+		}
+		if len(samples) == 0 || samples[0].ExpectedType == "" {
+			t.Errorf("%s: expected samples to be decoded, got %+v", file, samples)
+		}
+	}
+}
+
+func TestValidateReportsLocations(t *testing.T) {
+	data := []byte(`[
+  {"value": "4532015112830366", "expected_type": "CREDIT_CARD", "should_detect": true, "description": "Visa"},
+  {"value": "", "expected_type": "credit card", "should_detect": "yes", "description": "Bad", "notes": "x"},
+  {"value": "1234", "expected_type": "NON_PII", "should_detect": true},
+  {"value": "ABCDE1234F", "expected_type": "IN_PAN", "should_detect": false, "description": "Unlabeled"}
+]`)
+
+	var ve *ValidationError
+	if err := Validate(KindGroundTruth, data); !errors.As(err, &ve) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	var got []string
+	for _, p := range ve.Problems {
+		got = append(got, p.String())
+	}
+	want := []string{
+		`3:13: /1/value: must not be empty`,
+		`3:34: /1/expected_type: "credit card" does not match ^[A-Z][A-Z0-9_]*$`,
+		`3:66: /1/should_detect: expected boolean, got string`,
+		`3:104: /1/notes: unexpected property "notes"`,
+		`4:3: /2: missing required property "description"`,
+		`4:66: /2/should_detect: must be false`,
+		`5:44: /3/expected_type: must be "NON_PII"`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateSyntaxError(t *testing.T) {
+	err := Validate(KindGroundTruth, []byte("[\n  {\"value\": \"x\",}\n]"))
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Problems) != 1 || ve.Problems[0].Line != 2 {
+		t.Fatalf("expected a syntax error on line 2, got %v", err)
+	}
+}
+
+func TestValidateTestFindings(t *testing.T) {
+	valid := []byte(`[{"AssetID": "8e4adb0d-77a8-4e1d-8da1-6c489083a81c", "AssetName": "users", "AssetPath": "/data/users.csv",
+		"Host": "db.example.com", "Environment": "Staging", "DataSource": "filesystem", "Table": "users",
+		"PIIType": "IN_PAN", "PatternName": "pan_number", "Matches": ["ABCPA1234Z"], "Severity": "Critical",
+		"ConfidenceScore": 0.8, "DPDPACategory": "Sensitive Personal Data", "RequiresConsent": true}]`)
+	if err := Validate(KindTestFindings, valid); err != nil {
+		t.Fatalf("expected valid findings, got %v", err)
+	}
+	if kind, err := DetectKind(valid); err != nil || kind != KindTestFindings {
+		t.Errorf("DetectKind = %q, %v", kind, err)
+	}
+
+	invalid := strings.Replace(strings.Replace(string(valid), "0.8", "80", 1), `"Critical"`, `"Severe"`, 1)
+	err := Validate(KindTestFindings, []byte(invalid))
+	if err == nil || !strings.Contains(err.Error(), "/0/Severity: must be one of") || !strings.Contains(err.Error(), "/0/ConfidenceScore: must be at most 1") {
+		t.Errorf("expected severity and confidence problems, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// node is a parsed JSON value that remembers where it starts in the document, so
+// problems can be reported at a line and column
+type node struct {
+	kind   string      // object, array, string, number, boolean or null
+	value  interface{} // string, json.Number or bool for scalars
+	offset int64
+
+	keys   []string // Object keys in document order
+	fields map[string]*node
+	items  []*node
+}
+
+// parseNode parses a complete JSON document
+func parseNode(data []byte) (*node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	root, err := parseValue(dec, data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, &syntaxError{offset: dec.InputOffset(), msg: "unexpected data after the top-level value"}
+	}
+	return root, nil
+}
+
+func parseValue(dec *json.Decoder, data []byte) (*node, error) {
+	start := valueStart(data, dec.InputOffset())
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, locateSyntaxError(err, data)
+	}
+
+	n := &node{offset: start}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			n.kind = "object"
+			n.fields = make(map[string]*node)
+			for dec.More() {
+				keyStart := valueStart(data, dec.InputOffset())
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, locateSyntaxError(err, data)
+				}
+				key := keyTok.(string)
+				if _, dup := n.fields[key]; dup {
+					return nil, &syntaxError{offset: keyStart, msg: fmt.Sprintf("duplicate key %q", key)}
+				}
+				child, err := parseValue(dec, data)
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, key)
+				n.fields[key] = child
+			}
+		case '[':
+			n.kind = "array"
+			n.items = []*node{}
+			for dec.More() {
+				child, err := parseValue(dec, data)
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, child)
+			}
+		}
+		// The closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, locateSyntaxError(err, data)
+		}
+	case string:
+		n.kind, n.value = "string", t
+	case json.Number:
+		n.kind, n.value = "number", t
+	case bool:
+		n.kind, n.value = "boolean", t
+	case nil:
+		n.kind = "null"
+	}
+	return n, nil
+}
+
+// valueStart skips the whitespace and separators between the decoder's offset and
+// the next value
+func valueStart(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) {
+		switch data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// interfaceValue converts the node back to the values encoding/json decodes to
+func (n *node) interfaceValue() interface{} {
+	switch n.kind {
+	case "object":
+		m := make(map[string]interface{}, len(n.fields))
+		for k, v := range n.fields {
+			m[k] = v.interfaceValue()
+		}
+		return m
+	case "array":
+		s := make([]interface{}, len(n.items))
+		for i, v := range n.items {
+			s[i] = v.interfaceValue()
+		}
+		return s
+	default:
+		return n.value
+	}
+}
+
+// syntaxError is malformed JSON at an offset
+type syntaxError struct {
+	offset int64
+	msg    string
+}
+
+func (e *syntaxError) Error() string { return e.msg }
+
+func locateSyntaxError(err error, data []byte) error {
+	var se *json.SyntaxError
+	switch {
+	case errors.As(err, &se):
+		return &syntaxError{offset: se.Offset, msg: se.Error()}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return &syntaxError{offset: int64(len(data)), msg: "unexpected end of JSON input"}
+	default:
+		return &syntaxError{offset: 0, msg: err.Error()}
+	}
+}
+
+// lineColumn converts a byte offset to a 1-based line and column
+func lineColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte{'\n'}) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
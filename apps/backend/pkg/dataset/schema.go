@@ -0,0 +1,248 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// schema is the subset of JSON Schema (draft 2020-12) the dataset schemas use.
+// Unknown keywords are rejected when a schema is compiled, so a constraint can't
+// be added to a schema file and silently ignored.
+type schema struct {
+	SchemaURI   string `json:"$schema"`
+	ID          string `json:"$id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	Type  string          `json:"type"`
+	Enum  []interface{}   `json:"enum"`
+	Const json.RawMessage `json:"const"`
+
+	MinLength *int   `json:"minLength"`
+	MaxLength *int   `json:"maxLength"`
+	Pattern   string `json:"pattern"`
+	Format    string `json:"format"`
+
+	Minimum *float64 `json:"minimum"`
+	Maximum *float64 `json:"maximum"`
+
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+
+	Items    *schema `json:"items"`
+	MinItems *int    `json:"minItems"`
+
+	AllOf []*schema `json:"allOf"`
+	If    *schema   `json:"if"`
+	Then  *schema   `json:"then"`
+	Else  *schema   `json:"else"`
+
+	pattern  *regexp.Regexp
+	constVal interface{}
+}
+
+// formats are the supported "format" keywords
+var formats = map[string]*regexp.Regexp{
+	"uuid":      regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+	"date-time": regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`),
+}
+
+var schemaTypes = map[string]bool{
+	"": true, "object": true, "array": true, "string": true, "number": true, "integer": true, "boolean": true, "null": true,
+}
+
+// compileSchema parses a schema document and checks its keywords
+func compileSchema(data []byte) (*schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	var s schema
+	if err := dec.Decode(&s); err != nil {
+		return nil, err
+	}
+	if err := s.compile(""); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *schema) compile(path string) error {
+	if !schemaTypes[s.Type] {
+		return fmt.Errorf("%s: unsupported type %q", path, s.Type)
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+		s.pattern = re
+	}
+	if s.Format != "" && formats[s.Format] == nil {
+		return fmt.Errorf("%s: unsupported format %q", path, s.Format)
+	}
+	if len(s.Const) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(s.Const))
+		dec.UseNumber()
+		if err := dec.Decode(&s.constVal); err != nil {
+			return fmt.Errorf("%s: invalid const: %w", path, err)
+		}
+	}
+
+	for name, sub := range s.Properties {
+		if err := sub.compile(path + "/properties/" + name); err != nil {
+			return err
+		}
+	}
+	children := map[string]*schema{"items": s.Items, "if": s.If, "then": s.Then, "else": s.Else}
+	for i, sub := range s.AllOf {
+		children[fmt.Sprintf("allOf/%d", i)] = sub
+	}
+	for name, sub := range children {
+		if sub == nil {
+			continue
+		}
+		if err := sub.compile(path + "/" + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks n against the schema, reporting each problem at the JSON
+// pointer of the offending value
+func (s *schema) validate(n *node, pointer string, report func(n *node, pointer, msg string)) {
+	if s.Type != "" && !hasType(n, s.Type) {
+		report(n, pointer, fmt.Sprintf("expected %s, got %s", s.Type, n.kind))
+		return
+	}
+	if len(s.Const) > 0 && !reflect.DeepEqual(n.interfaceValue(), s.constVal) {
+		report(n, pointer, fmt.Sprintf("must be %s", s.Const))
+	}
+	if len(s.Enum) > 0 && !inEnum(n, s.Enum) {
+		report(n, pointer, fmt.Sprintf("must be one of %s", describeEnum(s.Enum)))
+	}
+
+	switch n.kind {
+	case "string":
+		s.validateString(n, pointer, report)
+	case "number":
+		s.validateNumber(n, pointer, report)
+	case "object":
+		s.validateObject(n, pointer, report)
+	case "array":
+		if s.MinItems != nil && len(n.items) < *s.MinItems {
+			report(n, pointer, fmt.Sprintf("must have at least %d items", *s.MinItems))
+		}
+		if s.Items != nil {
+			for i, item := range n.items {
+				s.Items.validate(item, fmt.Sprintf("%s/%d", pointer, i), report)
+			}
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		sub.validate(n, pointer, report)
+	}
+	if s.If != nil {
+		matched := true
+		s.If.validate(n, pointer, func(*node, string, string) { matched = false })
+		if matched && s.Then != nil {
+			s.Then.validate(n, pointer, report)
+		} else if !matched && s.Else != nil {
+			s.Else.validate(n, pointer, report)
+		}
+	}
+}
+
+func (s *schema) validateString(n *node, pointer string, report func(n *node, pointer, msg string)) {
+	value := n.value.(string)
+	length := utf8.RuneCountInString(value)
+	if s.MinLength != nil && length < *s.MinLength {
+		if *s.MinLength == 1 {
+			report(n, pointer, "must not be empty")
+		} else {
+			report(n, pointer, fmt.Sprintf("must be at least %d characters", *s.MinLength))
+		}
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		report(n, pointer, fmt.Sprintf("must be at most %d characters", *s.MaxLength))
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		report(n, pointer, fmt.Sprintf("%q does not match %s", value, s.Pattern))
+	}
+	if s.Format != "" && !formats[s.Format].MatchString(value) {
+		report(n, pointer, fmt.Sprintf("%q is not a valid %s", value, s.Format))
+	}
+}
+
+func (s *schema) validateNumber(n *node, pointer string, report func(n *node, pointer, msg string)) {
+	value, err := n.value.(json.Number).Float64()
+	if err != nil {
+		report(n, pointer, "is not a representable number")
+		return
+	}
+	if s.Minimum != nil && value < *s.Minimum {
+		report(n, pointer, fmt.Sprintf("must be at least %v", *s.Minimum))
+	}
+	if s.Maximum != nil && value > *s.Maximum {
+		report(n, pointer, fmt.Sprintf("must be at most %v", *s.Maximum))
+	}
+}
+
+func (s *schema) validateObject(n *node, pointer string, report func(n *node, pointer, msg string)) {
+	for _, name := range s.Required {
+		if _, ok := n.fields[name]; !ok {
+			report(n, pointer, fmt.Sprintf("missing required property %q", name))
+		}
+	}
+	for _, key := range n.keys {
+		child := n.fields[key]
+		childPointer := pointer + "/" + escapePointer(key)
+		if sub, ok := s.Properties[key]; ok {
+			sub.validate(child, childPointer, report)
+		} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			report(child, childPointer, fmt.Sprintf("unexpected property %q", key))
+		}
+	}
+}
+
+func hasType(n *node, want string) bool {
+	if want == "integer" {
+		if n.kind != "number" {
+			return false
+		}
+		_, err := n.value.(json.Number).Int64()
+		return err == nil
+	}
+	return n.kind == want
+}
+
+func inEnum(n *node, enum []interface{}) bool {
+	value := n.interfaceValue()
+	for _, allowed := range enum {
+		if reflect.DeepEqual(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeEnum(enum []interface{}) string {
+	parts := make([]string, len(enum))
+	for i, v := range enum {
+		b, _ := json.Marshal(v)
+		parts[i] = string(b)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapePointer escapes a key for a JSON pointer (RFC 6901)
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,153 @@
+// Package langdetect guesses the language of short text such as the sample text
+// around a finding. Non-Latin scripts name their language directly; Latin text is
+// told apart by common function words. It needs no models and is meant for
+// routing findings, not for linguistic accuracy.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Undetermined is the ISO 639-2 code reported when the text is too short or has
+// no recognizable words, as for a bare email address
+const Undetermined = "und"
+
+// minLetters is the fewest letters a language is guessed from
+const minLetters = 8
+
+// minWordHits is the fewest function words Latin text must contain to be guessed
+const minWordHits = 2
+
+// Result is a guessed language and the share of the evidence behind it
+type Result struct {
+	Language   string  `json:"language"`   // ISO 639-1 code, or Undetermined
+	Confidence float64 `json:"confidence"` // 0-1
+}
+
+// scripts map non-Latin scripts to the language they most likely carry. Devanagari
+// is also used for Marathi and Nepali; all are reported as Hindi.
+var scripts = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Devanagari, "hi"},
+	{unicode.Bengali, "bn"},
+	{unicode.Gurmukhi, "pa"},
+	{unicode.Gujarati, "gu"},
+	{unicode.Oriya, "or"},
+	{unicode.Tamil, "ta"},
+	{unicode.Telugu, "te"},
+	{unicode.Kannada, "kn"},
+	{unicode.Malayalam, "ml"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Han, "zh"},
+}
+
+// functionWords are frequent words of Latin-script languages. Words shared by
+// most of them, such as "de" and "a", are left out.
+var functionWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "that", "for", "with", "was", "are", "this", "you", "have", "not", "from", "your", "please", "name", "address", "phone"},
+	"es": {"el", "los", "las", "que", "y", "por", "para", "con", "una", "es", "su", "del", "se", "al", "como", "nombre", "correo", "teléfono", "dirección"},
+	"fr": {"le", "les", "des", "du", "et", "est", "pour", "avec", "une", "dans", "sur", "pas", "nous", "vous", "qui", "nom", "téléphone", "rue"},
+	"pt": {"o", "os", "do", "da", "dos", "das", "e", "um", "uma", "com", "não", "nome", "endereço", "você", "telefone", "rua"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für", "auf", "den", "dem", "ein", "eine", "sie", "ich", "von", "zu", "bitte", "straße"},
+	"it": {"il", "lo", "gli", "della", "di", "che", "è", "per", "un", "non", "sono", "nome", "indirizzo", "via"},
+	"nl": {"het", "een", "van", "niet", "met", "voor", "op", "zijn", "dat", "naam", "adres", "bij", "wij", "straat"},
+}
+
+// wordLanguages indexes functionWords by word
+var wordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for language, words := range functionWords {
+		for _, w := range words {
+			index[w] = append(index[w], language)
+		}
+	}
+	return index
+}()
+
+// Detect guesses the language of text. Text in a non-Latin script is reported by
+// its script once that script holds at least half of the letters; Latin text by
+// the language whose function words it uses most, preferring English on a tie.
+func Detect(text string) Result {
+	counts := make(map[string]int)
+	letters, latin := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for _, s := range scripts {
+			if unicode.Is(s.table, r) {
+				counts[s.language]++
+				break
+			}
+		}
+	}
+	if letters < minLetters {
+		return Result{Language: Undetermined}
+	}
+
+	// Kana mark Japanese even among Han characters
+	if counts["ja"] > 0 && counts["zh"] > 0 {
+		counts["ja"] += counts["zh"]
+		delete(counts, "zh")
+	}
+	best, bestCount := "", 0
+	for _, s := range scripts {
+		if n := counts[s.language]; n > bestCount {
+			best, bestCount = s.language, n
+		}
+	}
+	if bestCount*2 >= letters {
+		return Result{Language: best, Confidence: round(float64(bestCount) / float64(letters))}
+	}
+	if latin*2 < letters {
+		return Result{Language: Undetermined}
+	}
+	return detectLatin(text)
+}
+
+// detectLatin scores Latin text by the function words of each language
+func detectLatin(text string) Result {
+	hits := make(map[string]int)
+	total := 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		languages := wordLanguages[word]
+		for _, language := range languages {
+			hits[language]++
+		}
+		if len(languages) > 0 {
+			total++
+		}
+	}
+
+	best, bestHits := Undetermined, 0
+	for _, language := range []string{"en", "es", "fr", "pt", "de", "it", "nl"} {
+		if hits[language] > bestHits {
+			best, bestHits = language, hits[language]
+		}
+	}
+	if bestHits < minWordHits {
+		return Result{Language: Undetermined}
+	}
+	return Result{Language: best, Confidence: round(float64(bestHits) / float64(total))}
+}
+
+func round(f float64) float64 {
+	return float64(int(f*100+0.5)) / 100
+}
@@ -0,0 +1,47 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Please update the phone number of the customer and send it to billing", "en"},
+		{"spanish", "El nombre del cliente y su correo para la factura", "es"},
+		{"french", "Le nom du client est dans le fichier avec les adresses", "fr"},
+		{"german", "Der Name und die Adresse des Kunden sind nicht mit der Rechnung", "de"},
+		{"hindi", "ग्राहक का नाम राम कुमार है, ईमेल ram@example.com", "hi"},
+		{"tamil", "வாடிக்கையாளர் பெயர் மற்றும் முகவரி", "ta"},
+		{"japanese", "お客様の名前と住所を確認してください", "ja"},
+		{"chinese", "客户姓名和地址已经更新到系统", "zh"},
+		{"russian", "Имя клиента и адрес электронной почты", "ru"},
+		{"bare value", "ram.kumar@example.com", Undetermined},
+		{"too short", "Name:", Undetermined},
+		{"no function words", "customer_email,customer_phone,billing_zip", Undetermined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Detect(tt.text)
+			if got.Language != tt.want {
+				t.Errorf("Detect(%q) = %+v, want %s", tt.text, got, tt.want)
+			}
+			if got.Language == Undetermined && got.Confidence != 0 {
+				t.Errorf("undetermined result has confidence %v", got.Confidence)
+			}
+			if got.Language != Undetermined && (got.Confidence <= 0 || got.Confidence > 1) {
+				t.Errorf("confidence %v out of range", got.Confidence)
+			}
+		})
+	}
+}
+
+func TestDetectMixedScripts(t *testing.T) {
+	// An English form with a Hindi name is still English
+	got := Detect("Please send the invoice to राम and update the address for the account")
+	if got.Language != "en" {
+		t.Errorf("got %+v, want en", got)
+	}
+}
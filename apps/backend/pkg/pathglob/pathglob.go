@@ -0,0 +1,49 @@
+package pathglob
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Compile converts a path glob into an anchored regular expression.
+// Supported syntax: "**" matches across directories, "*" matches within one
+// path segment, "?" matches a single non-separator character.
+func Compile(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		ch := glob[i]
+		switch ch {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				// "**/" also matches zero directories
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// Match reports whether name matches the glob; invalid globs never match
+func Match(glob, name string) bool {
+	re, err := Compile(glob)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(name)
+}
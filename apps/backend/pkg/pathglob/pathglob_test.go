@@ -0,0 +1,28 @@
+package pathglob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		glob string
+		name string
+		want bool
+	}{
+		{"/var/log/**", "/var/log/app.log", true},
+		{"/var/log/**", "/var/log/nginx/access.log", true},
+		{"/var/log/**", "/var/lib/app.db", false},
+		{"**/*.csv", "customers.csv", true},
+		{"**/*.csv", "/data/2024/customers.csv", true},
+		{"/data/*.csv", "/data/2024/customers.csv", false},
+		{"staging-*", "staging-db1", true},
+		{"staging-*", "prod-db1", false},
+		{"db?.internal", "db1.internal", true},
+		{"db?.internal", "db12.internal", false},
+	}
+
+	for _, tt := range tests {
+		if got := Match(tt.glob, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.glob, tt.name, got, tt.want)
+		}
+	}
+}
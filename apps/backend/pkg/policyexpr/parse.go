@@ -0,0 +1,329 @@
+package policyexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string // Operator or identifier text, unquoted string value, or number literal
+	pos  int
+}
+
+// lex splits an expression into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		ch := src[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+
+		case isIdentStart(ch):
+			start := i
+			for i < len(src) && (isIdentStart(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+
+		case isDigit(ch):
+			start := i
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], pos: start})
+
+		case ch == '"' || ch == '\'':
+			start := i
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, fmt.Errorf("unterminated string at position %d", start)
+				}
+				c := src[i]
+				if c == ch {
+					i++
+					break
+				}
+				if c == '\\' && i+1 < len(src) {
+					i++
+					switch src[i] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(src[i])
+					}
+					i++
+					continue
+				}
+				b.WriteByte(c)
+				i++
+			}
+			tokens = append(tokens, token{kind: tokString, text: b.String(), pos: start})
+
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "-", "(", ")", "[", "]", ",", "."} {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", ch, i)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+func isIdentStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// node is a parsed expression
+type node struct {
+	op       string  // "lit", "var", "list", "call", or an operator
+	name     string  // Variable or function name
+	value    Value   // Literal value
+	args     []*node // Operands, list elements or call arguments; a method's receiver is args[0]
+	pos      int
+	resolved Type // Set by the type check
+}
+
+// parser is a recursive descent parser. Precedence, loosest first:
+// ||, &&, comparisons and "in", unary ! and -, method calls.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokOp && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		t := p.peek()
+		return fmt.Errorf("expected %q at position %d", op, t.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (*node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept("||") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &node{op: "||", args: []*node{left, right}, pos: t.pos}
+	}
+}
+
+func (p *parser) parseAnd() (*node, error) {
+	left, err := p.parseRelation()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept("&&") {
+			return left, nil
+		}
+		right, err := p.parseRelation()
+		if err != nil {
+			return nil, err
+		}
+		left = &node{op: "&&", args: []*node{left, right}, pos: t.pos}
+	}
+}
+
+func (p *parser) parseRelation() (*node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	op := ""
+	switch {
+	case t.kind == tokOp && (t.text == "==" || t.text == "!=" || t.text == "<" || t.text == "<=" || t.text == ">" || t.text == ">="):
+		op = t.text
+	case t.kind == tokIdent && t.text == "in":
+		op = "in"
+	default:
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &node{op: op, args: []*node{left, right}, pos: t.pos}, nil
+}
+
+func (p *parser) parseUnary() (*node, error) {
+	t := p.peek()
+	if p.accept("!") || p.accept("-") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{op: "unary" + t.text, args: []*node{operand}, pos: t.pos}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (*node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept(".") {
+			return n, nil
+		}
+		name := p.next()
+		if name.kind != tokIdent {
+			return nil, fmt.Errorf("expected a method name at position %d", name.pos)
+		}
+		args, err := p.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+		n = &node{op: "call", name: name.text, args: append([]*node{n}, args...), pos: t.pos}
+	}
+}
+
+func (p *parser) parsePrimary() (*node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos)
+		}
+		return &node{op: "lit", value: f, pos: t.pos}, nil
+
+	case tokString:
+		return &node{op: "lit", value: t.text, pos: t.pos}, nil
+
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			return &node{op: "lit", value: t.text == "true", pos: t.pos}, nil
+		case "in":
+			return nil, fmt.Errorf("unexpected \"in\" at position %d", t.pos)
+		}
+		if tok := p.peek(); tok.kind == tokOp && tok.text == "(" {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return &node{op: "call", name: t.text, args: args, pos: t.pos}, nil
+		}
+		return &node{op: "var", name: t.text, pos: t.pos}, nil
+
+	case tokOp:
+		switch t.text {
+		case "(":
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expect(")")
+		case "[":
+			list := &node{op: "list", pos: t.pos}
+			if p.accept("]") {
+				return list, nil
+			}
+			for {
+				elem, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				list.args = append(list.args, elem)
+				if p.accept("]") {
+					return list, nil
+				}
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+// parseArgs parses a parenthesised, comma separated argument list
+func (p *parser) parseArgs() ([]*node, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []*node
+	if p.accept(")") {
+		return args, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.accept(")") {
+			return args, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
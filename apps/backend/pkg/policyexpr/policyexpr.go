@@ -0,0 +1,351 @@
+// Package policyexpr compiles and evaluates boolean policy expressions, a small
+// CEL-like language over named variables:
+//
+//	pii_type == "CREDIT_CARD" && lower(environment) != "production" && !("ENCRYPT" in remediations)
+//
+// Supported syntax: string ("..." or '...'), number and boolean literals, list
+// literals ([a, b]), variables, ! && || == != < <= > >= in, unary minus,
+// parentheses, the functions lower(s), upper(s) and size(s|list), and the
+// string methods s.contains(t), s.startsWith(t) and s.endsWith(t).
+//
+// Expressions are type checked against the declared variables when compiled,
+// so a rule referencing an unknown variable or comparing a number with a
+// string is rejected before it is ever evaluated.
+package policyexpr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Type is the type of a variable or sub-expression
+type Type string
+
+// Variable and expression types
+const (
+	String     Type = "string"
+	Number     Type = "number"
+	Bool       Type = "bool"
+	StringList Type = "list<string>"
+	NumberList Type = "list<number>"
+
+	emptyList Type = "list" // An empty list literal, which matches any list type
+)
+
+// Value is a variable value: a string, a number (float64 or int), a bool, or a []string or []float64
+type Value = interface{}
+
+// Program is a compiled, type checked expression
+type Program struct {
+	source string
+	root   *node
+}
+
+// Compile parses an expression and checks it against the declared variables.
+// The expression must evaluate to a bool.
+func Compile(source string, decls map[string]Type) (*Program, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+	}
+
+	resolved, err := check(root, decls)
+	if err != nil {
+		return nil, err
+	}
+	if resolved != Bool {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not a %s", resolved)
+	}
+	return &Program{source: source, root: root}, nil
+}
+
+// String returns the source of the expression
+func (p *Program) String() string {
+	return p.source
+}
+
+// Eval evaluates the expression with the given variable values
+func (p *Program) Eval(vars map[string]Value) (bool, error) {
+	v, err := eval(p.root, vars)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// functions maps each function and method to its result type given its argument types.
+// A method's receiver is its first argument.
+var functions = map[string]func(args []Type) (Type, bool){
+	"lower":      stringFunc,
+	"upper":      stringFunc,
+	"contains":   stringPredicate,
+	"startsWith": stringPredicate,
+	"endsWith":   stringPredicate,
+	"size": func(args []Type) (Type, bool) {
+		return Number, len(args) == 1 && (args[0] == String || isList(args[0]))
+	},
+}
+
+func stringFunc(args []Type) (Type, bool) {
+	return String, len(args) == 1 && args[0] == String
+}
+
+func stringPredicate(args []Type) (Type, bool) {
+	return Bool, len(args) == 2 && args[0] == String && args[1] == String
+}
+
+func isList(t Type) bool {
+	return t == StringList || t == NumberList || t == emptyList
+}
+
+// check resolves the type of every node, rejecting unknown names and mismatched operands
+func check(n *node, decls map[string]Type) (Type, error) {
+	argTypes := make([]Type, len(n.args))
+	for i, arg := range n.args {
+		t, err := check(arg, decls)
+		if err != nil {
+			return "", err
+		}
+		argTypes[i] = t
+	}
+
+	var resolved Type
+	switch n.op {
+	case "lit":
+		switch n.value.(type) {
+		case string:
+			resolved = String
+		case float64:
+			resolved = Number
+		case bool:
+			resolved = Bool
+		}
+
+	case "var":
+		t, ok := decls[n.name]
+		if !ok {
+			return "", fmt.Errorf("unknown variable %q at position %d", n.name, n.pos)
+		}
+		resolved = t
+
+	case "list":
+		resolved = emptyList
+		for i, t := range argTypes {
+			if t != String && t != Number {
+				return "", fmt.Errorf("list elements must be strings or numbers at position %d", n.args[i].pos)
+			}
+			if i > 0 && t != argTypes[0] {
+				return "", fmt.Errorf("list elements must all have the same type at position %d", n.args[i].pos)
+			}
+			resolved = Type("list<" + string(t) + ">")
+		}
+
+	case "call":
+		fn, ok := functions[n.name]
+		if !ok {
+			return "", fmt.Errorf("unknown function %q at position %d", n.name, n.pos)
+		}
+		t, ok := fn(argTypes)
+		if !ok {
+			return "", fmt.Errorf("invalid arguments to %s at position %d", n.name, n.pos)
+		}
+		resolved = t
+
+	case "unary!":
+		if argTypes[0] != Bool {
+			return "", fmt.Errorf("! needs a bool at position %d", n.pos)
+		}
+		resolved = Bool
+
+	case "unary-":
+		if argTypes[0] != Number {
+			return "", fmt.Errorf("- needs a number at position %d", n.pos)
+		}
+		resolved = Number
+
+	case "&&", "||":
+		if argTypes[0] != Bool || argTypes[1] != Bool {
+			return "", fmt.Errorf("%s needs bools on both sides at position %d", n.op, n.pos)
+		}
+		resolved = Bool
+
+	case "==", "!=":
+		if argTypes[0] != argTypes[1] || isList(argTypes[0]) {
+			return "", fmt.Errorf("cannot compare %s with %s at position %d", argTypes[0], argTypes[1], n.pos)
+		}
+		resolved = Bool
+
+	case "<", "<=", ">", ">=":
+		if argTypes[0] != argTypes[1] || (argTypes[0] != Number && argTypes[0] != String) {
+			return "", fmt.Errorf("cannot order %s and %s at position %d", argTypes[0], argTypes[1], n.pos)
+		}
+		resolved = Bool
+
+	case "in":
+		if argTypes[1] != emptyList && argTypes[1] != Type("list<"+string(argTypes[0])+">") {
+			return "", fmt.Errorf("cannot look for a %s in a %s at position %d", argTypes[0], argTypes[1], n.pos)
+		}
+		resolved = Bool
+	}
+
+	n.resolved = resolved
+	return resolved, nil
+}
+
+// eval evaluates a checked node. Strings evaluate to string, numbers to float64,
+// bools to bool and lists to []Value.
+func eval(n *node, vars map[string]Value) (Value, error) {
+	switch n.op {
+	case "lit":
+		return n.value, nil
+
+	case "var":
+		v, ok := vars[n.name]
+		if !ok {
+			return nil, fmt.Errorf("variable %q has no value", n.name)
+		}
+		return normalize(n.name, v, n.resolved)
+
+	case "&&", "||":
+		left, err := eval(n.args[0], vars)
+		if err != nil {
+			return nil, err
+		}
+		// Short circuit
+		if left.(bool) == (n.op == "||") {
+			return left, nil
+		}
+		return eval(n.args[1], vars)
+	}
+
+	args := make([]Value, len(n.args))
+	for i, arg := range n.args {
+		v, err := eval(arg, vars)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	switch n.op {
+	case "list":
+		return args, nil
+	case "unary!":
+		return !args[0].(bool), nil
+	case "unary-":
+		return -args[0].(float64), nil
+	case "==":
+		return args[0] == args[1], nil
+	case "!=":
+		return args[0] != args[1], nil
+	case "<", "<=", ">", ">=":
+		return compare(n.op, args[0], args[1]), nil
+	case "in":
+		for _, elem := range args[1].([]Value) {
+			if elem == args[0] {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "call":
+		return call(n.name, args), nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", n.op)
+}
+
+func compare(op string, a, b Value) bool {
+	var cmp int
+	if x, ok := a.(float64); ok {
+		y := b.(float64)
+		switch {
+		case x < y:
+			cmp = -1
+		case x > y:
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(a.(string), b.(string))
+	}
+
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+func call(name string, args []Value) Value {
+	switch name {
+	case "lower":
+		return strings.ToLower(args[0].(string))
+	case "upper":
+		return strings.ToUpper(args[0].(string))
+	case "contains":
+		return strings.Contains(args[0].(string), args[1].(string))
+	case "startsWith":
+		return strings.HasPrefix(args[0].(string), args[1].(string))
+	case "endsWith":
+		return strings.HasSuffix(args[0].(string), args[1].(string))
+	default: // size
+		if s, ok := args[0].(string); ok {
+			return float64(len([]rune(s)))
+		}
+		return float64(len(args[0].([]Value)))
+	}
+}
+
+// normalize converts a variable value to its evaluation representation, checking it has the declared type
+func normalize(name string, v Value, t Type) (Value, error) {
+	var out Value
+	switch x := v.(type) {
+	case string:
+		if t == String {
+			out = x
+		}
+	case bool:
+		if t == Bool {
+			out = x
+		}
+	case float64:
+		if t == Number {
+			out = x
+		}
+	case int:
+		if t == Number {
+			out = float64(x)
+		}
+	case []string:
+		if t == StringList {
+			list := make([]Value, len(x))
+			for i, s := range x {
+				list[i] = s
+			}
+			out = list
+		}
+	case []float64:
+		if t == NumberList {
+			list := make([]Value, len(x))
+			for i, f := range x {
+				list[i] = f
+			}
+			out = list
+		}
+	}
+	if out == nil {
+		return nil, fmt.Errorf("variable %q is a %T, not a %s", name, v, t)
+	}
+	return out, nil
+}
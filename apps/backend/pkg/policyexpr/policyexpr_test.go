@@ -0,0 +1,95 @@
+package policyexpr
+
+import (
+	"strings"
+	"testing"
+)
+
+var testDecls = map[string]Type{
+	"pii_type":     String,
+	"environment":  String,
+	"age_days":     Number,
+	"remediated":   Bool,
+	"remediations": StringList,
+}
+
+func TestEval(t *testing.T) {
+	vars := map[string]Value{
+		"pii_type":     "CREDIT_CARD",
+		"environment":  "Staging",
+		"age_days":     9,
+		"remediated":   false,
+		"remediations": []string{"MASK"},
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`pii_type == "CREDIT_CARD" && lower(environment) != "production" && !("ENCRYPT" in remediations) && age_days > 7`, true},
+		{`pii_type == 'CREDIT_CARD' && "MASK" in remediations`, true},
+		{`pii_type in ["AADHAAR", "PAN"]`, false},
+		{`age_days >= 9 && age_days < 9.5 && -age_days < 0`, true},
+		{`remediated || environment.startsWith("Stag")`, true},
+		{`!remediated && (pii_type.contains("CARD") || false)`, true},
+		{`size(remediations) == 0 || upper(environment).endsWith("PROD")`, false},
+		{`environment in []`, false},
+	}
+
+	for _, tt := range tests {
+		p, err := Compile(tt.expr, testDecls)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", tt.expr, err)
+			continue
+		}
+		got, err := p.Eval(vars)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvalShortCircuits(t *testing.T) {
+	p, err := Compile(`remediated && pii_type == "PAN"`, testDecls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pii_type is never read when remediated is false
+	if got, err := p.Eval(map[string]Value{"remediated": false}); err != nil || got {
+		t.Errorf("expected false without error, got %v, %v", got, err)
+	}
+	if _, err := p.Eval(map[string]Value{"remediated": true}); err == nil {
+		t.Error("expected an error for the missing variable")
+	}
+}
+
+func TestCompileRejectsInvalidExpressions(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`pii_typ == "PAN"`, "unknown variable"},
+		{`age_days == "7"`, "cannot compare"},
+		{`pii_type`, "must evaluate to a bool"},
+		{`!pii_type`, "needs a bool"},
+		{`age_days in ["7"]`, "cannot look for"},
+		{`["a", 1] == remediations`, "same type"},
+		{`matches(pii_type, ".*")`, "unknown function"},
+		{`lower(age_days) == "7"`, "invalid arguments"},
+		{`pii_type == "PAN`, "unterminated string"},
+		{`pii_type == "PAN" &&`, "unexpected end"},
+		{`pii_type == "PAN" == true`, "unexpected"},
+		{`pii_type = "PAN"`, "unexpected character"},
+	}
+
+	for _, tt := range tests {
+		_, err := Compile(tt.expr, testDecls)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Compile(%q): expected an error containing %q, got %v", tt.expr, tt.want, err)
+		}
+	}
+}
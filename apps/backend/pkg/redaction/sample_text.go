@@ -0,0 +1,97 @@
+// Package redaction masks the context excerpts stored with findings so PII is not
+// kept at rest beyond the match itself
+package redaction
+
+import (
+	"unicode"
+)
+
+// Sample text policies
+const (
+	// PolicyMask masks every match and long digit run in the sample, keeping the
+	// surrounding context readable
+	PolicyMask = "mask"
+	// PolicyHashOnly stores no sample, only its hash
+	PolicyHashOnly = "hash_only"
+	// PolicyFull stores the sample as scanned
+	PolicyFull = "full"
+)
+
+// minDigitRun is the shortest digit run masked even when it is not a listed match,
+// catching account and ID numbers next to the detected value
+const minDigitRun = 6
+
+// IsPolicy reports whether policy is a sample text policy
+func IsPolicy(policy string) bool {
+	switch policy {
+	case PolicyMask, PolicyHashOnly, PolicyFull:
+		return true
+	}
+	return false
+}
+
+// Sample is a sample text prepared for storage under a policy
+type Sample struct {
+	Text     string // Stored text; empty under PolicyHashOnly
+	SHA256   string // Hex SHA-256 of the raw text; empty for an empty sample
+	Redacted bool   // Text was prepared under a policy other than PolicyFull
+}
+
+// Apply prepares a raw sample for storage. Unknown policies mask.
+func Apply(policy, sample string, matches []string) Sample {
+	return Templates(nil).Apply(policy, "", sample, matches)
+}
+
+// MaskSample masks each occurrence of the matches, then any remaining long digit
+// run. Masking keeps separators and, for values of ten or more letters and digits,
+// the last four, so masked text still reads like the original. It is idempotent.
+func MaskSample(sample string, matches []string) string {
+	return Templates(nil).MaskSample("", sample, matches)
+}
+
+// MaskValue masks the letters and digits of a value, keeping separators and, for
+// values with ten or more letters and digits, the last four
+func MaskValue(value string) string {
+	runes := []rune(value)
+	alnum := 0
+	for _, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			alnum++
+		}
+	}
+	keep := 0
+	if alnum >= 10 {
+		keep = 4
+	}
+
+	seen := 0
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		seen++
+		if seen <= alnum-keep {
+			runes[i] = 'X'
+		}
+	}
+	return string(runes)
+}
+
+// maskDigitRuns masks every run of at least minDigitRun digits
+func maskDigitRuns(text string) string {
+	runes := []rune(text)
+	start := -1
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && unicode.IsDigit(runes[i]) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && i-start >= minDigitRun {
+			copy(runes[start:i], []rune(MaskValue(string(runes[start:i]))))
+		}
+		start = -1
+	}
+	return string(runes)
+}
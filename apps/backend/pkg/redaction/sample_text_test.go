@@ -0,0 +1,70 @@
+package redaction
+
+import "testing"
+
+func TestMaskSample(t *testing.T) {
+	cases := []struct {
+		name    string
+		sample  string
+		matches []string
+		want    string
+	}{
+		{
+			name:    "aadhaar keeps last four",
+			sample:  "aadhaar: 2345 6789 0123, verified",
+			matches: []string{"2345 6789 0123"},
+			want:    "aadhaar: XXXX XXXX 0123, verified",
+		},
+		{
+			name:    "email keeps separators",
+			sample:  "contact ravi.k@example.in today",
+			matches: []string{"ravi.k@example.in"},
+			want:    "contact XXXX.X@XXXXXle.in today",
+		},
+		{
+			name:    "unlisted account number is masked",
+			sample:  "pan ABCDE1234F acct 00123456789",
+			matches: []string{"ABCDE1234F"},
+			want:    "pan XXXXXX234F acct XXXXXXX6789",
+		},
+		{
+			name:    "short value is fully masked",
+			sample:  "pin 560001",
+			matches: []string{"560001"},
+			want:    "pin XXXXXX",
+		},
+		{
+			name:   "short numbers are kept",
+			sample: "row 42 of 2024",
+			want:   "row 42 of 2024",
+		},
+	}
+	for _, tc := range cases {
+		got := MaskSample(tc.sample, tc.matches)
+		if got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+		if again := MaskSample(got, tc.matches); again != got {
+			t.Errorf("%s: masking is not idempotent: %q", tc.name, again)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	sample := "phone 9876543210"
+	matches := []string{"9876543210"}
+
+	masked := Apply(PolicyMask, sample, matches)
+	if masked.Text != "phone XXXXXX3210" || !masked.Redacted || len(masked.SHA256) != 64 {
+		t.Errorf("unexpected masked sample: %+v", masked)
+	}
+	if full := Apply(PolicyFull, sample, matches); full.Text != sample || full.Redacted || full.SHA256 != masked.SHA256 {
+		t.Errorf("unexpected full sample: %+v", full)
+	}
+	if hashed := Apply(PolicyHashOnly, sample, matches); hashed.Text != "" || !hashed.Redacted || hashed.SHA256 != masked.SHA256 {
+		t.Errorf("unexpected hash-only sample: %+v", hashed)
+	}
+	if empty := Apply(PolicyMask, "", matches); empty != (Sample{}) {
+		t.Errorf("an empty sample should stay empty, got %+v", empty)
+	}
+}
@@ -0,0 +1,284 @@
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Masking template styles
+const (
+	// StylePartial masks letters and digits, keeping KeepFirst and KeepLast of them
+	// and every separator, so the value keeps its length and shape
+	StylePartial = "partial"
+	// StyleEmail masks the local part of an address as StylePartial would and keeps
+	// the domain
+	StyleEmail = "email"
+	// StyleFormat lays the value's letters and digits into Format, where X is masked,
+	// # is kept and any other character is written as is
+	StyleFormat = "format"
+	// StyleRedact replaces the whole value with Replacement
+	StyleRedact = "redact"
+)
+
+const (
+	defaultMaskChar    = "X"
+	defaultReplacement = "[REDACTED]"
+	// maxKept bounds KeepFirst and KeepLast, and the #s of a format
+	maxKept = 6
+	// minMasked is the fewest characters masked when any are kept
+	minMasked = 4
+)
+
+// Template describes how values of one PII type are masked
+type Template struct {
+	Style       string `json:"style"`
+	KeepFirst   int    `json:"keep_first,omitempty"`
+	KeepLast    int    `json:"keep_last,omitempty"`
+	Format      string `json:"format,omitempty"`
+	MaskChar    string `json:"mask_char,omitempty"`   // One character; X when empty
+	Replacement string `json:"replacement,omitempty"` // StyleRedact only; [REDACTED] when empty
+}
+
+// Validate reports whether the template can be applied
+func (t Template) Validate() error {
+	switch t.Style {
+	case StylePartial, StyleEmail, StyleRedact:
+	case StyleFormat:
+		masked, kept := formatSlots(t.Format)
+		if masked == 0 {
+			return fmt.Errorf("invalid format %q: needs at least one X", t.Format)
+		}
+		if kept > maxKept || kept > masked {
+			return fmt.Errorf("invalid format %q: keeps more characters than it masks", t.Format)
+		}
+	default:
+		return fmt.Errorf("invalid style %q: must be partial, email, format or redact", t.Style)
+	}
+	if t.KeepFirst < 0 || t.KeepLast < 0 || t.KeepFirst > maxKept || t.KeepLast > maxKept {
+		return fmt.Errorf("invalid keep_first/keep_last: must be 0 to %d", maxKept)
+	}
+	if t.MaskChar != "" && utf8.RuneCountInString(t.MaskChar) != 1 {
+		return fmt.Errorf("invalid mask_char %q: must be one character", t.MaskChar)
+	}
+	return nil
+}
+
+// Mask masks value under the template. Nothing is kept of values too short to
+// keep the requested characters while masking at least four.
+func (t Template) Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+	switch t.Style {
+	case StyleRedact:
+		if t.Replacement != "" {
+			return t.Replacement
+		}
+		return defaultReplacement
+	case StyleEmail:
+		at := strings.LastIndex(value, "@")
+		if at < 0 {
+			return t.maskPartial(value)
+		}
+		return t.maskPartial(value[:at]) + value[at:]
+	case StyleFormat:
+		return t.maskFormat(value)
+	default:
+		return t.maskPartial(value)
+	}
+}
+
+func (t Template) maskChar() rune {
+	if t.MaskChar == "" {
+		return []rune(defaultMaskChar)[0]
+	}
+	return []rune(t.MaskChar)[0]
+}
+
+func (t Template) maskPartial(value string) string {
+	runes := []rune(value)
+	alnum := countAlnum(runes)
+	first, last := t.KeepFirst, t.KeepLast
+	if alnum-first-last < minMasked {
+		first, last = 0, 0
+	}
+
+	mask := t.maskChar()
+	seen := 0
+	for i, r := range runes {
+		if !isAlnum(r) {
+			continue
+		}
+		seen++
+		if seen > first && seen <= alnum-last {
+			runes[i] = mask
+		}
+	}
+	return string(runes)
+}
+
+// maskFormat fills the format when the value has exactly as many letters and
+// digits as it has slots; other values are masked keeping as many trailing
+// characters as the format ends with
+func (t Template) maskFormat(value string) string {
+	chars := make([]rune, 0, len(value))
+	for _, r := range value {
+		if isAlnum(r) {
+			chars = append(chars, r)
+		}
+	}
+	masked, kept := formatSlots(t.Format)
+	if len(chars) != masked+kept {
+		_, trailing := formatSlots(t.Format[strings.LastIndex(t.Format, "X")+1:])
+		return Template{Style: StylePartial, KeepLast: trailing, MaskChar: t.MaskChar}.maskPartial(value)
+	}
+
+	mask := t.maskChar()
+	var b strings.Builder
+	next := 0
+	for _, r := range t.Format {
+		switch r {
+		case 'X':
+			b.WriteRune(mask)
+			next++
+		case '#':
+			b.WriteRune(chars[next])
+			next++
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// formatSlots counts the masked (X) and kept (#) slots of a format
+func formatSlots(format string) (masked, kept int) {
+	for _, r := range format {
+		switch r {
+		case 'X':
+			masked++
+		case '#':
+			kept++
+		}
+	}
+	return masked, kept
+}
+
+func isAlnum(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func countAlnum(runes []rune) int {
+	n := 0
+	for _, r := range runes {
+		if isAlnum(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// Templates maps PII types to their masking template. Types without one are
+// masked by MaskValue.
+type Templates map[string]Template
+
+// DefaultTemplates returns the built-in templates
+func DefaultTemplates() Templates {
+	return Templates{
+		"CREDIT_CARD":     {Style: StylePartial, KeepLast: 4},
+		"EMAIL_ADDRESS":   {Style: StyleEmail, KeepFirst: 1},
+		"IN_AADHAAR":      {Style: StyleFormat, Format: "XXXX-XXXX-####"},
+		"IN_PAN":          {Style: StylePartial, KeepLast: 4},
+		"IN_PHONE":        {Style: StylePartial, KeepLast: 4},
+		"IN_UPI":          {Style: StyleEmail, KeepFirst: 1},
+		"IN_BANK_ACCOUNT": {Style: StylePartial, KeepLast: 4},
+	}
+}
+
+// piiTypeAliases maps scanner pattern names to the PII type whose template applies
+var piiTypeAliases = map[string]string{
+	"AADHAAR":             "IN_AADHAAR",
+	"AADHAR":              "IN_AADHAAR",
+	"PAN":                 "IN_PAN",
+	"EMAIL":               "EMAIL_ADDRESS",
+	"PHONE":               "IN_PHONE",
+	"PHONE_NUMBER":        "IN_PHONE",
+	"INDIAN_PHONE_NUMBER": "IN_PHONE",
+	"UPI":                 "IN_UPI",
+	"BANK_ACCOUNT":        "IN_BANK_ACCOUNT",
+}
+
+// CanonicalPIIType returns the PII type a type or pattern name is masked as
+func CanonicalPIIType(piiType string) string {
+	key := strings.ToUpper(strings.TrimSpace(piiType))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	if alias, ok := piiTypeAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// With returns the templates with overrides replacing templates of the same type
+func (ts Templates) With(overrides Templates) Templates {
+	merged := make(Templates, len(ts)+len(overrides))
+	for piiType, t := range ts {
+		merged[CanonicalPIIType(piiType)] = t
+	}
+	for piiType, t := range overrides {
+		merged[CanonicalPIIType(piiType)] = t
+	}
+	return merged
+}
+
+// Mask masks a value of piiType with its template, or with MaskValue if it has none
+func (ts Templates) Mask(piiType, value string) string {
+	if t, ok := ts[CanonicalPIIType(piiType)]; ok {
+		return t.Mask(value)
+	}
+	return MaskValue(value)
+}
+
+// MaskSample masks each occurrence of the matches as values of piiType, then any
+// remaining long digit run
+func (ts Templates) MaskSample(piiType, sample string, matches []string) string {
+	// Longest first, so a match containing a shorter one is masked whole
+	ordered := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if strings.TrimSpace(m) != "" {
+			ordered = append(ordered, m)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return len(ordered[i]) > len(ordered[j]) })
+
+	masked := sample
+	for _, m := range ordered {
+		masked = strings.ReplaceAll(masked, m, ts.Mask(piiType, m))
+	}
+	return maskDigitRuns(masked)
+}
+
+// Apply prepares a raw sample of piiType for storage under policy, masking its
+// matches with the templates. Unknown policies mask.
+func (ts Templates) Apply(policy, piiType, sample string, matches []string) Sample {
+	if sample == "" {
+		return Sample{}
+	}
+	sum := sha256.Sum256([]byte(sample))
+	prepared := Sample{SHA256: hex.EncodeToString(sum[:])}
+
+	switch policy {
+	case PolicyFull:
+		prepared.Text = sample
+		return prepared
+	case PolicyHashOnly:
+	default:
+		prepared.Text = ts.MaskSample(piiType, sample, matches)
+	}
+	prepared.Redacted = true
+	return prepared
+}
@@ -0,0 +1,76 @@
+package redaction
+
+import "testing"
+
+func TestTemplateMask(t *testing.T) {
+	cases := []struct {
+		name     string
+		template Template
+		value    string
+		want     string
+	}{
+		{"card keeps last four", Template{Style: StylePartial, KeepLast: 4}, "4111 1111 1111 1234", "XXXX XXXX XXXX 1234"},
+		{"email keeps domain", Template{Style: StyleEmail, KeepFirst: 1}, "ravi.k@example.in", "rXXX.X@example.in"},
+		{"aadhaar fills format", Template{Style: StyleFormat, Format: "XXXX-XXXX-####"}, "234567890123", "XXXX-XXXX-0123"},
+		{"aadhaar separators replaced", Template{Style: StyleFormat, Format: "XXXX-XXXX-####"}, "2345 6789 0123", "XXXX-XXXX-0123"},
+		{"format mismatch keeps trailing", Template{Style: StyleFormat, Format: "XXXX-XXXX-####"}, "23456789", "XXXX6789"},
+		{"short value keeps nothing", Template{Style: StylePartial, KeepFirst: 2, KeepLast: 4}, "98765", "XXXXX"},
+		{"mask char", Template{Style: StylePartial, KeepLast: 4, MaskChar: "*"}, "9876543210", "******3210"},
+		{"redact", Template{Style: StyleRedact}, "ABCDE1234F", "[REDACTED]"},
+		{"redact replacement", Template{Style: StyleRedact, Replacement: "<pan>"}, "ABCDE1234F", "<pan>"},
+	}
+
+	for _, tc := range cases {
+		if got := tc.template.Mask(tc.value); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTemplateValidate(t *testing.T) {
+	valid := []Template{
+		{Style: StylePartial, KeepLast: 4},
+		{Style: StyleEmail},
+		{Style: StyleFormat, Format: "XXXX-XXXX-####"},
+		{Style: StyleRedact, Replacement: "-"},
+	}
+	for _, tmpl := range valid {
+		if err := tmpl.Validate(); err != nil {
+			t.Errorf("%+v: unexpected error %v", tmpl, err)
+		}
+	}
+
+	invalid := []Template{
+		{Style: "shuffle"},
+		{Style: StylePartial, KeepLast: 12},
+		{Style: StylePartial, KeepFirst: -1},
+		{Style: StyleFormat, Format: "####"},
+		{Style: StyleFormat, Format: "X-####"},
+		{Style: StylePartial, MaskChar: "**"},
+	}
+	for _, tmpl := range invalid {
+		if err := tmpl.Validate(); err == nil {
+			t.Errorf("%+v: expected a validation error", tmpl)
+		}
+	}
+}
+
+func TestTemplatesMaskSample(t *testing.T) {
+	templates := DefaultTemplates().With(Templates{"credit card": {Style: StyleRedact}})
+
+	got := templates.MaskSample("Aadhar", "uid 2345 6789 0123 ok", []string{"2345 6789 0123"})
+	if got != "uid XXXX-XXXX-0123 ok" {
+		t.Errorf("expected the aadhaar template by pattern name, got %q", got)
+	}
+
+	got = templates.MaskSample("CREDIT_CARD", "card 4111111111111234", []string{"4111111111111234"})
+	if got != "card [REDACTED]" {
+		t.Errorf("expected the overriding card template, got %q", got)
+	}
+
+	// Types without a template mask as before, and unlisted digit runs are still masked
+	got = templates.MaskSample("IN_PASSPORT", "passport K1234567 acct 00123456789", []string{"K1234567"})
+	if got != MaskSample("passport K1234567 acct 00123456789", []string{"K1234567"}) {
+		t.Errorf("expected default masking for a type without a template, got %q", got)
+	}
+}
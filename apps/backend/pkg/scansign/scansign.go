@@ -0,0 +1,100 @@
+// Package scansign signs and verifies the scan payloads scanners send to the
+// ingestion API. A signature is Ed25519 over Message, which commits to the
+// SHA-256 of the request body, so a payload can be verified as it streams.
+package scansign
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// Request headers carrying a payload's signature
+const (
+	HeaderKeyID     = "X-Scan-Key-Id"
+	HeaderSignature = "X-Scan-Signature"
+)
+
+// Context prefixes every signed message, so scan signatures cannot be replayed
+// as signatures over anything else
+const Context = "arc-hawk-scan-v1"
+
+// ErrInvalidPublicKey reports a public key that is not an Ed25519 key
+var ErrInvalidPublicKey = errors.New("public key must be a base64 Ed25519 key or a PEM public key")
+
+// Message is what a scanner signs for a body with the given SHA-256 digest:
+// the context, a newline and the hex digest
+func Message(digest []byte) []byte {
+	return []byte(Context + "\n" + hex.EncodeToString(digest))
+}
+
+// Sign returns the base64 signature of body to send in HeaderSignature
+func Sign(key ed25519.PrivateKey, body []byte) string {
+	digest := sha256.Sum256(body)
+	return base64.StdEncoding.EncodeToString(ed25519.Sign(key, Message(digest[:])))
+}
+
+// ParsePublicKey reads a raw 32-byte Ed25519 key in base64, or a PEM encoded
+// PKIX public key
+func ParsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	encoded = strings.TrimSpace(encoded)
+	if block, _ := pem.Decode([]byte(encoded)); block != nil {
+		if block.Type != "PUBLIC KEY" {
+			return nil, ErrInvalidPublicKey
+		}
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+		}
+		key, ok := parsed.(ed25519.PublicKey)
+		if !ok {
+			return nil, ErrInvalidPublicKey
+		}
+		return key, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// Fingerprint identifies a public key: the hex SHA-256 of its raw bytes
+func Fingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verifier checks a signature against a body written to it
+type Verifier struct {
+	key       ed25519.PublicKey
+	signature []byte
+	digest    hash.Hash
+}
+
+// NewVerifier prepares the check of a base64 signature made with key
+func NewVerifier(key ed25519.PublicKey, signature string) (*Verifier, error) {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, errors.New("signature must be a base64 Ed25519 signature")
+	}
+	return &Verifier{key: key, signature: sig, digest: sha256.New()}, nil
+}
+
+// Write adds body bytes to the digest
+func (v *Verifier) Write(p []byte) (int, error) {
+	return v.digest.Write(p)
+}
+
+// Verify reports whether the signature matches the body written so far
+func (v *Verifier) Verify() bool {
+	return ed25519.Verify(v.key, Message(v.digest.Sum(nil)), v.signature)
+}
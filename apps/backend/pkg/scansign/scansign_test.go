@@ -0,0 +1,88 @@
+package scansign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSignAndVerifyStreamed(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"scan_id": "scan-1", "findings": [{"pii_type": "IN_PAN"}]}`)
+	signature := Sign(private, body)
+
+	verifier, err := NewVerifier(public, signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Read in small pieces, as a streamed request body is
+	if _, err := io.CopyBuffer(verifier, bytes.NewReader(body), make([]byte, 7)); err != nil {
+		t.Fatal(err)
+	}
+	if !verifier.Verify() {
+		t.Fatal("signature over the body did not verify")
+	}
+
+	tampered, _ := NewVerifier(public, signature)
+	tampered.Write(bytes.Replace(body, []byte("IN_PAN"), []byte("IN_GST"), 1))
+	if tampered.Verify() {
+		t.Error("signature verified a modified body")
+	}
+
+	other, _, _ := ed25519.GenerateKey(nil)
+	wrongKey, _ := NewVerifier(other, signature)
+	wrongKey.Write(body)
+	if wrongKey.Verify() {
+		t.Error("signature verified with another scanner's key")
+	}
+
+	// A plain Ed25519 signature over the body is not a scan signature
+	plain := base64.StdEncoding.EncodeToString(ed25519.Sign(private, body))
+	unprefixed, _ := NewVerifier(public, plain)
+	unprefixed.Write(body)
+	if unprefixed.Verify() {
+		t.Error("signature without the scan context verified")
+	}
+}
+
+func TestNewVerifierRejectsMalformedSignatures(t *testing.T) {
+	public, _, _ := ed25519.GenerateKey(nil)
+	for _, signature := range []string{"", "not base64!", base64.StdEncoding.EncodeToString([]byte("short"))} {
+		if _, err := NewVerifier(public, signature); err == nil {
+			t.Errorf("signature %q accepted", signature)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	public, _, _ := ed25519.GenerateKey(nil)
+
+	raw, err := ParsePublicKey(base64.StdEncoding.EncodeToString(public))
+	if err != nil || !raw.Equal(public) {
+		t.Errorf("base64 key: %v", err)
+	}
+
+	der, _ := x509.MarshalPKIXPublicKey(public)
+	encoded := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	parsed, err := ParsePublicKey("\n" + string(encoded))
+	if err != nil || !parsed.Equal(public) {
+		t.Errorf("PEM key: %v", err)
+	}
+	if Fingerprint(parsed) != Fingerprint(public) || len(Fingerprint(public)) != 64 {
+		t.Error("fingerprint differs between encodings of the same key")
+	}
+
+	for _, bad := range []string{"", "abc", base64.StdEncoding.EncodeToString(make([]byte, 31)), strings.Replace(string(encoded), "PUBLIC", "PRIVATE", 2)} {
+		if _, err := ParsePublicKey(bad); err == nil {
+			t.Errorf("key %q accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package synthetic produces format-preserving stand-ins for PII values: fresh
+// values for test data, and keyed replacements of real values for anonymized
+// exports
+package synthetic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/arc-platform/backend/pkg/normalization"
+	"github.com/arc-platform/backend/pkg/validation"
+)
+
+// templates shape generated values: '#' is a digit, 'A' an upper-case and 'a' a
+// lower-case letter; anything else is kept as is
+var templates = map[string]string{
+	"IN_AADHAAR":         "#### #### ####",
+	"IN_PAN":             "AAAPA####A",
+	"CREDIT_CARD":        "#### #### #### ####",
+	"IN_PHONE":           "+91 9#### #####",
+	"EMAIL_ADDRESS":      "aaaaaaaa@example.com",
+	"IN_PASSPORT":        "A#######",
+	"IN_DRIVING_LICENSE": "AA## ###########",
+	"IN_VOTER_ID":        "AAA#######",
+	"IN_BANK_ACCOUNT":    "############",
+	"IN_UPI":             "aaaaaaaa@aaaa",
+	"IN_IFSC":            "AAAA0######",
+}
+
+const defaultTemplate = "aaaaaaaaaa"
+
+// maxAttempts bounds the rederivations when a replacement collides
+const maxAttempts = 64
+
+// Generate returns a fresh synthetic value of a PII type drawn from r. Aadhaar
+// numbers and card numbers carry valid check digits.
+func Generate(piiType string, r *rand.Rand) string {
+	template, ok := templates[piiType]
+	if !ok {
+		template = defaultTemplate
+	}
+
+	out := make([]rune, 0, len(template))
+	for _, c := range template {
+		switch c {
+		case '#':
+			out = append(out, rune('0'+r.Intn(10)))
+		case 'A':
+			out = append(out, rune('A'+r.Intn(26)))
+		case 'a':
+			out = append(out, rune('a'+r.Intn(26)))
+		default:
+			out = append(out, c)
+		}
+	}
+	return string(fixChecksum(piiType, out))
+}
+
+// Anonymizer replaces PII values with synthetic values of the same shape. A
+// replacement is derived from the value with a secret key, so values equal after
+// normalization get equal replacements across findings and exports made with the
+// same key, and distinct values get distinct replacements. No value is its own
+// replacement.
+type Anonymizer struct {
+	key []byte
+
+	mu       sync.Mutex
+	replaced map[string]string // type and canonical value -> canonical replacement
+	taken    map[string]bool   // type and canonical replacement
+}
+
+// NewAnonymizer creates an anonymizer keyed by key
+func NewAnonymizer(key []byte) *Anonymizer {
+	return &Anonymizer{
+		key:      key,
+		replaced: make(map[string]string),
+		taken:    make(map[string]bool),
+	}
+}
+
+// Replace returns the synthetic replacement of a value of a PII type, keeping
+// its separators, letter case and length
+func (a *Anonymizer) Replace(piiType, value string) string {
+	canonical := normalization.NormalizeForDedup(value)
+	if canonical == "" {
+		return value
+	}
+	return applyShape(value, a.canonicalReplacement(piiType, canonical))
+}
+
+func (a *Anonymizer) canonicalReplacement(piiType, canonical string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	id := piiType + "\x00" + canonical
+	if replacement, ok := a.replaced[id]; ok {
+		return replacement
+	}
+
+	var replacement string
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		replacement = a.derive(piiType, canonical, attempt)
+		if replacement != canonical && !a.taken[piiType+"\x00"+replacement] {
+			break
+		}
+	}
+	a.replaced[id] = replacement
+	a.taken[piiType+"\x00"+replacement] = true
+	return replacement
+}
+
+// derive substitutes every digit and letter of the canonical value from a
+// generator seeded by the keyed hash of the value
+func (a *Anonymizer) derive(piiType, canonical string, attempt int) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(piiType))
+	mac.Write([]byte{0})
+	mac.Write([]byte(canonical))
+	mac.Write([]byte{0, byte(attempt)})
+	r := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(mac.Sum(nil)))))
+
+	out := []rune(canonical)
+	for i, c := range out {
+		switch {
+		case unicode.IsDigit(c):
+			out[i] = rune('0' + r.Intn(10))
+		case unicode.IsLetter(c):
+			out[i] = rune('a' + r.Intn(26))
+		}
+	}
+	return string(fixChecksum(piiType, out))
+}
+
+// applyShape lays the canonical replacement over the original value: characters
+// the normalization drops stay in place, the rest are taken in order with the
+// original's letter case
+func applyShape(original, replacement string) string {
+	repl := []rune(replacement)
+	var sb strings.Builder
+	i := 0
+	for _, c := range original {
+		if normalization.NormalizeForDedup(string(c)) == "" || i >= len(repl) {
+			sb.WriteRune(c)
+			continue
+		}
+		r := repl[i]
+		i++
+		if unicode.IsUpper(c) {
+			r = unicode.ToUpper(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// fixChecksum makes the digits of an Aadhaar or card number valid: Aadhaar
+// numbers do not start with 0 or 1 and end in a Verhoeff check digit, card
+// numbers end in a Luhn check digit
+func fixChecksum(piiType string, value []rune) []rune {
+	var positions []int
+	for i, c := range value {
+		if c >= '0' && c <= '9' {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) < 2 {
+		return value
+	}
+
+	digits := func() string {
+		var sb strings.Builder
+		for _, p := range positions[:len(positions)-1] {
+			sb.WriteRune(value[p])
+		}
+		return sb.String()
+	}
+	last := positions[len(positions)-1]
+
+	switch piiType {
+	case "IN_AADHAAR":
+		if first := positions[0]; value[first] < '2' {
+			value[first] += 2
+		}
+		if d, ok := validation.VerhoeffCheckDigit(digits()); ok {
+			value[last] = rune('0' + d)
+		}
+	case "CREDIT_CARD":
+		if d, ok := validation.LuhnCheckDigit(digits()); ok {
+			value[last] = rune('0' + d)
+		}
+	}
+	return value
+}
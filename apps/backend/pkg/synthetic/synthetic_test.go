@@ -0,0 +1,60 @@
+package synthetic
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/arc-platform/backend/pkg/normalization"
+	"github.com/arc-platform/backend/pkg/validation"
+)
+
+func TestGenerateIsValidForItsType(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < 50; i++ {
+		if aadhaar := Generate("IN_AADHAAR", r); !validation.ValidateAadhaar(aadhaar) {
+			t.Fatalf("generated Aadhaar %q is not valid", aadhaar)
+		}
+		if card := Generate("CREDIT_CARD", r); !validation.ValidateLuhn(normalization.ExtractDigits(card)) {
+			t.Fatalf("generated card %q fails Luhn", card)
+		}
+		if pan := Generate("IN_PAN", r); !validation.ValidatePAN(pan) {
+			t.Fatalf("generated PAN %q is not valid", pan)
+		}
+	}
+}
+
+func TestAnonymizerPreservesFormatAndEquality(t *testing.T) {
+	a := NewAnonymizer([]byte("export-key"))
+
+	card := a.Replace("CREDIT_CARD", "4111-1111-1111-1111")
+	if len(card) != len("4111-1111-1111-1111") || strings.Count(card, "-") != 3 {
+		t.Errorf("expected the card shape kept, got %q", card)
+	}
+	if card == "4111-1111-1111-1111" || !validation.ValidateLuhn(normalization.ExtractDigits(card)) {
+		t.Errorf("expected a different card passing Luhn, got %q", card)
+	}
+
+	// Formatting differences do not break equality
+	if spaced := a.Replace("CREDIT_CARD", "4111 1111 1111 1111"); normalization.ExtractDigits(spaced) != normalization.ExtractDigits(card) {
+		t.Errorf("expected equal values to get equal replacements, got %q and %q", spaced, card)
+	}
+	if other := a.Replace("CREDIT_CARD", "5500 0000 0000 0004"); normalization.ExtractDigits(other) == normalization.ExtractDigits(card) {
+		t.Errorf("expected distinct values to get distinct replacements")
+	}
+
+	pan := a.Replace("IN_PAN", "ABCPE1234F")
+	if pan == "ABCPE1234F" || strings.ToUpper(pan) != pan || len(pan) != 10 {
+		t.Errorf("expected an upper-case replacement of the same length, got %q", pan)
+	}
+
+	email := a.Replace("EMAIL_ADDRESS", "Asha.Rao@example.com")
+	if strings.Count(email, "@") != 1 || strings.Count(email, ".") != 2 || email[0] < 'A' || email[0] > 'Z' {
+		t.Errorf("expected separators and case kept, got %q", email)
+	}
+
+	// Another key yields unrelated replacements
+	if NewAnonymizer([]byte("other-key")).Replace("IN_PAN", "ABCPE1234F") == pan {
+		t.Errorf("expected replacements to depend on the key")
+	}
+}
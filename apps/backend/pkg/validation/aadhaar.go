@@ -0,0 +1,89 @@
+package validation
+
+import "strings"
+
+// Aadhaar (UIDAI) numbers: 12 digits, optionally grouped as "XXXX XXXX XXXX" or "XXXX-XXXX-XXXX".
+// The last digit is a Verhoeff check digit and the first digit is never 0 or 1.
+
+// Blacklist of UIDAI sandbox and commonly published sample Aadhaar numbers
+var aadhaarBlacklist = map[string]bool{
+	"999999990019": true,
+	"999999990026": true,
+	"999941057058": true,
+	"999971658847": true,
+	"123456789012": true,
+	"234567890123": true,
+	"987654321098": true,
+}
+
+// ValidateAadhaar checks if a string is a valid Aadhaar number
+func ValidateAadhaar(aadhaar string) bool {
+	valid, _ := ValidateAadhaarWithDetails(aadhaar)
+	return valid
+}
+
+// ValidateAadhaarWithDetails validates an Aadhaar number and explains a rejection
+func ValidateAadhaarWithDetails(aadhaar string) (bool, string) {
+	digits, ok := normalizeAadhaar(aadhaar)
+	if !ok {
+		return false, "Invalid Aadhaar format"
+	}
+
+	// UIDAI never issues numbers starting with 0 or 1
+	if digits[0] == '0' || digits[0] == '1' {
+		return false, "Invalid first digit"
+	}
+
+	if aadhaarBlacklist[digits] {
+		return false, "Known sample Aadhaar number"
+	}
+
+	if isRepeatedSequence(digits) {
+		return false, "Repeated digit sequence"
+	}
+
+	if !ValidateVerhoeff(digits) {
+		return false, "Verhoeff checksum failed"
+	}
+
+	return true, "Valid Aadhaar"
+}
+
+// normalizeAadhaar strips the separators allowed between 4-digit groups and
+// returns the 12 digits, rejecting any other characters
+func normalizeAadhaar(aadhaar string) (string, bool) {
+	aadhaar = strings.TrimSpace(aadhaar)
+
+	var b strings.Builder
+	for i := 0; i < len(aadhaar); i++ {
+		ch := aadhaar[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			b.WriteByte(ch)
+		case ch == ' ' || ch == '-':
+			// Separators are only valid between 4-digit groups
+			if b.Len() == 0 || b.Len()%4 != 0 {
+				return "", false
+			}
+		default:
+			return "", false
+		}
+	}
+
+	digits := b.String()
+	if len(digits) != 12 {
+		return "", false
+	}
+	return digits, true
+}
+
+// isRepeatedSequence reports numbers built from a single repeated 1-, 2-, 3-, 4- or
+// 6-digit block, e.g. 222222222222, 121212121212 or 456745674567
+func isRepeatedSequence(digits string) bool {
+	for _, size := range []int{1, 2, 3, 4, 6} {
+		if strings.Repeat(digits[:size], len(digits)/size) == digits {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"strconv"
+	"testing"
+)
+
+// withCheckDigit appends the Verhoeff check digit to an 11-digit prefix
+func withCheckDigit(t *testing.T, prefix string) string {
+	t.Helper()
+	check, ok := VerhoeffCheckDigit(prefix)
+	if !ok {
+		t.Fatalf("VerhoeffCheckDigit(%q) failed", prefix)
+	}
+	return prefix + strconv.Itoa(check)
+}
+
+func TestVerhoeff(t *testing.T) {
+	// Reference example: the check digit of 236 is 3
+	if check, ok := VerhoeffCheckDigit("236"); !ok || check != 3 {
+		t.Errorf("VerhoeffCheckDigit(236) = %d, %v; want 3, true", check, ok)
+	}
+	if !ValidateVerhoeff("2363") {
+		t.Error("expected 2363 to pass Verhoeff")
+	}
+	if ValidateVerhoeff("2364") {
+		t.Error("expected 2364 to fail Verhoeff")
+	}
+	if ValidateVerhoeff("23a3") {
+		t.Error("expected non-digit input to fail Verhoeff")
+	}
+}
+
+func TestValidateAadhaar(t *testing.T) {
+	valid := withCheckDigit(t, "49876543210")
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"valid", valid, true},
+		{"valid with spaces", valid[:4] + " " + valid[4:8] + " " + valid[8:], true},
+		{"valid with hyphens", valid[:4] + "-" + valid[4:8] + "-" + valid[8:], true},
+		{"misplaced separator", valid[:3] + " " + valid[3:], false},
+		{"bad checksum", valid[:11] + strconv.Itoa((int(valid[11]-'0')+1)%10), false},
+		{"too short", valid[:11], false},
+		{"letters", "4987A5432101", false},
+		{"first digit zero", withCheckDigit(t, "09876543210"), false},
+		{"first digit one", withCheckDigit(t, "19876543210"), false},
+		{"repeated digit", "222222222222", false},
+		{"repeated block", "456745674567", false},
+		{"sandbox number", "999999990019", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidateAadhaar(tt.input); got != tt.want {
+			t.Errorf("%s: ValidateAadhaar(%q) = %v, want %v", tt.name, tt.input, got, tt.want)
+		}
+	}
+}
@@ -31,3 +31,13 @@ func ValidateLuhn(number string) bool {
 
 	return sum%10 == 0
 }
+
+// LuhnCheckDigit computes the check digit to append to a digit string
+func LuhnCheckDigit(number string) (int, bool) {
+	for d := 0; d <= 9; d++ {
+		if ValidateLuhn(number + string(rune('0'+d))) {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"sort"
+	"strings"
+)
+
+// Validator checks a match value and returns why it failed
+type Validator func(value string) (valid bool, reason string)
+
+// validators are keyed by the names jurisdiction profiles refer to them by
+var validators = map[string]Validator{
+	"aadhaar":  ValidateAadhaarWithDetails,
+	"pan":      ValidatePANWithDetails,
+	"luhn":     digitsValidator("Luhn checksum", ValidateLuhn),
+	"verhoeff": digitsValidator("Verhoeff checksum", ValidateVerhoeff),
+	"ssn":      digitsValidator("SSN format", ValidateSSN),
+	"phone":    digitsValidator("phone number format", ValidatePhone),
+	"in_phone": digitsValidator("Indian phone number format", ValidateIndianPhone),
+	"us_phone": digitsValidator("US phone number format", ValidateUSPhone),
+	"email": func(value string) (bool, string) {
+		if !ValidateEmail(value) {
+			return false, "Invalid email format"
+		}
+		return true, ""
+	},
+}
+
+// LookupValidator returns the validator registered under name
+func LookupValidator(name string) (Validator, bool) {
+	v, ok := validators[strings.ToLower(strings.TrimSpace(name))]
+	return v, ok
+}
+
+// ValidatorNames lists the registered validator names in order
+func ValidatorNames() []string {
+	names := make([]string, 0, len(validators))
+	for name := range validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// digitsValidator strips spaces and dashes before checking a numeric value
+func digitsValidator(check string, validate func(string) bool) Validator {
+	return func(value string) (bool, string) {
+		value = strings.NewReplacer(" ", "", "-", "").Replace(value)
+		if !validate(value) {
+			return false, "Failed " + check
+		}
+		return true, ""
+	}
+}
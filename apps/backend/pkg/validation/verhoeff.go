@@ -1,12 +1,12 @@
 package validation
 
-// ValidateVerhoeff checks if a number passes the Verhoeff algorithm
+// Verhoeff checksum (dihedral group D5)
 // Used for Aadhaar (Indian national ID) validation
 // Reference: https://en.wikipedia.org/wiki/Verhoeff_algorithm
 
 var (
 	// Multiplication table
-	d = [10][10]int{
+	verhoeffD = [10][10]int{
 		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
 		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
 		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
@@ -20,7 +20,7 @@ var (
 	}
 
 	// Permutation table
-	p = [8][10]int{
+	verhoeffP = [8][10]int{
 		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
 		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
 		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
@@ -30,23 +30,46 @@ var (
 		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
 		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
 	}
+
+	// Inverse table
+	verhoeffInv = [10]int{0, 4, 3, 2, 1, 5, 6, 7, 8, 9}
 )
 
-// ValidateVerhoeff checks Aadhaar checksum using Verhoeff algorithm
+// ValidateVerhoeff checks that a digit string (check digit last) passes the Verhoeff checksum
 func ValidateVerhoeff(number string) bool {
-	if len(number) != 12 {
-		return false // Aadhaar must be exactly 12 digits
+	if len(number) < 2 {
+		return false
+	}
+
+	c, ok := verhoeffChecksum(number, 0)
+	return ok && c == 0
+}
+
+// VerhoeffCheckDigit computes the check digit to append to a digit string
+func VerhoeffCheckDigit(number string) (int, bool) {
+	if len(number) == 0 {
+		return 0, false
 	}
 
+	c, ok := verhoeffChecksum(number, 1)
+	if !ok {
+		return 0, false
+	}
+	return verhoeffInv[c], true
+}
+
+// verhoeffChecksum folds the digits right to left; offset shifts the permutation
+// index by one when the check digit is not yet present
+func verhoeffChecksum(number string, offset int) (int, bool) {
 	c := 0
-	for i, char := range number {
+	for i := len(number) - 1; i >= 0; i-- {
+		char := number[i]
 		if char < '0' || char > '9' {
-			return false // Invalid character
+			return 0, false // Invalid character
 		}
 
-		digit := int(char - '0')
-		c = d[c][p[(len(number)-i)%8][digit]]
+		pos := len(number) - 1 - i + offset
+		c = verhoeffD[c][verhoeffP[pos%8][int(char-'0')]]
 	}
-
-	return c == 0
+	return c, true
 }
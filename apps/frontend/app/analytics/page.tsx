@@ -7,14 +7,20 @@ import Tooltip, { InfoIcon } from '@/components/Tooltip';
 import ErrorBanner from '@/components/ErrorBanner';
 
 interface PIIHeatmap {
+    dimensions: string[];
     rows: HeatmapRow[];
     columns: string[];
+    total_findings: number;
 }
 
 interface HeatmapRow {
-    asset_type: string;
+    label: string;
+    environment?: string;
+    data_source?: string;
+    host?: string;
     cells: HeatmapCell[];
     total: number;
+    max_risk: string;
 }
 
 interface HeatmapCell {
@@ -144,14 +150,13 @@ export default function AnalyticsPage() {
                                 </thead>
                                 <tbody>
                                     {heatmap.rows.map(row => (
-                                        <tr key={row.asset_type}>
+                                        <tr key={row.label}>
                                             <td style={{
                                                 fontWeight: 600,
                                                 color: theme.colors.text.primary,
-                                                textTransform: 'capitalize',
                                                 padding: '8px'
                                             }}>
-                                                {row.asset_type}s
+                                                {row.label}
                                             </td>
                                             {row.cells.map(cell => (
                                                 <td key={cell.pii_type} title={`${cell.finding_count} findings (${cell.risk_level})`}>
@@ -4,7 +4,7 @@ import React, { useEffect, useState } from 'react';
 import Topbar from '@/components/Topbar';
 import FindingsTable from '@/components/FindingsTable';
 import LoadingState from '@/components/LoadingState';
-import { findingsApi } from '@/services/findings.api';
+import { findingsApi, ReviewConflictError } from '@/services/findings.api';
 import { theme } from '@/design-system/theme';
 import type { FindingWithDetails, FindingsResponse } from '@/types';
 import { RemediationConfirmationModal } from '@/components/remediation/RemediationConfirmationModal';
@@ -132,12 +132,20 @@ export default function FindingsPage() {
 
     const handleMarkFalsePositive = async (id: string) => {
         try {
+            const finding = findingsData?.findings.find(f => f.id === id);
             await findingsApi.submitFeedback(id, {
                 feedback_type: 'FALSE_POSITIVE',
-                comments: 'Marked via UI'
+                comments: 'Marked via UI',
+                version: finding?.review_version ?? 0
             });
             fetchFindings(); // Refresh list to see status change
         } catch (error) {
+            if (error instanceof ReviewConflictError) {
+                // Show the other reviewer's decision before trying again
+                setError('This finding was reviewed by someone else; the list has been refreshed');
+                fetchFindings();
+                return;
+            }
             console.error('Failed to mark false positive:', error);
             setError('Failed to update finding');
         }
@@ -5,13 +5,24 @@ export interface FeedbackRequest {
     original_classification?: string;
     proposed_classification?: string;
     comments?: string;
+    version: number; // review_version the reviewer saw; 0 when the finding is unreviewed
+}
+
+// Thrown when another reviewer updated the finding after it was loaded
+export class ReviewConflictError extends Error {
+    constructor(public conflict: any) {
+        super('This finding was updated by someone else');
+    }
 }
 
 export const findingsApi = {
     submitFeedback: async (findingId: string, feedback: FeedbackRequest): Promise<void> => {
         try {
             await post<void>(`/findings/${findingId}/feedback`, feedback);
-        } catch (error) {
+        } catch (error: any) {
+            if (error?.response?.status === 409) {
+                throw new ReviewConflictError(error.response.data?.conflict);
+            }
             console.error(`Error submitting feedback for finding ${findingId}:`, error);
             throw new Error('Failed to submit feedback');
         }
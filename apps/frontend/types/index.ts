@@ -96,6 +96,7 @@ export interface FindingWithDetails extends Finding {
     source_system: string;
     classifications: Classification[];
     review_status: string;
+    review_version: number;
 }
 
 export interface Node {
@@ -15,6 +15,9 @@ from requests.adapters import HTTPAdapter
 from urllib3.util.retry import Retry
 from typing import List, Dict, Any
 
+# Ingestion payload schema version; GET /api/v1/scans/capabilities lists what the backend accepts
+SCHEMA_VERSION = 2
+
 # Try to import SDK schema, but handle case where SDK might not be in path
 try:
     from sdk.schema import VerifiedFinding, SourceInfo
@@ -54,6 +57,40 @@ def create_retry_session(retries=3, backoff_factor=0.5, status_forcelist=(500, 5
     return session
 
 
+# Scan payload signing; must match pkg/scansign in the backend
+SCAN_SIGNING_CONTEXT = b"arc-hawk-scan-v1"
+
+
+def scan_signature_headers(body):
+    """
+    Sign a request body with the Ed25519 key registered with the backend.
+
+    The key ID comes from ARC_SCAN_KEY_ID and the PEM private key from the file
+    named by ARC_SCAN_SIGNING_KEY. Without them the payload is sent unsigned,
+    which tenants in strict mode reject.
+    """
+    import os
+    key_id = os.environ.get('ARC_SCAN_KEY_ID')
+    key_path = os.environ.get('ARC_SCAN_SIGNING_KEY')
+    if not key_id or not key_path:
+        return {}
+
+    import base64
+    from cryptography.hazmat.primitives import serialization
+    from cryptography.hazmat.primitives.asymmetric.ed25519 import Ed25519PrivateKey
+
+    with open(key_path, 'rb') as f:
+        key = serialization.load_pem_private_key(f.read(), password=None)
+    if not isinstance(key, Ed25519PrivateKey):
+        raise ValueError(f"{key_path} is not an Ed25519 private key")
+
+    message = SCAN_SIGNING_CONTEXT + b"\n" + hashlib.sha256(body).hexdigest().encode()
+    return {
+        "X-Scan-Key-Id": key_id,
+        "X-Scan-Signature": base64.b64encode(key.sign(message)).decode(),
+    }
+
+
 def ingest_verified_findings(args, verified_findings, scan_metadata=None):
     """
     POST verified findings to backend /ingest-verified API.
@@ -85,7 +122,9 @@ def ingest_verified_findings(args, verified_findings, scan_metadata=None):
     import os
     scan_id = args.scan_id if hasattr(args, 'scan_id') and args.scan_id else os.environ.get('SCAN_ID', f"scan_{int(time.time())}")
     
+    # schema_version must precede findings: the backend streams the body
     payload = {
+        "schema_version": SCHEMA_VERSION,
         "scan_id": scan_id,
         "scan_metadata": scan_metadata or {
             "scanner_version": "hawk-eye-scanner-2.0-cli",
@@ -105,16 +144,24 @@ def ingest_verified_findings(args, verified_findings, scan_metadata=None):
     try:
         system.print_info(args, f"⏳ Sending {len(findings_dicts)} VERIFIED findings to backend...")
         
+        # The exact bytes sent are the bytes signed
+        body = json.dumps(payload).encode()
+        headers = {"Content-Type": "application/json"}
+        headers.update(scan_signature_headers(body))
+
         response = session.post(
             ingest_url,
-            json=payload,
-            headers={"Content-Type": "application/json"},
+            data=body,
+            headers=headers,
             timeout=timeout
         )
         
         if response.status_code in [200, 201]:
             system.print_success(args, f"✅ Successfully ingested {len(findings_dicts)} verified findings!")
             return True
+        elif response.status_code in [401, 403]:
+            system.print_error(args, f"❌ Ingestion refused ({response.status_code}); check ARC_SCAN_KEY_ID and ARC_SCAN_SIGNING_KEY: {response.text}")
+            return False
         else:
             system.print_error(args, f"❌ Ingestion failed with status {response.status_code}: {response.text}")
             return False
@@ -148,7 +195,7 @@ def ingest_scan_results(args, grouped_results, scan_metadata=None):
             
             # Determine SourceInfo
             source_info = {
-                "data_source": group,
+                "data_source": "filesystem" if group == "fs" else group,
                 "host": result.get('host', 'localhost'),
                 "path": result.get('file_path') or result.get('file_name') or 'unknown',
                 "table": result.get('table'),
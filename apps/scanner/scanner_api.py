@@ -9,7 +9,7 @@ import threading
 import tempfile
 import requests
 from flask import Flask, request, jsonify
-from datetime import datetime
+from datetime import datetime, timezone
 
 app = Flask(__name__)
 
@@ -201,7 +201,7 @@ def ingest_results(scan_id, results):
                     "line": 0,
                     "column": "",
                     "table": "",
-                    "data_source": "fs",
+                    "data_source": "filesystem",
                     "host": f.get('host', 'localhost')
                 },
                 "validators_passed": ["pattern_match"],
@@ -211,13 +211,15 @@ def ingest_results(scan_id, results):
                 "context_excerpt": f.get('sample_text', ''),
                 "context_keywords": [],
                 "pattern_name": pattern_name,
-                "detected_at": datetime.now().isoformat(),
+                "detected_at": datetime.now(timezone.utc).isoformat(),
                 "scanner_version": "0.3.39",
                 "metadata": f.get('file_data', {})
             }
             verified_findings.append(vf)
             
+        # schema_version must precede findings: the backend streams the body
         payload = {
+            "schema_version": 2,
             "scan_id": scan_id,
             "findings": verified_findings,
             "metadata": {}